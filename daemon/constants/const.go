@@ -6,6 +6,8 @@ const (
 	VarIni = "/var/local/emhttp/var.ini"
 	// DisksIni is the path to the Unraid disks.ini configuration file.
 	DisksIni = "/var/local/emhttp/disks.ini"
+	// DevsIni is the path to the Unraid devs.ini file listing unassigned devices.
+	DevsIni = "/var/local/emhttp/devs.ini"
 	// SharesIni is the path to the Unraid shares.ini configuration file.
 	SharesIni = "/var/local/emhttp/shares.ini"
 	// NetworkIni is the path to the Unraid network.ini configuration file.
@@ -25,10 +27,17 @@ const (
 	IdentCfg = "/boot/config/ident.cfg"
 	// SharesConfigDir is the directory containing per-share configuration files.
 	SharesConfigDir = "/boot/config/shares"
+	// PoolsConfigDir holds one <name>.cfg per Unraid pool (Unraid 6.9+).
+	PoolsConfigDir = "/boot/config/pools"
 	// PluginsConfigDir is the directory containing plugin files.
 	PluginsConfigDir = "/boot/config/plugins"
 	// PluginsTempDir is the directory containing downloaded plugin updates.
 	PluginsTempDir = "/tmp/plugins"
+	// CacheSnapshotFile holds the API caches across an agent restart. It
+	// lives in RAM so shutdowns never write to the flash drive.
+	CacheSnapshotFile = "/tmp/unraid-management-agent/cache-snapshot.json"
+	// ShadowFile holds the password hashes checked by /api/v1/auth/login.
+	ShadowFile = "/etc/shadow"
 
 	// DynamixCfg is the path to the dynamix plugin configuration (contains temp thresholds).
 	DynamixCfg = "/boot/config/plugins/dynamix/dynamix.cfg"
@@ -45,6 +54,9 @@ const (
 	ProcUptime = "/proc/uptime"
 	// ProcStat is the path to the /proc/stat file.
 	ProcStat = "/proc/stat"
+	// ProcMdstat is the Unraid md driver status (key=value lines, including
+	// the live mdResync* parity operation counters).
+	ProcMdstat = "/proc/mdstat"
 	// SysHwmon is the path to the /sys/class/hwmon directory.
 	SysHwmon = "/sys/class/hwmon"
 	// SysPowercap is the path to the /sys/class/powercap directory (Intel RAPL).
@@ -72,13 +84,50 @@ const (
 	ZpoolBin = "/usr/sbin/zpool"
 	// ZfsBin is the path to the zfs binary.
 	ZfsBin = "/usr/sbin/zfs"
+	// BtrfsBin is the path to the btrfs binary.
+	BtrfsBin = "/sbin/btrfs"
+	// SgdiskBin is the path to the sgdisk GPT partitioning tool.
+	SgdiskBin = "/usr/sbin/sgdisk"
+	// MkfsXFSBin is the path to the mkfs.xfs binary.
+	MkfsXFSBin = "/sbin/mkfs.xfs"
+	// MkfsBtrfsBin is the path to the mkfs.btrfs binary.
+	MkfsBtrfsBin = "/sbin/mkfs.btrfs"
+	// MkfsExfatBin is the path to the mkfs.exfat binary.
+	MkfsExfatBin = "/sbin/mkfs.exfat"
+	// MoverBin is the path to the Unraid mover script ("start" / "stop").
+	MoverBin = "/usr/local/sbin/mover"
+	// FstrimBin is the path to the fstrim binary.
+	FstrimBin = "/sbin/fstrim"
 	// PluginBin is the path to the Unraid plugin management binary.
 	PluginBin = "/usr/local/sbin/plugin"
+	// SetsidBin is the path to setsid, used to run the self-update detached
+	// from the agent process it replaces.
+	SetsidBin = "/usr/bin/setsid"
+	// AgentScriptsDir holds the plugin's start and stop scripts, used to
+	// restart the agent.
+	AgentScriptsDir = "/usr/local/emhttp/plugins/unraid-management-agent/scripts"
 	// VirtCloneBin is the path to the virt-clone binary.
 	VirtCloneBin = "/usr/bin/virt-clone"
+	// QemuImgBin is the path to qemu-img, used to inspect, grow and convert
+	// VM disk images.
+	QemuImgBin = "/usr/bin/qemu-img"
 	// RcUnassignedBin is the Unassigned Devices plugin control script used to
-	// mount and unmount SMB/NFS remote shares by source.
+	// mount and unmount SMB/NFS remote shares by source and unassigned disk
+	// partitions by device path.
 	RcUnassignedBin = "/usr/local/sbin/rc.unassigned"
+	// RcInet1Bin is the Slackware network init script; "restart" rebuilds the
+	// bond, bridge and VLAN interfaces from network.cfg.
+	RcInet1Bin = "/etc/rc.d/rc.inet1"
+	// IpmitoolBin is the path to ipmitool, used to read BMC sensors and the
+	// chassis status and to send chassis power commands.
+	IpmitoolBin = "/usr/bin/ipmitool"
+	// FatraceBin is the path to fatrace (NerdTools), used to catch the file
+	// accesses that keep a disk spinning after a spin-up.
+	FatraceBin = "/usr/bin/fatrace"
+
+	// NetworkCfg is the Unraid network configuration (Settings > Network
+	// Settings), with per-port keys such as IPADDR[0] and VLANID[0,1].
+	NetworkCfg = "/boot/config/network.cfg"
 
 	// UnassignedSambaMountCfg is the Unassigned Devices SMB/NFS remote-share
 	// configuration file (INI keyed by share source).
@@ -99,6 +148,37 @@ const (
 	NutPluginDir = "/usr/local/emhttp/plugins/nut-dw"
 	// ApcPidFile is the path to the APC UPS daemon PID file.
 	ApcPidFile = "/var/run/apcupsd.pid"
+	// RecycleBinPluginDir is the path to the Recycle Bin plugin directory.
+	RecycleBinPluginDir = "/usr/local/emhttp/plugins/recycle.bin"
+	// RecycleBinDirName is the directory at each user share's root where
+	// Samba moves files deleted over SMB while the Recycle Bin plugin is active.
+	RecycleBinDirName = ".Recycle.Bin"
+	// UserSharesDir is the mount point of the user share filesystem.
+	UserSharesDir = "/mnt/user"
+	// ConnectPluginDir is the path to the Unraid Connect plugin directory.
+	ConnectPluginDir = "/usr/local/emhttp/plugins/dynamix.my.servers"
+	// ConnectCfg is the Unraid Connect settings file on the flash drive
+	// (sign-in and remote access configuration).
+	ConnectCfg = "/boot/config/plugins/dynamix.my.servers/myservers.cfg"
+	// ConnectStateCfg is the runtime copy of myservers.cfg to which the
+	// Unraid API adds the cloud and UPnP connection status.
+	ConnectStateCfg = "/var/local/emhttp/myservers.cfg"
+	// FlashBackupIni is the flash backup status written by the Unraid Connect plugin.
+	FlashBackupIni = "/var/local/emhttp/flashbackup.ini"
+	// FlashGitDir is the git repository Unraid Connect flash backup pushes from.
+	FlashGitDir = "/boot/.git"
+	// LibvirtQemuStateDir holds libvirt's live XML of each running VM, which
+	// names the host tap interfaces (vnetX) attached to it.
+	LibvirtQemuStateDir = "/var/run/libvirt/qemu"
+	// DockerCgroupDir is the cgroup v2 directory of Docker containers, one
+	// subdirectory per full container ID.
+	DockerCgroupDir = "/sys/fs/cgroup/docker"
+	// DockerSocket is the Docker daemon's API socket.
+	DockerSocket = "/var/run/docker.sock"
+	// LibvirtSocket is libvirtd's read-write API socket.
+	LibvirtSocket = "/var/run/libvirt/libvirt-sock"
+	// LogDir is the system log directory, a small RAM disk on Unraid.
+	LogDir = "/var/log"
 
 	// Collection intervals optimized for power efficiency (Issue #8)
 	// Higher intervals reduce CPU wake-ups and allow deeper C-states
@@ -161,6 +241,43 @@ const (
 	// IntervalMover is the interval for collecting mover status in seconds.
 	// 30 seconds balances responsiveness with low overhead (reads two local files).
 	IntervalMover = 30
+	// IntervalDNS is the interval for DNS health checks in seconds.
+	// Each run sends a handful of UDP queries; 5 minutes catches outages quickly enough.
+	IntervalDNS = 300
+	// IntervalWAN is the interval for WAN connectivity checks in seconds when
+	// the collector is enabled without an explicit interval. Each run pings the
+	// probe targets and queries a public IP service, so the collector is off by
+	// default (INTERVAL_WAN=0) and this is kept conservative.
+	IntervalWAN = 300
+	// IntervalSpeedtest is the interval for scheduled bandwidth tests in seconds
+	// when the collector is enabled without an explicit interval. A test
+	// saturates the uplink for tens of seconds and transfers hundreds of
+	// megabytes, so the collector is off by default (INTERVAL_SPEEDTEST=0).
+	IntervalSpeedtest = 21600
+	// IntervalPools is the interval for collecting pool layout, allocation,
+	// and balance/scrub state in seconds. Each run executes a few btrfs or
+	// zpool queries per pool.
+	IntervalPools = 60
+	// IntervalBtrfs is the interval for collecting btrfs device error counters
+	// and scrub results in seconds. The counters are persistent and only change
+	// on I/O errors, so a slow poll is enough.
+	IntervalBtrfs = 300
+	// IntervalRecycleBin is the interval for measuring the per-share recycle
+	// bins in seconds. Each run walks every .Recycle.Bin directory, so it runs
+	// hourly and is skipped entirely when the Recycle Bin plugin is absent.
+	IntervalRecycleBin = 3600
+	// IntervalIPMI is the interval for reading BMC sensors and the chassis
+	// status in seconds. A full sensor read takes a few seconds on most BMCs.
+	IntervalIPMI = 60
+	// IntervalConnect is the interval for reading the Unraid Connect plugin
+	// state in seconds. It only reads small state files.
+	IntervalConnect = 60
+	// IntervalGuestTraffic is the interval for attributing the traffic of host
+	// virtual interfaces (vnetX, veth*) to VMs and containers in seconds.
+	IntervalGuestTraffic = 30
+	// IntervalRemoteMounts is the interval for probing NFS/SMB client mounts
+	// for staleness in seconds. Each run is one statfs and one TCP connect per mount.
+	IntervalRemoteMounts = 60
 
 	// WSPingInterval is the WebSocket ping interval in seconds.
 	WSPingInterval = 30
@@ -168,6 +285,15 @@ const (
 	WSMaxClients = 10
 	// WSBufferSize is the WebSocket message buffer size.
 	WSBufferSize = 256
+	// WSWriteTimeout is how long a single WebSocket write may block, in
+	// seconds, before the client is considered stalled and disconnected.
+	WSWriteTimeout = 10
+
+	// WSSlowClientDisconnect and WSSlowClientDropOldest are the policies for
+	// a WebSocket client whose send queue is full: disconnect it, or discard
+	// its oldest queued event to make room.
+	WSSlowClientDisconnect = "disconnect"
+	WSSlowClientDropOldest = "drop-oldest"
 
 	// DiscoveryServiceType is the mDNS/DNS-SD service type advertised for
 	// zeroconf auto-discovery (e.g. by the Home Assistant integration).
@@ -176,4 +302,16 @@ const (
 	// DiscoveryDomain is the mDNS domain used for service registration.
 	// "local." is the standard multicast DNS domain.
 	DiscoveryDomain = "local."
+	// AgentServiceType is the generic service type dashboards and mobile apps
+	// browse for; its TXT records carry the REST API and MCP endpoints.
+	AgentServiceType = "_unraid-agent._tcp"
+	// AvahiServicesDir is where avahi-daemon picks up static service files.
+	AvahiServicesDir = "/etc/avahi/services"
+	// AvahiServiceFile is the agent's service file in AvahiServicesDir.
+	AvahiServiceFile = "unraid-agent.service"
 )
+
+// DefaultWANProbes are the targets pinged by the wan collector when no
+// WAN_PROBES are configured: two independent anycast resolvers, so a single
+// provider outage does not look like a WAN outage.
+var DefaultWANProbes = []string{"1.1.1.1", "8.8.8.8"}
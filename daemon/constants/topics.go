@@ -19,6 +19,8 @@ var (
 	TopicShareListUpdate = domain.NewTopic[[]dto.ShareInfo]("share_list_update")
 	// TopicContainerListUpdate is published by the docker collector with []*dto.ContainerInfo.
 	TopicContainerListUpdate = domain.NewTopic[[]*dto.ContainerInfo]("container_list_update")
+	// TopicDockerStatsUpdate is published by the docker collector with *dto.DockerStatsSummary.
+	TopicDockerStatsUpdate = domain.NewTopic[*dto.DockerStatsSummary]("docker_stats_update")
 	// TopicVMListUpdate is published by the VM collector with []*dto.VMInfo.
 	TopicVMListUpdate = domain.NewTopic[[]*dto.VMInfo]("vm_list_update")
 	// TopicUPSStatusUpdate is published by the UPS collector with *dto.UPSStatus.
@@ -33,6 +35,10 @@ var (
 	TopicHardwareUpdate = domain.NewTopic[*dto.HardwareInfo]("hardware_update")
 	// TopicRegistrationUpdate is published by the registration collector with *dto.Registration.
 	TopicRegistrationUpdate = domain.NewTopic[*dto.Registration]("registration_update")
+	// TopicParityScheduleUpdate is published by the registration collector with *dto.ParitySchedule.
+	TopicParityScheduleUpdate = domain.NewTopic[*dto.ParitySchedule]("parity_schedule_update")
+	// TopicFlashHealthUpdate is published by the registration collector with *dto.FlashDriveHealth.
+	TopicFlashHealthUpdate = domain.NewTopic[*dto.FlashDriveHealth]("flash_health_update")
 	// TopicNotificationsUpdate is published by the notification collector with *dto.NotificationList.
 	TopicNotificationsUpdate = domain.NewTopic[*dto.NotificationList]("notifications_update")
 	// TopicUnassignedDevicesUpdate is published by the unassigned collector with *dto.UnassignedDeviceList.
@@ -61,9 +67,111 @@ var (
 	TopicOSUpdateUpdate = domain.NewTopic[*dto.OSUpdateStatus]("os_update_update")
 	// TopicMoverUpdate is published by the mover collector with *dto.MoverStatus.
 	TopicMoverUpdate = domain.NewTopic[*dto.MoverStatus]("mover_update")
+	// TopicDNSHealthUpdate is published by the dns collector with *dto.DNSHealth.
+	TopicDNSHealthUpdate = domain.NewTopic[*dto.DNSHealth]("dns_health_update")
+	// TopicWANStatusUpdate is published by the wan collector with *dto.WANStatus.
+	TopicWANStatusUpdate = domain.NewTopic[*dto.WANStatus]("wan_status_update")
+	// TopicWANIPChanged is published by the wan collector with *dto.WANIPChange
+	// when the public IP address changes.
+	TopicWANIPChanged = domain.NewTopic[*dto.WANIPChange]("wan_ip_changed")
+	// TopicSpeedtestUpdate is published by the speedtest collector with *dto.SpeedtestStatus.
+	TopicSpeedtestUpdate = domain.NewTopic[*dto.SpeedtestStatus]("speedtest_update")
+	// TopicPoolsUpdate is published by the pools collector with []dto.PoolInfo.
+	TopicPoolsUpdate = domain.NewTopic[[]dto.PoolInfo]("pools_update")
+	// TopicBtrfsUpdate is published by the btrfs collector with []dto.BtrfsFilesystem.
+	TopicBtrfsUpdate = domain.NewTopic[[]dto.BtrfsFilesystem]("btrfs_update")
+	// TopicRecycleBinUpdate is published by the recycle bin collector with *dto.RecycleBinStatus.
+	TopicRecycleBinUpdate = domain.NewTopic[*dto.RecycleBinStatus]("recycle_bin_update")
+	// TopicIPMIUpdate is published by the ipmi collector with *dto.IPMIStatus.
+	TopicIPMIUpdate = domain.NewTopic[*dto.IPMIStatus]("ipmi_update")
+	// TopicConnectUpdate is published by the connect collector with *dto.ConnectStatus.
+	TopicConnectUpdate = domain.NewTopic[*dto.ConnectStatus]("connect_update")
+	// TopicGuestTrafficUpdate is published by the guest traffic collector with *dto.GuestTraffic.
+	TopicGuestTrafficUpdate = domain.NewTopic[*dto.GuestTraffic]("guest_traffic_update")
+	// TopicRemoteMountsUpdate is published by the remote mount collector with *dto.RemoteMountHealth.
+	TopicRemoteMountsUpdate = domain.NewTopic[*dto.RemoteMountHealth]("remote_mounts_update")
+	// TopicPowerUpdate is published by the power estimator with *dto.PowerEstimate.
+	TopicPowerUpdate = domain.NewTopic[*dto.PowerEstimate]("power_update")
+	// TopicDiskSpinHistoryUpdate is published by the disk spin tracker with
+	// *dto.DiskSpinHistory whenever a disk spins up or down.
+	TopicDiskSpinHistoryUpdate = domain.NewTopic[*dto.DiskSpinHistory]("disk_spin_history_update")
 	// TopicAgentWake is published by alerting/watchdog to wake the autonomous agent
 	// with a dto.AgentWakeEvent describing the triggering incident.
 	TopicAgentWake = domain.NewTopic[dto.AgentWakeEvent]("agent_wake")
+	// TopicAlertEvent is published by the alerting engine with every dispatched
+	// dto.AlertEvent (firing and resolved).
+	TopicAlertEvent = domain.NewTopic[dto.AlertEvent]("alert_event")
+	// TopicHealthCheckStatusUpdate is published by the watchdog runner with the
+	// current []dto.HealthCheckStatus after each tick that executed a probe.
+	TopicHealthCheckStatusUpdate = domain.NewTopic[[]dto.HealthCheckStatus]("healthcheck_status_update")
 	// TopicSourceStatusChanged fires when a subsystem's data-source health transitions.
 	TopicSourceStatusChanged = domain.NewTopic[dto.SourceStatus]("source_status_changed")
+	// TopicShutdownProgress is published by the system controller with a
+	// dto.ShutdownProgress for every step of an orchestrated shutdown.
+	TopicShutdownProgress = domain.NewTopic[dto.ShutdownProgress]("shutdown_progress")
+	// TopicJobUpdate is published by the job manager with a dto.Job whenever
+	// a job is queued, starts, reports progress or finishes.
+	TopicJobUpdate = domain.NewTopic[dto.Job]("job_update")
+	// TopicMaintenanceUpdate is published by the maintenance manager with a
+	// dto.MaintenanceStatus when a maintenance window starts, changes or ends.
+	TopicMaintenanceUpdate = domain.NewTopic[dto.MaintenanceStatus]("maintenance_update")
+	// TopicThermalEvent is published by the alerting engine with a
+	// dto.ThermalEvent when a temperature stays above a threshold or recovers.
+	TopicThermalEvent = domain.NewTopic[dto.ThermalEvent]("thermal_event")
+	// TopicUtilizationEvent is published by the alerting engine with a
+	// dto.UtilizationEvent when an array disk or pool changes utilization level.
+	TopicUtilizationEvent = domain.NewTopic[dto.UtilizationEvent]("utilization_event")
+	// TopicDiskSpinEvent is published by the disk spin tracker with a
+	// dto.DiskSpinEvent for every spin-up (with its likely causes) and
+	// spin-down.
+	TopicDiskSpinEvent = domain.NewTopic[dto.DiskSpinEvent]("disk_spin_event")
+	// TopicTransferProgress is published by the transfer runner with a
+	// dto.TransferRun when a transfer starts, about once a second while it
+	// runs, and when it finishes.
+	TopicTransferProgress = domain.NewTopic[dto.TransferRun]("transfer_progress")
 )
+
+// TopicCollectors maps cache topics to the collector publishing them. It
+// drives stale-data reporting and detects each collector's first result at
+// startup. Topics published on events rather than on an interval (e.g. disk
+// spin history) are absent and never reported stale.
+var TopicCollectors = map[string]string{
+	TopicSystemUpdate.Name:            "system",
+	TopicArrayStatusUpdate.Name:       "array",
+	TopicDiskListUpdate.Name:          "disk",
+	TopicShareListUpdate.Name:         "shares",
+	TopicContainerListUpdate.Name:     "docker",
+	TopicDockerStatsUpdate.Name:       "docker",
+	TopicVMListUpdate.Name:            "vm",
+	TopicUPSStatusUpdate.Name:         "ups",
+	TopicNUTStatusUpdate.Name:         "nut",
+	TopicGPUMetricsUpdate.Name:        "gpu",
+	TopicNetworkListUpdate.Name:       "network",
+	TopicHardwareUpdate.Name:          "hardware",
+	TopicRegistrationUpdate.Name:      "registration",
+	TopicNotificationsUpdate.Name:     "notification",
+	TopicUnassignedDevicesUpdate.Name: "unassigned",
+	TopicZFSPoolsUpdate.Name:          "zfs",
+	TopicZFSDatasetsUpdate.Name:       "zfs",
+	TopicZFSSnapshotsUpdate.Name:      "zfs",
+	TopicZFSARCStatsUpdate.Name:       "zfs",
+	TopicFanControlUpdate.Name:        "fancontrol",
+	TopicTuningUpdate.Name:            "tuning",
+	TopicDockerUpdatesUpdate.Name:     "docker_update",
+	TopicDockerNetworksUpdate.Name:    "docker_networks",
+	TopicPluginUpdatesUpdate.Name:     "plugin_update",
+	TopicOSUpdateUpdate.Name:          "os_update",
+	TopicMoverUpdate.Name:             "mover",
+	TopicDNSHealthUpdate.Name:         "dns",
+	TopicWANStatusUpdate.Name:         "wan",
+	TopicSpeedtestUpdate.Name:         "speedtest",
+	TopicPoolsUpdate.Name:             "pools",
+	TopicBtrfsUpdate.Name:             "btrfs",
+	TopicRecycleBinUpdate.Name:        "recycle_bin",
+	TopicIPMIUpdate.Name:              "ipmi",
+	TopicConnectUpdate.Name:           "connect",
+	TopicGuestTrafficUpdate.Name:      "guest_traffic",
+	TopicRemoteMountsUpdate.Name:      "remote_mounts",
+	// The power estimate is recomputed on every system update
+	TopicPowerUpdate.Name: "system",
+}
@@ -22,6 +22,172 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/agent/config": {
+            "get": {
+                "description": "Returns the YAML config file as JSON (MQTT password and MCP API key redacted), the last reload time and error, and settings that changed but need a restart to take effect.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get agent config file",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AgentConfigStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "Config reload not available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Validates and writes the full config file, then reloads it. Log level and collector intervals apply immediately; other changes are listed in restart_required. Send \"********\" for a secret to keep its stored value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Replace agent config file",
+                "parameters": [
+                    {
+                        "description": "Complete config file contents",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.FileConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfigReloadResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid config",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to write config",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Config reload not available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/agent/config/reload": {
+            "post": {
+                "description": "Re-reads the config file from disk, equivalent to sending SIGHUP.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Reload agent config file",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfigReloadResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Config file is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Config reload not available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/agent/logs": {
+            "get": {
+                "description": "Read the agent's own log, including the rotated backup, as structured entries (oldest first) so the agent can be debugged remotely without shell access to /var/log. Only entries written at or above the configured log level exist. Sensitive values are redacted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logs"
+                ],
+                "summary": "Get agent log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Minimum level: debug, info (default), warning or error",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries newer than a duration (e.g. 2h) or RFC3339 timestamp",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries for this request ID (X-Request-ID), job ID or collector (collector:\u003cname\u003e)",
+                        "name": "correlation_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Newest entries to return (default 200, max 5000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Agent log entries",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AgentLog"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to read agent log",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/agent/memory": {
             "get": {
                 "description": "Retrieve the agent's recorded incidents and learned preferences",
@@ -103,6 +269,32 @@ const docTemplate = `{
                 }
             }
         },
+        "/agent/restart": {
+            "post": {
+                "description": "Restarts the agent through the plugin's stop and start scripts. The response arrives before the agent stops. The shutdown is graceful: MQTT reports the agent offline, WebSocket clients receive a going-away close frame (code 1001) and the caches are restored by the new process.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Restart the agent",
+                "responses": {
+                    "202": {
+                        "description": "Restart started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Restart could not be started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/agent/sessions": {
             "get": {
                 "description": "Retrieve all agent sessions, newest first",
@@ -388,73 +580,99 @@ const docTemplate = `{
                 }
             }
         },
-        "/alerts/firing": {
+        "/agent/stats": {
             "get": {
-                "description": "Get only alert rules currently in the firing state",
+                "description": "Request counts and latencies per route, WebSocket clients, MQTT publish rates and Go runtime memory and goroutine figures, to diagnose agent performance issues in the field. Counters reset when the agent restarts.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Monitoring"
                 ],
-                "summary": "Get firing alerts",
+                "summary": "Get agent performance stats",
                 "responses": {
                     "200": {
-                        "description": "Firing alerts",
+                        "description": "Agent performance stats",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.AlertStatus"
-                            }
+                            "$ref": "#/definitions/dto.AgentStats"
                         }
                     }
                 }
             }
         },
-        "/alerts/history": {
+        "/agent/tls": {
             "get": {
-                "description": "Get recent alert events (last 100)",
+                "description": "Get how the HTTPS certificate is provided (off, files or acme), its names, issuer and validity, and for ACME the renewal state and last error.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Configuration"
                 ],
-                "summary": "Get alert history",
+                "summary": "Get HTTPS certificate status",
                 "responses": {
                     "200": {
-                        "description": "Alert event history",
+                        "description": "Certificate status",
                         "schema": {
-                            "$ref": "#/definitions/dto.AlertHistoryResponse"
+                            "$ref": "#/definitions/dto.TLSStatus"
                         }
                     }
                 }
             }
         },
-        "/alerts/rules": {
+        "/agent/tls/renew": {
+            "post": {
+                "description": "Starts an ACME certificate renewal in the background, even when the certificate is not yet due. Poll GET /agent/tls for the outcome.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Renew the ACME certificate",
+                "responses": {
+                    "202": {
+                        "description": "Renewal started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "ACME is not enabled or a renewal is already running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/agent/update": {
             "get": {
-                "description": "Retrieve all configured alert rules",
+                "description": "Compares the running agent version with the latest GitHub release.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Configuration"
                 ],
-                "summary": "List all alert rules",
+                "summary": "Check for agent updates",
                 "responses": {
                     "200": {
-                        "description": "List of alert rules",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.AlertRule"
-                            }
+                            "$ref": "#/definitions/dto.AgentUpdateStatus"
+                        }
+                    },
+                    "502": {
+                        "description": "Release lookup failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Create a new alert rule with an expr-lang expression",
+                "description": "Downloads the latest release bundle and verifies it against the SHA-256 checksum published with the release; releases without one are refused. Releases are not signed, so the checksum guards against corrupted downloads, not a tampered release. With dry_run the bundle is discarded after verification. Otherwise confirm must be true; the bundle is installed through the Unraid plugin system and the agent restarts, so the response arrives before the update completes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -462,72 +680,188 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Configuration"
                 ],
-                "summary": "Create a new alert rule",
+                "summary": "Update the agent",
                 "parameters": [
                     {
-                        "description": "Alert rule to create",
-                        "name": "rule",
+                        "description": "Dry run or confirmed install",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AlertRule"
+                            "$ref": "#/definitions/dto.AgentUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Rule created successfully",
+                    "200": {
+                        "description": "Up to date or dry run verified",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.AgentUpdateStatus"
+                        }
+                    },
+                    "202": {
+                        "description": "Update installing",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AgentUpdateStatus"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Missing confirmation",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal error",
+                    "502": {
+                        "description": "Download or verification failed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/alerts/rules/{id}": {
+        "/alerts/firing": {
             "get": {
-                "description": "Retrieve a single alert rule by ID",
+                "description": "Get only alert rules currently in the firing state",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Alerts"
                 ],
-                "summary": "Get a specific alert rule",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Alert rule ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Get firing alerts",
                 "responses": {
                     "200": {
-                        "description": "Alert rule",
+                        "description": "Firing alerts",
                         "schema": {
-                            "$ref": "#/definitions/dto.AlertRule"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AlertStatus"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts/history": {
+            "get": {
+                "description": "Get recent alert events (last 100)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Get alert history",
+                "responses": {
+                    "200": {
+                        "description": "Alert event history",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AlertHistoryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts/rules": {
+            "get": {
+                "description": "Retrieve all configured alert rules",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "List all alert rules",
+                "responses": {
+                    "200": {
+                        "description": "List of alert rules",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AlertRule"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new alert rule with an expr-lang expression",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Create a new alert rule",
+                "parameters": [
+                    {
+                        "description": "Alert rule to create",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AlertRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Rule created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts/rules/{id}": {
+            "get": {
+                "description": "Retrieve a single alert rule by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Get a specific alert rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alert rule",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AlertRule"
                         }
                     },
                     "404": {
@@ -782,6 +1116,96 @@ const docTemplate = `{
                 }
             }
         },
+        "/array/encryption": {
+            "get": {
+                "description": "Get the LUKS encryption state of each encrypted array and pool device (unlocked, locked or wrong_key), whether any of them is still locked and whether Unraid's keyfile is present in RAM.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Array"
+                ],
+                "summary": "Get array encryption status",
+                "responses": {
+                    "200": {
+                        "description": "Encryption status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ArrayEncryptionStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/array/mdstat": {
+            "get": {
+                "description": "Get /proc/mdstat both as raw text and parsed: array state and counts, the running parity check, sync or rebuild (action, position, percent and speed), each assigned slot's device, status, read/write/error counters and queue depth, and the resync speed limits.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Array"
+                ],
+                "summary": "Get md driver status",
+                "responses": {
+                    "200": {
+                        "description": "md driver status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MDStat"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to read /proc/mdstat",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change the md resync speed limits (sync_speed_min and sync_speed_max, in KB/s) and the block queue depth (nr_requests) of every assigned array device. Omitted fields are left unchanged. Changes apply immediately, also to a running parity check, and last until reboot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Array"
+                ],
+                "summary": "Tune md sync speed and queue depth",
+                "parameters": [
+                    {
+                        "description": "Tuning values",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MDTuningRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated md driver status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MDStat"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tuning values",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to apply tuning",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/array/parity-check/history": {
             "get": {
                 "description": "Retrieve the history of parity check operations",
@@ -808,6 +1232,65 @@ const docTemplate = `{
                 }
             }
         },
+        "/array/parity-check/history/{id}/note": {
+            "post": {
+                "description": "Set or clear the note on a parity check history record (e.g. \"after disk swap\"). Notes are stored by the agent alongside parity-checks.log, which is not modified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Array"
+                ],
+                "summary": "Annotate a parity check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Record ID from the parity check history",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note; empty clears it",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ParityCheckNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Annotated record",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ParityCheckRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or note too long",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Record not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to save note",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/array/parity-check/pause": {
             "post": {
                 "description": "Pause an in-progress parity check operation",
@@ -888,7 +1371,7 @@ const docTemplate = `{
         },
         "/array/parity-check/start": {
             "post": {
-                "description": "Start a parity check operation, optionally with correction",
+                "description": "Start a parity check operation, optionally with correction. With async=true the check is started in a background job that follows its progress until it finishes; cancelling the job stops the check.",
                 "produces": [
                     "application/json"
                 ],
@@ -902,6 +1385,12 @@ const docTemplate = `{
                         "description": "Enable correcting mode",
                         "name": "correcting",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job and return 202 with the job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -911,6 +1400,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
+                    "202": {
+                        "description": "Parity check job queued",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Job"
+                        }
+                    },
                     "500": {
                         "description": "Failed to start parity check",
                         "schema": {
@@ -974,7 +1469,7 @@ const docTemplate = `{
         },
         "/array/stop": {
             "post": {
-                "description": "Stop the Unraid array",
+                "description": "Stop the Unraid array. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token and the consequences; repeat it with the X-Confirm-Token header within 60 seconds to stop the array.",
                 "produces": [
                     "application/json"
                 ],
@@ -982,6 +1477,14 @@ const docTemplate = `{
                     "Array"
                 ],
                 "summary": "Stop array",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the confirmation challenge",
+                        "name": "X-Confirm-Token",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Array stopped",
@@ -989,69 +1492,96 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to stop array",
+                    "412": {
+                        "description": "Invalid or expired confirmation token",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/collectors/status": {
-            "get": {
-                "description": "Retrieve status of all data collectors including enabled state and intervals",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Collectors"
-                ],
-                "summary": "Get all collectors status",
-                "responses": {
-                    "200": {
-                        "description": "Collectors status",
+                    },
+                    "428": {
+                        "description": "Confirmation required",
                         "schema": {
-                            "$ref": "#/definitions/dto.CollectorsStatusResponse"
+                            "$ref": "#/definitions/dto.ConfirmationChallenge"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to stop array",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/collectors/{name}": {
-            "get": {
-                "description": "Retrieve status of a specific collector by name",
+        "/array/unlock": {
+            "post": {
+                "description": "Supply the array encryption passphrase or base64-encoded keyfile and start the array, like the Main page does after an unattended reboot. The key is written to Unraid's keyfile for emhttpd and deleted once the array has started unless keep_keyfile is set. The array must be stopped and have encrypted devices. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token; repeat it with the X-Confirm-Token header within 60 seconds.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collectors"
+                    "Array"
                 ],
-                "summary": "Get specific collector status",
+                "summary": "Unlock encrypted drives and start the array",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Collector name (e.g., system, docker, vm)",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Token from the confirmation challenge",
+                        "name": "X-Confirm-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Passphrase or keyfile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ArrayUnlockRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Collector status",
+                        "description": "Array unlocked and started",
                         "schema": {
-                            "$ref": "#/definitions/dto.CollectorResponse"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "404": {
-                        "description": "Collector not found",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "503": {
-                        "description": "Collector management not available",
+                    "409": {
+                        "description": "Array not stopped or not encrypted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Invalid or expired confirmation token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Wrong encryption key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Confirmation required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmationChallenge"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start array",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1059,46 +1589,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/collectors/{name}/disable": {
-            "post": {
-                "description": "Disable a specific collector at runtime",
+        "/array/utilization": {
+            "get": {
+                "description": "Return the utilization level (ok, warning, critical) of every array data disk and pool, using the warning and critical utilization thresholds from the Unraid disk settings, with recent utilization events. A level clears only once usage falls 2 points below its threshold, and each level change raises one event and Unraid notification.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collectors"
-                ],
-                "summary": "Disable collector",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Collector name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Array"
                 ],
+                "summary": "Get array and pool utilization levels",
                 "responses": {
                     "200": {
-                        "description": "Collector disabled",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CollectorResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Collector not found",
+                        "description": "Utilization levels and events",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.UtilizationSummary"
                         }
                     },
                     "503": {
-                        "description": "Collector management not available",
+                        "description": "Alerting engine not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1106,46 +1615,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/collectors/{name}/enable": {
-            "post": {
-                "description": "Enable a specific collector at runtime",
+        "/audit": {
+            "get": {
+                "description": "List recorded control actions (REST, MCP, MQTT, inbound webhooks), newest first, with optional filtering",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collectors"
+                    "Audit"
                 ],
-                "summary": "Enable collector",
+                "summary": "Get audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Collector name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by source (rest, mcp, mqtt, webhook)",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action (substring match)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by target (substring match)",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by result (success, failure, denied, approved)",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries at or after this RFC3339 timestamp",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum entries to return (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Collector enabled",
+                        "description": "Audit entries",
                         "schema": {
-                            "$ref": "#/definitions/dto.CollectorResponse"
+                            "$ref": "#/definitions/dto.AuditLogResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Collector not found",
+                        "description": "Invalid query parameter",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "503": {
-                        "description": "Collector management not available",
+                        "description": "Audit log not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1153,9 +1685,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/collectors/{name}/interval": {
-            "patch": {
-                "description": "Update the collection interval for a specific collector",
+        "/auth/login": {
+            "post": {
+                "description": "Check the Unraid root password and start a session. Returns a short-lived access token to send as \"Authorization: Bearer \u003ctoken\u003e\" and a refresh token for POST /auth/refresh. Five failed attempts lock the client out for five minutes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1163,48 +1695,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Collectors"
+                    "Authentication"
                 ],
-                "summary": "Update collector interval",
+                "summary": "Log in",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Collector name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "New interval in seconds",
-                        "name": "interval",
+                        "description": "Credentials",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CollectorIntervalRequest"
+                            "$ref": "#/definitions/dto.AuthLoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Interval updated",
+                        "description": "Session tokens",
                         "schema": {
-                            "$ref": "#/definitions/dto.CollectorResponse"
+                            "$ref": "#/definitions/dto.AuthTokens"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "404": {
-                        "description": "Collector not found",
+                    "401": {
+                        "description": "Invalid username or password",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "503": {
-                        "description": "Collector management not available",
+                    "429": {
+                        "description": "Too many failed attempts",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Password cannot be verified",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1212,9 +1743,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/cpu/governor": {
+        "/auth/logout": {
             "post": {
-                "description": "Set the CPU scaling governor for all cores (e.g. performance, powersave)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End the session of the access token in the Authorization header. Its access and refresh tokens stop working.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Log out",
+                "responses": {
+                    "200": {
+                        "description": "Logged out",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "No valid access token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access and refresh token. Each refresh token works once; reusing one ends the session.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1222,35 +1784,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CPU"
+                    "Authentication"
                 ],
-                "summary": "Set CPU scaling governor",
+                "summary": "Refresh session tokens",
                 "parameters": [
                     {
-                        "description": "CPU governor request",
+                        "description": "Refresh token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CPUGovernorRequest"
+                            "$ref": "#/definitions/dto.AuthRefreshRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Governor set",
+                        "description": "New session tokens",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.AuthTokens"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to set governor",
+                    "401": {
+                        "description": "Invalid or expired refresh token",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1258,201 +1820,140 @@ const docTemplate = `{
                 }
             }
         },
-        "/diagnostics/bundle": {
+        "/auth/session": {
             "get": {
-                "description": "Collects a redacted diagnostics bundle (system state, array, containers, VMs, network, recent agent/syslog logs, and redacted configuration) and returns it as a downloadable ZIP archive. Safe to attach to bug reports — secrets (MQTT credentials, etc.) are redacted. Enable Debug Logging first for richer agent logs in the bundle.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report whether the API requires authentication and how this request is authenticated (token, api_key or none), so frontends can decide whether to show a login page. Always public: without valid credentials it answers 200 with authenticated false.",
                 "produces": [
-                    "application/zip"
+                    "application/json"
                 ],
                 "tags": [
-                    "Diagnostics"
+                    "Authentication"
                 ],
-                "summary": "Download diagnostics bundle",
+                "summary": "Get current session",
                 "responses": {
                     "200": {
-                        "description": "ZIP archive (binary)",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to build diagnostics bundle",
+                        "description": "Current session",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.AuthSession"
                         }
                     }
                 }
             }
         },
-        "/diagnostics/self-test": {
-            "get": {
-                "description": "Returns the detected Unraid version, overall data-source health, probed capabilities, and per-subsystem source status (healthy/degraded/unavailable).",
+        "/batch": {
+            "post": {
+                "description": "Execute up to 100 container, VM and disk actions in one request (e.g. stop five containers and spin down three disks). Actions run with limited concurrency (default 4, max 10); actions on the same target run in request order. A failing action does not stop the others. Supported actions: start_container, stop_container, restart_container, start_vm, stop_vm, restart_vm, force_stop_vm, spin_down_disk, spin_up_disk.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnostics"
+                    "Batch"
+                ],
+                "summary": "Execute a batch of actions",
+                "parameters": [
+                    {
+                        "description": "Actions to execute",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchRequest"
+                        }
+                    }
                 ],
-                "summary": "Run agent self-test",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Per-action results in request order",
                         "schema": {
-                            "$ref": "#/definitions/api.selfTestResponse"
+                            "$ref": "#/definitions/dto.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid batch",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/disks": {
+        "/boot-sequence": {
             "get": {
-                "description": "Retrieve information about all disks including SMART data",
+                "description": "Get the boot sequence configuration and the progress of its last run. After the server boots the agent waits for the network and UPS, unlocks and starts the array, and starts the selected containers and VMs in order. The state is empty until the sequence has run, and skipped when the agent started more than 15 minutes after boot.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disks"
-                ],
-                "summary": "Get all disks",
-                "responses": {
-                    "200": {
-                        "description": "List of disks",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.DiskInfo"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/disks/{id}": {
-            "get": {
-                "description": "Retrieve information about a specific disk by ID, device name, or name",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Disks"
-                ],
-                "summary": "Get specific disk",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Disk ID, device name (e.g., sda), or disk name (e.g., disk1)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "System"
                 ],
+                "summary": "Get boot sequence",
                 "responses": {
                     "200": {
-                        "description": "Disk information",
+                        "description": "Boot sequence",
                         "schema": {
-                            "$ref": "#/definitions/dto.DiskInfo"
+                            "$ref": "#/definitions/dto.BootSequenceStatus"
                         }
                     },
-                    "404": {
-                        "description": "Disk not found",
+                    "503": {
+                        "description": "Boot sequence not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/docker": {
-            "get": {
-                "description": "Retrieve information about all Docker containers including stats",
-                "produces": [
+            },
+            "put": {
+                "description": "Replace the boot sequence configuration. It is saved on the flash drive and applies from the next boot. An encrypted array is unlocked with the key read from key_file (e.g. on a USB stick) or fetched from key_url over HTTPS; with neither, the sequence waits for POST /array/unlock.",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Docker"
-                ],
-                "summary": "Get all Docker containers",
-                "responses": {
-                    "200": {
-                        "description": "List of containers",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ContainerInfo"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/docker/networks": {
-            "get": {
-                "description": "Serves the cached Docker network list. Returns an empty list when no cache is available yet.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "System"
                 ],
-                "summary": "Get Docker networks",
-                "responses": {
-                    "200": {
-                        "description": "Docker network list",
+                "summary": "Configure boot sequence",
+                "parameters": [
+                    {
+                        "description": "Boot sequence configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DockerNetworkList"
+                            "$ref": "#/definitions/dto.BootSequenceConfig"
                         }
                     }
-                }
-            }
-        },
-        "/docker/port-conflicts": {
-            "get": {
-                "description": "Returns any host port bound by more than one running container (read-only, no confirm required).",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Docker"
                 ],
-                "summary": "List Docker port conflicts",
                 "responses": {
                     "200": {
-                        "description": "List of port conflicts (empty if none)",
+                        "description": "Boot sequence",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.PortConflict"
-                            }
+                            "$ref": "#/definitions/dto.BootSequenceStatus"
                         }
                     },
-                    "500": {
-                        "description": "Failed to detect port conflicts",
+                    "400": {
+                        "description": "Invalid configuration",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/docker/stats": {
-            "get": {
-                "description": "Returns aggregate CPU and memory statistics across all running containers",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Docker"
-                ],
-                "summary": "Get Docker aggregate stats",
-                "responses": {
-                    "200": {
-                        "description": "Aggregate stats",
+                    },
+                    "500": {
+                        "description": "Failed to save the configuration",
                         "schema": {
-                            "$ref": "#/definitions/dto.DockerAggregateStats"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal error",
+                    "503": {
+                        "description": "Boot sequence not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1460,25 +1961,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/update-all": {
+        "/boot-sequence/run": {
             "post": {
-                "description": "Check all containers for updates and update those that have updates available",
+                "description": "Run the configured boot sequence in the background, whether or not it is enabled, and return its initial progress. Poll GET /boot-sequence for the result.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "System"
                 ],
-                "summary": "Update all containers",
+                "summary": "Run boot sequence now",
                 "responses": {
-                    "200": {
-                        "description": "Bulk update results",
+                    "202": {
+                        "description": "Boot sequence started",
                         "schema": {
-                            "$ref": "#/definitions/dto.ContainerBulkUpdateResult"
+                            "$ref": "#/definitions/dto.BootSequenceStatus"
                         }
                     },
-                    "500": {
-                        "description": "Failed to update containers",
+                    "409": {
+                        "description": "Boot sequence already running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Boot sequence not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1486,80 +1993,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/updates": {
+        "/btrfs": {
             "get": {
-                "description": "Serves the cached container update result. Returns an empty result when no cache is available yet.",
+                "description": "Retrieve per-device btrfs error counters (write/read/flush I/O, corruption, generation) and the latest scrub result for every mounted btrfs filesystem",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Disks"
                 ],
-                "summary": "Get cached container update status",
+                "summary": "Get btrfs health statistics",
                 "responses": {
                     "200": {
-                        "description": "Container update status",
+                        "description": "List of btrfs filesystems",
                         "schema": {
-                            "$ref": "#/definitions/dto.ContainerUpdatesResult"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BtrfsFilesystem"
+                            }
                         }
                     }
                 }
             }
         },
-        "/docker/updates/refresh": {
-            "post": {
-                "description": "Runs an immediate registry digest comparison for all containers and publishes the result.",
+        "/collectors/status": {
+            "get": {
+                "description": "Retrieve status of all data collectors including enabled state and intervals",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Collectors"
                 ],
-                "summary": "Force a container update re-check",
+                "summary": "Get all collectors status",
                 "responses": {
                     "200": {
-                        "description": "Refreshed update status",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ContainerUpdatesResult"
-                        }
-                    },
-                    "500": {
-                        "description": "Check failed",
+                        "description": "Collectors status",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.CollectorsStatusResponse"
                         }
                     }
                 }
             }
         },
-        "/docker/{id}": {
+        "/collectors/{name}": {
             "get": {
-                "description": "Retrieve information about a specific Docker container by ID or name",
+                "description": "Retrieve status of a specific collector by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Collectors"
                 ],
-                "summary": "Get specific Docker container",
+                "summary": "Get specific collector status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
+                        "description": "Collector name (e.g., system, docker, vm)",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Container information",
+                        "description": "Collector status",
                         "schema": {
-                            "$ref": "#/definitions/dto.ContainerInfo"
+                            "$ref": "#/definitions/dto.CollectorResponse"
                         }
                     },
                     "404": {
-                        "description": "Container not found",
+                        "description": "Collector not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Collector management not available",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1567,52 +2077,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/{id}/autostart": {
+        "/collectors/{name}/disable": {
             "post": {
-                "description": "Enable or disable autostart for a Docker container. Uses the Unraid autostart file (/var/lib/docker/unraid-autostart). The change persists across reboots.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Disable a specific collector at runtime",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Collectors"
                 ],
-                "summary": "Set container autostart",
+                "summary": "Disable collector",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
+                        "description": "Collector name",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Enabled flag",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ContainerAutostartRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Autostart updated",
+                        "description": "Collector disabled",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.CollectorResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid container ID or request body",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to update autostart",
+                    "404": {
+                        "description": "Collector not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Collector management not available",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1620,99 +2124,105 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/{id}/check-update": {
-            "get": {
-                "description": "Pull latest image and check if a specific container has an update available",
+        "/collectors/{name}/enable": {
+            "post": {
+                "description": "Enable a specific collector at runtime",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Collectors"
                 ],
-                "summary": "Check a specific container for updates",
+                "summary": "Enable collector",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
+                        "description": "Collector name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Container update status",
+                        "description": "Collector enabled",
                         "schema": {
-                            "$ref": "#/definitions/dto.ContainerUpdateInfo"
+                            "$ref": "#/definitions/dto.CollectorResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid container reference",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to check update",
+                    "404": {
+                        "description": "Collector not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
+                    },
+                    "503": {
+                        "description": "Collector management not available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
             }
         },
-        "/docker/{id}/logs": {
-            "get": {
-                "description": "Retrieve stdout/stderr logs from a specific Docker container (equivalent to docker logs)",
+        "/collectors/{name}/interval": {
+            "patch": {
+                "description": "Update the collection interval for a specific collector",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Collectors"
                 ],
-                "summary": "Get Docker container logs",
+                "summary": "Update collector interval",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
+                        "description": "Collector name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of recent lines (default: 100, max: 5000)",
-                        "name": "tail",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Only logs since this timestamp (RFC3339)",
-                        "name": "since",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Include timestamps in output",
-                        "name": "timestamps",
-                        "in": "query"
+                        "description": "New interval in seconds",
+                        "name": "interval",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CollectorIntervalRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Container logs",
+                        "description": "Interval updated",
                         "schema": {
-                            "$ref": "#/definitions/dto.ContainerLogs"
+                            "$ref": "#/definitions/dto.CollectorResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid container reference",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get logs",
+                    "404": {
+                        "description": "Collector not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Collector management not available",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1720,50 +2230,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/{id}/pause": {
-            "post": {
-                "description": "Pause a specific Docker container by ID or name",
+        "/connect": {
+            "get": {
+                "description": "Returns the Unraid Connect plugin state: whether an account is signed in, the cloud connection status, flash backup activation and last sync, and the remote access mode. installed is false when the plugin is not installed. API keys and tokens are never returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
-                ],
-                "summary": "Pause Docker container",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "System"
                 ],
+                "summary": "Get Unraid Connect status",
                 "responses": {
                     "200": {
-                        "description": "Container paused",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid container ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to pause container",
+                        "description": "Unraid Connect status",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.ConnectStatus"
                         }
                     }
                 }
             }
         },
-        "/docker/{id}/remove": {
+        "/cpu/governor": {
             "post": {
-                "description": "Permanently remove a Docker container (force-stopped if running). Requires confirm=true in the request body.",
+                "description": "Set the CPU scaling governor for all cores (e.g. performance, powersave)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1771,42 +2260,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "CPU"
                 ],
-                "summary": "Remove Docker container",
+                "summary": "Set CPU scaling governor",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Confirm flag and optional remove_image flag",
+                        "description": "CPU governor request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ContainerRemoveRequest"
+                            "$ref": "#/definitions/dto.CPUGovernorRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Container removed",
+                        "description": "Governor set",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid container ID or missing confirmation",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to remove container",
+                        "description": "Failed to set governor",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1814,40 +2296,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/{id}/restart": {
-            "post": {
-                "description": "Restart a specific Docker container by ID or name",
+        "/diagnostics/bundle": {
+            "get": {
+                "description": "Collects a redacted diagnostics bundle (system state, array, containers, VMs, network, recent agent/syslog logs, and redacted configuration) and returns it as a downloadable ZIP archive. Safe to attach to bug reports — secrets (MQTT credentials, etc.) are redacted. Enable Debug Logging first for richer agent logs in the bundle.",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "Docker"
-                ],
-                "summary": "Restart Docker container",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Diagnostics"
                 ],
+                "summary": "Download diagnostics bundle",
                 "responses": {
                     "200": {
-                        "description": "Container restarted",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid container ID",
+                        "description": "ZIP archive (binary)",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to restart container",
+                        "description": "Failed to build diagnostics bundle",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1855,40 +2322,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/{id}/size": {
+        "/diagnostics/dns": {
             "get": {
-                "description": "Get size information for a specific Docker container",
+                "description": "Look up DNS records for an allow-listed host (DIAGNOSTICS_TARGETS) using the Unraid server's resolver. A failed lookup is reported in the result, not as an HTTP error.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Diagnostics"
                 ],
-                "summary": "Get container size",
+                "summary": "Resolve a hostname from the server",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Hostname",
+                        "name": "host",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Record type: A (default), AAAA, CNAME, MX, TXT, NS",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Container size information",
+                        "description": "Lookup result",
                         "schema": {
-                            "$ref": "#/definitions/dto.ContainerSizeInfo"
+                            "$ref": "#/definitions/dto.DNSLookupResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid container reference",
+                        "description": "Invalid host or record type",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get container size",
+                    "403": {
+                        "description": "Host not in the diagnostics allow list",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1896,40 +2369,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/{id}/start": {
-            "post": {
-                "description": "Start a specific Docker container by ID or name",
+        "/diagnostics/http": {
+            "get": {
+                "description": "Fetch an http(s) URL on an allow-listed host (DIAGNOSTICS_TARGETS) from the Unraid server and report the status code with a DNS/connect/TLS/first-byte timing breakdown, like curl -w. Redirects are only followed to allow-listed hosts. A failed request is reported in the result, not as an HTTP error.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Diagnostics"
                 ],
-                "summary": "Start Docker container",
+                "summary": "Request a URL from the server",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
-                        "in": "path",
+                        "description": "http:// or https:// URL",
+                        "name": "url",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "GET (default) or HEAD",
+                        "name": "method",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Container started",
+                        "description": "Request result",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.HTTPCheckResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid container ID",
+                        "description": "Invalid URL or method",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to start container",
+                    "403": {
+                        "description": "Host not in the diagnostics allow list",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1937,40 +2416,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/{id}/stop": {
-            "post": {
-                "description": "Stop a specific Docker container by ID or name",
+        "/diagnostics/ping": {
+            "get": {
+                "description": "Send ICMP echo requests from the Unraid server to an allow-listed host (DIAGNOSTICS_TARGETS) and report packet loss and round-trip times. An unreachable host is reported in the result, not as an HTTP error.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Diagnostics"
                 ],
-                "summary": "Stop Docker container",
+                "summary": "Ping a host from the server",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Hostname or IP address",
+                        "name": "host",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Echo requests to send (1-20, default 4)",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Container stopped",
+                        "description": "Ping result",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.PingResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid container ID",
+                        "description": "Invalid host or count",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to stop container",
+                    "403": {
+                        "description": "Host not in the diagnostics allow list",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -1978,81 +2463,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/docker/{id}/unpause": {
-            "post": {
-                "description": "Unpause a specific Docker container by ID or name",
+        "/diagnostics/self-test": {
+            "get": {
+                "description": "Returns the detected Unraid version, overall data-source health, probed capabilities, and per-subsystem source status (healthy/degraded/unavailable).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
-                ],
-                "summary": "Unpause Docker container",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Diagnostics"
                 ],
+                "summary": "Run agent self-test",
                 "responses": {
                     "200": {
-                        "description": "Container unpaused",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid container ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to unpause container",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/api.selfTestResponse"
                         }
                     }
                 }
             }
         },
-        "/docker/{id}/update": {
-            "post": {
-                "description": "Pull latest image and recreate the container with the updated image",
+        "/disks": {
+            "get": {
+                "description": "Retrieve information about all disks including SMART data. Supports field selection and pagination; the X-Total-Count header holds the number of disks.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docker"
+                    "Disks"
                 ],
-                "summary": "Update a specific container",
+                "summary": "Get all disks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Container ID or name",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Comma-separated JSON fields to return per item (e.g. name,state)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum items to return (default all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Container update result",
+                        "description": "List of disks",
                         "schema": {
-                            "$ref": "#/definitions/dto.ContainerUpdateResult"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DiskInfo"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid container reference",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to update container",
+                        "description": "Invalid fields, limit or offset",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2060,65 +2532,111 @@ const docTemplate = `{
                 }
             }
         },
-        "/fans": {
+        "/disks/spin-history": {
             "get": {
-                "description": "Retrieve current fan speeds, modes, profiles, and configuration",
+                "description": "Returns the current spin state of every array and pool disk with the number of spin-ups and spin-downs in the last 24 hours, and the recent spin transitions (last 500). Spin-ups list the processes that were accessing the disk when the spin-up was seen: files held open on the disk or on a user share path stored on it, plus file accesses reported by fatrace (NerdTools) in the following 5 seconds when it is installed. Attribution is best effort: the access that woke the disk may already have finished. New transitions are broadcast on the WebSocket topic disk_spin_event.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Fans"
+                    "Disks"
+                ],
+                "summary": "Get disk spin state history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only this disk (ID or name, e.g. disk3)",
+                        "name": "disk",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get fan control status",
                 "responses": {
                     "200": {
-                        "description": "Fan control status",
+                        "description": "Spin state history",
                         "schema": {
-                            "$ref": "#/definitions/dto.FanControlStatus"
+                            "$ref": "#/definitions/dto.DiskSpinHistory"
                         }
                     }
                 }
             }
         },
-        "/fans/config": {
-            "put": {
-                "description": "Update fan control settings (enable/disable control, safety thresholds, etc.)",
-                "consumes": [
-                    "application/json"
-                ],
+        "/disks/{id}": {
+            "get": {
+                "description": "Retrieve information about a specific disk by ID, device name, or name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Fans"
+                    "Disks"
                 ],
-                "summary": "Update fan control configuration",
+                "summary": "Get specific disk",
                 "parameters": [
                     {
-                        "description": "Fan control configuration",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.FanControlConfig"
-                        }
+                        "type": "string",
+                        "description": "Disk ID, device name (e.g., sda), or disk name (e.g., disk1)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Configuration updated",
+                        "description": "Disk information",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.DiskInfo"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "404": {
+                        "description": "Disk not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
+                    }
+                }
+            }
+        },
+        "/docker": {
+            "get": {
+                "description": "Retrieve information about all Docker containers including stats. Supports field selection and pagination; the X-Total-Count header holds the number of containers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docker"
+                ],
+                "summary": "Get all Docker containers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON fields to return per item (e.g. name,state)",
+                        "name": "fields",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Failed to update configuration",
+                    {
+                        "type": "integer",
+                        "description": "Maximum items to return (default all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of containers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ContainerInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields, limit or offset",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2126,35 +2644,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/fans/defaults": {
-            "post": {
-                "description": "Restore all fans to automatic (BIOS-controlled) mode",
+        "/docker/autostart": {
+            "get": {
+                "description": "Returns the Unraid container autostart configuration: autostarted containers in start order with their wait times, followed by the remaining containers with autostart disabled.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Fans"
+                    "Docker"
                 ],
-                "summary": "Restore fan defaults",
+                "summary": "Get container autostart order",
                 "responses": {
                     "200": {
-                        "description": "Defaults restored",
+                        "description": "Autostart configuration",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.DockerAutostart"
                         }
                     },
                     "500": {
-                        "description": "Failed to restore defaults",
+                        "description": "Failed to read autostart configuration",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/fans/mode": {
-            "post": {
-                "description": "Set the control mode (automatic/manual) for a specific fan",
+            },
+            "put": {
+                "description": "Replace the Unraid container autostart list. Listed containers are started at boot in the given order, waiting each entry's wait seconds before starting the next; all other containers have autostart disabled. An empty list disables autostart for every container. The change persists across reboots.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2162,35 +2678,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Fans"
+                    "Docker"
                 ],
-                "summary": "Set fan control mode",
+                "summary": "Set container autostart order",
                 "parameters": [
                     {
-                        "description": "Fan mode request",
+                        "description": "Containers in start order",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.FanModeRequest"
+                            "$ref": "#/definitions/dto.DockerAutostartRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mode set",
+                        "description": "Autostart configuration after the update",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.DockerAutostart"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to set mode",
+                        "description": "Failed to update autostart",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2198,51 +2714,109 @@ const docTemplate = `{
                 }
             }
         },
-        "/fans/profile": {
-            "post": {
-                "description": "Assign a temperature curve profile to a specific fan",
-                "consumes": [
+        "/docker/dependencies": {
+            "get": {
+                "description": "Maps every container's bind mounts to the user shares, pools, array disks, Unassigned Devices or remote mounts they reside on, and each share and storage location back to the containers using it. User share paths follow the share's primary and secondary storage (a pool and/or the array). requires_array marks data that is unavailable while the array is stopped.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Docker"
+                ],
+                "summary": "Get the container-to-share dependency map",
+                "responses": {
+                    "200": {
+                        "description": "Dependency map",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DockerDependencies"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/groups": {
+            "get": {
+                "description": "Groups containers by the folder-view plugin label (folder.view2 or folder.view) or, failing that, the com.docker.compose.project label, with each group's running count and aggregate CPU and memory usage of its running containers. Containers without a group label are listed under ungrouped.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Fans"
+                    "Docker"
                 ],
-                "summary": "Assign fan profile",
-                "parameters": [
-                    {
-                        "description": "Fan profile request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Get containers grouped by folder or compose project",
+                "responses": {
+                    "200": {
+                        "description": "Container groups",
                         "schema": {
-                            "$ref": "#/definitions/dto.FanProfileRequest"
+                            "$ref": "#/definitions/dto.DockerGroups"
                         }
                     }
+                }
+            }
+        },
+        "/docker/groups/{name}/start": {
+            "post": {
+                "description": "Starts the stopped containers of a folder or compose project group in Unraid autostart order, then by name. Containers that are already running or paused are skipped. A failure does not stop the remaining containers; see the per-container results.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docker"
+                ],
+                "summary": "Start every container in a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile assigned",
+                        "description": "Per-container results",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.DockerGroupActionResult"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "404": {
+                        "description": "Group not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    },
-                    "500": {
-                        "description": "Failed to assign profile",
+                    }
+                }
+            }
+        },
+        "/docker/groups/{name}/stop": {
+            "post": {
+                "description": "Stops the running containers of a folder or compose project group in reverse start order, so dependants stop before the services they use. Stopped containers are skipped. A failure does not stop the remaining containers; see the per-container results.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docker"
+                ],
+                "summary": "Stop every container in a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-container results",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.DockerGroupActionResult"
                         }
                     },
-                    "503": {
-                        "description": "Fan controller not initialized",
+                    "404": {
+                        "description": "Group not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2250,9 +2824,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/fans/profile/create": {
+        "/docker/networks": {
+            "get": {
+                "description": "Serves the cached Docker network list. Returns an empty list when no cache is available yet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docker"
+                ],
+                "summary": "Get Docker networks",
+                "responses": {
+                    "200": {
+                        "description": "Docker network list",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DockerNetworkList"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Create a new temperature curve profile for fan control",
+                "description": "Create a custom bridge, macvlan or ipvlan network, like the custom networks on the Unraid Docker settings page. macvlan and ipvlan networks need a parent interface (br0, eth0, or a VLAN sub-interface such as br0.20, which Docker creates if missing) and a subnet; containers on them get their own address on that LAN or VLAN.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2260,35 +2852,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Fans"
+                    "Docker"
                 ],
-                "summary": "Create fan profile",
+                "summary": "Create a Docker network",
                 "parameters": [
                     {
-                        "description": "Fan profile create request",
+                        "description": "Network definition",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.FanProfileCreateRequest"
+                            "$ref": "#/definitions/dto.DockerNetworkCreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Profile created",
+                    "201": {
+                        "description": "Network created",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.DockerNetworkCreateResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid network definition",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to create profile",
+                        "description": "Failed to create the network",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2296,71 +2888,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/fans/sensors": {
-            "get": {
-                "description": "List available hwmon temperature sensors and drives for fan-curve assignment",
+        "/docker/networks/{id}": {
+            "delete": {
+                "description": "Remove a custom Docker network by name or ID. Networks that still have containers attached are refused; the predefined bridge, host and none networks cannot be removed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Fans"
+                    "Docker"
+                ],
+                "summary": "Remove a Docker network",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network name or ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List fan temperature sources",
                 "responses": {
                     "200": {
-                        "description": "Available sensors and drives",
+                        "description": "Network removed",
                         "schema": {
-                            "$ref": "#/definitions/dto.FanSensorCatalog"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "503": {
-                        "description": "Fan controller not initialized",
+                    "400": {
+                        "description": "Invalid network reference",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/fans/speed": {
-            "post": {
-                "description": "Set the PWM speed for a specific fan (requires fan control to be enabled)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Fans"
-                ],
-                "summary": "Set fan speed",
-                "parameters": [
-                    {
-                        "description": "Fan speed request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.FanSpeedRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Speed set",
+                    },
+                    "404": {
+                        "description": "Network not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "409": {
+                        "description": "Containers are attached to the network",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to set speed",
+                        "description": "Failed to remove the network",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2368,409 +2941,372 @@ const docTemplate = `{
                 }
             }
         },
-        "/gpu": {
+        "/docker/port-conflicts": {
             "get": {
-                "description": "Retrieve GPU metrics for NVIDIA and AMD GPUs",
+                "description": "Returns any host port bound by more than one running container (read-only, no confirm required).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "GPU"
+                    "Docker"
                 ],
-                "summary": "Get GPU metrics",
+                "summary": "List Docker port conflicts",
                 "responses": {
                     "200": {
-                        "description": "List of GPU metrics",
+                        "description": "List of port conflicts (empty if none)",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.GPUMetrics"
+                                "$ref": "#/definitions/dto.PortConflict"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/hardware/baseboard": {
-            "get": {
-                "description": "Retrieve motherboard/baseboard information from DMI data",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Hardware"
-                ],
-                "summary": "Get baseboard information",
-                "responses": {
-                    "200": {
-                        "description": "Baseboard information",
-                        "schema": {
-                            "$ref": "#/definitions/dto.BaseboardInfo"
-                        }
                     },
-                    "404": {
-                        "description": "Baseboard info not available",
+                    "500": {
+                        "description": "Failed to detect port conflicts",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/hardware/bios": {
+        "/docker/stats": {
             "get": {
-                "description": "Retrieve BIOS information from DMI data",
+                "description": "Returns aggregate CPU and memory statistics across all running containers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hardware"
+                    "Docker"
                 ],
-                "summary": "Get BIOS information",
+                "summary": "Get Docker aggregate stats",
                 "responses": {
                     "200": {
-                        "description": "BIOS information",
+                        "description": "Aggregate stats",
                         "schema": {
-                            "$ref": "#/definitions/dto.BIOSInfo"
+                            "$ref": "#/definitions/dto.DockerAggregateStats"
                         }
                     },
-                    "404": {
-                        "description": "BIOS info not available",
+                    "500": {
+                        "description": "Internal error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/hardware/cache": {
+        "/docker/stats/summary": {
             "get": {
-                "description": "Retrieve CPU cache hierarchy information from DMI data",
+                "description": "Returns the aggregate CPU and memory usage of the running containers with the largest CPU and memory consumers, as computed by the docker collector on each collection",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hardware"
+                    "Docker"
+                ],
+                "summary": "Get Docker stats summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of containers in each ranking, 1-10 (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get CPU cache information",
                 "responses": {
                     "200": {
-                        "description": "CPU cache information",
+                        "description": "Stats summary",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.CPUCacheInfo"
-                            }
+                            "$ref": "#/definitions/dto.DockerStatsSummary"
                         }
                     },
-                    "404": {
-                        "description": "Cache info not available",
+                    "400": {
+                        "description": "Invalid limit",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Docker stats not available yet",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/hardware/cpu": {
-            "get": {
-                "description": "Retrieve CPU hardware information from DMI data",
+        "/docker/update-all": {
+            "post": {
+                "description": "Check all containers for updates and update those that have updates available. With async=true the updates run in a background job whose result is the bulk update result.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hardware"
+                    "Docker"
+                ],
+                "summary": "Update all containers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job and return 202 with the job",
+                        "name": "async",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get CPU hardware information",
                 "responses": {
                     "200": {
-                        "description": "CPU information",
+                        "description": "Bulk update results",
                         "schema": {
-                            "$ref": "#/definitions/dto.CPUHardwareInfo"
+                            "$ref": "#/definitions/dto.ContainerBulkUpdateResult"
                         }
                     },
-                    "404": {
-                        "description": "CPU info not available",
+                    "202": {
+                        "description": "Update job queued",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Job"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update containers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/hardware/full": {
+        "/docker/updates": {
             "get": {
-                "description": "Retrieve complete hardware information including BIOS, CPU, and memory",
+                "description": "Serves the cached container update result. Returns an empty result when no cache is available yet.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hardware"
+                    "Docker"
                 ],
-                "summary": "Get full hardware information",
+                "summary": "Get cached container update status",
                 "responses": {
                     "200": {
-                        "description": "Hardware information",
+                        "description": "Container update status",
                         "schema": {
-                            "$ref": "#/definitions/dto.HardwareInfo"
+                            "$ref": "#/definitions/dto.ContainerUpdatesResult"
                         }
                     }
                 }
             }
         },
-        "/hardware/memory-array": {
-            "get": {
-                "description": "Retrieve physical memory array information from DMI data",
+        "/docker/updates/refresh": {
+            "post": {
+                "description": "Runs an immediate registry digest comparison for all containers and publishes the result.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hardware"
+                    "Docker"
                 ],
-                "summary": "Get memory array information",
+                "summary": "Force a container update re-check",
                 "responses": {
                     "200": {
-                        "description": "Memory array information",
+                        "description": "Refreshed update status",
                         "schema": {
-                            "$ref": "#/definitions/dto.MemoryArrayInfo"
+                            "$ref": "#/definitions/dto.ContainerUpdatesResult"
                         }
                     },
-                    "404": {
-                        "description": "Memory array info not available",
+                    "500": {
+                        "description": "Check failed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/hardware/memory-devices": {
+        "/docker/{id}": {
             "get": {
-                "description": "Retrieve individual memory device (DIMM) information from DMI data",
+                "description": "Retrieve information about a specific Docker container by ID or name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hardware"
+                    "Docker"
+                ],
+                "summary": "Get specific Docker container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get memory device information",
                 "responses": {
                     "200": {
-                        "description": "Memory device information",
+                        "description": "Container information",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.MemoryDeviceInfo"
-                            }
+                            "$ref": "#/definitions/dto.ContainerInfo"
                         }
                     },
                     "404": {
-                        "description": "Memory device info not available",
+                        "description": "Container not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Check if the API server is running and healthy",
+        "/docker/{id}/autostart": {
+            "post": {
+                "description": "Enable or disable autostart for a Docker container. Uses the Unraid autostart file (/var/lib/docker/unraid-autostart). The change persists across reboots.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "Docker"
                 ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "Server is healthy",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/health/report": {
-            "get": {
-                "description": "Aggregate health signals from array, disks, containers, and firing alerts into a prioritized list of findings with recommended actions",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "System"
-                ],
-                "summary": "Get system health report",
-                "responses": {
-                    "200": {
-                        "description": "Health report",
-                        "schema": {
-                            "$ref": "#/definitions/dto.HealthReport"
-                        }
-                    }
-                }
-            }
-        },
-        "/healthchecks": {
-            "get": {
-                "description": "Get all configured health checks",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "HealthChecks"
-                ],
-                "summary": "List health checks",
-                "responses": {
-                    "200": {
-                        "description": "List of health checks",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.HealthCheck"
-                            }
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a new health check probe",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "HealthChecks"
-                ],
-                "summary": "Create health check",
+                "summary": "Set container autostart",
                 "parameters": [
                     {
-                        "description": "Health check configuration",
-                        "name": "check",
+                        "type": "string",
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Enabled flag",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthCheck"
+                            "$ref": "#/definitions/dto.ContainerAutostartRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Autostart updated",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid container ID or request body",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/healthchecks/history": {
-            "get": {
-                "description": "Get recent health check state change events",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "HealthChecks"
-                ],
-                "summary": "Get health check history",
-                "responses": {
-                    "200": {
-                        "description": "Health check events",
+                    },
+                    "500": {
+                        "description": "Failed to update autostart",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthCheckHistoryResponse"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/healthchecks/status": {
+        "/docker/{id}/check-update": {
             "get": {
-                "description": "Get the current status of all health checks",
+                "description": "Pull latest image and check if a specific container has an update available",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HealthChecks"
+                    "Docker"
+                ],
+                "summary": "Check a specific container for updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get health check statuses",
                 "responses": {
                     "200": {
-                        "description": "Health check statuses",
+                        "description": "Container update status",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthChecksStatusResponse"
+                            "$ref": "#/definitions/dto.ContainerUpdateInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid container reference",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to check update",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/healthchecks/{id}": {
-            "get": {
-                "description": "Get a specific health check by ID",
+        "/docker/{id}/cpuset": {
+            "put": {
+                "description": "Change the CPUs a container may run on (docker update --cpuset-cpus). A running container is re-pinned immediately, and the cpuset is saved in the container's Unraid template so it survives the container being recreated; persisted is false when the container has no template. An empty cpuset allows every CPU. Requires confirm=true.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HealthChecks"
+                    "Docker"
                 ],
-                "summary": "Get health check",
+                "summary": "Set container cpuset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Health check ID",
+                        "description": "Container ID or name",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "cpuset",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContainerCPUSetRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Health check details",
+                        "description": "cpuset updated",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthCheck"
+                            "$ref": "#/definitions/dto.CPUPinningResult"
                         }
                     },
-                    "404": {
-                        "description": "Health check not found",
+                    "400": {
+                        "description": "Invalid request, unknown CPU, or not confirmed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update the cpuset",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing health check configuration",
+            }
+        },
+        "/docker/{id}/limits": {
+            "patch": {
+                "description": "Change the CPU shares, CPU quota/period, memory limit and restart policy of a container in place (docker update). Only the fields in the request body are changed. Changes are lost when Unraid recreates the container from its template.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2778,75 +3314,101 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "HealthChecks"
+                    "Docker"
                 ],
-                "summary": "Update health check",
+                "summary": "Update container resource limits",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Health check ID",
+                        "description": "Container ID or name",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated health check configuration",
-                        "name": "check",
+                        "description": "Limits to change",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthCheck"
+                            "$ref": "#/definitions/dto.ContainerLimitsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated",
+                        "description": "Limits after the update",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.ContainerLimits"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid container reference or limits",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "500": {
+                        "description": "Failed to update limits",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a health check by ID",
+            }
+        },
+        "/docker/{id}/logs": {
+            "get": {
+                "description": "Retrieve stdout/stderr logs from a specific Docker container (equivalent to docker logs)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HealthChecks"
+                    "Docker"
                 ],
-                "summary": "Delete health check",
+                "summary": "Get Docker container logs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Health check ID",
+                        "description": "Container ID or name",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of recent lines (default: 100, max: 5000)",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only logs since this timestamp (RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include timestamps in output",
+                        "name": "timestamps",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Deleted",
+                        "description": "Container logs",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContainerLogs"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid container reference",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "500": {
+                        "description": "Failed to get logs",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2854,20 +3416,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/healthchecks/{id}/run": {
+        "/docker/{id}/pause": {
             "post": {
-                "description": "Manually trigger a specific health check and return the result",
+                "description": "Pause a specific Docker container by ID or name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HealthChecks"
+                    "Docker"
                 ],
-                "summary": "Run health check",
+                "summary": "Pause Docker container",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Health check ID",
+                        "description": "Container ID or name",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2875,13 +3437,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Check result",
+                        "description": "Container paused",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthCheckStatus"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "400": {
+                        "description": "Invalid container ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to pause container",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2889,107 +3457,93 @@ const docTemplate = `{
                 }
             }
         },
-        "/logs": {
-            "get": {
-                "description": "List available log files or get log content with optional pagination",
+        "/docker/{id}/remove": {
+            "post": {
+                "description": "Permanently remove a Docker container (force-stopped if running). Requires confirm=true in the request body.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Logs"
+                    "Docker"
                 ],
-                "summary": "Get logs",
+                "summary": "Remove Docker container",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Log file path (if empty, lists all logs)",
-                        "name": "path",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of lines to return",
-                        "name": "lines",
-                        "in": "query"
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Starting line number",
-                        "name": "start",
-                        "in": "query"
+                        "description": "Confirm flag and optional remove_image flag",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContainerRemoveRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Log content or list",
+                        "description": "Container removed",
                         "schema": {
-                            "$ref": "#/definitions/dto.LogFileContent"
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid container ID or missing confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Error reading logs",
+                        "description": "Failed to remove container",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/logs/{filename}": {
-            "get": {
-                "description": "Retrieve a specific log file by filename with optional pagination",
+        "/docker/{id}/restart": {
+            "post": {
+                "description": "Restart a specific Docker container by ID or name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Logs"
+                    "Docker"
                 ],
-                "summary": "Get specific log file",
+                "summary": "Restart Docker container",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Log filename",
-                        "name": "filename",
+                        "description": "Container ID or name",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of lines to return",
-                        "name": "lines",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Starting line number",
-                        "name": "start",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Log content",
-                        "schema": {
-                            "$ref": "#/definitions/dto.LogFileContent"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid filename",
+                        "description": "Container restarted",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "404": {
-                        "description": "Log file not found",
+                    "400": {
+                        "description": "Invalid container ID",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Error reading log",
+                        "description": "Failed to restart container",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -2997,66 +3551,81 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics": {
+        "/docker/{id}/size": {
             "get": {
-                "description": "Returns metrics in Prometheus exposition format for Grafana integration",
+                "description": "Get size information for a specific Docker container",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Monitoring"
+                    "Docker"
+                ],
+                "summary": "Get container size",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Prometheus metrics endpoint",
                 "responses": {
                     "200": {
-                        "description": "Prometheus metrics",
+                        "description": "Container size information",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ContainerSizeInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid container reference",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get container size",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/metrics/history": {
-            "get": {
-                "description": "Return in-memory ring-buffer samples and summary stats (slope, min, max, avg, last) for a named metric series. Pass entity for per-disk or per-container metrics.",
+        "/docker/{id}/start": {
+            "post": {
+                "description": "Start a specific Docker container by ID or name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Docker"
                 ],
-                "summary": "Query metric history",
+                "summary": "Start Docker container",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Metric name (e.g. cpu_temp, array_used_pct, disk_temp)",
-                        "name": "metric",
-                        "in": "query",
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Entity ID for per-entity metrics (e.g. disk ID, container ID)",
-                        "name": "entity",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Metric history",
+                        "description": "Container started",
                         "schema": {
-                            "$ref": "#/definitions/dto.MetricHistoryResult"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "400": {
-                        "description": "metric query parameter is required",
+                        "description": "Invalid container ID",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "503": {
-                        "description": "Alerting engine not initialized",
+                    "500": {
+                        "description": "Failed to start container",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -3064,65 +3633,81 @@ const docTemplate = `{
                 }
             }
         },
-        "/mover": {
-            "get": {
-                "description": "Returns the cached mover status including active state, schedule, and last-run statistics. Returns a zero-value sentinel when no data is available yet.",
+        "/docker/{id}/stop": {
+            "post": {
+                "description": "Stop a specific Docker container by ID or name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Mover"
+                    "Docker"
+                ],
+                "summary": "Stop Docker container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get mover status",
                 "responses": {
                     "200": {
-                        "description": "Mover status",
+                        "description": "Container stopped",
                         "schema": {
-                            "$ref": "#/definitions/dto.MoverStatus"
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid container ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to stop container",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/mqtt/publish": {
+        "/docker/{id}/unpause": {
             "post": {
-                "description": "Publish a custom message to a specific MQTT topic",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Unpause a specific Docker container by ID or name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MQTT"
+                    "Docker"
                 ],
-                "summary": "Publish custom MQTT message",
+                "summary": "Unpause Docker container",
                 "parameters": [
                     {
-                        "description": "Message to publish",
-                        "name": "message",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.MQTTPublishRequest"
-                        }
+                        "type": "string",
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Message published",
+                        "description": "Container unpaused",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid container ID",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to publish",
+                        "description": "Failed to unpause container",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -3130,123 +3715,150 @@ const docTemplate = `{
                 }
             }
         },
-        "/mqtt/status": {
-            "get": {
-                "description": "Retrieve MQTT connection status and configuration",
+        "/docker/{id}/update": {
+            "post": {
+                "description": "Pull latest image and recreate the container with the updated image. With async=true the update runs in a background job whose result is the update result.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MQTT"
+                    "Docker"
+                ],
+                "summary": "Update a specific container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Recreate even when no update is available",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job and return 202 with the job",
+                        "name": "async",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get MQTT status",
                 "responses": {
                     "200": {
-                        "description": "MQTT status",
+                        "description": "Container update result",
                         "schema": {
-                            "$ref": "#/definitions/dto.MQTTStatus"
+                            "$ref": "#/definitions/dto.ContainerUpdateResult"
                         }
-                    }
-                }
-            }
-        },
-        "/mqtt/test": {
-            "post": {
-                "description": "Test the MQTT broker connection",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "MQTT"
-                ],
-                "summary": "Test MQTT connection",
-                "responses": {
-                    "200": {
-                        "description": "Test successful",
+                    },
+                    "202": {
+                        "description": "Update job queued",
                         "schema": {
-                            "$ref": "#/definitions/dto.MQTTTestResponse"
+                            "$ref": "#/definitions/dto.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid container reference",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Test failed",
+                        "description": "Failed to update container",
                         "schema": {
-                            "$ref": "#/definitions/dto.MQTTTestResponse"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/network": {
+        "/fans": {
             "get": {
-                "description": "Retrieve information about all network interfaces",
+                "description": "Retrieve current fan speeds, modes, profiles, and configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Network"
+                    "Fans"
                 ],
-                "summary": "Get network interfaces",
+                "summary": "Get fan control status",
                 "responses": {
                     "200": {
-                        "description": "List of network interfaces",
+                        "description": "Fan control status",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.NetworkInfo"
-                            }
+                            "$ref": "#/definitions/dto.FanControlStatus"
                         }
                     }
                 }
             }
         },
-        "/network/access-urls": {
-            "get": {
-                "description": "Returns all methods to access the Unraid server including LAN IP, mDNS hostname, WireGuard VPN IPs, WAN IP, and IPv6 addresses",
+        "/fans/config": {
+            "put": {
+                "description": "Update fan control settings (enable/disable control, safety thresholds, etc.)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Network"
+                    "Fans"
+                ],
+                "summary": "Update fan control configuration",
+                "parameters": [
+                    {
+                        "description": "Fan control configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FanControlConfig"
+                        }
+                    }
                 ],
-                "summary": "Get network access URLs",
                 "responses": {
                     "200": {
-                        "description": "Network access URLs",
+                        "description": "Configuration updated",
                         "schema": {
-                            "$ref": "#/definitions/dto.NetworkAccessURLs"
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update configuration",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/network/{interface}/config": {
-            "get": {
-                "description": "Retrieve configuration for a specific network interface",
+        "/fans/defaults": {
+            "post": {
+                "description": "Restore all fans to automatic (BIOS-controlled) mode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
-                ],
-                "summary": "Get network interface configuration",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Interface name (e.g., eth0, bond0)",
-                        "name": "interface",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Fans"
                 ],
+                "summary": "Restore fan defaults",
                 "responses": {
                     "200": {
-                        "description": "Network configuration",
+                        "description": "Defaults restored",
                         "schema": {
-                            "$ref": "#/definitions/dto.NetworkConfig"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "404": {
-                        "description": "Interface not found",
+                    "500": {
+                        "description": "Failed to restore defaults",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -3254,35 +3866,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications": {
-            "get": {
-                "description": "Retrieve all notifications with overview counts, optionally filtered by importance",
+        "/fans/mode": {
+            "post": {
+                "description": "Set the control mode (automatic/manual) for a specific fan",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Fans"
                 ],
-                "summary": "Get all notifications",
+                "summary": "Set fan control mode",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by importance level (alert, warning, normal)",
-                        "name": "importance",
-                        "in": "query"
+                        "description": "Fan mode request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FanModeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notifications with overview",
+                        "description": "Mode set",
                         "schema": {
-                            "$ref": "#/definitions/dto.NotificationList"
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to set mode",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/fans/profile": {
             "post": {
-                "description": "Create a new system notification",
+                "description": "Assign a temperature curve profile to a specific fan",
                 "consumes": [
                     "application/json"
                 ],
@@ -3290,432 +3922,391 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Fans"
                 ],
-                "summary": "Create notification",
+                "summary": "Assign fan profile",
                 "parameters": [
                     {
-                        "description": "Notification data",
-                        "name": "notification",
+                        "description": "Fan profile request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "description": {
-                                    "type": "string"
-                                },
-                                "importance": {
-                                    "type": "string"
-                                },
-                                "link": {
-                                    "type": "string"
-                                },
-                                "subject": {
-                                    "type": "string"
-                                },
-                                "title": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/dto.FanProfileRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Notification created",
+                    "200": {
+                        "description": "Profile assigned",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to create notification",
+                        "description": "Failed to assign profile",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Fan controller not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/notifications/archive": {
-            "get": {
-                "description": "Retrieve only archived notifications",
+        "/fans/profile/create": {
+            "post": {
+                "description": "Create a new temperature curve profile for fan control",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Fans"
+                ],
+                "summary": "Create fan profile",
+                "parameters": [
+                    {
+                        "description": "Fan profile create request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FanProfileCreateRequest"
+                        }
+                    }
                 ],
-                "summary": "Get archived notifications",
                 "responses": {
                     "200": {
-                        "description": "Archived notifications with count",
+                        "description": "Profile created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create profile",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/notifications/archive/all": {
-            "post": {
-                "description": "Archive all unread notifications",
+        "/fans/sensors": {
+            "get": {
+                "description": "List available hwmon temperature sensors and drives for fan-curve assignment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Fans"
                 ],
-                "summary": "Archive all notifications",
+                "summary": "List fan temperature sources",
                 "responses": {
                     "200": {
-                        "description": "All notifications archived",
+                        "description": "Available sensors and drives",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.FanSensorCatalog"
                         }
                     },
-                    "500": {
-                        "description": "Failed to archive",
+                    "503": {
+                        "description": "Fan controller not initialized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/notifications/overview": {
-            "get": {
-                "description": "Retrieve only the notification overview counts",
+        "/fans/speed": {
+            "post": {
+                "description": "Set the PWM speed for a specific fan (requires fan control to be enabled)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Fans"
                 ],
-                "summary": "Get notification counts",
-                "responses": {
-                    "200": {
-                        "description": "Notification counts by category",
+                "summary": "Set fan speed",
+                "parameters": [
+                    {
+                        "description": "Fan speed request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.NotificationOverview"
+                            "$ref": "#/definitions/dto.FanSpeedRequest"
                         }
                     }
-                }
-            }
-        },
-        "/notifications/unread": {
-            "get": {
-                "description": "Retrieve only unread notifications",
-                "produces": [
-                    "application/json"
                 ],
-                "tags": [
-                    "Notifications"
-                ],
-                "summary": "Get unread notifications",
                 "responses": {
                     "200": {
-                        "description": "Unread notifications with count",
+                        "description": "Speed set",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to set speed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/notifications/{id}": {
+        "/files/download": {
             "get": {
-                "description": "Retrieve a specific notification by its ID",
+                "description": "Download a regular file within a user share as an attachment. Range requests are supported for resuming large downloads.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Filesystem"
                 ],
-                "summary": "Get notification by ID",
+                "summary": "Download a file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Absolute file path within a share",
+                        "name": "path",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notification details",
+                        "description": "File contents",
                         "schema": {
-                            "$ref": "#/definitions/dto.Notification"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid path or not a regular file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Path outside the share paths",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "404": {
-                        "description": "Notification not found",
+                        "description": "Path not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a specific notification by ID",
+            }
+        },
+        "/files/list": {
+            "get": {
+                "description": "List the entries of a directory within a user share, directories first and then by name. The path must lie within a user share, either under /mnt/user or on an array disk or pool. Symlinks are listed but not followed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Filesystem"
                 ],
-                "summary": "Delete notification",
+                "summary": "List a directory",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Absolute directory path within a share",
+                        "name": "path",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Whether notification is archived",
-                        "name": "archived",
+                        "type": "integer",
+                        "description": "Entries to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries to return (1-5000, default 1000)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notification deleted",
+                        "description": "Directory listing",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.DirectoryListing"
                         }
                     },
-                    "500": {
-                        "description": "Failed to delete",
+                    "400": {
+                        "description": "Invalid path, offset or limit, or not a directory",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/notifications/{id}/archive": {
-            "post": {
-                "description": "Archive a specific notification by ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Notifications"
-                ],
-                "summary": "Archive notification",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Notification archived",
+                    },
+                    "403": {
+                        "description": "Path outside the share paths",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to archive",
+                    "404": {
+                        "description": "Path not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/notifications/{id}/unarchive": {
-            "post": {
-                "description": "Unarchive a specific notification by ID",
+        "/files/stat": {
+            "get": {
+                "description": "Get the type, size, permissions and modification time of a file or directory within a user share.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Filesystem"
                 ],
-                "summary": "Unarchive notification",
+                "summary": "Get file information",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Absolute path within a share",
+                        "name": "path",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notification unarchived",
+                        "description": "File information",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.FileEntry"
                         }
                     },
-                    "500": {
-                        "description": "Failed to unarchive",
+                    "400": {
+                        "description": "Invalid path",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Path outside the share paths",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Path not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/nut": {
+        "/filesystem/analyze": {
             "get": {
-                "description": "Retrieve Network UPS Tools (NUT) status information",
+                "description": "List running and recently finished directory analyses, newest first. Results are omitted; fetch a job by ID for its result. Finished jobs are kept for an hour.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "UPS"
+                    "Filesystem"
                 ],
-                "summary": "Get NUT status",
+                "summary": "List directory size analyses",
                 "responses": {
                     "200": {
-                        "description": "NUT status",
+                        "description": "Analysis jobs",
                         "schema": {
-                            "$ref": "#/definitions/dto.NUTResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.FilesystemAnalyzeJob"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/os/update": {
-            "get": {
-                "description": "Returns the cached OS update status. No outbound network calls are made; the result is based on local files only. Returns an \"unknown\" sentinel when no local latest-version data is available.",
+            },
+            "post": {
+                "description": "Compute the size of a directory and its largest children in the background. The path must lie within a user share, either under /mnt/user or on an array disk or pool (e.g. /mnt/cache/appdata). Poll the returned job for progress and the result.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OS"
+                    "Filesystem"
                 ],
-                "summary": "Get OS update availability",
-                "responses": {
-                    "200": {
-                        "description": "OS update status",
+                "summary": "Start a directory size analysis",
+                "parameters": [
+                    {
+                        "description": "Directory to analyze",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.OSUpdateStatus"
+                            "$ref": "#/definitions/dto.FilesystemAnalyzeRequest"
                         }
                     }
-                }
-            }
-        },
-        "/plugins": {
-            "get": {
-                "description": "Retrieve list of installed plugins with their versions and update status",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Plugins"
                 ],
-                "summary": "Get installed plugins list",
                 "responses": {
-                    "200": {
-                        "description": "List of installed plugins",
+                    "202": {
+                        "description": "Analysis started",
                         "schema": {
-                            "$ref": "#/definitions/dto.PluginList"
+                            "$ref": "#/definitions/dto.FilesystemAnalyzeJob"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get plugins",
+                    "400": {
+                        "description": "Invalid path, depth or limit",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/plugins/check-updates": {
-            "get": {
-                "description": "Serves the cached plugin update result. Returns an empty result when no cache is available yet.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Plugins"
-                ],
-                "summary": "Get cached plugin update status",
-                "responses": {
-                    "200": {
-                        "description": "Plugin update status",
+                    },
+                    "403": {
+                        "description": "Path outside the share paths",
                         "schema": {
-                            "$ref": "#/definitions/dto.PluginList"
+                            "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/plugins/update-all": {
-            "post": {
-                "description": "Update all plugins that have updates available",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Plugins"
-                ],
-                "summary": "Update all plugins",
-                "responses": {
-                    "200": {
-                        "description": "Plugin update results",
+                    },
+                    "404": {
+                        "description": "Path not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.PluginBulkUpdateResult"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to update plugins",
+                    "429": {
+                        "description": "Too many analyses running",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -3723,66 +4314,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/plugins/updates/refresh": {
-            "post": {
-                "description": "Runs an immediate plugin update check and publishes the result.",
+        "/filesystem/analyze/{id}": {
+            "get": {
+                "description": "Get the progress of a directory analysis, and its result once completed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Plugins"
+                    "Filesystem"
+                ],
+                "summary": "Get a directory size analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Force a plugin update re-check",
                 "responses": {
                     "200": {
-                        "description": "Refreshed plugin update status",
+                        "description": "Analysis job",
                         "schema": {
-                            "$ref": "#/definitions/dto.PluginList"
+                            "$ref": "#/definitions/dto.FilesystemAnalyzeJob"
                         }
                     },
-                    "500": {
-                        "description": "Check failed",
+                    "404": {
+                        "description": "Job not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/plugins/{name}/update": {
-            "post": {
-                "description": "Update a specific plugin by name",
+            },
+            "delete": {
+                "description": "Stop a running directory analysis. Cancelling a finished job has no effect.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Plugins"
+                    "Filesystem"
                 ],
-                "summary": "Update a specific plugin",
+                "summary": "Cancel a directory size analysis",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Plugin name",
-                        "name": "name",
+                        "description": "Job ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Plugin updated",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid plugin name",
+                        "description": "Analysis job",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.FilesystemAnalyzeJob"
                         }
                     },
-                    "500": {
-                        "description": "Failed to update plugin",
+                    "404": {
+                        "description": "Job not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -3790,39 +4382,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/processes": {
+        "/fleet": {
             "get": {
-                "description": "Get all running processes on the Unraid server",
+                "description": "Aggregated status of this server and every registered peer agent. Peers are queried concurrently with a 5 second timeout; unreachable peers are listed with an error.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
-                ],
-                "summary": "List running processes",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Sort by: cpu, memory, or pid (default: cpu)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Max processes to return (default: 50)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Fleet"
                 ],
+                "summary": "Get fleet overview",
                 "responses": {
                     "200": {
-                        "description": "Process list",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProcessList"
+                            "$ref": "#/definitions/dto.FleetOverview"
                         }
                     },
-                    "500": {
-                        "description": "Failed to list processes",
+                    "503": {
+                        "description": "Fleet mode not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -3830,148 +4408,190 @@ const docTemplate = `{
                 }
             }
         },
-        "/processes/io": {
+        "/fleet/peers": {
             "get": {
-                "description": "Get processes ranked by current disk I/O rate (bytes/sec), sampled from /proc/\u003cpid\u003e/io. Native, lightweight alternative to iotop-c.",
+                "description": "Registered peer agents. API keys are redacted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
-                ],
-                "summary": "Top processes by disk I/O",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Max processes to return (default: 20)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Fleet"
                 ],
+                "summary": "List fleet peers",
                 "responses": {
                     "200": {
-                        "description": "Processes ranked by disk I/O",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProcessList"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.FleetPeer"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Failed to sample process I/O",
+                    "503": {
+                        "description": "Fleet mode not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/registration": {
-            "get": {
-                "description": "Retrieve Unraid license/registration information",
+            },
+            "post": {
+                "description": "Register (or replace) a peer agent by name. The url must be scheme://host[:port] of the peer's agent, optionally followed by the path prefix of a reverse proxy in front of it (without /api/v1); api_key, if set, is sent to the peer as a bearer token.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "Fleet"
+                ],
+                "summary": "Register fleet peer",
+                "parameters": [
+                    {
+                        "description": "Peer agent",
+                        "name": "peer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FleetPeer"
+                        }
+                    }
                 ],
-                "summary": "Get registration status",
                 "responses": {
-                    "200": {
-                        "description": "Registration information",
+                    "201": {
+                        "description": "Registered",
                         "schema": {
-                            "$ref": "#/definitions/dto.Registration"
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Fleet mode not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/services": {
-            "get": {
-                "description": "List all managed Unraid system services and their status",
+        "/fleet/peers/{name}": {
+            "delete": {
+                "description": "Unregister a peer agent",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Fleet"
+                ],
+                "summary": "Remove fleet peer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Peer name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List available services",
                 "responses": {
                     "200": {
-                        "description": "Service list with status",
+                        "description": "Removed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Fleet mode not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/services/{name}/{action}": {
+        "/fleet/peers/{name}/relay": {
             "post": {
-                "description": "Start, stop, or restart an Unraid system service",
+                "description": "Run one of a fixed set of commands on a peer, for servers that do not run the agent yet. \"status\" reads hostname, Unraid version, uptime and array state over SSH; \"reboot\" and \"shutdown\" run /sbin/reboot and /sbin/poweroff over SSH and require confirm, and a confirmation token when CONFIRM_DESTRUCTIVE is on; \"wake\" sends a Wake-on-LAN packet to the peer's mac_address. The peer's SSH host key is pinned on first connection. A failed remote command is reported in the result with success false.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Fleet"
                 ],
-                "summary": "Control an Unraid service",
+                "summary": "Run relay command on fleet peer",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service name (docker, libvirt, smb, nfs, ftp, sshd, nginx, syslog, ntpd, avahi, wireguard)",
+                        "description": "Peer name",
                         "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Action (start, stop, restart)",
-                        "name": "action",
-                        "in": "path",
-                        "required": true
+                        "description": "Relay command",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FleetRelayRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service action completed",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.FleetRelayResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid service or action",
+                        "description": "Invalid command or peer not configured for it",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to execute action",
+                    "404": {
+                        "description": "Peer not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/settings/disk-thresholds": {
-            "get": {
-                "description": "Retrieve disk configuration settings including global temperature thresholds for HDD and SSD",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Configuration"
-                ],
-                "summary": "Get extended disk settings with temperature thresholds",
-                "responses": {
-                    "200": {
-                        "description": "Extended disk settings with temp thresholds",
+                    },
+                    "409": {
+                        "description": "No fleet SSH key configured",
                         "schema": {
-                            "$ref": "#/definitions/dto.DiskSettingsExtended"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get settings",
+                    "412": {
+                        "description": "Invalid or expired confirmation token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Confirmation required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmationChallenge"
+                        }
+                    },
+                    "503": {
+                        "description": "Fleet mode not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -3979,77 +4599,104 @@ const docTemplate = `{
                 }
             }
         },
-        "/settings/disks": {
+        "/fleet/ssh-key": {
             "get": {
-                "description": "Retrieve disk configuration settings",
+                "description": "The public key the agent uses to log in to peers over SSH. Add it to /root/.ssh/authorized_keys on each peer (on Unraid, also to /boot/config/ssh/root/authorized_keys so it survives a reboot).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "Fleet"
                 ],
-                "summary": "Get disk settings",
+                "summary": "Get fleet SSH public key",
                 "responses": {
                     "200": {
-                        "description": "Disk settings",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.DiskSettings"
+                            "$ref": "#/definitions/dto.FleetSSHKey"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get settings",
+                    "404": {
+                        "description": "No key configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Fleet mode not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/settings/docker": {
-            "get": {
-                "description": "Retrieve Docker daemon settings",
+            },
+            "post": {
+                "description": "Generate a new ed25519 key for logging in to peers, or import an unencrypted private key. Replaces any existing key. The private key is stored with mode 0600 and never returned.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "Fleet"
+                ],
+                "summary": "Create or import fleet SSH key",
+                "parameters": [
+                    {
+                        "description": "Private key to import (omit to generate)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FleetSSHKeyRequest"
+                        }
+                    }
                 ],
-                "summary": "Get Docker settings",
                 "responses": {
-                    "200": {
-                        "description": "Docker settings",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.DockerSettings"
+                            "$ref": "#/definitions/dto.FleetSSHKey"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get settings",
+                    "400": {
+                        "description": "Invalid key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Fleet mode not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/settings/mover": {
-            "get": {
-                "description": "Retrieve mover configuration, schedule, and current running status",
+            },
+            "delete": {
+                "description": "Remove the key used to log in to peers. Relay commands over SSH fail until a new key is created.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "Fleet"
                 ],
-                "summary": "Get mover schedule and status",
+                "summary": "Delete fleet SSH key",
                 "responses": {
                     "200": {
-                        "description": "Mover settings and status",
+                        "description": "Removed",
                         "schema": {
-                            "$ref": "#/definitions/dto.MoverSettings"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get settings",
+                    "404": {
+                        "description": "No key configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Fleet mode not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -4057,25 +4704,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/settings/network-services": {
+        "/fleet/{server}/{path}": {
             "get": {
-                "description": "Retrieve status of all network services including SMB, NFS, FTP, SSH, Telnet, Avahi, WireGuard, etc.",
+                "description": "Forward any /api/v1 request to a registered peer, e.g. GET /fleet/backup-nas/docker or POST /fleet/backup-nas/docker/plex/restart. The peer's response is returned unchanged; 502 if the peer is unreachable.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "Fleet"
+                ],
+                "summary": "Proxy request to fleet peer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Peer name",
+                        "name": "server",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API path on the peer, relative to /api/v1",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get network services status",
                 "responses": {
                     "200": {
-                        "description": "Network services status",
+                        "description": "Peer response",
                         "schema": {
-                            "$ref": "#/definitions/dto.NetworkServicesStatus"
+                            "type": "object"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get network services status",
+                    "400": {
+                        "description": "Invalid path",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Peer not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Peer unreachable",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -4083,399 +4758,348 @@ const docTemplate = `{
                 }
             }
         },
-        "/settings/services": {
+        "/gpu": {
             "get": {
-                "description": "Retrieve whether Docker and VM Manager services are enabled in Unraid settings",
+                "description": "Retrieve GPU metrics for NVIDIA and AMD GPUs",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "GPU"
                 ],
-                "summary": "Get Docker and VM service enabled status",
+                "summary": "Get GPU metrics",
                 "responses": {
                     "200": {
-                        "description": "Service enabled status",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ServiceStatus"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to get status",
+                        "description": "List of GPU metrics",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GPUMetrics"
+                            }
                         }
                     }
                 }
             }
         },
-        "/settings/system": {
+        "/hardware/baseboard": {
             "get": {
-                "description": "Retrieve Unraid system settings",
+                "description": "Retrieve motherboard/baseboard information from DMI data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "Hardware"
                 ],
-                "summary": "Get system settings",
+                "summary": "Get baseboard information",
                 "responses": {
                     "200": {
-                        "description": "System settings",
+                        "description": "Baseboard information",
                         "schema": {
-                            "$ref": "#/definitions/dto.SystemSettings"
+                            "$ref": "#/definitions/dto.BaseboardInfo"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get settings",
+                    "404": {
+                        "description": "Baseboard info not available",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Update Unraid system settings",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/hardware/bios": {
+            "get": {
+                "description": "Retrieve BIOS information from DMI data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
-                ],
-                "summary": "Update system settings",
-                "parameters": [
-                    {
-                        "description": "System settings",
-                        "name": "settings",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SystemSettings"
-                        }
-                    }
+                    "Hardware"
                 ],
+                "summary": "Get BIOS information",
                 "responses": {
                     "200": {
-                        "description": "Settings updated",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "BIOS information",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.BIOSInfo"
                         }
                     },
-                    "500": {
-                        "description": "Failed to update",
+                    "404": {
+                        "description": "BIOS info not available",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/settings/vm": {
+        "/hardware/cache": {
             "get": {
-                "description": "Retrieve virtual machine manager settings",
+                "description": "Retrieve CPU cache hierarchy information from DMI data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
+                    "Hardware"
                 ],
-                "summary": "Get VM settings",
+                "summary": "Get CPU cache information",
                 "responses": {
                     "200": {
-                        "description": "VM settings",
+                        "description": "CPU cache information",
                         "schema": {
-                            "$ref": "#/definitions/dto.VMSettings"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CPUCacheInfo"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Failed to get settings",
+                    "404": {
+                        "description": "Cache info not available",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/shares": {
+        "/hardware/cpu": {
             "get": {
-                "description": "Retrieve information about all user shares",
+                "description": "Retrieve CPU hardware information from DMI data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shares"
+                    "Hardware"
                 ],
-                "summary": "Get all shares",
+                "summary": "Get CPU hardware information",
                 "responses": {
                     "200": {
-                        "description": "List of shares",
+                        "description": "CPU information",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ShareInfo"
+                            "$ref": "#/definitions/dto.CPUHardwareInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "CPU info not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/shares/{name}/config": {
+        "/hardware/full": {
             "get": {
-                "description": "Retrieve configuration for a specific user share",
+                "description": "Retrieve complete hardware information including BIOS, CPU, and memory",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
-                ],
-                "summary": "Get share configuration",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Share name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Hardware"
                 ],
+                "summary": "Get full hardware information",
                 "responses": {
                     "200": {
-                        "description": "Share configuration",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ShareConfig"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid share name",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Share not found",
+                        "description": "Hardware information",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.HardwareInfo"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Update configuration for a specific user share",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/hardware/memory-array": {
+            "get": {
+                "description": "Retrieve physical memory array information from DMI data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Configuration"
-                ],
-                "summary": "Update share configuration",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Share name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Share configuration",
-                        "name": "config",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ShareConfig"
-                        }
-                    }
+                    "Hardware"
                 ],
+                "summary": "Get memory array information",
                 "responses": {
                     "200": {
-                        "description": "Configuration updated",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "Memory array information",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.MemoryArrayInfo"
                         }
                     },
-                    "500": {
-                        "description": "Failed to update",
+                    "404": {
+                        "description": "Memory array info not available",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/system": {
+        "/hardware/memory-devices": {
             "get": {
-                "description": "Retrieve comprehensive system metrics including CPU, RAM, temperatures, and uptime",
+                "description": "Retrieve individual memory device (DIMM) information from DMI data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "Hardware"
                 ],
-                "summary": "Get system information",
+                "summary": "Get memory device information",
                 "responses": {
                     "200": {
-                        "description": "System information",
+                        "description": "Memory device information",
                         "schema": {
-                            "$ref": "#/definitions/dto.SystemInfo"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MemoryDeviceInfo"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Memory device info not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/system/flash": {
+        "/health": {
             "get": {
-                "description": "Retrieve health information for the USB flash boot drive",
+                "description": "Reports the state of the components the agent depends on: the Docker and libvirt sockets, the MQTT connection, the collectors and the space left in /var/log. Answers 503 when a critical component is down, so load balancers and uptime monitors detect partial failures; degraded states still answer 200. ready turns true once every enabled collector has run once after startup. The probes run at most once every 5 seconds. When AUTH_REQUIRED is set, callers without credentials only get the overall and per-component status, without messages.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "System"
                 ],
-                "summary": "Get USB flash drive health",
+                "summary": "Health check",
                 "responses": {
                     "200": {
-                        "description": "Flash drive health information",
+                        "description": "Healthy or degraded",
                         "schema": {
-                            "$ref": "#/definitions/dto.FlashDriveHealth"
+                            "$ref": "#/definitions/dto.HealthStatus"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get flash health",
+                    "503": {
+                        "description": "A critical component is down",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.HealthStatus"
                         }
                     }
                 }
             }
         },
-        "/system/reboot": {
-            "post": {
-                "description": "Initiate a system reboot",
+        "/health/live": {
+            "get": {
+                "description": "Answers 200 as long as the API server is serving requests, including during startup. Use it to decide whether to restart the agent.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "System"
                 ],
-                "summary": "Reboot system",
+                "summary": "Liveness probe",
                 "responses": {
                     "200": {
-                        "description": "Reboot initiated",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to initiate reboot",
+                        "description": "Agent is alive",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/system/shutdown": {
-            "post": {
-                "description": "Initiate a system shutdown",
+        "/health/ready": {
+            "get": {
+                "description": "Answers 200 once the startup phase has finished: every enabled collector has run once, in dependency order, or the 60-second startup timeout has passed. Until then it answers 503 and lists the collectors still pending, so clients can wait before reading data.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "System"
                 ],
-                "summary": "Shutdown system",
+                "summary": "Readiness probe",
                 "responses": {
                     "200": {
-                        "description": "Shutdown initiated",
+                        "description": "Agent is ready",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.Readiness"
                         }
                     },
-                    "500": {
-                        "description": "Failed to initiate shutdown",
+                    "503": {
+                        "description": "Startup phase still running",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.Readiness"
                         }
                     }
                 }
             }
         },
-        "/temperatures": {
+        "/health/report": {
             "get": {
-                "description": "Returns all detected temperature sensor readings from hwmon",
+                "description": "Aggregate health signals from array, disks, containers, and firing alerts into a prioritized list of findings with recommended actions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "System"
                 ],
-                "summary": "Get all temperature sensors",
+                "summary": "Get system health report",
                 "responses": {
                     "200": {
-                        "description": "Temperature readings",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.TemperatureReading"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal error",
+                        "description": "Health report",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.HealthReport"
                         }
                     }
                 }
             }
         },
-        "/tuning": {
+        "/healthchecks": {
             "get": {
-                "description": "Retrieve current kernel tuning parameters (turbo boost, disk cache, inotify, NIC offloads, ring buffers)",
+                "description": "Get all configured health checks",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tuning"
+                    "HealthChecks"
                 ],
-                "summary": "Get system tuning parameters",
+                "summary": "List health checks",
                 "responses": {
                     "200": {
-                        "description": "Tuning parameters",
-                        "schema": {
-                            "$ref": "#/definitions/dto.TuningInfo"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal error",
+                        "description": "List of health checks",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.HealthCheck"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/tuning/disk-cache": {
+            },
             "post": {
-                "description": "Set Linux vm.dirty_* kernel parameters for disk cache tuning",
+                "description": "Create a new health check probe",
                 "consumes": [
                     "application/json"
                 ],
@@ -4483,23 +5107,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tuning"
+                    "HealthChecks"
                 ],
-                "summary": "Set disk cache parameters",
+                "summary": "Create health check",
                 "parameters": [
                     {
-                        "description": "Disk cache request",
-                        "name": "request",
+                        "description": "Health check configuration",
+                        "name": "check",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DiskCacheRequest"
+                            "$ref": "#/definitions/dto.HealthCheck"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Disk cache updated",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -4509,101 +5133,117 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error applying tuning",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "503": {
-                        "description": "Tuning controller not available",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
                     }
                 }
             }
         },
-        "/tuning/inotify": {
-            "post": {
-                "description": "Set Linux inotify kernel parameters (max watches, instances, events)",
-                "consumes": [
-                    "application/json"
-                ],
+        "/healthchecks/history": {
+            "get": {
+                "description": "Get recent health check state change events",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tuning"
+                    "HealthChecks"
                 ],
-                "summary": "Set inotify limits",
-                "parameters": [
-                    {
-                        "description": "Inotify limits request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Get health check history",
+                "responses": {
+                    "200": {
+                        "description": "Health check events",
                         "schema": {
-                            "$ref": "#/definitions/dto.InotifyLimitsRequest"
+                            "$ref": "#/definitions/dto.HealthCheckHistoryResponse"
                         }
                     }
+                }
+            }
+        },
+        "/healthchecks/status": {
+            "get": {
+                "description": "Get the current status of all health checks",
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "HealthChecks"
+                ],
+                "summary": "Get health check statuses",
                 "responses": {
                     "200": {
-                        "description": "Inotify limits updated",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error applying tuning",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "503": {
-                        "description": "Tuning controller not available",
+                        "description": "Health check statuses",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.HealthChecksStatusResponse"
                         }
                     }
                 }
             }
         },
-        "/tuning/turbo": {
-            "post": {
-                "description": "Enable or disable Intel Turbo Boost / AMD Performance Boost",
-                "consumes": [
-                    "application/json"
-                ],
+        "/healthchecks/{id}": {
+            "get": {
+                "description": "Get a specific health check by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tuning"
+                    "HealthChecks"
                 ],
-                "summary": "Set turbo boost state",
+                "summary": "Get health check",
                 "parameters": [
                     {
-                        "description": "Turbo boost request",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Health check ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Health check details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthCheck"
+                        }
+                    },
+                    "404": {
+                        "description": "Health check not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing health check configuration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "HealthChecks"
+                ],
+                "summary": "Update health check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Health check ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated health check configuration",
+                        "name": "check",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TurboBoostRequest"
+                            "$ref": "#/definitions/dto.HealthCheck"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Turbo boost set",
+                        "description": "Updated",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -4614,86 +5254,113 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error applying tuning",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "503": {
-                        "description": "Tuning controller not available",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/unassigned": {
-            "get": {
-                "description": "Retrieve all unassigned devices and remote shares",
+            },
+            "delete": {
+                "description": "Delete a health check by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Unassigned Devices"
+                    "HealthChecks"
+                ],
+                "summary": "Delete health check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Health check ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all unassigned devices",
                 "responses": {
                     "200": {
-                        "description": "Unassigned devices and remote shares",
+                        "description": "Deleted",
                         "schema": {
-                            "$ref": "#/definitions/dto.UnassignedDeviceList"
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/unassigned/devices": {
-            "get": {
-                "description": "Retrieve only unassigned devices (excludes remote shares)",
+        "/healthchecks/{id}/run": {
+            "post": {
+                "description": "Manually trigger a specific health check and return the result",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Unassigned Devices"
+                    "HealthChecks"
+                ],
+                "summary": "Run health check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Health check ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get unassigned devices only",
                 "responses": {
                     "200": {
-                        "description": "Unassigned devices",
+                        "description": "Check result",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/dto.HealthCheckStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/unassigned/remote-shares": {
+        "/hooks": {
             "get": {
-                "description": "Retrieve only remote shares (excludes local unassigned devices)",
+                "description": "Inbound webhooks with their action and delivery statistics since the agent started. Secrets are redacted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Unassigned Devices"
+                    "Webhooks"
                 ],
-                "summary": "Get remote shares only",
+                "summary": "List inbound webhooks",
                 "responses": {
                     "200": {
-                        "description": "Remote shares",
+                        "description": "Inbound webhooks",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.InboundHookInfo"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Inbound webhooks not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/unassigned/remote-shares/mount": {
+            },
             "post": {
-                "description": "Mount an Unassigned Devices SMB/NFS remote share that is configured in the plugin. The source must match a value from the remote_shares list: \"//server/share\" for SMB or \"server:/export\" for NFS.",
+                "description": "Create a webhook at /api/v1/hooks/{name} that runs a registered script, starts, stops or restarts a container, or starts or stops a VM. A secret is generated when none is given; it is returned only by this call.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4701,25 +5368,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Unassigned Devices"
+                    "Webhooks"
                 ],
-                "summary": "Mount a remote share",
+                "summary": "Create inbound webhook",
                 "parameters": [
                     {
-                        "description": "Remote share source",
-                        "name": "request",
+                        "description": "Webhook",
+                        "name": "hook",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RemoteShareActionRequest"
+                            "$ref": "#/definitions/dto.InboundHook"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Remote share mounted",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.InboundHookCreated"
                         }
                     },
                     "400": {
@@ -4728,8 +5395,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to mount",
+                    "409": {
+                        "description": "Webhook already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Inbound webhooks not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -4737,9 +5410,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/unassigned/remote-shares/unmount": {
-            "post": {
-                "description": "Unmount an Unassigned Devices SMB/NFS remote share that is currently mounted. The source must match a value from the remote_shares list: \"//server/share\" for SMB or \"server:/export\" for NFS.",
+        "/hooks/{name}": {
+            "get": {
+                "description": "Get an inbound webhook by name. The secret is redacted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get inbound webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundHookInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Inbound webhooks not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace an inbound webhook's action and settings. An empty or redacted secret keeps the stored one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4747,23 +5459,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Unassigned Devices"
+                    "Webhooks"
                 ],
-                "summary": "Unmount a remote share",
+                "summary": "Update inbound webhook",
                 "parameters": [
                     {
-                        "description": "Remote share source",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Webhook name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "hook",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RemoteShareActionRequest"
+                            "$ref": "#/definitions/dto.InboundHook"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Remote share unmounted",
+                        "description": "Updated",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -4774,93 +5493,155 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to unmount",
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Inbound webhooks not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/updates": {
-            "get": {
-                "description": "Retrieve Unraid OS and plugin update availability information",
+            },
+            "post": {
+                "description": "Trigger an inbound webhook's action. The caller proves knowledge of the secret with an X-Hub-Signature-256 HMAC of the body (GitHub, Gitea), the X-Webhook-Secret header, or the basic auth password (Sonarr, Radarr); no API credentials are needed even with AUTH_REQUIRED. The action runs in the background. GitHub ping and *arr Test deliveries, and events not in the hook's events list, are acknowledged without running it.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Updates"
+                    "Webhooks"
+                ],
+                "summary": "Deliver inbound webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sha256=\u003chex HMAC-SHA256 of the body\u003e",
+                        "name": "X-Hub-Signature-256",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook secret",
+                        "name": "X-Webhook-Secret",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get update availability status",
                 "responses": {
                     "200": {
-                        "description": "Update availability status",
+                        "description": "Test delivery or ignored event",
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateStatus"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get update status",
+                    "202": {
+                        "description": "Action started",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/ups": {
-            "get": {
-                "description": "Retrieve UPS status information from apcupsd",
-                "produces": [
-                    "application/json"
+                    },
+                    "401": {
+                        "description": "Missing or invalid secret",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Webhook disabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Inbound webhooks not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an inbound webhook",
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "UPS"
+                    "Webhooks"
+                ],
+                "summary": "Delete inbound webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get UPS status",
                 "responses": {
                     "200": {
-                        "description": "UPS status",
+                        "description": "Deleted",
                         "schema": {
-                            "$ref": "#/definitions/dto.UPSStatus"
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Inbound webhooks not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/user-scripts": {
+        "/ipmi": {
             "get": {
-                "description": "Retrieve a list of all available user scripts",
+                "description": "Returns the BMC's fan, voltage, temperature, power and current sensors with their critical thresholds, the power supply states, and the chassis status (power, faults, intrusion), read with ipmitool. available is false when ipmitool is missing or the board has no BMC.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Scripts"
+                    "Hardware"
                 ],
-                "summary": "Get user scripts",
+                "summary": "Get IPMI sensors and chassis status",
                 "responses": {
                     "200": {
-                        "description": "List of user scripts",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.UserScriptInfo"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to list scripts",
+                        "description": "IPMI sensors and chassis status",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.IPMIStatus"
                         }
                     }
                 }
             }
         },
-        "/user-scripts/{name}/execute": {
+        "/ipmi/chassis": {
             "post": {
-                "description": "Execute a user script by name",
+                "description": "Blink the chassis identify LED (identify), or change the power state through the BMC: soft (ACPI shutdown), off (hard power off), cycle or reset. Power actions bypass Unraid and do not stop the array, so they require confirm=true, and off, cycle and reset also require a confirmation token when CONFIRM_DESTRUCTIVE is on; use them only when the OS no longer responds to a normal shutdown.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4868,79 +5649,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Scripts"
+                    "Hardware"
                 ],
-                "summary": "Execute user script",
+                "summary": "Send an IPMI chassis command",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Script name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Execution options",
-                        "name": "options",
+                        "description": "Chassis action",
+                        "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserScriptExecuteRequest"
+                            "$ref": "#/definitions/dto.IPMIChassisActionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Execution result",
+                        "description": "Command sent",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserScriptExecuteResponse"
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid action or missing confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Invalid or expired confirmation token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Confirmation required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmationChallenge"
                         }
                     },
                     "500": {
-                        "description": "Failed to execute",
+                        "description": "ipmitool failed",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserScriptExecuteResponse"
+                            "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
             }
         },
-        "/vm": {
+        "/jobs": {
             "get": {
-                "description": "Retrieve information about all virtual machines",
+                "description": "List queued, running and recently finished background jobs, newest first. Logs and results are omitted; fetch a job by ID for them. Finished jobs are kept for an hour.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Jobs"
                 ],
-                "summary": "Get all VMs",
+                "summary": "List jobs",
                 "responses": {
                     "200": {
-                        "description": "List of VMs",
+                        "description": "Jobs",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.VMInfo"
+                                "$ref": "#/definitions/dto.Job"
                             }
                         }
                     }
                 }
             }
         },
-        "/vm/{id}": {
+        "/jobs/{id}": {
             "get": {
-                "description": "Retrieve information about a specific virtual machine by ID or name",
+                "description": "Get the state, progress, log lines and result of a background job.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Jobs"
                 ],
-                "summary": "Get specific VM",
+                "summary": "Get a job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "VM ID or name",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4948,61 +5741,46 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "VM information",
+                        "description": "Job",
                         "schema": {
-                            "$ref": "#/definitions/dto.VMInfo"
+                            "$ref": "#/definitions/dto.Job"
                         }
                     },
                     "404": {
-                        "description": "VM not found",
+                        "description": "Job not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/vm/{name}/clone": {
-            "post": {
-                "description": "Clone a VM including disk images (source VM must be shut off)",
+            },
+            "delete": {
+                "description": "Cancel a queued or running job. A running parity check or mover is stopped; a container update or VM hibernation already sent to Docker or libvirt runs to completion. Cancelling a finished job has no effect.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Jobs"
                 ],
-                "summary": "Clone a virtual machine",
+                "summary": "Cancel a job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Source VM name",
-                        "name": "name",
+                        "description": "Job ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Name for the cloned VM",
-                        "name": "clone_name",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "VM cloned successfully",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid parameters",
+                        "description": "Job",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.Job"
                         }
                     },
-                    "500": {
-                        "description": "Failed to clone VM",
+                    "404": {
+                        "description": "Job not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -5010,81 +5788,107 @@ const docTemplate = `{
                 }
             }
         },
-        "/vm/{name}/force-stop": {
-            "post": {
-                "description": "Force stop a specific virtual machine by name (equivalent to pulling the power cord)",
+        "/logs": {
+            "get": {
+                "description": "List available log files or get log content with optional pagination",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Logs"
                 ],
-                "summary": "Force stop VM",
+                "summary": "Get logs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Log file path (if empty, lists all logs)",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of lines to return",
+                        "name": "lines",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Starting line number",
+                        "name": "start",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "VM force stopped",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid VM name",
+                        "description": "Log content or list",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.LogFileContent"
                         }
                     },
                     "500": {
-                        "description": "Failed to force stop VM",
+                        "description": "Error reading logs",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/vm/{name}/hibernate": {
-            "post": {
-                "description": "Hibernate a specific virtual machine by name",
+        "/logs/search": {
+            "get": {
+                "description": "Case-insensitive search across approved log files with an optional time window. Results are capped and only the most recent 32 MiB of each file is scanned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Logs"
                 ],
-                "summary": "Hibernate VM",
+                "summary": "Search logs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
+                        "description": "Text to search for",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Treat q as a regular expression",
+                        "name": "regex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated log names or paths (default: all text logs)",
+                        "name": "files",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only lines newer than a duration (e.g. 2h) or RFC3339 timestamp",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum matches to return (default 200, max 1000)",
+                        "name": "max_results",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "VM hibernated",
+                        "description": "Matching lines",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.LogSearchResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid VM name",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to hibernate VM",
+                        "description": "Invalid search parameters",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -5092,40 +5896,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/vm/{name}/pause": {
-            "post": {
-                "description": "Pause a specific virtual machine by name",
+        "/logs/{filename}": {
+            "get": {
+                "description": "Retrieve a specific log file by filename with optional pagination",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Logs"
                 ],
-                "summary": "Pause VM",
+                "summary": "Get specific log file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "VM name",
-                        "name": "name",
+                        "description": "Log filename",
+                        "name": "filename",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of lines to return",
+                        "name": "lines",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Starting line number",
+                        "name": "start",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "VM paused",
+                        "description": "Log content",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.LogFileContent"
                         }
                     },
                     "400": {
-                        "description": "Invalid VM name",
+                        "description": "Invalid filename",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Log file not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to pause VM",
+                        "description": "Error reading log",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -5133,52 +5955,84 @@ const docTemplate = `{
                 }
             }
         },
-        "/vm/{name}/reset": {
-            "post": {
-                "description": "Hard reset a specific virtual machine by name (equivalent to the physical reset button). The VM must be running. Requires confirm=true in the request body.",
-                "consumes": [
-                    "application/json"
+        "/metrics": {
+            "get": {
+                "description": "Returns metrics in Prometheus exposition format for Grafana integration",
+                "produces": [
+                    "text/plain"
                 ],
+                "tags": [
+                    "Monitoring"
+                ],
+                "summary": "Prometheus metrics endpoint",
+                "responses": {
+                    "200": {
+                        "description": "Prometheus metrics",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/history": {
+            "get": {
+                "description": "Return samples and summary stats (slope, min, max, avg, last) for a named metric series, optionally limited to a time range. The last hour is kept at full resolution and the last 7 days as 5-minute averages. Pass entity for per-disk or per-container metrics.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Alerts"
                 ],
-                "summary": "Reset VM",
+                "summary": "Query metric history",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
+                        "description": "Metric name (e.g. cpu_usage, cpu_temp, array_used_pct, disk_temp)",
+                        "name": "metric",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Confirm flag",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.VMResetRequest"
-                        }
+                        "type": "string",
+                        "description": "Entity ID for per-entity metrics (e.g. disk ID, container ID)",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start of the time range",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end of the time range",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Duration back from now (e.g. 30m); ignored when start is set",
+                        "name": "lookback",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "VM reset",
+                        "description": "Metric history",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.MetricHistoryResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid VM name or missing confirmation",
+                        "description": "Missing metric or invalid time range",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to reset VM",
+                    "503": {
+                        "description": "Alerting engine not initialized",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -5186,135 +6040,85 @@ const docTemplate = `{
                 }
             }
         },
-        "/vm/{name}/restart": {
-            "post": {
-                "description": "Restart a specific virtual machine by name",
+        "/mover": {
+            "get": {
+                "description": "Returns the cached mover status including active state, schedule, and last-run statistics. Returns a zero-value sentinel when no data is available yet.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
-                ],
-                "summary": "Restart VM",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Mover"
                 ],
+                "summary": "Get mover status",
                 "responses": {
                     "200": {
-                        "description": "VM restarted",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid VM name",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to restart VM",
+                        "description": "Mover status",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.MoverStatus"
                         }
                     }
                 }
             }
         },
-        "/vm/{name}/resume": {
+        "/mover/start": {
             "post": {
-                "description": "Resume a paused virtual machine by name",
+                "description": "Start the mover, like the Move button on the Main page, as a background job that finishes when the mover does. Cancelling the job stops the mover.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
-                ],
-                "summary": "Resume VM",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Mover"
                 ],
+                "summary": "Start the mover",
                 "responses": {
-                    "200": {
-                        "description": "VM resumed",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid VM name",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to resume VM",
+                    "202": {
+                        "description": "Mover job queued",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.Job"
                         }
                     }
                 }
             }
         },
-        "/vm/{name}/snapshot": {
+        "/mqtt/publish": {
             "post": {
-                "description": "Create a snapshot of a virtual machine",
+                "description": "Publish a custom message to a specific MQTT topic",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "MQTT"
                 ],
-                "summary": "Create a VM snapshot",
+                "summary": "Publish custom MQTT message",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Snapshot name",
-                        "name": "snapshot_name",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Snapshot description",
-                        "name": "description",
-                        "in": "query"
+                        "description": "Message to publish",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MQTTPublishRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Snapshot created",
+                        "description": "Message published",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid parameters",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to create snapshot",
+                        "description": "Failed to publish",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -5322,177 +6126,164 @@ const docTemplate = `{
                 }
             }
         },
-        "/vm/{name}/snapshots": {
+        "/mqtt/status": {
             "get": {
-                "description": "List all snapshots for a virtual machine",
+                "description": "Retrieve MQTT connection status and configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
-                ],
-                "summary": "List VM snapshots",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    }
+                    "MQTT"
                 ],
+                "summary": "Get MQTT status",
                 "responses": {
                     "200": {
-                        "description": "Snapshot list",
-                        "schema": {
-                            "$ref": "#/definitions/dto.VMSnapshotList"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid VM name",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to list snapshots",
+                        "description": "MQTT status",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.MQTTStatus"
                         }
                     }
                 }
             }
         },
-        "/vm/{name}/snapshots/{snapshot_name}": {
-            "delete": {
-                "description": "Delete a specific snapshot of a virtual machine",
+        "/mqtt/test": {
+            "post": {
+                "description": "Test the MQTT broker connection",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
-                ],
-                "summary": "Delete a VM snapshot",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Snapshot name",
-                        "name": "snapshot_name",
-                        "in": "path",
-                        "required": true
-                    }
+                    "MQTT"
                 ],
+                "summary": "Test MQTT connection",
                 "responses": {
                     "200": {
-                        "description": "Snapshot deleted",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid parameters",
+                        "description": "Test successful",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.MQTTTestResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete snapshot",
+                        "description": "Test failed",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.MQTTTestResponse"
                         }
                     }
                 }
             }
         },
-        "/vm/{name}/snapshots/{snapshot_name}/restore": {
-            "post": {
-                "description": "Restore a virtual machine to a previously created snapshot. WARNING: This reverts the VM to the snapshot state.",
+        "/network": {
+            "get": {
+                "description": "Retrieve information about all network interfaces",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Network"
                 ],
-                "summary": "Restore a VM snapshot",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Snapshot name",
-                        "name": "snapshot_name",
-                        "in": "path",
-                        "required": true
+                "summary": "Get network interfaces",
+                "responses": {
+                    "200": {
+                        "description": "List of network interfaces",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.NetworkInfo"
+                            }
+                        }
                     }
+                }
+            }
+        },
+        "/network/access-urls": {
+            "get": {
+                "description": "Returns all methods to access the Unraid server including LAN IP, mDNS hostname, WireGuard VPN IPs, WAN IP, and IPv6 addresses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Network"
                 ],
+                "summary": "Get network access URLs",
                 "responses": {
                     "200": {
-                        "description": "Snapshot restored",
+                        "description": "Network access URLs",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.NetworkAccessURLs"
                         }
-                    },
-                    "400": {
-                        "description": "Invalid parameters",
+                    }
+                }
+            }
+        },
+        "/network/config": {
+            "get": {
+                "description": "Get the configuration of each network port from /boot/config/network.cfg: bonding, bridging, VLAN sub-interfaces, DHCP or static IPv4 addressing, MTU and the static DNS servers, plus the state of the last change applied through the API.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Network"
+                ],
+                "summary": "Get network interface configuration",
+                "responses": {
+                    "200": {
+                        "description": "Network configuration",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.NetworkSettings"
                         }
                     },
                     "500": {
-                        "description": "Failed to restore snapshot",
+                        "description": "Failed to read network.cfg",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     }
                 }
-            }
-        },
-        "/vm/{name}/start": {
-            "post": {
-                "description": "Start a specific virtual machine by name",
+            },
+            "put": {
+                "description": "Change bonds, bridges, VLANs, static addresses, MTU or DNS servers in network.cfg and restart networking. Only the listed ports and fields change. Networking restarts two seconds after the response, which briefly drops connections. The change is reverted unless POST /network/config/confirm is called within rollback_seconds (default 120), so a change that makes the server unreachable undoes itself; it is also reverted if the restart fails.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Network"
                 ],
-                "summary": "Start VM",
+                "summary": "Change network interface configuration",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Changes to apply",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NetworkSettingsUpdate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "VM started",
+                    "202": {
+                        "description": "Change applied, waiting for confirmation",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.NetworkChange"
                         }
                     },
                     "400": {
-                        "description": "Invalid VM name",
+                        "description": "Invalid configuration",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Previous change not confirmed yet",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to start VM",
+                        "description": "Failed to write network.cfg",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -5500,40 +6291,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/vm/{name}/stop": {
+        "/network/config/confirm": {
             "post": {
-                "description": "Gracefully stop a specific virtual machine by name",
+                "description": "Keep the network change applied with PUT /network/config. Call it over the new configuration once the server is reachable again; without it the change is reverted when the rollback timer expires.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VMs"
+                    "Network"
                 ],
-                "summary": "Stop VM",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "VM name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                "summary": "Confirm a network change",
+                "responses": {
+                    "200": {
+                        "description": "Change confirmed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NetworkChange"
+                        }
+                    },
+                    "409": {
+                        "description": "No change waiting for confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
                     }
+                }
+            }
+        },
+        "/network/config/rollback": {
+            "post": {
+                "description": "Restore the network configuration from before the pending change now and restart networking, instead of waiting for the rollback timer.",
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "Network"
+                ],
+                "summary": "Roll back a network change",
                 "responses": {
                     "200": {
-                        "description": "VM stopped",
+                        "description": "Change rolled back",
                         "schema": {
-                            "$ref": "#/definitions/dto.Response"
+                            "$ref": "#/definitions/dto.NetworkChange"
                         }
                     },
-                    "400": {
-                        "description": "Invalid VM name",
+                    "409": {
+                        "description": "No change waiting for confirmation",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to stop VM",
+                        "description": "Failed to restore network.cfg",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -5541,110 +6349,125 @@ const docTemplate = `{
                 }
             }
         },
-        "/ws": {
+        "/network/dns": {
             "get": {
-                "description": "Establish a WebSocket connection for real-time system updates\n\n**Connection:** ` + "`" + `ws://localhost:8043/api/v1/ws` + "`" + `\n\n**Event Format:**\n` + "`" + `` + "`" + `` + "`" + `json\n{\n\"event\": \"update\",\n\"timestamp\": \"2025-01-01T00:00:00Z\",\n\"data\": { ... }\n}\n` + "`" + `` + "`" + `` + "`" + `\n\n**Supported Events:**\n- system_update: System metrics (CPU, RAM, temps)\n- array_status_update: Array status changes\n- disk_list_update: Disk information updates\n- container_list_update: Docker container updates\n- vm_list_update: VM status updates\n- ups_status_update: UPS status updates\n- gpu_metrics_update: GPU metrics updates\n- network_list_update: Network interface updates\n- hardware_update: Hardware information updates\n- notifications_update: Notification updates\n- zfs_pools_update: ZFS pool updates",
+                "description": "Returns the cached DNS health: configured resolvers, per-resolver response time and DNSSEC validation from the host, and upstream reachability for queries sourced from each Docker bridge gateway address (run from the host, not inside containers). Returns an empty, unhealthy sentinel until the dns collector has run.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "WebSocket"
+                    "Network"
                 ],
-                "summary": "WebSocket connection",
-                "responses": {}
+                "summary": "Get DNS health",
+                "responses": {
+                    "200": {
+                        "description": "DNS health",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DNSHealth"
+                        }
+                    }
+                }
             }
         },
-        "/zfs/arc": {
+        "/network/mounts": {
             "get": {
-                "description": "Retrieve ZFS Adaptive Replacement Cache (ARC) statistics",
+                "description": "Returns the cached health of remote NFS and SMB client mounts: Unassigned Devices remote shares, /etc/fstab entries and other mounted network filesystems. Each mounted share is probed with a bounded statfs, and is stale when that hangs or fails (hung NFS server, disconnected SMB session); the server's NFS (2049) or SMB (445) port is probed for reachability and latency. Stale mounts raise the built-in remote-mount-stale alert. Returns an empty list until the remote_mounts collector has run.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ZFS"
+                    "Network"
                 ],
-                "summary": "Get ZFS ARC statistics",
+                "summary": "Get NFS/SMB mount health",
                 "responses": {
                     "200": {
-                        "description": "ZFS ARC statistics",
+                        "description": "Remote mount health",
                         "schema": {
-                            "$ref": "#/definitions/dto.ZFSARCStats"
+                            "$ref": "#/definitions/dto.RemoteMountHealth"
                         }
                     }
                 }
             }
         },
-        "/zfs/datasets": {
+        "/network/speedtest": {
             "get": {
-                "description": "Retrieve information about all ZFS datasets",
+                "description": "Returns the latest scheduled bandwidth test (download/upload in Mbps and ping in ms, run with speedtest-cli or iperf3) and the retained result history, oldest first. The speedtest collector is disabled by default; returns an empty history until it has run.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ZFS"
+                    "Network"
                 ],
-                "summary": "Get all ZFS datasets",
+                "summary": "Get bandwidth test results",
                 "responses": {
                     "200": {
-                        "description": "List of ZFS datasets",
+                        "description": "Bandwidth test results",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ZFSDataset"
-                            }
+                            "$ref": "#/definitions/dto.SpeedtestStatus"
                         }
                     }
                 }
             }
         },
-        "/zfs/pools": {
+        "/network/wan": {
             "get": {
-                "description": "Retrieve information about all ZFS pools",
+                "description": "Returns the cached internet connectivity status: online state, current public IP (with the previous IP and time of the last change), and latency/packet loss to each configured probe target. Returns an offline sentinel until the wan collector has run.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ZFS"
+                    "Network"
                 ],
-                "summary": "Get all ZFS pools",
+                "summary": "Get WAN status",
                 "responses": {
                     "200": {
-                        "description": "List of ZFS pools",
+                        "description": "WAN status",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ZFSPool"
-                            }
+                            "$ref": "#/definitions/dto.WANStatus"
                         }
                     }
                 }
             }
         },
-        "/zfs/pools/{name}": {
-            "get": {
-                "description": "Retrieve information about a specific ZFS pool by name",
+        "/network/wol": {
+            "post": {
+                "description": "Send a magic packet from the Unraid server to wake a machine on the local network. Give a configured device name (WOL_DEVICES) or a MAC address. The broadcast address defaults to the device's, or 255.255.255.255:9. Success means the packet was sent, not that the machine woke up.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ZFS"
+                    "Network"
                 ],
-                "summary": "Get specific ZFS pool",
+                "summary": "Send a Wake-on-LAN packet",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Pool name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Device or MAC address",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WOLRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ZFS pool information",
+                        "description": "Packet sent",
                         "schema": {
-                            "$ref": "#/definitions/dto.ZFSPool"
+                            "$ref": "#/definitions/dto.WOLResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid MAC or broadcast address",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
                         }
                     },
                     "404": {
-                        "description": "Pool not found",
+                        "description": "Device not configured",
                         "schema": {
                             "$ref": "#/definitions/dto.Response"
                         }
@@ -5652,1982 +6475,10478 @@ const docTemplate = `{
                 }
             }
         },
-        "/zfs/snapshots": {
+        "/network/wol/devices": {
             "get": {
-                "description": "Retrieve information about all ZFS snapshots",
+                "description": "List the machines configured with WOL_DEVICES that can be woken by name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ZFS"
+                    "Network"
                 ],
-                "summary": "Get all ZFS snapshots",
+                "summary": "List Wake-on-LAN devices",
                 "responses": {
                     "200": {
-                        "description": "List of ZFS snapshots",
+                        "description": "Configured devices",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.ZFSSnapshot"
+                                "$ref": "#/definitions/dto.WOLDevice"
                             }
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.selfTestResponse": {
-            "type": "object",
-            "properties": {
-                "capabilities": {
-                    "$ref": "#/definitions/dto.Capabilities"
-                },
-                "overall_state": {
-                    "$ref": "#/definitions/dto.SourceState"
-                },
-                "subsystems": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.SourceStatus"
-                    }
-                },
-                "timestamp": {
-                    "type": "string"
-                },
-                "unraid_version": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.AccessURL": {
-            "type": "object",
-            "properties": {
-                "ipv4": {
-                    "type": "string"
-                },
-                "ipv6": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "type": {
-                    "description": "\"lan\", \"wan\", \"wireguard\", \"mdns\", \"ipv6\", \"other\"",
-                    "type": "string"
-                }
-            }
         },
-        "dto.ActionRef": {
+        "/network/{interface}/config": {
+            "get": {
+                "description": "Retrieve configuration for a specific network interface",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get network interface configuration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Interface name (e.g., eth0, bond0)",
+                        "name": "interface",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Network configuration",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NetworkConfig"
+                        }
+                    },
+                    "404": {
+                        "description": "Interface not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "description": "Retrieve all notifications with overview counts, optionally filtered by importance. The notifications list supports field selection and pagination; the X-Total-Count header holds the number of (filtered) notifications.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get all notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by importance level (alert, warning, normal)",
+                        "name": "importance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON fields to return per notification (e.g. title,importance)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum notifications to return (default all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Notifications to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications with overview",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields, limit or offset",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new system notification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Create notification",
+                "parameters": [
+                    {
+                        "description": "Notification data",
+                        "name": "notification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "description": {
+                                    "type": "string"
+                                },
+                                "importance": {
+                                    "type": "string"
+                                },
+                                "link": {
+                                    "type": "string"
+                                },
+                                "subject": {
+                                    "type": "string"
+                                },
+                                "title": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Notification created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create notification",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/archive": {
+            "get": {
+                "description": "Retrieve only archived notifications",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get archived notifications",
+                "responses": {
+                    "200": {
+                        "description": "Archived notifications with count",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/archive/all": {
+            "post": {
+                "description": "Archive all unread notifications",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Archive all notifications",
+                "responses": {
+                    "200": {
+                        "description": "All notifications archived",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to archive",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/overview": {
+            "get": {
+                "description": "Retrieve only the notification overview counts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get notification counts",
+                "responses": {
+                    "200": {
+                        "description": "Notification counts by category",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationOverview"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/targets": {
+            "get": {
+                "description": "Get all notification forwarding targets. Target URLs are redacted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "List notification targets",
+                "responses": {
+                    "200": {
+                        "description": "List of notification targets",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.NotificationTarget"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a Telegram (telegram://), Discord (discord://), or Pushover (pushover://) target that new Unraid notifications and alert rule events are forwarded to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Create notification target",
+                "parameters": [
+                    {
+                        "description": "Notification target configuration",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationTarget"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/targets/{id}": {
+            "put": {
+                "description": "Replace a notification target. An empty or redacted url keeps the stored one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Update notification target",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification target ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated notification target configuration",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationTarget"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a notification target by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Delete notification target",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification target ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/targets/{id}/test": {
+            "post": {
+                "description": "Send a test message to a notification target, even if it is disabled",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Test notification target",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification target ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Test message sent",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Delivery failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread": {
+            "get": {
+                "description": "Retrieve only unread notifications",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get unread notifications",
+                "responses": {
+                    "200": {
+                        "description": "Unread notifications with count",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}": {
+            "get": {
+                "description": "Retrieve a specific notification by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get notification by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Notification"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a specific notification by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Delete notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether notification is archived",
+                        "name": "archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/archive": {
+            "post": {
+                "description": "Archive a specific notification by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Archive notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification archived",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to archive",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/unarchive": {
+            "post": {
+                "description": "Unarchive a specific notification by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Unarchive notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification unarchived",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to unarchive",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/nut": {
+            "get": {
+                "description": "Retrieve Network UPS Tools (NUT) status information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UPS"
+                ],
+                "summary": "Get NUT status",
+                "responses": {
+                    "200": {
+                        "description": "NUT status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NUTResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/os/update": {
+            "get": {
+                "description": "Returns the cached OS update status. No outbound network calls are made; the result is based on local files only. Returns an \"unknown\" sentinel when no local latest-version data is available.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OS"
+                ],
+                "summary": "Get OS update availability",
+                "responses": {
+                    "200": {
+                        "description": "OS update status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OSUpdateStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/plugins": {
+            "get": {
+                "description": "Retrieve list of installed plugins with their versions and update status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plugins"
+                ],
+                "summary": "Get installed plugins list",
+                "responses": {
+                    "200": {
+                        "description": "List of installed plugins",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PluginList"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get plugins",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/plugins/check-updates": {
+            "get": {
+                "description": "Serves the cached plugin update result. Returns an empty result when no cache is available yet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plugins"
+                ],
+                "summary": "Get cached plugin update status",
+                "responses": {
+                    "200": {
+                        "description": "Plugin update status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PluginList"
+                        }
+                    }
+                }
+            }
+        },
+        "/plugins/update-all": {
+            "post": {
+                "description": "Update all plugins that have updates available",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plugins"
+                ],
+                "summary": "Update all plugins",
+                "responses": {
+                    "200": {
+                        "description": "Plugin update results",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PluginBulkUpdateResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update plugins",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/plugins/updates/refresh": {
+            "post": {
+                "description": "Runs an immediate plugin update check and publishes the result.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plugins"
+                ],
+                "summary": "Force a plugin update re-check",
+                "responses": {
+                    "200": {
+                        "description": "Refreshed plugin update status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PluginList"
+                        }
+                    },
+                    "500": {
+                        "description": "Check failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/plugins/{name}/update": {
+            "post": {
+                "description": "Update a specific plugin by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plugins"
+                ],
+                "summary": "Update a specific plugin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Plugin name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plugin updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid plugin name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update plugin",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools": {
+            "get": {
+                "description": "Retrieve every Unraid pool (cache, cache2, named btrfs/ZFS/XFS pools) with device membership, capacity, btrfs RAID profile and chunk allocation, and balance/scrub state",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Disks"
+                ],
+                "summary": "Get all pools",
+                "responses": {
+                    "200": {
+                        "description": "List of pools",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PoolInfo"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/{name}": {
+            "get": {
+                "description": "Retrieve a single Unraid pool by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Disks"
+                ],
+                "summary": "Get specific pool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pool name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pool information",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PoolInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Pool not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/{name}/trim": {
+            "post": {
+                "description": "Discard unused blocks on a mounted SSD pool as a background job: fstrim for btrfs and XFS pools, zpool trim for ZFS pools. The job result reports the bytes trimmed (not reported for ZFS) and the run is shown as last_trim on the pool and its disks. Cancelling the job stops fstrim; a ZFS trim continues in the background.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Disks"
+                ],
+                "summary": "Trim a pool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pool name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Trim job queued; its result is a dto.TrimResult",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Job"
+                        }
+                    },
+                    "404": {
+                        "description": "Pool not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Pool not mounted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/power": {
+            "get": {
+                "description": "Returns the estimated power draw of the server. Combines CPU and DRAM power (Intel RAPL), GPU power draw, UPS load and per-disk spin state. total_watts is the UPS load when a UPS reports it (source \"ups\"), otherwise the sum of the components (source \"components\"). Also returns the projected kWh per day and energy_kwh, a monotonic energy total since energy_since (resets when the agent restarts). Returns a zero estimate until the system collector has run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get power estimate",
+                "responses": {
+                    "200": {
+                        "description": "Power estimate",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PowerEstimate"
+                        }
+                    }
+                }
+            }
+        },
+        "/processes": {
+            "get": {
+                "description": "Get all running processes on the Unraid server",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "List running processes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sort by: cpu, memory, or pid (default: cpu)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max processes to return (default: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Process list",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProcessList"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to list processes",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/processes/io": {
+            "get": {
+                "description": "Get processes ranked by current disk I/O rate (bytes/sec), sampled from /proc/\u003cpid\u003e/io. Native, lightweight alternative to iotop-c.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Top processes by disk I/O",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Max processes to return (default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Processes ranked by disk I/O",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProcessList"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to sample process I/O",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/recyclebin": {
+            "get": {
+                "description": "Returns the size, file count and oldest/newest file of each user share's recycle bin (.Recycle.Bin), as kept by the Recycle Bin plugin for files deleted over SMB. installed is false and shares is empty when the plugin is not installed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Get recycle bin statistics",
+                "responses": {
+                    "200": {
+                        "description": "Recycle bin statistics",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecycleBinStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/recyclebin/{share}/empty": {
+            "post": {
+                "description": "Permanently delete every file in a user share's recycle bin. The .Recycle.Bin directory itself is kept. Requires the Recycle Bin plugin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Empty a share's recycle bin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share name",
+                        "name": "share",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recycle bin emptied",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecycleBinEmptyResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid share name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Share has no recycle bin",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to empty the recycle bin",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/registration": {
+            "get": {
+                "description": "Retrieve Unraid license/registration information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get registration status",
+                "responses": {
+                    "200": {
+                        "description": "Registration information",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Registration"
+                        }
+                    }
+                }
+            }
+        },
+        "/registration/key": {
+            "post": {
+                "description": "Install or replace the Unraid registration key on the flash drive, e.g. after migrating to a new flash drive or upgrading the license. Either upload the key file as base64 key_data with its file_name, or give the https url from the purchase or upgrade email; URLs are only fetched from lime-technology.com and unraid.net hosts. confirm must be true. Existing key files are kept with a .bak suffix and the registration is re-read right away. If the array is started, Unraid applies the new key the next time it is started.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Install a registration key file",
+                "parameters": [
+                    {
+                        "description": "Key file upload or URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegistrationKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Key file installed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegistrationKeyResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, key file or URL",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Download or install failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scripts": {
+            "get": {
+                "description": "List scripts registered with the agent, including their body, default arguments and environment. Unlike /user-scripts this does not need the User Scripts plugin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "List registered scripts",
+                "responses": {
+                    "200": {
+                        "description": "Registered scripts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Script"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to list scripts",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Script registry not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a named bash script with default arguments and environment. Scripts are stored under /boot/config/plugins/unraid-management-agent/scripts and survive reboots.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "Register script",
+                "parameters": [
+                    {
+                        "description": "Script",
+                        "name": "script",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.Script"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Script already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Script registry not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scripts/{name}": {
+            "get": {
+                "description": "Get a registered script by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "Get registered script",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Script name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Script",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Script"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Script registry not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace a registered script's body, arguments and environment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "Update registered script",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Script name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Script",
+                        "name": "script",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.Script"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Script registry not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a registered script",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "Delete registered script",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Script name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Script registry not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scripts/{name}/execute": {
+            "post": {
+                "description": "Run a registered script with bash. Arguments are passed without a shell; args in the body replace the script's default arguments and env is merged over its environment. With wait=true the call blocks until the script finishes or its timeout elapses and returns the output; otherwise the script runs in the background.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scripts"
+                ],
+                "summary": "Execute registered script",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Script name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Execution options",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScriptExecuteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Execution result",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserScriptExecuteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to execute",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserScriptExecuteResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Script registry not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "description": "List all managed Unraid system services and their status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "List available services",
+                "responses": {
+                    "200": {
+                        "description": "Service list with status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{name}/{action}": {
+            "post": {
+                "description": "Start, stop, or restart an Unraid system service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Control an Unraid service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service name (docker, libvirt, smb, nfs, ftp, sshd, nginx, syslog, ntpd, avahi, wireguard)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action (start, stop, restart)",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service action completed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid service or action",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to execute action",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/disk-thresholds": {
+            "get": {
+                "description": "Retrieve disk configuration settings including global temperature thresholds for HDD and SSD",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get extended disk settings with temperature thresholds",
+                "responses": {
+                    "200": {
+                        "description": "Extended disk settings with temp thresholds",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DiskSettingsExtended"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/disks": {
+            "get": {
+                "description": "Retrieve disk configuration settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get disk settings",
+                "responses": {
+                    "200": {
+                        "description": "Disk settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DiskSettings"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/docker": {
+            "get": {
+                "description": "Retrieve Docker daemon settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get Docker settings",
+                "responses": {
+                    "200": {
+                        "description": "Docker settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DockerSettings"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/mover": {
+            "get": {
+                "description": "Retrieve mover configuration, schedule, and current running status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get mover schedule and status",
+                "responses": {
+                    "200": {
+                        "description": "Mover settings and status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MoverSettings"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/network-services": {
+            "get": {
+                "description": "Retrieve status of all network services including SMB, NFS, FTP, SSH, Telnet, Avahi, WireGuard, etc.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get network services status",
+                "responses": {
+                    "200": {
+                        "description": "Network services status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NetworkServicesStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get network services status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/services": {
+            "get": {
+                "description": "Retrieve whether Docker and VM Manager services are enabled in Unraid settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get Docker and VM service enabled status",
+                "responses": {
+                    "200": {
+                        "description": "Service enabled status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/system": {
+            "get": {
+                "description": "Retrieve Unraid system settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get system settings",
+                "responses": {
+                    "200": {
+                        "description": "System settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SystemSettings"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Update Unraid system settings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Update system settings",
+                "parameters": [
+                    {
+                        "description": "System settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SystemSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Settings updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/vm": {
+            "get": {
+                "description": "Retrieve virtual machine manager settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get VM settings",
+                "responses": {
+                    "200": {
+                        "description": "VM settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMSettings"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shares": {
+            "get": {
+                "description": "Retrieve information about all user shares",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Get all shares",
+                "responses": {
+                    "200": {
+                        "description": "List of shares",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ShareInfo"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/shares/{name}/config": {
+            "get": {
+                "description": "Retrieve configuration for a specific user share",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Get share configuration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Share configuration",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShareConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid share name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Update configuration for a specific user share. Only the fields that are set are changed; other settings in the share's .cfg file are kept. use_cache must be yes, no, only or prefer, allocator highwater, mostfree or fillup, and floor (minimum free space) a number of KiB or a number with a K, M, G or T suffix. mover_tuning updates the share's override in the Mover Tuning plugin, which must be installed.\nWith dry_run=true nothing is written and a dto.ShareConfigDryRun is returned instead, listing the settings that would change, the files whose handling by the mover would change (largest first, up to 500) with totals per action, and the disks with less free space than a new minimum free space.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Configuration"
+                ],
+                "summary": "Update share configuration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Share configuration",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShareConfig"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report the effect of the change without applying it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Scan disks that are in standby during a dry run (spins them up)",
+                        "name": "spinup",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Configuration updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found (dry run)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shares/{name}/distribution": {
+            "get": {
+                "description": "Show how much of a user share is stored on each array disk and pool. Results are cached per disk and only rescanned when the disk's used space changes; disks in standby are not spun up unless spinup=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Get share distribution across disks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Scan disks that are in standby (spins them up)",
+                        "name": "spinup",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-disk usage of the share",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShareDistribution"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid share name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to scan share",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shares/{name}/export": {
+            "get": {
+                "description": "Get how a user share is exported over SMB and NFS: export mode, security mode, the SMB users with read-only or read-write access and the NFS host rules.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Get share export settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Share export settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShareExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid share name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to read the share configuration",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Turn SMB and NFS export of a user share on or off and change its security mode (public, secure or private), SMB user access lists and NFS host rules. Only the fields in the request are changed. The settings are applied through emhttpd, which rewrites the share configuration and reloads Samba and NFS, so clients see the change immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Update share export settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Export settings to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShareExportUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated share export settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShareExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to apply the settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/summary": {
+            "get": {
+                "description": "Returns a small, pre-aggregated payload for phone widgets and e-ink displays: CPU, RAM and CPU temperature, array state and usage, parity status, disk counts (total, above 55 °C, failing SMART), running/total containers and VMs, unread notifications and firing alert rules. Fields whose data has not been collected yet are omitted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get a compact dashboard summary",
+                "responses": {
+                    "200": {
+                        "description": "Dashboard summary",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Summary"
+                        }
+                    }
+                }
+            }
+        },
+        "/system": {
+            "get": {
+                "description": "Retrieve comprehensive system metrics including CPU, RAM, temperatures, and uptime",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get system information",
+                "responses": {
+                    "200": {
+                        "description": "System information",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SystemInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/cpu-topology": {
+            "get": {
+                "description": "Get the host's logical CPUs with their core, package and hyper-thread siblings, the CPUs isolated with the isolcpus kernel parameter, the vCPU and emulator pinning of every VM and the cpuset of every container. Each CPU lists the VMs and containers pinned to it. VMs or containers that cannot be read (libvirt or Docker not running) are reported in warnings.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get CPU topology and pinning",
+                "responses": {
+                    "200": {
+                        "description": "CPU topology",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CPUTopology"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to read the CPU topology",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/flash": {
+            "get": {
+                "description": "Retrieve health information for the USB flash boot drive",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get USB flash drive health",
+                "responses": {
+                    "200": {
+                        "description": "Flash drive health information",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FlashDriveHealth"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get flash health",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/maintenance": {
+            "get": {
+                "description": "Get whether a maintenance window is active, when it ends and how much collectors are throttled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get maintenance window",
+                "responses": {
+                    "200": {
+                        "description": "Maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Enter a maintenance window for planned work. Until it ends, alert rules are not evaluated, watchdog remediation and notification forwarding are suppressed, and Home Assistant binary sensors report unavailable. Set throttle_factor to slow all collectors down by that factor. Posting during an active window extends it from now. The window survives agent restarts and reboots.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Start maintenance window",
+                "parameters": [
+                    {
+                        "description": "Maintenance window",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "End the active maintenance window early and restore alerts, notifications and collector intervals. Alert rules that are still true fire on the next evaluation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "End maintenance window",
+                "responses": {
+                    "200": {
+                        "description": "Maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/processes": {
+            "get": {
+                "description": "Get the top resource-consuming processes with their name, user, cgroup and the Docker container they run in (if any)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Top processes by CPU or memory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rank by: cpu or memory (default: cpu)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of processes to return, 1-100 (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Top processes",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProcessList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort or limit",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to list processes",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/reboot": {
+            "post": {
+                "description": "Initiate a system reboot. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token and the consequences; repeat it with the X-Confirm-Token header within 60 seconds to reboot.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Reboot system",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the confirmation challenge",
+                        "name": "X-Confirm-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reboot initiated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Invalid or expired confirmation token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Confirmation required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmationChallenge"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to initiate reboot",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/shutdown": {
+            "post": {
+                "description": "Initiate a system shutdown. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token and the consequences; repeat it with the X-Confirm-Token header within 60 seconds to shut down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Shutdown system",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the confirmation challenge",
+                        "name": "X-Confirm-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shutdown initiated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Invalid or expired confirmation token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Confirmation required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmationChallenge"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to initiate shutdown",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/shutdown/orchestrated": {
+            "post": {
+                "description": "Safely power off the server: shut down running VMs, stop containers in reverse autostart order, stop the array, sync disks, then power off. Runs in the background with per-step progress on the shutdown_progress WebSocket topic. The sequence is aborted (server stays on) if the array cannot be stopped. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token; repeat it with the X-Confirm-Token header within 60 seconds.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Orchestrated shutdown",
+                "parameters": [
+                    {
+                        "description": "Confirmation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrchestratedShutdownRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token from the confirmation challenge",
+                        "name": "X-Confirm-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Shutdown sequence started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or missing confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Shutdown already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Invalid or expired confirmation token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Confirmation required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmationChallenge"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/swap": {
+            "get": {
+                "description": "Get the active swap areas from /proc/swaps (swap files, partitions and zram devices) with their size, usage and priority, the compression algorithm and ratio of zram devices, the vm.swappiness tunable and the swap file enabled through this API.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get swap and zram status",
+                "responses": {
+                    "200": {
+                        "description": "Swap status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SwapStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to read swap status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/swap/file": {
+            "post": {
+                "description": "Create a swap file of size_bytes in the root of a pool (/mnt/{pool}/swapfile) and enable it. On btrfs the file is created with copy-on-write disabled; ZFS pools are refused. The pool must keep 1 GiB free after allocation. The swap file is re-enabled whenever the agent starts with the array and disabled when it stops, so the pool can unmount. Requires confirm=true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Enable a swap file on a pool",
+                "parameters": [
+                    {
+                        "description": "Pool and size",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SwapFileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Swap file enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SwapFileConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, unsupported pool or not enough space",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "A swap file is already configured or the path is taken",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create the swap file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Turn off the swap file enabled through this API, moving its pages back into RAM, and stop re-enabling it at startup. The file is kept on the pool unless delete=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Disable the swap file",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also delete the swap file",
+                        "name": "delete",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Swap file disabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SwapFileConfig"
+                        }
+                    },
+                    "404": {
+                        "description": "No swap file configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to disable the swap file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/system/thermal": {
+            "get": {
+                "description": "Return the current, maximum and average temperature over the last 24 hours for the CPU, motherboard, every disk and every GPU, with their warning/critical thresholds and recent thermal events. A thermal event is raised when a sensor stays at or above a threshold for 5 minutes; disk thresholds come from the Unraid disk settings or per-disk overrides.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get temperature history",
+                "responses": {
+                    "200": {
+                        "description": "Temperature history and thermal events",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ThermalSummary"
+                        }
+                    },
+                    "503": {
+                        "description": "Alerting engine not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/temperatures": {
+            "get": {
+                "description": "Returns all detected temperature sensor readings from hwmon",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get all temperature sensors",
+                "responses": {
+                    "200": {
+                        "description": "Temperature readings",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TemperatureReading"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "description": "List the configured rsync and rclone transfer jobs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "List transfer jobs",
+                "responses": {
+                    "200": {
+                        "description": "Transfer jobs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TransferJob"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Transfer runner not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Define an rsync or rclone transfer job. Source, destination and flags are passed to the tool as separate arguments without a shell; options that run other programs (rsync -e/--rsh/--rsync-path, rclone --password-command/--rc) are rejected. Set schedule_interval_minutes or schedule_daily_at with enabled=true to run the job on a schedule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Create transfer job",
+                "parameters": [
+                    {
+                        "description": "Transfer job",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferJob"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transfer runner not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/runs": {
+            "get": {
+                "description": "Running transfers with their live progress, followed by the last 50 finished runs, newest first. Finished runs are kept in memory and cleared when the agent restarts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "List transfer runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only runs of this job",
+                        "name": "job_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer runs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TransferRun"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Transfer runner not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Get transfer job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer job",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transfer runner not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace a transfer job definition. A running transfer keeps its original settings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Update transfer job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer job",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferJob"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transfer runner not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a transfer job. Cancel a running transfer first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Delete transfer job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transfer runner not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/cancel": {
+            "post": {
+                "description": "Stop the running transfer of a job.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Cancel transfer job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer not running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transfer runner not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/run": {
+            "post": {
+                "description": "Start a transfer job now. Progress (bytes, percent, speed, ETA) is broadcast on the transfer_progress WebSocket topic and published to MQTT; poll GET /transfers/runs for the outcome.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Run transfer job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Transfer started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferRun"
+                        }
+                    },
+                    "400": {
+                        "description": "Tool not installed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer already running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Transfer runner not initialized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tuning": {
+            "get": {
+                "description": "Retrieve current kernel tuning parameters (turbo boost, disk cache, inotify, NIC offloads, ring buffers)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tuning"
+                ],
+                "summary": "Get system tuning parameters",
+                "responses": {
+                    "200": {
+                        "description": "Tuning parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TuningInfo"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tuning/disk-cache": {
+            "post": {
+                "description": "Set Linux vm.dirty_* kernel parameters for disk cache tuning",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tuning"
+                ],
+                "summary": "Set disk cache parameters",
+                "parameters": [
+                    {
+                        "description": "Disk cache request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DiskCacheRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Disk cache updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error applying tuning",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Tuning controller not available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tuning/inotify": {
+            "post": {
+                "description": "Set Linux inotify kernel parameters (max watches, instances, events)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tuning"
+                ],
+                "summary": "Set inotify limits",
+                "parameters": [
+                    {
+                        "description": "Inotify limits request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InotifyLimitsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Inotify limits updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error applying tuning",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Tuning controller not available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tuning/turbo": {
+            "post": {
+                "description": "Enable or disable Intel Turbo Boost / AMD Performance Boost",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tuning"
+                ],
+                "summary": "Set turbo boost state",
+                "parameters": [
+                    {
+                        "description": "Turbo boost request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TurboBoostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turbo boost set",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error applying tuning",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Tuning controller not available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/unassigned": {
+            "get": {
+                "description": "Retrieve all unassigned devices and remote shares",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unassigned Devices"
+                ],
+                "summary": "Get all unassigned devices",
+                "responses": {
+                    "200": {
+                        "description": "Unassigned devices and remote shares",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UnassignedDeviceList"
+                        }
+                    }
+                }
+            }
+        },
+        "/unassigned/devices": {
+            "get": {
+                "description": "Retrieve only unassigned devices (excludes remote shares)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unassigned Devices"
+                ],
+                "summary": "Get unassigned devices only",
+                "responses": {
+                    "200": {
+                        "description": "Unassigned devices",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unassigned/devices/{device}/format": {
+            "post": {
+                "description": "Erase an unassigned disk and create a single partition with an xfs, btrfs, or exfat filesystem. Requires confirm=true. Only disks in the unassigned devices list can be formatted, and never while the disk or any partition is mounted or passed through. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token listing what is erased; repeat it with the X-Confirm-Token header within 60 seconds.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unassigned Devices"
+                ],
+                "summary": "Format an unassigned device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device name (e.g. sdc, nvme1n1)",
+                        "name": "device",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Filesystem and confirm flag",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UnassignedFormatRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token from the confirmation challenge",
+                        "name": "X-Confirm-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Device formatted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid device name, request body, or missing confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Device not found in the unassigned devices list",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Invalid or expired confirmation token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Confirmation required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmationChallenge"
+                        }
+                    },
+                    "500": {
+                        "description": "Device mounted or passed through, or format failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/unassigned/devices/{device}/mount": {
+            "post": {
+                "description": "Mount an unassigned disk or one of its partitions through the Unassigned Devices plugin, using the mount point and options configured in the plugin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unassigned Devices"
+                ],
+                "summary": "Mount an unassigned device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device or partition name (e.g. sdc, sdc1, nvme1n1p1)",
+                        "name": "device",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Device mounted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid device name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to mount",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/unassigned/devices/{device}/unmount": {
+            "post": {
+                "description": "Unmount an unassigned disk or one of its partitions through the Unassigned Devices plugin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unassigned Devices"
+                ],
+                "summary": "Unmount an unassigned device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device or partition name (e.g. sdc, sdc1, nvme1n1p1)",
+                        "name": "device",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Device unmounted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid device name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to unmount",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/unassigned/remote-shares": {
+            "get": {
+                "description": "Retrieve only remote shares (excludes local unassigned devices)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unassigned Devices"
+                ],
+                "summary": "Get remote shares only",
+                "responses": {
+                    "200": {
+                        "description": "Remote shares",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unassigned/remote-shares/mount": {
+            "post": {
+                "description": "Mount an Unassigned Devices SMB/NFS remote share that is configured in the plugin. The source must match a value from the remote_shares list: \"//server/share\" for SMB or \"server:/export\" for NFS.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unassigned Devices"
+                ],
+                "summary": "Mount a remote share",
+                "parameters": [
+                    {
+                        "description": "Remote share source",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RemoteShareActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Remote share mounted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to mount",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/unassigned/remote-shares/unmount": {
+            "post": {
+                "description": "Unmount an Unassigned Devices SMB/NFS remote share that is currently mounted. The source must match a value from the remote_shares list: \"//server/share\" for SMB or \"server:/export\" for NFS.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Unassigned Devices"
+                ],
+                "summary": "Unmount a remote share",
+                "parameters": [
+                    {
+                        "description": "Remote share source",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RemoteShareActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Remote share unmounted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to unmount",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/updates": {
+            "get": {
+                "description": "Retrieve Unraid OS and plugin update availability information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Updates"
+                ],
+                "summary": "Get update availability status",
+                "responses": {
+                    "200": {
+                        "description": "Update availability status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get update status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ups": {
+            "get": {
+                "description": "Retrieve UPS status information from apcupsd",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UPS"
+                ],
+                "summary": "Get UPS status",
+                "responses": {
+                    "200": {
+                        "description": "UPS status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UPSStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/user-scripts": {
+            "get": {
+                "description": "Retrieve a list of all available user scripts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Scripts"
+                ],
+                "summary": "Get user scripts",
+                "responses": {
+                    "200": {
+                        "description": "List of user scripts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.UserScriptInfo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to list scripts",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user-scripts/{name}/execute": {
+            "post": {
+                "description": "Execute a user script by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Scripts"
+                ],
+                "summary": "Execute user script",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Script name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Execution options",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserScriptExecuteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Execution result",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserScriptExecuteResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to execute",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserScriptExecuteResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm": {
+            "get": {
+                "description": "Retrieve information about all virtual machines. Supports field selection and pagination; the X-Total-Count header holds the number of VMs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Get all VMs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON fields to return per item (e.g. name,state)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum items to return (default all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of VMs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.VMInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields, limit or offset",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{id}": {
+            "get": {
+                "description": "Retrieve information about a specific virtual machine by ID or name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Get specific VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM information",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "VM not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/clone": {
+            "post": {
+                "description": "Clone a VM including disk images (source VM must be shut off)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Clone a virtual machine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name for the cloned VM",
+                        "name": "clone_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM cloned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to clone VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/cpu-pinning": {
+            "put": {
+                "description": "Replace the vCPU pinning of a VM. The VM definition is updated and a running VM is re-pinned immediately; vCPUs that are not listed are unpinned. emulator_cpuset optionally pins the QEMU emulator threads. Requires confirm=true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Set VM vCPU pinning",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "vCPU pinning",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMCPUPinningRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pinning updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CPUPinningResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, unknown CPU or vCPU, or not confirmed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update the pinning",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/disks": {
+            "get": {
+                "description": "List the file-backed disks of a VM (CD-ROMs and passed-through block devices are skipped) with the image format, the virtual size seen by the guest and the space the image occupies on disk, as reported by qemu-img. Each disk includes its running or most recent format conversion.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "List VM disk images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM disk images",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMDiskImageList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to list disk images",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/disks/{target}/convert": {
+            "post": {
+                "description": "Convert a VM disk image to qcow2 or raw with qemu-img convert, in the background. The VM must be shut off and have no snapshots. The converted image is written next to the original (vdisk1.img becomes vdisk1.qcow2); when it completes the VM definition is switched to it and the original is kept for you to remove. Follow the conversion in GET /vm/{name}/disks; starting the VM before it finishes discards the converted image.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Convert a VM disk image format",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disk target device (e.g. hdc)",
+                        "name": "target",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target format",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMDiskConvertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Conversion started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMDiskConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Disk not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "VM not shut off or conversion running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start the conversion",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/disks/{target}/resize": {
+            "post": {
+                "description": "Grow the virtual size of a VM disk image with qemu-img resize. The VM must be shut off; shrinking is refused. Extend the partition and filesystem from inside the guest afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Grow a VM disk image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disk target device (e.g. hdc)",
+                        "name": "target",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New virtual size",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMDiskResizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resized disk image",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMDiskImage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or size not larger than current",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Disk not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "VM not shut off or conversion running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to resize",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/force-stop": {
+            "post": {
+                "description": "Force stop a specific virtual machine by name (equivalent to pulling the power cord)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Force stop VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM force stopped",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to force stop VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/hibernate": {
+            "post": {
+                "description": "Hibernate a specific virtual machine by name. With async=true the VM is hibernated in a background job tracked under /jobs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Hibernate VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job and return 202 with the job",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM hibernated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "202": {
+                        "description": "Hibernation job queued",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to hibernate VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/pause": {
+            "post": {
+                "description": "Pause a specific virtual machine by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Pause VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM paused",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to pause VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/reset": {
+            "post": {
+                "description": "Hard reset a specific virtual machine by name (equivalent to the physical reset button). The VM must be running. Requires confirm=true in the request body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Reset VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Confirm flag",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMResetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM reset",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name or missing confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reset VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/restart": {
+            "post": {
+                "description": "Restart a specific virtual machine by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Restart VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM restarted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to restart VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/resume": {
+            "post": {
+                "description": "Resume a paused virtual machine by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Resume VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM resumed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to resume VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/snapshot": {
+            "post": {
+                "description": "Create a snapshot of a virtual machine",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Create a VM snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot name",
+                        "name": "snapshot_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot description",
+                        "name": "description",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/snapshots": {
+            "get": {
+                "description": "List all snapshots for a virtual machine",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "List VM snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot list",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMSnapshotList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to list snapshots",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/snapshots/{snapshot_name}": {
+            "delete": {
+                "description": "Delete a specific snapshot of a virtual machine",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Delete a VM snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot name",
+                        "name": "snapshot_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot deleted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/snapshots/{snapshot_name}/restore": {
+            "post": {
+                "description": "Restore a virtual machine to a previously created snapshot. WARNING: This reverts the VM to the snapshot state.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Restore a VM snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot name",
+                        "name": "snapshot_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot restored",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to restore snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/start": {
+            "post": {
+                "description": "Start a specific virtual machine by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Start VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/stop": {
+            "post": {
+                "description": "Gracefully stop a specific virtual machine by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Stop VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VM stopped",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to stop VM",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/usb/attach": {
+            "post": {
+                "description": "Attach a host USB device to a running VM without restarting it. The device is identified as vendor:product (see host_usb_devices in GET /settings/vm). The change applies to the live VM only and is undone when the VM shuts down.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Hot-plug a USB device into a VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "USB device",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMUSBDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "USB device attached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name or device ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to attach USB device (for example, VM not running)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vm/{name}/usb/detach": {
+            "post": {
+                "description": "Detach a USB device from a running VM without changing the VM's saved definition.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VMs"
+                ],
+                "summary": "Unplug a USB device from a VM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VM name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "USB device",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VMUSBDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "USB device detached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid VM name or device ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to detach USB device (for example, VM not running)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "description": "Establish a WebSocket connection for real-time system updates\n\n**Connection:** ` + "`" + `ws://localhost:8043/api/v1/ws` + "`" + `\n\n**Event Format:**\n` + "`" + `` + "`" + `` + "`" + `json\n{\n\"event\": \"update\",\n\"timestamp\": \"2025-01-01T00:00:00Z\",\n\"data\": { ... }\n}\n` + "`" + `` + "`" + `` + "`" + `\n\n**Supported Events:**\n- system_update: System metrics (CPU, RAM, temps)\n- array_status_update: Array status changes\n- disk_list_update: Disk information updates\n- container_list_update: Docker container updates\n- vm_list_update: VM status updates\n- ups_status_update: UPS status updates\n- gpu_metrics_update: GPU metrics updates\n- network_list_update: Network interface updates\n- hardware_update: Hardware information updates\n- notifications_update: Notification updates\n- zfs_pools_update: ZFS pool updates",
+                "tags": [
+                    "WebSocket"
+                ],
+                "summary": "WebSocket connection",
+                "responses": {}
+            }
+        },
+        "/zfs/arc": {
+            "get": {
+                "description": "Retrieve ZFS Adaptive Replacement Cache (ARC) statistics",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ZFS"
+                ],
+                "summary": "Get ZFS ARC statistics",
+                "responses": {
+                    "200": {
+                        "description": "ZFS ARC statistics",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ZFSARCStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/zfs/datasets": {
+            "get": {
+                "description": "Retrieve information about all ZFS datasets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ZFS"
+                ],
+                "summary": "Get all ZFS datasets",
+                "responses": {
+                    "200": {
+                        "description": "List of ZFS datasets",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ZFSDataset"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/zfs/pools": {
+            "get": {
+                "description": "Retrieve information about all ZFS pools",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ZFS"
+                ],
+                "summary": "Get all ZFS pools",
+                "responses": {
+                    "200": {
+                        "description": "List of ZFS pools",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ZFSPool"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/zfs/pools/{name}": {
+            "get": {
+                "description": "Retrieve information about a specific ZFS pool by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ZFS"
+                ],
+                "summary": "Get specific ZFS pool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pool name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZFS pool information",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ZFSPool"
+                        }
+                    },
+                    "404": {
+                        "description": "Pool not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/zfs/snapshots": {
+            "get": {
+                "description": "Retrieve information about all ZFS snapshots. Supports field selection and pagination; the X-Total-Count header holds the number of snapshots.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ZFS"
+                ],
+                "summary": "Get all ZFS snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated JSON fields to return per item (e.g. name,state)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum items to return (default all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of ZFS snapshots",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ZFSSnapshot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields, limit or offset",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.selfTestResponse": {
+            "type": "object",
+            "properties": {
+                "capabilities": {
+                    "$ref": "#/definitions/dto.Capabilities"
+                },
+                "overall_state": {
+                    "$ref": "#/definitions/dto.SourceState"
+                },
+                "subsystems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SourceStatus"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "unraid_version": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileConfig": {
+            "type": "object",
+            "properties": {
+                "acme": {
+                    "description": "ACME certificate automation for the HTTPS listener",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigACME"
+                        }
+                    ]
+                },
+                "auth": {
+                    "description": "Auth controls password login sessions for the REST API",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigAuth"
+                        }
+                    ]
+                },
+                "bind_address": {
+                    "type": "string"
+                },
+                "confirm_destructive": {
+                    "description": "ConfirmDestructive requires a confirmation token for destructive REST calls.",
+                    "type": "boolean"
+                },
+                "cors_origin": {
+                    "description": "CORS",
+                    "type": "string"
+                },
+                "debug": {
+                    "type": "boolean"
+                },
+                "diagnostics_targets": {
+                    "description": "DiagnosticsTargets is a comma-separated allow list for ping, DNS, and\nHTTP diagnostics.",
+                    "type": "string"
+                },
+                "disable_collectors": {
+                    "type": "string"
+                },
+                "discovery": {
+                    "description": "Discovery (zeroconf/mDNS) configuration",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigDiscovery"
+                        }
+                    ]
+                },
+                "event_replay_size": {
+                    "description": "EventReplaySize is the number of events kept per topic for replay to\nnew WebSocket clients.",
+                    "type": "integer"
+                },
+                "gpu_backends": {
+                    "description": "GPUBackends is a comma-separated list of GPU vendors to probe.",
+                    "type": "string"
+                },
+                "graphite": {
+                    "description": "Graphite plaintext / StatsD export",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigGraphite"
+                        }
+                    ]
+                },
+                "heartbeat": {
+                    "description": "Heartbeat (healthchecks.io / Uptime Kuma) pings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigHeartbeat"
+                        }
+                    ]
+                },
+                "influxdb": {
+                    "description": "InfluxDB line-protocol export",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigInfluxDB"
+                        }
+                    ]
+                },
+                "intervals": {
+                    "description": "Collection intervals (seconds, 0 = disabled)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigIntervals"
+                        }
+                    ]
+                },
+                "language": {
+                    "type": "string"
+                },
+                "log_format": {
+                    "type": "string"
+                },
+                "log_level": {
+                    "type": "string"
+                },
+                "logs_dir": {
+                    "type": "string"
+                },
+                "low_power_mode": {
+                    "description": "Power mode",
+                    "type": "boolean"
+                },
+                "mcp": {
+                    "description": "MCP access control",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigMCP"
+                        }
+                    ]
+                },
+                "mqtt": {
+                    "description": "MQTT configuration",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigMQTT"
+                        }
+                    ]
+                },
+                "port": {
+                    "description": "Server settings",
+                    "type": "integer"
+                },
+                "pprof": {
+                    "description": "Pprof serves the Go runtime profiles at /debug/pprof.",
+                    "type": "boolean"
+                },
+                "read_only": {
+                    "description": "ReadOnly blocks all state-changing MCP tools (AI agents can only read).",
+                    "type": "boolean"
+                },
+                "speedtest_server": {
+                    "type": "string"
+                },
+                "speedtest_tool": {
+                    "description": "Scheduled bandwidth tests",
+                    "type": "string"
+                },
+                "tls_cert_file": {
+                    "description": "TLS: serve HTTPS when both a certificate and key file are provided.",
+                    "type": "string"
+                },
+                "tls_key_file": {
+                    "type": "string"
+                },
+                "unhealthy_restart": {
+                    "description": "Automatic restart of containers stuck unhealthy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigUnhealthyRestart"
+                        }
+                    ]
+                },
+                "wan_probes": {
+                    "description": "WANProbes is a comma-separated list of hosts pinged by the wan collector.",
+                    "type": "string"
+                },
+                "wol_devices": {
+                    "description": "WOLDevices is a comma-separated list of name=MAC[@broadcast]\nWake-on-LAN targets.",
+                    "type": "string"
+                },
+                "ws_queue_size": {
+                    "description": "WebSocket send queue per client and the policy when it is full.",
+                    "type": "integer"
+                },
+                "ws_slow_client": {
+                    "type": "string"
+                },
+                "zabbix": {
+                    "description": "Zabbix sender / low-level discovery",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FileConfigZabbix"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.FileConfigACME": {
+            "type": "object",
+            "properties": {
+                "directory": {
+                    "type": "string"
+                },
+                "dns_hook": {
+                    "type": "string"
+                },
+                "dns_provider": {
+                    "type": "string"
+                },
+                "dns_token": {
+                    "type": "string"
+                },
+                "domains": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "renew_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FileConfigAuth": {
+            "type": "object",
+            "properties": {
+                "access_ttl": {
+                    "type": "integer"
+                },
+                "refresh_ttl": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.FileConfigDiscovery": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "service_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileConfigGraphite": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileConfigHeartbeat": {
+            "type": "object",
+            "properties": {
+                "critical_sources": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileConfigInfluxDB": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string"
+                },
+                "database": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "insecure_skip_verify": {
+                    "type": "boolean"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "org": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "retention_policy": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FileConfigIntervals": {
+            "type": "object",
+            "properties": {
+                "array": {
+                    "type": "integer"
+                },
+                "btrfs": {
+                    "type": "integer"
+                },
+                "connect": {
+                    "type": "integer"
+                },
+                "disk": {
+                    "type": "integer"
+                },
+                "dns": {
+                    "type": "integer"
+                },
+                "docker": {
+                    "type": "integer"
+                },
+                "docker_networks": {
+                    "type": "integer"
+                },
+                "docker_update": {
+                    "type": "integer"
+                },
+                "fancontrol": {
+                    "type": "integer"
+                },
+                "gpu": {
+                    "type": "integer"
+                },
+                "guest_traffic": {
+                    "type": "integer"
+                },
+                "hardware": {
+                    "type": "integer"
+                },
+                "ipmi": {
+                    "type": "integer"
+                },
+                "mover": {
+                    "type": "integer"
+                },
+                "network": {
+                    "type": "integer"
+                },
+                "notification": {
+                    "type": "integer"
+                },
+                "nut": {
+                    "type": "integer"
+                },
+                "os_update": {
+                    "type": "integer"
+                },
+                "plugin_update": {
+                    "type": "integer"
+                },
+                "pools": {
+                    "type": "integer"
+                },
+                "recycle_bin": {
+                    "type": "integer"
+                },
+                "registration": {
+                    "type": "integer"
+                },
+                "remote_mounts": {
+                    "type": "integer"
+                },
+                "shares": {
+                    "type": "integer"
+                },
+                "speedtest": {
+                    "type": "integer"
+                },
+                "system": {
+                    "type": "integer"
+                },
+                "tuning": {
+                    "type": "integer"
+                },
+                "unassigned": {
+                    "type": "integer"
+                },
+                "ups": {
+                    "type": "integer"
+                },
+                "vm": {
+                    "type": "integer"
+                },
+                "wan": {
+                    "type": "integer"
+                },
+                "zfs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FileConfigMCP": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "http_tools": {
+                    "type": "string"
+                },
+                "stdio_tools": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileConfigMQTT": {
+            "type": "object",
+            "properties": {
+                "broker": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "entity_name_template": {
+                    "type": "string"
+                },
+                "ha_disabled_categories": {
+                    "type": "string"
+                },
+                "ha_prefix": {
+                    "type": "string"
+                },
+                "home_assistant": {
+                    "type": "boolean"
+                },
+                "insecure_skip_verify": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "qos": {
+                    "type": "integer"
+                },
+                "retain": {
+                    "type": "boolean"
+                },
+                "topic_prefix": {
+                    "type": "string"
+                },
+                "topic_template": {
+                    "description": "TopicTemplate, EntityNameTemplate and UniqueIDTemplate customize the\ntopic layout and HA entity naming; HADisabledCategories is a\ncomma-separated list of topic categories left out of HA discovery.",
+                    "type": "string"
+                },
+                "unique_id_template": {
+                    "type": "string"
+                },
+                "use_tls": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileConfigUnhealthyRestart": {
+            "type": "object",
+            "properties": {
+                "after_minutes": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exclude": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileConfigZabbix": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "server": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccessURL": {
+            "type": "object",
+            "properties": {
+                "ipv4": {
+                    "type": "string"
+                },
+                "ipv6": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"lan\", \"wan\", \"wireguard\", \"mdns\", \"ipv6\", \"other\"",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ActionRef": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "restart_container"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string",
+                    "example": "abc123def456"
+                }
+            }
+        },
+        "dto.ActionResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "succeeded": {
+                    "type": "boolean"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AgentConfigStatus": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "Config is the parsed file with secrets (MQTT password, MCP API key)\nreplaced by RedactedSecret."
+                },
+                "exists": {
+                    "type": "boolean"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_reload": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "restart_required": {
+                    "description": "RestartRequired lists settings changed since start-up that only take\neffect after the agent restarts.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AgentIncident": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "at": {
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AgentLog": {
+            "type": "object",
+            "properties": {
+                "correlation_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AgentLogEntry"
+                    }
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "level": {
+                    "type": "string",
+                    "example": "info"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.AgentLogEntry": {
+            "type": "object",
+            "properties": {
+                "correlation_id": {
+                    "description": "CorrelationID is the request ID, job ID or collector the entry was\nlogged for, if any.",
+                    "type": "string",
+                    "example": "collector:docker"
+                },
+                "level": {
+                    "description": "debug, info, warning or error",
+                    "type": "string",
+                    "example": "warning"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "MQTT: connection lost, reconnecting"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AgentMQTTStats": {
+            "type": "object",
+            "properties": {
+                "connected": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "messages_errors": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "messages_per_minute": {
+                    "description": "MessagesPerMinute is averaged since the agent started.",
+                    "type": "number",
+                    "example": 86.8
+                },
+                "messages_sent": {
+                    "type": "integer",
+                    "example": 125000
+                }
+            }
+        },
+        "dto.AgentMessage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "tool_call_id": {
+                    "type": "string"
+                },
+                "tool_calls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AgentMsgToolCall"
+                    }
+                }
+            }
+        },
+        "dto.AgentMsgToolCall": {
+            "type": "object",
+            "properties": {
+                "args": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AgentPreference": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/dto.PreferenceStatus"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AgentRequestStats": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "responses with status \u003e= 500",
+                    "type": "integer",
+                    "example": 12
+                },
+                "routes": {
+                    "description": "Routes are sorted by request count, busiest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AgentRouteStats"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 15230
+                }
+            }
+        },
+        "dto.AgentRouteStats": {
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "type": "number",
+                    "example": 2.4
+                },
+                "client_errors": {
+                    "description": "4xx responses",
+                    "type": "integer",
+                    "example": 3
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 420
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "max_latency_ms": {
+                    "type": "number",
+                    "example": 180.5
+                },
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "route": {
+                    "type": "string",
+                    "example": "/api/v1/docker/{id}"
+                },
+                "server_errors": {
+                    "description": "5xx responses",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "dto.AgentRuntimeStats": {
+            "type": "object",
+            "properties": {
+                "gc_pause_total_ms": {
+                    "type": "number",
+                    "example": 45.2
+                },
+                "go_version": {
+                    "type": "string",
+                    "example": "go1.26.0"
+                },
+                "goroutines": {
+                    "type": "integer",
+                    "example": 84
+                },
+                "heap_alloc_bytes": {
+                    "type": "integer",
+                    "example": 18874368
+                },
+                "heap_sys_bytes": {
+                    "type": "integer",
+                    "example": 33554432
+                },
+                "last_gc_pause_ms": {
+                    "type": "number",
+                    "example": 0.12
+                },
+                "num_gc": {
+                    "type": "integer",
+                    "example": 312
+                },
+                "sys_bytes": {
+                    "type": "integer",
+                    "example": 52428800
+                }
+            }
+        },
+        "dto.AgentSession": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pending_approval": {
+                    "$ref": "#/definitions/dto.ApprovalRequest"
+                },
+                "plan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlanStep"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/dto.AgentSessionStatus"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AgentStep"
+                    }
+                },
+                "tokens_used": {
+                    "type": "integer"
+                },
+                "transcript": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AgentMessage"
+                    }
+                }
+            }
+        },
+        "dto.AgentSessionStatus": {
+            "type": "string",
+            "enum": [
+                "running",
+                "completed",
+                "failed",
+                "cancelled",
+                "awaiting_approval"
+            ],
+            "x-enum-varnames": [
+                "SessionRunning",
+                "SessionCompleted",
+                "SessionFailed",
+                "SessionCancelled",
+                "SessionAwaitingApproval"
+            ]
+        },
+        "dto.AgentStats": {
+            "type": "object",
+            "properties": {
+                "mqtt": {
+                    "$ref": "#/definitions/dto.AgentMQTTStats"
+                },
+                "pprof_enabled": {
+                    "type": "boolean"
+                },
+                "requests": {
+                    "$ref": "#/definitions/dto.AgentRequestStats"
+                },
+                "runtime": {
+                    "$ref": "#/definitions/dto.AgentRuntimeStats"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "uptime_seconds": {
+                    "type": "integer",
+                    "example": 86400
+                },
+                "websocket": {
+                    "$ref": "#/definitions/dto.AgentWSStats"
+                }
+            }
+        },
+        "dto.AgentStep": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "thought": {
+                    "type": "string"
+                },
+                "tool_calls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AgentToolCall"
+                    }
+                }
+            }
+        },
+        "dto.AgentToolCall": {
+            "type": "object",
+            "properties": {
+                "args": {
+                    "description": "raw JSON arguments",
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "risk_tier": {
+                    "$ref": "#/definitions/dto.RiskTier"
+                }
+            }
+        },
+        "dto.AgentUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "Confirm must be true to install; the agent restarts during the update.",
+                    "type": "boolean"
+                },
+                "dry_run": {
+                    "description": "DryRun downloads and verifies the release bundle without installing it.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.AgentUpdateStatus": {
+            "type": "object",
+            "properties": {
+                "bundle_url": {
+                    "type": "string"
+                },
+                "checksum": {
+                    "description": "Checksum is the published SHA-256 checksum of the bundle, prefixed with\n\"sha256:\".",
+                    "type": "string"
+                },
+                "checksum_verified": {
+                    "type": "boolean"
+                },
+                "current_version": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "latest_version": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "release_url": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "update_available": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.AgentWSStats": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "dropped_messages": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "evicted_clients": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "queue_size": {
+                    "type": "integer",
+                    "example": 256
+                },
+                "slow_client_policy": {
+                    "type": "string",
+                    "enum": [
+                        "disconnect",
+                        "drop-oldest"
+                    ],
+                    "example": "disconnect"
+                }
+            }
+        },
+        "dto.AlertEvent": {
+            "type": "object",
+            "properties": {
+                "fired_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                },
+                "rule_name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "\"firing\" or \"resolved\"",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AlertHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AlertEvent"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AlertRule": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "description": "shoutrrr URLs or \"unraid\" for local notification",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cooldown_minutes": {
+                    "description": "Minutes between re-fires (default 5)",
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "description": "Must be true for this many seconds before firing (0 = immediate)",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "expression": {
+                    "description": "expr-lang expression, e.g. \"CPU \u003e 90 \u0026\u0026 ArrayState == 'Started'\"",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "\"info\", \"warning\", \"critical\"",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AlertStatus": {
+            "type": "object",
+            "properties": {
+                "eval_count": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                },
+                "rule_name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "\"ok\", \"pending\", \"firing\"",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AlertsStatusResponse": {
+            "type": "object",
+            "properties": {
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AlertStatus"
+                    }
+                }
+            }
+        },
+        "dto.ApprovalRequest": {
+            "type": "object",
+            "properties": {
+                "action_id": {
+                    "type": "string"
+                },
+                "args": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "risk_tier": {
+                    "$ref": "#/definitions/dto.RiskTier"
+                },
+                "tool_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ArrayEncryptionStatus": {
+            "type": "object",
+            "properties": {
+                "array_state": {
+                    "type": "string",
+                    "example": "Stopped"
+                },
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.EncryptedDevice"
+                    }
+                },
+                "encrypted": {
+                    "description": "At least one device is encrypted",
+                    "type": "boolean",
+                    "example": true
+                },
+                "keyfile_present": {
+                    "description": "Unraid's keyfile exists in RAM",
+                    "type": "boolean",
+                    "example": false
+                },
+                "locked": {
+                    "description": "At least one encrypted device is not unlocked",
+                    "type": "boolean",
+                    "example": true
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ArrayStatus": {
+            "type": "object",
+            "properties": {
+                "days_until_full": {
+                    "type": "number",
+                    "example": 2560.4
+                },
+                "fill_rate_bytes_per_day": {
+                    "description": "Fill forecast from the trend of used space over the last 7 days; nil\nuntil 6 hours of history exist. DaysUntilFull is nil while usage is\nflat or shrinking.",
+                    "type": "number",
+                    "example": 21474836480
+                },
+                "free_bytes": {
+                    "type": "integer",
+                    "example": 54975581388800
+                },
+                "num_data_disks": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "num_disks": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "num_parity_disks": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "parity_check_avg_speed_bytes_per_sec": {
+                    "description": "Average while running since observed start",
+                    "type": "number",
+                    "example": 172000000
+                },
+                "parity_check_eta_seconds": {
+                    "type": "integer",
+                    "example": 62700
+                },
+                "parity_check_finish_at": {
+                    "type": "string"
+                },
+                "parity_check_position_bytes": {
+                    "description": "Parity operation position, speed and estimated completion; set only\nwhile a check, sync or rebuild is in progress. Speeds are in bytes per\nsecond; the ETA and finish time are omitted while paused.",
+                    "type": "integer",
+                    "example": 4398046511104
+                },
+                "parity_check_progress": {
+                    "type": "number",
+                    "example": 0
+                },
+                "parity_check_size_bytes": {
+                    "type": "integer",
+                    "example": 16000900661248
+                },
+                "parity_check_speed_bytes_per_sec": {
+                    "description": "Current (smoothed) speed",
+                    "type": "number",
+                    "example": 185000000
+                },
+                "parity_check_status": {
+                    "type": "string",
+                    "example": "idle"
+                },
+                "parity_valid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "source_status": {
+                    "description": "SourceStatus is non-nil when the data source is degraded or unavailable.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.SourceStatus"
+                        }
+                    ]
+                },
+                "state": {
+                    "type": "string",
+                    "example": "Started"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_bytes": {
+                    "type": "integer",
+                    "example": 100862164623360
+                },
+                "used_percent": {
+                    "type": "number",
+                    "example": 45.5
+                }
+            }
+        },
+        "dto.ArrayStopImpact": {
+            "type": "object",
+            "properties": {
+                "array_state": {
+                    "type": "string",
+                    "example": "Started"
+                },
+                "containers": {
+                    "description": "Containers are the running containers; Unraid stops the Docker service\nwith the array, so all of them stop.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerDependencies"
+                    }
+                },
+                "notes": {
+                    "description": "Notes explain the impact in plain language.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "shares": {
+                    "description": "Shares are the SMB/NFS exported user shares that go offline.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Media",
+                        "appdata"
+                    ]
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "vms": {
+                    "description": "VMs are the running VMs; the VM service stops with the array.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VMArrayDependency"
+                    }
+                }
+            }
+        },
+        "dto.ArrayUnlockRequest": {
+            "type": "object",
+            "properties": {
+                "keep_keyfile": {
+                    "description": "Keep the key in RAM after the array starts",
+                    "type": "boolean",
+                    "example": false
+                },
+                "keyfile": {
+                    "description": "Base64-encoded keyfile contents",
+                    "type": "string"
+                },
+                "passphrase": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is the operation performed, e.g. \"POST /api/v1/docker/{id}/start\",\nthe MCP tool name, the MQTT command path, or the webhook action.",
+                    "type": "string"
+                },
+                "client": {
+                    "description": "Client identifies the caller: source IP for REST, session ID for MCP,\nbroker address for MQTT, webhook name for webhooks.",
+                    "type": "string"
+                },
+                "detail": {
+                    "description": "Detail carries a short error or status message.",
+                    "type": "string"
+                },
+                "result": {
+                    "description": "Result is \"success\", \"failure\", \"denied\", or \"approved\".",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the interface the action arrived on: \"rest\", \"mcp\", \"mqtt\", or\n\"webhook\".",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.AuditSource"
+                        }
+                    ]
+                },
+                "target": {
+                    "description": "Target is the entity the action was applied to (container, VM, disk, ...).",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Timestamp is when the action completed.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditEntry"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AuditSource": {
+            "type": "string",
+            "enum": [
+                "rest",
+                "mcp",
+                "mqtt",
+                "webhook"
+            ],
+            "x-enum-varnames": [
+                "AuditSourceREST",
+                "AuditSourceMCP",
+                "AuditSourceMQTT",
+                "AuditSourceWebhook"
+            ]
+        },
+        "dto.AuthLoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "secret"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "root"
+                }
+            }
+        },
+        "dto.AuthRefreshRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuthSession": {
+            "type": "object",
+            "properties": {
+                "auth_required": {
+                    "description": "AuthRequired is true when the API rejects unauthenticated requests.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "authenticated": {
+                    "description": "Authenticated is true when the request carries a valid access token or\nAPI key.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "method": {
+                    "description": "Method is \"token\" (login session), \"api_key\" or \"none\".",
+                    "type": "string",
+                    "example": "token"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "root"
+                }
+            }
+        },
+        "dto.AuthTokens": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "description": "RefreshToken can be used once; each refresh returns a new one.",
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "root"
+                }
+            }
+        },
+        "dto.AvailableDriveSensor": {
+            "type": "object",
+            "properties": {
+                "device": {
+                    "type": "string",
+                    "example": "sdb"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "disk1"
+                },
+                "spun_down": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "temp_celsius": {
+                    "type": "number",
+                    "example": 38
+                }
+            }
+        },
+        "dto.AvailableTempSensor": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "Tctl"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
+                },
+                "plausible": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "temp_celsius": {
+                    "type": "number",
+                    "example": 45
+                }
+            }
+        },
+        "dto.BIOSInfo": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "0xF0000"
+                },
+                "characteristics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "12/25/2023"
+                },
+                "revision": {
+                    "type": "string",
+                    "example": "5.14"
+                },
+                "rom_size": {
+                    "type": "string",
+                    "example": "16 MB"
+                },
+                "runtime_size": {
+                    "type": "string",
+                    "example": "64 KB"
+                },
+                "vendor": {
+                    "type": "string",
+                    "example": "American Megatrends International, LLC."
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.4"
+                }
+            }
+        },
+        "dto.BaseboardInfo": {
+            "type": "object",
+            "properties": {
+                "asset_tag": {
+                    "type": "string",
+                    "example": "To be filled by O.E.M."
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "location_in_chassis": {
+                    "type": "string",
+                    "example": "Default string"
+                },
+                "manufacturer": {
+                    "type": "string",
+                    "example": "Supermicro"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "X11SCL-F"
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "OM123456789"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Motherboard"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.02"
+                }
+            }
+        },
+        "dto.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ActionRef"
+                    }
+                },
+                "concurrency": {
+                    "description": "Concurrency is the number of actions run at the same time (0 uses the default).",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.BatchResult": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ActionResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BootInfo": {
+            "type": "object",
+            "properties": {
+                "boot_pool": {
+                    "description": "ZFS boot pool name when present",
+                    "type": "string",
+                    "example": "boot"
+                },
+                "boot_pool_type": {
+                    "description": "\"single\", \"mirror\" when on a ZFS boot pool",
+                    "type": "string",
+                    "example": "mirror"
+                },
+                "device": {
+                    "description": "Backing device of the boot partition",
+                    "type": "string",
+                    "example": "sda1"
+                },
+                "device_type": {
+                    "description": "\"usb\" (flash) or \"internal\"",
+                    "type": "string",
+                    "example": "usb"
+                },
+                "filesystem": {
+                    "description": "Boot partition filesystem",
+                    "type": "string",
+                    "example": "vfat"
+                }
+            }
+        },
+        "dto.BootSequenceConfig": {
+            "description": "Boot sequence: wait for preconditions, unlock and start the array, then start containers and VMs",
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "description": "Started in this order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mariadb",
+                        "nextcloud"
+                    ]
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "key_file": {
+                    "type": "string",
+                    "example": "/mnt/disks/keystick/keyfile"
+                },
+                "key_url": {
+                    "description": "HTTPS only",
+                    "type": "string",
+                    "example": "https://keys.example.com/tower"
+                },
+                "min_battery_percent": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "network_host": {
+                    "description": "host:port",
+                    "type": "string",
+                    "example": "192.168.1.1:53"
+                },
+                "start_array": {
+                    "description": "StartArray starts the array if it is stopped. Encrypted arrays are\nunlocked with the key read from KeyFile or fetched from KeyURL; with\nneither, the sequence waits for POST /array/unlock.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "start_delay_seconds": {
+                    "description": "Pause between container and VM starts",
+                    "type": "integer",
+                    "example": 5
+                },
+                "step_timeout_seconds": {
+                    "description": "StepTimeoutSeconds bounds each waiting step (default 300).",
+                    "type": "integer",
+                    "example": 300
+                },
+                "vms": {
+                    "description": "Started in this order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "HomeAssistant"
+                    ]
+                },
+                "wait_network": {
+                    "description": "WaitNetwork waits for an address and a default route and, when\nNetworkHost is set, for a TCP connection to it.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "wait_ups": {
+                    "description": "WaitUPS waits until the UPS is on line power with at least\nMinBatteryPercent charge.",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.BootSequenceStatus": {
+            "description": "Boot sequence configuration and progress of the last run",
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/dto.BootSequenceConfig"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "agent started 42 minutes after boot"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "Empty until the sequence has run",
+                    "type": "string",
+                    "example": "done"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BootSequenceStep"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "trigger": {
+                    "description": "\"boot\" or \"manual\"",
+                    "type": "string",
+                    "example": "boot"
+                }
+            }
+        },
+        "dto.BootSequenceStep": {
+            "type": "object",
+            "properties": {
+                "finished_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "array started"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "array"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "done"
+                }
+            }
+        },
+        "dto.BtrfsDeviceStats": {
+            "type": "object",
+            "properties": {
+                "corruption_errs": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "device": {
+                    "type": "string",
+                    "example": "/dev/nvme0n1p1"
+                },
+                "flush_io_errs": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "generation_errs": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "read_io_errs": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "write_io_errs": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "dto.BtrfsFilesystem": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BtrfsDeviceStats"
+                    }
+                },
+                "mount_point": {
+                    "type": "string",
+                    "example": "/mnt/cache"
+                },
+                "name": {
+                    "description": "Last element of the mount point",
+                    "type": "string",
+                    "example": "cache"
+                },
+                "scrub_corrected_errors": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "scrub_errors": {
+                    "type": "string",
+                    "example": "no errors found"
+                },
+                "scrub_started": {
+                    "type": "string",
+                    "example": "Sun Oct 12 03:00:01 2026"
+                },
+                "scrub_status": {
+                    "description": "Latest scrub, from ` + "`" + `btrfs scrub status` + "`" + `.",
+                    "type": "string",
+                    "example": "finished"
+                },
+                "scrub_uncorrectable_errors": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_errors": {
+                    "description": "TotalErrors is the sum of every error counter across all devices. The\ncounters are persistent, so any non-zero value needs attention until it\nis reset with ` + "`" + `btrfs device stats -z` + "`" + `.",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "dto.CPUCacheInfo": {
+            "type": "object",
+            "properties": {
+                "associativity": {
+                    "type": "string"
+                },
+                "configuration": {
+                    "type": "string"
+                },
+                "error_correction_type": {
+                    "type": "string"
+                },
+                "installed_size": {
+                    "type": "string"
+                },
+                "installed_sram_type": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "maximum_size": {
+                    "type": "string"
+                },
+                "operational_mode": {
+                    "type": "string"
+                },
+                "socket_designation": {
+                    "type": "string"
+                },
+                "supported_sram_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "system_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CPUCoreFrequency": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "mhz": {
+                    "type": "number",
+                    "example": 4700
+                }
+            }
+        },
+        "dto.CPUCoreTemp": {
+            "type": "object",
+            "properties": {
+                "core": {
+                    "description": "Core number within the socket, as reported by coretemp",
+                    "type": "integer",
+                    "example": 3
+                },
+                "package": {
+                    "description": "CPU socket",
+                    "type": "integer",
+                    "example": 0
+                },
+                "temp_celsius": {
+                    "type": "number",
+                    "example": 52
+                }
+            }
+        },
+        "dto.CPUGovernorRequest": {
+            "type": "object",
+            "required": [
+                "governor"
+            ],
+            "properties": {
+                "governor": {
+                    "type": "string",
+                    "example": "performance"
+                }
+            }
+        },
+        "dto.CPUHardwareInfo": {
+            "type": "object",
+            "properties": {
+                "asset_tag": {
+                    "type": "string",
+                    "example": "To Be Filled By O.E.M."
+                },
+                "characteristics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "core_enabled": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "current_speed_mhz": {
+                    "type": "integer",
+                    "example": 3600
+                },
+                "external_clock_mhz": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "family": {
+                    "type": "string",
+                    "example": "Core i7"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "manufacturer": {
+                    "type": "string",
+                    "example": "Intel(R) Corporation"
+                },
+                "max_speed_mhz": {
+                    "type": "integer",
+                    "example": 4900
+                },
+                "part_number": {
+                    "type": "string",
+                    "example": "To Be Filled By O.E.M."
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "To Be Filled By O.E.M."
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "Type 0, Family 6, Model 158, Stepping 10"
+                },
+                "socket_designation": {
+                    "type": "string",
+                    "example": "CPU 1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Populated, Enabled"
+                },
+                "thread_count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "upgrade": {
+                    "type": "string",
+                    "example": "Socket LGA1151"
+                },
+                "voltage": {
+                    "type": "string",
+                    "example": "1.0 V"
+                }
+            }
+        },
+        "dto.CPUPinningResult": {
+            "type": "object",
+            "properties": {
+                "live": {
+                    "description": "Live is true when the change was applied to the running VM or container.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "CPU pinning updated"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "persisted": {
+                    "description": "Persisted is true when the change was saved in the VM definition or\nthe container's Unraid template, so it survives a restart or recreation.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CPUPowerState": {
+            "type": "object",
+            "properties": {
+                "available_governors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "performance",
+                        "powersave"
+                    ]
+                },
+                "current_freq_mhz": {
+                    "type": "integer",
+                    "example": 3600
+                },
+                "driver": {
+                    "type": "string",
+                    "example": "intel_pstate"
+                },
+                "governor": {
+                    "type": "string",
+                    "example": "powersave"
+                },
+                "max_freq_mhz": {
+                    "type": "integer",
+                    "example": 4900
+                },
+                "min_freq_mhz": {
+                    "type": "integer",
+                    "example": 800
+                }
+            }
+        },
+        "dto.CPUThread": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "core": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "cpu": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "isolated": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "package": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "siblings": {
+                    "description": "Siblings lists the logical CPUs sharing this CPU's physical core\n(hyper-threads), including itself.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "vms": {
+                    "description": "VMs and Containers name the guests pinned to this CPU. Guests without\npinning may run on any non-isolated CPU and are not listed.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CPUTopology": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerCPUSet"
+                    }
+                },
+                "cpus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CPUThread"
+                    }
+                },
+                "isolated": {
+                    "description": "Isolated lists the CPUs removed from the general scheduler with the\nisolcpus kernel parameter, as currently active.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "isolcpus_param": {
+                    "description": "IsolcpusParam is the isolcpus= value of the running kernel's command\nline, e.g. \"4-7,12-15\"; empty when not set.",
+                    "type": "string",
+                    "example": "4-7,12-15"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "vms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VMCPUPinning"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.Capabilities": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Capability"
+                    }
+                },
+                "unraid_version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Capability": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChassisInfo": {
+            "type": "object",
+            "properties": {
+                "asset_tag": {
+                    "type": "string",
+                    "example": "To be filled by O.E.M."
+                },
+                "manufacturer": {
+                    "type": "string",
+                    "example": "Supermicro"
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "C1234567890"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Tower"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "0123456789"
+                }
+            }
+        },
+        "dto.CollectorIntervalRequest": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "description": "seconds",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CollectorResponse": {
+            "type": "object",
+            "properties": {
+                "collector": {
+                    "$ref": "#/definitions/dto.CollectorStatus"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CollectorStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "error_count": {
+                    "type": "integer"
+                },
+                "interval_seconds": {
+                    "description": "0 if disabled",
+                    "type": "integer"
+                },
+                "last_run": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required": {
+                    "description": "true if collector cannot be disabled",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "\"running\", \"stopped\", \"disabled\", \"registered\"",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CollectorsStatusResponse": {
+            "type": "object",
+            "properties": {
+                "collectors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CollectorStatus"
+                    }
+                },
+                "disabled_count": {
+                    "type": "integer"
+                },
+                "enabled_count": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ComponentHealth": {
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "description": "Critical components being down make the agent report down (HTTP 503).",
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "description": "Message explains a state; left out for unauthenticated callers when\nAUTH_REQUIRED is set.",
+                    "type": "string",
+                    "example": "connection refused"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "docker"
+                },
+                "status": {
+                    "description": "ok|degraded|down|disabled",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "dto.ConfigReloadResult": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "restart_required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ConfirmationChallenge": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "system_reboot"
+                },
+                "confirm_token": {
+                    "description": "ConfirmToken is single-use and only valid for this action and target.",
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                },
+                "confirmation_required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "consequences": {
+                    "description": "Consequences lists what the action will interrupt or destroy, based on\nthe current state of the server.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "impact": {
+                    "description": "Impact details the running containers, VMs and exported shares that\nstop when the array stops; set for array stop, reboot and shutdown.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ArrayStopImpact"
+                        }
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Confirmation required: repeat the request with the X-Confirm-Token header within 60 seconds"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Reboot the server"
+                },
+                "target": {
+                    "type": "string",
+                    "example": "sdd"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ConnectFlashBackup": {
+            "type": "object",
+            "properties": {
+                "activated": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "error": {
+                    "type": "string"
+                },
+                "last_sync": {
+                    "description": "LastSync is when the flash configuration was last pushed to the cloud.",
+                    "type": "string"
+                },
+                "up_to_date": {
+                    "description": "UpToDate is true when the last flash backup holds every configuration\nchange; nil when the plugin has not reported a status yet.",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.ConnectRemoteAccess": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "description": "Type is \"static\" (port forward), \"upnp\" or \"disabled\"; dynamic remote\naccess reports \"dynamic_static\" or \"dynamic_upnp\".",
+                    "type": "string",
+                    "example": "static"
+                },
+                "upnp_enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "upnp_status": {
+                    "type": "string"
+                },
+                "wan_port": {
+                    "type": "integer",
+                    "example": 33443
+                }
+            }
+        },
+        "dto.ConnectStatus": {
+            "type": "object",
+            "properties": {
+                "cloud_connected": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cloud_status": {
+                    "description": "CloudStatus is the connection state reported by the Unraid API, e.g.\nCONNECTED, CONNECTING, ERROR_RETRYING or PRE_INIT.",
+                    "type": "string",
+                    "example": "CONNECTED"
+                },
+                "flash_backup": {
+                    "$ref": "#/definitions/dto.ConnectFlashBackup"
+                },
+                "installed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "remote_access": {
+                    "$ref": "#/definitions/dto.ConnectRemoteAccess"
+                },
+                "signed_in": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "tower-admin"
+                }
+            }
+        },
+        "dto.ContainerAutostartEntry": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Enabled reports whether the container is started when the array starts.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "order": {
+                    "description": "Order is the 1-based start position; 0 when autostart is disabled.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "wait": {
+                    "description": "Wait is the number of seconds Unraid waits after starting this container\nbefore starting the next one.",
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "dto.ContainerAutostartRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Enabled controls whether autostart is turned on (true) or off (false).",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ContainerAutostartRequestEntry": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name is the container name (not ID), as used by Unraid.",
+                    "type": "string",
+                    "example": "plex"
+                },
+                "wait": {
+                    "description": "Wait is the delay in seconds before the next container is started (0-3600).",
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "dto.ContainerBulkUpdateResult": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerUpdateResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContainerCPUSet": {
+            "type": "object",
+            "properties": {
+                "cpus": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "cpuset": {
+                    "description": "CPUSet is the --cpuset-cpus list; empty when the container may use\nevery CPU.",
+                    "type": "string",
+                    "example": "2-3"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "running"
+                }
+            }
+        },
+        "dto.ContainerCPUSetRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "Confirm must be true; a running container is re-pinned immediately.",
+                    "type": "boolean"
+                },
+                "cpuset": {
+                    "description": "CPUSet is the CPU list, e.g. \"2-3,10-11\"; empty allows every CPU.",
+                    "type": "string",
+                    "example": "2-3"
+                }
+            }
+        },
+        "dto.ContainerDependencies": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "requires_array": {
+                    "description": "Any volume requires the array",
+                    "type": "boolean",
+                    "example": true
+                },
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "appdata",
+                        "Media"
+                    ]
+                },
+                "state": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "storage": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "cache",
+                        "array"
+                    ]
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VolumeDependency"
+                    }
+                }
+            }
+        },
+        "dto.ContainerInfo": {
+            "type": "object",
+            "properties": {
+                "cpu_percent": {
+                    "type": "number",
+                    "example": 5.2
+                },
+                "group": {
+                    "description": "Group is the folder or compose project the container belongs to, read\nfrom its folder-view or com.docker.compose.project label.",
+                    "type": "string",
+                    "example": "media"
+                },
+                "health": {
+                    "description": "Docker HEALTHCHECK status — see ContainerHealth* constants (\"none\" when the image has no healthcheck).",
+                    "type": "string",
+                    "example": "healthy"
+                },
+                "health_failing_streak": {
+                    "description": "consecutive failed health probes",
+                    "type": "integer",
+                    "example": 0
+                },
+                "health_probes": {
+                    "description": "Service probes — populated from watchdog health checks linked to this container at read time.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HealthCheckStatus"
+                    }
+                },
+                "host_interfaces": {
+                    "description": "HostInterfaces lists the host-side veth interfaces of the container's\nbridge networks with their traffic, from the guest traffic collector.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GuestInterfaceTraffic"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "plexinc/pms-docker:latest"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "172.17.0.2"
+                },
+                "mac_address": {
+                    "description": "Container MAC (Docker 29 / Unraid 7.3 fixed-MAC support)",
+                    "type": "string",
+                    "example": "02:42:ac:11:00:02"
+                },
+                "memory_display": {
+                    "type": "string",
+                    "example": "1 GiB / 8 GiB"
+                },
+                "memory_limit_bytes": {
+                    "type": "integer",
+                    "example": 8589934592
+                },
+                "memory_percent": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "memory_usage_bytes": {
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "memory_usage_mb": {
+                    "type": "number",
+                    "example": 1024
+                },
+                "name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "network_mode": {
+                    "type": "string",
+                    "example": "bridge"
+                },
+                "network_rx_bytes": {
+                    "type": "integer",
+                    "example": 104857600
+                },
+                "network_rx_bytes_per_sec": {
+                    "type": "number",
+                    "example": 1024
+                },
+                "network_tx_bytes": {
+                    "type": "integer",
+                    "example": 52428800
+                },
+                "network_tx_bytes_per_sec": {
+                    "type": "number",
+                    "example": 512
+                },
+                "port_mappings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PortMapping"
+                    }
+                },
+                "restart_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "restart_policy": {
+                    "type": "string",
+                    "example": "unless-stopped"
+                },
+                "source_status": {
+                    "$ref": "#/definitions/dto.SourceStatus"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Up 2 days"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "update_available": {
+                    "description": "null when not yet checked / registry unreachable (field omitted in JSON)",
+                    "type": "boolean"
+                },
+                "update_checked": {
+                    "type": "string"
+                },
+                "update_status": {
+                    "description": "Update status — populated by merging the DockerUpdate collector's cache at read time.",
+                    "type": "string",
+                    "example": "up_to_date"
+                },
+                "uptime": {
+                    "type": "string",
+                    "example": "2 days"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.40.1"
+                },
+                "volume_mappings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VolumeMapping"
+                    }
+                }
+            }
+        },
+        "dto.ContainerLimits": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "container_name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "cpu_period": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "cpu_quota": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "cpu_shares": {
+                    "type": "integer",
+                    "example": 512
+                },
+                "memory_bytes": {
+                    "type": "integer",
+                    "example": 2147483648
+                },
+                "memory_swap_bytes": {
+                    "type": "integer",
+                    "example": 4294967296
+                },
+                "restart_max_retries": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "restart_policy": {
+                    "type": "string",
+                    "example": "unless-stopped"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ContainerLimitsRequest": {
+            "type": "object",
+            "properties": {
+                "cpu_period": {
+                    "description": "CPUPeriod is the CFS scheduler period in microseconds (1000-1000000).",
+                    "type": "integer",
+                    "example": 100000
+                },
+                "cpu_quota": {
+                    "description": "CPUQuota is the CPU time in microseconds the container may use per\nCPUPeriod; -1 removes the quota.",
+                    "type": "integer",
+                    "example": 50000
+                },
+                "cpu_shares": {
+                    "description": "CPUShares is the relative CPU weight (default 1024, minimum 2).",
+                    "type": "integer",
+                    "example": 512
+                },
+                "memory_bytes": {
+                    "description": "MemoryBytes is the hard memory limit (minimum 6 MiB).",
+                    "type": "integer",
+                    "example": 2147483648
+                },
+                "memory_swap_bytes": {
+                    "description": "MemorySwapBytes is the memory plus swap limit; -1 allows unlimited swap.",
+                    "type": "integer",
+                    "example": 4294967296
+                },
+                "restart_max_retries": {
+                    "description": "RestartMaxRetries limits restarts for the on-failure policy.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "restart_policy": {
+                    "description": "RestartPolicy is one of no, always, unless-stopped or on-failure.",
+                    "type": "string",
+                    "example": "unless-stopped"
+                }
+            }
+        },
+        "dto.ContainerLogs": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "container_name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "line_count": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "logs": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string",
+                    "example": "2026-02-17T00:00:00Z"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContainerRemoveRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "Confirm must be set to true to authorise the destructive remove operation.",
+                    "type": "boolean"
+                },
+                "remove_image": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ContainerSizeInfo": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "container_name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "image_size_bytes": {
+                    "type": "integer",
+                    "example": 536870912
+                },
+                "size_display": {
+                    "type": "string",
+                    "example": "1.0 GiB"
+                },
+                "size_root_fs_bytes": {
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "size_rw_bytes": {
+                    "type": "integer",
+                    "example": 104857600
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContainerUpdateInfo": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "container_name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "current_digest": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "plexinc/pms-docker:latest"
+                },
+                "latest_digest": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "update_available": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.ContainerUpdateResult": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "container_name": {
+                    "type": "string",
+                    "example": "plex"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "plexinc/pms-docker:latest"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Container updated successfully"
+                },
+                "new_digest": {
+                    "type": "string"
+                },
+                "previous_digest": {
+                    "type": "string"
+                },
+                "recreated": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.ContainerUpdatesResult": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerUpdateInfo"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "updates_available": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.ContainerUsage": {
+            "type": "object",
+            "properties": {
+                "cpu_percent": {
+                    "type": "number",
+                    "example": 5.2
+                },
+                "id": {
+                    "type": "string",
+                    "example": "abc123def456"
+                },
+                "memory_usage_bytes": {
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "memory_usage_mb": {
+                    "type": "number",
+                    "example": 1024
+                },
+                "name": {
+                    "type": "string",
+                    "example": "plex"
+                }
+            }
+        },
+        "dto.DNSHealth": {
+            "description": "DNS resolver configuration and health as seen from the host, including queries sourced from Docker bridge gateway addresses.",
+            "type": "object",
+            "properties": {
+                "avg_response_time_ms": {
+                    "description": "AvgResponseTimeMs is the mean response time of successful host queries.",
+                    "type": "number",
+                    "example": 14.2
+                },
+                "checks": {
+                    "description": "Checks holds every individual query result (host first, then per network).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DNSResolverCheck"
+                    }
+                },
+                "dnssec_validating": {
+                    "description": "DNSSECValidating is true when at least one working host resolver validates DNSSEC.",
+                    "type": "boolean"
+                },
+                "healthy": {
+                    "description": "Healthy is true when at least one host resolver works and every checked\nDocker bridge gateway can reach at least one upstream resolver.",
+                    "type": "boolean"
+                },
+                "issues": {
+                    "description": "Issues lists human-readable problems detected during the run.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "resolvers": {
+                    "description": "Resolvers are the nameservers configured in /etc/resolv.conf.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "search_domains": {
+                    "description": "SearchDomains are the search domains configured in /etc/resolv.conf.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timestamp": {
+                    "description": "Timestamp is when this status was collected.",
+                    "type": "string"
+                },
+                "unchecked_networks": {
+                    "description": "UncheckedNetworks lists external Docker networks that were not tested\nbecause their traffic does not pass through a host bridge gateway\n(macvlan/ipvlan, e.g. Unraid's br0) or because their gateway is not IPv4.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "br0"
+                    ]
+                }
+            }
+        },
+        "dto.DNSLookupResult": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "number",
+                    "example": 3.2
+                },
+                "error": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string",
+                    "example": "github.com"
+                },
+                "record_type": {
+                    "description": "\"A\", \"AAAA\", \"CNAME\", \"MX\", \"TXT\", \"NS\"",
+                    "type": "string",
+                    "example": "A"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DNSResolverCheck": {
+            "description": "Outcome of resolving the probe name through one resolver from the host, optionally sourced from a Docker bridge gateway address.",
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "Addresses are the A records returned.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dnssec_validated": {
+                    "description": "DNSSECValidated is true when the resolver set the AD (authenticated data) flag.",
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "Error describes why the query failed, if it did.",
+                    "type": "string"
+                },
+                "network": {
+                    "description": "Network is the Docker network name (docker_gateway scope only).",
+                    "type": "string",
+                    "example": "proxynet"
+                },
+                "query": {
+                    "description": "Query is the name that was resolved.",
+                    "type": "string",
+                    "example": "cloudflare.com"
+                },
+                "rcode": {
+                    "description": "Rcode is the DNS response code (e.g. \"NOERROR\", \"SERVFAIL\", \"NXDOMAIN\").",
+                    "type": "string",
+                    "example": "NOERROR"
+                },
+                "response_time_ms": {
+                    "description": "ResponseTimeMs is the round-trip time of the query in milliseconds.",
+                    "type": "number",
+                    "example": 12.4
+                },
+                "scope": {
+                    "description": "Scope is \"host\" for queries from the host's default route, or\n\"docker_gateway\" for host queries sourced from a Docker bridge network's\ngateway address. Neither runs inside a container's network namespace.",
+                    "type": "string",
+                    "example": "host"
+                },
+                "server": {
+                    "description": "Server is the resolver that was queried (ip:port).",
+                    "type": "string",
+                    "example": "192.168.1.1:53"
+                },
+                "success": {
+                    "description": "Success is true when the resolver answered with NOERROR and at least one address.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.DegradedSubsystems": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SourceStatus"
+                    }
+                }
+            }
+        },
+        "dto.DirectoryListing": {
             "type": "object",
             "properties": {
-                "action": {
-                    "type": "string",
-                    "example": "restart_container"
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FileEntry"
+                    }
                 },
-                "reason": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer",
+                    "example": 1000
                 },
-                "target": {
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "path": {
                     "type": "string",
-                    "example": "abc123def456"
+                    "example": "/mnt/user/appdata/sonarr"
+                },
+                "total": {
+                    "description": "Entries in the directory",
+                    "type": "integer",
+                    "example": 12
                 }
             }
         },
-        "dto.AgentIncident": {
+        "dto.DirectorySize": {
             "type": "object",
             "properties": {
-                "actions": {
+                "bytes": {
+                    "type": "integer",
+                    "example": 42949672960
+                },
+                "children": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.DirectorySize"
                     }
                 },
-                "at": {
-                    "type": "string"
+                "dirs": {
+                    "type": "integer",
+                    "example": 9120
                 },
-                "goal": {
-                    "type": "string"
+                "files": {
+                    "type": "integer",
+                    "example": 181234
                 },
-                "id": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "plex"
                 },
-                "outcome": {
-                    "type": "string"
+                "omitted_bytes": {
+                    "type": "integer",
+                    "example": 1073741824
                 },
-                "signature": {
-                    "type": "string"
+                "omitted_children": {
+                    "description": "OmittedChildren and OmittedBytes summarize the children beyond the\nrequested limit.",
+                    "type": "integer",
+                    "example": 57
                 },
-                "summary": {
-                    "type": "string"
+                "path": {
+                    "type": "string",
+                    "example": "/mnt/cache/appdata/plex"
+                },
+                "type": {
+                    "description": "\"dir\" or \"file\"",
+                    "type": "string",
+                    "example": "dir"
                 }
             }
         },
-        "dto.AgentMessage": {
+        "dto.DiskCacheInfo": {
             "type": "object",
             "properties": {
-                "content": {
+                "dirty_background_ratio": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "dirty_expire_centisecs": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "dirty_ratio": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "dirty_writeback_centisecs": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.DiskCacheRequest": {
+            "type": "object",
+            "properties": {
+                "dirty_background_ratio": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "dirty_expire_centisecs": {
+                    "type": "integer",
+                    "example": 3000
+                },
+                "dirty_ratio": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "dirty_writeback_centisecs": {
+                    "type": "integer",
+                    "example": 500
+                }
+            }
+        },
+        "dto.DiskInfo": {
+            "type": "object",
+            "properties": {
+                "controller": {
+                    "description": "Controller is the PCI address of the storage controller the disk is\nattached to, and Port its sysfs port name on it: \"ata3\" (SATA),\n\"port-2:0\" (SAS), \"nvme0\", \"1-4\" (USB) or a SCSI address.",
+                    "type": "string",
+                    "example": "0000:00:17.0"
+                },
+                "device": {
+                    "type": "string",
+                    "example": "sda"
+                },
+                "encrypted": {
+                    "description": "LUKS encryption. EncryptionState is one of the Encryption* constants;\nit is empty for unencrypted disks and while Unraid has not opened an\nencrypted one yet.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "encryption_state": {
+                    "type": "string",
+                    "example": "unlocked"
+                },
+                "filesystem": {
+                    "type": "string",
+                    "example": "xfs"
+                },
+                "free_bytes": {
+                    "type": "integer",
+                    "example": 6600076243764
+                },
+                "id": {
+                    "type": "string",
+                    "example": "disk1"
+                },
+                "io_utilization_percent": {
+                    "type": "number",
+                    "example": 5.2
+                },
+                "last_trim": {
+                    "description": "LastTrim is when the filesystem on this disk was last trimmed through\nthe agent.",
                     "type": "string"
                 },
+                "model": {
+                    "type": "string",
+                    "example": "WDC WD120EFBX-68B0EN0"
+                },
+                "mount_point": {
+                    "description": "Mount information",
+                    "type": "string",
+                    "example": "/mnt/disk1"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Disk 1"
+                },
+                "partial": {
+                    "description": "Partial is true when the device did not answer in time (e.g. smartctl\nhung on it), so SMART, usage and I/O fields only hold what disks.ini\nreports.",
+                    "type": "boolean"
+                },
+                "pool": {
+                    "type": "string",
+                    "example": "cache"
+                },
+                "port": {
+                    "type": "string",
+                    "example": "ata3"
+                },
+                "power_cycle_count": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "power_on_hours": {
+                    "type": "integer",
+                    "example": 25000
+                },
+                "read_bytes": {
+                    "description": "I/O Statistics",
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "read_ops": {
+                    "type": "integer",
+                    "example": 100000
+                },
                 "role": {
+                    "description": "\"parity\", \"parity2\", \"data\", \"cache\", \"pool\", \"flash\", \"unassigned\"",
+                    "type": "string",
+                    "example": "data"
+                },
+                "serial_number": {
+                    "description": "Disk identification",
+                    "type": "string",
+                    "example": "WD-WMC4N0123456"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 12000138625024
+                },
+                "slot": {
+                    "description": "Array layout. Slot is the Unraid slot index from disks.ini (0 parity,\n1-28 data disks, 29 parity 2, pool devices after them); it is nil for\nunassigned devices. Pool names the pool a cache or pool device is a\nmember of.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "smart_attributes": {
+                    "description": "Enhanced SMART attributes",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.SMARTAttribute"
+                    }
+                },
+                "smart_collected_at": {
+                    "description": "SMARTCollectedAt is when smartctl last answered for the disk. SMART\ndata is cached and refreshed less often than the disk list, and not at\nall while the disk is spun down.",
                     "type": "string"
                 },
-                "tool_call_id": {
+                "smart_errors": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "smart_status": {
+                    "type": "string",
+                    "example": "PASSED"
+                },
+                "source_status": {
+                    "description": "SourceStatus is non-nil only when the backing data source is not healthy.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.SourceStatus"
+                        }
+                    ]
+                },
+                "spin_state": {
+                    "description": "\"active\", \"standby\", \"unknown\"",
+                    "type": "string",
+                    "example": "active"
+                },
+                "spindown_delay": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "temp_critical_celsius": {
+                    "description": "Per-disk critical threshold override",
+                    "type": "integer",
+                    "example": 60
+                },
+                "temp_warning_celsius": {
+                    "description": "Per-disk temperature thresholds (Issue #46)\nIf set, these override the global defaults. Null/omitted means use global defaults.",
+                    "type": "integer",
+                    "example": 50
+                },
+                "temperature_celsius": {
+                    "type": "number",
+                    "example": 35
+                },
+                "timestamp": {
                     "type": "string"
                 },
-                "tool_calls": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.AgentMsgToolCall"
-                    }
+                "total_bytes_written": {
+                    "type": "integer",
+                    "example": 6320000000000
+                },
+                "usage_percent": {
+                    "type": "number",
+                    "example": 45
+                },
+                "used_bytes": {
+                    "type": "integer",
+                    "example": 5400062381260
+                },
+                "wear_percent": {
+                    "description": "SSD endurance (SSDs only). WearPercent is the share of the rated\nendurance used: NVMe \"Percentage Used\", or 100 minus the normalized\nvalue of the ATA wear attribute (231, 233, 177 or 202). NVMe drives may\nreport more than 100 once the rating is exceeded.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "write_bytes": {
+                    "type": "integer",
+                    "example": 536870912
+                },
+                "write_ops": {
+                    "type": "integer",
+                    "example": 50000
                 }
             }
         },
-        "dto.AgentMsgToolCall": {
+        "dto.DiskSettings": {
             "type": "object",
             "properties": {
-                "args": {
+                "default_filesystem": {
+                    "description": "Default filesystem type (xfs, btrfs, etc.)",
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "shutdown_timeout_seconds": {
+                    "description": "Shutdown timeout in seconds",
+                    "type": "integer"
                 },
-                "name": {
+                "spindown_delay_minutes": {
+                    "description": "Default spin down delay in minutes",
+                    "type": "integer"
+                },
+                "spinup_groups": {
+                    "description": "Enable spinup groups",
+                    "type": "boolean"
+                },
+                "start_array": {
+                    "description": "Auto start array on boot",
+                    "type": "boolean"
+                },
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "dto.AgentPreference": {
+        "dto.DiskSettingsExtended": {
+            "description": "Extended disk settings with temperature thresholds from Unraid configuration",
             "type": "object",
             "properties": {
-                "at": {
-                    "type": "string"
+                "critical_utilization_percent": {
+                    "description": "Disk utilization critical threshold",
+                    "type": "integer",
+                    "example": 90
                 },
-                "id": {
-                    "type": "string"
+                "default_filesystem": {
+                    "description": "Default filesystem type",
+                    "type": "string",
+                    "example": "xfs"
                 },
-                "kind": {
-                    "type": "string"
+                "hdd_temp_critical_celsius": {
+                    "description": "HDD critical temperature threshold",
+                    "type": "integer",
+                    "example": 55
                 },
-                "note": {
-                    "type": "string"
+                "hdd_temp_warning_celsius": {
+                    "description": "Temperature thresholds from dynamix.cfg (Issue #45)",
+                    "type": "integer",
+                    "example": 45
                 },
-                "status": {
-                    "$ref": "#/definitions/dto.PreferenceStatus"
+                "nvme_power_monitoring": {
+                    "description": "NVME power monitoring setting",
+                    "type": "boolean",
+                    "example": false
                 },
-                "subject": {
+                "shutdown_timeout_seconds": {
+                    "description": "Shutdown timeout in seconds",
+                    "type": "integer",
+                    "example": 90
+                },
+                "spindown_delay_minutes": {
+                    "description": "Basic disk settings (from disk.cfg)",
+                    "type": "integer",
+                    "example": 30
+                },
+                "spinup_groups": {
+                    "description": "Enable spinup groups",
+                    "type": "boolean",
+                    "example": false
+                },
+                "ssd_temp_critical_celsius": {
+                    "description": "SSD critical temperature threshold",
+                    "type": "integer",
+                    "example": 70
+                },
+                "ssd_temp_warning_celsius": {
+                    "description": "SSD warning temperature threshold",
+                    "type": "integer",
+                    "example": 60
+                },
+                "start_array": {
+                    "description": "Auto start array on boot",
+                    "type": "boolean",
+                    "example": true
+                },
+                "timestamp": {
                     "type": "string"
+                },
+                "warning_utilization_percent": {
+                    "description": "Disk utilization warning threshold",
+                    "type": "integer",
+                    "example": 70
                 }
             }
         },
-        "dto.AgentSession": {
+        "dto.DiskSpinEvent": {
             "type": "object",
             "properties": {
-                "answer": {
-                    "type": "string"
+                "causes": {
+                    "description": "Spin-ups only, best effort",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DiskSpinUpCause"
+                    }
                 },
-                "ended_at": {
-                    "type": "string"
+                "device": {
+                    "type": "string",
+                    "example": "sdd"
                 },
-                "error": {
-                    "type": "string"
+                "disk": {
+                    "type": "string",
+                    "example": "disk3"
                 },
-                "goal": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "Disk 3"
                 },
-                "id": {
-                    "type": "string"
+                "previous_seconds": {
+                    "description": "PreviousSeconds is how long the disk stayed in the previous state.",
+                    "type": "integer",
+                    "example": 5400
                 },
-                "pending_approval": {
-                    "$ref": "#/definitions/dto.ApprovalRequest"
+                "previous_state": {
+                    "description": "State before the transition",
+                    "type": "string",
+                    "example": "standby"
                 },
-                "plan": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.PlanStep"
-                    }
+                "state": {
+                    "description": "\"active\" (spin-up) or \"standby\" (spin-down)",
+                    "type": "string",
+                    "example": "active"
                 },
-                "started_at": {
+                "timestamp": {
                     "type": "string"
-                },
-                "status": {
-                    "$ref": "#/definitions/dto.AgentSessionStatus"
-                },
-                "steps": {
+                }
+            }
+        },
+        "dto.DiskSpinHistory": {
+            "type": "object",
+            "properties": {
+                "disks": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.AgentStep"
+                        "$ref": "#/definitions/dto.DiskSpinStats"
                     }
                 },
-                "tokens_used": {
-                    "type": "integer"
-                },
-                "transcript": {
+                "events": {
+                    "description": "Recent transitions, oldest first",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.AgentMessage"
+                        "$ref": "#/definitions/dto.DiskSpinEvent"
                     }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "window_seconds": {
+                    "type": "integer",
+                    "example": 86400
                 }
             }
         },
-        "dto.AgentSessionStatus": {
-            "type": "string",
-            "enum": [
-                "running",
-                "completed",
-                "failed",
-                "cancelled",
-                "awaiting_approval"
-            ],
-            "x-enum-varnames": [
-                "SessionRunning",
-                "SessionCompleted",
-                "SessionFailed",
-                "SessionCancelled",
-                "SessionAwaitingApproval"
-            ]
-        },
-        "dto.AgentStep": {
+        "dto.DiskSpinStats": {
             "type": "object",
             "properties": {
-                "at": {
+                "device": {
+                    "type": "string",
+                    "example": "sdd"
+                },
+                "disk": {
+                    "type": "string",
+                    "example": "disk3"
+                },
+                "last_spin_up": {
                     "type": "string"
                 },
-                "index": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "Disk 3"
                 },
-                "thought": {
+                "since": {
+                    "description": "When the disk entered the current state (or was first seen in it)",
                     "type": "string"
                 },
-                "tool_calls": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.AgentToolCall"
-                    }
+                "spin_downs": {
+                    "description": "Spin-downs in the window",
+                    "type": "integer",
+                    "example": 4
+                },
+                "spin_ups": {
+                    "description": "Spin-ups in the window",
+                    "type": "integer",
+                    "example": 4
+                },
+                "state": {
+                    "description": "Current state: \"active\" or \"standby\"",
+                    "type": "string",
+                    "example": "standby"
                 }
             }
         },
-        "dto.AgentToolCall": {
+        "dto.DiskSpinUpCause": {
             "type": "object",
             "properties": {
-                "args": {
-                    "description": "raw JSON arguments",
-                    "type": "string"
-                },
-                "at": {
-                    "type": "string"
-                },
-                "error": {
-                    "type": "string"
+                "path": {
+                    "type": "string",
+                    "example": "/mnt/user/Media/Movies/Heat (1995)/Heat.mkv"
                 },
-                "name": {
-                    "type": "string"
+                "pid": {
+                    "type": "integer",
+                    "example": 4121
                 },
-                "result": {
-                    "type": "string"
+                "process": {
+                    "type": "string",
+                    "example": "Plex Media Serv"
                 },
-                "risk_tier": {
-                    "$ref": "#/definitions/dto.RiskTier"
+                "source": {
+                    "description": "\"open_file\" or \"fatrace\"",
+                    "type": "string",
+                    "example": "open_file"
                 }
             }
         },
-        "dto.AlertEvent": {
+        "dto.DockerAggregateStats": {
             "type": "object",
             "properties": {
-                "fired_at": {
-                    "type": "string"
+                "memory_usage_percent": {
+                    "type": "number",
+                    "example": 25
                 },
-                "message": {
-                    "type": "string"
+                "running_containers": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "resolved_at": {
+                "timestamp": {
                     "type": "string"
                 },
-                "rule_id": {
-                    "type": "string"
+                "total_containers": {
+                    "type": "integer",
+                    "example": 15
                 },
-                "rule_name": {
-                    "type": "string"
+                "total_cpu_percent": {
+                    "type": "number",
+                    "example": 12.5
                 },
-                "severity": {
-                    "type": "string"
+                "total_memory_limit_bytes": {
+                    "type": "integer",
+                    "example": 34359738368
+                },
+                "total_memory_usage_bytes": {
+                    "type": "integer",
+                    "example": 8589934592
+                },
+                "total_memory_usage_mb": {
+                    "type": "number",
+                    "example": 8192
+                }
+            }
+        },
+        "dto.DockerAutostart": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerAutostartEntry"
+                    }
                 },
-                "state": {
-                    "description": "\"firing\" or \"resolved\"",
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "dto.AlertHistoryResponse": {
+        "dto.DockerAutostartRequest": {
             "type": "object",
             "properties": {
-                "events": {
+                "containers": {
+                    "description": "Containers is required; an empty list disables autostart for all containers.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.AlertEvent"
+                        "$ref": "#/definitions/dto.ContainerAutostartRequestEntry"
                     }
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.AlertRule": {
+        "dto.DockerDependencies": {
             "type": "object",
             "properties": {
-                "channels": {
-                    "description": "shoutrrr URLs or \"unraid\" for local notification",
+                "containers": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.ContainerDependencies"
                     }
                 },
-                "cooldown_minutes": {
-                    "description": "Minutes between re-fires (default 5)",
-                    "type": "integer"
-                },
-                "duration_seconds": {
-                    "description": "Must be true for this many seconds before firing (0 = immediate)",
-                    "type": "integer"
-                },
-                "enabled": {
-                    "type": "boolean"
-                },
-                "expression": {
-                    "description": "expr-lang expression, e.g. \"CPU \u003e 90 \u0026\u0026 ArrayState == 'Started'\"",
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ShareDependents"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "storage": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StorageDependents"
+                    }
                 },
-                "severity": {
-                    "description": "\"info\", \"warning\", \"critical\"",
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "dto.AlertStatus": {
+        "dto.DockerGroup": {
             "type": "object",
             "properties": {
-                "eval_count": {
-                    "type": "integer"
+                "containers": {
+                    "description": "Containers lists the member container names, sorted.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "message": {
-                    "type": "string"
+                "cpu_percent": {
+                    "type": "number",
+                    "example": 7.5
                 },
-                "rule_id": {
-                    "type": "string"
+                "memory_usage_bytes": {
+                    "type": "integer",
+                    "example": 2147483648
                 },
-                "rule_name": {
-                    "type": "string"
+                "memory_usage_mb": {
+                    "type": "number",
+                    "example": 2048
                 },
-                "severity": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "media"
                 },
-                "since": {
-                    "type": "string"
+                "running": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "state": {
-                    "description": "\"ok\", \"pending\", \"firing\"",
-                    "type": "string"
+                "total": {
+                    "type": "integer",
+                    "example": 4
                 }
             }
         },
-        "dto.AlertsStatusResponse": {
+        "dto.DockerGroupActionResult": {
             "type": "object",
             "properties": {
-                "statuses": {
+                "action": {
+                    "type": "string",
+                    "example": "start"
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "group": {
+                    "type": "string",
+                    "example": "media"
+                },
+                "results": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.AlertStatus"
+                        "$ref": "#/definitions/dto.DockerGroupContainerResult"
                     }
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "dto.ApprovalRequest": {
+        "dto.DockerGroupContainerResult": {
             "type": "object",
             "properties": {
-                "action_id": {
-                    "type": "string"
-                },
-                "args": {
+                "error": {
                     "type": "string"
                 },
-                "reason": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "sonarr"
                 },
-                "requested_at": {
-                    "type": "string"
+                "skipped": {
+                    "description": "Skipped is true when the container was already in the requested state.",
+                    "type": "boolean"
                 },
-                "risk_tier": {
-                    "$ref": "#/definitions/dto.RiskTier"
+                "succeeded": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.DockerGroups": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DockerGroup"
+                    }
                 },
-                "tool_name": {
+                "timestamp": {
                     "type": "string"
+                },
+                "ungrouped": {
+                    "description": "Ungrouped lists the containers without a group label.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.ArrayStatus": {
+        "dto.DockerNetworkCreateRequest": {
             "type": "object",
             "properties": {
-                "free_bytes": {
-                    "type": "integer",
-                    "example": 54975581388800
+                "attachable": {
+                    "type": "boolean"
                 },
-                "num_data_disks": {
-                    "type": "integer",
-                    "example": 8
+                "driver": {
+                    "description": "Driver is bridge, macvlan or ipvlan.",
+                    "type": "string",
+                    "example": "macvlan"
                 },
-                "num_disks": {
-                    "type": "integer",
-                    "example": 10
+                "gateway": {
+                    "type": "string",
+                    "example": "192.168.20.1"
                 },
-                "num_parity_disks": {
-                    "type": "integer",
-                    "example": 2
+                "internal": {
+                    "type": "boolean"
                 },
-                "parity_check_progress": {
-                    "type": "number",
-                    "example": 0
+                "ip_range": {
+                    "description": "IPRange limits the addresses Docker assigns to a part of the subnet,\nso containers do not collide with the router's DHCP pool.",
+                    "type": "string",
+                    "example": "192.168.20.128/25"
                 },
-                "parity_check_status": {
+                "ipvlan_mode": {
+                    "description": "IPVlanMode is l2 (default), l3 or l3s; only valid for ipvlan.",
                     "type": "string",
-                    "example": "idle"
+                    "example": "l2"
                 },
-                "parity_valid": {
-                    "type": "boolean",
-                    "example": true
+                "name": {
+                    "type": "string",
+                    "example": "iot"
                 },
-                "source_status": {
-                    "description": "SourceStatus is non-nil when the data source is degraded or unavailable.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.SourceStatus"
-                        }
-                    ]
+                "parent": {
+                    "description": "Parent is the host interface macvlan/ipvlan traffic leaves through.\nA dotted VLAN sub-interface (br0.20) is created by Docker if missing.",
+                    "type": "string",
+                    "example": "br0.20"
                 },
-                "state": {
+                "subnet": {
                     "type": "string",
-                    "example": "Started"
+                    "example": "192.168.20.0/24"
+                }
+            }
+        },
+        "dto.DockerNetworkCreateResult": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iot"
                 },
                 "timestamp": {
                     "type": "string"
                 },
-                "total_bytes": {
-                    "type": "integer",
-                    "example": 100862164623360
-                },
-                "used_percent": {
-                    "type": "number",
-                    "example": 45.5
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.AvailableDriveSensor": {
+        "dto.DockerNetworkInfo": {
             "type": "object",
             "properties": {
-                "device": {
+                "attachable": {
+                    "type": "boolean"
+                },
+                "container_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created": {
+                    "type": "string"
+                },
+                "driver": {
                     "type": "string",
-                    "example": "sdb"
+                    "example": "bridge"
+                },
+                "gateway": {
+                    "type": "string",
+                    "example": "172.17.0.1"
                 },
                 "id": {
+                    "type": "string"
+                },
+                "internal": {
+                    "type": "boolean"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
                     "type": "string",
-                    "example": "disk1"
+                    "example": "bridge"
                 },
-                "spun_down": {
-                    "type": "boolean",
-                    "example": false
+                "scope": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "subnet": {
+                    "type": "string",
+                    "example": "172.17.0.0/16"
                 },
-                "temp_celsius": {
-                    "type": "number",
-                    "example": 38
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "dto.AvailableTempSensor": {
+        "dto.DockerNetworkList": {
             "type": "object",
             "properties": {
-                "label": {
-                    "type": "string",
-                    "example": "Tctl"
-                },
-                "path": {
-                    "type": "string",
-                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
+                "count": {
+                    "type": "integer"
                 },
-                "plausible": {
-                    "type": "boolean",
-                    "example": true
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DockerNetworkInfo"
+                    }
                 },
-                "temp_celsius": {
-                    "type": "number",
-                    "example": 45
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "dto.BIOSInfo": {
+        "dto.DockerSettings": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string",
-                    "example": "0xF0000"
-                },
-                "characteristics": {
+                "custom_networks": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "release_date": {
-                    "type": "string",
-                    "example": "12/25/2023"
-                },
-                "revision": {
-                    "type": "string",
-                    "example": "5.14"
-                },
-                "rom_size": {
-                    "type": "string",
-                    "example": "16 MB"
+                "default_network": {
+                    "type": "string"
                 },
-                "runtime_size": {
-                    "type": "string",
-                    "example": "64 KB"
+                "enabled": {
+                    "type": "boolean"
                 },
-                "vendor": {
-                    "type": "string",
-                    "example": "American Megatrends International, LLC."
+                "image_path": {
+                    "type": "string"
                 },
-                "version": {
-                    "type": "string",
-                    "example": "1.4"
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "dto.BaseboardInfo": {
+        "dto.DockerStatsSummary": {
             "type": "object",
             "properties": {
-                "asset_tag": {
-                    "type": "string",
-                    "example": "To be filled by O.E.M."
+                "memory_usage_percent": {
+                    "type": "number",
+                    "example": 25
                 },
-                "features": {
+                "running_containers": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "top_cpu": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.ContainerUsage"
                     }
                 },
-                "location_in_chassis": {
-                    "type": "string",
-                    "example": "Default string"
+                "top_memory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerUsage"
+                    }
                 },
-                "manufacturer": {
-                    "type": "string",
-                    "example": "Supermicro"
+                "total_containers": {
+                    "type": "integer",
+                    "example": 15
                 },
-                "product_name": {
-                    "type": "string",
-                    "example": "X11SCL-F"
+                "total_cpu_percent": {
+                    "type": "number",
+                    "example": 12.5
                 },
-                "serial_number": {
-                    "type": "string",
-                    "example": "OM123456789"
+                "total_memory_limit_bytes": {
+                    "type": "integer",
+                    "example": 34359738368
                 },
-                "type": {
-                    "type": "string",
-                    "example": "Motherboard"
+                "total_memory_usage_bytes": {
+                    "type": "integer",
+                    "example": 8589934592
                 },
-                "version": {
-                    "type": "string",
-                    "example": "1.02"
+                "total_memory_usage_mb": {
+                    "type": "number",
+                    "example": 8192
                 }
             }
         },
-        "dto.BootInfo": {
+        "dto.EncryptedDevice": {
             "type": "object",
             "properties": {
-                "boot_pool": {
-                    "description": "ZFS boot pool name when present",
-                    "type": "string",
-                    "example": "boot"
-                },
-                "boot_pool_type": {
-                    "description": "\"single\", \"mirror\" when on a ZFS boot pool",
-                    "type": "string",
-                    "example": "mirror"
-                },
                 "device": {
-                    "description": "Backing device of the boot partition",
                     "type": "string",
-                    "example": "sda1"
+                    "example": "sdb"
                 },
-                "device_type": {
-                    "description": "\"usb\" (flash) or \"internal\"",
+                "name": {
                     "type": "string",
-                    "example": "usb"
+                    "example": "disk1"
                 },
-                "filesystem": {
-                    "description": "Boot partition filesystem",
+                "state": {
+                    "description": "One of the Encryption* constants",
                     "type": "string",
-                    "example": "vfat"
+                    "example": "locked"
                 }
             }
         },
-        "dto.CPUCacheInfo": {
+        "dto.ExternalFanControl": {
             "type": "object",
             "properties": {
-                "associativity": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "configuration": {
-                    "type": "string"
+                "controllers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "FanCTRL Plus"
+                    ]
+                }
+            }
+        },
+        "dto.FanControlConfig": {
+            "type": "object",
+            "properties": {
+                "control_enabled": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "error_correction_type": {
-                    "type": "string"
+                "control_method": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.FanControlMethod"
+                        }
+                    ],
+                    "example": "hwmon"
                 },
-                "installed_size": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "installed_sram_type": {
-                    "type": "string"
+                "poll_interval_seconds": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "level": {
-                    "type": "integer"
+                "safety": {
+                    "$ref": "#/definitions/dto.FanSafetyConfig"
+                }
+            }
+        },
+        "dto.FanControlMethod": {
+            "type": "string",
+            "enum": [
+                "hwmon",
+                "ipmi"
+            ],
+            "x-enum-varnames": [
+                "FanMethodHwmon",
+                "FanMethodIPMI"
+            ]
+        },
+        "dto.FanControlMode": {
+            "type": "string",
+            "enum": [
+                "automatic",
+                "manual",
+                "off"
+            ],
+            "x-enum-varnames": [
+                "FanModeAutomatic",
+                "FanModeManual",
+                "FanModeOff"
+            ]
+        },
+        "dto.FanControlStatus": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/dto.FanControlConfig"
                 },
-                "location": {
-                    "type": "string"
+                "external_control": {
+                    "$ref": "#/definitions/dto.ExternalFanControl"
                 },
-                "maximum_size": {
-                    "type": "string"
+                "fans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FanDevice"
+                    }
                 },
-                "operational_mode": {
-                    "type": "string"
+                "profiles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FanProfile"
+                    }
                 },
-                "socket_designation": {
+                "summary": {
+                    "$ref": "#/definitions/dto.FanControlSummary"
+                },
+                "timestamp": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.FanControlSummary": {
+            "type": "object",
+            "properties": {
+                "controllable_fans": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "supported_sram_types": {
+                "failed_fans": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "system_type": {
-                    "type": "string"
+                "total_fans": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "dto.CPUGovernorRequest": {
+        "dto.FanCurvePoint": {
             "type": "object",
-            "required": [
-                "governor"
-            ],
             "properties": {
-                "governor": {
-                    "type": "string",
-                    "example": "performance"
+                "speed_percent": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "temp_celsius": {
+                    "type": "number",
+                    "example": 40
                 }
             }
         },
-        "dto.CPUHardwareInfo": {
+        "dto.FanDevice": {
             "type": "object",
             "properties": {
-                "asset_tag": {
+                "active_profile": {
                     "type": "string",
-                    "example": "To Be Filled By O.E.M."
-                },
-                "characteristics": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "core_enabled": {
-                    "type": "integer",
-                    "example": 8
+                    "example": "balanced"
                 },
-                "current_speed_mhz": {
-                    "type": "integer",
-                    "example": 3600
+                "controllable": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "external_clock_mhz": {
+                "hwmon_index": {
                     "type": "integer",
-                    "example": 100
-                },
-                "family": {
-                    "type": "string",
-                    "example": "Core i7"
-                },
-                "flags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                    "example": 1
                 },
-                "manufacturer": {
+                "hwmon_path": {
                     "type": "string",
-                    "example": "Intel(R) Corporation"
-                },
-                "max_speed_mhz": {
-                    "type": "integer",
-                    "example": 4900
+                    "example": "/sys/class/hwmon/hwmon0"
                 },
-                "part_number": {
+                "id": {
                     "type": "string",
-                    "example": "To Be Filled By O.E.M."
+                    "example": "hwmon0_fan1"
                 },
-                "serial_number": {
-                    "type": "string",
-                    "example": "To Be Filled By O.E.M."
+                "mode": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.FanControlMode"
+                        }
+                    ],
+                    "example": "automatic"
                 },
-                "signature": {
+                "name": {
                     "type": "string",
-                    "example": "Type 0, Family 6, Model 158, Stepping 10"
+                    "example": "CPU Fan"
                 },
-                "socket_designation": {
-                    "type": "string",
-                    "example": "CPU 1"
+                "pwm_percent": {
+                    "type": "integer",
+                    "example": 71
                 },
-                "status": {
-                    "type": "string",
-                    "example": "Populated, Enabled"
+                "pwm_value": {
+                    "type": "integer",
+                    "example": 180
                 },
-                "thread_count": {
+                "rpm": {
                     "type": "integer",
-                    "example": 8
+                    "example": 1200
                 },
-                "upgrade": {
+                "temp_sensor_path": {
                     "type": "string",
-                    "example": "Socket LGA1151"
+                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
                 },
-                "voltage": {
-                    "type": "string",
-                    "example": "1.0 V"
+                "temp_source": {
+                    "$ref": "#/definitions/dto.FanTempSource"
                 }
             }
         },
-        "dto.CPUPowerState": {
+        "dto.FanInfo": {
             "type": "object",
             "properties": {
-                "available_governors": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "performance",
-                        "powersave"
-                    ]
-                },
-                "current_freq_mhz": {
-                    "type": "integer",
-                    "example": 3600
-                },
-                "driver": {
-                    "type": "string",
-                    "example": "intel_pstate"
-                },
-                "governor": {
+                "name": {
                     "type": "string",
-                    "example": "powersave"
-                },
-                "max_freq_mhz": {
-                    "type": "integer",
-                    "example": 4900
+                    "example": "CPU Fan"
                 },
-                "min_freq_mhz": {
+                "rpm": {
                     "type": "integer",
-                    "example": 800
+                    "example": 1200
                 }
             }
         },
-        "dto.Capabilities": {
+        "dto.FanModeRequest": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.Capability"
-                    }
+                "fan_id": {
+                    "type": "string",
+                    "example": "hwmon0_fan1"
                 },
-                "unraid_version": {
-                    "type": "string"
+                "mode": {
+                    "type": "string",
+                    "example": "manual"
                 }
             }
         },
-        "dto.Capability": {
+        "dto.FanProfile": {
             "type": "object",
             "properties": {
-                "available": {
-                    "type": "boolean"
+                "built_in": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "detail": {
-                    "type": "string"
+                "curve_points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FanCurvePoint"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "description": {
+                    "type": "string",
+                    "example": "Balanced cooling and noise"
                 },
-                "target": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "balanced"
                 }
             }
         },
-        "dto.ChassisInfo": {
+        "dto.FanProfileCreateRequest": {
             "type": "object",
             "properties": {
-                "asset_tag": {
-                    "type": "string",
-                    "example": "To be filled by O.E.M."
-                },
-                "manufacturer": {
-                    "type": "string",
-                    "example": "Supermicro"
-                },
-                "serial_number": {
-                    "type": "string",
-                    "example": "C1234567890"
+                "curve_points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FanCurvePoint"
+                    }
                 },
-                "type": {
+                "description": {
                     "type": "string",
-                    "example": "Tower"
+                    "example": "Custom quiet profile"
                 },
-                "version": {
+                "name": {
                     "type": "string",
-                    "example": "0123456789"
-                }
-            }
-        },
-        "dto.CollectorIntervalRequest": {
-            "type": "object",
-            "properties": {
-                "interval": {
-                    "description": "seconds",
-                    "type": "integer"
+                    "example": "custom_quiet"
                 }
             }
         },
-        "dto.CollectorResponse": {
+        "dto.FanProfileRequest": {
             "type": "object",
             "properties": {
-                "collector": {
-                    "$ref": "#/definitions/dto.CollectorStatus"
+                "fan_id": {
+                    "type": "string",
+                    "example": "hwmon0_fan1"
                 },
-                "message": {
-                    "type": "string"
+                "profile_name": {
+                    "type": "string",
+                    "example": "balanced"
                 },
-                "success": {
-                    "type": "boolean"
+                "source": {
+                    "$ref": "#/definitions/dto.FanTempSource"
                 },
-                "timestamp": {
-                    "type": "string"
+                "temp_sensor_path": {
+                    "type": "string",
+                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
                 }
-            }
-        },
-        "dto.CollectorStatus": {
-            "type": "object",
-            "properties": {
-                "enabled": {
-                    "type": "boolean"
-                },
-                "error_count": {
-                    "type": "integer"
-                },
-                "interval_seconds": {
-                    "description": "0 if disabled",
-                    "type": "integer"
-                },
-                "last_run": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+            }
+        },
+        "dto.FanSafetyConfig": {
+            "type": "object",
+            "properties": {
+                "critical_temp_celsius": {
+                    "type": "number",
+                    "example": 90
                 },
-                "required": {
-                    "description": "true if collector cannot be disabled",
-                    "type": "boolean"
+                "failure_rpm_threshold": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "status": {
-                    "description": "\"running\", \"stopped\", \"disabled\", \"registered\"",
-                    "type": "string"
+                "min_speed_percent": {
+                    "type": "integer",
+                    "example": 20
                 }
             }
         },
-        "dto.CollectorsStatusResponse": {
+        "dto.FanSensorCatalog": {
             "type": "object",
             "properties": {
-                "collectors": {
+                "drives": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.CollectorStatus"
+                        "$ref": "#/definitions/dto.AvailableDriveSensor"
                     }
                 },
-                "disabled_count": {
-                    "type": "integer"
-                },
-                "enabled_count": {
-                    "type": "integer"
+                "hwmon_sensors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AvailableTempSensor"
+                    }
                 },
                 "timestamp": {
                     "type": "string"
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.ContainerAutostartRequest": {
+        "dto.FanSpeedRequest": {
             "type": "object",
             "properties": {
-                "enabled": {
-                    "description": "Enabled controls whether autostart is turned on (true) or off (false).",
-                    "type": "boolean"
+                "fan_id": {
+                    "type": "string",
+                    "example": "hwmon0_fan1"
+                },
+                "pwm_percent": {
+                    "type": "integer",
+                    "example": 50
                 }
             }
         },
-        "dto.ContainerBulkUpdateResult": {
+        "dto.FanTempSource": {
             "type": "object",
             "properties": {
-                "failed": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "results": {
+                "drive_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ContainerUpdateResult"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "disk1",
+                        "disk2"
+                    ]
                 },
-                "skipped": {
-                    "type": "integer",
-                    "example": 1
+                "fallback_sensor_path": {
+                    "type": "string",
+                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
                 },
-                "succeeded": {
-                    "type": "integer",
-                    "example": 8
+                "sensor_path": {
+                    "type": "string",
+                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
                 },
-                "timestamp": {
-                    "type": "string"
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.FanTempSourceType"
+                        }
+                    ],
+                    "example": "drives"
                 }
             }
         },
-        "dto.ContainerInfo": {
+        "dto.FanTempSourceType": {
+            "type": "string",
+            "enum": [
+                "hwmon",
+                "drives"
+            ],
+            "x-enum-varnames": [
+                "FanTempSourceHwmon",
+                "FanTempSourceDrives"
+            ]
+        },
+        "dto.FileEntry": {
             "type": "object",
             "properties": {
-                "cpu_percent": {
-                    "type": "number",
-                    "example": 5.2
-                },
-                "id": {
-                    "type": "string",
-                    "example": "abc123def456"
-                },
-                "image": {
-                    "type": "string",
-                    "example": "plexinc/pms-docker:latest"
+                "mod_time": {
+                    "type": "string"
                 },
-                "ip_address": {
+                "mode": {
                     "type": "string",
-                    "example": "172.17.0.2"
+                    "example": "-rw-r--r--"
                 },
-                "mac_address": {
-                    "description": "Container MAC (Docker 29 / Unraid 7.3 fixed-MAC support)",
+                "name": {
                     "type": "string",
-                    "example": "02:42:ac:11:00:02"
+                    "example": "config.xml"
                 },
-                "memory_display": {
+                "path": {
                     "type": "string",
-                    "example": "1 GiB / 8 GiB"
+                    "example": "/mnt/user/appdata/sonarr/config.xml"
                 },
-                "memory_limit_bytes": {
+                "size_bytes": {
                     "type": "integer",
-                    "example": 8589934592
-                },
-                "memory_percent": {
-                    "type": "number",
-                    "example": 12.5
+                    "example": 2048
                 },
-                "memory_usage_bytes": {
+                "type": {
+                    "description": "\"file\", \"dir\", \"symlink\" or \"other\"",
+                    "type": "string",
+                    "example": "file"
+                }
+            }
+        },
+        "dto.FilesystemAnalyzeJob": {
+            "type": "object",
+            "properties": {
+                "depth": {
                     "type": "integer",
-                    "example": 1073741824
+                    "example": 2
                 },
-                "memory_usage_mb": {
-                    "type": "number",
-                    "example": 1024
+                "error": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "plex"
+                "finished_at": {
+                    "type": "string"
                 },
-                "network_mode": {
+                "id": {
                     "type": "string",
-                    "example": "bridge"
+                    "example": "9f2c4e1ab07d3356"
                 },
-                "network_rx_bytes": {
+                "limit": {
                     "type": "integer",
-                    "example": 104857600
-                },
-                "network_rx_bytes_per_sec": {
-                    "type": "number",
-                    "example": 1024
+                    "example": 20
                 },
-                "network_tx_bytes": {
-                    "type": "integer",
-                    "example": 52428800
+                "path": {
+                    "type": "string",
+                    "example": "/mnt/cache/appdata"
                 },
-                "network_tx_bytes_per_sec": {
-                    "type": "number",
-                    "example": 512
+                "result": {
+                    "$ref": "#/definitions/dto.DirectorySize"
                 },
-                "port_mappings": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "scanned_bytes": {
+                    "type": "integer",
+                    "example": 18253611008
                 },
-                "ports": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.PortMapping"
-                    }
+                "scanned_files": {
+                    "type": "integer",
+                    "example": 52011
                 },
-                "restart_count": {
+                "skipped_dirs": {
+                    "description": "Directories that could not be read",
                     "type": "integer",
                     "example": 0
                 },
-                "restart_policy": {
-                    "type": "string",
-                    "example": "unless-stopped"
-                },
-                "source_status": {
-                    "$ref": "#/definitions/dto.SourceStatus"
+                "started_at": {
+                    "type": "string"
                 },
                 "state": {
+                    "description": "\"running\", \"completed\", \"failed\", \"cancelled\"",
                     "type": "string",
                     "example": "running"
-                },
-                "status": {
-                    "type": "string",
-                    "example": "Up 2 days"
-                },
-                "timestamp": {
-                    "type": "string"
-                },
-                "update_available": {
-                    "description": "null when not yet checked / registry unreachable (field omitted in JSON)",
-                    "type": "boolean"
-                },
-                "update_checked": {
-                    "type": "string"
-                },
-                "update_status": {
-                    "description": "Update status — populated by merging the DockerUpdate collector's cache at read time.",
-                    "type": "string",
-                    "example": "up_to_date"
-                },
-                "uptime": {
-                    "type": "string",
-                    "example": "2 days"
-                },
-                "version": {
-                    "type": "string",
-                    "example": "1.40.1"
-                },
-                "volume_mappings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.VolumeMapping"
-                    }
                 }
             }
         },
-        "dto.ContainerLogs": {
+        "dto.FilesystemAnalyzeRequest": {
             "type": "object",
             "properties": {
-                "container_id": {
-                    "type": "string",
-                    "example": "abc123def456"
-                },
-                "container_name": {
-                    "type": "string",
-                    "example": "plex"
-                },
-                "line_count": {
+                "depth": {
+                    "description": "Levels of children to report (1-3, default 1)",
                     "type": "integer",
-                    "example": 100
-                },
-                "logs": {
-                    "type": "string"
-                },
-                "since": {
-                    "type": "string",
-                    "example": "2026-02-17T00:00:00Z"
+                    "example": 2
                 },
-                "timestamp": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.ContainerRemoveRequest": {
-            "type": "object",
-            "properties": {
-                "confirm": {
-                    "description": "Confirm must be set to true to authorise the destructive remove operation.",
-                    "type": "boolean"
+                "limit": {
+                    "description": "Largest children kept per directory (1-100, default 20)",
+                    "type": "integer",
+                    "example": 20
                 },
-                "remove_image": {
-                    "type": "boolean"
+                "path": {
+                    "type": "string",
+                    "example": "/mnt/cache/appdata"
                 }
             }
         },
-        "dto.ContainerSizeInfo": {
+        "dto.FlashDriveHealth": {
+            "description": "USB flash boot drive health information",
             "type": "object",
             "properties": {
-                "container_id": {
-                    "type": "string",
-                    "example": "abc123def456"
-                },
-                "container_name": {
+                "device": {
+                    "description": "Device path",
                     "type": "string",
-                    "example": "plex"
+                    "example": "/dev/sda"
                 },
-                "image_size_bytes": {
+                "free_bytes": {
+                    "description": "Free space in bytes",
                     "type": "integer",
-                    "example": 536870912
+                    "example": 28752636928
                 },
-                "size_display": {
+                "guid": {
+                    "description": "Flash GUID",
                     "type": "string",
-                    "example": "1.0 GiB"
+                    "example": "0781-5583-..."
                 },
-                "size_root_fs_bytes": {
-                    "type": "integer",
-                    "example": 1073741824
+                "model": {
+                    "description": "Device model",
+                    "type": "string",
+                    "example": "SanDisk Ultra Fit"
                 },
-                "size_rw_bytes": {
+                "size_bytes": {
+                    "description": "Size and usage",
                     "type": "integer",
-                    "example": 104857600
+                    "example": 30752636928
+                },
+                "smart_available": {
+                    "description": "SMART status (may be limited for USB)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "smart_status": {
+                    "description": "SMART status if available",
+                    "type": "string",
+                    "example": "PASSED"
                 },
                 "timestamp": {
                     "type": "string"
+                },
+                "usage_percent": {
+                    "description": "Usage percentage",
+                    "type": "number",
+                    "example": 6.5
+                },
+                "used_bytes": {
+                    "description": "Used space in bytes",
+                    "type": "integer",
+                    "example": 2000000000
+                },
+                "vendor": {
+                    "description": "Device vendor",
+                    "type": "string",
+                    "example": "SanDisk"
                 }
             }
         },
-        "dto.ContainerUpdateInfo": {
+        "dto.FleetMember": {
             "type": "object",
             "properties": {
-                "container_id": {
-                    "type": "string",
-                    "example": "abc123def456"
-                },
-                "container_name": {
-                    "type": "string",
-                    "example": "plex"
+                "agent_version": {
+                    "type": "string"
                 },
-                "current_digest": {
+                "array_state": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string",
-                    "example": "plexinc/pms-docker:latest"
+                "array_used_percent": {
+                    "type": "number"
                 },
-                "latest_digest": {
+                "cpu_usage_percent": {
+                    "type": "number"
+                },
+                "error": {
                     "type": "string"
                 },
-                "timestamp": {
+                "hostname": {
                     "type": "string"
                 },
-                "update_available": {
-                    "type": "boolean",
-                    "example": true
-                }
-            }
-        },
-        "dto.ContainerUpdateResult": {
-            "type": "object",
-            "properties": {
-                "container_id": {
-                    "type": "string",
-                    "example": "abc123def456"
+                "latency_ms": {
+                    "type": "number"
                 },
-                "container_name": {
-                    "type": "string",
-                    "example": "plex"
+                "local": {
+                    "type": "boolean"
                 },
-                "image": {
-                    "type": "string",
-                    "example": "plexinc/pms-docker:latest"
+                "name": {
+                    "type": "string"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Container updated successfully"
+                "ram_usage_percent": {
+                    "type": "number"
                 },
-                "new_digest": {
-                    "type": "string"
+                "reachable": {
+                    "type": "boolean"
                 },
-                "previous_digest": {
+                "unraid_version": {
                     "type": "string"
                 },
-                "recreated": {
-                    "type": "boolean",
-                    "example": true
+                "uptime_seconds": {
+                    "type": "integer"
                 },
-                "timestamp": {
+                "url": {
                     "type": "string"
                 },
-                "updated": {
-                    "type": "boolean",
-                    "example": true
+                "via": {
+                    "description": "Via is \"agent\" when the status came from the peer's agent and \"ssh\"\nwhen it came from the status relay command.",
+                    "type": "string",
+                    "example": "agent"
                 }
             }
         },
-        "dto.ContainerUpdatesResult": {
+        "dto.FleetOverview": {
             "type": "object",
             "properties": {
-                "containers": {
+                "members": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ContainerUpdateInfo"
+                        "$ref": "#/definitions/dto.FleetMember"
                     }
                 },
+                "reachable": {
+                    "type": "integer"
+                },
                 "timestamp": {
                     "type": "string"
                 },
-                "total_count": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "updates_available": {
-                    "type": "integer",
-                    "example": 2
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.DegradedSubsystems": {
+        "dto.FleetPeer": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "added_at": {
+                    "type": "string"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.SourceStatus"
-                    }
+                "api_key": {
+                    "description": "APIKey, if set, is sent to the peer as a bearer token. It is never\nreturned by the API.",
+                    "type": "string"
+                },
+                "mac_address": {
+                    "description": "MACAddress is the peer's NIC for the \"wake\" relay command.",
+                    "type": "string",
+                    "example": "a8:a1:59:12:34:56"
+                },
+                "name": {
+                    "description": "Name identifies the peer in /fleet/{server} URLs.",
+                    "type": "string",
+                    "example": "backup-nas"
+                },
+                "ssh": {
+                    "description": "SSH, if set, lets the agent run the relay commands on the peer with\nthe fleet SSH key, e.g. for servers that do not run the agent yet.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.FleetPeerSSH"
+                        }
+                    ]
+                },
+                "url": {
+                    "description": "URL is the peer agent's base URL, e.g. http://192.168.1.20:8043, with\nthe path prefix of a reverse proxy if there is one. It may be empty\nfor a peer that is only reachable over SSH.",
+                    "type": "string",
+                    "example": "http://192.168.1.20:8043"
                 }
             }
         },
-        "dto.DiskCacheInfo": {
+        "dto.FleetPeerSSH": {
             "type": "object",
             "properties": {
-                "dirty_background_ratio": {
-                    "type": "integer",
-                    "example": 10
+                "host": {
+                    "type": "string",
+                    "example": "192.168.1.20"
                 },
-                "dirty_expire_centisecs": {
+                "port": {
+                    "description": "Port defaults to 22.",
                     "type": "integer",
-                    "example": 1000
+                    "example": 22
                 },
-                "dirty_ratio": {
-                    "type": "integer",
-                    "example": 20
+                "user": {
+                    "description": "User defaults to root.",
+                    "type": "string",
+                    "example": "root"
+                }
+            }
+        },
+        "dto.FleetRelayRequest": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "description": "Command is one of \"status\", \"wake\", \"reboot\" or \"shutdown\".",
+                    "type": "string",
+                    "example": "status"
                 },
-                "dirty_writeback_centisecs": {
-                    "type": "integer",
-                    "example": 200
+                "confirm": {
+                    "description": "Confirm must be true for \"reboot\" and \"shutdown\".",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.DiskCacheRequest": {
+        "dto.FleetRelayResult": {
             "type": "object",
             "properties": {
-                "dirty_background_ratio": {
-                    "type": "integer",
-                    "example": 10
+                "command": {
+                    "type": "string",
+                    "example": "status"
                 },
-                "dirty_expire_centisecs": {
-                    "type": "integer",
-                    "example": 3000
+                "duration_ms": {
+                    "type": "number",
+                    "example": 182.4
                 },
-                "dirty_ratio": {
-                    "type": "integer",
-                    "example": 20
+                "error": {
+                    "type": "string"
                 },
-                "dirty_writeback_centisecs": {
-                    "type": "integer",
-                    "example": 500
+                "exit_code": {
+                    "type": "integer"
+                },
+                "output": {
+                    "description": "Output is the combined output of the remote command, truncated to 64 KiB.",
+                    "type": "string"
+                },
+                "peer": {
+                    "type": "string",
+                    "example": "backup-nas"
+                },
+                "status": {
+                    "description": "Status is the parsed result of the \"status\" command.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.FleetMember"
+                        }
+                    ]
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "dto.DiskInfo": {
+        "dto.FleetSSHKey": {
             "type": "object",
             "properties": {
-                "device": {
-                    "type": "string",
-                    "example": "sda"
+                "created_at": {
+                    "type": "string"
                 },
-                "filesystem": {
+                "fingerprint": {
                     "type": "string",
-                    "example": "xfs"
-                },
-                "free_bytes": {
-                    "type": "integer",
-                    "example": 6600076243764
+                    "example": "SHA256:q3X4s1mN7cT5bYc0uWzV2kLrJ9aEfGhD8oPiQxRtUyA"
                 },
-                "id": {
+                "public_key": {
                     "type": "string",
-                    "example": "disk1"
+                    "example": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI... unraid-management-agent@tower"
+                }
+            }
+        },
+        "dto.FleetSSHKeyRequest": {
+            "type": "object",
+            "properties": {
+                "private_key": {
+                    "description": "PrivateKey is an unencrypted OpenSSH or PEM private key to import.\nWhen empty, a new ed25519 key is generated.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GPUMetrics": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "io_utilization_percent": {
+                "cpu_temperature_celsius": {
+                    "description": "CPU temp for Intel iGPUs (shares die with CPU)",
                     "type": "number",
-                    "example": 5.2
-                },
-                "model": {
-                    "type": "string",
-                    "example": "WDC WD120EFBX-68B0EN0"
-                },
-                "mount_point": {
-                    "description": "Mount information",
-                    "type": "string",
-                    "example": "/mnt/disk1"
+                    "example": 45
                 },
-                "name": {
+                "driver_version": {
                     "type": "string",
-                    "example": "Disk 1"
+                    "example": "535.183.01"
                 },
-                "power_cycle_count": {
+                "fan_max_rpm": {
+                    "description": "Max fan RPM (AMD discrete)",
                     "type": "integer",
-                    "example": 100
+                    "example": 3000
                 },
-                "power_on_hours": {
+                "fan_rpm": {
+                    "description": "Fan RPM (AMD discrete)",
                     "type": "integer",
-                    "example": 25000
+                    "example": 2000
                 },
-                "read_bytes": {
-                    "description": "I/O Statistics",
-                    "type": "integer",
-                    "example": 1073741824
+                "fan_speed_percent": {
+                    "description": "Fan speed % (NVIDIA)",
+                    "type": "number",
+                    "example": 65
                 },
-                "read_ops": {
+                "index": {
+                    "description": "Globally unique GPU index across all vendors (Intel → NVIDIA → AMD order)",
                     "type": "integer",
-                    "example": 100000
-                },
-                "role": {
-                    "description": "\"parity\", \"parity2\", \"data\", \"cache\", \"pool\"",
-                    "type": "string",
-                    "example": "data"
-                },
-                "serial_number": {
-                    "description": "Disk identification",
-                    "type": "string",
-                    "example": "WD-WMC4N0123456"
+                    "example": 0
                 },
-                "size_bytes": {
+                "memory_total_bytes": {
                     "type": "integer",
-                    "example": 12000138625024
-                },
-                "smart_attributes": {
-                    "description": "Enhanced SMART attributes",
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/dto.SMARTAttribute"
-                    }
+                    "example": 10737418240
                 },
-                "smart_errors": {
+                "memory_used_bytes": {
                     "type": "integer",
-                    "example": 0
-                },
-                "smart_status": {
-                    "type": "string",
-                    "example": "PASSED"
-                },
-                "source_status": {
-                    "description": "SourceStatus is non-nil only when the backing data source is not healthy.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.SourceStatus"
-                        }
-                    ]
+                    "example": 6442450944
                 },
-                "spin_state": {
-                    "description": "\"active\", \"standby\", \"unknown\"",
+                "name": {
                     "type": "string",
-                    "example": "active"
-                },
-                "spindown_delay": {
-                    "type": "integer",
-                    "example": 30
+                    "example": "NVIDIA GeForce RTX 3080"
                 },
-                "status": {
+                "pci_id": {
+                    "description": "PCI bus ID (e.g., \"0000:01:00.0\")",
                     "type": "string",
-                    "example": "OK"
-                },
-                "temp_critical_celsius": {
-                    "description": "Per-disk critical threshold override",
-                    "type": "integer",
-                    "example": 60
+                    "example": "0000:01:00.0"
                 },
-                "temp_warning_celsius": {
-                    "description": "Per-disk temperature thresholds (Issue #46)\nIf set, these override the global defaults. Null/omitted means use global defaults.",
-                    "type": "integer",
-                    "example": 50
+                "power_draw_watts": {
+                    "type": "number",
+                    "example": 250.5
                 },
                 "temperature_celsius": {
                     "type": "number",
-                    "example": 35
+                    "example": 55
                 },
                 "timestamp": {
                     "type": "string"
                 },
-                "usage_percent": {
+                "utilization_gpu_percent": {
                     "type": "number",
-                    "example": 45
+                    "example": 75.5
                 },
-                "used_bytes": {
-                    "type": "integer",
-                    "example": 5400062381260
+                "utilization_memory_percent": {
+                    "type": "number",
+                    "example": 60.2
                 },
-                "write_bytes": {
-                    "type": "integer",
-                    "example": 536870912
+                "uuid": {
+                    "description": "Device UUID (NVIDIA only)",
+                    "type": "string",
+                    "example": "GPU-12345678-1234-1234-1234-123456789012"
                 },
-                "write_ops": {
-                    "type": "integer",
-                    "example": 50000
+                "vendor": {
+                    "description": "\"nvidia\", \"intel\", \"amd\"",
+                    "type": "string",
+                    "example": "nvidia"
                 }
             }
         },
-        "dto.DiskSettings": {
+        "dto.GuestInterfaceTraffic": {
             "type": "object",
             "properties": {
-                "default_filesystem": {
-                    "description": "Default filesystem type (xfs, btrfs, etc.)",
-                    "type": "string"
+                "bridge": {
+                    "description": "Bridge is the bridge the interface is attached to, e.g. br0 or docker0.",
+                    "type": "string",
+                    "example": "br0"
                 },
-                "shutdown_timeout_seconds": {
-                    "description": "Shutdown timeout in seconds",
-                    "type": "integer"
+                "interface": {
+                    "type": "string",
+                    "example": "vnet0"
                 },
-                "spindown_delay_minutes": {
-                    "description": "Default spin down delay in minutes",
-                    "type": "integer"
+                "owner": {
+                    "description": "Owner is the VM name or the container's short ID.",
+                    "type": "string",
+                    "example": "Windows 11"
                 },
-                "spinup_groups": {
-                    "description": "Enable spinup groups",
-                    "type": "boolean"
+                "owner_type": {
+                    "description": "OwnerType is \"vm\" or \"container\".",
+                    "type": "string",
+                    "example": "vm"
                 },
-                "start_array": {
-                    "description": "Auto start array on boot",
-                    "type": "boolean"
+                "rx_bytes": {
+                    "type": "integer",
+                    "example": 104857600
                 },
-                "timestamp": {
-                    "type": "string"
+                "rx_bytes_per_sec": {
+                    "type": "number",
+                    "example": 10240
+                },
+                "tx_bytes": {
+                    "type": "integer",
+                    "example": 52428800
+                },
+                "tx_bytes_per_sec": {
+                    "type": "number",
+                    "example": 2048
                 }
             }
         },
-        "dto.DiskSettingsExtended": {
-            "description": "Extended disk settings with temperature thresholds from Unraid configuration",
+        "dto.HTTPCheckResult": {
             "type": "object",
             "properties": {
-                "critical_utilization_percent": {
-                    "description": "Disk utilization critical threshold",
+                "body_bytes": {
+                    "description": "Bytes read, capped at 1 MiB",
                     "type": "integer",
-                    "example": 90
+                    "example": 87
                 },
-                "default_filesystem": {
-                    "description": "Default filesystem type",
-                    "type": "string",
-                    "example": "xfs"
+                "cert_expires_at": {
+                    "type": "string"
                 },
-                "hdd_temp_critical_celsius": {
-                    "description": "HDD critical temperature threshold",
-                    "type": "integer",
-                    "example": 55
+                "connect_ms": {
+                    "type": "number",
+                    "example": 14.8
                 },
-                "hdd_temp_warning_celsius": {
-                    "description": "Temperature thresholds from dynamix.cfg (Issue #45)",
-                    "type": "integer",
-                    "example": 45
+                "dns_ms": {
+                    "description": "Timings in milliseconds. DNS, connect, and TLS are zero when a phase\nwas skipped (IP literal, reused connection, plain HTTP).",
+                    "type": "number",
+                    "example": 2.1
                 },
-                "nvme_power_monitoring": {
-                    "description": "NVME power monitoring setting",
-                    "type": "boolean",
-                    "example": false
+                "duration_ms": {
+                    "type": "number",
+                    "example": 101.7
                 },
-                "shutdown_timeout_seconds": {
-                    "description": "Shutdown timeout in seconds",
-                    "type": "integer",
-                    "example": 90
+                "error": {
+                    "type": "string"
                 },
-                "spindown_delay_minutes": {
-                    "description": "Basic disk settings (from disk.cfg)",
-                    "type": "integer",
-                    "example": 30
+                "final_url": {
+                    "description": "After redirects",
+                    "type": "string",
+                    "example": "https://registry-1.docker.io/v2/"
                 },
-                "spinup_groups": {
-                    "description": "Enable spinup groups",
-                    "type": "boolean",
-                    "example": false
+                "method": {
+                    "type": "string",
+                    "example": "GET"
                 },
-                "ssd_temp_critical_celsius": {
-                    "description": "SSD critical temperature threshold",
-                    "type": "integer",
-                    "example": 70
+                "status": {
+                    "type": "string",
+                    "example": "401 Unauthorized"
                 },
-                "ssd_temp_warning_celsius": {
-                    "description": "SSD warning temperature threshold",
+                "status_code": {
                     "type": "integer",
-                    "example": 60
-                },
-                "start_array": {
-                    "description": "Auto start array on boot",
-                    "type": "boolean",
-                    "example": true
+                    "example": 401
                 },
                 "timestamp": {
                     "type": "string"
                 },
-                "warning_utilization_percent": {
-                    "description": "Disk utilization warning threshold",
-                    "type": "integer",
-                    "example": 70
-                }
-            }
-        },
-        "dto.DockerAggregateStats": {
-            "type": "object",
-            "properties": {
-                "memory_usage_percent": {
+                "tls_ms": {
                     "type": "number",
-                    "example": 25
+                    "example": 31.5
                 },
-                "running_containers": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "timestamp": {
-                    "type": "string"
-                },
-                "total_containers": {
-                    "type": "integer",
-                    "example": 15
+                "tls_version": {
+                    "type": "string",
+                    "example": "TLS 1.3"
                 },
-                "total_cpu_percent": {
+                "ttfb_ms": {
                     "type": "number",
-                    "example": 12.5
-                },
-                "total_memory_limit_bytes": {
-                    "type": "integer",
-                    "example": 34359738368
-                },
-                "total_memory_usage_bytes": {
-                    "type": "integer",
-                    "example": 8589934592
+                    "example": 98.2
                 },
-                "total_memory_usage_mb": {
-                    "type": "number",
-                    "example": 8192
+                "url": {
+                    "type": "string",
+                    "example": "https://registry-1.docker.io/v2/"
                 }
             }
         },
-        "dto.DockerNetworkInfo": {
+        "dto.HardwareInfo": {
             "type": "object",
             "properties": {
-                "attachable": {
-                    "type": "boolean"
+                "baseboard": {
+                    "$ref": "#/definitions/dto.BaseboardInfo"
                 },
-                "container_names": {
+                "bios": {
+                    "$ref": "#/definitions/dto.BIOSInfo"
+                },
+                "boot": {
+                    "$ref": "#/definitions/dto.BootInfo"
+                },
+                "cache": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.CPUCacheInfo"
                     }
                 },
-                "created": {
-                    "type": "string"
-                },
-                "driver": {
-                    "type": "string",
-                    "example": "bridge"
-                },
-                "gateway": {
-                    "type": "string",
-                    "example": "172.17.0.1"
+                "chassis": {
+                    "$ref": "#/definitions/dto.ChassisInfo"
                 },
-                "id": {
-                    "type": "string"
+                "cpu": {
+                    "$ref": "#/definitions/dto.CPUHardwareInfo"
                 },
-                "internal": {
-                    "type": "boolean"
+                "memory_array": {
+                    "$ref": "#/definitions/dto.MemoryArrayInfo"
                 },
-                "labels": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
+                "memory_devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MemoryDeviceInfo"
                     }
                 },
-                "name": {
-                    "type": "string",
-                    "example": "bridge"
-                },
-                "scope": {
-                    "type": "string",
-                    "example": "local"
-                },
-                "subnet": {
-                    "type": "string",
-                    "example": "172.17.0.0/16"
-                },
                 "timestamp": {
                     "type": "string"
+                },
+                "tpm": {
+                    "$ref": "#/definitions/dto.TPMInfo"
                 }
             }
         },
-        "dto.DockerNetworkList": {
+        "dto.HealthCheck": {
             "type": "object",
             "properties": {
-                "count": {
+                "container": {
+                    "description": "Container optionally links the probe to a Docker container (ID or name).\nLinked probe results are reported on that container's ContainerInfo.",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled determines whether this health check is active.",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "ID is the unique identifier for this health check.",
+                    "type": "string"
+                },
+                "interval_seconds": {
+                    "description": "IntervalSeconds is how often the check runs (minimum 10, default 30).",
                     "type": "integer"
                 },
-                "networks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.DockerNetworkInfo"
-                    }
+                "name": {
+                    "description": "Name is a human-readable name for this health check.",
+                    "type": "string"
                 },
-                "timestamp": {
+                "on_fail": {
+                    "description": "OnFail is the remediation action: \"notify\", \"restart_container:\u003cname\u003e\", or \"webhook:\u003curl\u003e\".",
+                    "type": "string"
+                },
+                "success_code": {
+                    "description": "SuccessCode is the expected HTTP status code (HTTP probes only, default 200).",
+                    "type": "integer"
+                },
+                "target": {
+                    "description": "Target is the probe target: URL for HTTP, host:port for TCP, container ID/name for container, hostname/IP for ping.",
                     "type": "string"
+                },
+                "timeout_seconds": {
+                    "description": "TimeoutSeconds is the probe timeout (minimum 1, default 5).",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type is the probe type: \"http\", \"tcp\", \"container\", or \"ping\".",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.HealthCheckType"
+                        }
+                    ]
                 }
             }
         },
-        "dto.DockerSettings": {
+        "dto.HealthCheckEvent": {
             "type": "object",
             "properties": {
-                "custom_networks": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "check_id": {
+                    "description": "CheckID is the health check identifier.",
+                    "type": "string"
                 },
-                "default_network": {
+                "check_name": {
+                    "description": "CheckName is the human-readable check name.",
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
+                "message": {
+                    "description": "Message describes the event.",
+                    "type": "string"
                 },
-                "image_path": {
+                "remediation_taken": {
+                    "description": "RemediationTaken is the action that was executed, if any.",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State is \"healthy\" or \"unhealthy\".",
                     "type": "string"
                 },
                 "timestamp": {
+                    "description": "Timestamp is when the event occurred.",
                     "type": "string"
                 }
             }
         },
-        "dto.ExternalFanControl": {
+        "dto.HealthCheckHistoryResponse": {
             "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "controllers": {
+                "events": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "FanCTRL Plus"
-                    ]
+                        "$ref": "#/definitions/dto.HealthCheckEvent"
+                    }
                 }
             }
         },
-        "dto.FanControlConfig": {
+        "dto.HealthCheckStatus": {
             "type": "object",
             "properties": {
-                "control_enabled": {
-                    "type": "boolean",
-                    "example": false
+                "check_id": {
+                    "description": "CheckID is the health check identifier.",
+                    "type": "string"
                 },
-                "control_method": {
+                "check_name": {
+                    "description": "CheckName is the human-readable check name.",
+                    "type": "string"
+                },
+                "check_type": {
+                    "description": "CheckType is the probe type.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/dto.FanControlMethod"
+                            "$ref": "#/definitions/dto.HealthCheckType"
                         }
-                    ],
-                    "example": "hwmon"
+                    ]
                 },
-                "enabled": {
-                    "type": "boolean",
-                    "example": true
+                "consecutive_fails": {
+                    "description": "ConsecutiveFails is the number of consecutive probe failures.",
+                    "type": "integer"
                 },
-                "poll_interval_seconds": {
-                    "type": "integer",
-                    "example": 5
+                "container": {
+                    "description": "Container is the Docker container (ID or name) the probe is linked to, if any.",
+                    "type": "string"
                 },
-                "safety": {
-                    "$ref": "#/definitions/dto.FanSafetyConfig"
+                "healthy": {
+                    "description": "Healthy is true when the last probe succeeded.",
+                    "type": "boolean"
+                },
+                "last_check": {
+                    "description": "LastCheck is the timestamp of the last probe execution.",
+                    "type": "string"
+                },
+                "last_error": {
+                    "description": "LastError is the error message from the last failed probe, empty if healthy.",
+                    "type": "string"
+                },
+                "last_remediation": {
+                    "description": "LastRemediation is the timestamp of the last remediation action taken.",
+                    "type": "string"
+                },
+                "remediation_action": {
+                    "description": "RemediationAction is the configured on_fail action.",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Target is the probe target.",
+                    "type": "string"
                 }
             }
         },
-        "dto.FanControlMethod": {
-            "type": "string",
-            "enum": [
-                "hwmon",
-                "ipmi"
-            ],
-            "x-enum-varnames": [
-                "FanMethodHwmon",
-                "FanMethodIPMI"
-            ]
-        },
-        "dto.FanControlMode": {
+        "dto.HealthCheckType": {
             "type": "string",
             "enum": [
-                "automatic",
-                "manual",
-                "off"
+                "http",
+                "tcp",
+                "container",
+                "ping"
             ],
             "x-enum-varnames": [
-                "FanModeAutomatic",
-                "FanModeManual",
-                "FanModeOff"
+                "HealthCheckHTTP",
+                "HealthCheckTCP",
+                "HealthCheckContainer",
+                "HealthCheckPing"
             ]
         },
-        "dto.FanControlStatus": {
+        "dto.HealthChecksStatusResponse": {
             "type": "object",
             "properties": {
-                "config": {
-                    "$ref": "#/definitions/dto.FanControlConfig"
-                },
-                "external_control": {
-                    "$ref": "#/definitions/dto.ExternalFanControl"
-                },
-                "fans": {
+                "checks": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.FanDevice"
+                        "$ref": "#/definitions/dto.HealthCheckStatus"
                     }
+                }
+            }
+        },
+        "dto.HealthFinding": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
                 },
-                "profiles": {
+                "recommended_actions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.FanProfile"
+                        "$ref": "#/definitions/dto.ActionRef"
                     }
                 },
-                "summary": {
-                    "$ref": "#/definitions/dto.FanControlSummary"
+                "severity": {
+                    "description": "info|warning|critical",
+                    "type": "string",
+                    "example": "warning"
                 },
-                "timestamp": {
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "dto.FanControlSummary": {
+        "dto.HealthReport": {
             "type": "object",
             "properties": {
-                "controllable_fans": {
-                    "type": "integer",
-                    "example": 2
+                "critical_count": {
+                    "type": "integer"
                 },
-                "failed_fans": {
+                "degraded_subsystems": {
+                    "description": "DegradedSubsystems summarizes data sources whose health is not \"healthy\"\n(OS-resilience). Omitted when all sources are healthy.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.DegradedSubsystems"
+                        }
+                    ]
+                },
+                "findings": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.HealthFinding"
                     }
                 },
-                "total_fans": {
-                    "type": "integer",
-                    "example": 3
+                "generated_at": {
+                    "type": "string"
+                },
+                "info_count": {
+                    "type": "integer"
+                },
+                "warning_count": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.FanCurvePoint": {
+        "dto.HealthStatus": {
             "type": "object",
             "properties": {
-                "speed_percent": {
-                    "type": "integer",
-                    "example": 30
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ComponentHealth"
+                    }
                 },
-                "temp_celsius": {
-                    "type": "number",
-                    "example": 40
+                "ready": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "status": {
+                    "description": "ok|degraded|down",
+                    "type": "string",
+                    "example": "ok"
+                },
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "dto.FanDevice": {
+        "dto.HostPCIDevice": {
             "type": "object",
             "properties": {
-                "active_profile": {
+                "address": {
                     "type": "string",
-                    "example": "balanced"
-                },
-                "controllable": {
-                    "type": "boolean",
-                    "example": true
+                    "example": "0000:01:00.0"
                 },
-                "hwmon_index": {
-                    "type": "integer",
-                    "example": 1
+                "assigned_to": {
+                    "description": "AssignedTo lists the VMs whose definition passes this device through.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "hwmon_path": {
+                "class": {
                     "type": "string",
-                    "example": "/sys/class/hwmon/hwmon0"
+                    "example": "VGA compatible controller"
                 },
-                "id": {
+                "class_id": {
                     "type": "string",
-                    "example": "hwmon0_fan1"
-                },
-                "mode": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.FanControlMode"
-                        }
-                    ],
-                    "example": "automatic"
+                    "example": "030000"
                 },
-                "name": {
+                "device_id": {
                     "type": "string",
-                    "example": "CPU Fan"
+                    "example": "1b80"
                 },
-                "pwm_percent": {
-                    "type": "integer",
-                    "example": 71
+                "driver": {
+                    "description": "Driver is the kernel driver bound to the device; \"vfio-pci\" means it is\nreserved for passthrough.",
+                    "type": "string",
+                    "example": "vfio-pci"
                 },
-                "pwm_value": {
+                "iommu_group": {
                     "type": "integer",
-                    "example": 180
+                    "example": 14
                 },
-                "rpm": {
-                    "type": "integer",
-                    "example": 1200
+                "name": {
+                    "type": "string",
+                    "example": "GP104 [GeForce GTX 1080]"
                 },
-                "temp_sensor_path": {
+                "vendor": {
                     "type": "string",
-                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
+                    "example": "NVIDIA Corporation"
                 },
-                "temp_source": {
-                    "$ref": "#/definitions/dto.FanTempSource"
+                "vendor_id": {
+                    "type": "string",
+                    "example": "10de"
                 }
             }
         },
-        "dto.FanInfo": {
+        "dto.HostUSBDevice": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string",
-                    "example": "CPU Fan"
+                "assigned_to": {
+                    "description": "AssignedTo lists the VMs whose definition (or live configuration, for a\nrunning VM) passes this device through.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "rpm": {
+                "bus": {
                     "type": "integer",
-                    "example": 1200
-                }
-            }
-        },
-        "dto.FanModeRequest": {
-            "type": "object",
-            "properties": {
-                "fan_id": {
+                    "example": 1
+                },
+                "device": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "description": "ID is \"vendor:product\", the form used by the USB hotplug endpoints.",
                     "type": "string",
-                    "example": "hwmon0_fan1"
+                    "example": "046d:c52b"
                 },
-                "mode": {
+                "manufacturer": {
                     "type": "string",
-                    "example": "manual"
+                    "example": "Logitech"
+                },
+                "product": {
+                    "type": "string",
+                    "example": "USB Receiver"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "c52b"
+                },
+                "vendor_id": {
+                    "type": "string",
+                    "example": "046d"
                 }
             }
         },
-        "dto.FanProfile": {
+        "dto.IPMIChassis": {
             "type": "object",
             "properties": {
-                "built_in": {
-                    "type": "boolean",
-                    "example": true
+                "cooling_fault": {
+                    "type": "boolean"
                 },
-                "curve_points": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.FanCurvePoint"
-                    }
+                "drive_fault": {
+                    "type": "boolean"
                 },
-                "description": {
+                "intrusion": {
+                    "description": "Chassis intrusion switch is active",
+                    "type": "boolean"
+                },
+                "last_power_event": {
                     "type": "string",
-                    "example": "Balanced cooling and noise"
+                    "example": "command"
                 },
-                "name": {
+                "main_power_fault": {
+                    "type": "boolean"
+                },
+                "power_control_fault": {
+                    "type": "boolean"
+                },
+                "power_on": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "power_overload": {
+                    "type": "boolean"
+                },
+                "power_restore_policy": {
+                    "description": "always-on, always-off or previous",
                     "type": "string",
-                    "example": "balanced"
+                    "example": "always-on"
                 }
             }
         },
-        "dto.FanProfileCreateRequest": {
+        "dto.IPMIChassisActionRequest": {
             "type": "object",
             "properties": {
-                "curve_points": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.FanCurvePoint"
-                    }
-                },
-                "description": {
+                "action": {
+                    "description": "Action is identify, soft, off, cycle or reset.",
                     "type": "string",
-                    "example": "Custom quiet profile"
+                    "example": "identify"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "custom_quiet"
+                "confirm": {
+                    "description": "Confirm must be true for the power actions, which cut power to the\nserver without stopping the array.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "identify_seconds": {
+                    "description": "IdentifySeconds is how long the chassis identify LED blinks (1-255,\ndefault 15).",
+                    "type": "integer",
+                    "example": 15
                 }
             }
         },
-        "dto.FanProfileRequest": {
+        "dto.IPMISensor": {
             "type": "object",
             "properties": {
-                "fan_id": {
+                "lower_critical": {
+                    "type": "number",
+                    "example": 500
+                },
+                "name": {
                     "type": "string",
-                    "example": "hwmon0_fan1"
+                    "example": "FAN1"
                 },
-                "profile_name": {
+                "reading": {
                     "type": "string",
-                    "example": "balanced"
+                    "example": "Presence detected"
                 },
-                "source": {
-                    "$ref": "#/definitions/dto.FanTempSource"
+                "status": {
+                    "description": "Status is ok, nc (non-critical), cr (critical), nr (non-recoverable)\nor ns (no reading, e.g. an empty fan header).",
+                    "type": "string",
+                    "example": "ok"
                 },
-                "temp_sensor_path": {
+                "type": {
+                    "description": "Type is fan, voltage, temperature, power, current, power_supply or other.",
                     "type": "string",
-                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
+                    "example": "fan"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "RPM"
+                },
+                "upper_critical": {
+                    "type": "number",
+                    "example": 25400
+                },
+                "value": {
+                    "description": "nil when the sensor has no reading",
+                    "type": "number",
+                    "example": 1400
                 }
             }
         },
-        "dto.FanSafetyConfig": {
+        "dto.IPMIStatus": {
             "type": "object",
             "properties": {
-                "critical_temp_celsius": {
-                    "type": "number",
-                    "example": 90
+                "available": {
+                    "description": "ipmitool is installed and the BMC answered",
+                    "type": "boolean",
+                    "example": true
                 },
-                "failure_rpm_threshold": {
-                    "type": "integer",
-                    "example": 100
+                "chassis": {
+                    "$ref": "#/definitions/dto.IPMIChassis"
                 },
-                "min_speed_percent": {
+                "problem_count": {
+                    "description": "ProblemCount is the number of sensors outside their thresholds or\nreporting a power supply fault.",
                     "type": "integer",
-                    "example": 20
-                }
-            }
-        },
-        "dto.FanSensorCatalog": {
-            "type": "object",
-            "properties": {
-                "drives": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.AvailableDriveSensor"
-                    }
+                    "example": 0
                 },
-                "hwmon_sensors": {
+                "sensors": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.AvailableTempSensor"
+                        "$ref": "#/definitions/dto.IPMISensor"
                     }
                 },
                 "timestamp": {
@@ -7635,523 +16954,524 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.FanSpeedRequest": {
+        "dto.InboundHook": {
+            "description": "Inbound webhook and the action it triggers",
             "type": "object",
             "properties": {
-                "fan_id": {
+                "action": {
+                    "description": "Action is script, container_start, container_stop, container_restart,\nvm_start or vm_stop.",
                     "type": "string",
-                    "example": "hwmon0_fan1"
+                    "example": "container_restart"
                 },
-                "pwm_percent": {
-                    "type": "integer",
-                    "example": 50
-                }
-            }
-        },
-        "dto.FanTempSource": {
-            "type": "object",
-            "properties": {
-                "drive_ids": {
+                "args": {
+                    "description": "Args are passed to a script action instead of its default arguments.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Restart the app after GitHub Actions pushes a new image"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "description": "Events limits the hook to these sender events (X-GitHub-Event header or\nthe eventType field of Sonarr/Radarr payloads); empty accepts all.",
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "disk1",
-                        "disk2"
+                        "workflow_run",
+                        "Download"
                     ]
                 },
-                "fallback_sensor_path": {
+                "name": {
+                    "description": "Name identifies the hook in /hooks/{name} URLs.",
                     "type": "string",
-                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
+                    "example": "deploy-app"
                 },
-                "sensor_path": {
-                    "type": "string",
-                    "example": "/sys/class/hwmon/hwmon0/temp1_input"
+                "secret": {
+                    "description": "Secret authenticates callers. It is generated when left empty on\ncreate, returned only by the create call, and redacted afterwards.",
+                    "type": "string"
                 },
-                "type": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.FanTempSourceType"
-                        }
-                    ],
-                    "example": "drives"
+                "target": {
+                    "description": "Target is the registered script, container or VM the action applies to.",
+                    "type": "string",
+                    "example": "myapp"
                 }
             }
         },
-        "dto.FanTempSourceType": {
-            "type": "string",
-            "enum": [
-                "hwmon",
-                "drives"
-            ],
-            "x-enum-varnames": [
-                "FanTempSourceHwmon",
-                "FanTempSourceDrives"
-            ]
-        },
-        "dto.FlashDriveHealth": {
-            "description": "USB flash boot drive health information",
+        "dto.InboundHookCreated": {
             "type": "object",
             "properties": {
-                "device": {
-                    "description": "Device path",
-                    "type": "string",
-                    "example": "/dev/sda"
-                },
-                "free_bytes": {
-                    "description": "Free space in bytes",
-                    "type": "integer",
-                    "example": 28752636928
-                },
-                "guid": {
-                    "description": "Flash GUID",
+                "message": {
                     "type": "string",
-                    "example": "0781-5583-..."
+                    "example": "Webhook created"
                 },
-                "model": {
-                    "description": "Device model",
+                "secret": {
                     "type": "string",
-                    "example": "SanDisk Ultra Fit"
-                },
-                "size_bytes": {
-                    "description": "Size and usage",
-                    "type": "integer",
-                    "example": 30752636928
+                    "example": "3f1c9a0e5b7d2468ace013579bdf2468ace013579bdf2468ace013579bdf2468"
                 },
-                "smart_available": {
-                    "description": "SMART status (may be limited for USB)",
+                "success": {
                     "type": "boolean",
-                    "example": false
-                },
-                "smart_status": {
-                    "description": "SMART status if available",
-                    "type": "string",
-                    "example": "PASSED"
+                    "example": true
                 },
                 "timestamp": {
                     "type": "string"
                 },
-                "usage_percent": {
-                    "description": "Usage percentage",
-                    "type": "number",
-                    "example": 6.5
-                },
-                "used_bytes": {
-                    "description": "Used space in bytes",
-                    "type": "integer",
-                    "example": 2000000000
-                },
-                "vendor": {
-                    "description": "Device vendor",
+                "url": {
+                    "description": "URL is the path to configure in the sending service.",
                     "type": "string",
-                    "example": "SanDisk"
+                    "example": "/api/v1/hooks/deploy-app"
                 }
             }
         },
-        "dto.GPUMetrics": {
+        "dto.InboundHookInfo": {
             "type": "object",
             "properties": {
-                "available": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "cpu_temperature_celsius": {
-                    "description": "CPU temp for Intel iGPUs (shares die with CPU)",
-                    "type": "number",
-                    "example": 45
-                },
-                "driver_version": {
+                "action": {
+                    "description": "Action is script, container_start, container_stop, container_restart,\nvm_start or vm_stop.",
                     "type": "string",
-                    "example": "535.183.01"
-                },
-                "fan_max_rpm": {
-                    "description": "Max fan RPM (AMD discrete)",
-                    "type": "integer",
-                    "example": 3000
+                    "example": "container_restart"
                 },
-                "fan_rpm": {
-                    "description": "Fan RPM (AMD discrete)",
-                    "type": "integer",
-                    "example": 2000
+                "args": {
+                    "description": "Args are passed to a script action instead of its default arguments.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "fan_speed_percent": {
-                    "description": "Fan speed % (NVIDIA)",
-                    "type": "number",
-                    "example": 65
+                "created_at": {
+                    "type": "string"
                 },
-                "index": {
-                    "description": "Globally unique GPU index across all vendors (Intel → NVIDIA → AMD order)",
-                    "type": "integer",
-                    "example": 0
+                "description": {
+                    "type": "string",
+                    "example": "Restart the app after GitHub Actions pushes a new image"
                 },
-                "memory_total_bytes": {
-                    "type": "integer",
-                    "example": 10737418240
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "memory_used_bytes": {
-                    "type": "integer",
-                    "example": 6442450944
+                "events": {
+                    "description": "Events limits the hook to these sender events (X-GitHub-Event header or\nthe eventType field of Sonarr/Radarr payloads); empty accepts all.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "workflow_run",
+                        "Download"
+                    ]
                 },
-                "name": {
+                "last_result": {
+                    "description": "LastResult is \"ok\" or the error of the last triggered action.",
                     "type": "string",
-                    "example": "NVIDIA GeForce RTX 3080"
+                    "example": "ok"
                 },
-                "pci_id": {
-                    "description": "PCI bus ID (e.g., \"0000:01:00.0\")",
+                "last_triggered_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name identifies the hook in /hooks/{name} URLs.",
                     "type": "string",
-                    "example": "0000:01:00.0"
+                    "example": "deploy-app"
                 },
-                "power_draw_watts": {
-                    "type": "number",
-                    "example": 250.5
+                "secret": {
+                    "description": "Secret authenticates callers. It is generated when left empty on\ncreate, returned only by the create call, and redacted afterwards.",
+                    "type": "string"
                 },
-                "temperature_celsius": {
-                    "type": "number",
-                    "example": 55
+                "target": {
+                    "description": "Target is the registered script, container or VM the action applies to.",
+                    "type": "string",
+                    "example": "myapp"
                 },
-                "timestamp": {
-                    "type": "string"
+                "trigger_count": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.InotifyInfo": {
+            "type": "object",
+            "properties": {
+                "max_queued_events": {
+                    "type": "integer",
+                    "example": 16384
                 },
-                "utilization_gpu_percent": {
-                    "type": "number",
-                    "example": 75.5
+                "max_user_instances": {
+                    "type": "integer",
+                    "example": 128
                 },
-                "utilization_memory_percent": {
-                    "type": "number",
-                    "example": 60.2
+                "max_user_watches": {
+                    "type": "integer",
+                    "example": 524288
+                }
+            }
+        },
+        "dto.InotifyLimitsRequest": {
+            "type": "object",
+            "required": [
+                "max_queued_events",
+                "max_user_instances",
+                "max_user_watches"
+            ],
+            "properties": {
+                "max_queued_events": {
+                    "type": "integer",
+                    "example": 16384
                 },
-                "uuid": {
-                    "description": "Device UUID (NVIDIA only)",
-                    "type": "string",
-                    "example": "GPU-12345678-1234-1234-1234-123456789012"
+                "max_user_instances": {
+                    "type": "integer",
+                    "example": 512
                 },
-                "vendor": {
-                    "description": "\"nvidia\", \"intel\", \"amd\"",
-                    "type": "string",
-                    "example": "nvidia"
+                "max_user_watches": {
+                    "type": "integer",
+                    "example": 524288
                 }
             }
         },
-        "dto.HardwareInfo": {
+        "dto.Job": {
             "type": "object",
             "properties": {
-                "baseboard": {
-                    "$ref": "#/definitions/dto.BaseboardInfo"
+                "created_at": {
+                    "type": "string"
                 },
-                "bios": {
-                    "$ref": "#/definitions/dto.BIOSInfo"
+                "error": {
+                    "type": "string"
                 },
-                "boot": {
-                    "$ref": "#/definitions/dto.BootInfo"
+                "finished_at": {
+                    "type": "string"
                 },
-                "cache": {
+                "id": {
+                    "type": "string",
+                    "example": "3f9a1c2b7d4e8f60"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "parity_check"
+                },
+                "logs": {
+                    "description": "Last log lines, oldest first",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.CPUCacheInfo"
+                        "type": "string"
                     }
                 },
-                "chassis": {
-                    "$ref": "#/definitions/dto.ChassisInfo"
+                "message": {
+                    "type": "string",
+                    "example": "parity check 42.5% complete"
                 },
-                "cpu": {
-                    "$ref": "#/definitions/dto.CPUHardwareInfo"
+                "progress": {
+                    "description": "Percent; stays 0 when the operation reports none",
+                    "type": "number",
+                    "example": 42.5
                 },
-                "memory_array": {
-                    "$ref": "#/definitions/dto.MemoryArrayInfo"
+                "result": {},
+                "started_at": {
+                    "type": "string"
                 },
-                "memory_devices": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.MemoryDeviceInfo"
-                    }
+                "state": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.JobState"
+                        }
+                    ],
+                    "example": "running"
+                },
+                "target": {
+                    "description": "Container, VM or other subject of the job",
+                    "type": "string",
+                    "example": "plex"
                 },
-                "timestamp": {
+                "updated_at": {
                     "type": "string"
-                },
-                "tpm": {
-                    "$ref": "#/definitions/dto.TPMInfo"
                 }
             }
         },
-        "dto.HealthCheck": {
+        "dto.JobState": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "running",
+                "succeeded",
+                "failed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "JobQueued",
+                "JobRunning",
+                "JobSucceeded",
+                "JobFailed",
+                "JobCancelled"
+            ]
+        },
+        "dto.LogFileContent": {
             "type": "object",
             "properties": {
-                "enabled": {
-                    "description": "Enabled determines whether this health check is active.",
-                    "type": "boolean"
-                },
-                "id": {
-                    "description": "ID is the unique identifier for this health check.",
+                "content": {
                     "type": "string"
                 },
-                "interval_seconds": {
-                    "description": "IntervalSeconds is how often the check runs (minimum 10, default 30).",
+                "end_line": {
                     "type": "integer"
                 },
-                "name": {
-                    "description": "Name is a human-readable name for this health check.",
-                    "type": "string"
-                },
-                "on_fail": {
-                    "description": "OnFail is the remediation action: \"notify\", \"restart_container:\u003cname\u003e\", or \"webhook:\u003curl\u003e\".",
-                    "type": "string"
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "success_code": {
-                    "description": "SuccessCode is the expected HTTP status code (HTTP probes only, default 200).",
+                "lines_returned": {
                     "type": "integer"
                 },
-                "target": {
-                    "description": "Target is the probe target: URL for HTTP, host:port for TCP, container ID/name for container, hostname/IP for ping.",
+                "path": {
                     "type": "string"
                 },
-                "timeout_seconds": {
-                    "description": "TimeoutSeconds is the probe timeout (minimum 1, default 5).",
+                "start_line": {
                     "type": "integer"
                 },
-                "type": {
-                    "description": "Type is the probe type: \"http\", \"tcp\", \"container\", or \"ping\".",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.HealthCheckType"
-                        }
-                    ]
+                "total_lines": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.HealthCheckEvent": {
+        "dto.LogSearchMatch": {
             "type": "object",
             "properties": {
-                "check_id": {
-                    "description": "CheckID is the health check identifier.",
-                    "type": "string"
-                },
-                "check_name": {
-                    "description": "CheckName is the human-readable check name.",
+                "file": {
                     "type": "string"
                 },
-                "message": {
-                    "description": "Message describes the event.",
-                    "type": "string"
-                },
-                "remediation_taken": {
-                    "description": "RemediationTaken is the action that was executed, if any.",
-                    "type": "string"
-                },
-                "state": {
-                    "description": "State is \"healthy\" or \"unhealthy\".",
-                    "type": "string"
+                "line": {
+                    "type": "integer"
                 },
-                "timestamp": {
-                    "description": "Timestamp is when the event occurred.",
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "dto.HealthCheckHistoryResponse": {
+        "dto.LogSearchResult": {
             "type": "object",
             "properties": {
-                "events": {
+                "files": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.HealthCheckEvent"
+                        "type": "string"
+                    }
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LogSearchMatch"
+                    }
+                },
+                "query": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "skipped_files": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_matches": {
+                    "type": "integer"
+                },
+                "truncated": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.HealthCheckStatus": {
+        "dto.MDDevice": {
             "type": "object",
             "properties": {
-                "check_id": {
-                    "description": "CheckID is the health check identifier.",
-                    "type": "string"
+                "device": {
+                    "description": "rdevName",
+                    "type": "string",
+                    "example": "sdc"
                 },
-                "check_name": {
-                    "description": "CheckName is the human-readable check name.",
-                    "type": "string"
+                "errors": {
+                    "description": "rdevNumErrors",
+                    "type": "integer",
+                    "example": 0
                 },
-                "check_type": {
-                    "description": "CheckType is the probe type.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.HealthCheckType"
-                        }
-                    ]
+                "id": {
+                    "description": "rdevId",
+                    "type": "string",
+                    "example": "WDC_WD120EFBX"
                 },
-                "consecutive_fails": {
-                    "description": "ConsecutiveFails is the number of consecutive probe failures.",
-                    "type": "integer"
+                "name": {
+                    "description": "diskName",
+                    "type": "string",
+                    "example": "disk1"
                 },
-                "healthy": {
-                    "description": "Healthy is true when the last probe succeeded.",
-                    "type": "boolean"
+                "nr_requests": {
+                    "description": "NrRequests is the block queue depth of the device\n(/sys/block/\u003cdevice\u003e/queue/nr_requests).",
+                    "type": "integer",
+                    "example": 128
                 },
-                "last_check": {
-                    "description": "LastCheck is the timestamp of the last probe execution.",
-                    "type": "string"
+                "reads": {
+                    "type": "integer",
+                    "example": 123456
                 },
-                "last_error": {
-                    "description": "LastError is the error message from the last failed probe, empty if healthy.",
-                    "type": "string"
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 12000138625024
                 },
-                "last_remediation": {
-                    "description": "LastRemediation is the timestamp of the last remediation action taken.",
-                    "type": "string"
+                "slot": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "remediation_action": {
-                    "description": "RemediationAction is the configured on_fail action.",
-                    "type": "string"
+                "status": {
+                    "description": "rdevStatus",
+                    "type": "string",
+                    "example": "DISK_OK"
                 },
-                "target": {
-                    "description": "Target is the probe target.",
-                    "type": "string"
+                "writes": {
+                    "type": "integer",
+                    "example": 65432
                 }
             }
         },
-        "dto.HealthCheckType": {
-            "type": "string",
-            "enum": [
-                "http",
-                "tcp",
-                "container",
-                "ping"
-            ],
-            "x-enum-varnames": [
-                "HealthCheckHTTP",
-                "HealthCheckTCP",
-                "HealthCheckContainer",
-                "HealthCheckPing"
-            ]
-        },
-        "dto.HealthChecksStatusResponse": {
+        "dto.MDResyncStatus": {
             "type": "object",
             "properties": {
-                "checks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.HealthCheckStatus"
-                    }
+                "action": {
+                    "description": "mdResyncAction",
+                    "type": "string",
+                    "example": "check P"
+                },
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "correcting": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "paused": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "percent_complete": {
+                    "type": "number",
+                    "example": 27.5
+                },
+                "position_bytes": {
+                    "type": "integer",
+                    "example": 4398046511104
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 16000900661248
+                },
+                "speed_bytes_per_sec": {
+                    "description": "Over the driver's last sample window",
+                    "type": "integer",
+                    "example": 185000000
                 }
             }
         },
-        "dto.HealthFinding": {
+        "dto.MDStat": {
+            "description": "Unraid md driver status: raw /proc/mdstat text, parsed array, resync and device fields, and sync tuning",
             "type": "object",
             "properties": {
-                "detail": {
-                    "type": "string"
-                },
-                "recommended_actions": {
+                "devices": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ActionRef"
+                        "$ref": "#/definitions/dto.MDDevice"
                     }
                 },
-                "severity": {
-                    "description": "info|warning|critical",
-                    "type": "string",
-                    "example": "warning"
+                "global": {
+                    "description": "Global holds every key without a device index (sbName, mdState,\nmdResyncAction, ...) as reported.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "title": {
+                "last_sync_exit": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "num_disabled": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "num_disks": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "num_invalid": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "num_missing": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "raw": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.HealthReport": {
-            "type": "object",
-            "properties": {
-                "critical_count": {
-                    "type": "integer"
                 },
-                "degraded_subsystems": {
-                    "description": "DegradedSubsystems summarizes data sources whose health is not \"healthy\"\n(OS-resilience). Omitted when all sources are healthy.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.DegradedSubsystems"
-                        }
-                    ]
+                "resync": {
+                    "$ref": "#/definitions/dto.MDResyncStatus"
                 },
-                "findings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.HealthFinding"
-                    }
+                "state": {
+                    "type": "string",
+                    "example": "STARTED"
                 },
-                "generated_at": {
-                    "type": "string"
+                "sync_errors": {
+                    "description": "sbSyncErrs: errors found by the last parity operation",
+                    "type": "integer",
+                    "example": 0
                 },
-                "info_count": {
-                    "type": "integer"
+                "timestamp": {
+                    "type": "string"
                 },
-                "warning_count": {
-                    "type": "integer"
+                "tuning": {
+                    "$ref": "#/definitions/dto.MDTuning"
                 }
             }
         },
-        "dto.InotifyInfo": {
+        "dto.MDTuning": {
             "type": "object",
             "properties": {
-                "max_queued_events": {
-                    "type": "integer",
-                    "example": 16384
-                },
-                "max_user_instances": {
+                "sync_speed_max": {
+                    "description": "dev.raid.speed_limit_max, KB/s",
                     "type": "integer",
-                    "example": 128
+                    "example": 200000
                 },
-                "max_user_watches": {
+                "sync_speed_min": {
+                    "description": "dev.raid.speed_limit_min, KB/s",
                     "type": "integer",
-                    "example": 524288
+                    "example": 1000
                 }
             }
         },
-        "dto.InotifyLimitsRequest": {
+        "dto.MDTuningRequest": {
             "type": "object",
-            "required": [
-                "max_queued_events",
-                "max_user_instances",
-                "max_user_watches"
-            ],
             "properties": {
-                "max_queued_events": {
+                "nr_requests": {
                     "type": "integer",
-                    "example": 16384
+                    "example": 256
                 },
-                "max_user_instances": {
+                "sync_speed_max": {
                     "type": "integer",
-                    "example": 512
+                    "example": 100000
                 },
-                "max_user_watches": {
+                "sync_speed_min": {
                     "type": "integer",
-                    "example": 524288
-                }
-            }
-        },
-        "dto.LogFileContent": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "end_line": {
-                    "type": "integer"
-                },
-                "lines": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "lines_returned": {
-                    "type": "integer"
-                },
-                "path": {
-                    "type": "string"
-                },
-                "start_line": {
-                    "type": "integer"
-                },
-                "total_lines": {
-                    "type": "integer"
+                    "example": 1000
                 }
             }
         },
@@ -8254,6 +17574,59 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.MaintenanceRequest": {
+            "description": "Maintenance window to start; an active window is extended and its reason and throttle replaced",
+            "type": "object",
+            "properties": {
+                "duration_minutes": {
+                    "description": "Window length from now, 1 to 4320 (72 hours)",
+                    "type": "integer",
+                    "example": 90
+                },
+                "reason": {
+                    "description": "Shown in status and logs, up to 200 characters",
+                    "type": "string",
+                    "example": "Replacing disk3"
+                },
+                "throttle_factor": {
+                    "description": "Multiply collector intervals by this factor during the window (0 or 1 = no throttling, max 10)",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.MaintenanceStatus": {
+            "description": "Maintenance window state",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Replacing disk3"
+                },
+                "remaining_seconds": {
+                    "type": "integer",
+                    "example": 5400
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "throttle_factor": {
+                    "description": "Collector interval multiplier while active",
+                    "type": "integer",
+                    "example": 4
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.MemoryArrayInfo": {
             "type": "object",
             "properties": {
@@ -8274,6 +17647,73 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.MemoryBreakdown": {
+            "type": "object",
+            "properties": {
+                "docker_bytes": {
+                    "description": "Attribution of ram_used_bytes. DockerBytes is the containers'\nanonymous and kernel memory (their page cache is in ram_cached_bytes);\nVMBytes is the resident memory of QEMU processes plus the huge pages in\nuse; HostBytes is the remainder, including the kernel and free huge pages.",
+                    "type": "integer",
+                    "example": 4294967296
+                },
+                "host_bytes": {
+                    "type": "integer",
+                    "example": 3221225472
+                },
+                "hugepage_size_bytes": {
+                    "type": "integer",
+                    "example": 2097152
+                },
+                "hugepages_bytes": {
+                    "type": "integer",
+                    "example": 17179869184
+                },
+                "hugepages_free": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "hugepages_reserved": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "hugepages_total": {
+                    "description": "Huge pages (/proc/meminfo). The pool is reserved at boot or by VMs\nwith hugepage backing and is not available to anything else.",
+                    "type": "integer",
+                    "example": 8192
+                },
+                "hugepages_used_bytes": {
+                    "type": "integer",
+                    "example": 17179869184
+                },
+                "shmem_bytes": {
+                    "description": "Shmem is the RAM held by tmpfs files and shared memory, counted in\nram_cached_bytes but not freeable by dropping caches.",
+                    "type": "integer",
+                    "example": 536870912
+                },
+                "slab_bytes": {
+                    "description": "Kernel slab caches; reclaimable slab (dentries, inodes) is freed under\nmemory pressure.",
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "slab_reclaimable_bytes": {
+                    "type": "integer",
+                    "example": 805306368
+                },
+                "slab_unreclaimable_bytes": {
+                    "type": "integer",
+                    "example": 268435456
+                },
+                "tmpfs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TmpfsUsage"
+                    }
+                },
+                "vm_bytes": {
+                    "type": "integer",
+                    "example": 2147483648
+                }
+            }
+        },
         "dto.MemoryDeviceInfo": {
             "type": "object",
             "properties": {
@@ -8357,6 +17797,14 @@ const docTemplate = `{
                 "min": {
                     "type": "number"
                 },
+                "note": {
+                    "description": "Note explains gaps, e.g. a requested range that predates the retained history.",
+                    "type": "string"
+                },
+                "retention_seconds": {
+                    "description": "RetentionSeconds is how far back history reaches (older than the raw tier\nthe samples are downsampled averages).",
+                    "type": "integer"
+                },
                 "samples": {
                     "type": "array",
                     "items": {
@@ -8806,6 +18254,29 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.NetworkChange": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message explains a rollback.",
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "rollback_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State is pending, confirmed or rolled_back.",
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
         "dto.NetworkConfig": {
             "type": "object",
             "properties": {
@@ -9007,6 +18478,132 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.NetworkInterfaceSettings": {
+            "type": "object",
+            "properties": {
+                "bond_mode": {
+                    "description": "BondMode is the Linux bonding mode number, 0-6 (1 = active-backup).",
+                    "type": "string",
+                    "example": "1"
+                },
+                "bond_nics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bonding": {
+                    "type": "boolean"
+                },
+                "bridge_nics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bridge_stp": {
+                    "type": "boolean"
+                },
+                "bridging": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "gateway": {
+                    "type": "string",
+                    "example": "192.168.1.1"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "192.168.1.10"
+                },
+                "mtu": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "name": {
+                    "description": "Name is the interface that carries the address: br0, bond0 or eth0.",
+                    "type": "string",
+                    "example": "br0"
+                },
+                "netmask": {
+                    "type": "string",
+                    "example": "255.255.255.0"
+                },
+                "port": {
+                    "type": "string",
+                    "example": "eth0"
+                },
+                "use_dhcp": {
+                    "type": "boolean"
+                },
+                "vlans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NetworkVLANSettings"
+                    }
+                }
+            }
+        },
+        "dto.NetworkInterfaceUpdate": {
+            "type": "object",
+            "properties": {
+                "bond_mode": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "bond_nics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bonding": {
+                    "type": "boolean"
+                },
+                "bridge_stp": {
+                    "type": "boolean"
+                },
+                "bridging": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "gateway": {
+                    "type": "string",
+                    "example": "192.168.1.1"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "192.168.1.10"
+                },
+                "mtu": {
+                    "description": "MTU is 68-9216; 0 restores the default.",
+                    "type": "integer",
+                    "example": 9000
+                },
+                "netmask": {
+                    "type": "string",
+                    "example": "255.255.255.0"
+                },
+                "port": {
+                    "type": "string",
+                    "example": "eth0"
+                },
+                "use_dhcp": {
+                    "type": "boolean"
+                },
+                "vlans": {
+                    "description": "VLANs replaces the port's VLAN list; an empty list removes all VLANs.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NetworkVLANSettings"
+                    }
+                }
+            }
+        },
         "dto.NetworkServiceInfo": {
             "description": "Status information for a single network service",
             "type": "object",
@@ -9166,6 +18763,85 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.NetworkSettings": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "Change is the most recent change applied through the API: pending\nuntil confirmed, or rolled back when the confirmation did not arrive.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.NetworkChange"
+                        }
+                    ]
+                },
+                "dns_servers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "interfaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NetworkInterfaceSettings"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NetworkSettingsUpdate": {
+            "type": "object",
+            "properties": {
+                "dns_servers": {
+                    "description": "DNSServers replaces the static DNS servers (up to three).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "interfaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NetworkInterfaceUpdate"
+                    }
+                },
+                "rollback_seconds": {
+                    "description": "RollbackSeconds is how long the change waits for\nPOST /network/config/confirm before it is reverted (30-600, default 120).",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "dto.NetworkVLANSettings": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "IoT"
+                },
+                "gateway": {
+                    "type": "string",
+                    "example": "192.168.20.1"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "192.168.20.10"
+                },
+                "netmask": {
+                    "type": "string",
+                    "example": "255.255.255.0"
+                },
+                "use_dhcp": {
+                    "type": "boolean"
+                }
+            }
+        },
         "dto.Notification": {
             "type": "object",
             "properties": {
@@ -9257,6 +18933,51 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.NotificationTarget": {
+            "description": "Telegram bot, Discord webhook, or Pushover destination for forwarded notifications",
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "phone"
+                },
+                "levels": {
+                    "description": "Levels limits forwarding to these importance levels (alert, warning,\nnormal). Empty forwards every level.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "alert",
+                        "warning"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "My phone"
+                },
+                "sources": {
+                    "description": "Sources limits forwarding to Unraid notifications (\"notifications\") or\nalert rule events (\"alerts\"). Empty forwards both.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "notifications",
+                        "alerts"
+                    ]
+                },
+                "url": {
+                    "description": "URL is a shoutrrr service URL (telegram://, discord:// or pushover://).\nIt embeds credentials and is redacted in API responses.",
+                    "type": "string",
+                    "example": "telegram://[REDACTED]"
+                }
+            }
+        },
         "dto.OSUpdateStatus": {
             "type": "object",
             "properties": {
@@ -9286,6 +19007,15 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.OrchestratedShutdownRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "Confirm must be set to true to authorise powering off the server.",
+                    "type": "boolean"
+                }
+            }
+        },
         "dto.ParityCheckHistory": {
             "type": "object",
             "properties": {
@@ -9300,6 +19030,16 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ParityCheckNoteRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "Empty clears the note",
+                    "type": "string",
+                    "example": "after disk swap"
+                }
+            }
+        },
         "dto.ParityCheckRecord": {
             "type": "object",
             "properties": {
@@ -9307,6 +19047,10 @@ const docTemplate = `{
                     "description": "\"Parity-Check\", \"Parity-Sync\", \"Read-Check\", \"Clear\"",
                     "type": "string"
                 },
+                "correcting": {
+                    "description": "Correcting mode, when started through the agent",
+                    "type": "boolean"
+                },
                 "date": {
                     "description": "Date and time of the operation",
                     "type": "string"
@@ -9319,6 +19063,14 @@ const docTemplate = `{
                     "description": "Number of errors found",
                     "type": "integer"
                 },
+                "id": {
+                    "description": "Stable ID: Unix time of Date",
+                    "type": "string"
+                },
+                "note": {
+                    "description": "Agent annotations, stored alongside parity-checks.log rather than in it.",
+                    "type": "string"
+                },
                 "size_bytes": {
                     "description": "Size of array checked in bytes",
                     "type": "integer"
@@ -9330,6 +19082,10 @@ const docTemplate = `{
                 "status": {
                     "description": "\"OK\", \"Canceled\", or error count",
                     "type": "string"
+                },
+                "trigger": {
+                    "description": "\"api\", \"mcp\" or \"mqtt\" when started through the agent",
+                    "type": "string"
                 }
             }
         },
@@ -9392,6 +19148,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "next_check": {
+                    "description": "NextCheck is when CheckCron next fires in the server's local time;\nomitted when no check is scheduled.",
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
+                },
                 "pause_hour": {
                     "description": "Pause/resume schedule",
                     "type": "integer",
@@ -9407,6 +19168,49 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PingResult": {
+            "type": "object",
+            "properties": {
+                "avg_ms": {
+                    "type": "number",
+                    "example": 12.4
+                },
+                "error": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string",
+                    "example": "1.1.1.1"
+                },
+                "max_ms": {
+                    "type": "number",
+                    "example": 14.1
+                },
+                "min_ms": {
+                    "type": "number",
+                    "example": 11.2
+                },
+                "packet_loss_percent": {
+                    "type": "number",
+                    "example": 0
+                },
+                "reachable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "received": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "transmitted": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
         "dto.PlanStep": {
             "type": "object",
             "properties": {
@@ -9503,44 +19307,193 @@ const docTemplate = `{
                         "$ref": "#/definitions/dto.PluginInfo"
                     }
                 },
-                "timestamp": {
-                    "type": "string"
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "updates_available": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.PluginUpdateResult": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Updated successfully"
+                },
+                "new_version": {
+                    "type": "string",
+                    "example": "2025.10.28"
+                },
+                "plugin_name": {
+                    "type": "string",
+                    "example": "community.applications"
+                },
+                "previous_version": {
+                    "type": "string",
+                    "example": "2025.10.27"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PoolDevice": {
+            "type": "object",
+            "properties": {
+                "device": {
+                    "type": "string",
+                    "example": "nvme1n1"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "Samsung_SSD_980_PRO_1TB_S5GXNF0R123456"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 1000204886016
+                },
+                "slot": {
+                    "type": "string",
+                    "example": "cache2"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "DISK_OK"
+                }
+            }
+        },
+        "dto.PoolInfo": {
+            "type": "object",
+            "properties": {
+                "balance_progress": {
+                    "type": "string",
+                    "example": "2 out of about 10 chunks balanced (3 considered),  80% left"
+                },
+                "balance_running": {
+                    "description": "Balance state (btrfs only).",
+                    "type": "boolean",
+                    "example": false
+                },
+                "balance_status": {
+                    "description": "\"idle\", \"running\", \"paused\"",
+                    "type": "string",
+                    "example": "idle"
+                },
+                "data_allocated_bytes": {
+                    "type": "integer",
+                    "example": 429496729600
+                },
+                "data_used_bytes": {
+                    "type": "integer",
+                    "example": 408021893120
+                },
+                "days_until_full": {
+                    "type": "number",
+                    "example": 109.5
+                },
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PoolDevice"
+                    }
+                },
+                "filesystem": {
+                    "description": "\"btrfs\", \"zfs\", \"xfs\"",
+                    "type": "string",
+                    "example": "btrfs"
+                },
+                "fill_rate_bytes_per_day": {
+                    "description": "Fill forecast from the trend of used space over the last 7 days; nil\nuntil 6 hours of history exist. DaysUntilFull is nil while usage is\nflat or shrinking.",
+                    "type": "number",
+                    "example": 5368709120
+                },
+                "free_bytes": {
+                    "type": "integer",
+                    "example": 587888025600
+                },
+                "health": {
+                    "description": "ZFS pool health",
+                    "type": "string",
+                    "example": "ONLINE"
+                },
+                "last_trim": {
+                    "description": "LastTrim is when the pool was last trimmed through the agent.",
+                    "type": "string"
+                },
+                "metadata_allocated_bytes": {
+                    "type": "integer",
+                    "example": 4294967296
+                },
+                "metadata_profile": {
+                    "description": "Btrfs allocation: chunks allocated per block group type vs bytes used\ninside them. A large gap between data allocated and data used is what a\nbalance reclaims.",
+                    "type": "string",
+                    "example": "raid1"
+                },
+                "metadata_used_bytes": {
+                    "type": "integer",
+                    "example": 2147483648
+                },
+                "mount_point": {
+                    "type": "string",
+                    "example": "/mnt/cache"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "cache"
                 },
-                "total_count": {
-                    "type": "integer",
-                    "example": 18
+                "profile": {
+                    "description": "btrfs data profile or top-level ZFS vdev type",
+                    "type": "string",
+                    "example": "raid1"
                 },
-                "updates_available": {
-                    "type": "integer",
-                    "example": 2
-                }
-            }
-        },
-        "dto.PluginUpdateResult": {
-            "type": "object",
-            "properties": {
-                "message": {
+                "scrub_errors": {
                     "type": "string",
-                    "example": "Updated successfully"
+                    "example": "no errors found"
                 },
-                "new_version": {
+                "scrub_started": {
                     "type": "string",
-                    "example": "2025.10.28"
+                    "example": "Sun Oct 12 03:00:01 2026"
                 },
-                "plugin_name": {
+                "scrub_status": {
+                    "description": "Scrub state of the most recent scrub (btrfs or ZFS).",
                     "type": "string",
-                    "example": "community.applications"
+                    "example": "finished"
                 },
-                "previous_version": {
+                "status": {
+                    "description": "Filesystem status from disks.ini",
                     "type": "string",
-                    "example": "2025.10.27"
+                    "example": "Mounted"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "system_allocated_bytes": {
+                    "type": "integer",
+                    "example": 33554432
                 },
                 "timestamp": {
                     "type": "string"
+                },
+                "total_bytes": {
+                    "description": "Capacity as reported by Unraid for the mounted filesystem.",
+                    "type": "integer",
+                    "example": 1000204886016
+                },
+                "usage_percent": {
+                    "type": "number",
+                    "example": 41.2
+                },
+                "used_bytes": {
+                    "type": "integer",
+                    "example": 412316860416
                 }
             }
         },
@@ -9578,6 +19531,75 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PowerEstimate": {
+            "type": "object",
+            "properties": {
+                "component_watts": {
+                    "description": "CPU + DRAM + GPU + disks",
+                    "type": "number",
+                    "example": 89
+                },
+                "cpu_watts": {
+                    "description": "Component readings; pointers are omitted when the source is unavailable.",
+                    "type": "number",
+                    "example": 35.2
+                },
+                "disk_watts": {
+                    "description": "Disks are estimated from their spin state, as drives do not report power.",
+                    "type": "number",
+                    "example": 31
+                },
+                "disks_active": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "disks_standby": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "dram_watts": {
+                    "description": "DRAM power (RAPL)",
+                    "type": "number",
+                    "example": 4.1
+                },
+                "energy_kwh": {
+                    "description": "Energy used since EnergySince (monotonic)",
+                    "type": "number",
+                    "example": 12.873
+                },
+                "energy_since": {
+                    "type": "string"
+                },
+                "gpu_watts": {
+                    "description": "Sum of GPU power draw",
+                    "type": "number",
+                    "example": 18.7
+                },
+                "kwh_per_day": {
+                    "description": "TotalWatts projected over 24 hours",
+                    "type": "number",
+                    "example": 3.42
+                },
+                "source": {
+                    "description": "\"ups\" or \"components\"",
+                    "type": "string",
+                    "example": "ups"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_watts": {
+                    "description": "TotalWatts is the best available figure for the whole server: the UPS\nload when a UPS reports it, otherwise ComponentWatts.",
+                    "type": "number",
+                    "example": 142.5
+                },
+                "ups_watts": {
+                    "description": "UPS load in watts",
+                    "type": "number",
+                    "example": 142.5
+                }
+            }
+        },
         "dto.PreferenceStatus": {
             "type": "string",
             "enum": [
@@ -9592,10 +19614,24 @@ const docTemplate = `{
         "dto.ProcessInfo": {
             "type": "object",
             "properties": {
+                "cgroup": {
+                    "description": "Cgroup is the process's cgroup v2 path from /proc/\u003cpid\u003e/cgroup,\npopulated by the /system/processes endpoint.",
+                    "type": "string",
+                    "example": "/docker/3f4e2a1b9c8d"
+                },
                 "command": {
                     "type": "string",
                     "example": "/usr/bin/docker"
                 },
+                "container_id": {
+                    "description": "ContainerID and ContainerName attribute the process to the Docker\ncontainer whose cgroup it runs in. Omitted for host processes.",
+                    "type": "string",
+                    "example": "3f4e2a1b9c8d"
+                },
+                "container_name": {
+                    "type": "string",
+                    "example": "plex"
+                },
                 "cpu_percent": {
                     "type": "number",
                     "example": 5.2
@@ -9614,6 +19650,11 @@ const docTemplate = `{
                     "type": "number",
                     "example": 2.1
                 },
+                "name": {
+                    "description": "Name is the process name from /proc/\u003cpid\u003e/comm, populated by the\n/system/processes endpoint.",
+                    "type": "string",
+                    "example": "Plex Media Serv"
+                },
                 "pid": {
                     "type": "integer",
                     "example": 1234
@@ -9642,60 +19683,310 @@ const docTemplate = `{
                     "type": "string",
                     "example": "root"
                 },
-                "vsz_bytes": {
+                "vsz_bytes": {
+                    "type": "integer",
+                    "example": 1073741824
+                }
+            }
+        },
+        "dto.ProcessList": {
+            "type": "object",
+            "properties": {
+                "processes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProcessInfo"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "dto.Readiness": {
+            "type": "object",
+            "properties": {
+                "pending": {
+                    "description": "Pending lists the collectors that have not published a first result.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ready": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "ready_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "timed_out": {
+                    "description": "TimedOut lists the collectors that had not published when the startup\nphase timed out; the agent became ready without their data.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RecycleBinEmptyResult": {
+            "type": "object",
+            "properties": {
+                "bytes_freed": {
+                    "type": "integer",
+                    "example": 21474836480
+                },
+                "files_removed": {
+                    "type": "integer",
+                    "example": 312
+                },
+                "share": {
+                    "type": "string",
+                    "example": "Media"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RecycleBinShare": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "type": "integer",
+                    "example": 312
+                },
+                "newest_at": {
+                    "description": "Modification time of the newest file",
+                    "type": "string"
+                },
+                "oldest_age_days": {
+                    "description": "Age of the oldest file in days",
+                    "type": "number",
+                    "example": 13.5
+                },
+                "oldest_at": {
+                    "description": "Modification time of the oldest file (deletion time when the plugin touches files)",
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/mnt/user/Media/.Recycle.Bin"
+                },
+                "share": {
+                    "type": "string",
+                    "example": "Media"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 21474836480
+                }
+            }
+        },
+        "dto.RecycleBinStatus": {
+            "type": "object",
+            "properties": {
+                "installed": {
+                    "description": "Recycle Bin plugin installed",
+                    "type": "boolean",
+                    "example": true
+                },
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RecycleBinShare"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_bytes": {
+                    "type": "integer",
+                    "example": 21474836480
+                },
+                "total_files": {
+                    "type": "integer",
+                    "example": 312
+                }
+            }
+        },
+        "dto.Registration": {
+            "type": "object",
+            "properties": {
+                "expiration": {
+                    "description": "License expiration date",
+                    "type": "string"
+                },
+                "guid": {
+                    "description": "Registration GUID",
+                    "type": "string"
+                },
+                "server_name": {
+                    "description": "Server name from config",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "\"valid\", \"expired\", \"invalid\", \"trial\"",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Collection timestamp",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"trial\", \"basic\", \"plus\", \"pro\", \"lifetime\"",
+                    "type": "string"
+                },
+                "update_expiration": {
+                    "description": "Update expiration date",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegistrationKeyRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "Confirm must be true; any other key file on the flash drive is\nreplaced.",
+                    "type": "boolean"
+                },
+                "file_name": {
+                    "description": "FileName names an uploaded key file (e.g. \"Pro.key\"); for URL installs\nit defaults to the last path element of the URL.",
+                    "type": "string",
+                    "example": "Pro.key"
+                },
+                "key_data": {
+                    "description": "KeyData is the base64-encoded content of an uploaded key file.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL is an https link to a key file on a Lime Technology key server, as\nsent in the purchase or upgrade email.",
+                    "type": "string",
+                    "example": "https://keys.lime-technology.com/unraid/1234abcd/Pro.key"
+                }
+            }
+        },
+        "dto.RegistrationKeyResult": {
+            "type": "object",
+            "properties": {
+                "array_started": {
+                    "description": "ArrayStarted is true when the array was running during the install;\nUnraid then applies the new key the next time the array is started.",
+                    "type": "boolean"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "Pro.key"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/boot/config/Pro.key"
+                },
+                "replaced": {
+                    "description": "Replaced lists the previous key files, kept with a .bak suffix.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "size": {
                     "type": "integer",
-                    "example": 1073741824
+                    "example": 256
+                },
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "dto.ProcessList": {
+        "dto.RemoteMountHealth": {
+            "description": "Health of all NFS/SMB client mounts.",
             "type": "object",
             "properties": {
-                "processes": {
+                "mounts": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ProcessInfo"
+                        "$ref": "#/definitions/dto.RemoteMountStatus"
                     }
                 },
+                "stale_count": {
+                    "description": "StaleCount is the number of mounted remote mounts that are stale.",
+                    "type": "integer",
+                    "example": 0
+                },
                 "timestamp": {
                     "type": "string"
-                },
-                "total_count": {
-                    "type": "integer",
-                    "example": 150
                 }
             }
         },
-        "dto.Registration": {
+        "dto.RemoteMountStatus": {
+            "description": "Reachability and staleness of a remote NFS/SMB mount.",
             "type": "object",
             "properties": {
-                "expiration": {
-                    "description": "License expiration date",
+                "error": {
+                    "description": "Error describes why the mount is stale or the server unreachable.",
                     "type": "string"
                 },
-                "guid": {
-                    "description": "Registration GUID",
+                "last_healthy_at": {
+                    "description": "LastHealthyAt is when the mount last answered statfs.",
                     "type": "string"
                 },
-                "server_name": {
-                    "description": "Server name from config",
-                    "type": "string"
+                "mount_point": {
+                    "type": "string",
+                    "example": "/mnt/remotes/nas_media"
                 },
-                "state": {
-                    "description": "\"valid\", \"expired\", \"invalid\", \"trial\"",
-                    "type": "string"
+                "mounted": {
+                    "type": "boolean"
                 },
-                "timestamp": {
-                    "description": "Collection timestamp",
-                    "type": "string"
+                "origin": {
+                    "description": "Origin is \"unassigned\" for Unassigned Devices remote shares, \"fstab\"\nfor /etc/fstab entries and \"manual\" for other mounts.",
+                    "type": "string",
+                    "example": "unassigned"
                 },
-                "type": {
-                    "description": "\"trial\", \"basic\", \"plus\", \"pro\", \"lifetime\"",
-                    "type": "string"
+                "server": {
+                    "type": "string",
+                    "example": "nas"
                 },
-                "update_expiration": {
-                    "description": "Update expiration date",
+                "server_latency_ms": {
+                    "type": "number",
+                    "example": 0.6
+                },
+                "server_reachable": {
+                    "description": "ServerReachable is true when a TCP connection to the server's NFS\n(2049) or SMB (445) port succeeded.",
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "//nas/media"
+                },
+                "stale": {
+                    "description": "Stale is true when the mount is mounted but statfs on it hangs or\nfails, e.g. a hung NFS server or a disconnected SMB session.",
+                    "type": "boolean"
+                },
+                "stale_since": {
+                    "description": "StaleSince is when the mount was first seen stale in the current run\nof failures.",
                     "type": "string"
+                },
+                "statfs_latency_ms": {
+                    "description": "StatfsLatencyMs is how long statfs on the mount point took.",
+                    "type": "number",
+                    "example": 1.4
+                },
+                "type": {
+                    "description": "Type is \"nfs\" or \"smb\".",
+                    "type": "string",
+                    "example": "smb"
                 }
             }
         },
@@ -9714,6 +20005,11 @@ const docTemplate = `{
         "dto.Response": {
             "type": "object",
             "properties": {
+                "correlation_id": {
+                    "description": "CorrelationID is the request's X-Request-ID, set on API error responses\nso a failure can be matched to the agent's log entries.",
+                    "type": "string",
+                    "example": "3f0c2a9e-8d1b-4c55-9a8e-2b7f6d1e4a10"
+                },
                 "data": {},
                 "error": {
                     "type": "string",
@@ -9788,6 +20084,79 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.Script": {
+            "description": "Script stored by the agent with its default arguments and environment",
+            "type": "object",
+            "properties": {
+                "args": {
+                    "description": "Args are the default arguments, passed to the script without a shell.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "--filter",
+                        "until=24h"
+                    ]
+                },
+                "content": {
+                    "description": "Content is the bash script body.",
+                    "type": "string",
+                    "example": "docker image prune -f \"$@\""
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Remove dangling Docker images"
+                },
+                "env": {
+                    "description": "Env holds extra environment variables for the script.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "prune-images"
+                },
+                "timeout_seconds": {
+                    "description": "Limit for runs that wait for completion (default 60, max 3600)",
+                    "type": "integer",
+                    "example": 300
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ScriptExecuteRequest": {
+            "type": "object",
+            "properties": {
+                "args": {
+                    "description": "Args replace the script's default arguments when set.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "--filter",
+                        "until=48h"
+                    ]
+                },
+                "env": {
+                    "description": "Env is merged over the script's environment.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "wait": {
+                    "description": "Wait runs the script to completion and returns its output; otherwise\nit runs in the background.",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "dto.ServiceStatus": {
             "description": "Docker and VM Manager service enabled status",
             "type": "object",
@@ -9817,6 +20186,21 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ShareActivity": {
+            "type": "object",
+            "properties": {
+                "nfs": {
+                    "$ref": "#/definitions/dto.ShareProtocolIO"
+                },
+                "since": {
+                    "description": "When the agent started counting",
+                    "type": "string"
+                },
+                "smb": {
+                    "$ref": "#/definitions/dto.ShareProtocolIO"
+                }
+            }
+        },
         "dto.ShareConfig": {
             "type": "object",
             "properties": {
@@ -9824,6 +20208,10 @@ const docTemplate = `{
                     "description": "\"highwater\", \"mostfree\", \"fillup\"",
                     "type": "string"
                 },
+                "cache_pool": {
+                    "description": "Pool used by use_cache, e.g. \"cache\"",
+                    "type": "string"
+                },
                 "comment": {
                     "type": "string"
                 },
@@ -9849,6 +20237,14 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "mover_tuning": {
+                    "description": "MoverTuning is the share's override in the Mover Tuning plugin; nil\nwhen the plugin is not installed or the share has no override.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ShareMoverTuning"
+                        }
+                    ]
+                },
                 "name": {
                     "type": "string"
                 },
@@ -9869,9 +20265,195 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ShareDependents": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "plex",
+                        "sonarr"
+                    ]
+                },
+                "share": {
+                    "type": "string",
+                    "example": "Media"
+                }
+            }
+        },
+        "dto.ShareDiskUsage": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "description": "Allocated bytes of the share's files on this disk",
+                    "type": "integer",
+                    "example": 2199023255552
+                },
+                "cached": {
+                    "description": "Served from the last scan because the disk's usage had not changed",
+                    "type": "boolean"
+                },
+                "disk": {
+                    "type": "string",
+                    "example": "disk1"
+                },
+                "files": {
+                    "type": "integer",
+                    "example": 18432
+                },
+                "mount_point": {
+                    "type": "string",
+                    "example": "/mnt/disk1"
+                },
+                "percent": {
+                    "description": "Share of TotalBytes stored on this disk",
+                    "type": "number",
+                    "example": 41.5
+                },
+                "role": {
+                    "description": "\"data\", \"cache\", \"pool\"",
+                    "type": "string",
+                    "example": "data"
+                },
+                "scanned_at": {
+                    "type": "string"
+                },
+                "standby": {
+                    "description": "Disk was spun down and not scanned; Bytes is from an earlier scan, if any",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ShareDistribution": {
+            "type": "object",
+            "properties": {
+                "disks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ShareDiskUsage"
+                    }
+                },
+                "incomplete": {
+                    "description": "Some disks were in standby and have no scan yet",
+                    "type": "boolean"
+                },
+                "share": {
+                    "type": "string",
+                    "example": "Media"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_bytes": {
+                    "type": "integer",
+                    "example": 5299989643264
+                },
+                "total_files": {
+                    "type": "integer",
+                    "example": 40210
+                }
+            }
+        },
+        "dto.ShareExport": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "media"
+                },
+                "nfs_export": {
+                    "description": "NFSExport is \"no\" or \"yes\".",
+                    "type": "string",
+                    "example": "no"
+                },
+                "nfs_rules": {
+                    "description": "NFSRules is the exports(5) host rule list used by private NFS security.",
+                    "type": "string",
+                    "example": "192.168.1.0/24(rw,sec=sys)"
+                },
+                "nfs_security": {
+                    "type": "string",
+                    "example": "public"
+                },
+                "smb_export": {
+                    "description": "SMBExport is \"no\", \"yes\", \"hidden\", \"time_machine\" or \"time_machine_hidden\".",
+                    "type": "string",
+                    "example": "yes"
+                },
+                "smb_read_users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "smb_security": {
+                    "description": "SMBSecurity is \"public\" (guest read/write), \"secure\" (guest read, listed\nusers write) or \"private\" (listed users only).",
+                    "type": "string",
+                    "example": "secure"
+                },
+                "smb_write_users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ShareExportUpdate": {
+            "type": "object",
+            "properties": {
+                "nfs_export": {
+                    "type": "string",
+                    "example": "yes"
+                },
+                "nfs_rules": {
+                    "type": "string",
+                    "example": "192.168.1.0/24(rw,sec=sys)"
+                },
+                "nfs_security": {
+                    "type": "string",
+                    "example": "private"
+                },
+                "smb_export": {
+                    "type": "string",
+                    "example": "yes"
+                },
+                "smb_read_users": {
+                    "description": "SMBReadUsers and SMBWriteUsers replace the per-user access lists; an\nomitted list keeps its current users. Users in neither list get no\naccess to a private share and read-only access to a secure share.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "smb_security": {
+                    "type": "string",
+                    "example": "private"
+                },
+                "smb_write_users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "dto.ShareInfo": {
             "type": "object",
             "properties": {
+                "activity": {
+                    "description": "Activity is the SMB and NFS I/O of the share; nil when neither source\ncould be read.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ShareActivity"
+                        }
+                    ]
+                },
                 "cache_pool": {
                     "description": "Cache pool settings (Issue #53)",
                     "type": "string",
@@ -9954,6 +20536,61 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ShareMoverTuning": {
+            "type": "object",
+            "properties": {
+                "age_days": {
+                    "description": "AgeDays only moves files last modified at least this many days ago.",
+                    "type": "integer",
+                    "example": 30
+                },
+                "min_size_mb": {
+                    "description": "MinSizeMB only moves files of at least this size.",
+                    "type": "integer",
+                    "example": 100
+                },
+                "override": {
+                    "description": "Override enables the per-share settings below.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "threshold_percent": {
+                    "description": "ThresholdPercent is the pool usage the mover waits for before moving\nthe share's files.",
+                    "type": "integer",
+                    "example": 70
+                }
+            }
+        },
+        "dto.ShareProtocolIO": {
+            "type": "object",
+            "properties": {
+                "read_bytes": {
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "read_bytes_per_sec": {
+                    "type": "number",
+                    "example": 1048576
+                },
+                "read_ops": {
+                    "description": "SMB only: read syscalls of the serving smbd processes",
+                    "type": "integer",
+                    "example": 8192
+                },
+                "write_bytes": {
+                    "type": "integer",
+                    "example": 536870912
+                },
+                "write_bytes_per_sec": {
+                    "type": "number",
+                    "example": 0
+                },
+                "write_ops": {
+                    "type": "integer",
+                    "example": 4096
+                }
+            }
+        },
         "dto.SourceState": {
             "type": "string",
             "enum": [
@@ -9972,26 +20609,328 @@ const docTemplate = `{
         "dto.SourceStatus": {
             "type": "object",
             "properties": {
-                "last_checked": {
-                    "type": "string",
-                    "format": "date-time"
+                "last_checked": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_healthy": {
+                    "description": "LastHealthy is the RFC3339 timestamp of when the subsystem last reported\nhealthy. It is preserved across degradations so a persistent degraded\nstate can be dated. Omitted from JSON when the subsystem has never been\nhealthy.",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/dto.SourceState"
+                },
+                "subsystem": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SpeedtestResult": {
+            "description": "Download/upload throughput and latency measured by one bandwidth test.",
+            "type": "object",
+            "properties": {
+                "download_mbps": {
+                    "description": "DownloadMbps is the measured download throughput in megabits per second.",
+                    "type": "number",
+                    "example": 938.4
+                },
+                "duration_seconds": {
+                    "description": "DurationSeconds is how long the test took.",
+                    "type": "number",
+                    "example": 24.6
+                },
+                "error": {
+                    "description": "Error describes why the test failed, if it did.",
+                    "type": "string"
+                },
+                "ping_ms": {
+                    "description": "PingMs is the latency to the test server in milliseconds.",
+                    "type": "number",
+                    "example": 8.2
+                },
+                "server": {
+                    "description": "Server describes the test server (sponsor and location for speedtest-cli, host for iperf3).",
+                    "type": "string",
+                    "example": "Example ISP (Amsterdam)"
+                },
+                "success": {
+                    "description": "Success is false when the test could not complete; see Error.",
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "description": "Timestamp is when the test started.",
+                    "type": "string"
+                },
+                "tool": {
+                    "description": "Tool is the program that ran the test: \"speedtest-cli\" or \"iperf3\".",
+                    "type": "string",
+                    "example": "speedtest-cli"
+                },
+                "upload_mbps": {
+                    "description": "UploadMbps is the measured upload throughput in megabits per second.",
+                    "type": "number",
+                    "example": 47.9
+                }
+            }
+        },
+        "dto.SpeedtestStatus": {
+            "description": "Latest bandwidth test result and the retained result history.",
+            "type": "object",
+            "properties": {
+                "history": {
+                    "description": "History holds previous runs, oldest first, including Latest.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SpeedtestResult"
+                    }
+                },
+                "latest": {
+                    "description": "Latest is the most recent test run (successful or not).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.SpeedtestResult"
+                        }
+                    ]
+                },
+                "timestamp": {
+                    "description": "Timestamp is when this status was published.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StorageDependents": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "plex",
+                        "sonarr"
+                    ]
+                },
+                "storage": {
+                    "type": "string",
+                    "example": "cache"
+                }
+            }
+        },
+        "dto.Summary": {
+            "type": "object",
+            "properties": {
+                "array_state": {
+                    "type": "string",
+                    "example": "Started"
+                },
+                "array_used_percent": {
+                    "type": "number",
+                    "example": 45.5
+                },
+                "containers_running": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "containers_total": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "cpu_percent": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "cpu_temp_c": {
+                    "type": "number",
+                    "example": 45
+                },
+                "disks": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "failing_disks": {
+                    "description": "Disks whose SMART status is not PASSED",
+                    "type": "integer",
+                    "example": 0
+                },
+                "firing_alerts": {
+                    "description": "Alert rules currently firing",
+                    "type": "integer",
+                    "example": 0
+                },
+                "hostname": {
+                    "type": "string",
+                    "example": "tower"
+                },
+                "hot_disks": {
+                    "description": "Disks above the warning temperature (55 °C)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "parity_eta_seconds": {
+                    "description": "While a parity operation runs",
+                    "type": "integer",
+                    "example": 62700
+                },
+                "parity_progress": {
+                    "description": "Percent, while a parity operation runs",
+                    "type": "number",
+                    "example": 27.5
+                },
+                "parity_status": {
+                    "description": "Parity check status: \"idle\", \"running\", \"paused\"",
+                    "type": "string",
+                    "example": "idle"
+                },
+                "parity_valid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "ram_percent": {
+                    "type": "number",
+                    "example": 65.5
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "unread_alerts": {
+                    "description": "Unread notifications with \"alert\" importance",
+                    "type": "integer",
+                    "example": 1
+                },
+                "unread_notifications": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "uptime_seconds": {
+                    "type": "integer",
+                    "example": 86400
+                },
+                "vms_running": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "vms_total": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.SwapDevice": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "type": "string",
+                    "example": "/mnt/cache/swapfile"
+                },
+                "priority": {
+                    "description": "Priority decides which swap area is used first; higher wins.",
+                    "type": "integer",
+                    "example": -2
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 8589934592
+                },
+                "type": {
+                    "description": "Type is \"file\", \"partition\" or \"zram\".",
+                    "type": "string",
+                    "example": "file"
+                },
+                "usage_percent": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "used_bytes": {
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "zram": {
+                    "$ref": "#/definitions/dto.ZramStats"
+                }
+            }
+        },
+        "dto.SwapFileConfig": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/mnt/cache/swapfile"
+                },
+                "pool": {
+                    "type": "string",
+                    "example": "cache"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 8589934592
+                }
+            }
+        },
+        "dto.SwapFileRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "Confirm must be true; the file is allocated on the pool immediately.",
+                    "type": "boolean"
+                },
+                "pool": {
+                    "type": "string",
+                    "example": "cache"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 8589934592
+                }
+            }
+        },
+        "dto.SwapStatus": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SwapDevice"
+                    }
                 },
-                "last_error": {
-                    "type": "string"
+                "swap_file": {
+                    "description": "SwapFile is the swap file enabled through the API; nil when none is\nconfigured.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.SwapFileConfig"
+                        }
+                    ]
                 },
-                "last_healthy": {
-                    "description": "LastHealthy is the RFC3339 timestamp of when the subsystem last reported\nhealthy. It is preserved across degradations so a persistent degraded\nstate can be dated. Omitted from JSON when the subsystem has never been\nhealthy.",
-                    "type": "string",
-                    "format": "date-time"
+                "swappiness": {
+                    "description": "Swappiness is the kernel vm.swappiness tunable (0-200); -1 means unavailable.",
+                    "type": "integer",
+                    "example": 60
                 },
-                "reason": {
+                "timestamp": {
                     "type": "string"
                 },
-                "state": {
-                    "$ref": "#/definitions/dto.SourceState"
+                "total_bytes": {
+                    "type": "integer",
+                    "example": 8589934592
                 },
-                "subsystem": {
-                    "type": "string"
+                "usage_percent": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "used_bytes": {
+                    "type": "integer",
+                    "example": 1073741824
                 }
             }
         },
@@ -10010,6 +20949,20 @@ const docTemplate = `{
                     "type": "string",
                     "example": "1.4"
                 },
+                "cpu_core_frequencies": {
+                    "description": "Current frequency of each logical CPU (cpufreq)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CPUCoreFrequency"
+                    }
+                },
+                "cpu_core_temps": {
+                    "description": "Per-core CPU breakdown for spotting thermal imbalance and throttling",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CPUCoreTemp"
+                    }
+                },
                 "cpu_cores": {
                     "type": "integer",
                     "example": 8
@@ -10022,6 +20975,17 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Intel(R) Core(TM) i7-9700K CPU @ 3.60GHz"
                 },
+                "cpu_package_power_watts": {
+                    "description": "Package power per socket in watts (Intel RAPL)",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        35.2,
+                        30.1
+                    ]
+                },
                 "cpu_per_core_usage": {
                     "type": "object",
                     "additionalProperties": {
@@ -10084,6 +21048,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "6.1.64-Unraid"
                 },
+                "memory_breakdown": {
+                    "description": "MemoryBreakdown shows where the used RAM went; nil when /proc/meminfo\ncannot be read.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.MemoryBreakdown"
+                        }
+                    ]
+                },
                 "motherboard_temp_celsius": {
                     "type": "number",
                     "example": 35
@@ -10216,6 +21188,57 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TLSStatus": {
+            "type": "object",
+            "properties": {
+                "dns_provider": {
+                    "description": "DNSProvider publishes the DNS-01 challenge records (ACME only).",
+                    "type": "string",
+                    "example": "cloudflare"
+                },
+                "domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "R11"
+                },
+                "last_attempt": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "mode": {
+                    "description": "Mode is \"off\" (plain HTTP), \"files\" (TLS_CERT_FILE/TLS_KEY_FILE) or\n\"acme\" (issued and renewed automatically).",
+                    "type": "string",
+                    "example": "acme"
+                },
+                "next_renewal": {
+                    "description": "NextRenewal is when the certificate becomes due for renewal.",
+                    "type": "string"
+                },
+                "not_after": {
+                    "type": "string"
+                },
+                "not_before": {
+                    "type": "string"
+                },
+                "renewing": {
+                    "type": "boolean"
+                },
+                "self_signed": {
+                    "description": "SelfSigned is true while a temporary certificate is served until the\nfirst one is issued.",
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.TPMInfo": {
             "type": "object",
             "properties": {
@@ -10241,24 +21264,343 @@ const docTemplate = `{
             "properties": {
                 "name": {
                     "type": "string",
-                    "example": "coretemp_Core_0"
+                    "example": "coretemp_Core_0"
+                },
+                "sensor_type": {
+                    "description": "cpu, motherboard, chipset, other",
+                    "type": "string",
+                    "example": "cpu"
+                },
+                "source": {
+                    "description": "hwmon chip name",
+                    "type": "string",
+                    "example": "coretemp"
+                },
+                "value_celsius": {
+                    "type": "number",
+                    "example": 45
+                }
+            }
+        },
+        "dto.ThermalEvent": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "string",
+                    "example": "disk3"
+                },
+                "level": {
+                    "description": "\"warning\", \"critical\", \"resolved\"",
+                    "type": "string",
+                    "example": "warning"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Disk 3"
+                },
+                "sensor": {
+                    "type": "string",
+                    "example": "disk"
+                },
+                "since": {
+                    "description": "When the temperature first crossed the threshold",
+                    "type": "string"
+                },
+                "temperature_celsius": {
+                    "type": "number",
+                    "example": 52
+                },
+                "threshold_celsius": {
+                    "type": "number",
+                    "example": 45
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ThermalSensorStats": {
+            "type": "object",
+            "properties": {
+                "avg_celsius": {
+                    "description": "Average reading in the window",
+                    "type": "number",
+                    "example": 39.4
+                },
+                "critical_celsius": {
+                    "description": "Critical threshold",
+                    "type": "number",
+                    "example": 55
+                },
+                "current_celsius": {
+                    "description": "Latest reading",
+                    "type": "number",
+                    "example": 41
+                },
+                "entity": {
+                    "description": "Disk ID or GPU index",
+                    "type": "string",
+                    "example": "disk1"
+                },
+                "level": {
+                    "description": "Sustained level: \"ok\", \"warning\", \"critical\"",
+                    "type": "string",
+                    "example": "ok"
+                },
+                "max_celsius": {
+                    "description": "Highest reading in the window",
+                    "type": "number",
+                    "example": 46
+                },
+                "name": {
+                    "description": "Display name",
+                    "type": "string",
+                    "example": "Disk 1"
+                },
+                "samples": {
+                    "description": "Readings in the window",
+                    "type": "integer",
+                    "example": 480
+                },
+                "sensor": {
+                    "description": "\"cpu\", \"motherboard\", \"disk\", \"gpu\"",
+                    "type": "string",
+                    "example": "disk"
+                },
+                "warning_celsius": {
+                    "description": "Warning threshold",
+                    "type": "number",
+                    "example": 45
+                }
+            }
+        },
+        "dto.ThermalSummary": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Recent events, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ThermalEvent"
+                    }
+                },
+                "sensors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ThermalSensorStats"
+                    }
+                },
+                "sustain_seconds": {
+                    "description": "How long a threshold must be exceeded before an event",
+                    "type": "integer",
+                    "example": 300
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "window_seconds": {
+                    "type": "integer",
+                    "example": 86400
+                }
+            }
+        },
+        "dto.TmpfsUsage": {
+            "type": "object",
+            "properties": {
+                "mount": {
+                    "type": "string",
+                    "example": "/var/log"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 134217728
+                },
+                "usage_percent": {
+                    "type": "number",
+                    "example": 9.4
+                },
+                "used_bytes": {
+                    "type": "integer",
+                    "example": 12582912
+                }
+            }
+        },
+        "dto.TransferJob": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "/mnt/user/backups/appdata/"
+                },
+                "enabled": {
+                    "description": "Enabled determines whether the schedule is active. Disabled jobs can\nstill be run manually.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "flags": {
+                    "description": "Flags are extra options, e.g. [\"-a\", \"--delete\"]. Options that make the\ntool run other programs (such as rsync -e) are rejected.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "-a",
+                        "--delete"
+                    ]
+                },
+                "id": {
+                    "description": "ID is the unique identifier (lowercase letters, digits, \"-\" and \"_\").",
+                    "type": "string",
+                    "example": "appdata-backup"
+                },
+                "name": {
+                    "description": "Name is a human-readable name for this job.",
+                    "type": "string",
+                    "example": "Appdata backup"
+                },
+                "operation": {
+                    "description": "Operation is the rclone subcommand: \"copy\" (default), \"sync\" or \"move\".\nIgnored for rsync.",
+                    "type": "string",
+                    "example": "copy"
+                },
+                "schedule_daily_at": {
+                    "description": "ScheduleDailyAt runs the job once a day at this local time (\"HH:MM\").",
+                    "type": "string",
+                    "example": "03:00"
+                },
+                "schedule_interval_minutes": {
+                    "description": "ScheduleIntervalMinutes runs the job every N minutes (0 = not scheduled).",
+                    "type": "integer",
+                    "example": 0
+                },
+                "source": {
+                    "description": "Source and Destination are passed to the tool unchanged.",
+                    "type": "string",
+                    "example": "/mnt/user/appdata/"
+                },
+                "tool": {
+                    "description": "Tool is the program to run: \"rsync\" or \"rclone\".",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.TransferTool"
+                        }
+                    ],
+                    "example": "rsync"
+                }
+            }
+        },
+        "dto.TransferRun": {
+            "type": "object",
+            "properties": {
+                "bytes_transferred": {
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "error": {
+                    "type": "string"
+                },
+                "eta_seconds": {
+                    "type": "integer",
+                    "example": 61
+                },
+                "exit_code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "files_transferred": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "appdata-backup-1760655600"
                 },
-                "sensor_type": {
-                    "description": "cpu, motherboard, chipset, other",
+                "job_id": {
                     "type": "string",
-                    "example": "cpu"
+                    "example": "appdata-backup"
                 },
-                "source": {
-                    "description": "hwmon chip name",
+                "job_name": {
                     "type": "string",
-                    "example": "coretemp"
+                    "example": "Appdata backup"
                 },
-                "value_celsius": {
+                "output": {
+                    "description": "Last lines of tool output other than progress",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "percent": {
                     "type": "number",
-                    "example": 45
+                    "example": 25
+                },
+                "speed_bytes_per_sec": {
+                    "type": "number",
+                    "example": 52428800
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.TransferRunState"
+                        }
+                    ],
+                    "example": "running"
+                },
+                "tool": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.TransferTool"
+                        }
+                    ],
+                    "example": "rsync"
+                },
+                "total_bytes": {
+                    "description": "Estimated; 0 until known",
+                    "type": "integer",
+                    "example": 4294967296
+                },
+                "trigger": {
+                    "description": "manual or schedule",
+                    "type": "string",
+                    "example": "manual"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
+        "dto.TransferRunState": {
+            "type": "string",
+            "enum": [
+                "running",
+                "succeeded",
+                "failed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "TransferRunRunning",
+                "TransferRunSucceeded",
+                "TransferRunFailed",
+                "TransferRunCancelled"
+            ]
+        },
+        "dto.TransferTool": {
+            "type": "string",
+            "enum": [
+                "rsync",
+                "rclone"
+            ],
+            "x-enum-varnames": [
+                "TransferToolRsync",
+                "TransferToolRclone"
+            ]
+        },
         "dto.TuningInfo": {
             "type": "object",
             "properties": {
@@ -10457,6 +21799,23 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UnassignedFormatRequest": {
+            "type": "object",
+            "required": [
+                "filesystem"
+            ],
+            "properties": {
+                "confirm": {
+                    "description": "Confirm must be set to true to authorise the destructive format operation.",
+                    "type": "boolean"
+                },
+                "filesystem": {
+                    "description": "\"xfs\", \"btrfs\", \"exfat\"",
+                    "type": "string",
+                    "example": "xfs"
+                }
+            }
+        },
         "dto.UnassignedPartition": {
             "type": "object",
             "properties": {
@@ -10599,60 +21958,383 @@ const docTemplate = `{
                 "timestamp": {
                     "type": "string"
                 },
-                "total_plugins": {
+                "total_plugins": {
+                    "type": "integer",
+                    "example": 18
+                }
+            }
+        },
+        "dto.UserScriptExecuteRequest": {
+            "type": "object",
+            "properties": {
+                "background": {
+                    "type": "boolean"
+                },
+                "wait": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.UserScriptExecuteResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "output": {
+                    "type": "string"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.UserScriptInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "executable": {
+                    "type": "boolean"
+                },
+                "last_modified": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UtilizationEvent": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "string",
+                    "example": "disk2"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "disk"
+                },
+                "level": {
+                    "description": "\"warning\", \"critical\", \"resolved\"",
+                    "type": "string",
+                    "example": "critical"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Disk 2"
+                },
+                "previous_level": {
+                    "type": "string",
+                    "example": "warning"
+                },
+                "threshold_percent": {
+                    "type": "number",
+                    "example": 90
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "used_percent": {
+                    "type": "number",
+                    "example": 91.4
+                }
+            }
+        },
+        "dto.UtilizationStatus": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "string",
+                    "example": "disk2"
+                },
+                "kind": {
+                    "description": "\"disk\", \"pool\"",
+                    "type": "string",
+                    "example": "disk"
+                },
+                "level": {
+                    "description": "\"ok\", \"warning\", \"critical\"",
+                    "type": "string",
+                    "example": "critical"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Disk 2"
+                },
+                "since": {
+                    "description": "Since is when the current warning or critical level was entered.",
+                    "type": "string"
+                },
+                "used_percent": {
+                    "type": "number",
+                    "example": 91.4
+                }
+            }
+        },
+        "dto.UtilizationSummary": {
+            "type": "object",
+            "properties": {
+                "critical_percent": {
+                    "description": "0 disables the critical level",
+                    "type": "integer",
+                    "example": 90
+                },
+                "events": {
+                    "description": "Recent events, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UtilizationEvent"
+                    }
+                },
+                "filesystems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UtilizationStatus"
+                    }
+                },
+                "hysteresis_percent": {
+                    "description": "How far usage must fall below a threshold to leave its level",
+                    "type": "integer",
+                    "example": 2
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "warning_percent": {
+                    "description": "0 disables the warning level",
+                    "type": "integer",
+                    "example": 70
+                }
+            }
+        },
+        "dto.VCPUPin": {
+            "type": "object",
+            "properties": {
+                "cpus": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "cpuset": {
+                    "type": "string",
+                    "example": "4,12"
+                },
+                "vcpu": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "dto.VMArrayDependency": {
+            "type": "object",
+            "properties": {
+                "disk_path": {
+                    "type": "string",
+                    "example": "/mnt/user/domains/Windows 11/vdisk1.img"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Windows 11"
+                },
+                "requires_array": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "state": {
+                    "type": "string",
+                    "example": "running"
+                }
+            }
+        },
+        "dto.VMCPUPinning": {
+            "type": "object",
+            "properties": {
+                "emulator_cpuset": {
+                    "description": "EmulatorCPUSet is the host CPU list the QEMU emulator threads are\npinned to; empty when not pinned.",
+                    "type": "string",
+                    "example": "0,8"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Windows 11"
+                },
+                "pins": {
+                    "description": "Pins lists the pinned vCPUs; unpinned vCPUs float over all host CPUs.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VCPUPin"
+                    }
+                },
+                "state": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "vcpus": {
                     "type": "integer",
-                    "example": 18
+                    "example": 4
                 }
             }
         },
-        "dto.UserScriptExecuteRequest": {
+        "dto.VMCPUPinningRequest": {
             "type": "object",
             "properties": {
-                "background": {
+                "confirm": {
+                    "description": "Confirm must be true; a running VM is re-pinned immediately.",
                     "type": "boolean"
                 },
-                "wait": {
-                    "type": "boolean"
+                "emulator_cpuset": {
+                    "description": "EmulatorCPUSet pins the emulator threads; empty leaves them unchanged.",
+                    "type": "string",
+                    "example": "0,8"
+                },
+                "pins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VCPUPin"
+                    }
                 }
             }
         },
-        "dto.UserScriptExecuteResponse": {
+        "dto.VMDiskConversion": {
             "type": "object",
             "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "/mnt/user/domains/Windows 11/vdisk1.qcow2"
+                },
                 "error": {
                     "type": "string"
                 },
-                "message": {
+                "finished_at": {
                     "type": "string"
                 },
-                "output": {
+                "source_path": {
+                    "type": "string",
+                    "example": "/mnt/user/domains/Windows 11/vdisk1.img"
+                },
+                "started_at": {
                     "type": "string"
                 },
-                "pid": {
-                    "type": "integer"
+                "state": {
+                    "description": "State is \"running\", \"succeeded\" or \"failed\".",
+                    "type": "string",
+                    "example": "running"
                 },
-                "success": {
-                    "type": "boolean"
+                "target": {
+                    "type": "string",
+                    "example": "hdc"
+                },
+                "target_format": {
+                    "type": "string",
+                    "example": "qcow2"
+                },
+                "vm_name": {
+                    "type": "string",
+                    "example": "Windows 11"
                 }
             }
         },
-        "dto.UserScriptInfo": {
+        "dto.VMDiskConvertRequest": {
             "type": "object",
             "properties": {
-                "description": {
+                "format": {
+                    "description": "Format is the target image format: \"qcow2\" or \"raw\".",
+                    "type": "string",
+                    "example": "qcow2"
+                }
+            }
+        },
+        "dto.VMDiskImage": {
+            "type": "object",
+            "properties": {
+                "actual_size_bytes": {
+                    "description": "ActualSizeBytes is the space the image file occupies on disk, which is\nsmaller than the virtual size for sparse and qcow2 images.",
+                    "type": "integer",
+                    "example": 32212254720
+                },
+                "backing_file": {
                     "type": "string"
                 },
-                "executable": {
-                    "type": "boolean"
+                "bus": {
+                    "type": "string",
+                    "example": "virtio"
                 },
-                "last_modified": {
-                    "type": "string"
+                "conversion": {
+                    "description": "Conversion is the running or most recent format conversion of this disk.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.VMDiskConversion"
+                        }
+                    ]
                 },
-                "name": {
+                "error": {
+                    "description": "Error is set when qemu-img could not read the image.",
                     "type": "string"
                 },
+                "format": {
+                    "description": "Format is the image format detected by qemu-img (\"qcow2\", \"raw\", ...).",
+                    "type": "string",
+                    "example": "raw"
+                },
                 "path": {
+                    "type": "string",
+                    "example": "/mnt/user/domains/Windows 11/vdisk1.img"
+                },
+                "target": {
+                    "description": "Target is the guest device name from the VM definition.",
+                    "type": "string",
+                    "example": "hdc"
+                },
+                "virtual_size_bytes": {
+                    "description": "VirtualSizeBytes is the disk size seen by the guest.",
+                    "type": "integer",
+                    "example": 107374182400
+                }
+            }
+        },
+        "dto.VMDiskImageList": {
+            "type": "object",
+            "properties": {
+                "disks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VMDiskImage"
+                    }
+                },
+                "shut_off": {
+                    "description": "ShutOff reports whether the VM is shut off, which resizing and\nconverting disk images require.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "timestamp": {
                     "type": "string"
+                },
+                "vm_name": {
+                    "type": "string",
+                    "example": "Windows 11"
+                }
+            }
+        },
+        "dto.VMDiskResizeRequest": {
+            "type": "object",
+            "properties": {
+                "size_bytes": {
+                    "description": "SizeBytes is the new virtual size; it must be larger than the current one.",
+                    "type": "integer",
+                    "example": 161061273600
                 }
             }
         },
@@ -10695,6 +22377,13 @@ const docTemplate = `{
                     "type": "string",
                     "example": "1"
                 },
+                "interfaces": {
+                    "description": "Interfaces lists the VM's host tap interfaces (vnetX) with their traffic.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GuestInterfaceTraffic"
+                    }
+                },
                 "memory_allocated_bytes": {
                     "type": "integer",
                     "example": 8589934592
@@ -10715,10 +22404,19 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 104857600
                 },
+                "network_rx_bytes_per_sec": {
+                    "description": "NetworkRXBytesPerSec and NetworkTXBytesPerSec are the throughput of the\nVM's host tap interfaces, from the guest traffic collector.",
+                    "type": "number",
+                    "example": 10240
+                },
                 "network_tx_bytes": {
                     "type": "integer",
                     "example": 52428800
                 },
+                "network_tx_bytes_per_sec": {
+                    "type": "number",
+                    "example": 2048
+                },
                 "persistent": {
                     "type": "boolean",
                     "example": true
@@ -10756,6 +22454,19 @@ const docTemplate = `{
                 "enabled": {
                     "type": "boolean"
                 },
+                "host_pci_devices": {
+                    "description": "HostPCIDevices and HostUSBDevices are the host's devices with their\nIOMMU group and the VMs they are passed through to.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HostPCIDevice"
+                    }
+                },
+                "host_usb_devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HostUSBDevice"
+                    }
+                },
                 "pci_devices": {
                     "type": "array",
                     "items": {
@@ -10828,6 +22539,57 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.VMUSBDeviceRequest": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "description": "DeviceID is the USB device as \"vendor:product\" in hex, as listed in\nhost_usb_devices of GET /settings/vm.",
+                    "type": "string",
+                    "example": "046d:c52b"
+                }
+            }
+        },
+        "dto.VolumeDependency": {
+            "type": "object",
+            "properties": {
+                "container_path": {
+                    "type": "string",
+                    "example": "/config"
+                },
+                "host_path": {
+                    "type": "string",
+                    "example": "/mnt/user/appdata/plex"
+                },
+                "kind": {
+                    "description": "See VolumeKind* constants",
+                    "type": "string",
+                    "example": "share"
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "rw"
+                },
+                "requires_array": {
+                    "description": "RequiresArray is true when the path is unavailable while the array is\nstopped (user shares, array disks and pools).",
+                    "type": "boolean",
+                    "example": true
+                },
+                "share": {
+                    "type": "string",
+                    "example": "appdata"
+                },
+                "storage": {
+                    "description": "Storage is where the data can be: \"array\", a pool name or a disk ID.\nFor a user share it follows the share's primary and secondary storage.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "cache"
+                    ]
+                }
+            }
+        },
         "dto.VolumeMapping": {
             "type": "object",
             "properties": {
@@ -10845,6 +22607,136 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.WANProbeResult": {
+            "description": "Ping statistics for a single WAN probe target.",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error describes why the probe failed, if it did.",
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "description": "LatencyMs is the average round-trip time in milliseconds (0 when unreachable).",
+                    "type": "number",
+                    "example": 11.8
+                },
+                "packet_loss_percent": {
+                    "description": "PacketLossPct is the percentage of echo requests that went unanswered.",
+                    "type": "number",
+                    "example": 0
+                },
+                "reachable": {
+                    "description": "Reachable is true when at least one echo reply was received.",
+                    "type": "boolean"
+                },
+                "target": {
+                    "description": "Target is the probed host or IP address.",
+                    "type": "string",
+                    "example": "1.1.1.1"
+                }
+            }
+        },
+        "dto.WANStatus": {
+            "description": "Internet connectivity, public IP, and probe latency/packet loss.",
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "description": "AvgLatencyMs is the mean latency across reachable probe targets.",
+                    "type": "number",
+                    "example": 12.3
+                },
+                "online": {
+                    "description": "Online is true when any probe target answered or the public IP lookup succeeded.",
+                    "type": "boolean"
+                },
+                "packet_loss_percent": {
+                    "description": "PacketLossPct is the mean packet loss across all probe targets.",
+                    "type": "number",
+                    "example": 0
+                },
+                "previous_public_ip": {
+                    "description": "PreviousPublicIP is the public IP before the most recent change.",
+                    "type": "string",
+                    "example": "203.0.113.12"
+                },
+                "probes": {
+                    "description": "Probes holds the per-target results.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WANProbeResult"
+                    }
+                },
+                "public_ip": {
+                    "description": "PublicIP is the current public IPv4 address as seen by external lookup services.",
+                    "type": "string",
+                    "example": "203.0.113.45"
+                },
+                "public_ip_changed_at": {
+                    "description": "PublicIPChangedAt is when the most recent public IP change was detected.",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Timestamp is when this status was collected.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WOLDevice": {
+            "type": "object",
+            "properties": {
+                "broadcast": {
+                    "description": "Broadcast is the host:port the magic packet is sent to.",
+                    "type": "string",
+                    "example": "192.168.1.255:9"
+                },
+                "mac": {
+                    "type": "string",
+                    "example": "a8:a1:59:12:34:56"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "desktop"
+                }
+            }
+        },
+        "dto.WOLRequest": {
+            "type": "object",
+            "properties": {
+                "broadcast": {
+                    "description": "Broadcast is an IPv4 broadcast address with optional port; defaults\nto the device's, or 255.255.255.255:9.",
+                    "type": "string",
+                    "example": "192.168.1.255"
+                },
+                "device": {
+                    "type": "string",
+                    "example": "desktop"
+                },
+                "mac": {
+                    "type": "string",
+                    "example": "a8:a1:59:12:34:56"
+                }
+            }
+        },
+        "dto.WOLResult": {
+            "type": "object",
+            "properties": {
+                "broadcast": {
+                    "type": "string",
+                    "example": "192.168.1.255:9"
+                },
+                "device": {
+                    "type": "string",
+                    "example": "desktop"
+                },
+                "mac": {
+                    "type": "string",
+                    "example": "a8:a1:59:12:34:56"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.ZFSARCStats": {
             "type": "object",
             "properties": {
@@ -11067,11 +22959,20 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "days_until_full": {
+                    "type": "number",
+                    "example": 50
+                },
                 "dedup_ratio": {
                     "description": "Deduplication and Compression",
                     "type": "number",
                     "example": 1
                 },
+                "fill_rate_bytes_per_day": {
+                    "description": "Fill forecast from the trend of allocated space over the last 7 days;\nnil until 6 hours of history exist. DaysUntilFull is nil while usage is\nflat or shrinking.",
+                    "type": "number",
+                    "example": 1073741824
+                },
                 "fragmentation_percent": {
                     "description": "Fragmentation %",
                     "type": "number",
@@ -11246,6 +23147,40 @@ const docTemplate = `{
                     "example": 0
                 }
             }
+        },
+        "dto.ZramStats": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string",
+                    "example": "zstd"
+                },
+                "compressed_bytes": {
+                    "type": "integer",
+                    "example": 536870912
+                },
+                "compression_ratio": {
+                    "type": "number",
+                    "example": 4
+                },
+                "mem_used_bytes": {
+                    "type": "integer",
+                    "example": 566231040
+                },
+                "original_bytes": {
+                    "description": "OriginalBytes is the data stored, CompressedBytes its compressed size\nand MemUsedBytes the RAM the device uses including overhead.",
+                    "type": "integer",
+                    "example": 2147483648
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "\"Bearer \u003caccess token\u003e\" from /auth/login, or \"Bearer \u003cAPI key\u003e\". Required on every endpoint when AUTH_REQUIRED=true.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     },
     "tags": [
@@ -11336,6 +23271,10 @@ const docTemplate = `{
         {
             "description": "System tuning endpoints (turbo boost, disk cache, inotify, NIC offloads, ring buffers)",
             "name": "Tuning"
+        },
+        {
+            "description": "Password login with short-lived JWT access tokens and rotating refresh tokens",
+            "name": "Authentication"
         }
     ]
 }`
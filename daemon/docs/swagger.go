@@ -62,3 +62,10 @@ package docs
 //	@tag.description			Unraid OS and plugin update availability
 //	@tag.name					Tuning
 //	@tag.description			System tuning endpoints (turbo boost, disk cache, inotify, NIC offloads, ring buffers)
+//	@tag.name					Authentication
+//	@tag.description			Password login with short-lived JWT access tokens and rotating refresh tokens
+//
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				"Bearer <access token>" from /auth/login, or "Bearer <API key>". Required on every endpoint when AUTH_REQUIRED=true.
@@ -31,6 +31,12 @@ type MQTTConfig struct {
 	HomeAssistantMode   bool   `json:"home_assistant_mode"`
 	HomeAssistantPrefix string `json:"home_assistant_prefix"`
 	DiscoveryEnabled    bool   `json:"discovery_enabled"`
+
+	// Topic layout and entity naming templates; see dto.MQTTConfig.
+	TopicTemplate        string   `json:"topic_template,omitempty"`
+	EntityNameTemplate   string   `json:"entity_name_template,omitempty"`
+	UniqueIDTemplate     string   `json:"unique_id_template,omitempty"`
+	HADisabledCategories []string `json:"ha_disabled_categories,omitempty"`
 }
 
 // Config holds the application configuration settings.
@@ -44,21 +50,86 @@ type Config struct {
 	// ReadOnly blocks all state-changing MCP tools so AI agents can only
 	// consume data. The REST API is unaffected.
 	ReadOnly bool `json:"read_only,omitempty"`
+	// MCPAPIKey, when set, must be presented as a bearer token (or X-API-Key
+	// header) on the Streamable HTTP /mcp endpoint. STDIO is unaffected.
+	MCPAPIKey string `json:"-"`
+	// MCPHTTPTools and MCPStdioTools select the MCP tools exposed on each
+	// transport: "all", "read-only", "auto" (HTTP: all with an API key,
+	// read-only without), or a comma-separated allow-list of tool names.
+	MCPHTTPTools  string `json:"mcp_http_tools,omitempty"`
+	MCPStdioTools string `json:"mcp_stdio_tools,omitempty"`
 	// TLSCertFile and TLSKeyFile point at a PEM certificate/key pair. When both
 	// are set the HTTP server (including the /mcp endpoint) is served over HTTPS;
 	// when either is empty the server stays on plain HTTP.
 	TLSCertFile string `json:"tls_cert_file,omitempty"`
 	TLSKeyFile  string `json:"tls_key_file,omitempty"`
+	// ACME obtains and renews the HTTPS certificate automatically. When
+	// enabled it takes precedence over TLSCertFile and TLSKeyFile.
+	ACME ACMEConfig `json:"acme"`
+	// Auth configures password login and whether the REST API requires it.
+	Auth AuthConfig `json:"auth"`
+	// LowPowerMode multiplies every collector interval by 4.
+	LowPowerMode bool `json:"low_power_mode,omitempty"`
+	// Pprof serves the Go runtime profiles at /debug/pprof.
+	Pprof bool `json:"pprof,omitempty"`
+	// Mock replaces the collectors with synthetic data and simulates
+	// control actions instead of running them, for development without an
+	// Unraid server.
+	Mock bool `json:"mock,omitempty"`
+	// WSQueueSize is the number of events queued per WebSocket client, and
+	// WSSlowClientPolicy what happens when a client's queue is full:
+	// "disconnect" (default) or "drop-oldest".
+	WSQueueSize        int    `json:"ws_queue_size,omitempty"`
+	WSSlowClientPolicy string `json:"ws_slow_client_policy,omitempty"`
+	// ConfirmDestructive makes array stop and unlock, reboot, shutdown and
+	// format require a second call carrying a one-time confirmation token.
+	ConfirmDestructive bool `json:"confirm_destructive"`
 }
 
 // TLSEnabled reports whether HTTPS should be served. TLS is considered enabled
-// only when both a certificate and key file are configured. Whitespace-only
-// values are treated as unset so they don't mislead the HTTPS branch before
-// validation runs.
+// when ACME is enabled or when both a certificate and key file are configured.
+// Whitespace-only values are treated as unset so they don't mislead the HTTPS
+// branch before validation runs.
 func (c Config) TLSEnabled() bool {
+	if c.ACME.Enabled {
+		return true
+	}
 	return strings.TrimSpace(c.TLSCertFile) != "" && strings.TrimSpace(c.TLSKeyFile) != ""
 }
 
+// ACMEConfig holds settings for obtaining and renewing the HTTPS certificate
+// from an ACME CA (Let's Encrypt by default) with DNS-01 challenges.
+type ACMEConfig struct {
+	Enabled bool `json:"enabled"`
+	// Domains are the certificate names; "*.example.com" requests a wildcard.
+	Domains []string `json:"domains"`
+	// Email is the optional contact address of the ACME account.
+	Email string `json:"email,omitempty"`
+	// DNSProvider publishes the challenge record: cloudflare, digitalocean,
+	// duckdns or exec.
+	DNSProvider string `json:"dns_provider"`
+	// DNSToken is the provider API token.
+	DNSToken string `json:"-"` // Never serialize
+	// DNSHook is the script the exec provider runs as
+	// "<hook> present|cleanup <fqdn> <value>".
+	DNSHook string `json:"dns_hook,omitempty"`
+	// DirectoryURL is the ACME directory; empty means Let's Encrypt.
+	DirectoryURL string `json:"directory_url,omitempty"`
+	// RenewDays is how many days before expiry the certificate is renewed.
+	RenewDays int `json:"renew_days"`
+}
+
+// AuthConfig holds settings for /api/v1/auth/login sessions.
+type AuthConfig struct {
+	// Required rejects REST requests without a valid access token or the
+	// static API key.
+	Required bool `json:"required"`
+	// AccessTTL is the access token lifetime in minutes.
+	AccessTTL int `json:"access_ttl"`
+	// RefreshTTL is the refresh token (session) lifetime in hours.
+	RefreshTTL int `json:"refresh_ttl"`
+}
+
 // DiscoveryConfig holds zeroconf (mDNS/DNS-SD) auto-discovery settings.
 // When enabled, the agent advertises itself on the local network so that
 // integrations (e.g. the Home Assistant integration) can auto-discover it.
@@ -78,6 +149,78 @@ func DefaultDiscoveryConfig() DiscoveryConfig {
 	}
 }
 
+// InfluxDBConfig holds settings for pushing metrics to InfluxDB in line
+// protocol. Version 2 writes to Org/Bucket with Token; version 1 writes to
+// Database (and optional RetentionPolicy) with Username/Password.
+type InfluxDBConfig struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"url"`
+	Version int    `json:"version"`
+
+	// InfluxDB 2.x
+	Org    string `json:"org,omitempty"`
+	Bucket string `json:"bucket,omitempty"`
+	Token  string `json:"-"` // Never serialize token
+
+	// InfluxDB 1.x
+	Database        string `json:"database,omitempty"`
+	RetentionPolicy string `json:"retention_policy,omitempty"`
+	Username        string `json:"username,omitempty"`
+	Password        string `json:"-"` // Never serialize password
+
+	// Interval is the batch push interval in seconds.
+	Interval           int  `json:"interval"`
+	InsecureSkipVerify bool `json:"insecure_skip_verify"`
+}
+
+// GraphiteConfig holds settings for pushing metrics to Graphite (plaintext
+// over TCP) or StatsD (gauges over UDP).
+type GraphiteConfig struct {
+	Enabled bool `json:"enabled"`
+	// Protocol is "graphite" or "statsd".
+	Protocol string `json:"protocol"`
+	// Address is host[:port]; the port defaults to 2003 (graphite) or 8125 (statsd).
+	Address string `json:"address"`
+	// Prefix is prepended to every metric path.
+	Prefix string `json:"prefix"`
+	// Interval is the push interval in seconds.
+	Interval int `json:"interval"`
+}
+
+// ZabbixConfig holds settings for pushing collector data to a Zabbix server
+// or proxy with the zabbix_sender protocol.
+type ZabbixConfig struct {
+	Enabled bool `json:"enabled"`
+	// Server is the Zabbix server or proxy as host[:port] (default port 10051).
+	Server string `json:"server"`
+	// Host is the host name configured in Zabbix; empty uses the system hostname.
+	Host string `json:"host"`
+}
+
+// HeartbeatConfig holds settings for dead-man's-switch pings to a
+// healthchecks.io or Uptime Kuma push URL.
+type HeartbeatConfig struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"-"` // Never serialize: the URL contains the check's secret token
+	// Provider is "auto", "healthchecks" or "uptime_kuma".
+	Provider string `json:"provider"`
+	// Interval is the ping interval in seconds.
+	Interval int `json:"interval"`
+	// CriticalSources are the data source subsystems (e.g. "array", "disk")
+	// whose failure is reported as a failed heartbeat.
+	CriticalSources []string `json:"critical_sources"`
+}
+
+// UnhealthyRestartConfig holds settings for automatically restarting
+// containers whose Docker HEALTHCHECK stays unhealthy.
+type UnhealthyRestartConfig struct {
+	Enabled bool `json:"enabled"`
+	// AfterMinutes is how long a container must be unhealthy before it is restarted.
+	AfterMinutes int `json:"after_minutes"`
+	// Exclude lists container names that are never restarted automatically.
+	Exclude []string `json:"exclude"`
+}
+
 // DefaultMQTTConfig returns the default MQTT configuration.
 func DefaultMQTTConfig() MQTTConfig {
 	return MQTTConfig{
@@ -125,5 +268,10 @@ func (c *MQTTConfig) ToDTOConfig() *dto.MQTTConfig {
 		AutoReconnect:     true,
 		HomeAssistantMode: c.HomeAssistantMode,
 		HADiscoveryPrefix: c.HomeAssistantPrefix,
+
+		TopicTemplate:        c.TopicTemplate,
+		EntityNameTemplate:   c.EntityNameTemplate,
+		UniqueIDTemplate:     c.UniqueIDTemplate,
+		HADisabledCategories: c.HADisabledCategories,
 	}
 }
@@ -180,6 +180,10 @@ func TestConfigTLSEnabled(t *testing.T) {
 			}
 		})
 	}
+
+	if c := (Config{ACME: ACMEConfig{Enabled: true}}); !c.TLSEnabled() {
+		t.Error("TLSEnabled() = false with ACME enabled, want true")
+	}
 }
 
 func TestContextFields(t *testing.T) {
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/platform"
 )
@@ -29,6 +30,16 @@ type Intervals struct {
 	PluginUpdate   int
 	OSUpdate       int
 	Mover          int
+	DNS            int
+	WAN            int
+	Speedtest      int
+	Pools          int
+	Btrfs          int
+	RecycleBin     int
+	IPMI           int
+	Connect        int
+	GuestTraffic   int
+	RemoteMounts   int
 }
 
 // Context holds the application runtime context including the event hub and configuration.
@@ -38,8 +49,30 @@ type Context struct {
 	Intervals          Intervals
 	MQTTConfig         MQTTConfig
 	DiscoveryConfig    DiscoveryConfig
+	InfluxDBConfig     InfluxDBConfig
+	GraphiteConfig     GraphiteConfig
+	ZabbixConfig       ZabbixConfig
+	HeartbeatConfig    HeartbeatConfig
+	UnhealthyRestart   UnhealthyRestartConfig
 	DiagnosticLogger   *logger.DiagnosticLogger
 	LogsDir            string
 	DockerUpdateNotify bool
+	// WANProbes are the hosts pinged by the wan collector for latency and
+	// packet loss; empty means constants.DefaultWANProbes.
+	WANProbes []string
+	// SpeedtestTool selects the bandwidth test program ("speedtest-cli" or
+	// "iperf3"); SpeedtestServer is the speedtest-cli server ID or iperf3 host.
+	SpeedtestTool   string
+	SpeedtestServer string
+	// GPUBackends are the GPU vendors the gpu collector probes ("intel",
+	// "nvidia", "amd"), or "none"; empty or "auto" probes every vendor.
+	GPUBackends []string
+	// DiagnosticsTargets is the allow list for on-demand ping, DNS, and HTTP
+	// diagnostics: hostnames, "*.domain" wildcards, IPs, CIDRs, or "*". Empty
+	// allows nothing.
+	DiagnosticsTargets []string
+	// WOLDevices are the machines offered as Wake-on-LAN targets by name
+	// over REST, MCP and as Home Assistant buttons.
+	WOLDevices []dto.WOLDevice
 	Config
 }
@@ -1,6 +1,9 @@
 package domain
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 // EventBus is a type-safe publish/subscribe event bus.
 // It provides an untyped API (Sub/Pub/Unsub) that mirrors the cskr/pubsub
@@ -10,6 +13,12 @@ type EventBus struct {
 	mu         sync.RWMutex
 	subs       map[string][]chan any
 	bufferSize int
+
+	// recent keeps the last replaySize messages per topic (see Recent).
+	recentMu   sync.Mutex
+	recent     map[string][]RecentEvent
+	recentSeq  uint64
+	replaySize int
 }
 
 // NewEventBus creates a new EventBus with the given per-subscriber buffer size.
@@ -40,6 +49,11 @@ func (bus *EventBus) Sub(topics ...string) chan any {
 // Pub publishes msg to all subscribers of the given topics.
 // Argument order matches cskr/pubsub: data first, then topic(s).
 func (bus *EventBus) Pub(msg any, topics ...string) {
+	now := time.Now()
+	for _, t := range topics {
+		bus.record(msg, t, now)
+	}
+
 	bus.mu.RLock()
 	for _, t := range topics {
 		for _, ch := range bus.subs[t] {
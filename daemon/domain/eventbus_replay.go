@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// RecentEvent is a message kept for replay to late subscribers.
+type RecentEvent struct {
+	Topic       string
+	Data        any
+	PublishedAt time.Time
+	seq         uint64
+}
+
+// SetReplaySize keeps the last n messages of every topic for Recent; 0 (the
+// default) keeps none. Changing the size discards the messages kept so far.
+func (bus *EventBus) SetReplaySize(n int) {
+	bus.recentMu.Lock()
+	defer bus.recentMu.Unlock()
+	bus.replaySize = max(n, 0)
+	bus.recent = make(map[string][]RecentEvent)
+}
+
+// record keeps msg as the newest message of topic, evicting the oldest one
+// beyond the replay size.
+func (bus *EventBus) record(msg any, topic string, now time.Time) {
+	bus.recentMu.Lock()
+	defer bus.recentMu.Unlock()
+	if bus.replaySize == 0 {
+		return
+	}
+	bus.recentSeq++
+	kept := append(bus.recent[topic], RecentEvent{Topic: topic, Data: msg, PublishedAt: now, seq: bus.recentSeq})
+	if len(kept) > bus.replaySize {
+		kept = kept[len(kept)-bus.replaySize:]
+	}
+	bus.recent[topic] = kept
+}
+
+// Recent returns the kept messages of the given topics, oldest first, so a
+// new subscriber can render current state without waiting for the next
+// publish.
+func (bus *EventBus) Recent(topics ...string) []RecentEvent {
+	bus.recentMu.Lock()
+	defer bus.recentMu.Unlock()
+	var events []RecentEvent
+	for _, t := range topics {
+		events = append(events, bus.recent[t]...)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].seq < events[j].seq })
+	return events
+}
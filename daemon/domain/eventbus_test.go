@@ -230,3 +230,32 @@ func TestTypedPublish_Pointer(t *testing.T) {
 		t.Fatal("timed out")
 	}
 }
+
+func TestEventBus_Recent(t *testing.T) {
+	bus := NewEventBus(10)
+	bus.Pub("before", "a")
+	if got := bus.Recent("a"); len(got) != 0 {
+		t.Fatalf("replay disabled by default, got %v", got)
+	}
+
+	bus.SetReplaySize(2)
+	bus.Pub("a1", "a")
+	bus.Pub("b1", "b")
+	bus.Pub("a2", "a")
+	bus.Pub("a3", "a")
+	bus.Pub("c1", "c")
+
+	got := bus.Recent("a", "b")
+	want := []string{"b1", "a2", "a3"}
+	if len(got) != len(want) {
+		t.Fatalf("Recent() returned %d events, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if e.Data != want[i] {
+			t.Errorf("Recent()[%d] = %v, want %v", i, e.Data, want[i])
+		}
+		if e.PublishedAt.IsZero() {
+			t.Errorf("Recent()[%d] has no publish time", i)
+		}
+	}
+}
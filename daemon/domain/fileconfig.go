@@ -1,10 +1,17 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 
 	"go.yaml.in/yaml/v3"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 )
 
 // DefaultConfigPath is the standard location for the config file on Unraid.
@@ -15,83 +22,233 @@ const DefaultConfigPath = "/boot/config/plugins/unraid-management-agent/config.y
 // by CLI flags and environment variables.
 type FileConfig struct {
 	// Server settings
-	Port        *int    `yaml:"port,omitempty"`
-	BindAddress *string `yaml:"bind_address,omitempty"`
-	LogLevel    *string `yaml:"log_level,omitempty"`
-	LogsDir     *string `yaml:"logs_dir,omitempty"`
-	Debug       *bool   `yaml:"debug,omitempty"`
+	Port        *int    `yaml:"port,omitempty" json:"port,omitempty"`
+	BindAddress *string `yaml:"bind_address,omitempty" json:"bind_address,omitempty"`
+	LogLevel    *string `yaml:"log_level,omitempty" json:"log_level,omitempty"`
+	LogFormat   *string `yaml:"log_format,omitempty" json:"log_format,omitempty"`
+	Language    *string `yaml:"language,omitempty" json:"language,omitempty"`
+	LogsDir     *string `yaml:"logs_dir,omitempty" json:"logs_dir,omitempty"`
+	Debug       *bool   `yaml:"debug,omitempty" json:"debug,omitempty"`
 
 	// ReadOnly blocks all state-changing MCP tools (AI agents can only read).
-	ReadOnly *bool `yaml:"read_only,omitempty"`
+	ReadOnly *bool `yaml:"read_only,omitempty" json:"read_only,omitempty"`
+
+	// Pprof serves the Go runtime profiles at /debug/pprof.
+	Pprof *bool `yaml:"pprof,omitempty" json:"pprof,omitempty"`
+
+	// ConfirmDestructive requires a confirmation token for destructive REST calls.
+	ConfirmDestructive *bool `yaml:"confirm_destructive,omitempty" json:"confirm_destructive,omitempty"`
+
+	// WebSocket send queue per client and the policy when it is full.
+	WSQueueSize  *int    `yaml:"ws_queue_size,omitempty" json:"ws_queue_size,omitempty"`
+	WSSlowClient *string `yaml:"ws_slow_client,omitempty" json:"ws_slow_client,omitempty"`
+
+	// EventReplaySize is the number of events kept per topic for replay to
+	// new WebSocket clients.
+	EventReplaySize *int `yaml:"event_replay_size,omitempty" json:"event_replay_size,omitempty"`
 
 	// Power mode
-	LowPowerMode      *bool   `yaml:"low_power_mode,omitempty"`
-	DisableCollectors *string `yaml:"disable_collectors,omitempty"`
+	LowPowerMode      *bool   `yaml:"low_power_mode,omitempty" json:"low_power_mode,omitempty"`
+	DisableCollectors *string `yaml:"disable_collectors,omitempty" json:"disable_collectors,omitempty"`
 
 	// CORS
-	CORSOrigin *string `yaml:"cors_origin,omitempty"`
+	CORSOrigin *string `yaml:"cors_origin,omitempty" json:"cors_origin,omitempty"`
 
 	// TLS: serve HTTPS when both a certificate and key file are provided.
-	TLSCertFile *string `yaml:"tls_cert_file,omitempty"`
-	TLSKeyFile  *string `yaml:"tls_key_file,omitempty"`
+	TLSCertFile *string `yaml:"tls_cert_file,omitempty" json:"tls_cert_file,omitempty"`
+	TLSKeyFile  *string `yaml:"tls_key_file,omitempty" json:"tls_key_file,omitempty"`
+
+	// ACME certificate automation for the HTTPS listener
+	ACME *FileConfigACME `yaml:"acme,omitempty" json:"acme,omitempty"`
+
+	// Auth controls password login sessions for the REST API
+	Auth *FileConfigAuth `yaml:"auth,omitempty" json:"auth,omitempty"`
+
+	// WANProbes is a comma-separated list of hosts pinged by the wan collector.
+	WANProbes *string `yaml:"wan_probes,omitempty" json:"wan_probes,omitempty"`
+
+	// DiagnosticsTargets is a comma-separated allow list for ping, DNS, and
+	// HTTP diagnostics.
+	DiagnosticsTargets *string `yaml:"diagnostics_targets,omitempty" json:"diagnostics_targets,omitempty"`
+
+	// WOLDevices is a comma-separated list of name=MAC[@broadcast]
+	// Wake-on-LAN targets.
+	WOLDevices *string `yaml:"wol_devices,omitempty" json:"wol_devices,omitempty"`
+
+	// GPUBackends is a comma-separated list of GPU vendors to probe.
+	GPUBackends *string `yaml:"gpu_backends,omitempty" json:"gpu_backends,omitempty"`
+
+	// Scheduled bandwidth tests
+	SpeedtestTool   *string `yaml:"speedtest_tool,omitempty" json:"speedtest_tool,omitempty"`
+	SpeedtestServer *string `yaml:"speedtest_server,omitempty" json:"speedtest_server,omitempty"`
 
 	// MQTT configuration
-	MQTT *FileConfigMQTT `yaml:"mqtt,omitempty"`
+	MQTT *FileConfigMQTT `yaml:"mqtt,omitempty" json:"mqtt,omitempty"`
+
+	// InfluxDB line-protocol export
+	InfluxDB *FileConfigInfluxDB `yaml:"influxdb,omitempty" json:"influxdb,omitempty"`
+
+	// Graphite plaintext / StatsD export
+	Graphite *FileConfigGraphite `yaml:"graphite,omitempty" json:"graphite,omitempty"`
+
+	// Zabbix sender / low-level discovery
+	Zabbix *FileConfigZabbix `yaml:"zabbix,omitempty" json:"zabbix,omitempty"`
+
+	// Heartbeat (healthchecks.io / Uptime Kuma) pings
+	Heartbeat *FileConfigHeartbeat `yaml:"heartbeat,omitempty" json:"heartbeat,omitempty"`
+
+	// Automatic restart of containers stuck unhealthy
+	UnhealthyRestart *FileConfigUnhealthyRestart `yaml:"unhealthy_restart,omitempty" json:"unhealthy_restart,omitempty"`
 
 	// Discovery (zeroconf/mDNS) configuration
-	Discovery *FileConfigDiscovery `yaml:"discovery,omitempty"`
+	Discovery *FileConfigDiscovery `yaml:"discovery,omitempty" json:"discovery,omitempty"`
+
+	// MCP access control
+	MCP *FileConfigMCP `yaml:"mcp,omitempty" json:"mcp,omitempty"`
 
 	// Collection intervals (seconds, 0 = disabled)
-	Intervals *FileConfigIntervals `yaml:"intervals,omitempty"`
+	Intervals *FileConfigIntervals `yaml:"intervals,omitempty" json:"intervals,omitempty"`
 }
 
 // FileConfigDiscovery holds zeroconf (mDNS) discovery settings from the config file.
 type FileConfigDiscovery struct {
-	Enabled     *bool   `yaml:"enabled,omitempty"`
-	ServiceName *string `yaml:"service_name,omitempty"`
+	Enabled     *bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	ServiceName *string `yaml:"service_name,omitempty" json:"service_name,omitempty"`
+}
+
+// FileConfigACME holds ACME certificate settings from the config file.
+type FileConfigACME struct {
+	Enabled     *bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Domains     *string `yaml:"domains,omitempty" json:"domains,omitempty"`
+	Email       *string `yaml:"email,omitempty" json:"email,omitempty"`
+	DNSProvider *string `yaml:"dns_provider,omitempty" json:"dns_provider,omitempty"`
+	DNSToken    *string `yaml:"dns_token,omitempty" json:"dns_token,omitempty"`
+	DNSHook     *string `yaml:"dns_hook,omitempty" json:"dns_hook,omitempty"`
+	Directory   *string `yaml:"directory,omitempty" json:"directory,omitempty"`
+	RenewDays   *int    `yaml:"renew_days,omitempty" json:"renew_days,omitempty"`
+}
+
+// FileConfigAuth holds login session settings from the config file.
+type FileConfigAuth struct {
+	Required   *bool `yaml:"required,omitempty" json:"required,omitempty"`
+	AccessTTL  *int  `yaml:"access_ttl,omitempty" json:"access_ttl,omitempty"`
+	RefreshTTL *int  `yaml:"refresh_ttl,omitempty" json:"refresh_ttl,omitempty"`
+}
+
+// FileConfigMCP holds MCP access control settings from the config file.
+type FileConfigMCP struct {
+	APIKey     *string `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+	HTTPTools  *string `yaml:"http_tools,omitempty" json:"http_tools,omitempty"`
+	StdioTools *string `yaml:"stdio_tools,omitempty" json:"stdio_tools,omitempty"`
 }
 
 // FileConfigMQTT holds MQTT-specific settings from the config file.
 type FileConfigMQTT struct {
-	Enabled            *bool   `yaml:"enabled,omitempty"`
-	Broker             *string `yaml:"broker,omitempty"`
-	Port               *int    `yaml:"port,omitempty"`
-	Username           *string `yaml:"username,omitempty"`
-	Password           *string `yaml:"password,omitempty"`
-	ClientID           *string `yaml:"client_id,omitempty"`
-	TopicPrefix        *string `yaml:"topic_prefix,omitempty"`
-	UseTLS             *bool   `yaml:"use_tls,omitempty"`
-	InsecureSkipVerify *bool   `yaml:"insecure_skip_verify,omitempty"`
-	QoS                *int    `yaml:"qos,omitempty"`
-	Retain             *bool   `yaml:"retain,omitempty"`
-	HomeAssistant      *bool   `yaml:"home_assistant,omitempty"`
-	HAPrefix           *string `yaml:"ha_prefix,omitempty"`
+	Enabled            *bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Broker             *string `yaml:"broker,omitempty" json:"broker,omitempty"`
+	Port               *int    `yaml:"port,omitempty" json:"port,omitempty"`
+	Username           *string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password           *string `yaml:"password,omitempty" json:"password,omitempty"`
+	ClientID           *string `yaml:"client_id,omitempty" json:"client_id,omitempty"`
+	TopicPrefix        *string `yaml:"topic_prefix,omitempty" json:"topic_prefix,omitempty"`
+	UseTLS             *bool   `yaml:"use_tls,omitempty" json:"use_tls,omitempty"`
+	InsecureSkipVerify *bool   `yaml:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+	QoS                *int    `yaml:"qos,omitempty" json:"qos,omitempty"`
+	Retain             *bool   `yaml:"retain,omitempty" json:"retain,omitempty"`
+	HomeAssistant      *bool   `yaml:"home_assistant,omitempty" json:"home_assistant,omitempty"`
+	HAPrefix           *string `yaml:"ha_prefix,omitempty" json:"ha_prefix,omitempty"`
+	// TopicTemplate, EntityNameTemplate and UniqueIDTemplate customize the
+	// topic layout and HA entity naming; HADisabledCategories is a
+	// comma-separated list of topic categories left out of HA discovery.
+	TopicTemplate        *string `yaml:"topic_template,omitempty" json:"topic_template,omitempty"`
+	EntityNameTemplate   *string `yaml:"entity_name_template,omitempty" json:"entity_name_template,omitempty"`
+	UniqueIDTemplate     *string `yaml:"unique_id_template,omitempty" json:"unique_id_template,omitempty"`
+	HADisabledCategories *string `yaml:"ha_disabled_categories,omitempty" json:"ha_disabled_categories,omitempty"`
+}
+
+// FileConfigInfluxDB holds InfluxDB export settings from the config file.
+type FileConfigInfluxDB struct {
+	Enabled            *bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	URL                *string `yaml:"url,omitempty" json:"url,omitempty"`
+	Version            *int    `yaml:"version,omitempty" json:"version,omitempty"`
+	Org                *string `yaml:"org,omitempty" json:"org,omitempty"`
+	Bucket             *string `yaml:"bucket,omitempty" json:"bucket,omitempty"`
+	Token              *string `yaml:"token,omitempty" json:"token,omitempty"`
+	Database           *string `yaml:"database,omitempty" json:"database,omitempty"`
+	RetentionPolicy    *string `yaml:"retention_policy,omitempty" json:"retention_policy,omitempty"`
+	Username           *string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password           *string `yaml:"password,omitempty" json:"password,omitempty"`
+	Interval           *int    `yaml:"interval,omitempty" json:"interval,omitempty"`
+	InsecureSkipVerify *bool   `yaml:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+}
+
+// FileConfigGraphite holds Graphite/StatsD export settings from the config file.
+type FileConfigGraphite struct {
+	Enabled  *bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Protocol *string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	Address  *string `yaml:"address,omitempty" json:"address,omitempty"`
+	Prefix   *string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	Interval *int    `yaml:"interval,omitempty" json:"interval,omitempty"`
+}
+
+// FileConfigZabbix holds Zabbix sender settings from the config file.
+type FileConfigZabbix struct {
+	Enabled *bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Server  *string `yaml:"server,omitempty" json:"server,omitempty"`
+	Host    *string `yaml:"host,omitempty" json:"host,omitempty"`
+}
+
+// FileConfigHeartbeat holds heartbeat ping settings from the config file.
+type FileConfigHeartbeat struct {
+	Enabled         *bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	URL             *string `yaml:"url,omitempty" json:"url,omitempty"`
+	Provider        *string `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Interval        *int    `yaml:"interval,omitempty" json:"interval,omitempty"`
+	CriticalSources *string `yaml:"critical_sources,omitempty" json:"critical_sources,omitempty"`
+}
+
+// FileConfigUnhealthyRestart holds unhealthy-container restart settings from
+// the config file.
+type FileConfigUnhealthyRestart struct {
+	Enabled      *bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	AfterMinutes *int    `yaml:"after_minutes,omitempty" json:"after_minutes,omitempty"`
+	Exclude      *string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
 }
 
 // FileConfigIntervals holds collection interval overrides from the config file.
 type FileConfigIntervals struct {
-	System         *int `yaml:"system,omitempty"`
-	Array          *int `yaml:"array,omitempty"`
-	Disk           *int `yaml:"disk,omitempty"`
-	Docker         *int `yaml:"docker,omitempty"`
-	VM             *int `yaml:"vm,omitempty"`
-	UPS            *int `yaml:"ups,omitempty"`
-	NUT            *int `yaml:"nut,omitempty"`
-	GPU            *int `yaml:"gpu,omitempty"`
-	Shares         *int `yaml:"shares,omitempty"`
-	Network        *int `yaml:"network,omitempty"`
-	Hardware       *int `yaml:"hardware,omitempty"`
-	ZFS            *int `yaml:"zfs,omitempty"`
-	Notification   *int `yaml:"notification,omitempty"`
-	Registration   *int `yaml:"registration,omitempty"`
-	Unassigned     *int `yaml:"unassigned,omitempty"`
-	FanControl     *int `yaml:"fancontrol,omitempty"`
-	Tuning         *int `yaml:"tuning,omitempty"`
-	DockerUpdate   *int `yaml:"docker_update,omitempty"`
-	DockerNetworks *int `yaml:"docker_networks,omitempty"`
-	PluginUpdate   *int `yaml:"plugin_update,omitempty"`
-	OSUpdate       *int `yaml:"os_update,omitempty"`
-	Mover          *int `yaml:"mover,omitempty"`
+	System         *int `yaml:"system,omitempty" json:"system,omitempty"`
+	Array          *int `yaml:"array,omitempty" json:"array,omitempty"`
+	Disk           *int `yaml:"disk,omitempty" json:"disk,omitempty"`
+	Docker         *int `yaml:"docker,omitempty" json:"docker,omitempty"`
+	VM             *int `yaml:"vm,omitempty" json:"vm,omitempty"`
+	UPS            *int `yaml:"ups,omitempty" json:"ups,omitempty"`
+	NUT            *int `yaml:"nut,omitempty" json:"nut,omitempty"`
+	GPU            *int `yaml:"gpu,omitempty" json:"gpu,omitempty"`
+	Shares         *int `yaml:"shares,omitempty" json:"shares,omitempty"`
+	Network        *int `yaml:"network,omitempty" json:"network,omitempty"`
+	Hardware       *int `yaml:"hardware,omitempty" json:"hardware,omitempty"`
+	ZFS            *int `yaml:"zfs,omitempty" json:"zfs,omitempty"`
+	Notification   *int `yaml:"notification,omitempty" json:"notification,omitempty"`
+	Registration   *int `yaml:"registration,omitempty" json:"registration,omitempty"`
+	Unassigned     *int `yaml:"unassigned,omitempty" json:"unassigned,omitempty"`
+	FanControl     *int `yaml:"fancontrol,omitempty" json:"fancontrol,omitempty"`
+	Tuning         *int `yaml:"tuning,omitempty" json:"tuning,omitempty"`
+	DockerUpdate   *int `yaml:"docker_update,omitempty" json:"docker_update,omitempty"`
+	DockerNetworks *int `yaml:"docker_networks,omitempty" json:"docker_networks,omitempty"`
+	PluginUpdate   *int `yaml:"plugin_update,omitempty" json:"plugin_update,omitempty"`
+	OSUpdate       *int `yaml:"os_update,omitempty" json:"os_update,omitempty"`
+	Mover          *int `yaml:"mover,omitempty" json:"mover,omitempty"`
+	DNS            *int `yaml:"dns,omitempty" json:"dns,omitempty"`
+	WAN            *int `yaml:"wan,omitempty" json:"wan,omitempty"`
+	Speedtest      *int `yaml:"speedtest,omitempty" json:"speedtest,omitempty"`
+	Pools          *int `yaml:"pools,omitempty" json:"pools,omitempty"`
+	Btrfs          *int `yaml:"btrfs,omitempty" json:"btrfs,omitempty"`
+	RecycleBin     *int `yaml:"recycle_bin,omitempty" json:"recycle_bin,omitempty"`
+	IPMI           *int `yaml:"ipmi,omitempty" json:"ipmi,omitempty"`
+	Connect        *int `yaml:"connect,omitempty" json:"connect,omitempty"`
+	GuestTraffic   *int `yaml:"guest_traffic,omitempty" json:"guest_traffic,omitempty"`
+	RemoteMounts   *int `yaml:"remote_mounts,omitempty" json:"remote_mounts,omitempty"`
 }
 
 // LoadConfigFile reads and parses a YAML config file.
@@ -112,3 +269,142 @@ func LoadConfigFile(path string) (*FileConfig, error) {
 	}
 	return &cfg, nil
 }
+
+// SaveConfigFile writes cfg as YAML to path. The file is written to a
+// temporary sibling and renamed into place so a crash mid-write never leaves
+// a truncated config on the flash drive.
+func SaveConfigFile(path string, cfg *FileConfig) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("encoding config file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("replacing config file: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the values set in the config file. Unset values are not
+// validated since they fall back to CLI flags, environment, or defaults.
+func (c *FileConfig) Validate() error {
+	if c.Port != nil && (*c.Port < 1 || *c.Port > 65535) {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+	if c.LogLevel != nil {
+		if _, ok := ParseLogLevel(*c.LogLevel); !ok {
+			return fmt.Errorf("invalid log_level %q: use debug, info, warning, or error", *c.LogLevel)
+		}
+	}
+	if c.LogFormat != nil {
+		if _, ok := logger.ParseFormat(*c.LogFormat); !ok {
+			return fmt.Errorf("invalid log_format %q: use text or json", *c.LogFormat)
+		}
+	}
+	if c.Language != nil {
+		if _, ok := i18n.Normalize(*c.Language); !ok {
+			return fmt.Errorf("invalid language %q: use %s", *c.Language, strings.Join(i18n.Languages(), ", "))
+		}
+	}
+	if m := c.MQTT; m != nil {
+		if m.Port != nil && (*m.Port < 1 || *m.Port > 65535) {
+			return errors.New("mqtt.port must be between 1 and 65535")
+		}
+		if m.QoS != nil && (*m.QoS < 0 || *m.QoS > 2) {
+			return errors.New("mqtt.qos must be 0, 1, or 2")
+		}
+		if m.TopicTemplate != nil && *m.TopicTemplate != "" && !strings.HasSuffix(*m.TopicTemplate, "{topic}") {
+			return errors.New("mqtt.topic_template must end with {topic}")
+		}
+	}
+	if i := c.InfluxDB; i != nil {
+		if i.Version != nil && *i.Version != 1 && *i.Version != 2 {
+			return errors.New("influxdb.version must be 1 or 2")
+		}
+		if i.Interval != nil && (*i.Interval < 5 || *i.Interval > 86400) {
+			return errors.New("influxdb.interval must be between 5 and 86400 seconds")
+		}
+	}
+	if g := c.Graphite; g != nil {
+		if g.Protocol != nil && *g.Protocol != "graphite" && *g.Protocol != "statsd" {
+			return errors.New("graphite.protocol must be graphite or statsd")
+		}
+		if g.Interval != nil && (*g.Interval < 5 || *g.Interval > 86400) {
+			return errors.New("graphite.interval must be between 5 and 86400 seconds")
+		}
+	}
+	if a := c.ACME; a != nil {
+		if a.DNSProvider != nil && *a.DNSProvider != "" && *a.DNSProvider != "cloudflare" && *a.DNSProvider != "digitalocean" && *a.DNSProvider != "duckdns" && *a.DNSProvider != "exec" {
+			return errors.New("acme.dns_provider must be cloudflare, digitalocean, duckdns, or exec")
+		}
+		if a.RenewDays != nil && (*a.RenewDays < 1 || *a.RenewDays > 60) {
+			return errors.New("acme.renew_days must be between 1 and 60")
+		}
+	}
+	if a := c.Auth; a != nil {
+		if a.AccessTTL != nil && (*a.AccessTTL < 1 || *a.AccessTTL > 1440) {
+			return errors.New("auth.access_ttl must be between 1 and 1440 minutes")
+		}
+		if a.RefreshTTL != nil && (*a.RefreshTTL < 1 || *a.RefreshTTL > 2160) {
+			return errors.New("auth.refresh_ttl must be between 1 and 2160 hours")
+		}
+	}
+	if h := c.Heartbeat; h != nil {
+		if h.Provider != nil && *h.Provider != "" && *h.Provider != "auto" && *h.Provider != "healthchecks" && *h.Provider != "uptime_kuma" {
+			return errors.New("heartbeat.provider must be auto, healthchecks, or uptime_kuma")
+		}
+		if h.Interval != nil && (*h.Interval < 10 || *h.Interval > 86400) {
+			return errors.New("heartbeat.interval must be between 10 and 86400 seconds")
+		}
+	}
+	if u := c.UnhealthyRestart; u != nil {
+		if u.AfterMinutes != nil && (*u.AfterMinutes < 1 || *u.AfterMinutes > 1440) {
+			return errors.New("unhealthy_restart.after_minutes must be between 1 and 1440")
+		}
+	}
+	if c.Intervals != nil {
+		for name, v := range c.Intervals.ByCollector() {
+			if v != nil && *v != 0 && (*v < 5 || *v > 86400) {
+				return fmt.Errorf("intervals.%s must be 0 (disabled) or between 5 and 86400 seconds", name)
+			}
+		}
+	}
+	return nil
+}
+
+// ByCollector returns the interval overrides keyed by collector name. Nil
+// values are intervals not set in the file.
+func (iv *FileConfigIntervals) ByCollector() map[string]*int {
+	out := make(map[string]*int)
+	v := reflect.ValueOf(iv).Elem()
+	t := v.Type()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		out[name] = v.Field(i).Interface().(*int)
+	}
+	return out
+}
+
+// ParseLogLevel maps a config log level name onto a logger level.
+func ParseLogLevel(level string) (logger.LogLevel, bool) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return logger.LevelDebug, true
+	case "info":
+		return logger.LevelInfo, true
+	case "warning", "warn":
+		return logger.LevelWarning, true
+	case "error":
+		return logger.LevelError, true
+	}
+	return logger.LevelInfo, false
+}
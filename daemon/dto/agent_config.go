@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// AgentConfigStatus describes the agent's YAML config file and the outcome of
+// the most recent reload.
+type AgentConfigStatus struct {
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+	// Config is the parsed file with secrets (MQTT password, MCP API key)
+	// replaced by RedactedSecret.
+	Config     any        `json:"config"`
+	LastReload *time.Time `json:"last_reload,omitempty"`
+	LastError  string     `json:"last_error,omitempty"`
+	// RestartRequired lists settings changed since start-up that only take
+	// effect after the agent restarts.
+	RestartRequired []string  `json:"restart_required"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// ConfigReloadResult reports what a config reload changed.
+type ConfigReloadResult struct {
+	Applied         []string  `json:"applied"`
+	RestartRequired []string  `json:"restart_required"`
+	Errors          []string  `json:"errors,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// RedactedSecret replaces secret values in config responses. Sending it back
+// unchanged in an update keeps the stored secret.
+const RedactedSecret = "********"
@@ -0,0 +1,69 @@
+package dto
+
+import "time"
+
+// AgentStats reports the agent's own performance counters for diagnosing
+// slow or resource-hungry agents in the field.
+type AgentStats struct {
+	StartedAt     time.Time         `json:"started_at"`
+	UptimeSeconds int64             `json:"uptime_seconds" example:"86400"`
+	Requests      AgentRequestStats `json:"requests"`
+	WebSocket     AgentWSStats      `json:"websocket"`
+	MQTT          *AgentMQTTStats   `json:"mqtt,omitempty"`
+	Runtime       AgentRuntimeStats `json:"runtime"`
+	PprofEnabled  bool              `json:"pprof_enabled"`
+	Timestamp     time.Time         `json:"timestamp"`
+}
+
+// AgentRequestStats holds REST request counters since the agent started.
+type AgentRequestStats struct {
+	Total  int64 `json:"total" example:"15230"`
+	Errors int64 `json:"errors" example:"12"` // responses with status >= 500
+	// Routes are sorted by request count, busiest first.
+	Routes []AgentRouteStats `json:"routes"`
+}
+
+// AgentRouteStats holds the counters of one route.
+type AgentRouteStats struct {
+	Method         string  `json:"method" example:"GET"`
+	Route          string  `json:"route" example:"/api/v1/docker/{id}"`
+	Count          int64   `json:"count" example:"420"`
+	ClientErrors   int64   `json:"client_errors" example:"3"` // 4xx responses
+	ServerErrors   int64   `json:"server_errors" example:"0"` // 5xx responses
+	AvgLatencyMS   float64 `json:"avg_latency_ms" example:"2.4"`
+	MaxLatencyMS   float64 `json:"max_latency_ms" example:"180.5"`
+	LastStatusCode int     `json:"last_status_code" example:"200"`
+}
+
+// AgentWSStats holds WebSocket counters. DroppedMessages counts events not
+// delivered because a client's send queue was full; EvictedClients counts
+// clients disconnected for it under the "disconnect" policy.
+type AgentWSStats struct {
+	Clients          int    `json:"clients" example:"2"`
+	QueueSize        int    `json:"queue_size" example:"256"`
+	SlowClientPolicy string `json:"slow_client_policy" example:"disconnect" enums:"disconnect,drop-oldest"`
+	DroppedMessages  uint64 `json:"dropped_messages" example:"0"`
+	EvictedClients   uint64 `json:"evicted_clients" example:"0"`
+}
+
+// AgentMQTTStats holds MQTT publish counters; omitted when MQTT is not
+// configured.
+type AgentMQTTStats struct {
+	Connected      bool  `json:"connected" example:"true"`
+	MessagesSent   int64 `json:"messages_sent" example:"125000"`
+	MessagesErrors int64 `json:"messages_errors" example:"0"`
+	// MessagesPerMinute is averaged since the agent started.
+	MessagesPerMinute float64 `json:"messages_per_minute" example:"86.8"`
+}
+
+// AgentRuntimeStats holds Go runtime memory and scheduler figures.
+type AgentRuntimeStats struct {
+	GoVersion      string  `json:"go_version" example:"go1.26.0"`
+	Goroutines     int     `json:"goroutines" example:"84"`
+	HeapAllocBytes uint64  `json:"heap_alloc_bytes" example:"18874368"`
+	HeapSysBytes   uint64  `json:"heap_sys_bytes" example:"33554432"`
+	SysBytes       uint64  `json:"sys_bytes" example:"52428800"`
+	NumGC          uint32  `json:"num_gc" example:"312"`
+	GCPauseTotalMS float64 `json:"gc_pause_total_ms" example:"45.2"`
+	LastGCPauseMS  float64 `json:"last_gc_pause_ms" example:"0.12"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// Agent self-update states reported in AgentUpdateStatus.Status.
+const (
+	AgentUpdateUpToDate   = "up_to_date"
+	AgentUpdateAvailable  = "update_available"
+	AgentUpdateVerified   = "verified"
+	AgentUpdateInstalling = "installing"
+)
+
+// AgentUpdateStatus reports a self-update check, dry run, or install.
+type AgentUpdateStatus struct {
+	CurrentVersion  string     `json:"current_version"`
+	LatestVersion   string     `json:"latest_version,omitempty"`
+	UpdateAvailable bool       `json:"update_available"`
+	ReleaseURL      string     `json:"release_url,omitempty"`
+	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	BundleURL       string     `json:"bundle_url,omitempty"`
+	// Checksum is the published SHA-256 checksum of the bundle, prefixed with
+	// "sha256:".
+	Checksum         string    `json:"checksum,omitempty"`
+	ChecksumVerified bool      `json:"checksum_verified"`
+	DryRun           bool      `json:"dry_run"`
+	Status           string    `json:"status"`
+	Message          string    `json:"message"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// AgentUpdateRequest is the body of POST /agent/update.
+type AgentUpdateRequest struct {
+	// DryRun downloads and verifies the release bundle without installing it.
+	DryRun bool `json:"dry_run"`
+	// Confirm must be true to install; the agent restarts during the update.
+	Confirm bool `json:"confirm"`
+}
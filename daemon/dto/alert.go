@@ -69,6 +69,7 @@ type AlertEnv struct {
 	RunningContainers         int     `expr:"RunningContainers"`
 	StoppedContainers         int     `expr:"StoppedContainers"`
 	ContainerUpdatesAvailable int     `expr:"ContainerUpdatesAvailable"`
+	UnhealthyContainerProbes  int     `expr:"UnhealthyContainerProbes"` // Containers with at least one failing linked health probe
 	PluginUpdatesAvailable    int     `expr:"PluginUpdatesAvailable"`
 	VMCount                   int     `expr:"VMCount"`
 	RunningVMs                int     `expr:"RunningVMs"`
@@ -99,6 +100,15 @@ type AlertEnv struct {
 	NetworkErrors  uint64 `expr:"NetworkErrors"`
 	NetworkIFCount int    `expr:"NetworkIFCount"`
 
+	// WAN
+	WANOnline        bool    `expr:"WANOnline"`        // True when internet is reachable (also true when no WAN data exists)
+	WANLatencyMs     float64 `expr:"WANLatencyMs"`     // Mean latency to reachable probe targets
+	WANPacketLossPct float64 `expr:"WANPacketLossPct"` // Mean packet loss across probe targets
+	WANIPChanged     bool    `expr:"WANIPChanged"`     // True for 15 minutes after the public IP changes
+
+	// Remote mounts
+	StaleRemoteMounts int `expr:"StaleRemoteMounts"` // Mounted NFS/SMB shares whose statfs hangs or fails
+
 	// NUT (Network UPS Tools)
 	NUTBatteryCharge  float64 `expr:"NUTBatteryCharge"`
 	NUTBatteryRuntime int     `expr:"NUTBatteryRuntime"`
@@ -5,18 +5,68 @@ import "time"
 
 // ArrayStatus contains Unraid array status information
 type ArrayStatus struct {
-	State               string    `json:"state" example:"Started"`
-	UsedPercent         float64   `json:"used_percent" example:"45.5"`
-	FreeBytes           uint64    `json:"free_bytes" example:"54975581388800"`
-	TotalBytes          uint64    `json:"total_bytes" example:"100862164623360"`
-	ParityValid         bool      `json:"parity_valid" example:"true"`
-	ParityCheckStatus   string    `json:"parity_check_status" example:"idle"`
-	ParityCheckProgress float64   `json:"parity_check_progress" example:"0"`
-	NumDisks            int       `json:"num_disks" example:"10"`
-	NumDataDisks        int       `json:"num_data_disks" example:"8"`
-	NumParityDisks      int       `json:"num_parity_disks" example:"2"`
-	Timestamp           time.Time `json:"timestamp"`
+	State               string  `json:"state" example:"Started"`
+	UsedPercent         float64 `json:"used_percent" example:"45.5"`
+	FreeBytes           uint64  `json:"free_bytes" example:"54975581388800"`
+	TotalBytes          uint64  `json:"total_bytes" example:"100862164623360"`
+	ParityValid         bool    `json:"parity_valid" example:"true"`
+	ParityCheckStatus   string  `json:"parity_check_status" example:"idle"`
+	ParityCheckProgress float64 `json:"parity_check_progress" example:"0"`
+
+	// Parity operation position, speed and estimated completion; set only
+	// while a check, sync or rebuild is in progress. Speeds are in bytes per
+	// second; the ETA and finish time are omitted while paused.
+	ParityCheckPositionBytes uint64     `json:"parity_check_position_bytes,omitempty" example:"4398046511104"`
+	ParityCheckSizeBytes     uint64     `json:"parity_check_size_bytes,omitempty" example:"16000900661248"`
+	ParityCheckSpeed         float64    `json:"parity_check_speed_bytes_per_sec,omitempty" example:"185000000"`     // Current (smoothed) speed
+	ParityCheckAvgSpeed      float64    `json:"parity_check_avg_speed_bytes_per_sec,omitempty" example:"172000000"` // Average while running since observed start
+	ParityCheckETASeconds    int64      `json:"parity_check_eta_seconds,omitempty" example:"62700"`
+	ParityCheckFinishAt      *time.Time `json:"parity_check_finish_at,omitempty"`
+
+	// Fill forecast from the trend of used space over the last 7 days; nil
+	// until 6 hours of history exist. DaysUntilFull is nil while usage is
+	// flat or shrinking.
+	FillRateBytesPerDay *float64 `json:"fill_rate_bytes_per_day,omitempty" example:"21474836480"`
+	DaysUntilFull       *float64 `json:"days_until_full,omitempty" example:"2560.4"`
+
+	NumDisks       int       `json:"num_disks" example:"10"`
+	NumDataDisks   int       `json:"num_data_disks" example:"8"`
+	NumParityDisks int       `json:"num_parity_disks" example:"2"`
+	Timestamp      time.Time `json:"timestamp"`
 
 	// SourceStatus is non-nil when the data source is degraded or unavailable.
 	SourceStatus *SourceStatus `json:"source_status,omitempty"`
 }
+
+// Disk encryption states (DiskInfo.EncryptionState).
+const (
+	EncryptionUnlocked = "unlocked"  // Opened with the array key
+	EncryptionLocked   = "locked"    // Encrypted; the key is missing
+	EncryptionWrongKey = "wrong_key" // Encrypted; the key did not open it
+)
+
+// ArrayEncryptionStatus summarizes LUKS encryption of the array and pool
+// devices.
+type ArrayEncryptionStatus struct {
+	ArrayState     string            `json:"array_state" example:"Stopped"`
+	Encrypted      bool              `json:"encrypted" example:"true"`        // At least one device is encrypted
+	Locked         bool              `json:"locked" example:"true"`           // At least one encrypted device is not unlocked
+	KeyfilePresent bool              `json:"keyfile_present" example:"false"` // Unraid's keyfile exists in RAM
+	Devices        []EncryptedDevice `json:"devices"`
+	Timestamp      time.Time         `json:"timestamp"`
+}
+
+// EncryptedDevice is an encrypted array or pool device.
+type EncryptedDevice struct {
+	Name   string `json:"name" example:"disk1"`
+	Device string `json:"device,omitempty" example:"sdb"`
+	State  string `json:"state" example:"locked"` // One of the Encryption* constants
+}
+
+// ArrayUnlockRequest supplies the array encryption key. Exactly one of
+// Passphrase and Keyfile must be set.
+type ArrayUnlockRequest struct {
+	Passphrase  string `json:"passphrase,omitempty"`
+	Keyfile     string `json:"keyfile,omitempty"`                      // Base64-encoded keyfile contents
+	KeepKeyfile bool   `json:"keep_keyfile,omitempty" example:"false"` // Keep the key in RAM after the array starts
+}
@@ -0,0 +1,79 @@
+package dto
+
+import "time"
+
+// AuditSource identifies the interface through which a control action arrived.
+type AuditSource string
+
+const (
+	// AuditSourceREST marks actions received over the REST API.
+	AuditSourceREST AuditSource = "rest"
+
+	// AuditSourceMCP marks actions invoked through an MCP write tool.
+	AuditSourceMCP AuditSource = "mcp"
+
+	// AuditSourceMQTT marks actions received on an MQTT command topic.
+	AuditSourceMQTT AuditSource = "mqtt"
+
+	// AuditSourceWebhook marks actions run by a triggered inbound webhook.
+	AuditSourceWebhook AuditSource = "webhook"
+)
+
+const (
+	// AuditResultSuccess indicates the action completed successfully.
+	AuditResultSuccess = "success"
+
+	// AuditResultFailure indicates the action was attempted but failed.
+	AuditResultFailure = "failure"
+
+	// AuditResultDenied indicates the action was rejected before execution
+	// (e.g. read-only mode, or the user declined an MCP approval prompt).
+	AuditResultDenied = "denied"
+
+	// AuditResultApproved records that a user approved a destructive MCP tool
+	// call when prompted. The call itself is recorded in a separate entry.
+	AuditResultApproved = "approved"
+)
+
+// AuditEntry records a single state-changing operation.
+type AuditEntry struct {
+	// Timestamp is when the action completed.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Source is the interface the action arrived on: "rest", "mcp", "mqtt", or
+	// "webhook".
+	Source AuditSource `json:"source"`
+
+	// Client identifies the caller: source IP for REST, session ID for MCP,
+	// broker address for MQTT, webhook name for webhooks.
+	Client string `json:"client,omitempty"`
+
+	// Action is the operation performed, e.g. "POST /api/v1/docker/{id}/start",
+	// the MCP tool name, the MQTT command path, or the webhook action.
+	Action string `json:"action"`
+
+	// Target is the entity the action was applied to (container, VM, disk, ...).
+	Target string `json:"target,omitempty"`
+
+	// Result is "success", "failure", "denied", or "approved".
+	Result string `json:"result"`
+
+	// Detail carries a short error or status message.
+	Detail string `json:"detail,omitempty"`
+}
+
+// AuditFilter narrows an audit log query. Zero values match everything.
+type AuditFilter struct {
+	Source AuditSource
+	Action string
+	Target string
+	Result string
+	Since  time.Time
+	Limit  int
+}
+
+// AuditLogResponse is the API response for the audit log endpoint.
+type AuditLogResponse struct {
+	Entries []AuditEntry `json:"entries"`
+	Total   int          `json:"total"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// Authentication methods reported in AuthSession.Method.
+const (
+	AuthMethodToken  = "token"
+	AuthMethodAPIKey = "api_key"
+	AuthMethodNone   = "none"
+)
+
+// AuthLoginRequest is the request body for POST /auth/login.
+type AuthLoginRequest struct {
+	Username string `json:"username" example:"root"`
+	Password string `json:"password" example:"secret"`
+}
+
+// AuthRefreshRequest is the request body for POST /auth/refresh.
+type AuthRefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// AuthTokens is returned by login and refresh. Send the access token as
+// "Authorization: Bearer <token>"; exchange the refresh token for a new pair
+// before the access token expires.
+type AuthTokens struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type" example:"Bearer"`
+	ExpiresIn   int       `json:"expires_in" example:"900"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// RefreshToken can be used once; each refresh returns a new one.
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	Username         string    `json:"username" example:"root"`
+}
+
+// AuthSession describes how the current request is authenticated.
+type AuthSession struct {
+	// AuthRequired is true when the API rejects unauthenticated requests.
+	AuthRequired bool `json:"auth_required" example:"true"`
+	// Authenticated is true when the request carries a valid access token or
+	// API key.
+	Authenticated bool `json:"authenticated" example:"true"`
+	// Method is "token" (login session), "api_key" or "none".
+	Method    string     `json:"method" example:"token"`
+	Username  string     `json:"username,omitempty" example:"root"`
+	SessionID string     `json:"session_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// BatchRequest is a list of actions executed together by POST /batch.
+type BatchRequest struct {
+	Actions []ActionRef `json:"actions"`
+	// Concurrency is the number of actions run at the same time (0 uses the default).
+	Concurrency int `json:"concurrency,omitempty" example:"4"`
+}
+
+// BatchResult reports the outcome of every action in a batch, in request order.
+type BatchResult struct {
+	Results    []ActionResult `json:"results"`
+	Succeeded  int            `json:"succeeded"`
+	Failed     int            `json:"failed"`
+	DurationMs int64          `json:"duration_ms"`
+	Timestamp  time.Time      `json:"timestamp"`
+}
@@ -0,0 +1,80 @@
+package dto
+
+import "time"
+
+// Boot sequence steps, in the order they run.
+const (
+	BootStepNetwork    = "network"
+	BootStepUPS        = "ups"
+	BootStepArray      = "array"
+	BootStepContainers = "containers"
+	BootStepVMs        = "vms"
+)
+
+// Boot sequence and step states.
+const (
+	BootStatePending = "pending"
+	BootStateRunning = "running"
+	BootStateDone    = "done"
+	// BootStateSkipped means the step was not configured or not needed, or
+	// (for the sequence) that the agent did not start right after a boot.
+	BootStateSkipped = "skipped"
+	// BootStateWarning means the step had problems but the sequence continued.
+	BootStateWarning = "warning"
+	// BootStateFailed means a precondition or the array start failed and the
+	// remaining steps were not run.
+	BootStateFailed = "failed"
+)
+
+// BootSequenceConfig configures what the agent does after the server boots.
+// @Description Boot sequence: wait for preconditions, unlock and start the array, then start containers and VMs
+type BootSequenceConfig struct {
+	Enabled bool `json:"enabled" example:"true"`
+
+	// WaitNetwork waits for an address and a default route and, when
+	// NetworkHost is set, for a TCP connection to it.
+	WaitNetwork bool   `json:"wait_network" example:"true"`
+	NetworkHost string `json:"network_host,omitempty" example:"192.168.1.1:53"` // host:port
+
+	// WaitUPS waits until the UPS is on line power with at least
+	// MinBatteryPercent charge.
+	WaitUPS           bool `json:"wait_ups" example:"true"`
+	MinBatteryPercent int  `json:"min_battery_percent,omitempty" example:"50"`
+
+	// StartArray starts the array if it is stopped. Encrypted arrays are
+	// unlocked with the key read from KeyFile or fetched from KeyURL; with
+	// neither, the sequence waits for POST /array/unlock.
+	StartArray bool   `json:"start_array" example:"true"`
+	KeyFile    string `json:"key_file,omitempty" example:"/mnt/disks/keystick/keyfile"`
+	KeyURL     string `json:"key_url,omitempty" example:"https://keys.example.com/tower"` // HTTPS only
+
+	Containers        []string `json:"containers,omitempty" example:"mariadb,nextcloud"` // Started in this order
+	VMs               []string `json:"vms,omitempty" example:"HomeAssistant"`            // Started in this order
+	StartDelaySeconds int      `json:"start_delay_seconds,omitempty" example:"5"`        // Pause between container and VM starts
+
+	// StepTimeoutSeconds bounds each waiting step (default 300).
+	StepTimeoutSeconds int `json:"step_timeout_seconds,omitempty" example:"300"`
+}
+
+// BootSequenceStep is the progress of one boot sequence step.
+type BootSequenceStep struct {
+	Name       string     `json:"name" example:"array"`
+	State      string     `json:"state" example:"done"`
+	Message    string     `json:"message,omitempty" example:"array started"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// BootSequenceStatus is the configuration and the progress of the last
+// boot sequence run.
+// @Description Boot sequence configuration and progress of the last run
+type BootSequenceStatus struct {
+	Config     BootSequenceConfig `json:"config"`
+	State      string             `json:"state" example:"done"` // Empty until the sequence has run
+	Message    string             `json:"message,omitempty" example:"agent started 42 minutes after boot"`
+	Trigger    string             `json:"trigger,omitempty" example:"boot"` // "boot" or "manual"
+	StartedAt  *time.Time         `json:"started_at,omitempty"`
+	FinishedAt *time.Time         `json:"finished_at,omitempty"`
+	Steps      []BootSequenceStep `json:"steps"`
+	Timestamp  time.Time          `json:"timestamp"`
+}
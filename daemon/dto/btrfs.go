@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// BtrfsFilesystem reports the per-device error counters and latest scrub
+// result of a mounted btrfs filesystem (mainly cache pools, but also the
+// docker.img and libvirt.img loop filesystems).
+type BtrfsFilesystem struct {
+	Name       string             `json:"name" example:"cache"` // Last element of the mount point
+	MountPoint string             `json:"mount_point" example:"/mnt/cache"`
+	Devices    []BtrfsDeviceStats `json:"devices"`
+
+	// TotalErrors is the sum of every error counter across all devices. The
+	// counters are persistent, so any non-zero value needs attention until it
+	// is reset with `btrfs device stats -z`.
+	TotalErrors uint64 `json:"total_errors" example:"0"`
+
+	// Latest scrub, from `btrfs scrub status`.
+	ScrubStatus        string `json:"scrub_status,omitempty" example:"finished"` // "running", "finished", "aborted", "interrupted", "never"
+	ScrubStarted       string `json:"scrub_started,omitempty" example:"Sun Oct 12 03:00:01 2026"`
+	ScrubErrors        string `json:"scrub_errors,omitempty" example:"no errors found"`
+	ScrubCorrected     uint64 `json:"scrub_corrected_errors" example:"0"`
+	ScrubUncorrectable uint64 `json:"scrub_uncorrectable_errors" example:"0"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// BtrfsDeviceStats holds the `btrfs device stats` counters of one device.
+type BtrfsDeviceStats struct {
+	Device           string `json:"device" example:"/dev/nvme0n1p1"`
+	WriteIOErrors    uint64 `json:"write_io_errs" example:"0"`
+	ReadIOErrors     uint64 `json:"read_io_errs" example:"0"`
+	FlushIOErrors    uint64 `json:"flush_io_errs" example:"0"`
+	CorruptionErrors uint64 `json:"corruption_errs" example:"0"`
+	GenerationErrors uint64 `json:"generation_errs" example:"0"`
+}
+
+// TotalErrors returns the sum of all error counters of the device.
+func (d BtrfsDeviceStats) TotalErrors() uint64 {
+	return d.WriteIOErrors + d.ReadIOErrors + d.FlushIOErrors + d.CorruptionErrors + d.GenerationErrors
+}
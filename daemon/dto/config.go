@@ -12,9 +12,107 @@ type ShareConfig struct {
 	IncludeDisks []string  `json:"include_disks,omitempty"` // Disks to include
 	ExcludeDisks []string  `json:"exclude_disks,omitempty"` // Disks to exclude
 	UseCache     string    `json:"use_cache,omitempty"`     // "yes", "no", "only", "prefer"
+	CachePool    string    `json:"cache_pool,omitempty"`    // Pool used by use_cache, e.g. "cache"
 	Export       string    `json:"export,omitempty"`        // SMB/NFS/AFP export settings
 	Security     string    `json:"security,omitempty"`      // "public", "private", "secure"
 	Timestamp    time.Time `json:"timestamp"`
+
+	// MoverTuning is the share's override in the Mover Tuning plugin; nil
+	// when the plugin is not installed or the share has no override.
+	MoverTuning *ShareMoverTuning `json:"mover_tuning,omitempty"`
+}
+
+// ShareMoverTuning is a per-share override of the Mover Tuning plugin
+// (ca.mover.tuning). Unset fields use the plugin's global settings.
+type ShareMoverTuning struct {
+	// Override enables the per-share settings below.
+	Override bool `json:"override" example:"true"`
+	// ThresholdPercent is the pool usage the mover waits for before moving
+	// the share's files.
+	ThresholdPercent *int `json:"threshold_percent,omitempty" example:"70"`
+	// AgeDays only moves files last modified at least this many days ago.
+	AgeDays *int `json:"age_days,omitempty" example:"30"`
+	// MinSizeMB only moves files of at least this size.
+	MinSizeMB *int `json:"min_size_mb,omitempty" example:"100"`
+}
+
+// ShareConfigDryRun is the response for POST /shares/{name}/config?dry_run=true:
+// what a share config change would do, without applying it.
+type ShareConfigDryRun struct {
+	Share   string              `json:"share" example:"media"`
+	Changes []ShareConfigChange `json:"changes"`
+	// Moves totals the affected files by what the mover would do with them.
+	Moves []ShareMoveSummary `json:"moves"`
+	// Files lists affected files, largest first, up to 500.
+	Files          []ShareAffectedFile `json:"files"`
+	FilesTruncated bool                `json:"files_truncated,omitempty"`
+	// SkippedDisks lists disks in standby that were not scanned; pass
+	// spinup=true to include them.
+	SkippedDisks []string `json:"skipped_disks,omitempty"`
+	// DisksBelowFloor lists the disks and pools with less free space than the
+	// new minimum free space; new files for the share no longer go there.
+	DisksBelowFloor []string  `json:"disks_below_floor,omitempty"`
+	Warnings        []string  `json:"warnings,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// ShareConfigChange is one setting a share config update changes.
+type ShareConfigChange struct {
+	Field string `json:"field" example:"use_cache"`
+	From  string `json:"from" example:"yes"`
+	To    string `json:"to" example:"prefer"`
+}
+
+// ShareMoveSummary totals the files given one mover action.
+type ShareMoveSummary struct {
+	// Action is "move_to_array", "move_to_pool", "stay" (the mover no
+	// longer moves the file) or "stranded" (the mover leaves the file where
+	// the new settings no longer expect it).
+	Action string `json:"action" example:"move_to_pool"`
+	Files  uint64 `json:"files" example:"1240"`
+	Bytes  uint64 `json:"bytes" example:"53687091200"`
+}
+
+// ShareAffectedFile is a file whose handling by the mover would change.
+type ShareAffectedFile struct {
+	Path       string    `json:"path" example:"/mnt/disk1/media/movie.mkv"`
+	SizeBytes  uint64    `json:"size_bytes" example:"4294967296"`
+	ModifiedAt time.Time `json:"modified_at"`
+	// Action is what the mover would do with the file under the new settings.
+	Action string `json:"action" example:"move_to_pool"`
+}
+
+// ShareExport is how a user share is exported over SMB and NFS.
+type ShareExport struct {
+	Name string `json:"name" example:"media"`
+	// SMBExport is "no", "yes", "hidden", "time_machine" or "time_machine_hidden".
+	SMBExport string `json:"smb_export" example:"yes"`
+	// SMBSecurity is "public" (guest read/write), "secure" (guest read, listed
+	// users write) or "private" (listed users only).
+	SMBSecurity   string   `json:"smb_security" example:"secure"`
+	SMBReadUsers  []string `json:"smb_read_users"`
+	SMBWriteUsers []string `json:"smb_write_users"`
+	// NFSExport is "no" or "yes".
+	NFSExport   string `json:"nfs_export" example:"no"`
+	NFSSecurity string `json:"nfs_security" example:"public"`
+	// NFSRules is the exports(5) host rule list used by private NFS security.
+	NFSRules  string    `json:"nfs_rules,omitempty" example:"192.168.1.0/24(rw,sec=sys)"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ShareExportUpdate is the request body for PATCH /shares/{name}/export.
+// Omitted fields are left unchanged.
+type ShareExportUpdate struct {
+	SMBExport   *string `json:"smb_export,omitempty" example:"yes"`
+	SMBSecurity *string `json:"smb_security,omitempty" example:"private"`
+	// SMBReadUsers and SMBWriteUsers replace the per-user access lists; an
+	// omitted list keeps its current users. Users in neither list get no
+	// access to a private share and read-only access to a secure share.
+	SMBReadUsers  *[]string `json:"smb_read_users,omitempty"`
+	SMBWriteUsers *[]string `json:"smb_write_users,omitempty"`
+	NFSExport     *string   `json:"nfs_export,omitempty" example:"yes"`
+	NFSSecurity   *string   `json:"nfs_security,omitempty" example:"private"`
+	NFSRules      *string   `json:"nfs_rules,omitempty" example:"192.168.1.0/24(rw,sec=sys)"`
 }
 
 // NetworkConfig represents network interface configuration
@@ -31,6 +129,91 @@ type NetworkConfig struct {
 	Timestamp     time.Time `json:"timestamp"`
 }
 
+// NetworkSettings is the interface configuration of /boot/config/network.cfg
+// as edited on Settings > Network Settings.
+type NetworkSettings struct {
+	Interfaces []NetworkInterfaceSettings `json:"interfaces"`
+	DNSServers []string                   `json:"dns_servers"`
+	// Change is the most recent change applied through the API: pending
+	// until confirmed, or rolled back when the confirmation did not arrive.
+	Change    *NetworkChange `json:"change,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// NetworkInterfaceSettings is the configuration of one network port (eth0,
+// eth1, ...) and the bond, bridge and VLAN interfaces built on it.
+type NetworkInterfaceSettings struct {
+	Port string `json:"port" example:"eth0"`
+	// Name is the interface that carries the address: br0, bond0 or eth0.
+	Name        string `json:"name" example:"br0"`
+	Description string `json:"description,omitempty"`
+	Bonding     bool   `json:"bonding"`
+	// BondMode is the Linux bonding mode number, 0-6 (1 = active-backup).
+	BondMode   string                `json:"bond_mode,omitempty" example:"1"`
+	BondNICs   []string              `json:"bond_nics,omitempty"`
+	Bridging   bool                  `json:"bridging"`
+	BridgeNICs []string              `json:"bridge_nics,omitempty"`
+	BridgeSTP  bool                  `json:"bridge_stp"`
+	UseDHCP    bool                  `json:"use_dhcp"`
+	IPAddress  string                `json:"ip_address,omitempty" example:"192.168.1.10"`
+	Netmask    string                `json:"netmask,omitempty" example:"255.255.255.0"`
+	Gateway    string                `json:"gateway,omitempty" example:"192.168.1.1"`
+	MTU        int                   `json:"mtu,omitempty" example:"1500"`
+	VLANs      []NetworkVLANSettings `json:"vlans"`
+}
+
+// NetworkVLANSettings is a VLAN sub-interface (br0.20) of a network port.
+type NetworkVLANSettings struct {
+	ID          int    `json:"id" example:"20"`
+	Description string `json:"description,omitempty" example:"IoT"`
+	UseDHCP     bool   `json:"use_dhcp"`
+	IPAddress   string `json:"ip_address,omitempty" example:"192.168.20.10"`
+	Netmask     string `json:"netmask,omitempty" example:"255.255.255.0"`
+	Gateway     string `json:"gateway,omitempty" example:"192.168.20.1"`
+}
+
+// NetworkSettingsUpdate is the request body for PUT /network/config. Only
+// the listed ports and the fields set on them change.
+type NetworkSettingsUpdate struct {
+	Interfaces []NetworkInterfaceUpdate `json:"interfaces,omitempty"`
+	// DNSServers replaces the static DNS servers (up to three).
+	DNSServers *[]string `json:"dns_servers,omitempty"`
+	// RollbackSeconds is how long the change waits for
+	// POST /network/config/confirm before it is reverted (30-600, default 120).
+	RollbackSeconds int `json:"rollback_seconds,omitempty" example:"120"`
+}
+
+// NetworkInterfaceUpdate changes the configuration of one network port.
+type NetworkInterfaceUpdate struct {
+	Port        string    `json:"port" example:"eth0"`
+	Description *string   `json:"description,omitempty"`
+	Bonding     *bool     `json:"bonding,omitempty"`
+	BondMode    *string   `json:"bond_mode,omitempty" example:"1"`
+	BondNICs    *[]string `json:"bond_nics,omitempty"`
+	Bridging    *bool     `json:"bridging,omitempty"`
+	BridgeSTP   *bool     `json:"bridge_stp,omitempty"`
+	UseDHCP     *bool     `json:"use_dhcp,omitempty"`
+	IPAddress   *string   `json:"ip_address,omitempty" example:"192.168.1.10"`
+	Netmask     *string   `json:"netmask,omitempty" example:"255.255.255.0"`
+	Gateway     *string   `json:"gateway,omitempty" example:"192.168.1.1"`
+	// MTU is 68-9216; 0 restores the default.
+	MTU *int `json:"mtu,omitempty" example:"9000"`
+	// VLANs replaces the port's VLAN list; an empty list removes all VLANs.
+	VLANs *[]NetworkVLANSettings `json:"vlans,omitempty"`
+}
+
+// NetworkChange is the state of a network change applied with a rollback
+// timer.
+type NetworkChange struct {
+	// State is pending, confirmed or rolled_back.
+	State      string     `json:"state" example:"pending"`
+	AppliedAt  time.Time  `json:"applied_at"`
+	RollbackAt time.Time  `json:"rollback_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	// Message explains a rollback.
+	Message string `json:"message,omitempty"`
+}
+
 // SystemSettings represents system configuration
 type SystemSettings struct {
 	ServerName   string    `json:"server_name"`
@@ -58,7 +241,11 @@ type VMSettings struct {
 	PCIDevices      []string          `json:"pci_devices,omitempty"`
 	USBDevices      []string          `json:"usb_devices,omitempty"`
 	DefaultSettings map[string]string `json:"default_settings,omitempty"`
-	Timestamp       time.Time         `json:"timestamp"`
+	// HostPCIDevices and HostUSBDevices are the host's devices with their
+	// IOMMU group and the VMs they are passed through to.
+	HostPCIDevices []HostPCIDevice `json:"host_pci_devices,omitempty"`
+	HostUSBDevices []HostUSBDevice `json:"host_usb_devices,omitempty"`
+	Timestamp      time.Time       `json:"timestamp"`
 }
 
 // DiskSettings represents disk configuration
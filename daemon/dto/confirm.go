@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// ConfirmTokenHeader carries the token from a ConfirmationChallenge when a
+// destructive REST request is repeated to execute it.
+const ConfirmTokenHeader = "X-Confirm-Token"
+
+// ConfirmationChallenge is returned with 428 Precondition Required by
+// destructive REST endpoints called without a confirmation token. Repeating
+// the same request with the token (X-Confirm-Token header or confirm_token
+// query parameter) before it expires executes it.
+type ConfirmationChallenge struct {
+	Success              bool   `json:"success" example:"false"`
+	Message              string `json:"message" example:"Confirmation required: repeat the request with the X-Confirm-Token header within 60 seconds"`
+	ConfirmationRequired bool   `json:"confirmation_required" example:"true"`
+	// ConfirmToken is single-use and only valid for this action and target.
+	ConfirmToken string `json:"confirm_token" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	Action       string `json:"action" example:"system_reboot"`
+	Target       string `json:"target,omitempty" example:"sdd"`
+	Summary      string `json:"summary" example:"Reboot the server"`
+	// Consequences lists what the action will interrupt or destroy, based on
+	// the current state of the server.
+	Consequences []string `json:"consequences"`
+	// Impact details the running containers, VMs and exported shares that
+	// stop when the array stops; set for array stop, reboot and shutdown.
+	Impact    *ArrayStopImpact `json:"impact,omitempty"`
+	ExpiresIn int              `json:"expires_in" example:"60"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	Timestamp time.Time        `json:"timestamp"`
+}
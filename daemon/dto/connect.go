@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// ConnectStatus reports the state of the Unraid Connect plugin: account
+// sign-in, the cloud connection, flash backup and remote access.
+type ConnectStatus struct {
+	Installed bool   `json:"installed" example:"true"`
+	SignedIn  bool   `json:"signed_in" example:"true"`
+	Username  string `json:"username,omitempty" example:"tower-admin"`
+	// CloudStatus is the connection state reported by the Unraid API, e.g.
+	// CONNECTED, CONNECTING, ERROR_RETRYING or PRE_INIT.
+	CloudStatus    string              `json:"cloud_status,omitempty" example:"CONNECTED"`
+	CloudConnected bool                `json:"cloud_connected" example:"true"`
+	FlashBackup    ConnectFlashBackup  `json:"flash_backup"`
+	RemoteAccess   ConnectRemoteAccess `json:"remote_access"`
+	Timestamp      time.Time           `json:"timestamp"`
+}
+
+// ConnectFlashBackup reports Unraid Connect flash backup.
+type ConnectFlashBackup struct {
+	Activated bool `json:"activated" example:"true"`
+	// UpToDate is true when the last flash backup holds every configuration
+	// change; nil when the plugin has not reported a status yet.
+	UpToDate *bool  `json:"up_to_date,omitempty" example:"true"`
+	Error    string `json:"error,omitempty"`
+	// LastSync is when the flash configuration was last pushed to the cloud.
+	LastSync *time.Time `json:"last_sync,omitempty"`
+}
+
+// ConnectRemoteAccess reports the Unraid Connect remote access settings.
+type ConnectRemoteAccess struct {
+	Enabled bool `json:"enabled" example:"true"`
+	// Type is "static" (port forward), "upnp" or "disabled"; dynamic remote
+	// access reports "dynamic_static" or "dynamic_upnp".
+	Type        string `json:"type" example:"static"`
+	WANPort     int    `json:"wan_port,omitempty" example:"33443"`
+	UPnPEnabled bool   `json:"upnp_enabled" example:"false"`
+	UPnPStatus  string `json:"upnp_status,omitempty"`
+}
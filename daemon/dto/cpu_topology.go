@@ -0,0 +1,96 @@
+package dto
+
+import "time"
+
+// CPUTopology is the response for GET /system/cpu-topology: the host's
+// logical CPUs with their isolation and pinning, and the CPU assignments of
+// every VM and container.
+type CPUTopology struct {
+	CPUs []CPUThread `json:"cpus"`
+	// Isolated lists the CPUs removed from the general scheduler with the
+	// isolcpus kernel parameter, as currently active.
+	Isolated []int `json:"isolated"`
+	// IsolcpusParam is the isolcpus= value of the running kernel's command
+	// line, e.g. "4-7,12-15"; empty when not set.
+	IsolcpusParam string            `json:"isolcpus_param,omitempty" example:"4-7,12-15"`
+	VMs           []VMCPUPinning    `json:"vms"`
+	Containers    []ContainerCPUSet `json:"containers"`
+	Warnings      []string          `json:"warnings,omitempty"`
+	Timestamp     time.Time         `json:"timestamp"`
+}
+
+// CPUThread is one logical CPU with the VMs and containers pinned to it.
+type CPUThread struct {
+	CPU     int `json:"cpu" example:"4"`
+	Core    int `json:"core" example:"4"`
+	Package int `json:"package" example:"0"`
+	// Siblings lists the logical CPUs sharing this CPU's physical core
+	// (hyper-threads), including itself.
+	Siblings []int `json:"siblings"`
+	Isolated bool  `json:"isolated" example:"true"`
+	// VMs and Containers name the guests pinned to this CPU. Guests without
+	// pinning may run on any non-isolated CPU and are not listed.
+	VMs        []string `json:"vms,omitempty"`
+	Containers []string `json:"containers,omitempty"`
+}
+
+// VMCPUPinning is the vCPU pinning of a VM.
+type VMCPUPinning struct {
+	Name  string `json:"name" example:"Windows 11"`
+	State string `json:"state" example:"running"`
+	VCPUs int    `json:"vcpus" example:"4"`
+	// Pins lists the pinned vCPUs; unpinned vCPUs float over all host CPUs.
+	Pins []VCPUPin `json:"pins"`
+	// EmulatorCPUSet is the host CPU list the QEMU emulator threads are
+	// pinned to; empty when not pinned.
+	EmulatorCPUSet string `json:"emulator_cpuset,omitempty" example:"0,8"`
+}
+
+// VCPUPin pins one vCPU to a set of host CPUs.
+type VCPUPin struct {
+	VCPU   int    `json:"vcpu" example:"0"`
+	CPUSet string `json:"cpuset" example:"4,12"`
+	CPUs   []int  `json:"cpus,omitempty"`
+}
+
+// ContainerCPUSet is the cpuset of a container.
+type ContainerCPUSet struct {
+	ID    string `json:"id" example:"abc123def456"`
+	Name  string `json:"name" example:"plex"`
+	State string `json:"state" example:"running"`
+	// CPUSet is the --cpuset-cpus list; empty when the container may use
+	// every CPU.
+	CPUSet string `json:"cpuset,omitempty" example:"2-3"`
+	CPUs   []int  `json:"cpus,omitempty"`
+}
+
+// VMCPUPinningRequest is the request body for PUT /vm/{name}/cpu-pinning. It
+// replaces the VM's vCPU pinning; vCPUs that are not listed are unpinned.
+type VMCPUPinningRequest struct {
+	Pins []VCPUPin `json:"pins"`
+	// EmulatorCPUSet pins the emulator threads; empty leaves them unchanged.
+	EmulatorCPUSet string `json:"emulator_cpuset,omitempty" example:"0,8"`
+	// Confirm must be true; a running VM is re-pinned immediately.
+	Confirm bool `json:"confirm"`
+}
+
+// ContainerCPUSetRequest is the request body for PUT /docker/{id}/cpuset.
+type ContainerCPUSetRequest struct {
+	// CPUSet is the CPU list, e.g. "2-3,10-11"; empty allows every CPU.
+	CPUSet string `json:"cpuset" example:"2-3"`
+	// Confirm must be true; a running container is re-pinned immediately.
+	Confirm bool `json:"confirm"`
+}
+
+// CPUPinningResult is the response for a VM pinning or container cpuset change.
+type CPUPinningResult struct {
+	Name string `json:"name" example:"plex"`
+	// Live is true when the change was applied to the running VM or container.
+	Live bool `json:"live" example:"true"`
+	// Persisted is true when the change was saved in the VM definition or
+	// the container's Unraid template, so it survives a restart or recreation.
+	Persisted bool      `json:"persisted" example:"true"`
+	Message   string    `json:"message" example:"CPU pinning updated"`
+	Warnings  []string  `json:"warnings,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
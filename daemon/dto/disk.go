@@ -20,13 +20,47 @@ type DiskInfo struct {
 	// Disk identification
 	SerialNumber string `json:"serial_number,omitempty" example:"WD-WMC4N0123456"`
 	Model        string `json:"model,omitempty" example:"WDC WD120EFBX-68B0EN0"`
-	Role         string `json:"role,omitempty" example:"data"`         // "parity", "parity2", "data", "cache", "pool"
+	Role         string `json:"role,omitempty" example:"data"`         // "parity", "parity2", "data", "cache", "pool", "flash", "unassigned"
 	SpinState    string `json:"spin_state,omitempty" example:"active"` // "active", "standby", "unknown"
 
+	// Array layout. Slot is the Unraid slot index from disks.ini (0 parity,
+	// 1-28 data disks, 29 parity 2, pool devices after them); it is nil for
+	// unassigned devices. Pool names the pool a cache or pool device is a
+	// member of.
+	Slot *int   `json:"slot,omitempty" example:"1"`
+	Pool string `json:"pool,omitempty" example:"cache"`
+
+	// Controller is the PCI address of the storage controller the disk is
+	// attached to, and Port its sysfs port name on it: "ata3" (SATA),
+	// "port-2:0" (SAS), "nvme0", "1-4" (USB) or a SCSI address.
+	Controller string `json:"controller,omitempty" example:"0000:00:17.0"`
+	Port       string `json:"port,omitempty" example:"ata3"`
+
+	// LUKS encryption. EncryptionState is one of the Encryption* constants;
+	// it is empty for unencrypted disks and while Unraid has not opened an
+	// encrypted one yet.
+	Encrypted       bool   `json:"encrypted,omitempty" example:"true"`
+	EncryptionState string `json:"encryption_state,omitempty" example:"unlocked"`
+
 	// Enhanced SMART attributes
 	SMARTAttributes map[string]SMARTAttribute `json:"smart_attributes,omitempty"`
 	PowerOnHours    uint64                    `json:"power_on_hours,omitempty" example:"25000"`
 	PowerCycleCount uint64                    `json:"power_cycle_count,omitempty" example:"100"`
+	// SMARTCollectedAt is when smartctl last answered for the disk. SMART
+	// data is cached and refreshed less often than the disk list, and not at
+	// all while the disk is spun down.
+	SMARTCollectedAt *time.Time `json:"smart_collected_at,omitempty"`
+
+	// SSD endurance (SSDs only). WearPercent is the share of the rated
+	// endurance used: NVMe "Percentage Used", or 100 minus the normalized
+	// value of the ATA wear attribute (231, 233, 177 or 202). NVMe drives may
+	// report more than 100 once the rating is exceeded.
+	WearPercent       *int   `json:"wear_percent,omitempty" example:"3"`
+	TotalBytesWritten uint64 `json:"total_bytes_written,omitempty" example:"6320000000000"`
+
+	// LastTrim is when the filesystem on this disk was last trimmed through
+	// the agent.
+	LastTrim *time.Time `json:"last_trim,omitempty"`
 
 	// I/O Statistics
 	ReadBytes     uint64  `json:"read_bytes,omitempty" example:"1073741824"`
@@ -44,6 +78,11 @@ type DiskInfo struct {
 	TempWarning  *int `json:"temp_warning_celsius,omitempty" example:"50"`  // Per-disk warning threshold override
 	TempCritical *int `json:"temp_critical_celsius,omitempty" example:"60"` // Per-disk critical threshold override
 
+	// Partial is true when the device did not answer in time (e.g. smartctl
+	// hung on it), so SMART, usage and I/O fields only hold what disks.ini
+	// reports.
+	Partial bool `json:"partial,omitempty"`
+
 	// SourceStatus is non-nil only when the backing data source is not healthy.
 	SourceStatus *SourceStatus `json:"source_status,omitempty"`
 
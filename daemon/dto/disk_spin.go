@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"strings"
+	"time"
+)
+
+// Sources of a spin-up cause
+const (
+	// SpinUpCauseOpenFile is a file held open on the disk (or on a user share
+	// path stored on the disk) when the spin-up was seen.
+	SpinUpCauseOpenFile = "open_file"
+	// SpinUpCauseFatrace is a file access on the disk reported by fatrace in
+	// the seconds after the spin-up.
+	SpinUpCauseFatrace = "fatrace"
+)
+
+// DiskSpinEvent is one spin state transition of a disk. It is broadcast on
+// the WebSocket topic "disk_spin_event"; for spin-ups it is sent once the
+// cause attribution has finished.
+type DiskSpinEvent struct {
+	Disk          string `json:"disk" example:"disk3"`
+	Name          string `json:"name" example:"Disk 3"`
+	Device        string `json:"device" example:"sdd"`
+	State         string `json:"state" example:"active"`           // "active" (spin-up) or "standby" (spin-down)
+	PreviousState string `json:"previous_state" example:"standby"` // State before the transition
+	// PreviousSeconds is how long the disk stayed in the previous state.
+	PreviousSeconds int64             `json:"previous_seconds" example:"5400"`
+	Causes          []DiskSpinUpCause `json:"causes,omitempty"` // Spin-ups only, best effort
+	Timestamp       time.Time         `json:"timestamp"`
+}
+
+// DiskSpinUpCause is a process that was accessing a disk around a spin-up.
+type DiskSpinUpCause struct {
+	Process string `json:"process" example:"Plex Media Serv"`
+	PID     int    `json:"pid" example:"4121"`
+	Path    string `json:"path" example:"/mnt/user/Media/Movies/Heat (1995)/Heat.mkv"`
+	Source  string `json:"source" example:"open_file"` // "open_file" or "fatrace"
+}
+
+// DiskSpinStats summarizes the spin state of one disk over the history window.
+type DiskSpinStats struct {
+	Disk       string     `json:"disk" example:"disk3"`
+	Name       string     `json:"name" example:"Disk 3"`
+	Device     string     `json:"device" example:"sdd"`
+	State      string     `json:"state" example:"standby"` // Current state: "active" or "standby"
+	Since      time.Time  `json:"since"`                   // When the disk entered the current state (or was first seen in it)
+	SpinUps    int        `json:"spin_ups" example:"4"`    // Spin-ups in the window
+	SpinDowns  int        `json:"spin_downs" example:"4"`  // Spin-downs in the window
+	LastSpinUp *time.Time `json:"last_spin_up,omitempty"`
+}
+
+// DiskSpinHistory is the spin state history of every array and pool disk.
+type DiskSpinHistory struct {
+	WindowSeconds int64           `json:"window_seconds" example:"86400"`
+	Disks         []DiskSpinStats `json:"disks"`
+	Events        []DiskSpinEvent `json:"events"` // Recent transitions, oldest first
+	Timestamp     time.Time       `json:"timestamp"`
+}
+
+// ForDisk returns a copy of h with only the stats and events of the disk with
+// the given ID or name (case-insensitive).
+func (h *DiskSpinHistory) ForDisk(disk string) *DiskSpinHistory {
+	match := func(id, name string) bool {
+		return strings.EqualFold(id, disk) || strings.EqualFold(name, disk)
+	}
+	filtered := &DiskSpinHistory{
+		WindowSeconds: h.WindowSeconds,
+		Disks:         []DiskSpinStats{},
+		Events:        []DiskSpinEvent{},
+		Timestamp:     h.Timestamp,
+	}
+	for _, d := range h.Disks {
+		if match(d.Disk, d.Name) {
+			filtered.Disks = append(filtered.Disks, d)
+		}
+	}
+	for _, e := range h.Events {
+		if match(e.Disk, e.Name) {
+			filtered.Events = append(filtered.Events, e)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// DNSCheckScope values for DNSResolverCheck.Scope.
+const (
+	DNSScopeHost          = "host"
+	DNSScopeDockerGateway = "docker_gateway"
+)
+
+// DNSResolverCheck is the result of a single test query against one resolver.
+// @Description Outcome of resolving the probe name through one resolver from the host, optionally sourced from a Docker bridge gateway address.
+type DNSResolverCheck struct {
+	// Scope is "host" for queries from the host's default route, or
+	// "docker_gateway" for host queries sourced from a Docker bridge network's
+	// gateway address. Neither runs inside a container's network namespace.
+	Scope string `json:"scope" example:"host"`
+	// Network is the Docker network name (docker_gateway scope only).
+	Network string `json:"network,omitempty" example:"proxynet"`
+	// Server is the resolver that was queried (ip:port).
+	Server string `json:"server" example:"192.168.1.1:53"`
+	// Query is the name that was resolved.
+	Query string `json:"query" example:"cloudflare.com"`
+	// Success is true when the resolver answered with NOERROR and at least one address.
+	Success bool `json:"success"`
+	// ResponseTimeMs is the round-trip time of the query in milliseconds.
+	ResponseTimeMs float64 `json:"response_time_ms" example:"12.4"`
+	// Rcode is the DNS response code (e.g. "NOERROR", "SERVFAIL", "NXDOMAIN").
+	Rcode string `json:"rcode,omitempty" example:"NOERROR"`
+	// Addresses are the A records returned.
+	Addresses []string `json:"addresses,omitempty"`
+	// DNSSECValidated is true when the resolver set the AD (authenticated data) flag.
+	DNSSECValidated bool `json:"dnssec_validated"`
+	// Error describes why the query failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// DNSHealth is the envelope published on TopicDNSHealthUpdate and served by
+// GET /api/v1/network/dns.
+// @Description DNS resolver configuration and health as seen from the host, including queries sourced from Docker bridge gateway addresses.
+type DNSHealth struct {
+	// Healthy is true when at least one host resolver works and every checked
+	// Docker bridge gateway can reach at least one upstream resolver.
+	Healthy bool `json:"healthy"`
+	// Resolvers are the nameservers configured in /etc/resolv.conf.
+	Resolvers []string `json:"resolvers"`
+	// SearchDomains are the search domains configured in /etc/resolv.conf.
+	SearchDomains []string `json:"search_domains,omitempty"`
+	// DNSSECValidating is true when at least one working host resolver validates DNSSEC.
+	DNSSECValidating bool `json:"dnssec_validating"`
+	// AvgResponseTimeMs is the mean response time of successful host queries.
+	AvgResponseTimeMs float64 `json:"avg_response_time_ms" example:"14.2"`
+	// Checks holds every individual query result (host first, then per network).
+	Checks []DNSResolverCheck `json:"checks"`
+	// UncheckedNetworks lists external Docker networks that were not tested
+	// because their traffic does not pass through a host bridge gateway
+	// (macvlan/ipvlan, e.g. Unraid's br0) or because their gateway is not IPv4.
+	UncheckedNetworks []string `json:"unchecked_networks,omitempty" example:"br0"`
+	// Issues lists human-readable problems detected during the run.
+	Issues []string `json:"issues,omitempty"`
+	// Timestamp is when this status was collected.
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -9,6 +9,15 @@ const (
 	UpdateStatusUnknown   = "unknown"
 )
 
+// Container health values for ContainerInfo.Health, as reported by the
+// image's Docker HEALTHCHECK.
+const (
+	ContainerHealthNone      = "none"
+	ContainerHealthStarting  = "starting"
+	ContainerHealthHealthy   = "healthy"
+	ContainerHealthUnhealthy = "unhealthy"
+)
+
 // ContainerInfo contains Docker container information
 type ContainerInfo struct {
 	ID                   string          `json:"id" example:"abc123def456"`
@@ -36,11 +45,22 @@ type ContainerInfo struct {
 	RestartPolicy        string          `json:"restart_policy" example:"unless-stopped"`
 	Uptime               string          `json:"uptime" example:"2 days"`
 	RestartCount         int             `json:"restart_count" example:"0"`
+	// Group is the folder or compose project the container belongs to, read
+	// from its folder-view or com.docker.compose.project label.
+	Group string `json:"group,omitempty" example:"media"`
+	// Docker HEALTHCHECK status — see ContainerHealth* constants ("none" when the image has no healthcheck).
+	Health              string `json:"health" example:"healthy"`
+	HealthFailingStreak int    `json:"health_failing_streak,omitempty" example:"0"` // consecutive failed health probes
 	// Update status — populated by merging the DockerUpdate collector's cache at read time.
 	UpdateStatus    string     `json:"update_status" example:"up_to_date"` // see UpdateStatus* constants
 	UpdateAvailable *bool      `json:"update_available,omitempty"`         // null when not yet checked / registry unreachable (field omitted in JSON)
 	UpdateChecked   *time.Time `json:"update_checked,omitempty"`
-	Timestamp       time.Time  `json:"timestamp"`
+	// Service probes — populated from watchdog health checks linked to this container at read time.
+	HealthProbes []HealthCheckStatus `json:"health_probes,omitempty"`
+	// HostInterfaces lists the host-side veth interfaces of the container's
+	// bridge networks with their traffic, from the guest traffic collector.
+	HostInterfaces []GuestInterfaceTraffic `json:"host_interfaces,omitempty"`
+	Timestamp      time.Time               `json:"timestamp"`
 
 	SourceStatus *SourceStatus `json:"source_status,omitempty"`
 }
@@ -127,6 +147,23 @@ type DockerAggregateStats struct {
 	Timestamp          time.Time `json:"timestamp"`
 }
 
+// ContainerUsage is the resource usage of one container in a ranking.
+type ContainerUsage struct {
+	ID            string  `json:"id" example:"abc123def456"`
+	Name          string  `json:"name" example:"plex"`
+	CPUPercent    float64 `json:"cpu_percent" example:"5.2"`
+	MemoryUsage   uint64  `json:"memory_usage_bytes" example:"1073741824"`
+	MemoryUsageMB float64 `json:"memory_usage_mb" example:"1024.0"`
+}
+
+// DockerStatsSummary is the aggregate usage of the running containers with
+// their largest CPU and memory consumers, computed by the docker collector.
+type DockerStatsSummary struct {
+	DockerAggregateStats
+	TopCPU    []ContainerUsage `json:"top_cpu"`
+	TopMemory []ContainerUsage `json:"top_memory"`
+}
+
 // ContainerBulkUpdateResult contains results of updating multiple containers
 type ContainerBulkUpdateResult struct {
 	Results   []ContainerUpdateResult `json:"results"`
@@ -149,6 +186,78 @@ type ContainerAutostartRequest struct {
 	Enabled bool `json:"enabled"`
 }
 
+// ContainerAutostartEntry is one container in the Unraid autostart configuration.
+type ContainerAutostartEntry struct {
+	Name string `json:"name" example:"plex"`
+	// Enabled reports whether the container is started when the array starts.
+	Enabled bool `json:"enabled" example:"true"`
+	// Order is the 1-based start position; 0 when autostart is disabled.
+	Order int `json:"order" example:"1"`
+	// Wait is the number of seconds Unraid waits after starting this container
+	// before starting the next one.
+	Wait int `json:"wait" example:"30"`
+}
+
+// DockerAutostart is the response for GET /docker/autostart: autostarted
+// containers in start order, followed by the remaining containers.
+type DockerAutostart struct {
+	Containers []ContainerAutostartEntry `json:"containers"`
+	Timestamp  time.Time                 `json:"timestamp"`
+}
+
+// ContainerAutostartRequestEntry is one container in a DockerAutostartRequest.
+type ContainerAutostartRequestEntry struct {
+	// Name is the container name (not ID), as used by Unraid.
+	Name string `json:"name" example:"plex"`
+	// Wait is the delay in seconds before the next container is started (0-3600).
+	Wait int `json:"wait,omitempty" example:"30"`
+}
+
+// DockerAutostartRequest is the request body for PUT /docker/autostart. It
+// replaces the whole autostart list: the listed containers are started in the
+// given order and every other container has autostart disabled.
+type DockerAutostartRequest struct {
+	// Containers is required; an empty list disables autostart for all containers.
+	Containers []ContainerAutostartRequestEntry `json:"containers"`
+}
+
+// ContainerLimitsRequest is the request body for PATCH /docker/{id}/limits.
+// Only the fields that are set are changed; omitted fields keep their current
+// value. Like `docker update`, the change applies to the running container and
+// is lost when Unraid recreates it from its template.
+type ContainerLimitsRequest struct {
+	// CPUShares is the relative CPU weight (default 1024, minimum 2).
+	CPUShares *int64 `json:"cpu_shares,omitempty" example:"512"`
+	// CPUQuota is the CPU time in microseconds the container may use per
+	// CPUPeriod; -1 removes the quota.
+	CPUQuota *int64 `json:"cpu_quota,omitempty" example:"50000"`
+	// CPUPeriod is the CFS scheduler period in microseconds (1000-1000000).
+	CPUPeriod *int64 `json:"cpu_period,omitempty" example:"100000"`
+	// MemoryBytes is the hard memory limit (minimum 6 MiB).
+	MemoryBytes *int64 `json:"memory_bytes,omitempty" example:"2147483648"`
+	// MemorySwapBytes is the memory plus swap limit; -1 allows unlimited swap.
+	MemorySwapBytes *int64 `json:"memory_swap_bytes,omitempty" example:"4294967296"`
+	// RestartPolicy is one of no, always, unless-stopped or on-failure.
+	RestartPolicy *string `json:"restart_policy,omitempty" example:"unless-stopped"`
+	// RestartMaxRetries limits restarts for the on-failure policy.
+	RestartMaxRetries *int `json:"restart_max_retries,omitempty" example:"5"`
+}
+
+// ContainerLimits is the resource limits and restart policy of a container.
+type ContainerLimits struct {
+	ContainerID       string    `json:"container_id" example:"abc123def456"`
+	ContainerName     string    `json:"container_name" example:"plex"`
+	CPUShares         int64     `json:"cpu_shares" example:"512"`
+	CPUQuota          int64     `json:"cpu_quota" example:"50000"`
+	CPUPeriod         int64     `json:"cpu_period" example:"100000"`
+	MemoryBytes       int64     `json:"memory_bytes" example:"2147483648"`
+	MemorySwapBytes   int64     `json:"memory_swap_bytes" example:"4294967296"`
+	RestartPolicy     string    `json:"restart_policy" example:"unless-stopped"`
+	RestartMaxRetries int       `json:"restart_max_retries" example:"0"`
+	Warnings          []string  `json:"warnings,omitempty"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
 // PortConflict reports a host port bound by more than one container.
 type PortConflict struct {
 	HostPort   int      `json:"host_port"`
@@ -165,3 +274,45 @@ type ContainerLogs struct {
 	Since         string    `json:"since,omitempty" example:"2026-02-17T00:00:00Z"`
 	Timestamp     time.Time `json:"timestamp"`
 }
+
+// DockerGroup is a folder or compose project whose containers are handled as
+// a unit, with their aggregate resource usage.
+type DockerGroup struct {
+	Name string `json:"name" example:"media"`
+	// Containers lists the member container names, sorted.
+	Containers    []string `json:"containers"`
+	Running       int      `json:"running" example:"3"`
+	Total         int      `json:"total" example:"4"`
+	CPUPercent    float64  `json:"cpu_percent" example:"7.5"`
+	MemoryUsage   uint64   `json:"memory_usage_bytes" example:"2147483648"`
+	MemoryUsageMB float64  `json:"memory_usage_mb" example:"2048.0"`
+}
+
+// DockerGroups is the response for GET /docker/groups.
+type DockerGroups struct {
+	Groups []DockerGroup `json:"groups"`
+	// Ungrouped lists the containers without a group label.
+	Ungrouped []string  `json:"ungrouped"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DockerGroupContainerResult is the outcome of a group action for one container.
+type DockerGroupContainerResult struct {
+	Name      string `json:"name" example:"sonarr"`
+	Succeeded bool   `json:"succeeded" example:"true"`
+	// Skipped is true when the container was already in the requested state.
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// DockerGroupActionResult is the response for POST /docker/groups/{name}/start
+// and /stop.
+type DockerGroupActionResult struct {
+	Group     string                       `json:"group" example:"media"`
+	Action    string                       `json:"action" example:"start"`
+	Results   []DockerGroupContainerResult `json:"results"`
+	Succeeded int                          `json:"succeeded" example:"3"`
+	Failed    int                          `json:"failed" example:"0"`
+	Skipped   int                          `json:"skipped" example:"1"`
+	Timestamp time.Time                    `json:"timestamp"`
+}
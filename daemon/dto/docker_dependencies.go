@@ -0,0 +1,92 @@
+package dto
+
+import "time"
+
+// Volume location kinds for VolumeDependency.Kind.
+const (
+	VolumeKindShare      = "share"      // /mnt/user/<share> or /mnt/user0/<share>
+	VolumeKindDisk       = "disk"       // /mnt/diskN
+	VolumeKindPool       = "pool"       // /mnt/<pool>
+	VolumeKindUnassigned = "unassigned" // /mnt/disks (Unassigned Devices)
+	VolumeKindRemote     = "remote"     // /mnt/remotes, /mnt/rootshare
+	VolumeKindFlash      = "flash"      // /boot
+	VolumeKindSystem     = "system"     // Anything else: /var/run, /dev, /tmp, ...
+)
+
+// StorageArray is the storage name used for the parity-protected array in
+// VolumeDependency.Storage and StorageDependents.Storage.
+const StorageArray = "array"
+
+// VolumeDependency is one container bind mount and where its data lives.
+type VolumeDependency struct {
+	HostPath      string `json:"host_path" example:"/mnt/user/appdata/plex"`
+	ContainerPath string `json:"container_path" example:"/config"`
+	Mode          string `json:"mode,omitempty" example:"rw"`
+	Kind          string `json:"kind" example:"share"` // See VolumeKind* constants
+	Share         string `json:"share,omitempty" example:"appdata"`
+	// Storage is where the data can be: "array", a pool name or a disk ID.
+	// For a user share it follows the share's primary and secondary storage.
+	Storage []string `json:"storage,omitempty" example:"cache"`
+	// RequiresArray is true when the path is unavailable while the array is
+	// stopped (user shares, array disks and pools).
+	RequiresArray bool `json:"requires_array" example:"true"`
+}
+
+// ContainerDependencies lists the shares and storage a container's volumes
+// reside on.
+type ContainerDependencies struct {
+	ID            string             `json:"id" example:"abc123def456"`
+	Name          string             `json:"name" example:"plex"`
+	State         string             `json:"state" example:"running"`
+	RequiresArray bool               `json:"requires_array" example:"true"` // Any volume requires the array
+	Shares        []string           `json:"shares" example:"appdata,Media"`
+	Storage       []string           `json:"storage" example:"cache,array"`
+	Volumes       []VolumeDependency `json:"volumes"`
+}
+
+// ShareDependents lists the containers with a volume on a user share.
+type ShareDependents struct {
+	Share      string   `json:"share" example:"Media"`
+	Containers []string `json:"containers" example:"plex,sonarr"`
+}
+
+// StorageDependents lists the containers with data on the array, a pool or a
+// single array disk.
+type StorageDependents struct {
+	Storage    string   `json:"storage" example:"cache"`
+	Containers []string `json:"containers" example:"plex,sonarr"`
+}
+
+// DockerDependencies maps containers to the shares and storage their volumes
+// reside on, and each share and storage location back to its containers.
+type DockerDependencies struct {
+	Containers []ContainerDependencies `json:"containers"`
+	Shares     []ShareDependents       `json:"shares"`
+	Storage    []StorageDependents     `json:"storage"`
+	Timestamp  time.Time               `json:"timestamp"`
+}
+
+// VMArrayDependency is a VM and whether its primary vdisk is on array-backed
+// storage.
+type VMArrayDependency struct {
+	Name          string `json:"name" example:"Windows 11"`
+	State         string `json:"state" example:"running"`
+	DiskPath      string `json:"disk_path,omitempty" example:"/mnt/user/domains/Windows 11/vdisk1.img"`
+	RequiresArray bool   `json:"requires_array" example:"true"`
+}
+
+// ArrayStopImpact describes what stops or becomes unavailable when the array
+// is stopped.
+type ArrayStopImpact struct {
+	ArrayState string `json:"array_state" example:"Started"`
+	// Containers are the running containers; Unraid stops the Docker service
+	// with the array, so all of them stop.
+	Containers []ContainerDependencies `json:"containers"`
+	// VMs are the running VMs; the VM service stops with the array.
+	VMs []VMArrayDependency `json:"vms"`
+	// Shares are the SMB/NFS exported user shares that go offline.
+	Shares []string `json:"shares" example:"Media,appdata"`
+	// Notes explain the impact in plain language.
+	Notes     []string  `json:"notes"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -25,3 +25,33 @@ type DockerNetworkList struct {
 	Count     int                 `json:"count"`
 	Timestamp time.Time           `json:"timestamp"`
 }
+
+// DockerNetworkCreateRequest is the request body for POST /docker/networks.
+// It mirrors the custom network options of the Unraid Docker settings page:
+// macvlan and ipvlan networks put containers directly on a LAN or VLAN, using
+// a parent interface such as br0 or a VLAN sub-interface such as br0.20.
+type DockerNetworkCreateRequest struct {
+	Name string `json:"name" example:"iot"`
+	// Driver is bridge, macvlan or ipvlan.
+	Driver string `json:"driver" example:"macvlan"`
+	// Parent is the host interface macvlan/ipvlan traffic leaves through.
+	// A dotted VLAN sub-interface (br0.20) is created by Docker if missing.
+	Parent  string `json:"parent,omitempty" example:"br0.20"`
+	Subnet  string `json:"subnet,omitempty" example:"192.168.20.0/24"`
+	Gateway string `json:"gateway,omitempty" example:"192.168.20.1"`
+	// IPRange limits the addresses Docker assigns to a part of the subnet,
+	// so containers do not collide with the router's DHCP pool.
+	IPRange string `json:"ip_range,omitempty" example:"192.168.20.128/25"`
+	// IPVlanMode is l2 (default), l3 or l3s; only valid for ipvlan.
+	IPVlanMode string `json:"ipvlan_mode,omitempty" example:"l2"`
+	Internal   bool   `json:"internal,omitempty"`
+	Attachable bool   `json:"attachable,omitempty"`
+}
+
+// DockerNetworkCreateResult is the response of POST /docker/networks.
+type DockerNetworkCreateResult struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name" example:"iot"`
+	Warnings  []string  `json:"warnings,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+// Directory analysis job states.
+const (
+	AnalyzeJobRunning   = "running"
+	AnalyzeJobCompleted = "completed"
+	AnalyzeJobFailed    = "failed"
+	AnalyzeJobCancelled = "cancelled"
+)
+
+// FilesystemAnalyzeRequest starts a directory size analysis
+// (POST /filesystem/analyze).
+type FilesystemAnalyzeRequest struct {
+	Path  string `json:"path" example:"/mnt/cache/appdata"`
+	Depth int    `json:"depth,omitempty" example:"2"`  // Levels of children to report (1-3, default 1)
+	Limit int    `json:"limit,omitempty" example:"20"` // Largest children kept per directory (1-100, default 20)
+}
+
+// DirectorySize is the allocated size of a directory tree, or of a single
+// file when it appears among a directory's largest children.
+type DirectorySize struct {
+	Name     string          `json:"name" example:"plex"`
+	Path     string          `json:"path" example:"/mnt/cache/appdata/plex"`
+	Type     string          `json:"type" example:"dir"` // "dir" or "file"
+	Bytes    uint64          `json:"bytes" example:"42949672960"`
+	Files    uint64          `json:"files" example:"181234"`
+	Dirs     uint64          `json:"dirs,omitempty" example:"9120"`
+	Children []DirectorySize `json:"children,omitempty"`
+
+	// OmittedChildren and OmittedBytes summarize the children beyond the
+	// requested limit.
+	OmittedChildren int    `json:"omitted_children,omitempty" example:"57"`
+	OmittedBytes    uint64 `json:"omitted_bytes,omitempty" example:"1073741824"`
+}
+
+// FilesystemAnalyzeJob is the state of an asynchronous directory size
+// analysis.
+type FilesystemAnalyzeJob struct {
+	ID           string         `json:"id" example:"9f2c4e1ab07d3356"`
+	Path         string         `json:"path" example:"/mnt/cache/appdata"`
+	Depth        int            `json:"depth" example:"2"`
+	Limit        int            `json:"limit" example:"20"`
+	State        string         `json:"state" example:"running"` // "running", "completed", "failed", "cancelled"
+	ScannedFiles uint64         `json:"scanned_files" example:"52011"`
+	ScannedBytes uint64         `json:"scanned_bytes" example:"18253611008"`
+	SkippedDirs  uint64         `json:"skipped_dirs,omitempty" example:"0"` // Directories that could not be read
+	Error        string         `json:"error,omitempty"`
+	Result       *DirectorySize `json:"result,omitempty"`
+	StartedAt    time.Time      `json:"started_at"`
+	FinishedAt   *time.Time     `json:"finished_at,omitempty"`
+}
+
+// FileEntry describes a file, directory or symlink within a share. Symlinks
+// are reported as links and not followed.
+type FileEntry struct {
+	Name      string    `json:"name" example:"config.xml"`
+	Path      string    `json:"path" example:"/mnt/user/appdata/sonarr/config.xml"`
+	Type      string    `json:"type" example:"file"` // "file", "dir", "symlink" or "other"
+	SizeBytes int64     `json:"size_bytes" example:"2048"`
+	Mode      string    `json:"mode" example:"-rw-r--r--"`
+	ModTime   time.Time `json:"mod_time"`
+}
+
+// DirectoryListing is one page of a directory's entries, directories first
+// and then by name.
+type DirectoryListing struct {
+	Path    string      `json:"path" example:"/mnt/user/appdata/sonarr"`
+	Entries []FileEntry `json:"entries"`
+	Total   int         `json:"total" example:"12"` // Entries in the directory
+	Offset  int         `json:"offset" example:"0"`
+	Limit   int         `json:"limit" example:"1000"`
+}
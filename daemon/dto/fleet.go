@@ -0,0 +1,103 @@
+package dto
+
+import "time"
+
+// FleetPeer is another Unraid Management Agent registered with this one.
+type FleetPeer struct {
+	// Name identifies the peer in /fleet/{server} URLs.
+	Name string `json:"name" example:"backup-nas"`
+	// URL is the peer agent's base URL, e.g. http://192.168.1.20:8043, with
+	// the path prefix of a reverse proxy if there is one. It may be empty
+	// for a peer that is only reachable over SSH.
+	URL string `json:"url,omitempty" example:"http://192.168.1.20:8043"`
+	// APIKey, if set, is sent to the peer as a bearer token. It is never
+	// returned by the API.
+	APIKey string `json:"api_key,omitempty"`
+	// SSH, if set, lets the agent run the relay commands on the peer with
+	// the fleet SSH key, e.g. for servers that do not run the agent yet.
+	SSH *FleetPeerSSH `json:"ssh,omitempty"`
+	// MACAddress is the peer's NIC for the "wake" relay command.
+	MACAddress string    `json:"mac_address,omitempty" example:"a8:a1:59:12:34:56"`
+	AddedAt    time.Time `json:"added_at"`
+}
+
+// FleetPeerSSH is how the agent logs in to a peer over SSH.
+type FleetPeerSSH struct {
+	Host string `json:"host" example:"192.168.1.20"`
+	// Port defaults to 22.
+	Port int `json:"port,omitempty" example:"22"`
+	// User defaults to root.
+	User string `json:"user,omitempty" example:"root"`
+}
+
+// FleetPeersConfig is the on-disk peer list.
+type FleetPeersConfig struct {
+	Peers []FleetPeer `json:"peers"`
+}
+
+// FleetMember summarises one server in the fleet overview.
+type FleetMember struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+	// Via is "agent" when the status came from the peer's agent and "ssh"
+	// when it came from the status relay command.
+	Via          string   `json:"via,omitempty" example:"agent"`
+	Local        bool     `json:"local"`
+	Reachable    bool     `json:"reachable"`
+	Error        string   `json:"error,omitempty"`
+	LatencyMs    float64  `json:"latency_ms,omitempty"`
+	Hostname     string   `json:"hostname,omitempty"`
+	UnraidVer    string   `json:"unraid_version,omitempty"`
+	AgentVersion string   `json:"agent_version,omitempty"`
+	Uptime       int64    `json:"uptime_seconds,omitempty"`
+	CPUUsage     *float64 `json:"cpu_usage_percent,omitempty"`
+	RAMUsage     *float64 `json:"ram_usage_percent,omitempty"`
+	ArrayState   string   `json:"array_state,omitempty"`
+	ArrayUsed    *float64 `json:"array_used_percent,omitempty"`
+}
+
+// FleetOverview is the aggregated view across this server and its peers.
+type FleetOverview struct {
+	Members   []FleetMember `json:"members"`
+	Total     int           `json:"total"`
+	Reachable int           `json:"reachable"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// FleetSSHKey is the public half of the key the agent uses to log in to
+// peers. Add PublicKey to /root/.ssh/authorized_keys on each peer.
+type FleetSSHKey struct {
+	PublicKey   string    `json:"public_key" example:"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI... unraid-management-agent@tower"`
+	Fingerprint string    `json:"fingerprint" example:"SHA256:q3X4s1mN7cT5bYc0uWzV2kLrJ9aEfGhD8oPiQxRtUyA"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FleetSSHKeyRequest is the request body for POST /fleet/ssh-key.
+type FleetSSHKeyRequest struct {
+	// PrivateKey is an unencrypted OpenSSH or PEM private key to import.
+	// When empty, a new ed25519 key is generated.
+	PrivateKey string `json:"private_key,omitempty"`
+}
+
+// FleetRelayRequest is the request body for POST /fleet/peers/{name}/relay.
+type FleetRelayRequest struct {
+	// Command is one of "status", "wake", "reboot" or "shutdown".
+	Command string `json:"command" example:"status"`
+	// Confirm must be true for "reboot" and "shutdown".
+	Confirm bool `json:"confirm"`
+}
+
+// FleetRelayResult is the outcome of a relay command on a peer.
+type FleetRelayResult struct {
+	Peer    string `json:"peer" example:"backup-nas"`
+	Command string `json:"command" example:"status"`
+	Success bool   `json:"success"`
+	// Output is the combined output of the remote command, truncated to 64 KiB.
+	Output   string `json:"output,omitempty"`
+	ExitCode int    `json:"exit_code"`
+	Error    string `json:"error,omitempty"`
+	// Status is the parsed result of the "status" command.
+	Status     *FleetMember `json:"status,omitempty"`
+	DurationMs float64      `json:"duration_ms" example:"182.4"`
+	Timestamp  time.Time    `json:"timestamp"`
+}
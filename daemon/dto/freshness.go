@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// CacheFreshness tells consumers how old cached collector data is.
+type CacheFreshness struct {
+	// CollectedAt is when the collector last published the data.
+	CollectedAt time.Time `json:"collected_at"`
+	// Stale is true when the collector has not published for several of its
+	// intervals (e.g. it hangs on an unresponsive disk) or is disabled.
+	Stale bool `json:"stale"`
+}
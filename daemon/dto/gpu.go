@@ -2,6 +2,15 @@ package dto
 
 import "time"
 
+// GPU backends selectable with the GPU_BACKENDS setting.
+const (
+	GPUBackendAuto   = "auto"
+	GPUBackendNone   = "none"
+	GPUBackendIntel  = "intel"
+	GPUBackendNvidia = "nvidia"
+	GPUBackendAMD    = "amd"
+)
+
 // GPUMetrics contains GPU metrics
 type GPUMetrics struct {
 	Available         bool      `json:"available" example:"true"`
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// Health states of the agent and its components.
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+	HealthDown     = "down"
+	// HealthDisabled marks a component that is turned off or not present on
+	// this server; it does not affect the overall status.
+	HealthDisabled = "disabled"
+)
+
+// ComponentHealth is the state of one component the agent depends on.
+type ComponentHealth struct {
+	Name   string `json:"name" example:"docker"`
+	Status string `json:"status" example:"ok"` // ok|degraded|down|disabled
+	// Critical components being down make the agent report down (HTTP 503).
+	Critical bool `json:"critical" example:"true"`
+	// Message explains a state; left out for unauthenticated callers when
+	// AUTH_REQUIRED is set.
+	Message string `json:"message,omitempty" example:"connection refused"`
+}
+
+// HealthStatus is the response of /health: the overall status is down when
+// a critical component is down, degraded when any component is degraded or
+// a non-critical one is down, and ok otherwise.
+type HealthStatus struct {
+	Status     string            `json:"status" example:"ok"` // ok|degraded|down
+	Ready      bool              `json:"ready" example:"true"`
+	Components []ComponentHealth `json:"components"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
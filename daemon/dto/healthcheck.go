@@ -45,6 +45,10 @@ type HealthCheck struct {
 	// OnFail is the remediation action: "notify", "restart_container:<name>", or "webhook:<url>".
 	OnFail string `json:"on_fail"`
 
+	// Container optionally links the probe to a Docker container (ID or name).
+	// Linked probe results are reported on that container's ContainerInfo.
+	Container string `json:"container,omitempty"`
+
 	// Enabled determines whether this health check is active.
 	Enabled bool `json:"enabled"`
 }
@@ -63,6 +67,9 @@ type HealthCheckStatus struct {
 	// Target is the probe target.
 	Target string `json:"target"`
 
+	// Container is the Docker container (ID or name) the probe is linked to, if any.
+	Container string `json:"container,omitempty"`
+
 	// Healthy is true when the last probe succeeded.
 	Healthy bool `json:"healthy"`
 
@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// Inbound webhook actions.
+const (
+	HookActionScript           = "script"
+	HookActionContainerStart   = "container_start"
+	HookActionContainerStop    = "container_stop"
+	HookActionContainerRestart = "container_restart"
+	HookActionVMStart          = "vm_start"
+	HookActionVMStop           = "vm_stop"
+)
+
+// InboundHook maps POST /hooks/{name} to a server action, so external
+// services can trigger it with a shared secret instead of full API access.
+// @Description Inbound webhook and the action it triggers
+type InboundHook struct {
+	// Name identifies the hook in /hooks/{name} URLs.
+	Name        string `json:"name" example:"deploy-app"`
+	Description string `json:"description,omitempty" example:"Restart the app after GitHub Actions pushes a new image"`
+	// Secret authenticates callers. It is generated when left empty on
+	// create, returned only by the create call, and redacted afterwards.
+	Secret string `json:"secret,omitempty"`
+	// Action is script, container_start, container_stop, container_restart,
+	// vm_start or vm_stop.
+	Action string `json:"action" example:"container_restart"`
+	// Target is the registered script, container or VM the action applies to.
+	Target string `json:"target" example:"myapp"`
+	// Args are passed to a script action instead of its default arguments.
+	Args []string `json:"args,omitempty"`
+	// Events limits the hook to these sender events (X-GitHub-Event header or
+	// the eventType field of Sonarr/Radarr payloads); empty accepts all.
+	Events    []string  `json:"events,omitempty" example:"workflow_run,Download"`
+	Enabled   bool      `json:"enabled" example:"true"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InboundHooksConfig is the on-disk list of inbound webhooks.
+type InboundHooksConfig struct {
+	Hooks []InboundHook `json:"hooks"`
+}
+
+// InboundHookInfo is an inbound webhook with its delivery statistics since the
+// agent started.
+type InboundHookInfo struct {
+	InboundHook
+	TriggerCount    int        `json:"trigger_count" example:"3"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
+	// LastResult is "ok" or the error of the last triggered action.
+	LastResult string `json:"last_result,omitempty" example:"ok"`
+}
+
+// InboundHookCreated is returned when a webhook is created. It is the only
+// response that contains the secret.
+type InboundHookCreated struct {
+	Success bool   `json:"success" example:"true"`
+	Message string `json:"message" example:"Webhook created"`
+	// URL is the path to configure in the sending service.
+	URL       string    `json:"url" example:"/api/v1/hooks/deploy-app"`
+	Secret    string    `json:"secret" example:"3f1c9a0e5b7d2468ace013579bdf2468ace013579bdf2468ace013579bdf2468"`
+	Timestamp time.Time `json:"timestamp"`
+}
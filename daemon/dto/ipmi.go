@@ -0,0 +1,69 @@
+package dto
+
+import "time"
+
+// IPMI sensor types reported in IPMISensor.Type.
+const (
+	IPMISensorFan         = "fan"
+	IPMISensorVoltage     = "voltage"
+	IPMISensorTemperature = "temperature"
+	IPMISensorPower       = "power"
+	IPMISensorCurrent     = "current"
+	IPMISensorPowerSupply = "power_supply"
+	IPMISensorOther       = "other"
+)
+
+// IPMIStatus reports the chassis status and sensor readings of the server's
+// BMC (Supermicro, ASRock Rack, Dell iDRAC, HPE iLO, ...), read in-band with
+// ipmitool.
+type IPMIStatus struct {
+	Available bool         `json:"available" example:"true"` // ipmitool is installed and the BMC answered
+	Chassis   *IPMIChassis `json:"chassis,omitempty"`
+	Sensors   []IPMISensor `json:"sensors"`
+	// ProblemCount is the number of sensors outside their thresholds or
+	// reporting a power supply fault.
+	ProblemCount int       `json:"problem_count" example:"0"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// IPMIChassis is the output of `ipmitool chassis status`.
+type IPMIChassis struct {
+	PowerOn            bool   `json:"power_on" example:"true"`
+	PowerOverload      bool   `json:"power_overload"`
+	MainPowerFault     bool   `json:"main_power_fault"`
+	PowerControlFault  bool   `json:"power_control_fault"`
+	DriveFault         bool   `json:"drive_fault"`
+	CoolingFault       bool   `json:"cooling_fault"`
+	Intrusion          bool   `json:"intrusion"`                                          // Chassis intrusion switch is active
+	PowerRestorePolicy string `json:"power_restore_policy,omitempty" example:"always-on"` // always-on, always-off or previous
+	LastPowerEvent     string `json:"last_power_event,omitempty" example:"command"`
+}
+
+// IPMISensor is one BMC sensor. Threshold sensors (fans, voltages,
+// temperatures) have a numeric value; power supply sensors report their state
+// as text in Reading.
+type IPMISensor struct {
+	Name string `json:"name" example:"FAN1"`
+	// Type is fan, voltage, temperature, power, current, power_supply or other.
+	Type  string   `json:"type" example:"fan"`
+	Value *float64 `json:"value,omitempty" example:"1400"` // nil when the sensor has no reading
+	Unit  string   `json:"unit,omitempty" example:"RPM"`
+	// Status is ok, nc (non-critical), cr (critical), nr (non-recoverable)
+	// or ns (no reading, e.g. an empty fan header).
+	Status        string   `json:"status" example:"ok"`
+	Reading       string   `json:"reading,omitempty" example:"Presence detected"`
+	LowerCritical *float64 `json:"lower_critical,omitempty" example:"500"`
+	UpperCritical *float64 `json:"upper_critical,omitempty" example:"25400"`
+}
+
+// IPMIChassisActionRequest is the request body for POST /ipmi/chassis.
+type IPMIChassisActionRequest struct {
+	// Action is identify, soft, off, cycle or reset.
+	Action string `json:"action" example:"identify"`
+	// IdentifySeconds is how long the chassis identify LED blinks (1-255,
+	// default 15).
+	IdentifySeconds int `json:"identify_seconds,omitempty" example:"15"`
+	// Confirm must be true for the power actions, which cut power to the
+	// server without stopping the array.
+	Confirm bool `json:"confirm" example:"false"`
+}
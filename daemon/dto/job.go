@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+// JobState is the state of an asynchronous job.
+type JobState string
+
+const (
+	// JobQueued means the job waits for a free slot.
+	JobQueued JobState = "queued"
+
+	// JobRunning means the job is in progress.
+	JobRunning JobState = "running"
+
+	// JobSucceeded means the job finished without error.
+	JobSucceeded JobState = "succeeded"
+
+	// JobFailed means the job finished with an error.
+	JobFailed JobState = "failed"
+
+	// JobCancelled means the job was cancelled or the agent stopped.
+	JobCancelled JobState = "cancelled"
+)
+
+// Job kinds started by the REST API.
+const (
+	JobKindParityCheck        = "parity_check"
+	JobKindContainerUpdate    = "container_update"
+	JobKindContainerUpdateAll = "container_update_all"
+	JobKindVMHibernate        = "vm_hibernate"
+	JobKindMover              = "mover"
+	JobKindPoolTrim           = "pool_trim"
+)
+
+// Job is a long-running operation tracked in the background. Its updates are
+// broadcast on the WebSocket topic "job_update" and published to MQTT.
+type Job struct {
+	ID     string   `json:"id" example:"3f9a1c2b7d4e8f60"`
+	Kind   string   `json:"kind" example:"parity_check"`
+	Target string   `json:"target,omitempty" example:"plex"` // Container, VM or other subject of the job
+	State  JobState `json:"state" example:"running"`
+
+	Progress float64  `json:"progress" example:"42.5"` // Percent; stays 0 when the operation reports none
+	Message  string   `json:"message,omitempty" example:"parity check 42.5% complete"`
+	Logs     []string `json:"logs,omitempty"` // Last log lines, oldest first
+	Result   any      `json:"result,omitempty"`
+	Error    string   `json:"error,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Finished reports whether the job has reached a final state.
+func (j Job) Finished() bool {
+	return j.State == JobSucceeded || j.State == JobFailed || j.State == JobCancelled
+}
@@ -20,3 +20,54 @@ type LogFileContent struct {
 	StartLine     int      `json:"start_line"`
 	EndLine       int      `json:"end_line"`
 }
+
+// LogSearchQuery describes a server-side search across approved log files
+type LogSearchQuery struct {
+	Query      string   `json:"query"`
+	Regex      bool     `json:"regex"`
+	Files      []string `json:"files,omitempty"`
+	Since      string   `json:"since,omitempty"`
+	MaxResults int      `json:"max_results"`
+}
+
+// LogSearchMatch is a single matching log line
+type LogSearchMatch struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// LogSearchResult holds the matches of a log search
+type LogSearchResult struct {
+	Query        string           `json:"query"`
+	Files        []string         `json:"files"`
+	Since        *time.Time       `json:"since,omitempty"`
+	Matches      []LogSearchMatch `json:"matches"`
+	TotalMatches int              `json:"total_matches"`
+	Truncated    bool             `json:"truncated"`
+	SkippedFiles []string         `json:"skipped_files,omitempty"`
+	Timestamp    time.Time        `json:"timestamp"`
+}
+
+// AgentLogEntry is one entry of the agent's own log. Continuation lines such
+// as panic stack traces are folded into the entry's message.
+type AgentLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level" example:"warning"` // debug, info, warning or error
+	Message   string    `json:"message" example:"MQTT: connection lost, reconnecting"`
+	// CorrelationID is the request ID, job ID or collector the entry was
+	// logged for, if any.
+	CorrelationID string `json:"correlation_id,omitempty" example:"collector:docker"`
+}
+
+// AgentLog holds filtered entries of the agent's log, oldest first
+type AgentLog struct {
+	Files         []string        `json:"files"`
+	Level         string          `json:"level" example:"info"`
+	Since         *time.Time      `json:"since,omitempty"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Entries       []AgentLogEntry `json:"entries"`
+	Count         int             `json:"count"`
+	Truncated     bool            `json:"truncated"`
+	Timestamp     time.Time       `json:"timestamp"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// MaintenanceRequest starts or extends a maintenance window.
+// @Description Maintenance window to start; an active window is extended and its reason and throttle replaced
+type MaintenanceRequest struct {
+	DurationMinutes int    `json:"duration_minutes" example:"90"`              // Window length from now, 1 to 4320 (72 hours)
+	Reason          string `json:"reason,omitempty" example:"Replacing disk3"` // Shown in status and logs, up to 200 characters
+	ThrottleFactor  int    `json:"throttle_factor,omitempty" example:"4"`      // Multiply collector intervals by this factor during the window (0 or 1 = no throttling, max 10)
+}
+
+// MaintenanceStatus is the state of the maintenance window. While active,
+// alert rules, watchdog remediation, notification forwarding and Home
+// Assistant binary sensors are suppressed.
+// @Description Maintenance window state
+type MaintenanceStatus struct {
+	Active           bool       `json:"active" example:"true"`
+	Reason           string     `json:"reason,omitempty" example:"Replacing disk3"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	EndsAt           *time.Time `json:"ends_at,omitempty"`
+	RemainingSeconds int64      `json:"remaining_seconds,omitempty" example:"5400"`
+	ThrottleFactor   int        `json:"throttle_factor,omitempty" example:"4"` // Collector interval multiplier while active
+	Timestamp        time.Time  `json:"timestamp"`
+}
@@ -10,6 +10,11 @@ type MCPDiskArgs struct {
 	IncludeSmart bool   `json:"include_smart,omitempty" jsonschema:"Include SMART health data in the response"`
 }
 
+// MCPDiskSpinHistoryArgs represents arguments for the disk spin history tool.
+type MCPDiskSpinHistoryArgs struct {
+	Disk string `json:"disk,omitempty" jsonschema:"Only this disk, by ID or name (e.g. disk3); omit for all disks"`
+}
+
 // MCPContainerArgs represents arguments for container-related tools.
 type MCPContainerArgs struct {
 	ContainerID string `json:"container_id" jsonschema:"The Docker container ID or name"`
@@ -35,6 +40,41 @@ type MCPSetAutostartArgs struct {
 	Enabled     bool   `json:"enabled" jsonschema:"Set to true to enable autostart, false to disable"`
 }
 
+// MCPSetAutostartOrderArgs represents arguments for the set_container_autostart_order tool.
+type MCPSetAutostartOrderArgs struct {
+	Containers []ContainerAutostartRequestEntry `json:"containers" jsonschema:"Containers to autostart in start order, each with a name and an optional wait in seconds (0-3600) before the next container starts; an empty list disables autostart for all containers"`
+}
+
+// MCPSetContainerLimitsArgs represents arguments for the set_container_limits tool.
+type MCPSetContainerLimitsArgs struct {
+	ContainerID       string  `json:"container_id" jsonschema:"The Docker container ID or name"`
+	CPUShares         *int64  `json:"cpu_shares,omitempty" jsonschema:"Relative CPU weight (default 1024, minimum 2)"`
+	CPUQuota          *int64  `json:"cpu_quota,omitempty" jsonschema:"CPU time in microseconds per cpu_period (e.g. 50000 with period 100000 = half a core); -1 removes the quota"`
+	CPUPeriod         *int64  `json:"cpu_period,omitempty" jsonschema:"CFS scheduler period in microseconds (1000-1000000, default 100000)"`
+	MemoryBytes       *int64  `json:"memory_bytes,omitempty" jsonschema:"Hard memory limit in bytes (minimum 6 MiB)"`
+	MemorySwapBytes   *int64  `json:"memory_swap_bytes,omitempty" jsonschema:"Memory plus swap limit in bytes; -1 allows unlimited swap"`
+	RestartPolicy     *string `json:"restart_policy,omitempty" jsonschema:"Restart policy: no, always, unless-stopped or on-failure"`
+	RestartMaxRetries *int    `json:"restart_max_retries,omitempty" jsonschema:"Maximum restart attempts for the on-failure policy"`
+}
+
+// MCPCreateDockerNetworkArgs represents arguments for the create_docker_network tool.
+type MCPCreateDockerNetworkArgs struct {
+	Name       string `json:"name" jsonschema:"Network name"`
+	Driver     string `json:"driver" jsonschema:"Network driver: bridge, macvlan or ipvlan"`
+	Parent     string `json:"parent,omitempty" jsonschema:"Parent interface for macvlan/ipvlan, e.g. br0 or VLAN sub-interface br0.20 (created if missing)"`
+	Subnet     string `json:"subnet,omitempty" jsonschema:"Subnet in CIDR notation, required for macvlan/ipvlan (e.g. 192.168.20.0/24)"`
+	Gateway    string `json:"gateway,omitempty" jsonschema:"Gateway address inside the subnet"`
+	IPRange    string `json:"ip_range,omitempty" jsonschema:"Part of the subnet Docker assigns container addresses from (e.g. 192.168.20.128/25)"`
+	IPVlanMode string `json:"ipvlan_mode,omitempty" jsonschema:"ipvlan mode: l2 (default), l3 or l3s"`
+	Internal   bool   `json:"internal,omitempty" jsonschema:"Restrict external access to the network"`
+}
+
+// MCPRemoveDockerNetworkArgs represents arguments for the remove_docker_network tool.
+type MCPRemoveDockerNetworkArgs struct {
+	Network string `json:"network" jsonschema:"Network name or ID"`
+	Confirm bool   `json:"confirm,omitempty" jsonschema:"Must be set to true to confirm removing the network"`
+}
+
 // MCPVMArgs represents arguments for VM-related tools.
 type MCPVMArgs struct {
 	VMName string `json:"vm_name" jsonschema:"The virtual machine name"`
@@ -47,6 +87,13 @@ type MCPVMActionArgs struct {
 	Confirm bool   `json:"confirm,omitempty" jsonschema:"Must be set to true to confirm the reset action"`
 }
 
+// MCPVMUSBHotplugArgs represents arguments for the vm_usb_hotplug tool.
+type MCPVMUSBHotplugArgs struct {
+	VMName   string `json:"vm_name" jsonschema:"The running virtual machine name"`
+	Action   string `json:"action" jsonschema:"attach or detach"`
+	DeviceID string `json:"device_id" jsonschema:"USB device as vendor:product in hex, e.g. 046d:c52b"`
+}
+
 // MCPVMListArgs represents arguments for listing VMs.
 type MCPVMListArgs struct {
 	State string `json:"state,omitempty" jsonschema:"Filter VMs by state: running, stopped, or all (default: all)"`
@@ -68,6 +115,13 @@ type MCPSystemActionArgs struct {
 	Confirm bool `json:"confirm" jsonschema:"Must be set to true to confirm the action - prevents accidental execution"`
 }
 
+// MCPIPMIChassisActionArgs represents arguments for a BMC chassis command.
+type MCPIPMIChassisActionArgs struct {
+	Action          string `json:"action" jsonschema:"The chassis command: identify, soft, off, cycle or reset"`
+	IdentifySeconds int    `json:"identify_seconds,omitempty" jsonschema:"How long the identify LED blinks in seconds (1-255, default 15)"`
+	Confirm         bool   `json:"confirm,omitempty" jsonschema:"Must be set to true for the power actions (soft, off, cycle, reset)"`
+}
+
 // MCPArrayActionArgs represents arguments for array control actions.
 type MCPArrayActionArgs struct {
 	Action  string `json:"action" jsonschema:"The action to perform on the array: start or stop"`
@@ -85,17 +139,39 @@ type MCPLogArgs struct {
 	Lines   int    `json:"lines,omitempty" jsonschema:"Number of recent lines to retrieve (default: 100, max: 1000)"`
 }
 
+// MCPLogSearchArgs represents arguments for the log search tool.
+type MCPLogSearchArgs struct {
+	Query      string   `json:"query" jsonschema:"Text to search for (case-insensitive)"`
+	Regex      bool     `json:"regex,omitempty" jsonschema:"Treat query as a regular expression"`
+	Files      []string `json:"files,omitempty" jsonschema:"Log names or paths to search (default: all text logs)"`
+	Since      string   `json:"since,omitempty" jsonschema:"Only lines newer than a duration (e.g. 2h) or RFC3339 timestamp"`
+	MaxResults int      `json:"max_results,omitempty" jsonschema:"Maximum matching lines to return (default: 200, max: 1000)"`
+}
+
 // MCPZFSPoolArgs represents arguments for ZFS pool operations.
 type MCPZFSPoolArgs struct {
 	PoolName string `json:"pool_name,omitempty" jsonschema:"The name of a specific ZFS pool"`
 }
 
+// MCPPoolArgs represents arguments for pool queries.
+type MCPPoolArgs struct {
+	PoolName string `json:"pool_name,omitempty" jsonschema:"The name of a specific pool (e.g. cache)"`
+}
+
 // MCPUserScriptArgs represents arguments for user script execution.
 type MCPUserScriptArgs struct {
 	ScriptName string `json:"script_name" jsonschema:"The name of the user script to execute"`
 	Confirm    bool   `json:"confirm" jsonschema:"Must be set to true to confirm script execution"`
 }
 
+// MCPScriptExecuteArgs represents arguments for running a registered script.
+type MCPScriptExecuteArgs struct {
+	ScriptName string            `json:"script_name" jsonschema:"The name of the registered script to execute"`
+	Args       []string          `json:"args,omitempty" jsonschema:"Arguments replacing the script's default arguments"`
+	Env        map[string]string `json:"env,omitempty" jsonschema:"Environment variables merged over the script's environment"`
+	Confirm    bool              `json:"confirm" jsonschema:"Must be set to true to confirm script execution"`
+}
+
 // MCPCollectorArgs represents arguments for collector-related tools.
 type MCPCollectorArgs struct {
 	CollectorName string `json:"collector_name,omitempty" jsonschema:"The name of a specific collector (e.g. system, docker, vm, array, disk)"`
@@ -190,6 +266,12 @@ type MCPProcessListArgs struct {
 	Limit  int    `json:"limit,omitempty" jsonschema:"Maximum number of processes to return (default: 50, max: 500)"`
 }
 
+// MCPTopProcessesArgs represents arguments for listing the top resource consumers.
+type MCPTopProcessesArgs struct {
+	SortBy string `json:"sort_by,omitempty" jsonschema:"Rank by: cpu or memory (default: cpu)"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Number of processes to return (default: 10, max: 100)"`
+}
+
 // MCPContainerLogsArgs represents arguments for retrieving container logs.
 type MCPContainerLogsArgs struct {
 	ContainerID string `json:"container_id" jsonschema:"The Docker container ID or name"`
@@ -250,6 +332,7 @@ type MCPCreateHealthCheckArgs struct {
 	SuccessCode     int    `json:"success_code,omitempty" jsonschema:"Expected HTTP status code for http probes (default 200)"`
 	OnFail          string `json:"on_fail,omitempty" jsonschema:"Remediation action: notify, restart_container:<id>, or webhook:<url>"`
 	Enabled         bool   `json:"enabled,omitempty" jsonschema:"Whether the health check is enabled (default: true when created)"`
+	Container       string `json:"container,omitempty" jsonschema:"Optional container ID/name this http/tcp probe monitors; results appear on that container"`
 }
 
 // MCPDeleteHealthCheckArgs represents arguments for deleting a health check.
@@ -329,10 +412,30 @@ type MCPHealthReportArgs struct {
 	Actions []ActionRef `json:"actions,omitempty" jsonschema:"List of recommended actions to execute (from a previous report). Leave empty to receive a report only."`
 }
 
+// MCPBatchArgs represents arguments for the batch_actions tool.
+type MCPBatchArgs struct {
+	Actions     []ActionRef `json:"actions" jsonschema:"required,Actions to execute; each has an action (start/stop/restart_container, start/stop/restart/force_stop_vm, spin_down_disk, spin_up_disk) and a target (container ID, VM name or disk name)"`
+	Concurrency int         `json:"concurrency,omitempty" jsonschema:"Number of actions run at the same time (default 4, max 10)"`
+	Confirm     bool        `json:"confirm" jsonschema:"Must be true to execute the batch"`
+}
+
 // MCPMetricHistoryArgs represents arguments for the query_metric_history tool.
 type MCPMetricHistoryArgs struct {
-	Metric string `json:"metric" jsonschema:"required,metric name e.g. cpu_temp,array_used_pct,disk_temp"`
-	Entity string `json:"entity,omitempty" jsonschema:"optional entity id e.g. a disk or container id"`
+	Metric   string `json:"metric" jsonschema:"required,metric name e.g. cpu_usage,cpu_temp,array_used_pct,disk_temp"`
+	Entity   string `json:"entity,omitempty" jsonschema:"optional entity id e.g. a disk or container id"`
+	Start    string `json:"start,omitempty" jsonschema:"optional RFC 3339 start of the time range e.g. 2026-01-20T02:00:00Z"`
+	End      string `json:"end,omitempty" jsonschema:"optional RFC 3339 end of the time range"`
+	Lookback string `json:"lookback,omitempty" jsonschema:"optional duration back from now e.g. 15m or 1h; ignored when start is set"`
+}
+
+// MCPComparePeriodsArgs represents arguments for the compare_periods tool.
+type MCPComparePeriodsArgs struct {
+	Metric       string `json:"metric" jsonschema:"required,metric name e.g. cpu_usage,cpu_temp,disk_temp"`
+	Entity       string `json:"entity,omitempty" jsonschema:"optional entity id e.g. a disk or container id"`
+	PeriodAStart string `json:"period_a_start" jsonschema:"RFC 3339 start of the baseline period"`
+	PeriodAEnd   string `json:"period_a_end" jsonschema:"RFC 3339 end of the baseline period"`
+	PeriodBStart string `json:"period_b_start" jsonschema:"RFC 3339 start of the period to compare against the baseline"`
+	PeriodBEnd   string `json:"period_b_end" jsonschema:"RFC 3339 end of the period to compare against the baseline"`
 }
 
 // MCPRemoteShareActionArgs represents arguments for mounting/unmounting an
@@ -342,6 +445,58 @@ type MCPRemoteShareActionArgs struct {
 	Action string `json:"action" jsonschema:"The action to perform: mount or unmount"`
 }
 
+// MCPRecycleBinEmptyArgs represents arguments for emptying a share's recycle bin.
+type MCPRecycleBinEmptyArgs struct {
+	Share   string `json:"share" jsonschema:"The user share name as reported by get_recycle_bin"`
+	Confirm bool   `json:"confirm" jsonschema:"Must be set to true to confirm the action - deleted files cannot be recovered"`
+}
+
+// MCPTransferRunsArgs represents arguments for listing transfer runs.
+type MCPTransferRunsArgs struct {
+	JobID string `json:"job_id,omitempty" jsonschema:"Only return runs of this transfer job"`
+}
+
+// MCPTransferJobActionArgs represents arguments for running or cancelling a transfer job.
+type MCPTransferJobActionArgs struct {
+	JobID   string `json:"job_id" jsonschema:"The transfer job ID as reported by list_transfer_jobs"`
+	Action  string `json:"action" jsonschema:"The action to perform: run or cancel"`
+	Confirm bool   `json:"confirm,omitempty" jsonschema:"Must be set to true to run a job - transfers may overwrite or delete files at the destination"`
+}
+
+// MCPUnassignedDeviceActionArgs represents arguments for mounting, unmounting,
+// or formatting an Unassigned Devices disk.
+type MCPUnassignedDeviceActionArgs struct {
+	Device     string `json:"device" jsonschema:"The device name as reported by get_unassigned_devices (e.g. sdc), or a partition (e.g. sdc1) for mount/unmount"`
+	Action     string `json:"action" jsonschema:"The action to perform: mount, unmount, or format"`
+	Filesystem string `json:"filesystem,omitempty" jsonschema:"Filesystem to create when action is format: xfs, btrfs, or exfat"`
+	Confirm    bool   `json:"confirm,omitempty" jsonschema:"Must be set to true to confirm the format action - erases all data on the device"`
+}
+
+// MCPPingArgs represents arguments for the ping_host tool.
+type MCPPingArgs struct {
+	Host  string `json:"host" jsonschema:"Hostname or IP address to ping (must be in DIAGNOSTICS_TARGETS)"`
+	Count int    `json:"count,omitempty" jsonschema:"Echo requests to send (1-20, default 4)"`
+}
+
+// MCPDNSLookupArgs represents arguments for the dns_lookup tool.
+type MCPDNSLookupArgs struct {
+	Host       string `json:"host" jsonschema:"Hostname to resolve (must be in DIAGNOSTICS_TARGETS)"`
+	RecordType string `json:"record_type,omitempty" jsonschema:"Record type: A (default), AAAA, CNAME, MX, TXT, or NS"`
+}
+
+// MCPHTTPCheckArgs represents arguments for the http_check tool.
+type MCPHTTPCheckArgs struct {
+	URL    string `json:"url" jsonschema:"http:// or https:// URL to request (host must be in DIAGNOSTICS_TARGETS)"`
+	Method string `json:"method,omitempty" jsonschema:"GET (default) or HEAD"`
+}
+
+// MCPWakeOnLANArgs represents arguments for the wake_on_lan tool.
+type MCPWakeOnLANArgs struct {
+	Device    string `json:"device,omitempty" jsonschema:"Name of a device configured in WOL_DEVICES"`
+	MAC       string `json:"mac,omitempty" jsonschema:"MAC address to wake when no device name is given"`
+	Broadcast string `json:"broadcast,omitempty" jsonschema:"IPv4 broadcast address with optional port (default: the device's, or 255.255.255.255:9)"`
+}
+
 // MCPRunRunbookArgs represents arguments for the run_runbook tool.
 // When Confirm is false the tool is a dry-run: it returns planned steps without executing anything.
 // When Confirm is true supported-action steps are executed via the executor.
@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+// MDStat is the Unraid md driver status from /proc/mdstat, raw and parsed.
+// @Description Unraid md driver status: raw /proc/mdstat text, parsed array, resync and device fields, and sync tuning
+type MDStat struct {
+	Raw string `json:"raw"`
+
+	// Global holds every key without a device index (sbName, mdState,
+	// mdResyncAction, ...) as reported.
+	Global map[string]string `json:"global"`
+
+	State        string `json:"state" example:"STARTED"`
+	NumDisks     int    `json:"num_disks" example:"5"`
+	NumDisabled  int    `json:"num_disabled" example:"0"`
+	NumInvalid   int    `json:"num_invalid" example:"0"`
+	NumMissing   int    `json:"num_missing" example:"0"`
+	SyncErrors   uint64 `json:"sync_errors" example:"0"` // sbSyncErrs: errors found by the last parity operation
+	LastSyncExit int    `json:"last_sync_exit" example:"0"`
+
+	Resync  MDResyncStatus `json:"resync"`
+	Devices []MDDevice     `json:"devices"`
+	Tuning  MDTuning       `json:"tuning"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MDResyncStatus is the state of the running parity check, sync or rebuild.
+type MDResyncStatus struct {
+	Active          bool    `json:"active" example:"true"`
+	Paused          bool    `json:"paused,omitempty" example:"false"`
+	Action          string  `json:"action,omitempty" example:"check P"` // mdResyncAction
+	Correcting      bool    `json:"correcting,omitempty" example:"false"`
+	PositionBytes   uint64  `json:"position_bytes,omitempty" example:"4398046511104"`
+	SizeBytes       uint64  `json:"size_bytes,omitempty" example:"16000900661248"`
+	PercentComplete float64 `json:"percent_complete,omitempty" example:"27.5"`
+	SpeedBytes      uint64  `json:"speed_bytes_per_sec,omitempty" example:"185000000"` // Over the driver's last sample window
+}
+
+// MDDevice is one array slot in /proc/mdstat.
+type MDDevice struct {
+	Slot      int    `json:"slot" example:"1"`
+	Name      string `json:"name,omitempty" example:"disk1"`       // diskName
+	Device    string `json:"device,omitempty" example:"sdc"`       // rdevName
+	Status    string `json:"status,omitempty" example:"DISK_OK"`   // rdevStatus
+	ID        string `json:"id,omitempty" example:"WDC_WD120EFBX"` // rdevId
+	SizeBytes uint64 `json:"size_bytes,omitempty" example:"12000138625024"`
+	Reads     uint64 `json:"reads" example:"123456"`
+	Writes    uint64 `json:"writes" example:"65432"`
+	Errors    uint64 `json:"errors" example:"0"` // rdevNumErrors
+
+	// NrRequests is the block queue depth of the device
+	// (/sys/block/<device>/queue/nr_requests).
+	NrRequests int `json:"nr_requests,omitempty" example:"128"`
+}
+
+// MDTuning holds the md resync speed limits and the array devices' queue
+// depth.
+type MDTuning struct {
+	SyncSpeedMinKB int `json:"sync_speed_min" example:"1000"`   // dev.raid.speed_limit_min, KB/s
+	SyncSpeedMaxKB int `json:"sync_speed_max" example:"200000"` // dev.raid.speed_limit_max, KB/s
+}
+
+// MDTuningRequest changes md sync tuning. Omitted fields are left unchanged;
+// nr_requests is applied to every assigned array device. Changes last until
+// reboot.
+type MDTuningRequest struct {
+	SyncSpeedMinKB *int `json:"sync_speed_min,omitempty" example:"1000"`
+	SyncSpeedMaxKB *int `json:"sync_speed_max,omitempty" example:"100000"`
+	NrRequests     *int `json:"nr_requests,omitempty" example:"256"`
+}
@@ -18,4 +18,35 @@ type MetricHistoryResult struct {
 	Max     float64        `json:"max"`
 	Avg     float64        `json:"avg"`
 	Last    float64        `json:"last"`
+	// RetentionSeconds is how far back history reaches (older than the raw tier
+	// the samples are downsampled averages).
+	RetentionSeconds int64 `json:"retention_seconds"`
+	// Note explains gaps, e.g. a requested range that predates the retained history.
+	Note string `json:"note,omitempty"`
+}
+
+// MetricPeriodStats summarizes a metric series over one time window.
+type MetricPeriodStats struct {
+	StartUnix int64   `json:"start_unix"`
+	EndUnix   int64   `json:"end_unix"`
+	Count     int     `json:"count"`
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Avg       float64 `json:"avg"`
+}
+
+// MetricPeriodComparison compares a metric series across two time windows.
+// Deltas are period B minus period A and are only meaningful when both
+// periods contain samples.
+type MetricPeriodComparison struct {
+	Metric   string            `json:"metric"`
+	Entity   string            `json:"entity,omitempty"`
+	PeriodA  MetricPeriodStats `json:"period_a"`
+	PeriodB  MetricPeriodStats `json:"period_b"`
+	AvgDelta float64           `json:"avg_delta"`
+	// AvgDeltaPct is AvgDelta relative to period A's average (0 when that average is 0).
+	AvgDeltaPct      float64 `json:"avg_delta_pct"`
+	MaxDelta         float64 `json:"max_delta"`
+	RetentionSeconds int64   `json:"retention_seconds"`
+	Note             string  `json:"note,omitempty"`
 }
@@ -19,6 +19,19 @@ type MQTTConfig struct {
 	AutoReconnect     bool   `json:"auto_reconnect" example:"true"`
 	HomeAssistantMode bool   `json:"homeassistant_mode" example:"true"`
 	HADiscoveryPrefix string `json:"ha_discovery_prefix" example:"homeassistant"`
+
+	// TopicTemplate lays out state topics from {prefix}, {host} and {topic}
+	// (e.g. "system" or "docker/plex"). It must end with {topic}; empty means
+	// "{prefix}/{topic}".
+	TopicTemplate string `json:"topic_template,omitempty" example:"{prefix}/{host}/{topic}"`
+	// EntityNameTemplate and UniqueIDTemplate build HA entity names and
+	// unique IDs from {host}, {category}, {id} and {name}. Empty keeps
+	// "{name}" and "unraid_{host}_{id}".
+	EntityNameTemplate string `json:"entity_name_template,omitempty" example:"{host} {name}"`
+	UniqueIDTemplate   string `json:"unique_id_template,omitempty" example:"unraid_{host}_{id}"`
+	// HADisabledCategories lists topic categories (e.g. "docker", "vm",
+	// "triggers") whose HA discovery is not published.
+	HADisabledCategories []string `json:"ha_disabled_categories,omitempty" example:"docker,vm"`
 }
 
 // MQTTStatus represents the current status of the MQTT client.
@@ -131,6 +144,22 @@ type MQTTTopics struct {
 	ZFSDatasets       string `json:"zfs_datasets" example:"unraid/zfs/datasets"`
 	ZFSSnapshots      string `json:"zfs_snapshots" example:"unraid/zfs/snapshots"`
 	ZFSARC            string `json:"zfs_arc" example:"unraid/zfs/arc"`
+	WAN               string `json:"wan" example:"unraid/wan"`
+	WANIPChanged      string `json:"wan_ip_changed" example:"unraid/wan/ip_changed"`
+	Speedtest         string `json:"speedtest" example:"unraid/speedtest"`
+	Btrfs             string `json:"btrfs" example:"unraid/btrfs"`
+	Pools             string `json:"pools" example:"unraid/pools"`
+	Power             string `json:"power" example:"unraid/power"`
+	RecycleBin        string `json:"recycle_bin" example:"unraid/recycle_bin"`
+	IPMI              string `json:"ipmi" example:"unraid/ipmi"`
+	Maintenance       string `json:"maintenance" example:"unraid/maintenance"`
+	ParitySchedule    string `json:"parity_schedule" example:"unraid/array/parity_schedule"`
+	Flash             string `json:"flash" example:"unraid/flash"`
+	Mover             string `json:"mover" example:"unraid/mover"`
+	OSUpdate          string `json:"os_update" example:"unraid/updates/os"`
+	PluginUpdates     string `json:"plugin_updates" example:"unraid/updates/plugins"`
+	ContainerUpdates  string `json:"container_updates" example:"unraid/updates/docker"`
+	Collectors        string `json:"collectors" example:"unraid/collectors"`
 }
 
 // MQTTEnableRequest represents a request to enable/disable MQTT.
@@ -45,3 +45,55 @@ type NetworkInfo struct {
 
 	Timestamp time.Time `json:"timestamp"`
 }
+
+// GuestInterfaceTraffic is the traffic of a host virtual interface attributed
+// to the VM (vnetX tap) or container (veth peer) that owns it. RX and TX are
+// from the guest's point of view: what the host side transmits, the guest
+// receives.
+type GuestInterfaceTraffic struct {
+	Interface string `json:"interface" example:"vnet0"`
+	// OwnerType is "vm" or "container".
+	OwnerType string `json:"owner_type" example:"vm"`
+	// Owner is the VM name or the container's short ID.
+	Owner string `json:"owner" example:"Windows 11"`
+	// Bridge is the bridge the interface is attached to, e.g. br0 or docker0.
+	Bridge        string  `json:"bridge,omitempty" example:"br0"`
+	RXBytes       uint64  `json:"rx_bytes" example:"104857600"`
+	TXBytes       uint64  `json:"tx_bytes" example:"52428800"`
+	RXBytesPerSec float64 `json:"rx_bytes_per_sec" example:"10240"`
+	TXBytesPerSec float64 `json:"tx_bytes_per_sec" example:"2048"`
+}
+
+// GuestTraffic is the host virtual interface traffic attributed to VMs and
+// containers, merged into their DTOs at read time.
+type GuestTraffic struct {
+	Interfaces []GuestInterfaceTraffic `json:"interfaces"`
+	Timestamp  time.Time               `json:"timestamp"`
+}
+
+// WOLDevice is a machine that can be woken with Wake-on-LAN, configured with
+// WOL_DEVICES.
+type WOLDevice struct {
+	Name string `json:"name" example:"desktop"`
+	MAC  string `json:"mac" example:"a8:a1:59:12:34:56"`
+	// Broadcast is the host:port the magic packet is sent to.
+	Broadcast string `json:"broadcast" example:"192.168.1.255:9"`
+}
+
+// WOLRequest is the request body for POST /network/wol. Give either a
+// configured device name or a MAC address.
+type WOLRequest struct {
+	Device string `json:"device,omitempty" example:"desktop"`
+	MAC    string `json:"mac,omitempty" example:"a8:a1:59:12:34:56"`
+	// Broadcast is an IPv4 broadcast address with optional port; defaults
+	// to the device's, or 255.255.255.255:9.
+	Broadcast string `json:"broadcast,omitempty" example:"192.168.1.255"`
+}
+
+// WOLResult reports a magic packet that was sent.
+type WOLResult struct {
+	Device    string    `json:"device,omitempty" example:"desktop"`
+	MAC       string    `json:"mac" example:"a8:a1:59:12:34:56"`
+	Broadcast string    `json:"broadcast" example:"192.168.1.255:9"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// PingResult is the outcome of an on-demand ping from the server
+// (GET /diagnostics/ping).
+type PingResult struct {
+	Host          string    `json:"host" example:"1.1.1.1"`
+	Transmitted   int       `json:"transmitted" example:"4"`
+	Received      int       `json:"received" example:"4"`
+	PacketLossPct float64   `json:"packet_loss_percent" example:"0"`
+	MinMs         float64   `json:"min_ms,omitempty" example:"11.2"`
+	AvgMs         float64   `json:"avg_ms,omitempty" example:"12.4"`
+	MaxMs         float64   `json:"max_ms,omitempty" example:"14.1"`
+	Reachable     bool      `json:"reachable" example:"true"`
+	Error         string    `json:"error,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// DNSLookupResult is the outcome of an on-demand DNS lookup using the
+// server's resolver (GET /diagnostics/dns).
+type DNSLookupResult struct {
+	Host       string    `json:"host" example:"github.com"`
+	RecordType string    `json:"record_type" example:"A"` // "A", "AAAA", "CNAME", "MX", "TXT", "NS"
+	Records    []string  `json:"records"`
+	DurationMs float64   `json:"duration_ms" example:"3.2"`
+	Error      string    `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// HTTPCheckResult is the outcome of an on-demand HTTP(S) request from the
+// server (GET /diagnostics/http), with a timing breakdown like curl -w.
+type HTTPCheckResult struct {
+	URL        string `json:"url" example:"https://registry-1.docker.io/v2/"`
+	Method     string `json:"method" example:"GET"`
+	FinalURL   string `json:"final_url,omitempty" example:"https://registry-1.docker.io/v2/"` // After redirects
+	StatusCode int    `json:"status_code,omitempty" example:"401"`
+	Status     string `json:"status,omitempty" example:"401 Unauthorized"`
+
+	// Timings in milliseconds. DNS, connect, and TLS are zero when a phase
+	// was skipped (IP literal, reused connection, plain HTTP).
+	DNSMs      float64 `json:"dns_ms" example:"2.1"`
+	ConnectMs  float64 `json:"connect_ms" example:"14.8"`
+	TLSMs      float64 `json:"tls_ms" example:"31.5"`
+	TTFBMs     float64 `json:"ttfb_ms" example:"98.2"`
+	DurationMs float64 `json:"duration_ms" example:"101.7"`
+
+	BodyBytes     int64      `json:"body_bytes" example:"87"` // Bytes read, capped at 1 MiB
+	TLSVersion    string     `json:"tls_version,omitempty" example:"TLS 1.3"`
+	CertExpiresAt *time.Time `json:"cert_expires_at,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	Timestamp     time.Time  `json:"timestamp"`
+}
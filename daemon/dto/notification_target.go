@@ -0,0 +1,40 @@
+package dto
+
+// Importance levels a notification target can subscribe to. They match the
+// importance of Unraid notifications; alert rule severities map onto them
+// (critical→alert, warning→warning, info→normal).
+const (
+	NotificationLevelAlert   = "alert"
+	NotificationLevelWarning = "warning"
+	NotificationLevelNormal  = "normal"
+)
+
+// Sources a notification target can forward.
+const (
+	// NotificationSourceUnraid forwards new Unraid notifications.
+	NotificationSourceUnraid = "notifications"
+	// NotificationSourceAlerts forwards alert rule firing/resolved events.
+	NotificationSourceAlerts = "alerts"
+)
+
+// NotificationTarget is an external service that notifications are forwarded to.
+// @Description Telegram bot, Discord webhook, or Pushover destination for forwarded notifications
+type NotificationTarget struct {
+	ID   string `json:"id" example:"phone"`
+	Name string `json:"name" example:"My phone"`
+	// URL is a shoutrrr service URL (telegram://, discord:// or pushover://).
+	// It embeds credentials and is redacted in API responses.
+	URL string `json:"url" example:"telegram://[REDACTED]"`
+	// Levels limits forwarding to these importance levels (alert, warning,
+	// normal). Empty forwards every level.
+	Levels []string `json:"levels,omitempty" example:"alert,warning"`
+	// Sources limits forwarding to Unraid notifications ("notifications") or
+	// alert rule events ("alerts"). Empty forwards both.
+	Sources []string `json:"sources,omitempty" example:"notifications,alerts"`
+	Enabled bool     `json:"enabled" example:"true"`
+}
+
+// NotificationTargetsConfig is the on-disk format for notification targets.
+type NotificationTargetsConfig struct {
+	Targets []NotificationTarget `json:"targets"`
+}
@@ -2,8 +2,16 @@ package dto
 
 import "time"
 
+// Parity check trigger sources, recorded for checks started through the agent.
+const (
+	ParityTriggerAPI  = "api"
+	ParityTriggerMCP  = "mcp"
+	ParityTriggerMQTT = "mqtt"
+)
+
 // ParityCheckRecord represents a single parity check/sync operation from history
 type ParityCheckRecord struct {
+	ID       string    `json:"id"`               // Stable ID: Unix time of Date
 	Action   string    `json:"action"`           // "Parity-Check", "Parity-Sync", "Read-Check", "Clear"
 	Date     time.Time `json:"date"`             // Date and time of the operation
 	Duration int64     `json:"duration_seconds"` // Duration in seconds
@@ -11,6 +19,16 @@ type ParityCheckRecord struct {
 	Status   string    `json:"status"`           // "OK", "Canceled", or error count
 	Errors   int64     `json:"errors"`           // Number of errors found
 	Size     uint64    `json:"size_bytes"`       // Size of array checked in bytes
+
+	// Agent annotations, stored alongside parity-checks.log rather than in it.
+	Note       string `json:"note,omitempty"`       // User note, e.g. "after disk swap"
+	Trigger    string `json:"trigger,omitempty"`    // "api", "mcp" or "mqtt" when started through the agent
+	Correcting *bool  `json:"correcting,omitempty"` // Correcting mode, when started through the agent
+}
+
+// ParityCheckNoteRequest sets or clears the note on a parity history record.
+type ParityCheckNoteRequest struct {
+	Note string `json:"note" example:"after disk swap"` // Empty clears the note
 }
 
 // ParityCheckHistory contains the list of parity check records
@@ -0,0 +1,73 @@
+package dto
+
+import "time"
+
+// PoolInfo describes an Unraid pool (cache, cache2, or a named btrfs/ZFS/XFS
+// pool outside the parity-protected array) with its member devices and
+// filesystem-level capacity breakdown.
+type PoolInfo struct {
+	Name       string `json:"name" example:"cache"`
+	FileSystem string `json:"filesystem" example:"btrfs"`         // "btrfs", "zfs", "xfs"
+	Status     string `json:"status,omitempty" example:"Mounted"` // Filesystem status from disks.ini
+	MountPoint string `json:"mount_point" example:"/mnt/cache"`
+	Profile    string `json:"profile,omitempty" example:"raid1"` // btrfs data profile or top-level ZFS vdev type
+	Health     string `json:"health,omitempty" example:"ONLINE"` // ZFS pool health
+
+	Devices []PoolDevice `json:"devices"`
+
+	// Capacity as reported by Unraid for the mounted filesystem.
+	TotalBytes   uint64  `json:"total_bytes" example:"1000204886016"`
+	UsedBytes    uint64  `json:"used_bytes" example:"412316860416"`
+	FreeBytes    uint64  `json:"free_bytes" example:"587888025600"`
+	UsagePercent float64 `json:"usage_percent" example:"41.2"`
+
+	// Fill forecast from the trend of used space over the last 7 days; nil
+	// until 6 hours of history exist. DaysUntilFull is nil while usage is
+	// flat or shrinking.
+	FillRateBytesPerDay *float64 `json:"fill_rate_bytes_per_day,omitempty" example:"5368709120"`
+	DaysUntilFull       *float64 `json:"days_until_full,omitempty" example:"109.5"`
+
+	// Btrfs allocation: chunks allocated per block group type vs bytes used
+	// inside them. A large gap between data allocated and data used is what a
+	// balance reclaims.
+	MetadataProfile        string `json:"metadata_profile,omitempty" example:"raid1"`
+	DataAllocatedBytes     uint64 `json:"data_allocated_bytes,omitempty" example:"429496729600"`
+	DataUsedBytes          uint64 `json:"data_used_bytes,omitempty" example:"408021893120"`
+	MetadataAllocatedBytes uint64 `json:"metadata_allocated_bytes,omitempty" example:"4294967296"`
+	MetadataUsedBytes      uint64 `json:"metadata_used_bytes,omitempty" example:"2147483648"`
+	SystemAllocatedBytes   uint64 `json:"system_allocated_bytes,omitempty" example:"33554432"`
+
+	// Balance state (btrfs only).
+	BalanceRunning  bool   `json:"balance_running" example:"false"`
+	BalanceStatus   string `json:"balance_status,omitempty" example:"idle"` // "idle", "running", "paused"
+	BalanceProgress string `json:"balance_progress,omitempty" example:"2 out of about 10 chunks balanced (3 considered),  80% left"`
+
+	// Scrub state of the most recent scrub (btrfs or ZFS).
+	ScrubStatus  string `json:"scrub_status,omitempty" example:"finished"` // "running", "finished", "aborted", "interrupted", "never"
+	ScrubStarted string `json:"scrub_started,omitempty" example:"Sun Oct 12 03:00:01 2026"`
+	ScrubErrors  string `json:"scrub_errors,omitempty" example:"no errors found"`
+
+	// LastTrim is when the pool was last trimmed through the agent.
+	LastTrim *time.Time `json:"last_trim,omitempty"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PoolDevice is a device slot belonging to a pool.
+type PoolDevice struct {
+	Slot      string `json:"slot" example:"cache2"`
+	Device    string `json:"device" example:"nvme1n1"`
+	ID        string `json:"id,omitempty" example:"Samsung_SSD_980_PRO_1TB_S5GXNF0R123456"`
+	Status    string `json:"status,omitempty" example:"DISK_OK"`
+	SizeBytes uint64 `json:"size_bytes,omitempty" example:"1000204886016"`
+}
+
+// TrimResult is the result of a pool TRIM job.
+type TrimResult struct {
+	Pool            string    `json:"pool" example:"cache"`
+	MountPoint      string    `json:"mount_point" example:"/mnt/cache"`
+	FileSystem      string    `json:"filesystem" example:"btrfs"`
+	TrimmedBytes    uint64    `json:"trimmed_bytes" example:"42088275968"` // 0 for ZFS, which does not report it
+	DurationSeconds float64   `json:"duration_seconds" example:"12.4"`
+	FinishedAt      time.Time `json:"finished_at"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// Power estimate sources
+const (
+	// PowerSourceUPS means TotalWatts is the load reported by the UPS.
+	PowerSourceUPS = "ups"
+	// PowerSourceComponents means TotalWatts is the sum of the component
+	// readings and per-disk estimates.
+	PowerSourceComponents = "components"
+)
+
+// PowerEstimate is the estimated power draw of the server, combining CPU/DRAM
+// RAPL readings, GPU power draw, UPS load, and per-disk spin state.
+type PowerEstimate struct {
+	// TotalWatts is the best available figure for the whole server: the UPS
+	// load when a UPS reports it, otherwise ComponentWatts.
+	TotalWatts float64 `json:"total_watts" example:"142.5"`
+	Source     string  `json:"source" example:"ups"` // "ups" or "components"
+
+	// Component readings; pointers are omitted when the source is unavailable.
+	CPUWatts  *float64 `json:"cpu_watts,omitempty" example:"35.2"`  // CPU package power (RAPL)
+	DRAMWatts *float64 `json:"dram_watts,omitempty" example:"4.1"`  // DRAM power (RAPL)
+	GPUWatts  *float64 `json:"gpu_watts,omitempty" example:"18.7"`  // Sum of GPU power draw
+	UPSWatts  *float64 `json:"ups_watts,omitempty" example:"142.5"` // UPS load in watts
+
+	// Disks are estimated from their spin state, as drives do not report power.
+	DiskWatts      float64   `json:"disk_watts" example:"31"`
+	DisksActive    int       `json:"disks_active" example:"4"`
+	DisksStandby   int       `json:"disks_standby" example:"3"`
+	ComponentWatts float64   `json:"component_watts" example:"89"` // CPU + DRAM + GPU + disks
+	KWhPerDay      float64   `json:"kwh_per_day" example:"3.42"`   // TotalWatts projected over 24 hours
+	EnergyKWh      float64   `json:"energy_kwh" example:"12.873"`  // Energy used since EnergySince (monotonic)
+	EnergySince    time.Time `json:"energy_since"`
+	Timestamp      time.Time `json:"timestamp"`
+}
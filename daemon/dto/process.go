@@ -16,6 +16,17 @@ type ProcessInfo struct {
 	Time          string  `json:"time" example:"0:05"`
 	Command       string  `json:"command" example:"/usr/bin/docker"`
 
+	// Name is the process name from /proc/<pid>/comm, populated by the
+	// /system/processes endpoint.
+	Name string `json:"name,omitempty" example:"Plex Media Serv"`
+	// Cgroup is the process's cgroup v2 path from /proc/<pid>/cgroup,
+	// populated by the /system/processes endpoint.
+	Cgroup string `json:"cgroup,omitempty" example:"/docker/3f4e2a1b9c8d"`
+	// ContainerID and ContainerName attribute the process to the Docker
+	// container whose cgroup it runs in. Omitted for host processes.
+	ContainerID   string `json:"container_id,omitempty" example:"3f4e2a1b9c8d"`
+	ContainerName string `json:"container_name,omitempty" example:"plex"`
+
 	// DiskReadBytesPerSec is the per-process disk read rate (bytes/sec), populated by
 	// the /processes/io endpoint from /proc/<pid>/io. Omitted from the standard process list.
 	DiskReadBytesPerSec uint64 `json:"disk_read_bytes_per_sec,omitempty" example:"1048576"`
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// Readiness reports whether the agent has finished its startup phase, in
+// which every enabled collector runs once in dependency order.
+type Readiness struct {
+	Ready     bool       `json:"ready" example:"true"`
+	StartedAt time.Time  `json:"started_at"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+	// Pending lists the collectors that have not published a first result.
+	Pending []string `json:"pending,omitempty"`
+	// TimedOut lists the collectors that had not published when the startup
+	// phase timed out; the agent became ready without their data.
+	TimedOut  []string  `json:"timed_out,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// RecycleBinShare is the recycle bin of one user share.
+type RecycleBinShare struct {
+	Share         string     `json:"share" example:"Media"`
+	Path          string     `json:"path" example:"/mnt/user/Media/.Recycle.Bin"`
+	SizeBytes     uint64     `json:"size_bytes" example:"21474836480"`
+	Files         uint64     `json:"files" example:"312"`
+	OldestAt      *time.Time `json:"oldest_at,omitempty"`                      // Modification time of the oldest file (deletion time when the plugin touches files)
+	NewestAt      *time.Time `json:"newest_at,omitempty"`                      // Modification time of the newest file
+	OldestAgeDays float64    `json:"oldest_age_days,omitempty" example:"13.5"` // Age of the oldest file in days
+}
+
+// RecycleBinStatus reports the Recycle Bin plugin's per-share recycle bins.
+type RecycleBinStatus struct {
+	Installed  bool              `json:"installed" example:"true"` // Recycle Bin plugin installed
+	TotalBytes uint64            `json:"total_bytes" example:"21474836480"`
+	TotalFiles uint64            `json:"total_files" example:"312"`
+	Shares     []RecycleBinShare `json:"shares"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
+// RecycleBinEmptyResult is the outcome of emptying a share's recycle bin.
+type RecycleBinEmptyResult struct {
+	Share        string    `json:"share" example:"Media"`
+	FilesRemoved uint64    `json:"files_removed" example:"312"`
+	BytesFreed   uint64    `json:"bytes_freed" example:"21474836480"`
+	Timestamp    time.Time `json:"timestamp"`
+}
@@ -12,3 +12,33 @@ type Registration struct {
 	GUID             string    `json:"guid,omitempty"`        // Registration GUID
 	Timestamp        time.Time `json:"timestamp"`             // Collection timestamp
 }
+
+// RegistrationKeyRequest is the body of POST /registration/key. Exactly one
+// of URL or KeyData must be set.
+type RegistrationKeyRequest struct {
+	// URL is an https link to a key file on a Lime Technology key server, as
+	// sent in the purchase or upgrade email.
+	URL string `json:"url,omitempty" example:"https://keys.lime-technology.com/unraid/1234abcd/Pro.key"`
+	// KeyData is the base64-encoded content of an uploaded key file.
+	KeyData string `json:"key_data,omitempty"`
+	// FileName names an uploaded key file (e.g. "Pro.key"); for URL installs
+	// it defaults to the last path element of the URL.
+	FileName string `json:"file_name,omitempty" example:"Pro.key"`
+	// Confirm must be true; any other key file on the flash drive is
+	// replaced.
+	Confirm bool `json:"confirm"`
+}
+
+// RegistrationKeyResult reports an installed registration key file.
+type RegistrationKeyResult struct {
+	FileName string `json:"file_name" example:"Pro.key"`
+	Path     string `json:"path" example:"/boot/config/Pro.key"`
+	Size     int    `json:"size" example:"256"`
+	// Replaced lists the previous key files, kept with a .bak suffix.
+	Replaced []string `json:"replaced,omitempty"`
+	// ArrayStarted is true when the array was running during the install;
+	// Unraid then applies the new key the next time the array is started.
+	ArrayStarted bool      `json:"array_started"`
+	Message      string    `json:"message"`
+	Timestamp    time.Time `json:"timestamp"`
+}
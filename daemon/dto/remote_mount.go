@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// RemoteMountStatus is the health of one NFS or SMB client mount.
+// @Description Reachability and staleness of a remote NFS/SMB mount.
+type RemoteMountStatus struct {
+	MountPoint string `json:"mount_point" example:"/mnt/remotes/nas_media"`
+	Source     string `json:"source" example:"//nas/media"`
+	// Type is "nfs" or "smb".
+	Type string `json:"type" example:"smb"`
+	// Origin is "unassigned" for Unassigned Devices remote shares, "fstab"
+	// for /etc/fstab entries and "manual" for other mounts.
+	Origin  string `json:"origin" example:"unassigned"`
+	Server  string `json:"server" example:"nas"`
+	Mounted bool   `json:"mounted"`
+	// Stale is true when the mount is mounted but statfs on it hangs or
+	// fails, e.g. a hung NFS server or a disconnected SMB session.
+	Stale bool `json:"stale"`
+	// StatfsLatencyMs is how long statfs on the mount point took.
+	StatfsLatencyMs float64 `json:"statfs_latency_ms" example:"1.4"`
+	// ServerReachable is true when a TCP connection to the server's NFS
+	// (2049) or SMB (445) port succeeded.
+	ServerReachable bool    `json:"server_reachable"`
+	ServerLatencyMs float64 `json:"server_latency_ms" example:"0.6"`
+	// Error describes why the mount is stale or the server unreachable.
+	Error string `json:"error,omitempty"`
+	// StaleSince is when the mount was first seen stale in the current run
+	// of failures.
+	StaleSince *time.Time `json:"stale_since,omitempty"`
+	// LastHealthyAt is when the mount last answered statfs.
+	LastHealthyAt *time.Time `json:"last_healthy_at,omitempty"`
+}
+
+// RemoteMountHealth is the envelope published on TopicRemoteMountsUpdate and
+// served by GET /api/v1/network/mounts.
+// @Description Health of all NFS/SMB client mounts.
+type RemoteMountHealth struct {
+	Mounts []RemoteMountStatus `json:"mounts"`
+	// StaleCount is the number of mounted remote mounts that are stale.
+	StaleCount int       `json:"stale_count" example:"0"`
+	Timestamp  time.Time `json:"timestamp"`
+}
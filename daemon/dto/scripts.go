@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// Script is a named bash script registered with the agent, independent of the
+// User Scripts plugin.
+// @Description Script stored by the agent with its default arguments and environment
+type Script struct {
+	Name        string `json:"name" example:"prune-images"`
+	Description string `json:"description,omitempty" example:"Remove dangling Docker images"`
+	// Content is the bash script body.
+	Content string `json:"content" example:"docker image prune -f \"$@\""`
+	// Args are the default arguments, passed to the script without a shell.
+	Args []string `json:"args,omitempty" example:"--filter,until=24h"`
+	// Env holds extra environment variables for the script.
+	Env            map[string]string `json:"env,omitempty"`
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty" example:"300"` // Limit for runs that wait for completion (default 60, max 3600)
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// ScriptExecuteRequest holds the options of a registered script run.
+type ScriptExecuteRequest struct {
+	// Args replace the script's default arguments when set.
+	Args []string `json:"args,omitempty" example:"--filter,until=48h"`
+	// Env is merged over the script's environment.
+	Env map[string]string `json:"env,omitempty"`
+	// Wait runs the script to completion and returns its output; otherwise
+	// it runs in the background.
+	Wait bool `json:"wait" example:"true"`
+}
@@ -64,6 +64,9 @@ type ParitySchedule struct {
 	// scheduled mode including custom. Use it to compute the next check
 	// without re-implementing Unraid's scheduling rules (Issue #124).
 	CheckCron string `json:"check_cron,omitempty" example:"0 0 1 1 *"`
+	// NextCheck is when CheckCron next fires in the server's local time;
+	// omitted when no check is scheduled.
+	NextCheck *time.Time `json:"next_check,omitempty" example:"2027-01-01T00:00:00Z"`
 
 	// Pause/resume schedule
 	PauseHour  int `json:"pause_hour,omitempty" example:"6"`  // Hour to pause (if scheduled)
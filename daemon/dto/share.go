@@ -24,8 +24,55 @@ type ShareInfo struct {
 	CachePool2  string `json:"cache_pool2,omitempty" example:""`              // Secondary cache pool (for mover destination)
 	MoverAction string `json:"mover_action,omitempty" example:"cache->array"` // Mover action: "cache->array", "array->cache", or empty
 
+	// Activity is the SMB and NFS I/O of the share; nil when neither source
+	// could be read.
+	Activity *ShareActivity `json:"activity,omitempty"`
+
 	// SourceStatus is non-nil only when the backing data source is not healthy.
 	SourceStatus *SourceStatus `json:"source_status,omitempty"`
 
 	Timestamp time.Time `json:"timestamp"`
 }
+
+// ShareProtocolIO is the I/O one file sharing protocol generated on a share
+// since Since, with its rates over the last collection interval.
+type ShareProtocolIO struct {
+	ReadBytes        uint64  `json:"read_bytes" example:"1073741824"`
+	WriteBytes       uint64  `json:"write_bytes" example:"536870912"`
+	ReadOps          uint64  `json:"read_ops,omitempty" example:"8192"` // SMB only: read syscalls of the serving smbd processes
+	WriteOps         uint64  `json:"write_ops,omitempty" example:"4096"`
+	ReadBytesPerSec  float64 `json:"read_bytes_per_sec" example:"1048576"`
+	WriteBytesPerSec float64 `json:"write_bytes_per_sec" example:"0"`
+}
+
+// ShareActivity is the SMB and NFS I/O of a user share. A protocol is nil
+// when the share is not exported over it or its counters are unavailable.
+type ShareActivity struct {
+	SMB   *ShareProtocolIO `json:"smb,omitempty"`
+	NFS   *ShareProtocolIO `json:"nfs,omitempty"`
+	Since time.Time        `json:"since"` // When the agent started counting
+}
+
+// ShareDiskUsage is the part of a user share stored on one array disk or pool.
+type ShareDiskUsage struct {
+	Disk       string    `json:"disk" example:"disk1"`
+	Role       string    `json:"role,omitempty" example:"data"` // "data", "cache", "pool"
+	MountPoint string    `json:"mount_point" example:"/mnt/disk1"`
+	Bytes      uint64    `json:"bytes" example:"2199023255552"` // Allocated bytes of the share's files on this disk
+	Files      uint64    `json:"files" example:"18432"`
+	Percent    float64   `json:"percent" example:"41.5"` // Share of TotalBytes stored on this disk
+	Standby    bool      `json:"standby,omitempty"`      // Disk was spun down and not scanned; Bytes is from an earlier scan, if any
+	Cached     bool      `json:"cached,omitempty"`       // Served from the last scan because the disk's usage had not changed
+	ScannedAt  time.Time `json:"scanned_at,omitzero"`
+}
+
+// ShareDistribution describes how a user share's data is spread across the
+// array disks and pools that back it.
+type ShareDistribution struct {
+	Share      string           `json:"share" example:"Media"`
+	TotalBytes uint64           `json:"total_bytes" example:"5299989643264"`
+	TotalFiles uint64           `json:"total_files" example:"40210"`
+	Disks      []ShareDiskUsage `json:"disks"`
+	Incomplete bool             `json:"incomplete,omitempty"` // Some disks were in standby and have no scan yet
+	Timestamp  time.Time        `json:"timestamp"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// Speedtest tools supported by the speedtest collector.
+const (
+	SpeedtestToolSpeedtestCLI = "speedtest-cli"
+	SpeedtestToolIperf3       = "iperf3"
+)
+
+// SpeedtestResult is the outcome of a single bandwidth test run.
+// @Description Download/upload throughput and latency measured by one bandwidth test.
+type SpeedtestResult struct {
+	// Tool is the program that ran the test: "speedtest-cli" or "iperf3".
+	Tool string `json:"tool" example:"speedtest-cli"`
+	// Server describes the test server (sponsor and location for speedtest-cli, host for iperf3).
+	Server string `json:"server,omitempty" example:"Example ISP (Amsterdam)"`
+	// Success is false when the test could not complete; see Error.
+	Success bool `json:"success"`
+	// DownloadMbps is the measured download throughput in megabits per second.
+	DownloadMbps float64 `json:"download_mbps" example:"938.4"`
+	// UploadMbps is the measured upload throughput in megabits per second.
+	UploadMbps float64 `json:"upload_mbps" example:"47.9"`
+	// PingMs is the latency to the test server in milliseconds.
+	PingMs float64 `json:"ping_ms" example:"8.2"`
+	// DurationSeconds is how long the test took.
+	DurationSeconds float64 `json:"duration_seconds" example:"24.6"`
+	// Error describes why the test failed, if it did.
+	Error string `json:"error,omitempty"`
+	// Timestamp is when the test started.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SpeedtestStatus is the envelope published on TopicSpeedtestUpdate and served
+// by GET /api/v1/network/speedtest.
+// @Description Latest bandwidth test result and the retained result history.
+type SpeedtestStatus struct {
+	// Latest is the most recent test run (successful or not).
+	Latest *SpeedtestResult `json:"latest,omitempty"`
+	// History holds previous runs, oldest first, including Latest.
+	History []SpeedtestResult `json:"history"`
+	// Timestamp is when this status was published.
+	Timestamp time.Time `json:"timestamp"`
+}
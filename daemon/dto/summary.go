@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// Summary is a compact, pre-aggregated snapshot of the server for dashboard
+// widgets and low-power displays that can afford only one request per
+// refresh. Fields whose source has not been collected yet are omitted.
+type Summary struct {
+	Hostname      string   `json:"hostname,omitempty" example:"tower"`
+	UptimeSeconds int64    `json:"uptime_seconds,omitempty" example:"86400"`
+	CPUPercent    *float64 `json:"cpu_percent,omitempty" example:"12.5"`
+	CPUTempC      *float64 `json:"cpu_temp_c,omitempty" example:"45"`
+	RAMPercent    *float64 `json:"ram_percent,omitempty" example:"65.5"`
+
+	ArrayState       string   `json:"array_state,omitempty" example:"Started"`
+	ArrayUsedPercent *float64 `json:"array_used_percent,omitempty" example:"45.5"`
+	ParityValid      *bool    `json:"parity_valid,omitempty" example:"true"`
+	ParityStatus     string   `json:"parity_status,omitempty" example:"idle"`       // Parity check status: "idle", "running", "paused"
+	ParityProgress   float64  `json:"parity_progress,omitempty" example:"27.5"`     // Percent, while a parity operation runs
+	ParityETASeconds int64    `json:"parity_eta_seconds,omitempty" example:"62700"` // While a parity operation runs
+
+	Disks        int `json:"disks" example:"10"`
+	HotDisks     int `json:"hot_disks" example:"1"`     // Disks above the warning temperature (55 °C)
+	FailingDisks int `json:"failing_disks" example:"0"` // Disks whose SMART status is not PASSED
+
+	ContainersRunning int `json:"containers_running" example:"12"`
+	ContainersTotal   int `json:"containers_total" example:"15"`
+	VMsRunning        int `json:"vms_running" example:"1"`
+	VMsTotal          int `json:"vms_total" example:"2"`
+
+	UnreadNotifications int `json:"unread_notifications" example:"3"`
+	UnreadAlerts        int `json:"unread_alerts" example:"1"` // Unread notifications with "alert" importance
+	FiringAlerts        int `json:"firing_alerts" example:"0"` // Alert rules currently firing
+
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+// SwapStatus is the response for GET /system/swap: the active swap devices,
+// including zram, and the swap file managed by the agent.
+type SwapStatus struct {
+	TotalBytes uint64  `json:"total_bytes" example:"8589934592"`
+	UsedBytes  uint64  `json:"used_bytes" example:"1073741824"`
+	Usage      float64 `json:"usage_percent" example:"12.5"`
+	// Swappiness is the kernel vm.swappiness tunable (0-200); -1 means unavailable.
+	Swappiness int          `json:"swappiness" example:"60"`
+	Devices    []SwapDevice `json:"devices"`
+	// SwapFile is the swap file enabled through the API; nil when none is
+	// configured.
+	SwapFile  *SwapFileConfig `json:"swap_file,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// SwapDevice is an active swap area from /proc/swaps.
+type SwapDevice struct {
+	Path string `json:"path" example:"/mnt/cache/swapfile"`
+	// Type is "file", "partition" or "zram".
+	Type      string  `json:"type" example:"file"`
+	SizeBytes uint64  `json:"size_bytes" example:"8589934592"`
+	UsedBytes uint64  `json:"used_bytes" example:"1073741824"`
+	Usage     float64 `json:"usage_percent" example:"12.5"`
+	// Priority decides which swap area is used first; higher wins.
+	Priority int        `json:"priority" example:"-2"`
+	Zram     *ZramStats `json:"zram,omitempty"`
+}
+
+// ZramStats is the compression state of a zram swap device.
+type ZramStats struct {
+	Algorithm string `json:"algorithm" example:"zstd"`
+	// OriginalBytes is the data stored, CompressedBytes its compressed size
+	// and MemUsedBytes the RAM the device uses including overhead.
+	OriginalBytes    uint64  `json:"original_bytes" example:"2147483648"`
+	CompressedBytes  uint64  `json:"compressed_bytes" example:"536870912"`
+	MemUsedBytes     uint64  `json:"mem_used_bytes" example:"566231040"`
+	CompressionRatio float64 `json:"compression_ratio" example:"4.0"`
+}
+
+// SwapFileConfig is the swap file enabled through the API. It is
+// re-enabled when the agent starts with the array and released when the
+// array stops, so the pool can unmount.
+type SwapFileConfig struct {
+	Pool      string `json:"pool" example:"cache"`
+	Path      string `json:"path" example:"/mnt/cache/swapfile"`
+	SizeBytes uint64 `json:"size_bytes" example:"8589934592"`
+	Active    bool   `json:"active" example:"true"`
+}
+
+// SwapFileRequest is the request body for POST /system/swap/file.
+type SwapFileRequest struct {
+	Pool      string `json:"pool" example:"cache"`
+	SizeBytes uint64 `json:"size_bytes" example:"8589934592"`
+	// Confirm must be true; the file is allocated on the pool immediately.
+	Confirm bool `json:"confirm"`
+}
@@ -20,6 +20,11 @@ type SystemInfo struct {
 	CPUPowerWatts  *float64           `json:"cpu_power_watts,omitempty" example:"65.5"` // CPU package power in watts (only present when Intel RAPL is available)
 	DRAMPowerWatts *float64           `json:"dram_power_watts,omitempty" example:"5.2"` // DRAM power in watts (only present when Intel RAPL is available)
 
+	// Per-core CPU breakdown for spotting thermal imbalance and throttling
+	CPUCoreTemps         []CPUCoreTemp      `json:"cpu_core_temps,omitempty"`                              // Per-core temperatures (Intel coretemp only)
+	CPUCoreFrequencies   []CPUCoreFrequency `json:"cpu_core_frequencies,omitempty"`                        // Current frequency of each logical CPU (cpufreq)
+	CPUPackagePowerWatts []float64          `json:"cpu_package_power_watts,omitempty" example:"35.2,30.1"` // Package power per socket in watts (Intel RAPL)
+
 	// Memory Information
 	RAMUsage   float64 `json:"ram_usage_percent" example:"65.5"`
 	RAMTotal   uint64  `json:"ram_total_bytes" example:"34359738368"`
@@ -28,6 +33,10 @@ type SystemInfo struct {
 	RAMBuffers uint64  `json:"ram_buffers_bytes" example:"1073741824"`
 	RAMCached  uint64  `json:"ram_cached_bytes" example:"8589934592"`
 
+	// MemoryBreakdown shows where the used RAM went; nil when /proc/meminfo
+	// cannot be read.
+	MemoryBreakdown *MemoryBreakdown `json:"memory_breakdown,omitempty"`
+
 	// Swap Information
 	SwapUsage float64 `json:"swap_usage_percent" example:"12.5"`
 	SwapTotal uint64  `json:"swap_total_bytes" example:"8589934592"`
@@ -69,12 +78,65 @@ type SystemInfo struct {
 	SourceStatus *SourceStatus `json:"source_status,omitempty"`
 }
 
+// MemoryBreakdown splits memory use into huge pages, tmpfs, kernel slab and
+// the share of Docker containers, VMs and the host.
+type MemoryBreakdown struct {
+	// Huge pages (/proc/meminfo). The pool is reserved at boot or by VMs
+	// with hugepage backing and is not available to anything else.
+	HugePagesTotal    uint64 `json:"hugepages_total" example:"8192"`
+	HugePagesFree     uint64 `json:"hugepages_free" example:"0"`
+	HugePagesReserved uint64 `json:"hugepages_reserved" example:"0"`
+	HugePageSize      uint64 `json:"hugepage_size_bytes" example:"2097152"`
+	HugePagesBytes    uint64 `json:"hugepages_bytes" example:"17179869184"`
+	HugePagesUsed     uint64 `json:"hugepages_used_bytes" example:"17179869184"`
+
+	// Kernel slab caches; reclaimable slab (dentries, inodes) is freed under
+	// memory pressure.
+	Slab            uint64 `json:"slab_bytes" example:"1073741824"`
+	SlabReclaimable uint64 `json:"slab_reclaimable_bytes" example:"805306368"`
+	SlabUnreclaim   uint64 `json:"slab_unreclaimable_bytes" example:"268435456"`
+
+	// Shmem is the RAM held by tmpfs files and shared memory, counted in
+	// ram_cached_bytes but not freeable by dropping caches.
+	Shmem uint64       `json:"shmem_bytes" example:"536870912"`
+	Tmpfs []TmpfsUsage `json:"tmpfs,omitempty"`
+
+	// Attribution of ram_used_bytes. DockerBytes is the containers'
+	// anonymous and kernel memory (their page cache is in ram_cached_bytes);
+	// VMBytes is the resident memory of QEMU processes plus the huge pages in
+	// use; HostBytes is the remainder, including the kernel and free huge pages.
+	DockerBytes uint64 `json:"docker_bytes" example:"4294967296"`
+	VMBytes     uint64 `json:"vm_bytes" example:"2147483648"`
+	HostBytes   uint64 `json:"host_bytes" example:"3221225472"`
+}
+
+// TmpfsUsage is the usage of a RAM-backed tmpfs mount.
+type TmpfsUsage struct {
+	Mount     string  `json:"mount" example:"/var/log"`
+	SizeBytes uint64  `json:"size_bytes" example:"134217728"`
+	UsedBytes uint64  `json:"used_bytes" example:"12582912"`
+	Usage     float64 `json:"usage_percent" example:"9.4"`
+}
+
 // FanInfo contains fan speed information
 type FanInfo struct {
 	Name string `json:"name" example:"CPU Fan"`
 	RPM  int    `json:"rpm" example:"1200"`
 }
 
+// CPUCoreTemp is the temperature of one physical CPU core.
+type CPUCoreTemp struct {
+	Package int     `json:"package" example:"0"` // CPU socket
+	Core    int     `json:"core" example:"3"`    // Core number within the socket, as reported by coretemp
+	TempC   float64 `json:"temp_celsius" example:"52.0"`
+}
+
+// CPUCoreFrequency is the current clock frequency of one logical CPU.
+type CPUCoreFrequency struct {
+	CPU int     `json:"cpu" example:"5"`
+	MHz float64 `json:"mhz" example:"4700.0"`
+}
+
 // TemperatureReading represents a single temperature sensor reading
 type TemperatureReading struct {
 	Name       string  `json:"name" example:"coretemp_Core_0"`
@@ -97,3 +159,39 @@ type CPUPowerState struct {
 type CPUGovernorRequest struct {
 	Governor string `json:"governor" validate:"required" example:"performance"`
 }
+
+// Orchestrated shutdown steps, in the order they run.
+const (
+	ShutdownStepVMs        = "vms"
+	ShutdownStepContainers = "containers"
+	ShutdownStepArray      = "array"
+	ShutdownStepSync       = "sync"
+	ShutdownStepPowerOff   = "poweroff"
+)
+
+// Orchestrated shutdown step states.
+const (
+	ShutdownStateRunning = "running"
+	ShutdownStateDone    = "done"
+	// ShutdownStateWarning means the step had problems but the sequence continued.
+	ShutdownStateWarning = "warning"
+	// ShutdownStateFailed means the step failed and the sequence was aborted
+	// before powering off.
+	ShutdownStateFailed = "failed"
+)
+
+// ShutdownProgress is one progress event of an orchestrated shutdown,
+// broadcast on the WebSocket topic "shutdown_progress".
+type ShutdownProgress struct {
+	Step      string    `json:"step" example:"containers"`
+	State     string    `json:"state" example:"running"`
+	Message   string    `json:"message" example:"stopping container plex (3/12)"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// OrchestratedShutdownRequest is the request body for
+// POST /system/shutdown/orchestrated.
+type OrchestratedShutdownRequest struct {
+	// Confirm must be set to true to authorise powering off the server.
+	Confirm bool `json:"confirm"`
+}
@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+// Thermal sensor kinds
+const (
+	ThermalSensorCPU         = "cpu"
+	ThermalSensorMotherboard = "motherboard"
+	ThermalSensorDisk        = "disk"
+	ThermalSensorGPU         = "gpu"
+)
+
+// Thermal levels
+const (
+	ThermalLevelOK       = "ok"
+	ThermalLevelWarning  = "warning"
+	ThermalLevelCritical = "critical"
+	// ThermalLevelResolved marks the event emitted when a sensor that had a
+	// thermal event drops back below its warning threshold.
+	ThermalLevelResolved = "resolved"
+)
+
+// ThermalSensorStats summarizes one temperature sensor over the history window.
+type ThermalSensorStats struct {
+	Sensor    string  `json:"sensor" example:"disk"`            // "cpu", "motherboard", "disk", "gpu"
+	Entity    string  `json:"entity,omitempty" example:"disk1"` // Disk ID or GPU index
+	Name      string  `json:"name" example:"Disk 1"`            // Display name
+	CurrentC  float64 `json:"current_celsius" example:"41"`     // Latest reading
+	MaxC      float64 `json:"max_celsius" example:"46"`         // Highest reading in the window
+	AvgC      float64 `json:"avg_celsius" example:"39.4"`       // Average reading in the window
+	Samples   int     `json:"samples" example:"480"`            // Readings in the window
+	WarningC  float64 `json:"warning_celsius" example:"45"`     // Warning threshold
+	CriticalC float64 `json:"critical_celsius" example:"55"`    // Critical threshold
+	Level     string  `json:"level" example:"ok"`               // Sustained level: "ok", "warning", "critical"
+}
+
+// ThermalEvent is emitted when a sensor stays above its warning or critical
+// threshold for the sustain period, and again when it recovers. It is
+// broadcast on the WebSocket topic "thermal_event".
+type ThermalEvent struct {
+	Sensor       string    `json:"sensor" example:"disk"`
+	Entity       string    `json:"entity,omitempty" example:"disk3"`
+	Name         string    `json:"name" example:"Disk 3"`
+	Level        string    `json:"level" example:"warning"` // "warning", "critical", "resolved"
+	TemperatureC float64   `json:"temperature_celsius" example:"52"`
+	ThresholdC   float64   `json:"threshold_celsius" example:"45"`
+	Since        time.Time `json:"since"` // When the temperature first crossed the threshold
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// ThermalSummary is the temperature history of every sensor with recent
+// thermal events.
+type ThermalSummary struct {
+	WindowSeconds  int64                `json:"window_seconds" example:"86400"`
+	SustainSeconds int64                `json:"sustain_seconds" example:"300"` // How long a threshold must be exceeded before an event
+	Sensors        []ThermalSensorStats `json:"sensors"`
+	Events         []ThermalEvent       `json:"events"` // Recent events, oldest first
+	Timestamp      time.Time            `json:"timestamp"`
+}
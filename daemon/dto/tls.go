@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// TLS modes reported in TLSStatus.Mode.
+const (
+	TLSModeOff   = "off"
+	TLSModeFiles = "files"
+	TLSModeACME  = "acme"
+)
+
+// TLSStatus describes the certificate served by the HTTPS listener and, with
+// ACME, the state of its automatic renewal.
+type TLSStatus struct {
+	// Mode is "off" (plain HTTP), "files" (TLS_CERT_FILE/TLS_KEY_FILE) or
+	// "acme" (issued and renewed automatically).
+	Mode      string     `json:"mode" example:"acme"`
+	Domains   []string   `json:"domains,omitempty"`
+	Issuer    string     `json:"issuer,omitempty" example:"R11"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+
+	// DNSProvider publishes the DNS-01 challenge records (ACME only).
+	DNSProvider string `json:"dns_provider,omitempty" example:"cloudflare"`
+	// SelfSigned is true while a temporary certificate is served until the
+	// first one is issued.
+	SelfSigned  bool       `json:"self_signed,omitempty"`
+	Renewing    bool       `json:"renewing,omitempty"`
+	LastAttempt *time.Time `json:"last_attempt,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	// NextRenewal is when the certificate becomes due for renewal.
+	NextRenewal *time.Time `json:"next_renewal,omitempty"`
+	Timestamp   time.Time  `json:"timestamp"`
+}
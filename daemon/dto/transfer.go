@@ -0,0 +1,94 @@
+package dto
+
+import "time"
+
+// TransferTool is the program a transfer job runs.
+type TransferTool string
+
+const (
+	// TransferToolRsync copies with rsync (local paths or host:path over SSH).
+	TransferToolRsync TransferTool = "rsync"
+
+	// TransferToolRclone copies with rclone (local paths or configured remotes).
+	TransferToolRclone TransferTool = "rclone"
+)
+
+// TransferRunState is the state of a transfer run.
+type TransferRunState string
+
+const (
+	// TransferRunRunning means the transfer is in progress.
+	TransferRunRunning TransferRunState = "running"
+
+	// TransferRunSucceeded means the tool exited successfully.
+	TransferRunSucceeded TransferRunState = "succeeded"
+
+	// TransferRunFailed means the tool could not be started or exited with an error.
+	TransferRunFailed TransferRunState = "failed"
+
+	// TransferRunCancelled means the run was cancelled or the agent stopped.
+	TransferRunCancelled TransferRunState = "cancelled"
+)
+
+// TransferJob defines a supervised rsync or rclone transfer.
+type TransferJob struct {
+	// ID is the unique identifier (lowercase letters, digits, "-" and "_").
+	ID string `json:"id" example:"appdata-backup"`
+
+	// Name is a human-readable name for this job.
+	Name string `json:"name" example:"Appdata backup"`
+
+	// Tool is the program to run: "rsync" or "rclone".
+	Tool TransferTool `json:"tool" example:"rsync"`
+
+	// Operation is the rclone subcommand: "copy" (default), "sync" or "move".
+	// Ignored for rsync.
+	Operation string `json:"operation,omitempty" example:"copy"`
+
+	// Source and Destination are passed to the tool unchanged.
+	Source      string `json:"source" example:"/mnt/user/appdata/"`
+	Destination string `json:"destination" example:"/mnt/user/backups/appdata/"`
+
+	// Flags are extra options, e.g. ["-a", "--delete"]. Options that make the
+	// tool run other programs (such as rsync -e) are rejected.
+	Flags []string `json:"flags,omitempty" example:"-a,--delete"`
+
+	// ScheduleIntervalMinutes runs the job every N minutes (0 = not scheduled).
+	ScheduleIntervalMinutes int `json:"schedule_interval_minutes,omitempty" example:"0"`
+
+	// ScheduleDailyAt runs the job once a day at this local time ("HH:MM").
+	ScheduleDailyAt string `json:"schedule_daily_at,omitempty" example:"03:00"`
+
+	// Enabled determines whether the schedule is active. Disabled jobs can
+	// still be run manually.
+	Enabled bool `json:"enabled" example:"true"`
+}
+
+// TransferRun is one execution of a transfer job with its latest progress.
+type TransferRun struct {
+	ID      string           `json:"id" example:"appdata-backup-1760655600"`
+	JobID   string           `json:"job_id" example:"appdata-backup"`
+	JobName string           `json:"job_name" example:"Appdata backup"`
+	Tool    TransferTool     `json:"tool" example:"rsync"`
+	Trigger string           `json:"trigger" example:"manual"` // manual or schedule
+	State   TransferRunState `json:"state" example:"running"`
+
+	BytesTransferred uint64  `json:"bytes_transferred" example:"1073741824"`
+	TotalBytes       uint64  `json:"total_bytes,omitempty" example:"4294967296"` // Estimated; 0 until known
+	Percent          float64 `json:"percent" example:"25"`
+	SpeedBytesPerSec float64 `json:"speed_bytes_per_sec" example:"52428800"`
+	ETASeconds       int64   `json:"eta_seconds,omitempty" example:"61"`
+	FilesTransferred uint64  `json:"files_transferred" example:"120"`
+
+	ExitCode   int        `json:"exit_code,omitempty" example:"0"`
+	Error      string     `json:"error,omitempty"`
+	Output     []string   `json:"output,omitempty"` // Last lines of tool output other than progress
+	StartedAt  time.Time  `json:"started_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// TransferJobsConfig is the on-disk JSON structure for transfer job persistence.
+type TransferJobsConfig struct {
+	Jobs []TransferJob `json:"jobs"`
+}
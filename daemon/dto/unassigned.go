@@ -95,3 +95,11 @@ type UnassignedDeviceList struct {
 type RemoteShareActionRequest struct {
 	Source string `json:"source" validate:"required" example:"//server/share"`
 }
+
+// UnassignedFormatRequest is the request body for formatting an unassigned
+// device. All data on the device is erased.
+type UnassignedFormatRequest struct {
+	Filesystem string `json:"filesystem" validate:"required" example:"xfs"` // "xfs", "btrfs", "exfat"
+	// Confirm must be set to true to authorise the destructive format operation.
+	Confirm bool `json:"confirm"`
+}
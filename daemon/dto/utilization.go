@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// Filesystem kinds evaluated for utilization alarms
+const (
+	UtilizationKindDisk = "disk" // Array data disk
+	UtilizationKindPool = "pool"
+)
+
+// UtilizationStatus is the utilization level of one array disk or pool.
+type UtilizationStatus struct {
+	Kind        string  `json:"kind" example:"disk"` // "disk", "pool"
+	Entity      string  `json:"entity" example:"disk2"`
+	Name        string  `json:"name" example:"Disk 2"`
+	UsedPercent float64 `json:"used_percent" example:"91.4"`
+	Level       string  `json:"level" example:"critical"` // "ok", "warning", "critical"
+	// Since is when the current warning or critical level was entered.
+	Since *time.Time `json:"since,omitempty"`
+}
+
+// UtilizationEvent is emitted once whenever an array disk or pool changes
+// utilization level. It is broadcast on the WebSocket topic
+// "utilization_event".
+type UtilizationEvent struct {
+	Kind             string    `json:"kind" example:"disk"`
+	Entity           string    `json:"entity" example:"disk2"`
+	Name             string    `json:"name" example:"Disk 2"`
+	Level            string    `json:"level" example:"critical"` // "warning", "critical", "resolved"
+	PreviousLevel    string    `json:"previous_level" example:"warning"`
+	UsedPercent      float64   `json:"used_percent" example:"91.4"`
+	ThresholdPercent float64   `json:"threshold_percent" example:"90"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// UtilizationSummary is the utilization level of every array disk and pool
+// with the thresholds from the Unraid disk settings and recent events.
+type UtilizationSummary struct {
+	WarningPercent    int                 `json:"warning_percent" example:"70"`   // 0 disables the warning level
+	CriticalPercent   int                 `json:"critical_percent" example:"90"`  // 0 disables the critical level
+	HysteresisPercent int                 `json:"hysteresis_percent" example:"2"` // How far usage must fall below a threshold to leave its level
+	Filesystems       []UtilizationStatus `json:"filesystems"`
+	Events            []UtilizationEvent  `json:"events"` // Recent events, oldest first
+	Timestamp         time.Time           `json:"timestamp"`
+}
@@ -23,6 +23,13 @@ type VMInfo struct {
 	PersistentState bool      `json:"persistent" example:"true"`
 	Timestamp       time.Time `json:"timestamp"`
 
+	// NetworkRXBytesPerSec and NetworkTXBytesPerSec are the throughput of the
+	// VM's host tap interfaces, from the guest traffic collector.
+	NetworkRXBytesPerSec float64 `json:"network_rx_bytes_per_sec" example:"10240"`
+	NetworkTXBytesPerSec float64 `json:"network_tx_bytes_per_sec" example:"2048"`
+	// Interfaces lists the VM's host tap interfaces (vnetX) with their traffic.
+	Interfaces []GuestInterfaceTraffic `json:"interfaces,omitempty"`
+
 	SourceStatus *SourceStatus `json:"source_status,omitempty"`
 }
 
@@ -51,3 +58,101 @@ type VMSnapshotList struct {
 	Count     int          `json:"count" example:"3"`
 	Timestamp time.Time    `json:"timestamp"`
 }
+
+// HostPCIDevice is a PCI device on the host with the information needed to
+// plan VM passthrough.
+type HostPCIDevice struct {
+	Address    string `json:"address" example:"0000:01:00.0"`
+	VendorID   string `json:"vendor_id" example:"10de"`
+	DeviceID   string `json:"device_id" example:"1b80"`
+	Vendor     string `json:"vendor,omitempty" example:"NVIDIA Corporation"`
+	Name       string `json:"name,omitempty" example:"GP104 [GeForce GTX 1080]"`
+	Class      string `json:"class,omitempty" example:"VGA compatible controller"`
+	ClassID    string `json:"class_id" example:"030000"`
+	IOMMUGroup *int   `json:"iommu_group,omitempty" example:"14"`
+	// Driver is the kernel driver bound to the device; "vfio-pci" means it is
+	// reserved for passthrough.
+	Driver string `json:"driver,omitempty" example:"vfio-pci"`
+	// AssignedTo lists the VMs whose definition passes this device through.
+	AssignedTo []string `json:"assigned_to,omitempty"`
+}
+
+// HostUSBDevice is a USB device attached to the host.
+type HostUSBDevice struct {
+	// ID is "vendor:product", the form used by the USB hotplug endpoints.
+	ID           string `json:"id" example:"046d:c52b"`
+	VendorID     string `json:"vendor_id" example:"046d"`
+	ProductID    string `json:"product_id" example:"c52b"`
+	Manufacturer string `json:"manufacturer,omitempty" example:"Logitech"`
+	Product      string `json:"product,omitempty" example:"USB Receiver"`
+	Bus          int    `json:"bus" example:"1"`
+	Device       int    `json:"device" example:"3"`
+	// AssignedTo lists the VMs whose definition (or live configuration, for a
+	// running VM) passes this device through.
+	AssignedTo []string `json:"assigned_to,omitempty"`
+}
+
+// VMUSBDeviceRequest is the request body for POST /vm/{name}/usb/attach and
+// POST /vm/{name}/usb/detach.
+type VMUSBDeviceRequest struct {
+	// DeviceID is the USB device as "vendor:product" in hex, as listed in
+	// host_usb_devices of GET /settings/vm.
+	DeviceID string `json:"device_id" example:"046d:c52b"`
+}
+
+// VMDiskImage is a file-backed disk of a VM with its format and sizes as
+// reported by qemu-img.
+type VMDiskImage struct {
+	// Target is the guest device name from the VM definition.
+	Target string `json:"target" example:"hdc"`
+	Bus    string `json:"bus,omitempty" example:"virtio"`
+	Path   string `json:"path" example:"/mnt/user/domains/Windows 11/vdisk1.img"`
+	// Format is the image format detected by qemu-img ("qcow2", "raw", ...).
+	Format string `json:"format" example:"raw"`
+	// VirtualSizeBytes is the disk size seen by the guest.
+	VirtualSizeBytes uint64 `json:"virtual_size_bytes" example:"107374182400"`
+	// ActualSizeBytes is the space the image file occupies on disk, which is
+	// smaller than the virtual size for sparse and qcow2 images.
+	ActualSizeBytes uint64 `json:"actual_size_bytes" example:"32212254720"`
+	BackingFile     string `json:"backing_file,omitempty"`
+	// Error is set when qemu-img could not read the image.
+	Error string `json:"error,omitempty"`
+	// Conversion is the running or most recent format conversion of this disk.
+	Conversion *VMDiskConversion `json:"conversion,omitempty"`
+}
+
+// VMDiskImageList lists the file-backed disks of a VM.
+type VMDiskImageList struct {
+	VMName string `json:"vm_name" example:"Windows 11"`
+	// ShutOff reports whether the VM is shut off, which resizing and
+	// converting disk images require.
+	ShutOff   bool          `json:"shut_off" example:"true"`
+	Disks     []VMDiskImage `json:"disks"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// VMDiskResizeRequest is the request body for POST /vm/{name}/disks/{target}/resize.
+type VMDiskResizeRequest struct {
+	// SizeBytes is the new virtual size; it must be larger than the current one.
+	SizeBytes uint64 `json:"size_bytes" example:"161061273600"`
+}
+
+// VMDiskConvertRequest is the request body for POST /vm/{name}/disks/{target}/convert.
+type VMDiskConvertRequest struct {
+	// Format is the target image format: "qcow2" or "raw".
+	Format string `json:"format" example:"qcow2"`
+}
+
+// VMDiskConversion is the state of a disk image format conversion.
+type VMDiskConversion struct {
+	VMName       string `json:"vm_name" example:"Windows 11"`
+	Target       string `json:"target" example:"hdc"`
+	SourcePath   string `json:"source_path" example:"/mnt/user/domains/Windows 11/vdisk1.img"`
+	Destination  string `json:"destination" example:"/mnt/user/domains/Windows 11/vdisk1.qcow2"`
+	TargetFormat string `json:"target_format" example:"qcow2"`
+	// State is "running", "succeeded" or "failed".
+	State      string     `json:"state" example:"running"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
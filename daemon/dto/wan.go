@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// WANProbeResult is the latency and packet loss measured to one probe target.
+// @Description Ping statistics for a single WAN probe target.
+type WANProbeResult struct {
+	// Target is the probed host or IP address.
+	Target string `json:"target" example:"1.1.1.1"`
+	// Reachable is true when at least one echo reply was received.
+	Reachable bool `json:"reachable"`
+	// LatencyMs is the average round-trip time in milliseconds (0 when unreachable).
+	LatencyMs float64 `json:"latency_ms" example:"11.8"`
+	// PacketLossPct is the percentage of echo requests that went unanswered.
+	PacketLossPct float64 `json:"packet_loss_percent" example:"0"`
+	// Error describes why the probe failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// WANStatus is the envelope published on TopicWANStatusUpdate and served by
+// GET /api/v1/network/wan.
+// @Description Internet connectivity, public IP, and probe latency/packet loss.
+type WANStatus struct {
+	// Online is true when any probe target answered or the public IP lookup succeeded.
+	Online bool `json:"online"`
+	// PublicIP is the current public IPv4 address as seen by external lookup services.
+	PublicIP string `json:"public_ip,omitempty" example:"203.0.113.45"`
+	// PreviousPublicIP is the public IP before the most recent change.
+	PreviousPublicIP string `json:"previous_public_ip,omitempty" example:"203.0.113.12"`
+	// PublicIPChangedAt is when the most recent public IP change was detected.
+	PublicIPChangedAt *time.Time `json:"public_ip_changed_at,omitempty"`
+	// AvgLatencyMs is the mean latency across reachable probe targets.
+	AvgLatencyMs float64 `json:"avg_latency_ms" example:"12.3"`
+	// PacketLossPct is the mean packet loss across all probe targets.
+	PacketLossPct float64 `json:"packet_loss_percent" example:"0"`
+	// Probes holds the per-target results.
+	Probes []WANProbeResult `json:"probes"`
+	// Timestamp is when this status was collected.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WANIPChange is published on TopicWANIPChanged when the public IP changes.
+// @Description Public IP change event.
+type WANIPChange struct {
+	OldIP     string    `json:"old_ip" example:"203.0.113.12"`
+	NewIP     string    `json:"new_ip" example:"203.0.113.45"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -7,13 +7,19 @@ type WSEvent struct {
 	Event     string    `json:"event" example:"system_update"`
 	Timestamp time.Time `json:"timestamp"`
 	Data      any       `json:"data"`
+	// Replay marks an event published before the client connected or
+	// subscribed, sent so it can render current state immediately.
+	Replay bool `json:"replay,omitempty"`
 }
 
 // Response represents a standard API response
 type Response struct {
-	Success   bool      `json:"success" example:"true"`
-	Message   string    `json:"message,omitempty" example:"Operation completed successfully"`
-	Error     string    `json:"error,omitempty" example:""`
-	Data      any       `json:"data,omitempty"`
-	Timestamp time.Time `json:"timestamp"`
+	Success bool   `json:"success" example:"true"`
+	Message string `json:"message,omitempty" example:"Operation completed successfully"`
+	Error   string `json:"error,omitempty" example:""`
+	Data    any    `json:"data,omitempty"`
+	// CorrelationID is the request's X-Request-ID, set on API error responses
+	// so a failure can be matched to the agent's log entries.
+	CorrelationID string    `json:"correlation_id,omitempty" example:"3f0c2a9e-8d1b-4c55-9a8e-2b7f6d1e4a10"`
+	Timestamp     time.Time `json:"timestamp"`
 }
@@ -20,6 +20,12 @@ type ZFSPool struct {
 	FragmentationPct float64 `json:"fragmentation_percent" example:"5"`     // Fragmentation %
 	CapacityPct      float64 `json:"capacity_percent" example:"50"`         // Usage %
 
+	// Fill forecast from the trend of allocated space over the last 7 days;
+	// nil until 6 hours of history exist. DaysUntilFull is nil while usage is
+	// flat or shrinking.
+	FillRateBytesPerDay *float64 `json:"fill_rate_bytes_per_day,omitempty" example:"1073741824"`
+	DaysUntilFull       *float64 `json:"days_until_full,omitempty" example:"50"`
+
 	// Deduplication and Compression
 	DedupRatio    float64 `json:"dedup_ratio" example:"1.00"`              // Deduplication ratio (e.g., 1.00 = no dedup)
 	CompressRatio float64 `json:"compress_ratio,omitempty" example:"1.50"` // Compression ratio (e.g., 1.50 = 1.5x)
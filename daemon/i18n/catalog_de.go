@@ -0,0 +1,307 @@
+package i18n
+
+// german holds the German translations.
+var german = map[string]string{
+	// Notifications
+	"Alert: %s":                 "Alarm: %s",
+	"Resolved: %s":              "Behoben: %s",
+	"Management Agent Alert":    "Management-Agent-Alarm",
+	"%s temperature %s: %.0f°C": "%s Temperatur (%s): %.0f°C",
+	"%s has been at or above its %s threshold of %.0f°C since %s.":   "%s liegt seit %[4]s auf oder über dem Schwellenwert (%[2]s) von %.0[3]f°C.",
+	"%s temperature back to normal: %.0f°C":                          "%s Temperatur wieder normal: %.0f°C",
+	"%s is back below its warning threshold of %.0f°C.":              "%s liegt wieder unter dem Warnschwellenwert von %.0f°C.",
+	"%s usage %s: %.0f%%":                                            "%s Belegung (%s): %.0f%%",
+	"%s is %.0f%% full, at or above its %s threshold of %.0f%%.":     "%s ist zu %.0f%% belegt und liegt auf oder über dem Schwellenwert (%s) von %.0f%%.",
+	"%s usage back to normal: %.0f%%":                                "%s Belegung wieder normal: %.0f%%",
+	"%s is %.0f%% full, back below its warning threshold of %.0f%%.": "%s ist zu %.0f%% belegt und liegt wieder unter dem Warnschwellenwert von %.0f%%.",
+	"critical":             "kritisch",
+	"warning":              "Warnung",
+	"Boot sequence failed": "Startsequenz fehlgeschlagen",
+	"The array and guests were not started: %s": "Array und Gäste wurden nicht gestartet: %s",
+	"Container updates available":               "Container-Updates verfügbar",
+	"Updates available for: %s":                 "Updates verfügbar für: %s",
+	"Plugin updates available":                  "Plugin-Updates verfügbar",
+	"Plugins":                                   "Plugins",
+	"Unraid OS update available":                "Unraid-OS-Update verfügbar",
+	"System":                                    "System",
+	"A new Unraid OS version is available: %s":  "Eine neue Unraid-OS-Version ist verfügbar: %s",
+	"Shutdown aborted":                          "Herunterfahren abgebrochen",
+	"Orchestrated shutdown aborted":             "Geordnetes Herunterfahren abgebrochen",
+	"The server was not powered off: %v":        "Der Server wurde nicht ausgeschaltet: %v",
+	"Health check '%s' (%s) failed: %s":         "Integritätsprüfung '%s' (%s) fehlgeschlagen: %s",
+	"Health Check":                              "Integritätsprüfung",
+	"Unhealthy container":                       "Fehlerhafter Container",
+	"Container '%s' is still unhealthy after %d automatic restarts in the last 24 hours; not restarting it again.": "Container '%s' ist nach %d automatischen Neustarts in den letzten 24 Stunden weiterhin fehlerhaft; er wird nicht erneut neu gestartet.",
+	"Container '%s' has been unhealthy for %s; restart failed: %v":                                                 "Container '%s' ist seit %s fehlerhaft; Neustart fehlgeschlagen: %v",
+	"Container '%s' was unhealthy for %s and has been restarted.":                                                  "Container '%s' war %s lang fehlerhaft und wurde neu gestartet.",
+
+	// Health report
+	"Array not started": "Array nicht gestartet",
+	"Array is in state %q — data is inaccessible until the array is started.": "Das Array hat den Status %q – die Daten sind erst nach dem Start des Arrays zugänglich.",
+	"Disk %s SMART failure": "SMART-Fehler auf Festplatte %s",
+	"Disk %s reported SMART status %q. Backup data and replace the disk.": "Festplatte %s meldet den SMART-Status %q. Sichern Sie die Daten und tauschen Sie die Festplatte aus.",
+	"Disk %s high temperature": "Hohe Temperatur auf Festplatte %s",
+	"Disk %s temperature is %.0f °C (threshold: %.0f °C). Improve airflow or reduce load.": "Festplatte %s hat %.0f °C (Schwellenwert: %.0f °C). Verbessern Sie die Belüftung oder verringern Sie die Last.",
+	"Container %q is not running":                                                           "Container %q läuft nicht",
+	"Container %q is in state %q.":                                                          "Container %q hat den Status %q.",
+	"Container %q is not running (restarted %d times)":                                      "Container %q läuft nicht (%d-mal neu gestartet)",
+	"Container %q is in state %q and has restarted %d times — it may be crash-looping.":     "Container %q hat den Status %q und wurde %d-mal neu gestartet – er stürzt möglicherweise wiederholt ab.",
+	"Start container %q to restore service.":                                                "Starten Sie Container %q, um den Dienst wiederherzustellen.",
+	"Container %q has an update available":                                                  "Für Container %q ist ein Update verfügbar",
+	"A newer image is available for container %q. Update via the Docker UI or docker pull.": "Für Container %q ist ein neueres Image verfügbar. Aktualisieren Sie über die Docker-Oberfläche oder mit docker pull.",
+	"Alert rule %q is firing.":                                                              "Alarmregel %q ist ausgelöst.",
+	"Firing alert: %s":                                                                      "Ausgelöster Alarm: %s",
+
+	// Home Assistant entities
+	"Collector: %s":                   "Collector: %s",
+	"Collector: %s Interval":          "Collector: %s Intervall",
+	"System: CPU Usage":               "System: CPU-Auslastung",
+	"System: CPU Temperature":         "System: CPU-Temperatur",
+	"System: CPU Frequency":           "System: CPU-Frequenz",
+	"System: CPU Power":               "System: CPU-Leistung",
+	"System: DRAM Power":              "System: DRAM-Leistung",
+	"System: RAM Usage":               "System: RAM-Auslastung",
+	"System: RAM Used":                "System: RAM belegt",
+	"System: RAM Free":                "System: RAM frei",
+	"System: RAM Total":               "System: RAM gesamt",
+	"System: Swap Usage":              "System: Swap-Auslastung",
+	"System: Swap Used":               "System: Swap belegt",
+	"System: Swap Free":               "System: Swap frei",
+	"System: Swap Total":              "System: Swap gesamt",
+	"System: Swappiness":              "System: Swappiness",
+	"System: Motherboard Temperature": "System: Mainboard-Temperatur",
+	"System: Uptime":                  "System: Betriebszeit",
+	"System: Unraid Version":          "System: Unraid-Version",
+	"System: Agent Version":           "System: Agent-Version",
+	"System: Kernel Version":          "System: Kernel-Version",
+	"System: CPU Model":               "System: CPU-Modell",
+	"System: CPU Cores":               "System: CPU-Kerne",
+	"System: HVM Support":             "System: HVM-Unterstützung",
+	"System: IOMMU Support":           "System: IOMMU-Unterstützung",
+	"System: %s":                      "System: %s",
+	"System: Reboot":                  "System: Neustart",
+	"System: Shutdown":                "System: Herunterfahren",
+	"System: Maintenance Mode":        "System: Wartungsmodus",
+	"System: Maintenance Ends":        "System: Wartungsende",
+
+	"Array: State":               "Array: Status",
+	"Array: Usage":               "Array: Auslastung",
+	"Array: Free Space":          "Array: Freier Speicher",
+	"Array: Total Space":         "Array: Gesamtspeicher",
+	"Array: Fill Rate":           "Array: Füllrate",
+	"Array: Days Until Full":     "Array: Tage bis voll",
+	"Array: Disk Count":          "Array: Anzahl Festplatten",
+	"Array: Parity Status":       "Array: Paritätsstatus",
+	"Array: Parity Progress":     "Array: Paritätsfortschritt",
+	"Array: Parity Speed":        "Array: Paritätsgeschwindigkeit",
+	"Array: Parity Finishes At":  "Array: Parität fertig um",
+	"Array: Parity Valid":        "Array: Parität gültig",
+	"Array: Started":             "Array: Gestartet",
+	"Array: Power":               "Array: Ein/Aus",
+	"Array: Start Parity Check":  "Array: Paritätsprüfung starten",
+	"Array: Stop Parity Check":   "Array: Paritätsprüfung stoppen",
+	"Array: Pause Parity Check":  "Array: Paritätsprüfung pausieren",
+	"Array: Resume Parity Check": "Array: Paritätsprüfung fortsetzen",
+	"Array: Next Parity Check":   "Array: Nächste Paritätsprüfung",
+	"Array: Parity Schedule":     "Array: Paritätszeitplan",
+
+	"UPS: Connected":         "USV: Verbunden",
+	"UPS: Status":            "USV: Status",
+	"UPS: Load":              "USV: Last",
+	"UPS: Battery Level":     "USV: Akkustand",
+	"UPS: Runtime Remaining": "USV: Restlaufzeit",
+	"UPS: Power Draw":        "USV: Leistungsaufnahme",
+	"UPS: Model":             "USV: Modell",
+
+	"Notifications: Unread":      "Benachrichtigungen: Ungelesen",
+	"Notifications: Alerts":      "Benachrichtigungen: Alarme",
+	"Notifications: Warnings":    "Benachrichtigungen: Warnungen",
+	"Notifications: Info":        "Benachrichtigungen: Info",
+	"Notifications: Archive All": "Benachrichtigungen: Alle archivieren",
+	"Notifications: Event":       "Benachrichtigungen: Ereignis",
+
+	"Service: %s": "Dienst: %s",
+
+	"Disk: %s Temperature":     "Festplatte: %s Temperatur",
+	"Disk: %s Status":          "Festplatte: %s Status",
+	"Disk: %s SMART Status":    "Festplatte: %s SMART-Status",
+	"Disk: %s Usage":           "Festplatte: %s Auslastung",
+	"Disk: %s Used":            "Festplatte: %s belegt",
+	"Disk: %s Free":            "Festplatte: %s frei",
+	"Disk: %s Spin State":      "Festplatte: %s Drehzustand",
+	"Disk: %s Power On Hours":  "Festplatte: %s Betriebsstunden",
+	"Disk: %s I/O Utilization": "Festplatte: %s E/A-Auslastung",
+	"Disk: %s Healthy":         "Festplatte: %s fehlerfrei",
+	"Disk: %s Spin Up":         "Festplatte: %s hochfahren",
+	"Disk: %s Spin Down":       "Festplatte: %s herunterfahren",
+
+	"Docker: Total Containers":   "Docker: Container gesamt",
+	"Docker: Running Containers": "Docker: Laufende Container",
+	"Docker: %s Running":         "Docker: %s läuft",
+	"Docker: %s CPU":             "Docker: %s CPU",
+	"Docker: %s Memory":          "Docker: %s Arbeitsspeicher",
+	"Docker: %s Network RX":      "Docker: %s Netzwerk empfangen",
+	"Docker: %s Network TX":      "Docker: %s Netzwerk gesendet",
+	"Docker: %s MAC Address":     "Docker: %s MAC-Adresse",
+	"Docker: %s Health":          "Docker: %s Zustand",
+	"Docker: %s Power":           "Docker: %s Ein/Aus",
+	"Docker: %s Restart":         "Docker: %s neu starten",
+	"Docker: %s Pause":           "Docker: %s pausieren",
+	"Docker: %s Unpause":         "Docker: %s fortsetzen",
+
+	"VM: Total":               "VM: Gesamt",
+	"VM: Running":             "VM: Laufend",
+	"VM: %s Running":          "VM: %s läuft",
+	"VM: %s Guest CPU":        "VM: %s Gast-CPU",
+	"VM: %s Host CPU":         "VM: %s Host-CPU",
+	"VM: %s Memory Used":      "VM: %s Arbeitsspeicher belegt",
+	"VM: %s Memory Allocated": "VM: %s Arbeitsspeicher zugewiesen",
+	"VM: %s Power":            "VM: %s Ein/Aus",
+	"VM: %s Restart":          "VM: %s neu starten",
+	"VM: %s Pause":            "VM: %s pausieren",
+	"VM: %s Resume":           "VM: %s fortsetzen",
+	"VM: %s Hibernate":        "VM: %s Ruhezustand",
+	"VM: %s Force Stop":       "VM: %s erzwungen stoppen",
+
+	"GPU %d":                     "GPU %d",
+	"GPU: %s Temperature":        "GPU: %s Temperatur",
+	"GPU: %s Utilization":        "GPU: %s Auslastung",
+	"GPU: %s Memory Utilization": "GPU: %s Speicherauslastung",
+	"GPU: %s Memory Used":        "GPU: %s Speicher belegt",
+	"GPU: %s Power Draw":         "GPU: %s Leistungsaufnahme",
+	"GPU: %s Fan Speed":          "GPU: %s Lüfterdrehzahl",
+
+	"Network: %s Link":           "Netzwerk: %s Verbindung",
+	"Network: %s Speed":          "Netzwerk: %s Geschwindigkeit",
+	"Network: %s Throughput In":  "Netzwerk: %s Durchsatz eingehend",
+	"Network: %s Throughput Out": "Netzwerk: %s Durchsatz ausgehend",
+	"Network: %s RX Errors":      "Netzwerk: %s Empfangsfehler",
+	"Network: %s TX Errors":      "Netzwerk: %s Sendefehler",
+
+	"Share: %s Usage": "Freigabe: %s Auslastung",
+	"Share: %s Used":  "Freigabe: %s belegt",
+	"Share: %s Free":  "Freigabe: %s frei",
+
+	"ZFS: %s Health":          "ZFS: %s Zustand",
+	"ZFS: %s Usage":           "ZFS: %s Auslastung",
+	"ZFS: %s Free":            "ZFS: %s frei",
+	"ZFS: %s Fragmentation":   "ZFS: %s Fragmentierung",
+	"ZFS: %s Errors":          "ZFS: %s Fehler",
+	"ZFS: %s Healthy":         "ZFS: %s fehlerfrei",
+	"ZFS: %s Corrupted Files": "ZFS: %s beschädigte Dateien",
+	"ZFS: %s Fill Rate":       "ZFS: %s Füllrate",
+	"ZFS: %s Days Until Full": "ZFS: %s Tage bis voll",
+
+	"Pool: %s Usage":           "Pool: %s Auslastung",
+	"Pool: %s Free Space":      "Pool: %s freier Speicher",
+	"Pool: %s Fill Rate":       "Pool: %s Füllrate",
+	"Pool: %s Days Until Full": "Pool: %s Tage bis voll",
+
+	"Btrfs: %s Device Errors":     "Btrfs: %s Gerätefehler",
+	"Btrfs: %s Corruption Errors": "Btrfs: %s Beschädigungsfehler",
+	"Btrfs: %s Scrub Status":      "Btrfs: %s Scrub-Status",
+	"Btrfs: %s Problem":           "Btrfs: %s Problem",
+
+	"Recycle Bin: %s Size":            "Papierkorb: %s Größe",
+	"Recycle Bin: %s Files":           "Papierkorb: %s Dateien",
+	"Recycle Bin: %s Oldest File Age": "Papierkorb: %s Alter der ältesten Datei",
+	"Recycle Bin: %s Empty":           "Papierkorb: %s leeren",
+
+	"IPMI: Problem":           "IPMI: Problem",
+	"IPMI: Identify":          "IPMI: Identifizieren",
+	"IPMI: Chassis Power":     "IPMI: Gehäusestrom",
+	"IPMI: Chassis Intrusion": "IPMI: Gehäuse geöffnet",
+	"IPMI: %s":                "IPMI: %s",
+
+	"NUT: UPS Connected":   "NUT: USV verbunden",
+	"NUT: UPS Status":      "NUT: USV-Status",
+	"NUT: Battery Charge":  "NUT: Akkuladung",
+	"NUT: Battery Runtime": "NUT: Akkulaufzeit",
+	"NUT: Load":            "NUT: Last",
+	"NUT: Real Power":      "NUT: Wirkleistung",
+	"NUT: Input Voltage":   "NUT: Eingangsspannung",
+	"NUT: Output Voltage":  "NUT: Ausgangsspannung",
+	"NUT: UPS Model":       "NUT: USV-Modell",
+
+	"Hardware: BIOS Version":       "Hardware: BIOS-Version",
+	"Hardware: BIOS Release Date":  "Hardware: BIOS-Datum",
+	"Hardware: Board Manufacturer": "Hardware: Mainboard-Hersteller",
+	"Hardware: Board Model":        "Hardware: Mainboard-Modell",
+	"Hardware: CPU Max Speed":      "Hardware: Maximaler CPU-Takt",
+	"Hardware: Memory Slots":       "Hardware: Speichersteckplätze",
+	"Hardware: Chassis Serial":     "Hardware: Gehäuse-Seriennummer",
+	"Hardware: TPM Present":        "Hardware: TPM vorhanden",
+	"Hardware: Boot Device Type":   "Hardware: Boot-Gerätetyp",
+
+	"Registration: State":  "Registrierung: Status",
+	"Registration: Type":   "Registrierung: Typ",
+	"Registration: Valid":  "Registrierung: Gültig",
+	"Registration: Expiry": "Registrierung: Ablauf",
+
+	"Remote Share: %s Mounted": "Netzwerkfreigabe: %s eingehängt",
+	"Remote Share: %s Mount":   "Netzwerkfreigabe: %s einhängen",
+	"Remote Share: %s Usage":   "Netzwerkfreigabe: %s Auslastung",
+	"Remote Share: %s Used":    "Netzwerkfreigabe: %s belegt",
+	"Remote Share: %s Free":    "Netzwerkfreigabe: %s frei",
+
+	"Unassigned: %s Connected":   "Nicht zugewiesen: %s verbunden",
+	"Unassigned: %s Temperature": "Nicht zugewiesen: %s Temperatur",
+	"Unassigned: %s Spin State":  "Nicht zugewiesen: %s Drehzustand",
+	"Part %d":                    "Partition %d",
+	"Unassigned: %s %s Usage":    "Nicht zugewiesen: %s %s Auslastung",
+	"Unassigned: %s %s Used":     "Nicht zugewiesen: %s %s belegt",
+	"Unassigned: %s %s Free":     "Nicht zugewiesen: %s %s frei",
+
+	"ZFS Dataset: %s Used":              "ZFS-Dataset: %s belegt",
+	"ZFS Dataset: %s Available":         "ZFS-Dataset: %s verfügbar",
+	"ZFS Dataset: %s Compression Ratio": "ZFS-Dataset: %s Kompressionsrate",
+	"ZFS Dataset: %s Read-Only":         "ZFS-Dataset: %s schreibgeschützt",
+	"ZFS: Snapshot Count":               "ZFS: Anzahl Snapshots",
+	"ZFS: Snapshot Total Size":          "ZFS: Gesamtgröße Snapshots",
+	"ZFS ARC: Size":                     "ZFS ARC: Größe",
+	"ZFS ARC: Target Size":              "ZFS ARC: Zielgröße",
+	"ZFS ARC: Configured Max":           "ZFS ARC: Konfiguriertes Maximum",
+	"ZFS ARC: Hit Ratio":                "ZFS ARC: Trefferquote",
+	"ZFS L2ARC: Size":                   "ZFS L2ARC: Größe",
+	"ZFS L2ARC: Hit Ratio":              "ZFS L2ARC: Trefferquote",
+
+	"Fan Control: %s RPM":  "Lüftersteuerung: %s Drehzahl",
+	"Fan Control: %s PWM":  "Lüftersteuerung: %s PWM",
+	"Fan Control: %s Mode": "Lüftersteuerung: %s Modus",
+	"Fan Control: %s":      "Lüftersteuerung: %s",
+	"Fan Control: Enabled": "Lüftersteuerung: Aktiviert",
+
+	"Health Check: %s": "Integritätsprüfung: %s",
+
+	"WAN: Online":            "WAN: Online",
+	"WAN: Public IP":         "WAN: Öffentliche IP",
+	"WAN: Latency":           "WAN: Latenz",
+	"WAN: Packet Loss":       "WAN: Paketverlust",
+	"WAN: Public IP Changed": "WAN: Öffentliche IP geändert",
+
+	"Speedtest: Download": "Speedtest: Download",
+	"Speedtest: Upload":   "Speedtest: Upload",
+	"Speedtest: Ping":     "Speedtest: Ping",
+
+	"Power: Estimated Draw":         "Strom: Geschätzte Leistungsaufnahme",
+	"Power: Energy":                 "Strom: Energie",
+	"Power: Estimated Daily Energy": "Strom: Geschätzte Tagesenergie",
+	"Power: Estimate Source":        "Strom: Schätzungsquelle",
+
+	"Mover: Active":               "Mover: Aktiv",
+	"Mover: Last Run":             "Mover: Letzter Lauf",
+	"Mover: Last Run Duration":    "Mover: Dauer des letzten Laufs",
+	"Mover: Last Run Data Moved":  "Mover: Verschobene Daten (letzter Lauf)",
+	"Mover: Last Run Files Moved": "Mover: Verschobene Dateien (letzter Lauf)",
+
+	"Flash: Usage":      "Flash: Auslastung",
+	"Flash: Free Space": "Flash: Freier Speicher",
+	"Flash: GUID":       "Flash: GUID",
+
+	"Updates: Unraid OS":             "Updates: Unraid OS",
+	"Updates: Latest Unraid Version": "Updates: Neueste Unraid-Version",
+	"Updates: Plugins":               "Updates: Plugins",
+	"Updates: Containers":            "Updates: Container",
+
+	"Wake: %s": "Wecken: %s",
+}
@@ -0,0 +1,307 @@
+package i18n
+
+// spanish holds the Spanish translations.
+var spanish = map[string]string{
+	// Notifications
+	"Alert: %s":                 "Alerta: %s",
+	"Resolved: %s":              "Resuelto: %s",
+	"Management Agent Alert":    "Alerta del agente de gestión",
+	"%s temperature %s: %.0f°C": "%s temperatura (%s): %.0f°C",
+	"%s has been at or above its %s threshold of %.0f°C since %s.":   "%s está en su umbral (%s) de %.0f°C o por encima desde las %s.",
+	"%s temperature back to normal: %.0f°C":                          "%s temperatura de nuevo normal: %.0f°C",
+	"%s is back below its warning threshold of %.0f°C.":              "%s vuelve a estar por debajo de su umbral de advertencia de %.0f°C.",
+	"%s usage %s: %.0f%%":                                            "%s uso (%s): %.0f%%",
+	"%s is %.0f%% full, at or above its %s threshold of %.0f%%.":     "%s está lleno al %.0f%%, igual o por encima de su umbral (%s) de %.0f%%.",
+	"%s usage back to normal: %.0f%%":                                "%s uso de nuevo normal: %.0f%%",
+	"%s is %.0f%% full, back below its warning threshold of %.0f%%.": "%s está lleno al %.0f%%, de nuevo por debajo de su umbral de advertencia de %.0f%%.",
+	"critical":             "crítico",
+	"warning":              "advertencia",
+	"Boot sequence failed": "La secuencia de arranque ha fallado",
+	"The array and guests were not started: %s": "No se iniciaron el array ni los invitados: %s",
+	"Container updates available":               "Actualizaciones de contenedores disponibles",
+	"Updates available for: %s":                 "Actualizaciones disponibles para: %s",
+	"Plugin updates available":                  "Actualizaciones de plugins disponibles",
+	"Plugins":                                   "Plugins",
+	"Unraid OS update available":                "Actualización de Unraid OS disponible",
+	"System":                                    "Sistema",
+	"A new Unraid OS version is available: %s":  "Hay una nueva versión de Unraid OS disponible: %s",
+	"Shutdown aborted":                          "Apagado cancelado",
+	"Orchestrated shutdown aborted":             "Apagado ordenado cancelado",
+	"The server was not powered off: %v":        "El servidor no se apagó: %v",
+	"Health check '%s' (%s) failed: %s":         "La comprobación de estado '%s' (%s) ha fallado: %s",
+	"Health Check":                              "Comprobación de estado",
+	"Unhealthy container":                       "Contenedor no saludable",
+	"Container '%s' is still unhealthy after %d automatic restarts in the last 24 hours; not restarting it again.": "El contenedor '%s' sigue sin estar saludable tras %d reinicios automáticos en las últimas 24 horas; no se volverá a reiniciar.",
+	"Container '%s' has been unhealthy for %s; restart failed: %v":                                                 "El contenedor '%s' lleva %s sin estar saludable; el reinicio ha fallado: %v",
+	"Container '%s' was unhealthy for %s and has been restarted.":                                                  "El contenedor '%s' estuvo %s sin estar saludable y se ha reiniciado.",
+
+	// Health report
+	"Array not started": "Array no iniciado",
+	"Array is in state %q — data is inaccessible until the array is started.": "El array está en estado %q: los datos no son accesibles hasta que se inicie.",
+	"Disk %s SMART failure": "Fallo SMART en el disco %s",
+	"Disk %s reported SMART status %q. Backup data and replace the disk.": "El disco %s informa del estado SMART %q. Haga una copia de seguridad y sustituya el disco.",
+	"Disk %s high temperature": "Temperatura alta en el disco %s",
+	"Disk %s temperature is %.0f °C (threshold: %.0f °C). Improve airflow or reduce load.": "La temperatura del disco %s es de %.0f °C (umbral: %.0f °C). Mejore la ventilación o reduzca la carga.",
+	"Container %q is not running":                                                           "El contenedor %q no se está ejecutando",
+	"Container %q is in state %q.":                                                          "El contenedor %q está en estado %q.",
+	"Container %q is not running (restarted %d times)":                                      "El contenedor %q no se está ejecutando (reiniciado %d veces)",
+	"Container %q is in state %q and has restarted %d times — it may be crash-looping.":     "El contenedor %q está en estado %q y se ha reiniciado %d veces; puede estar fallando en bucle.",
+	"Start container %q to restore service.":                                                "Inicie el contenedor %q para restablecer el servicio.",
+	"Container %q has an update available":                                                  "Hay una actualización disponible para el contenedor %q",
+	"A newer image is available for container %q. Update via the Docker UI or docker pull.": "Hay una imagen más reciente para el contenedor %q. Actualícelo desde la interfaz de Docker o con docker pull.",
+	"Alert rule %q is firing.":                                                              "La regla de alerta %q está activa.",
+	"Firing alert: %s":                                                                      "Alerta activa: %s",
+
+	// Home Assistant entities
+	"Collector: %s":                   "Recolector: %s",
+	"Collector: %s Interval":          "Recolector: %s intervalo",
+	"System: CPU Usage":               "Sistema: uso de CPU",
+	"System: CPU Temperature":         "Sistema: temperatura de CPU",
+	"System: CPU Frequency":           "Sistema: frecuencia de CPU",
+	"System: CPU Power":               "Sistema: potencia de CPU",
+	"System: DRAM Power":              "Sistema: potencia de DRAM",
+	"System: RAM Usage":               "Sistema: uso de RAM",
+	"System: RAM Used":                "Sistema: RAM usada",
+	"System: RAM Free":                "Sistema: RAM libre",
+	"System: RAM Total":               "Sistema: RAM total",
+	"System: Swap Usage":              "Sistema: uso de swap",
+	"System: Swap Used":               "Sistema: swap usado",
+	"System: Swap Free":               "Sistema: swap libre",
+	"System: Swap Total":              "Sistema: swap total",
+	"System: Swappiness":              "Sistema: swappiness",
+	"System: Motherboard Temperature": "Sistema: temperatura de la placa base",
+	"System: Uptime":                  "Sistema: tiempo de actividad",
+	"System: Unraid Version":          "Sistema: versión de Unraid",
+	"System: Agent Version":           "Sistema: versión del agente",
+	"System: Kernel Version":          "Sistema: versión del kernel",
+	"System: CPU Model":               "Sistema: modelo de CPU",
+	"System: CPU Cores":               "Sistema: núcleos de CPU",
+	"System: HVM Support":             "Sistema: soporte HVM",
+	"System: IOMMU Support":           "Sistema: soporte IOMMU",
+	"System: %s":                      "Sistema: %s",
+	"System: Reboot":                  "Sistema: reiniciar",
+	"System: Shutdown":                "Sistema: apagar",
+	"System: Maintenance Mode":        "Sistema: modo de mantenimiento",
+	"System: Maintenance Ends":        "Sistema: fin del mantenimiento",
+
+	"Array: State":               "Array: estado",
+	"Array: Usage":               "Array: uso",
+	"Array: Free Space":          "Array: espacio libre",
+	"Array: Total Space":         "Array: espacio total",
+	"Array: Fill Rate":           "Array: ritmo de llenado",
+	"Array: Days Until Full":     "Array: días hasta llenarse",
+	"Array: Disk Count":          "Array: número de discos",
+	"Array: Parity Status":       "Array: estado de paridad",
+	"Array: Parity Progress":     "Array: progreso de paridad",
+	"Array: Parity Speed":        "Array: velocidad de paridad",
+	"Array: Parity Finishes At":  "Array: fin de la paridad",
+	"Array: Parity Valid":        "Array: paridad válida",
+	"Array: Started":             "Array: iniciado",
+	"Array: Power":               "Array: encendido",
+	"Array: Start Parity Check":  "Array: iniciar comprobación de paridad",
+	"Array: Stop Parity Check":   "Array: detener comprobación de paridad",
+	"Array: Pause Parity Check":  "Array: pausar comprobación de paridad",
+	"Array: Resume Parity Check": "Array: reanudar comprobación de paridad",
+	"Array: Next Parity Check":   "Array: próxima comprobación de paridad",
+	"Array: Parity Schedule":     "Array: programación de paridad",
+
+	"UPS: Connected":         "SAI: conectado",
+	"UPS: Status":            "SAI: estado",
+	"UPS: Load":              "SAI: carga",
+	"UPS: Battery Level":     "SAI: nivel de batería",
+	"UPS: Runtime Remaining": "SAI: autonomía restante",
+	"UPS: Power Draw":        "SAI: consumo",
+	"UPS: Model":             "SAI: modelo",
+
+	"Notifications: Unread":      "Notificaciones: sin leer",
+	"Notifications: Alerts":      "Notificaciones: alertas",
+	"Notifications: Warnings":    "Notificaciones: advertencias",
+	"Notifications: Info":        "Notificaciones: información",
+	"Notifications: Archive All": "Notificaciones: archivar todas",
+	"Notifications: Event":       "Notificaciones: evento",
+
+	"Service: %s": "Servicio: %s",
+
+	"Disk: %s Temperature":     "Disco: %s temperatura",
+	"Disk: %s Status":          "Disco: %s estado",
+	"Disk: %s SMART Status":    "Disco: %s estado SMART",
+	"Disk: %s Usage":           "Disco: %s uso",
+	"Disk: %s Used":            "Disco: %s usado",
+	"Disk: %s Free":            "Disco: %s libre",
+	"Disk: %s Spin State":      "Disco: %s estado de giro",
+	"Disk: %s Power On Hours":  "Disco: %s horas de funcionamiento",
+	"Disk: %s I/O Utilization": "Disco: %s uso de E/S",
+	"Disk: %s Healthy":         "Disco: %s en buen estado",
+	"Disk: %s Spin Up":         "Disco: %s activar giro",
+	"Disk: %s Spin Down":       "Disco: %s detener giro",
+
+	"Docker: Total Containers":   "Docker: contenedores totales",
+	"Docker: Running Containers": "Docker: contenedores en ejecución",
+	"Docker: %s Running":         "Docker: %s en ejecución",
+	"Docker: %s CPU":             "Docker: %s CPU",
+	"Docker: %s Memory":          "Docker: %s memoria",
+	"Docker: %s Network RX":      "Docker: %s red recibida",
+	"Docker: %s Network TX":      "Docker: %s red enviada",
+	"Docker: %s MAC Address":     "Docker: %s dirección MAC",
+	"Docker: %s Health":          "Docker: %s salud",
+	"Docker: %s Power":           "Docker: %s encendido",
+	"Docker: %s Restart":         "Docker: %s reiniciar",
+	"Docker: %s Pause":           "Docker: %s pausar",
+	"Docker: %s Unpause":         "Docker: %s reanudar",
+
+	"VM: Total":               "VM: total",
+	"VM: Running":             "VM: en ejecución",
+	"VM: %s Running":          "VM: %s en ejecución",
+	"VM: %s Guest CPU":        "VM: %s CPU invitado",
+	"VM: %s Host CPU":         "VM: %s CPU anfitrión",
+	"VM: %s Memory Used":      "VM: %s memoria usada",
+	"VM: %s Memory Allocated": "VM: %s memoria asignada",
+	"VM: %s Power":            "VM: %s encendido",
+	"VM: %s Restart":          "VM: %s reiniciar",
+	"VM: %s Pause":            "VM: %s pausar",
+	"VM: %s Resume":           "VM: %s reanudar",
+	"VM: %s Hibernate":        "VM: %s hibernar",
+	"VM: %s Force Stop":       "VM: %s forzar detención",
+
+	"GPU %d":                     "GPU %d",
+	"GPU: %s Temperature":        "GPU: %s temperatura",
+	"GPU: %s Utilization":        "GPU: %s uso",
+	"GPU: %s Memory Utilization": "GPU: %s uso de memoria",
+	"GPU: %s Memory Used":        "GPU: %s memoria usada",
+	"GPU: %s Power Draw":         "GPU: %s consumo",
+	"GPU: %s Fan Speed":          "GPU: %s velocidad del ventilador",
+
+	"Network: %s Link":           "Red: %s enlace",
+	"Network: %s Speed":          "Red: %s velocidad",
+	"Network: %s Throughput In":  "Red: %s tráfico entrante",
+	"Network: %s Throughput Out": "Red: %s tráfico saliente",
+	"Network: %s RX Errors":      "Red: %s errores de recepción",
+	"Network: %s TX Errors":      "Red: %s errores de envío",
+
+	"Share: %s Usage": "Recurso compartido: %s uso",
+	"Share: %s Used":  "Recurso compartido: %s usado",
+	"Share: %s Free":  "Recurso compartido: %s libre",
+
+	"ZFS: %s Health":          "ZFS: %s salud",
+	"ZFS: %s Usage":           "ZFS: %s uso",
+	"ZFS: %s Free":            "ZFS: %s libre",
+	"ZFS: %s Fragmentation":   "ZFS: %s fragmentación",
+	"ZFS: %s Errors":          "ZFS: %s errores",
+	"ZFS: %s Healthy":         "ZFS: %s en buen estado",
+	"ZFS: %s Corrupted Files": "ZFS: %s archivos dañados",
+	"ZFS: %s Fill Rate":       "ZFS: %s ritmo de llenado",
+	"ZFS: %s Days Until Full": "ZFS: %s días hasta llenarse",
+
+	"Pool: %s Usage":           "Pool: %s uso",
+	"Pool: %s Free Space":      "Pool: %s espacio libre",
+	"Pool: %s Fill Rate":       "Pool: %s ritmo de llenado",
+	"Pool: %s Days Until Full": "Pool: %s días hasta llenarse",
+
+	"Btrfs: %s Device Errors":     "Btrfs: %s errores de dispositivo",
+	"Btrfs: %s Corruption Errors": "Btrfs: %s errores de corrupción",
+	"Btrfs: %s Scrub Status":      "Btrfs: %s estado del scrub",
+	"Btrfs: %s Problem":           "Btrfs: %s problema",
+
+	"Recycle Bin: %s Size":            "Papelera: %s tamaño",
+	"Recycle Bin: %s Files":           "Papelera: %s archivos",
+	"Recycle Bin: %s Oldest File Age": "Papelera: %s antigüedad del archivo más antiguo",
+	"Recycle Bin: %s Empty":           "Papelera: %s vaciar",
+
+	"IPMI: Problem":           "IPMI: problema",
+	"IPMI: Identify":          "IPMI: identificar",
+	"IPMI: Chassis Power":     "IPMI: alimentación del chasis",
+	"IPMI: Chassis Intrusion": "IPMI: intrusión en el chasis",
+	"IPMI: %s":                "IPMI: %s",
+
+	"NUT: UPS Connected":   "NUT: SAI conectado",
+	"NUT: UPS Status":      "NUT: estado del SAI",
+	"NUT: Battery Charge":  "NUT: carga de la batería",
+	"NUT: Battery Runtime": "NUT: autonomía de la batería",
+	"NUT: Load":            "NUT: carga",
+	"NUT: Real Power":      "NUT: potencia real",
+	"NUT: Input Voltage":   "NUT: tensión de entrada",
+	"NUT: Output Voltage":  "NUT: tensión de salida",
+	"NUT: UPS Model":       "NUT: modelo del SAI",
+
+	"Hardware: BIOS Version":       "Hardware: versión de la BIOS",
+	"Hardware: BIOS Release Date":  "Hardware: fecha de la BIOS",
+	"Hardware: Board Manufacturer": "Hardware: fabricante de la placa base",
+	"Hardware: Board Model":        "Hardware: modelo de la placa base",
+	"Hardware: CPU Max Speed":      "Hardware: velocidad máxima de CPU",
+	"Hardware: Memory Slots":       "Hardware: ranuras de memoria",
+	"Hardware: Chassis Serial":     "Hardware: número de serie del chasis",
+	"Hardware: TPM Present":        "Hardware: TPM presente",
+	"Hardware: Boot Device Type":   "Hardware: tipo de dispositivo de arranque",
+
+	"Registration: State":  "Licencia: estado",
+	"Registration: Type":   "Licencia: tipo",
+	"Registration: Valid":  "Licencia: válida",
+	"Registration: Expiry": "Licencia: caducidad",
+
+	"Remote Share: %s Mounted": "Recurso remoto: %s montado",
+	"Remote Share: %s Mount":   "Recurso remoto: %s montar",
+	"Remote Share: %s Usage":   "Recurso remoto: %s uso",
+	"Remote Share: %s Used":    "Recurso remoto: %s usado",
+	"Remote Share: %s Free":    "Recurso remoto: %s libre",
+
+	"Unassigned: %s Connected":   "Sin asignar: %s conectado",
+	"Unassigned: %s Temperature": "Sin asignar: %s temperatura",
+	"Unassigned: %s Spin State":  "Sin asignar: %s estado de giro",
+	"Part %d":                    "Partición %d",
+	"Unassigned: %s %s Usage":    "Sin asignar: %s %s uso",
+	"Unassigned: %s %s Used":     "Sin asignar: %s %s usado",
+	"Unassigned: %s %s Free":     "Sin asignar: %s %s libre",
+
+	"ZFS Dataset: %s Used":              "Dataset ZFS: %s usado",
+	"ZFS Dataset: %s Available":         "Dataset ZFS: %s disponible",
+	"ZFS Dataset: %s Compression Ratio": "Dataset ZFS: %s ratio de compresión",
+	"ZFS Dataset: %s Read-Only":         "Dataset ZFS: %s solo lectura",
+	"ZFS: Snapshot Count":               "ZFS: número de instantáneas",
+	"ZFS: Snapshot Total Size":          "ZFS: tamaño total de instantáneas",
+	"ZFS ARC: Size":                     "ZFS ARC: tamaño",
+	"ZFS ARC: Target Size":              "ZFS ARC: tamaño objetivo",
+	"ZFS ARC: Configured Max":           "ZFS ARC: máximo configurado",
+	"ZFS ARC: Hit Ratio":                "ZFS ARC: tasa de aciertos",
+	"ZFS L2ARC: Size":                   "ZFS L2ARC: tamaño",
+	"ZFS L2ARC: Hit Ratio":              "ZFS L2ARC: tasa de aciertos",
+
+	"Fan Control: %s RPM":  "Control de ventiladores: %s RPM",
+	"Fan Control: %s PWM":  "Control de ventiladores: %s PWM",
+	"Fan Control: %s Mode": "Control de ventiladores: %s modo",
+	"Fan Control: %s":      "Control de ventiladores: %s",
+	"Fan Control: Enabled": "Control de ventiladores: activado",
+
+	"Health Check: %s": "Comprobación de estado: %s",
+
+	"WAN: Online":            "WAN: en línea",
+	"WAN: Public IP":         "WAN: IP pública",
+	"WAN: Latency":           "WAN: latencia",
+	"WAN: Packet Loss":       "WAN: pérdida de paquetes",
+	"WAN: Public IP Changed": "WAN: IP pública cambiada",
+
+	"Speedtest: Download": "Speedtest: descarga",
+	"Speedtest: Upload":   "Speedtest: subida",
+	"Speedtest: Ping":     "Speedtest: ping",
+
+	"Power: Estimated Draw":         "Energía: consumo estimado",
+	"Power: Energy":                 "Energía: energía",
+	"Power: Estimated Daily Energy": "Energía: energía diaria estimada",
+	"Power: Estimate Source":        "Energía: origen de la estimación",
+
+	"Mover: Active":               "Mover: activo",
+	"Mover: Last Run":             "Mover: última ejecución",
+	"Mover: Last Run Duration":    "Mover: duración de la última ejecución",
+	"Mover: Last Run Data Moved":  "Mover: datos movidos (última ejecución)",
+	"Mover: Last Run Files Moved": "Mover: archivos movidos (última ejecución)",
+
+	"Flash: Usage":      "Flash: uso",
+	"Flash: Free Space": "Flash: espacio libre",
+	"Flash: GUID":       "Flash: GUID",
+
+	"Updates: Unraid OS":             "Actualizaciones: Unraid OS",
+	"Updates: Latest Unraid Version": "Actualizaciones: última versión de Unraid",
+	"Updates: Plugins":               "Actualizaciones: plugins",
+	"Updates: Containers":            "Actualizaciones: contenedores",
+
+	"Wake: %s": "Despertar: %s",
+}
@@ -0,0 +1,307 @@
+package i18n
+
+// french holds the French translations.
+var french = map[string]string{
+	// Notifications
+	"Alert: %s":                 "Alerte : %s",
+	"Resolved: %s":              "Résolu : %s",
+	"Management Agent Alert":    "Alerte de l'agent de gestion",
+	"%s temperature %s: %.0f°C": "%s température (%s) : %.0f°C",
+	"%s has been at or above its %s threshold of %.0f°C since %s.":   "%s est au niveau ou au-dessus de son seuil (%s) de %.0f°C depuis %s.",
+	"%s temperature back to normal: %.0f°C":                          "%s température revenue à la normale : %.0f°C",
+	"%s is back below its warning threshold of %.0f°C.":              "%s est de nouveau sous son seuil d'avertissement de %.0f°C.",
+	"%s usage %s: %.0f%%":                                            "%s utilisation (%s) : %.0f%%",
+	"%s is %.0f%% full, at or above its %s threshold of %.0f%%.":     "%s est rempli à %.0f%%, au niveau ou au-dessus de son seuil (%s) de %.0f%%.",
+	"%s usage back to normal: %.0f%%":                                "%s utilisation revenue à la normale : %.0f%%",
+	"%s is %.0f%% full, back below its warning threshold of %.0f%%.": "%s est rempli à %.0f%%, de nouveau sous son seuil d'avertissement de %.0f%%.",
+	"critical":             "critique",
+	"warning":              "avertissement",
+	"Boot sequence failed": "Échec de la séquence de démarrage",
+	"The array and guests were not started: %s": "L'array et les invités n'ont pas été démarrés : %s",
+	"Container updates available":               "Mises à jour de conteneurs disponibles",
+	"Updates available for: %s":                 "Mises à jour disponibles pour : %s",
+	"Plugin updates available":                  "Mises à jour de plugins disponibles",
+	"Plugins":                                   "Plugins",
+	"Unraid OS update available":                "Mise à jour d'Unraid OS disponible",
+	"System":                                    "Système",
+	"A new Unraid OS version is available: %s":  "Une nouvelle version d'Unraid OS est disponible : %s",
+	"Shutdown aborted":                          "Arrêt annulé",
+	"Orchestrated shutdown aborted":             "Arrêt orchestré annulé",
+	"The server was not powered off: %v":        "Le serveur n'a pas été éteint : %v",
+	"Health check '%s' (%s) failed: %s":         "Le contrôle de santé '%s' (%s) a échoué : %s",
+	"Health Check":                              "Contrôle de santé",
+	"Unhealthy container":                       "Conteneur défaillant",
+	"Container '%s' is still unhealthy after %d automatic restarts in the last 24 hours; not restarting it again.": "Le conteneur '%s' est toujours défaillant après %d redémarrages automatiques au cours des dernières 24 heures ; il ne sera plus redémarré.",
+	"Container '%s' has been unhealthy for %s; restart failed: %v":                                                 "Le conteneur '%s' est défaillant depuis %s ; échec du redémarrage : %v",
+	"Container '%s' was unhealthy for %s and has been restarted.":                                                  "Le conteneur '%s' a été défaillant pendant %s et a été redémarré.",
+
+	// Health report
+	"Array not started": "Array non démarré",
+	"Array is in state %q — data is inaccessible until the array is started.": "L'array est dans l'état %q — les données sont inaccessibles tant qu'il n'est pas démarré.",
+	"Disk %s SMART failure": "Défaillance SMART du disque %s",
+	"Disk %s reported SMART status %q. Backup data and replace the disk.": "Le disque %s signale l'état SMART %q. Sauvegardez les données et remplacez le disque.",
+	"Disk %s high temperature": "Température élevée du disque %s",
+	"Disk %s temperature is %.0f °C (threshold: %.0f °C). Improve airflow or reduce load.": "La température du disque %s est de %.0f °C (seuil : %.0f °C). Améliorez la ventilation ou réduisez la charge.",
+	"Container %q is not running":                                                           "Le conteneur %q n'est pas en cours d'exécution",
+	"Container %q is in state %q.":                                                          "Le conteneur %q est dans l'état %q.",
+	"Container %q is not running (restarted %d times)":                                      "Le conteneur %q n'est pas en cours d'exécution (redémarré %d fois)",
+	"Container %q is in state %q and has restarted %d times — it may be crash-looping.":     "Le conteneur %q est dans l'état %q et a redémarré %d fois — il plante peut-être en boucle.",
+	"Start container %q to restore service.":                                                "Démarrez le conteneur %q pour rétablir le service.",
+	"Container %q has an update available":                                                  "Une mise à jour est disponible pour le conteneur %q",
+	"A newer image is available for container %q. Update via the Docker UI or docker pull.": "Une image plus récente est disponible pour le conteneur %q. Mettez-le à jour via l'interface Docker ou docker pull.",
+	"Alert rule %q is firing.":                                                              "La règle d'alerte %q est déclenchée.",
+	"Firing alert: %s":                                                                      "Alerte déclenchée : %s",
+
+	// Home Assistant entities
+	"Collector: %s":                   "Collecteur : %s",
+	"Collector: %s Interval":          "Collecteur : %s intervalle",
+	"System: CPU Usage":               "Système : utilisation CPU",
+	"System: CPU Temperature":         "Système : température CPU",
+	"System: CPU Frequency":           "Système : fréquence CPU",
+	"System: CPU Power":               "Système : puissance CPU",
+	"System: DRAM Power":              "Système : puissance DRAM",
+	"System: RAM Usage":               "Système : utilisation RAM",
+	"System: RAM Used":                "Système : RAM utilisée",
+	"System: RAM Free":                "Système : RAM libre",
+	"System: RAM Total":               "Système : RAM totale",
+	"System: Swap Usage":              "Système : utilisation swap",
+	"System: Swap Used":               "Système : swap utilisé",
+	"System: Swap Free":               "Système : swap libre",
+	"System: Swap Total":              "Système : swap total",
+	"System: Swappiness":              "Système : swappiness",
+	"System: Motherboard Temperature": "Système : température carte mère",
+	"System: Uptime":                  "Système : temps de fonctionnement",
+	"System: Unraid Version":          "Système : version Unraid",
+	"System: Agent Version":           "Système : version de l'agent",
+	"System: Kernel Version":          "Système : version du noyau",
+	"System: CPU Model":               "Système : modèle CPU",
+	"System: CPU Cores":               "Système : cœurs CPU",
+	"System: HVM Support":             "Système : prise en charge HVM",
+	"System: IOMMU Support":           "Système : prise en charge IOMMU",
+	"System: %s":                      "Système : %s",
+	"System: Reboot":                  "Système : redémarrer",
+	"System: Shutdown":                "Système : éteindre",
+	"System: Maintenance Mode":        "Système : mode maintenance",
+	"System: Maintenance Ends":        "Système : fin de maintenance",
+
+	"Array: State":               "Array : état",
+	"Array: Usage":               "Array : utilisation",
+	"Array: Free Space":          "Array : espace libre",
+	"Array: Total Space":         "Array : espace total",
+	"Array: Fill Rate":           "Array : taux de remplissage",
+	"Array: Days Until Full":     "Array : jours avant saturation",
+	"Array: Disk Count":          "Array : nombre de disques",
+	"Array: Parity Status":       "Array : état de la parité",
+	"Array: Parity Progress":     "Array : progression de la parité",
+	"Array: Parity Speed":        "Array : vitesse de la parité",
+	"Array: Parity Finishes At":  "Array : fin de la parité",
+	"Array: Parity Valid":        "Array : parité valide",
+	"Array: Started":             "Array : démarré",
+	"Array: Power":               "Array : marche/arrêt",
+	"Array: Start Parity Check":  "Array : lancer la vérification de parité",
+	"Array: Stop Parity Check":   "Array : arrêter la vérification de parité",
+	"Array: Pause Parity Check":  "Array : suspendre la vérification de parité",
+	"Array: Resume Parity Check": "Array : reprendre la vérification de parité",
+	"Array: Next Parity Check":   "Array : prochaine vérification de parité",
+	"Array: Parity Schedule":     "Array : planification de la parité",
+
+	"UPS: Connected":         "Onduleur : connecté",
+	"UPS: Status":            "Onduleur : état",
+	"UPS: Load":              "Onduleur : charge",
+	"UPS: Battery Level":     "Onduleur : niveau de batterie",
+	"UPS: Runtime Remaining": "Onduleur : autonomie restante",
+	"UPS: Power Draw":        "Onduleur : consommation",
+	"UPS: Model":             "Onduleur : modèle",
+
+	"Notifications: Unread":      "Notifications : non lues",
+	"Notifications: Alerts":      "Notifications : alertes",
+	"Notifications: Warnings":    "Notifications : avertissements",
+	"Notifications: Info":        "Notifications : infos",
+	"Notifications: Archive All": "Notifications : tout archiver",
+	"Notifications: Event":       "Notifications : événement",
+
+	"Service: %s": "Service : %s",
+
+	"Disk: %s Temperature":     "Disque : %s température",
+	"Disk: %s Status":          "Disque : %s état",
+	"Disk: %s SMART Status":    "Disque : %s état SMART",
+	"Disk: %s Usage":           "Disque : %s utilisation",
+	"Disk: %s Used":            "Disque : %s utilisé",
+	"Disk: %s Free":            "Disque : %s libre",
+	"Disk: %s Spin State":      "Disque : %s état de rotation",
+	"Disk: %s Power On Hours":  "Disque : %s heures de fonctionnement",
+	"Disk: %s I/O Utilization": "Disque : %s utilisation E/S",
+	"Disk: %s Healthy":         "Disque : %s sain",
+	"Disk: %s Spin Up":         "Disque : %s démarrer la rotation",
+	"Disk: %s Spin Down":       "Disque : %s arrêter la rotation",
+
+	"Docker: Total Containers":   "Docker : conteneurs au total",
+	"Docker: Running Containers": "Docker : conteneurs en cours d'exécution",
+	"Docker: %s Running":         "Docker : %s en cours d'exécution",
+	"Docker: %s CPU":             "Docker : %s CPU",
+	"Docker: %s Memory":          "Docker : %s mémoire",
+	"Docker: %s Network RX":      "Docker : %s réseau reçu",
+	"Docker: %s Network TX":      "Docker : %s réseau envoyé",
+	"Docker: %s MAC Address":     "Docker : %s adresse MAC",
+	"Docker: %s Health":          "Docker : %s santé",
+	"Docker: %s Power":           "Docker : %s marche/arrêt",
+	"Docker: %s Restart":         "Docker : %s redémarrer",
+	"Docker: %s Pause":           "Docker : %s suspendre",
+	"Docker: %s Unpause":         "Docker : %s reprendre",
+
+	"VM: Total":               "VM : total",
+	"VM: Running":             "VM : en cours d'exécution",
+	"VM: %s Running":          "VM : %s en cours d'exécution",
+	"VM: %s Guest CPU":        "VM : %s CPU invité",
+	"VM: %s Host CPU":         "VM : %s CPU hôte",
+	"VM: %s Memory Used":      "VM : %s mémoire utilisée",
+	"VM: %s Memory Allocated": "VM : %s mémoire allouée",
+	"VM: %s Power":            "VM : %s marche/arrêt",
+	"VM: %s Restart":          "VM : %s redémarrer",
+	"VM: %s Pause":            "VM : %s suspendre",
+	"VM: %s Resume":           "VM : %s reprendre",
+	"VM: %s Hibernate":        "VM : %s mettre en veille prolongée",
+	"VM: %s Force Stop":       "VM : %s forcer l'arrêt",
+
+	"GPU %d":                     "GPU %d",
+	"GPU: %s Temperature":        "GPU : %s température",
+	"GPU: %s Utilization":        "GPU : %s utilisation",
+	"GPU: %s Memory Utilization": "GPU : %s utilisation mémoire",
+	"GPU: %s Memory Used":        "GPU : %s mémoire utilisée",
+	"GPU: %s Power Draw":         "GPU : %s consommation",
+	"GPU: %s Fan Speed":          "GPU : %s vitesse du ventilateur",
+
+	"Network: %s Link":           "Réseau : %s liaison",
+	"Network: %s Speed":          "Réseau : %s vitesse",
+	"Network: %s Throughput In":  "Réseau : %s débit entrant",
+	"Network: %s Throughput Out": "Réseau : %s débit sortant",
+	"Network: %s RX Errors":      "Réseau : %s erreurs de réception",
+	"Network: %s TX Errors":      "Réseau : %s erreurs d'émission",
+
+	"Share: %s Usage": "Partage : %s utilisation",
+	"Share: %s Used":  "Partage : %s utilisé",
+	"Share: %s Free":  "Partage : %s libre",
+
+	"ZFS: %s Health":          "ZFS : %s santé",
+	"ZFS: %s Usage":           "ZFS : %s utilisation",
+	"ZFS: %s Free":            "ZFS : %s libre",
+	"ZFS: %s Fragmentation":   "ZFS : %s fragmentation",
+	"ZFS: %s Errors":          "ZFS : %s erreurs",
+	"ZFS: %s Healthy":         "ZFS : %s sain",
+	"ZFS: %s Corrupted Files": "ZFS : %s fichiers corrompus",
+	"ZFS: %s Fill Rate":       "ZFS : %s taux de remplissage",
+	"ZFS: %s Days Until Full": "ZFS : %s jours avant saturation",
+
+	"Pool: %s Usage":           "Pool : %s utilisation",
+	"Pool: %s Free Space":      "Pool : %s espace libre",
+	"Pool: %s Fill Rate":       "Pool : %s taux de remplissage",
+	"Pool: %s Days Until Full": "Pool : %s jours avant saturation",
+
+	"Btrfs: %s Device Errors":     "Btrfs : %s erreurs de périphérique",
+	"Btrfs: %s Corruption Errors": "Btrfs : %s erreurs de corruption",
+	"Btrfs: %s Scrub Status":      "Btrfs : %s état du scrub",
+	"Btrfs: %s Problem":           "Btrfs : %s problème",
+
+	"Recycle Bin: %s Size":            "Corbeille : %s taille",
+	"Recycle Bin: %s Files":           "Corbeille : %s fichiers",
+	"Recycle Bin: %s Oldest File Age": "Corbeille : %s âge du plus ancien fichier",
+	"Recycle Bin: %s Empty":           "Corbeille : %s vider",
+
+	"IPMI: Problem":           "IPMI : problème",
+	"IPMI: Identify":          "IPMI : identifier",
+	"IPMI: Chassis Power":     "IPMI : alimentation du châssis",
+	"IPMI: Chassis Intrusion": "IPMI : intrusion dans le châssis",
+	"IPMI: %s":                "IPMI : %s",
+
+	"NUT: UPS Connected":   "NUT : onduleur connecté",
+	"NUT: UPS Status":      "NUT : état de l'onduleur",
+	"NUT: Battery Charge":  "NUT : charge de la batterie",
+	"NUT: Battery Runtime": "NUT : autonomie de la batterie",
+	"NUT: Load":            "NUT : charge",
+	"NUT: Real Power":      "NUT : puissance active",
+	"NUT: Input Voltage":   "NUT : tension d'entrée",
+	"NUT: Output Voltage":  "NUT : tension de sortie",
+	"NUT: UPS Model":       "NUT : modèle de l'onduleur",
+
+	"Hardware: BIOS Version":       "Matériel : version du BIOS",
+	"Hardware: BIOS Release Date":  "Matériel : date du BIOS",
+	"Hardware: Board Manufacturer": "Matériel : fabricant de la carte mère",
+	"Hardware: Board Model":        "Matériel : modèle de la carte mère",
+	"Hardware: CPU Max Speed":      "Matériel : fréquence CPU maximale",
+	"Hardware: Memory Slots":       "Matériel : emplacements mémoire",
+	"Hardware: Chassis Serial":     "Matériel : numéro de série du châssis",
+	"Hardware: TPM Present":        "Matériel : TPM présent",
+	"Hardware: Boot Device Type":   "Matériel : type de périphérique de démarrage",
+
+	"Registration: State":  "Licence : état",
+	"Registration: Type":   "Licence : type",
+	"Registration: Valid":  "Licence : valide",
+	"Registration: Expiry": "Licence : expiration",
+
+	"Remote Share: %s Mounted": "Partage distant : %s monté",
+	"Remote Share: %s Mount":   "Partage distant : %s monter",
+	"Remote Share: %s Usage":   "Partage distant : %s utilisation",
+	"Remote Share: %s Used":    "Partage distant : %s utilisé",
+	"Remote Share: %s Free":    "Partage distant : %s libre",
+
+	"Unassigned: %s Connected":   "Non assigné : %s connecté",
+	"Unassigned: %s Temperature": "Non assigné : %s température",
+	"Unassigned: %s Spin State":  "Non assigné : %s état de rotation",
+	"Part %d":                    "Partition %d",
+	"Unassigned: %s %s Usage":    "Non assigné : %s %s utilisation",
+	"Unassigned: %s %s Used":     "Non assigné : %s %s utilisé",
+	"Unassigned: %s %s Free":     "Non assigné : %s %s libre",
+
+	"ZFS Dataset: %s Used":              "Dataset ZFS : %s utilisé",
+	"ZFS Dataset: %s Available":         "Dataset ZFS : %s disponible",
+	"ZFS Dataset: %s Compression Ratio": "Dataset ZFS : %s taux de compression",
+	"ZFS Dataset: %s Read-Only":         "Dataset ZFS : %s lecture seule",
+	"ZFS: Snapshot Count":               "ZFS : nombre d'instantanés",
+	"ZFS: Snapshot Total Size":          "ZFS : taille totale des instantanés",
+	"ZFS ARC: Size":                     "ZFS ARC : taille",
+	"ZFS ARC: Target Size":              "ZFS ARC : taille cible",
+	"ZFS ARC: Configured Max":           "ZFS ARC : maximum configuré",
+	"ZFS ARC: Hit Ratio":                "ZFS ARC : taux de réussite",
+	"ZFS L2ARC: Size":                   "ZFS L2ARC : taille",
+	"ZFS L2ARC: Hit Ratio":              "ZFS L2ARC : taux de réussite",
+
+	"Fan Control: %s RPM":  "Contrôle des ventilateurs : %s tr/min",
+	"Fan Control: %s PWM":  "Contrôle des ventilateurs : %s PWM",
+	"Fan Control: %s Mode": "Contrôle des ventilateurs : %s mode",
+	"Fan Control: %s":      "Contrôle des ventilateurs : %s",
+	"Fan Control: Enabled": "Contrôle des ventilateurs : activé",
+
+	"Health Check: %s": "Contrôle de santé : %s",
+
+	"WAN: Online":            "WAN : en ligne",
+	"WAN: Public IP":         "WAN : IP publique",
+	"WAN: Latency":           "WAN : latence",
+	"WAN: Packet Loss":       "WAN : perte de paquets",
+	"WAN: Public IP Changed": "WAN : IP publique modifiée",
+
+	"Speedtest: Download": "Speedtest : téléchargement",
+	"Speedtest: Upload":   "Speedtest : envoi",
+	"Speedtest: Ping":     "Speedtest : ping",
+
+	"Power: Estimated Draw":         "Énergie : consommation estimée",
+	"Power: Energy":                 "Énergie : énergie",
+	"Power: Estimated Daily Energy": "Énergie : énergie quotidienne estimée",
+	"Power: Estimate Source":        "Énergie : source de l'estimation",
+
+	"Mover: Active":               "Mover : actif",
+	"Mover: Last Run":             "Mover : dernière exécution",
+	"Mover: Last Run Duration":    "Mover : durée de la dernière exécution",
+	"Mover: Last Run Data Moved":  "Mover : données déplacées (dernière exécution)",
+	"Mover: Last Run Files Moved": "Mover : fichiers déplacés (dernière exécution)",
+
+	"Flash: Usage":      "Flash : utilisation",
+	"Flash: Free Space": "Flash : espace libre",
+	"Flash: GUID":       "Flash : GUID",
+
+	"Updates: Unraid OS":             "Mises à jour : Unraid OS",
+	"Updates: Latest Unraid Version": "Mises à jour : dernière version d'Unraid",
+	"Updates: Plugins":               "Mises à jour : plugins",
+	"Updates: Containers":            "Mises à jour : conteneurs",
+
+	"Wake: %s": "Réveil : %s",
+}
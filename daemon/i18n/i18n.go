@@ -0,0 +1,79 @@
+// Package i18n translates the text the agent writes for people: Unraid
+// notification titles and messages, Home Assistant entity names and health
+// report findings. Messages are identified by their English text, which is
+// also the fallback when a language has no translation. API fields, enum
+// values and log messages always stay in English.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultLanguage is the language of the message IDs.
+const DefaultLanguage = "en"
+
+// catalogs maps a language code to its translations, keyed by English text.
+var catalogs = map[string]map[string]string{
+	"de": german,
+	"fr": french,
+	"es": spanish,
+}
+
+var current atomic.Value // string
+
+func init() {
+	current.Store(DefaultLanguage)
+}
+
+// Languages returns the supported language codes.
+func Languages() []string {
+	return []string{DefaultLanguage, "de", "fr", "es"}
+}
+
+// Normalize returns the supported language code for lang, accepting locale
+// forms such as "de_DE.UTF-8" or "fr-CA". An empty lang means English.
+func Normalize(lang string) (string, bool) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		return DefaultLanguage, true
+	}
+	if i := strings.IndexAny(lang, "_-."); i > 0 {
+		lang = lang[:i]
+	}
+	if lang == DefaultLanguage {
+		return lang, true
+	}
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// SetLanguage selects the language of translated text.
+func SetLanguage(lang string) error {
+	code, ok := Normalize(lang)
+	if !ok {
+		return fmt.Errorf("unsupported language %q: use %s", lang, strings.Join(Languages(), ", "))
+	}
+	current.Store(code)
+	return nil
+}
+
+// Language returns the selected language code.
+func Language() string {
+	return current.Load().(string)
+}
+
+// T returns msg in the selected language. With args, msg is a fmt format
+// string and the translation is formatted with them; translations may
+// reorder arguments with explicit indexes such as %[2]s.
+func T(msg string, args ...any) string {
+	format := msg
+	if translated, ok := catalogs[Language()][msg]; ok {
+		format = translated
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
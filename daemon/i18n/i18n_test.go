@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSetLanguage(t *testing.T) {
+	t.Cleanup(func() { _ = SetLanguage(DefaultLanguage) })
+
+	for input, want := range map[string]string{"de_DE.UTF-8": "de", "fr-CA": "fr", " ES ": "es", "": "en"} {
+		if err := SetLanguage(input); err != nil {
+			t.Fatalf("SetLanguage(%q): %v", input, err)
+		}
+		if got := Language(); got != want {
+			t.Errorf("SetLanguage(%q): language %q, want %q", input, got, want)
+		}
+	}
+	_ = SetLanguage("de")
+	if err := SetLanguage("nl"); err == nil || Language() != "de" {
+		t.Errorf("unsupported language: err %v, language %q", err, Language())
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { _ = SetLanguage(DefaultLanguage) })
+
+	if got := T("Disk: %s Temperature", "disk1"); got != "Disk: disk1 Temperature" {
+		t.Errorf("en: %q", got)
+	}
+	_ = SetLanguage("de")
+	if got := T("Disk: %s Temperature", "disk1"); got != "Festplatte: disk1 Temperatur" {
+		t.Errorf("de: %q", got)
+	}
+	if got := T("Not translated: %d", 3); got != "Not translated: 3" {
+		t.Errorf("fallback: %q", got)
+	}
+}
+
+// verbPattern matches the fmt verbs used in message IDs.
+var verbPattern = regexp.MustCompile(`%(\.\d+)?[sdqvf]`)
+
+// sampleArgs returns an argument of the right type for each verb in msg.
+func sampleArgs(msg string) []any {
+	var args []any
+	for _, verb := range verbPattern.FindAllString(msg, -1) {
+		switch verb[len(verb)-1] {
+		case 'd':
+			args = append(args, 2)
+		case 'f':
+			args = append(args, 55.0)
+		default:
+			args = append(args, "x")
+		}
+	}
+	return args
+}
+
+// TestCatalogsCoverSourceMessages checks that every message passed to T in
+// the daemon is translated in every language, with matching arguments.
+func TestCatalogsCoverSourceMessages(t *testing.T) {
+	used := map[string]string{}
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "T" {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "i18n" {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok {
+				t.Errorf("%s: i18n.T needs a string literal message", path)
+				return true
+			}
+			msg, _ := strconv.Unquote(lit.Value)
+			used[msg] = path
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(used) == 0 {
+		t.Fatal("no translated messages found")
+	}
+
+	for lang, catalog := range catalogs {
+		for msg, path := range used {
+			translated, ok := catalog[msg]
+			if !ok {
+				t.Errorf("%s: missing translation of %q (%s)", lang, msg, path)
+				continue
+			}
+			if out := fmt.Sprintf(translated, sampleArgs(msg)...); strings.Contains(out, "%!") {
+				t.Errorf("%s: translation of %q has mismatched arguments: %q", lang, msg, out)
+			}
+		}
+		for msg := range catalog {
+			if _, ok := used[msg]; !ok {
+				t.Errorf("%s: unused translation %q", lang, msg)
+			}
+		}
+	}
+}
@@ -1,11 +1,15 @@
 package lib
 
 import (
+	"cmp"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 )
 
 // HwmonBasePath is the sysfs base path for hwmon devices.
@@ -20,6 +24,10 @@ const MaxFanChannels = 20
 // MaxTempChannels is the upper bound for hwmon temperature channel scanning per device.
 const MaxTempChannels = 20
 
+// MaxCoretempChannels is the upper bound for temperature channels on a coretemp
+// device, which exposes one per core plus the package sensor.
+const MaxCoretempChannels = 256
+
 // MaxPlausibleRPM is the upper bound for plausible fan RPM readings.
 // Values above this are treated as bogus sensor data. Even extreme server
 // fans (Delta, Nidec) rarely exceed 15 000 RPM; 25 000 gives headroom.
@@ -261,3 +269,59 @@ func ReadMaxHwmonTemp() float64 {
 	}
 	return maxTemp
 }
+
+// ReadCPUCoreTemps returns per-core temperatures from Intel coretemp hwmon
+// devices (one per socket), ordered by package then core. AMD k10temp only
+// reports per-CCD temperatures, so it contributes nothing here.
+func ReadCPUCoreTemps() []dto.CPUCoreTemp {
+	return readCPUCoreTemps(HwmonBasePath)
+}
+
+func readCPUCoreTemps(base string) []dto.CPUCoreTemp {
+	var temps []dto.CPUCoreTemp
+	socket := 0
+	for i := range MaxHwmonDevices {
+		hwmonDir := filepath.Join(base, fmt.Sprintf("hwmon%d", i))
+		if ReadSysfsString(filepath.Join(hwmonDir, "name")) != "coretemp" {
+			continue
+		}
+
+		// Each coretemp device covers one socket; its "Package id N" label
+		// names the socket, falling back to discovery order.
+		pkg := socket
+		socket++
+		var cores []dto.CPUCoreTemp
+		for j := 1; j <= MaxCoretempChannels; j++ {
+			label := ReadSysfsString(filepath.Join(hwmonDir, fmt.Sprintf("temp%d_label", j)))
+			if id, ok := strings.CutPrefix(label, "Package id "); ok {
+				if n, err := strconv.Atoi(id); err == nil {
+					pkg = n
+				}
+				continue
+			}
+			coreID, ok := strings.CutPrefix(label, "Core ")
+			if !ok {
+				continue
+			}
+			core, err := strconv.Atoi(coreID)
+			if err != nil {
+				continue
+			}
+			raw := ReadSysfsInt(filepath.Join(hwmonDir, fmt.Sprintf("temp%d_input", j)))
+			tempC := float64(raw) / 1000.0
+			if raw == 0 || !IsPlausibleTempC(tempC) {
+				continue
+			}
+			cores = append(cores, dto.CPUCoreTemp{Core: core, TempC: tempC})
+		}
+		for _, core := range cores {
+			core.Package = pkg
+			temps = append(temps, core)
+		}
+	}
+
+	slices.SortFunc(temps, func(a, b dto.CPUCoreTemp) int {
+		return cmp.Or(cmp.Compare(a.Package, b.Package), cmp.Compare(a.Core, b.Core))
+	})
+	return temps
+}
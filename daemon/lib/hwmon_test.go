@@ -1,6 +1,13 @@
 package lib
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
 
 func TestDiscoverHwmonTempSensorsPlausibility(t *testing.T) {
 	// Pure-logic guard: ensures unreliable labels and out-of-range temps are
@@ -16,3 +23,40 @@ func TestDiscoverHwmonTempSensorsPlausibility(t *testing.T) {
 		t.Errorf("out-of-range temp should be flagged implausible")
 	}
 }
+
+func TestReadCPUCoreTemps(t *testing.T) {
+	base := t.TempDir()
+	writeHwmon := func(dev string, files map[string]string) {
+		dir := filepath.Join(base, dev)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for name, content := range files {
+			writeTestFile(t, filepath.Join(dir, name), content)
+		}
+	}
+	// Socket 1 is discovered first; its package label sets the socket number.
+	writeHwmon("hwmon1", map[string]string{
+		"name": "coretemp", "temp1_label": "Package id 1", "temp1_input": "61000",
+		"temp2_label": "Core 0", "temp2_input": "58000",
+	})
+	writeHwmon("hwmon2", map[string]string{
+		"name": "coretemp", "temp1_label": "Package id 0", "temp1_input": "55000",
+		"temp2_label": "Core 4", "temp2_input": "54000",
+		"temp3_label": "Core 0", "temp3_input": "49000",
+		"temp4_label": "Core 1", "temp4_input": "0",
+	})
+	writeHwmon("hwmon3", map[string]string{
+		"name": "k10temp", "temp1_label": "Tccd1", "temp1_input": "70000",
+	})
+
+	got := readCPUCoreTemps(base)
+	want := []dto.CPUCoreTemp{
+		{Package: 0, Core: 0, TempC: 49},
+		{Package: 0, Core: 4, TempC: 54},
+		{Package: 1, Core: 0, TempC: 58},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("core temps = %+v, want %+v", got, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"time"
 )
@@ -22,10 +23,19 @@ func ExecCommand(command string, args ...string) ([]string, error) {
 
 // ExecCommandWithTimeout executes a command with a specific timeout
 func ExecCommandWithTimeout(timeout time.Duration, command string, args ...string) ([]string, error) {
+	return ExecCommandWithEnv(timeout, nil, command, args...)
+}
+
+// ExecCommandWithEnv executes a command with a specific timeout and extra
+// KEY=VALUE environment variables on top of the agent's environment.
+func ExecCommandWithEnv(timeout time.Duration, env []string, command string, args ...string) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, command, args...) // #nosec G204 -- callers pass validated commands and arguments without shell interpolation
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
@@ -96,6 +106,19 @@ func ExecCommandStdout(command string, args ...string) (string, error) {
 	return string(out), nil
 }
 
+// ExecCommandStdoutWithContext is ExecCommandStdout honouring the caller's
+// context instead of a fixed timeout, for long-running machine-parsed commands.
+// On a non-zero exit the returned error wraps *exec.ExitError, whose Stderr
+// holds the command's error output.
+func ExecCommandStdoutWithContext(ctx context.Context, command string, args ...string) (string, error) {
+	cmd := execCommand(ctx, command, args...) // #nosec G204 -- callers pass validated commands and arguments without shell interpolation
+	out, err := cmd.Output()
+	if err != nil {
+		return string(out), fmt.Errorf("command failed: %w", err)
+	}
+	return string(out), nil
+}
+
 // CommandExists checks if a command exists in PATH
 func CommandExists(command string) bool {
 	_, err := exec.LookPath(command)
@@ -2,6 +2,7 @@ package lib
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -110,6 +111,16 @@ func TestExecCommandWithTimeoutMultiLine(t *testing.T) {
 	}
 }
 
+func TestExecCommandWithEnv(t *testing.T) {
+	lines, err := ExecCommandWithEnv(5*time.Second, []string{"UMA_TEST_VALUE=hello"}, "sh", "-c", "echo $UMA_TEST_VALUE")
+	if err != nil {
+		t.Fatalf("ExecCommandWithEnv failed: %v", err)
+	}
+	if len(lines) != 1 || lines[0] != "hello" {
+		t.Errorf("Expected ['hello'], got %v", lines)
+	}
+}
+
 func TestExecCommandWithTimeoutNonExistent(t *testing.T) {
 	// Test non-existent command
 	_, err := ExecCommandWithTimeout(5*time.Second, "command-that-does-not-exist")
@@ -221,6 +232,26 @@ func TestExecCommandStdout(t *testing.T) {
 	}
 }
 
+func TestExecCommandStdoutWithContext(t *testing.T) {
+	out, err := ExecCommandStdoutWithContext(context.Background(), "sh", "-c", "echo out; echo warn >&2; exit 2")
+	if out != "out\n" {
+		t.Errorf("expected stdout only, got %q", out)
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected wrapped *exec.ExitError, got %v", err)
+	}
+	if string(exitErr.Stderr) != "warn\n" {
+		t.Errorf("expected captured stderr, got %q", exitErr.Stderr)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ExecCommandStdoutWithContext(ctx, "sleep", "5"); err == nil {
+		t.Error("expected error for cancelled context")
+	}
+}
+
 // TestHelperProcess is not a real test - it's a helper process used by TestExecCommandStdout
 // to mock command execution. When GO_TEST_HELPER_PROCESS is set, it simulates a command
 // by outputting the mock data and exiting with the mock exit code.
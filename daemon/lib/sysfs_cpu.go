@@ -1,11 +1,16 @@
 package lib
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 )
 
 // cpufreqPath is a helper to construct cpufreq sysfs paths.
@@ -51,6 +56,35 @@ func ReadCPUFreqLimits() (minMHz, maxMHz, curMHz int) {
 	return minMHz, maxMHz, curMHz
 }
 
+// ReadCPUCoreFrequencies returns the current frequency of every logical CPU
+// that exposes cpufreq, ordered by CPU number.
+func ReadCPUCoreFrequencies() []dto.CPUCoreFrequency {
+	return readCPUCoreFrequencies(cpufreqBasePath)
+}
+
+func readCPUCoreFrequencies(base string) []dto.CPUCoreFrequency {
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil
+	}
+
+	var freqs []dto.CPUCoreFrequency
+	for _, entry := range entries {
+		cpu, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), "cpu"))
+		if err != nil || !strings.HasPrefix(entry.Name(), "cpu") {
+			continue
+		}
+		kHz := ReadSysfsInt(filepath.Join(base, entry.Name(), "cpufreq", "scaling_cur_freq"))
+		if kHz <= 0 {
+			continue // offline or no cpufreq driver
+		}
+		freqs = append(freqs, dto.CPUCoreFrequency{CPU: cpu, MHz: float64(kHz) / 1000})
+	}
+
+	slices.SortFunc(freqs, func(a, b dto.CPUCoreFrequency) int { return cmp.Compare(a.CPU, b.CPU) })
+	return freqs
+}
+
 // WriteCPUGovernor sets the scaling governor for all online CPU cores.
 func WriteCPUGovernor(governor string) error {
 	// Enumerate all cpuN directories
@@ -178,3 +178,32 @@ func TestWriteTurboBoost_NotAvailable(t *testing.T) {
 		t.Error("expected error when no turbo/boost is available")
 	}
 }
+
+func TestReadCPUCoreFrequencies(t *testing.T) {
+	base := t.TempDir()
+	for cpu, kHz := range map[string]string{"cpu0": "4700000", "cpu1": "800000", "cpu10": "3600000"} {
+		dir := filepath.Join(base, cpu, "cpufreq")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		writeTestFile(t, filepath.Join(dir, "scaling_cur_freq"), kHz)
+	}
+	// Offline CPUs and non-CPU directories are skipped.
+	for _, dir := range []string{"cpu2", "cpufreq", "cpuidle"} {
+		if err := os.MkdirAll(filepath.Join(base, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := readCPUCoreFrequencies(base)
+	if len(got) != 3 {
+		t.Fatalf("got %d frequencies, want 3: %+v", len(got), got)
+	}
+	if got[0].CPU != 0 || got[0].MHz != 4700 || got[1].CPU != 1 || got[1].MHz != 800 || got[2].CPU != 10 {
+		t.Errorf("frequencies = %+v, want cpu0=4700, cpu1=800, cpu10 last", got)
+	}
+
+	if got := readCPUCoreFrequencies(filepath.Join(base, "missing")); got != nil {
+		t.Errorf("expected nil without cpufreq, got %+v", got)
+	}
+}
@@ -33,8 +33,9 @@ type RAPLReading struct {
 
 // RAPLPower represents calculated power consumption in watts.
 type RAPLPower struct {
-	PackageWatts float64 // Total CPU package power (cores + uncore)
-	DRAMWatts    float64 // DRAM power consumption
+	PackageWatts    float64   // Total CPU package power (cores + uncore)
+	PerPackageWatts []float64 // Package power per socket, in sysfs zone order
+	DRAMWatts       float64   // DRAM power consumption
 }
 
 // IsRAPLAvailable checks if the Intel RAPL powercap interface is available.
@@ -172,8 +173,11 @@ func CalculateRAPLPower(prev, curr *RAPLReading) *RAPLPower {
 
 	power := &RAPLPower{}
 
-	// Calculate package power (summed across sockets)
-	power.PackageWatts = calculateZonePower(prev.Packages, curr.Packages, elapsed)
+	// Calculate package power per socket and summed across sockets
+	power.PerPackageWatts = zoneWatts(prev.Packages, curr.Packages, elapsed)
+	for _, watts := range power.PerPackageWatts {
+		power.PackageWatts += watts
+	}
 
 	// Calculate DRAM power (summed across sockets)
 	power.DRAMWatts = calculateZonePower(prev.DRAM, curr.DRAM, elapsed)
@@ -182,21 +186,27 @@ func CalculateRAPLPower(prev, curr *RAPLReading) *RAPLPower {
 }
 
 // calculateZonePower computes total power for a set of zones matched by position.
-// This is safe because Linux sysfs enumerates powercap zones in deterministic order.
 func calculateZonePower(prev, curr []RAPLZone, elapsedSeconds float64) float64 {
 	var totalWatts float64
+	for _, watts := range zoneWatts(prev, curr, elapsedSeconds) {
+		totalWatts += watts
+	}
+	return totalWatts
+}
 
+// zoneWatts computes the power of each zone, matching zones by position.
+// This is safe because Linux sysfs enumerates powercap zones in deterministic order.
+func zoneWatts(prev, curr []RAPLZone, elapsedSeconds float64) []float64 {
+	watts := make([]float64, 0, len(curr))
 	for i := range curr {
 		if i >= len(prev) {
 			break
 		}
 
 		deltaUJ := energyDelta(prev[i].EnergyUJ, curr[i].EnergyUJ, curr[i].MaxRange)
-		watts := float64(deltaUJ) / (elapsedSeconds * 1_000_000) // µJ → J/s (watts)
-		totalWatts += watts
+		watts = append(watts, float64(deltaUJ)/(elapsedSeconds*1_000_000)) // µJ → J/s (watts)
 	}
-
-	return totalWatts
+	return watts
 }
 
 // energyDelta calculates the difference between two energy readings,
@@ -345,6 +345,9 @@ func TestCalculateRAPLPowerMultiSocket(t *testing.T) {
 	}
 
 	// Socket 0: 15M µJ / 1s = 15W, Socket 1: 20M µJ / 1s = 20W → Total: 35W
+	if len(power.PerPackageWatts) != 2 || power.PerPackageWatts[0] != 15 || power.PerPackageWatts[1] != 20 {
+		t.Errorf("Expected per-package power [15 20], got %v", power.PerPackageWatts)
+	}
 	expectedPackage := 35.0
 	if power.PackageWatts < expectedPackage-0.1 || power.PackageWatts > expectedPackage+0.1 {
 		t.Errorf("Expected total package power ~%.0f W, got %.2f W", expectedPackage, power.PackageWatts)
@@ -4,9 +4,14 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"net/netip"
+	"net/url"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
@@ -29,6 +34,10 @@ var (
 	// Examples: sda, sdb1, nvme0n1, nvme0n1p1, md0, loop0
 	diskIDRegex = regexp.MustCompile(`^(sd[a-z]|nvme[0-9]+n[0-9]+|md[0-9]+|loop[0-9]+)(p?[0-9]+)?$`)
 
+	// Unraid disk slot names: lowercase alphanumeric and underscores (max 32 chars)
+	// e.g. "disk1", "parity2", "cache", "cache_nvme"
+	diskNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
 	// Share names: alphanumeric, hyphens, underscores (max 255 chars)
 	// Must not contain path separators or parent directory references
 	shareNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,255}$`)
@@ -55,6 +64,9 @@ var (
 
 	// hwmon temperature inputs look like /sys/class/hwmon/hwmon0/temp1_input
 	hwmonSensorPathRegex = regexp.MustCompile(`^/sys/class/hwmon/hwmon[0-9]+/temp[0-9]+_input$`)
+
+	// USB device IDs: "vendor:product", four hex digits each (e.g. "046d:c52b")
+	usbDeviceIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{4}$`)
 )
 
 // ValidateContainerID validates a Docker container ID format
@@ -117,6 +129,20 @@ func ValidateDiskID(id string) error {
 	return nil
 }
 
+// ValidateDiskName validates an Unraid disk slot name such as disk1, parity
+// or a pool device like cache.
+func ValidateDiskName(name string) error {
+	if name == "" {
+		return errors.New("disk name cannot be empty")
+	}
+
+	if !diskNameRegex.MatchString(name) {
+		return errors.New("invalid disk name format: must be an Unraid disk slot name (e.g., disk1, parity, cache)")
+	}
+
+	return nil
+}
+
 // ValidateShareName validates an Unraid share name
 // Prevents path traversal attacks by ensuring the name contains only safe characters
 // and does not contain path separators or parent directory references
@@ -613,3 +639,274 @@ func ValidateRemoteShareSource(source string) error {
 	}
 	return nil
 }
+
+// notificationTargetSchemes are the shoutrrr services notification forwarding
+// delivers to.
+var notificationTargetSchemes = []string{"telegram", "discord", "pushover"}
+
+// ValidateNotificationTargetURL validates a notification forwarding target: a
+// shoutrrr service URL using the telegram://, discord:// or pushover:// scheme.
+// The URL embeds credentials, so errors never echo it back.
+func ValidateNotificationTargetURL(rawURL string) error {
+	if rawURL == "" {
+		return errors.New("notification target url cannot be empty")
+	}
+	if len(rawURL) > 2048 {
+		return errors.New("invalid notification target url: exceeds 2048 characters")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.New("invalid notification target url: cannot be parsed")
+	}
+	if !slices.Contains(notificationTargetSchemes, u.Scheme) {
+		return fmt.Errorf("unsupported notification target service %q: must be one of %s",
+			u.Scheme, strings.Join(notificationTargetSchemes, ", "))
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid %s notification target url: missing host", u.Scheme)
+	}
+	return nil
+}
+
+// containerRestartPolicies are the restart policies accepted by docker update.
+var containerRestartPolicies = []string{"no", "always", "unless-stopped", "on-failure"}
+
+// minContainerMemory is the smallest memory limit Docker accepts (6 MiB).
+const minContainerMemory = 6 * 1024 * 1024
+
+// ValidateContainerLimits validates a container resource limit update. At
+// least one field must be set, and values must be in the ranges Docker
+// accepts so obviously bad input is rejected before reaching the daemon.
+// Docker treats 0 as "leave unchanged", so 0 is rejected rather than silently
+// ignored.
+func ValidateContainerLimits(req dto.ContainerLimitsRequest) error {
+	if req.CPUShares == nil && req.CPUQuota == nil && req.CPUPeriod == nil &&
+		req.MemoryBytes == nil && req.MemorySwapBytes == nil &&
+		req.RestartPolicy == nil && req.RestartMaxRetries == nil {
+		return errors.New("at least one limit or the restart policy must be set")
+	}
+	if req.CPUShares != nil && *req.CPUShares < 2 {
+		return fmt.Errorf("cpu_shares must be at least 2, got %d", *req.CPUShares)
+	}
+	if req.CPUQuota != nil && *req.CPUQuota != -1 && *req.CPUQuota < 1000 {
+		return fmt.Errorf("cpu_quota must be at least 1000 microseconds (or -1 for no quota), got %d", *req.CPUQuota)
+	}
+	if req.CPUPeriod != nil && (*req.CPUPeriod < 1000 || *req.CPUPeriod > 1000000) {
+		return fmt.Errorf("cpu_period must be between 1000 and 1000000 microseconds, got %d", *req.CPUPeriod)
+	}
+	if req.MemoryBytes != nil && *req.MemoryBytes < minContainerMemory {
+		return fmt.Errorf("memory_bytes must be at least %d (6 MiB), got %d", minContainerMemory, *req.MemoryBytes)
+	}
+	if req.MemorySwapBytes != nil && (*req.MemorySwapBytes == 0 || *req.MemorySwapBytes < -1) {
+		return fmt.Errorf("memory_swap_bytes must be -1 (unlimited) or a byte count, got %d", *req.MemorySwapBytes)
+	}
+	if req.MemoryBytes != nil && req.MemorySwapBytes != nil && *req.MemorySwapBytes > 0 && *req.MemorySwapBytes < *req.MemoryBytes {
+		return errors.New("memory_swap_bytes must not be smaller than memory_bytes")
+	}
+	if req.RestartPolicy != nil && !slices.Contains(containerRestartPolicies, *req.RestartPolicy) {
+		return fmt.Errorf("invalid restart policy %q: must be one of %s",
+			*req.RestartPolicy, strings.Join(containerRestartPolicies, ", "))
+	}
+	if req.RestartMaxRetries != nil {
+		if *req.RestartMaxRetries < 0 {
+			return fmt.Errorf("restart_max_retries must not be negative, got %d", *req.RestartMaxRetries)
+		}
+		if *req.RestartMaxRetries > 0 && (req.RestartPolicy == nil || *req.RestartPolicy != "on-failure") {
+			return errors.New("restart_max_retries requires restart_policy on-failure")
+		}
+	}
+	return nil
+}
+
+// maxAutostartWait caps the per-container autostart wait (one hour).
+const maxAutostartWait = 3600
+
+// ValidateAutostartOrder validates a replacement autostart list. The list must
+// be present (an empty list is allowed and disables autostart for every
+// container), names must be valid container names without duplicates, and
+// waits must be between 0 and 3600 seconds.
+func ValidateAutostartOrder(req dto.DockerAutostartRequest) error {
+	if req.Containers == nil {
+		return errors.New("containers is required (use an empty list to disable autostart for all containers)")
+	}
+	seen := make(map[string]bool, len(req.Containers))
+	for _, c := range req.Containers {
+		if !containerNameRegex.MatchString(c.Name) {
+			return fmt.Errorf("invalid container name %q", c.Name)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("container %q is listed more than once", c.Name)
+		}
+		seen[c.Name] = true
+		if c.Wait < 0 || c.Wait > maxAutostartWait {
+			return fmt.Errorf("wait for %q must be between 0 and %d seconds, got %d", c.Name, maxAutostartWait, c.Wait)
+		}
+	}
+	return nil
+}
+
+// dockerNetworkNameRegex matches Docker network names and IDs.
+var dockerNetworkNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,127}$`)
+
+// interfaceNameRegex matches Linux interface names (IFNAMSIZ allows 15
+// characters), including VLAN sub-interfaces such as br0.20.
+var interfaceNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,14}$`)
+
+// predefinedDockerNetworks are created by Docker itself and cannot be
+// created or removed.
+var predefinedDockerNetworks = []string{"bridge", "host", "none"}
+
+// ValidateDockerNetworkRef validates a Docker network name or ID, rejecting
+// the predefined bridge, host and none networks.
+func ValidateDockerNetworkRef(ref string) error {
+	if !dockerNetworkNameRegex.MatchString(ref) {
+		return fmt.Errorf("invalid network name %q: use letters, digits, '_', '.' and '-' (max 128 characters)", ref)
+	}
+	if slices.Contains(predefinedDockerNetworks, ref) {
+		return fmt.Errorf("network %q is predefined by Docker", ref)
+	}
+	return nil
+}
+
+// ValidateDockerNetworkCreate validates a custom Docker network definition.
+// macvlan and ipvlan networks need a parent interface and a subnet, as on the
+// Unraid Docker settings page; the gateway and IP range must lie inside the
+// subnet.
+func ValidateDockerNetworkCreate(req dto.DockerNetworkCreateRequest) error {
+	if err := ValidateDockerNetworkRef(req.Name); err != nil {
+		return err
+	}
+	switch req.Driver {
+	case "bridge":
+		if req.Parent != "" || req.IPVlanMode != "" {
+			return errors.New("parent and ipvlan_mode are only valid for macvlan and ipvlan networks")
+		}
+	case "macvlan", "ipvlan":
+		if !interfaceNameRegex.MatchString(req.Parent) {
+			return fmt.Errorf("%s networks need a parent interface such as br0 or br0.20, got %q", req.Driver, req.Parent)
+		}
+		if req.Subnet == "" {
+			return fmt.Errorf("%s networks need a subnet", req.Driver)
+		}
+	default:
+		return fmt.Errorf("invalid driver %q: must be bridge, macvlan or ipvlan", req.Driver)
+	}
+	if req.IPVlanMode != "" {
+		if req.Driver != "ipvlan" {
+			return errors.New("ipvlan_mode is only valid for ipvlan networks")
+		}
+		if !slices.Contains([]string{"l2", "l3", "l3s"}, req.IPVlanMode) {
+			return fmt.Errorf("invalid ipvlan_mode %q: must be l2, l3 or l3s", req.IPVlanMode)
+		}
+	}
+
+	if req.Subnet == "" {
+		if req.Gateway != "" || req.IPRange != "" {
+			return errors.New("gateway and ip_range need a subnet")
+		}
+		return nil
+	}
+	subnet, err := netip.ParsePrefix(req.Subnet)
+	if err != nil {
+		return fmt.Errorf("invalid subnet %q: %w", req.Subnet, err)
+	}
+	if subnet != subnet.Masked() {
+		return fmt.Errorf("invalid subnet %q: host bits set, did you mean %s?", req.Subnet, subnet.Masked())
+	}
+	if req.Gateway != "" {
+		gateway, err := netip.ParseAddr(req.Gateway)
+		if err != nil {
+			return fmt.Errorf("invalid gateway %q: %w", req.Gateway, err)
+		}
+		if !subnet.Contains(gateway) {
+			return fmt.Errorf("gateway %s is outside subnet %s", gateway, subnet)
+		}
+	}
+	if req.IPRange != "" {
+		ipRange, err := netip.ParsePrefix(req.IPRange)
+		if err != nil {
+			return fmt.Errorf("invalid ip_range %q: %w", req.IPRange, err)
+		}
+		if ipRange.Bits() < subnet.Bits() || !subnet.Contains(ipRange.Masked().Addr()) {
+			return fmt.Errorf("ip_range %s is outside subnet %s", ipRange, subnet)
+		}
+	}
+	return nil
+}
+
+// ValidateUSBDeviceID validates a USB device ID in "vendor:product" form.
+func ValidateUSBDeviceID(id string) error {
+	if !usbDeviceIDRegex.MatchString(id) {
+		return fmt.Errorf("invalid USB device ID %q: expected vendor:product in hex, e.g. 046d:c52b", id)
+	}
+	return nil
+}
+
+// shareFloorRegex matches a share's minimum free space: a number of KiB, or a
+// number with a K, M, G or T suffix (optionally followed by B).
+var shareFloorRegex = regexp.MustCompile(`^([0-9]{1,15})\s*([KMGT]B?)?$`)
+
+// ParseShareFloor converts a share's minimum free space setting to bytes.
+// Suffixes are binary (1K = 1024 bytes) and a plain number is in KiB, as in
+// the share's .cfg file.
+func ParseShareFloor(floor string) (uint64, error) {
+	m := shareFloorRegex.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(floor)))
+	if m == nil {
+		return 0, fmt.Errorf("invalid minimum free space %q: expected a number with an optional K, M, G or T suffix", floor)
+	}
+	n, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid minimum free space %q: %w", floor, err)
+	}
+	shift := map[string]uint{"": 10, "K": 10, "M": 20, "G": 30, "T": 40}[strings.TrimSuffix(m[2], "B")]
+	if n > math.MaxUint64>>shift {
+		return 0, fmt.Errorf("minimum free space %q is too large", floor)
+	}
+	return n << shift, nil
+}
+
+// Share cache and allocation settings accepted by ValidateShareConfig.
+var (
+	shareUseCacheModes = []string{"yes", "no", "only", "prefer"}
+	shareAllocators    = []string{"highwater", "mostfree", "fillup"}
+)
+
+// ValidateShareConfig validates the fields set in a share config update:
+// the cache mode, allocator, minimum free space, cache pool, included and
+// excluded disks and Mover Tuning thresholds. Empty fields are left
+// unchanged by the update and are not checked.
+func ValidateShareConfig(config dto.ShareConfig) error {
+	if config.UseCache != "" && !slices.Contains(shareUseCacheModes, config.UseCache) {
+		return fmt.Errorf("invalid use_cache %q: must be one of %s", config.UseCache, strings.Join(shareUseCacheModes, ", "))
+	}
+	if config.Allocator != "" && !slices.Contains(shareAllocators, config.Allocator) {
+		return fmt.Errorf("invalid allocator %q: must be one of %s", config.Allocator, strings.Join(shareAllocators, ", "))
+	}
+	if config.Floor != "" {
+		if _, err := ParseShareFloor(config.Floor); err != nil {
+			return err
+		}
+	}
+	if config.CachePool != "" {
+		if err := ValidateDiskName(config.CachePool); err != nil {
+			return fmt.Errorf("invalid cache_pool: %w", err)
+		}
+	}
+	for _, disk := range slices.Concat(config.IncludeDisks, config.ExcludeDisks) {
+		if err := ValidateDiskName(disk); err != nil {
+			return fmt.Errorf("invalid included or excluded disk: %w", err)
+		}
+	}
+	if t := config.MoverTuning; t != nil {
+		if t.ThresholdPercent != nil && (*t.ThresholdPercent < 0 || *t.ThresholdPercent > 100) {
+			return fmt.Errorf("mover_tuning.threshold_percent must be between 0 and 100, got %d", *t.ThresholdPercent)
+		}
+		if t.AgeDays != nil && (*t.AgeDays < 0 || *t.AgeDays > 3650) {
+			return fmt.Errorf("mover_tuning.age_days must be between 0 and 3650, got %d", *t.AgeDays)
+		}
+		if t.MinSizeMB != nil && *t.MinSizeMB < 0 {
+			return fmt.Errorf("mover_tuning.min_size_mb must not be negative, got %d", *t.MinSizeMB)
+		}
+	}
+	return nil
+}
@@ -139,6 +139,35 @@ func TestValidateServiceName(t *testing.T) {
 	}
 }
 
+func TestValidateDiskName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"array disk", "disk1", false},
+		{"parity", "parity", false},
+		{"second parity", "parity2", false},
+		{"pool device", "cache", false},
+		{"pool with underscore", "cache_nvme2", false},
+		{"empty string", "", true},
+		{"uppercase", "Disk1", true},
+		{"starts with number", "1disk", true},
+		{"too long", strings.Repeat("a", 33), true},
+		{"path traversal", "../disk1", true},
+		{"command injection", "disk1;id", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDiskName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDiskName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateSnapshotName(t *testing.T) {
 	tests := []struct {
 		name    string
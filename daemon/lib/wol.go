@@ -0,0 +1,154 @@
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// DefaultWOLBroadcast is where magic packets go when no broadcast address is
+// given: the limited broadcast address on the discard port.
+const DefaultWOLBroadcast = "255.255.255.255:9"
+
+// ErrUnknownWOLDevice is returned by Wake for a device name that is not in
+// the configured list.
+var ErrUnknownWOLDevice = errors.New("unknown wake-on-lan device")
+
+// BuildMagicPacket returns the Wake-on-LAN magic packet for mac: six 0xFF
+// bytes followed by the MAC address repeated 16 times.
+func BuildMagicPacket(mac net.HardwareAddr) []byte {
+	packet := bytes.Repeat([]byte{0xff}, 6)
+	for range 16 {
+		packet = append(packet, mac...)
+	}
+	return packet
+}
+
+// ParseWOLMAC parses a 48-bit MAC address in any format net.ParseMAC accepts.
+func ParseWOLMAC(s string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(strings.TrimSpace(s))
+	if err != nil || len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q", s)
+	}
+	return mac, nil
+}
+
+// NormalizeWOLBroadcast validates an IPv4 broadcast address with an optional
+// port and returns it as host:port. Empty means DefaultWOLBroadcast.
+func NormalizeWOLBroadcast(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultWOLBroadcast, nil
+	}
+	host, port := s, "9"
+	if h, p, err := net.SplitHostPort(s); err == nil {
+		host, port = h, p
+	}
+	if ip := net.ParseIP(host); ip == nil || ip.To4() == nil {
+		return "", fmt.Errorf("invalid broadcast address %q: must be an IPv4 address", s)
+	}
+	if n, err := net.LookupPort("udp", port); err != nil || n == 0 {
+		return "", fmt.Errorf("invalid broadcast port %q", port)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// SendWakeOnLAN sends a magic packet for mac to the broadcast address
+// (host[:port], default DefaultWOLBroadcast).
+func SendWakeOnLAN(mac net.HardwareAddr, broadcast string) error {
+	addr, err := NormalizeWOLBroadcast(broadcast)
+	if err != nil {
+		return err
+	}
+	conn, err := net.Dial("udp4", addr)
+	if err != nil {
+		return fmt.Errorf("opening broadcast socket: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err := conn.Write(BuildMagicPacket(mac)); err != nil {
+		return fmt.Errorf("sending magic packet to %s: %w", addr, err)
+	}
+	return nil
+}
+
+// ParseWOLDevices parses "name=MAC[@broadcast]" entries, e.g.
+// "desktop=a8:a1:59:12:34:56@192.168.1.255". Invalid entries are returned as
+// errors and left out of the device list.
+func ParseWOLDevices(entries []string) ([]dto.WOLDevice, []error) {
+	var devices []dto.WOLDevice
+	var errs []error
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		name, rest, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			errs = append(errs, fmt.Errorf("wake-on-lan device %q: want name=MAC[@broadcast]", entry))
+			continue
+		}
+		if seen[name] {
+			errs = append(errs, fmt.Errorf("wake-on-lan device %q: duplicate name", name))
+			continue
+		}
+		macStr, broadcast, _ := strings.Cut(rest, "@")
+		mac, err := ParseWOLMAC(macStr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("wake-on-lan device %q: %w", name, err))
+			continue
+		}
+		addr, err := NormalizeWOLBroadcast(broadcast)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("wake-on-lan device %q: %w", name, err))
+			continue
+		}
+		seen[name] = true
+		devices = append(devices, dto.WOLDevice{Name: name, MAC: mac.String(), Broadcast: addr})
+	}
+	return devices, errs
+}
+
+// Wake sends a magic packet for req: either a device from devices by name or
+// a MAC address, with req.Broadcast overriding the device's broadcast address.
+func Wake(devices []dto.WOLDevice, req dto.WOLRequest) (*dto.WOLResult, error) {
+	macStr, broadcast := req.MAC, req.Broadcast
+	if req.Device != "" {
+		var found *dto.WOLDevice
+		for i := range devices {
+			if devices[i].Name == req.Device {
+				found = &devices[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownWOLDevice, req.Device)
+		}
+		macStr = found.MAC
+		if broadcast == "" {
+			broadcast = found.Broadcast
+		}
+	} else if macStr == "" {
+		return nil, errors.New("device or mac is required")
+	}
+
+	mac, err := ParseWOLMAC(macStr)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := NormalizeWOLBroadcast(broadcast)
+	if err != nil {
+		return nil, err
+	}
+	if err := SendWakeOnLAN(mac, addr); err != nil {
+		return nil, err
+	}
+	return &dto.WOLResult{
+		Device:    req.Device,
+		MAC:       mac.String(),
+		Broadcast: addr,
+		Timestamp: time.Now(),
+	}, nil
+}
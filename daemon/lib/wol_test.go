@@ -0,0 +1,106 @@
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestBuildMagicPacket(t *testing.T) {
+	mac, _ := net.ParseMAC("a8:a1:59:12:34:56")
+	packet := BuildMagicPacket(mac)
+	if len(packet) != 102 {
+		t.Fatalf("len = %d, want 102", len(packet))
+	}
+	if !bytes.Equal(packet[:6], bytes.Repeat([]byte{0xff}, 6)) {
+		t.Errorf("header = %x", packet[:6])
+	}
+	for i := range 16 {
+		if got := packet[6+i*6 : 12+i*6]; !bytes.Equal(got, mac) {
+			t.Errorf("repetition %d = %x", i, got)
+		}
+	}
+}
+
+func TestNormalizeWOLBroadcast(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"", DefaultWOLBroadcast, false},
+		{"192.168.1.255", "192.168.1.255:9", false},
+		{"192.168.1.255:7", "192.168.1.255:7", false},
+		{"nas.local", "", true},
+		{"ff02::1", "", true},
+		{"192.168.1.255:0", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeWOLBroadcast(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeWOLBroadcast(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestParseWOLDevices(t *testing.T) {
+	devices, errs := ParseWOLDevices([]string{
+		"desktop=A8-A1-59-12-34-56@192.168.1.255",
+		"htpc=00:11:22:33:44:55",
+		"desktop=00:11:22:33:44:66",
+		"broken",
+		"bad=zz:11:22:33:44:55",
+	})
+	want := []dto.WOLDevice{
+		{Name: "desktop", MAC: "a8:a1:59:12:34:56", Broadcast: "192.168.1.255:9"},
+		{Name: "htpc", MAC: "00:11:22:33:44:55", Broadcast: DefaultWOLBroadcast},
+	}
+	if len(devices) != len(want) {
+		t.Fatalf("devices = %+v", devices)
+	}
+	for i := range want {
+		if devices[i] != want[i] {
+			t.Errorf("devices[%d] = %+v, want %+v", i, devices[i], want[i])
+		}
+	}
+	if len(errs) != 3 {
+		t.Errorf("errs = %v, want 3", errs)
+	}
+}
+
+func TestWake(t *testing.T) {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("udp listen: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	addr := conn.LocalAddr().String()
+	devices := []dto.WOLDevice{{Name: "desktop", MAC: "a8:a1:59:12:34:56", Broadcast: addr}}
+
+	result, err := Wake(devices, dto.WOLRequest{Device: "desktop"})
+	if err != nil {
+		t.Fatalf("Wake: %v", err)
+	}
+	if result.MAC != "a8:a1:59:12:34:56" || result.Broadcast != addr {
+		t.Errorf("result = %+v", result)
+	}
+	buf := make([]byte, 256)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil || n != 102 {
+		t.Errorf("received %d bytes, err %v", n, err)
+	}
+
+	if _, err := Wake(devices, dto.WOLRequest{Device: "laptop"}); !errors.Is(err, ErrUnknownWOLDevice) {
+		t.Errorf("unknown device err = %v", err)
+	}
+	if _, err := Wake(devices, dto.WOLRequest{}); err == nil {
+		t.Error("empty request: want error")
+	}
+	if _, err := Wake(devices, dto.WOLRequest{MAC: "not-a-mac"}); err == nil {
+		t.Error("invalid MAC: want error")
+	}
+}
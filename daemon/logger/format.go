@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Format selects how log entries are written.
+type Format int32
+
+const (
+	// FormatText writes colour-coded lines prefixed by the standard logger's
+	// date and time (the default).
+	FormatText Format = iota
+	// FormatJSON writes one JSON object per line for log shippers such as
+	// Loki or Elasticsearch.
+	FormatJSON
+)
+
+var currentFormat atomic.Int32
+
+// ParseFormat parses a --log-format value ("text" or "json").
+func ParseFormat(value string) (Format, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "text":
+		return FormatText, true
+	case "json":
+		return FormatJSON, true
+	}
+	return FormatText, false
+}
+
+// SetFormat sets the log format. FormatJSON clears the standard logger's
+// flags, since every entry carries its own timestamp.
+func SetFormat(format Format) {
+	currentFormat.Store(int32(format))
+	if format == FormatJSON {
+		log.SetFlags(0)
+	}
+}
+
+// GetFormat returns the current log format.
+func GetFormat() Format {
+	return Format(currentFormat.Load())
+}
+
+// jsonEntry is one line of FormatJSON output.
+type jsonEntry struct {
+	Time          string `json:"time"`
+	Level         string `json:"level"`
+	Message       string `json:"msg"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// levelNames are the level names used in FormatJSON output.
+var levelNames = map[LogLevel]string{
+	LevelDebug:   "debug",
+	LevelInfo:    "info",
+	LevelWarning: "warning",
+	LevelError:   "error",
+}
+
+// emit writes one entry at level if the current level allows it. In text
+// format the message is wrapped in color and preceded by prefix and the
+// correlation ID of ctx, if any.
+func emit(ctx context.Context, level LogLevel, color, prefix, format string, v []any) {
+	if GetLevel() > level {
+		return
+	}
+	msg := fmt.Sprintf(format, v...)
+
+	if GetFormat() == FormatJSON {
+		log.Print(jsonLine(ctx, levelNames[level], msg))
+		return
+	}
+
+	if id := correlationIDFromContext(ctx); id != "" {
+		msg = "[" + id + "] " + msg
+	}
+	if color == "" {
+		log.Print(prefix + msg)
+		return
+	}
+	log.Print(color + prefix + msg + ColorReset)
+}
+
+// jsonLine encodes a FormatJSON entry.
+func jsonLine(ctx context.Context, level, msg string) string {
+	data, err := json.Marshal(jsonEntry{
+		Time:          time.Now().Format(time.RFC3339Nano),
+		Level:         level,
+		Message:       msg,
+		CorrelationID: correlationIDFromContext(ctx),
+	})
+	if err != nil {
+		return fmt.Sprintf(`{"level":%q,"msg":%q}`, level, msg)
+	}
+	return string(data)
+}
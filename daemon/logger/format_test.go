@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+)
+
+// captureLog redirects the standard logger for the duration of the test.
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	out, flags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	t.Cleanup(func() {
+		log.SetOutput(out)
+		log.SetFlags(flags)
+		SetFormat(FormatText)
+		SetLevel(LevelWarning)
+	})
+	return &buf
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Format
+		ok    bool
+	}{
+		{"", FormatText, true},
+		{"text", FormatText, true},
+		{" JSON ", FormatJSON, true},
+		{"logfmt", FormatText, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseFormat(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseFormat(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	buf := captureLog(t)
+	SetLevel(LevelInfo)
+	SetFormat(FormatJSON)
+
+	ctx := context.WithValue(context.Background(), CorrelationContextKey, "req-42")
+	WarningContext(ctx, "disk %s is hot", "sdb")
+	Debug("filtered out")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+	var entry jsonEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", lines[0], err)
+	}
+	if entry.Level != "warning" || entry.Message != "disk sdb is hot" || entry.CorrelationID != "req-42" || entry.Time == "" {
+		t.Errorf("entry = %+v", entry)
+	}
+}
+
+func TestTextFormatCorrelationID(t *testing.T) {
+	buf := captureLog(t)
+	SetLevel(LevelInfo)
+
+	ctx := context.WithValue(context.Background(), CorrelationContextKey, "req-42")
+	ErrorContext(ctx, "boom")
+	Info("plain")
+
+	out := buf.String()
+	if !strings.Contains(out, ColorRed+"ERROR: [req-42] boom"+ColorReset) {
+		t.Errorf("missing tagged error entry: %q", out)
+	}
+	if strings.Contains(out, "[] plain") || !strings.Contains(out, "plain") {
+		t.Errorf("untagged entry = %q", out)
+	}
+}
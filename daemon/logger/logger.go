@@ -2,10 +2,13 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 	"runtime"
 	"runtime/debug"
+	"sync/atomic"
 )
 
 // stackBuf captures the current goroutine's stack trace and returns it as a
@@ -52,7 +55,13 @@ const (
 	LevelError
 )
 
-var currentLevel = LevelWarning // Default to WARNING level for production
+// currentLevel is read on every log call and can be changed at runtime by a
+// config reload, so it is stored atomically.
+var currentLevel atomic.Int32
+
+func init() {
+	currentLevel.Store(int32(LevelWarning)) // Default to WARNING level for production
+}
 
 // Color codes for terminal output
 const (
@@ -68,56 +77,74 @@ const (
 
 // SetLevel sets the global logging level
 func SetLevel(level LogLevel) {
-	currentLevel = level
+	currentLevel.Store(int32(level))
 }
 
 // GetLevel returns the current logging level
 func GetLevel() LogLevel {
-	return currentLevel
+	return LogLevel(currentLevel.Load())
 }
 
 // Info logs informational messages in blue
 func Info(format string, v ...any) {
-	if currentLevel <= LevelInfo {
-		log.Printf(ColorBlue+format+ColorReset, v...)
-	}
+	emit(context.Background(), LevelInfo, ColorBlue, "", format, v)
 }
 
 // Success logs success messages in green
 func Success(format string, v ...any) {
-	if currentLevel <= LevelInfo {
-		log.Printf(ColorGreen+format+ColorReset, v...)
-	}
+	emit(context.Background(), LevelInfo, ColorGreen, "", format, v)
 }
 
 // Warning logs warning messages in yellow
 func Warning(format string, v ...any) {
-	if currentLevel <= LevelWarning {
-		log.Printf(ColorYellow+"WARNING: "+format+ColorReset, v...)
-	}
+	emit(context.Background(), LevelWarning, ColorYellow, "WARNING: ", format, v)
 }
 
 // Error logs error messages in red
 func Error(format string, v ...any) {
-	if currentLevel <= LevelError {
-		log.Printf(ColorRed+"ERROR: "+format+ColorReset, v...)
-	}
+	emit(context.Background(), LevelError, ColorRed, "ERROR: ", format, v)
 }
 
 // Debug logs debug messages in cyan (only if debug level is enabled)
 func Debug(format string, v ...any) {
-	if currentLevel <= LevelDebug {
-		log.Printf(ColorCyan+"DEBUG: "+format+ColorReset, v...)
-	}
+	emit(context.Background(), LevelDebug, ColorCyan, "DEBUG: ", format, v)
+}
+
+// InfoContext is Info tagged with the correlation ID carried by ctx
+func InfoContext(ctx context.Context, format string, v ...any) {
+	emit(ctx, LevelInfo, ColorBlue, "", format, v)
+}
+
+// WarningContext is Warning tagged with the correlation ID carried by ctx
+func WarningContext(ctx context.Context, format string, v ...any) {
+	emit(ctx, LevelWarning, ColorYellow, "WARNING: ", format, v)
+}
+
+// ErrorContext is Error tagged with the correlation ID carried by ctx
+func ErrorContext(ctx context.Context, format string, v ...any) {
+	emit(ctx, LevelError, ColorRed, "ERROR: ", format, v)
+}
+
+// DebugContext is Debug tagged with the correlation ID carried by ctx
+func DebugContext(ctx context.Context, format string, v ...any) {
+	emit(ctx, LevelDebug, ColorCyan, "DEBUG: ", format, v)
 }
 
 // Fatal logs fatal error and exits
 func Fatal(format string, v ...any) {
+	if GetFormat() == FormatJSON {
+		log.Print(jsonLine(context.Background(), "fatal", fmt.Sprintf(format, v...)))
+		os.Exit(1)
+	}
 	log.Fatalf(ColorRed+"FATAL: "+format+ColorReset, v...)
 }
 
 // Plain logs without color
 func Plain(format string, v ...any) {
+	if GetFormat() == FormatJSON {
+		log.Print(jsonLine(context.Background(), "info", fmt.Sprintf(format, v...)))
+		return
+	}
 	log.Printf(format, v...)
 }
 
@@ -138,16 +165,12 @@ func Green(format string, v ...any) {
 
 // LightGreen logs in light green
 func LightGreen(format string, v ...any) {
-	if currentLevel <= LevelInfo {
-		log.Printf("\033[92m"+format+ColorReset, v...)
-	}
+	emit(context.Background(), LevelInfo, "\033[92m", "", format, v)
 }
 
 // Printf is a wrapper for standard log.Printf
 func Printf(format string, v ...any) {
-	if currentLevel <= LevelInfo {
-		log.Printf(format, v...)
-	}
+	emit(context.Background(), LevelInfo, "", "", format, v)
 }
 
 // LogPanicWithStack logs a recovered panic value along with a stack trace for diagnostics.
@@ -155,11 +178,23 @@ func LogPanicWithStack(prefix string, r any) {
 	Error("%s PANIC: %v\n%s", prefix, r, stackBuf())
 }
 
+// LogPanicWithStackContext is LogPanicWithStack tagged with the correlation
+// ID carried by ctx.
+func LogPanicWithStackContext(ctx context.Context, prefix string, r any) {
+	ErrorContext(ctx, "%s PANIC: %v\n%s", prefix, r, stackBuf())
+}
+
 // Println is a wrapper for standard log.Println
 func Println(v ...any) {
-	if currentLevel <= LevelInfo {
-		log.Println(v...)
+	if GetLevel() > LevelInfo {
+		return
+	}
+	if GetFormat() == FormatJSON {
+		msg := fmt.Sprintln(v...)
+		log.Print(jsonLine(context.Background(), "info", msg[:len(msg)-1]))
+		return
 	}
+	log.Println(v...)
 }
 
 // Sprintf formats and returns a string
@@ -0,0 +1,382 @@
+package acme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+)
+
+// DNS-01 providers selectable with ACME_DNS_PROVIDER.
+const (
+	ProviderCloudflare   = "cloudflare"
+	ProviderDigitalOcean = "digitalocean"
+	ProviderDuckDNS      = "duckdns"
+	ProviderExec         = "exec"
+)
+
+const (
+	// providerTimeout bounds a single DNS provider API call or hook run.
+	providerTimeout = 2 * time.Minute
+
+	// maxProviderResponse caps the API response bodies that are read.
+	maxProviderResponse = 1 << 20
+)
+
+// DNSProvider publishes and removes the TXT record of a DNS-01 challenge.
+// fqdn is the record name without a trailing dot, e.g.
+// "_acme-challenge.tower.example.com".
+type DNSProvider interface {
+	Present(ctx context.Context, fqdn, value string) error
+	CleanUp(ctx context.Context, fqdn, value string) error
+}
+
+// newDNSProvider returns the provider selected in cfg.
+func newDNSProvider(cfg domain.ACMEConfig) (DNSProvider, error) {
+	client := &http.Client{Timeout: providerTimeout}
+	switch cfg.DNSProvider {
+	case ProviderCloudflare:
+		return &cloudflareProvider{token: cfg.DNSToken, baseURL: "https://api.cloudflare.com/client/v4", client: client, records: map[string]cloudflareRecord{}}, nil
+	case ProviderDigitalOcean:
+		return &digitalOceanProvider{token: cfg.DNSToken, baseURL: "https://api.digitalocean.com/v2", client: client, records: map[string]digitalOceanRecord{}}, nil
+	case ProviderDuckDNS:
+		return &duckDNSProvider{token: cfg.DNSToken, baseURL: "https://www.duckdns.org/update", client: client}, nil
+	case ProviderExec:
+		return &execProvider{hook: cfg.DNSHook}, nil
+	default:
+		return nil, fmt.Errorf("unknown dns provider %q", cfg.DNSProvider)
+	}
+}
+
+// zoneCandidates returns fqdn's parent domains from the longest to the
+// shortest that still has two labels, e.g. "a.b.example.com" →
+// ["b.example.com", "example.com"]. The challenge label itself is skipped.
+func zoneCandidates(fqdn string) []string {
+	labels := strings.Split(strings.TrimSuffix(fqdn, "."), ".")
+	var zones []string
+	for i := 1; i+2 <= len(labels); i++ {
+		zones = append(zones, strings.Join(labels[i:], "."))
+	}
+	return zones
+}
+
+// doJSON sends a JSON request with a bearer token and decodes the response
+// into out (when non-nil). It returns the HTTP status code.
+func doJSON(ctx context.Context, client *http.Client, method, rawURL, token string, body, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxProviderResponse))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil && resp.StatusCode < 300 {
+			return resp.StatusCode, fmt.Errorf("decoding response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Cloudflare
+// ──────────────────────────────────────────────────────────────────────────────
+
+// cloudflareProvider manages TXT records with a Cloudflare API token that has
+// Zone:Read and DNS:Edit permission.
+type cloudflareProvider struct {
+	token   string
+	baseURL string
+	client  *http.Client
+
+	mu      sync.Mutex
+	records map[string]cloudflareRecord // fqdn+value → created record
+}
+
+type cloudflareRecord struct {
+	zoneID, recordID string
+}
+
+// cloudflareResponse is the Cloudflare API envelope.
+type cloudflareResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+	Result json.RawMessage `json:"result"`
+}
+
+func (r cloudflareResponse) err(status int) error {
+	if r.Success && status < 300 {
+		return nil
+	}
+	msgs := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		msgs = append(msgs, e.Message)
+	}
+	return fmt.Errorf("cloudflare API: HTTP %d: %s", status, strings.Join(msgs, "; "))
+}
+
+func (p *cloudflareProvider) call(ctx context.Context, method, path string, body, result any) error {
+	var resp cloudflareResponse
+	status, err := doJSON(ctx, p.client, method, p.baseURL+path, p.token, body, &resp)
+	if err != nil {
+		return fmt.Errorf("cloudflare API: %w", err)
+	}
+	if err := resp.err(status); err != nil {
+		return err
+	}
+	if result != nil && len(resp.Result) > 0 {
+		return json.Unmarshal(resp.Result, result)
+	}
+	return nil
+}
+
+// zoneID finds the Cloudflare zone that contains fqdn.
+func (p *cloudflareProvider) zoneID(ctx context.Context, fqdn string) (string, error) {
+	for _, zone := range zoneCandidates(fqdn) {
+		var zones []struct {
+			ID string `json:"id"`
+		}
+		if err := p.call(ctx, http.MethodGet, "/zones?name="+url.QueryEscape(zone), nil, &zones); err != nil {
+			return "", err
+		}
+		if len(zones) > 0 {
+			return zones[0].ID, nil
+		}
+	}
+	return "", fmt.Errorf("no cloudflare zone found for %s", fqdn)
+}
+
+func (p *cloudflareProvider) Present(ctx context.Context, fqdn, value string) error {
+	zoneID, err := p.zoneID(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+	var record struct {
+		ID string `json:"id"`
+	}
+	body := map[string]any{"type": "TXT", "name": fqdn, "content": value, "ttl": 120}
+	if err := p.call(ctx, http.MethodPost, "/zones/"+zoneID+"/dns_records", body, &record); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.records[fqdn+" "+value] = cloudflareRecord{zoneID: zoneID, recordID: record.ID}
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *cloudflareProvider) CleanUp(ctx context.Context, fqdn, value string) error {
+	p.mu.Lock()
+	record, ok := p.records[fqdn+" "+value]
+	delete(p.records, fqdn+" "+value)
+	p.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return p.call(ctx, http.MethodDelete, "/zones/"+record.zoneID+"/dns_records/"+record.recordID, nil, nil)
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// DigitalOcean
+// ──────────────────────────────────────────────────────────────────────────────
+
+// digitalOceanProvider manages TXT records with a DigitalOcean API token that
+// has write access to the domain.
+type digitalOceanProvider struct {
+	token   string
+	baseURL string
+	client  *http.Client
+
+	mu      sync.Mutex
+	records map[string]digitalOceanRecord // fqdn+value → created record
+}
+
+type digitalOceanRecord struct {
+	zone string
+	id   int64
+}
+
+// zone finds the DigitalOcean domain that contains fqdn.
+func (p *digitalOceanProvider) zone(ctx context.Context, fqdn string) (string, error) {
+	for _, zone := range zoneCandidates(fqdn) {
+		status, err := doJSON(ctx, p.client, http.MethodGet, p.baseURL+"/domains/"+url.PathEscape(zone), p.token, nil, nil)
+		if err != nil {
+			return "", fmt.Errorf("digitalocean API: %w", err)
+		}
+		switch {
+		case status == http.StatusOK:
+			return zone, nil
+		case status != http.StatusNotFound:
+			return "", fmt.Errorf("digitalocean API: HTTP %d looking up domain %s", status, zone)
+		}
+	}
+	return "", fmt.Errorf("no digitalocean domain found for %s", fqdn)
+}
+
+func (p *digitalOceanProvider) Present(ctx context.Context, fqdn, value string) error {
+	zone, err := p.zone(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		DomainRecord struct {
+			ID int64 `json:"id"`
+		} `json:"domain_record"`
+		Message string `json:"message"`
+	}
+	body := map[string]any{"type": "TXT", "name": strings.TrimSuffix(fqdn, "."+zone), "data": value, "ttl": 30}
+	status, err := doJSON(ctx, p.client, http.MethodPost, p.baseURL+"/domains/"+url.PathEscape(zone)+"/records", p.token, body, &resp)
+	if err != nil {
+		return fmt.Errorf("digitalocean API: %w", err)
+	}
+	if status >= 300 {
+		return fmt.Errorf("digitalocean API: HTTP %d: %s", status, resp.Message)
+	}
+	p.mu.Lock()
+	p.records[fqdn+" "+value] = digitalOceanRecord{zone: zone, id: resp.DomainRecord.ID}
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *digitalOceanProvider) CleanUp(ctx context.Context, fqdn, value string) error {
+	p.mu.Lock()
+	record, ok := p.records[fqdn+" "+value]
+	delete(p.records, fqdn+" "+value)
+	p.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	status, err := doJSON(ctx, p.client, http.MethodDelete, fmt.Sprintf("%s/domains/%s/records/%d", p.baseURL, url.PathEscape(record.zone), record.id), p.token, nil, nil)
+	if err != nil {
+		return fmt.Errorf("digitalocean API: %w", err)
+	}
+	if status >= 300 && status != http.StatusNotFound {
+		return fmt.Errorf("digitalocean API: HTTP %d deleting record", status)
+	}
+	return nil
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// DuckDNS
+// ──────────────────────────────────────────────────────────────────────────────
+
+// duckDNSProvider sets the TXT record of a duckdns.org subdomain. DuckDNS
+// holds a single TXT value per subdomain, so a certificate for both
+// "name.duckdns.org" and "*.name.duckdns.org" cannot be validated at once.
+type duckDNSProvider struct {
+	token   string
+	baseURL string
+	client  *http.Client
+}
+
+// duckDNSSubdomain returns the DuckDNS subdomain of a challenge fqdn, e.g.
+// "_acme-challenge.www.tower.duckdns.org" → "tower".
+func duckDNSSubdomain(fqdn string) (string, error) {
+	name, ok := strings.CutSuffix(strings.TrimSuffix(fqdn, "."), ".duckdns.org")
+	if !ok {
+		return "", fmt.Errorf("%s is not a duckdns.org name", fqdn)
+	}
+	labels := strings.Split(name, ".")
+	return labels[len(labels)-1], nil
+}
+
+func (p *duckDNSProvider) update(ctx context.Context, fqdn string, query url.Values) error {
+	sub, err := duckDNSSubdomain(fqdn)
+	if err != nil {
+		return err
+	}
+	query.Set("domains", sub)
+	query.Set("token", p.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// The URL carries the token; report the failure without it.
+		return fmt.Errorf("duckdns update failed: %w", unwrapURLError(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if strings.TrimSpace(string(body)) != "OK" {
+		return fmt.Errorf("duckdns update rejected (check the token and subdomain %s)", sub)
+	}
+	return nil
+}
+
+func (p *duckDNSProvider) Present(ctx context.Context, fqdn, value string) error {
+	return p.update(ctx, fqdn, url.Values{"txt": {value}})
+}
+
+func (p *duckDNSProvider) CleanUp(ctx context.Context, fqdn, _ string) error {
+	return p.update(ctx, fqdn, url.Values{"txt": {""}, "clear": {"true"}})
+}
+
+// unwrapURLError drops the request URL from a *url.Error.
+func unwrapURLError(err error) error {
+	if urlErr, ok := err.(*url.Error); ok { //nolint:errorlint // only the outer error carries the URL
+		return urlErr.Err
+	}
+	return err
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Exec hook
+// ──────────────────────────────────────────────────────────────────────────────
+
+// execProvider runs a user script for DNS providers without built-in
+// support: "<hook> present <fqdn> <value>" and "<hook> cleanup <fqdn> <value>".
+type execProvider struct {
+	hook string
+}
+
+func (p *execProvider) run(ctx context.Context, action, fqdn, value string) error {
+	ctx, cancel := context.WithTimeout(ctx, providerTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, p.hook, action, fqdn, value).CombinedOutput() //nolint:gosec // G204: the hook is configured by the administrator
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if len(msg) > 500 {
+			msg = msg[:500]
+		}
+		return fmt.Errorf("dns hook %s failed: %w: %s", action, err, msg)
+	}
+	return nil
+}
+
+func (p *execProvider) Present(ctx context.Context, fqdn, value string) error {
+	return p.run(ctx, "present", fqdn, value)
+}
+
+func (p *execProvider) CleanUp(ctx context.Context, fqdn, value string) error {
+	return p.run(ctx, "cleanup", fqdn, value)
+}
@@ -0,0 +1,165 @@
+package acme
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestZoneCandidates(t *testing.T) {
+	got := zoneCandidates("_acme-challenge.tower.home.example.com")
+	want := []string{"tower.home.example.com", "home.example.com", "example.com"}
+	if !slices.Equal(got, want) {
+		t.Errorf("zoneCandidates() = %v, want %v", got, want)
+	}
+}
+
+func TestCloudflareProvider(t *testing.T) {
+	var created map[string]any
+	deleted := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer cf-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"message":"bad token"}]}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/zones":
+			if r.URL.Query().Get("name") == "example.com" {
+				_, _ = w.Write([]byte(`{"success":true,"result":[{"id":"zone1"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success":true,"result":[]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/zones/zone1/dns_records":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"success":true,"result":{"id":"rec1"}}`))
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path
+			_, _ = w.Write([]byte(`{"success":true,"result":{"id":"rec1"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p := &cloudflareProvider{token: "cf-token", baseURL: srv.URL, client: srv.Client(), records: map[string]cloudflareRecord{}}
+	ctx := context.Background()
+	if err := p.Present(ctx, "_acme-challenge.tower.example.com", "abc"); err != nil {
+		t.Fatalf("Present: %v", err)
+	}
+	if created["type"] != "TXT" || created["name"] != "_acme-challenge.tower.example.com" || created["content"] != "abc" {
+		t.Errorf("created record = %v", created)
+	}
+	if err := p.CleanUp(ctx, "_acme-challenge.tower.example.com", "abc"); err != nil {
+		t.Fatalf("CleanUp: %v", err)
+	}
+	if deleted != "/zones/zone1/dns_records/rec1" {
+		t.Errorf("deleted %q", deleted)
+	}
+
+	p.token = "wrong"
+	if err := p.Present(ctx, "_acme-challenge.tower.example.com", "abc"); err == nil || !strings.Contains(err.Error(), "bad token") {
+		t.Errorf("Present with bad token = %v", err)
+	}
+}
+
+func TestDigitalOceanProvider(t *testing.T) {
+	var created map[string]any
+	deleted := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/domains/example.com":
+			_, _ = w.Write([]byte(`{"domain":{"name":"example.com"}}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/domains/example.com/records":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"domain_record":{"id":42}}`))
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	p := &digitalOceanProvider{token: "do", baseURL: srv.URL, client: srv.Client(), records: map[string]digitalOceanRecord{}}
+	ctx := context.Background()
+	if err := p.Present(ctx, "_acme-challenge.tower.example.com", "abc"); err != nil {
+		t.Fatalf("Present: %v", err)
+	}
+	if created["name"] != "_acme-challenge.tower" || created["data"] != "abc" {
+		t.Errorf("created record = %v", created)
+	}
+	if err := p.CleanUp(ctx, "_acme-challenge.tower.example.com", "abc"); err != nil {
+		t.Fatalf("CleanUp: %v", err)
+	}
+	if deleted != "/domains/example.com/records/42" {
+		t.Errorf("deleted %q", deleted)
+	}
+}
+
+func TestDuckDNSProvider(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("token") != "duck" {
+			_, _ = w.Write([]byte("KO"))
+			return
+		}
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+
+	p := &duckDNSProvider{token: "duck", baseURL: srv.URL, client: srv.Client()}
+	ctx := context.Background()
+	if err := p.Present(ctx, "_acme-challenge.www.tower.duckdns.org", "abc"); err != nil {
+		t.Fatalf("Present: %v", err)
+	}
+	if err := p.CleanUp(ctx, "_acme-challenge.www.tower.duckdns.org", "abc"); err != nil {
+		t.Fatalf("CleanUp: %v", err)
+	}
+	if len(queries) != 2 || !strings.Contains(queries[0], "domains=tower") || !strings.Contains(queries[0], "txt=abc") || !strings.Contains(queries[1], "clear=true") {
+		t.Errorf("queries = %v", queries)
+	}
+
+	p.token = "wrong"
+	if err := p.Present(ctx, "_acme-challenge.tower.duckdns.org", "abc"); err == nil {
+		t.Error("Present with a rejected token: want error")
+	}
+	if err := p.Present(ctx, "_acme-challenge.tower.example.com", "abc"); err == nil {
+		t.Error("Present for a non-duckdns name: want error")
+	}
+}
+
+func TestExecProvider(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "calls")
+	hook := filepath.Join(dir, "hook.sh")
+	script := "#!/bin/sh\necho \"$1 $2 $3\" >> " + log + "\n[ \"$3\" != fail ] || { echo boom; exit 1; }\n"
+	if err := os.WriteFile(hook, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &execProvider{hook: hook}
+	ctx := context.Background()
+	if err := p.Present(ctx, "_acme-challenge.tower.example.com", "abc"); err != nil {
+		t.Fatalf("Present: %v", err)
+	}
+	if err := p.CleanUp(ctx, "_acme-challenge.tower.example.com", "abc"); err != nil {
+		t.Fatalf("CleanUp: %v", err)
+	}
+	data, _ := os.ReadFile(log)
+	if string(data) != "present _acme-challenge.tower.example.com abc\ncleanup _acme-challenge.tower.example.com abc\n" {
+		t.Errorf("hook calls = %q", data)
+	}
+	if err := p.Present(ctx, "_acme-challenge.tower.example.com", "fail"); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("failing hook = %v", err)
+	}
+}
@@ -0,0 +1,586 @@
+// Package acme obtains and renews the certificate of the agent's HTTPS
+// listener from an ACME CA (Let's Encrypt by default) using DNS-01
+// challenges, so servers exposed under a public FQDN get a trusted
+// certificate without opening port 80. Certificates are stored on the flash
+// drive and survive reboots.
+package acme
+
+import (
+	"cmp"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DefaultDir is where the account key, certificate and key are stored.
+	DefaultDir = "/boot/config/plugins/unraid-management-agent/acme"
+
+	// DefaultRenewDays is how many days before expiry a certificate is renewed.
+	DefaultRenewDays = 30
+
+	accountKeyFile = "account.key"
+	certFile       = "cert.pem"
+	keyFile        = "key.pem"
+
+	// checkInterval is how often the certificate's expiry is checked.
+	checkInterval = 12 * time.Hour
+
+	// retryInterval is the wait after a failed issuance. Let's Encrypt
+	// limits failed validations to 5 per hour per account and name.
+	retryInterval = time.Hour
+
+	// issueTimeout bounds a whole issuance, DNS propagation included.
+	issueTimeout = 15 * time.Minute
+
+	// propagationTimeout and propagationPoll control the wait for the
+	// challenge record to become visible before the CA is asked to check it.
+	propagationTimeout = 3 * time.Minute
+	propagationPoll    = 10 * time.Second
+
+	// cleanupTimeout bounds the removal of a challenge record.
+	cleanupTimeout = 30 * time.Second
+)
+
+// ErrRenewalInProgress is returned by Renew while an issuance is running.
+var ErrRenewalInProgress = errors.New("certificate renewal already in progress")
+
+// ValidateConfig checks the ACME settings before the manager is created.
+func ValidateConfig(cfg domain.ACMEConfig) error {
+	if len(cfg.Domains) == 0 {
+		return errors.New("acme requires at least one domain (ACME_DOMAINS)")
+	}
+	for _, d := range cfg.Domains {
+		name := strings.TrimPrefix(d, "*.")
+		if err := lib.ValidateHostOrIP(name); err != nil || net.ParseIP(name) != nil || !strings.Contains(name, ".") {
+			return fmt.Errorf("invalid acme domain %q: must be a fully qualified domain name", d)
+		}
+	}
+	if cfg.Email != "" && !strings.Contains(cfg.Email, "@") {
+		return fmt.Errorf("invalid acme email %q", cfg.Email)
+	}
+	switch cfg.DNSProvider {
+	case ProviderCloudflare, ProviderDigitalOcean:
+		if cfg.DNSToken == "" {
+			return fmt.Errorf("acme dns provider %s requires ACME_DNS_TOKEN", cfg.DNSProvider)
+		}
+	case ProviderDuckDNS:
+		if cfg.DNSToken == "" {
+			return errors.New("acme dns provider duckdns requires ACME_DNS_TOKEN")
+		}
+		for _, d := range cfg.Domains {
+			if !strings.HasSuffix(d, ".duckdns.org") {
+				return fmt.Errorf("acme domain %q is not a duckdns.org name", d)
+			}
+		}
+	case ProviderExec:
+		if !filepath.IsAbs(cfg.DNSHook) {
+			return errors.New("acme dns provider exec requires ACME_DNS_HOOK as an absolute path")
+		}
+	default:
+		return fmt.Errorf("unknown acme dns provider %q: must be cloudflare, digitalocean, duckdns, or exec", cfg.DNSProvider)
+	}
+	if cfg.DirectoryURL != "" && !strings.HasPrefix(cfg.DirectoryURL, "https://") {
+		return fmt.Errorf("acme directory %q must be an https:// URL", cfg.DirectoryURL)
+	}
+	if cfg.RenewDays < 0 || cfg.RenewDays > 60 {
+		return errors.New("acme renew days must be between 1 and 60")
+	}
+	return nil
+}
+
+// Manager serves the HTTPS certificate and renews it in the background.
+// Until the first certificate is issued a self-signed one is served, so the
+// listener can start immediately.
+type Manager struct {
+	cfg       domain.ACMEConfig
+	dir       string
+	renewDays int
+	dns       DNSProvider
+
+	// waitPropagation blocks until the challenge record is visible in DNS;
+	// tests replace it.
+	waitPropagation func(ctx context.Context, fqdn, value string) error
+
+	cert    atomic.Pointer[tls.Certificate]
+	renewCh chan struct{}
+
+	mu     sync.Mutex
+	status dto.TLSStatus
+}
+
+// NewManager validates cfg and loads the stored certificate from dir (empty
+// means DefaultDir).
+func NewManager(cfg domain.ACMEConfig, dir string) (*Manager, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		dir = DefaultDir
+	}
+	if cfg.DirectoryURL == "" {
+		cfg.DirectoryURL = acme.LetsEncryptURL
+	}
+	provider, err := newDNSProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		cfg:             cfg,
+		dir:             dir,
+		renewDays:       cmp.Or(cfg.RenewDays, DefaultRenewDays),
+		dns:             provider,
+		waitPropagation: waitForTXT,
+		renewCh:         make(chan struct{}, 1),
+		status: dto.TLSStatus{
+			Mode:        dto.TLSModeACME,
+			Domains:     cfg.Domains,
+			DNSProvider: cfg.DNSProvider,
+		},
+	}
+	if err := m.loadStored(); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warning("ACME: ignoring stored certificate: %v", err)
+		}
+		if err := m.installSelfSigned(); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// GetCertificate returns the current certificate; it is used as
+// tls.Config.GetCertificate.
+func (m *Manager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if cert := m.cert.Load(); cert != nil {
+		return cert, nil
+	}
+	return nil, errors.New("no certificate available")
+}
+
+// Status returns the served certificate and the renewal state.
+func (m *Manager) Status() dto.TLSStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status := m.status
+	status.Timestamp = time.Now()
+	return status
+}
+
+// Renew asks the background loop to issue a new certificate now.
+func (m *Manager) Renew() error {
+	m.mu.Lock()
+	renewing := m.status.Renewing
+	m.mu.Unlock()
+	if renewing {
+		return ErrRenewalInProgress
+	}
+	select {
+	case m.renewCh <- struct{}{}:
+		return nil
+	default:
+		return ErrRenewalInProgress
+	}
+}
+
+// Start issues the certificate when it is missing or due for renewal, then
+// checks again every 12 hours until ctx is cancelled.
+func (m *Manager) Start(ctx context.Context) {
+	force := false
+	for {
+		wait := checkInterval
+		if force || m.needsRenewal(time.Now()) {
+			if err := m.issue(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logger.Error("ACME: certificate issuance for %s failed: %v", strings.Join(m.cfg.Domains, ", "), err)
+				wait = retryInterval
+			}
+		}
+		force = false
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-m.renewCh:
+			timer.Stop()
+			force = true
+		}
+	}
+}
+
+// needsRenewal reports whether the served certificate is a placeholder, does
+// not cover the configured domains, or expires within renewDays.
+func (m *Manager) needsRenewal(now time.Time) bool {
+	cert := m.cert.Load()
+	if cert == nil || cert.Leaf == nil {
+		return true
+	}
+	m.mu.Lock()
+	selfSigned := m.status.SelfSigned
+	m.mu.Unlock()
+	if selfSigned || !coversDomains(cert.Leaf, m.cfg.Domains) {
+		return true
+	}
+	return now.After(cert.Leaf.NotAfter.Add(-time.Duration(m.renewDays) * 24 * time.Hour))
+}
+
+// coversDomains reports whether leaf lists exactly the configured domains.
+func coversDomains(leaf *x509.Certificate, domains []string) bool {
+	have := slices.Clone(leaf.DNSNames)
+	want := slices.Clone(domains)
+	slices.Sort(have)
+	slices.Sort(want)
+	return slices.Equal(have, want)
+}
+
+// issue runs one ACME order and installs the resulting certificate.
+func (m *Manager) issue(ctx context.Context) error {
+	now := time.Now()
+	m.mu.Lock()
+	m.status.Renewing = true
+	m.status.LastAttempt = &now
+	m.mu.Unlock()
+
+	err := m.obtain(ctx)
+
+	m.mu.Lock()
+	m.status.Renewing = false
+	m.status.LastError = ""
+	if err != nil {
+		m.status.LastError = err.Error()
+	}
+	m.mu.Unlock()
+	return err
+}
+
+func (m *Manager) obtain(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, issueTimeout)
+	defer cancel()
+
+	client, err := m.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("ACME: requesting a certificate for %s from %s", strings.Join(m.cfg.Domains, ", "), m.cfg.DirectoryURL)
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(m.cfg.Domains...))
+	if err != nil {
+		return fmt.Errorf("creating order: %w", err)
+	}
+	for _, authzURL := range order.AuthzURLs {
+		if err := m.authorize(ctx, client, authzURL); err != nil {
+			return err
+		}
+	}
+	if order, err = client.WaitOrder(ctx, order.URI); err != nil {
+		return fmt.Errorf("waiting for order: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: m.cfg.Domains}, key)
+	if err != nil {
+		return fmt.Errorf("creating CSR: %w", err)
+	}
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return fmt.Errorf("finalizing order: %w", err)
+	}
+
+	cert, err := m.store(chain, key)
+	if err != nil {
+		return err
+	}
+	m.install(cert, false)
+	logger.Success("ACME: certificate for %s issued, valid until %s", strings.Join(m.cfg.Domains, ", "), cert.Leaf.NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// client returns an ACME client with a registered account, creating the
+// account key on first use.
+func (m *Manager) client(ctx context.Context) (*acme.Client, error) {
+	key, err := m.accountKey()
+	if err != nil {
+		return nil, err
+	}
+	client := &acme.Client{Key: key, DirectoryURL: m.cfg.DirectoryURL, UserAgent: "unraid-management-agent"}
+
+	account := &acme.Account{}
+	if m.cfg.Email != "" {
+		account.Contact = []string{"mailto:" + m.cfg.Email}
+	}
+	if _, err := client.Register(ctx, account, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("registering account: %w", err)
+	}
+	return client, nil
+}
+
+// authorize completes the DNS-01 challenge of one authorization.
+func (m *Manager) authorize(ctx context.Context, client *acme.Client, authzURL string) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("fetching authorization: %w", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("no dns-01 challenge offered for %s", authz.Identifier.Value)
+	}
+	value, err := client.DNS01ChallengeRecord(challenge.Token)
+	if err != nil {
+		return err
+	}
+
+	// Wildcard identifiers are validated on the base name's challenge label.
+	fqdn := "_acme-challenge." + strings.TrimPrefix(authz.Identifier.Value, "*.")
+	if err := m.dns.Present(ctx, fqdn, value); err != nil {
+		return fmt.Errorf("publishing %s: %w", fqdn, err)
+	}
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+		defer cancel()
+		if err := m.dns.CleanUp(cleanupCtx, fqdn, value); err != nil {
+			logger.Warning("ACME: removing %s failed: %v", fqdn, err)
+		}
+	}()
+
+	if err := m.waitPropagation(ctx, fqdn, value); err != nil {
+		return err
+	}
+	if _, err := client.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("accepting challenge for %s: %w", authz.Identifier.Value, err)
+	}
+	if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("validating %s: %w", authz.Identifier.Value, err)
+	}
+	return nil
+}
+
+// waitForTXT polls DNS until fqdn has a TXT record with value. When the
+// record is still not visible after propagationTimeout (e.g. a split-horizon
+// resolver), the CA is asked to check anyway.
+func waitForTXT(ctx context.Context, fqdn, value string) error {
+	deadline := time.Now().Add(propagationTimeout)
+	for {
+		if records, err := net.DefaultResolver.LookupTXT(ctx, fqdn); err == nil && slices.Contains(records, value) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			logger.Warning("ACME: %s not visible in local DNS after %s; continuing", fqdn, propagationTimeout)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(propagationPoll):
+		}
+	}
+}
+
+// accountKey loads the ACME account key, creating it on first use.
+func (m *Manager) accountKey() (crypto.Signer, error) {
+	path := filepath.Join(m.dir, accountKeyFile)
+	if data, err := os.ReadFile(path); err == nil {
+		return parseECKey(data)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	data, err := encodeECKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(path, data, 0o600); err != nil {
+		return nil, fmt.Errorf("saving account key: %w", err)
+	}
+	return key, nil
+}
+
+// store writes the certificate chain and key to disk and returns them as a
+// tls.Certificate.
+func (m *Manager) store(chain [][]byte, key *ecdsa.PrivateKey) (*tls.Certificate, error) {
+	var certPEM []byte
+	for _, der := range chain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	keyPEM, err := encodeECKey(key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("issued certificate is invalid: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(m.dir, keyFile), keyPEM, 0o600); err != nil {
+		return nil, fmt.Errorf("saving certificate key: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(m.dir, certFile), certPEM, 0o644); err != nil {
+		return nil, fmt.Errorf("saving certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// loadStored loads the certificate saved by a previous run.
+func (m *Manager) loadStored() error {
+	cert, err := tls.LoadX509KeyPair(filepath.Join(m.dir, certFile), filepath.Join(m.dir, keyFile))
+	if err != nil {
+		return err
+	}
+	m.install(&cert, false)
+	return nil
+}
+
+// installSelfSigned serves a temporary certificate for the configured domains
+// until the first one is issued.
+func (m *Manager) installSelfSigned() error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: m.cfg.Domains[0]},
+		DNSNames:     m.cfg.Domains,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(30 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return fmt.Errorf("creating temporary certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return err
+	}
+	m.install(&tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, true)
+	return nil
+}
+
+// install makes cert the served certificate and updates the status.
+func (m *Manager) install(cert *tls.Certificate, selfSigned bool) {
+	if cert.Leaf == nil {
+		if leaf, err := x509.ParseCertificate(cert.Certificate[0]); err == nil {
+			cert.Leaf = leaf
+		}
+	}
+	m.cert.Store(cert)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status.SelfSigned = selfSigned
+	m.status.Issuer, m.status.NotBefore, m.status.NotAfter, m.status.NextRenewal = "", nil, nil, nil
+	if leaf := cert.Leaf; leaf != nil {
+		notBefore, notAfter := leaf.NotBefore, leaf.NotAfter
+		m.status.Issuer = leaf.Issuer.CommonName
+		m.status.NotBefore = &notBefore
+		m.status.NotAfter = &notAfter
+		if !selfSigned {
+			next := notAfter.Add(-time.Duration(m.renewDays) * 24 * time.Hour)
+			m.status.NextRenewal = &next
+		}
+	}
+}
+
+// CertificateStatus describes a certificate loaded from PEM files, for the
+// "files" TLS mode.
+func CertificateStatus(certPath, keyPath string) dto.TLSStatus {
+	status := dto.TLSStatus{Mode: dto.TLSModeFiles, Timestamp: time.Now()}
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		status.LastError = err.Error()
+		return status
+	}
+	if leaf := cert.Leaf; leaf != nil {
+		notBefore, notAfter := leaf.NotBefore, leaf.NotAfter
+		status.Domains = leaf.DNSNames
+		status.Issuer = leaf.Issuer.CommonName
+		status.NotBefore = &notBefore
+		status.NotAfter = &notAfter
+	}
+	return status
+}
+
+func encodeECKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+func parseECKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("account key is not PEM encoded")
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place,
+// so a crash never leaves a truncated key or certificate behind.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package acme
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+)
+
+func testConfig() domain.ACMEConfig {
+	return domain.ACMEConfig{
+		Enabled:     true,
+		Domains:     []string{"tower.example.com"},
+		DNSProvider: ProviderCloudflare,
+		DNSToken:    "token",
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*domain.ACMEConfig)
+		wantErr bool
+	}{
+		{"valid", func(*domain.ACMEConfig) {}, false},
+		{"wildcard", func(c *domain.ACMEConfig) { c.Domains = []string{"*.example.com", "example.com"} }, false},
+		{"no domains", func(c *domain.ACMEConfig) { c.Domains = nil }, true},
+		{"ip address", func(c *domain.ACMEConfig) { c.Domains = []string{"192.168.1.10"} }, true},
+		{"single label", func(c *domain.ACMEConfig) { c.Domains = []string{"tower"} }, true},
+		{"bad email", func(c *domain.ACMEConfig) { c.Email = "admin" }, true},
+		{"missing token", func(c *domain.ACMEConfig) { c.DNSToken = "" }, true},
+		{"unknown provider", func(c *domain.ACMEConfig) { c.DNSProvider = "route53" }, true},
+		{"duckdns other domain", func(c *domain.ACMEConfig) { c.DNSProvider = ProviderDuckDNS }, true},
+		{"duckdns", func(c *domain.ACMEConfig) {
+			c.DNSProvider, c.Domains = ProviderDuckDNS, []string{"tower.duckdns.org"}
+		}, false},
+		{"exec relative hook", func(c *domain.ACMEConfig) { c.DNSProvider, c.DNSHook = ProviderExec, "hook.sh" }, true},
+		{"exec", func(c *domain.ACMEConfig) { c.DNSProvider, c.DNSHook = ProviderExec, "/boot/config/acme-hook.sh" }, false},
+		{"http directory", func(c *domain.ACMEConfig) { c.DirectoryURL = "http://ca.example.com/dir" }, true},
+		{"renew days too high", func(c *domain.ACMEConfig) { c.RenewDays = 90 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			tt.mutate(&cfg)
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// testChain returns a certificate for domains that expires after validFor.
+func testChain(t *testing.T, domains []string, validFor time.Duration) ([][]byte, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domains[0]},
+		DNSNames:     domains,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validFor),
+	}
+	parent := &x509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "Test CA"}}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return [][]byte{der}, key
+}
+
+func TestNewManagerServesSelfSignedUntilIssued(t *testing.T) {
+	m, err := NewManager(testConfig(), t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	cert, err := m.GetCertificate(nil)
+	if err != nil || cert.Leaf == nil || cert.Leaf.DNSNames[0] != "tower.example.com" {
+		t.Fatalf("GetCertificate() = %v, %v", cert, err)
+	}
+	status := m.Status()
+	if !status.SelfSigned || status.Mode != "acme" || status.NextRenewal != nil {
+		t.Errorf("status = %+v", status)
+	}
+	if !m.needsRenewal(time.Now()) {
+		t.Error("needsRenewal() = false for the self-signed placeholder")
+	}
+}
+
+func TestManagerStoresAndReloadsCertificate(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewManager(testConfig(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, key := testChain(t, []string{"tower.example.com"}, 90*24*time.Hour)
+	cert, err := m.store(chain, key)
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	m.install(cert, false)
+	if m.needsRenewal(time.Now()) {
+		t.Error("needsRenewal() = true for a certificate valid for 90 days")
+	}
+	if m.needsRenewal(time.Now().Add(61*24*time.Hour)) == false {
+		t.Error("needsRenewal() = false within 30 days of expiry")
+	}
+
+	// A restart picks the stored certificate up again.
+	reloaded, err := NewManager(testConfig(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := reloaded.Status()
+	if status.SelfSigned || status.Issuer != "Test CA" || status.NextRenewal == nil {
+		t.Errorf("reloaded status = %+v", status)
+	}
+
+	// Changing the domains forces a new certificate.
+	cfg := testConfig()
+	cfg.Domains = []string{"tower.example.com", "nas.example.com"}
+	changed, err := NewManager(cfg, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed.needsRenewal(time.Now()) {
+		t.Error("needsRenewal() = false after the domains changed")
+	}
+
+	files := CertificateStatus(filepath.Join(dir, certFile), filepath.Join(dir, keyFile))
+	if files.Mode != "files" || files.Issuer != "Test CA" || files.NotAfter == nil {
+		t.Errorf("CertificateStatus() = %+v", files)
+	}
+}
+
+func TestManagerAccountKeyIsReused(t *testing.T) {
+	m, err := NewManager(testConfig(), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := m.accountKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := m.accountKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.(*ecdsa.PrivateKey).Equal(second) {
+		t.Error("accountKey() created a new key instead of loading the stored one")
+	}
+}
+
+func TestRenewQueuesOnce(t *testing.T) {
+	m, err := NewManager(testConfig(), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Renew(); err != nil {
+		t.Fatalf("first Renew() = %v", err)
+	}
+	if err := m.Renew(); !errors.Is(err, ErrRenewalInProgress) {
+		t.Errorf("second Renew() = %v, want ErrRenewalInProgress", err)
+	}
+}
@@ -0,0 +1,236 @@
+// Package agentlog reads the agent's own rotated log file back as structured
+// entries, so the log can be inspected over the API without shell access.
+package agentlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// BaseName is the agent log file name without extension. lumberjack
+	// names rotated backups <BaseName>-<timestamp>.log next to it.
+	BaseName = "unraid-management-agent"
+
+	// DefaultLimit and MaxLimit bound the number of entries returned.
+	DefaultLimit = 200
+	MaxLimit     = 5000
+
+	// timeLayout matches the log.LstdFlags prefix of every entry.
+	timeLayout = "2006/01/02 15:04:05"
+
+	// maxMessageLength caps a single entry, including folded stack traces.
+	maxMessageLength = 16 << 10
+)
+
+var (
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	// correlationPattern matches the "[id] " prefix of text entries.
+	correlationPattern = regexp.MustCompile(`^\[([A-Za-z0-9._:-]{1,128})\] `)
+)
+
+// levelPrefixes maps the prefixes written by the logger package to levels.
+// Entries without a prefix are info (Info, Success and the colour helpers).
+var levelPrefixes = []struct {
+	prefix string
+	level  logger.LogLevel
+}{
+	{"DEBUG: ", logger.LevelDebug},
+	{"WARNING: ", logger.LevelWarning},
+	{"ERROR: ", logger.LevelError},
+	{"FATAL: ", logger.LevelError},
+}
+
+// Query filters the entries returned by Read.
+type Query struct {
+	// MinLevel drops entries below this level.
+	MinLevel logger.LogLevel
+	// Since drops entries logged before this time when set.
+	Since time.Time
+	// CorrelationID keeps only entries logged for this request, job or
+	// collector when set.
+	CorrelationID string
+	// Limit keeps only the newest entries (DefaultLimit when zero).
+	Limit int
+}
+
+// Read returns the entries of the agent log in logsDir, including the rotated
+// backup, that match q. Messages are redacted.
+func Read(logsDir string, q Query) (*dto.AgentLog, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+
+	result := &dto.AgentLog{
+		Files:         Files(logsDir),
+		Level:         LevelName(q.MinLevel),
+		CorrelationID: q.CorrelationID,
+		Entries:       []dto.AgentLogEntry{},
+		Timestamp:     time.Now(),
+	}
+	if !q.Since.IsZero() {
+		since := q.Since
+		result.Since = &since
+	}
+
+	for _, path := range result.Files {
+		entries, err := readFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		for _, entry := range entries {
+			if entry.level < q.MinLevel || (!q.Since.IsZero() && entry.Timestamp.Before(q.Since)) {
+				continue
+			}
+			if q.CorrelationID != "" && entry.CorrelationID != q.CorrelationID {
+				continue
+			}
+			entry.Message = lib.Redact(entry.Message)
+			result.Entries = append(result.Entries, entry.AgentLogEntry)
+		}
+	}
+
+	if len(result.Entries) > q.Limit {
+		result.Entries = result.Entries[len(result.Entries)-q.Limit:]
+		result.Truncated = true
+	}
+	result.Count = len(result.Entries)
+	return result, nil
+}
+
+// Files returns the agent log paths in logsDir, rotated backups first and the
+// live file last.
+func Files(logsDir string) []string {
+	backups, _ := filepath.Glob(filepath.Join(logsDir, BaseName+"-*.log"))
+	// Backup names embed a sortable timestamp.
+	sort.Strings(backups)
+	return append(backups, filepath.Join(logsDir, BaseName+".log"))
+}
+
+// LevelName returns the name of a log level as used in entries and queries.
+func LevelName(level logger.LogLevel) string {
+	switch level {
+	case logger.LevelDebug:
+		return "debug"
+	case logger.LevelWarning:
+		return "warning"
+	case logger.LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+type entry struct {
+	dto.AgentLogEntry
+	level logger.LogLevel
+}
+
+func readFile(path string) ([]entry, error) {
+	file, err := os.Open(path) // #nosec G304 -- path is built from the trusted LogsDir configuration
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Debug("Agent log: failed to close %s: %v", path, err)
+		}
+	}()
+	return parse(file)
+}
+
+// parse splits log output into entries. Lines without a timestamp prefix
+// continue the previous entry.
+func parse(r io.Reader) ([]entry, error) {
+	var entries []entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := ansiPattern.ReplaceAllString(scanner.Text(), "")
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "{") {
+			if e, ok := parseJSON(line); ok {
+				entries = append(entries, e)
+				continue
+			}
+		}
+
+		if len(line) > len(timeLayout) && line[len(timeLayout)] == ' ' {
+			if ts, err := time.ParseInLocation(timeLayout, line[:len(timeLayout)], time.Local); err == nil {
+				entries = append(entries, newEntry(ts, line[len(timeLayout)+1:]))
+				continue
+			}
+		}
+
+		if n := len(entries); n > 0 && len(entries[n-1].Message) < maxMessageLength {
+			entries[n-1].Message += "\n" + line
+		}
+	}
+	return entries, scanner.Err()
+}
+
+func newEntry(ts time.Time, message string) entry {
+	level := logger.LevelInfo
+	for _, p := range levelPrefixes {
+		if rest, ok := strings.CutPrefix(message, p.prefix); ok {
+			level, message = p.level, rest
+			break
+		}
+	}
+	var correlationID string
+	if m := correlationPattern.FindStringSubmatch(message); m != nil {
+		correlationID, message = m[1], message[len(m[0]):]
+	}
+	return entry{
+		AgentLogEntry: dto.AgentLogEntry{Timestamp: ts, Level: LevelName(level), Message: message, CorrelationID: correlationID},
+		level:         level,
+	}
+}
+
+// jsonLine is an entry written with --log-format=json.
+type jsonLine struct {
+	Time          time.Time `json:"time"`
+	Level         string    `json:"level"`
+	Message       string    `json:"msg"`
+	CorrelationID string    `json:"correlation_id"`
+}
+
+func parseJSON(line string) (entry, bool) {
+	var l jsonLine
+	if err := json.Unmarshal([]byte(line), &l); err != nil || l.Time.IsZero() {
+		return entry{}, false
+	}
+	level, ok := domain.ParseLogLevel(l.Level)
+	if !ok {
+		// fatal
+		level = logger.LevelError
+	}
+	return entry{
+		AgentLogEntry: dto.AgentLogEntry{Timestamp: l.Time, Level: LevelName(level), Message: l.Message, CorrelationID: l.CorrelationID},
+		level:         level,
+	}, true
+}
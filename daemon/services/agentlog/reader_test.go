@@ -0,0 +1,129 @@
+package agentlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const sampleLog = "2026/10/17 09:00:00 \x1b[34mStarting collectors\x1b[0m\n" +
+	"2026/10/17 09:00:01 \x1b[36mDEBUG: MQTT: connect password=hunter2\x1b[0m\n" +
+	"2026/10/17 09:05:00 \x1b[33mWARNING: Docker: slow response\x1b[0m\n" +
+	"2026/10/17 09:10:00 \x1b[31mERROR: Collector panic PANIC: boom\n" +
+	"goroutine 1 [running]:\n" +
+	"main.main()\x1b[0m\n"
+
+func writeLogs(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	backup := "2026/10/17 08:00:00 Previous run\n"
+	if err := os.WriteFile(filepath.Join(dir, BaseName+"-2026-10-17T08-30-00.000.log"), []byte(backup), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, BaseName+".log"), []byte(sampleLog), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestReadLevelsAndContinuations(t *testing.T) {
+	result, err := Read(writeLogs(t), Query{MinLevel: logger.LevelDebug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Files) != 2 || result.Count != 5 {
+		t.Fatalf("files = %v, count = %d", result.Files, result.Count)
+	}
+
+	first := result.Entries[0]
+	if first.Message != "Previous run" || first.Level != "info" {
+		t.Errorf("backup entry = %+v", first)
+	}
+	debug := result.Entries[2]
+	if debug.Level != "debug" || strings.Contains(debug.Message, "hunter2") || strings.Contains(debug.Message, "\x1b") {
+		t.Errorf("debug entry = %+v", debug)
+	}
+	last := result.Entries[4]
+	if last.Level != "error" || !strings.Contains(last.Message, "goroutine 1 [running]:\nmain.main()") {
+		t.Errorf("error entry = %+v", last)
+	}
+	want := time.Date(2026, 10, 17, 9, 10, 0, 0, time.Local)
+	if !last.Timestamp.Equal(want) {
+		t.Errorf("timestamp = %v, want %v", last.Timestamp, want)
+	}
+}
+
+func TestReadFilters(t *testing.T) {
+	dir := writeLogs(t)
+
+	result, err := Read(dir, Query{MinLevel: logger.LevelWarning})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 2 || result.Level != "warning" {
+		t.Errorf("warning filter: count = %d, level = %s", result.Count, result.Level)
+	}
+
+	result, err = Read(dir, Query{Since: time.Date(2026, 10, 17, 9, 0, 0, 0, time.Local)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 4 || result.Since == nil {
+		t.Errorf("since filter: count = %d, entries = %+v", result.Count, result.Entries)
+	}
+
+	result, err = Read(dir, Query{MinLevel: logger.LevelDebug, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 2 || !result.Truncated || result.Entries[1].Level != "error" {
+		t.Errorf("limit: %+v", result)
+	}
+}
+
+func TestReadMissingLog(t *testing.T) {
+	result, err := Read(t.TempDir(), Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 0 || result.Entries == nil {
+		t.Errorf("missing log: %+v", result)
+	}
+}
+
+func TestReadCorrelationID(t *testing.T) {
+	dir := t.TempDir()
+	content := "2026/10/17 09:00:00 \x1b[33mWARNING: [collector:docker] Docker: slow response\x1b[0m\n" +
+		`{"time":"2026-10-17T09:01:00Z","level":"error","msg":"API: Failed to start VM","correlation_id":"req-42"}` + "\n" +
+		`{"time":"2026-10-17T09:02:00Z","level":"fatal","msg":"Failed to start"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, BaseName+".log"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Read(dir, Query{MinLevel: logger.LevelInfo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 3 {
+		t.Fatalf("count = %d: %+v", result.Count, result.Entries)
+	}
+	text := result.Entries[0]
+	if text.CorrelationID != "collector:docker" || text.Message != "Docker: slow response" || text.Level != "warning" {
+		t.Errorf("text entry = %+v", text)
+	}
+	if fatal := result.Entries[2]; fatal.Level != "error" || fatal.CorrelationID != "" {
+		t.Errorf("fatal entry = %+v", fatal)
+	}
+
+	result, err = Read(dir, Query{MinLevel: logger.LevelInfo, CorrelationID: "req-42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 1 || result.Entries[0].Message != "API: Failed to start VM" || result.CorrelationID != "req-42" {
+		t.Errorf("correlation filter: %+v", result)
+	}
+}
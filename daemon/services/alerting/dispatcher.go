@@ -7,6 +7,7 @@ import (
 	"github.com/nicholas-fedor/shoutrrr"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
 )
@@ -63,13 +64,13 @@ func (d *Dispatcher) sendToUnraid(event dto.AlertEvent) error {
 		importance = "normal"
 	}
 
-	subject := fmt.Sprintf("Alert: %s", event.RuleName)
+	subject := i18n.T("Alert: %s", event.RuleName)
 	if event.State == "resolved" {
-		subject = fmt.Sprintf("Resolved: %s", event.RuleName)
+		subject = i18n.T("Resolved: %s", event.RuleName)
 	}
 
 	return controllers.CreateNotification(
-		"Management Agent Alert",
+		i18n.T("Management Agent Alert"),
 		subject,
 		event.Message,
 		importance,
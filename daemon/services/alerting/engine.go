@@ -2,7 +2,10 @@ package alerting
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -20,6 +23,13 @@ const (
 
 	// MaxHistoryEvents is the maximum number of alert events kept in memory.
 	MaxHistoryEvents = 100
+
+	// wanIPChangeWindow is how long WANIPChanged stays true after a public IP change.
+	wanIPChangeWindow = 15 * time.Minute
+
+	// historySaveInterval is how often the downsampled metrics history is
+	// written to the flash drive. Kept coarse to limit flash wear.
+	historySaveInterval = time.Hour
 )
 
 // DataProvider defines the interface for reading cached collector data.
@@ -37,20 +47,31 @@ type DataProvider interface {
 	GetNUTCache() *dto.NUTResponse
 	GetNotificationsCache() *dto.NotificationList
 	GetPluginUpdatesCache() *dto.PluginList
+	GetWANStatusCache() *dto.WANStatus
+	GetRemoteMountsCache() *dto.RemoteMountHealth
+	GetPoolsCache() []dto.PoolInfo
 	// DegradedSubsystemCount reports how many data sources are not healthy (OS-resilience).
 	DegradedSubsystemCount() int
 }
 
+// Maintenance reports whether a maintenance window is in effect.
+type Maintenance interface {
+	Active() bool
+}
+
 // Engine orchestrates alert rule evaluation and notification dispatch.
 // It periodically builds an AlertEnv from cached collector data, evaluates
 // all enabled rules via the Evaluator, and dispatches notifications via the Dispatcher.
 type Engine struct {
-	store      *Store
-	evaluator  *Evaluator
-	dispatcher *Dispatcher
-	provider   DataProvider
-	history    *MetricsHistory
-	hub        *domain.EventBus
+	store       *Store
+	evaluator   *Evaluator
+	dispatcher  *Dispatcher
+	provider    DataProvider
+	history     *MetricsHistory
+	thermal     *thermalMonitor
+	utilization *utilizationMonitor
+	hub         *domain.EventBus
+	maint       Maintenance
 
 	mu           sync.RWMutex
 	alertHistory []dto.AlertEvent
@@ -64,6 +85,8 @@ func NewEngine(store *Store, provider DataProvider) *Engine {
 		dispatcher:   NewDispatcher(),
 		provider:     provider,
 		history:      NewMetricsHistory(240, time.Hour),
+		thermal:      newThermalMonitor(),
+		utilization:  newUtilizationMonitor(),
 		alertHistory: make([]dto.AlertEvent, 0, MaxHistoryEvents),
 	}
 }
@@ -98,16 +121,26 @@ func (e *Engine) Start(ctx context.Context) {
 	// Compile all loaded rules
 	e.compileEnabledRules()
 
+	historyPath := e.historyPath()
+	if err := e.history.Load(historyPath, time.Now()); err != nil {
+		logger.Warning("Alerting: Failed to load metrics history: %v", err)
+	}
+
 	logger.Info("Alerting: Engine started (eval interval: %s)", EvalInterval)
 
 	ticker := time.NewTicker(EvalInterval)
 	defer ticker.Stop()
+	saveTicker := time.NewTicker(historySaveInterval)
+	defer saveTicker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
+			e.saveHistory(historyPath)
 			logger.Info("Alerting: Engine stopped")
 			return
+		case <-saveTicker.C:
+			e.saveHistory(historyPath)
 		case <-ticker.C:
 			func() {
 				defer func() {
@@ -121,10 +154,30 @@ func (e *Engine) Start(ctx context.Context) {
 	}
 }
 
+// historyPath returns where the downsampled metrics history is persisted,
+// next to the alert rules file.
+func (e *Engine) historyPath() string {
+	return filepath.Join(filepath.Dir(e.store.filePath), MetricsHistoryFile)
+}
+
+// saveHistory persists the downsampled metrics history, logging failures.
+func (e *Engine) saveHistory(path string) {
+	if err := e.history.Save(path); err != nil {
+		logger.Warning("Alerting: Failed to save metrics history: %v", err)
+	}
+}
+
 // evaluate runs one evaluation cycle for all enabled rules.
 func (e *Engine) evaluate() {
 	now := time.Now()
 	e.sampleHistory(now)
+	// Rules keep their last state during maintenance, so problems that
+	// outlast the window still fire once it ends.
+	if e.maint != nil && e.maint.Active() {
+		return
+	}
+	e.checkThermal(now)
+	e.checkUtilization(now)
 	env := e.buildEnv()
 	e.overlayTrends(&env)
 	rules := e.store.GetEnabledRules()
@@ -145,13 +198,27 @@ func (e *Engine) evaluate() {
 
 		e.addHistory(event)
 		e.dispatcher.Dispatch(result.Rule, event)
+		e.publishEvent(event)
 		e.publishWake(event)
 	}
 }
 
-// SetEventBus wires the pubsub hub so firing alerts can wake the agent.
+// SetEventBus wires the pubsub hub so dispatched alerts reach subscribers
+// (notification forwarding) and firing alerts can wake the agent.
 func (e *Engine) SetEventBus(hub *domain.EventBus) { e.hub = hub }
 
+// SetMaintenance suppresses rule evaluation and thermal events while m
+// reports a maintenance window. Call before Start.
+func (e *Engine) SetMaintenance(m Maintenance) { e.maint = m }
+
+// publishEvent emits a dispatched AlertEvent (no-op if no hub).
+func (e *Engine) publishEvent(event dto.AlertEvent) {
+	if e.hub == nil {
+		return
+	}
+	domain.Publish(e.hub, constants.TopicAlertEvent, event)
+}
+
 // publishWake emits an AgentWakeEvent for a firing alert (no-op if no hub or not firing).
 func (e *Engine) publishWake(event dto.AlertEvent) {
 	if e.hub == nil || event.State != "firing" {
@@ -272,6 +339,9 @@ func smartRawInt(raw string) int {
 func (e *Engine) sampleHistory(now time.Time) {
 	if sys := e.provider.GetSystemCache(); sys != nil {
 		e.history.Record("cpu_temp", "", sys.CPUTemp, now)
+		e.history.Record("motherboard_temp", "", sys.MotherboardTemp, now)
+		e.history.Record("cpu_usage", "", sys.CPUUsage, now)
+		e.history.Record("ram_used_pct", "", sys.RAMUsage, now)
 	}
 	if arr := e.provider.GetArrayCache(); arr != nil {
 		e.history.Record("array_used_pct", "", arr.UsedPercent, now)
@@ -318,6 +388,17 @@ func (e *Engine) sampleHistory(now time.Time) {
 		}
 	}
 	e.history.pruneEntities("restart_count", containerIDs)
+
+	gpuIDs := map[string]bool{}
+	for _, g := range e.provider.GetGPUCache() {
+		if g == nil || !g.Available {
+			continue
+		}
+		id := strconv.Itoa(g.Index)
+		gpuIDs[id] = true
+		e.history.Record("gpu_temp", id, g.Temperature, now)
+	}
+	e.history.pruneEntities("gpu_temp", gpuIDs)
 }
 
 // overlayTrends computes trend/predictive fields from the MetricsHistory and
@@ -412,30 +493,136 @@ func (e *Engine) overlayTrends(env *dto.AlertEnv) {
 // QueryHistory returns the samples and summary statistics for a metric series.
 // Pass entity="" for global (non-entity) metrics such as cpu_temp or array_used_pct.
 func (e *Engine) QueryHistory(metric, entity string) dto.MetricHistoryResult {
-	s := e.history.SeriesSnapshot(metric, entity)
-	res := dto.MetricHistoryResult{Metric: metric, Entity: entity, Count: len(s)}
+	return e.QueryHistoryRange(metric, entity, time.Time{}, time.Time{})
+}
+
+// QueryHistoryRange is QueryHistory restricted to samples in [from, to]. A zero
+// from or to leaves that side unbounded.
+func (e *Engine) QueryHistoryRange(metric, entity string, from, to time.Time) dto.MetricHistoryResult {
+	s := e.history.SeriesRange(metric, entity, from, to)
+	res := dto.MetricHistoryResult{
+		Metric:           metric,
+		Entity:           entity,
+		Count:            len(s),
+		RetentionSeconds: int64(e.history.Retention().Seconds()),
+		Note:             e.retentionNote(from),
+	}
 	if len(s) == 0 {
 		return res
 	}
 	res.Slope = e.history.slope(s)
-	res.Min = s[0].v
-	res.Max = s[0].v
-	var sum float64
 	for _, p := range s {
 		res.Samples = append(res.Samples, dto.MetricSample{TimeUnix: p.t.Unix(), Value: p.v})
-		if p.v < res.Min {
-			res.Min = p.v
-		}
-		if p.v > res.Max {
-			res.Max = p.v
-		}
-		sum += p.v
 	}
-	res.Avg = sum / float64(len(s))
+	stats := periodStats(s, from, to)
+	res.Min, res.Max, res.Avg = stats.Min, stats.Max, stats.Avg
 	res.Last = s[len(s)-1].v
 	return res
 }
 
+// ComparePeriods summarizes a metric series over two time windows and reports
+// how period B differs from period A.
+func (e *Engine) ComparePeriods(metric, entity string, aFrom, aTo, bFrom, bTo time.Time) dto.MetricPeriodComparison {
+	res := dto.MetricPeriodComparison{
+		Metric:           metric,
+		Entity:           entity,
+		PeriodA:          periodStats(e.history.SeriesRange(metric, entity, aFrom, aTo), aFrom, aTo),
+		PeriodB:          periodStats(e.history.SeriesRange(metric, entity, bFrom, bTo), bFrom, bTo),
+		RetentionSeconds: int64(e.history.Retention().Seconds()),
+	}
+	earliest := aFrom
+	if bFrom.Before(earliest) {
+		earliest = bFrom
+	}
+	res.Note = e.retentionNote(earliest)
+
+	if res.PeriodA.Count == 0 || res.PeriodB.Count == 0 {
+		if res.Note == "" {
+			res.Note = "one or both periods contain no samples"
+		}
+		return res
+	}
+	res.AvgDelta = res.PeriodB.Avg - res.PeriodA.Avg
+	res.MaxDelta = res.PeriodB.Max - res.PeriodA.Max
+	if res.PeriodA.Avg != 0 {
+		res.AvgDeltaPct = res.AvgDelta / math.Abs(res.PeriodA.Avg) * 100
+	}
+	return res
+}
+
+// retentionNote explains how much of a requested range can be answered: ranges
+// older than the raw tier are served from RollupInterval averages, and nothing
+// is kept beyond Retention.
+func (e *Engine) retentionNote(from time.Time) string {
+	if from.IsZero() {
+		return ""
+	}
+	now := time.Now()
+	if retention := e.history.Retention(); from.Before(now.Add(-retention)) {
+		return fmt.Sprintf("requested range starts before the retained history; samples are kept for %s", retention)
+	}
+	if raw := e.history.RawRetention(); from.Before(now.Add(-raw)) {
+		return fmt.Sprintf("samples older than %s are %s averages", raw, RollupInterval)
+	}
+	return ""
+}
+
+// periodStats computes count/min/max/avg over s, labelled with the requested window.
+func periodStats(s []sample, from, to time.Time) dto.MetricPeriodStats {
+	stats := dto.MetricPeriodStats{Count: len(s)}
+	if !from.IsZero() {
+		stats.StartUnix = from.Unix()
+	}
+	if !to.IsZero() {
+		stats.EndUnix = to.Unix()
+	}
+	if len(s) == 0 {
+		return stats
+	}
+	if from.IsZero() {
+		stats.StartUnix = s[0].t.Unix()
+	}
+	if to.IsZero() {
+		stats.EndUnix = s[len(s)-1].t.Unix()
+	}
+	stats.Min, stats.Max = s[0].v, s[0].v
+	var sum float64
+	for _, p := range s {
+		stats.Min = math.Min(stats.Min, p.v)
+		stats.Max = math.Max(stats.Max, p.v)
+		sum += p.v
+	}
+	stats.Avg = sum / float64(len(s))
+	return stats
+}
+
+// ParseTimeRange parses the time-range arguments shared by the history REST
+// endpoint and MCP tools. start and end are RFC 3339 timestamps; lookback is a
+// Go duration (e.g. "30m") measured back from now and is ignored when start is
+// set. Empty values leave that side of the range unbounded.
+func ParseTimeRange(start, end, lookback string, now time.Time) (from, to time.Time, err error) {
+	if start != "" {
+		if from, err = time.Parse(time.RFC3339, start); err != nil {
+			return from, to, fmt.Errorf("invalid start %q: must be RFC 3339", start)
+		}
+	} else if lookback != "" {
+		d, perr := time.ParseDuration(lookback)
+		if perr != nil || d <= 0 {
+			return from, to, fmt.Errorf("invalid lookback %q: must be a positive duration such as 30m or 1h", lookback)
+		}
+		from = now.Add(-d)
+	}
+	if end != "" {
+		if to, err = time.Parse(time.RFC3339, end); err != nil {
+			return from, to, fmt.Errorf("invalid end %q: must be RFC 3339", end)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return from, to, errors.New("start must not be after end")
+	}
+	return from, to, nil
+}
+
 // buildEnv constructs an AlertEnv from the current cached collector data.
 func (e *Engine) buildEnv() dto.AlertEnv {
 	env := dto.AlertEnv{}
@@ -497,6 +684,12 @@ func (e *Engine) buildEnv() dto.AlertEnv {
 			if c.UpdateAvailable != nil && *c.UpdateAvailable {
 				env.ContainerUpdatesAvailable++
 			}
+			for _, probe := range c.HealthProbes {
+				if !probe.Healthy {
+					env.UnhealthyContainerProbes++
+					break
+				}
+			}
 		}
 	}
 
@@ -565,6 +758,21 @@ func (e *Engine) buildEnv() dto.AlertEnv {
 		}
 	}
 
+	// WAN — WANOnline defaults to true so a disabled wan collector never
+	// looks like an outage.
+	env.WANOnline = true
+	if wan := e.provider.GetWANStatusCache(); wan != nil {
+		env.WANOnline = wan.Online
+		env.WANLatencyMs = wan.AvgLatencyMs
+		env.WANPacketLossPct = wan.PacketLossPct
+		env.WANIPChanged = wan.PublicIPChangedAt != nil && time.Since(*wan.PublicIPChangedAt) < wanIPChangeWindow
+	}
+
+	// Remote NFS/SMB mounts
+	if mounts := e.provider.GetRemoteMountsCache(); mounts != nil {
+		env.StaleRemoteMounts = mounts.StaleCount
+	}
+
 	// NUT
 	if nut := e.provider.GetNUTCache(); nut != nil && nut.Status != nil {
 		env.NUTStatus = nut.Status.Status
@@ -41,6 +41,22 @@ func TestEngineNoWakeWhenResolvedOrNoBus(t *testing.T) {
 	}
 }
 
+func TestEnginePublishesAlertEvents(t *testing.T) {
+	bus := domain.NewEventBus(8)
+	ch := bus.SubTopics(constants.TopicAlertEvent)
+	e := NewEngine(NewStore(t.TempDir()), nil)
+	e.SetEventBus(bus)
+	e.publishEvent(dto.AlertEvent{RuleID: "cpu", State: "resolved"})
+	select {
+	case msg := <-ch:
+		if ev := msg.(dto.AlertEvent); ev.RuleID != "cpu" || ev.State != "resolved" {
+			t.Fatalf("unexpected event: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no alert event published")
+	}
+}
+
 // mockDataProvider implements DataProvider for testing.
 type mockDataProvider struct {
 	system     *dto.SystemInfo
@@ -49,6 +65,9 @@ type mockDataProvider struct {
 	containers []dto.ContainerInfo
 	vms        []dto.VMInfo
 	ups        *dto.UPSStatus
+	gpus       []*dto.GPUMetrics
+	wan        *dto.WANStatus
+	pools      []dto.PoolInfo
 
 	degradedCount int
 }
@@ -59,12 +78,15 @@ func (m *mockDataProvider) GetDisksCache() []dto.DiskInfo                { retur
 func (m *mockDataProvider) GetDockerCache() []dto.ContainerInfo          { return m.containers }
 func (m *mockDataProvider) GetVMsCache() []dto.VMInfo                    { return m.vms }
 func (m *mockDataProvider) GetUPSCache() *dto.UPSStatus                  { return m.ups }
-func (m *mockDataProvider) GetGPUCache() []*dto.GPUMetrics               { return nil }
+func (m *mockDataProvider) GetGPUCache() []*dto.GPUMetrics               { return m.gpus }
 func (m *mockDataProvider) GetZFSPoolsCache() []dto.ZFSPool              { return nil }
+func (m *mockDataProvider) GetPoolsCache() []dto.PoolInfo                { return m.pools }
 func (m *mockDataProvider) GetNetworkCache() []dto.NetworkInfo           { return nil }
 func (m *mockDataProvider) GetNUTCache() *dto.NUTResponse                { return nil }
 func (m *mockDataProvider) GetNotificationsCache() *dto.NotificationList { return nil }
 func (m *mockDataProvider) GetPluginUpdatesCache() *dto.PluginList       { return nil }
+func (m *mockDataProvider) GetWANStatusCache() *dto.WANStatus            { return m.wan }
+func (m *mockDataProvider) GetRemoteMountsCache() *dto.RemoteMountHealth { return nil }
 func (m *mockDataProvider) DegradedSubsystemCount() int                  { return m.degradedCount }
 
 func newMockProvider() *mockDataProvider {
@@ -317,6 +339,54 @@ func TestContainerUpdatesAvailableMetric(t *testing.T) {
 	}
 }
 
+func TestUnhealthyContainerProbesMetric(t *testing.T) {
+	provider := &mockDataProvider{
+		containers: []dto.ContainerInfo{
+			{Name: "plex", State: "running", HealthProbes: []dto.HealthCheckStatus{
+				{CheckID: "plex-http", Healthy: false},
+				{CheckID: "plex-tcp", Healthy: false},
+			}},
+			{Name: "sonarr", State: "running", HealthProbes: []dto.HealthCheckStatus{
+				{CheckID: "sonarr-http", Healthy: true},
+			}},
+			{Name: "radarr", State: "running"},
+		},
+	}
+	e := NewEngine(NewStore(t.TempDir()), provider)
+	env := e.buildEnv()
+	if env.UnhealthyContainerProbes != 1 {
+		t.Errorf("UnhealthyContainerProbes = %d, want 1", env.UnhealthyContainerProbes)
+	}
+}
+
+func TestWANMetrics(t *testing.T) {
+	recent := time.Now().Add(-5 * time.Minute)
+	stale := time.Now().Add(-2 * time.Hour)
+	tests := []struct {
+		name        string
+		wan         *dto.WANStatus
+		wantOnline  bool
+		wantChanged bool
+	}{
+		{"no wan data defaults online", nil, true, false},
+		{"offline", &dto.WANStatus{Online: false, PacketLossPct: 100}, false, false},
+		{"recent ip change", &dto.WANStatus{Online: true, PublicIPChangedAt: &recent}, true, true},
+		{"old ip change", &dto.WANStatus{Online: true, PublicIPChangedAt: &stale}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEngine(NewStore(t.TempDir()), &mockDataProvider{wan: tt.wan})
+			env := e.buildEnv()
+			if env.WANOnline != tt.wantOnline || env.WANIPChanged != tt.wantChanged {
+				t.Errorf("WANOnline=%v WANIPChanged=%v, want %v/%v", env.WANOnline, env.WANIPChanged, tt.wantOnline, tt.wantChanged)
+			}
+			if tt.wan != nil && env.WANPacketLossPct != tt.wan.PacketLossPct {
+				t.Errorf("WANPacketLossPct = %v, want %v", env.WANPacketLossPct, tt.wan.PacketLossPct)
+			}
+		})
+	}
+}
+
 func TestEngineTrendFields(t *testing.T) {
 	provider := &mockDataProvider{}
 	e := NewEngine(NewStore(t.TempDir()), provider)
@@ -372,3 +442,36 @@ func TestEngineEvaluateIntegration(t *testing.T) {
 		t.Error("expected at least 1 history event")
 	}
 }
+
+// fixedMaintenance is a maintenance window that is always or never active.
+type fixedMaintenance bool
+
+func (m fixedMaintenance) Active() bool { return bool(m) }
+
+func TestEngineSkipsEvaluationDuringMaintenance(t *testing.T) {
+	store := NewStore(t.TempDir())
+	engine := NewEngine(store, newMockProvider())
+	store.CreateRule(dto.AlertRule{
+		ID:         "cpu-test",
+		Name:       "CPU Over 50",
+		Expression: "CPU > 50",
+		Severity:   "warning",
+		Channels:   []string{},
+		Enabled:    true,
+	})
+	engine.compileEnabledRules()
+
+	maint := fixedMaintenance(true)
+	engine.SetMaintenance(&maint)
+	engine.evaluate()
+	if history := engine.GetHistory(); len(history) != 0 {
+		t.Errorf("history during maintenance = %+v, want none", history)
+	}
+
+	// The rule fires on the first evaluation after the window ends.
+	maint = false
+	engine.evaluate()
+	if history := engine.GetHistory(); len(history) != 1 || history[0].State != "firing" {
+		t.Errorf("history after maintenance = %+v, want one firing event", history)
+	}
+}
@@ -1,9 +1,27 @@
 package alerting
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+const (
+	// RollupInterval is the bucket width of the downsampled long-term tier.
+	RollupInterval = 5 * time.Minute
+
+	// RollupRetention is how long downsampled buckets are kept.
+	RollupRetention = 7 * 24 * time.Hour
+
+	// MetricsHistoryFile is the filename the downsampled tier is persisted to,
+	// alongside the alert rules.
+	MetricsHistoryFile = "metrics_history.json"
 )
 
 // sample is one timestamped metric reading.
@@ -12,9 +30,24 @@ type sample struct {
 	v float64
 }
 
-// MetricsHistory holds bounded in-memory ring buffers of metric samples for
-// trend/ETA computation. Tier-0: memory-only, never persisted. Sampled on the
-// alert eval tick. Thread-safe for concurrent reads (history query API).
+// seriesKey identifies a series in the downsampled tier (entity "" = global).
+type seriesKey struct {
+	metric string
+	entity string
+}
+
+// rollupBucket accumulates raw samples for the RollupInterval bucket starting at start.
+type rollupBucket struct {
+	start time.Time
+	sum   float64
+	n     int
+}
+
+// MetricsHistory holds metric samples in two tiers. Tier-0 is a bounded
+// in-memory ring buffer of raw samples used for trend/ETA computation. Tier-1
+// keeps RollupInterval averages for RollupRetention so range queries can reach
+// back days; it can be persisted with Save/Load. Sampled on the alert eval
+// tick. Thread-safe for concurrent reads (history query API).
 type MetricsHistory struct {
 	mu       sync.RWMutex
 	maxCount int
@@ -22,6 +55,9 @@ type MetricsHistory struct {
 
 	globalSeries map[string][]sample
 	entitySeries map[string]map[string][]sample
+
+	rollups map[seriesKey][]sample
+	pending map[seriesKey]*rollupBucket
 }
 
 // NewMetricsHistory creates a history bounded by maxCount samples and maxAge per series.
@@ -31,12 +67,15 @@ func NewMetricsHistory(maxCount int, maxAge time.Duration) *MetricsHistory {
 		maxAge:       maxAge,
 		globalSeries: map[string][]sample{},
 		entitySeries: map[string]map[string][]sample{},
+		rollups:      map[seriesKey][]sample{},
+		pending:      map[seriesKey]*rollupBucket{},
 	}
 }
 
 func (h *MetricsHistory) recordAt(metric, entity string, v float64, t time.Time) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	h.addToRollup(seriesKey{metric, entity}, sample{t, v})
 	if entity == "" {
 		h.globalSeries[metric] = h.appendBounded(h.globalSeries[metric], sample{t, v})
 		return
@@ -70,6 +109,39 @@ func (h *MetricsHistory) appendBounded(s []sample, x sample) []sample {
 	return trimmed
 }
 
+// addToRollup folds x into the open bucket for k, closing it into the
+// long-term tier once x falls into a later bucket (must be called with lock held).
+func (h *MetricsHistory) addToRollup(k seriesKey, x sample) {
+	start := x.t.Truncate(RollupInterval)
+	b := h.pending[k]
+	if b != nil && !b.start.Equal(start) {
+		h.rollups[k] = appendRollup(h.rollups[k], sample{b.start, b.sum / float64(b.n)}, x.t)
+		b = nil
+	}
+	if b == nil {
+		b = &rollupBucket{start: start}
+		h.pending[k] = b
+	}
+	b.sum += x.v
+	b.n++
+}
+
+// appendRollup appends a closed bucket and drops buckets older than RollupRetention.
+func appendRollup(s []sample, x sample, now time.Time) []sample {
+	s = append(s, x)
+	cutoff := now.Add(-RollupRetention)
+	start := 0
+	for start < len(s) && s[start].t.Before(cutoff) {
+		start++
+	}
+	if start == 0 {
+		return s
+	}
+	trimmed := make([]sample, len(s)-start)
+	copy(trimmed, s[start:])
+	return trimmed
+}
+
 // pruneEntities drops per-entity series for a metric whose entity is not in keep.
 func (h *MetricsHistory) pruneEntities(metric string, keep map[string]bool) {
 	h.mu.Lock()
@@ -80,6 +152,16 @@ func (h *MetricsHistory) pruneEntities(metric string, keep map[string]bool) {
 			delete(m, id)
 		}
 	}
+	for k := range h.rollups {
+		if k.metric == metric && k.entity != "" && !keep[k.entity] {
+			delete(h.rollups, k)
+		}
+	}
+	for k := range h.pending {
+		if k.metric == metric && k.entity != "" && !keep[k.entity] {
+			delete(h.pending, k)
+		}
+	}
 }
 
 // slope returns least-squares slope in value-units per SECOND. 0 if <2 points.
@@ -124,6 +206,126 @@ func (h *MetricsHistory) etaToThreshold(s []sample, threshold float64) float64 {
 	return seconds / 3600.0
 }
 
+// SeriesRange returns a copy of the samples in [from, to]. A zero from or to
+// leaves that side of the range unbounded. Raw samples are used where the
+// in-memory ring still covers the range; older parts of the range are served
+// from the RollupInterval averages, each stamped at the start of its bucket.
+func (h *MetricsHistory) SeriesRange(metric, entity string, from, to time.Time) []sample {
+	raw := h.SeriesSnapshot(metric, entity)
+
+	h.mu.RLock()
+	long := h.rollups[seriesKey{metric, entity}]
+	var out []sample
+	for _, p := range long {
+		// Skip buckets that overlap the raw tier so no period is counted twice.
+		if len(raw) > 0 && p.t.Add(RollupInterval).After(raw[0].t) {
+			break
+		}
+		if inRange(p.t, from, to) {
+			out = append(out, p)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, p := range raw {
+		if inRange(p.t, from, to) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func inRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
+
+// Retention returns how far back range queries can reach (the long-term tier).
+func (h *MetricsHistory) Retention() time.Duration {
+	return max(h.maxAge, RollupRetention)
+}
+
+// RawRetention returns the maximum age of full-resolution samples.
+func (h *MetricsHistory) RawRetention() time.Duration {
+	return h.maxAge
+}
+
+// persistedHistory is the on-disk form of the downsampled tier.
+type persistedHistory struct {
+	IntervalSeconds int64             `json:"interval_seconds"`
+	Series          []persistedSeries `json:"series"`
+}
+
+type persistedSeries struct {
+	Metric  string             `json:"metric"`
+	Entity  string             `json:"entity,omitempty"`
+	Samples []dto.MetricSample `json:"samples"`
+}
+
+// Save writes the downsampled tier to path. Raw samples are not persisted.
+func (h *MetricsHistory) Save(path string) error {
+	h.mu.RLock()
+	data := persistedHistory{IntervalSeconds: int64(RollupInterval.Seconds())}
+	for k, s := range h.rollups {
+		ps := persistedSeries{Metric: k.metric, Entity: k.entity, Samples: make([]dto.MetricSample, len(s))}
+		for i, p := range s {
+			ps.Samples[i] = dto.MetricSample{TimeUnix: p.t.Unix(), Value: p.v}
+		}
+		data.Series = append(data.Series, ps)
+	}
+	h.mu.RUnlock()
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil { //nolint:gosec // G306: Plugin config file
+		return fmt.Errorf("failed to write metrics history: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace metrics history: %w", err)
+	}
+	return nil
+}
+
+// Load restores the downsampled tier from path, dropping buckets older than
+// RollupRetention relative to now. A missing file is not an error.
+func (h *MetricsHistory) Load(path string, now time.Time) error {
+	raw, err := os.ReadFile(path) //nolint:gosec // G304: path is built from the plugin config dir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read metrics history: %w", err)
+	}
+	var data persistedHistory
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("failed to parse metrics history: %w", err)
+	}
+	if data.IntervalSeconds != int64(RollupInterval.Seconds()) {
+		return fmt.Errorf("metrics history interval %ds does not match %s", data.IntervalSeconds, RollupInterval)
+	}
+
+	cutoff := now.Add(-RollupRetention)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ps := range data.Series {
+		var s []sample
+		for _, p := range ps.Samples {
+			if t := time.Unix(p.TimeUnix, 0); !t.Before(cutoff) && !t.After(now) {
+				s = append(s, sample{t, p.Value})
+			}
+		}
+		if len(s) > 0 {
+			h.rollups[seriesKey{ps.Metric, ps.Entity}] = s
+		}
+	}
+	return nil
+}
+
 // SeriesSnapshot returns a copy of a series (global if entity=="") for the query API.
 func (h *MetricsHistory) SeriesSnapshot(metric, entity string) []sample {
 	h.mu.RLock()
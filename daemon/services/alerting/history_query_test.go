@@ -1,8 +1,11 @@
 package alerting
 
 import (
+	"math"
 	"testing"
 	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 )
 
 func TestQueryHistory(t *testing.T) {
@@ -29,3 +32,188 @@ func TestQueryHistory(t *testing.T) {
 		t.Error("empty series should have count 0")
 	}
 }
+
+func TestParseTimeRange(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                 string
+		start, end, lookback string
+		wantFrom, wantTo     time.Time
+		wantErr              bool
+	}{
+		{name: "unbounded"},
+		{
+			name:     "rfc3339 range",
+			start:    "2026-10-15T22:00:00Z",
+			end:      "2026-10-16T06:00:00+02:00",
+			wantFrom: time.Date(2026, 10, 15, 22, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 10, 16, 4, 0, 0, 0, time.UTC),
+		},
+		{name: "lookback", lookback: "30m", wantFrom: now.Add(-30 * time.Minute)},
+		{
+			name:     "start overrides lookback",
+			start:    "2026-10-16T11:00:00Z",
+			lookback: "bogus",
+			wantFrom: time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC),
+		},
+		{name: "equal start and end", start: "2026-10-16T11:00:00Z", end: "2026-10-16T11:00:00Z",
+			wantFrom: time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC), wantTo: time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC)},
+		{name: "zero lookback", lookback: "0s", wantErr: true},
+		{name: "negative lookback", lookback: "-1h", wantErr: true},
+		{name: "unparsable lookback", lookback: "yesterday", wantErr: true},
+		{name: "invalid start", start: "2026-10-16", wantErr: true},
+		{name: "invalid end", end: "06:00", wantErr: true},
+		{name: "start after end", start: "2026-10-16T06:00:00Z", end: "2026-10-15T22:00:00Z", wantErr: true},
+		{name: "lookback after end", lookback: "1h", end: "2026-10-15T22:00:00Z", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := ParseTimeRange(tt.start, tt.end, tt.lookback, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("range = [%v, %v], want [%v, %v]", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+// recentEngine returns an engine whose cpu_usage series holds values 0..9, one
+// per second, ending a minute ago (well inside the raw tier).
+func recentEngine(t *testing.T) (*Engine, time.Time) {
+	t.Helper()
+	e := NewEngine(NewStore(t.TempDir()), &mockDataProvider{})
+	base := time.Now().Add(-time.Minute).Truncate(time.Second)
+	for i := 0; i < 10; i++ {
+		e.history.Record("cpu_usage", "", float64(i), ts(base, i))
+		e.history.Record("idle", "", 0, ts(base, i))
+	}
+	return e, base
+}
+
+func TestQueryHistoryRange(t *testing.T) {
+	e, base := recentEngine(t)
+	tests := []struct {
+		name          string
+		from, to      time.Time
+		wantCount     int
+		wantMin       float64
+		wantMax       float64
+		wantAvg       float64
+		wantOlderNote bool
+	}{
+		{name: "unbounded", wantCount: 10, wantMin: 0, wantMax: 9, wantAvg: 4.5},
+		{name: "inclusive bounds", from: ts(base, 2), to: ts(base, 5), wantCount: 4, wantMin: 2, wantMax: 5, wantAvg: 3.5},
+		{name: "single instant", from: ts(base, 7), to: ts(base, 7), wantCount: 1, wantMin: 7, wantMax: 7, wantAvg: 7},
+		{name: "empty range", from: ts(base, 20), to: ts(base, 30)},
+		{name: "beyond raw tier", from: base.Add(-3 * time.Hour), wantCount: 10, wantMin: 0, wantMax: 9, wantAvg: 4.5, wantOlderNote: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := e.QueryHistoryRange("cpu_usage", "", tt.from, tt.to)
+			if r.Count != tt.wantCount || len(r.Samples) != tt.wantCount {
+				t.Fatalf("count = %d (%d samples), want %d", r.Count, len(r.Samples), tt.wantCount)
+			}
+			if r.Min != tt.wantMin || r.Max != tt.wantMax || r.Avg != tt.wantAvg {
+				t.Errorf("min/max/avg = %v/%v/%v, want %v/%v/%v", r.Min, r.Max, r.Avg, tt.wantMin, tt.wantMax, tt.wantAvg)
+			}
+			if r.RetentionSeconds != int64(RollupRetention.Seconds()) {
+				t.Errorf("retention = %d", r.RetentionSeconds)
+			}
+			if (r.Note != "") != tt.wantOlderNote {
+				t.Errorf("note = %q", r.Note)
+			}
+		})
+	}
+}
+
+func TestComparePeriods(t *testing.T) {
+	e, base := recentEngine(t)
+	e.history.Record("idle", "", 5, ts(base, 10))
+
+	tests := []struct {
+		name                       string
+		metric                     string
+		aFrom, aTo, bFrom, bTo     time.Time
+		wantAvgDelta, wantMaxDelta float64
+		wantAvgDeltaPct            float64
+		wantNote                   bool
+	}{
+		{
+			name: "increase", metric: "cpu_usage",
+			aFrom: ts(base, 0), aTo: ts(base, 4), bFrom: ts(base, 5), bTo: ts(base, 9),
+			wantAvgDelta: 5, wantMaxDelta: 5, wantAvgDeltaPct: 250,
+		},
+		{
+			name: "decrease", metric: "cpu_usage",
+			aFrom: ts(base, 5), aTo: ts(base, 9), bFrom: ts(base, 0), bTo: ts(base, 4),
+			wantAvgDelta: -5, wantMaxDelta: -5, wantAvgDeltaPct: -100 * 5.0 / 7.0,
+		},
+		{
+			name: "zero baseline has no percentage", metric: "idle",
+			aFrom: ts(base, 0), aTo: ts(base, 9), bFrom: ts(base, 10), bTo: ts(base, 10),
+			wantAvgDelta: 5, wantMaxDelta: 5, wantAvgDeltaPct: 0,
+		},
+		{
+			name: "empty period", metric: "cpu_usage",
+			aFrom: ts(base, 0), aTo: ts(base, 4), bFrom: ts(base, 30), bTo: ts(base, 40),
+			wantNote: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := e.ComparePeriods(tt.metric, "", tt.aFrom, tt.aTo, tt.bFrom, tt.bTo)
+			if r.AvgDelta != tt.wantAvgDelta || r.MaxDelta != tt.wantMaxDelta {
+				t.Errorf("avg/max delta = %v/%v, want %v/%v", r.AvgDelta, r.MaxDelta, tt.wantAvgDelta, tt.wantMaxDelta)
+			}
+			if math.Abs(r.AvgDeltaPct-tt.wantAvgDeltaPct) > 1e-9 {
+				t.Errorf("avg delta pct = %v, want %v", r.AvgDeltaPct, tt.wantAvgDeltaPct)
+			}
+			if (r.Note != "") != tt.wantNote {
+				t.Errorf("note = %q", r.Note)
+			}
+			if r.PeriodA.StartUnix != tt.aFrom.Unix() || r.PeriodB.EndUnix != tt.bTo.Unix() {
+				t.Errorf("period bounds = %+v / %+v", r.PeriodA, r.PeriodB)
+			}
+		})
+	}
+}
+
+func TestPeriodStats(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	series := []sample{{ts(base, 0), -2}, {ts(base, 1), 4}, {ts(base, 2), 1}}
+	tests := []struct {
+		name     string
+		s        []sample
+		from, to time.Time
+		want     dto.MetricPeriodStats
+	}{
+		{
+			name: "bounded window keeps requested bounds",
+			s:    series, from: ts(base, -10), to: ts(base, 10),
+			want: dto.MetricPeriodStats{StartUnix: base.Unix() - 10, EndUnix: base.Unix() + 10, Count: 3, Min: -2, Max: 4, Avg: 1},
+		},
+		{
+			name: "unbounded window uses sample bounds",
+			s:    series,
+			want: dto.MetricPeriodStats{StartUnix: base.Unix(), EndUnix: base.Unix() + 2, Count: 3, Min: -2, Max: 4, Avg: 1},
+		},
+		{
+			name: "empty period",
+			from: ts(base, 0), to: ts(base, 60),
+			want: dto.MetricPeriodStats{StartUnix: base.Unix(), EndUnix: base.Unix() + 60},
+		},
+		{name: "empty and unbounded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := periodStats(tt.s, tt.from, tt.to); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
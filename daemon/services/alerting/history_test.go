@@ -64,3 +64,93 @@ func TestMetricsHistory_PrunesVanishedEntities(t *testing.T) {
 		t.Error("sda should remain")
 	}
 }
+
+func TestMetricsHistory_SeriesRange(t *testing.T) {
+	// One sample per minute for 30 minutes with a 10 minute raw tier: minutes
+	// 19-29 stay raw, older minutes survive only as 5 minute bucket averages.
+	base := time.Unix(1_699_999_800, 0) // aligned to RollupInterval
+	h := NewMetricsHistory(1000, 10*time.Minute)
+	for i := 0; i < 30; i++ {
+		h.Record("cpu_usage", "", float64(i), base.Add(time.Duration(i)*time.Minute))
+	}
+	at := func(min int) time.Time { return base.Add(time.Duration(min) * time.Minute) }
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []float64
+	}{
+		{
+			name: "unbounded merges rollups before raw",
+			// Buckets 0, 5 and 10 end before the oldest raw sample; bucket 15 overlaps it.
+			want: []float64{2, 7, 12, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29},
+		},
+		{name: "bounds are inclusive on rollups", from: at(5), to: at(10), want: []float64{7, 12}},
+		{name: "bounds are inclusive on raw", from: at(19), to: at(21), want: []float64{19, 20, 21}},
+		{name: "open end", from: at(27), want: []float64{27, 28, 29}},
+		{name: "open start", to: at(5), want: []float64{2, 7}},
+		{name: "range after data", from: at(31), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := h.SeriesRange("cpu_usage", "", tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("len = %d, want %d (%v)", len(got), len(tt.want), got)
+			}
+			for i, p := range got {
+				if p.v != tt.want[i] {
+					t.Errorf("sample %d = %v, want %v", i, p.v, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if got := h.SeriesRange("missing", "", time.Time{}, time.Time{}); len(got) != 0 {
+		t.Errorf("missing series = %v, want empty", got)
+	}
+}
+
+func TestMetricsHistory_SaveLoad(t *testing.T) {
+	base := time.Unix(1_699_999_800, 0)
+	h := NewMetricsHistory(1000, 10*time.Minute)
+	for i := 0; i < 30; i++ {
+		h.Record("disk_temp", "sda", float64(i), base.Add(time.Duration(i)*time.Minute))
+	}
+	path := t.TempDir() + "/" + MetricsHistoryFile
+	if err := h.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	restored := NewMetricsHistory(1000, 10*time.Minute)
+	if err := restored.Load(path, base.Add(30*time.Minute)); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	// Only closed buckets are persisted; with no raw samples all of them are served.
+	got := restored.SeriesRange("disk_temp", "sda", time.Time{}, time.Time{})
+	if len(got) != 5 || got[0].v != 2 || got[4].v != 22 {
+		t.Errorf("restored = %v, want 5 buckets 2..22", got)
+	}
+
+	expired := NewMetricsHistory(1000, 10*time.Minute)
+	if err := expired.Load(path, base.Add(RollupRetention+time.Hour)); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := expired.SeriesRange("disk_temp", "sda", time.Time{}, time.Time{}); len(got) != 0 {
+		t.Errorf("expired buckets restored: %v", got)
+	}
+
+	if err := NewMetricsHistory(1, time.Hour).Load(t.TempDir()+"/missing.json", base); err != nil {
+		t.Errorf("missing file should not error: %v", err)
+	}
+}
+
+func TestMetricsHistory_PruneDropsRollups(t *testing.T) {
+	base := time.Unix(1_699_999_800, 0)
+	h := NewMetricsHistory(240, time.Hour)
+	h.Record("disk_temp", "sdb", 40, base)
+	h.Record("disk_temp", "sdb", 41, base.Add(RollupInterval))
+	h.pruneEntities("disk_temp", map[string]bool{})
+	if got := h.SeriesRange("disk_temp", "sdb", time.Time{}, time.Time{}); len(got) != 0 {
+		t.Errorf("pruned entity still has samples: %v", got)
+	}
+}
@@ -16,6 +16,16 @@ func BuiltinRules() []dto.AlertRule {
 			Channels:        []string{"unraid"},
 			CooldownMinutes: 60,
 		},
+		{
+			ID:              "remote-mount-stale",
+			Name:            "Remote NFS/SMB mount stale",
+			Expression:      "StaleRemoteMounts > 0",
+			Severity:        "warning",
+			Enabled:         true,
+			Channels:        []string{"unraid"},
+			DurationSeconds: 120,
+			CooldownMinutes: 60,
+		},
 	}
 }
 
@@ -26,7 +36,11 @@ func AlertRuleTemplates() []dto.AlertRule {
 		{ID: "tmpl-array-fill", Name: "Array filling soon (< 72h)", Expression: "ArrayFillETAHours > 0 && ArrayFillETAHours < 72", Severity: "warning", Enabled: false, CooldownMinutes: 360},
 		{ID: "tmpl-disk-temp-climb", Name: "Disk temperature climbing", Expression: "MaxDiskTempSlopePerMin > 1", Severity: "warning", Enabled: false, CooldownMinutes: 30},
 		{ID: "tmpl-container-flapping", Name: "Container flapping", Expression: "MaxContainerRestartsPerHour >= 5", Severity: "warning", Enabled: false, CooldownMinutes: 30},
+		{ID: "tmpl-container-probe-failing", Name: "Container service probe failing", Expression: "UnhealthyContainerProbes > 0", Severity: "warning", Enabled: false, DurationSeconds: 60, CooldownMinutes: 30},
 		{ID: "tmpl-smart-reallocated", Name: "Disk reallocated sectors detected", Expression: "MaxReallocatedSectors > 0", Severity: "critical", Enabled: false, CooldownMinutes: 1440},
+		{ID: "tmpl-wan-down", Name: "Internet connection down", Expression: "!WANOnline", Severity: "critical", Enabled: false, DurationSeconds: 120, CooldownMinutes: 60},
+		{ID: "tmpl-wan-packet-loss", Name: "WAN packet loss high", Expression: "WANOnline && WANPacketLossPct >= 20", Severity: "warning", Enabled: false, DurationSeconds: 300, CooldownMinutes: 60},
+		{ID: "tmpl-wan-ip-changed", Name: "Public IP address changed", Expression: "WANIPChanged", Severity: "info", Enabled: false, CooldownMinutes: 30},
 		{ID: "tmpl-disk-errors-rising", Name: "Disk errors increasing", Expression: "DiskErrorsIncreasing", Severity: "critical", Enabled: false, CooldownMinutes: 720},
 	}
 }
@@ -0,0 +1,384 @@
+package alerting
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+const (
+	// ThermalWindow is the period covered by the thermal summary statistics.
+	ThermalWindow = 24 * time.Hour
+
+	// ThermalSustain is how long a temperature must stay at or above a
+	// threshold before a thermal event is raised, so momentary spikes are
+	// ignored.
+	ThermalSustain = 5 * time.Minute
+
+	// maxThermalEvents is the number of thermal events kept in memory.
+	maxThermalEvents = 50
+
+	// thermalSettingsTTL is how long the disk temperature thresholds read
+	// from the Unraid configuration are reused before being read again.
+	thermalSettingsTTL = 5 * time.Minute
+)
+
+// Default thresholds for sensors Unraid has no setting for.
+const (
+	cpuTempWarning          = 80
+	cpuTempCritical         = 90
+	motherboardTempWarning  = 55
+	motherboardTempCritical = 65
+	gpuTempWarning          = 85
+	gpuTempCritical         = 95
+)
+
+// sysBlockPath is where the kernel exposes block device queue attributes.
+// Variable for testing.
+var sysBlockPath = "/sys/block"
+
+// thermalSensor is one temperature reading with its thresholds.
+type thermalSensor struct {
+	sensor   string
+	entity   string
+	name     string
+	metric   string
+	temp     float64
+	warning  float64
+	critical float64
+}
+
+func (s thermalSensor) key() string { return s.sensor + "/" + s.entity }
+
+// thermalState tracks how long one sensor has been above its thresholds.
+type thermalState struct {
+	warnSince time.Time
+	critSince time.Time
+	level     string // sustained level at the last check
+	reported  string // last level an event was raised for
+	since     time.Time
+}
+
+// thermalMonitor raises thermal events when a sensor stays above its warning
+// or critical threshold for ThermalSustain, and again once it recovers.
+type thermalMonitor struct {
+	mu     sync.RWMutex
+	states map[string]*thermalState
+	events []dto.ThermalEvent
+
+	settings   *dto.DiskSettingsExtended
+	settingsAt time.Time
+
+	// settingsFn and notifyFn are replaced in tests.
+	settingsFn func() (*dto.DiskSettingsExtended, error)
+	notifyFn   func(title, subject, description, importance, link string) error
+}
+
+func newThermalMonitor() *thermalMonitor {
+	return &thermalMonitor{
+		states:     map[string]*thermalState{},
+		settingsFn: collectors.NewSettingsCollector().GetDiskSettingsExtended,
+		notifyFn:   controllers.CreateNotification,
+	}
+}
+
+// diskSettings returns the disk temperature thresholds, re-reading them from
+// the Unraid configuration at most every thermalSettingsTTL.
+func (m *thermalMonitor) diskSettings(now time.Time) *dto.DiskSettingsExtended {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.settings != nil && now.Sub(m.settingsAt) < thermalSettingsTTL {
+		return m.settings
+	}
+	settings, err := m.settingsFn()
+	if err != nil || settings == nil {
+		logger.Debug("Alerting: Could not read disk temperature thresholds: %v", err)
+		settings = &dto.DiskSettingsExtended{HDDTempWarning: 45, HDDTempCritical: 55, SSDTempWarning: 60, SSDTempCritical: 70,
+			WarningUtilization: 70, CriticalUtilization: 90}
+	}
+	m.settings, m.settingsAt = settings, now
+	return settings
+}
+
+// check updates the sustained state of every sensor and returns the events
+// raised by this reading.
+func (m *thermalMonitor) check(sensors []thermalSensor, now time.Time) []dto.ThermalEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var events []dto.ThermalEvent
+	seen := make(map[string]bool, len(sensors))
+	for _, s := range sensors {
+		key := s.key()
+		seen[key] = true
+		// A zero reading means no sensor or a spun-down disk; keep the
+		// current state until a real reading arrives.
+		if s.temp <= 0 {
+			continue
+		}
+		st := m.states[key]
+		if st == nil {
+			st = &thermalState{level: dto.ThermalLevelOK, reported: dto.ThermalLevelOK}
+			m.states[key] = st
+		}
+
+		st.warnSince = trackSince(st.warnSince, s.temp >= s.warning, now)
+		st.critSince = trackSince(st.critSince, s.temp >= s.critical, now)
+
+		st.level = dto.ThermalLevelOK
+		switch {
+		case !st.critSince.IsZero() && now.Sub(st.critSince) >= ThermalSustain:
+			st.level = dto.ThermalLevelCritical
+		case !st.warnSince.IsZero() && now.Sub(st.warnSince) >= ThermalSustain:
+			st.level = dto.ThermalLevelWarning
+		}
+
+		event := dto.ThermalEvent{
+			Sensor:       s.sensor,
+			Entity:       s.entity,
+			Name:         s.name,
+			TemperatureC: s.temp,
+			Timestamp:    now,
+		}
+		switch {
+		case levelRank(st.level) > levelRank(st.reported):
+			event.Level = st.level
+			event.ThresholdC, event.Since = s.warning, st.warnSince
+			if st.level == dto.ThermalLevelCritical {
+				event.ThresholdC, event.Since = s.critical, st.critSince
+			}
+			if st.reported == dto.ThermalLevelOK {
+				st.since = st.warnSince
+			}
+		case st.reported != dto.ThermalLevelOK && st.warnSince.IsZero():
+			// Only resolve once the temperature is back below the warning
+			// threshold; dropping from critical to warning is not a recovery.
+			event.Level = dto.ThermalLevelResolved
+			event.ThresholdC, event.Since = s.warning, st.since
+		default:
+			continue
+		}
+		st.reported = event.Level
+		if event.Level == dto.ThermalLevelResolved {
+			st.reported = dto.ThermalLevelOK
+		}
+		events = append(events, event)
+	}
+
+	for key := range m.states {
+		if !seen[key] {
+			delete(m.states, key)
+		}
+	}
+
+	m.events = append(m.events, events...)
+	if len(m.events) > maxThermalEvents {
+		m.events = m.events[len(m.events)-maxThermalEvents:]
+	}
+	return events
+}
+
+// level returns the sustained level of the sensor with key.
+func (m *thermalMonitor) level(key string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if st := m.states[key]; st != nil {
+		return st.level
+	}
+	return dto.ThermalLevelOK
+}
+
+// recentEvents returns a copy of the kept thermal events, oldest first.
+func (m *thermalMonitor) recentEvents() []dto.ThermalEvent {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]dto.ThermalEvent, len(m.events))
+	copy(out, m.events)
+	return out
+}
+
+// notify creates an Unraid notification for a thermal event.
+func (m *thermalMonitor) notify(event dto.ThermalEvent) {
+	importance := "warning"
+	subject := i18n.T("%s temperature %s: %.0f°C", event.Name, thermalLevelLabel(event.Level), event.TemperatureC)
+	description := i18n.T("%s has been at or above its %s threshold of %.0f°C since %s.",
+		event.Name, thermalLevelLabel(event.Level), event.ThresholdC, event.Since.Format(time.Kitchen))
+	switch event.Level {
+	case dto.ThermalLevelCritical:
+		importance = "alert"
+	case dto.ThermalLevelResolved:
+		importance = "info"
+		subject = i18n.T("%s temperature back to normal: %.0f°C", event.Name, event.TemperatureC)
+		description = i18n.T("%s is back below its warning threshold of %.0f°C.", event.Name, event.ThresholdC)
+	}
+	if err := m.notifyFn("thermal_event", subject, description, importance, ""); err != nil {
+		logger.Warning("Alerting: Failed to create thermal notification: %v", err)
+	}
+}
+
+// thermalLevelLabel returns the translated name of a warning or critical
+// level for notification text.
+func thermalLevelLabel(level string) string {
+	if level == dto.ThermalLevelCritical {
+		return i18n.T("critical")
+	}
+	return i18n.T("warning")
+}
+
+// trackSince returns when a condition started holding: since if it was
+// already holding, now if it just started, or zero if it no longer holds.
+func trackSince(since time.Time, holds bool, now time.Time) time.Time {
+	switch {
+	case !holds:
+		return time.Time{}
+	case since.IsZero():
+		return now
+	default:
+		return since
+	}
+}
+
+func levelRank(level string) int {
+	switch level {
+	case dto.ThermalLevelCritical:
+		return 2
+	case dto.ThermalLevelWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isSSD reports whether the block device is non-rotational.
+func isSSD(device string) bool {
+	if strings.HasPrefix(device, "nvme") {
+		return true
+	}
+	if device == "" {
+		return false
+	}
+	data, err := os.ReadFile(filepath.Join(sysBlockPath, device, "queue", "rotational"))
+	return err == nil && strings.TrimSpace(string(data)) == "0"
+}
+
+// thermalSensors collects the current CPU, motherboard, disk and GPU
+// temperatures with their thresholds. Disks use their per-disk overrides,
+// falling back to the HDD or SSD thresholds from the Unraid disk settings.
+func (e *Engine) thermalSensors(now time.Time) []thermalSensor {
+	var sensors []thermalSensor
+	if sys := e.provider.GetSystemCache(); sys != nil {
+		sensors = append(sensors,
+			thermalSensor{sensor: dto.ThermalSensorCPU, name: "CPU", metric: "cpu_temp",
+				temp: sys.CPUTemp, warning: cpuTempWarning, critical: cpuTempCritical},
+			thermalSensor{sensor: dto.ThermalSensorMotherboard, name: "Motherboard", metric: "motherboard_temp",
+				temp: sys.MotherboardTemp, warning: motherboardTempWarning, critical: motherboardTempCritical})
+	}
+
+	if disks := e.provider.GetDisksCache(); len(disks) > 0 {
+		settings := e.thermal.diskSettings(now)
+		for _, d := range disks {
+			if d.ID == "" {
+				continue
+			}
+			warning, critical := settings.HDDTempWarning, settings.HDDTempCritical
+			if isSSD(d.Device) {
+				warning, critical = settings.SSDTempWarning, settings.SSDTempCritical
+			}
+			if d.TempWarning != nil {
+				warning = *d.TempWarning
+			}
+			if d.TempCritical != nil {
+				critical = *d.TempCritical
+			}
+			name := d.Name
+			if name == "" {
+				name = d.ID
+			}
+			sensors = append(sensors, thermalSensor{sensor: dto.ThermalSensorDisk, entity: d.ID, name: name,
+				metric: "disk_temp", temp: d.Temperature, warning: float64(warning), critical: float64(critical)})
+		}
+	}
+
+	for _, g := range e.provider.GetGPUCache() {
+		if g == nil || !g.Available {
+			continue
+		}
+		name := g.Name
+		if name == "" {
+			name = fmt.Sprintf("GPU %d", g.Index)
+		}
+		sensors = append(sensors, thermalSensor{sensor: dto.ThermalSensorGPU, entity: strconv.Itoa(g.Index), name: name,
+			metric: "gpu_temp", temp: g.Temperature, warning: gpuTempWarning, critical: gpuTempCritical})
+	}
+	return sensors
+}
+
+// checkThermal raises thermal events for sensors that stayed above their
+// thresholds, notifying Unraid and publishing them on the event bus.
+func (e *Engine) checkThermal(now time.Time) {
+	for _, event := range e.thermal.check(e.thermalSensors(now), now) {
+		logger.Info("Alerting: Thermal %s for %s at %.0f°C", event.Level, event.Name, event.TemperatureC)
+		e.thermal.notify(event)
+		if e.hub != nil {
+			domain.Publish(e.hub, constants.TopicThermalEvent, event)
+		}
+	}
+}
+
+// ThermalSummary returns the current, maximum and average temperature of
+// every sensor over ThermalWindow, with the recent thermal events. Readings
+// older than the raw history are 5-minute averages, so short peaks from
+// earlier in the window are smoothed.
+func (e *Engine) ThermalSummary(now time.Time) dto.ThermalSummary {
+	summary := dto.ThermalSummary{
+		WindowSeconds:  int64(ThermalWindow / time.Second),
+		SustainSeconds: int64(ThermalSustain / time.Second),
+		Sensors:        []dto.ThermalSensorStats{},
+		Events:         e.thermal.recentEvents(),
+		Timestamp:      now,
+	}
+
+	for _, s := range e.thermalSensors(now) {
+		stats := dto.ThermalSensorStats{
+			Sensor:    s.sensor,
+			Entity:    s.entity,
+			Name:      s.name,
+			CurrentC:  math.Max(s.temp, 0),
+			WarningC:  s.warning,
+			CriticalC: s.critical,
+			Level:     e.thermal.level(s.key()),
+		}
+		var sum float64
+		for _, p := range e.history.SeriesRange(s.metric, s.entity, now.Add(-ThermalWindow), now) {
+			if p.v <= 0 {
+				continue
+			}
+			stats.Samples++
+			sum += p.v
+			stats.MaxC = math.Max(stats.MaxC, p.v)
+		}
+		if stats.Samples == 0 && s.temp <= 0 {
+			// No sensor reading at all (e.g. no motherboard sensor).
+			continue
+		}
+		if stats.Samples > 0 {
+			stats.AvgC = math.Round(sum/float64(stats.Samples)*10) / 10
+		}
+		stats.MaxC = math.Max(stats.MaxC, stats.CurrentC)
+		summary.Sensors = append(summary.Sensors, stats)
+	}
+	return summary
+}
@@ -0,0 +1,193 @@
+package alerting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+type sentNotification struct {
+	subject    string
+	importance string
+}
+
+// newThermalTestEngine returns an engine whose thermal monitor uses fixed
+// disk thresholds and records notifications instead of writing them.
+func newThermalTestEngine(t *testing.T, provider *mockDataProvider) (*Engine, *[]sentNotification) {
+	t.Helper()
+	e := NewEngine(NewStore(t.TempDir()), provider)
+	e.thermal.settingsFn = func() (*dto.DiskSettingsExtended, error) {
+		return &dto.DiskSettingsExtended{HDDTempWarning: 45, HDDTempCritical: 55, SSDTempWarning: 60, SSDTempCritical: 70}, nil
+	}
+	var sent []sentNotification
+	e.thermal.notifyFn = func(_, subject, _, importance, _ string) error {
+		sent = append(sent, sentNotification{subject, importance})
+		return nil
+	}
+	return e, &sent
+}
+
+func TestThermalIgnoresMomentarySpike(t *testing.T) {
+	provider := &mockDataProvider{disks: []dto.DiskInfo{{ID: "disk1", Name: "Disk 1", Temperature: 40}}}
+	e, sent := newThermalTestEngine(t, provider)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	provider.disks[0].Temperature = 58
+	e.checkThermal(start)
+	e.checkThermal(start.Add(2 * time.Minute))
+	provider.disks[0].Temperature = 40
+	e.checkThermal(start.Add(4 * time.Minute))
+	provider.disks[0].Temperature = 50
+	e.checkThermal(start.Add(6 * time.Minute))
+	e.checkThermal(start.Add(10 * time.Minute))
+
+	if len(*sent) != 0 {
+		t.Fatalf("spikes shorter than %s raised notifications: %+v", ThermalSustain, *sent)
+	}
+	if events := e.thermal.recentEvents(); len(events) != 0 {
+		t.Errorf("events = %+v, want none", events)
+	}
+}
+
+func TestThermalSustainedBreachEscalatesAndResolves(t *testing.T) {
+	provider := &mockDataProvider{disks: []dto.DiskInfo{{ID: "disk3", Name: "Disk 3", Temperature: 48}}}
+	e, sent := newThermalTestEngine(t, provider)
+	hub := domain.NewEventBus(8)
+	e.SetEventBus(hub)
+	ch := hub.SubTopics(constants.TopicThermalEvent)
+	defer hub.Unsub(ch, constants.TopicThermalEvent.Name)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	e.checkThermal(start)
+	e.checkThermal(start.Add(ThermalSustain))
+	if len(*sent) != 1 || (*sent)[0].importance != "warning" {
+		t.Fatalf("notifications after sustained warning = %+v", *sent)
+	}
+	select {
+	case msg := <-ch:
+		event, ok := msg.(dto.ThermalEvent)
+		if !ok || event.Level != dto.ThermalLevelWarning || event.ThresholdC != 45 || !event.Since.Equal(start) {
+			t.Errorf("published %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no thermal event published")
+	}
+
+	// Staying at warning does not repeat the event.
+	e.checkThermal(start.Add(ThermalSustain + time.Minute))
+	if len(*sent) != 1 {
+		t.Fatalf("warning repeated: %+v", *sent)
+	}
+
+	provider.disks[0].Temperature = 57
+	at := start.Add(10 * time.Minute)
+	e.checkThermal(at)
+	e.checkThermal(at.Add(ThermalSustain))
+	if len(*sent) != 2 || (*sent)[1].importance != "alert" {
+		t.Fatalf("notifications after sustained critical = %+v", *sent)
+	}
+
+	// Falling back to the warning band is not a recovery.
+	provider.disks[0].Temperature = 50
+	e.checkThermal(at.Add(ThermalSustain + time.Minute))
+	if len(*sent) != 2 {
+		t.Fatalf("unexpected notification in warning band: %+v", *sent)
+	}
+
+	provider.disks[0].Temperature = 41
+	e.checkThermal(at.Add(ThermalSustain + 2*time.Minute))
+	if len(*sent) != 3 || (*sent)[2].importance != "info" {
+		t.Fatalf("notifications after recovery = %+v", *sent)
+	}
+
+	events := e.thermal.recentEvents()
+	levels := []string{dto.ThermalLevelWarning, dto.ThermalLevelCritical, dto.ThermalLevelResolved}
+	if len(events) != len(levels) {
+		t.Fatalf("events = %+v", events)
+	}
+	for i, level := range levels {
+		if events[i].Level != level {
+			t.Errorf("events[%d].Level = %q, want %q", i, events[i].Level, level)
+		}
+	}
+	if !events[2].Since.Equal(start) {
+		t.Errorf("resolved Since = %v, want %v", events[2].Since, start)
+	}
+}
+
+func TestThermalDiskThresholds(t *testing.T) {
+	orig := sysBlockPath
+	sysBlockPath = t.TempDir()
+	defer func() { sysBlockPath = orig }()
+	if err := os.MkdirAll(filepath.Join(sysBlockPath, "sdb", "queue"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sysBlockPath, "sdb", "queue", "rotational"), []byte("0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	override := 50
+	provider := &mockDataProvider{disks: []dto.DiskInfo{
+		{ID: "disk1", Device: "sda", Temperature: 40},
+		{ID: "cache", Device: "sdb", Temperature: 40},
+		{ID: "cache2", Device: "nvme0n1", Temperature: 40},
+		{ID: "disk2", Device: "sdc", Temperature: 40, TempWarning: &override},
+	}}
+	e, _ := newThermalTestEngine(t, provider)
+
+	want := map[string]float64{"disk1": 45, "cache": 60, "cache2": 60, "disk2": 50}
+	for _, s := range e.thermalSensors(time.Now()) {
+		if s.warning != want[s.entity] {
+			t.Errorf("%s warning = %v, want %v", s.entity, s.warning, want[s.entity])
+		}
+	}
+}
+
+func TestThermalSummary(t *testing.T) {
+	provider := &mockDataProvider{
+		system: &dto.SystemInfo{CPUTemp: 50},
+		disks:  []dto.DiskInfo{{ID: "disk1", Name: "Disk 1", Temperature: 0}},
+		gpus:   []*dto.GPUMetrics{{Index: 0, Name: "Arc A380", Available: true, Temperature: 60}},
+	}
+	e, _ := newThermalTestEngine(t, provider)
+	now := time.Now()
+
+	for i, temp := range []float64{40, 44, 0, 36} {
+		at := now.Add(time.Duration(i-4) * time.Minute)
+		provider.system.CPUTemp = temp + 10
+		provider.disks[0].Temperature = temp
+		e.sampleHistory(at)
+	}
+	provider.system.CPUTemp = 50
+	provider.disks[0].Temperature = 0
+
+	summary := e.ThermalSummary(now)
+	if summary.WindowSeconds != 86400 || summary.SustainSeconds != 300 {
+		t.Errorf("window = %d, sustain = %d", summary.WindowSeconds, summary.SustainSeconds)
+	}
+	byName := map[string]dto.ThermalSensorStats{}
+	for _, s := range summary.Sensors {
+		byName[s.Name] = s
+	}
+	if _, ok := byName["Motherboard"]; ok {
+		t.Error("motherboard without a reading should be omitted")
+	}
+
+	disk := byName["Disk 1"]
+	if disk.Samples != 3 || disk.MaxC != 44 || disk.AvgC != 40 || disk.CurrentC != 0 || disk.WarningC != 45 {
+		t.Errorf("disk stats = %+v", disk)
+	}
+	cpu := byName["CPU"]
+	if cpu.MaxC != 54 || cpu.Samples != 4 || cpu.Level != dto.ThermalLevelOK {
+		t.Errorf("cpu stats = %+v", cpu)
+	}
+	gpu := byName["Arc A380"]
+	if gpu.Entity != "0" || gpu.Samples != 4 || gpu.AvgC != 60 || gpu.CriticalC != gpuTempCritical {
+		t.Errorf("gpu stats = %+v", gpu)
+	}
+}
@@ -0,0 +1,246 @@
+package alerting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+const (
+	// UtilizationHysteresis is how many percentage points usage must fall
+	// below a threshold before its level clears, so a disk hovering around a
+	// threshold does not raise a notification on every check.
+	UtilizationHysteresis = 2
+
+	// maxUtilizationEvents is the number of utilization events kept in memory.
+	maxUtilizationEvents = 50
+)
+
+// utilizationTarget is the usage of one array data disk or pool.
+type utilizationTarget struct {
+	kind   string
+	entity string
+	name   string
+	used   float64
+}
+
+func (t utilizationTarget) key() string { return t.kind + "/" + t.entity }
+
+// utilizationState is the current level of one filesystem.
+type utilizationState struct {
+	level string
+	since time.Time
+}
+
+// utilizationMonitor raises an event each time an array disk or pool moves
+// between the ok, warning and critical utilization levels of the Unraid disk
+// settings.
+type utilizationMonitor struct {
+	mu     sync.RWMutex
+	states map[string]*utilizationState
+	events []dto.UtilizationEvent
+
+	// notifyFn is replaced in tests.
+	notifyFn func(title, subject, description, importance, link string) error
+}
+
+func newUtilizationMonitor() *utilizationMonitor {
+	return &utilizationMonitor{
+		states:   map[string]*utilizationState{},
+		notifyFn: controllers.CreateNotification,
+	}
+}
+
+// utilizationLevel returns the level for used percent given the current
+// level. A level is entered when usage reaches its threshold and left only
+// once usage falls UtilizationHysteresis points below it. A threshold of
+// zero disables its level, as in the Unraid disk settings.
+func utilizationLevel(current string, used, warning, critical float64) string {
+	reached := func(threshold float64, level string) bool {
+		if threshold <= 0 {
+			return false
+		}
+		if levelRank(current) >= levelRank(level) {
+			return used > threshold-UtilizationHysteresis
+		}
+		return used >= threshold
+	}
+	switch {
+	case reached(critical, dto.ThermalLevelCritical):
+		return dto.ThermalLevelCritical
+	case reached(warning, dto.ThermalLevelWarning):
+		return dto.ThermalLevelWarning
+	default:
+		return dto.ThermalLevelOK
+	}
+}
+
+// check updates the level of every filesystem and returns the events raised
+// by this reading, one per level change. The first reading of a filesystem
+// only sets its level.
+func (m *utilizationMonitor) check(targets []utilizationTarget, warning, critical float64, now time.Time) []dto.UtilizationEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var events []dto.UtilizationEvent
+	seen := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		key := t.key()
+		seen[key] = true
+		st := m.states[key]
+		if st == nil {
+			// Seed from the first reading: restarting the agent or the array
+			// must not repeat notifications Unraid has already shown.
+			m.states[key] = &utilizationState{level: utilizationLevel(dto.ThermalLevelOK, t.used, warning, critical), since: now}
+			continue
+		}
+
+		level := utilizationLevel(st.level, t.used, warning, critical)
+		if level == st.level {
+			continue
+		}
+		event := dto.UtilizationEvent{
+			Kind:             t.kind,
+			Entity:           t.entity,
+			Name:             t.name,
+			Level:            level,
+			PreviousLevel:    st.level,
+			UsedPercent:      t.used,
+			ThresholdPercent: warning,
+			Timestamp:        now,
+		}
+		switch level {
+		case dto.ThermalLevelCritical:
+			event.ThresholdPercent = critical
+		case dto.ThermalLevelOK:
+			event.Level = dto.ThermalLevelResolved
+		}
+		st.level, st.since = level, now
+		events = append(events, event)
+	}
+
+	// Filesystems that disappeared (array stopped, pool removed) are seeded
+	// again when they return.
+	for key := range m.states {
+		if !seen[key] {
+			delete(m.states, key)
+		}
+	}
+
+	m.events = append(m.events, events...)
+	if len(m.events) > maxUtilizationEvents {
+		m.events = m.events[len(m.events)-maxUtilizationEvents:]
+	}
+	return events
+}
+
+// status returns the level of the filesystem with key and when it was
+// entered, or ok and the zero time.
+func (m *utilizationMonitor) status(key string) (string, time.Time) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if st := m.states[key]; st != nil {
+		return st.level, st.since
+	}
+	return dto.ThermalLevelOK, time.Time{}
+}
+
+// recentEvents returns a copy of the kept utilization events, oldest first.
+func (m *utilizationMonitor) recentEvents() []dto.UtilizationEvent {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]dto.UtilizationEvent, len(m.events))
+	copy(out, m.events)
+	return out
+}
+
+// notify creates an Unraid notification for a utilization event.
+func (m *utilizationMonitor) notify(event dto.UtilizationEvent) {
+	importance := "warning"
+	subject := i18n.T("%s usage %s: %.0f%%", event.Name, thermalLevelLabel(event.Level), event.UsedPercent)
+	description := i18n.T("%s is %.0f%% full, at or above its %s threshold of %.0f%%.",
+		event.Name, event.UsedPercent, thermalLevelLabel(event.Level), event.ThresholdPercent)
+	switch event.Level {
+	case dto.ThermalLevelCritical:
+		importance = "alert"
+	case dto.ThermalLevelResolved:
+		importance = "info"
+		subject = i18n.T("%s usage back to normal: %.0f%%", event.Name, event.UsedPercent)
+		description = i18n.T("%s is %.0f%% full, back below its warning threshold of %.0f%%.",
+			event.Name, event.UsedPercent, event.ThresholdPercent)
+	}
+	if err := m.notifyFn("utilization_event", subject, description, importance, ""); err != nil {
+		logger.Warning("Alerting: Failed to create utilization notification: %v", err)
+	}
+}
+
+// utilizationTargets collects the usage of the mounted array data disks and
+// pools.
+func (e *Engine) utilizationTargets() []utilizationTarget {
+	var targets []utilizationTarget
+	for _, d := range e.provider.GetDisksCache() {
+		if d.Role != "data" || d.ID == "" || d.Size == 0 {
+			continue
+		}
+		used := d.UsagePercent
+		if used == 0 {
+			used = float64(d.Used) / float64(d.Size) * 100
+		}
+		name := d.Name
+		if name == "" {
+			name = d.ID
+		}
+		targets = append(targets, utilizationTarget{kind: dto.UtilizationKindDisk, entity: d.ID, name: name, used: used})
+	}
+	for _, p := range e.provider.GetPoolsCache() {
+		if p.Name == "" || p.TotalBytes == 0 {
+			continue
+		}
+		targets = append(targets, utilizationTarget{kind: dto.UtilizationKindPool, entity: p.Name, name: p.Name, used: p.UsagePercent})
+	}
+	return targets
+}
+
+// checkUtilization raises utilization events for filesystems that changed
+// level, notifying Unraid and publishing them on the event bus.
+func (e *Engine) checkUtilization(now time.Time) {
+	settings := e.thermal.diskSettings(now)
+	warning, critical := float64(settings.WarningUtilization), float64(settings.CriticalUtilization)
+	for _, event := range e.utilization.check(e.utilizationTargets(), warning, critical, now) {
+		logger.Info("Alerting: Utilization %s for %s at %.1f%%", event.Level, event.Name, event.UsedPercent)
+		e.utilization.notify(event)
+		if e.hub != nil {
+			domain.Publish(e.hub, constants.TopicUtilizationEvent, event)
+		}
+	}
+}
+
+// UtilizationSummary returns the utilization level of every array data disk
+// and pool with the thresholds in effect and the recent utilization events.
+func (e *Engine) UtilizationSummary(now time.Time) dto.UtilizationSummary {
+	settings := e.thermal.diskSettings(now)
+	summary := dto.UtilizationSummary{
+		WarningPercent:    settings.WarningUtilization,
+		CriticalPercent:   settings.CriticalUtilization,
+		HysteresisPercent: UtilizationHysteresis,
+		Filesystems:       []dto.UtilizationStatus{},
+		Events:            e.utilization.recentEvents(),
+		Timestamp:         now,
+	}
+	for _, t := range e.utilizationTargets() {
+		status := dto.UtilizationStatus{Kind: t.kind, Entity: t.entity, Name: t.name, UsedPercent: t.used}
+		level, since := e.utilization.status(t.key())
+		status.Level = level
+		if level != dto.ThermalLevelOK {
+			status.Since = &since
+		}
+		summary.Filesystems = append(summary.Filesystems, status)
+	}
+	return summary
+}
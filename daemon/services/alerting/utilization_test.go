@@ -0,0 +1,95 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestUtilizationLevel(t *testing.T) {
+	tests := []struct {
+		current string
+		used    float64
+		want    string
+	}{
+		{dto.ThermalLevelOK, 69.9, dto.ThermalLevelOK},
+		{dto.ThermalLevelOK, 70, dto.ThermalLevelWarning},
+		{dto.ThermalLevelOK, 95, dto.ThermalLevelCritical},
+		{dto.ThermalLevelWarning, 68.5, dto.ThermalLevelWarning},
+		{dto.ThermalLevelWarning, 68, dto.ThermalLevelOK},
+		{dto.ThermalLevelCritical, 88.5, dto.ThermalLevelCritical},
+		{dto.ThermalLevelCritical, 88, dto.ThermalLevelWarning},
+		{dto.ThermalLevelCritical, 50, dto.ThermalLevelOK},
+	}
+	for _, tt := range tests {
+		if got := utilizationLevel(tt.current, tt.used, 70, 90); got != tt.want {
+			t.Errorf("utilizationLevel(%s, %.1f) = %s, want %s", tt.current, tt.used, got, tt.want)
+		}
+	}
+
+	if got := utilizationLevel(dto.ThermalLevelOK, 99, 0, 0); got != dto.ThermalLevelOK {
+		t.Errorf("disabled thresholds = %s, want ok", got)
+	}
+}
+
+func TestUtilizationEventsOncePerTransition(t *testing.T) {
+	provider := &mockDataProvider{
+		disks: []dto.DiskInfo{{ID: "disk2", Name: "Disk 2", Role: "data", Size: 100, UsagePercent: 85}},
+		pools: []dto.PoolInfo{{Name: "cache", TotalBytes: 100, UsagePercent: 40}},
+	}
+	e, _ := newThermalTestEngine(t, provider)
+	e.thermal.settingsFn = func() (*dto.DiskSettingsExtended, error) {
+		return &dto.DiskSettingsExtended{WarningUtilization: 70, CriticalUtilization: 90}, nil
+	}
+	var sent []sentNotification
+	e.utilization.notifyFn = func(_, subject, _, importance, _ string) error {
+		sent = append(sent, sentNotification{subject, importance})
+		return nil
+	}
+	hub := domain.NewEventBus(8)
+	e.SetEventBus(hub)
+	ch := hub.SubTopics(constants.TopicUtilizationEvent)
+	defer hub.Unsub(ch, constants.TopicUtilizationEvent.Name)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// The first reading only seeds the level
+	e.checkUtilization(now)
+	if len(sent) != 0 {
+		t.Fatalf("first reading notified %+v", sent)
+	}
+	if s := e.UtilizationSummary(now); len(s.Filesystems) != 2 || s.Filesystems[0].Level != dto.ThermalLevelWarning || s.Filesystems[1].Level != dto.ThermalLevelOK {
+		t.Fatalf("seeded summary = %+v", s.Filesystems)
+	}
+
+	for _, used := range []float64{91, 92, 89, 88.5} {
+		provider.disks[0].UsagePercent = used
+		e.checkUtilization(now)
+	}
+	if len(sent) != 1 || sent[0].importance != "alert" {
+		t.Fatalf("notifications after reaching critical = %+v, want one alert", sent)
+	}
+	select {
+	case msg := <-ch:
+		event, ok := msg.(dto.UtilizationEvent)
+		if !ok || event.Level != dto.ThermalLevelCritical || event.PreviousLevel != dto.ThermalLevelWarning || event.ThresholdPercent != 90 {
+			t.Errorf("published %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no utilization event published")
+	}
+
+	for _, used := range []float64{85, 69, 68.5, 60} {
+		provider.disks[0].UsagePercent = used
+		e.checkUtilization(now)
+	}
+	if len(sent) != 3 || sent[1].importance != "warning" || sent[2].importance != "info" {
+		t.Fatalf("notifications after recovering = %+v, want warning then info", sent)
+	}
+	events := e.utilization.recentEvents()
+	if last := events[len(events)-1]; last.Level != dto.ThermalLevelResolved || last.ThresholdPercent != 70 || last.Entity != "disk2" {
+		t.Errorf("last event = %+v, want disk2 resolved below 70%%", last)
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/configreload"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// SetConfigReloader sets the config reloader backing the /agent/config endpoints.
+func (s *Server) SetConfigReloader(r *configreload.Reloader) {
+	s.configReloader = r
+}
+
+// handleGetAgentConfig godoc
+//
+//	@Summary		Get agent config file
+//	@Description	Returns the YAML config file as JSON (MQTT password and MCP API key redacted), the last reload time and error, and settings that changed but need a restart to take effect.
+//	@Tags			Configuration
+//	@Produce		json
+//	@Success		200	{object}	dto.AgentConfigStatus
+//	@Failure		503	{object}	dto.Response	"Config reload not available"
+//	@Router			/agent/config [get]
+func (s *Server) handleGetAgentConfig(w http.ResponseWriter, _ *http.Request) {
+	if s.configReloader == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Config reload not available")
+		return
+	}
+	respondJSON(w, http.StatusOK, s.configReloader.Status())
+}
+
+// handleUpdateAgentConfig godoc
+//
+//	@Summary		Replace agent config file
+//	@Description	Validates and writes the full config file, then reloads it. Log level and collector intervals apply immediately; other changes are listed in restart_required. Send "********" for a secret to keep its stored value.
+//	@Tags			Configuration
+//	@Accept			json
+//	@Produce		json
+//	@Param			config	body		domain.FileConfig	true	"Complete config file contents"
+//	@Success		200		{object}	dto.ConfigReloadResult
+//	@Failure		400		{object}	dto.Response	"Invalid config"
+//	@Failure		500		{object}	dto.Response	"Failed to write config"
+//	@Failure		503		{object}	dto.Response	"Config reload not available"
+//	@Router			/agent/config [put]
+func (s *Server) handleUpdateAgentConfig(w http.ResponseWriter, r *http.Request) {
+	if s.configReloader == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Config reload not available")
+		return
+	}
+
+	var cfg domain.FileConfig
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	result, err := s.configReloader.Update(&cfg)
+	if err != nil {
+		if errors.Is(err, configreload.ErrInvalidConfig) {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.ErrorContext(r.Context(), "API: Failed to update config file: %v", err)
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update config: %v", err))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// handleReloadAgentConfig godoc
+//
+//	@Summary		Reload agent config file
+//	@Description	Re-reads the config file from disk, equivalent to sending SIGHUP.
+//	@Tags			Configuration
+//	@Produce		json
+//	@Success		200	{object}	dto.ConfigReloadResult
+//	@Failure		400	{object}	dto.Response	"Config file is invalid"
+//	@Failure		503	{object}	dto.Response	"Config reload not available"
+//	@Router			/agent/config/reload [post]
+func (s *Server) handleReloadAgentConfig(w http.ResponseWriter, _ *http.Request) {
+	if s.configReloader == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Config reload not available")
+		return
+	}
+
+	result, err := s.configReloader.Reload()
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Config reload failed: %v", err))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// handleAgentUpdateCheck godoc
+//
+//	@Summary		Check for agent updates
+//	@Description	Compares the running agent version with the latest GitHub release.
+//	@Tags			Configuration
+//	@Produce		json
+//	@Success		200	{object}	dto.AgentUpdateStatus
+//	@Failure		502	{object}	dto.Response	"Release lookup failed"
+//	@Router			/agent/update [get]
+func (s *Server) handleAgentUpdateCheck(w http.ResponseWriter, r *http.Request) {
+	status, err := controllers.NewAgentUpdater(s.ctx.Version).Check(r.Context())
+	if err != nil {
+		logger.WarningContext(r.Context(), "API: Agent update check failed: %v", err)
+		respondWithError(w, http.StatusBadGateway, fmt.Sprintf("Update check failed: %v", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, status)
+}
+
+// handleAgentUpdate godoc
+//
+//	@Summary		Update the agent
+//	@Description	Downloads the latest release bundle and verifies it against the SHA-256 checksum published with the release; releases without one are refused. Releases are not signed, so the checksum guards against corrupted downloads, not a tampered release. With dry_run the bundle is discarded after verification. Otherwise confirm must be true; the bundle is installed through the Unraid plugin system and the agent restarts, so the response arrives before the update completes.
+//	@Tags			Configuration
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.AgentUpdateRequest	true	"Dry run or confirmed install"
+//	@Success		200		{object}	dto.AgentUpdateStatus	"Up to date or dry run verified"
+//	@Success		202		{object}	dto.AgentUpdateStatus	"Update installing"
+//	@Failure		400		{object}	dto.Response			"Missing confirmation"
+//	@Failure		502		{object}	dto.Response			"Download or verification failed"
+//	@Router			/agent/update [post]
+func (s *Server) handleAgentUpdate(w http.ResponseWriter, r *http.Request) {
+	var req dto.AgentUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if !req.DryRun && !req.Confirm {
+		respondWithError(w, http.StatusBadRequest, "Set confirm to true to install the update (the agent will restart), or dry_run to verify only")
+		return
+	}
+
+	status, err := controllers.NewAgentUpdater(s.ctx.Version).Update(r.Context(), req.DryRun)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Agent update failed: %v", err)
+		respondWithError(w, http.StatusBadGateway, fmt.Sprintf("Update failed: %v", err))
+		return
+	}
+
+	code := http.StatusOK
+	if status.Status == dto.AgentUpdateInstalling {
+		code = http.StatusAccepted
+	}
+	respondJSON(w, code, status)
+}
+
+// handleAgentRestart godoc
+//
+//	@Summary		Restart the agent
+//	@Description	Restarts the agent through the plugin's stop and start scripts. The response arrives before the agent stops. The shutdown is graceful: MQTT reports the agent offline, WebSocket clients receive a going-away close frame (code 1001) and the caches are restored by the new process.
+//	@Tags			Configuration
+//	@Produce		json
+//	@Success		202	{object}	dto.Response	"Restart started"
+//	@Failure		500	{object}	dto.Response	"Restart could not be started"
+//	@Router			/agent/restart [post]
+func (s *Server) handleAgentRestart(w http.ResponseWriter, r *http.Request) {
+	logFile, err := controllers.RestartAgent(r.Context())
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Agent restart failed: %v", err)
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Restart failed: %v", err))
+		return
+	}
+	respondJSON(w, http.StatusAccepted, dto.Response{
+		Success:   true,
+		Message:   fmt.Sprintf("The agent will restart shortly (log: %s)", logFile),
+		Timestamp: time.Now(),
+	})
+}
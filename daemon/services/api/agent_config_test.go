@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/configreload"
+)
+
+type noopCollectors struct{}
+
+func (noopCollectors) EnableCollector(string) error     { return nil }
+func (noopCollectors) DisableCollector(string) error    { return nil }
+func (noopCollectors) UpdateInterval(string, int) error { return nil }
+
+func TestAgentConfigEndpoints(t *testing.T) {
+	server, ctx := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/agent/config", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 without reloader, got %d", w.Code)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	server.SetConfigReloader(configreload.NewReloader(path, ctx, noopCollectors{}))
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		code   int
+		want   string
+	}{
+		{"get", http.MethodGet, "/api/v1/agent/config", "", http.StatusOK, `"exists":false`},
+		{"put", http.MethodPut, "/api/v1/agent/config", `{"port":9000,"intervals":{"docker":20}}`, http.StatusOK, `"restart_required":["port"]`},
+		{"get after put", http.MethodGet, "/api/v1/agent/config", "", http.StatusOK, `"docker":20`},
+		{"put invalid", http.MethodPut, "/api/v1/agent/config", `{"intervals":{"docker":1}}`, http.StatusBadRequest, "invalid config"},
+		{"put unknown field", http.MethodPut, "/api/v1/agent/config", `{"prot":9000}`, http.StatusBadRequest, "unknown field"},
+		{"reload", http.MethodPost, "/api/v1/agent/config/reload", "", http.StatusOK, `"applied":[]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			if w.Code != tt.code {
+				t.Fatalf("Expected %d, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("Expected body to contain %s, got %s", tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleAgentUpdate_RequiresConfirm(t *testing.T) {
+	server, _ := setupTestServer()
+
+	for _, body := range []string{`{}`, `{"confirm":false}`, `not json`} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/agent/update", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("body %s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/agentlog"
+)
+
+// handleAgentLogs godoc
+//
+//	@Summary		Get agent log
+//	@Description	Read the agent's own log, including the rotated backup, as structured entries (oldest first) so the agent can be debugged remotely without shell access to /var/log. Only entries written at or above the configured log level exist. Sensitive values are redacted.
+//	@Tags			Logs
+//	@Produce		json
+//	@Param			level	query		string	false	"Minimum level: debug, info (default), warning or error"
+//	@Param			since	query		string	false	"Only entries newer than a duration (e.g. 2h) or RFC3339 timestamp"
+//	@Param			correlation_id	query	string	false	"Only entries for this request ID (X-Request-ID), job ID or collector (collector:<name>)"
+//	@Param			limit	query		integer	false	"Newest entries to return (default 200, max 5000)"
+//	@Success		200		{object}	dto.AgentLog	"Agent log entries"
+//	@Failure		400		{object}	dto.Response	"Invalid parameters"
+//	@Failure		500		{object}	dto.Response	"Failed to read agent log"
+//	@Router			/agent/logs [get]
+func (s *Server) handleAgentLogs(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := agentlog.Query{MinLevel: logger.LevelInfo}
+
+	if value := params.Get("level"); value != "" {
+		level, ok := domain.ParseLogLevel(value)
+		if !ok {
+			respondWithError(w, http.StatusBadRequest, "level must be debug, info, warning or error")
+			return
+		}
+		query.MinLevel = level
+	}
+	if value := params.Get("since"); value != "" {
+		since, err := parseLogSearchSince(value, time.Now())
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		query.Since = since
+	}
+	query.CorrelationID = params.Get("correlation_id")
+	if value := params.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > agentlog.MaxLimit {
+			respondWithError(w, http.StatusBadRequest, "limit must be between 1 and 5000")
+			return
+		}
+		query.Limit = limit
+	}
+
+	result, err := agentlog.Read(s.ctx.LogsDir, query)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to read agent log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to read agent log")
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestHandleAgentLogs(t *testing.T) {
+	server, ctx := setupTestServer()
+	ctx.LogsDir = t.TempDir()
+	content := "2026/10/17 09:00:00 Starting collectors\n" +
+		"2026/10/17 09:05:00 WARNING: Docker: slow response\n"
+	if err := os.WriteFile(filepath.Join(ctx.LogsDir, "unraid-management-agent.log"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(query string) *httptest.ResponseRecorder {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/agent/logs"+query, nil))
+		return rr
+	}
+
+	rr := get("?level=warning")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rr.Code, rr.Body.String())
+	}
+	var result dto.AgentLog
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 1 || result.Entries[0].Message != "Docker: slow response" {
+		t.Errorf("result = %+v", result)
+	}
+
+	for _, query := range []string{"?level=verbose", "?since=yesterday", "?limit=0"} {
+		if rr := get(query); rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", query, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"cmp"
+	"net/http"
+	"net/http/pprof" // #nosec G108 -- only mounted on the router when --pprof is set
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// requestStats counts requests and latencies per route since the server was
+// created.
+type requestStats struct {
+	mu      sync.Mutex
+	started time.Time
+	routes  map[routeKey]*routeCounters
+}
+
+type routeKey struct {
+	method, route string
+}
+
+type routeCounters struct {
+	count, clientErrors, serverErrors int64
+	total, max                        time.Duration
+	lastStatus                        int
+}
+
+func newRequestStats() *requestStats {
+	return &requestStats{started: time.Now(), routes: make(map[routeKey]*routeCounters)}
+}
+
+func (rs *requestStats) record(method, route string, status int, elapsed time.Duration) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	key := routeKey{method: method, route: route}
+	c, ok := rs.routes[key]
+	if !ok {
+		c = &routeCounters{}
+		rs.routes[key] = c
+	}
+	c.count++
+	switch {
+	case status >= http.StatusInternalServerError:
+		c.serverErrors++
+	case status >= http.StatusBadRequest:
+		c.clientErrors++
+	}
+	c.total += elapsed
+	c.max = max(c.max, elapsed)
+	c.lastStatus = status
+}
+
+// snapshot returns the counters with routes sorted busiest first.
+func (rs *requestStats) snapshot() dto.AgentRequestStats {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	result := dto.AgentRequestStats{Routes: make([]dto.AgentRouteStats, 0, len(rs.routes))}
+	for key, c := range rs.routes {
+		result.Total += c.count
+		result.Errors += c.serverErrors
+		result.Routes = append(result.Routes, dto.AgentRouteStats{
+			Method:         key.method,
+			Route:          key.route,
+			Count:          c.count,
+			ClientErrors:   c.clientErrors,
+			ServerErrors:   c.serverErrors,
+			AvgLatencyMS:   milliseconds(c.total / time.Duration(c.count)),
+			MaxLatencyMS:   milliseconds(c.max),
+			LastStatusCode: c.lastStatus,
+		})
+	}
+	slices.SortFunc(result.Routes, func(a, b dto.AgentRouteStats) int {
+		if n := cmp.Compare(b.Count, a.Count); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Route+" "+a.Method, b.Route+" "+b.Method)
+	})
+	return result
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// statsMiddleware records every matched request under its route template, so
+// /docker/{id} is counted once rather than per container.
+func (s *Server) statsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if tmpl, err := current.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.requestStats.record(r.Method, route, rec.status, time.Since(start))
+	})
+}
+
+// handleAgentStats godoc
+//
+//	@Summary		Get agent performance stats
+//	@Description	Request counts and latencies per route, WebSocket clients, MQTT publish rates and Go runtime memory and goroutine figures, to diagnose agent performance issues in the field. Counters reset when the agent restarts.
+//	@Tags			Monitoring
+//	@Produce		json
+//	@Success		200	{object}	dto.AgentStats	"Agent performance stats"
+//	@Router			/agent/stats [get]
+func (s *Server) handleAgentStats(w http.ResponseWriter, _ *http.Request) {
+	now := time.Now()
+	uptime := now.Sub(s.requestStats.started)
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := dto.AgentStats{
+		StartedAt:     s.requestStats.started,
+		UptimeSeconds: int64(uptime.Seconds()),
+		Requests:      s.requestStats.snapshot(),
+		WebSocket:     s.wsHub.Stats(),
+		Runtime: dto.AgentRuntimeStats{
+			GoVersion:      runtime.Version(),
+			Goroutines:     runtime.NumGoroutine(),
+			HeapAllocBytes: mem.HeapAlloc,
+			HeapSysBytes:   mem.HeapSys,
+			SysBytes:       mem.Sys,
+			NumGC:          mem.NumGC,
+			GCPauseTotalMS: float64(mem.PauseTotalNs) / 1e6,
+			LastGCPauseMS:  float64(mem.PauseNs[(mem.NumGC+255)%256]) / 1e6,
+		},
+		PprofEnabled: s.ctx.Pprof,
+		Timestamp:    now,
+	}
+	if s.mqttClient != nil {
+		if status := s.mqttClient.GetStatus(); status != nil {
+			mqtt := &dto.AgentMQTTStats{
+				Connected:      status.Connected,
+				MessagesSent:   status.MessagesSent,
+				MessagesErrors: status.MessagesErrors,
+			}
+			if minutes := uptime.Minutes(); minutes > 0 {
+				mqtt.MessagesPerMinute = float64(status.MessagesSent) / minutes
+			}
+			stats.MQTT = mqtt
+		}
+	}
+
+	respondJSON(w, http.StatusOK, stats)
+}
+
+// registerPprof serves the Go runtime profiles under /debug/pprof. The
+// cmdline profile is left out because flags may carry secrets such as the
+// MQTT password.
+func (s *Server) registerPprof() {
+	debug := s.router.PathPrefix("/debug/pprof").Subrouter()
+	debug.HandleFunc("/profile", withoutWriteDeadline(pprof.Profile)).Methods("GET")
+	debug.HandleFunc("/trace", withoutWriteDeadline(pprof.Trace)).Methods("GET")
+	debug.HandleFunc("/symbol", pprof.Symbol).Methods("GET", "POST")
+	debug.PathPrefix("/").HandlerFunc(pprof.Index).Methods("GET")
+}
+
+// withoutWriteDeadline lifts the server's write timeout for profiles that
+// sample for longer than it (profile and trace take ?seconds=N).
+func withoutWriteDeadline(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		h(w, r)
+	}
+}
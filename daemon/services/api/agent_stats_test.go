@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestHandleAgentStats(t *testing.T) {
+	server, _ := setupTestServer()
+	server.SetMQTTClient(&mockMQTTClient{connected: true})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		return rr
+	}
+	get("/api/v1/docker/abc123")
+	get("/api/v1/docker/def456")
+	get("/api/v1/health")
+
+	rr := get("/api/v1/agent/stats")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rr.Code, rr.Body.String())
+	}
+	var stats dto.AgentStats
+	if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Requests.Total != 3 || len(stats.Requests.Routes) != 2 {
+		t.Fatalf("requests = %+v", stats.Requests)
+	}
+	docker := stats.Requests.Routes[0]
+	if docker.Route != "/api/v1/docker/{id}" || docker.Method != "GET" || docker.Count != 2 || docker.ClientErrors != 2 {
+		t.Errorf("busiest route = %+v", docker)
+	}
+	if stats.MQTT == nil || !stats.MQTT.Connected {
+		t.Errorf("mqtt = %+v", stats.MQTT)
+	}
+	if stats.Runtime.Goroutines == 0 || stats.Runtime.HeapAllocBytes == 0 || stats.PprofEnabled {
+		t.Errorf("runtime = %+v, pprof = %v", stats.Runtime, stats.PprofEnabled)
+	}
+}
+
+func TestPprofRoutes(t *testing.T) {
+	get := func(server *Server, path string) int {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		return rr.Code
+	}
+
+	disabled, _ := setupTestServer()
+	if code := get(disabled, "/debug/pprof/"); code != http.StatusNotFound {
+		t.Errorf("disabled: status %d, want 404", code)
+	}
+
+	enabled := NewServer(&domain.Context{Config: domain.Config{Port: 8080, Pprof: true}})
+	if code := get(enabled, "/debug/pprof/"); code != http.StatusOK {
+		t.Errorf("index: status %d, want 200", code)
+	}
+	if code := get(enabled, "/debug/pprof/goroutine?debug=1"); code != http.StatusOK {
+		t.Errorf("goroutine: status %d, want 200", code)
+	}
+	if code := get(enabled, "/debug/pprof/cmdline"); code != http.StatusNotFound {
+		t.Errorf("cmdline: status %d, want 404", code)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+)
+
+// maxAuditQueryLimit caps the number of entries a single /audit request can return.
+const maxAuditQueryLimit = audit.MaxEntries
+
+// auditMiddleware records every state-changing REST request (POST, PUT, PATCH,
+// DELETE) under /api/v1 to the audit log once the handler has completed. The
+// /mcp endpoint shares this router but is audited per tool call by the MCP
+// server instead.
+func (s *Server) auditMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isStateChangingMethod(r.Method) || !strings.HasPrefix(r.URL.Path, "/api/v1/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		path := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				path = tmpl
+			}
+		}
+
+		entry := dto.AuditEntry{
+			Source: dto.AuditSourceREST,
+			Client: clientKey(r.RemoteAddr),
+			Action: r.Method + " " + path,
+			Target: auditTarget(mux.Vars(r)),
+			Result: dto.AuditResultSuccess,
+		}
+		if rec.status >= http.StatusBadRequest {
+			entry.Result = dto.AuditResultFailure
+			entry.Detail = fmt.Sprintf("HTTP %d", rec.status)
+		}
+		s.auditLog.Record(entry)
+	})
+}
+
+// isStateChangingMethod reports whether an HTTP method can modify server state.
+func isStateChangingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// auditTarget flattens route variables into a stable "key=value" list.
+func auditTarget(vars map[string]string) string {
+	if len(vars) == 0 {
+		return ""
+	}
+	if len(vars) == 1 {
+		for _, v := range vars {
+			return v
+		}
+	}
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + vars[k]
+	}
+	return strings.Join(parts, ",")
+}
+
+// handleAuditLog godoc
+//
+//	@Summary		Get audit log
+//	@Description	List recorded control actions (REST, MCP, MQTT, inbound webhooks), newest first, with optional filtering
+//	@Tags			Audit
+//	@Produce		json
+//	@Param			source	query		string					false	"Filter by source (rest, mcp, mqtt, webhook)"
+//	@Param			action	query		string					false	"Filter by action (substring match)"
+//	@Param			target	query		string					false	"Filter by target (substring match)"
+//	@Param			result	query		string					false	"Filter by result (success, failure, denied, approved)"
+//	@Param			since	query		string					false	"Only entries at or after this RFC3339 timestamp"
+//	@Param			limit	query		int						false	"Maximum entries to return (default 100, max 1000)"
+//	@Success		200		{object}	dto.AuditLogResponse	"Audit entries"
+//	@Failure		400		{object}	dto.Response			"Invalid query parameter"
+//	@Failure		503		{object}	dto.Response			"Audit log not initialized"
+//	@Router			/audit [get]
+func (s *Server) handleAuditLog(w http.ResponseWriter, r *http.Request) {
+	if s.auditLog == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Audit log not initialized")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := dto.AuditFilter{
+		Source: dto.AuditSource(q.Get("source")),
+		Action: q.Get("action"),
+		Target: q.Get("target"),
+		Result: q.Get("result"),
+	}
+
+	if v := q.Get("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "since must be an RFC3339 timestamp")
+			return
+		}
+		filter.Since = since
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			respondWithError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		filter.Limit = min(limit, maxAuditQueryLimit)
+	}
+
+	entries := s.auditLog.Query(filter)
+	respondJSON(w, http.StatusOK, dto.AuditLogResponse{
+		Entries: entries,
+		Total:   len(entries),
+	})
+}
+
+// GetAuditLog returns the audit log (may be nil when auditing is not wired).
+func (s *Server) GetAuditLog() *audit.Log {
+	return s.auditLog
+}
+
+// SetAuditLog sets the audit log used to record state-changing requests.
+func (s *Server) SetAuditLog(l *audit.Log) {
+	s.auditLog = l
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+)
+
+func newAuditServer(t *testing.T) *Server {
+	t.Helper()
+	s := NewServer(&domain.Context{Hub: domain.NewEventBus(10)})
+	s.SetAuditLog(audit.NewLog(""))
+	return s
+}
+
+func TestAuditMiddlewareRecordsStateChangingRequests(t *testing.T) {
+	s := newAuditServer(t)
+
+	// A POST to a known route is recorded with its path template and target.
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/collectors/gpu/disable", nil)
+	req.RemoteAddr = "192.168.1.50:51234"
+	s.GetRouter().ServeHTTP(httptest.NewRecorder(), req)
+
+	// GET requests are never recorded.
+	s.GetRouter().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+
+	entries := s.GetAuditLog().Query(dto.AuditFilter{})
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Source != dto.AuditSourceREST {
+		t.Errorf("source = %q, want rest", e.Source)
+	}
+	if e.Action != "POST /api/v1/collectors/{name}/disable" {
+		t.Errorf("action = %q", e.Action)
+	}
+	if e.Target != "gpu" {
+		t.Errorf("target = %q, want gpu", e.Target)
+	}
+	if e.Client != "192.168.1.50" {
+		t.Errorf("client = %q", e.Client)
+	}
+	// No collector manager is wired, so the handler fails.
+	if e.Result != dto.AuditResultFailure {
+		t.Errorf("result = %q, want failure", e.Result)
+	}
+}
+
+func TestHandleAuditLog(t *testing.T) {
+	s := newAuditServer(t)
+	s.GetAuditLog().Record(dto.AuditEntry{Source: dto.AuditSourceMCP, Action: "vm_action", Result: dto.AuditResultSuccess})
+	s.GetAuditLog().Record(dto.AuditEntry{Source: dto.AuditSourceMQTT, Action: "docker/restart", Result: dto.AuditResultFailure})
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantTotal  int
+	}{
+		{"all", "", http.StatusOK, 2},
+		{"by source", "?source=mqtt", http.StatusOK, 1},
+		{"by result", "?result=success", http.StatusOK, 1},
+		{"limit", "?limit=1", http.StatusOK, 1},
+		{"invalid limit", "?limit=abc", http.StatusBadRequest, 0},
+		{"invalid since", "?since=yesterday", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			s.GetRouter().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/audit"+tt.query, nil))
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp dto.AuditLogResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", resp.Total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestHandleAuditLogUnavailable(t *testing.T) {
+	s := NewServer(&domain.Context{Hub: domain.NewEventBus(10)})
+	rr := httptest.NewRecorder()
+	s.GetRouter().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/audit", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", rr.Code)
+	}
+}
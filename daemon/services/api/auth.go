@@ -0,0 +1,213 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/auth"
+)
+
+// publicAuthPaths stay reachable without credentials when AUTH_REQUIRED is
+// set, so clients can find out they must log in, log in, and monitoring can
+// still probe health.
+var publicAuthPaths = map[string]bool{
+	"/api/v1/health":       true,
+	"/api/v1/health/live":  true,
+	"/api/v1/health/ready": true,
+	"/api/v1/auth/login":   true,
+	"/api/v1/auth/refresh": true,
+	"/api/v1/auth/session": true,
+}
+
+// SetAuthManager sets the login session manager backing the /auth endpoints
+// and token checks.
+func (s *Server) SetAuthManager(m *auth.Manager) {
+	s.authManager = m
+}
+
+// authMiddleware rejects unauthenticated requests to the REST API, /metrics
+// and /debug when AUTH_REQUIRED is set. /mcp checks its own API key and the
+// Swagger UI stays public. CORS preflight requests carry no credentials and
+// pass through.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.ctx.Auth.Required || r.Method == http.MethodOptions || !requiresAuth(r.URL.Path) || isHookDelivery(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if s.authenticate(r) == nil {
+			logger.DebugContext(r.Context(), "API: rejected unauthenticated request to %s from %s", r.URL.Path, r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="unraid-management-agent"`)
+			respondWithError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func requiresAuth(path string) bool {
+	if publicAuthPaths[path] {
+		return false
+	}
+	return strings.HasPrefix(path, "/api/v1/") || path == "/metrics" || strings.HasPrefix(path, "/debug/")
+}
+
+// authenticate returns the session of a request that carries a valid access
+// token or the static API key (MCP_API_KEY), or nil. Browsers cannot set
+// headers on WebSocket upgrades, so /ws also accepts ?access_token=.
+func (s *Server) authenticate(r *http.Request) *dto.AuthSession {
+	presented := r.Header.Get("X-API-Key")
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		presented = strings.TrimSpace(token)
+	}
+	if presented == "" && r.URL.Path == "/api/v1/ws" {
+		presented = r.URL.Query().Get("access_token")
+	}
+	if presented == "" {
+		return nil
+	}
+
+	if key := s.ctx.MCPAPIKey; key != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(key)) == 1 {
+		return &dto.AuthSession{AuthRequired: s.ctx.Auth.Required, Authenticated: true, Method: dto.AuthMethodAPIKey}
+	}
+	if s.authManager == nil {
+		return nil
+	}
+	claims, err := s.authManager.Authenticate(presented)
+	if err != nil {
+		return nil
+	}
+	expires := claims.ExpiresAt.Time
+	return &dto.AuthSession{
+		AuthRequired:  s.ctx.Auth.Required,
+		Authenticated: true,
+		Method:        dto.AuthMethodToken,
+		Username:      claims.Subject,
+		SessionID:     claims.SessionID,
+		ExpiresAt:     &expires,
+	}
+}
+
+// authReady writes a 503 response and returns false when login is not
+// initialized.
+func (s *Server) authReady(w http.ResponseWriter) bool {
+	if s.authManager == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Login not initialized")
+		return false
+	}
+	return true
+}
+
+// handleAuthLogin godoc
+//
+//	@Summary		Log in
+//	@Description	Check the Unraid root password and start a session. Returns a short-lived access token to send as "Authorization: Bearer <token>" and a refresh token for POST /auth/refresh. Five failed attempts lock the client out for five minutes.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.AuthLoginRequest	true	"Credentials"
+//	@Success		200		{object}	dto.AuthTokens			"Session tokens"
+//	@Failure		400		{object}	dto.Response			"Invalid request body"
+//	@Failure		401		{object}	dto.Response			"Invalid username or password"
+//	@Failure		429		{object}	dto.Response			"Too many failed attempts"
+//	@Failure		500		{object}	dto.Response			"Password cannot be verified"
+//	@Router			/auth/login [post]
+func (s *Server) handleAuthLogin(w http.ResponseWriter, r *http.Request) {
+	if !s.authReady(w) {
+		return
+	}
+	var req dto.AuthLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	tokens, err := s.authManager.Login(clientKey(r.RemoteAddr), req.Username, req.Password)
+	switch {
+	case err == nil:
+		logger.InfoContext(r.Context(), "API: %s logged in from %s", tokens.Username, r.RemoteAddr)
+		respondJSON(w, http.StatusOK, tokens)
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		logger.WarningContext(r.Context(), "API: failed login for %q from %s", req.Username, r.RemoteAddr)
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, auth.ErrTooManyAttempts):
+		w.Header().Set("Retry-After", "300")
+		respondWithError(w, http.StatusTooManyRequests, err.Error())
+	default:
+		logger.ErrorContext(r.Context(), "API: login failed: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Password cannot be verified: "+err.Error())
+	}
+}
+
+// handleAuthRefresh godoc
+//
+//	@Summary		Refresh session tokens
+//	@Description	Exchange a refresh token for a new access and refresh token. Each refresh token works once; reusing one ends the session.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.AuthRefreshRequest	true	"Refresh token"
+//	@Success		200		{object}	dto.AuthTokens			"New session tokens"
+//	@Failure		400		{object}	dto.Response			"Invalid request body"
+//	@Failure		401		{object}	dto.Response			"Invalid or expired refresh token"
+//	@Router			/auth/refresh [post]
+func (s *Server) handleAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	if !s.authReady(w) {
+		return
+	}
+	var req dto.AuthRefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	tokens, err := s.authManager.Refresh(req.RefreshToken)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, tokens)
+}
+
+// handleAuthLogout godoc
+//
+//	@Summary		Log out
+//	@Description	End the session of the access token in the Authorization header. Its access and refresh tokens stop working.
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.Response	"Logged out"
+//	@Failure		401	{object}	dto.Response	"No valid access token"
+//	@Router			/auth/logout [post]
+func (s *Server) handleAuthLogout(w http.ResponseWriter, r *http.Request) {
+	session := s.authenticate(r)
+	if session == nil || session.Method != dto.AuthMethodToken {
+		respondWithError(w, http.StatusUnauthorized, "No valid access token")
+		return
+	}
+	s.authManager.Logout(session.SessionID)
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Logged out", Timestamp: time.Now()})
+}
+
+// handleAuthSession godoc
+//
+//	@Summary		Get current session
+//	@Description	Report whether the API requires authentication and how this request is authenticated (token, api_key or none), so frontends can decide whether to show a login page. Always public: without valid credentials it answers 200 with authenticated false.
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.AuthSession	"Current session"
+//	@Router			/auth/session [get]
+func (s *Server) handleAuthSession(w http.ResponseWriter, r *http.Request) {
+	session := s.authenticate(r)
+	if session == nil {
+		session = &dto.AuthSession{AuthRequired: s.ctx.Auth.Required, Method: dto.AuthMethodNone}
+	}
+	respondJSON(w, http.StatusOK, session)
+}
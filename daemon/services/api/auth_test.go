@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/auth"
+)
+
+// setupAuthServer returns a test server whose root password is "hunter2".
+func setupAuthServer(t *testing.T, required bool) *Server {
+	t.Helper()
+	shadow := filepath.Join(t.TempDir(), "shadow")
+	entry := "root:$6$testsalt$ehrqOiQRn2f7nCvA/LgwTY1odMW9hjQ/GS8KC7ztGNzzC8hmzy8/g/pV7Ryg5gmQx7Wa1u13rOGLJIS5QQGcQ/:19000:0:99999:7:::\n"
+	if err := os.WriteFile(shadow, []byte(entry), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server, ctx := setupTestServer()
+	ctx.Auth.Required = required
+	ctx.MCPAPIKey = "static-key"
+	m, err := auth.NewManager(domain.AuthConfig{}, shadow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.SetAuthManager(m)
+	return server
+}
+
+func authRequest(server *Server, method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAuthLoginFlow(t *testing.T) {
+	server := setupAuthServer(t, true)
+
+	if rr := authRequest(server, "POST", "/api/v1/auth/login", `{"username":"root","password":"wrong"}`, ""); rr.Code != http.StatusUnauthorized {
+		t.Errorf("wrong password: status %d", rr.Code)
+	}
+	if rr := authRequest(server, "POST", "/api/v1/auth/login", `{`, ""); rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid body: status %d", rr.Code)
+	}
+
+	rr := authRequest(server, "POST", "/api/v1/auth/login", `{"username":"root","password":"hunter2"}`, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("login: status %d: %s", rr.Code, rr.Body)
+	}
+	var tokens dto.AuthTokens
+	if err := json.Unmarshal(rr.Body.Bytes(), &tokens); err != nil || tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Fatalf("tokens = %+v, %v", tokens, err)
+	}
+
+	rr = authRequest(server, "GET", "/api/v1/auth/session", "", tokens.AccessToken)
+	var session dto.AuthSession
+	if err := json.Unmarshal(rr.Body.Bytes(), &session); err != nil || session.Method != dto.AuthMethodToken || session.Username != "root" || !session.AuthRequired {
+		t.Errorf("session = %+v, %v", session, err)
+	}
+
+	rr = authRequest(server, "POST", "/api/v1/auth/refresh", `{"refresh_token":"`+tokens.RefreshToken+`"}`, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("refresh: status %d: %s", rr.Code, rr.Body)
+	}
+	var refreshed dto.AuthTokens
+	_ = json.Unmarshal(rr.Body.Bytes(), &refreshed)
+	if rr := authRequest(server, "POST", "/api/v1/auth/refresh", `{"refresh_token":"`+tokens.RefreshToken+`"}`, ""); rr.Code != http.StatusUnauthorized {
+		t.Errorf("reused refresh token: status %d", rr.Code)
+	}
+
+	// Reusing the old refresh token revoked the session; log in again.
+	rr = authRequest(server, "POST", "/api/v1/auth/login", `{"username":"root","password":"hunter2"}`, "")
+	_ = json.Unmarshal(rr.Body.Bytes(), &tokens)
+	if rr := authRequest(server, "POST", "/api/v1/auth/logout", "", tokens.AccessToken); rr.Code != http.StatusOK {
+		t.Errorf("logout: status %d", rr.Code)
+	}
+	if rr := authRequest(server, "GET", "/api/v1/system", "", tokens.AccessToken); rr.Code != http.StatusUnauthorized {
+		t.Errorf("access token after logout: status %d", rr.Code)
+	}
+	if rr := authRequest(server, "POST", "/api/v1/auth/logout", "", ""); rr.Code != http.StatusUnauthorized {
+		t.Errorf("logout without token: status %d", rr.Code)
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	server := setupAuthServer(t, true)
+	rr := authRequest(server, "POST", "/api/v1/auth/login", `{"username":"root","password":"hunter2"}`, "")
+	var tokens dto.AuthTokens
+	_ = json.Unmarshal(rr.Body.Bytes(), &tokens)
+
+	tests := []struct {
+		name, path, token string
+		want              int
+	}{
+		{"health is public", "/api/v1/health", "", http.StatusOK},
+		{"session is public", "/api/v1/auth/session", "", http.StatusOK},
+		{"no credentials", "/api/v1/system", "", http.StatusUnauthorized},
+		{"invalid token", "/api/v1/system", "nope", http.StatusUnauthorized},
+		{"refresh token as access token", "/api/v1/system", tokens.RefreshToken, http.StatusUnauthorized},
+		{"access token", "/api/v1/system", tokens.AccessToken, http.StatusOK},
+		{"api key", "/api/v1/system", "static-key", http.StatusOK},
+		{"metrics", "/metrics", "", http.StatusUnauthorized},
+		{"swagger is public", "/swagger/doc.json", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := authRequest(server, "GET", tt.path, "", tt.token)
+			if rr.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", rr.Code, tt.want, rr.Body)
+			}
+		})
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/session", nil)
+	req.Header.Set("X-API-Key", "static-key")
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	if !strings.Contains(rr.Body.String(), `"method":"api_key"`) || !strings.Contains(rr.Body.String(), `"authenticated":true`) {
+		t.Errorf("X-API-Key session: %s", rr.Body)
+	}
+
+	// Without valid credentials the session reports that a login is needed
+	for _, token := range []string{"", "nope", tokens.RefreshToken} {
+		var session dto.AuthSession
+		rr := authRequest(server, "GET", "/api/v1/auth/session", "", token)
+		if err := json.Unmarshal(rr.Body.Bytes(), &session); err != nil || rr.Code != http.StatusOK ||
+			session.Authenticated || !session.AuthRequired || session.Method != dto.AuthMethodNone {
+			t.Errorf("session with token %q: %d %s", token, rr.Code, rr.Body)
+		}
+	}
+}
+
+func TestAuthNotRequiredByDefault(t *testing.T) {
+	server := setupAuthServer(t, false)
+	rr := authRequest(server, "GET", "/api/v1/auth/session", "", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"auth_required":false`) {
+		t.Errorf("status %d: %s", rr.Code, rr.Body)
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+)
+
+func TestHandleBatch(t *testing.T) {
+	server, _ := setupTestServer()
+	server.SetAuditLog(audit.NewLog(""))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/batch", strings.NewReader(body)))
+		return rr
+	}
+
+	for _, body := range []string{
+		`not json`,
+		`{"actions":[]}`,
+		`{"actions":[{"action":"stop_container","target":"a1b2c3d4e5f6"}],"concurrency":99}`,
+	} {
+		if rr := post(body); rr.Code != http.StatusBadRequest {
+			t.Errorf("POST %s: status %d, want 400", body, rr.Code)
+		}
+	}
+
+	// Invalid actions are rejected by the executor without touching any
+	// controller and reported per item.
+	rr := post(`{"actions":[{"action":"frobnicate","target":"x"},{"action":"stop_container","target":"bad id!"},{"action":"spin_down_disk","target":"../disk1"}]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rr.Code, rr.Body.String())
+	}
+	var result dto.BatchResult
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Results) != 3 || result.Failed != 3 || result.Succeeded != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+	if result.Results[0].Action != "frobnicate" || result.Results[0].Error == "" {
+		t.Errorf("first result = %+v", result.Results[0])
+	}
+
+	// Each action is audited on its own besides the request
+	entries := server.GetAuditLog().Query(dto.AuditFilter{Action: "batch stop_container"})
+	if len(entries) != 1 || entries[0].Target != "bad id!" || entries[0].Result != dto.AuditResultFailure || entries[0].Detail == "" {
+		t.Errorf("audit entries = %+v", entries)
+	}
+}
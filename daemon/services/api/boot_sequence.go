@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/bootseq"
+)
+
+// SetBootSequence sets the runner backing the /boot-sequence endpoints.
+func (s *Server) SetBootSequence(r *bootseq.Runner) {
+	s.bootSequence = r
+}
+
+// bootSequenceReady writes a 503 response and returns false when the boot
+// sequence runner is not initialized.
+func (s *Server) bootSequenceReady(w http.ResponseWriter) bool {
+	if s.bootSequence == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Boot sequence not initialized")
+		return false
+	}
+	return true
+}
+
+// handleBootSequence godoc
+//
+//	@Summary		Get boot sequence
+//	@Description	Get the boot sequence configuration and the progress of its last run. After the server boots the agent waits for the network and UPS, unlocks and starts the array, and starts the selected containers and VMs in order. The state is empty until the sequence has run, and skipped when the agent started more than 15 minutes after boot.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.BootSequenceStatus	"Boot sequence"
+//	@Failure		503	{object}	dto.Response			"Boot sequence not initialized"
+//	@Router			/boot-sequence [get]
+func (s *Server) handleBootSequence(w http.ResponseWriter, _ *http.Request) {
+	if !s.bootSequenceReady(w) {
+		return
+	}
+	respondJSON(w, http.StatusOK, s.bootSequence.Status())
+}
+
+// handleUpdateBootSequence godoc
+//
+//	@Summary		Configure boot sequence
+//	@Description	Replace the boot sequence configuration. It is saved on the flash drive and applies from the next boot. An encrypted array is unlocked with the key read from key_file (e.g. on a USB stick) or fetched from key_url over HTTPS; with neither, the sequence waits for POST /array/unlock.
+//	@Tags			System
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.BootSequenceConfig	true	"Boot sequence configuration"
+//	@Success		200		{object}	dto.BootSequenceStatus	"Boot sequence"
+//	@Failure		400		{object}	dto.Response			"Invalid configuration"
+//	@Failure		500		{object}	dto.Response			"Failed to save the configuration"
+//	@Failure		503		{object}	dto.Response			"Boot sequence not initialized"
+//	@Router			/boot-sequence [put]
+func (s *Server) handleUpdateBootSequence(w http.ResponseWriter, r *http.Request) {
+	if !s.bootSequenceReady(w) {
+		return
+	}
+	var cfg dto.BootSequenceConfig
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if err := bootseq.Validate(cfg); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	status, err := s.bootSequence.SetConfig(cfg)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to save boot sequence: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, status)
+}
+
+// handleRunBootSequence godoc
+//
+//	@Summary		Run boot sequence now
+//	@Description	Run the configured boot sequence in the background, whether or not it is enabled, and return its initial progress. Poll GET /boot-sequence for the result.
+//	@Tags			System
+//	@Produce		json
+//	@Success		202	{object}	dto.BootSequenceStatus	"Boot sequence started"
+//	@Failure		409	{object}	dto.Response			"Boot sequence already running"
+//	@Failure		503	{object}	dto.Response			"Boot sequence not initialized"
+//	@Router			/boot-sequence/run [post]
+func (s *Server) handleRunBootSequence(w http.ResponseWriter, r *http.Request) {
+	if !s.bootSequenceReady(w) {
+		return
+	}
+	status, err := s.bootSequence.Start(s.detachedContext(r))
+	if err != nil {
+		if errors.Is(err, bootseq.ErrRunning) {
+			respondWithError(w, http.StatusConflict, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusAccepted, status)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/bootseq"
+)
+
+func TestBootSequenceEndpoints(t *testing.T) {
+	server, ctx := setupTestServer()
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rr
+	}
+
+	if rr := do("GET", "/api/v1/boot-sequence", ""); rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("uninitialized: status %d, want 503", rr.Code)
+	}
+
+	server.SetBootSequence(bootseq.NewRunner(ctx, t.TempDir(), server.GetUPSCache))
+
+	if rr := do("PUT", "/api/v1/boot-sequence", `{"enabled":true,"key_url":"http://keys.example.com"}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("plain http key url: status %d, want 400", rr.Code)
+	}
+
+	rr := do("PUT", "/api/v1/boot-sequence", `{"enabled":true,"start_array":true,"containers":["mariadb","nextcloud"]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("update: status %d: %s", rr.Code, rr.Body)
+	}
+
+	rr = do("GET", "/api/v1/boot-sequence", "")
+	var status dto.BootSequenceStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.Config.Enabled || len(status.Config.Containers) != 2 || status.State != "" {
+		t.Errorf("status = %+v", status)
+	}
+}
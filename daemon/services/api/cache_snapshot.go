@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// cacheSnapshotMaxAge is how old a snapshot may be and still be restored.
+// Older data would be misleading rather than merely stale, so the caches
+// start empty instead.
+const cacheSnapshotMaxAge = 10 * time.Minute
+
+// cacheSnapshot is the on-disk form of the caches, written on shutdown so
+// that a restarted agent answers with the last known state until each
+// collector has run again.
+type cacheSnapshot struct {
+	SavedAt time.Time                     `json:"saved_at"`
+	Caches  map[string]cacheSnapshotEntry `json:"caches"`
+}
+
+type cacheSnapshotEntry struct {
+	UpdatedAt time.Time       `json:"updated_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// SaveCacheSnapshot writes the last message of every populated cache to
+// path. Call it after the collectors have stopped so the snapshot holds
+// their final readings.
+func (s *Server) SaveCacheSnapshot(path string) error {
+	snap := cacheSnapshot{SavedAt: time.Now(), Caches: make(map[string]cacheSnapshotEntry)}
+	for _, b := range cacheBindings() {
+		msg, ok := s.CacheStore.lastEvents.Load(b.topicName)
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			logger.Debug("Cache: Skipping %s in snapshot: %v", b.topicName, err)
+			continue
+		}
+		entry := cacheSnapshotEntry{UpdatedAt: snap.SavedAt, Data: data}
+		if v, ok := s.CacheStore.updatedAt.Load(b.topicName); ok {
+			entry.UpdatedAt = v.(time.Time)
+		}
+		snap.Caches[b.topicName] = entry
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encoding cache snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("creating cache snapshot directory: %w", err)
+	}
+	// Write to a temporary file first so a crash mid-write leaves no
+	// truncated snapshot behind.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing cache snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("writing cache snapshot: %w", err)
+	}
+	logger.Info("Cache: Saved %d caches to %s", len(snap.Caches), path)
+	return nil
+}
+
+// RestoreCacheSnapshot fills the caches from a snapshot written by
+// SaveCacheSnapshot, keeping each cache's original update time so freshness
+// and ETags stay truthful. The snapshot is removed afterwards: it is only
+// meant to bridge one restart. It returns the number of caches restored.
+func (s *Server) RestoreCacheSnapshot(path string) (int, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is a constant chosen by the caller
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("reading cache snapshot: %w", err)
+	}
+	defer func() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logger.Warning("Cache: Failed to remove cache snapshot: %v", err)
+		}
+	}()
+
+	var snap cacheSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return 0, fmt.Errorf("parsing cache snapshot: %w", err)
+	}
+	if age := time.Since(snap.SavedAt); age > cacheSnapshotMaxAge || age < 0 {
+		logger.Info("Cache: Ignoring cache snapshot saved %s ago", age.Round(time.Second))
+		return 0, nil
+	}
+
+	restored := 0
+	for _, b := range cacheBindings() {
+		entry, ok := snap.Caches[b.topicName]
+		if !ok {
+			continue
+		}
+		ptr := reflect.New(b.msgType)
+		if err := json.Unmarshal(entry.Data, ptr.Interface()); err != nil {
+			logger.Debug("Cache: Skipping %s from snapshot: %v", b.topicName, err)
+			continue
+		}
+		msg := ptr.Elem().Interface()
+		b.update(s.CacheStore, msg)
+		s.CacheStore.markUpdated(b.topicName, entry.UpdatedAt)
+		s.CacheStore.lastEvents.Store(b.topicName, msg)
+		restored++
+	}
+	return restored, nil
+}
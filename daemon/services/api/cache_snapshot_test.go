@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestCacheSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache-snapshot.json")
+	updated := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	old, _ := setupTestServer()
+	system := &dto.SystemInfo{Hostname: "tower"}
+	old.systemCache.Store(system)
+	old.markUpdated(constants.TopicSystemUpdate.Name, updated)
+	old.lastEvents.Store(constants.TopicSystemUpdate.Name, system)
+	disks := []dto.DiskInfo{{ID: "disk1"}}
+	old.disksCache.Store(&disks)
+	old.markUpdated(constants.TopicDiskListUpdate.Name, updated)
+	old.lastEvents.Store(constants.TopicDiskListUpdate.Name, disks)
+
+	if err := old.SaveCacheSnapshot(path); err != nil {
+		t.Fatalf("SaveCacheSnapshot: %v", err)
+	}
+
+	restarted, _ := setupTestServer()
+	n, err := restarted.RestoreCacheSnapshot(path)
+	if err != nil {
+		t.Fatalf("RestoreCacheSnapshot: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("restored %d caches, want 2", n)
+	}
+	if got := restarted.systemCache.Load(); got == nil || got.Hostname != "tower" {
+		t.Errorf("system cache = %+v, want hostname tower", got)
+	}
+	if got := restarted.disksCache.Load(); got == nil || len(*got) != 1 || (*got)[0].ID != "disk1" {
+		t.Errorf("disks cache = %+v, want disk1", got)
+	}
+	if got := restarted.lastUpdated([]string{constants.TopicSystemUpdate.Name}); !got.Equal(updated) {
+		t.Errorf("system updated at %v, want the original %v", got, updated)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("snapshot should be removed after restoring it")
+	}
+}
+
+func TestRestoreCacheSnapshotIgnoresStaleOrMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache-snapshot.json")
+	server, _ := setupTestServer()
+
+	if n, err := server.RestoreCacheSnapshot(path); n != 0 || err != nil {
+		t.Errorf("missing snapshot restored %d caches (%v)", n, err)
+	}
+
+	data, err := json.Marshal(cacheSnapshot{
+		SavedAt: time.Now().Add(-cacheSnapshotMaxAge - time.Minute),
+		Caches: map[string]cacheSnapshotEntry{
+			constants.TopicSystemUpdate.Name: {UpdatedAt: time.Now(), Data: json.RawMessage(`{"hostname":"tower"}`)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := server.RestoreCacheSnapshot(path); n != 0 || err != nil {
+		t.Errorf("stale snapshot restored %d caches (%v)", n, err)
+	}
+	if server.systemCache.Load() != nil {
+		t.Error("stale snapshot should not fill the cache")
+	}
+}
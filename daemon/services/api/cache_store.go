@@ -1,6 +1,7 @@
 package api
 
 import (
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/platform"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/alerting"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/watchdog"
 )
 
 // networkServicesCacheTTL controls how often network services status is refreshed.
@@ -48,13 +50,37 @@ type CacheStore struct {
 	tuningCache          atomic.Pointer[dto.TuningInfo]
 	dockerUpdatesCache   atomic.Pointer[dto.ContainerUpdatesResult]
 	dockerNetworksCache  atomic.Pointer[dto.DockerNetworkList]
+	dockerStatsCache     atomic.Pointer[dto.DockerStatsSummary]
 	pluginUpdatesCache   atomic.Pointer[dto.PluginList]
 	osUpdateCache        atomic.Pointer[dto.OSUpdateStatus]
 	moverCache           atomic.Pointer[dto.MoverStatus]
 	parityHistoryCache   atomic.Pointer[dto.ParityCheckHistory]
+	dnsHealthCache       atomic.Pointer[dto.DNSHealth]
+	wanStatusCache       atomic.Pointer[dto.WANStatus]
+	speedtestCache       atomic.Pointer[dto.SpeedtestStatus]
+	poolsCache           atomic.Pointer[[]dto.PoolInfo]
+	btrfsCache           atomic.Pointer[[]dto.BtrfsFilesystem]
+	powerCache           atomic.Pointer[dto.PowerEstimate]
+	recycleBinCache      atomic.Pointer[dto.RecycleBinStatus]
+	ipmiCache            atomic.Pointer[dto.IPMIStatus]
+	connectCache         atomic.Pointer[dto.ConnectStatus]
+	guestTrafficCache    atomic.Pointer[dto.GuestTraffic]
+	remoteMountsCache    atomic.Pointer[dto.RemoteMountHealth]
+	diskSpinHistoryCache atomic.Pointer[dto.DiskSpinHistory]
+
+	// updatedAt holds the last update time per cache, keyed by topic name;
+	// it drives the ETag and Last-Modified headers of cache endpoints.
+	updatedAt sync.Map
+
+	// lastEvents holds the last message per cache, keyed by topic name, for
+	// the cache snapshot written on shutdown.
+	lastEvents sync.Map
 
 	// registry is the OS-resilience status registry (may be nil in tests).
 	registry *platform.Registry
+
+	// healthProbes supplies watchdog probe results linked to containers (nil until wired).
+	healthProbes atomic.Pointer[watchdog.Runner]
 }
 
 // DegradedSubsystemCount reports how many data sources are not healthy. It
@@ -143,14 +169,20 @@ func (c *CacheStore) GetSharesCache() []dto.ShareInfo {
 }
 
 // GetDockerCache returns cached Docker container information with update status
-// merged in from the docker_update collector's cache. The raw stored slice is
-// never mutated — a shallow copy is returned with update fields overlaid.
+// merged in from the docker_update collector's cache and linked watchdog probe
+// results. The raw stored slice is never mutated — a shallow copy is returned
+// with update and probe fields overlaid.
 func (c *CacheStore) GetDockerCache() []dto.ContainerInfo {
 	v := c.dockerCache.Load()
 	if v == nil {
 		return nil
 	}
 
+	var probes map[string][]dto.HealthCheckStatus
+	if runner := c.healthProbes.Load(); runner != nil {
+		probes = runner.GetContainerStatuses()
+	}
+
 	updates := map[string]dto.ContainerUpdateInfo{}
 	var checkedAt *time.Time
 	if u := c.dockerUpdatesCache.Load(); u != nil {
@@ -164,6 +196,7 @@ func (c *CacheStore) GetDockerCache() []dto.ContainerInfo {
 		}
 	}
 
+	traffic := c.guestInterfaces()
 	out := make([]dto.ContainerInfo, len(*v))
 	for i, ci := range *v {
 		if info, ok := updates[ci.ID]; ok {
@@ -181,11 +214,28 @@ func (c *CacheStore) GetDockerCache() []dto.ContainerInfo {
 			ci.UpdateAvailable = nil
 			ci.UpdateChecked = nil
 		}
+		ci.HealthProbes = containerProbes(probes, ci)
+		ci.HostInterfaces = traffic["container/"+ci.ID]
 		out[i] = ci
 	}
 	return out
 }
 
+// containerProbes returns the probe statuses linked to a container by ID or name.
+func containerProbes(probes map[string][]dto.HealthCheckStatus, ci dto.ContainerInfo) []dto.HealthCheckStatus {
+	if len(probes) == 0 {
+		return nil
+	}
+	result := probes[ci.ID]
+	if ci.Name != ci.ID {
+		result = append(result[:len(result):len(result)], probes[ci.Name]...)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
 // GetContainerUpdatesCache returns the cached container update result, or nil.
 func (c *CacheStore) GetContainerUpdatesCache() *dto.ContainerUpdatesResult {
 	return c.dockerUpdatesCache.Load()
@@ -196,6 +246,11 @@ func (c *CacheStore) GetDockerNetworksCache() *dto.DockerNetworkList {
 	return c.dockerNetworksCache.Load()
 }
 
+// GetDockerStatsSummaryCache returns the cached Docker stats summary, or nil.
+func (c *CacheStore) GetDockerStatsSummaryCache() *dto.DockerStatsSummary {
+	return c.dockerStatsCache.Load()
+}
+
 // GetPluginUpdatesCache returns the cached plugin update list, or nil.
 func (c *CacheStore) GetPluginUpdatesCache() *dto.PluginList {
 	return c.pluginUpdatesCache.Load()
@@ -211,14 +266,106 @@ func (c *CacheStore) GetMoverCache() *dto.MoverStatus {
 	return c.moverCache.Load()
 }
 
-// GetVMsCache returns cached VM information.
-func (c *CacheStore) GetVMsCache() []dto.VMInfo {
-	if v := c.vmsCache.Load(); v != nil {
+// GetDNSHealthCache returns the cached DNS health result, or nil.
+func (c *CacheStore) GetDNSHealthCache() *dto.DNSHealth {
+	return c.dnsHealthCache.Load()
+}
+
+// GetWANStatusCache returns the cached WAN connectivity status, or nil.
+func (c *CacheStore) GetWANStatusCache() *dto.WANStatus {
+	return c.wanStatusCache.Load()
+}
+
+// GetRemoteMountsCache returns the cached NFS/SMB mount health, or nil.
+func (c *CacheStore) GetRemoteMountsCache() *dto.RemoteMountHealth {
+	return c.remoteMountsCache.Load()
+}
+
+// GetSpeedtestCache returns the cached bandwidth test results, or nil.
+func (c *CacheStore) GetSpeedtestCache() *dto.SpeedtestStatus {
+	return c.speedtestCache.Load()
+}
+
+// GetPowerCache returns the latest power estimate, or nil.
+func (c *CacheStore) GetPowerCache() *dto.PowerEstimate {
+	return c.powerCache.Load()
+}
+
+// GetRecycleBinCache returns the cached recycle bin statistics, or nil.
+func (c *CacheStore) GetRecycleBinCache() *dto.RecycleBinStatus {
+	return c.recycleBinCache.Load()
+}
+
+// GetIPMICache returns the cached BMC sensors and chassis status, or nil.
+func (c *CacheStore) GetIPMICache() *dto.IPMIStatus {
+	return c.ipmiCache.Load()
+}
+
+// GetConnectCache returns the cached Unraid Connect status, or nil.
+func (c *CacheStore) GetConnectCache() *dto.ConnectStatus {
+	return c.connectCache.Load()
+}
+
+// GetDiskSpinHistoryCache returns the latest disk spin history, or nil when
+// no disk has changed spin state since the agent started.
+func (c *CacheStore) GetDiskSpinHistoryCache() *dto.DiskSpinHistory {
+	return c.diskSpinHistoryCache.Load()
+}
+
+// GetPoolsCache returns cached pool information.
+func (c *CacheStore) GetPoolsCache() []dto.PoolInfo {
+	if v := c.poolsCache.Load(); v != nil {
 		return *v
 	}
 	return nil
 }
 
+// GetBtrfsCache returns cached btrfs device error and scrub statistics.
+func (c *CacheStore) GetBtrfsCache() []dto.BtrfsFilesystem {
+	if v := c.btrfsCache.Load(); v != nil {
+		return *v
+	}
+	return nil
+}
+
+// GetVMsCache returns cached VM information, with the traffic of each VM's
+// host tap interfaces merged in from the guest traffic cache.
+func (c *CacheStore) GetVMsCache() []dto.VMInfo {
+	v := c.vmsCache.Load()
+	if v == nil {
+		return nil
+	}
+	traffic := c.guestInterfaces()
+	if len(traffic) == 0 {
+		return *v
+	}
+	out := make([]dto.VMInfo, len(*v))
+	for i, vm := range *v {
+		vm.Interfaces = traffic["vm/"+vm.Name]
+		for _, iface := range vm.Interfaces {
+			vm.NetworkRXBytesPerSec += iface.RXBytesPerSec
+			vm.NetworkTXBytesPerSec += iface.TXBytesPerSec
+		}
+		out[i] = vm
+	}
+	return out
+}
+
+// guestInterfaces groups the cached guest traffic by owner, keyed by
+// "vm/<name>" or "container/<short ID>".
+func (c *CacheStore) guestInterfaces() map[string][]dto.GuestInterfaceTraffic {
+	traffic := c.guestTrafficCache.Load()
+	if traffic == nil {
+		return nil
+	}
+	byOwner := make(map[string][]dto.GuestInterfaceTraffic, len(traffic.Interfaces))
+	for _, iface := range traffic.Interfaces {
+		key := iface.OwnerType + "/" + iface.Owner
+		byOwner[key] = append(byOwner[key], iface)
+	}
+	return byOwner
+}
+
 // GetGPUCache returns cached GPU metrics.
 func (c *CacheStore) GetGPUCache() []*dto.GPUMetrics {
 	if v := c.gpuCache.Load(); v != nil {
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/watchdog"
 )
 
 func TestGetDockerCacheMerge(t *testing.T) {
@@ -55,3 +59,78 @@ func TestGetDockerCacheMerge(t *testing.T) {
 		t.Error("sonarr UpdateChecked should be nil for unmatched container")
 	}
 }
+
+func TestGetDockerCacheHealthProbes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	store := watchdog.NewStore(t.TempDir())
+	if err := store.CreateCheck(dto.HealthCheck{
+		ID: "plex-web", Name: "Plex Web", Type: dto.HealthCheckHTTP,
+		Target: srv.URL, Container: "plex", Enabled: true,
+	}); err != nil {
+		t.Fatalf("CreateCheck: %v", err)
+	}
+	runner := watchdog.NewRunner(store)
+	if _, err := runner.RunSingleCheck(context.Background(), "plex-web"); err != nil {
+		t.Fatalf("RunSingleCheck: %v", err)
+	}
+
+	var cs CacheStore
+	containers := []dto.ContainerInfo{
+		{ID: "abc123", Name: "plex"},
+		{ID: "def456", Name: "sonarr"},
+	}
+	cs.dockerCache.Store(&containers)
+
+	// No runner wired yet → no probes.
+	if got := cs.GetDockerCache(); got[0].HealthProbes != nil {
+		t.Errorf("expected no probes before runner is wired, got %+v", got[0].HealthProbes)
+	}
+
+	cs.healthProbes.Store(runner)
+	got := cs.GetDockerCache()
+	if len(got[0].HealthProbes) != 1 {
+		t.Fatalf("plex probes = %d, want 1", len(got[0].HealthProbes))
+	}
+	if probe := got[0].HealthProbes[0]; probe.CheckID != "plex-web" || probe.Healthy {
+		t.Errorf("unexpected plex probe: %+v", probe)
+	}
+	if got[1].HealthProbes != nil {
+		t.Errorf("sonarr should have no probes, got %+v", got[1].HealthProbes)
+	}
+	if containers[0].HealthProbes != nil {
+		t.Error("raw stored slice was mutated")
+	}
+}
+
+func TestGuestTrafficMerge(t *testing.T) {
+	var cs CacheStore
+	vms := []dto.VMInfo{{Name: "Windows 11"}, {Name: "HomeAssistant"}}
+	containers := []dto.ContainerInfo{{ID: "abc123def456", Name: "plex"}}
+	cs.vmsCache.Store(&vms)
+	cs.dockerCache.Store(&containers)
+
+	if got := cs.GetVMsCache(); got[0].Interfaces != nil {
+		t.Errorf("no traffic cache: interfaces = %+v", got[0].Interfaces)
+	}
+
+	cs.guestTrafficCache.Store(&dto.GuestTraffic{Interfaces: []dto.GuestInterfaceTraffic{
+		{Interface: "vnet0", OwnerType: "vm", Owner: "Windows 11", RXBytesPerSec: 100, TXBytesPerSec: 10},
+		{Interface: "vnet1", OwnerType: "vm", Owner: "Windows 11", RXBytesPerSec: 50, TXBytesPerSec: 5},
+		{Interface: "veth1a2b", OwnerType: "container", Owner: "abc123def456", RXBytes: 700},
+	}})
+
+	gotVMs := cs.GetVMsCache()
+	if w := gotVMs[0]; len(w.Interfaces) != 2 || w.NetworkRXBytesPerSec != 150 || w.NetworkTXBytesPerSec != 15 {
+		t.Errorf("Windows 11 = %+v", w)
+	}
+	if gotVMs[1].Interfaces != nil || vms[0].Interfaces != nil {
+		t.Errorf("unexpected interfaces on HomeAssistant or the raw slice: %+v, %+v", gotVMs[1], vms[0])
+	}
+	if got := cs.GetDockerCache(); len(got[0].HostInterfaces) != 1 || got[0].HostInterfaces[0].RXBytes != 700 {
+		t.Errorf("plex host interfaces = %+v", got[0].HostInterfaces)
+	}
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// compressMinSize is the smallest body worth compressing. Shorter bodies
+// (most action responses) are sent as-is, where the encoding overhead would
+// outweigh the saving.
+const compressMinSize = 1024
+
+// brotliQuality trades ratio for CPU; 4 compresses better than gzip's default
+// level at a similar speed.
+const brotliQuality = 4
+
+var (
+	gzipWriters   = sync.Pool{New: func() any { return gzip.NewWriter(io.Discard) }}
+	brotliWriters = sync.Pool{New: func() any { return brotli.NewWriterLevel(io.Discard, brotliQuality) }}
+)
+
+// compressibleTypes are the media types compressed by compressMiddleware.
+// Everything else (downloads, profiles, images) is already compact or
+// compressed, and event streams must not be buffered.
+var compressibleTypes = map[string]bool{
+	"application/json":       true,
+	"application/javascript": true,
+	"application/xml":        true,
+	"application/yaml":       true,
+	"image/svg+xml":          true,
+	"text/css":               true,
+	"text/html":              true,
+	"text/javascript":        true,
+	"text/plain":             true,
+	"text/xml":               true,
+}
+
+// negotiateEncoding picks br or gzip from an Accept-Encoding header by
+// q-value, preferring br on a tie. It returns "" when neither is acceptable.
+func negotiateEncoding(accept string) string {
+	best, bestQ := "", 0.0
+	for part := range strings.SplitSeq(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "br" && name != "gzip" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ || (q == bestQ && name == "br") {
+			best, bestQ = name, q
+		}
+	}
+	if bestQ <= 0 {
+		return ""
+	}
+	return best
+}
+
+// compressMiddleware compresses REST responses with brotli or gzip when the
+// client accepts it, for remote dashboards on slow links. Responses that are
+// small, already encoded or not text-like pass through unchanged, as do
+// WebSocket upgrades, which negotiate permessage-deflate instead.
+func compressMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, status: http.StatusOK}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// compressWriter buffers the start of a response until it can tell whether
+// the body is worth compressing, then either compresses or passes through.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding    string
+	status      int
+	wroteHeader bool
+	decided     bool
+	buf         []byte
+	enc         io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.wroteHeader || cw.decided {
+		return
+	}
+	if code < http.StatusOK {
+		cw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = code
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		cw.passThrough()
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		if !cw.compressible() {
+			cw.passThrough()
+		} else {
+			cw.buf = append(cw.buf, p...)
+			if len(cw.buf) < compressMinSize {
+				return len(p), nil
+			}
+			if err := cw.startCompression(); err != nil {
+				return 0, err
+			}
+			return len(p), nil
+		}
+	}
+	if cw.enc != nil {
+		return cw.enc.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// compressible reports whether the response headers allow compression.
+func (cw *compressWriter) compressible() bool {
+	h := cw.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		return true // sniffed from the buffered body in startCompression
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	return err == nil && compressibleTypes[mediaType]
+}
+
+// passThrough sends the headers and any buffered body uncompressed.
+func (cw *compressWriter) passThrough() {
+	cw.decided = true
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if len(cw.buf) > 0 {
+		_, _ = cw.ResponseWriter.Write(cw.buf)
+		cw.buf = nil
+	}
+}
+
+// startCompression sends the headers with the chosen Content-Encoding and
+// writes the buffered body through the encoder.
+func (cw *compressWriter) startCompression() error {
+	cw.decided = true
+	h := cw.Header()
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", http.DetectContentType(cw.buf))
+		if !cw.compressible() {
+			cw.passThrough()
+			return nil
+		}
+	}
+	h.Set("Content-Encoding", cw.encoding)
+	h.Del("Content-Length")
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	switch cw.encoding {
+	case "br":
+		bw := brotliWriters.Get().(*brotli.Writer)
+		bw.Reset(cw.ResponseWriter)
+		cw.enc = bw
+	default:
+		gw := gzipWriters.Get().(*gzip.Writer)
+		gw.Reset(cw.ResponseWriter)
+		cw.enc = gw
+	}
+	_, err := cw.enc.Write(cw.buf)
+	cw.buf = nil
+	return err
+}
+
+// close finishes the response: short bodies are sent uncompressed and the
+// encoder is flushed and returned to its pool.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		cw.passThrough()
+		return
+	}
+	if cw.enc == nil {
+		return
+	}
+	_ = cw.enc.Close()
+	switch enc := cw.enc.(type) {
+	case *brotli.Writer:
+		brotliWriters.Put(enc)
+	case *gzip.Writer:
+		gzipWriters.Put(enc)
+	}
+	cw.enc = nil
+}
+
+// Flush sends everything written so far, compressing it if the response is
+// compressible even when it is still short, so streamed responses keep
+// flowing.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if cw.compressible() && len(cw.buf) > 0 {
+			_ = cw.startCompression()
+		} else {
+			cw.passThrough()
+		}
+	}
+	if f, ok := cw.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	_ = http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Hijack delegates to the underlying ResponseWriter so connection upgrades
+// keep working.
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hj, ok := cw.ResponseWriter.(http.Hijacker); ok {
+		return hj.Hijack()
+	}
+	return nil, nil, fmt.Errorf("underlying ResponseWriter does not implement http.Hijacker")
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"br;q=0.5, gzip", "gzip"},
+		{"br;q=0, gzip;q=0", ""},
+		{"GZIP;q=0.8, deflate", "gzip"},
+	}
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.accept); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestCompressMiddleware(t *testing.T) {
+	large := `{"data":"` + strings.Repeat("unraid ", 500) + `"}`
+	handler := compressMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, large)
+		case "/small":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"ok":true}`)
+		case "/binary":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = io.WriteString(w, large)
+		case "/encoded":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = io.WriteString(w, large)
+		}
+	}))
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Accept-Encoding", accept)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		rr := get("/large", "gzip")
+		if rr.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("Content-Encoding = %q", rr.Header().Get("Content-Encoding"))
+		}
+		zr, err := gzip.NewReader(rr.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(zr)
+		if string(body) != large {
+			t.Error("decompressed body mismatch")
+		}
+	})
+
+	t.Run("brotli", func(t *testing.T) {
+		rr := get("/large", "gzip, br")
+		if rr.Header().Get("Content-Encoding") != "br" {
+			t.Fatalf("Content-Encoding = %q", rr.Header().Get("Content-Encoding"))
+		}
+		if rr.Body.Len() >= len(large) {
+			t.Errorf("compressed size %d >= %d", rr.Body.Len(), len(large))
+		}
+		body, _ := io.ReadAll(brotli.NewReader(bytes.NewReader(rr.Body.Bytes())))
+		if string(body) != large {
+			t.Error("decompressed body mismatch")
+		}
+		if rr.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("Vary = %q", rr.Header().Get("Vary"))
+		}
+	})
+
+	passthrough := []struct{ name, path, accept string }{
+		{"no accept-encoding", "/large", ""},
+		{"small body", "/small", "gzip"},
+		{"binary", "/binary", "gzip"},
+		{"already encoded", "/encoded", "br"},
+	}
+	for _, tt := range passthrough {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := get(tt.path, tt.accept)
+			if tt.path == "/encoded" {
+				if rr.Header().Get("Content-Encoding") != "gzip" {
+					t.Errorf("Content-Encoding = %q", rr.Header().Get("Content-Encoding"))
+				}
+			} else if enc := rr.Header().Get("Content-Encoding"); enc != "" {
+				t.Errorf("Content-Encoding = %q, want none", enc)
+			}
+			if rr.Body.Len() == 0 {
+				t.Error("empty body")
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+)
+
+// cacheRoute lists the cache topics a GET endpoint is rendered from. The
+// response only changes when one of those caches is updated, so the newest
+// update time identifies the representation.
+type cacheRoute struct {
+	topics []string
+	// volatile reports whether the response currently depends on state that
+	// is not a cache (e.g. watchdog probes overlaid on containers); such
+	// responses are served without validators.
+	volatile func(*CacheStore) bool
+}
+
+// cacheRoutes maps route templates to the caches they are rendered from.
+// Only endpoints that read nothing but caches belong here.
+var cacheRoutes = func() map[string]cacheRoute {
+	on := func(topics ...string) cacheRoute { return cacheRoute{topics: topics} }
+	docker := cacheRoute{
+		topics:   []string{constants.TopicContainerListUpdate.Name, constants.TopicDockerUpdatesUpdate.Name, constants.TopicGuestTrafficUpdate.Name},
+		volatile: (*CacheStore).hasContainerProbes,
+	}
+	vms := on(constants.TopicVMListUpdate.Name, constants.TopicGuestTrafficUpdate.Name)
+	hardware := on(constants.TopicHardwareUpdate.Name)
+	notifications := on(constants.TopicNotificationsUpdate.Name)
+	unassigned := on(constants.TopicUnassignedDevicesUpdate.Name)
+
+	return map[string]cacheRoute{
+		"/api/v1/system":                   on(constants.TopicSystemUpdate.Name),
+		"/api/v1/array":                    on(constants.TopicArrayStatusUpdate.Name),
+		"/api/v1/disks":                    on(constants.TopicDiskListUpdate.Name),
+		"/api/v1/disks/{id}":               on(constants.TopicDiskListUpdate.Name),
+		"/api/v1/disks/spin-history":       on(constants.TopicDiskSpinHistoryUpdate.Name),
+		"/api/v1/shares":                   on(constants.TopicShareListUpdate.Name),
+		"/api/v1/docker":                   docker,
+		"/api/v1/docker/{id}":              docker,
+		"/api/v1/docker/networks":          on(constants.TopicDockerNetworksUpdate.Name),
+		"/api/v1/docker/groups":            on(constants.TopicContainerListUpdate.Name),
+		"/api/v1/docker/stats":             on(constants.TopicDockerStatsUpdate.Name),
+		"/api/v1/docker/stats/summary":     on(constants.TopicDockerStatsUpdate.Name),
+		"/api/v1/vm":                       vms,
+		"/api/v1/vm/{id}":                  vms,
+		"/api/v1/ups":                      on(constants.TopicUPSStatusUpdate.Name),
+		"/api/v1/nut":                      on(constants.TopicNUTStatusUpdate.Name),
+		"/api/v1/gpu":                      on(constants.TopicGPUMetricsUpdate.Name),
+		"/api/v1/network":                  on(constants.TopicNetworkListUpdate.Name),
+		"/api/v1/network/dns":              on(constants.TopicDNSHealthUpdate.Name),
+		"/api/v1/network/wan":              on(constants.TopicWANStatusUpdate.Name),
+		"/api/v1/network/mounts":           on(constants.TopicRemoteMountsUpdate.Name),
+		"/api/v1/network/speedtest":        on(constants.TopicSpeedtestUpdate.Name),
+		"/api/v1/power":                    on(constants.TopicPowerUpdate.Name),
+		"/api/v1/pools":                    on(constants.TopicPoolsUpdate.Name),
+		"/api/v1/pools/{name}":             on(constants.TopicPoolsUpdate.Name),
+		"/api/v1/btrfs":                    on(constants.TopicBtrfsUpdate.Name),
+		"/api/v1/zfs/pools":                on(constants.TopicZFSPoolsUpdate.Name),
+		"/api/v1/zfs/pools/{name}":         on(constants.TopicZFSPoolsUpdate.Name),
+		"/api/v1/zfs/datasets":             on(constants.TopicZFSDatasetsUpdate.Name),
+		"/api/v1/zfs/snapshots":            on(constants.TopicZFSSnapshotsUpdate.Name),
+		"/api/v1/zfs/arc":                  on(constants.TopicZFSARCStatsUpdate.Name),
+		"/api/v1/hardware/full":            hardware,
+		"/api/v1/hardware/bios":            hardware,
+		"/api/v1/hardware/baseboard":       hardware,
+		"/api/v1/hardware/cpu":             hardware,
+		"/api/v1/hardware/cache":           hardware,
+		"/api/v1/hardware/memory-array":    hardware,
+		"/api/v1/hardware/memory-devices":  hardware,
+		"/api/v1/mover":                    on(constants.TopicMoverUpdate.Name),
+		"/api/v1/recyclebin":               on(constants.TopicRecycleBinUpdate.Name),
+		"/api/v1/ipmi":                     on(constants.TopicIPMIUpdate.Name),
+		"/api/v1/connect":                  on(constants.TopicConnectUpdate.Name),
+		"/api/v1/registration":             on(constants.TopicRegistrationUpdate.Name),
+		"/api/v1/notifications":            notifications,
+		"/api/v1/notifications/unread":     notifications,
+		"/api/v1/notifications/archive":    notifications,
+		"/api/v1/unassigned":               unassigned,
+		"/api/v1/unassigned/devices":       unassigned,
+		"/api/v1/unassigned/remote-shares": unassigned,
+	}
+}()
+
+// markUpdated records that the cache fed by topic was updated at t.
+func (c *CacheStore) markUpdated(topic string, t time.Time) {
+	c.updatedAt.Store(topic, t)
+}
+
+// lastUpdated returns the newest update time of the caches fed by topics, or
+// the zero time if any of them has not been populated yet.
+func (c *CacheStore) lastUpdated(topics []string) time.Time {
+	var latest time.Time
+	for _, topic := range topics {
+		v, ok := c.updatedAt.Load(topic)
+		if !ok {
+			return time.Time{}
+		}
+		if t := v.(time.Time); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// hasContainerProbes reports whether watchdog probes are linked to any
+// container, making the Docker endpoints change outside cache updates.
+func (c *CacheStore) hasContainerProbes() bool {
+	runner := c.healthProbes.Load()
+	return runner != nil && len(runner.GetContainerStatuses()) > 0
+}
+
+// conditionalGetMiddleware adds ETag and Last-Modified headers to cache
+// endpoints, derived from the update time of the caches behind them, plus
+// the data's freshness (see freshnessWriter), and
+// answers If-None-Match / If-Modified-Since with 304 Not Modified when the
+// client already holds the current representation. Polling clients then only
+// download a body when the data changed.
+func (s *Server) conditionalGetMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		route := mux.CurrentRoute(r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		tmpl, err := route.GetPathTemplate()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cr, ok := cacheRoutes[tmpl]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// The first topic is the route's primary data; the others only
+		// enrich it
+		fresh := s.CacheFreshness(cr.topics[0])
+		if fresh.CollectedAt.IsZero() {
+			s.serveCacheRoute(w, r, next, cr, false)
+			return
+		}
+		fw := &freshnessWriter{ResponseWriter: w, fresh: fresh}
+		s.serveCacheRoute(fw, r, next, cr, fresh.Stale)
+		fw.finish()
+	})
+}
+
+// serveCacheRoute serves a cache endpoint with validators, or 304 Not
+// Modified when the client's copy is current.
+func (s *Server) serveCacheRoute(w http.ResponseWriter, r *http.Request, next http.Handler, cr cacheRoute, stale bool) {
+	if cr.volatile != nil && cr.volatile(s.CacheStore) {
+		next.ServeHTTP(w, r)
+		return
+	}
+	updated := s.CacheStore.lastUpdated(cr.topics)
+	if updated.IsZero() {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	etag := cacheETag(updated, stale)
+	if notModified(r, etag, updated) {
+		h := w.Header()
+		h.Set("ETag", etag)
+		h.Set("Last-Modified", updated.UTC().Format(http.TimeFormat))
+		h.Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	next.ServeHTTP(&validatorWriter{ResponseWriter: w, etag: etag, updated: updated}, r)
+}
+
+// cacheETag returns the weak entity tag for a cache update time. It is weak
+// because the body may be re-encoded (e.g. compressed) without changing.
+// Data turning stale changes the tag, as the response then reports it.
+func cacheETag(updated time.Time, stale bool) string {
+	tag := strconv.FormatInt(updated.UnixNano(), 36)
+	if stale {
+		tag += "-stale"
+	}
+	return `W/"` + tag + `"`
+}
+
+// notModified reports whether the request's validators match the current
+// representation. If-None-Match takes precedence over If-Modified-Since.
+func notModified(r *http.Request, etag string, updated time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for tag := range strings.SplitSeq(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		since, err := http.ParseTime(ims)
+		return err == nil && !updated.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// validatorWriter sets the validators on successful responses only, so error
+// bodies (e.g. 404 for an unknown disk) are never cached.
+type validatorWriter struct {
+	http.ResponseWriter
+	etag        string
+	updated     time.Time
+	wroteHeader bool
+}
+
+func (vw *validatorWriter) WriteHeader(code int) {
+	if !vw.wroteHeader {
+		vw.wroteHeader = true
+		if code == http.StatusOK {
+			h := vw.Header()
+			h.Set("ETag", vw.etag)
+			h.Set("Last-Modified", vw.updated.UTC().Format(http.TimeFormat))
+			h.Set("Cache-Control", "no-cache")
+		}
+	}
+	vw.ResponseWriter.WriteHeader(code)
+}
+
+func (vw *validatorWriter) Write(b []byte) (int, error) {
+	if !vw.wroteHeader {
+		vw.WriteHeader(http.StatusOK)
+	}
+	return vw.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (vw *validatorWriter) Unwrap() http.ResponseWriter {
+	return vw.ResponseWriter
+}
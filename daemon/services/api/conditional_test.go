@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestConditionalGet(t *testing.T) {
+	server, _ := setupTestServer()
+
+	get := func(url string, headers map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("GET", url, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// No validators before the cache has been populated.
+	if rr := get("/api/v1/array", nil); rr.Header().Get("ETag") != "" {
+		t.Errorf("ETag before first update: %q", rr.Header().Get("ETag"))
+	}
+
+	updated := time.Date(2026, 10, 17, 9, 30, 15, 500, time.UTC)
+	server.arrayCache.Store(&dto.ArrayStatus{State: "Started"})
+	server.markUpdated(constants.TopicArrayStatusUpdate.Name, updated)
+
+	rr := get("/api/v1/array", nil)
+	etag := rr.Header().Get("ETag")
+	if rr.Code != http.StatusOK || etag == "" || rr.Body.Len() == 0 {
+		t.Fatalf("status %d, ETag %q, body %d bytes", rr.Code, etag, rr.Body.Len())
+	}
+	if got := rr.Header().Get("Last-Modified"); got != "Sat, 17 Oct 2026 09:30:15 GMT" {
+		t.Errorf("Last-Modified = %q", got)
+	}
+
+	if rr := get("/api/v1/array", map[string]string{"If-None-Match": etag}); rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+		t.Errorf("If-None-Match: status %d, body %d bytes", rr.Code, rr.Body.Len())
+	}
+	if rr := get("/api/v1/array", map[string]string{"If-None-Match": `"other", ` + etag[2:]}); rr.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match list with strong tag: status %d", rr.Code)
+	}
+	if rr := get("/api/v1/array", map[string]string{"If-Modified-Since": "Sat, 17 Oct 2026 09:30:15 GMT"}); rr.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since: status %d", rr.Code)
+	}
+	if rr := get("/api/v1/array", map[string]string{"If-Modified-Since": "Sat, 17 Oct 2026 09:30:14 GMT"}); rr.Code != http.StatusOK {
+		t.Errorf("stale If-Modified-Since: status %d", rr.Code)
+	}
+
+	// A newer cache update invalidates the old tag.
+	server.markUpdated(constants.TopicArrayStatusUpdate.Name, updated.Add(time.Second))
+	if rr := get("/api/v1/array", map[string]string{"If-None-Match": etag}); rr.Code != http.StatusOK || rr.Header().Get("ETag") == etag {
+		t.Errorf("after update: status %d, ETag %q", rr.Code, rr.Header().Get("ETag"))
+	}
+
+	// Error responses carry no validators.
+	server.disksCache.Store(&[]dto.DiskInfo{{ID: "disk1"}})
+	server.markUpdated(constants.TopicDiskListUpdate.Name, updated)
+	if rr := get("/api/v1/disks/disk9", nil); rr.Code != http.StatusNotFound || rr.Header().Get("ETag") != "" {
+		t.Errorf("unknown disk: status %d, ETag %q", rr.Code, rr.Header().Get("ETag"))
+	}
+
+	// Endpoints not backed only by caches are untouched.
+	if rr := get("/api/v1/health", nil); rr.Header().Get("ETag") != "" {
+		t.Errorf("health ETag = %q", rr.Header().Get("ETag"))
+	}
+}
+
+func TestCacheRoutesAreRegistered(t *testing.T) {
+	server, _ := setupTestServer()
+	registered := map[string]bool{}
+	_ = server.router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			registered[tmpl] = true
+		}
+		return nil
+	})
+	for tmpl := range cacheRoutes {
+		if !registered[tmpl] {
+			t.Errorf("cacheRoutes entry %s is not a registered route", tmpl)
+		}
+	}
+}
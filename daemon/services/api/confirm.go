@@ -0,0 +1,296 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// confirmTokenTTL is how long a confirmation token can be redeemed.
+	confirmTokenTTL = 60 * time.Second
+
+	// maxPendingConfirmations caps unredeemed tokens; the oldest is dropped.
+	maxPendingConfirmations = 256
+
+	// maxListedWorkloads caps the names listed per consequence.
+	maxListedWorkloads = 5
+)
+
+// destructiveAction describes a REST action that needs a confirmation token.
+type destructiveAction struct {
+	// action and target identify what the token is valid for; params holds
+	// request parameters that change the outcome (e.g. the filesystem).
+	action, target, params string
+
+	summary      string
+	consequences []string
+	impact       *dto.ArrayStopImpact
+}
+
+func (a destructiveAction) key() string {
+	return a.action + "\x00" + a.target + "\x00" + a.params
+}
+
+type pendingConfirmation struct {
+	key     string
+	expires time.Time
+}
+
+// confirmationStore holds single-use confirmation tokens in memory.
+type confirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+	now     func() time.Time
+}
+
+func newConfirmationStore() *confirmationStore {
+	return &confirmationStore{pending: make(map[string]pendingConfirmation), now: time.Now}
+}
+
+// issue returns a new token for key and when it expires.
+func (c *confirmationStore) issue(key string) (string, time.Time) {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	token := hex.EncodeToString(b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	var oldest string
+	for t, p := range c.pending {
+		if now.After(p.expires) {
+			delete(c.pending, t)
+		} else if oldest == "" || p.expires.Before(c.pending[oldest].expires) {
+			oldest = t
+		}
+	}
+	if len(c.pending) >= maxPendingConfirmations {
+		delete(c.pending, oldest)
+	}
+	expires := now.Add(confirmTokenTTL)
+	c.pending[token] = pendingConfirmation{key: key, expires: expires}
+	return token, expires
+}
+
+// redeem consumes token and reports whether it was issued for key and has
+// not expired. A token is spent by any attempt, so it cannot be guessed
+// against other actions.
+func (c *confirmationStore) redeem(token, key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.pending[token]
+	delete(c.pending, token)
+	return ok && p.key == key && !c.now().After(p.expires)
+}
+
+// confirmDestructive enforces the two-step confirmation of a destructive
+// action when CONFIRM_DESTRUCTIVE is on. It returns true when the request
+// carries a valid token for a. Otherwise it writes a 428 challenge with a new
+// token, or 412 for an invalid or expired token, and returns false.
+func (s *Server) confirmDestructive(w http.ResponseWriter, r *http.Request, a destructiveAction) bool {
+	if !s.ctx.ConfirmDestructive {
+		return true
+	}
+
+	token := r.Header.Get(dto.ConfirmTokenHeader)
+	if token == "" {
+		token = r.URL.Query().Get("confirm_token")
+	}
+	if token != "" {
+		if s.confirmations.redeem(token, a.key()) {
+			logger.InfoContext(r.Context(), "API: %s confirmed by %s", a.action, r.RemoteAddr)
+			return true
+		}
+		respondWithError(w, http.StatusPreconditionFailed, "Invalid or expired confirmation token; repeat the request without it to get a new one")
+		return false
+	}
+
+	token, expires := s.confirmations.issue(a.key())
+	consequences := a.consequences
+	if consequences == nil {
+		consequences = []string{}
+	}
+	respondJSON(w, http.StatusPreconditionRequired, dto.ConfirmationChallenge{
+		Success:              false,
+		Message:              fmt.Sprintf("Confirmation required: repeat the request with the %s header within %d seconds", dto.ConfirmTokenHeader, int(confirmTokenTTL.Seconds())),
+		ConfirmationRequired: true,
+		ConfirmToken:         token,
+		Action:               a.action,
+		Target:               a.target,
+		Summary:              a.summary,
+		Consequences:         consequences,
+		Impact:               a.impact,
+		ExpiresIn:            int(confirmTokenTTL.Seconds()),
+		ExpiresAt:            expires,
+		Timestamp:            time.Now(),
+	})
+	return false
+}
+
+// workloadImpact reports what stopping the array interrupts, as consequence
+// lines and the full impact. Rebooting or shutting down stops the array too.
+func (s *Server) workloadImpact() ([]string, *dto.ArrayStopImpact) {
+	array := s.GetArrayCache()
+	impact := BuildArrayStopImpact(array, s.GetDockerCache(), s.GetSharesCache(), s.GetVMsCache(), time.Now())
+
+	var out []string
+	if len(impact.Containers) > 0 {
+		names := make([]string, len(impact.Containers))
+		for i, c := range impact.Containers {
+			names[i] = c.Name
+		}
+		out = append(out, fmt.Sprintf("%d running container(s) will be stopped: %s", len(names), listNames(names)))
+	}
+	if len(impact.VMs) > 0 {
+		names := make([]string, len(impact.VMs))
+		for i, vm := range impact.VMs {
+			names[i] = vm.Name
+		}
+		out = append(out, fmt.Sprintf("%d running or paused VM(s) will be shut down: %s", len(names), listNames(names)))
+	}
+	if len(impact.Shares) > 0 {
+		out = append(out, fmt.Sprintf("%d exported share(s) go offline for SMB/NFS clients: %s", len(impact.Shares), listNames(impact.Shares)))
+	}
+	if array != nil {
+		switch array.ParityCheckStatus {
+		case "running", "paused":
+			out = append(out, fmt.Sprintf("The %s parity check (%.1f%% done) will be cancelled", array.ParityCheckStatus, array.ParityCheckProgress))
+		}
+	}
+	return out, impact
+}
+
+func listNames(names []string) string {
+	if len(names) <= maxListedWorkloads {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxListedWorkloads], ", "), len(names)-maxListedWorkloads)
+}
+
+func (s *Server) rebootAction() destructiveAction {
+	consequences, impact := s.workloadImpact()
+	return destructiveAction{
+		action:  "system_reboot",
+		summary: "Reboot the server",
+		consequences: append(consequences,
+			"The server and all its services are unreachable until it has booted"),
+		impact: impact,
+	}
+}
+
+func (s *Server) shutdownAction(action string) destructiveAction {
+	consequences, impact := s.workloadImpact()
+	return destructiveAction{
+		action:  action,
+		summary: "Power off the server",
+		consequences: append(consequences,
+			"The server stays off until it is powered on locally, via IPMI, or with Wake-on-LAN"),
+		impact: impact,
+	}
+}
+
+func (s *Server) arrayStopAction() destructiveAction {
+	consequences, impact := s.workloadImpact()
+	return destructiveAction{
+		action:  "array_stop",
+		summary: "Stop the array",
+		consequences: append(consequences,
+			"User shares and array disks are unavailable until the array is started again"),
+		impact: impact,
+	}
+}
+
+func arrayUnlockAction() destructiveAction {
+	return destructiveAction{
+		action:  "array_unlock",
+		summary: "Unlock the encrypted devices and start the array",
+		consequences: []string{
+			"The array is started and its shares become available",
+			"Containers and VMs set to autostart are started",
+		},
+	}
+}
+
+// ipmiChassisPowerAction returns the confirmation of a chassis power command
+// that cuts power to the OS without warning it. soft asks the OS to shut
+// down over ACPI and only needs confirm=true.
+func (s *Server) ipmiChassisPowerAction(action string) (destructiveAction, bool) {
+	var summary, outcome string
+	switch action {
+	case "off":
+		summary = "Power off the server through the BMC"
+		outcome = "The server loses power immediately and stays off until it is powered on again"
+	case "cycle":
+		summary = "Power cycle the server through the BMC"
+		outcome = "The server loses power immediately and is powered on again a few seconds later"
+	case "reset":
+		summary = "Reset the server through the BMC"
+		outcome = "The server is reset immediately, like pressing its reset button"
+	default:
+		return destructiveAction{}, false
+	}
+	consequences, impact := s.workloadImpact()
+	return destructiveAction{
+		action:  "ipmi_chassis_power",
+		params:  action,
+		summary: summary,
+		consequences: append(consequences, outcome,
+			"The array is not stopped cleanly, so Unraid runs a parity check when it next starts"),
+		impact: impact,
+	}, true
+}
+
+// fleetRelayAction returns the confirmation of a relay command that reboots
+// or powers off a peer. The token is tied to the peer and the command.
+func fleetRelayAction(peer, command string) destructiveAction {
+	summary := fmt.Sprintf("Reboot fleet peer %s", peer)
+	outcome := fmt.Sprintf("%s and all its services are unreachable until it has booted", peer)
+	if command == "shutdown" {
+		summary = fmt.Sprintf("Power off fleet peer %s", peer)
+		outcome = fmt.Sprintf("%s stays off until it is powered on locally, via IPMI, or with Wake-on-LAN", peer)
+	}
+	return destructiveAction{
+		action:  "fleet_relay",
+		target:  peer,
+		params:  command,
+		summary: summary,
+		consequences: []string{
+			fmt.Sprintf("The command runs over SSH without stopping the array or the workloads on %s first", peer),
+			outcome,
+		},
+	}
+}
+
+func formatAction(device dto.UnassignedDevice, filesystem string) destructiveAction {
+	name := device.Device
+	if device.Model != "" {
+		name = fmt.Sprintf("%s (%s %s)", device.Device, device.Model, device.SerialNumber)
+	}
+	consequences := []string{fmt.Sprintf("All data on %s is erased and replaced by a single empty %s partition", strings.TrimSpace(name), filesystem)}
+	for _, p := range device.Partitions {
+		desc := fmt.Sprintf("Partition %d", p.PartitionNumber)
+		if p.FileSystem != "" {
+			desc += " (" + p.FileSystem
+			if p.Label != "" {
+				desc += ", label " + p.Label
+			}
+			desc += ")"
+		}
+		consequences = append(consequences, desc+" will be destroyed")
+	}
+	return destructiveAction{
+		action:       "unassigned_format",
+		target:       device.Device,
+		params:       filesystem,
+		summary:      fmt.Sprintf("Format %s as %s", device.Device, filesystem),
+		consequences: consequences,
+	}
+}
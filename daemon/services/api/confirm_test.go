@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func setupConfirmServer() *Server {
+	server, ctx := setupTestServer()
+	ctx.ConfirmDestructive = true
+	populateTestCaches(server)
+	return server
+}
+
+func formatRequest(filesystem, token string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/v1/unassigned/devices/sdd/format",
+		strings.NewReader(`{"filesystem":"`+filesystem+`","confirm":true}`))
+	if token != "" {
+		req.Header.Set(dto.ConfirmTokenHeader, token)
+	}
+	return req
+}
+
+func requestChallenge(t *testing.T, server *Server, req *http.Request) dto.ConfirmationChallenge {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusPreconditionRequired {
+		t.Fatalf("got %d %s, want 428", rr.Code, rr.Body.String())
+	}
+	var challenge dto.ConfirmationChallenge
+	if err := json.Unmarshal(rr.Body.Bytes(), &challenge); err != nil {
+		t.Fatal(err)
+	}
+	return challenge
+}
+
+func TestConfirmDestructive_Challenge(t *testing.T) {
+	server := setupConfirmServer()
+
+	challenge := requestChallenge(t, server, httptest.NewRequest("POST", "/api/v1/array/stop", nil))
+	if !challenge.ConfirmationRequired || challenge.ConfirmToken == "" {
+		t.Fatalf("challenge = %+v, want a token", challenge)
+	}
+	if challenge.Action != "array_stop" || challenge.ExpiresIn != 60 {
+		t.Errorf("action = %q, expires_in = %d", challenge.Action, challenge.ExpiresIn)
+	}
+	joined := strings.Join(challenge.Consequences, "\n")
+	if !strings.Contains(joined, "plex") {
+		t.Errorf("consequences %q should list the running container", joined)
+	}
+}
+
+func TestConfirmDestructive_FormatFlow(t *testing.T) {
+	server := setupConfirmServer()
+
+	challenge := requestChallenge(t, server, formatRequest("ntfs", ""))
+	if challenge.Target != "sdd" || !strings.Contains(challenge.Consequences[0], "Samsung 870 EVO") {
+		t.Errorf("challenge = %+v", challenge)
+	}
+
+	// The token passes the confirmation step; ntfs then fails in the controller.
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, formatRequest("ntfs", challenge.ConfirmToken))
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("confirmed call: got %d %s, want 500", rr.Code, rr.Body.String())
+	}
+
+	// Tokens are single use.
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, formatRequest("ntfs", challenge.ConfirmToken))
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("reused token: got %d, want 412", rr.Code)
+	}
+}
+
+func TestConfirmDestructive_TokenBoundToRequest(t *testing.T) {
+	server := setupConfirmServer()
+
+	tests := []struct {
+		name string
+		req  func(token string) *http.Request
+	}{
+		{"other filesystem", func(token string) *http.Request { return formatRequest("ntfs", token) }},
+		{"other action", func(token string) *http.Request {
+			req := httptest.NewRequest("POST", "/api/v1/array/stop", nil)
+			req.Header.Set(dto.ConfirmTokenHeader, token)
+			return req
+		}},
+		{"unknown token", func(string) *http.Request { return formatRequest("xfs", "bogus") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge := requestChallenge(t, server, formatRequest("xfs", ""))
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, tt.req(challenge.ConfirmToken))
+			if rr.Code != http.StatusPreconditionFailed {
+				t.Errorf("got %d %s, want 412", rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestConfirmDestructive_Disabled(t *testing.T) {
+	server, _ := setupTestServer()
+	populateTestCaches(server)
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, formatRequest("ntfs", ""))
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("got %d %s, want 500 from the controller", rr.Code, rr.Body.String())
+	}
+}
+
+func TestConfirmationStore(t *testing.T) {
+	store := newConfirmationStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	token, expires := store.issue("k")
+	if !expires.Equal(now.Add(confirmTokenTTL)) {
+		t.Errorf("expires = %v", expires)
+	}
+	now = now.Add(confirmTokenTTL + time.Second)
+	if store.redeem(token, "k") {
+		t.Error("expired token redeemed")
+	}
+
+	for range maxPendingConfirmations + 10 {
+		store.issue("k")
+	}
+	if n := len(store.pending); n != maxPendingConfirmations {
+		t.Errorf("pending = %d, want %d", n, maxPendingConfirmations)
+	}
+}
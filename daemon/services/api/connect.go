@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// handleConnect godoc
+//
+//	@Summary		Get Unraid Connect status
+//	@Description	Returns the Unraid Connect plugin state: whether an account is signed in, the cloud connection status, flash backup activation and last sync, and the remote access mode. installed is false when the plugin is not installed. API keys and tokens are never returned.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.ConnectStatus	"Unraid Connect status"
+//	@Router			/connect [get]
+func (s *Server) handleConnect(w http.ResponseWriter, _ *http.Request) {
+	if cached := s.GetConnectCache(); cached != nil {
+		respondJSON(w, http.StatusOK, cached)
+		return
+	}
+	respondJSON(w, http.StatusOK, &dto.ConnectStatus{
+		RemoteAccess: dto.ConnectRemoteAccess{Type: "disabled"},
+		Timestamp:    time.Now(),
+	})
+}
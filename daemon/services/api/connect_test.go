@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestHandleConnect(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/connect", nil))
+	var status dto.ConnectStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("empty cache: status %d, error %v", rr.Code, err)
+	}
+	if status.Installed || status.RemoteAccess.Type != "disabled" {
+		t.Errorf("empty cache: status = %+v", status)
+	}
+
+	server.connectCache.Store(&dto.ConnectStatus{
+		Installed:      true,
+		SignedIn:       true,
+		CloudStatus:    "CONNECTED",
+		CloudConnected: true,
+		RemoteAccess:   dto.ConnectRemoteAccess{Enabled: true, Type: "static", WANPort: 33443},
+	})
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/connect", nil))
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.CloudConnected || status.RemoteAccess.WANPort != 33443 {
+		t.Errorf("cached status = %+v", status)
+	}
+}
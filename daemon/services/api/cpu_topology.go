@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// respondCPUPinningError maps CPU pinning controller errors to HTTP statuses.
+func respondCPUPinningError(w http.ResponseWriter, err error) {
+	if errors.Is(err, controllers.ErrInvalidCPUPinning) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondWithError(w, http.StatusInternalServerError, err.Error())
+}
+
+// handleCPUTopology godoc
+//
+//	@Summary		Get CPU topology and pinning
+//	@Description	Get the host's logical CPUs with their core, package and hyper-thread siblings, the CPUs isolated with the isolcpus kernel parameter, the vCPU and emulator pinning of every VM and the cpuset of every container. Each CPU lists the VMs and containers pinned to it. VMs or containers that cannot be read (libvirt or Docker not running) are reported in warnings.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.CPUTopology	"CPU topology"
+//	@Failure		500	{object}	dto.Response	"Failed to read the CPU topology"
+//	@Router			/system/cpu-topology [get]
+func (s *Server) handleCPUTopology(w http.ResponseWriter, r *http.Request) {
+	topo, err := controllers.GetCPUTopology()
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to read CPU topology: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, topo)
+}
+
+// handleVMCPUPinning godoc
+//
+//	@Summary		Set VM vCPU pinning
+//	@Description	Replace the vCPU pinning of a VM. The VM definition is updated and a running VM is re-pinned immediately; vCPUs that are not listed are unpinned. emulator_cpuset optionally pins the QEMU emulator threads. Requires confirm=true.
+//	@Tags			VMs
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string					true	"VM name"
+//	@Param			request	body		dto.VMCPUPinningRequest	true	"vCPU pinning"
+//	@Success		200		{object}	dto.CPUPinningResult	"Pinning updated"
+//	@Failure		400		{object}	dto.Response			"Invalid request, unknown CPU or vCPU, or not confirmed"
+//	@Failure		500		{object}	dto.Response			"Failed to update the pinning"
+//	@Router			/vm/{name}/cpu-pinning [put]
+func (s *Server) handleVMCPUPinning(w http.ResponseWriter, r *http.Request) {
+	vmName := mux.Vars(r)["name"]
+	if err := lib.ValidateVMName(vmName); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req dto.VMCPUPinningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if !req.Confirm {
+		respondWithError(w, http.StatusBadRequest, "set confirm to true to change the VM's CPU pinning")
+		return
+	}
+
+	result, err := controllers.NewVMController().SetCPUPinning(vmName, req)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to set CPU pinning of VM %s: %v", vmName, err)
+		respondCPUPinningError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// handleDockerCPUSet godoc
+//
+//	@Summary		Set container cpuset
+//	@Description	Change the CPUs a container may run on (docker update --cpuset-cpus). A running container is re-pinned immediately, and the cpuset is saved in the container's Unraid template so it survives the container being recreated; persisted is false when the container has no template. An empty cpuset allows every CPU. Requires confirm=true.
+//	@Tags			Docker
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Container ID or name"
+//	@Param			request	body		dto.ContainerCPUSetRequest	true	"cpuset"
+//	@Success		200		{object}	dto.CPUPinningResult		"cpuset updated"
+//	@Failure		400		{object}	dto.Response				"Invalid request, unknown CPU, or not confirmed"
+//	@Failure		500		{object}	dto.Response				"Failed to update the cpuset"
+//	@Router			/docker/{id}/cpuset [put]
+func (s *Server) handleDockerCPUSet(w http.ResponseWriter, r *http.Request) {
+	containerRef := mux.Vars(r)["id"]
+	if err := lib.ValidateContainerRef(containerRef); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var req dto.ContainerCPUSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if !req.Confirm {
+		respondWithError(w, http.StatusBadRequest, "set confirm to true to change the container's cpuset")
+		return
+	}
+
+	controller := controllers.NewDockerController()
+	defer controller.Close() //nolint:errcheck
+
+	result, err := controller.SetCPUSet(containerRef, req)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to set cpuset of container %s: %v", containerRef, err)
+		respondCPUPinningError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCPUPinningEndpointsValidation(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name, method, path, body string
+	}{
+		{"invalid vm name", "PUT", "/api/v1/vm/-bad/cpu-pinning", `{"confirm":true}`},
+		{"vm invalid json", "PUT", "/api/v1/vm/win11/cpu-pinning", `{`},
+		{"vm not confirmed", "PUT", "/api/v1/vm/win11/cpu-pinning", `{"pins":[{"vcpu":0,"cpuset":"4"}]}`},
+		{"invalid container", "PUT", "/api/v1/docker/bad;id/cpuset", `{"cpuset":"2","confirm":true}`},
+		{"container invalid json", "PUT", "/api/v1/docker/plex/cpuset", `{`},
+		{"container not confirmed", "PUT", "/api/v1/docker/plex/cpuset", `{"cpuset":"2-3"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("status %d, want %d: %s", rr.Code, http.StatusBadRequest, rr.Body)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package api
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
@@ -65,7 +67,7 @@ func (s *Server) handleDiagnosticsBundle(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		// Full detail to the log; a generic message to the client (the raw error
 		// can contain internal paths).
-		logger.Error("Diagnostics bundle collection failed: %v", err)
+		logger.ErrorContext(r.Context(), "Diagnostics bundle collection failed: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to collect diagnostics — check the agent log",
@@ -78,7 +80,7 @@ func (s *Server) handleDiagnosticsBundle(w http.ResponseWriter, r *http.Request)
 	// a truncated download — the bundle is small (logs are capped to last-N lines).
 	var buf bytes.Buffer
 	if err := diagnostics.WriteArchive(&buf, bundle); err != nil {
-		logger.Error("Diagnostics bundle archiving failed: %v", err)
+		logger.ErrorContext(r.Context(), "Diagnostics bundle archiving failed: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to build diagnostics archive — check the agent log",
@@ -94,6 +96,92 @@ func (s *Server) handleDiagnosticsBundle(w http.ResponseWriter, r *http.Request)
 	// The bundle contains host diagnostics — keep browsers/proxies from caching it.
 	w.Header().Set("Cache-Control", "no-store")
 	if _, err := w.Write(buf.Bytes()); err != nil {
-		logger.Warning("Diagnostics bundle write to client failed: %v", err)
+		logger.WarningContext(r.Context(), "Diagnostics bundle write to client failed: %v", err)
 	}
 }
+
+// respondDiagnosticsError maps a network diagnostics validation error to 403
+// (target not in DIAGNOSTICS_TARGETS) or 400 (invalid input).
+func respondDiagnosticsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, diagnostics.ErrTargetNotAllowed) {
+		respondWithError(w, http.StatusForbidden, err.Error()+" (configure DIAGNOSTICS_TARGETS)")
+		return
+	}
+	respondWithError(w, http.StatusBadRequest, err.Error())
+}
+
+// handleDiagnosticsPing godoc
+//
+//	@Summary		Ping a host from the server
+//	@Description	Send ICMP echo requests from the Unraid server to an allow-listed host (DIAGNOSTICS_TARGETS) and report packet loss and round-trip times. An unreachable host is reported in the result, not as an HTTP error.
+//	@Tags			Diagnostics
+//	@Produce		json
+//	@Param			host	query		string			true	"Hostname or IP address"
+//	@Param			count	query		int				false	"Echo requests to send (1-20, default 4)"
+//	@Success		200		{object}	dto.PingResult	"Ping result"
+//	@Failure		400		{object}	dto.Response	"Invalid host or count"
+//	@Failure		403		{object}	dto.Response	"Host not in the diagnostics allow list"
+//	@Router			/diagnostics/ping [get]
+func (s *Server) handleDiagnosticsPing(w http.ResponseWriter, r *http.Request) {
+	count := 0
+	if v := r.URL.Query().Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid count %q: must be an integer", v))
+			return
+		}
+		count = n
+	}
+
+	result, err := diagnostics.NewNetworkDiagnostics(s.ctx.DiagnosticsTargets).
+		Ping(r.Context(), r.URL.Query().Get("host"), count)
+	if err != nil {
+		respondDiagnosticsError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// handleDiagnosticsDNS godoc
+//
+//	@Summary		Resolve a hostname from the server
+//	@Description	Look up DNS records for an allow-listed host (DIAGNOSTICS_TARGETS) using the Unraid server's resolver. A failed lookup is reported in the result, not as an HTTP error.
+//	@Tags			Diagnostics
+//	@Produce		json
+//	@Param			host	query		string					true	"Hostname"
+//	@Param			type	query		string					false	"Record type: A (default), AAAA, CNAME, MX, TXT, NS"
+//	@Success		200		{object}	dto.DNSLookupResult	"Lookup result"
+//	@Failure		400		{object}	dto.Response			"Invalid host or record type"
+//	@Failure		403		{object}	dto.Response			"Host not in the diagnostics allow list"
+//	@Router			/diagnostics/dns [get]
+func (s *Server) handleDiagnosticsDNS(w http.ResponseWriter, r *http.Request) {
+	result, err := diagnostics.NewNetworkDiagnostics(s.ctx.DiagnosticsTargets).
+		LookupDNS(r.Context(), r.URL.Query().Get("host"), r.URL.Query().Get("type"))
+	if err != nil {
+		respondDiagnosticsError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// handleDiagnosticsHTTP godoc
+//
+//	@Summary		Request a URL from the server
+//	@Description	Fetch an http(s) URL on an allow-listed host (DIAGNOSTICS_TARGETS) from the Unraid server and report the status code with a DNS/connect/TLS/first-byte timing breakdown, like curl -w. Redirects are only followed to allow-listed hosts. A failed request is reported in the result, not as an HTTP error.
+//	@Tags			Diagnostics
+//	@Produce		json
+//	@Param			url		query		string					true	"http:// or https:// URL"
+//	@Param			method	query		string					false	"GET (default) or HEAD"
+//	@Success		200		{object}	dto.HTTPCheckResult	"Request result"
+//	@Failure		400		{object}	dto.Response			"Invalid URL or method"
+//	@Failure		403		{object}	dto.Response			"Host not in the diagnostics allow list"
+//	@Router			/diagnostics/http [get]
+func (s *Server) handleDiagnosticsHTTP(w http.ResponseWriter, r *http.Request) {
+	result, err := diagnostics.NewNetworkDiagnostics(s.ctx.DiagnosticsTargets).
+		CheckHTTP(r.Context(), r.URL.Query().Get("url"), r.URL.Query().Get("method"))
+	if err != nil {
+		respondDiagnosticsError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
@@ -45,3 +45,47 @@ func TestSelfTestEndpointNilRegistry(t *testing.T) {
 		t.Fatalf("status = %d, want 200", rec.Code)
 	}
 }
+
+func TestNetworkDiagnosticsEndpoints(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := &Server{ctx: &domain.Context{DiagnosticsTargets: []string{"127.0.0.1", "github.com"}}}
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		query    string
+		wantCode int
+	}{
+		{"ping not allowed", s.handleDiagnosticsPing, "host=example.com", http.StatusForbidden},
+		{"ping invalid count", s.handleDiagnosticsPing, "host=github.com&count=abc", http.StatusBadRequest},
+		{"ping count too high", s.handleDiagnosticsPing, "host=github.com&count=500", http.StatusBadRequest},
+		{"ping missing host", s.handleDiagnosticsPing, "", http.StatusBadRequest},
+		{"dns not allowed", s.handleDiagnosticsDNS, "host=example.com", http.StatusForbidden},
+		{"dns bad type", s.handleDiagnosticsDNS, "host=github.com&type=SRV", http.StatusBadRequest},
+		{"http not allowed", s.handleDiagnosticsHTTP, "url=https://example.com/", http.StatusForbidden},
+		{"http bad scheme", s.handleDiagnosticsHTTP, "url=file:///etc/passwd", http.StatusBadRequest},
+		{"http allowed", s.handleDiagnosticsHTTP, "url=" + srv.URL + "/", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/diagnostics?"+tt.query, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d (%s), want %d", rec.Code, rec.Body.String(), tt.wantCode)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleDiagnosticsHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/diagnostics/http?url="+srv.URL+"/", nil))
+	var result dto.HTTPCheckResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if result.StatusCode != http.StatusNoContent {
+		t.Errorf("status_code = %d, want 204", result.StatusCode)
+	}
+}
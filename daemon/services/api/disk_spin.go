@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// handleDiskSpinHistory godoc
+//
+//	@Summary		Get disk spin state history
+//	@Description	Returns the current spin state of every array and pool disk with the number of spin-ups and spin-downs in the last 24 hours, and the recent spin transitions (last 500). Spin-ups list the processes that were accessing the disk when the spin-up was seen: files held open on the disk or on a user share path stored on it, plus file accesses reported by fatrace (NerdTools) in the following 5 seconds when it is installed. Attribution is best effort: the access that woke the disk may already have finished. New transitions are broadcast on the WebSocket topic disk_spin_event.
+//	@Tags			Disks
+//	@Produce		json
+//	@Param			disk	query		string				false	"Only this disk (ID or name, e.g. disk3)"
+//	@Success		200		{object}	dto.DiskSpinHistory	"Spin state history"
+//	@Router			/disks/spin-history [get]
+func (s *Server) handleDiskSpinHistory(w http.ResponseWriter, r *http.Request) {
+	history := s.GetDiskSpinHistoryCache()
+	if history == nil {
+		history = &dto.DiskSpinHistory{
+			Disks:     []dto.DiskSpinStats{},
+			Events:    []dto.DiskSpinEvent{},
+			Timestamp: time.Now(),
+		}
+	}
+	if disk := r.URL.Query().Get("disk"); disk != "" {
+		history = history.ForDisk(disk)
+	}
+	respondJSON(w, http.StatusOK, history)
+}
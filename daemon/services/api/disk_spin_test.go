@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestHandleDiskSpinHistory(t *testing.T) {
+	server, _ := setupTestServer()
+
+	get := func(url string) dto.DiskSpinHistory {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", url, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d", url, rr.Code)
+		}
+		var history dto.DiskSpinHistory
+		if err := json.Unmarshal(rr.Body.Bytes(), &history); err != nil {
+			t.Fatal(err)
+		}
+		return history
+	}
+
+	if h := get("/api/v1/disks/spin-history"); h.Disks == nil || h.Events == nil || len(h.Disks) != 0 {
+		t.Errorf("empty cache: %+v", h)
+	}
+
+	server.diskSpinHistoryCache.Store(&dto.DiskSpinHistory{
+		WindowSeconds: 86400,
+		Disks: []dto.DiskSpinStats{
+			{Disk: "disk1", Name: "Disk 1", State: "standby"},
+			{Disk: "disk3", Name: "Disk 3", State: "active", SpinUps: 1},
+		},
+		Events: []dto.DiskSpinEvent{
+			{Disk: "disk1", Name: "Disk 1", State: "standby", PreviousState: "active"},
+			{Disk: "disk3", Name: "Disk 3", State: "active", PreviousState: "standby",
+				Causes: []dto.DiskSpinUpCause{{Process: "smbd", PID: 2211, Path: "/mnt/disk3/a", Source: dto.SpinUpCauseFatrace}}},
+		},
+	})
+
+	if h := get("/api/v1/disks/spin-history"); len(h.Disks) != 2 || len(h.Events) != 2 {
+		t.Errorf("unfiltered: %+v", h)
+	}
+	h := get("/api/v1/disks/spin-history?disk=DISK3")
+	if len(h.Disks) != 1 || h.Disks[0].Disk != "disk3" || len(h.Events) != 1 || h.Events[0].Causes[0].Process != "smbd" {
+		t.Errorf("filtered: %+v", h)
+	}
+	if h.WindowSeconds != 86400 {
+		t.Errorf("WindowSeconds = %d", h.WindowSeconds)
+	}
+}
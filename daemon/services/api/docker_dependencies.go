@@ -0,0 +1,236 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// arrayDiskDir matches the mount directory of an array data disk.
+var arrayDiskDir = regexp.MustCompile(`^disk\d+$`)
+
+// handleDockerDependencies godoc
+//
+//	@Summary		Get the container-to-share dependency map
+//	@Description	Maps every container's bind mounts to the user shares, pools, array disks, Unassigned Devices or remote mounts they reside on, and each share and storage location back to the containers using it. User share paths follow the share's primary and secondary storage (a pool and/or the array). requires_array marks data that is unavailable while the array is stopped.
+//	@Tags			Docker
+//	@Produce		json
+//	@Success		200	{object}	dto.DockerDependencies	"Dependency map"
+//	@Router			/docker/dependencies [get]
+func (s *Server) handleDockerDependencies(w http.ResponseWriter, _ *http.Request) {
+	respondJSON(w, http.StatusOK, BuildDockerDependencies(s.GetDockerCache(), s.GetSharesCache(), time.Now()))
+}
+
+// BuildDockerDependencies maps containers to the shares and storage their
+// volumes reside on. Keeping inputs as plain values makes the function
+// unit-testable without a running Server.
+func BuildDockerDependencies(containers []dto.ContainerInfo, shares []dto.ShareInfo, now time.Time) *dto.DockerDependencies {
+	byName := make(map[string]dto.ShareInfo, len(shares))
+	for _, sh := range shares {
+		byName[sh.Name] = sh
+	}
+
+	deps := &dto.DockerDependencies{
+		Containers: make([]dto.ContainerDependencies, 0, len(containers)),
+		Shares:     []dto.ShareDependents{},
+		Storage:    []dto.StorageDependents{},
+		Timestamp:  now,
+	}
+	shareUsers := map[string][]string{}
+	storageUsers := map[string][]string{}
+
+	for _, c := range containers {
+		cd := dto.ContainerDependencies{
+			ID:      c.ID,
+			Name:    c.Name,
+			State:   c.State,
+			Shares:  []string{},
+			Storage: []string{},
+			Volumes: make([]dto.VolumeDependency, 0, len(c.VolumeMappings)),
+		}
+		for _, v := range c.VolumeMappings {
+			vd := classifyVolume(v, byName)
+			cd.Volumes = append(cd.Volumes, vd)
+			cd.RequiresArray = cd.RequiresArray || vd.RequiresArray
+			if vd.Share != "" && !slices.Contains(cd.Shares, vd.Share) {
+				cd.Shares = append(cd.Shares, vd.Share)
+				shareUsers[vd.Share] = append(shareUsers[vd.Share], c.Name)
+			}
+			for _, st := range vd.Storage {
+				if !slices.Contains(cd.Storage, st) {
+					cd.Storage = append(cd.Storage, st)
+					storageUsers[st] = append(storageUsers[st], c.Name)
+				}
+			}
+		}
+		deps.Containers = append(deps.Containers, cd)
+	}
+
+	for _, name := range sortedKeys(shareUsers) {
+		deps.Shares = append(deps.Shares, dto.ShareDependents{Share: name, Containers: shareUsers[name]})
+	}
+	for _, name := range sortedKeys(storageUsers) {
+		deps.Storage = append(deps.Storage, dto.StorageDependents{Storage: name, Containers: storageUsers[name]})
+	}
+	return deps
+}
+
+// BuildArrayStopImpact lists the running containers and VMs and the exported
+// shares that stop or go offline when the array is stopped.
+func BuildArrayStopImpact(array *dto.ArrayStatus, containers []dto.ContainerInfo, shares []dto.ShareInfo, vms []dto.VMInfo, now time.Time) *dto.ArrayStopImpact {
+	impact := &dto.ArrayStopImpact{
+		Containers: []dto.ContainerDependencies{},
+		VMs:        []dto.VMArrayDependency{},
+		Shares:     []string{},
+		Notes:      []string{},
+		Timestamp:  now,
+	}
+	if array != nil {
+		impact.ArrayState = array.State
+	}
+	if impact.ArrayState != "" && impact.ArrayState != "Started" {
+		impact.Notes = append(impact.Notes, fmt.Sprintf("The array is not started (state %q); stopping it has no further effect.", impact.ArrayState))
+		return impact
+	}
+
+	byName := make(map[string]dto.ShareInfo, len(shares))
+	for _, sh := range shares {
+		byName[sh.Name] = sh
+	}
+
+	for _, cd := range BuildDockerDependencies(containers, shares, now).Containers {
+		if cd.State == "running" {
+			impact.Containers = append(impact.Containers, cd)
+		}
+	}
+	for _, vm := range vms {
+		if vm.State != "running" && vm.State != "paused" {
+			continue
+		}
+		dep := dto.VMArrayDependency{Name: vm.Name, State: vm.State, DiskPath: vm.DiskPath}
+		if vm.DiskPath != "" {
+			dep.RequiresArray = classifyVolume(dto.VolumeMapping{HostPath: vm.DiskPath}, byName).RequiresArray
+		}
+		impact.VMs = append(impact.VMs, dep)
+	}
+	for _, sh := range shares {
+		if sh.SMBExport || sh.NFSExport {
+			impact.Shares = append(impact.Shares, sh.Name)
+		}
+	}
+
+	if n := len(impact.Containers); n > 0 {
+		withData := 0
+		for _, cd := range impact.Containers {
+			if cd.RequiresArray {
+				withData++
+			}
+		}
+		impact.Notes = append(impact.Notes, fmt.Sprintf(
+			"Unraid stops the Docker service with the array: %d running container(s) will stop, %d of them with data on the array or a pool. They restart with the array only if autostart is enabled.", n, withData))
+	}
+	if n := len(impact.VMs); n > 0 {
+		impact.Notes = append(impact.Notes, fmt.Sprintf(
+			"The VM service stops with the array: %d running or paused VM(s) will be shut down (or hibernated, per the VM settings).", n))
+	}
+	if n := len(impact.Shares); n > 0 {
+		impact.Notes = append(impact.Notes, fmt.Sprintf("%d exported share(s) go offline for SMB/NFS clients.", n))
+	}
+	if len(impact.Notes) == 0 {
+		impact.Notes = append(impact.Notes, "No running containers, VMs or exported shares depend on the array.")
+	}
+	return impact
+}
+
+// classifyVolume works out where a bind mount's host path resides.
+func classifyVolume(v dto.VolumeMapping, shares map[string]dto.ShareInfo) dto.VolumeDependency {
+	vd := dto.VolumeDependency{
+		HostPath:      v.HostPath,
+		ContainerPath: v.ContainerPath,
+		Mode:          v.Mode,
+		Kind:          dto.VolumeKindSystem,
+	}
+
+	p := path.Clean(v.HostPath)
+	if p == "/boot" || strings.HasPrefix(p, "/boot/") {
+		vd.Kind = dto.VolumeKindFlash
+		return vd
+	}
+	rest, ok := strings.CutPrefix(p, "/mnt/")
+	if !ok {
+		return vd
+	}
+	top, sub, _ := strings.Cut(rest, "/")
+	share, _, _ := strings.Cut(sub, "/")
+
+	switch {
+	case top == "user" || top == "user0":
+		if share == "" {
+			// The whole /mnt/user tree: every share and all storage.
+			vd.Kind, vd.Storage, vd.RequiresArray = dto.VolumeKindShare, []string{dto.StorageArray}, true
+			return vd
+		}
+		vd.Kind, vd.Share, vd.RequiresArray = dto.VolumeKindShare, share, true
+		if top == "user0" {
+			vd.Storage = []string{dto.StorageArray}
+		} else {
+			vd.Storage = shareStorage(shares[share])
+		}
+	case arrayDiskDir.MatchString(top):
+		vd.Kind, vd.Share, vd.Storage, vd.RequiresArray = dto.VolumeKindDisk, share, []string{top}, true
+	case top == "disks":
+		vd.Kind = dto.VolumeKindUnassigned
+	case top == "remotes" || top == "rootshare":
+		vd.Kind = dto.VolumeKindRemote
+	case top == "addons" || top == "":
+		// Unraid internals; not array storage.
+	default:
+		// Any other directory under /mnt is a pool mount point.
+		vd.Kind, vd.Share, vd.Storage, vd.RequiresArray = dto.VolumeKindPool, share, []string{top}, true
+	}
+	return vd
+}
+
+// shareStorage returns where a user share's files can be: its primary pool
+// and/or the array, and the secondary storage the mover targets.
+func shareStorage(sh dto.ShareInfo) []string {
+	pool := sh.CachePool
+	if pool == "" {
+		pool = "cache"
+	}
+	secondary := dto.StorageArray
+	if sh.CachePool2 != "" {
+		secondary = sh.CachePool2
+	}
+	switch sh.UseCache {
+	case "only":
+		return []string{pool}
+	case "no":
+		return []string{dto.StorageArray}
+	case "yes", "prefer":
+		return []string{pool, secondary}
+	}
+	switch sh.Storage {
+	case "cache":
+		return []string{pool}
+	case "cache+array":
+		return []string{pool, secondary}
+	}
+	// Unknown share (not in the cache yet) or array only.
+	return []string{dto.StorageArray}
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestClassifyVolume(t *testing.T) {
+	shares := map[string]dto.ShareInfo{
+		"appdata": {Name: "appdata", UseCache: "only", CachePool: "nvme"},
+		"Media":   {Name: "Media", UseCache: "yes", CachePool: "cache"},
+		"backups": {Name: "backups", UseCache: "no"},
+		"isos":    {Name: "isos", Storage: "cache+array", CachePool: "cache", CachePool2: "archive"},
+	}
+	tests := []struct {
+		hostPath      string
+		kind, share   string
+		storage       []string
+		requiresArray bool
+	}{
+		{"/mnt/user/appdata/plex", dto.VolumeKindShare, "appdata", []string{"nvme"}, true},
+		{"/mnt/user/Media", dto.VolumeKindShare, "Media", []string{"cache", "array"}, true},
+		{"/mnt/user/backups/", dto.VolumeKindShare, "backups", []string{"array"}, true},
+		{"/mnt/user/isos", dto.VolumeKindShare, "isos", []string{"cache", "archive"}, true},
+		{"/mnt/user/unknown/x", dto.VolumeKindShare, "unknown", []string{"array"}, true},
+		{"/mnt/user0/Media/Movies", dto.VolumeKindShare, "Media", []string{"array"}, true},
+		{"/mnt/disk3/Media", dto.VolumeKindDisk, "Media", []string{"disk3"}, true},
+		{"/mnt/cache/appdata/db", dto.VolumeKindPool, "appdata", []string{"cache"}, true},
+		{"/mnt/disks/WD_Black/downloads", dto.VolumeKindUnassigned, "", nil, false},
+		{"/mnt/remotes/NAS_backup", dto.VolumeKindRemote, "", nil, false},
+		{"/boot/config/plugins", dto.VolumeKindFlash, "", nil, false},
+		{"/var/run/docker.sock", dto.VolumeKindSystem, "", nil, false},
+		{"/dev/dri", dto.VolumeKindSystem, "", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hostPath, func(t *testing.T) {
+			vd := classifyVolume(dto.VolumeMapping{HostPath: tt.hostPath, ContainerPath: "/data", Mode: "rw"}, shares)
+			if vd.Kind != tt.kind || vd.Share != tt.share || !slices.Equal(vd.Storage, tt.storage) || vd.RequiresArray != tt.requiresArray {
+				t.Errorf("classifyVolume(%q) = %+v", tt.hostPath, vd)
+			}
+		})
+	}
+}
+
+func TestBuildDockerDependencies(t *testing.T) {
+	containers := []dto.ContainerInfo{
+		{ID: "1", Name: "plex", State: "running", VolumeMappings: []dto.VolumeMapping{
+			{HostPath: "/mnt/user/appdata/plex", ContainerPath: "/config"},
+			{HostPath: "/mnt/user/Media", ContainerPath: "/media"},
+			{HostPath: "/dev/dri", ContainerPath: "/dev/dri"},
+		}},
+		{ID: "2", Name: "sonarr", State: "exited", VolumeMappings: []dto.VolumeMapping{
+			{HostPath: "/mnt/user/appdata/sonarr", ContainerPath: "/config"},
+			{HostPath: "/mnt/user/Media/TV", ContainerPath: "/tv"},
+		}},
+		{ID: "3", Name: "syncthing", State: "running", VolumeMappings: []dto.VolumeMapping{
+			{HostPath: "/mnt/disks/usb/sync", ContainerPath: "/sync"},
+		}},
+	}
+	shares := []dto.ShareInfo{
+		{Name: "appdata", UseCache: "only", CachePool: "cache"},
+		{Name: "Media", UseCache: "yes", CachePool: "cache"},
+	}
+
+	deps := BuildDockerDependencies(containers, shares, time.Now())
+	if len(deps.Containers) != 3 {
+		t.Fatalf("containers = %d", len(deps.Containers))
+	}
+	plex := deps.Containers[0]
+	if !plex.RequiresArray || !slices.Equal(plex.Shares, []string{"appdata", "Media"}) || !slices.Equal(plex.Storage, []string{"cache", "array"}) {
+		t.Errorf("plex = %+v", plex)
+	}
+	if sync := deps.Containers[2]; sync.RequiresArray || len(sync.Shares) != 0 || len(sync.Storage) != 0 {
+		t.Errorf("syncthing = %+v", sync)
+	}
+	wantShares := []dto.ShareDependents{
+		{Share: "Media", Containers: []string{"plex", "sonarr"}},
+		{Share: "appdata", Containers: []string{"plex", "sonarr"}},
+	}
+	if len(deps.Shares) != 2 || deps.Shares[0].Share != wantShares[0].Share || !slices.Equal(deps.Shares[1].Containers, wantShares[1].Containers) {
+		t.Errorf("shares = %+v", deps.Shares)
+	}
+	if len(deps.Storage) != 2 || deps.Storage[0].Storage != "array" || deps.Storage[1].Storage != "cache" {
+		t.Errorf("storage = %+v", deps.Storage)
+	}
+}
+
+func TestBuildArrayStopImpact(t *testing.T) {
+	containers := []dto.ContainerInfo{
+		{Name: "plex", State: "running", VolumeMappings: []dto.VolumeMapping{{HostPath: "/mnt/user/Media"}}},
+		{Name: "sonarr", State: "exited", VolumeMappings: []dto.VolumeMapping{{HostPath: "/mnt/user/Media"}}},
+		{Name: "syncthing", State: "running", VolumeMappings: []dto.VolumeMapping{{HostPath: "/mnt/disks/usb"}}},
+	}
+	shares := []dto.ShareInfo{{Name: "Media", SMBExport: true}, {Name: "system"}}
+	vms := []dto.VMInfo{
+		{Name: "Windows 11", State: "running", DiskPath: "/mnt/user/domains/Windows 11/vdisk1.img"},
+		{Name: "HAOS", State: "running", DiskPath: "/mnt/disks/ssd/haos.qcow2"},
+		{Name: "Old", State: "shut off"},
+	}
+
+	impact := BuildArrayStopImpact(&dto.ArrayStatus{State: "Started"}, containers, shares, vms, time.Now())
+	if len(impact.Containers) != 2 || impact.Containers[0].Name != "plex" || impact.Containers[1].RequiresArray {
+		t.Errorf("containers = %+v", impact.Containers)
+	}
+	if len(impact.VMs) != 2 || !impact.VMs[0].RequiresArray || impact.VMs[1].RequiresArray {
+		t.Errorf("vms = %+v", impact.VMs)
+	}
+	if !slices.Equal(impact.Shares, []string{"Media"}) {
+		t.Errorf("shares = %v", impact.Shares)
+	}
+	if len(impact.Notes) != 3 {
+		t.Errorf("notes = %v", impact.Notes)
+	}
+
+	stopped := BuildArrayStopImpact(&dto.ArrayStatus{State: "Stopped"}, containers, shares, vms, time.Now())
+	if len(stopped.Containers) != 0 || len(stopped.Notes) != 1 {
+		t.Errorf("stopped array impact = %+v", stopped)
+	}
+}
+
+func TestHandleDockerDependencies(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/docker/dependencies", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rr.Code, rr.Body)
+	}
+	var deps dto.DockerDependencies
+	if err := json.Unmarshal(rr.Body.Bytes(), &deps); err != nil {
+		t.Fatal(err)
+	}
+	if deps.Containers == nil || deps.Shares == nil || deps.Storage == nil {
+		t.Errorf("nil slices in %+v", deps)
+	}
+}
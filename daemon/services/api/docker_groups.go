@@ -0,0 +1,180 @@
+package api
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// handleDockerGroups godoc
+//
+//	@Summary		Get containers grouped by folder or compose project
+//	@Description	Groups containers by the folder-view plugin label (folder.view2 or folder.view) or, failing that, the com.docker.compose.project label, with each group's running count and aggregate CPU and memory usage of its running containers. Containers without a group label are listed under ungrouped.
+//	@Tags			Docker
+//	@Produce		json
+//	@Success		200	{object}	dto.DockerGroups	"Container groups"
+//	@Router			/docker/groups [get]
+func (s *Server) handleDockerGroups(w http.ResponseWriter, _ *http.Request) {
+	respondJSON(w, http.StatusOK, BuildDockerGroups(s.GetDockerCache(), time.Now()))
+}
+
+// BuildDockerGroups groups containers by their Group label, sorted by group
+// and container name.
+func BuildDockerGroups(containers []dto.ContainerInfo, now time.Time) *dto.DockerGroups {
+	result := &dto.DockerGroups{
+		Groups:    []dto.DockerGroup{},
+		Ungrouped: []string{},
+		Timestamp: now,
+	}
+	byName := map[string]*dto.DockerGroup{}
+	for _, c := range containers {
+		if c.Group == "" {
+			result.Ungrouped = append(result.Ungrouped, c.Name)
+			continue
+		}
+		g, ok := byName[c.Group]
+		if !ok {
+			g = &dto.DockerGroup{Name: c.Group}
+			byName[c.Group] = g
+		}
+		g.Containers = append(g.Containers, c.Name)
+		g.Total++
+		if c.State == "running" {
+			g.Running++
+			g.CPUPercent += c.CPUPercent
+			g.MemoryUsage += c.MemoryUsage
+			g.MemoryUsageMB += c.MemoryUsageMB
+		}
+	}
+	for _, g := range byName {
+		slices.Sort(g.Containers)
+		result.Groups = append(result.Groups, *g)
+	}
+	slices.SortFunc(result.Groups, func(a, b dto.DockerGroup) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	slices.Sort(result.Ungrouped)
+	return result
+}
+
+// handleDockerGroupStart godoc
+//
+//	@Summary		Start every container in a group
+//	@Description	Starts the stopped containers of a folder or compose project group in Unraid autostart order, then by name. Containers that are already running or paused are skipped. A failure does not stop the remaining containers; see the per-container results.
+//	@Tags			Docker
+//	@Produce		json
+//	@Param			name	path		string							true	"Group name"
+//	@Success		200		{object}	dto.DockerGroupActionResult	"Per-container results"
+//	@Failure		404		{object}	dto.Response					"Group not found"
+//	@Router			/docker/groups/{name}/start [post]
+func (s *Server) handleDockerGroupStart(w http.ResponseWriter, r *http.Request) {
+	s.handleDockerGroupAction(w, r, "start")
+}
+
+// handleDockerGroupStop godoc
+//
+//	@Summary		Stop every container in a group
+//	@Description	Stops the running containers of a folder or compose project group in reverse start order, so dependants stop before the services they use. Stopped containers are skipped. A failure does not stop the remaining containers; see the per-container results.
+//	@Tags			Docker
+//	@Produce		json
+//	@Param			name	path		string							true	"Group name"
+//	@Success		200		{object}	dto.DockerGroupActionResult	"Per-container results"
+//	@Failure		404		{object}	dto.Response					"Group not found"
+//	@Router			/docker/groups/{name}/stop [post]
+func (s *Server) handleDockerGroupStop(w http.ResponseWriter, r *http.Request) {
+	s.handleDockerGroupAction(w, r, "stop")
+}
+
+func (s *Server) handleDockerGroupAction(w http.ResponseWriter, r *http.Request, action string) {
+	group := mux.Vars(r)["name"]
+	var members []dto.ContainerInfo
+	for _, c := range s.GetDockerCache() {
+		if c.Group == group {
+			members = append(members, c)
+		}
+	}
+	if len(members) == 0 {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("Container group not found: %s", group))
+		return
+	}
+
+	dc := controllers.NewDockerController()
+	defer dc.Close() //nolint:errcheck
+
+	names := make([]string, 0, len(members))
+	for _, c := range members {
+		names = append(names, c.Name)
+	}
+	var order []string
+	if autostart, err := dc.GetAutostart(names); err == nil {
+		for _, entry := range autostart.Containers {
+			order = append(order, entry.Name)
+		}
+	} else {
+		logger.DebugContext(r.Context(), "API: Failed to read autostart order for group %s: %v", group, err)
+	}
+
+	logger.InfoContext(r.Context(), "API: %s container group %s (%d containers)", action, group, len(members))
+	operation := dc.Start
+	if action == "stop" {
+		operation = dc.Stop
+	}
+	respondJSON(w, http.StatusOK, runDockerGroupAction(group, action, members, order, operation, time.Now()))
+}
+
+// runDockerGroupAction starts or stops the members of a group one at a time.
+// Members are started in the given order (autostart order), followed by the
+// rest by name, and stopped in the reverse. Members already in the requested
+// state are skipped.
+func runDockerGroupAction(group, action string, members []dto.ContainerInfo, order []string, operation func(string) error, now time.Time) *dto.DockerGroupActionResult {
+	rank := func(name string) int {
+		if i := slices.Index(order, name); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	members = slices.Clone(members)
+	slices.SortStableFunc(members, func(a, b dto.ContainerInfo) int {
+		if d := rank(a.Name) - rank(b.Name); d != 0 {
+			return d
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	if action == "stop" {
+		slices.Reverse(members)
+	}
+
+	result := &dto.DockerGroupActionResult{
+		Group:     group,
+		Action:    action,
+		Results:   make([]dto.DockerGroupContainerResult, 0, len(members)),
+		Timestamp: now,
+	}
+	for _, c := range members {
+		res := dto.DockerGroupContainerResult{Name: c.Name}
+		active := c.State == "running" || c.State == "paused"
+		switch {
+		case action == "start" && active, action == "stop" && !active:
+			res.Skipped = true
+			result.Skipped++
+		default:
+			if err := operation(c.Name); err != nil {
+				res.Error = err.Error()
+				result.Failed++
+			} else {
+				res.Succeeded = true
+				result.Succeeded++
+			}
+		}
+		result.Results = append(result.Results, res)
+	}
+	return result
+}
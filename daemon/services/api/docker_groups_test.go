@@ -0,0 +1,89 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestBuildDockerGroups(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	containers := []dto.ContainerInfo{
+		{Name: "sonarr", Group: "media", State: "running", CPUPercent: 2, MemoryUsage: 100, MemoryUsageMB: 1},
+		{Name: "plex", Group: "media", State: "running", CPUPercent: 5, MemoryUsage: 300, MemoryUsageMB: 3},
+		{Name: "radarr", Group: "media", State: "exited", CPUPercent: 9, MemoryUsage: 999},
+		{Name: "immich-server", Group: "immich", State: "running", CPUPercent: 1},
+		{Name: "unifi", State: "running"},
+	}
+
+	groups := BuildDockerGroups(containers, now)
+	if len(groups.Groups) != 2 || groups.Groups[0].Name != "immich" || groups.Groups[1].Name != "media" {
+		t.Fatalf("groups = %+v", groups.Groups)
+	}
+	media := groups.Groups[1]
+	if !slices.Equal(media.Containers, []string{"plex", "radarr", "sonarr"}) {
+		t.Errorf("media containers = %v", media.Containers)
+	}
+	if media.Total != 3 || media.Running != 2 || media.CPUPercent != 7 || media.MemoryUsage != 400 || media.MemoryUsageMB != 4 {
+		t.Errorf("media group = %+v", media)
+	}
+	if !slices.Equal(groups.Ungrouped, []string{"unifi"}) {
+		t.Errorf("ungrouped = %v", groups.Ungrouped)
+	}
+
+	empty := BuildDockerGroups(nil, now)
+	if empty.Groups == nil || empty.Ungrouped == nil {
+		t.Errorf("empty result should have non-nil slices: %+v", empty)
+	}
+}
+
+func TestRunDockerGroupAction(t *testing.T) {
+	members := []dto.ContainerInfo{
+		{Name: "app", State: "exited"},
+		{Name: "cache", State: "running"},
+		{Name: "db", State: "exited"},
+		{Name: "worker", State: "exited"},
+	}
+	order := []string{"other", "db", "cache", "app"}
+
+	var called []string
+	operation := func(name string) error {
+		called = append(called, name)
+		if name == "worker" {
+			return errors.New("no such image")
+		}
+		return nil
+	}
+
+	result := runDockerGroupAction("stack", "start", members, order, operation, time.Now())
+	if !slices.Equal(called, []string{"db", "app", "worker"}) {
+		t.Errorf("start order = %v", called)
+	}
+	if result.Succeeded != 2 || result.Failed != 1 || result.Skipped != 1 || len(result.Results) != 4 {
+		t.Errorf("start result = %+v", result)
+	}
+	if last := result.Results[3]; last.Name != "worker" || last.Error == "" {
+		t.Errorf("failed member = %+v", last)
+	}
+
+	called = nil
+	result = runDockerGroupAction("stack", "stop", members, order, operation, time.Now())
+	if !slices.Equal(called, []string{"cache"}) || result.Skipped != 3 || result.Succeeded != 1 {
+		t.Errorf("stop: called %v, result %+v", called, result)
+	}
+}
+
+func TestHandleDockerGroupActionNotFound(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/docker/groups/missing/start", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("status %d, want 404: %s", rr.Code, rr.Body)
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// publishDockerNetworks refreshes the cached network list after a network is
+// created or removed, instead of waiting for the next collector interval.
+func (s *Server) publishDockerNetworks(dc *controllers.DockerController) {
+	networks, err := dc.ListNetworks()
+	if err != nil {
+		logger.Warning("API: Failed to refresh Docker networks: %v", err)
+		return
+	}
+	domain.Publish(s.ctx.Hub, constants.TopicDockerNetworksUpdate, &dto.DockerNetworkList{
+		Networks:  networks,
+		Count:     len(networks),
+		Timestamp: time.Now(),
+	})
+}
+
+// handleDockerNetworkCreate godoc
+//
+//	@Summary		Create a Docker network
+//	@Description	Create a custom bridge, macvlan or ipvlan network, like the custom networks on the Unraid Docker settings page. macvlan and ipvlan networks need a parent interface (br0, eth0, or a VLAN sub-interface such as br0.20, which Docker creates if missing) and a subnet; containers on them get their own address on that LAN or VLAN.
+//	@Tags			Docker
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.DockerNetworkCreateRequest	true	"Network definition"
+//	@Success		201		{object}	dto.DockerNetworkCreateResult	"Network created"
+//	@Failure		400		{object}	dto.Response					"Invalid network definition"
+//	@Failure		500		{object}	dto.Response					"Failed to create the network"
+//	@Router			/docker/networks [post]
+func (s *Server) handleDockerNetworkCreate(w http.ResponseWriter, r *http.Request) {
+	var req dto.DockerNetworkCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if err := lib.ValidateDockerNetworkCreate(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dc := controllers.NewDockerController()
+	defer dc.Close() //nolint:errcheck
+
+	result, err := dc.CreateNetwork(req)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to create Docker network %s: %v", req.Name, err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.publishDockerNetworks(dc)
+	respondJSON(w, http.StatusCreated, result)
+}
+
+// handleDockerNetworkRemove godoc
+//
+//	@Summary		Remove a Docker network
+//	@Description	Remove a custom Docker network by name or ID. Networks that still have containers attached are refused; the predefined bridge, host and none networks cannot be removed.
+//	@Tags			Docker
+//	@Produce		json
+//	@Param			id	path		string			true	"Network name or ID"
+//	@Success		200	{object}	dto.Response	"Network removed"
+//	@Failure		400	{object}	dto.Response	"Invalid network reference"
+//	@Failure		404	{object}	dto.Response	"Network not found"
+//	@Failure		409	{object}	dto.Response	"Containers are attached to the network"
+//	@Failure		500	{object}	dto.Response	"Failed to remove the network"
+//	@Router			/docker/networks/{id} [delete]
+func (s *Server) handleDockerNetworkRemove(w http.ResponseWriter, r *http.Request) {
+	ref := mux.Vars(r)["id"]
+	if err := lib.ValidateDockerNetworkRef(ref); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dc := controllers.NewDockerController()
+	defer dc.Close() //nolint:errcheck
+
+	if err := dc.RemoveNetwork(ref); err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to remove Docker network %s: %v", ref, err)
+		switch {
+		case errors.Is(err, controllers.ErrDockerNetworkNotFound):
+			respondWithError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, controllers.ErrDockerNetworkInUse):
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	s.publishDockerNetworks(dc)
+	respondJSON(w, http.StatusOK, dto.Response{
+		Success:   true,
+		Message:   "Docker network " + ref + " removed",
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDockerNetworkEndpointsValidation(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name, method, path, body string
+	}{
+		{"create invalid json", "POST", "/api/v1/docker/networks", `{`},
+		{"create unknown driver", "POST", "/api/v1/docker/networks", `{"name":"iot","driver":"overlay"}`},
+		{"create macvlan without parent", "POST", "/api/v1/docker/networks", `{"name":"iot","driver":"macvlan","subnet":"192.168.20.0/24"}`},
+		{"create predefined name", "POST", "/api/v1/docker/networks", `{"name":"bridge","driver":"bridge"}`},
+		{"remove predefined network", "DELETE", "/api/v1/docker/networks/host", ""},
+		{"remove invalid name", "DELETE", "/api/v1/docker/networks/-iot", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("status %d, want %d: %s", rr.Code, http.StatusBadRequest, rr.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
+)
+
+func TestDockerStatsSummary(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/docker/stats/summary", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("before first collection: status %d, want 503", rr.Code)
+	}
+
+	server.dockerStatsCache.Store(collectors.SummarizeDockerStats([]*dto.ContainerInfo{
+		{ID: "a", Name: "plex", State: "running", CPUPercent: 20, MemoryUsage: 100},
+		{ID: "b", Name: "sonarr", State: "running", CPUPercent: 5, MemoryUsage: 300},
+		{ID: "c", Name: "radarr", State: "running", CPUPercent: 10, MemoryUsage: 200},
+	}, time.Now()))
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/docker/stats/summary?limit=2", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200: %s", rr.Code, rr.Body)
+	}
+	var summary dto.DockerStatsSummary
+	if err := json.Unmarshal(rr.Body.Bytes(), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.TotalCPUPercent != 35 || summary.RunningContainers != 3 {
+		t.Errorf("totals = %+v", summary.DockerAggregateStats)
+	}
+	if len(summary.TopCPU) != 2 || summary.TopCPU[0].Name != "plex" || summary.TopCPU[1].Name != "radarr" {
+		t.Errorf("top_cpu = %+v, want plex, radarr", summary.TopCPU)
+	}
+	if len(summary.TopMemory) != 2 || summary.TopMemory[0].Name != "sonarr" {
+		t.Errorf("top_memory = %+v, want sonarr first", summary.TopMemory)
+	}
+	// Trimming the response must leave the cached rankings whole
+	if got := len(server.GetDockerStatsSummaryCache().TopCPU); got != 3 {
+		t.Errorf("cached top_cpu has %d entries, want 3", got)
+	}
+
+	// /docker/stats is not shadowed by /docker/{id}
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/docker/stats", nil))
+	var stats dto.DockerAggregateStats
+	if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil || stats.TotalContainers != 3 {
+		t.Errorf("/docker/stats = %d %s", rr.Code, rr.Body)
+	}
+
+	for _, limit := range []string{"0", "11", "ten"} {
+		rr = httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/docker/stats/summary?limit="+limit, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("limit=%s: status %d, want 400", limit, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// buildArrayEncryptionStatus summarizes the encrypted devices among disks.
+// An encrypted device Unraid has not opened yet counts as locked.
+func buildArrayEncryptionStatus(array *dto.ArrayStatus, disks []dto.DiskInfo, keyfilePresent bool) dto.ArrayEncryptionStatus {
+	status := dto.ArrayEncryptionStatus{
+		KeyfilePresent: keyfilePresent,
+		Devices:        []dto.EncryptedDevice{},
+		Timestamp:      time.Now(),
+	}
+	if array != nil {
+		status.ArrayState = array.State
+	}
+	for _, disk := range disks {
+		if !disk.Encrypted {
+			continue
+		}
+		state := disk.EncryptionState
+		if state == "" {
+			state = dto.EncryptionLocked
+		}
+		status.Encrypted = true
+		if state != dto.EncryptionUnlocked {
+			status.Locked = true
+		}
+		status.Devices = append(status.Devices, dto.EncryptedDevice{
+			Name:   disk.Name,
+			Device: disk.Device,
+			State:  state,
+		})
+	}
+	return status
+}
+
+// handleArrayEncryption godoc
+//
+//	@Summary		Get array encryption status
+//	@Description	Get the LUKS encryption state of each encrypted array and pool device (unlocked, locked or wrong_key), whether any of them is still locked and whether Unraid's keyfile is present in RAM.
+//	@Tags			Array
+//	@Produce		json
+//	@Success		200	{object}	dto.ArrayEncryptionStatus	"Encryption status"
+//	@Router			/array/encryption [get]
+func (s *Server) handleArrayEncryption(w http.ResponseWriter, _ *http.Request) {
+	_, err := os.Stat(controllers.LUKSKeyfilePath())
+	respondJSON(w, http.StatusOK, buildArrayEncryptionStatus(s.GetArrayCache(), s.GetDisksCache(), err == nil))
+}
+
+// handleArrayUnlock godoc
+//
+//	@Summary		Unlock encrypted drives and start the array
+//	@Description	Supply the array encryption passphrase or base64-encoded keyfile and start the array, like the Main page does after an unattended reboot. The key is written to Unraid's keyfile for emhttpd and deleted once the array has started unless keep_keyfile is set. The array must be stopped and have encrypted devices. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token; repeat it with the X-Confirm-Token header within 60 seconds.
+//	@Tags			Array
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Confirm-Token	header		string						false	"Token from the confirmation challenge"
+//	@Param			request			body		dto.ArrayUnlockRequest		true	"Passphrase or keyfile"
+//	@Success		200				{object}	dto.Response				"Array unlocked and started"
+//	@Failure		400				{object}	dto.Response				"Invalid request"
+//	@Failure		409				{object}	dto.Response				"Array not stopped or not encrypted"
+//	@Failure		412				{object}	dto.Response				"Invalid or expired confirmation token"
+//	@Failure		422				{object}	dto.Response				"Wrong encryption key"
+//	@Failure		428				{object}	dto.ConfirmationChallenge	"Confirmation required"
+//	@Failure		500				{object}	dto.Response				"Failed to start array"
+//	@Router			/array/unlock [post]
+func (s *Server) handleArrayUnlock(w http.ResponseWriter, r *http.Request) {
+	var req dto.ArrayUnlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if (req.Passphrase == "") == (req.Keyfile == "") {
+		respondWithError(w, http.StatusBadRequest, "set exactly one of passphrase and keyfile")
+		return
+	}
+	key := []byte(req.Passphrase)
+	if req.Keyfile != "" {
+		decoded, err := base64.StdEncoding.DecodeString(req.Keyfile)
+		if err != nil || len(decoded) == 0 {
+			respondWithError(w, http.StatusBadRequest, "keyfile must be non-empty base64")
+			return
+		}
+		key = decoded
+	}
+
+	status := buildArrayEncryptionStatus(s.GetArrayCache(), s.GetDisksCache(), false)
+	if !strings.EqualFold(status.ArrayState, "Stopped") {
+		respondWithError(w, http.StatusConflict, "The array must be stopped to unlock it")
+		return
+	}
+	if !status.Encrypted {
+		respondWithError(w, http.StatusConflict, "The array has no encrypted devices")
+		return
+	}
+
+	if !s.confirmDestructive(w, r, arrayUnlockAction()) {
+		return
+	}
+
+	logger.InfoContext(r.Context(), "API: Unlocking and starting encrypted array")
+	if err := controllers.NewArrayController(s.ctx).UnlockAndStartArray(key, req.KeepKeyfile); err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to unlock array: %v", err)
+		if errors.Is(err, controllers.ErrWrongEncryptionKey) {
+			respondWithError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{
+		Success:   true,
+		Message:   "Array unlocked and started successfully",
+		Timestamp: time.Now(),
+	})
+}
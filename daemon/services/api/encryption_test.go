@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestBuildArrayEncryptionStatus(t *testing.T) {
+	disks := []dto.DiskInfo{
+		{Name: "parity", Device: "sdb"},
+		{Name: "disk1", Device: "sdc", Encrypted: true, EncryptionState: dto.EncryptionUnlocked},
+		{Name: "disk2", Device: "sdd", Encrypted: true},
+	}
+
+	status := buildArrayEncryptionStatus(&dto.ArrayStatus{State: "Stopped"}, disks, true)
+	if !status.Encrypted || !status.Locked || !status.KeyfilePresent || status.ArrayState != "Stopped" {
+		t.Errorf("status = %+v", status)
+	}
+	if len(status.Devices) != 2 || status.Devices[1].State != dto.EncryptionLocked {
+		t.Errorf("devices = %+v", status.Devices)
+	}
+
+	status = buildArrayEncryptionStatus(nil, disks[:2], false)
+	if !status.Encrypted || status.Locked {
+		t.Errorf("unlocked status = %+v", status)
+	}
+	if status = buildArrayEncryptionStatus(nil, nil, false); status.Encrypted || status.Devices == nil {
+		t.Errorf("unencrypted status = %+v", status)
+	}
+}
+
+func TestArrayUnlockValidation(t *testing.T) {
+	server, _ := setupTestServer()
+	disks := []dto.DiskInfo{{Name: "disk1", Encrypted: true}}
+	server.disksCache.Store(&disks)
+
+	for _, tt := range []struct {
+		name  string
+		state string
+		body  string
+		want  int
+	}{
+		{"invalid json", "Stopped", `{`, http.StatusBadRequest},
+		{"no key", "Stopped", `{}`, http.StatusBadRequest},
+		{"both keys", "Stopped", `{"passphrase":"secret","keyfile":"c2VjcmV0"}`, http.StatusBadRequest},
+		{"bad keyfile", "Stopped", `{"keyfile":"not base64!"}`, http.StatusBadRequest},
+		{"started", "Started", `{"passphrase":"secret"}`, http.StatusConflict},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server.arrayCache.Store(&dto.ArrayStatus{State: tt.state})
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/array/unlock", strings.NewReader(tt.body)))
+			if rr.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", rr.Code, tt.want, rr.Body)
+			}
+		})
+	}
+
+	server.arrayCache.Store(&dto.ArrayStatus{State: "Stopped"})
+	server.disksCache.Store(&[]dto.DiskInfo{{Name: "disk1"}})
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/array/unlock", strings.NewReader(`{"passphrase":"secret"}`)))
+	if rr.Code != http.StatusConflict {
+		t.Errorf("unencrypted array: status %d, want 409", rr.Code)
+	}
+}
@@ -105,6 +105,9 @@ func cacheBindings() []eventBinding {
 		bind(constants.TopicDockerNetworksUpdate, func(c *CacheStore, v *dto.DockerNetworkList) {
 			c.dockerNetworksCache.Store(v)
 		}),
+		bind(constants.TopicDockerStatsUpdate, func(c *CacheStore, v *dto.DockerStatsSummary) {
+			c.dockerStatsCache.Store(v)
+		}),
 		bind(constants.TopicPluginUpdatesUpdate, func(c *CacheStore, v *dto.PluginList) {
 			c.pluginUpdatesCache.Store(v)
 		}),
@@ -114,14 +117,50 @@ func cacheBindings() []eventBinding {
 		bind(constants.TopicMoverUpdate, func(c *CacheStore, v *dto.MoverStatus) {
 			c.moverCache.Store(v)
 		}),
+		bind(constants.TopicDNSHealthUpdate, func(c *CacheStore, v *dto.DNSHealth) {
+			c.dnsHealthCache.Store(v)
+		}),
+		bind(constants.TopicWANStatusUpdate, func(c *CacheStore, v *dto.WANStatus) {
+			c.wanStatusCache.Store(v)
+		}),
+		bind(constants.TopicSpeedtestUpdate, func(c *CacheStore, v *dto.SpeedtestStatus) {
+			c.speedtestCache.Store(v)
+		}),
+		bind(constants.TopicPoolsUpdate, func(c *CacheStore, v []dto.PoolInfo) {
+			c.poolsCache.Store(&v)
+		}),
+		bind(constants.TopicBtrfsUpdate, func(c *CacheStore, v []dto.BtrfsFilesystem) {
+			c.btrfsCache.Store(&v)
+		}),
+		bind(constants.TopicPowerUpdate, func(c *CacheStore, v *dto.PowerEstimate) {
+			c.powerCache.Store(v)
+		}),
+		bind(constants.TopicRecycleBinUpdate, func(c *CacheStore, v *dto.RecycleBinStatus) {
+			c.recycleBinCache.Store(v)
+		}),
+		bind(constants.TopicIPMIUpdate, func(c *CacheStore, v *dto.IPMIStatus) {
+			c.ipmiCache.Store(v)
+		}),
+		bind(constants.TopicConnectUpdate, func(c *CacheStore, v *dto.ConnectStatus) {
+			c.connectCache.Store(v)
+		}),
+		bind(constants.TopicGuestTrafficUpdate, func(c *CacheStore, v *dto.GuestTraffic) {
+			c.guestTrafficCache.Store(v)
+		}),
+		bind(constants.TopicRemoteMountsUpdate, func(c *CacheStore, v *dto.RemoteMountHealth) {
+			c.remoteMountsCache.Store(v)
+		}),
+		bind(constants.TopicDiskSpinHistoryUpdate, func(c *CacheStore, v *dto.DiskSpinHistory) {
+			c.diskSpinHistoryCache.Store(v)
+		}),
 	}
 }
 
-// buildCacheDispatch creates a type-to-handler map for O(1) event dispatch.
-func buildCacheDispatch(bindings []eventBinding) map[reflect.Type]func(*CacheStore, any) {
-	m := make(map[reflect.Type]func(*CacheStore, any), len(bindings))
+// buildCacheDispatch creates a type-to-binding map for O(1) event dispatch.
+func buildCacheDispatch(bindings []eventBinding) map[reflect.Type]eventBinding {
+	m := make(map[reflect.Type]eventBinding, len(bindings))
 	for _, b := range bindings {
-		m[b.msgType] = b.update
+		m[b.msgType] = b
 	}
 	return m
 }
@@ -131,7 +170,7 @@ func buildCacheDispatch(bindings []eventBinding) map[reflect.Type]func(*CacheSto
 // This ensures adding a new cache binding automatically enables its broadcast.
 func broadcastTopicNames() []string {
 	bindings := cacheBindings()
-	names := make([]string, 0, len(bindings)+2)
+	names := make([]string, 0, len(bindings)+4)
 	for _, b := range bindings {
 		names = append(names, b.topicName)
 	}
@@ -139,6 +178,22 @@ func broadcastTopicNames() []string {
 	names = append(names, constants.TopicCollectorStateChange.Name)
 	// SourceStatusChanged is broadcast but not cached.
 	names = append(names, constants.TopicSourceStatusChanged.Name)
+	// WANIPChanged is broadcast but not cached.
+	names = append(names, constants.TopicWANIPChanged.Name)
+	// ShutdownProgress is broadcast but not cached.
+	names = append(names, constants.TopicShutdownProgress.Name)
+	// ThermalEvent is broadcast but not cached.
+	names = append(names, constants.TopicThermalEvent.Name)
+	// UtilizationEvent is broadcast but not cached.
+	names = append(names, constants.TopicUtilizationEvent.Name)
+	// Transfer progress is broadcast but not cached.
+	names = append(names, constants.TopicTransferProgress.Name)
+	// DiskSpinEvent is broadcast but not cached.
+	names = append(names, constants.TopicDiskSpinEvent.Name)
+	// Job updates are broadcast but not cached.
+	names = append(names, constants.TopicJobUpdate.Name)
+	// Maintenance window changes are broadcast but not cached.
+	names = append(names, constants.TopicMaintenanceUpdate.Name)
 	return names
 }
 
@@ -146,11 +201,27 @@ func broadcastTopicNames() []string {
 // for resolving the topic name of a broadcast message.
 func buildTypeToTopicMap() map[reflect.Type]string {
 	bindings := cacheBindings()
-	m := make(map[reflect.Type]string, len(bindings)+1)
+	m := make(map[reflect.Type]string, len(bindings)+3)
 	for _, b := range bindings {
 		m[b.msgType] = b.topicName
 	}
 	// SourceStatus is broadcast but not cached.
 	m[reflect.TypeOf(dto.SourceStatus{})] = "source_status_changed"
+	// WANIPChange is broadcast but not cached.
+	m[reflect.TypeFor[*dto.WANIPChange]()] = constants.TopicWANIPChanged.Name
+	// ShutdownProgress is broadcast but not cached.
+	m[reflect.TypeFor[dto.ShutdownProgress]()] = constants.TopicShutdownProgress.Name
+	// ThermalEvent is broadcast but not cached.
+	m[reflect.TypeFor[dto.ThermalEvent]()] = constants.TopicThermalEvent.Name
+	// UtilizationEvent is broadcast but not cached.
+	m[reflect.TypeFor[dto.UtilizationEvent]()] = constants.TopicUtilizationEvent.Name
+	// Transfer progress is broadcast but not cached.
+	m[reflect.TypeFor[dto.TransferRun]()] = constants.TopicTransferProgress.Name
+	// DiskSpinEvent is broadcast but not cached.
+	m[reflect.TypeFor[dto.DiskSpinEvent]()] = constants.TopicDiskSpinEvent.Name
+	// Job updates are broadcast but not cached.
+	m[reflect.TypeFor[dto.Job]()] = constants.TopicJobUpdate.Name
+	// Maintenance window changes are broadcast but not cached.
+	m[reflect.TypeFor[dto.MaintenanceStatus]()] = constants.TopicMaintenanceUpdate.Name
 	return m
 }
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/filesystem"
+)
+
+// handleFilesystemAnalyze godoc
+//
+//	@Summary		Start a directory size analysis
+//	@Description	Compute the size of a directory and its largest children in the background. The path must lie within a user share, either under /mnt/user or on an array disk or pool (e.g. /mnt/cache/appdata). Poll the returned job for progress and the result.
+//	@Tags			Filesystem
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.FilesystemAnalyzeRequest	true	"Directory to analyze"
+//	@Success		202		{object}	dto.FilesystemAnalyzeJob		"Analysis started"
+//	@Failure		400		{object}	dto.Response					"Invalid path, depth or limit"
+//	@Failure		403		{object}	dto.Response					"Path outside the share paths"
+//	@Failure		404		{object}	dto.Response					"Path not found"
+//	@Failure		429		{object}	dto.Response					"Too many analyses running"
+//	@Router			/filesystem/analyze [post]
+func (s *Server) handleFilesystemAnalyze(w http.ResponseWriter, r *http.Request) {
+	var req dto.FilesystemAnalyzeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if req.Path == "" {
+		respondWithError(w, http.StatusBadRequest, "path is required")
+		return
+	}
+
+	path, err := filesystem.ResolvePath(req.Path, filesystem.ShareRoots(s.GetSharesCache(), s.GetDisksCache()))
+	if err != nil {
+		respondFilesystemError(w, err)
+		return
+	}
+	job, err := s.analyzer.Start(s.cancelCtx, path, req.Depth, req.Limit)
+	if err != nil {
+		respondFilesystemError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusAccepted, job)
+}
+
+// handleFilesystemAnalyzeJobs godoc
+//
+//	@Summary		List directory size analyses
+//	@Description	List running and recently finished directory analyses, newest first. Results are omitted; fetch a job by ID for its result. Finished jobs are kept for an hour.
+//	@Tags			Filesystem
+//	@Produce		json
+//	@Success		200	{array}	dto.FilesystemAnalyzeJob	"Analysis jobs"
+//	@Router			/filesystem/analyze [get]
+func (s *Server) handleFilesystemAnalyzeJobs(w http.ResponseWriter, _ *http.Request) {
+	respondJSON(w, http.StatusOK, s.analyzer.List())
+}
+
+// handleFilesystemAnalyzeJob godoc
+//
+//	@Summary		Get a directory size analysis
+//	@Description	Get the progress of a directory analysis, and its result once completed.
+//	@Tags			Filesystem
+//	@Produce		json
+//	@Param			id	path		string						true	"Job ID"
+//	@Success		200	{object}	dto.FilesystemAnalyzeJob	"Analysis job"
+//	@Failure		404	{object}	dto.Response				"Job not found"
+//	@Router			/filesystem/analyze/{id} [get]
+func (s *Server) handleFilesystemAnalyzeJob(w http.ResponseWriter, r *http.Request) {
+	job, err := s.analyzer.Get(mux.Vars(r)["id"])
+	if err != nil {
+		respondFilesystemError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, job)
+}
+
+// handleFilesystemAnalyzeCancel godoc
+//
+//	@Summary		Cancel a directory size analysis
+//	@Description	Stop a running directory analysis. Cancelling a finished job has no effect.
+//	@Tags			Filesystem
+//	@Produce		json
+//	@Param			id	path		string						true	"Job ID"
+//	@Success		200	{object}	dto.FilesystemAnalyzeJob	"Analysis job"
+//	@Failure		404	{object}	dto.Response				"Job not found"
+//	@Router			/filesystem/analyze/{id} [delete]
+func (s *Server) handleFilesystemAnalyzeCancel(w http.ResponseWriter, r *http.Request) {
+	job, err := s.analyzer.Cancel(mux.Vars(r)["id"])
+	if err != nil {
+		respondFilesystemError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, job)
+}
+
+// handleFilesList godoc
+//
+//	@Summary		List a directory
+//	@Description	List the entries of a directory within a user share, directories first and then by name. The path must lie within a user share, either under /mnt/user or on an array disk or pool. Symlinks are listed but not followed.
+//	@Tags			Filesystem
+//	@Produce		json
+//	@Param			path	query		string					true	"Absolute directory path within a share"
+//	@Param			offset	query		int						false	"Entries to skip (default 0)"
+//	@Param			limit	query		int						false	"Entries to return (1-5000, default 1000)"
+//	@Success		200		{object}	dto.DirectoryListing	"Directory listing"
+//	@Failure		400		{object}	dto.Response			"Invalid path, offset or limit, or not a directory"
+//	@Failure		403		{object}	dto.Response			"Path outside the share paths"
+//	@Failure		404		{object}	dto.Response			"Path not found"
+//	@Router			/files/list [get]
+func (s *Server) handleFilesList(w http.ResponseWriter, r *http.Request) {
+	path, ok := s.resolveSharePath(w, r)
+	if !ok {
+		return
+	}
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	listing, err := filesystem.ListDir(path, offset, limit)
+	if err != nil {
+		respondFilesystemError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, listing)
+}
+
+// handleFilesStat godoc
+//
+//	@Summary		Get file information
+//	@Description	Get the type, size, permissions and modification time of a file or directory within a user share.
+//	@Tags			Filesystem
+//	@Produce		json
+//	@Param			path	query		string			true	"Absolute path within a share"
+//	@Success		200		{object}	dto.FileEntry	"File information"
+//	@Failure		400		{object}	dto.Response	"Invalid path"
+//	@Failure		403		{object}	dto.Response	"Path outside the share paths"
+//	@Failure		404		{object}	dto.Response	"Path not found"
+//	@Router			/files/stat [get]
+func (s *Server) handleFilesStat(w http.ResponseWriter, r *http.Request) {
+	path, ok := s.resolveSharePath(w, r)
+	if !ok {
+		return
+	}
+	entry, err := filesystem.Stat(path)
+	if err != nil {
+		respondFilesystemError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, entry)
+}
+
+// handleFilesDownload godoc
+//
+//	@Summary		Download a file
+//	@Description	Download a regular file within a user share as an attachment. Range requests are supported for resuming large downloads.
+//	@Tags			Filesystem
+//	@Produce		octet-stream
+//	@Param			path	query		string			true	"Absolute file path within a share"
+//	@Success		200		{file}		file			"File contents"
+//	@Failure		400		{object}	dto.Response	"Invalid path or not a regular file"
+//	@Failure		403		{object}	dto.Response	"Path outside the share paths"
+//	@Failure		404		{object}	dto.Response	"Path not found"
+//	@Router			/files/download [get]
+func (s *Server) handleFilesDownload(w http.ResponseWriter, r *http.Request) {
+	path, ok := s.resolveSharePath(w, r)
+	if !ok {
+		return
+	}
+	f, info, err := filesystem.OpenFile(path)
+	if err != nil {
+		respondFilesystemError(w, err)
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	// Large backups take longer than the server's write timeout.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		logger.DebugContext(r.Context(), "API: Could not lift write deadline for download: %v", err)
+	}
+	logger.InfoContext(r.Context(), "API: Downloading %s (%d bytes)", path, info.Size())
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": info.Name()}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+// resolveSharePath resolves the path query parameter against the share
+// roots, writing an error response and returning false when it is missing or
+// not allowed.
+func (s *Server) resolveSharePath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	raw := r.URL.Query().Get("path")
+	if raw == "" {
+		respondWithError(w, http.StatusBadRequest, "path is required")
+		return "", false
+	}
+	path, err := filesystem.ResolvePath(raw, filesystem.ShareRoots(s.GetSharesCache(), s.GetDisksCache()))
+	if err != nil {
+		respondFilesystemError(w, err)
+		return "", false
+	}
+	return path, true
+}
+
+// queryInt parses an optional integer query parameter; absent is 0.
+func queryInt(r *http.Request, name string) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: must be an integer", name, v)
+	}
+	return n, nil
+}
+
+// respondFilesystemError maps filesystem service errors to HTTP statuses.
+func respondFilesystemError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, filesystem.ErrPathNotAllowed):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, filesystem.ErrPathNotFound), errors.Is(err, filesystem.ErrJobNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, filesystem.ErrTooManyJobs):
+		respondWithError(w, http.StatusTooManyRequests, err.Error())
+	default:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	}
+}
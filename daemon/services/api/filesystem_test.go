@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestHandleFilesystemAnalyze(t *testing.T) {
+	server, _ := setupTestServer()
+	mnt := t.TempDir()
+	share := filepath.Join(mnt, "user", "appdata")
+	if err := os.MkdirAll(filepath.Join(share, "plex"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(share, "plex", "db"), make([]byte, 4096), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	shares := []dto.ShareInfo{{Name: "appdata", Path: share}}
+	server.sharesCache.Store(&shares)
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{"invalid body", "{", http.StatusBadRequest},
+		{"missing path", `{}`, http.StatusBadRequest},
+		{"outside shares", `{"path":"` + mnt + `"}`, http.StatusForbidden},
+		{"not found", `{"path":"` + filepath.Join(share, "missing") + `"}`, http.StatusNotFound},
+		{"bad depth", `{"path":"` + share + `","depth":9}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/api/v1/filesystem/analyze", strings.NewReader(tt.body))
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+		if rr.Code != tt.wantCode {
+			t.Errorf("%s: status %d, want %d", tt.name, rr.Code, tt.wantCode)
+		}
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/filesystem/analyze", strings.NewReader(`{"path":"`+share+`"}`))
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("start: status %d: %s", rr.Code, rr.Body.String())
+	}
+	var job dto.FilesystemAnalyzeJob
+	if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.State == dto.AnalyzeJobRunning && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		rr = httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/filesystem/analyze/"+job.ID, nil))
+		if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if job.State != dto.AnalyzeJobCompleted || job.Result == nil || job.Result.Files != 1 {
+		t.Fatalf("job = %+v", job)
+	}
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/filesystem/analyze", nil))
+	var jobs []dto.FilesystemAnalyzeJob
+	if err := json.Unmarshal(rr.Body.Bytes(), &jobs); err != nil || len(jobs) != 1 {
+		t.Errorf("list = %s", rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("DELETE", "/api/v1/filesystem/analyze/unknown", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("cancel unknown job: status %d", rr.Code)
+	}
+}
+
+func TestHandleFilesBrowse(t *testing.T) {
+	server, _ := setupTestServer()
+	mnt := t.TempDir()
+	share := filepath.Join(mnt, "cache", "backups")
+	if err := os.MkdirAll(filepath.Join(share, "daily"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(share, "flash.zip"), []byte("zip-bytes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mnt, "secret"), []byte("no"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	shares := []dto.ShareInfo{{Name: "backups", Path: filepath.Join(mnt, "user", "backups")}}
+	disks := []dto.DiskInfo{{ID: "cache", MountPoint: filepath.Join(mnt, "cache")}}
+	server.sharesCache.Store(&shares)
+	server.disksCache.Store(&disks)
+
+	get := func(endpoint, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/files/"+endpoint+"?path="+url.QueryEscape(path), nil))
+		return rr
+	}
+
+	rr := get("list", share)
+	var listing dto.DirectoryListing
+	if err := json.Unmarshal(rr.Body.Bytes(), &listing); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("list: status %d, error %v", rr.Code, err)
+	}
+	if listing.Total != 2 || listing.Entries[0].Name != "daily" || listing.Entries[1].Name != "flash.zip" {
+		t.Errorf("listing = %+v", listing)
+	}
+
+	rr = get("stat", filepath.Join(share, "flash.zip"))
+	var entry dto.FileEntry
+	if err := json.Unmarshal(rr.Body.Bytes(), &entry); err != nil || entry.SizeBytes != 9 || entry.Type != "file" {
+		t.Errorf("stat: status %d, entry %+v", rr.Code, entry)
+	}
+
+	rr = get("download", filepath.Join(share, "flash.zip"))
+	if rr.Code != http.StatusOK || rr.Body.String() != "zip-bytes" {
+		t.Fatalf("download: status %d, body %q", rr.Code, rr.Body.String())
+	}
+	if cd := rr.Header().Get("Content-Disposition"); cd != `attachment; filename=flash.zip` {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+
+	for _, tt := range []struct {
+		endpoint, path string
+		wantCode       int
+	}{
+		{"list", "", http.StatusBadRequest},
+		{"list", filepath.Join(share, "flash.zip"), http.StatusBadRequest},
+		{"download", filepath.Join(share, "daily"), http.StatusBadRequest},
+		{"download", filepath.Join(share, "..", "..", "secret"), http.StatusForbidden},
+		{"stat", filepath.Join(share, "missing"), http.StatusNotFound},
+	} {
+		if rr := get(tt.endpoint, tt.path); rr.Code != tt.wantCode {
+			t.Errorf("%s %q: status %d, want %d", tt.endpoint, tt.path, rr.Code, tt.wantCode)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/fleet"
+)
+
+// SetFleet sets the fleet client backing the /fleet endpoints.
+func (s *Server) SetFleet(client *fleet.Client) {
+	s.fleetClient = client
+}
+
+// GetFleetOverview returns this server and all registered peers with their
+// current status.
+func (s *Server) GetFleetOverview(ctx context.Context) (*dto.FleetOverview, error) {
+	if s.fleetClient == nil {
+		return nil, errors.New("fleet mode not initialized")
+	}
+	return s.fleetClient.Overview(ctx, s.localFleetMember()), nil
+}
+
+// localFleetMember builds this server's fleet entry from the collector caches.
+func (s *Server) localFleetMember() dto.FleetMember {
+	member := dto.FleetMember{Name: "local"}
+	if system := s.GetSystemCache(); system != nil {
+		member.Hostname = system.Hostname
+		member.UnraidVer = system.Version
+		member.AgentVersion = system.AgentVersion
+		member.Uptime = system.Uptime
+		member.CPUUsage = &system.CPUUsage
+		member.RAMUsage = &system.RAMUsage
+	}
+	if array := s.GetArrayCache(); array != nil {
+		member.ArrayState = array.State
+		member.ArrayUsed = &array.UsedPercent
+	}
+	return member
+}
+
+// redactPeer hides a peer's API key before it is returned to clients.
+func redactPeer(peer dto.FleetPeer) dto.FleetPeer {
+	if peer.APIKey != "" {
+		peer.APIKey = dto.RedactedSecret
+	}
+	return peer
+}
+
+// handleFleetOverview godoc
+//
+//	@Summary		Get fleet overview
+//	@Description	Aggregated status of this server and every registered peer agent. Peers are queried concurrently with a 5 second timeout; unreachable peers are listed with an error.
+//	@Tags			Fleet
+//	@Produce		json
+//	@Success		200	{object}	dto.FleetOverview
+//	@Failure		503	{object}	dto.Response	"Fleet mode not initialized"
+//	@Router			/fleet [get]
+func (s *Server) handleFleetOverview(w http.ResponseWriter, r *http.Request) {
+	overview, err := s.GetFleetOverview(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+	respondJSON(w, http.StatusOK, overview)
+}
+
+// handleListFleetPeers godoc
+//
+//	@Summary		List fleet peers
+//	@Description	Registered peer agents. API keys are redacted.
+//	@Tags			Fleet
+//	@Produce		json
+//	@Success		200	{array}		dto.FleetPeer
+//	@Failure		503	{object}	dto.Response	"Fleet mode not initialized"
+//	@Router			/fleet/peers [get]
+func (s *Server) handleListFleetPeers(w http.ResponseWriter, _ *http.Request) {
+	if s.fleetClient == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+
+	peers := s.fleetClient.Store().GetPeers()
+	for i := range peers {
+		peers[i] = redactPeer(peers[i])
+	}
+	respondJSON(w, http.StatusOK, peers)
+}
+
+// handleAddFleetPeer godoc
+//
+//	@Summary		Register fleet peer
+//	@Description	Register (or replace) a peer agent by name. The url must be scheme://host[:port] of the peer's agent, optionally followed by the path prefix of a reverse proxy in front of it (without /api/v1); api_key, if set, is sent to the peer as a bearer token.
+//	@Tags			Fleet
+//	@Accept			json
+//	@Produce		json
+//	@Param			peer	body		dto.FleetPeer	true	"Peer agent"
+//	@Success		201		{object}	dto.Response	"Registered"
+//	@Failure		400		{object}	dto.Response	"Invalid request"
+//	@Failure		503		{object}	dto.Response	"Fleet mode not initialized"
+//	@Router			/fleet/peers [post]
+func (s *Server) handleAddFleetPeer(w http.ResponseWriter, r *http.Request) {
+	if s.fleetClient == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+
+	var peer dto.FleetPeer
+	if err := json.NewDecoder(r.Body).Decode(&peer); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+
+	if err := s.fleetClient.Store().AddPeer(peer); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.Response{Success: true, Message: "Fleet peer registered", Timestamp: time.Now()})
+}
+
+// handleDeleteFleetPeer godoc
+//
+//	@Summary		Remove fleet peer
+//	@Description	Unregister a peer agent
+//	@Tags			Fleet
+//	@Produce		json
+//	@Param			name	path		string			true	"Peer name"
+//	@Success		200		{object}	dto.Response	"Removed"
+//	@Failure		404		{object}	dto.Response	"Not found"
+//	@Failure		503		{object}	dto.Response	"Fleet mode not initialized"
+//	@Router			/fleet/peers/{name} [delete]
+func (s *Server) handleDeleteFleetPeer(w http.ResponseWriter, r *http.Request) {
+	if s.fleetClient == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+
+	if err := s.fleetClient.Store().DeletePeer(mux.Vars(r)["name"]); err != nil {
+		if errors.Is(err, fleet.ErrPeerNotFound) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Fleet peer removed", Timestamp: time.Now()})
+}
+
+// handleFleetProxy godoc
+//
+//	@Summary		Proxy request to fleet peer
+//	@Description	Forward any /api/v1 request to a registered peer, e.g. GET /fleet/backup-nas/docker or POST /fleet/backup-nas/docker/plex/restart. The peer's response is returned unchanged; 502 if the peer is unreachable.
+//	@Tags			Fleet
+//	@Produce		json
+//	@Param			server	path		string			true	"Peer name"
+//	@Param			path	path		string			true	"API path on the peer, relative to /api/v1"
+//	@Success		200		{object}	object			"Peer response"
+//	@Failure		400		{object}	dto.Response	"Invalid path"
+//	@Failure		404		{object}	dto.Response	"Peer not found"
+//	@Failure		502		{object}	dto.Response	"Peer unreachable"
+//	@Router			/fleet/{server}/{path} [get]
+func (s *Server) handleFleetProxy(w http.ResponseWriter, r *http.Request) {
+	if s.fleetClient == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := s.fleetClient.Proxy(w, r, vars["server"], vars["path"]); err != nil {
+		if errors.Is(err, fleet.ErrPeerNotFound) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// handleGetFleetSSHKey godoc
+//
+//	@Summary		Get fleet SSH public key
+//	@Description	The public key the agent uses to log in to peers over SSH. Add it to /root/.ssh/authorized_keys on each peer (on Unraid, also to /boot/config/ssh/root/authorized_keys so it survives a reboot).
+//	@Tags			Fleet
+//	@Produce		json
+//	@Success		200	{object}	dto.FleetSSHKey
+//	@Failure		404	{object}	dto.Response	"No key configured"
+//	@Failure		503	{object}	dto.Response	"Fleet mode not initialized"
+//	@Router			/fleet/ssh-key [get]
+func (s *Server) handleGetFleetSSHKey(w http.ResponseWriter, _ *http.Request) {
+	if s.fleetClient == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+
+	key, err := s.fleetClient.Relay().PublicKey()
+	if err != nil {
+		if errors.Is(err, fleet.ErrNoSSHKey) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, key)
+}
+
+// handleSetFleetSSHKey godoc
+//
+//	@Summary		Create or import fleet SSH key
+//	@Description	Generate a new ed25519 key for logging in to peers, or import an unencrypted private key. Replaces any existing key. The private key is stored with mode 0600 and never returned.
+//	@Tags			Fleet
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.FleetSSHKeyRequest	false	"Private key to import (omit to generate)"
+//	@Success		201		{object}	dto.FleetSSHKey
+//	@Failure		400		{object}	dto.Response	"Invalid key"
+//	@Failure		503		{object}	dto.Response	"Fleet mode not initialized"
+//	@Router			/fleet/ssh-key [post]
+func (s *Server) handleSetFleetSSHKey(w http.ResponseWriter, r *http.Request) {
+	if s.fleetClient == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+
+	var req dto.FleetSSHKeyRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+
+	key, err := s.fleetClient.Relay().SetKey(req.PrivateKey)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusCreated, key)
+}
+
+// handleDeleteFleetSSHKey godoc
+//
+//	@Summary		Delete fleet SSH key
+//	@Description	Remove the key used to log in to peers. Relay commands over SSH fail until a new key is created.
+//	@Tags			Fleet
+//	@Produce		json
+//	@Success		200	{object}	dto.Response	"Removed"
+//	@Failure		404	{object}	dto.Response	"No key configured"
+//	@Failure		503	{object}	dto.Response	"Fleet mode not initialized"
+//	@Router			/fleet/ssh-key [delete]
+func (s *Server) handleDeleteFleetSSHKey(w http.ResponseWriter, _ *http.Request) {
+	if s.fleetClient == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+
+	if err := s.fleetClient.Relay().DeleteKey(); err != nil {
+		if errors.Is(err, fleet.ErrNoSSHKey) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Fleet SSH key removed", Timestamp: time.Now()})
+}
+
+// handleFleetRelay godoc
+//
+//	@Summary		Run relay command on fleet peer
+//	@Description	Run one of a fixed set of commands on a peer, for servers that do not run the agent yet. "status" reads hostname, Unraid version, uptime and array state over SSH; "reboot" and "shutdown" run /sbin/reboot and /sbin/poweroff over SSH and require confirm, and a confirmation token when CONFIRM_DESTRUCTIVE is on; "wake" sends a Wake-on-LAN packet to the peer's mac_address. The peer's SSH host key is pinned on first connection. A failed remote command is reported in the result with success false.
+//	@Tags			Fleet
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string					true	"Peer name"
+//	@Param			request	body		dto.FleetRelayRequest	true	"Relay command"
+//	@Success		200		{object}	dto.FleetRelayResult
+//	@Failure		400		{object}	dto.Response	"Invalid command or peer not configured for it"
+//	@Failure		404		{object}	dto.Response	"Peer not found"
+//	@Failure		409		{object}	dto.Response	"No fleet SSH key configured"
+//	@Failure		412		{object}	dto.Response	"Invalid or expired confirmation token"
+//	@Failure		428		{object}	dto.ConfirmationChallenge	"Confirmation required"
+//	@Failure		503		{object}	dto.Response	"Fleet mode not initialized"
+//	@Router			/fleet/peers/{name}/relay [post]
+func (s *Server) handleFleetRelay(w http.ResponseWriter, r *http.Request) {
+	if s.fleetClient == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Fleet mode not initialized")
+		return
+	}
+
+	var req dto.FleetRelayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	name := mux.Vars(r)["name"]
+	if fleet.IsDestructiveRelayCommand(req.Command) {
+		if !req.Confirm {
+			respondWithError(w, http.StatusBadRequest, "confirm must be true to "+req.Command+" a peer")
+			return
+		}
+		if !s.confirmDestructive(w, r, fleetRelayAction(name, req.Command)) {
+			return
+		}
+	}
+
+	result, err := s.fleetClient.RunRelay(r.Context(), name, req.Command)
+	if err != nil {
+		switch {
+		case errors.Is(err, fleet.ErrPeerNotFound):
+			respondWithError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, fleet.ErrNoSSHKey):
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
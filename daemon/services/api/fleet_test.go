@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/fleet"
+)
+
+func TestFleetEndpoints(t *testing.T) {
+	server, _ := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/fleet", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 without fleet client, got %d", w.Code)
+	}
+
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"hostname":"nas2","path":"` + r.URL.Path + `"}`))
+	}))
+	defer peer.Close()
+
+	server.SetFleet(fleet.NewClient(fleet.NewStore(t.TempDir())))
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		code   int
+		want   string
+	}{
+		{"add peer", http.MethodPost, "/api/v1/fleet/peers", `{"name":"nas2","url":"` + peer.URL + `","api_key":"secret"}`, http.StatusCreated, "registered"},
+		{"add invalid peer", http.MethodPost, "/api/v1/fleet/peers", `{"name":"nas2","url":"ftp://x"}`, http.StatusBadRequest, "scheme"},
+		{"list redacts key", http.MethodGet, "/api/v1/fleet/peers", "", http.StatusOK, `"api_key":"********"`},
+		{"overview", http.MethodGet, "/api/v1/fleet", "", http.StatusOK, `"reachable":2`},
+		{"proxy", http.MethodGet, "/api/v1/fleet/nas2/docker", "", http.StatusOK, `"path":"/api/v1/docker"`},
+		{"proxy unknown peer", http.MethodGet, "/api/v1/fleet/other/docker", "", http.StatusNotFound, "peer not found"},
+		{"no ssh key", http.MethodGet, "/api/v1/fleet/ssh-key", "", http.StatusNotFound, "no fleet ssh key"},
+		{"add ssh-only peer", http.MethodPost, "/api/v1/fleet/peers", `{"name":"old-tower","ssh":{"host":"192.0.2.30"}}`, http.StatusCreated, "registered"},
+		{"relay without key", http.MethodPost, "/api/v1/fleet/peers/old-tower/relay", `{"command":"status"}`, http.StatusConflict, "no fleet ssh key"},
+		{"generate ssh key", http.MethodPost, "/api/v1/fleet/ssh-key", "", http.StatusCreated, `"public_key":"ssh-ed25519 `},
+		{"get ssh key", http.MethodGet, "/api/v1/fleet/ssh-key", "", http.StatusOK, `"fingerprint":"SHA256:`},
+		{"relay unknown command", http.MethodPost, "/api/v1/fleet/peers/old-tower/relay", `{"command":"rm -rf /"}`, http.StatusBadRequest, "unknown relay command"},
+		{"relay shutdown unconfirmed", http.MethodPost, "/api/v1/fleet/peers/old-tower/relay", `{"command":"shutdown"}`, http.StatusBadRequest, "confirm"},
+		{"relay wake without mac", http.MethodPost, "/api/v1/fleet/peers/old-tower/relay", `{"command":"wake"}`, http.StatusBadRequest, "mac_address"},
+		{"proxy ssh-only peer", http.MethodGet, "/api/v1/fleet/old-tower/docker", "", http.StatusBadRequest, "does not run the agent"},
+		{"delete ssh key", http.MethodDelete, "/api/v1/fleet/ssh-key", "", http.StatusOK, "removed"},
+		{"delete peer", http.MethodDelete, "/api/v1/fleet/peers/nas2", "", http.StatusOK, "removed"},
+		{"delete missing peer", http.MethodDelete, "/api/v1/fleet/peers/nas2", "", http.StatusNotFound, "peer not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			if w.Code != tt.code {
+				t.Fatalf("Expected %d, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("Expected body to contain %s, got %s", tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestFleetRelayConfirmation(t *testing.T) {
+	server := setupConfirmServer()
+	server.SetFleet(fleet.NewClient(fleet.NewStore(t.TempDir())))
+
+	// A closed port: the relay is attempted and reports the failed connection
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := closed.Addr().(*net.TCPAddr).Port
+	_ = closed.Close()
+
+	for _, setup := range []struct{ method, url, body string }{
+		{http.MethodPost, "/api/v1/fleet/peers", fmt.Sprintf(`{"name":"old-tower","ssh":{"host":"127.0.0.1","port":%d}}`, port)},
+		{http.MethodPost, "/api/v1/fleet/peers", `{"name":"backup","ssh":{"host":"127.0.0.1"}}`},
+		{http.MethodPost, "/api/v1/fleet/ssh-key", ""},
+	} {
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, httptest.NewRequest(setup.method, setup.url, strings.NewReader(setup.body)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("%s %s: %d %s", setup.method, setup.url, w.Code, w.Body.String())
+		}
+	}
+
+	relay := func(peer, command, token string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/fleet/peers/"+peer+"/relay",
+			strings.NewReader(`{"command":"`+command+`","confirm":true}`))
+		if token != "" {
+			req.Header.Set(dto.ConfirmTokenHeader, token)
+		}
+		return req
+	}
+
+	challenge := requestChallenge(t, server, relay("old-tower", "shutdown", ""))
+	if challenge.Action != "fleet_relay" || challenge.Target != "old-tower" {
+		t.Errorf("challenge = %+v", challenge)
+	}
+
+	// The token is tied to the peer and the command
+	for _, req := range []*http.Request{relay("backup", "shutdown", challenge.ConfirmToken), relay("old-tower", "reboot", challenge.ConfirmToken)} {
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		if w.Code != http.StatusPreconditionFailed {
+			t.Errorf("%s with another request's token: got %d, want 412", req.URL.Path, w.Code)
+		}
+	}
+
+	challenge = requestChallenge(t, server, relay("old-tower", "shutdown", ""))
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, relay("old-tower", "shutdown", challenge.ConfirmToken))
+	var result dto.FleetRelayResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("confirmed relay: %d %s", w.Code, w.Body.String())
+	}
+	if result.Peer != "old-tower" || result.Command != "shutdown" || result.Success {
+		t.Errorf("result = %+v, want a failed connection to the closed port", result)
+	}
+
+	// status is not destructive
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/fleet/peers/old-tower/relay", strings.NewReader(`{"command":"status"}`)))
+	if w.Code != http.StatusOK {
+		t.Errorf("status relay: got %d", w.Code)
+	}
+}
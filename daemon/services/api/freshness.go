@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// staleFactor is how many collector intervals may pass without an update
+// before cached data is reported as stale.
+const staleFactor = 3
+
+// Response headers carrying the freshness of cache endpoints; list bodies
+// are JSON arrays and cannot hold the fields themselves.
+const (
+	collectedAtHeader = "X-Collected-At"
+	staleHeader       = "X-Data-Stale"
+)
+
+// CacheFreshness reports when the caches fed by topics were last updated
+// and whether any of them is stale: its collector is disabled or has not
+// published for staleFactor intervals. CollectedAt is the oldest update, or
+// zero if none of the caches has been populated yet.
+func (s *Server) CacheFreshness(topics ...string) dto.CacheFreshness {
+	var f dto.CacheFreshness
+	now := time.Now()
+	for _, topic := range topics {
+		v, ok := s.CacheStore.updatedAt.Load(topic)
+		if !ok {
+			continue
+		}
+		updated := v.(time.Time)
+		if f.CollectedAt.IsZero() || updated.Before(f.CollectedAt) {
+			f.CollectedAt = updated
+		}
+		if s.topicStale(topic, now.Sub(updated)) {
+			f.Stale = true
+		}
+	}
+	return f
+}
+
+// topicStale reports whether a cache last updated age ago is stale.
+func (s *Server) topicStale(topic string, age time.Duration) bool {
+	name, ok := constants.TopicCollectors[topic]
+	if !ok || s.collectorManager == nil {
+		return false
+	}
+	status, err := s.collectorManager.GetStatus(name)
+	if err != nil {
+		return false
+	}
+	if !status.Enabled {
+		return true
+	}
+	return status.Interval > 0 && age > time.Duration(status.Interval*staleFactor)*time.Second
+}
+
+// freshnessWriter adds the freshness of a cache endpoint to its successful
+// responses: as headers, and as collected_at and stale fields when the body
+// is a JSON object. Such bodies are buffered until finish.
+type freshnessWriter struct {
+	http.ResponseWriter
+	fresh       dto.CacheFreshness
+	code        int
+	passthrough bool // body is written directly, not buffered
+	buf         bytes.Buffer
+}
+
+func (fw *freshnessWriter) WriteHeader(code int) {
+	if fw.code != 0 {
+		return
+	}
+	fw.code = code
+	if code == http.StatusOK || code == http.StatusNotModified {
+		h := fw.Header()
+		h.Set(collectedAtHeader, fw.fresh.CollectedAt.UTC().Format(time.RFC3339))
+		h.Set(staleHeader, strconv.FormatBool(fw.fresh.Stale))
+	}
+	if code != http.StatusOK || !strings.HasPrefix(fw.Header().Get("Content-Type"), "application/json") {
+		fw.passthrough = true
+		fw.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (fw *freshnessWriter) Write(b []byte) (int, error) {
+	if fw.code == 0 {
+		fw.WriteHeader(http.StatusOK)
+	}
+	if fw.passthrough {
+		return fw.ResponseWriter.Write(b)
+	}
+	return fw.buf.Write(b)
+}
+
+// finish writes a buffered body with the freshness fields added.
+func (fw *freshnessWriter) finish() {
+	if fw.code == 0 || fw.passthrough {
+		return
+	}
+	body := withFreshness(fw.buf.Bytes(), fw.fresh)
+	fw.Header().Del("Content-Length")
+	fw.ResponseWriter.WriteHeader(http.StatusOK)
+	_, _ = fw.ResponseWriter.Write(body)
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (fw *freshnessWriter) Unwrap() http.ResponseWriter {
+	return fw.ResponseWriter
+}
+
+// withFreshness inserts the freshness fields at the start of a JSON object
+// body. Other bodies are returned unchanged.
+func withFreshness(body []byte, fresh dto.CacheFreshness) []byte {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return body
+	}
+	fields, err := json.Marshal(fresh)
+	if err != nil {
+		return body
+	}
+	fields = fields[1 : len(fields)-1] // drop the braces
+	rest := trimmed[1:]
+	out := make([]byte, 0, len(body)+len(fields)+2)
+	out = append(out, '{')
+	out = append(out, fields...)
+	if r := bytes.TrimLeft(rest, " \t\r\n"); len(r) > 0 && r[0] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, rest...)
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestCacheFreshness(t *testing.T) {
+	server, _ := setupTestServerWithCollectorManager()
+	system := constants.TopicSystemUpdate.Name  // 15s interval
+	gpu := constants.TopicGPUMetricsUpdate.Name // disabled
+
+	if f := server.CacheFreshness(system); !f.CollectedAt.IsZero() || f.Stale {
+		t.Errorf("unpopulated cache: %+v", f)
+	}
+
+	server.markUpdated(system, time.Now().Add(-30*time.Second))
+	if f := server.CacheFreshness(system); f.CollectedAt.IsZero() || f.Stale {
+		t.Errorf("two intervals old: %+v", f)
+	}
+	server.markUpdated(system, time.Now().Add(-time.Minute))
+	if f := server.CacheFreshness(system); !f.Stale {
+		t.Errorf("four intervals old: %+v", f)
+	}
+
+	server.markUpdated(system, time.Now())
+	server.markUpdated(gpu, time.Now())
+	if f := server.CacheFreshness(system, gpu); !f.Stale {
+		t.Errorf("disabled collector should make the data stale: %+v", f)
+	}
+}
+
+func TestFreshnessOnCacheRoutes(t *testing.T) {
+	server, _ := setupTestServerWithCollectorManager()
+	collected := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", url, nil))
+		return rr
+	}
+
+	server.systemCache.Store(&dto.SystemInfo{Hostname: "tower"})
+	server.markUpdated(constants.TopicSystemUpdate.Name, collected)
+	rr := get("/api/v1/system")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d", rr.Code)
+	}
+	var body struct {
+		dto.CacheFreshness
+		Hostname string `json:"hostname"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON %s: %v", rr.Body, err)
+	}
+	if !body.CollectedAt.Equal(collected) || !body.Stale || body.Hostname != "tower" {
+		t.Errorf("unexpected body %s", rr.Body)
+	}
+	if rr.Header().Get(staleHeader) != "true" || rr.Header().Get(collectedAtHeader) == "" {
+		t.Errorf("headers %v", rr.Header())
+	}
+
+	// Lists carry the freshness in headers only
+	server.dockerCache.Store(&[]dto.ContainerInfo{{Name: "plex"}})
+	server.markUpdated(constants.TopicContainerListUpdate.Name, time.Now())
+	rr = get("/api/v1/docker")
+	var containers []dto.ContainerInfo
+	if err := json.Unmarshal(rr.Body.Bytes(), &containers); err != nil || len(containers) != 1 {
+		t.Errorf("list body changed: %s (%v)", rr.Body, err)
+	}
+	if rr.Header().Get(staleHeader) != "false" {
+		t.Errorf("%s = %q", staleHeader, rr.Header().Get(staleHeader))
+	}
+}
+
+func TestWithFreshness(t *testing.T) {
+	fresh := dto.CacheFreshness{CollectedAt: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	tests := map[string]string{
+		`{"a":1}`: `{"collected_at":"2026-10-17T09:00:00Z","stale":false,"a":1}`,
+		"{}\n":    `{"collected_at":"2026-10-17T09:00:00Z","stale":false}` + "\n",
+		`[1,2]`:   `[1,2]`,
+	}
+	for in, want := range tests {
+		if got := string(withFreshness([]byte(in), fresh)); got != want {
+			t.Errorf("withFreshness(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
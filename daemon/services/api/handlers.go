@@ -3,10 +3,14 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -23,15 +27,63 @@ import (
 // handleHealth godoc
 //
 //	@Summary		Health check
-//	@Description	Check if the API server is running and healthy
+//	@Description	Reports the state of the components the agent depends on: the Docker and libvirt sockets, the MQTT connection, the collectors and the space left in /var/log. Answers 503 when a critical component is down, so load balancers and uptime monitors detect partial failures; degraded states still answer 200. ready turns true once every enabled collector has run once after startup. The probes run at most once every 5 seconds. When AUTH_REQUIRED is set, callers without credentials only get the overall and per-component status, without messages.
 //	@Tags			System
 //	@Produce		json
-//	@Success		200	{object}	map[string]string	"Server is healthy"
+//	@Success		200	{object}	dto.HealthStatus	"Healthy or degraded"
+//	@Failure		503	{object}	dto.HealthStatus	"A critical component is down"
 //	@Router			/health [get]
-func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	health := s.cachedHealthStatus()
+	if s.ctx.Auth.Required && s.authenticate(r) == nil {
+		health = publicHealth(health)
+	}
+	code := http.StatusOK
+	if health.Status == dto.HealthDown {
+		code = http.StatusServiceUnavailable
+	}
+	respondJSON(w, code, health)
+}
+
+// handleHealthLive godoc
+//
+//	@Summary		Liveness probe
+//	@Description	Answers 200 as long as the API server is serving requests, including during startup. Use it to decide whether to restart the agent.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	map[string]string	"Agent is alive"
+//	@Router			/health/live [get]
+func (s *Server) handleHealthLive(w http.ResponseWriter, _ *http.Request) {
 	respondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
+// handleHealthReady godoc
+//
+//	@Summary		Readiness probe
+//	@Description	Answers 200 once the startup phase has finished: every enabled collector has run once, in dependency order, or the 60-second startup timeout has passed. Until then it answers 503 and lists the collectors still pending, so clients can wait before reading data.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.Readiness	"Agent is ready"
+//	@Failure		503	{object}	dto.Readiness	"Startup phase still running"
+//	@Router			/health/ready [get]
+func (s *Server) handleHealthReady(w http.ResponseWriter, _ *http.Request) {
+	r := s.readiness()
+	code := http.StatusOK
+	if !r.Ready {
+		code = http.StatusServiceUnavailable
+	}
+	respondJSON(w, code, r)
+}
+
+// readiness reports the collector manager's startup phase. Without a
+// manager that tracks it (tests, embedded use) the agent counts as ready.
+func (s *Server) readiness() dto.Readiness {
+	if rr, ok := s.collectorManager.(readinessReporter); ok {
+		return rr.Readiness()
+	}
+	return dto.Readiness{Ready: true, Timestamp: time.Now()}
+}
+
 // handleSystem godoc
 //
 //	@Summary		Get system information
@@ -58,13 +110,19 @@ func (s *Server) handleSystem(w http.ResponseWriter, _ *http.Request) {
 // handleSystemReboot godoc
 //
 //	@Summary		Reboot system
-//	@Description	Initiate a system reboot
+//	@Description	Initiate a system reboot. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token and the consequences; repeat it with the X-Confirm-Token header within 60 seconds to reboot.
 //	@Tags			System
 //	@Produce		json
-//	@Success		200	{object}	dto.Response	"Reboot initiated"
-//	@Failure		500	{object}	dto.Response	"Failed to initiate reboot"
+//	@Param			X-Confirm-Token	header		string						false	"Token from the confirmation challenge"
+//	@Success		200				{object}	dto.Response				"Reboot initiated"
+//	@Failure		412				{object}	dto.Response				"Invalid or expired confirmation token"
+//	@Failure		428				{object}	dto.ConfirmationChallenge	"Confirmation required"
+//	@Failure		500				{object}	dto.Response				"Failed to initiate reboot"
 //	@Router			/system/reboot [post]
-func (s *Server) handleSystemReboot(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleSystemReboot(w http.ResponseWriter, r *http.Request) {
+	if !s.confirmDestructive(w, r, s.rebootAction()) {
+		return
+	}
 	logger.Info("API: System reboot requested")
 
 	systemCtrl := controllers.NewSystemController(s.ctx)
@@ -90,13 +148,19 @@ func (s *Server) handleSystemReboot(w http.ResponseWriter, _ *http.Request) {
 // handleSystemShutdown godoc
 //
 //	@Summary		Shutdown system
-//	@Description	Initiate a system shutdown
+//	@Description	Initiate a system shutdown. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token and the consequences; repeat it with the X-Confirm-Token header within 60 seconds to shut down.
 //	@Tags			System
 //	@Produce		json
-//	@Success		200	{object}	dto.Response	"Shutdown initiated"
-//	@Failure		500	{object}	dto.Response	"Failed to initiate shutdown"
+//	@Param			X-Confirm-Token	header		string						false	"Token from the confirmation challenge"
+//	@Success		200				{object}	dto.Response				"Shutdown initiated"
+//	@Failure		412				{object}	dto.Response				"Invalid or expired confirmation token"
+//	@Failure		428				{object}	dto.ConfirmationChallenge	"Confirmation required"
+//	@Failure		500				{object}	dto.Response				"Failed to initiate shutdown"
 //	@Router			/system/shutdown [post]
-func (s *Server) handleSystemShutdown(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleSystemShutdown(w http.ResponseWriter, r *http.Request) {
+	if !s.confirmDestructive(w, r, s.shutdownAction("system_shutdown")) {
+		return
+	}
 	logger.Info("API: System shutdown requested")
 
 	systemCtrl := controllers.NewSystemController(s.ctx)
@@ -119,6 +183,61 @@ func (s *Server) handleSystemShutdown(w http.ResponseWriter, _ *http.Request) {
 	})
 }
 
+// handleSystemOrchestratedShutdown godoc
+//
+//	@Summary		Orchestrated shutdown
+//	@Description	Safely power off the server: shut down running VMs, stop containers in reverse autostart order, stop the array, sync disks, then power off. Runs in the background with per-step progress on the shutdown_progress WebSocket topic. The sequence is aborted (server stays on) if the array cannot be stopped. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token; repeat it with the X-Confirm-Token header within 60 seconds.
+//	@Tags			System
+//	@Accept			json
+//	@Produce		json
+//	@Param			request			body		dto.OrchestratedShutdownRequest	true	"Confirmation"
+//	@Param			X-Confirm-Token	header		string							false	"Token from the confirmation challenge"
+//	@Success		202				{object}	dto.Response					"Shutdown sequence started"
+//	@Failure		400				{object}	dto.Response					"Invalid request or missing confirmation"
+//	@Failure		409				{object}	dto.Response					"Shutdown already in progress"
+//	@Failure		412				{object}	dto.Response					"Invalid or expired confirmation token"
+//	@Failure		428				{object}	dto.ConfirmationChallenge		"Confirmation required"
+//	@Router			/system/shutdown/orchestrated [post]
+func (s *Server) handleSystemOrchestratedShutdown(w http.ResponseWriter, r *http.Request) {
+	var req dto.OrchestratedShutdownRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   fmt.Sprintf("Invalid request body: %v", err),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	if !req.Confirm {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   "confirm must be true to shut down the server",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	if !s.confirmDestructive(w, r, s.shutdownAction("system_shutdown_orchestrated")) {
+		return
+	}
+
+	logger.InfoContext(r.Context(), "API: Orchestrated shutdown requested")
+	systemCtrl := controllers.NewSystemController(s.ctx)
+	if err := systemCtrl.StartOrchestratedShutdown(); err != nil {
+		respondJSON(w, http.StatusConflict, dto.Response{
+			Success:   false,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, dto.Response{
+		Success:   true,
+		Message:   "Orchestrated shutdown started; follow progress on the shutdown_progress WebSocket topic",
+		Timestamp: time.Now(),
+	})
+}
+
 // handleArray godoc
 //
 //	@Summary		Get array status
@@ -144,12 +263,16 @@ func (s *Server) handleArray(w http.ResponseWriter, _ *http.Request) {
 // handleDisks godoc
 //
 //	@Summary		Get all disks
-//	@Description	Retrieve information about all disks including SMART data
+//	@Description	Retrieve information about all disks including SMART data. Supports field selection and pagination; the X-Total-Count header holds the number of disks.
 //	@Tags			Disks
 //	@Produce		json
-//	@Success		200	{array}	dto.DiskInfo	"List of disks"
+//	@Param			fields	query		string	false	"Comma-separated JSON fields to return per item (e.g. name,state)"
+//	@Param			limit	query		int		false	"Maximum items to return (default all)"
+//	@Param			offset	query		int		false	"Items to skip (default 0)"
+//	@Success		200		{array}		dto.DiskInfo	"List of disks"
+//	@Failure		400		{object}	dto.Response	"Invalid fields, limit or offset"
 //	@Router			/disks [get]
-func (s *Server) handleDisks(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleDisks(w http.ResponseWriter, r *http.Request) {
 	// Get latest disk list from cache
 	disks := s.GetDisksCache()
 
@@ -157,7 +280,7 @@ func (s *Server) handleDisks(w http.ResponseWriter, _ *http.Request) {
 		disks = []dto.DiskInfo{}
 	}
 
-	respondJSON(w, http.StatusOK, disks)
+	respondList(w, r, disks)
 }
 
 // handleDisk godoc
@@ -173,7 +296,7 @@ func (s *Server) handleDisks(w http.ResponseWriter, _ *http.Request) {
 func (s *Server) handleDisk(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	diskID := vars["id"]
-	logger.Debug("API: Getting disk info for %s", diskID)
+	logger.DebugContext(r.Context(), "API: Getting disk info for %s", diskID)
 
 	disks := s.GetDisksCache()
 
@@ -215,12 +338,16 @@ func (s *Server) handleShares(w http.ResponseWriter, _ *http.Request) {
 // handleDockerList godoc
 //
 //	@Summary		Get all Docker containers
-//	@Description	Retrieve information about all Docker containers including stats
+//	@Description	Retrieve information about all Docker containers including stats. Supports field selection and pagination; the X-Total-Count header holds the number of containers.
 //	@Tags			Docker
 //	@Produce		json
-//	@Success		200	{array}	dto.ContainerInfo	"List of containers"
+//	@Param			fields	query		string	false	"Comma-separated JSON fields to return per item (e.g. name,state)"
+//	@Param			limit	query		int		false	"Maximum items to return (default all)"
+//	@Param			offset	query		int		false	"Items to skip (default 0)"
+//	@Success		200		{array}		dto.ContainerInfo	"List of containers"
+//	@Failure		400		{object}	dto.Response		"Invalid fields, limit or offset"
 //	@Router			/docker [get]
-func (s *Server) handleDockerList(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleDockerList(w http.ResponseWriter, r *http.Request) {
 	// Get latest container list from cache
 	containers := s.GetDockerCache()
 
@@ -228,7 +355,7 @@ func (s *Server) handleDockerList(w http.ResponseWriter, _ *http.Request) {
 		containers = []dto.ContainerInfo{}
 	}
 
-	respondJSON(w, http.StatusOK, containers)
+	respondList(w, r, containers)
 }
 
 // handleDockerInfo godoc
@@ -244,7 +371,7 @@ func (s *Server) handleDockerList(w http.ResponseWriter, _ *http.Request) {
 func (s *Server) handleDockerInfo(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	containerID := vars["id"]
-	logger.Debug("API: Getting container info for %s", containerID)
+	logger.DebugContext(r.Context(), "API: Getting container info for %s", containerID)
 
 	containers := s.GetDockerCache()
 
@@ -267,12 +394,16 @@ func (s *Server) handleDockerInfo(w http.ResponseWriter, r *http.Request) {
 // handleVMList godoc
 //
 //	@Summary		Get all VMs
-//	@Description	Retrieve information about all virtual machines
+//	@Description	Retrieve information about all virtual machines. Supports field selection and pagination; the X-Total-Count header holds the number of VMs.
 //	@Tags			VMs
 //	@Produce		json
-//	@Success		200	{array}	dto.VMInfo	"List of VMs"
+//	@Param			fields	query		string	false	"Comma-separated JSON fields to return per item (e.g. name,state)"
+//	@Param			limit	query		int		false	"Maximum items to return (default all)"
+//	@Param			offset	query		int		false	"Items to skip (default 0)"
+//	@Success		200		{array}		dto.VMInfo		"List of VMs"
+//	@Failure		400		{object}	dto.Response	"Invalid fields, limit or offset"
 //	@Router			/vm [get]
-func (s *Server) handleVMList(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleVMList(w http.ResponseWriter, r *http.Request) {
 	// Get latest VM list from cache
 	vms := s.GetVMsCache()
 
@@ -280,7 +411,7 @@ func (s *Server) handleVMList(w http.ResponseWriter, _ *http.Request) {
 		vms = []dto.VMInfo{}
 	}
 
-	respondJSON(w, http.StatusOK, vms)
+	respondList(w, r, vms)
 }
 
 // handleVMInfo godoc
@@ -296,7 +427,7 @@ func (s *Server) handleVMList(w http.ResponseWriter, _ *http.Request) {
 func (s *Server) handleVMInfo(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	vmID := vars["id"]
-	logger.Debug("API: Getting VM info for %s", vmID)
+	logger.DebugContext(r.Context(), "API: Getting VM info for %s", vmID)
 
 	vms := s.GetVMsCache()
 
@@ -420,7 +551,7 @@ func (s *Server) handleDockerOperation(w http.ResponseWriter, r *http.Request, o
 
 	// Validate container ID format
 	if err := lib.ValidateContainerID(containerID); err != nil {
-		logger.Warning("Invalid container ID for %s operation: %s - %v", operation, containerID, err)
+		logger.WarningContext(r.Context(), "Invalid container ID for %s operation: %s - %v", operation, containerID, err)
 		respondJSON(w, http.StatusBadRequest, dto.Response{
 			Success:   false,
 			Message:   err.Error(),
@@ -429,10 +560,10 @@ func (s *Server) handleDockerOperation(w http.ResponseWriter, r *http.Request, o
 		return
 	}
 
-	logger.Info("%s container %s", operation, containerID)
+	logger.InfoContext(r.Context(), "%s container %s", operation, containerID)
 
 	if err := operationFunc(containerID); err != nil {
-		logger.Error("Failed to %s container %s: %v", operation, containerID, err)
+		logger.ErrorContext(r.Context(), "Failed to %s container %s: %v", operation, containerID, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   fmt.Sprintf("Failed to %s container", operation),
@@ -457,7 +588,7 @@ func (s *Server) handleVMOperation(w http.ResponseWriter, r *http.Request, opera
 
 	// Validate VM name format
 	if err := lib.ValidateVMName(vmName); err != nil {
-		logger.Warning("Invalid VM name for %s operation: %s - %v", operation, vmName, err)
+		logger.WarningContext(r.Context(), "Invalid VM name for %s operation: %s - %v", operation, vmName, err)
 		respondJSON(w, http.StatusBadRequest, dto.Response{
 			Success:   false,
 			Message:   err.Error(),
@@ -466,10 +597,10 @@ func (s *Server) handleVMOperation(w http.ResponseWriter, r *http.Request, opera
 		return
 	}
 
-	logger.Info("%s VM %s", operation, vmName)
+	logger.InfoContext(r.Context(), "%s VM %s", operation, vmName)
 
 	if err := operationFunc(vmName); err != nil {
-		logger.Error("Failed to %s VM %s: %v", operation, vmName, err)
+		logger.ErrorContext(r.Context(), "Failed to %s VM %s: %v", operation, vmName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   fmt.Sprintf("Failed to %s VM", operation),
@@ -503,7 +634,7 @@ func (s *Server) handleDockerRemove(w http.ResponseWriter, r *http.Request) {
 	containerID := vars["id"]
 
 	if err := lib.ValidateContainerID(containerID); err != nil {
-		logger.Warning("Invalid container ID for remove operation: %s - %v", containerID, err)
+		logger.WarningContext(r.Context(), "Invalid container ID for remove operation: %s - %v", containerID, err)
 		respondJSON(w, http.StatusBadRequest, dto.Response{
 			Success:   false,
 			Message:   err.Error(),
@@ -531,13 +662,13 @@ func (s *Server) handleDockerRemove(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Info("Removing container %s (remove_image=%v)", containerID, req.RemoveImage)
+	logger.InfoContext(r.Context(), "Removing container %s (remove_image=%v)", containerID, req.RemoveImage)
 
 	controller := controllers.NewDockerController()
 	defer controller.Close() //nolint:errcheck
 
 	if err := controller.Remove(containerID, req.RemoveImage); err != nil {
-		logger.Error("Failed to remove container %s: %v", containerID, err)
+		logger.ErrorContext(r.Context(), "Failed to remove container %s: %v", containerID, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to remove container",
@@ -571,7 +702,7 @@ func (s *Server) handleDockerAutostart(w http.ResponseWriter, r *http.Request) {
 	containerID := vars["id"]
 
 	if err := lib.ValidateContainerID(containerID); err != nil {
-		logger.Warning("Invalid container ID for autostart operation: %s - %v", containerID, err)
+		logger.WarningContext(r.Context(), "Invalid container ID for autostart operation: %s - %v", containerID, err)
 		respondJSON(w, http.StatusBadRequest, dto.Response{
 			Success:   false,
 			Message:   err.Error(),
@@ -590,13 +721,13 @@ func (s *Server) handleDockerAutostart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Info("Setting autostart=%v for container %s", req.Enabled, containerID)
+	logger.InfoContext(r.Context(), "Setting autostart=%v for container %s", req.Enabled, containerID)
 
 	controller := controllers.NewDockerController()
 	defer controller.Close() //nolint:errcheck
 
 	if err := controller.SetAutostart(containerID, req.Enabled); err != nil {
-		logger.Error("Failed to set autostart for container %s: %v", containerID, err)
+		logger.ErrorContext(r.Context(), "Failed to set autostart for container %s: %v", containerID, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to update autostart",
@@ -616,6 +747,149 @@ func (s *Server) handleDockerAutostart(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleDockerAutostartList godoc
+//
+//	@Summary		Get container autostart order
+//	@Description	Returns the Unraid container autostart configuration: autostarted containers in start order with their wait times, followed by the remaining containers with autostart disabled.
+//	@Tags			Docker
+//	@Produce		json
+//	@Success		200	{object}	dto.DockerAutostart	"Autostart configuration"
+//	@Failure		500	{object}	dto.Response		"Failed to read autostart configuration"
+//	@Router			/docker/autostart [get]
+func (s *Server) handleDockerAutostartList(w http.ResponseWriter, _ *http.Request) {
+	containers := s.GetDockerCache()
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
+
+	controller := controllers.NewDockerController()
+	defer controller.Close() //nolint:errcheck
+
+	autostart, err := controller.GetAutostart(names)
+	if err != nil {
+		logger.Error("API: Failed to read autostart configuration: %v", err)
+		respondJSON(w, http.StatusInternalServerError, dto.Response{
+			Success:   false,
+			Message:   "Failed to read autostart configuration",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, autostart)
+}
+
+// handleDockerAutostartOrder godoc
+//
+//	@Summary		Set container autostart order
+//	@Description	Replace the Unraid container autostart list. Listed containers are started at boot in the given order, waiting each entry's wait seconds before starting the next; all other containers have autostart disabled. An empty list disables autostart for every container. The change persists across reboots.
+//	@Tags			Docker
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.DockerAutostartRequest	true	"Containers in start order"
+//	@Success		200		{object}	dto.DockerAutostart			"Autostart configuration after the update"
+//	@Failure		400		{object}	dto.Response				"Invalid request body"
+//	@Failure		500		{object}	dto.Response				"Failed to update autostart"
+//	@Router			/docker/autostart [put]
+func (s *Server) handleDockerAutostartOrder(w http.ResponseWriter, r *http.Request) {
+	var req dto.DockerAutostartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   fmt.Sprintf("Invalid request body: %v", err),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if err := lib.ValidateAutostartOrder(req); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	controller := controllers.NewDockerController()
+	defer controller.Close() //nolint:errcheck
+
+	if err := controller.SetAutostartOrder(req.Containers); err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to set autostart order: %v", err)
+		respondJSON(w, http.StatusInternalServerError, dto.Response{
+			Success:   false,
+			Message:   "Failed to update autostart",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	s.handleDockerAutostartList(w, r)
+}
+
+// handleDockerLimits godoc
+//
+//	@Summary		Update container resource limits
+//	@Description	Change the CPU shares, CPU quota/period, memory limit and restart policy of a container in place (docker update). Only the fields in the request body are changed. Changes are lost when Unraid recreates the container from its template.
+//	@Tags			Docker
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Container ID or name"
+//	@Param			request	body		dto.ContainerLimitsRequest	true	"Limits to change"
+//	@Success		200		{object}	dto.ContainerLimits			"Limits after the update"
+//	@Failure		400		{object}	dto.Response				"Invalid container reference or limits"
+//	@Failure		500		{object}	dto.Response				"Failed to update limits"
+//	@Router			/docker/{id}/limits [patch]
+func (s *Server) handleDockerLimits(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	containerRef := vars["id"]
+
+	if err := lib.ValidateContainerRef(containerRef); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	var req dto.ContainerLimitsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   fmt.Sprintf("Invalid request body: %v", err),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if err := lib.ValidateContainerLimits(req); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	controller := controllers.NewDockerController()
+	defer controller.Close() //nolint:errcheck
+
+	limits, err := controller.UpdateLimits(containerRef, req)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to update limits for container %s: %v", containerRef, err)
+		respondJSON(w, http.StatusInternalServerError, dto.Response{
+			Success:   false,
+			Message:   "Failed to update container limits",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, limits)
+}
+
 // handleDockerPortConflicts godoc
 //
 //	@Summary		List Docker port conflicts
@@ -811,15 +1085,26 @@ func (s *Server) handleVMResume(w http.ResponseWriter, r *http.Request) {
 // handleVMHibernate godoc
 //
 //	@Summary		Hibernate VM
-//	@Description	Hibernate a specific virtual machine by name
+//	@Description	Hibernate a specific virtual machine by name. With async=true the VM is hibernated in a background job tracked under /jobs.
 //	@Tags			VMs
 //	@Produce		json
 //	@Param			name	path		string	true	"VM name"
+//	@Param			async	query		boolean	false	"Run as a background job and return 202 with the job"
 //	@Success		200		{object}	dto.Response	"VM hibernated"
+//	@Success		202		{object}	dto.Job			"Hibernation job queued"
 //	@Failure		400		{object}	dto.Response	"Invalid VM name"
 //	@Failure		500		{object}	dto.Response	"Failed to hibernate VM"
 //	@Router			/vm/{name}/hibernate [post]
 func (s *Server) handleVMHibernate(w http.ResponseWriter, r *http.Request) {
+	if wantsAsync(r) {
+		name := mux.Vars(r)["name"]
+		if err := lib.ValidateVMName(name); err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondJobAccepted(w, s.jobManager.Submit(s.detachedContext(r), dto.JobKindVMHibernate, name, vmHibernateJob(name)))
+		return
+	}
 	controller := controllers.NewVMController()
 	s.handleVMOperation(w, r, "hibernated", controller.Hibernate)
 }
@@ -858,7 +1143,7 @@ func (s *Server) handleVMReset(w http.ResponseWriter, r *http.Request) {
 	vmName := vars["name"]
 
 	if err := lib.ValidateVMName(vmName); err != nil {
-		logger.Warning("Invalid VM name for reset operation: %s - %v", vmName, err)
+		logger.WarningContext(r.Context(), "Invalid VM name for reset operation: %s - %v", vmName, err)
 		respondJSON(w, http.StatusBadRequest, dto.Response{
 			Success:   false,
 			Message:   err.Error(),
@@ -886,11 +1171,11 @@ func (s *Server) handleVMReset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Info("Resetting VM %s", vmName)
+	logger.InfoContext(r.Context(), "Resetting VM %s", vmName)
 
 	controller := controllers.NewVMController()
 	if err := controller.Reset(vmName); err != nil {
-		logger.Error("Failed to reset VM %s: %v", vmName, err)
+		logger.ErrorContext(r.Context(), "Failed to reset VM %s: %v", vmName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to reset VM",
@@ -906,6 +1191,100 @@ func (s *Server) handleVMReset(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleVMUSBAttach godoc
+//
+//	@Summary		Hot-plug a USB device into a VM
+//	@Description	Attach a host USB device to a running VM without restarting it. The device is identified as vendor:product (see host_usb_devices in GET /settings/vm). The change applies to the live VM only and is undone when the VM shuts down.
+//	@Tags			VMs
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string					true	"VM name"
+//	@Param			request	body		dto.VMUSBDeviceRequest	true	"USB device"
+//	@Success		200		{object}	dto.Response			"USB device attached"
+//	@Failure		400		{object}	dto.Response			"Invalid VM name or device ID"
+//	@Failure		500		{object}	dto.Response			"Failed to attach USB device (for example, VM not running)"
+//	@Router			/vm/{name}/usb/attach [post]
+func (s *Server) handleVMUSBAttach(w http.ResponseWriter, r *http.Request) {
+	s.handleVMUSBHotplug(w, r, true)
+}
+
+// handleVMUSBDetach godoc
+//
+//	@Summary		Unplug a USB device from a VM
+//	@Description	Detach a USB device from a running VM without changing the VM's saved definition.
+//	@Tags			VMs
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string					true	"VM name"
+//	@Param			request	body		dto.VMUSBDeviceRequest	true	"USB device"
+//	@Success		200		{object}	dto.Response			"USB device detached"
+//	@Failure		400		{object}	dto.Response			"Invalid VM name or device ID"
+//	@Failure		500		{object}	dto.Response			"Failed to detach USB device (for example, VM not running)"
+//	@Router			/vm/{name}/usb/detach [post]
+func (s *Server) handleVMUSBDetach(w http.ResponseWriter, r *http.Request) {
+	s.handleVMUSBHotplug(w, r, false)
+}
+
+// handleVMUSBHotplug validates a USB hotplug request and attaches or detaches
+// the device.
+func (s *Server) handleVMUSBHotplug(w http.ResponseWriter, r *http.Request, attach bool) {
+	vmName := mux.Vars(r)["name"]
+	action := "detach"
+	if attach {
+		action = "attach"
+	}
+
+	if err := lib.ValidateVMName(vmName); err != nil {
+		logger.WarningContext(r.Context(), "Invalid VM name for USB %s: %s - %v", action, vmName, err)
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	var req dto.VMUSBDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   fmt.Sprintf("Invalid request body: %v", err),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if err := lib.ValidateUSBDeviceID(req.DeviceID); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	controller := controllers.NewVMController()
+	operation := controller.DetachUSB
+	if attach {
+		operation = controller.AttachUSB
+	}
+	if err := operation(vmName, req.DeviceID); err != nil {
+		logger.ErrorContext(r.Context(), "Failed to %s USB device %s on VM %s: %v", action, req.DeviceID, vmName, err)
+		respondJSON(w, http.StatusInternalServerError, dto.Response{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to %s USB device", action),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{
+		Success:   true,
+		Message:   fmt.Sprintf("USB device %s %sed on VM %s", req.DeviceID, action, vmName),
+		Timestamp: time.Now(),
+	})
+}
+
 // handleArrayStart godoc
 //
 //	@Summary		Start array
@@ -941,13 +1320,19 @@ func (s *Server) handleArrayStart(w http.ResponseWriter, _ *http.Request) {
 // handleArrayStop godoc
 //
 //	@Summary		Stop array
-//	@Description	Stop the Unraid array
+//	@Description	Stop the Unraid array. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token and the consequences; repeat it with the X-Confirm-Token header within 60 seconds to stop the array.
 //	@Tags			Array
 //	@Produce		json
-//	@Success		200	{object}	dto.Response	"Array stopped"
-//	@Failure		500	{object}	dto.Response	"Failed to stop array"
+//	@Param			X-Confirm-Token	header		string						false	"Token from the confirmation challenge"
+//	@Success		200				{object}	dto.Response				"Array stopped"
+//	@Failure		412				{object}	dto.Response				"Invalid or expired confirmation token"
+//	@Failure		428				{object}	dto.ConfirmationChallenge	"Confirmation required"
+//	@Failure		500				{object}	dto.Response				"Failed to stop array"
 //	@Router			/array/stop [post]
-func (s *Server) handleArrayStop(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleArrayStop(w http.ResponseWriter, r *http.Request) {
+	if !s.confirmDestructive(w, r, s.arrayStopAction()) {
+		return
+	}
 	logger.Info("API: Stopping array")
 
 	arrayCtrl := controllers.NewArrayController(s.ctx)
@@ -973,23 +1358,29 @@ func (s *Server) handleArrayStop(w http.ResponseWriter, _ *http.Request) {
 // handleParityCheckStart godoc
 //
 //	@Summary		Start parity check
-//	@Description	Start a parity check operation, optionally with correction
+//	@Description	Start a parity check operation, optionally with correction. With async=true the check is started in a background job that follows its progress until it finishes; cancelling the job stops the check.
 //	@Tags			Array
 //	@Produce		json
 //	@Param			correcting	query		boolean	false	"Enable correcting mode"
+//	@Param			async		query		boolean	false	"Run as a background job and return 202 with the job"
 //	@Success		200			{object}	dto.Response	"Parity check started"
+//	@Success		202			{object}	dto.Job			"Parity check job queued"
 //	@Failure		500			{object}	dto.Response	"Failed to start parity check"
 //	@Router			/array/parity-check/start [post]
 func (s *Server) handleParityCheckStart(w http.ResponseWriter, r *http.Request) {
 	// Read optional 'correcting' parameter from query
 	correcting := r.URL.Query().Get("correcting") == "true"
-	logger.Info("API: Starting parity check (correcting: %v)", correcting)
+	if wantsAsync(r) {
+		respondJobAccepted(w, s.jobManager.Submit(s.detachedContext(r), dto.JobKindParityCheck, "", s.parityCheckJob(correcting)))
+		return
+	}
+	logger.InfoContext(r.Context(), "API: Starting parity check (correcting: %v)", correcting)
 
 	arrayCtrl := controllers.NewArrayController(s.ctx)
-	err := arrayCtrl.StartParityCheck(correcting)
+	err := arrayCtrl.StartParityCheck(correcting, dto.ParityTriggerAPI)
 
 	if err != nil {
-		logger.Error("API: Failed to start parity check: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to start parity check: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to start parity check",
@@ -1129,6 +1520,48 @@ func (s *Server) handleParityCheckHistory(w http.ResponseWriter, _ *http.Request
 	respondJSON(w, http.StatusOK, history)
 }
 
+// handleParityCheckNote godoc
+//
+//	@Summary		Annotate a parity check
+//	@Description	Set or clear the note on a parity check history record (e.g. "after disk swap"). Notes are stored by the agent alongside parity-checks.log, which is not modified.
+//	@Tags			Array
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Record ID from the parity check history"
+//	@Param			request	body		dto.ParityCheckNoteRequest	true	"Note; empty clears it"
+//	@Success		200		{object}	dto.ParityCheckRecord		"Annotated record"
+//	@Failure		400		{object}	dto.Response				"Invalid request body or note too long"
+//	@Failure		404		{object}	dto.Response				"Record not found"
+//	@Failure		500		{object}	dto.Response				"Failed to save note"
+//	@Router			/array/parity-check/history/{id}/note [post]
+func (s *Server) handleParityCheckNote(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req dto.ParityCheckNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(note) > collectors.MaxParityNoteLength {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("note exceeds %d characters", collectors.MaxParityNoteLength))
+		return
+	}
+
+	record, err := collectors.NewParityCollector().SetNote(id, note)
+	if errors.Is(err, collectors.ErrParityRecordNotFound) {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to annotate parity check %s: %v", id, err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to save note")
+		return
+	}
+
+	s.parityHistoryCache.Store(nil)
+	respondJSON(w, http.StatusOK, record)
+}
+
 // handleClearDiskStats godoc
 //
 //	@Summary		Clear disk statistics
@@ -1173,11 +1606,11 @@ func (s *Server) handleClearDiskStats(w http.ResponseWriter, _ *http.Request) {
 func (s *Server) handleShareConfig(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	shareName := vars["name"]
-	logger.Debug("API: Getting share config for %s", shareName)
+	logger.DebugContext(r.Context(), "API: Getting share config for %s", shareName)
 
 	// Validate share name to prevent path traversal attacks
 	if err := lib.ValidateShareName(shareName); err != nil {
-		logger.Error("API: Invalid share name: %v", err)
+		logger.ErrorContext(r.Context(), "API: Invalid share name: %v", err)
 		respondJSON(w, http.StatusBadRequest, dto.Response{
 			Success:   false,
 			Message:   fmt.Sprintf("Invalid share name: %v", err),
@@ -1190,7 +1623,7 @@ func (s *Server) handleShareConfig(w http.ResponseWriter, r *http.Request) {
 	config, err := configCollector.GetShareConfig(shareName)
 
 	if err != nil {
-		logger.Error("API: Failed to get share config: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to get share config: %v", err)
 		respondJSON(w, http.StatusNotFound, dto.Response{
 			Success:   false,
 			Message:   "Failed to get share config",
@@ -1202,6 +1635,40 @@ func (s *Server) handleShareConfig(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, config)
 }
 
+// handleShareDistribution godoc
+//
+//	@Summary		Get share distribution across disks
+//	@Description	Show how much of a user share is stored on each array disk and pool. Results are cached per disk and only rescanned when the disk's used space changes; disks in standby are not spun up unless spinup=true.
+//	@Tags			Shares
+//	@Produce		json
+//	@Param			name	path		string	true	"Share name"
+//	@Param			spinup	query		bool	false	"Scan disks that are in standby (spins them up)"
+//	@Success		200		{object}	dto.ShareDistribution	"Per-disk usage of the share"
+//	@Failure		400		{object}	dto.Response			"Invalid share name"
+//	@Failure		404		{object}	dto.Response			"Share not found"
+//	@Failure		500		{object}	dto.Response			"Failed to scan share"
+//	@Router			/shares/{name}/distribution [get]
+func (s *Server) handleShareDistribution(w http.ResponseWriter, r *http.Request) {
+	shareName := mux.Vars(r)["name"]
+	if err := lib.ValidateShareName(shareName); err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid share name: %v", err))
+		return
+	}
+	if !slices.ContainsFunc(s.GetSharesCache(), func(sh dto.ShareInfo) bool { return sh.Name == shareName }) {
+		respondWithError(w, http.StatusNotFound, "Share not found")
+		return
+	}
+
+	spinUp := r.URL.Query().Get("spinup") == "true"
+	dist, err := s.shareDistrib.Distribution(r.Context(), shareName, s.GetDisksCache(), spinUp, time.Now())
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to compute distribution of share %s: %v", shareName, err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to scan share")
+		return
+	}
+	respondJSON(w, http.StatusOK, dist)
+}
+
 // handleNetworkConfig godoc
 //
 //	@Summary		Get network interface configuration
@@ -1215,13 +1682,13 @@ func (s *Server) handleShareConfig(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleNetworkConfig(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	interfaceName := vars["interface"]
-	logger.Debug("API: Getting network config for %s", interfaceName)
+	logger.DebugContext(r.Context(), "API: Getting network config for %s", interfaceName)
 
 	configCollector := collectors.NewConfigCollector()
 	config, err := configCollector.GetNetworkConfig(interfaceName)
 
 	if err != nil {
-		logger.Error("API: Failed to get network config: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to get network config: %v", err)
 		respondJSON(w, http.StatusNotFound, dto.Response{
 			Success:   false,
 			Message:   "Failed to get network config",
@@ -1348,24 +1815,28 @@ func (s *Server) handleDiskSettings(w http.ResponseWriter, _ *http.Request) {
 // handleUpdateShareConfig godoc
 //
 //	@Summary		Update share configuration
-//	@Description	Update configuration for a specific user share
+//	@Description	Update configuration for a specific user share. Only the fields that are set are changed; other settings in the share's .cfg file are kept. use_cache must be yes, no, only or prefer, allocator highwater, mostfree or fillup, and floor (minimum free space) a number of KiB or a number with a K, M, G or T suffix. mover_tuning updates the share's override in the Mover Tuning plugin, which must be installed.
+//	@Description	With dry_run=true nothing is written and a dto.ShareConfigDryRun is returned instead, listing the settings that would change, the files whose handling by the mover would change (largest first, up to 500) with totals per action, and the disks with less free space than a new minimum free space.
 //	@Tags			Configuration
 //	@Accept			json
 //	@Produce		json
-//	@Param			name	path		string			true	"Share name"
-//	@Param			config	body		dto.ShareConfig	true	"Share configuration"
-//	@Success		200		{object}	dto.Response	"Configuration updated"
-//	@Failure		400		{object}	dto.Response	"Invalid request"
-//	@Failure		500		{object}	dto.Response	"Failed to update"
+//	@Param			name	path		string					true	"Share name"
+//	@Param			config	body		dto.ShareConfig			true	"Share configuration"
+//	@Param			dry_run	query		bool					false	"Report the effect of the change without applying it"
+//	@Param			spinup	query		bool					false	"Scan disks that are in standby during a dry run (spins them up)"
+//	@Success		200		{object}	dto.Response			"Configuration updated"
+//	@Failure		400		{object}	dto.Response			"Invalid request"
+//	@Failure		404		{object}	dto.Response			"Share not found (dry run)"
+//	@Failure		500		{object}	dto.Response			"Failed to update"
 //	@Router			/shares/{name}/config [post]
 func (s *Server) handleUpdateShareConfig(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	shareName := vars["name"]
-	logger.Info("API: Updating share config for %s", shareName)
+	logger.InfoContext(r.Context(), "API: Updating share config for %s", shareName)
 
 	// Validate share name to prevent path traversal attacks
 	if err := lib.ValidateShareName(shareName); err != nil {
-		logger.Error("API: Invalid share name: %v", err)
+		logger.ErrorContext(r.Context(), "API: Invalid share name: %v", err)
 		respondJSON(w, http.StatusBadRequest, dto.Response{
 			Success:   false,
 			Message:   fmt.Sprintf("Invalid share name: %v", err),
@@ -1387,9 +1858,40 @@ func (s *Server) handleUpdateShareConfig(w http.ResponseWriter, r *http.Request)
 	// Ensure name matches URL parameter
 	config.Name = shareName
 
+	if err := lib.ValidateShareConfig(config); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if config.MoverTuning != nil && !collectors.MoverTuningInstalled() {
+		respondWithError(w, http.StatusBadRequest, "mover_tuning requires the Mover Tuning plugin to be installed")
+		return
+	}
+
 	configCollector := collectors.NewConfigCollector()
+	if r.URL.Query().Get("dry_run") == "true" {
+		current, err := configCollector.GetShareConfig(shareName)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				respondWithError(w, http.StatusNotFound, "Share not found")
+				return
+			}
+			logger.ErrorContext(r.Context(), "API: Failed to read share config: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to read share config")
+			return
+		}
+		spinUp := r.URL.Query().Get("spinup") == "true"
+		result, err := collectors.ShareConfigDryRun(r.Context(), current, &config, s.GetDisksCache(), spinUp, time.Now())
+		if err != nil {
+			logger.ErrorContext(r.Context(), "API: Failed to dry-run share config for %s: %v", shareName, err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan share")
+			return
+		}
+		respondJSON(w, http.StatusOK, result)
+		return
+	}
+
 	if err := configCollector.UpdateShareConfig(&config); err != nil {
-		logger.Error("API: Failed to update share config: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to update share config: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to update share config",
@@ -1418,7 +1920,7 @@ func (s *Server) handleUpdateShareConfig(w http.ResponseWriter, r *http.Request)
 //	@Failure		500			{object}	dto.Response		"Failed to update"
 //	@Router			/settings/system [post]
 func (s *Server) handleUpdateSystemSettings(w http.ResponseWriter, r *http.Request) {
-	logger.Info("API: Updating system settings")
+	logger.InfoContext(r.Context(), "API: Updating system settings")
 
 	var settings dto.SystemSettings
 	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
@@ -1432,7 +1934,7 @@ func (s *Server) handleUpdateSystemSettings(w http.ResponseWriter, r *http.Reque
 
 	configCollector := collectors.NewConfigCollector()
 	if err := configCollector.UpdateSystemSettings(&settings); err != nil {
-		logger.Error("API: Failed to update system settings: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to update system settings: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to update system settings",
@@ -1499,7 +2001,7 @@ func (s *Server) handleUserScriptExecute(w http.ResponseWriter, r *http.Request)
 	// Execute the script
 	response, err := controllers.ExecuteUserScript(scriptName, req.Background, req.Wait)
 	if err != nil {
-		logger.Error("API: Failed to execute user script %s: %v", scriptName, err)
+		logger.ErrorContext(r.Context(), "API: Failed to execute user script %s: %v", scriptName, err)
 		respondJSON(w, http.StatusInternalServerError, response)
 		return
 	}
@@ -1684,7 +2186,7 @@ func (s *Server) handleRegistration(w http.ResponseWriter, _ *http.Request) {
 //	@Failure		500		{object}	map[string]string	"Error reading logs"
 //	@Router			/logs [get]
 func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
-	logger.Debug("API: Getting logs")
+	logger.DebugContext(r.Context(), "API: Getting logs")
 
 	// Get query parameters
 	path := r.URL.Query().Get("path")
@@ -1701,7 +2203,7 @@ func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 	// Get log content with optional pagination
 	content, err := s.getLogContent(path, linesParam, startParam)
 	if err != nil {
-		logger.Error("API: Failed to read log content: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to read log content: %v", err)
 		respondJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to read log content"})
 		return
 	}
@@ -1709,6 +2211,49 @@ func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, content)
 }
 
+// handleLogSearch godoc
+//
+//	@Summary		Search logs
+//	@Description	Case-insensitive search across approved log files with an optional time window. Results are capped and only the most recent 32 MiB of each file is scanned.
+//	@Tags			Logs
+//	@Produce		json
+//	@Param			q			query		string	true	"Text to search for"
+//	@Param			regex		query		boolean	false	"Treat q as a regular expression"
+//	@Param			files		query		string	false	"Comma-separated log names or paths (default: all text logs)"
+//	@Param			since		query		string	false	"Only lines newer than a duration (e.g. 2h) or RFC3339 timestamp"
+//	@Param			max_results	query		integer	false	"Maximum matches to return (default 200, max 1000)"
+//	@Success		200			{object}	dto.LogSearchResult	"Matching lines"
+//	@Failure		400			{object}	dto.Response		"Invalid search parameters"
+//	@Router			/logs/search [get]
+func (s *Server) handleLogSearch(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := dto.LogSearchQuery{
+		Query: params.Get("q"),
+		Regex: params.Get("regex") == "true",
+		Since: params.Get("since"),
+	}
+	if files := params.Get("files"); files != "" {
+		query.Files = strings.Split(files, ",")
+	}
+	if maxParam := params.Get("max_results"); maxParam != "" {
+		maxResults, err := strconv.Atoi(maxParam)
+		if err != nil || maxResults < 1 {
+			respondWithError(w, http.StatusBadRequest, "max_results must be a positive integer")
+			return
+		}
+		query.MaxResults = maxResults
+	}
+
+	logger.DebugContext(r.Context(), "API: Searching logs for %q", query.Query)
+	result, err := s.searchLogs(query)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
 // handleLogFile godoc
 //
 //	@Summary		Get specific log file
@@ -1726,7 +2271,7 @@ func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleLogFile(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	filename := vars["filename"]
-	logger.Debug("API: Getting log file: %s", filename)
+	logger.DebugContext(r.Context(), "API: Getting log file: %s", filename)
 
 	// Validate filename to prevent directory traversal (CWE-22)
 	if err := lib.ValidateLogFilename(filename); err != nil {
@@ -1765,7 +2310,7 @@ func (s *Server) handleLogFile(w http.ResponseWriter, r *http.Request) {
 	// Get log content
 	content, err := s.getLogContent(foundPath, linesParam, startParam)
 	if err != nil {
-		logger.Error("API: Failed to read log file %s: %v", filename, err)
+		logger.ErrorContext(r.Context(), "API: Failed to read log file %s: %v", filename, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to read log file",
@@ -1779,6 +2324,11 @@ func (s *Server) handleLogFile(w http.ResponseWriter, r *http.Request) {
 
 // Helper function to respond with JSON
 func respondJSON(w http.ResponseWriter, status int, payload any) {
+	// Tag error responses with the request's correlation ID
+	if resp, ok := payload.(dto.Response); ok && status >= http.StatusBadRequest && resp.CorrelationID == "" {
+		resp.CorrelationID = w.Header().Get(requestIDHeader)
+		payload = resp
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(payload); err != nil {
@@ -1798,11 +2348,15 @@ func respondWithError(w http.ResponseWriter, status int, message string) {
 // handleNotifications godoc
 //
 //	@Summary		Get all notifications
-//	@Description	Retrieve all notifications with overview counts, optionally filtered by importance
+//	@Description	Retrieve all notifications with overview counts, optionally filtered by importance. The notifications list supports field selection and pagination; the X-Total-Count header holds the number of (filtered) notifications.
 //	@Tags			Notifications
 //	@Produce		json
-//	@Param			importance	query		string	false	"Filter by importance level (alert, warning, normal)"
+//	@Param			importance	query		string					false	"Filter by importance level (alert, warning, normal)"
+//	@Param			fields		query		string					false	"Comma-separated JSON fields to return per notification (e.g. title,importance)"
+//	@Param			limit		query		int						false	"Maximum notifications to return (default all)"
+//	@Param			offset		query		int						false	"Notifications to skip (default 0)"
 //	@Success		200			{object}	dto.NotificationList	"Notifications with overview"
+//	@Failure		400			{object}	dto.Response			"Invalid fields, limit or offset"
 //	@Router			/notifications [get]
 func (s *Server) handleNotifications(w http.ResponseWriter, r *http.Request) {
 	notificationList := s.notificationsCache.Load()
@@ -1818,19 +2372,28 @@ func (s *Server) handleNotifications(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Filter by importance if specified
-	importance := r.URL.Query().Get("importance")
-	if importance != "" {
+	// Filter by importance if specified. The cached list is shared, so the
+	// filtered slice is never stored back into it.
+	notifications := notificationList.Notifications
+	if importance := r.URL.Query().Get("importance"); importance != "" {
 		filtered := []dto.Notification{}
-		for _, n := range notificationList.Notifications {
+		for _, n := range notifications {
 			if n.Importance == importance {
 				filtered = append(filtered, n)
 			}
 		}
-		notificationList.Notifications = filtered
+		notifications = filtered
 	}
 
-	respondJSON(w, http.StatusOK, notificationList)
+	page, ok := listQueryBody(w, r, notifications)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]any{
+		"overview":      notificationList.Overview,
+		"notifications": page,
+		"timestamp":     notificationList.Timestamp,
+	})
 }
 
 // handleNotificationsUnread godoc
@@ -1986,7 +2549,7 @@ func (s *Server) handleCreateNotification(w http.ResponseWriter, r *http.Request
 	}
 
 	if err := controllers.CreateNotification(req.Title, req.Subject, req.Description, req.Importance, req.Link); err != nil {
-		logger.Error("API: Failed to create notification: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to create notification: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to create notification")
 		return
 	}
@@ -2009,7 +2572,7 @@ func (s *Server) handleArchiveNotification(w http.ResponseWriter, r *http.Reques
 	id := vars["id"]
 
 	if err := controllers.ArchiveNotification(id); err != nil {
-		logger.Error("API: Failed to archive notification %s: %v", id, err)
+		logger.ErrorContext(r.Context(), "API: Failed to archive notification %s: %v", id, err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to archive notification")
 		return
 	}
@@ -2032,7 +2595,7 @@ func (s *Server) handleUnarchiveNotification(w http.ResponseWriter, r *http.Requ
 	id := vars["id"]
 
 	if err := controllers.UnarchiveNotification(id); err != nil {
-		logger.Error("API: Failed to unarchive notification %s: %v", id, err)
+		logger.ErrorContext(r.Context(), "API: Failed to unarchive notification %s: %v", id, err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to unarchive notification")
 		return
 	}
@@ -2059,7 +2622,7 @@ func (s *Server) handleDeleteNotification(w http.ResponseWriter, r *http.Request
 	isArchived := r.URL.Query().Get("archived") == "true"
 
 	if err := controllers.DeleteNotification(id, isArchived); err != nil {
-		logger.Error("API: Failed to delete notification %s: %v", id, err)
+		logger.ErrorContext(r.Context(), "API: Failed to delete notification %s: %v", id, err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to delete notification")
 		return
 	}
@@ -2231,11 +2794,151 @@ func (s *Server) handleRemoteShareAction(w http.ResponseWriter, r *http.Request,
 		})
 		return
 	}
-	if err != nil {
-		logger.Error("API: Failed to %s remote share %q: %v", action, req.Source, err)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to %s remote share %q: %v", action, req.Source, err)
+		respondJSON(w, http.StatusInternalServerError, dto.Response{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to %s remote share", action),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{
+		Success:   true,
+		Message:   fmt.Sprintf("Remote share %s initiated for %s", action, req.Source),
+		Timestamp: time.Now(),
+	})
+}
+
+// handleMountUnassignedDevice godoc
+//
+//	@Summary		Mount an unassigned device
+//	@Description	Mount an unassigned disk or one of its partitions through the Unassigned Devices plugin, using the mount point and options configured in the plugin
+//	@Tags			Unassigned Devices
+//	@Produce		json
+//	@Param			device	path		string			true	"Device or partition name (e.g. sdc, sdc1, nvme1n1p1)"
+//	@Success		200		{object}	dto.Response	"Device mounted"
+//	@Failure		400		{object}	dto.Response	"Invalid device name"
+//	@Failure		500		{object}	dto.Response	"Failed to mount"
+//	@Router			/unassigned/devices/{device}/mount [post]
+func (s *Server) handleMountUnassignedDevice(w http.ResponseWriter, r *http.Request) {
+	s.handleUnassignedDeviceAction(w, r, "mount")
+}
+
+// handleUnmountUnassignedDevice godoc
+//
+//	@Summary		Unmount an unassigned device
+//	@Description	Unmount an unassigned disk or one of its partitions through the Unassigned Devices plugin
+//	@Tags			Unassigned Devices
+//	@Produce		json
+//	@Param			device	path		string			true	"Device or partition name (e.g. sdc, sdc1, nvme1n1p1)"
+//	@Success		200		{object}	dto.Response	"Device unmounted"
+//	@Failure		400		{object}	dto.Response	"Invalid device name"
+//	@Failure		500		{object}	dto.Response	"Failed to unmount"
+//	@Router			/unassigned/devices/{device}/unmount [post]
+func (s *Server) handleUnmountUnassignedDevice(w http.ResponseWriter, r *http.Request) {
+	s.handleUnassignedDeviceAction(w, r, "unmount")
+}
+
+// handleUnassignedDeviceAction is the shared implementation for the mount and
+// unmount unassigned device endpoints.
+func (s *Server) handleUnassignedDeviceAction(w http.ResponseWriter, r *http.Request, action string) {
+	device := mux.Vars(r)["device"]
+	if err := lib.ValidateDiskID(device); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	controller := controllers.NewUnassignedDeviceController()
+	var err error
+	if action == "mount" {
+		err = controller.Mount(device)
+	} else {
+		err = controller.Unmount(device)
+	}
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to %s unassigned device %s: %v", action, device, err)
+		respondJSON(w, http.StatusInternalServerError, dto.Response{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to %s device %s", action, device),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{
+		Success:   true,
+		Message:   fmt.Sprintf("Device %s initiated for %s", action, device),
+		Timestamp: time.Now(),
+	})
+}
+
+// handleFormatUnassignedDevice godoc
+//
+//	@Summary		Format an unassigned device
+//	@Description	Erase an unassigned disk and create a single partition with an xfs, btrfs, or exfat filesystem. Requires confirm=true. Only disks in the unassigned devices list can be formatted, and never while the disk or any partition is mounted or passed through. With CONFIRM_DESTRUCTIVE on the first call returns 428 with a confirmation token listing what is erased; repeat it with the X-Confirm-Token header within 60 seconds.
+//	@Tags			Unassigned Devices
+//	@Accept			json
+//	@Produce		json
+//	@Param			device			path		string						true	"Device name (e.g. sdc, nvme1n1)"
+//	@Param			request			body		dto.UnassignedFormatRequest	true	"Filesystem and confirm flag"
+//	@Param			X-Confirm-Token	header		string						false	"Token from the confirmation challenge"
+//	@Success		200				{object}	dto.Response				"Device formatted"
+//	@Failure		400				{object}	dto.Response				"Invalid device name, request body, or missing confirmation"
+//	@Failure		404				{object}	dto.Response				"Device not found in the unassigned devices list"
+//	@Failure		412				{object}	dto.Response				"Invalid or expired confirmation token"
+//	@Failure		428				{object}	dto.ConfirmationChallenge	"Confirmation required"
+//	@Failure		500				{object}	dto.Response				"Device mounted or passed through, or format failed"
+//	@Router			/unassigned/devices/{device}/format [post]
+func (s *Server) handleFormatUnassignedDevice(w http.ResponseWriter, r *http.Request) {
+	device := mux.Vars(r)["device"]
+	if err := lib.ValidateDiskID(device); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	var req dto.UnassignedFormatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   fmt.Sprintf("Invalid request body: %v", err),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if !req.Confirm {
+		respondJSON(w, http.StatusBadRequest, dto.Response{
+			Success:   false,
+			Message:   "confirm must be set to true to format a device",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	target, ok := s.findUnassignedDevice(device)
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("Unassigned device not found: %s", device))
+		return
+	}
+	if !s.confirmDestructive(w, r, formatAction(target, req.Filesystem)) {
+		return
+	}
+
+	if err := controllers.NewUnassignedDeviceController().Format(target, req.Filesystem); err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to format unassigned device %s: %v", device, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
-			Message:   fmt.Sprintf("Failed to %s remote share", action),
+			Message:   fmt.Sprintf("Failed to format device %s: %v", device, err),
 			Timestamp: time.Now(),
 		})
 		return
@@ -2243,11 +2946,87 @@ func (s *Server) handleRemoteShareAction(w http.ResponseWriter, r *http.Request,
 
 	respondJSON(w, http.StatusOK, dto.Response{
 		Success:   true,
-		Message:   fmt.Sprintf("Remote share %s initiated for %s", action, req.Source),
+		Message:   fmt.Sprintf("Device %s formatted as %s", device, req.Filesystem),
 		Timestamp: time.Now(),
 	})
 }
 
+// findUnassignedDevice looks up a whole disk in the cached unassigned devices
+// list. Array and pool disks are never part of that list.
+func (s *Server) findUnassignedDevice(device string) (dto.UnassignedDevice, bool) {
+	if list := s.GetUnassignedCache(); list != nil {
+		for _, d := range list.Devices {
+			if d.Device == device {
+				return d, true
+			}
+		}
+	}
+	return dto.UnassignedDevice{}, false
+}
+
+// ============================================================================
+// Pool Handlers
+// ============================================================================
+
+// handlePools godoc
+//
+//	@Summary		Get all pools
+//	@Description	Retrieve every Unraid pool (cache, cache2, named btrfs/ZFS/XFS pools) with device membership, capacity, btrfs RAID profile and chunk allocation, and balance/scrub state
+//	@Tags			Disks
+//	@Produce		json
+//	@Success		200	{array}	dto.PoolInfo	"List of pools"
+//	@Router			/pools [get]
+func (s *Server) handlePools(w http.ResponseWriter, _ *http.Request) {
+	pools := s.GetPoolsCache()
+
+	if pools == nil {
+		pools = []dto.PoolInfo{}
+	}
+
+	respondJSON(w, http.StatusOK, pools)
+}
+
+// handlePool godoc
+//
+//	@Summary		Get specific pool
+//	@Description	Retrieve a single Unraid pool by name
+//	@Tags			Disks
+//	@Produce		json
+//	@Param			name	path		string			true	"Pool name"
+//	@Success		200		{object}	dto.PoolInfo	"Pool information"
+//	@Failure		404		{object}	dto.Response	"Pool not found"
+//	@Router			/pools/{name} [get]
+func (s *Server) handlePool(w http.ResponseWriter, r *http.Request) {
+	poolName := mux.Vars(r)["name"]
+
+	for _, pool := range s.GetPoolsCache() {
+		if pool.Name == poolName {
+			respondJSON(w, http.StatusOK, pool)
+			return
+		}
+	}
+
+	respondWithError(w, http.StatusNotFound, fmt.Sprintf("Pool not found: %s", poolName))
+}
+
+// handleBtrfs godoc
+//
+//	@Summary		Get btrfs health statistics
+//	@Description	Retrieve per-device btrfs error counters (write/read/flush I/O, corruption, generation) and the latest scrub result for every mounted btrfs filesystem
+//	@Tags			Disks
+//	@Produce		json
+//	@Success		200	{array}	dto.BtrfsFilesystem	"List of btrfs filesystems"
+//	@Router			/btrfs [get]
+func (s *Server) handleBtrfs(w http.ResponseWriter, _ *http.Request) {
+	filesystems := s.GetBtrfsCache()
+
+	if filesystems == nil {
+		filesystems = []dto.BtrfsFilesystem{}
+	}
+
+	respondJSON(w, http.StatusOK, filesystems)
+}
+
 // ============================================================================
 // ZFS Handlers
 // ============================================================================
@@ -2323,19 +3102,23 @@ func (s *Server) handleZFSDatasets(w http.ResponseWriter, _ *http.Request) {
 // handleZFSSnapshots godoc
 //
 //	@Summary		Get all ZFS snapshots
-//	@Description	Retrieve information about all ZFS snapshots
+//	@Description	Retrieve information about all ZFS snapshots. Supports field selection and pagination; the X-Total-Count header holds the number of snapshots.
 //	@Tags			ZFS
 //	@Produce		json
-//	@Success		200	{array}	dto.ZFSSnapshot	"List of ZFS snapshots"
+//	@Param			fields	query		string	false	"Comma-separated JSON fields to return per item (e.g. name,state)"
+//	@Param			limit	query		int		false	"Maximum items to return (default all)"
+//	@Param			offset	query		int		false	"Items to skip (default 0)"
+//	@Success		200		{array}		dto.ZFSSnapshot	"List of ZFS snapshots"
+//	@Failure		400		{object}	dto.Response	"Invalid fields, limit or offset"
 //	@Router			/zfs/snapshots [get]
-func (s *Server) handleZFSSnapshots(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleZFSSnapshots(w http.ResponseWriter, r *http.Request) {
 	snapshots := s.GetZFSSnapshotsCache()
 
 	if snapshots == nil {
 		snapshots = []dto.ZFSSnapshot{}
 	}
 
-	respondJSON(w, http.StatusOK, snapshots)
+	respondList(w, r, snapshots)
 }
 
 // handleZFSARC godoc
@@ -2521,12 +3304,12 @@ func (s *Server) handleCollectorStateChange(w http.ResponseWriter, r *http.Reque
 	actionPast := "enabled"
 	var actionErr error
 	if enable {
-		logger.Info("Enabling collector: %s", name)
+		logger.InfoContext(r.Context(), "Enabling collector: %s", name)
 		actionErr = s.collectorManager.EnableCollector(name)
 	} else {
 		action = "Disabling"
 		actionPast = "disabled"
-		logger.Info("Disabling collector: %s", name)
+		logger.InfoContext(r.Context(), "Disabling collector: %s", name)
 		actionErr = s.collectorManager.DisableCollector(name)
 	}
 
@@ -2545,7 +3328,7 @@ func (s *Server) handleCollectorStateChange(w http.ResponseWriter, r *http.Reque
 
 	status, err := s.collectorManager.GetStatus(name)
 	if err != nil {
-		logger.Warning("%s collector %s succeeded but status retrieval failed: %v", action, name, err)
+		logger.WarningContext(r.Context(), "%s collector %s succeeded but status retrieval failed: %v", action, name, err)
 	}
 	respondJSON(w, http.StatusOK, dto.CollectorResponse{
 		Success:   true,
@@ -2592,7 +3375,7 @@ func (s *Server) handleCollectorInterval(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	logger.Info("Updating collector %s interval to %d seconds", name, req.Interval)
+	logger.InfoContext(r.Context(), "Updating collector %s interval to %d seconds", name, req.Interval)
 
 	if err := s.collectorManager.UpdateInterval(name, req.Interval); err != nil {
 		statusCode := http.StatusBadRequest
@@ -2927,7 +3710,7 @@ func (s *Server) handleMQTTTest(w http.ResponseWriter, _ *http.Request) {
 //	@Failure		500		{object}	dto.Response			"Failed to publish"
 //	@Router			/mqtt/publish [post]
 func (s *Server) handleMQTTPublish(w http.ResponseWriter, r *http.Request) {
-	logger.Info("API: Publishing custom MQTT message")
+	logger.InfoContext(r.Context(), "API: Publishing custom MQTT message")
 
 	if s.mqttClient == nil {
 		respondJSON(w, http.StatusServiceUnavailable, dto.Response{
@@ -2959,7 +3742,7 @@ func (s *Server) handleMQTTPublish(w http.ResponseWriter, r *http.Request) {
 
 	err := s.mqttClient.PublishCustom(req.Topic, req.Payload, req.Retained)
 	if err != nil {
-		logger.Error("API: Failed to publish MQTT message: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to publish MQTT message: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to publish message",
@@ -3007,7 +3790,7 @@ func (s *Server) handleDockerUpdatesRefresh(w http.ResponseWriter, r *http.Reque
 	defer func() { _ = dc.Close() }()
 	result, err := dc.CheckAllContainerUpdates(r.Context())
 	if err != nil {
-		logger.Error("API: container update refresh failed: %v", err)
+		logger.ErrorContext(r.Context(), "API: container update refresh failed: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success: false, Message: "update check failed", Timestamp: time.Now(),
 		})
@@ -3066,7 +3849,7 @@ func (s *Server) handleDockerCheckUpdate(w http.ResponseWriter, r *http.Request)
 
 	result, err := controller.CheckContainerUpdate(r.Context(), containerRef)
 	if err != nil {
-		logger.Error("API: Failed to check container update for %s: %v", containerRef, err)
+		logger.ErrorContext(r.Context(), "API: Failed to check container update for %s: %v", containerRef, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to check for update",
@@ -3107,7 +3890,7 @@ func (s *Server) handleDockerSize(w http.ResponseWriter, r *http.Request) {
 
 	result, err := controller.GetContainerSize(containerRef)
 	if err != nil {
-		logger.Error("API: Failed to get container size for %s: %v", containerRef, err)
+		logger.ErrorContext(r.Context(), "API: Failed to get container size for %s: %v", containerRef, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to get container size",
@@ -3122,12 +3905,15 @@ func (s *Server) handleDockerSize(w http.ResponseWriter, r *http.Request) {
 // handleDockerUpdate godoc
 //
 //	@Summary		Update a specific container
-//	@Description	Pull latest image and recreate the container with the updated image
+//	@Description	Pull latest image and recreate the container with the updated image. With async=true the update runs in a background job whose result is the update result.
 //	@Tags			Docker
 //	@Produce		json
-//	@Param			id	path		string						true	"Container ID or name"
-//	@Success		200	{object}	dto.ContainerUpdateResult	"Container update result"
-//	@Failure		400	{object}	dto.Response				"Invalid container reference"
+//	@Param			id		path		string						true	"Container ID or name"
+//	@Param			force	query		boolean						false	"Recreate even when no update is available"
+//	@Param			async	query		boolean						false	"Run as a background job and return 202 with the job"
+//	@Success		200		{object}	dto.ContainerUpdateResult	"Container update result"
+//	@Success		202		{object}	dto.Job						"Update job queued"
+//	@Failure		400		{object}	dto.Response				"Invalid container reference"
 //	@Failure		500	{object}	dto.Response				"Failed to update container"
 //	@Router			/docker/{id}/update [post]
 func (s *Server) handleDockerUpdate(w http.ResponseWriter, r *http.Request) {
@@ -3145,13 +3931,17 @@ func (s *Server) handleDockerUpdate(w http.ResponseWriter, r *http.Request) {
 
 	// Check for force parameter
 	force := r.URL.Query().Get("force") == "true"
+	if wantsAsync(r) {
+		respondJobAccepted(w, s.jobManager.Submit(s.detachedContext(r), dto.JobKindContainerUpdate, containerRef, containerUpdateJob(containerRef, force)))
+		return
+	}
 
 	controller := controllers.NewDockerController()
 	defer controller.Close() //nolint:errcheck
 
 	result, err := controller.UpdateContainer(containerRef, force)
 	if err != nil {
-		logger.Error("API: Failed to update container %s: %v", containerRef, err)
+		logger.ErrorContext(r.Context(), "API: Failed to update container %s: %v", containerRef, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to update container",
@@ -3166,21 +3956,27 @@ func (s *Server) handleDockerUpdate(w http.ResponseWriter, r *http.Request) {
 // handleDockerUpdateAll godoc
 //
 //	@Summary		Update all containers
-//	@Description	Check all containers for updates and update those that have updates available
+//	@Description	Check all containers for updates and update those that have updates available. With async=true the updates run in a background job whose result is the bulk update result.
 //	@Tags			Docker
 //	@Produce		json
-//	@Success		200	{object}	dto.ContainerBulkUpdateResult	"Bulk update results"
-//	@Failure		500	{object}	dto.Response					"Failed to update containers"
+//	@Param			async	query		boolean							false	"Run as a background job and return 202 with the job"
+//	@Success		200		{object}	dto.ContainerBulkUpdateResult	"Bulk update results"
+//	@Success		202		{object}	dto.Job							"Update job queued"
+//	@Failure		500		{object}	dto.Response					"Failed to update containers"
 //	@Router			/docker/update-all [post]
-func (s *Server) handleDockerUpdateAll(w http.ResponseWriter, _ *http.Request) {
-	logger.Info("API: Updating all containers")
+func (s *Server) handleDockerUpdateAll(w http.ResponseWriter, r *http.Request) {
+	if wantsAsync(r) {
+		respondJobAccepted(w, s.jobManager.Submit(s.detachedContext(r), dto.JobKindContainerUpdateAll, "", containerUpdateAllJob))
+		return
+	}
+	logger.InfoContext(r.Context(), "API: Updating all containers")
 
 	controller := controllers.NewDockerController()
 	defer controller.Close() //nolint:errcheck
 
 	result, err := controller.UpdateAllContainers()
 	if err != nil {
-		logger.Error("API: Failed to update all containers: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to update all containers: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to update containers",
@@ -3223,7 +4019,7 @@ func (s *Server) handlePluginUpdatesRefresh(w http.ResponseWriter, r *http.Reque
 	controller := controllers.NewPluginController()
 	updates, err := controller.CheckPluginUpdates(r.Context())
 	if err != nil {
-		logger.Error("API: plugin update refresh failed: %v", err)
+		logger.ErrorContext(r.Context(), "API: plugin update refresh failed: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success: false, Message: "plugin update check failed", Timestamp: time.Now(),
 		})
@@ -3266,7 +4062,7 @@ func (s *Server) handlePluginUpdate(w http.ResponseWriter, r *http.Request) {
 	controller := controllers.NewPluginController()
 	err := controller.UpdatePlugin(pluginName)
 	if err != nil {
-		logger.Error("API: Failed to update plugin %s: %v", pluginName, err)
+		logger.ErrorContext(r.Context(), "API: Failed to update plugin %s: %v", pluginName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to update plugin",
@@ -3373,7 +4169,7 @@ func (s *Server) handleVMClone(w http.ResponseWriter, r *http.Request) {
 	controller := controllers.NewVMController()
 	err := controller.CloneVM(vmName, cloneName)
 	if err != nil {
-		logger.Error("API: Failed to clone VM %s: %v", vmName, err)
+		logger.ErrorContext(r.Context(), "API: Failed to clone VM %s: %v", vmName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to clone VM",
@@ -3435,7 +4231,7 @@ func (s *Server) handleVMCreateSnapshot(w http.ResponseWriter, r *http.Request)
 	controller := controllers.NewVMController()
 	err := controller.CreateSnapshot(vmName, snapshotName, description)
 	if err != nil {
-		logger.Error("API: Failed to create snapshot for VM %s: %v", vmName, err)
+		logger.ErrorContext(r.Context(), "API: Failed to create snapshot for VM %s: %v", vmName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to create snapshot",
@@ -3478,7 +4274,7 @@ func (s *Server) handleVMListSnapshots(w http.ResponseWriter, r *http.Request) {
 	controller := controllers.NewVMController()
 	result, err := controller.ListSnapshots(vmName)
 	if err != nil {
-		logger.Error("API: Failed to list snapshots for VM %s: %v", vmName, err)
+		logger.ErrorContext(r.Context(), "API: Failed to list snapshots for VM %s: %v", vmName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to list snapshots",
@@ -3528,7 +4324,7 @@ func (s *Server) handleVMDeleteSnapshot(w http.ResponseWriter, r *http.Request)
 	controller := controllers.NewVMController()
 	err := controller.DeleteSnapshot(vmName, snapshotName)
 	if err != nil {
-		logger.Error("API: Failed to delete snapshot %s for VM %s: %v", snapshotName, vmName, err)
+		logger.ErrorContext(r.Context(), "API: Failed to delete snapshot %s for VM %s: %v", snapshotName, vmName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to delete snapshot",
@@ -3582,7 +4378,7 @@ func (s *Server) handleVMRestoreSnapshot(w http.ResponseWriter, r *http.Request)
 	controller := controllers.NewVMController()
 	err := controller.RestoreSnapshot(vmName, snapshotName)
 	if err != nil {
-		logger.Error("API: Failed to restore snapshot %s for VM %s: %v", snapshotName, vmName, err)
+		logger.ErrorContext(r.Context(), "API: Failed to restore snapshot %s for VM %s: %v", snapshotName, vmName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to restore snapshot",
@@ -3640,7 +4436,7 @@ func (s *Server) handleDockerLogs(w http.ResponseWriter, r *http.Request) {
 
 	result, err := controller.ContainerLogs(containerRef, tail, since, timestamps)
 	if err != nil {
-		logger.Error("API: Failed to get logs for container %s: %v", containerRef, err)
+		logger.ErrorContext(r.Context(), "API: Failed to get logs for container %s: %v", containerRef, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to get container logs",
@@ -3701,7 +4497,7 @@ func (s *Server) handleServiceAction(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		logger.Error("API: Failed to %s service %s: %v", action, serviceName, err)
+		logger.ErrorContext(r.Context(), "API: Failed to %s service %s: %v", action, serviceName, err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   fmt.Sprintf("Failed to %s service", action),
@@ -3779,7 +4575,7 @@ func (s *Server) handleProcessList(w http.ResponseWriter, r *http.Request) {
 	controller := controllers.NewProcessController()
 	result, err := controller.ListProcesses(sortBy, limit)
 	if err != nil {
-		logger.Error("API: Failed to list processes: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to list processes: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to list processes",
@@ -3791,6 +4587,50 @@ func (s *Server) handleProcessList(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, result)
 }
 
+// handleTopProcesses godoc
+//
+//	@Summary		Top processes by CPU or memory
+//	@Description	Get the top resource-consuming processes with their name, user, cgroup and the Docker container they run in (if any)
+//	@Tags			System
+//	@Produce		json
+//	@Param			sort	query		string			false	"Rank by: cpu or memory (default: cpu)"
+//	@Param			limit	query		int				false	"Number of processes to return, 1-100 (default: 10)"
+//	@Success		200		{object}	dto.ProcessList	"Top processes"
+//	@Failure		400		{object}	dto.Response	"Invalid sort or limit"
+//	@Failure		500		{object}	dto.Response	"Failed to list processes"
+//	@Router			/system/processes [get]
+func (s *Server) handleTopProcesses(w http.ResponseWriter, r *http.Request) {
+	sortBy := r.URL.Query().Get("sort")
+	if sortBy == "" {
+		sortBy = "cpu"
+	}
+	if sortBy != "cpu" && sortBy != "memory" {
+		respondWithError(w, http.StatusBadRequest, "Invalid sort: must be cpu or memory")
+		return
+	}
+
+	limit := controllers.DefaultTopProcesses
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 || parsed > controllers.MaxTopProcesses {
+			respondWithError(w, http.StatusBadRequest,
+				fmt.Sprintf("Invalid limit: must be between 1 and %d", controllers.MaxTopProcesses))
+			return
+		}
+		limit = parsed
+	}
+
+	controller := controllers.NewProcessController()
+	result, err := controller.TopProcesses(sortBy, limit, s.GetDockerCache())
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to list top processes: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to list processes")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
 // handleProcessIO godoc
 //
 //	@Summary		Top processes by disk I/O
@@ -3815,7 +4655,7 @@ func (s *Server) handleProcessIO(w http.ResponseWriter, r *http.Request) {
 	controller := controllers.NewProcessController()
 	result, err := controller.ListProcessIO(limit)
 	if err != nil {
-		logger.Error("API: Failed to sample process I/O: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to sample process I/O: %v", err)
 		respondJSON(w, http.StatusInternalServerError, dto.Response{
 			Success:   false,
 			Message:   "Failed to sample process I/O",
@@ -3922,7 +4762,7 @@ func (s *Server) handleCreateAlertRule(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := s.alertStore.CreateRule(rule); err != nil {
-		logger.Error("API: Failed to create alert rule: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to create alert rule: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to create alert rule")
 		return
 	}
@@ -4144,13 +4984,13 @@ func (s *Server) handleEnableAlertTemplate(w http.ResponseWriter, r *http.Reques
 	// Idempotent upsert: update if rule already exists, otherwise create.
 	if _, err := s.alertStore.GetRule(rule.ID); err == nil {
 		if err := s.alertStore.UpdateRule(rule); err != nil {
-			logger.Error("API: Failed to update alert rule from template %s: %v", id, err)
+			logger.ErrorContext(r.Context(), "API: Failed to update alert rule from template %s: %v", id, err)
 			respondWithError(w, http.StatusInternalServerError, "Failed to update alert rule")
 			return
 		}
 	} else {
 		if err := s.alertStore.CreateRule(rule); err != nil {
-			logger.Error("API: Failed to create alert rule from template %s: %v", id, err)
+			logger.ErrorContext(r.Context(), "API: Failed to create alert rule from template %s: %v", id, err)
 			respondWithError(w, http.StatusInternalServerError, "Failed to create alert rule")
 			return
 		}
@@ -4163,14 +5003,17 @@ func (s *Server) handleEnableAlertTemplate(w http.ResponseWriter, r *http.Reques
 // handleMetricHistory godoc
 //
 //	@Summary		Query metric history
-//	@Description	Return in-memory ring-buffer samples and summary stats (slope, min, max, avg, last) for a named metric series. Pass entity for per-disk or per-container metrics.
+//	@Description	Return samples and summary stats (slope, min, max, avg, last) for a named metric series, optionally limited to a time range. The last hour is kept at full resolution and the last 7 days as 5-minute averages. Pass entity for per-disk or per-container metrics.
 //	@Tags			Alerts
 //	@Produce		json
-//	@Param			metric	query		string					true	"Metric name (e.g. cpu_temp, array_used_pct, disk_temp)"
-//	@Param			entity	query		string					false	"Entity ID for per-entity metrics (e.g. disk ID, container ID)"
-//	@Success		200		{object}	dto.MetricHistoryResult	"Metric history"
-//	@Failure		400		{object}	dto.Response			"metric query parameter is required"
-//	@Failure		503		{object}	dto.Response			"Alerting engine not initialized"
+//	@Param			metric		query		string					true	"Metric name (e.g. cpu_usage, cpu_temp, array_used_pct, disk_temp)"
+//	@Param			entity		query		string					false	"Entity ID for per-entity metrics (e.g. disk ID, container ID)"
+//	@Param			start		query		string					false	"RFC 3339 start of the time range"
+//	@Param			end			query		string					false	"RFC 3339 end of the time range"
+//	@Param			lookback	query		string					false	"Duration back from now (e.g. 30m); ignored when start is set"
+//	@Success		200			{object}	dto.MetricHistoryResult	"Metric history"
+//	@Failure		400			{object}	dto.Response			"Missing metric or invalid time range"
+//	@Failure		503			{object}	dto.Response			"Alerting engine not initialized"
 //	@Router			/metrics/history [get]
 func (s *Server) handleMetricHistory(w http.ResponseWriter, r *http.Request) {
 	if s.alertEngine == nil {
@@ -4182,8 +5025,47 @@ func (s *Server) handleMetricHistory(w http.ResponseWriter, r *http.Request) {
 		respondWithError(w, http.StatusBadRequest, "metric query parameter is required")
 		return
 	}
-	entity := r.URL.Query().Get("entity")
-	respondJSON(w, http.StatusOK, s.alertEngine.QueryHistory(metric, entity))
+	q := r.URL.Query()
+	from, to, err := alerting.ParseTimeRange(q.Get("start"), q.Get("end"), q.Get("lookback"), time.Now())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, s.alertEngine.QueryHistoryRange(metric, q.Get("entity"), from, to))
+}
+
+// handleThermalSummary godoc
+//
+//	@Summary		Get temperature history
+//	@Description	Return the current, maximum and average temperature over the last 24 hours for the CPU, motherboard, every disk and every GPU, with their warning/critical thresholds and recent thermal events. A thermal event is raised when a sensor stays at or above a threshold for 5 minutes; disk thresholds come from the Unraid disk settings or per-disk overrides.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.ThermalSummary	"Temperature history and thermal events"
+//	@Failure		503	{object}	dto.Response		"Alerting engine not initialized"
+//	@Router			/system/thermal [get]
+func (s *Server) handleThermalSummary(w http.ResponseWriter, _ *http.Request) {
+	if s.alertEngine == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Alerting engine not initialized")
+		return
+	}
+	respondJSON(w, http.StatusOK, s.alertEngine.ThermalSummary(time.Now()))
+}
+
+// handleUtilizationSummary godoc
+//
+//	@Summary		Get array and pool utilization levels
+//	@Description	Return the utilization level (ok, warning, critical) of every array data disk and pool, using the warning and critical utilization thresholds from the Unraid disk settings, with recent utilization events. A level clears only once usage falls 2 points below its threshold, and each level change raises one event and Unraid notification.
+//	@Tags			Array
+//	@Produce		json
+//	@Success		200	{object}	dto.UtilizationSummary	"Utilization levels and events"
+//	@Failure		503	{object}	dto.Response			"Alerting engine not initialized"
+//	@Router			/array/utilization [get]
+func (s *Server) handleUtilizationSummary(w http.ResponseWriter, _ *http.Request) {
+	if s.alertEngine == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Alerting engine not initialized")
+		return
+	}
+	respondJSON(w, http.StatusOK, s.alertEngine.UtilizationSummary(time.Now()))
 }
 
 // ============================================================================
@@ -4272,6 +5154,12 @@ func (s *Server) handleCreateHealthCheck(w http.ResponseWriter, r *http.Request)
 		respondWithError(w, http.StatusBadRequest, "target is required")
 		return
 	}
+	if check.Container != "" {
+		if err := lib.ValidateContainerRef(check.Container); err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 
 	if err := s.watchdogStore.CreateCheck(check); err != nil {
 		respondWithError(w, http.StatusBadRequest, err.Error())
@@ -4308,12 +5196,23 @@ func (s *Server) handleUpdateHealthCheck(w http.ResponseWriter, r *http.Request)
 	}
 
 	check.ID = id // URL ID takes precedence
+	if check.Container != "" {
+		if err := lib.ValidateContainerRef(check.Container); err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 
 	if err := s.watchdogStore.UpdateCheck(check); err != nil {
 		respondWithError(w, http.StatusNotFound, err.Error())
 		return
 	}
 
+	// Drop stale runner state so a disabled or retargeted check is not reported
+	if s.watchdogRunner != nil {
+		s.watchdogRunner.ResetCheck(id)
+	}
+
 	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Health check updated", Timestamp: time.Now()})
 }
 
@@ -4414,6 +5313,163 @@ func (s *Server) handleRunHealthCheck(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, status)
 }
 
+// ============================================================================
+// Notification Forwarding Handlers
+// ============================================================================
+
+// redactTarget hides the credentials embedded in a notification target URL.
+func redactTarget(target dto.NotificationTarget) dto.NotificationTarget {
+	target.URL = lib.Redact(target.URL)
+	return target
+}
+
+// handleListNotificationTargets godoc
+//
+//	@Summary		List notification targets
+//	@Description	Get all notification forwarding targets. Target URLs are redacted.
+//	@Tags			Notifications
+//	@Produce		json
+//	@Success		200	{array}	dto.NotificationTarget	"List of notification targets"
+//	@Router			/notifications/targets [get]
+func (s *Server) handleListNotificationTargets(w http.ResponseWriter, _ *http.Request) {
+	if s.forwardingStore == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Notification forwarding not initialized")
+		return
+	}
+
+	targets := s.forwardingStore.GetTargets()
+	for i := range targets {
+		targets[i] = redactTarget(targets[i])
+	}
+	respondJSON(w, http.StatusOK, targets)
+}
+
+// handleCreateNotificationTarget godoc
+//
+//	@Summary		Create notification target
+//	@Description	Add a Telegram (telegram://), Discord (discord://), or Pushover (pushover://) target that new Unraid notifications and alert rule events are forwarded to
+//	@Tags			Notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			target	body		dto.NotificationTarget	true	"Notification target configuration"
+//	@Success		201		{object}	dto.Response			"Created"
+//	@Failure		400		{object}	dto.Response			"Invalid request"
+//	@Router			/notifications/targets [post]
+func (s *Server) handleCreateNotificationTarget(w http.ResponseWriter, r *http.Request) {
+	if s.forwardingStore == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Notification forwarding not initialized")
+		return
+	}
+
+	var target dto.NotificationTarget
+	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+
+	if err := s.forwardingStore.CreateTarget(target); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.Response{Success: true, Message: "Notification target created", Timestamp: time.Now()})
+}
+
+// handleUpdateNotificationTarget godoc
+//
+//	@Summary		Update notification target
+//	@Description	Replace a notification target. An empty or redacted url keeps the stored one.
+//	@Tags			Notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Notification target ID"
+//	@Param			target	body		dto.NotificationTarget	true	"Updated notification target configuration"
+//	@Success		200		{object}	dto.Response			"Updated"
+//	@Failure		400		{object}	dto.Response			"Invalid request"
+//	@Failure		404		{object}	dto.Response			"Not found"
+//	@Router			/notifications/targets/{id} [put]
+func (s *Server) handleUpdateNotificationTarget(w http.ResponseWriter, r *http.Request) {
+	if s.forwardingStore == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Notification forwarding not initialized")
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if _, err := s.forwardingStore.GetTarget(id); err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var target dto.NotificationTarget
+	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+
+	target.ID = id // URL ID takes precedence
+	if err := s.forwardingStore.UpdateTarget(target); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Notification target updated", Timestamp: time.Now()})
+}
+
+// handleDeleteNotificationTarget godoc
+//
+//	@Summary		Delete notification target
+//	@Description	Delete a notification target by ID
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			id	path		string			true	"Notification target ID"
+//	@Success		200	{object}	dto.Response	"Deleted"
+//	@Failure		404	{object}	dto.Response	"Not found"
+//	@Router			/notifications/targets/{id} [delete]
+func (s *Server) handleDeleteNotificationTarget(w http.ResponseWriter, r *http.Request) {
+	if s.forwardingStore == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Notification forwarding not initialized")
+		return
+	}
+
+	if err := s.forwardingStore.DeleteTarget(mux.Vars(r)["id"]); err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Notification target deleted", Timestamp: time.Now()})
+}
+
+// handleTestNotificationTarget godoc
+//
+//	@Summary		Test notification target
+//	@Description	Send a test message to a notification target, even if it is disabled
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			id	path		string			true	"Notification target ID"
+//	@Success		200	{object}	dto.Response	"Test message sent"
+//	@Failure		404	{object}	dto.Response	"Not found"
+//	@Failure		502	{object}	dto.Response	"Delivery failed"
+//	@Router			/notifications/targets/{id}/test [post]
+func (s *Server) handleTestNotificationTarget(w http.ResponseWriter, r *http.Request) {
+	if s.forwarder == nil || s.forwardingStore == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Notification forwarding not initialized")
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if _, err := s.forwardingStore.GetTarget(id); err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err := s.forwarder.SendTest(id); err != nil {
+		respondWithError(w, http.StatusBadGateway, lib.Redact(err.Error()))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Test notification sent", Timestamp: time.Now()})
+}
+
 // ────────────────────────────────────────────────────────────────────────────
 // Fan Control Handlers
 // ────────────────────────────────────────────────────────────────────────────
@@ -4474,7 +5530,7 @@ func (s *Server) handleSetFanSpeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := s.fanController.SetSpeed(req.FanID, req.PWMPercent); err != nil {
-		logger.Error("API: Failed to set fan speed: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to set fan speed: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to set fan speed")
 		return
 	}
@@ -4511,7 +5567,7 @@ func (s *Server) handleSetFanMode(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := s.fanController.SetMode(req.FanID, req.Mode); err != nil {
-		logger.Error("API: Failed to set fan mode: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to set fan mode: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to set fan mode")
 		return
 	}
@@ -4564,7 +5620,7 @@ func (s *Server) handleSetFanProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := s.fanController.SetProfile(req.FanID, req.ProfileName, source); err != nil {
-		logger.Error("API: Failed to assign fan profile: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to assign fan profile: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to assign fan profile")
 		return
 	}
@@ -4624,7 +5680,7 @@ func (s *Server) handleCreateFanProfile(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := s.fanController.CreateProfile(profile); err != nil {
-		logger.Error("API: Failed to create fan profile: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to create fan profile: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to create fan profile")
 		return
 	}
@@ -4689,7 +5745,7 @@ func (s *Server) handleUpdateFanConfig(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := s.fanController.UpdateConfig(config); err != nil {
-		logger.Error("API: Failed to update fan config: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to update fan config: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to update fan control configuration")
 		return
 	}
@@ -4747,30 +5803,46 @@ func (s *Server) handleSetCPUGovernor(w http.ResponseWriter, r *http.Request) {
 //	@Failure		500	{object}	dto.Response				"Internal error"
 //	@Router			/docker/stats [get]
 func (s *Server) handleDockerStats(w http.ResponseWriter, _ *http.Request) {
-	containers := s.GetDockerCache()
-	if containers == nil {
+	summary := s.GetDockerStatsSummaryCache()
+	if summary == nil {
 		respondJSON(w, http.StatusOK, dto.DockerAggregateStats{Timestamp: time.Now()})
 		return
 	}
+	respondJSON(w, http.StatusOK, summary.DockerAggregateStats)
+}
 
-	var stats dto.DockerAggregateStats
-	for i := range containers {
-		c := &containers[i]
-		stats.TotalContainers++
-		if c.State == "running" {
-			stats.RunningContainers++
-			stats.TotalCPUPercent += c.CPUPercent
-			stats.TotalMemoryUsage += c.MemoryUsage
-			stats.TotalMemoryUsageMB += c.MemoryUsageMB
-			stats.TotalMemoryLimit += c.MemoryLimit
+// handleDockerStatsSummary godoc
+//
+//	@Summary		Get Docker stats summary
+//	@Description	Returns the aggregate CPU and memory usage of the running containers with the largest CPU and memory consumers, as computed by the docker collector on each collection
+//	@Tags			Docker
+//	@Produce		json
+//	@Param			limit	query		int						false	"Number of containers in each ranking, 1-10 (default: 10)"
+//	@Success		200		{object}	dto.DockerStatsSummary	"Stats summary"
+//	@Failure		400		{object}	dto.Response			"Invalid limit"
+//	@Failure		503		{object}	dto.Response			"Docker stats not available yet"
+//	@Router			/docker/stats/summary [get]
+func (s *Server) handleDockerStatsSummary(w http.ResponseWriter, r *http.Request) {
+	limit := collectors.DockerStatsTopN
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 || parsed > collectors.DockerStatsTopN {
+			respondWithError(w, http.StatusBadRequest,
+				fmt.Sprintf("Invalid limit: must be between 1 and %d", collectors.DockerStatsTopN))
+			return
 		}
+		limit = parsed
 	}
-	if stats.TotalMemoryLimit > 0 {
-		stats.MemoryUsagePercent = float64(stats.TotalMemoryUsage) / float64(stats.TotalMemoryLimit) * 100
-	}
-	stats.Timestamp = time.Now()
 
-	respondJSON(w, http.StatusOK, stats)
+	cached := s.GetDockerStatsSummaryCache()
+	if cached == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Docker stats not available yet")
+		return
+	}
+	summary := *cached
+	summary.TopCPU = summary.TopCPU[:min(limit, len(summary.TopCPU))]
+	summary.TopMemory = summary.TopMemory[:min(limit, len(summary.TopMemory))]
+	respondJSON(w, http.StatusOK, summary)
 }
 
 // handleTemperatures godoc
@@ -4835,7 +5907,7 @@ func (s *Server) handleSetTurboBoost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := s.tuningController.SetTurboBoost(req.Enabled); err != nil {
-		logger.Error("API: Failed to set turbo boost: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to set turbo boost: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to set turbo boost")
 		return
 	}
@@ -4907,7 +5979,7 @@ func (s *Server) handleSetDiskCache(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := s.tuningController.SetDiskCache(bgRatio, ratio, wbCenti, expCenti); err != nil {
-		logger.Error("API: Failed to set disk cache: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to set disk cache: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to update disk cache parameters")
 		return
 	}
@@ -4947,7 +6019,7 @@ func (s *Server) handleSetInotifyLimits(w http.ResponseWriter, r *http.Request)
 	if err := s.tuningController.SetInotifyLimits(
 		req.MaxUserWatches, req.MaxUserInstances, req.MaxQueuedEvents,
 	); err != nil {
-		logger.Error("API: Failed to set inotify limits: %v", err)
+		logger.ErrorContext(r.Context(), "API: Failed to set inotify limits: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to update inotify limits")
 		return
 	}
@@ -4995,3 +6067,99 @@ func (s *Server) handleMover(w http.ResponseWriter, _ *http.Request) {
 		Timestamp: time.Now(),
 	})
 }
+
+// handleDNSHealth godoc
+//
+//	@Summary		Get DNS health
+//	@Description	Returns the cached DNS health: configured resolvers, per-resolver response time and DNSSEC validation from the host, and upstream reachability for queries sourced from each Docker bridge gateway address (run from the host, not inside containers). Returns an empty, unhealthy sentinel until the dns collector has run.
+//	@Tags			Network
+//	@Produce		json
+//	@Success		200	{object}	dto.DNSHealth	"DNS health"
+//	@Router			/network/dns [get]
+func (s *Server) handleDNSHealth(w http.ResponseWriter, _ *http.Request) {
+	if cached := s.GetDNSHealthCache(); cached != nil {
+		respondJSON(w, http.StatusOK, cached)
+		return
+	}
+	respondJSON(w, http.StatusOK, &dto.DNSHealth{
+		Resolvers: []string{},
+		Checks:    []dto.DNSResolverCheck{},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleWANStatus godoc
+//
+//	@Summary		Get WAN status
+//	@Description	Returns the cached internet connectivity status: online state, current public IP (with the previous IP and time of the last change), and latency/packet loss to each configured probe target. Returns an offline sentinel until the wan collector has run.
+//	@Tags			Network
+//	@Produce		json
+//	@Success		200	{object}	dto.WANStatus	"WAN status"
+//	@Router			/network/wan [get]
+func (s *Server) handleWANStatus(w http.ResponseWriter, _ *http.Request) {
+	if cached := s.GetWANStatusCache(); cached != nil {
+		respondJSON(w, http.StatusOK, cached)
+		return
+	}
+	respondJSON(w, http.StatusOK, &dto.WANStatus{
+		Probes:    []dto.WANProbeResult{},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleRemoteMounts godoc
+//
+//	@Summary		Get NFS/SMB mount health
+//	@Description	Returns the cached health of remote NFS and SMB client mounts: Unassigned Devices remote shares, /etc/fstab entries and other mounted network filesystems. Each mounted share is probed with a bounded statfs, and is stale when that hangs or fails (hung NFS server, disconnected SMB session); the server's NFS (2049) or SMB (445) port is probed for reachability and latency. Stale mounts raise the built-in remote-mount-stale alert. Returns an empty list until the remote_mounts collector has run.
+//	@Tags			Network
+//	@Produce		json
+//	@Success		200	{object}	dto.RemoteMountHealth	"Remote mount health"
+//	@Router			/network/mounts [get]
+func (s *Server) handleRemoteMounts(w http.ResponseWriter, _ *http.Request) {
+	if cached := s.GetRemoteMountsCache(); cached != nil {
+		respondJSON(w, http.StatusOK, cached)
+		return
+	}
+	respondJSON(w, http.StatusOK, &dto.RemoteMountHealth{
+		Mounts:    []dto.RemoteMountStatus{},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleSpeedtest godoc
+//
+//	@Summary		Get bandwidth test results
+//	@Description	Returns the latest scheduled bandwidth test (download/upload in Mbps and ping in ms, run with speedtest-cli or iperf3) and the retained result history, oldest first. The speedtest collector is disabled by default; returns an empty history until it has run.
+//	@Tags			Network
+//	@Produce		json
+//	@Success		200	{object}	dto.SpeedtestStatus	"Bandwidth test results"
+//	@Router			/network/speedtest [get]
+func (s *Server) handleSpeedtest(w http.ResponseWriter, _ *http.Request) {
+	if cached := s.GetSpeedtestCache(); cached != nil {
+		respondJSON(w, http.StatusOK, cached)
+		return
+	}
+	respondJSON(w, http.StatusOK, &dto.SpeedtestStatus{
+		History:   []dto.SpeedtestResult{},
+		Timestamp: time.Now(),
+	})
+}
+
+// handlePower godoc
+//
+//	@Summary		Get power estimate
+//	@Description	Returns the estimated power draw of the server. Combines CPU and DRAM power (Intel RAPL), GPU power draw, UPS load and per-disk spin state. total_watts is the UPS load when a UPS reports it (source "ups"), otherwise the sum of the components (source "components"). Also returns the projected kWh per day and energy_kwh, a monotonic energy total since energy_since (resets when the agent restarts). Returns a zero estimate until the system collector has run.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.PowerEstimate	"Power estimate"
+//	@Router			/power [get]
+func (s *Server) handlePower(w http.ResponseWriter, _ *http.Request) {
+	if cached := s.GetPowerCache(); cached != nil {
+		respondJSON(w, http.StatusOK, cached)
+		return
+	}
+	respondJSON(w, http.StatusOK, &dto.PowerEstimate{
+		Source:    dto.PowerSourceComponents,
+		Timestamp: time.Now(),
+	})
+}
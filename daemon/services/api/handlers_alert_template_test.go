@@ -24,10 +24,13 @@ func (s *stubDataProvider) GetVMsCache() []dto.VMInfo                    { retur
 func (s *stubDataProvider) GetUPSCache() *dto.UPSStatus                  { return nil }
 func (s *stubDataProvider) GetGPUCache() []*dto.GPUMetrics               { return nil }
 func (s *stubDataProvider) GetZFSPoolsCache() []dto.ZFSPool              { return nil }
+func (s *stubDataProvider) GetPoolsCache() []dto.PoolInfo                { return nil }
 func (s *stubDataProvider) GetNetworkCache() []dto.NetworkInfo           { return nil }
 func (s *stubDataProvider) GetNUTCache() *dto.NUTResponse                { return nil }
 func (s *stubDataProvider) GetNotificationsCache() *dto.NotificationList { return nil }
 func (s *stubDataProvider) GetPluginUpdatesCache() *dto.PluginList       { return nil }
+func (s *stubDataProvider) GetWANStatusCache() *dto.WANStatus            { return nil }
+func (s *stubDataProvider) GetRemoteMountsCache() *dto.RemoteMountHealth { return nil }
 func (s *stubDataProvider) DegradedSubsystemCount() int                  { return 0 }
 
 // setupAlertTemplateServer creates an API server with a real in-memory alertStore
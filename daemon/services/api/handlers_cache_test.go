@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -123,6 +124,16 @@ func populateTestCaches(server *Server) {
 	}
 	server.zfsPoolsCache.Store(&pools)
 
+	unraidPools := []dto.PoolInfo{
+		{Name: "cache", FileSystem: "btrfs", Profile: "raid1", Devices: []dto.PoolDevice{{Slot: "cache", Device: "nvme0n1"}}},
+	}
+	server.poolsCache.Store(&unraidPools)
+
+	btrfs := []dto.BtrfsFilesystem{
+		{Name: "cache", MountPoint: "/mnt/cache", TotalErrors: 3, Devices: []dto.BtrfsDeviceStats{{Device: "/dev/nvme0n1p1", CorruptionErrors: 3}}},
+	}
+	server.btrfsCache.Store(&btrfs)
+
 	datasets := []dto.ZFSDataset{
 		{Name: "tank/data", Type: "filesystem", UsedBytes: 1000000000},
 	}
@@ -922,6 +933,103 @@ func TestHandleZFSPool_NotFound(t *testing.T) {
 	}
 }
 
+func TestHandlePools(t *testing.T) {
+	server, _ := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/v1/pools", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK || strings.TrimSpace(rr.Body.String()) != "[]" {
+		t.Fatalf("empty cache: got %d %s, want 200 []", rr.Code, rr.Body.String())
+	}
+
+	populateTestCaches(server)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/pools", nil))
+	var pools []dto.PoolInfo
+	if err := json.Unmarshal(rr.Body.Bytes(), &pools); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(pools) != 1 || pools[0].Profile != "raid1" {
+		t.Errorf("pools = %+v", pools)
+	}
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/pools/cache", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("GET /pools/cache: expected 200, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/pools/nonexistent", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("GET /pools/nonexistent: expected 404, got %d", rr.Code)
+	}
+}
+
+func TestHandleFormatUnassignedDevice(t *testing.T) {
+	server, _ := setupTestServer()
+	populateTestCaches(server)
+
+	tests := []struct {
+		name     string
+		device   string
+		body     string
+		wantCode int
+	}{
+		{"invalid device", "sdd;reboot", `{"filesystem":"xfs","confirm":true}`, http.StatusBadRequest},
+		{"invalid body", "sdd", `{`, http.StatusBadRequest},
+		{"missing confirm", "sdd", `{"filesystem":"xfs"}`, http.StatusBadRequest},
+		{"not unassigned", "sda", `{"filesystem":"xfs","confirm":true}`, http.StatusNotFound},
+		{"unsupported filesystem", "sdd", `{"filesystem":"ntfs","confirm":true}`, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/v1/unassigned/devices/"+tt.device+"/format", strings.NewReader(tt.body))
+			server.router.ServeHTTP(rr, req)
+			if rr.Code != tt.wantCode {
+				t.Errorf("got %d %s, want %d", rr.Code, rr.Body.String(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandleUnassignedDeviceAction_InvalidDevice(t *testing.T) {
+	server, _ := setupTestServer()
+
+	for _, action := range []string{"mount", "unmount"} {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/unassigned/devices/sdd1x/"+action, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, want 400", action, rr.Code)
+		}
+	}
+}
+
+func TestHandleBtrfs(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/btrfs", nil))
+	if rr.Code != http.StatusOK || strings.TrimSpace(rr.Body.String()) != "[]" {
+		t.Fatalf("empty cache: got %d %s, want 200 []", rr.Code, rr.Body.String())
+	}
+
+	populateTestCaches(server)
+
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/btrfs", nil))
+	var filesystems []dto.BtrfsFilesystem
+	if err := json.Unmarshal(rr.Body.Bytes(), &filesystems); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(filesystems) != 1 || filesystems[0].TotalErrors != 3 || filesystems[0].Devices[0].CorruptionErrors != 3 {
+		t.Errorf("filesystems = %+v", filesystems)
+	}
+}
+
 func TestHandleZFSDatasets_WithCache(t *testing.T) {
 	server, _ := setupTestServer()
 	populateTestCaches(server)
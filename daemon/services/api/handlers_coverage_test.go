@@ -364,6 +364,44 @@ func TestHandleUpdateShareConfig_ValidJSON(t *testing.T) {
 	t.Logf("Update share config status=%d success=%v (expected on non-Unraid)", rr.Code, resp.Success)
 }
 
+func TestHandleUpdateShareConfig_InvalidSettings(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"unknown cache mode", `{"use_cache":"sometimes"}`},
+		{"unknown allocator", `{"allocator":"random"}`},
+		{"bad floor", `{"floor":"lots"}`},
+		{"bad cache pool", `{"cache_pool":"../cache"}`},
+		{"threshold over 100", `{"mover_tuning":{"override":true,"threshold_percent":150}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/shares/media/config?dry_run=true", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, req)
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400 (body %s)", rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleUpdateShareConfig_DryRunUnknownShare(t *testing.T) {
+	server, _ := setupTestServer()
+
+	req := httptest.NewRequest("POST", "/api/v1/shares/nosuchshare/config?dry_run=true", strings.NewReader(`{"use_cache":"prefer"}`))
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	// Off Unraid no share config exists, so the dry run cannot find the share.
+	if rr.Code != http.StatusNotFound && rr.Code != http.StatusOK {
+		t.Errorf("status = %d, want 404 or 200", rr.Code)
+	}
+}
+
 func TestHandleUpdateShareConfig_PathTraversal(t *testing.T) {
 	server, _ := setupTestServer()
 
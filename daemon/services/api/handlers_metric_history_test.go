@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestHandleMetricHistory(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+	}{
+		{"missing metric", "", http.StatusBadRequest},
+		{"unbounded", "metric=cpu_usage", http.StatusOK},
+		{"lookback", "metric=cpu_usage&lookback=30m", http.StatusOK},
+		{"rfc3339 range", "metric=cpu_usage&start=2026-10-15T22:00:00Z&end=2026-10-16T06:00:00Z", http.StatusOK},
+		{"invalid start", "metric=cpu_usage&start=yesterday", http.StatusBadRequest},
+		{"invalid end", "metric=cpu_usage&end=2026-10-16", http.StatusBadRequest},
+		{"non-positive lookback", "metric=cpu_usage&lookback=-1h", http.StatusBadRequest},
+		{"start after end", "metric=cpu_usage&start=2026-10-16T06:00:00Z&end=2026-10-15T22:00:00Z", http.StatusBadRequest},
+	}
+	server := setupAlertTemplateServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/metrics/history?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rr.Code, rr.Body.String())
+			}
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var res dto.MetricHistoryResult
+			if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+			if res.Metric != "cpu_usage" || res.RetentionSeconds <= 0 {
+				t.Errorf("unexpected result: %+v", res)
+			}
+		})
+	}
+}
+
+func TestHandleMetricHistory_NoAlertEngine(t *testing.T) {
+	server, _ := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/metrics/history?metric=cpu_usage", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -94,6 +96,100 @@ func TestHandleDockerUpdate_InvalidRef(t *testing.T) {
 	}
 }
 
+func TestHandleDockerLimits_BadRequest(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name string
+		ref  string
+		body string
+	}{
+		{"invalid ref", "abc;hack", `{"cpu_shares":512}`},
+		{"invalid body", "plex", `{`},
+		{"no limits", "plex", `{}`},
+		{"memory too low", "plex", `{"memory_bytes":1024}`},
+		{"bad restart policy", "plex", `{"restart_policy":"sometimes"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PATCH", "/api/v1/docker/"+tt.ref+"/limits", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestHandleDockerAutostartOrder_BadRequest(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `{`},
+		{"missing list", `{}`},
+		{"duplicate name", `{"containers":[{"name":"plex"},{"name":"plex"}]}`},
+		{"invalid name", `{"containers":[{"name":"abc;hack"}]}`},
+		{"wait too long", `{"containers":[{"name":"plex","wait":7200}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/api/v1/docker/autostart", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestHandleVMUSBHotplug_BadRequest(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"invalid vm name", "/api/v1/vm/bad;name/usb/attach", `{"device_id":"046d:c52b"}`},
+		{"invalid body", "/api/v1/vm/win11/usb/attach", `{`},
+		{"missing device", "/api/v1/vm/win11/usb/attach", `{}`},
+		{"invalid device", "/api/v1/vm/win11/usb/detach", `{"device_id":"046d:c52b'/>"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
 func TestHandleDockerUpdate_WrongMethod(t *testing.T) {
 	server, _ := setupTestServer()
 
@@ -551,6 +647,35 @@ func TestHandleProcessList_InvalidLimit(t *testing.T) {
 	}
 }
 
+func TestHandleTopProcesses_InvalidParams(t *testing.T) {
+	server, _ := setupTestServer()
+
+	for _, query := range []string{"sort=pid", "limit=0", "limit=101", "limit=abc"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/system/processes?"+query, nil)
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestHandleTopProcesses_ValidParams(t *testing.T) {
+	server, _ := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/v1/system/processes?sort=memory&limit=5", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	// Will return 200 or 500 depending on OS (ps command)
+	if rr.Code != http.StatusOK && rr.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 200 or 500, got %d", rr.Code)
+	}
+}
+
 // ===== Route Existence Tests =====
 
 func TestNewRoutes_Exist(t *testing.T) {
@@ -576,6 +701,7 @@ func TestNewRoutes_Exist(t *testing.T) {
 		{"GET", "/api/v1/services"},
 		{"POST", "/api/v1/services/docker/start"},
 		{"GET", "/api/v1/processes"},
+		{"GET", "/api/v1/system/processes"},
 	}
 
 	for _, route := range routes {
@@ -703,3 +829,136 @@ func TestHandleVMReset_InvalidVMName(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleSystemOrchestratedShutdown_RequiresConfirm(t *testing.T) {
+	server, _ := setupTestServer()
+
+	for _, body := range []string{`not-json`, `{}`, `{"confirm": false}`} {
+		req, err := http.NewRequest("POST", "/api/v1/system/shutdown/orchestrated", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("body %s: expected status 400, got %d", body, rr.Code)
+		}
+	}
+}
+
+func TestHandlePower(t *testing.T) {
+	server, _ := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/v1/power", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	var empty dto.PowerEstimate
+	if err := json.Unmarshal(rr.Body.Bytes(), &empty); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("status %d, error %v", rr.Code, err)
+	}
+	if empty.Source != dto.PowerSourceComponents || empty.TotalWatts != 0 {
+		t.Errorf("empty estimate = %+v", empty)
+	}
+
+	server.powerCache.Store(&dto.PowerEstimate{TotalWatts: 120, Source: dto.PowerSourceUPS})
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	var cached dto.PowerEstimate
+	if err := json.Unmarshal(rr.Body.Bytes(), &cached); err != nil {
+		t.Fatal(err)
+	}
+	if cached.TotalWatts != 120 || cached.Source != dto.PowerSourceUPS {
+		t.Errorf("cached estimate = %+v", cached)
+	}
+}
+
+func TestHandleThermalSummary(t *testing.T) {
+	server, _ := setupTestServer()
+	req := httptest.NewRequest("GET", "/api/v1/system/thermal", nil)
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without alert engine, got %d", rr.Code)
+	}
+
+	server = setupAlertTemplateServer(t)
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+
+	var summary dto.ThermalSummary
+	if err := json.Unmarshal(rr.Body.Bytes(), &summary); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("status %d, error %v", rr.Code, err)
+	}
+	if summary.WindowSeconds != 86400 || summary.Sensors == nil || summary.Events == nil {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestHandleParityCheckNote(t *testing.T) {
+	server, _ := setupTestServer()
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{"invalid body", "{", http.StatusBadRequest},
+		{"note too long", `{"note":"` + strings.Repeat("x", 501) + `"}`, http.StatusBadRequest},
+		{"unknown record", `{"note":"after disk swap"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/array/parity-check/history/1735799117/note", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, req)
+			if rr.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleShareDistribution(t *testing.T) {
+	server, _ := setupTestServer()
+	mount := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(mount, "Media"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mount, "Media", "a.mkv"), make([]byte, 8192), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	disks := []dto.DiskInfo{{ID: "disk1", Role: "data", MountPoint: mount}}
+	shares := []dto.ShareInfo{{Name: "Media"}}
+	server.disksCache.Store(&disks)
+	server.sharesCache.Store(&shares)
+
+	tests := []struct {
+		path     string
+		wantCode int
+	}{
+		{"/api/v1/shares/bad..name/distribution", http.StatusBadRequest},
+		{"/api/v1/shares/Missing/distribution", http.StatusNotFound},
+		{"/api/v1/shares/Media/distribution", http.StatusOK},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+		if rr.Code != tt.wantCode {
+			t.Errorf("%s: status %d, want %d", tt.path, rr.Code, tt.wantCode)
+			continue
+		}
+		if tt.wantCode != http.StatusOK {
+			continue
+		}
+		var dist dto.ShareDistribution
+		if err := json.Unmarshal(rr.Body.Bytes(), &dist); err != nil {
+			t.Fatal(err)
+		}
+		if len(dist.Disks) != 1 || dist.TotalFiles != 1 || dist.Disks[0].Percent != 100 {
+			t.Errorf("distribution = %+v", dist)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/forwarding"
+)
+
+func setupNotificationTargetServer(t *testing.T) (*Server, *forwarding.Store) {
+	t.Helper()
+	server := NewServer(&domain.Context{Config: domain.Config{Port: 8080}})
+	store := forwarding.NewStore(t.TempDir())
+	server.SetNotificationForwarding(forwarding.NewForwarder(store, nil), store)
+	return server, store
+}
+
+func serveNotificationTarget(server *Server, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestNotificationTargetLifecycle(t *testing.T) {
+	server, store := setupNotificationTargetServer(t)
+	const secretURL = "discord://webhooktoken@123456789"
+
+	rr := serveNotificationTarget(server, "POST", "/api/v1/notifications/targets",
+		`{"id":"discord","name":"Discord","url":"`+secretURL+`","levels":["alert"],"enabled":true}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Listing is routed before /notifications/{id} and never exposes the secret.
+	rr = serveNotificationTarget(server, "GET", "/api/v1/notifications/targets", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("list: expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var targets []dto.NotificationTarget
+	if err := json.Unmarshal(rr.Body.Bytes(), &targets); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(targets) != 1 || strings.Contains(targets[0].URL, "webhooktoken") {
+		t.Fatalf("list = %+v, want one target with a redacted url", targets)
+	}
+
+	// Round-tripping the redacted target keeps the stored url.
+	targets[0].Enabled = false
+	body, _ := json.Marshal(targets[0])
+	rr = serveNotificationTarget(server, "PUT", "/api/v1/notifications/targets/discord", string(body))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("update: expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got, _ := store.GetTarget("discord"); got.URL != secretURL || got.Enabled {
+		t.Errorf("stored target = %+v, want original url and disabled", got)
+	}
+
+	rr = serveNotificationTarget(server, "DELETE", "/api/v1/notifications/targets/discord", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(store.GetTargets()) != 0 {
+		t.Error("expected the target to be deleted")
+	}
+}
+
+func TestNotificationTargetErrors(t *testing.T) {
+	server, _ := setupNotificationTargetServer(t)
+	tests := []struct {
+		name, method, path, body string
+		want                     int
+	}{
+		{"unsupported service", "POST", "/api/v1/notifications/targets", `{"id":"x","url":"https://example.com"}`, http.StatusBadRequest},
+		{"invalid json", "POST", "/api/v1/notifications/targets", `{`, http.StatusBadRequest},
+		{"update missing", "PUT", "/api/v1/notifications/targets/nope", `{}`, http.StatusNotFound},
+		{"delete missing", "DELETE", "/api/v1/notifications/targets/nope", "", http.StatusNotFound},
+		{"test missing", "POST", "/api/v1/notifications/targets/nope/test", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rr := serveNotificationTarget(server, tt.method, tt.path, tt.body); rr.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rr.Code, rr.Body.String())
+			}
+		})
+	}
+
+	uninitialized := NewServer(&domain.Context{Config: domain.Config{Port: 8080}})
+	if rr := serveNotificationTarget(uninitialized, "GET", "/api/v1/notifications/targets", ""); rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without forwarding, got %d", rr.Code)
+	}
+}
@@ -2042,3 +2042,44 @@ func TestDockerUpdatesEmptyWhenUncached(t *testing.T) {
 		t.Errorf("UpdatesAvailable = %d, want 0", got.UpdatesAvailable)
 	}
 }
+
+// startingCollectorManager reports a startup phase that is still running.
+type startingCollectorManager struct {
+	*mockCollectorManager
+}
+
+func (startingCollectorManager) Readiness() dto.Readiness {
+	return dto.Readiness{Pending: []string{"disk"}}
+}
+
+func TestHealthProbes(t *testing.T) {
+	get := func(s *Server, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		s.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+
+	server, _ := setupTestServer()
+	if rr := get(server, "/api/v1/health/ready"); rr.Code != http.StatusOK {
+		t.Errorf("ready without collector manager: got %d, want 200", rr.Code)
+	}
+
+	starting := NewServerWithCollectorManager(&domain.Context{}, startingCollectorManager{newMockCollectorManager()})
+	if rr := get(starting, "/api/v1/health/live"); rr.Code != http.StatusOK {
+		t.Errorf("live during startup: got %d, want 200", rr.Code)
+	}
+	rr := get(starting, "/api/v1/health/ready")
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("ready during startup: got %d, want 503", rr.Code)
+	}
+	var r dto.Readiness
+	if err := json.Unmarshal(rr.Body.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Ready || len(r.Pending) != 1 || r.Pending[0] != "disk" {
+		t.Errorf("readiness = %+v, want pending disk", r)
+	}
+	if rr := get(starting, "/api/v1/health"); !strings.Contains(rr.Body.String(), `"ready":false`) {
+		t.Errorf("health body = %s, want ready false", rr.Body.String())
+	}
+}
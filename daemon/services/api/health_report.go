@@ -1,12 +1,12 @@
 package api
 
 import (
-	"fmt"
 	"net/http"
 	"sort"
 	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
 )
 
 // diskTempWarning is the temperature threshold (°C) above which a disk finding is emitted as a warning.
@@ -28,8 +28,8 @@ func BuildHealthReport(
 	if array != nil && array.State != "Started" {
 		findings = append(findings, dto.HealthFinding{
 			Severity: "critical",
-			Title:    "Array not started",
-			Detail:   fmt.Sprintf("Array is in state %q — data is inaccessible until the array is started.", array.State),
+			Title:    i18n.T("Array not started"),
+			Detail:   i18n.T("Array is in state %q — data is inaccessible until the array is started.", array.State),
 		})
 	}
 
@@ -39,15 +39,15 @@ func BuildHealthReport(
 		if d.SMARTStatus != "" && d.SMARTStatus != "PASSED" {
 			findings = append(findings, dto.HealthFinding{
 				Severity: "critical",
-				Title:    fmt.Sprintf("Disk %s SMART failure", diskLabel(d)),
-				Detail:   fmt.Sprintf("Disk %s reported SMART status %q. Backup data and replace the disk.", diskLabel(d), d.SMARTStatus),
+				Title:    i18n.T("Disk %s SMART failure", diskLabel(d)),
+				Detail:   i18n.T("Disk %s reported SMART status %q. Backup data and replace the disk.", diskLabel(d), d.SMARTStatus),
 			})
 		}
 		if d.Temperature > diskTempWarning {
 			findings = append(findings, dto.HealthFinding{
 				Severity: "warning",
-				Title:    fmt.Sprintf("Disk %s high temperature", diskLabel(d)),
-				Detail:   fmt.Sprintf("Disk %s temperature is %.0f °C (threshold: %.0f °C). Improve airflow or reduce load.", diskLabel(d), d.Temperature, diskTempWarning),
+				Title:    i18n.T("Disk %s high temperature", diskLabel(d)),
+				Detail:   i18n.T("Disk %s temperature is %.0f °C (threshold: %.0f °C). Improve airflow or reduce load.", diskLabel(d), d.Temperature, diskTempWarning),
 			})
 		}
 	}
@@ -57,14 +57,14 @@ func BuildHealthReport(
 	for _, c := range containers {
 		if c.State != "running" {
 			sev := "info"
-			title := fmt.Sprintf("Container %q is not running", c.Name)
-			detail := fmt.Sprintf("Container %q is in state %q.", c.Name, c.State)
+			title := i18n.T("Container %q is not running", c.Name)
+			detail := i18n.T("Container %q is in state %q.", c.Name, c.State)
 
 			// Elevate to warning when restart count indicates repeated failures.
 			if c.RestartCount > 3 {
 				sev = "warning"
-				title = fmt.Sprintf("Container %q is not running (restarted %d times)", c.Name, c.RestartCount)
-				detail = fmt.Sprintf("Container %q is in state %q and has restarted %d times — it may be crash-looping.", c.Name, c.State, c.RestartCount)
+				title = i18n.T("Container %q is not running (restarted %d times)", c.Name, c.RestartCount)
+				detail = i18n.T("Container %q is in state %q and has restarted %d times — it may be crash-looping.", c.Name, c.State, c.RestartCount)
 			}
 
 			findings = append(findings, dto.HealthFinding{
@@ -75,7 +75,7 @@ func BuildHealthReport(
 					{
 						Action: "start_container",
 						Target: c.ID,
-						Reason: fmt.Sprintf("Start container %q to restore service.", c.Name),
+						Reason: i18n.T("Start container %q to restore service.", c.Name),
 					},
 				},
 			})
@@ -86,8 +86,8 @@ func BuildHealthReport(
 		if c.UpdateAvailable != nil && *c.UpdateAvailable {
 			findings = append(findings, dto.HealthFinding{
 				Severity: "info",
-				Title:    fmt.Sprintf("Container %q has an update available", c.Name),
-				Detail:   fmt.Sprintf("A newer image is available for container %q. Update via the Docker UI or docker pull.", c.Name),
+				Title:    i18n.T("Container %q has an update available", c.Name),
+				Detail:   i18n.T("A newer image is available for container %q. Update via the Docker UI or docker pull.", c.Name),
 			})
 		}
 	}
@@ -101,11 +101,11 @@ func BuildHealthReport(
 		}
 		msg := a.Message
 		if msg == "" {
-			msg = fmt.Sprintf("Alert rule %q is firing.", a.RuleName)
+			msg = i18n.T("Alert rule %q is firing.", a.RuleName)
 		}
 		findings = append(findings, dto.HealthFinding{
 			Severity: normalizeSeverity(sev),
-			Title:    fmt.Sprintf("Firing alert: %s", a.RuleName),
+			Title:    i18n.T("Firing alert: %s", a.RuleName),
 			Detail:   msg,
 		})
 	}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
+)
+
+// Usage thresholds for the log directory. On Unraid it is a 128 MiB RAM
+// disk by default, and once it is full syslog, the web UI and the agent can
+// no longer write their logs.
+const (
+	logDirDegradedPercent = 80.0
+	logDirDownPercent     = 95.0
+)
+
+// healthDialTimeout bounds each socket probe of the health check.
+const healthDialTimeout = 2 * time.Second
+
+// healthCacheTTL is how long a health check result is reused, so frequent
+// polling by monitors does not dial sockets and stat the disk every time.
+const healthCacheTTL = 5 * time.Second
+
+// Paths probed by the health check; variables so tests can replace them.
+var (
+	healthDockerCfg     = constants.DockerCfg
+	healthDockerSocket  = constants.DockerSocket
+	healthDomainCfg     = constants.DomainCfg
+	healthLibvirtSocket = constants.LibvirtSocket
+	healthLogDir        = constants.LogDir
+)
+
+// cachedHealthStatus returns the last health check result while it is
+// younger than healthCacheTTL and runs the checks again otherwise.
+func (s *Server) cachedHealthStatus() dto.HealthStatus {
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	if c := s.healthCache; c != nil && time.Since(c.Timestamp) < healthCacheTTL {
+		return *c
+	}
+	health := s.healthStatus()
+	s.healthCache = &health
+	return health
+}
+
+// publicHealth strips a health result down to the overall and component
+// states for unauthenticated callers. Messages name broker addresses, file
+// paths and raw errors.
+func publicHealth(h dto.HealthStatus) dto.HealthStatus {
+	components := make([]dto.ComponentHealth, len(h.Components))
+	for i, c := range h.Components {
+		components[i] = dto.ComponentHealth{Name: c.Name, Status: c.Status, Critical: c.Critical}
+	}
+	h.Components = components
+	return h
+}
+
+// healthStatus checks the components the agent depends on and rolls their
+// states up into the overall status.
+func (s *Server) healthStatus() dto.HealthStatus {
+	components := []dto.ComponentHealth{
+		s.serviceSocketHealth("docker", healthDockerCfg, collectors.DockerServiceDisabledAt, healthDockerSocket),
+		s.serviceSocketHealth("libvirt", healthDomainCfg, collectors.VMServiceDisabledAt, healthLibvirtSocket),
+		s.mqttHealth(),
+		s.collectorsHealth(),
+		logDirHealth(healthLogDir),
+	}
+
+	status := dto.HealthOK
+	for _, c := range components {
+		switch {
+		case c.Status == dto.HealthDown && c.Critical:
+			status = dto.HealthDown
+		case (c.Status == dto.HealthDown || c.Status == dto.HealthDegraded) && status == dto.HealthOK:
+			status = dto.HealthDegraded
+		}
+	}
+
+	return dto.HealthStatus{
+		Status:     status,
+		Ready:      s.readiness().Ready,
+		Components: components,
+		Timestamp:  time.Now(),
+	}
+}
+
+// serviceSocketHealth checks a service Unraid can turn off. It is disabled
+// when its config file is missing or turns it off, or while the array is
+// stopped (Docker and VMs only run with a started array), and down when its
+// socket does not accept connections.
+func (s *Server) serviceSocketHealth(name, cfgPath string, disabledAt func(string) bool, socket string) dto.ComponentHealth {
+	c := dto.ComponentHealth{Name: name, Status: dto.HealthDisabled, Critical: true}
+	if _, err := os.Stat(cfgPath); err != nil || disabledAt(cfgPath) {
+		c.Message = "service not enabled"
+		return c
+	}
+	if array := s.GetArrayCache(); array != nil && array.State != "Started" {
+		c.Message = "array not started"
+		return c
+	}
+
+	conn, err := net.DialTimeout("unix", socket, healthDialTimeout)
+	if err != nil {
+		c.Status = dto.HealthDown
+		c.Message = err.Error()
+		return c
+	}
+	_ = conn.Close()
+	c.Status = dto.HealthOK
+	return c
+}
+
+// mqttHealth reports the broker connection. It is not critical: the API
+// keeps serving data while MQTT reconnects.
+func (s *Server) mqttHealth() dto.ComponentHealth {
+	c := dto.ComponentHealth{Name: "mqtt", Status: dto.HealthDisabled}
+	if s.mqttClient == nil {
+		return c
+	}
+	status := s.mqttClient.GetStatus()
+	switch {
+	case status == nil || !status.Enabled:
+		return c
+	case status.Connected:
+		c.Status = dto.HealthOK
+	default:
+		c.Status = dto.HealthDown
+		c.Message = "not connected to " + status.Broker
+		if status.LastError != "" {
+			c.Message += ": " + status.LastError
+		}
+	}
+	return c
+}
+
+// collectorsHealth reports enabled collectors whose data went stale, by the
+// same rule as the cache freshness headers. Collectors that never published
+// are left out; readiness lists them. It is down when every collector that
+// has published has gone stale, i.e. the agent no longer collects anything.
+func (s *Server) collectorsHealth() dto.ComponentHealth {
+	c := dto.ComponentHealth{Name: "collectors", Status: dto.HealthDisabled, Critical: true}
+	if s.collectorManager == nil {
+		return c
+	}
+	c.Status = dto.HealthOK
+	if !s.readiness().Ready {
+		c.Message = "starting"
+		return c
+	}
+
+	latest := make(map[string]time.Time)
+	for topic, name := range constants.TopicCollectors {
+		if v, ok := s.CacheStore.updatedAt.Load(topic); ok {
+			if t := v.(time.Time); t.After(latest[name]) {
+				latest[name] = t
+			}
+		}
+	}
+
+	now := time.Now()
+	checked := 0
+	var stale []string
+	for _, status := range s.collectorManager.GetAllStatus().Collectors {
+		updated, ok := latest[status.Name]
+		if !ok || !status.Enabled || status.Interval <= 0 {
+			continue
+		}
+		checked++
+		if now.Sub(updated) > time.Duration(status.Interval*staleFactor)*time.Second {
+			stale = append(stale, status.Name)
+		}
+	}
+
+	switch {
+	case len(stale) == 0:
+	case len(stale) == checked:
+		c.Status = dto.HealthDown
+		c.Message = "no collector has published recently"
+	default:
+		c.Status = dto.HealthDegraded
+		c.Message = "stale: " + strings.Join(stale, ", ")
+	}
+	return c
+}
+
+// logDirHealth reports how full the log directory is.
+func logDirHealth(dir string) dto.ComponentHealth {
+	c := dto.ComponentHealth{Name: "log_disk", Critical: true}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		c.Status = dto.HealthDegraded
+		c.Message = err.Error()
+		return c
+	}
+	if st.Blocks == 0 {
+		c.Status = dto.HealthOK
+		return c
+	}
+
+	used := float64(st.Blocks-st.Bfree) / float64(st.Blocks) * 100
+	c.Message = fmt.Sprintf("%s %.0f%% used", dir, used)
+	switch {
+	case used >= logDirDownPercent:
+		c.Status = dto.HealthDown
+	case used >= logDirDegradedPercent:
+		c.Status = dto.HealthDegraded
+	default:
+		c.Status = dto.HealthOK
+	}
+	return c
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// useHealthPaths points the health probes at dir for the test.
+func useHealthPaths(t *testing.T, dir string) {
+	t.Helper()
+	saved := []string{healthDockerCfg, healthDockerSocket, healthDomainCfg, healthLibvirtSocket}
+	t.Cleanup(func() {
+		healthDockerCfg, healthDockerSocket, healthDomainCfg, healthLibvirtSocket = saved[0], saved[1], saved[2], saved[3]
+	})
+	healthDockerCfg = filepath.Join(dir, "docker.cfg")
+	healthDockerSocket = filepath.Join(dir, "docker.sock")
+	healthDomainCfg = filepath.Join(dir, "domain.cfg")
+	healthLibvirtSocket = filepath.Join(dir, "libvirt-sock")
+}
+
+func component(t *testing.T, h dto.HealthStatus, name string) dto.ComponentHealth {
+	t.Helper()
+	for _, c := range h.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("component %q missing from %+v", name, h.Components)
+	return dto.ComponentHealth{}
+}
+
+// getHealth runs fresh probes, bypassing the result cache.
+func getHealth(t *testing.T, s *Server) (int, dto.HealthStatus) {
+	t.Helper()
+	s.healthMu.Lock()
+	s.healthCache = nil
+	s.healthMu.Unlock()
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	var h dto.HealthStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &h); err != nil {
+		t.Fatalf("decode health: %v", err)
+	}
+	return rr.Code, h
+}
+
+func TestHealthComponents(t *testing.T) {
+	dir := t.TempDir()
+	useHealthPaths(t, dir)
+	server, _ := setupTestServer()
+
+	// Neither service is configured on this host
+	code, h := getHealth(t, server)
+	if code != http.StatusOK || h.Status != dto.HealthOK {
+		t.Fatalf("unconfigured host: %d %s, want 200 ok", code, h.Status)
+	}
+	for _, name := range []string{"docker", "libvirt", "mqtt", "collectors"} {
+		if c := component(t, h, name); c.Status != dto.HealthDisabled {
+			t.Errorf("%s = %s, want disabled", name, c.Status)
+		}
+	}
+
+	// Docker enabled but its daemon is not listening: critical, so 503
+	if err := os.WriteFile(healthDockerCfg, []byte(`DOCKER_ENABLED="yes"`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	code, h = getHealth(t, server)
+	if code != http.StatusServiceUnavailable || h.Status != dto.HealthDown {
+		t.Fatalf("docker down: %d %s, want 503 down", code, h.Status)
+	}
+	if c := component(t, h, "docker"); c.Status != dto.HealthDown || !c.Critical || c.Message == "" {
+		t.Errorf("docker = %+v, want critical down with a message", c)
+	}
+
+	// While the array is stopped Docker is expected to be down
+	server.arrayCache.Store(&dto.ArrayStatus{State: "Stopped"})
+	if code, h = getHealth(t, server); code != http.StatusOK || component(t, h, "docker").Status != dto.HealthDisabled {
+		t.Errorf("array stopped: %d, docker %s, want 200 and disabled", code, component(t, h, "docker").Status)
+	}
+	server.arrayCache.Store(&dto.ArrayStatus{State: "Started"})
+
+	l, err := net.Listen("unix", healthDockerSocket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if code, h = getHealth(t, server); code != http.StatusOK || component(t, h, "docker").Status != dto.HealthOK {
+		t.Errorf("docker listening: %d, docker %s, want 200 and ok", code, component(t, h, "docker").Status)
+	}
+
+	// A disconnected broker degrades the agent without failing it
+	server.SetMQTTClient(&mockMQTTClient{})
+	code, h = getHealth(t, server)
+	if code != http.StatusOK || h.Status != dto.HealthDegraded || component(t, h, "mqtt").Status != dto.HealthDown {
+		t.Errorf("mqtt down: %d %s, mqtt %s, want 200 degraded with mqtt down", code, h.Status, component(t, h, "mqtt").Status)
+	}
+}
+
+func TestCollectorsHealth(t *testing.T) {
+	cm := newMockCollectorManager()
+	server := NewServerWithCollectorManager(&domain.Context{}, cm)
+	interval := time.Duration(cm.statuses["system"].Interval) * time.Second
+
+	if c := server.collectorsHealth(); c.Status != dto.HealthOK {
+		t.Errorf("nothing published yet = %+v, want ok", c)
+	}
+
+	server.markUpdated(constants.TopicSystemUpdate.Name, time.Now())
+	if c := server.collectorsHealth(); c.Status != dto.HealthOK {
+		t.Errorf("fresh = %+v, want ok", c)
+	}
+
+	server.markUpdated(constants.TopicSystemUpdate.Name, time.Now().Add(-interval*(staleFactor+1)))
+	if c := server.collectorsHealth(); c.Status != dto.HealthDown {
+		t.Errorf("only collector stale = %+v, want down", c)
+	}
+
+	server.markUpdated(constants.TopicContainerListUpdate.Name, time.Now())
+	if c := server.collectorsHealth(); c.Status != dto.HealthDegraded || c.Message != "stale: system" {
+		t.Errorf("one of two stale = %+v, want degraded listing system", c)
+	}
+}
+
+func TestHealthCached(t *testing.T) {
+	dir := t.TempDir()
+	useHealthPaths(t, dir)
+	server, _ := setupTestServer()
+
+	if code, _ := getHealth(t, server); code != http.StatusOK {
+		t.Fatalf("unconfigured host: %d, want 200", code)
+	}
+
+	// Docker going down is not seen until the cached result expires
+	if err := os.WriteFile(healthDockerCfg, []byte(`DOCKER_ENABLED="yes"`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("within TTL: %d, want the cached 200", rr.Code)
+	}
+
+	server.healthMu.Lock()
+	server.healthCache.Timestamp = time.Now().Add(-healthCacheTTL)
+	server.healthMu.Unlock()
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("after TTL: %d, want 503", rr.Code)
+	}
+}
+
+func TestHealthPublicWithoutDetails(t *testing.T) {
+	dir := t.TempDir()
+	useHealthPaths(t, dir)
+	server := setupAuthServer(t, true)
+	server.SetMQTTClient(&mockMQTTClient{})
+	if err := os.WriteFile(healthDockerCfg, []byte(`DOCKER_ENABLED="yes"`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		token       string
+		wantMessage bool
+	}{
+		{"", false},
+		{"static-key", true},
+	} {
+		rr := authRequest(server, http.MethodGet, "/api/v1/health", "", tc.token)
+		if rr.Code != http.StatusServiceUnavailable {
+			t.Fatalf("token %q: %d, want 503", tc.token, rr.Code)
+		}
+		var h dto.HealthStatus
+		if err := json.Unmarshal(rr.Body.Bytes(), &h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Status != dto.HealthDown {
+			t.Errorf("token %q: status %s, want down", tc.token, h.Status)
+		}
+		for _, name := range []string{"docker", "mqtt"} {
+			c := component(t, h, name)
+			if c.Status != dto.HealthDown {
+				t.Errorf("token %q: %s = %s, want down", tc.token, name, c.Status)
+			}
+			if (c.Message != "") != tc.wantMessage {
+				t.Errorf("token %q: %s message %q, want present %v", tc.token, name, c.Message, tc.wantMessage)
+			}
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/hooks"
+)
+
+// SetHooks sets the inbound webhook store and runner backing the /hooks endpoints.
+func (s *Server) SetHooks(runner *hooks.Runner, store *hooks.Store) {
+	s.hookRunner = runner
+	s.hookStore = store
+}
+
+// hooksReady writes a 503 response and returns false when inbound webhooks
+// are not initialized.
+func (s *Server) hooksReady(w http.ResponseWriter) bool {
+	if s.hookStore == nil || s.hookRunner == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Inbound webhooks not initialized")
+		return false
+	}
+	return true
+}
+
+// isHookDelivery reports whether r delivers an inbound webhook. Deliveries
+// authenticate with the hook secret, so they bypass AUTH_REQUIRED.
+func isHookDelivery(r *http.Request) bool {
+	name, ok := strings.CutPrefix(r.URL.Path, "/api/v1/hooks/")
+	return ok && r.Method == http.MethodPost && name != "" && !strings.Contains(name, "/")
+}
+
+// redactHook hides a webhook's secret before it is returned to clients.
+func redactHook(hook dto.InboundHookInfo) dto.InboundHookInfo {
+	if hook.Secret != "" {
+		hook.Secret = dto.RedactedSecret
+	}
+	return hook
+}
+
+// handleListHooks godoc
+//
+//	@Summary		List inbound webhooks
+//	@Description	Inbound webhooks with their action and delivery statistics since the agent started. Secrets are redacted.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Success		200	{array}		dto.InboundHookInfo	"Inbound webhooks"
+//	@Failure		503	{object}	dto.Response		"Inbound webhooks not initialized"
+//	@Router			/hooks [get]
+func (s *Server) handleListHooks(w http.ResponseWriter, _ *http.Request) {
+	if !s.hooksReady(w) {
+		return
+	}
+	list := s.hookStore.List()
+	for i := range list {
+		list[i] = redactHook(list[i])
+	}
+	respondJSON(w, http.StatusOK, list)
+}
+
+// handleCreateHook godoc
+//
+//	@Summary		Create inbound webhook
+//	@Description	Create a webhook at /api/v1/hooks/{name} that runs a registered script, starts, stops or restarts a container, or starts or stops a VM. A secret is generated when none is given; it is returned only by this call.
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			hook	body		dto.InboundHook			true	"Webhook"
+//	@Success		201		{object}	dto.InboundHookCreated	"Created"
+//	@Failure		400		{object}	dto.Response			"Invalid request"
+//	@Failure		409		{object}	dto.Response			"Webhook already exists"
+//	@Failure		503		{object}	dto.Response			"Inbound webhooks not initialized"
+//	@Router			/hooks [post]
+func (s *Server) handleCreateHook(w http.ResponseWriter, r *http.Request) {
+	if !s.hooksReady(w) {
+		return
+	}
+	var hook dto.InboundHook
+	if err := json.NewDecoder(r.Body).Decode(&hook); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	secret, err := s.hookStore.Create(hook)
+	if err != nil {
+		respondHookError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, dto.InboundHookCreated{
+		Success:   true,
+		Message:   "Webhook created",
+		URL:       "/api/v1/hooks/" + strings.ToLower(strings.TrimSpace(hook.Name)),
+		Secret:    secret,
+		Timestamp: time.Now(),
+	})
+}
+
+// handleGetHook godoc
+//
+//	@Summary		Get inbound webhook
+//	@Description	Get an inbound webhook by name. The secret is redacted.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			name	path		string				true	"Webhook name"
+//	@Success		200		{object}	dto.InboundHookInfo	"Webhook"
+//	@Failure		404		{object}	dto.Response		"Not found"
+//	@Failure		503		{object}	dto.Response		"Inbound webhooks not initialized"
+//	@Router			/hooks/{name} [get]
+func (s *Server) handleGetHook(w http.ResponseWriter, r *http.Request) {
+	if !s.hooksReady(w) {
+		return
+	}
+	hook, err := s.hookStore.Get(mux.Vars(r)["name"])
+	if err != nil {
+		respondHookError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, redactHook(*hook))
+}
+
+// handleUpdateHook godoc
+//
+//	@Summary		Update inbound webhook
+//	@Description	Replace an inbound webhook's action and settings. An empty or redacted secret keeps the stored one.
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string			true	"Webhook name"
+//	@Param			hook	body		dto.InboundHook	true	"Webhook"
+//	@Success		200		{object}	dto.Response	"Updated"
+//	@Failure		400		{object}	dto.Response	"Invalid request"
+//	@Failure		404		{object}	dto.Response	"Not found"
+//	@Failure		503		{object}	dto.Response	"Inbound webhooks not initialized"
+//	@Router			/hooks/{name} [put]
+func (s *Server) handleUpdateHook(w http.ResponseWriter, r *http.Request) {
+	if !s.hooksReady(w) {
+		return
+	}
+	var hook dto.InboundHook
+	if err := json.NewDecoder(r.Body).Decode(&hook); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	hook.Name = mux.Vars(r)["name"]
+	if err := s.hookStore.Update(hook); err != nil {
+		respondHookError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Webhook updated", Timestamp: time.Now()})
+}
+
+// handleDeleteHook godoc
+//
+//	@Summary		Delete inbound webhook
+//	@Description	Delete an inbound webhook
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			name	path		string			true	"Webhook name"
+//	@Success		200		{object}	dto.Response	"Deleted"
+//	@Failure		404		{object}	dto.Response	"Not found"
+//	@Failure		503		{object}	dto.Response	"Inbound webhooks not initialized"
+//	@Router			/hooks/{name} [delete]
+func (s *Server) handleDeleteHook(w http.ResponseWriter, r *http.Request) {
+	if !s.hooksReady(w) {
+		return
+	}
+	if err := s.hookStore.Delete(mux.Vars(r)["name"]); err != nil {
+		respondHookError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Webhook deleted", Timestamp: time.Now()})
+}
+
+// handleDeliverHook godoc
+//
+//	@Summary		Deliver inbound webhook
+//	@Description	Trigger an inbound webhook's action. The caller proves knowledge of the secret with an X-Hub-Signature-256 HMAC of the body (GitHub, Gitea), the X-Webhook-Secret header, or the basic auth password (Sonarr, Radarr); no API credentials are needed even with AUTH_REQUIRED. The action runs in the background. GitHub ping and *arr Test deliveries, and events not in the hook's events list, are acknowledged without running it.
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			name				path		string			true	"Webhook name"
+//	@Param			X-Hub-Signature-256	header		string			false	"sha256=<hex HMAC-SHA256 of the body>"
+//	@Param			X-Webhook-Secret	header		string			false	"Webhook secret"
+//	@Success		200					{object}	dto.Response	"Test delivery or ignored event"
+//	@Success		202					{object}	dto.Response	"Action started"
+//	@Failure		401					{object}	dto.Response	"Missing or invalid secret"
+//	@Failure		403					{object}	dto.Response	"Webhook disabled"
+//	@Failure		404					{object}	dto.Response	"Not found"
+//	@Failure		503					{object}	dto.Response	"Inbound webhooks not initialized"
+//	@Router			/hooks/{name} [post]
+func (s *Server) handleDeliverHook(w http.ResponseWriter, r *http.Request) {
+	if !s.hooksReady(w) {
+		return
+	}
+	hook, err := s.hookStore.Get(mux.Vars(r)["name"])
+	if err != nil {
+		respondHookError(w, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to read body: "+err.Error())
+		return
+	}
+	if !hooks.Verify(hook.Secret, r, body) {
+		logger.WarningContext(r.Context(), "API: rejected delivery to webhook '%s' from %s: invalid secret", hook.Name, r.RemoteAddr)
+		respondWithError(w, http.StatusUnauthorized, "Missing or invalid webhook secret")
+		return
+	}
+	if !hook.Enabled {
+		respondWithError(w, http.StatusForbidden, "Webhook is disabled")
+		return
+	}
+
+	event := hooks.Event(r, body)
+	if hooks.IsTest(event) {
+		respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Test delivery received; action not run", Timestamp: time.Now()})
+		return
+	}
+	if !hooks.Accepts(hook.Events, event) {
+		respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: fmt.Sprintf("Event %q ignored", event), Timestamp: time.Now()})
+		return
+	}
+
+	s.hookRunner.Trigger(hook.InboundHook, event, r.Header.Get("X-GitHub-Delivery"))
+	respondJSON(w, http.StatusAccepted, dto.Response{
+		Success:   true,
+		Message:   fmt.Sprintf("Started %s %s", hook.Action, hook.Target),
+		Timestamp: time.Now(),
+	})
+}
+
+// respondHookError maps inbound webhook errors to HTTP statuses.
+func respondHookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, hooks.ErrNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, hooks.ErrExists):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/hooks"
+)
+
+func TestHookEndpoints(t *testing.T) {
+	server, ctx := setupTestServer()
+
+	do := func(method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := do("GET", "/hooks", "", nil); rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("without store: status %d, want 503", rr.Code)
+	}
+
+	store := hooks.NewStore(t.TempDir())
+	// No script registry: script actions fail in the background without side effects.
+	server.SetHooks(hooks.NewRunner(store, nil), store)
+
+	rr := do("POST", "/hooks", `{"name": "sync", "action": "script", "target": "sync-media", "events": ["Download"], "enabled": true}`, nil)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", rr.Code, rr.Body.String())
+	}
+	var created dto.InboundHookCreated
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.URL != "/api/v1/hooks/sync" || created.Secret == "" {
+		t.Errorf("created = %+v", created)
+	}
+
+	rr = do("GET", "/hooks/sync", "", nil)
+	if rr.Code != http.StatusOK || strings.Contains(rr.Body.String(), created.Secret) {
+		t.Errorf("get: status %d, secret must be redacted: %s", rr.Code, rr.Body.String())
+	}
+
+	// Auth is required for management but not for deliveries.
+	ctx.Auth.Required = true
+	if rr := do("GET", "/hooks", "", nil); rr.Code != http.StatusUnauthorized {
+		t.Errorf("list with auth required: status %d, want 401", rr.Code)
+	}
+
+	secret := map[string]string{hooks.SecretHeader: created.Secret}
+	deliveries := []struct {
+		name   string
+		path   string
+		body   string
+		header map[string]string
+		want   int
+	}{
+		{"unknown hook", "/hooks/nope", `{}`, secret, http.StatusNotFound},
+		{"no secret", "/hooks/sync", `{"eventType":"Download"}`, nil, http.StatusUnauthorized},
+		{"wrong secret", "/hooks/sync", `{"eventType":"Download"}`, map[string]string{hooks.SecretHeader: "wrong-secret-value"}, http.StatusUnauthorized},
+		{"test event", "/hooks/sync", `{"eventType":"Test"}`, secret, http.StatusOK},
+		{"filtered event", "/hooks/sync", `{"eventType":"Grab"}`, secret, http.StatusOK},
+		{"accepted", "/hooks/sync", `{"eventType":"Download"}`, secret, http.StatusAccepted},
+	}
+	for _, tt := range deliveries {
+		t.Run(tt.name, func(t *testing.T) {
+			if rr := do("POST", tt.path, tt.body, tt.header); rr.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", rr.Code, tt.want, rr.Body.String())
+			}
+		})
+	}
+	ctx.Auth.Required = false
+
+	if rr := do("PUT", "/hooks/sync", `{"action": "script", "target": "sync-media", "enabled": false}`, nil); rr.Code != http.StatusOK {
+		t.Fatalf("update: status %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := do("POST", "/hooks/sync", `{}`, secret); rr.Code != http.StatusForbidden {
+		t.Errorf("disabled delivery: status %d, want 403", rr.Code)
+	}
+	if rr := do("DELETE", "/hooks/sync", "", nil); rr.Code != http.StatusOK {
+		t.Errorf("delete: status %d", rr.Code)
+	}
+	if rr := do("GET", "/hooks/sync", "", nil); rr.Code != http.StatusNotFound {
+		t.Errorf("get after delete: status %d, want 404", rr.Code)
+	}
+}
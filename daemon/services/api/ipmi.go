@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// handleIPMI godoc
+//
+//	@Summary		Get IPMI sensors and chassis status
+//	@Description	Returns the BMC's fan, voltage, temperature, power and current sensors with their critical thresholds, the power supply states, and the chassis status (power, faults, intrusion), read with ipmitool. available is false when ipmitool is missing or the board has no BMC.
+//	@Tags			Hardware
+//	@Produce		json
+//	@Success		200	{object}	dto.IPMIStatus	"IPMI sensors and chassis status"
+//	@Router			/ipmi [get]
+func (s *Server) handleIPMI(w http.ResponseWriter, _ *http.Request) {
+	if cached := s.GetIPMICache(); cached != nil {
+		respondJSON(w, http.StatusOK, cached)
+		return
+	}
+	respondJSON(w, http.StatusOK, &dto.IPMIStatus{
+		Sensors:   []dto.IPMISensor{},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleIPMIChassisAction godoc
+//
+//	@Summary		Send an IPMI chassis command
+//	@Description	Blink the chassis identify LED (identify), or change the power state through the BMC: soft (ACPI shutdown), off (hard power off), cycle or reset. Power actions bypass Unraid and do not stop the array, so they require confirm=true, and off, cycle and reset also require a confirmation token when CONFIRM_DESTRUCTIVE is on; use them only when the OS no longer responds to a normal shutdown.
+//	@Tags			Hardware
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.IPMIChassisActionRequest	true	"Chassis action"
+//	@Success		200		{object}	dto.Response					"Command sent"
+//	@Failure		400		{object}	dto.Response					"Invalid action or missing confirmation"
+//	@Failure		412		{object}	dto.Response					"Invalid or expired confirmation token"
+//	@Failure		428		{object}	dto.ConfirmationChallenge		"Confirmation required"
+//	@Failure		500		{object}	dto.Response					"ipmitool failed"
+//	@Router			/ipmi/chassis [post]
+func (s *Server) handleIPMIChassisAction(w http.ResponseWriter, r *http.Request) {
+	var req dto.IPMIChassisActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if controllers.IsIPMIPowerAction(req.Action) && !req.Confirm {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("confirm must be true to send chassis power %s", req.Action))
+		return
+	}
+	if action, ok := s.ipmiChassisPowerAction(req.Action); ok && !s.confirmDestructive(w, r, action) {
+		return
+	}
+
+	if err := controllers.IPMIChassisAction(req.Action, req.IdentifySeconds); err != nil {
+		if errors.Is(err, controllers.ErrInvalidIPMIAction) {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.ErrorContext(r.Context(), "API: IPMI chassis %s failed: %v", req.Action, err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.Response{
+		Success:   true,
+		Message:   fmt.Sprintf("Chassis %s command sent", req.Action),
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestHandleIPMI(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/ipmi", nil))
+	var status dto.IPMIStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("empty cache: status %d, error %v", rr.Code, err)
+	}
+	if status.Available || status.Sensors == nil {
+		t.Errorf("empty cache: status = %+v", status)
+	}
+
+	rpm := 1400.0
+	server.ipmiCache.Store(&dto.IPMIStatus{
+		Available: true,
+		Chassis:   &dto.IPMIChassis{PowerOn: true},
+		Sensors:   []dto.IPMISensor{{Name: "FAN1", Type: dto.IPMISensorFan, Value: &rpm, Unit: "RPM", Status: "ok"}},
+	})
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/ipmi", nil))
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.Available || len(status.Sensors) != 1 || *status.Sensors[0].Value != 1400 || !status.Chassis.PowerOn {
+		t.Errorf("cached status = %+v", status)
+	}
+}
+
+func TestHandleIPMIChassisActionValidation(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name, body string
+	}{
+		{"invalid json", `{`},
+		{"power action without confirm", `{"action":"off"}`},
+		{"unknown action", `{"action":"explode","confirm":true}`},
+		{"identify out of range", `{"action":"identify","identify_seconds":600}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/ipmi/chassis", strings.NewReader(tt.body)))
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("status %d, want 400: %s", rr.Code, rr.Body)
+			}
+		})
+	}
+}
+
+func TestHandleIPMIChassisActionConfirmation(t *testing.T) {
+	server := setupConfirmServer()
+
+	chassisRequest := func(action, token string) *http.Request {
+		req := httptest.NewRequest("POST", "/api/v1/ipmi/chassis",
+			strings.NewReader(`{"action":"`+action+`","confirm":true}`))
+		if token != "" {
+			req.Header.Set(dto.ConfirmTokenHeader, token)
+		}
+		return req
+	}
+
+	for _, action := range []string{"off", "cycle", "reset"} {
+		challenge := requestChallenge(t, server, chassisRequest(action, ""))
+		if challenge.Action != "ipmi_chassis_power" || !strings.Contains(strings.Join(challenge.Consequences, "\n"), "plex") {
+			t.Errorf("%s: challenge = %+v", action, challenge)
+		}
+	}
+
+	// A token for one power action does not confirm another
+	challenge := requestChallenge(t, server, chassisRequest("cycle", ""))
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, chassisRequest("off", challenge.ConfirmToken))
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("token for cycle used for off: got %d, want 412", rr.Code)
+	}
+
+	// identify and the ACPI shutdown need no token
+	for _, action := range []string{"identify", "soft"} {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, chassisRequest(action, ""))
+		if rr.Code == http.StatusPreconditionRequired {
+			t.Errorf("%s: asked for a confirmation token", action)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/jobs"
+)
+
+var (
+	// parityPollInterval is how often a parity check job samples the array
+	// cache for progress.
+	parityPollInterval = 10 * time.Second
+
+	// parityStartGrace is how long a parity check job waits for the array
+	// collector to report the check before treating it as finished.
+	parityStartGrace = 2 * time.Minute
+)
+
+// wantsAsync reports whether the request asks to run as a background job
+// (?async=true) instead of waiting for the operation to finish.
+func wantsAsync(r *http.Request) bool {
+	return r.URL.Query().Get("async") == "true"
+}
+
+// respondJobAccepted answers 202 Accepted with the job and its location.
+func respondJobAccepted(w http.ResponseWriter, job dto.Job) {
+	w.Header().Set("Location", "/api/v1/jobs/"+job.ID)
+	respondJSON(w, http.StatusAccepted, job)
+}
+
+// handleJobs godoc
+//
+//	@Summary		List jobs
+//	@Description	List queued, running and recently finished background jobs, newest first. Logs and results are omitted; fetch a job by ID for them. Finished jobs are kept for an hour.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Success		200	{array}	dto.Job	"Jobs"
+//	@Router			/jobs [get]
+func (s *Server) handleJobs(w http.ResponseWriter, _ *http.Request) {
+	respondJSON(w, http.StatusOK, s.jobManager.List())
+}
+
+// handleJob godoc
+//
+//	@Summary		Get a job
+//	@Description	Get the state, progress, log lines and result of a background job.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Param			id	path		string			true	"Job ID"
+//	@Success		200	{object}	dto.Job			"Job"
+//	@Failure		404	{object}	dto.Response	"Job not found"
+//	@Router			/jobs/{id} [get]
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobManager.Get(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, job)
+}
+
+// handleJobCancel godoc
+//
+//	@Summary		Cancel a job
+//	@Description	Cancel a queued or running job. A running parity check or mover is stopped; a container update or VM hibernation already sent to Docker or libvirt runs to completion. Cancelling a finished job has no effect.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Param			id	path		string			true	"Job ID"
+//	@Success		200	{object}	dto.Job			"Job"
+//	@Failure		404	{object}	dto.Response	"Job not found"
+//	@Router			/jobs/{id} [delete]
+func (s *Server) handleJobCancel(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobManager.Cancel(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, job)
+}
+
+// handleMoverStart godoc
+//
+//	@Summary		Start the mover
+//	@Description	Start the mover, like the Move button on the Main page, as a background job that finishes when the mover does. Cancelling the job stops the mover.
+//	@Tags			Mover
+//	@Produce		json
+//	@Success		202	{object}	dto.Job	"Mover job queued"
+//	@Router			/mover/start [post]
+func (s *Server) handleMoverStart(w http.ResponseWriter, r *http.Request) {
+	respondJobAccepted(w, s.jobManager.Submit(s.detachedContext(r), dto.JobKindMover, "", moverJob))
+}
+
+// handlePoolTrim godoc
+//
+//	@Summary		Trim a pool
+//	@Description	Discard unused blocks on a mounted SSD pool as a background job: fstrim for btrfs and XFS pools, zpool trim for ZFS pools. The job result reports the bytes trimmed (not reported for ZFS) and the run is shown as last_trim on the pool and its disks. Cancelling the job stops fstrim; a ZFS trim continues in the background.
+//	@Tags			Disks
+//	@Produce		json
+//	@Param			name	path		string			true	"Pool name"
+//	@Success		202		{object}	dto.Job			"Trim job queued; its result is a dto.TrimResult"
+//	@Failure		404		{object}	dto.Response	"Pool not found"
+//	@Failure		409		{object}	dto.Response	"Pool not mounted"
+//	@Router			/pools/{name}/trim [post]
+func (s *Server) handlePoolTrim(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	for _, pool := range s.GetPoolsCache() {
+		if pool.Name != name {
+			continue
+		}
+		if pool.Status != "" && pool.Status != "Mounted" {
+			respondWithError(w, http.StatusConflict, fmt.Sprintf("Pool %s is not mounted", name))
+			return
+		}
+		respondJobAccepted(w, s.jobManager.Submit(s.detachedContext(r), dto.JobKindPoolTrim, name, poolTrimJob(pool)))
+		return
+	}
+	respondWithError(w, http.StatusNotFound, fmt.Sprintf("Pool not found: %s", name))
+}
+
+// poolTrimJob trims a pool and returns the trim result.
+func poolTrimJob(pool dto.PoolInfo) jobs.Func {
+	return func(ctx context.Context, p *jobs.Progress) (any, error) {
+		p.Log("trimming %s", pool.MountPoint)
+		result, err := controllers.TrimPool(ctx, pool)
+		if err != nil {
+			return nil, err
+		}
+		p.Log("trimmed %d bytes in %.1fs", result.TrimmedBytes, result.DurationSeconds)
+		return result, nil
+	}
+}
+
+// userCancelled reports whether a job context was cancelled through the job
+// API rather than by the agent stopping.
+func userCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), jobs.ErrCancelled)
+}
+
+// moverJob runs the mover until it finishes, stopping it when the job is
+// cancelled. The agent stopping leaves the mover running.
+func moverJob(ctx context.Context, p *jobs.Progress) (any, error) {
+	type outcome struct {
+		out string
+		err error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		out, err := controllers.RunMover(context.WithoutCancel(ctx))
+		done <- outcome{out, err}
+	}()
+	p.Log("mover started")
+
+	select {
+	case o := <-done:
+		if o.out != "" {
+			p.Log("%s", o.out)
+		}
+		return nil, o.err
+	case <-ctx.Done():
+		if userCancelled(ctx) {
+			if err := controllers.StopMover(); err != nil {
+				p.Log("failed to stop mover: %v", err)
+			} else {
+				p.Log("mover stopped")
+			}
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// parityCheckJob starts a parity check and follows its progress in the
+// array cache until it finishes. Cancelling the job stops the check.
+func (s *Server) parityCheckJob(correcting bool) jobs.Func {
+	return func(ctx context.Context, p *jobs.Progress) (any, error) {
+		arrayCtrl := controllers.NewArrayController(s.ctx)
+		if err := arrayCtrl.StartParityCheck(correcting, dto.ParityTriggerAPI); err != nil {
+			return nil, err
+		}
+		p.Log("parity check started (correcting: %v)", correcting)
+
+		started := time.Now()
+		seen := false
+		ticker := time.NewTicker(parityPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				if userCancelled(ctx) {
+					if err := arrayCtrl.StopParityCheck(); err != nil {
+						p.Log("failed to stop parity check: %v", err)
+					} else {
+						p.Log("parity check stopped")
+					}
+				}
+				return nil, ctx.Err()
+			case <-ticker.C:
+			}
+
+			array := s.GetArrayCache()
+			switch {
+			case array != nil && array.ParityCheckStatus != "":
+				seen = true
+				p.Set(array.ParityCheckProgress, fmt.Sprintf("parity check %s, %.1f%% complete", array.ParityCheckStatus, array.ParityCheckProgress))
+			case seen || time.Since(started) > parityStartGrace:
+				p.Log("parity check finished")
+				return nil, nil
+			}
+		}
+	}
+}
+
+// containerUpdateJob pulls the latest image of a container and recreates it.
+func containerUpdateJob(ref string, force bool) jobs.Func {
+	return func(_ context.Context, p *jobs.Progress) (any, error) {
+		controller := controllers.NewDockerController()
+		defer controller.Close() //nolint:errcheck
+
+		p.Log("updating container %s", ref)
+		result, err := controller.UpdateContainer(ref, force)
+		if err != nil {
+			return nil, err
+		}
+		p.Log("container %s updated", ref)
+		return result, nil
+	}
+}
+
+// containerUpdateAllJob updates every container with an image update.
+func containerUpdateAllJob(_ context.Context, p *jobs.Progress) (any, error) {
+	controller := controllers.NewDockerController()
+	defer controller.Close() //nolint:errcheck
+
+	p.Log("updating all containers")
+	result, err := controller.UpdateAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	p.Log("update finished")
+	return result, nil
+}
+
+// vmHibernateJob saves a VM's state to disk and stops it.
+func vmHibernateJob(name string) jobs.Func {
+	return func(_ context.Context, p *jobs.Progress) (any, error) {
+		p.Log("hibernating VM %s", name)
+		if err := controllers.NewVMController().Hibernate(name); err != nil {
+			return nil, err
+		}
+		p.Log("VM %s hibernated", name)
+		return nil, nil
+	}
+}
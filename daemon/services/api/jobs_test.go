@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestAsyncJobEndpoints(t *testing.T) {
+	server, _ := setupTestServer()
+
+	do := func(method, url string) *httptest.ResponseRecorder {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest(method, url, nil))
+		return rr
+	}
+
+	rr := do("POST", "/api/v1/vm/bad$name/hibernate?async=true")
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid VM name: status %d, want 400", rr.Code)
+	}
+
+	// The mover is not installed in tests, so the job is accepted and fails.
+	rr = do("POST", "/api/v1/mover/start")
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("mover start: status %d: %s", rr.Code, rr.Body.String())
+	}
+	var job dto.Job
+	if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+	if job.Kind != dto.JobKindMover || rr.Header().Get("Location") != "/api/v1/jobs/"+job.ID {
+		t.Errorf("job = %+v, Location = %q", job, rr.Header().Get("Location"))
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !job.Finished() {
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not finish: %+v", job)
+		}
+		time.Sleep(10 * time.Millisecond)
+		rr = do("GET", "/api/v1/jobs/"+job.ID)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("get job: status %d", rr.Code)
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if job.State != dto.JobFailed || job.Error == "" || len(job.Logs) == 0 {
+		t.Errorf("finished job = %+v", job)
+	}
+
+	rr = do("GET", "/api/v1/jobs")
+	var list []dto.Job
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != job.ID || list[0].Logs != nil {
+		t.Errorf("jobs = %+v", list)
+	}
+
+	if rr := do("GET", "/api/v1/jobs/unknown"); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown job: status %d, want 404", rr.Code)
+	}
+	if rr := do("DELETE", "/api/v1/jobs/unknown"); rr.Code != http.StatusNotFound {
+		t.Errorf("cancel unknown job: status %d, want 404", rr.Code)
+	}
+	if rr := do("DELETE", "/api/v1/jobs/"+job.ID); rr.Code != http.StatusOK {
+		t.Errorf("cancel finished job: status %d, want 200", rr.Code)
+	}
+}
+
+func TestPoolTrimEndpoint(t *testing.T) {
+	server, _ := setupTestServer()
+	pools := []dto.PoolInfo{
+		{Name: "cache", FileSystem: "vfat", Status: "Mounted", MountPoint: "/mnt/cache"},
+		{Name: "fast", FileSystem: "btrfs", Status: "Unmountable: wrong or no file system", MountPoint: "/mnt/fast"},
+	}
+	server.poolsCache.Store(&pools)
+
+	for _, tt := range []struct {
+		pool string
+		want int
+	}{
+		{"missing", http.StatusNotFound},
+		{"fast", http.StatusConflict},
+		{"cache", http.StatusAccepted}, // accepted; the job fails on the unsupported filesystem
+	} {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/pools/"+tt.pool+"/trim", nil))
+		if rr.Code != tt.want {
+			t.Errorf("trim %s: status %d, want %d: %s", tt.pool, rr.Code, tt.want, rr.Body.String())
+			continue
+		}
+		if tt.want == http.StatusAccepted {
+			var job dto.Job
+			if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+				t.Fatal(err)
+			}
+			if job.Kind != dto.JobKindPoolTrim || job.Target != tt.pool {
+				t.Errorf("job = %+v", job)
+			}
+		}
+	}
+}
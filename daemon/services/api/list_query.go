@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// totalCountHeader carries the number of items before pagination.
+const totalCountHeader = "X-Total-Count"
+
+// listQuery holds the field selection and pagination parameters of a list
+// endpoint: ?fields=name,state&limit=50&offset=100.
+type listQuery struct {
+	fields []string // JSON field names to keep; empty keeps all
+	offset int
+	limit  int // 0 returns every item after offset
+}
+
+// parseListQuery reads the fields, limit and offset query parameters.
+func parseListQuery(r *http.Request) (listQuery, error) {
+	var q listQuery
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		return q, err
+	}
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		return q, err
+	}
+	if offset < 0 || limit < 0 {
+		return q, fmt.Errorf("offset and limit must not be negative")
+	}
+	q.offset, q.limit = offset, limit
+	for f := range strings.SplitSeq(r.URL.Query().Get("fields"), ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			q.fields = append(q.fields, f)
+		}
+	}
+	return q, nil
+}
+
+// applyListQuery returns the page of items selected by q, reduced to the
+// requested fields. Without fields the page is returned as []T; with fields
+// every item becomes an object holding only those fields (unknown names are
+// ignored).
+func applyListQuery[T any](items []T, q listQuery) (any, error) {
+	start := min(q.offset, len(items))
+	end := len(items)
+	if q.limit > 0 {
+		end = min(start+q.limit, end)
+	}
+	page := items[start:end]
+	if len(q.fields) == 0 {
+		return page, nil
+	}
+
+	projected := make([]map[string]json.RawMessage, len(page))
+	for i, item := range page {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+		kept := make(map[string]json.RawMessage, len(q.fields))
+		for _, f := range q.fields {
+			if v, ok := all[f]; ok {
+				kept[f] = v
+			}
+		}
+		projected[i] = kept
+	}
+	return projected, nil
+}
+
+// respondList writes items filtered by the request's list query, with the
+// unpaginated item count in the X-Total-Count header.
+func respondList[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	body, ok := listQueryBody(w, r, items)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, body)
+}
+
+// listQueryBody applies the request's list query to items and sets the
+// X-Total-Count header. It writes a 400 response and returns false when the
+// query is invalid.
+func listQueryBody[T any](w http.ResponseWriter, r *http.Request, items []T) (any, bool) {
+	q, err := parseListQuery(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	body, err := applyListQuery(items, q)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to select fields: "+err.Error())
+		return nil, false
+	}
+	w.Header().Set(totalCountHeader, strconv.Itoa(len(items)))
+	return body, true
+}
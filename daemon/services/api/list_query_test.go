@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestApplyListQuery(t *testing.T) {
+	items := []dto.VMInfo{{Name: "a", State: "running"}, {Name: "b", State: "shut off"}, {Name: "c", State: "paused"}}
+
+	tests := []struct {
+		name  string
+		q     listQuery
+		names []string
+	}{
+		{"all", listQuery{}, []string{"a", "b", "c"}},
+		{"limit", listQuery{limit: 2}, []string{"a", "b"}},
+		{"offset", listQuery{offset: 1}, []string{"b", "c"}},
+		{"offset and limit", listQuery{offset: 1, limit: 1}, []string{"b"}},
+		{"offset past end", listQuery{offset: 5, limit: 2}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyListQuery(items, tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			page := got.([]dto.VMInfo)
+			if len(page) != len(tt.names) {
+				t.Fatalf("got %d items, want %d", len(page), len(tt.names))
+			}
+			for i, name := range tt.names {
+				if page[i].Name != name {
+					t.Errorf("item %d = %q, want %q", i, page[i].Name, name)
+				}
+			}
+		})
+	}
+
+	got, err := applyListQuery(items, listQuery{fields: []string{"name", "state", "bogus"}, limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	projected := got.([]map[string]json.RawMessage)
+	if len(projected) != 1 || len(projected[0]) != 2 || string(projected[0]["name"]) != `"a"` || string(projected[0]["state"]) != `"running"` {
+		t.Errorf("projected = %v", projected)
+	}
+}
+
+func TestListEndpointsFieldsAndPagination(t *testing.T) {
+	server, _ := setupTestServer()
+	server.dockerCache.Store(&[]dto.ContainerInfo{
+		{ID: "1", Name: "plex", State: "running", Image: "plex:latest"},
+		{ID: "2", Name: "sonarr", State: "exited", Image: "sonarr:latest"},
+		{ID: "3", Name: "radarr", State: "running", Image: "radarr:latest"},
+	})
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/docker?fields=name,state&limit=2&offset=1", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get(totalCountHeader); got != "3" {
+		t.Errorf("%s = %q, want 3", totalCountHeader, got)
+	}
+	var containers []map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &containers); err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 2 || containers[0]["name"] != "sonarr" || containers[1]["state"] != "running" || containers[0]["image"] != nil {
+		t.Errorf("containers = %v", containers)
+	}
+
+	for _, url := range []string{"/api/v1/disks?limit=-1", "/api/v1/vm?offset=x", "/api/v1/zfs/snapshots?limit=ten"} {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", url, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status %d, want 400", url, rr.Code)
+		}
+	}
+}
+
+func TestNotificationsPaginationKeepsCache(t *testing.T) {
+	server, _ := setupTestServer()
+	server.notificationsCache.Store(&dto.NotificationList{
+		Overview: dto.NotificationOverview{Unread: dto.NotificationCounts{Alert: 1, Info: 2, Total: 3}},
+		Notifications: []dto.Notification{
+			{ID: "1", Title: "a", Importance: "alert"},
+			{ID: "2", Title: "b", Importance: "info"},
+			{ID: "3", Title: "c", Importance: "info"},
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/notifications?importance=info&fields=id&limit=1", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d", rr.Code)
+	}
+	var body struct {
+		Overview      dto.NotificationOverview `json:"overview"`
+		Notifications []map[string]any         `json:"notifications"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Notifications) != 1 || body.Notifications[0]["id"] != "2" || len(body.Notifications[0]) != 1 {
+		t.Errorf("notifications = %v", body.Notifications)
+	}
+	if body.Overview.Unread.Total != 3 || rr.Header().Get(totalCountHeader) != "2" {
+		t.Errorf("overview = %+v, total = %q", body.Overview, rr.Header().Get(totalCountHeader))
+	}
+	if n := len(server.GetNotificationsCache().Notifications); n != 3 {
+		t.Errorf("cached notifications = %d after filtering, want 3", n)
+	}
+}
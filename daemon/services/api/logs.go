@@ -2,13 +2,18 @@ package api
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 )
 
@@ -226,3 +231,267 @@ func (s *Server) getLogContent(path, linesParam, startParam string) (*dto.LogFil
 		EndLine:       endLine,
 	}, nil
 }
+
+// Log search limits keep a single request from scanning or returning unbounded data
+const (
+	defaultLogSearchResults = 200
+	maxLogSearchResults     = 1000
+	maxLogSearchQueryLength = 256
+	maxLogSearchLineLength  = 1024
+	maxLogSearchFiles       = 20
+)
+
+// maxLogSearchBytes is how much of the end of each file a search scans
+var maxLogSearchBytes int64 = 32 << 20
+
+// binaryLogFiles are login accounting files that are not line-oriented text
+var binaryLogFiles = map[string]bool{
+	"btmp":    true,
+	"lastlog": true,
+	"wtmp":    true,
+}
+
+// searchLogs greps the approved log files for a query, optionally restricted
+// to lines logged after a point in time
+func (s *Server) searchLogs(query dto.LogSearchQuery) (*dto.LogSearchResult, error) {
+	now := time.Now()
+
+	if strings.TrimSpace(query.Query) == "" {
+		return nil, errors.New("query is required")
+	}
+	if len(query.Query) > maxLogSearchQueryLength {
+		return nil, fmt.Errorf("query too long: maximum %d characters", maxLogSearchQueryLength)
+	}
+
+	pattern := regexp.QuoteMeta(query.Query)
+	if query.Regex {
+		pattern = query.Query
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %v", err)
+	}
+
+	var since time.Time
+	if query.Since != "" {
+		since, err = parseLogSearchSince(query.Since, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit := query.MaxResults
+	if limit <= 0 {
+		limit = defaultLogSearchResults
+	}
+	limit = min(limit, maxLogSearchResults)
+
+	files, err := s.resolveLogSearchFiles(query.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.LogSearchResult{
+		Query:     query.Query,
+		Files:     files,
+		Matches:   []dto.LogSearchMatch{},
+		Timestamp: now,
+	}
+	if !since.IsZero() {
+		result.Since = &since
+	}
+
+	for _, path := range files {
+		matches, total, err := searchLogFile(path, re, since, now, limit-len(result.Matches))
+		if err != nil {
+			logger.Debug("API: Skipping log %s during search: %v", path, err)
+			result.SkippedFiles = append(result.SkippedFiles, path)
+			continue
+		}
+		result.Matches = append(result.Matches, matches...)
+		result.TotalMatches += total
+	}
+	result.Truncated = result.TotalMatches > len(result.Matches)
+
+	return result, nil
+}
+
+// resolveLogSearchFiles maps requested paths or names onto the log allowlist,
+// defaulting to every known text log
+func (s *Server) resolveLogSearchFiles(requested []string) ([]string, error) {
+	known := s.listLogFiles()
+
+	if len(requested) == 0 {
+		files := make([]string, 0, len(known))
+		for _, logFile := range known {
+			if !binaryLogFiles[filepath.Base(logFile.Path)] {
+				files = append(files, logFile.Path)
+			}
+		}
+		return files, nil
+	}
+
+	if len(requested) > maxLogSearchFiles {
+		return nil, fmt.Errorf("too many files: maximum %d", maxLogSearchFiles)
+	}
+
+	files := make([]string, 0, len(requested))
+	seen := make(map[string]bool, len(requested))
+	for _, name := range requested {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		path := ""
+		if strings.HasPrefix(name, "/") {
+			allowed, err := s.resolveAllowedLogPath(name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			path = allowed
+		} else {
+			if err := lib.ValidateLogFilename(name); err != nil {
+				return nil, err
+			}
+			for _, logFile := range known {
+				if logFile.Name == name || filepath.Base(logFile.Path) == name {
+					path = logFile.Path
+					break
+				}
+			}
+			if path == "" {
+				return nil, fmt.Errorf("%s: log file not found", name)
+			}
+		}
+
+		if binaryLogFiles[filepath.Base(path)] {
+			return nil, fmt.Errorf("%s: binary log files cannot be searched", name)
+		}
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no log files selected")
+	}
+	return files, nil
+}
+
+// searchLogFile scans the tail of one log file and returns up to limit
+// matches along with the total number of matching lines
+func searchLogFile(path string, re *regexp.Regexp, since, now time.Time, limit int) ([]dto.LogSearchMatch, int, error) {
+	file, err := os.Open(path) // #nosec G304 -- path is resolved against the known log allowlist
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Error("Failed to close log file %s: %v", path, err)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Only scan the most recent part of very large files; the first line
+	// after the seek is likely partial so it is dropped
+	skipFirst := false
+	if info.Size() > maxLogSearchBytes {
+		if _, err := file.Seek(-maxLogSearchBytes, io.SeekEnd); err != nil {
+			return nil, 0, err
+		}
+		skipFirst = true
+	}
+
+	var matches []dto.LogSearchMatch
+	total := 0
+	lineNum := 0
+	inWindow := since.IsZero()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineNum++
+		if skipFirst {
+			skipFirst = false
+			continue
+		}
+		line := scanner.Text()
+
+		// Lines without their own timestamp (stack traces, continuations)
+		// inherit the window state of the last timestamped line
+		if !since.IsZero() {
+			if ts, ok := parseLogLineTime(line, now); ok {
+				inWindow = !ts.Before(since)
+			}
+		}
+		if !inWindow || !re.MatchString(line) {
+			continue
+		}
+
+		total++
+		if len(matches) < limit {
+			if len(line) > maxLogSearchLineLength {
+				line = line[:maxLogSearchLineLength]
+			}
+			matches = append(matches, dto.LogSearchMatch{File: path, Line: lineNum, Text: line})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return matches, total, err
+	}
+
+	return matches, total, nil
+}
+
+// parseLogSearchSince accepts a Go duration ("90m", "24h") relative to now or
+// an RFC3339 timestamp
+func parseLogSearchSince(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, errors.New("since duration must be positive")
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid since %q: use a duration like 2h or an RFC3339 timestamp", value)
+}
+
+var logrusTimePattern = regexp.MustCompile(`time="([^"]+)"`)
+
+// parseLogLineTime extracts the timestamp of a log line. Syslog timestamps
+// carry no year, so the current year is assumed unless that would place the
+// line in the future.
+func parseLogLineTime(line string, now time.Time) (time.Time, bool) {
+	if len(line) >= len(time.Stamp) {
+		if t, err := time.ParseInLocation(time.Stamp, line[:len(time.Stamp)], now.Location()); err == nil {
+			t = t.AddDate(now.Year(), 0, 0)
+			if t.After(now.Add(24 * time.Hour)) {
+				t = t.AddDate(-1, 0, 0)
+			}
+			return t, true
+		}
+	}
+
+	if field, _, _ := strings.Cut(line, " "); field != "" {
+		field = strings.Trim(field, "[]")
+		if t, err := time.Parse(time.RFC3339Nano, field); err == nil {
+			return t, true
+		}
+	}
+
+	if m := logrusTimePattern.FindStringSubmatch(line); m != nil {
+		if t, err := time.Parse(time.RFC3339Nano, m[1]); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -120,3 +120,34 @@ func TestListLogFiles_MultipleFiles(t *testing.T) {
 		t.Errorf("Expected 2 log files, got %d", len(logs))
 	}
 }
+
+func TestHandleLogSearch(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tmpLog := filepath.Join(t.TempDir(), "test.log")
+	if err := os.WriteFile(tmpLog, []byte("alpha\nbeta\n"), 0644); err != nil {
+		t.Fatalf("Failed to create log: %v", err)
+	}
+	setTestAllowedLogPaths(t, tmpLog)
+
+	tests := []struct {
+		name string
+		url  string
+		code int
+	}{
+		{"match", "/api/v1/logs/search?q=beta&files=" + tmpLog, http.StatusOK},
+		{"missing query", "/api/v1/logs/search", http.StatusBadRequest},
+		{"bad max_results", "/api/v1/logs/search?q=beta&max_results=x", http.StatusBadRequest},
+		{"disallowed file", "/api/v1/logs/search?q=root&files=/etc/passwd", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+			if w.Code != tt.code {
+				t.Errorf("Expected %d, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 )
 
 func setTestAllowedLogPaths(t *testing.T, paths ...string) {
@@ -406,3 +409,109 @@ func TestGetLogContent_PublicWrapper(t *testing.T) {
 		t.Errorf("expected 3 lines, got %d", content.TotalLines)
 	}
 }
+
+func TestSearchLogs(t *testing.T) {
+	server, _ := setupTestServer()
+	now := time.Now()
+	stamp := func(d time.Duration) string { return now.Add(-d).Format(time.Stamp) }
+
+	tmpDir := t.TempDir()
+	syslog := filepath.Join(tmpDir, "syslog")
+	content := stamp(3*time.Hour) + " Tower kernel: ata1: hard resetting link\n" +
+		stamp(30*time.Minute) + " Tower kernel: ata2: hard resetting link\n" +
+		"    continuation of ATA2 error\n" +
+		stamp(10*time.Minute) + " Tower emhttpd: spinning down disk1\n"
+	if err := os.WriteFile(syslog, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	setTestAllowedLogPaths(t, syslog)
+
+	t.Run("substring across whole file", func(t *testing.T) {
+		result, err := server.searchLogs(dto.LogSearchQuery{Query: "RESETTING"})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result.TotalMatches != 2 || len(result.Matches) != 2 {
+			t.Fatalf("Expected 2 matches, got %+v", result)
+		}
+		if result.Matches[0].Line != 1 || result.Matches[1].Line != 2 {
+			t.Errorf("Unexpected line numbers: %+v", result.Matches)
+		}
+	})
+
+	t.Run("since window includes continuation lines", func(t *testing.T) {
+		result, err := server.searchLogs(dto.LogSearchQuery{Query: "ata", Since: "1h"})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result.TotalMatches != 2 || result.Matches[0].Line != 2 || result.Matches[1].Line != 3 {
+			t.Errorf("Expected lines 2 and 3, got %+v", result.Matches)
+		}
+		if result.Since == nil {
+			t.Error("Expected since to be reported")
+		}
+	})
+
+	t.Run("results are capped", func(t *testing.T) {
+		result, err := server.searchLogs(dto.LogSearchQuery{Query: `ata\d`, Regex: true, MaxResults: 1})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(result.Matches) != 1 || result.TotalMatches != 3 || !result.Truncated {
+			t.Errorf("Expected 1 of 3 matches and truncated, got %+v", result)
+		}
+	})
+
+	t.Run("file by name", func(t *testing.T) {
+		result, err := server.searchLogs(dto.LogSearchQuery{Query: "disk1", Files: []string{"syslog"}})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result.TotalMatches != 1 {
+			t.Errorf("Expected 1 match, got %d", result.TotalMatches)
+		}
+	})
+
+	errorCases := []struct {
+		name  string
+		query dto.LogSearchQuery
+	}{
+		{"empty query", dto.LogSearchQuery{}},
+		{"bad regex", dto.LogSearchQuery{Query: "(", Regex: true}},
+		{"bad since", dto.LogSearchQuery{Query: "ata", Since: "yesterday"}},
+		{"file outside allowlist", dto.LogSearchQuery{Query: "root", Files: []string{"/etc/shadow"}}},
+		{"traversal", dto.LogSearchQuery{Query: "root", Files: []string{"../etc/shadow"}}},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := server.searchLogs(tt.query); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestParseLogLineTime(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name string
+		line string
+		want time.Time
+		ok   bool
+	}{
+		{"syslog", "Jan  2 11:30:00 Tower kernel: hello", time.Date(2026, time.January, 2, 11, 30, 0, 0, time.Local), true},
+		{"syslog previous year", "Dec 31 23:00:00 Tower kernel: hello", time.Date(2025, time.December, 31, 23, 0, 0, 0, time.Local), true},
+		{"rfc3339", "2026-01-02T10:00:00Z something", time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC), true},
+		{"logrus", `level=info time="2026-01-02T09:00:00Z" msg="hi"`, time.Date(2026, time.January, 2, 9, 0, 0, 0, time.UTC), true},
+		{"none", "    at stack frame", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseLogLineTime(tt.line, now)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("parseLogLineTime(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
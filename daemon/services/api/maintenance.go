@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/maintenance"
+)
+
+// SetMaintenance sets the maintenance manager backing the
+// /system/maintenance endpoints.
+func (s *Server) SetMaintenance(m *maintenance.Manager) {
+	s.maintenance = m
+}
+
+// GetMaintenance returns the maintenance manager, or nil if not set.
+func (s *Server) GetMaintenance() *maintenance.Manager {
+	return s.maintenance
+}
+
+// maintenanceReady writes a 503 response and returns false when the
+// maintenance manager is not initialized.
+func (s *Server) maintenanceReady(w http.ResponseWriter) bool {
+	if s.maintenance == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Maintenance mode not initialized")
+		return false
+	}
+	return true
+}
+
+// handleMaintenanceStatus godoc
+//
+//	@Summary		Get maintenance window
+//	@Description	Get whether a maintenance window is active, when it ends and how much collectors are throttled.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.MaintenanceStatus	"Maintenance window"
+//	@Failure		503	{object}	dto.Response			"Maintenance mode not initialized"
+//	@Router			/system/maintenance [get]
+func (s *Server) handleMaintenanceStatus(w http.ResponseWriter, _ *http.Request) {
+	if !s.maintenanceReady(w) {
+		return
+	}
+	respondJSON(w, http.StatusOK, s.maintenance.Status())
+}
+
+// handleMaintenanceStart godoc
+//
+//	@Summary		Start maintenance window
+//	@Description	Enter a maintenance window for planned work. Until it ends, alert rules are not evaluated, watchdog remediation and notification forwarding are suppressed, and Home Assistant binary sensors report unavailable. Set throttle_factor to slow all collectors down by that factor. Posting during an active window extends it from now. The window survives agent restarts and reboots.
+//	@Tags			System
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.MaintenanceRequest	true	"Maintenance window"
+//	@Success		200		{object}	dto.MaintenanceStatus	"Maintenance window"
+//	@Failure		400		{object}	dto.Response			"Invalid request"
+//	@Failure		503		{object}	dto.Response			"Maintenance mode not initialized"
+//	@Router			/system/maintenance [post]
+func (s *Server) handleMaintenanceStart(w http.ResponseWriter, r *http.Request) {
+	if !s.maintenanceReady(w) {
+		return
+	}
+	var req dto.MaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	status, err := s.maintenance.Begin(req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, status)
+}
+
+// handleMaintenanceEnd godoc
+//
+//	@Summary		End maintenance window
+//	@Description	End the active maintenance window early and restore alerts, notifications and collector intervals. Alert rules that are still true fire on the next evaluation.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.MaintenanceStatus	"Maintenance window"
+//	@Failure		503	{object}	dto.Response			"Maintenance mode not initialized"
+//	@Router			/system/maintenance [delete]
+func (s *Server) handleMaintenanceEnd(w http.ResponseWriter, _ *http.Request) {
+	if !s.maintenanceReady(w) {
+		return
+	}
+	respondJSON(w, http.StatusOK, s.maintenance.End())
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/maintenance"
+)
+
+func TestMaintenanceEndpoints(t *testing.T) {
+	server, _ := setupTestServer()
+
+	do := func(method, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest(method, "/api/v1/system/maintenance", strings.NewReader(body)))
+		return rr
+	}
+
+	if rr := do("GET", ""); rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("without manager: status %d, want 503", rr.Code)
+	}
+
+	server.SetMaintenance(maintenance.NewManager(t.TempDir(), nil))
+
+	if rr := do("POST", `{"duration_minutes": 0}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("zero duration: status %d, want 400", rr.Code)
+	}
+
+	rr := do("POST", `{"duration_minutes": 45, "reason": "disk swap", "throttle_factor": 2}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("start: status %d: %s", rr.Code, rr.Body.String())
+	}
+	var status dto.MaintenanceStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.Active || status.Reason != "disk swap" || status.EndsAt == nil {
+		t.Errorf("started = %+v", status)
+	}
+
+	rr = do("DELETE", "")
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if rr.Code != http.StatusOK || status.Active {
+		t.Errorf("end: status %d, %+v", rr.Code, status)
+	}
+}
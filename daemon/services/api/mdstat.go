@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// handleArrayMDStat godoc
+//
+//	@Summary		Get md driver status
+//	@Description	Get /proc/mdstat both as raw text and parsed: array state and counts, the running parity check, sync or rebuild (action, position, percent and speed), each assigned slot's device, status, read/write/error counters and queue depth, and the resync speed limits.
+//	@Tags			Array
+//	@Produce		json
+//	@Success		200	{object}	dto.MDStat		"md driver status"
+//	@Failure		500	{object}	dto.Response	"Failed to read /proc/mdstat"
+//	@Router			/array/mdstat [get]
+func (s *Server) handleArrayMDStat(w http.ResponseWriter, r *http.Request) {
+	stat, err := controllers.GetMDStat()
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to read mdstat: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, stat)
+}
+
+// handleArrayMDTuning godoc
+//
+//	@Summary		Tune md sync speed and queue depth
+//	@Description	Change the md resync speed limits (sync_speed_min and sync_speed_max, in KB/s) and the block queue depth (nr_requests) of every assigned array device. Omitted fields are left unchanged. Changes apply immediately, also to a running parity check, and last until reboot.
+//	@Tags			Array
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.MDTuningRequest	true	"Tuning values"
+//	@Success		200		{object}	dto.MDStat			"Updated md driver status"
+//	@Failure		400		{object}	dto.Response		"Invalid tuning values"
+//	@Failure		500		{object}	dto.Response		"Failed to apply tuning"
+//	@Router			/array/mdstat [patch]
+func (s *Server) handleArrayMDTuning(w http.ResponseWriter, r *http.Request) {
+	var req dto.MDTuningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if err := controllers.SetMDTuning(req); err != nil {
+		if errors.Is(err, controllers.ErrInvalidMDTuning) {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.ErrorContext(r.Context(), "API: Failed to apply md tuning: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.handleArrayMDStat(w, r)
+}
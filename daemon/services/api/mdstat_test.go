@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestArrayMDTuningValidation(t *testing.T) {
+	server, _ := setupTestServer()
+
+	for name, body := range map[string]string{
+		"invalid json":  `{`,
+		"empty":         `{}`,
+		"min above max": `{"sync_speed_min":200000,"sync_speed_max":1000}`,
+		"nr_requests":   `{"nr_requests":1}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest("PATCH", "/api/v1/array/mdstat", strings.NewReader(body)))
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("status %d, want %d: %s", rr.Code, http.StatusBadRequest, rr.Body)
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	prommodel "github.com/prometheus/client_model/go"
 )
 
 // Prometheus metric definitions
@@ -39,6 +41,27 @@ var (
 		Name: "unraid_dram_power_watts",
 		Help: "DRAM power consumption in watts (from Intel RAPL)",
 	})
+	cpuCoreTemperature = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "unraid_cpu_core_temperature_celsius",
+			Help: "Per-core CPU temperature in Celsius (Intel coretemp)",
+		},
+		[]string{"package", "core"},
+	)
+	cpuCoreFrequency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "unraid_cpu_core_frequency_mhz",
+			Help: "Current frequency of each logical CPU in MHz",
+		},
+		[]string{"cpu"},
+	)
+	cpuPackagePowerWatts = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "unraid_cpu_package_power_watts",
+			Help: "CPU package power per socket in watts (from Intel RAPL)",
+		},
+		[]string{"package"},
+	)
 	memoryTotal = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "unraid_memory_total_bytes",
 		Help: "Total memory in bytes",
@@ -85,6 +108,14 @@ var (
 		Name: "unraid_parity_check_progress",
 		Help: "Parity check progress percentage",
 	})
+	parityCheckSpeed = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "unraid_parity_check_speed_bytes_per_second",
+		Help: "Current parity check speed in bytes per second (0 when idle or paused)",
+	})
+	parityCheckETA = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "unraid_parity_check_eta_seconds",
+		Help: "Estimated seconds until the parity check finishes (0 when idle or paused)",
+	})
 
 	// Disk metrics
 	diskTemperature = prometheus.NewGaugeVec(
@@ -322,6 +353,8 @@ func init() {
 		parityValid,
 		parityCheckRunning,
 		parityCheckProgress,
+		parityCheckSpeed,
+		parityCheckETA,
 		// Disks
 		diskTemperature,
 		diskSizeBytes,
@@ -358,6 +391,10 @@ func init() {
 		// CPU Power
 		cpuPowerWatts,
 		dramPowerWatts,
+		// Per-core CPU
+		cpuCoreTemperature,
+		cpuCoreFrequency,
+		cpuPackagePowerWatts,
 		// Fans
 		fanRPM,
 		fanPWMPercent,
@@ -420,6 +457,19 @@ func (s *Server) updateMetrics() {
 		} else {
 			dramPowerWatts.Set(0)
 		}
+
+		cpuCoreTemperature.Reset()
+		for _, core := range sysCache.CPUCoreTemps {
+			cpuCoreTemperature.WithLabelValues(strconv.Itoa(core.Package), strconv.Itoa(core.Core)).Set(core.TempC)
+		}
+		cpuCoreFrequency.Reset()
+		for _, freq := range sysCache.CPUCoreFrequencies {
+			cpuCoreFrequency.WithLabelValues(strconv.Itoa(freq.CPU)).Set(freq.MHz)
+		}
+		cpuPackagePowerWatts.Reset()
+		for pkg, watts := range sysCache.CPUPackagePowerWatts {
+			cpuPackagePowerWatts.WithLabelValues(strconv.Itoa(pkg)).Set(watts)
+		}
 	}
 
 	// Update array metrics
@@ -448,6 +498,8 @@ func (s *Server) updateMetrics() {
 			parityCheckRunning.Set(0)
 		}
 		parityCheckProgress.Set(arrCache.ParityCheckProgress)
+		parityCheckSpeed.Set(arrCache.ParityCheckSpeed)
+		parityCheckETA.Set(float64(arrCache.ParityCheckETASeconds))
 	}
 
 	// Update disk metrics
@@ -611,6 +663,14 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	}).ServeHTTP(w, r)
 }
 
+// GatherMetrics refreshes the metrics from the cache and returns a snapshot of
+// the registry, for exporters that push instead of being scraped.
+func (s *Server) GatherMetrics() ([]*prommodel.MetricFamily, error) {
+	s.updateMetrics()
+	s.updateNetworkServiceMetrics()
+	return metricsRegistry.Gather()
+}
+
 // updateNetworkServiceMetrics reads network service status from the cache
 func (s *Server) updateNetworkServiceMetrics() {
 	status := s.GetNetworkServicesCache()
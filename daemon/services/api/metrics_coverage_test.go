@@ -99,6 +99,30 @@ func TestMetricsCPUPowerRAPL_Present(t *testing.T) {
 	}
 }
 
+func TestMetricsPerCoreCPU(t *testing.T) {
+	server := newMetricsTestServer()
+	server.systemCache.Store(&dto.SystemInfo{
+		Hostname:             "tower",
+		CPUCoreTemps:         []dto.CPUCoreTemp{{Package: 1, Core: 3, TempC: 71}},
+		CPUCoreFrequencies:   []dto.CPUCoreFrequency{{CPU: 5, MHz: 4700}},
+		CPUPackagePowerWatts: []float64{35.5, 30},
+		Timestamp:            time.Now(),
+	})
+
+	body := getMetricsBody(t, server)
+
+	for _, want := range []string{
+		`unraid_cpu_core_temperature_celsius{core="3",package="1"} 71`,
+		`unraid_cpu_core_frequency_mhz{cpu="5"} 4700`,
+		`unraid_cpu_package_power_watts{package="0"} 35.5`,
+		`unraid_cpu_package_power_watts{package="1"} 30`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metric %s", want)
+		}
+	}
+}
+
 func TestMetricsCPUPowerRAPL_Nil(t *testing.T) {
 	server := newMetricsTestServer()
 	server.systemCache.Store(&dto.SystemInfo{
@@ -2,10 +2,12 @@ package api
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/gorilla/mux"
 	"golang.org/x/time/rate"
 
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 )
 
@@ -25,7 +28,8 @@ func corsMiddleware(allowedOrigin string) mux.MiddlewareFunc {
 			if allowedOrigin != "" {
 				w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-None-Match, If-Modified-Since, X-Request-ID, X-Confirm-Token")
+				w.Header().Set("Access-Control-Expose-Headers", "ETag, Last-Modified, X-Total-Count, X-Request-ID")
 			}
 
 			if r.Method == "OPTIONS" {
@@ -59,20 +63,52 @@ func (sr *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return nil, nil, fmt.Errorf("underlying ResponseWriter does not implement http.Hijacker")
 }
 
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can
+// reach it, e.g. to lift the write deadline for long downloads.
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
 		next.ServeHTTP(rec, r)
-		logger.Debug("%s %s %d %v", r.Method, r.URL.Path, rec.status, time.Since(start))
+		logger.DebugContext(r.Context(), "%s %s %d %v", r.Method, r.URL.Path, rec.status, time.Since(start))
 	})
 }
 
+// requestIDHeader carries the correlation ID of a request. A valid ID sent by
+// the client (or a reverse proxy) is kept so logs can be joined across hops.
+const requestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// requestIDMiddleware assigns every request a correlation ID, echoes it in the
+// X-Request-ID response header and attaches it to the request context, where
+// the logger's *Context functions pick it up.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = lib.NewCorrelationID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(lib.ContextWithCorrelationID(r.Context(), id)))
+	})
+}
+
+// detachedContext returns the server's context tagged with the correlation ID
+// of r, for background work that outlives the request.
+func (s *Server) detachedContext(r *http.Request) context.Context {
+	return lib.ContextWithCorrelationID(s.cancelCtx, lib.CorrelationIDFromContext(r.Context()))
+}
+
 func recoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				logger.LogPanicWithStack("HTTP handler", err)
+				logger.LogPanicWithStackContext(r.Context(), "HTTP handler", err)
 				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			}
 		}()
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"golang.org/x/time/rate"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 )
 
 func TestCorsMiddleware(t *testing.T) {
@@ -26,8 +30,8 @@ func TestCorsMiddleware(t *testing.T) {
 		if got := rr.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST, PUT, PATCH, DELETE, OPTIONS" {
 			t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		}
-		if got := rr.Header().Get("Access-Control-Allow-Headers"); got != "Content-Type, Authorization" {
-			t.Errorf("Access-Control-Allow-Headers = %q, want %q", got, "Content-Type, Authorization")
+		if got := rr.Header().Get("Access-Control-Allow-Headers"); got != "Content-Type, Authorization, If-None-Match, If-Modified-Since, X-Request-ID, X-Confirm-Token" {
+			t.Errorf("Access-Control-Allow-Headers = %q, want %q", got, "Content-Type, Authorization, If-None-Match, If-Modified-Since, X-Request-ID, X-Confirm-Token")
 		}
 	})
 
@@ -127,6 +131,43 @@ func TestLoggingMiddleware(t *testing.T) {
 	})
 }
 
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := requestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = lib.CorrelationIDFromContext(r.Context())
+		respondWithError(w, http.StatusBadRequest, "bad input")
+	}))
+
+	t.Run("keeps a valid client ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set(requestIDHeader, "proxy-1234")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if seen != "proxy-1234" || rr.Header().Get(requestIDHeader) != "proxy-1234" {
+			t.Errorf("context ID = %q, header = %q", seen, rr.Header().Get(requestIDHeader))
+		}
+		var resp dto.Response
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.CorrelationID != "proxy-1234" {
+			t.Errorf("error response correlation_id = %q", resp.CorrelationID)
+		}
+	})
+
+	t.Run("replaces an invalid client ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set(requestIDHeader, "bad id\nwith newline")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if seen == "" || seen == "bad id\nwith newline" || rr.Header().Get(requestIDHeader) != seen {
+			t.Errorf("context ID = %q, header = %q", seen, rr.Header().Get(requestIDHeader))
+		}
+	})
+}
+
 func TestRecoveryMiddleware(t *testing.T) {
 	t.Run("recovers from panic", func(t *testing.T) {
 		handler := recoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/fleet"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/jobs"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/mock"
+)
+
+// mockPassthroughPrefixes are state-changing routes that still run in mock
+// mode: they only change the agent itself, or (batch) simulate their
+// actions in the handler.
+var mockPassthroughPrefixes = []string{
+	"/api/v1/auth/",
+	"/api/v1/collectors/",
+	"/api/v1/jobs/",
+	"/api/v1/batch",
+}
+
+// mockJob describes a route that answers with a background job.
+type mockJob struct {
+	kind      string
+	targetVar string // route variable naming the target, if any
+	always    bool   // a job even without ?async=true
+}
+
+// mockJobRoutes are the routes that submit background jobs.
+var mockJobRoutes = map[string]mockJob{
+	"/api/v1/vm/{name}/hibernate":      {kind: dto.JobKindVMHibernate, targetVar: "name"},
+	"/api/v1/array/parity-check/start": {kind: dto.JobKindParityCheck},
+	"/api/v1/docker/{id}/update":       {kind: dto.JobKindContainerUpdate, targetVar: "id"},
+	"/api/v1/docker/update-all":        {kind: dto.JobKindContainerUpdateAll},
+	"/api/v1/mover/start":              {kind: dto.JobKindMover, always: true},
+	"/api/v1/pools/{name}/trim":        {kind: dto.JobKindPoolTrim, targetVar: "name", always: true},
+}
+
+// mockMiddleware simulates state-changing requests in mock mode. Routes
+// that need a confirmation token still ask for one and routes that submit
+// a job still return one, which then completes after a simulated delay.
+// Every other simulated request answers with a plain dto.Response after a
+// realistic delay, without reaching a controller.
+func (s *Server) mockMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.ctx.Mock || !isStateChangingMethod(r.Method) || !strings.HasPrefix(r.URL.Path, "/api/v1/") || isMockPassthrough(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if tmpl, err := current.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+
+		if action, ok := s.mockDestructiveAction(r, route); ok && !s.confirmDestructive(w, r, action) {
+			return
+		}
+
+		if job, ok := mockJobRoutes[route]; ok && (job.always || wantsAsync(r)) {
+			target := mux.Vars(r)[job.targetVar]
+			logger.InfoContext(r.Context(), "Mock mode: simulated %s job for %s %s", job.kind, r.Method, r.URL.Path)
+			respondJobAccepted(w, s.jobManager.Submit(s.detachedContext(r), job.kind, target,
+				func(ctx context.Context, _ *jobs.Progress) (any, error) {
+					return nil, mock.SimulateAction(ctx)
+				}))
+			return
+		}
+
+		if err := mock.SimulateAction(r.Context()); err != nil {
+			return // client went away
+		}
+		logger.InfoContext(r.Context(), "Mock mode: simulated %s %s", r.Method, r.URL.Path)
+		respondJSON(w, http.StatusOK, dto.Response{
+			Success:   true,
+			Message:   fmt.Sprintf("Mock mode: %s %s simulated, nothing was changed", r.Method, r.URL.Path),
+			Timestamp: time.Now(),
+		})
+	})
+}
+
+// mockDestructiveAction returns the confirmation the real handler of route
+// requires, if any.
+func (s *Server) mockDestructiveAction(r *http.Request, route string) (destructiveAction, bool) {
+	switch route {
+	case "/api/v1/system/reboot":
+		return s.rebootAction(), true
+	case "/api/v1/system/shutdown":
+		return s.shutdownAction("system_shutdown"), true
+	case "/api/v1/system/shutdown/orchestrated":
+		return s.shutdownAction("system_shutdown_orchestrated"), true
+	case "/api/v1/array/stop":
+		return s.arrayStopAction(), true
+	case "/api/v1/array/unlock":
+		return arrayUnlockAction(), true
+	case "/api/v1/unassigned/devices/{device}/format":
+		var req dto.UnassignedFormatRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		return formatAction(dto.UnassignedDevice{Device: mux.Vars(r)["device"]}, req.Filesystem), true
+	case "/api/v1/fleet/peers/{name}/relay":
+		var req dto.FleetRelayRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if fleet.IsDestructiveRelayCommand(req.Command) {
+			return fleetRelayAction(mux.Vars(r)["name"], req.Command), true
+		}
+	case "/api/v1/ipmi/chassis":
+		var req dto.IPMIChassisActionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		return s.ipmiChassisPowerAction(req.Action)
+	}
+	return destructiveAction{}, false
+}
+
+func isMockPassthrough(path string) bool {
+	for _, prefix := range mockPassthroughPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestMockMiddleware(t *testing.T) {
+	server, ctx := setupTestServer()
+	ctx.Mock = true
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/docker/plex/restart", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200: %s", rr.Code, rr.Body)
+	}
+	var resp dto.Response
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil || !resp.Success {
+		t.Fatalf("unexpected response %s (%v)", rr.Body, err)
+	}
+
+	// Read requests and agent-only routes are not simulated
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/health", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("GET /health status %d", rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/collectors/nonexistent/enable", nil))
+	if rr.Code == http.StatusOK {
+		t.Error("collector control should reach the handler in mock mode")
+	}
+}
+
+func TestMockMiddlewareKeepsRouteShapes(t *testing.T) {
+	server, ctx := setupTestServer()
+	ctx.Mock = true
+	ctx.ConfirmDestructive = true
+
+	// Destructive routes still ask for a confirmation token
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/system/reboot", nil))
+	if rr.Code != http.StatusPreconditionRequired {
+		t.Errorf("reboot status %d, want 428: %s", rr.Code, rr.Body)
+	}
+
+	// Job routes answer with a job
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/mover/start", nil))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("mover status %d, want 202: %s", rr.Code, rr.Body)
+	}
+	var job dto.Job
+	if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil || job.Kind != dto.JobKindMover {
+		t.Errorf("unexpected job %s (%v)", rr.Body, err)
+	}
+}
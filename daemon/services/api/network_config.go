@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// respondNetworkConfigError maps network configuration errors to HTTP statuses.
+func respondNetworkConfigError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, controllers.ErrInvalidNetworkConfig):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, controllers.ErrNetworkChangePending), errors.Is(err, controllers.ErrNoNetworkChangePending):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// handleNetworkSettings godoc
+//
+//	@Summary		Get network interface configuration
+//	@Description	Get the configuration of each network port from /boot/config/network.cfg: bonding, bridging, VLAN sub-interfaces, DHCP or static IPv4 addressing, MTU and the static DNS servers, plus the state of the last change applied through the API.
+//	@Tags			Network
+//	@Produce		json
+//	@Success		200	{object}	dto.NetworkSettings	"Network configuration"
+//	@Failure		500	{object}	dto.Response		"Failed to read network.cfg"
+//	@Router			/network/config [get]
+func (s *Server) handleNetworkSettings(w http.ResponseWriter, _ *http.Request) {
+	settings, err := controllers.GetNetworkSettings()
+	if err != nil {
+		respondNetworkConfigError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, settings)
+}
+
+// handleUpdateNetworkSettings godoc
+//
+//	@Summary		Change network interface configuration
+//	@Description	Change bonds, bridges, VLANs, static addresses, MTU or DNS servers in network.cfg and restart networking. Only the listed ports and fields change. Networking restarts two seconds after the response, which briefly drops connections. The change is reverted unless POST /network/config/confirm is called within rollback_seconds (default 120), so a change that makes the server unreachable undoes itself; it is also reverted if the restart fails.
+//	@Tags			Network
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.NetworkSettingsUpdate	true	"Changes to apply"
+//	@Success		202		{object}	dto.NetworkChange			"Change applied, waiting for confirmation"
+//	@Failure		400		{object}	dto.Response				"Invalid configuration"
+//	@Failure		409		{object}	dto.Response				"Previous change not confirmed yet"
+//	@Failure		500		{object}	dto.Response				"Failed to write network.cfg"
+//	@Router			/network/config [put]
+func (s *Server) handleUpdateNetworkSettings(w http.ResponseWriter, r *http.Request) {
+	var update dto.NetworkSettingsUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	change, err := controllers.UpdateNetworkSettings(update)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to update network configuration: %v", err)
+		respondNetworkConfigError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusAccepted, change)
+}
+
+// handleConfirmNetworkSettings godoc
+//
+//	@Summary		Confirm a network change
+//	@Description	Keep the network change applied with PUT /network/config. Call it over the new configuration once the server is reachable again; without it the change is reverted when the rollback timer expires.
+//	@Tags			Network
+//	@Produce		json
+//	@Success		200	{object}	dto.NetworkChange	"Change confirmed"
+//	@Failure		409	{object}	dto.Response		"No change waiting for confirmation"
+//	@Router			/network/config/confirm [post]
+func (s *Server) handleConfirmNetworkSettings(w http.ResponseWriter, _ *http.Request) {
+	change, err := controllers.ConfirmNetworkChange()
+	if err != nil {
+		respondNetworkConfigError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, change)
+}
+
+// handleRollbackNetworkSettings godoc
+//
+//	@Summary		Roll back a network change
+//	@Description	Restore the network configuration from before the pending change now and restart networking, instead of waiting for the rollback timer.
+//	@Tags			Network
+//	@Produce		json
+//	@Success		200	{object}	dto.NetworkChange	"Change rolled back"
+//	@Failure		409	{object}	dto.Response		"No change waiting for confirmation"
+//	@Failure		500	{object}	dto.Response		"Failed to restore network.cfg"
+//	@Router			/network/config/rollback [post]
+func (s *Server) handleRollbackNetworkSettings(w http.ResponseWriter, _ *http.Request) {
+	change, err := controllers.RollbackNetworkChange()
+	if err != nil {
+		respondNetworkConfigError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, change)
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNetworkConfigEndpointsValidation(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name, method, path, body string
+		want                     int
+	}{
+		{"update invalid json", "PUT", "/api/v1/network/config", `{`, http.StatusBadRequest},
+		{"update without changes", "PUT", "/api/v1/network/config", `{}`, http.StatusBadRequest},
+		{"rollback window too short", "PUT", "/api/v1/network/config", `{"dns_servers":[],"rollback_seconds":5}`, http.StatusBadRequest},
+		{"confirm without change", "POST", "/api/v1/network/config/confirm", "", http.StatusConflict},
+		{"rollback without change", "POST", "/api/v1/network/config/rollback", "", http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rr.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", rr.Code, tt.want, rr.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// handleRecycleBin godoc
+//
+//	@Summary		Get recycle bin statistics
+//	@Description	Returns the size, file count and oldest/newest file of each user share's recycle bin (.Recycle.Bin), as kept by the Recycle Bin plugin for files deleted over SMB. installed is false and shares is empty when the plugin is not installed.
+//	@Tags			Shares
+//	@Produce		json
+//	@Success		200	{object}	dto.RecycleBinStatus	"Recycle bin statistics"
+//	@Router			/recyclebin [get]
+func (s *Server) handleRecycleBin(w http.ResponseWriter, _ *http.Request) {
+	if cached := s.GetRecycleBinCache(); cached != nil {
+		respondJSON(w, http.StatusOK, cached)
+		return
+	}
+	respondJSON(w, http.StatusOK, &dto.RecycleBinStatus{
+		Shares:    []dto.RecycleBinShare{},
+		Timestamp: time.Now(),
+	})
+}
+
+// handleRecycleBinEmpty godoc
+//
+//	@Summary		Empty a share's recycle bin
+//	@Description	Permanently delete every file in a user share's recycle bin. The .Recycle.Bin directory itself is kept. Requires the Recycle Bin plugin.
+//	@Tags			Shares
+//	@Produce		json
+//	@Param			share	path		string						true	"Share name"
+//	@Success		200		{object}	dto.RecycleBinEmptyResult	"Recycle bin emptied"
+//	@Failure		400		{object}	dto.Response				"Invalid share name"
+//	@Failure		404		{object}	dto.Response				"Share has no recycle bin"
+//	@Failure		500		{object}	dto.Response				"Failed to empty the recycle bin"
+//	@Router			/recyclebin/{share}/empty [post]
+func (s *Server) handleRecycleBinEmpty(w http.ResponseWriter, r *http.Request) {
+	share := mux.Vars(r)["share"]
+	if err := lib.ValidateShareName(share); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := controllers.EmptyRecycleBin(share)
+	if err != nil {
+		if errors.Is(err, controllers.ErrRecycleBinNotFound) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		logger.ErrorContext(r.Context(), "API: Failed to empty recycle bin of share %q: %v", share, err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestHandleRecycleBin(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/recyclebin", nil))
+	var status dto.RecycleBinStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("empty cache: status %d, error %v", rr.Code, err)
+	}
+	if status.Installed || status.Shares == nil {
+		t.Errorf("empty cache: status = %+v", status)
+	}
+
+	server.recycleBinCache.Store(&dto.RecycleBinStatus{
+		Installed:  true,
+		TotalBytes: 4096,
+		TotalFiles: 2,
+		Shares:     []dto.RecycleBinShare{{Share: "Media", SizeBytes: 4096, Files: 2}},
+	})
+	rr = httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/recyclebin", nil))
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.Installed || len(status.Shares) != 1 || status.Shares[0].Share != "Media" {
+		t.Errorf("cached: status = %+v", status)
+	}
+}
+
+func TestHandleRecycleBinEmptyInvalidShare(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/recyclebin/bad..name/empty", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("status %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// handleInstallRegistrationKey godoc
+//
+//	@Summary		Install a registration key file
+//	@Description	Install or replace the Unraid registration key on the flash drive, e.g. after migrating to a new flash drive or upgrading the license. Either upload the key file as base64 key_data with its file_name, or give the https url from the purchase or upgrade email; URLs are only fetched from lime-technology.com and unraid.net hosts. confirm must be true. Existing key files are kept with a .bak suffix and the registration is re-read right away. If the array is started, Unraid applies the new key the next time it is started.
+//	@Tags			System
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.RegistrationKeyRequest	true	"Key file upload or URL"
+//	@Success		200		{object}	dto.RegistrationKeyResult	"Key file installed"
+//	@Failure		400		{object}	dto.Response				"Invalid request, key file or URL"
+//	@Failure		502		{object}	dto.Response				"Download or install failed"
+//	@Router			/registration/key [post]
+func (s *Server) handleInstallRegistrationKey(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegistrationKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	result, err := controllers.InstallRegistrationKey(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, controllers.ErrInvalidRegistrationKey) {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.ErrorContext(r.Context(), "API: Failed to install registration key: %v", err)
+		respondWithError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
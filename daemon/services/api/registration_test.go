@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleInstallRegistrationKey_RejectsInvalid(t *testing.T) {
+	server, _ := setupTestServer()
+
+	for _, body := range []string{
+		`not json`,
+		`{"key_data":"a2V5","file_name":"Pro.key"}`,
+		`{"url":"https://example.com/Pro.key","confirm":true}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/registration/key", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("body %s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
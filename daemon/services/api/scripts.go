@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/scripts"
+)
+
+// SetScripts sets the script store backing the /scripts endpoints.
+func (s *Server) SetScripts(store *scripts.Store) {
+	s.scriptStore = store
+}
+
+// scriptsReady writes a 503 response and returns false when the script store
+// is not initialized.
+func (s *Server) scriptsReady(w http.ResponseWriter) bool {
+	if s.scriptStore == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Script registry not initialized")
+		return false
+	}
+	return true
+}
+
+// handleListScripts godoc
+//
+//	@Summary		List registered scripts
+//	@Description	List scripts registered with the agent, including their body, default arguments and environment. Unlike /user-scripts this does not need the User Scripts plugin.
+//	@Tags			Scripts
+//	@Produce		json
+//	@Success		200	{array}		dto.Script		"Registered scripts"
+//	@Failure		500	{object}	dto.Response	"Failed to list scripts"
+//	@Failure		503	{object}	dto.Response	"Script registry not initialized"
+//	@Router			/scripts [get]
+func (s *Server) handleListScripts(w http.ResponseWriter, _ *http.Request) {
+	if !s.scriptsReady(w) {
+		return
+	}
+	list, err := s.scriptStore.List()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, list)
+}
+
+// handleCreateScript godoc
+//
+//	@Summary		Register script
+//	@Description	Register a named bash script with default arguments and environment. Scripts are stored under /boot/config/plugins/unraid-management-agent/scripts and survive reboots.
+//	@Tags			Scripts
+//	@Accept			json
+//	@Produce		json
+//	@Param			script	body		dto.Script		true	"Script"
+//	@Success		201		{object}	dto.Response	"Created"
+//	@Failure		400		{object}	dto.Response	"Invalid request"
+//	@Failure		409		{object}	dto.Response	"Script already exists"
+//	@Failure		503		{object}	dto.Response	"Script registry not initialized"
+//	@Router			/scripts [post]
+func (s *Server) handleCreateScript(w http.ResponseWriter, r *http.Request) {
+	if !s.scriptsReady(w) {
+		return
+	}
+	var script dto.Script
+	if err := json.NewDecoder(r.Body).Decode(&script); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if err := s.scriptStore.Create(script); err != nil {
+		respondScriptError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, dto.Response{Success: true, Message: "Script registered", Timestamp: time.Now()})
+}
+
+// handleGetScript godoc
+//
+//	@Summary		Get registered script
+//	@Description	Get a registered script by name
+//	@Tags			Scripts
+//	@Produce		json
+//	@Param			name	path		string			true	"Script name"
+//	@Success		200		{object}	dto.Script		"Script"
+//	@Failure		404		{object}	dto.Response	"Not found"
+//	@Failure		503		{object}	dto.Response	"Script registry not initialized"
+//	@Router			/scripts/{name} [get]
+func (s *Server) handleGetScript(w http.ResponseWriter, r *http.Request) {
+	if !s.scriptsReady(w) {
+		return
+	}
+	script, err := s.scriptStore.Get(mux.Vars(r)["name"])
+	if err != nil {
+		respondScriptError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, script)
+}
+
+// handleUpdateScript godoc
+//
+//	@Summary		Update registered script
+//	@Description	Replace a registered script's body, arguments and environment
+//	@Tags			Scripts
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string			true	"Script name"
+//	@Param			script	body		dto.Script		true	"Script"
+//	@Success		200		{object}	dto.Response	"Updated"
+//	@Failure		400		{object}	dto.Response	"Invalid request"
+//	@Failure		404		{object}	dto.Response	"Not found"
+//	@Failure		503		{object}	dto.Response	"Script registry not initialized"
+//	@Router			/scripts/{name} [put]
+func (s *Server) handleUpdateScript(w http.ResponseWriter, r *http.Request) {
+	if !s.scriptsReady(w) {
+		return
+	}
+	var script dto.Script
+	if err := json.NewDecoder(r.Body).Decode(&script); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	script.Name = mux.Vars(r)["name"]
+	if err := s.scriptStore.Update(script); err != nil {
+		respondScriptError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Script updated", Timestamp: time.Now()})
+}
+
+// handleDeleteScript godoc
+//
+//	@Summary		Delete registered script
+//	@Description	Delete a registered script
+//	@Tags			Scripts
+//	@Produce		json
+//	@Param			name	path		string			true	"Script name"
+//	@Success		200		{object}	dto.Response	"Deleted"
+//	@Failure		404		{object}	dto.Response	"Not found"
+//	@Failure		503		{object}	dto.Response	"Script registry not initialized"
+//	@Router			/scripts/{name} [delete]
+func (s *Server) handleDeleteScript(w http.ResponseWriter, r *http.Request) {
+	if !s.scriptsReady(w) {
+		return
+	}
+	if err := s.scriptStore.Delete(mux.Vars(r)["name"]); err != nil {
+		respondScriptError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Script deleted", Timestamp: time.Now()})
+}
+
+// handleExecuteScript godoc
+//
+//	@Summary		Execute registered script
+//	@Description	Run a registered script with bash. Arguments are passed without a shell; args in the body replace the script's default arguments and env is merged over its environment. With wait=true the call blocks until the script finishes or its timeout elapses and returns the output; otherwise the script runs in the background.
+//	@Tags			Scripts
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string							true	"Script name"
+//	@Param			options	body		dto.ScriptExecuteRequest		false	"Execution options"
+//	@Success		200		{object}	dto.UserScriptExecuteResponse	"Execution result"
+//	@Failure		400		{object}	dto.Response					"Invalid request"
+//	@Failure		404		{object}	dto.Response					"Not found"
+//	@Failure		500		{object}	dto.UserScriptExecuteResponse	"Failed to execute"
+//	@Failure		503		{object}	dto.Response					"Script registry not initialized"
+//	@Router			/scripts/{name}/execute [post]
+func (s *Server) handleExecuteScript(w http.ResponseWriter, r *http.Request) {
+	if !s.scriptsReady(w) {
+		return
+	}
+	name := mux.Vars(r)["name"]
+
+	var req dto.ScriptExecuteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+
+	response, err := s.scriptStore.Execute(name, req)
+	if err != nil {
+		if response == nil {
+			respondScriptError(w, err)
+			return
+		}
+		logger.ErrorContext(r.Context(), "API: Failed to execute script %s: %v", name, err)
+		respondJSON(w, http.StatusInternalServerError, response)
+		return
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// respondScriptError maps script registry errors to HTTP statuses.
+func respondScriptError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, scripts.ErrNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, scripts.ErrExists):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	}
+}
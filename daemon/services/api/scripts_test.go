@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/scripts"
+)
+
+func TestScriptEndpoints(t *testing.T) {
+	server, _ := setupTestServer()
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest(method, "/api/v1"+path, strings.NewReader(body)))
+		return rr
+	}
+
+	if rr := do("GET", "/scripts", ""); rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("without store: status %d, want 503", rr.Code)
+	}
+
+	server.SetScripts(scripts.NewStore(t.TempDir()))
+
+	body := `{"name": "greet", "content": "echo \"$GREETING $1\"", "args": ["world"], "env": {"GREETING": "hello"}}`
+	if rr := do("POST", "/scripts", body); rr.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := do("POST", "/scripts", body); rr.Code != http.StatusConflict {
+		t.Errorf("duplicate create: status %d, want 409", rr.Code)
+	}
+	if rr := do("POST", "/scripts", `{"name": "../x", "content": "true"}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid name: status %d, want 400", rr.Code)
+	}
+
+	rr := do("GET", "/scripts", "")
+	var list []dto.Script
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Name != "greet" {
+		t.Errorf("list = %+v", list)
+	}
+
+	rr = do("POST", "/scripts/greet/execute", `{"args": ["there"], "wait": true}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("execute: status %d: %s", rr.Code, rr.Body.String())
+	}
+	var result dto.UserScriptExecuteResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(result.Output) != "hello there" {
+		t.Errorf("execute output = %q", result.Output)
+	}
+
+	if rr := do("PUT", "/scripts/missing", `{"content": "true"}`); rr.Code != http.StatusNotFound {
+		t.Errorf("update missing: status %d, want 404", rr.Code)
+	}
+	if rr := do("DELETE", "/scripts/greet", ""); rr.Code != http.StatusOK {
+		t.Errorf("delete: status %d", rr.Code)
+	}
+	if rr := do("GET", "/scripts/greet", ""); rr.Code != http.StatusNotFound {
+		t.Errorf("get after delete: status %d, want 404", rr.Code)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
@@ -18,10 +19,23 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/acme"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/agent"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/alerting"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/auth"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/bootseq"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/configreload"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/filesystem"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/fleet"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/forwarding"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/hooks"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/jobs"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/maintenance"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/scripts"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/transfer"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/watchdog"
 )
 
@@ -34,6 +48,12 @@ type CollectorManagerInterface interface {
 	GetAllStatus() dto.CollectorsStatusResponse
 }
 
+// readinessReporter is implemented by collector managers that track the
+// startup phase, in which every collector runs once.
+type readinessReporter interface {
+	Readiness() dto.Readiness
+}
+
 // MQTTClientInterface defines the methods required from MQTT client for API integration
 type MQTTClientInterface interface {
 	IsConnected() bool
@@ -58,10 +78,33 @@ type Server struct {
 	alertStore       *alerting.Store
 	watchdogRunner   *watchdog.Runner
 	watchdogStore    *watchdog.Store
+	forwarder        *forwarding.Forwarder
+	forwardingStore  *forwarding.Store
 	fanController    *controllers.FanController
 	cpuController    *controllers.CPUController
 	tuningController *controllers.TuningController
 	agentSvc         *agent.Service
+	auditLog         *audit.Log
+	configReloader   *configreload.Reloader
+	fleetClient      *fleet.Client
+	shareDistrib     *collectors.ShareDistributionCollector
+	analyzer         *filesystem.Analyzer
+	transferRunner   *transfer.Runner
+	jobManager       *jobs.Manager
+	maintenance      *maintenance.Manager
+	bootSequence     *bootseq.Runner
+	certManager      *acme.Manager
+	authManager      *auth.Manager
+	scriptStore      *scripts.Store
+	hookRunner       *hooks.Runner
+	hookStore        *hooks.Store
+	requestStats     *requestStats
+	confirmations    *confirmationStore
+
+	// healthMu serializes the /health probes; healthCache holds the last
+	// result for healthCacheTTL.
+	healthMu    sync.Mutex
+	healthCache *dto.HealthStatus
 
 	// Embedded cache store for lock-free atomic access to collector data
 	*CacheStore
@@ -84,8 +127,15 @@ func NewServerWithCollectorManager(ctx *domain.Context, cm CollectorManagerInter
 		cancelFunc:       cancelFunc,
 		ready:            make(chan struct{}),
 		collectorManager: cm,
+		shareDistrib:     collectors.NewShareDistributionCollector(),
+		analyzer:         filesystem.NewAnalyzer(),
+		jobManager:       jobs.NewManager(ctx.Hub),
+		requestStats:     newRequestStats(),
+		confirmations:    newConfirmationStore(),
 		CacheStore:       &CacheStore{registry: ctx.Platform},
 	}
+	s.wsHub.SetSlowClientPolicy(ctx.WSSlowClientPolicy, ctx.WSQueueSize)
+	s.wsHub.SetReplaySource(s.recentEvents)
 
 	s.setupRoutes()
 	return s
@@ -93,6 +143,9 @@ func NewServerWithCollectorManager(ctx *domain.Context, cm CollectorManagerInter
 
 func (s *Server) setupRoutes() {
 	// Apply middleware
+	s.router.Use(requestIDMiddleware)
+	s.router.Use(s.statsMiddleware)
+	s.router.Use(compressMiddleware)
 	s.router.Use(recoveryMiddleware)
 	s.router.Use(securityHeadersMiddleware)
 	s.router.Use(corsMiddleware(s.ctx.CORSOrigin))
@@ -100,10 +153,19 @@ func (s *Server) setupRoutes() {
 	s.router.Use(bodySizeLimitMiddleware)
 	s.router.Use(rateLimitMiddleware(newPerClientRateLimiter(rate.Limit(rateLimitPerSecond), rateLimitBurst)))
 	s.router.Use(loggingMiddleware)
+	s.router.Use(s.authMiddleware)
+	s.router.Use(s.auditMiddleware)
+	s.router.Use(s.mockMiddleware)
+	s.router.Use(s.conditionalGetMiddleware)
 
 	// Prometheus metrics endpoint (at root level, no /api/v1 prefix)
 	s.router.HandleFunc("/metrics", s.handleMetrics).Methods("GET")
 
+	// Go runtime profiles, only when enabled with --pprof
+	if s.ctx.Pprof {
+		s.registerPprof()
+	}
+
 	// Swagger UI endpoint (accessible at /swagger/index.html)
 	s.router.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"),
@@ -116,22 +178,40 @@ func (s *Server) setupRoutes() {
 
 	// Health check
 	api.HandleFunc("/health", s.handleHealth).Methods("GET")
+	api.HandleFunc("/health/live", s.handleHealthLive).Methods("GET")
+	api.HandleFunc("/health/ready", s.handleHealthReady).Methods("GET")
+
+	// Password login sessions (JWT access and refresh tokens)
+	api.HandleFunc("/auth/login", s.handleAuthLogin).Methods("POST")
+	api.HandleFunc("/auth/refresh", s.handleAuthRefresh).Methods("POST")
+	api.HandleFunc("/auth/logout", s.handleAuthLogout).Methods("POST")
+	api.HandleFunc("/auth/session", s.handleAuthSession).Methods("GET")
 	api.HandleFunc("/health/report", s.handleHealthReport).Methods("GET")
+	api.HandleFunc("/summary", s.handleSummary).Methods("GET")
 	api.HandleFunc("/diagnostics/self-test", s.handleSelfTest).Methods("GET")
 	api.HandleFunc("/diagnostics/bundle", s.handleDiagnosticsBundle).Methods("GET")
+	api.HandleFunc("/diagnostics/ping", s.handleDiagnosticsPing).Methods("GET")
+	api.HandleFunc("/diagnostics/dns", s.handleDiagnosticsDNS).Methods("GET")
+	api.HandleFunc("/diagnostics/http", s.handleDiagnosticsHTTP).Methods("GET")
 
 	// Monitoring endpoints
 	api.HandleFunc("/system", s.handleSystem).Methods("GET")
 	api.HandleFunc("/array", s.handleArray).Methods("GET")
 	api.HandleFunc("/disks", s.handleDisks).Methods("GET")
+	api.HandleFunc("/disks/spin-history", s.handleDiskSpinHistory).Methods("GET")
 	api.HandleFunc("/disks/{id}", s.handleDisk).Methods("GET")
 	api.HandleFunc("/shares", s.handleShares).Methods("GET")
 	api.HandleFunc("/docker", s.handleDockerList).Methods("GET")
 	api.HandleFunc("/docker/networks", s.handleDockerNetworks).Methods("GET")
+	api.HandleFunc("/docker/dependencies", s.handleDockerDependencies).Methods("GET")
 	api.HandleFunc("/docker/port-conflicts", s.handleDockerPortConflicts).Methods("GET")
 	api.HandleFunc("/docker/updates", s.handleDockerCheckUpdates).Methods("GET")
 	api.HandleFunc("/docker/updates/refresh", s.handleDockerUpdatesRefresh).Methods("POST")
 	api.HandleFunc("/docker/update-all", s.handleDockerUpdateAll).Methods("POST")
+	api.HandleFunc("/docker/autostart", s.handleDockerAutostartList).Methods("GET")
+	api.HandleFunc("/docker/groups", s.handleDockerGroups).Methods("GET")
+	api.HandleFunc("/docker/stats", s.handleDockerStats).Methods("GET")
+	api.HandleFunc("/docker/stats/summary", s.handleDockerStatsSummary).Methods("GET")
 	api.HandleFunc("/docker/{id}", s.handleDockerInfo).Methods("GET")
 	api.HandleFunc("/docker/{id}/check-update", s.handleDockerCheckUpdate).Methods("GET")
 	api.HandleFunc("/docker/{id}/size", s.handleDockerSize).Methods("GET")
@@ -146,11 +226,32 @@ func (s *Server) setupRoutes() {
 	// System control endpoints
 	api.HandleFunc("/system/reboot", s.handleSystemReboot).Methods("POST")
 	api.HandleFunc("/system/shutdown", s.handleSystemShutdown).Methods("POST")
+	api.HandleFunc("/system/shutdown/orchestrated", s.handleSystemOrchestratedShutdown).Methods("POST")
 	api.HandleFunc("/system/flash", s.handleFlashHealth).Methods("GET") // Issue #51
+	api.HandleFunc("/system/processes", s.handleTopProcesses).Methods("GET")
+	api.HandleFunc("/system/thermal", s.handleThermalSummary).Methods("GET")
+	api.HandleFunc("/system/cpu-topology", s.handleCPUTopology).Methods("GET")
+	api.HandleFunc("/system/swap", s.handleSwapStatus).Methods("GET")
+	api.HandleFunc("/system/swap/file", s.handleSwapFileEnable).Methods("POST")
+	api.HandleFunc("/system/swap/file", s.handleSwapFileDisable).Methods("DELETE")
+	api.HandleFunc("/system/maintenance", s.handleMaintenanceStatus).Methods("GET")
+	api.HandleFunc("/system/maintenance", s.handleMaintenanceStart).Methods("POST")
+	api.HandleFunc("/system/maintenance", s.handleMaintenanceEnd).Methods("DELETE")
 	api.HandleFunc("/network", s.handleNetwork).Methods("GET")
 	api.HandleFunc("/network/access-urls", s.handleNetworkAccessURLs).Methods("GET")
+	api.HandleFunc("/network/dns", s.handleDNSHealth).Methods("GET")
+	api.HandleFunc("/network/wan", s.handleWANStatus).Methods("GET")
+	api.HandleFunc("/network/mounts", s.handleRemoteMounts).Methods("GET")
+	api.HandleFunc("/network/wol", s.handleWakeOnLAN).Methods("POST")
+	api.HandleFunc("/network/wol/devices", s.handleWOLDevices).Methods("GET")
+	api.HandleFunc("/network/speedtest", s.handleSpeedtest).Methods("GET")
+	api.HandleFunc("/power", s.handlePower).Methods("GET")
 
 	// ZFS endpoints
+	api.HandleFunc("/pools", s.handlePools).Methods("GET")
+	api.HandleFunc("/pools/{name}", s.handlePool).Methods("GET")
+	api.HandleFunc("/pools/{name}/trim", s.handlePoolTrim).Methods("POST")
+	api.HandleFunc("/btrfs", s.handleBtrfs).Methods("GET")
 	api.HandleFunc("/zfs/pools", s.handleZFSPools).Methods("GET")
 	api.HandleFunc("/zfs/pools/{name}", s.handleZFSPool).Methods("GET")
 	api.HandleFunc("/zfs/datasets", s.handleZFSDatasets).Methods("GET")
@@ -167,6 +268,7 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/hardware/memory-devices", s.handleHardwareMemoryDevices).Methods("GET")
 
 	// Control endpoints
+	api.HandleFunc("/batch", s.handleBatch).Methods("POST")
 	api.HandleFunc("/docker/{id}/start", s.handleDockerStart).Methods("POST")
 	api.HandleFunc("/docker/{id}/stop", s.handleDockerStop).Methods("POST")
 	api.HandleFunc("/docker/{id}/restart", s.handleDockerRestart).Methods("POST")
@@ -174,6 +276,13 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/docker/{id}/unpause", s.handleDockerUnpause).Methods("POST")
 	api.HandleFunc("/docker/{id}/remove", s.handleDockerRemove).Methods("POST")
 	api.HandleFunc("/docker/{id}/autostart", s.handleDockerAutostart).Methods("POST")
+	api.HandleFunc("/docker/{id}/limits", s.handleDockerLimits).Methods("PATCH")
+	api.HandleFunc("/docker/{id}/cpuset", s.handleDockerCPUSet).Methods("PUT")
+	api.HandleFunc("/docker/networks", s.handleDockerNetworkCreate).Methods("POST")
+	api.HandleFunc("/docker/networks/{id}", s.handleDockerNetworkRemove).Methods("DELETE")
+	api.HandleFunc("/docker/autostart", s.handleDockerAutostartOrder).Methods("PUT")
+	api.HandleFunc("/docker/groups/{name}/start", s.handleDockerGroupStart).Methods("POST")
+	api.HandleFunc("/docker/groups/{name}/stop", s.handleDockerGroupStop).Methods("POST")
 
 	api.HandleFunc("/vm/{name}/start", s.handleVMStart).Methods("POST")
 	api.HandleFunc("/vm/{name}/stop", s.handleVMStop).Methods("POST")
@@ -183,25 +292,45 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/vm/{name}/hibernate", s.handleVMHibernate).Methods("POST")
 	api.HandleFunc("/vm/{name}/force-stop", s.handleVMForceStop).Methods("POST")
 	api.HandleFunc("/vm/{name}/reset", s.handleVMReset).Methods("POST")
+	api.HandleFunc("/vm/{name}/usb/attach", s.handleVMUSBAttach).Methods("POST")
+	api.HandleFunc("/vm/{name}/usb/detach", s.handleVMUSBDetach).Methods("POST")
 	api.HandleFunc("/vm/{name}/clone", s.handleVMClone).Methods("POST")
 	api.HandleFunc("/vm/{name}/snapshot", s.handleVMCreateSnapshot).Methods("POST")
 	api.HandleFunc("/vm/{name}/snapshots", s.handleVMListSnapshots).Methods("GET")
 	api.HandleFunc("/vm/{name}/snapshots/{snapshot_name}", s.handleVMDeleteSnapshot).Methods("DELETE")
 	api.HandleFunc("/vm/{name}/snapshots/{snapshot_name}/restore", s.handleVMRestoreSnapshot).Methods("POST")
+	api.HandleFunc("/vm/{name}/cpu-pinning", s.handleVMCPUPinning).Methods("PUT")
+	api.HandleFunc("/vm/{name}/disks", s.handleVMDisks).Methods("GET")
+	api.HandleFunc("/vm/{name}/disks/{target}/resize", s.handleVMDiskResize).Methods("POST")
+	api.HandleFunc("/vm/{name}/disks/{target}/convert", s.handleVMDiskConvert).Methods("POST")
 
 	// Array control endpoints
 	api.HandleFunc("/array/start", s.handleArrayStart).Methods("POST")
 	api.HandleFunc("/array/stop", s.handleArrayStop).Methods("POST")
+	api.HandleFunc("/array/mdstat", s.handleArrayMDStat).Methods("GET")
+	api.HandleFunc("/array/mdstat", s.handleArrayMDTuning).Methods("PATCH")
+	api.HandleFunc("/array/encryption", s.handleArrayEncryption).Methods("GET")
+	api.HandleFunc("/array/utilization", s.handleUtilizationSummary).Methods("GET")
+	api.HandleFunc("/array/unlock", s.handleArrayUnlock).Methods("POST")
 	api.HandleFunc("/array/parity-check/start", s.handleParityCheckStart).Methods("POST")
 	api.HandleFunc("/array/parity-check/stop", s.handleParityCheckStop).Methods("POST")
 	api.HandleFunc("/array/parity-check/pause", s.handleParityCheckPause).Methods("POST")
 	api.HandleFunc("/array/parity-check/resume", s.handleParityCheckResume).Methods("POST")
 	api.HandleFunc("/array/parity-check/history", s.handleParityCheckHistory).Methods("GET")
+	api.HandleFunc("/array/parity-check/history/{id}/note", s.handleParityCheckNote).Methods("POST")
 	api.HandleFunc("/array/parity-check/schedule", s.handleParitySchedule).Methods("GET") // Issue #47
 	api.HandleFunc("/array/clear-disk-stats", s.handleClearDiskStats).Methods("POST")
 
+	// Boot sequence: preconditions, array unlock/start, then containers and VMs
+	api.HandleFunc("/boot-sequence", s.handleBootSequence).Methods("GET")
+	api.HandleFunc("/boot-sequence", s.handleUpdateBootSequence).Methods("PUT")
+	api.HandleFunc("/boot-sequence/run", s.handleRunBootSequence).Methods("POST")
+
 	// Configuration endpoints (read-only)
 	api.HandleFunc("/shares/{name}/config", s.handleShareConfig).Methods("GET")
+	api.HandleFunc("/shares/{name}/distribution", s.handleShareDistribution).Methods("GET")
+	api.HandleFunc("/shares/{name}/export", s.handleShareExport).Methods("GET")
+	api.HandleFunc("/network/config", s.handleNetworkSettings).Methods("GET")
 	api.HandleFunc("/network/{interface}/config", s.handleNetworkConfig).Methods("GET")
 	api.HandleFunc("/settings/system", s.handleSystemSettings).Methods("GET")
 	api.HandleFunc("/settings/docker", s.handleDockerSettings).Methods("GET")
@@ -227,20 +356,80 @@ func (s *Server) setupRoutes() {
 
 	// Mover status (state + schedule + last-run stats from /var/log/mover.log)
 	api.HandleFunc("/mover", s.handleMover).Methods("GET")
+	api.HandleFunc("/mover/start", s.handleMoverStart).Methods("POST")
+
+	// Background jobs started with ?async=true (and the mover)
+	api.HandleFunc("/jobs", s.handleJobs).Methods("GET")
+	api.HandleFunc("/jobs/{id}", s.handleJob).Methods("GET")
+	api.HandleFunc("/jobs/{id}", s.handleJobCancel).Methods("DELETE")
+
+	// Directory size analysis (background jobs, share paths only)
+	api.HandleFunc("/filesystem/analyze", s.handleFilesystemAnalyzeJobs).Methods("GET")
+	api.HandleFunc("/filesystem/analyze", s.handleFilesystemAnalyze).Methods("POST")
+	api.HandleFunc("/filesystem/analyze/{id}", s.handleFilesystemAnalyzeJob).Methods("GET")
+	api.HandleFunc("/filesystem/analyze/{id}", s.handleFilesystemAnalyzeCancel).Methods("DELETE")
+
+	// Read-only file browser (share paths only)
+	api.HandleFunc("/files/list", s.handleFilesList).Methods("GET")
+	api.HandleFunc("/files/stat", s.handleFilesStat).Methods("GET")
+	api.HandleFunc("/files/download", s.handleFilesDownload).Methods("GET")
+
+	// rsync/rclone transfer jobs
+	api.HandleFunc("/transfers", s.handleListTransferJobs).Methods("GET")
+	api.HandleFunc("/transfers", s.handleCreateTransferJob).Methods("POST")
+	api.HandleFunc("/transfers/runs", s.handleTransferRuns).Methods("GET")
+	api.HandleFunc("/transfers/{id}", s.handleGetTransferJob).Methods("GET")
+	api.HandleFunc("/transfers/{id}", s.handleUpdateTransferJob).Methods("PUT")
+	api.HandleFunc("/transfers/{id}", s.handleDeleteTransferJob).Methods("DELETE")
+	api.HandleFunc("/transfers/{id}/run", s.handleRunTransferJob).Methods("POST")
+	api.HandleFunc("/transfers/{id}/cancel", s.handleCancelTransferJob).Methods("POST")
+
+	// Recycle Bin plugin (per-share deleted files)
+	api.HandleFunc("/recyclebin", s.handleRecycleBin).Methods("GET")
+	api.HandleFunc("/recyclebin/{share}/empty", s.handleRecycleBinEmpty).Methods("POST")
+
+	// IPMI (BMC sensors and chassis control)
+	api.HandleFunc("/ipmi", s.handleIPMI).Methods("GET")
+	api.HandleFunc("/ipmi/chassis", s.handleIPMIChassisAction).Methods("POST")
+
+	// Unraid Connect (cloud connection, flash backup, remote access)
+	api.HandleFunc("/connect", s.handleConnect).Methods("GET")
 
 	// Configuration endpoints (write)
 	api.HandleFunc("/shares/{name}/config", s.handleUpdateShareConfig).Methods("POST")
+	api.HandleFunc("/shares/{name}/export", s.handleUpdateShareExport).Methods("PATCH")
 	api.HandleFunc("/settings/system", s.handleUpdateSystemSettings).Methods("POST")
+	api.HandleFunc("/network/config", s.handleUpdateNetworkSettings).Methods("PUT")
+	api.HandleFunc("/network/config/confirm", s.handleConfirmNetworkSettings).Methods("POST")
+	api.HandleFunc("/network/config/rollback", s.handleRollbackNetworkSettings).Methods("POST")
 
 	// User Scripts endpoints
 	api.HandleFunc("/user-scripts", s.handleUserScripts).Methods("GET")
 	api.HandleFunc("/user-scripts/{name}/execute", s.handleUserScriptExecute).Methods("POST")
 
+	// Agent-managed script registry
+	api.HandleFunc("/scripts", s.handleListScripts).Methods("GET")
+	api.HandleFunc("/scripts", s.handleCreateScript).Methods("POST")
+	api.HandleFunc("/scripts/{name}", s.handleGetScript).Methods("GET")
+	api.HandleFunc("/scripts/{name}", s.handleUpdateScript).Methods("PUT")
+	api.HandleFunc("/scripts/{name}", s.handleDeleteScript).Methods("DELETE")
+	api.HandleFunc("/scripts/{name}/execute", s.handleExecuteScript).Methods("POST")
+
+	// Inbound webhooks; POST /hooks/{name} is authenticated by the hook secret
+	api.HandleFunc("/hooks", s.handleListHooks).Methods("GET")
+	api.HandleFunc("/hooks", s.handleCreateHook).Methods("POST")
+	api.HandleFunc("/hooks/{name}", s.handleGetHook).Methods("GET")
+	api.HandleFunc("/hooks/{name}", s.handleUpdateHook).Methods("PUT")
+	api.HandleFunc("/hooks/{name}", s.handleDeleteHook).Methods("DELETE")
+	api.HandleFunc("/hooks/{name}", s.handleDeliverHook).Methods("POST")
+
 	// Registration/License endpoint
 	api.HandleFunc("/registration", s.handleRegistration).Methods("GET")
+	api.HandleFunc("/registration/key", s.handleInstallRegistrationKey).Methods("POST")
 
 	// Log file endpoints
 	api.HandleFunc("/logs", s.handleLogs).Methods("GET")
+	api.HandleFunc("/logs/search", s.handleLogSearch).Methods("GET")
 	api.HandleFunc("/logs/{filename}", s.handleLogFile).Methods("GET")
 
 	// Notification endpoints (monitoring)
@@ -248,6 +437,7 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/notifications/unread", s.handleNotificationsUnread).Methods("GET")
 	api.HandleFunc("/notifications/archive", s.handleNotificationsArchive).Methods("GET")
 	api.HandleFunc("/notifications/overview", s.handleNotificationsOverview).Methods("GET")
+	api.HandleFunc("/notifications/targets", s.handleListNotificationTargets).Methods("GET")
 	api.HandleFunc("/notifications/{id}", s.handleNotificationByID).Methods("GET")
 
 	// Notification endpoints (control)
@@ -257,12 +447,21 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/notifications/{id}", s.handleDeleteNotification).Methods("DELETE")
 	api.HandleFunc("/notifications/archive/all", s.handleArchiveAllNotifications).Methods("POST")
 
+	// Notification forwarding targets (Telegram, Discord, Pushover)
+	api.HandleFunc("/notifications/targets", s.handleCreateNotificationTarget).Methods("POST")
+	api.HandleFunc("/notifications/targets/{id}", s.handleUpdateNotificationTarget).Methods("PUT")
+	api.HandleFunc("/notifications/targets/{id}", s.handleDeleteNotificationTarget).Methods("DELETE")
+	api.HandleFunc("/notifications/targets/{id}/test", s.handleTestNotificationTarget).Methods("POST")
+
 	// Unassigned Devices endpoints (monitoring)
 	api.HandleFunc("/unassigned", s.handleUnassignedDevices).Methods("GET")
 	api.HandleFunc("/unassigned/devices", s.handleUnassignedDevicesList).Methods("GET")
 	api.HandleFunc("/unassigned/remote-shares", s.handleUnassignedRemoteShares).Methods("GET")
 	api.HandleFunc("/unassigned/remote-shares/mount", s.handleMountRemoteShare).Methods("POST")
 	api.HandleFunc("/unassigned/remote-shares/unmount", s.handleUnmountRemoteShare).Methods("POST")
+	api.HandleFunc("/unassigned/devices/{device}/mount", s.handleMountUnassignedDevice).Methods("POST")
+	api.HandleFunc("/unassigned/devices/{device}/unmount", s.handleUnmountUnassignedDevice).Methods("POST")
+	api.HandleFunc("/unassigned/devices/{device}/format", s.handleFormatUnassignedDevice).Methods("POST")
 
 	// Collectors management endpoints
 	api.HandleFunc("/collectors/status", s.handleCollectorsStatus).Methods("GET")
@@ -294,6 +493,9 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/healthchecks/{id}", s.handleDeleteHealthCheck).Methods("DELETE")
 	api.HandleFunc("/healthchecks/{id}/run", s.handleRunHealthCheck).Methods("POST")
 
+	// Audit log of state-changing operations
+	api.HandleFunc("/audit", s.handleAuditLog).Methods("GET")
+
 	// Metrics history endpoint
 	api.HandleFunc("/metrics/history", s.handleMetricHistory).Methods("GET")
 
@@ -309,6 +511,31 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/alerts/history", s.handleAlertHistory).Methods("GET")
 	api.HandleFunc("/alerts/firing", s.handleFiringAlerts).Methods("GET")
 
+	// Agent config file (YAML, hot-reloaded), self-update, own log and stats,
+	// and the HTTPS certificate
+	api.HandleFunc("/agent/config", s.handleGetAgentConfig).Methods("GET")
+	api.HandleFunc("/agent/config", s.handleUpdateAgentConfig).Methods("PUT")
+	api.HandleFunc("/agent/config/reload", s.handleReloadAgentConfig).Methods("POST")
+	api.HandleFunc("/agent/update", s.handleAgentUpdateCheck).Methods("GET")
+	api.HandleFunc("/agent/update", s.handleAgentUpdate).Methods("POST")
+	api.HandleFunc("/agent/restart", s.handleAgentRestart).Methods("POST")
+	api.HandleFunc("/agent/logs", s.handleAgentLogs).Methods("GET")
+	api.HandleFunc("/agent/stats", s.handleAgentStats).Methods("GET")
+	api.HandleFunc("/agent/tls", s.handleTLSStatus).Methods("GET")
+	api.HandleFunc("/agent/tls/renew", s.handleTLSRenew).Methods("POST")
+
+	// Fleet mode: peer registry, aggregated view, SSH relay, and per-server proxy.
+	// The proxy route is last so it does not shadow /fleet/peers.
+	api.HandleFunc("/fleet", s.handleFleetOverview).Methods("GET")
+	api.HandleFunc("/fleet/peers", s.handleListFleetPeers).Methods("GET")
+	api.HandleFunc("/fleet/peers", s.handleAddFleetPeer).Methods("POST")
+	api.HandleFunc("/fleet/peers/{name}", s.handleDeleteFleetPeer).Methods("DELETE")
+	api.HandleFunc("/fleet/peers/{name}/relay", s.handleFleetRelay).Methods("POST")
+	api.HandleFunc("/fleet/ssh-key", s.handleGetFleetSSHKey).Methods("GET")
+	api.HandleFunc("/fleet/ssh-key", s.handleSetFleetSSHKey).Methods("POST")
+	api.HandleFunc("/fleet/ssh-key", s.handleDeleteFleetSSHKey).Methods("DELETE")
+	api.HandleFunc("/fleet/{server}/{path:.*}", s.handleFleetProxy)
+
 	// Agent (Phase 1: on-demand sessions; Phase 2: approve/cancel)
 	api.HandleFunc("/agent/sessions", s.handleAgentStartSession).Methods("POST")
 	api.HandleFunc("/agent/sessions", s.handleAgentListSessions).Methods("GET")
@@ -339,9 +566,6 @@ func (s *Server) setupRoutes() {
 	api.HandleFunc("/tuning/disk-cache", s.handleSetDiskCache).Methods("POST")
 	api.HandleFunc("/tuning/inotify", s.handleSetInotifyLimits).Methods("POST")
 
-	// Docker aggregate stats
-	api.HandleFunc("/docker/stats", s.handleDockerStats).Methods("GET")
-
 	// Temperature sensors
 	api.HandleFunc("/temperatures", s.handleTemperatures).Methods("GET")
 
@@ -420,6 +644,17 @@ func (s *Server) StartHTTP() error {
 		IdleTimeout:       120 * time.Second,
 	}
 
+	if s.certManager != nil {
+		// The manager serves the current certificate on every handshake, so
+		// renewals take effect without a restart.
+		s.httpServer.TLSConfig = &tls.Config{
+			GetCertificate: s.certManager.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		}
+		logger.Info("HTTPS server listening on %s (ACME certificate)", s.httpServer.Addr)
+		return s.httpServer.ListenAndServeTLS("", "")
+	}
+
 	if s.ctx.TLSEnabled() {
 		logger.Info("HTTPS server listening on %s", s.httpServer.Addr)
 		return s.httpServer.ListenAndServeTLS(s.ctx.TLSCertFile, s.ctx.TLSKeyFile)
@@ -441,6 +676,11 @@ func (s *Server) Stop() {
 	// Cancel all background goroutines
 	s.cancelFunc()
 
+	// Give WebSocket clients their going-away frame before the process exits
+	if !s.wsHub.waitClosed(wsCloseTimeout) {
+		logger.Debug("WebSocket clients did not close within %s", wsCloseTimeout)
+	}
+
 	// Shutdown HTTP server with timeout (only if it was started)
 	if s.httpServer != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -473,8 +713,10 @@ func (s *Server) subscribeToEvents(ctx context.Context, readyWg *sync.WaitGroup)
 			s.ctx.Hub.Unsub(ch)
 			return
 		case msg := <-ch:
-			if handler, ok := dispatch[reflect.TypeOf(msg)]; ok {
-				handler(s.CacheStore, msg)
+			if b, ok := dispatch[reflect.TypeOf(msg)]; ok {
+				b.update(s.CacheStore, msg)
+				s.CacheStore.markUpdated(b.topicName, time.Now())
+				s.CacheStore.lastEvents.Store(b.topicName, msg)
 				logger.Debug("Cache: Updated %T", msg)
 			} else {
 				logger.Warning("Cache: Received unknown event type: %T", msg)
@@ -504,6 +746,21 @@ func (s *Server) broadcastEvents(ctx context.Context, readyWg *sync.WaitGroup) {
 	}
 }
 
+// recentEvents returns the broadcast events the event bus kept for replay.
+func (s *Server) recentEvents() []dto.WSEvent {
+	recent := s.ctx.Hub.Recent(broadcastTopicNames()...)
+	events := make([]dto.WSEvent, 0, len(recent))
+	for _, e := range recent {
+		events = append(events, dto.WSEvent{
+			Event:     e.Topic,
+			Timestamp: e.PublishedAt,
+			Data:      e.Data,
+			Replay:    true,
+		})
+	}
+	return events
+}
+
 // ListLogFiles returns a list of available log files.
 func (s *Server) ListLogFiles() []dto.LogFile {
 	return s.listLogFiles()
@@ -514,6 +771,11 @@ func (s *Server) GetLogContent(path, lines, start string) (*dto.LogFileContent,
 	return s.getLogContent(path, lines, start)
 }
 
+// SearchLogs searches the approved log files for matching lines.
+func (s *Server) SearchLogs(query dto.LogSearchQuery) (*dto.LogSearchResult, error) {
+	return s.searchLogs(query)
+}
+
 // GetCollectorsStatus returns the status of all collectors.
 func (s *Server) GetCollectorsStatus() dto.CollectorsStatusResponse {
 	if s.collectorManager == nil {
@@ -685,6 +947,18 @@ func (s *Server) GetHealthStatus() map[string]any {
 	health["running_vms"] = runningVMs
 	health["total_vms"] = len(vms)
 
+	// Component states, as served by /health
+	status := s.cachedHealthStatus()
+	health["status"] = status.Status
+	health["components"] = status.Components
+
+	// Until the startup phase ends some of the figures above are missing
+	readiness := s.readiness()
+	health["ready"] = readiness.Ready
+	if len(readiness.Pending) > 0 {
+		health["pending_collectors"] = readiness.Pending
+	}
+
 	return health
 }
 
@@ -703,6 +977,14 @@ func (s *Server) SetAlertEngine(engine *alerting.Engine, store *alerting.Store)
 func (s *Server) SetWatchdog(runner *watchdog.Runner, store *watchdog.Store) {
 	s.watchdogRunner = runner
 	s.watchdogStore = store
+	s.healthProbes.Store(runner)
+}
+
+// SetNotificationForwarding sets the notification forwarder and target store for
+// the notification target API endpoints.
+func (s *Server) SetNotificationForwarding(forwarder *forwarding.Forwarder, store *forwarding.Store) {
+	s.forwarder = forwarder
+	s.forwardingStore = store
 }
 
 // SetFanController sets the fan controller for fan control API endpoints.
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// respondShareExportError maps share export controller errors to HTTP statuses.
+func respondShareExportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, controllers.ErrInvalidShareExport):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, controllers.ErrShareNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// handleShareExport godoc
+//
+//	@Summary		Get share export settings
+//	@Description	Get how a user share is exported over SMB and NFS: export mode, security mode, the SMB users with read-only or read-write access and the NFS host rules.
+//	@Tags			Shares
+//	@Produce		json
+//	@Param			name	path		string				true	"Share name"
+//	@Success		200		{object}	dto.ShareExport		"Share export settings"
+//	@Failure		400		{object}	dto.Response		"Invalid share name"
+//	@Failure		404		{object}	dto.Response		"Share not found"
+//	@Failure		500		{object}	dto.Response		"Failed to read the share configuration"
+//	@Router			/shares/{name}/export [get]
+func (s *Server) handleShareExport(w http.ResponseWriter, r *http.Request) {
+	export, err := controllers.GetShareExport(mux.Vars(r)["name"])
+	if err != nil {
+		respondShareExportError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, export)
+}
+
+// handleUpdateShareExport godoc
+//
+//	@Summary		Update share export settings
+//	@Description	Turn SMB and NFS export of a user share on or off and change its security mode (public, secure or private), SMB user access lists and NFS host rules. Only the fields in the request are changed. The settings are applied through emhttpd, which rewrites the share configuration and reloads Samba and NFS, so clients see the change immediately.
+//	@Tags			Shares
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string					true	"Share name"
+//	@Param			request	body		dto.ShareExportUpdate	true	"Export settings to change"
+//	@Success		200		{object}	dto.ShareExport			"Updated share export settings"
+//	@Failure		400		{object}	dto.Response			"Invalid request"
+//	@Failure		404		{object}	dto.Response			"Share not found"
+//	@Failure		500		{object}	dto.Response			"Failed to apply the settings"
+//	@Router			/shares/{name}/export [patch]
+func (s *Server) handleUpdateShareExport(w http.ResponseWriter, r *http.Request) {
+	shareName := mux.Vars(r)["name"]
+
+	var update dto.ShareExportUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	export, err := controllers.UpdateShareExport(shareName, update)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to update export settings of share %s: %v", shareName, err)
+		respondShareExportError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, export)
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestShareExportEndpointsValidation(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name, method, path, body string
+		want                     int
+	}{
+		{"invalid share name", "GET", "/api/v1/shares/.hidden/export", "", http.StatusBadRequest},
+		{"update invalid json", "PATCH", "/api/v1/shares/media/export", `{`, http.StatusBadRequest},
+		{"update invalid share name", "PATCH", "/api/v1/shares/.hidden/export", `{"smb_export":"yes"}`, http.StatusBadRequest},
+		{"unknown share", "GET", "/api/v1/shares/no-such-share-xyz/export", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rr.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", rr.Code, tt.want, rr.Body)
+			}
+		})
+	}
+}
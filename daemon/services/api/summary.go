@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// handleSummary godoc
+//
+//	@Summary		Get a compact dashboard summary
+//	@Description	Returns a small, pre-aggregated payload for phone widgets and e-ink displays: CPU, RAM and CPU temperature, array state and usage, parity status, disk counts (total, above 55 °C, failing SMART), running/total containers and VMs, unread notifications and firing alert rules. Fields whose data has not been collected yet are omitted.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.Summary	"Dashboard summary"
+//	@Router			/summary [get]
+func (s *Server) handleSummary(w http.ResponseWriter, _ *http.Request) {
+	firing := 0
+	if s.alertEngine != nil {
+		firing = len(s.alertEngine.GetFiringAlerts())
+	}
+	respondJSON(w, http.StatusOK, BuildSummary(
+		s.GetSystemCache(), s.GetArrayCache(), s.GetDisksCache(), s.GetDockerCache(),
+		s.GetVMsCache(), s.GetNotificationsCache(), firing, time.Now(),
+	))
+}
+
+// BuildSummary aggregates the cached collector data into a dto.Summary.
+// Keeping inputs as plain values makes the function unit-testable without a
+// running Server.
+func BuildSummary(
+	sys *dto.SystemInfo,
+	array *dto.ArrayStatus,
+	disks []dto.DiskInfo,
+	containers []dto.ContainerInfo,
+	vms []dto.VMInfo,
+	notifications *dto.NotificationList,
+	firingAlerts int,
+	now time.Time,
+) *dto.Summary {
+	summary := &dto.Summary{
+		Disks:           len(disks),
+		ContainersTotal: len(containers),
+		VMsTotal:        len(vms),
+		FiringAlerts:    firingAlerts,
+		Timestamp:       now,
+	}
+
+	if sys != nil {
+		summary.Hostname = sys.Hostname
+		summary.UptimeSeconds = sys.Uptime
+		cpu, ram, temp := sys.CPUUsage, sys.RAMUsage, sys.CPUTemp
+		summary.CPUPercent, summary.RAMPercent = &cpu, &ram
+		if temp > 0 {
+			summary.CPUTempC = &temp
+		}
+	}
+
+	if array != nil {
+		summary.ArrayState = array.State
+		used, valid := array.UsedPercent, array.ParityValid
+		summary.ArrayUsedPercent, summary.ParityValid = &used, &valid
+		summary.ParityStatus = array.ParityCheckStatus
+		if summary.ParityStatus == "" {
+			summary.ParityStatus = "idle"
+		}
+		if summary.ParityStatus != "idle" {
+			summary.ParityProgress = array.ParityCheckProgress
+			summary.ParityETASeconds = array.ParityCheckETASeconds
+		}
+	}
+
+	for _, d := range disks {
+		if d.Temperature > diskTempWarning {
+			summary.HotDisks++
+		}
+		if d.SMARTStatus != "" && d.SMARTStatus != "PASSED" {
+			summary.FailingDisks++
+		}
+	}
+	for _, c := range containers {
+		if c.State == "running" {
+			summary.ContainersRunning++
+		}
+	}
+	for _, vm := range vms {
+		if vm.State == "running" {
+			summary.VMsRunning++
+		}
+	}
+	if notifications != nil {
+		summary.UnreadNotifications = notifications.Overview.Unread.Total
+		summary.UnreadAlerts = notifications.Overview.Unread.Alert
+	}
+	return summary
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestBuildSummary(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	sys := &dto.SystemInfo{Hostname: "tower", Uptime: 3600, CPUUsage: 12.5, RAMUsage: 40, CPUTemp: 48}
+	array := &dto.ArrayStatus{State: "Started", UsedPercent: 61.2, ParityValid: true,
+		ParityCheckStatus: "running", ParityCheckProgress: 27.5, ParityCheckETASeconds: 62700}
+	disks := []dto.DiskInfo{
+		{ID: "disk1", Temperature: 38, SMARTStatus: "PASSED"},
+		{ID: "disk2", Temperature: 57, SMARTStatus: "PASSED"},
+		{ID: "disk3", Temperature: 0, SMARTStatus: "FAILED"},
+	}
+	containers := []dto.ContainerInfo{{Name: "plex", State: "running"}, {Name: "old", State: "exited"}}
+	vms := []dto.VMInfo{{Name: "win", State: "running"}, {Name: "lab", State: "shut off"}}
+	notifications := &dto.NotificationList{Overview: dto.NotificationOverview{
+		Unread: dto.NotificationCounts{Info: 2, Alert: 1, Total: 3},
+	}}
+
+	s := BuildSummary(sys, array, disks, containers, vms, notifications, 2, now)
+
+	if s.Hostname != "tower" || s.CPUPercent == nil || *s.CPUPercent != 12.5 || *s.RAMPercent != 40 || *s.CPUTempC != 48 {
+		t.Errorf("system fields = %+v", s)
+	}
+	if s.ArrayState != "Started" || *s.ArrayUsedPercent != 61.2 || !*s.ParityValid ||
+		s.ParityStatus != "running" || s.ParityProgress != 27.5 || s.ParityETASeconds != 62700 {
+		t.Errorf("array fields = %+v", s)
+	}
+	if s.Disks != 3 || s.HotDisks != 1 || s.FailingDisks != 1 {
+		t.Errorf("disks = %d hot = %d failing = %d", s.Disks, s.HotDisks, s.FailingDisks)
+	}
+	if s.ContainersRunning != 1 || s.ContainersTotal != 2 || s.VMsRunning != 1 || s.VMsTotal != 2 {
+		t.Errorf("workloads = %+v", s)
+	}
+	if s.UnreadNotifications != 3 || s.UnreadAlerts != 1 || s.FiringAlerts != 2 {
+		t.Errorf("alerts = %+v", s)
+	}
+	if !s.Timestamp.Equal(now) {
+		t.Errorf("Timestamp = %v", s.Timestamp)
+	}
+}
+
+func TestBuildSummaryIdleParityAndMissingData(t *testing.T) {
+	s := BuildSummary(nil, &dto.ArrayStatus{State: "Stopped", ParityCheckProgress: 100}, nil, nil, nil, nil, 0, time.Now())
+	if s.ParityStatus != "idle" || s.ParityProgress != 0 {
+		t.Errorf("parity = %q %v, want idle 0", s.ParityStatus, s.ParityProgress)
+	}
+	if s.CPUPercent != nil || s.RAMPercent != nil || s.Hostname != "" {
+		t.Errorf("system fields set without system data: %+v", s)
+	}
+}
+
+func TestHandleSummary(t *testing.T) {
+	server, _ := setupTestServer()
+	server.vmsCache.Store(&[]dto.VMInfo{{Name: "win", State: "running"}})
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/summary", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d", rr.Code)
+	}
+	var s dto.Summary
+	if err := json.Unmarshal(rr.Body.Bytes(), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.VMsRunning != 1 || s.VMsTotal != 1 {
+		t.Errorf("summary = %+v", s)
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// respondSwapError maps swap controller errors to HTTP statuses.
+func respondSwapError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, controllers.ErrInvalidSwapRequest):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, controllers.ErrNoSwapFile):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, controllers.ErrSwapFileExists):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// handleSwapStatus godoc
+//
+//	@Summary		Get swap and zram status
+//	@Description	Get the active swap areas from /proc/swaps (swap files, partitions and zram devices) with their size, usage and priority, the compression algorithm and ratio of zram devices, the vm.swappiness tunable and the swap file enabled through this API.
+//	@Tags			System
+//	@Produce		json
+//	@Success		200	{object}	dto.SwapStatus	"Swap status"
+//	@Failure		500	{object}	dto.Response	"Failed to read swap status"
+//	@Router			/system/swap [get]
+func (s *Server) handleSwapStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := controllers.GetSwapStatus()
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to read swap status: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, status)
+}
+
+// handleSwapFileEnable godoc
+//
+//	@Summary		Enable a swap file on a pool
+//	@Description	Create a swap file of size_bytes in the root of a pool (/mnt/{pool}/swapfile) and enable it. On btrfs the file is created with copy-on-write disabled; ZFS pools are refused. The pool must keep 1 GiB free after allocation. The swap file is re-enabled whenever the agent starts with the array and disabled when it stops, so the pool can unmount. Requires confirm=true.
+//	@Tags			System
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.SwapFileRequest		true	"Pool and size"
+//	@Success		200		{object}	dto.SwapFileConfig		"Swap file enabled"
+//	@Failure		400		{object}	dto.Response			"Invalid request, unsupported pool or not enough space"
+//	@Failure		409		{object}	dto.Response			"A swap file is already configured or the path is taken"
+//	@Failure		500		{object}	dto.Response			"Failed to create the swap file"
+//	@Router			/system/swap/file [post]
+func (s *Server) handleSwapFileEnable(w http.ResponseWriter, r *http.Request) {
+	var req dto.SwapFileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if !req.Confirm {
+		respondWithError(w, http.StatusBadRequest, "set confirm to true to create the swap file")
+		return
+	}
+
+	cfg, err := controllers.EnableSwapFile(req)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to enable swap file on %s: %v", req.Pool, err)
+		respondSwapError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, cfg)
+}
+
+// handleSwapFileDisable godoc
+//
+//	@Summary		Disable the swap file
+//	@Description	Turn off the swap file enabled through this API, moving its pages back into RAM, and stop re-enabling it at startup. The file is kept on the pool unless delete=true.
+//	@Tags			System
+//	@Produce		json
+//	@Param			delete	query		bool				false	"Also delete the swap file"
+//	@Success		200		{object}	dto.SwapFileConfig	"Swap file disabled"
+//	@Failure		404		{object}	dto.Response		"No swap file configured"
+//	@Failure		500		{object}	dto.Response		"Failed to disable the swap file"
+//	@Router			/system/swap/file [delete]
+func (s *Server) handleSwapFileDisable(w http.ResponseWriter, r *http.Request) {
+	cfg, err := controllers.DisableSwapFile(r.URL.Query().Get("delete") == "true")
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to disable swap file: %v", err)
+		respondSwapError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, cfg)
+}
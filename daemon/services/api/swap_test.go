@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSwapFileEnableValidation(t *testing.T) {
+	server, _ := setupTestServer()
+
+	for name, body := range map[string]string{
+		"invalid json":  `{`,
+		"not confirmed": `{"pool":"cache","size_bytes":4294967296}`,
+		"invalid pool":  `{"pool":"../boot","size_bytes":4294967296,"confirm":true}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/system/swap/file", strings.NewReader(body)))
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("status %d, want %d: %s", rr.Code, http.StatusBadRequest, rr.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/acme"
+)
+
+// SetCertificateManager sets the ACME manager that serves the HTTPS
+// certificate and backs the /agent/tls endpoints.
+func (s *Server) SetCertificateManager(m *acme.Manager) {
+	s.certManager = m
+}
+
+// handleTLSStatus godoc
+//
+//	@Summary		Get HTTPS certificate status
+//	@Description	Get how the HTTPS certificate is provided (off, files or acme), its names, issuer and validity, and for ACME the renewal state and last error.
+//	@Tags			Configuration
+//	@Produce		json
+//	@Success		200	{object}	dto.TLSStatus	"Certificate status"
+//	@Router			/agent/tls [get]
+func (s *Server) handleTLSStatus(w http.ResponseWriter, _ *http.Request) {
+	switch {
+	case s.certManager != nil:
+		respondJSON(w, http.StatusOK, s.certManager.Status())
+	case s.ctx.TLSCertFile != "" && s.ctx.TLSKeyFile != "":
+		respondJSON(w, http.StatusOK, acme.CertificateStatus(s.ctx.TLSCertFile, s.ctx.TLSKeyFile))
+	default:
+		respondJSON(w, http.StatusOK, dto.TLSStatus{Mode: dto.TLSModeOff, Timestamp: time.Now()})
+	}
+}
+
+// handleTLSRenew godoc
+//
+//	@Summary		Renew the ACME certificate
+//	@Description	Starts an ACME certificate renewal in the background, even when the certificate is not yet due. Poll GET /agent/tls for the outcome.
+//	@Tags			Configuration
+//	@Produce		json
+//	@Success		202	{object}	dto.Response	"Renewal started"
+//	@Failure		409	{object}	dto.Response	"ACME is not enabled or a renewal is already running"
+//	@Router			/agent/tls/renew [post]
+func (s *Server) handleTLSRenew(w http.ResponseWriter, _ *http.Request) {
+	if s.certManager == nil {
+		respondWithError(w, http.StatusConflict, "ACME certificates are not enabled")
+		return
+	}
+	if err := s.certManager.Renew(); err != nil {
+		if errors.Is(err, acme.ErrRenewalInProgress) {
+			respondWithError(w, http.StatusConflict, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusAccepted, dto.Response{
+		Success:   true,
+		Message:   "Certificate renewal started",
+		Timestamp: time.Now(),
+	})
+}
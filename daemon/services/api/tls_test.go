@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/acme"
+)
+
+func TestTLSEndpoints(t *testing.T) {
+	server, _ := setupTestServer()
+
+	get := func() dto.TLSStatus {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/agent/tls", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("GET /agent/tls status %d: %s", rr.Code, rr.Body)
+		}
+		var status dto.TLSStatus
+		if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+			t.Fatal(err)
+		}
+		return status
+	}
+	renew := func() int {
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/agent/tls/renew", nil))
+		return rr.Code
+	}
+
+	if status := get(); status.Mode != dto.TLSModeOff {
+		t.Errorf("mode = %q without TLS, want off", status.Mode)
+	}
+	if code := renew(); code != http.StatusConflict {
+		t.Errorf("renew without ACME: status %d, want 409", code)
+	}
+
+	manager, err := acme.NewManager(domain.ACMEConfig{
+		Enabled:     true,
+		Domains:     []string{"tower.example.com"},
+		DNSProvider: acme.ProviderCloudflare,
+		DNSToken:    "token",
+	}, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.SetCertificateManager(manager)
+
+	if status := get(); status.Mode != dto.TLSModeACME || !status.SelfSigned || status.DNSProvider != "cloudflare" {
+		t.Errorf("ACME status = %+v", status)
+	}
+	if code := renew(); code != http.StatusAccepted {
+		t.Errorf("renew: status %d, want 202", code)
+	}
+	// The manager is not running, so the first request is still queued.
+	if code := renew(); code != http.StatusConflict {
+		t.Errorf("second renew: status %d, want 409", code)
+	}
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/transfer"
+)
+
+// SetTransfers sets the transfer runner backing the /transfers endpoints.
+func (s *Server) SetTransfers(runner *transfer.Runner) {
+	s.transferRunner = runner
+}
+
+// transfersReady writes a 503 response and returns false when the transfer
+// runner is not initialized.
+func (s *Server) transfersReady(w http.ResponseWriter) bool {
+	if s.transferRunner == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Transfer runner not initialized")
+		return false
+	}
+	return true
+}
+
+// handleListTransferJobs godoc
+//
+//	@Summary		List transfer jobs
+//	@Description	List the configured rsync and rclone transfer jobs.
+//	@Tags			Transfers
+//	@Produce		json
+//	@Success		200	{array}		dto.TransferJob	"Transfer jobs"
+//	@Failure		503	{object}	dto.Response	"Transfer runner not initialized"
+//	@Router			/transfers [get]
+func (s *Server) handleListTransferJobs(w http.ResponseWriter, _ *http.Request) {
+	if !s.transfersReady(w) {
+		return
+	}
+	respondJSON(w, http.StatusOK, s.transferRunner.Store().GetJobs())
+}
+
+// handleCreateTransferJob godoc
+//
+//	@Summary		Create transfer job
+//	@Description	Define an rsync or rclone transfer job. Source, destination and flags are passed to the tool as separate arguments without a shell; options that run other programs (rsync -e/--rsh/--rsync-path, rclone --password-command/--rc) are rejected. Set schedule_interval_minutes or schedule_daily_at with enabled=true to run the job on a schedule.
+//	@Tags			Transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			job	body		dto.TransferJob	true	"Transfer job"
+//	@Success		201	{object}	dto.Response	"Created"
+//	@Failure		400	{object}	dto.Response	"Invalid request"
+//	@Failure		503	{object}	dto.Response	"Transfer runner not initialized"
+//	@Router			/transfers [post]
+func (s *Server) handleCreateTransferJob(w http.ResponseWriter, r *http.Request) {
+	if !s.transfersReady(w) {
+		return
+	}
+	var job dto.TransferJob
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if err := s.transferRunner.Store().CreateJob(job); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusCreated, dto.Response{Success: true, Message: "Transfer job created", Timestamp: time.Now()})
+}
+
+// handleGetTransferJob godoc
+//
+//	@Summary		Get transfer job
+//	@Tags			Transfers
+//	@Produce		json
+//	@Param			id	path		string			true	"Transfer job ID"
+//	@Success		200	{object}	dto.TransferJob	"Transfer job"
+//	@Failure		404	{object}	dto.Response	"Not found"
+//	@Failure		503	{object}	dto.Response	"Transfer runner not initialized"
+//	@Router			/transfers/{id} [get]
+func (s *Server) handleGetTransferJob(w http.ResponseWriter, r *http.Request) {
+	if !s.transfersReady(w) {
+		return
+	}
+	job, err := s.transferRunner.Store().GetJob(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, job)
+}
+
+// handleUpdateTransferJob godoc
+//
+//	@Summary		Update transfer job
+//	@Description	Replace a transfer job definition. A running transfer keeps its original settings.
+//	@Tags			Transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string			true	"Transfer job ID"
+//	@Param			job	body		dto.TransferJob	true	"Transfer job"
+//	@Success		200	{object}	dto.Response	"Updated"
+//	@Failure		400	{object}	dto.Response	"Invalid request"
+//	@Failure		404	{object}	dto.Response	"Not found"
+//	@Failure		503	{object}	dto.Response	"Transfer runner not initialized"
+//	@Router			/transfers/{id} [put]
+func (s *Server) handleUpdateTransferJob(w http.ResponseWriter, r *http.Request) {
+	if !s.transfersReady(w) {
+		return
+	}
+	var job dto.TransferJob
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	job.ID = mux.Vars(r)["id"]
+	if err := s.transferRunner.Store().UpdateJob(job); err != nil {
+		respondTransferError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Transfer job updated", Timestamp: time.Now()})
+}
+
+// handleDeleteTransferJob godoc
+//
+//	@Summary		Delete transfer job
+//	@Description	Delete a transfer job. Cancel a running transfer first.
+//	@Tags			Transfers
+//	@Produce		json
+//	@Param			id	path		string			true	"Transfer job ID"
+//	@Success		200	{object}	dto.Response	"Deleted"
+//	@Failure		404	{object}	dto.Response	"Not found"
+//	@Failure		409	{object}	dto.Response	"Transfer running"
+//	@Failure		503	{object}	dto.Response	"Transfer runner not initialized"
+//	@Router			/transfers/{id} [delete]
+func (s *Server) handleDeleteTransferJob(w http.ResponseWriter, r *http.Request) {
+	if !s.transfersReady(w) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	if s.transferRunner.Running(id) {
+		respondWithError(w, http.StatusConflict, transfer.ErrJobRunning.Error())
+		return
+	}
+	if err := s.transferRunner.Store().DeleteJob(id); err != nil {
+		respondTransferError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Transfer job deleted", Timestamp: time.Now()})
+}
+
+// handleRunTransferJob godoc
+//
+//	@Summary		Run transfer job
+//	@Description	Start a transfer job now. Progress (bytes, percent, speed, ETA) is broadcast on the transfer_progress WebSocket topic and published to MQTT; poll GET /transfers/runs for the outcome.
+//	@Tags			Transfers
+//	@Produce		json
+//	@Param			id	path		string			true	"Transfer job ID"
+//	@Success		202	{object}	dto.TransferRun	"Transfer started"
+//	@Failure		400	{object}	dto.Response	"Tool not installed"
+//	@Failure		404	{object}	dto.Response	"Not found"
+//	@Failure		409	{object}	dto.Response	"Transfer already running"
+//	@Failure		503	{object}	dto.Response	"Transfer runner not initialized"
+//	@Router			/transfers/{id}/run [post]
+func (s *Server) handleRunTransferJob(w http.ResponseWriter, r *http.Request) {
+	if !s.transfersReady(w) {
+		return
+	}
+	run, err := s.transferRunner.Run(s.cancelCtx, mux.Vars(r)["id"], transfer.TriggerManual)
+	if err != nil {
+		respondTransferError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusAccepted, run)
+}
+
+// handleCancelTransferJob godoc
+//
+//	@Summary		Cancel transfer job
+//	@Description	Stop the running transfer of a job.
+//	@Tags			Transfers
+//	@Produce		json
+//	@Param			id	path		string			true	"Transfer job ID"
+//	@Success		200	{object}	dto.Response	"Cancelled"
+//	@Failure		409	{object}	dto.Response	"Transfer not running"
+//	@Failure		503	{object}	dto.Response	"Transfer runner not initialized"
+//	@Router			/transfers/{id}/cancel [post]
+func (s *Server) handleCancelTransferJob(w http.ResponseWriter, r *http.Request) {
+	if !s.transfersReady(w) {
+		return
+	}
+	if err := s.transferRunner.Cancel(mux.Vars(r)["id"]); err != nil {
+		respondTransferError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, dto.Response{Success: true, Message: "Transfer cancelled", Timestamp: time.Now()})
+}
+
+// handleTransferRuns godoc
+//
+//	@Summary		List transfer runs
+//	@Description	Running transfers with their live progress, followed by the last 50 finished runs, newest first. Finished runs are kept in memory and cleared when the agent restarts.
+//	@Tags			Transfers
+//	@Produce		json
+//	@Param			job_id	query		string				false	"Only runs of this job"
+//	@Success		200		{array}		dto.TransferRun		"Transfer runs"
+//	@Failure		503		{object}	dto.Response		"Transfer runner not initialized"
+//	@Router			/transfers/runs [get]
+func (s *Server) handleTransferRuns(w http.ResponseWriter, r *http.Request) {
+	if !s.transfersReady(w) {
+		return
+	}
+	runs := s.transferRunner.Runs()
+	if jobID := r.URL.Query().Get("job_id"); jobID != "" {
+		filtered := make([]dto.TransferRun, 0, len(runs))
+		for _, run := range runs {
+			if run.JobID == jobID {
+				filtered = append(filtered, run)
+			}
+		}
+		runs = filtered
+	}
+	respondJSON(w, http.StatusOK, runs)
+}
+
+// respondTransferError maps transfer service errors to HTTP statuses.
+func respondTransferError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, transfer.ErrJobNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, transfer.ErrJobRunning), errors.Is(err, transfer.ErrJobNotRunning):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/transfer"
+)
+
+func TestTransferEndpointsNotInitialized(t *testing.T) {
+	server, _ := setupTestServer()
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/transfers", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("status %d, want %d", rr.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestTransferJobCRUD(t *testing.T) {
+	server, _ := setupTestServer()
+	server.SetTransfers(transfer.NewRunner(transfer.NewStore(t.TempDir())))
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		server.router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	job := `{"id":"backup","name":"Backup","tool":"rsync","source":"/mnt/user/a/","destination":"/mnt/user/b/","flags":["-a"]}`
+	if rr := do("POST", "/api/v1/transfers", job); rr.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", rr.Code, rr.Body)
+	}
+	if rr := do("POST", "/api/v1/transfers", `{"id":"bad","name":"Bad","tool":"rsync","source":"/a","destination":"/b","flags":["-e","sh"]}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("create with blocked flag: status %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+
+	rr := do("GET", "/api/v1/transfers/backup", "")
+	var got dto.TransferJob
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("get: status %d, error %v", rr.Code, err)
+	}
+	if got.Tool != dto.TransferToolRsync || got.Source != "/mnt/user/a/" {
+		t.Errorf("get: job = %+v", got)
+	}
+
+	if rr := do("PUT", "/api/v1/transfers/backup", `{"name":"Nightly","tool":"rclone","source":"/mnt/user/a","destination":"remote:a"}`); rr.Code != http.StatusOK {
+		t.Fatalf("update: status %d: %s", rr.Code, rr.Body)
+	}
+	rr = do("GET", "/api/v1/transfers", "")
+	var jobs []dto.TransferJob
+	if err := json.Unmarshal(rr.Body.Bytes(), &jobs); err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Name != "Nightly" || jobs[0].Operation != "copy" {
+		t.Errorf("list: jobs = %+v", jobs)
+	}
+
+	rr = do("GET", "/api/v1/transfers/runs", "")
+	var runs []dto.TransferRun
+	if err := json.Unmarshal(rr.Body.Bytes(), &runs); err != nil || rr.Code != http.StatusOK || len(runs) != 0 {
+		t.Errorf("runs: status %d, runs %v, error %v", rr.Code, runs, err)
+	}
+
+	if rr := do("POST", "/api/v1/transfers/backup/cancel", ""); rr.Code != http.StatusConflict {
+		t.Errorf("cancel idle job: status %d, want %d", rr.Code, http.StatusConflict)
+	}
+	if rr := do("DELETE", "/api/v1/transfers/backup", ""); rr.Code != http.StatusOK {
+		t.Errorf("delete: status %d", rr.Code)
+	}
+	if rr := do("GET", "/api/v1/transfers/backup", ""); rr.Code != http.StatusNotFound {
+		t.Errorf("get deleted: status %d, want %d", rr.Code, http.StatusNotFound)
+	}
+	if rr := do("POST", "/api/v1/transfers/backup/run", ""); rr.Code != http.StatusNotFound {
+		t.Errorf("run deleted: status %d, want %d", rr.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+// vmDiskTargetPattern matches libvirt disk target device names such as hdc or vda.
+var vmDiskTargetPattern = regexp.MustCompile(`^[a-z][a-z0-9]{1,15}$`)
+
+// vmDiskVars returns the validated VM name and disk target of a request,
+// writing a 400 response and returning ok=false when either is invalid.
+func vmDiskVars(w http.ResponseWriter, r *http.Request) (vmName, target string, ok bool) {
+	vars := mux.Vars(r)
+	vmName, target = vars["name"], vars["target"]
+	if err := lib.ValidateVMName(vmName); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+	if !vmDiskTargetPattern.MatchString(target) {
+		respondWithError(w, http.StatusBadRequest, "invalid disk target: use the target device name, e.g. hdc")
+		return "", "", false
+	}
+	return vmName, target, true
+}
+
+// respondVMDiskError maps VM disk controller errors to HTTP statuses.
+func respondVMDiskError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, controllers.ErrInvalidDiskRequest):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, controllers.ErrVMDiskNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, controllers.ErrVMNotShutOff), errors.Is(err, controllers.ErrDiskConversionRunning):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// handleVMDisks godoc
+//
+//	@Summary		List VM disk images
+//	@Description	List the file-backed disks of a VM (CD-ROMs and passed-through block devices are skipped) with the image format, the virtual size seen by the guest and the space the image occupies on disk, as reported by qemu-img. Each disk includes its running or most recent format conversion.
+//	@Tags			VMs
+//	@Produce		json
+//	@Param			name	path		string				true	"VM name"
+//	@Success		200		{object}	dto.VMDiskImageList	"VM disk images"
+//	@Failure		400		{object}	dto.Response		"Invalid VM name"
+//	@Failure		500		{object}	dto.Response		"Failed to list disk images"
+//	@Router			/vm/{name}/disks [get]
+func (s *Server) handleVMDisks(w http.ResponseWriter, r *http.Request) {
+	vmName := mux.Vars(r)["name"]
+	if err := lib.ValidateVMName(vmName); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := controllers.NewVMController().ListDiskImages(vmName)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to list disk images of VM %s: %v", vmName, err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, list)
+}
+
+// handleVMDiskResize godoc
+//
+//	@Summary		Grow a VM disk image
+//	@Description	Grow the virtual size of a VM disk image with qemu-img resize. The VM must be shut off; shrinking is refused. Extend the partition and filesystem from inside the guest afterwards.
+//	@Tags			VMs
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string					true	"VM name"
+//	@Param			target	path		string					true	"Disk target device (e.g. hdc)"
+//	@Param			request	body		dto.VMDiskResizeRequest	true	"New virtual size"
+//	@Success		200		{object}	dto.VMDiskImage			"Resized disk image"
+//	@Failure		400		{object}	dto.Response			"Invalid request or size not larger than current"
+//	@Failure		404		{object}	dto.Response			"Disk not found"
+//	@Failure		409		{object}	dto.Response			"VM not shut off or conversion running"
+//	@Failure		500		{object}	dto.Response			"Failed to resize"
+//	@Router			/vm/{name}/disks/{target}/resize [post]
+func (s *Server) handleVMDiskResize(w http.ResponseWriter, r *http.Request) {
+	vmName, target, ok := vmDiskVars(w, r)
+	if !ok {
+		return
+	}
+	var req dto.VMDiskResizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	if req.SizeBytes == 0 {
+		respondWithError(w, http.StatusBadRequest, "size_bytes is required")
+		return
+	}
+
+	disk, err := controllers.NewVMController().ResizeDiskImage(vmName, target, req.SizeBytes)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to resize disk %s of VM %s: %v", target, vmName, err)
+		respondVMDiskError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, disk)
+}
+
+// handleVMDiskConvert godoc
+//
+//	@Summary		Convert a VM disk image format
+//	@Description	Convert a VM disk image to qcow2 or raw with qemu-img convert, in the background. The VM must be shut off and have no snapshots. The converted image is written next to the original (vdisk1.img becomes vdisk1.qcow2); when it completes the VM definition is switched to it and the original is kept for you to remove. Follow the conversion in GET /vm/{name}/disks; starting the VM before it finishes discards the converted image.
+//	@Tags			VMs
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string						true	"VM name"
+//	@Param			target	path		string						true	"Disk target device (e.g. hdc)"
+//	@Param			request	body		dto.VMDiskConvertRequest	true	"Target format"
+//	@Success		202		{object}	dto.VMDiskConversion		"Conversion started"
+//	@Failure		400		{object}	dto.Response				"Invalid request"
+//	@Failure		404		{object}	dto.Response				"Disk not found"
+//	@Failure		409		{object}	dto.Response				"VM not shut off or conversion running"
+//	@Failure		500		{object}	dto.Response				"Failed to start the conversion"
+//	@Router			/vm/{name}/disks/{target}/convert [post]
+func (s *Server) handleVMDiskConvert(w http.ResponseWriter, r *http.Request) {
+	vmName, target, ok := vmDiskVars(w, r)
+	if !ok {
+		return
+	}
+	var req dto.VMDiskConvertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+
+	conv, err := controllers.NewVMController().ConvertDiskImage(s.detachedContext(r), vmName, target, req.Format)
+	if err != nil {
+		logger.ErrorContext(r.Context(), "API: Failed to convert disk %s of VM %s: %v", target, vmName, err)
+		respondVMDiskError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusAccepted, conv)
+}
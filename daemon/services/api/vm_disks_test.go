@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVMDiskEndpointsValidation(t *testing.T) {
+	server, _ := setupTestServer()
+
+	tests := []struct {
+		name, method, path, body string
+	}{
+		{"invalid vm name", "GET", "/api/v1/vm/-bad/disks", ""},
+		{"invalid target", "POST", "/api/v1/vm/win11/disks/hdc;ls/resize", `{"size_bytes":1}`},
+		{"resize without size", "POST", "/api/v1/vm/win11/disks/hdc/resize", `{}`},
+		{"resize invalid json", "POST", "/api/v1/vm/win11/disks/hdc/resize", `{`},
+		{"convert invalid target", "POST", "/api/v1/vm/win11/disks/HDC/convert", `{"format":"qcow2"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("status %d, want %d: %s", rr.Code, http.StatusBadRequest, rr.Body)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -18,6 +19,9 @@ import (
 // maxWSMessageSize is the maximum allowed size (bytes) for an incoming WebSocket message.
 const maxWSMessageSize = 64 * 1024 // 64 KB
 
+// wsCloseTimeout bounds how long shutdown waits for clients' close frames.
+const wsCloseTimeout = 2 * time.Second
+
 // broadcastMessage carries an event with its topic name through the broadcast channel.
 type broadcastMessage struct {
 	Topic string
@@ -32,43 +36,142 @@ type WSHub struct {
 	register   chan *WSClient
 	unregister chan *WSClient
 	mu         sync.RWMutex
+	// queueSize is the send queue length of new clients, and dropOldest the
+	// policy when a client's queue is full (see SetSlowClientPolicy).
+	queueSize  int
+	dropOldest bool
+	dropped    atomic.Uint64 // events not delivered to a slow client
+	evicted    atomic.Uint64 // clients disconnected for being slow
+	// recent returns the events replayed to a client when it connects or
+	// changes its subscription (see SetReplaySource); nil disables replay.
+	recent      func() []dto.WSEvent
+	resubscribe chan *WSClient
+	// running is set when Run starts and stopped is closed once it has
+	// closed every client on shutdown; closing lists those clients so
+	// shutdown can wait for their close frames to go out.
+	running atomic.Bool
+	stopped chan struct{}
+	closing []*WSClient
 }
 
 // WSClient represents a single WebSocket client connection.
 // It maintains the connection to the hub, the WebSocket connection, and a send channel for outgoing messages.
 type WSClient struct {
-	hub    *WSHub
-	conn   *websocket.Conn
-	send   chan dto.WSEvent
-	topics map[string]bool // nil = all topics; non-nil = only matching topics
-	topMu  sync.RWMutex
+	hub     *WSHub
+	conn    *websocket.Conn
+	send    chan dto.WSEvent
+	topics  map[string]bool // nil = all topics; non-nil = only matching topics
+	topMu   sync.RWMutex
+	dropped uint64 // events dropped for this client; only touched by the hub's Run loop
+	// closeMsg is the close frame payload written when the hub closes send;
+	// set by the hub before the close, nil for a plain close.
+	closeMsg []byte
+	done     chan struct{} // closed when writePump returns
 }
 
 // NewWSHub creates and initializes a new WebSocket hub.
 // The hub is ready to accept client connections and broadcast messages.
+// Slow clients are disconnected; see SetSlowClientPolicy.
 func NewWSHub() *WSHub {
 	return &WSHub{
-		clients:    make(map[*WSClient]bool),
-		broadcast:  make(chan broadcastMessage, constants.WSBufferSize),
-		register:   make(chan *WSClient),
-		unregister: make(chan *WSClient),
+		clients:     make(map[*WSClient]bool),
+		broadcast:   make(chan broadcastMessage, constants.WSBufferSize),
+		register:    make(chan *WSClient),
+		unregister:  make(chan *WSClient),
+		resubscribe: make(chan *WSClient),
+		stopped:     make(chan struct{}),
+		queueSize:   constants.WSBufferSize,
+	}
+}
+
+// SetReplaySource sets the function returning the recent events replayed to
+// clients as they connect or subscribe, so dashboards render immediately
+// instead of waiting for the next collection. It must be called before Run.
+func (h *WSHub) SetReplaySource(recent func() []dto.WSEvent) {
+	h.recent = recent
+}
+
+// SetSlowClientPolicy sets the send queue length of clients that connect
+// afterwards and what happens when a client's queue is full:
+// constants.WSSlowClientDisconnect evicts the client, while
+// constants.WSSlowClientDropOldest discards its oldest queued event so a
+// briefly stalled dashboard only misses updates. Either way a client's
+// memory is bounded by its queue. It must be called before Run.
+func (h *WSHub) SetSlowClientPolicy(policy string, queueSize int) {
+	if queueSize > 0 {
+		h.queueSize = queueSize
+	}
+	h.dropOldest = policy == constants.WSSlowClientDropOldest
+}
+
+// Stats returns the hub's client count and slow-consumer counters.
+func (h *WSHub) Stats() dto.AgentWSStats {
+	policy := constants.WSSlowClientDisconnect
+	if h.dropOldest {
+		policy = constants.WSSlowClientDropOldest
+	}
+	return dto.AgentWSStats{
+		Clients:          h.ClientCount(),
+		QueueSize:        h.queueSize,
+		SlowClientPolicy: policy,
+		DroppedMessages:  h.dropped.Load(),
+		EvictedClients:   h.evicted.Load(),
+	}
+}
+
+// waitClosed waits up to timeout for Run to stop and for the write pumps of
+// the clients it closed to finish, which means their close frames have been
+// written. It reports whether everything finished in time.
+func (h *WSHub) waitClosed(timeout time.Duration) bool {
+	if !h.running.Load() {
+		return true
+	}
+	deadline := time.After(timeout)
+	select {
+	case <-h.stopped:
+	case <-deadline:
+		return false
+	}
+	h.mu.RLock()
+	closing := h.closing
+	h.mu.RUnlock()
+	for _, client := range closing {
+		if client.done == nil {
+			continue
+		}
+		select {
+		case <-client.done:
+		case <-deadline:
+			return false
+		}
 	}
+	return true
 }
 
 // Run starts the WebSocket hub's main event loop.
 // It handles client registration, unregistration, and message broadcasting until the context is cancelled.
 func (h *WSHub) Run(ctx context.Context) {
+	h.running.Store(true)
 	for {
 		select {
 		case <-ctx.Done():
 			logger.Info("WebSocket hub stopping due to context cancellation")
-			// Close all client connections
+			// Close all client connections with a going-away frame, so
+			// clients reconnect after a restart instead of reporting an
+			// abnormal closure.
 			h.mu.Lock()
 			for client := range h.clients {
+				client.closeMsg = websocket.FormatCloseMessage(websocket.CloseGoingAway, "agent shutting down")
 				close(client.send)
 				delete(h.clients, client)
+				h.closing = append(h.closing, client)
 			}
 			h.mu.Unlock()
+			select {
+			case <-h.stopped: // Run was started more than once
+			default:
+				close(h.stopped)
+			}
 			return
 
 		case client := <-h.register:
@@ -76,6 +179,15 @@ func (h *WSHub) Run(ctx context.Context) {
 			h.clients[client] = true
 			h.mu.Unlock()
 			logger.Debug("WebSocket client connected")
+			h.replayTo(client)
+
+		case client := <-h.resubscribe:
+			h.mu.RLock()
+			_, ok := h.clients[client]
+			h.mu.RUnlock()
+			if ok {
+				h.replayTo(client)
+			}
 
 		case client := <-h.unregister:
 			h.mu.Lock()
@@ -108,7 +220,12 @@ func (h *WSHub) Run(ctx context.Context) {
 				select {
 				case client.send <- event:
 				default:
-					staleClients = append(staleClients, client)
+					if h.dropOldest {
+						h.replaceOldest(client, event)
+					} else {
+						staleClients = append(staleClients, client)
+					}
+					h.dropped.Add(1)
 				}
 			}
 
@@ -121,13 +238,14 @@ func (h *WSHub) Run(ctx context.Context) {
 				if _, ok := h.clients[client]; ok {
 					delete(h.clients, client)
 					close(client.send)
+					h.evicted.Add(1)
 					// A client is evicted when its send buffer (WSBufferSize) is
 					// full, i.e. it cannot keep up with the broadcast rate — on a
 					// congested link this surfaces to the consumer as a dropped
 					// connection ("EOF"). Log it so this is diagnosable from the
 					// agent log instead of being silent (ha-unraid-management-agent#83).
 					logger.Warning("WebSocket: evicting slow client %s — send buffer full (%d) on topic %q; it must reconnect",
-						clientRemoteAddr(client), constants.WSBufferSize, event.Event)
+						clientRemoteAddr(client), h.queueSize, event.Event)
 				}
 			}
 			h.mu.Unlock()
@@ -135,6 +253,44 @@ func (h *WSHub) Run(ctx context.Context) {
 	}
 }
 
+// replayTo queues the recent events of the client's topics. Replay stops
+// when the client's queue is full, leaving room for live events.
+func (h *WSHub) replayTo(client *WSClient) {
+	if h.recent == nil {
+		return
+	}
+	for _, event := range h.recent() {
+		if !client.wantsTopic(event.Event) {
+			continue
+		}
+		select {
+		case client.send <- event:
+		default:
+			return
+		}
+	}
+}
+
+// replaceOldest makes room in a full client queue by discarding its oldest
+// event, then queues event. Only Run sends on client queues, so the queue
+// cannot fill up again in between; the client's writer may drain it
+// concurrently, which only leaves more room.
+func (h *WSHub) replaceOldest(client *WSClient, event dto.WSEvent) {
+	select {
+	case <-client.send:
+	default:
+	}
+	select {
+	case client.send <- event:
+	default:
+	}
+	if client.dropped == 0 {
+		logger.Warning("WebSocket: client %s is not keeping up — send buffer full (%d); dropping its oldest events",
+			clientRemoteAddr(client), h.queueSize)
+	}
+	client.dropped++
+}
+
 // clientRemoteAddr returns the client's remote address for logging, or
 // "unknown" if the connection is unavailable. Kept defensive so a logging call
 // can never panic on a half-torn-down connection.
@@ -174,6 +330,13 @@ func (c *WSClient) setTopics(topics []string) {
 	}
 }
 
+// ClientCount returns the number of connected WebSocket clients.
+func (h *WSHub) ClientCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
 // Broadcast sends a message to all connected WebSocket clients matching the topic filter.
 func (h *WSHub) Broadcast(topic string, data any) {
 	h.broadcast <- broadcastMessage{Topic: topic, Data: data}
@@ -211,6 +374,9 @@ func (h *WSHub) Broadcast(topic string, data any) {
 //	@Router			/ws [get]
 func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	u := websocket.Upgrader{
+		// Negotiate permessage-deflate with clients that offer it; the
+		// repetitive JSON events compress well.
+		EnableCompression: true,
 		CheckOrigin: func(req *http.Request) bool {
 			origin := req.Header.Get("Origin")
 			if origin == "" {
@@ -281,7 +447,7 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	conn, err := u.Upgrade(w, r, nil)
 	if err != nil {
-		logger.Error("WebSocket upgrade error: %v", err)
+		logger.ErrorContext(r.Context(), "WebSocket upgrade error: %v", err)
 		return
 	}
 
@@ -290,7 +456,8 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	client := &WSClient{
 		hub:  s.wsHub,
 		conn: conn,
-		send: make(chan dto.WSEvent, constants.WSBufferSize),
+		send: make(chan dto.WSEvent, s.wsHub.queueSize),
+		done: make(chan struct{}),
 	}
 
 	client.hub.register <- client
@@ -299,6 +466,9 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	go client.readPump()
 }
 
+// writePump writes queued events to the connection. Every write has a
+// deadline, so a client that stopped reading is disconnected instead of
+// holding its goroutine and queue indefinitely.
 func (c *WSClient) writePump() {
 	ticker := time.NewTicker(time.Duration(constants.WSPingInterval) * time.Second)
 	defer func() {
@@ -306,14 +476,18 @@ func (c *WSClient) writePump() {
 		if err := c.conn.Close(); err != nil {
 			logger.Debug("Error closing WebSocket connection in writePump: %v", err)
 		}
+		if c.done != nil {
+			close(c.done)
+		}
 	}()
 
 	for {
 		select {
 		case event, ok := <-c.send:
+			c.setWriteDeadline()
 			if !ok {
 				// Channel closed, send close message
-				if err := c.conn.WriteMessage(websocket.CloseMessage, []byte{}); err != nil {
+				if err := c.conn.WriteMessage(websocket.CloseMessage, c.closeMsg); err != nil {
 					logger.Debug("Error writing close message: %v", err)
 				}
 				return
@@ -324,6 +498,7 @@ func (c *WSClient) writePump() {
 			}
 
 		case <-ticker.C:
+			c.setWriteDeadline()
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
@@ -331,6 +506,13 @@ func (c *WSClient) writePump() {
 	}
 }
 
+// setWriteDeadline bounds the next write to constants.WSWriteTimeout.
+func (c *WSClient) setWriteDeadline() {
+	if err := c.conn.SetWriteDeadline(time.Now().Add(constants.WSWriteTimeout * time.Second)); err != nil {
+		logger.Debug("Error setting WebSocket write deadline: %v", err)
+	}
+}
+
 func (c *WSClient) readPump() {
 	defer func() {
 		c.hub.unregister <- c
@@ -373,5 +555,6 @@ func (c *WSClient) readPump() {
 		}
 		c.setTopics(topics) // nil means "all topics"
 		logger.Debug("WebSocket client updated topic filter: %v", topics)
+		c.hub.resubscribe <- c
 	}
 }
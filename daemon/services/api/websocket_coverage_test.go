@@ -611,3 +611,27 @@ func TestWebSocketReadLimitEnforced(t *testing.T) {
 		t.Error("Expected connection to be closed after oversized message, but read succeeded")
 	}
 }
+
+func TestWebSocketGoingAwayOnShutdown(t *testing.T) {
+	server, cancel := newTestServerWithHub(t)
+	defer cancel()
+
+	ts := httptest.NewServer(server.router)
+	defer ts.Close()
+
+	ws := dialWS(t, ts)
+	defer ws.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for server.wsHub.ClientCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	server.Stop()
+
+	ws.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err := ws.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Errorf("read error = %v, want a going-away close", err)
+	}
+}
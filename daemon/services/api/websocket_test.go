@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 )
 
@@ -344,3 +345,77 @@ func BenchmarkWSHubBroadcast(b *testing.B) {
 		hub.Broadcast("update", map[string]int{"count": i})
 	}
 }
+
+func TestWSHubDropOldestKeepsSlowClient(t *testing.T) {
+	hub := NewWSHub()
+	hub.SetSlowClientPolicy(constants.WSSlowClientDropOldest, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hub.Run(ctx)
+
+	send := make(chan dto.WSEvent, hub.queueSize)
+	client := &WSClient{hub: hub, send: send}
+	hub.register <- client
+
+	for _, topic := range []string{"first", "second", "third"} {
+		hub.Broadcast(topic, nil)
+	}
+
+	deadline := time.After(time.Second)
+	for hub.Stats().DroppedMessages < 1 {
+		select {
+		case <-deadline:
+			t.Fatal("no event was dropped")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	if got := (<-send).Event; got != "second" {
+		t.Errorf("oldest queued event = %q, want %q", got, "second")
+	}
+	if got := (<-send).Event; got != "third" {
+		t.Errorf("newest queued event = %q, want %q", got, "third")
+	}
+	stats := hub.Stats()
+	if stats.Clients != 1 || stats.EvictedClients != 0 || stats.SlowClientPolicy != constants.WSSlowClientDropOldest {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestWSHubReplaysRecentEvents(t *testing.T) {
+	hub := NewWSHub()
+	hub.SetReplaySource(func() []dto.WSEvent {
+		return []dto.WSEvent{
+			{Event: "system_update", Replay: true},
+			{Event: "array_status_update", Replay: true},
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hub.Run(ctx)
+
+	send := make(chan dto.WSEvent, 4)
+	client := &WSClient{hub: hub, send: send}
+	client.setTopics([]string{"array_status_update"})
+	hub.register <- client
+
+	select {
+	case event := <-send:
+		if event.Event != "array_status_update" || !event.Replay {
+			t.Errorf("replayed %+v, want array_status_update", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event replayed on connect")
+	}
+
+	client.setTopics([]string{"system_update"})
+	hub.resubscribe <- client
+	select {
+	case event := <-send:
+		if event.Event != "system_update" {
+			t.Errorf("replayed %q after subscribing, want system_update", event.Event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event replayed on subscribe")
+	}
+}
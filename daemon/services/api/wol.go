@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// handleWOLDevices godoc
+//
+//	@Summary		List Wake-on-LAN devices
+//	@Description	List the machines configured with WOL_DEVICES that can be woken by name.
+//	@Tags			Network
+//	@Produce		json
+//	@Success		200	{array}	dto.WOLDevice	"Configured devices"
+//	@Router			/network/wol/devices [get]
+func (s *Server) handleWOLDevices(w http.ResponseWriter, _ *http.Request) {
+	devices := s.ctx.WOLDevices
+	if devices == nil {
+		devices = []dto.WOLDevice{}
+	}
+	respondJSON(w, http.StatusOK, devices)
+}
+
+// handleWakeOnLAN godoc
+//
+//	@Summary		Send a Wake-on-LAN packet
+//	@Description	Send a magic packet from the Unraid server to wake a machine on the local network. Give a configured device name (WOL_DEVICES) or a MAC address. The broadcast address defaults to the device's, or 255.255.255.255:9. Success means the packet was sent, not that the machine woke up.
+//	@Tags			Network
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.WOLRequest	true	"Device or MAC address"
+//	@Success		200		{object}	dto.WOLResult	"Packet sent"
+//	@Failure		400		{object}	dto.Response	"Invalid MAC or broadcast address"
+//	@Failure		404		{object}	dto.Response	"Device not configured"
+//	@Router			/network/wol [post]
+func (s *Server) handleWakeOnLAN(w http.ResponseWriter, r *http.Request) {
+	var req dto.WOLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	result, err := lib.Wake(s.ctx.WOLDevices, req)
+	if err != nil {
+		if errors.Is(err, lib.ErrUnknownWOLDevice) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		logger.WarningContext(r.Context(), "API: Wake-on-LAN failed: %v", err)
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	logger.InfoContext(r.Context(), "API: Sent Wake-on-LAN packet to %s via %s", result.MAC, result.Broadcast)
+	respondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestWakeOnLANEndpoints(t *testing.T) {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("udp listen: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	server, ctx := setupTestServer()
+	ctx.WOLDevices = []dto.WOLDevice{{Name: "desktop", MAC: "a8:a1:59:12:34:56", Broadcast: conn.LocalAddr().String()}}
+
+	tests := []struct {
+		name, method, path, body string
+		want                     int
+	}{
+		{"list devices", "GET", "/api/v1/network/wol/devices", "", http.StatusOK},
+		{"wake device", "POST", "/api/v1/network/wol", `{"device":"desktop"}`, http.StatusOK},
+		{"wake by mac", "POST", "/api/v1/network/wol", `{"mac":"00:11:22:33:44:55","broadcast":"` + conn.LocalAddr().String() + `"}`, http.StatusOK},
+		{"unknown device", "POST", "/api/v1/network/wol", `{"device":"laptop"}`, http.StatusNotFound},
+		{"invalid mac", "POST", "/api/v1/network/wol", `{"mac":"nope"}`, http.StatusBadRequest},
+		{"invalid broadcast", "POST", "/api/v1/network/wol", `{"mac":"00:11:22:33:44:55","broadcast":"example.com"}`, http.StatusBadRequest},
+		{"empty body", "POST", "/api/v1/network/wol", `{}`, http.StatusBadRequest},
+		{"invalid json", "POST", "/api/v1/network/wol", `{`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rr.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", rr.Code, tt.want, rr.Body)
+			}
+		})
+	}
+
+	rr := httptest.NewRecorder()
+	server.router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/network/wol", strings.NewReader(`{"device":"desktop"}`)))
+	var result dto.WOLResult
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil || result.Device != "desktop" || result.MAC != "a8:a1:59:12:34:56" {
+		t.Errorf("result = %+v, %v", result, err)
+	}
+}
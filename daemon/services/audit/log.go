@@ -0,0 +1,216 @@
+// Package audit records every state-changing operation received over REST,
+// MCP, or MQTT so operators can answer "who did what, and did it work".
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// LogFileName is the JSON Lines file the audit trail is appended to.
+	LogFileName = "unraid-management-agent-audit.jsonl"
+
+	// MaxEntries is the number of audit entries kept in memory for queries.
+	MaxEntries = 1000
+
+	// DefaultQueryLimit is the number of entries returned when no limit is given.
+	DefaultQueryLimit = 100
+
+	// maxFieldLen bounds free-form fields (target, detail) so a large tool
+	// argument or error message cannot bloat the log.
+	maxFieldLen = 256
+)
+
+// Log is an append-only audit trail. Entries are kept in a bounded in-memory
+// buffer for fast queries and mirrored to a rotated JSON Lines file so the
+// trail survives agent restarts. All methods are safe on a nil *Log, which
+// lets call sites record unconditionally.
+type Log struct {
+	mu        sync.RWMutex
+	entries   []dto.AuditEntry
+	filePath  string
+	writer    io.WriteCloser
+	listeners []func(dto.AuditEntry)
+}
+
+// NewLog creates an audit log persisted under logsDir. An empty logsDir keeps
+// the trail in memory only.
+func NewLog(logsDir string) *Log {
+	l := &Log{entries: make([]dto.AuditEntry, 0)}
+	if logsDir != "" {
+		l.filePath = filepath.Join(logsDir, LogFileName)
+		l.writer = &lumberjack.Logger{
+			Filename:   l.filePath,
+			MaxSize:    5, // 5 MB
+			MaxBackups: 1,
+			MaxAge:     30,
+			Compress:   false,
+		}
+	}
+	return l
+}
+
+// Load seeds the in-memory buffer with the most recent entries from the audit
+// file. A missing file is not an error.
+func (l *Log) Load() error {
+	if l == nil || l.filePath == "" {
+		return nil
+	}
+
+	// #nosec G304 -- path is built from the trusted LogsDir configuration.
+	f, err := os.Open(l.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	var loaded []dto.AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry dto.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // skip torn or corrupt lines
+		}
+		loaded = append(loaded, entry)
+		if len(loaded) > MaxEntries {
+			loaded = loaded[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.entries = append(loaded, l.entries...)
+	l.trimLocked()
+	l.mu.Unlock()
+
+	logger.Info("Audit: loaded %d entries from %s", len(loaded), l.filePath)
+	return nil
+}
+
+// Record appends an entry to the audit trail. A zero Timestamp is set to now.
+func (l *Log) Record(entry dto.AuditEntry) {
+	if l == nil {
+		return
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	entry.Target = truncate(entry.Target)
+	entry.Detail = truncate(entry.Detail)
+
+	l.mu.Lock()
+	l.entries = append(l.entries, entry)
+	l.trimLocked()
+	listeners := l.listeners
+	writer := l.writer
+	if writer != nil {
+		if data, err := json.Marshal(entry); err == nil {
+			if _, err := writer.Write(append(data, '\n')); err != nil {
+				logger.Warning("Audit: failed to persist entry: %v", err)
+			}
+		}
+	}
+	l.mu.Unlock()
+
+	logger.Debug("Audit: %s %s %s target=%q result=%s", entry.Source, entry.Client, entry.Action, entry.Target, entry.Result)
+
+	for _, fn := range listeners {
+		fn(entry)
+	}
+}
+
+// OnRecord registers fn to be called with every recorded entry, after it has
+// been stored. Listeners run on the recording goroutine and must not block.
+func (l *Log) OnRecord(fn func(dto.AuditEntry)) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	l.listeners = append(l.listeners, fn)
+	l.mu.Unlock()
+}
+
+// Query returns entries matching the filter, newest first.
+func (l *Log) Query(filter dto.AuditFilter) []dto.AuditEntry {
+	if l == nil {
+		return []dto.AuditEntry{}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make([]dto.AuditEntry, 0, min(limit, len(l.entries)))
+	for i := len(l.entries) - 1; i >= 0 && len(result) < limit; i-- {
+		if matches(l.entries[i], filter) {
+			result = append(result, l.entries[i])
+		}
+	}
+	return result
+}
+
+// Close flushes and closes the audit file.
+func (l *Log) Close() error {
+	if l == nil || l.writer == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.writer.Close()
+}
+
+// trimLocked drops the oldest entries beyond MaxEntries. Caller must hold the write lock.
+func (l *Log) trimLocked() {
+	if over := len(l.entries) - MaxEntries; over > 0 {
+		l.entries = append([]dto.AuditEntry(nil), l.entries[over:]...)
+	}
+}
+
+// matches reports whether an entry satisfies every non-empty filter field.
+// Action and Target match on a case-insensitive substring.
+func matches(e dto.AuditEntry, f dto.AuditFilter) bool {
+	if f.Source != "" && e.Source != f.Source {
+		return false
+	}
+	if f.Result != "" && e.Result != f.Result {
+		return false
+	}
+	if f.Action != "" && !strings.Contains(strings.ToLower(e.Action), strings.ToLower(f.Action)) {
+		return false
+	}
+	if f.Target != "" && !strings.Contains(strings.ToLower(e.Target), strings.ToLower(f.Target)) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Timestamp.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+func truncate(s string) string {
+	if len(s) <= maxFieldLen {
+		return s
+	}
+	return s[:maxFieldLen] + "…"
+}
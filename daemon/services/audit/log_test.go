@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestNilLogIsSafe(t *testing.T) {
+	var l *Log
+	l.Record(dto.AuditEntry{Action: "noop"})
+	if got := l.Query(dto.AuditFilter{}); len(got) != 0 {
+		t.Fatalf("expected no entries from nil log, got %d", len(got))
+	}
+	if err := l.Load(); err != nil {
+		t.Fatalf("Load on nil log: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close on nil log: %v", err)
+	}
+}
+
+func TestRecordAndQueryNewestFirst(t *testing.T) {
+	l := NewLog("")
+	l.Record(dto.AuditEntry{Source: dto.AuditSourceREST, Action: "POST /api/v1/array/start", Result: dto.AuditResultSuccess})
+	l.Record(dto.AuditEntry{Source: dto.AuditSourceMCP, Action: "container_action", Target: `{"container_id":"plex"}`, Result: dto.AuditResultFailure})
+
+	got := l.Query(dto.AuditFilter{})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(got))
+	}
+	if got[0].Action != "container_action" {
+		t.Errorf("expected newest entry first, got %q", got[0].Action)
+	}
+	if got[0].Timestamp.IsZero() {
+		t.Error("expected timestamp to be set on record")
+	}
+}
+
+func TestQueryFilters(t *testing.T) {
+	l := NewLog("")
+	base := time.Now().Add(-time.Hour)
+	l.Record(dto.AuditEntry{Timestamp: base, Source: dto.AuditSourceREST, Action: "POST /api/v1/docker/{id}/stop", Target: "plex", Result: dto.AuditResultSuccess})
+	l.Record(dto.AuditEntry{Timestamp: base.Add(30 * time.Minute), Source: dto.AuditSourceMQTT, Action: "docker/restart", Target: "sonarr", Result: dto.AuditResultFailure})
+	l.Record(dto.AuditEntry{Timestamp: base.Add(50 * time.Minute), Source: dto.AuditSourceMCP, Action: "vm_action", Target: `{"vm_name":"Windows"}`, Result: dto.AuditResultDenied})
+
+	tests := []struct {
+		name   string
+		filter dto.AuditFilter
+		want   int
+	}{
+		{"no filter", dto.AuditFilter{}, 3},
+		{"by source", dto.AuditFilter{Source: dto.AuditSourceMQTT}, 1},
+		{"by result", dto.AuditFilter{Result: dto.AuditResultDenied}, 1},
+		{"action substring case-insensitive", dto.AuditFilter{Action: "DOCKER"}, 2},
+		{"by target", dto.AuditFilter{Target: "windows"}, 1},
+		{"since", dto.AuditFilter{Since: base.Add(20 * time.Minute)}, 2},
+		{"limit", dto.AuditFilter{Limit: 1}, 1},
+		{"no match", dto.AuditFilter{Source: dto.AuditSourceREST, Result: dto.AuditResultFailure}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.Query(tt.filter); len(got) != tt.want {
+				t.Errorf("got %d entries, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordTrimsToMaxEntries(t *testing.T) {
+	l := NewLog("")
+	for i := 0; i < MaxEntries+10; i++ {
+		l.Record(dto.AuditEntry{Action: "a"})
+	}
+	if got := l.Query(dto.AuditFilter{Limit: MaxEntries * 2}); len(got) != MaxEntries {
+		t.Fatalf("expected %d entries, got %d", MaxEntries, len(got))
+	}
+}
+
+func TestRecordTruncatesLongFields(t *testing.T) {
+	l := NewLog("")
+	l.Record(dto.AuditEntry{Action: "a", Target: strings.Repeat("x", 1000)})
+	got := l.Query(dto.AuditFilter{})
+	if len(got[0].Target) > maxFieldLen+len("…") {
+		t.Errorf("target not truncated: %d bytes", len(got[0].Target))
+	}
+}
+
+func TestPersistAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLog(dir)
+	l.Record(dto.AuditEntry{Source: dto.AuditSourceREST, Action: "POST /api/v1/array/stop", Result: dto.AuditResultSuccess})
+	l.Record(dto.AuditEntry{Source: dto.AuditSourceMQTT, Action: "system/reboot", Result: dto.AuditResultFailure})
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reloaded := NewLog(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got := reloaded.Query(dto.AuditFilter{})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 persisted entries, got %d", len(got))
+	}
+	if got[0].Action != "system/reboot" {
+		t.Errorf("expected newest persisted entry first, got %q", got[0].Action)
+	}
+	_ = reloaded.Close()
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	l := NewLog(t.TempDir())
+	if err := l.Load(); err != nil {
+		t.Fatalf("Load should not fail on missing file: %v", err)
+	}
+}
+
+func TestOnRecordNotifiesListeners(t *testing.T) {
+	l := NewLog("")
+	var got []dto.AuditEntry
+	l.OnRecord(func(e dto.AuditEntry) { got = append(got, e) })
+	l.Record(dto.AuditEntry{Source: dto.AuditSourceMQTT, Action: "docker/restart", Target: "plex", Result: dto.AuditResultSuccess})
+
+	if len(got) != 1 || got[0].Action != "docker/restart" || got[0].Timestamp.IsZero() {
+		t.Fatalf("listener got %+v, want the recorded entry with a timestamp", got)
+	}
+}
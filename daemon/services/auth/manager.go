@@ -0,0 +1,330 @@
+// Package auth implements password login for the REST API with short-lived
+// JWT access tokens and rotating refresh tokens.
+package auth
+
+import (
+	"cmp"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DefaultAccessTTL is how long an access token is valid, in minutes.
+	DefaultAccessTTL = 15
+	// DefaultRefreshTTL is how long a refresh token is valid, in hours.
+	DefaultRefreshTTL = 168
+
+	// LoginUser is the only account that can log in: Unraid's administrator.
+	LoginUser = "root"
+
+	issuer = "unraid-management-agent"
+
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+
+	// maxFailures failed logins from one client lock it out for lockout.
+	maxFailures = 5
+	lockout     = 5 * time.Minute
+
+	// maxSessions caps live sessions; the oldest is dropped beyond it.
+	maxSessions = 64
+)
+
+var (
+	// ErrInvalidToken is returned for a malformed, expired, revoked or
+	// wrong-type token.
+	ErrInvalidToken = errors.New("invalid or expired token")
+
+	// ErrTooManyAttempts is returned while a client is locked out after
+	// repeated failed logins.
+	ErrTooManyAttempts = errors.New("too many failed login attempts; try again later")
+)
+
+// Claims are the JWT claims of access and refresh tokens.
+type Claims struct {
+	jwt.RegisteredClaims
+	Type      string `json:"typ"`
+	SessionID string `json:"sid"`
+}
+
+// session is a login; its current refresh token ID rotates on every refresh.
+type session struct {
+	username  string
+	refreshID string
+	created   time.Time
+	expires   time.Time
+}
+
+// failure counts a client's failed logins; the count restarts when the last
+// failure is older than lockout. pending counts its logins whose password is
+// being checked.
+type failure struct {
+	count   int
+	pending int
+	last    time.Time
+	until   time.Time
+}
+
+// Manager issues and verifies tokens. The signing key is generated at start,
+// so restarting the agent ends every session.
+type Manager struct {
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	shadowPath string
+	key        []byte
+	now        func() time.Time
+	// verify checks a password against the shadow file; tests replace it.
+	verify func(shadowPath, username, password string) error
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	failures map[string]*failure
+}
+
+// NewManager creates a manager that checks passwords against the shadow file
+// at shadowPath (empty means /etc/shadow).
+func NewManager(cfg domain.AuthConfig, shadowPath string) (*Manager, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating token signing key: %w", err)
+	}
+	return &Manager{
+		accessTTL:  time.Duration(cmp.Or(cfg.AccessTTL, DefaultAccessTTL)) * time.Minute,
+		refreshTTL: time.Duration(cmp.Or(cfg.RefreshTTL, DefaultRefreshTTL)) * time.Hour,
+		shadowPath: cmp.Or(shadowPath, constants.ShadowFile),
+		key:        key,
+		now:        time.Now,
+		verify:     VerifyShadowPassword,
+		sessions:   make(map[string]*session),
+		failures:   make(map[string]*failure),
+	}, nil
+}
+
+// Login checks username and password and starts a session. client identifies
+// the caller for the failed-login lockout.
+func (m *Manager) Login(client, username, password string) (*dto.AuthTokens, error) {
+	now := m.now()
+	if err := m.reserveAttempt(client, now); err != nil {
+		return nil, err
+	}
+
+	err := ErrInvalidCredentials
+	if username == LoginUser {
+		err = m.verify(m.shadowPath, username, password)
+	}
+	m.finishAttempt(client, now, err)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruneLocked(now)
+	sess := &session{username: username, refreshID: newID(), created: now, expires: now.Add(m.refreshTTL)}
+	sid := newID()
+	m.sessions[sid] = sess
+	return m.issueLocked(sid, sess, now)
+}
+
+// Refresh exchanges a refresh token for a new token pair. A refresh token
+// works once; presenting an already used one ends the session, since it
+// means the token was copied.
+func (m *Manager) Refresh(refreshToken string) (*dto.AuthTokens, error) {
+	claims, err := m.parse(refreshToken, tokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sess := m.sessions[claims.SessionID]
+	if sess == nil || now.After(sess.expires) {
+		return nil, ErrInvalidToken
+	}
+	if claims.ID != sess.refreshID {
+		delete(m.sessions, claims.SessionID)
+		logger.Warning("Auth: refresh token reused for session %s; session revoked", claims.SessionID)
+		return nil, ErrInvalidToken
+	}
+	sess.refreshID = newID()
+	return m.issueLocked(claims.SessionID, sess, now)
+}
+
+// Logout ends the session of an access or refresh token.
+func (m *Manager) Logout(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, sessionID)
+}
+
+// Authenticate verifies an access token and that its session is still live.
+func (m *Manager) Authenticate(accessToken string) (*Claims, error) {
+	claims, err := m.parse(accessToken, tokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions[claims.SessionID] == nil {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (m *Manager) issueLocked(sid string, sess *session, now time.Time) (*dto.AuthTokens, error) {
+	accessExpires := now.Add(m.accessTTL)
+	if accessExpires.After(sess.expires) {
+		accessExpires = sess.expires
+	}
+	access, err := m.sign(Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   sess.username,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(accessExpires),
+			ID:        newID(),
+		},
+		Type:      tokenTypeAccess,
+		SessionID: sid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := m.sign(Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   sess.username,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(sess.expires),
+			ID:        sess.refreshID,
+		},
+		Type:      tokenTypeRefresh,
+		SessionID: sid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AuthTokens{
+		AccessToken:      access,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(accessExpires.Sub(now).Seconds()),
+		ExpiresAt:        accessExpires,
+		RefreshToken:     refresh,
+		RefreshExpiresAt: sess.expires,
+		Username:         sess.username,
+	}, nil
+}
+
+func (m *Manager) sign(claims Claims) (string, error) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.key)
+	if err != nil {
+		return "", fmt.Errorf("signing token: %w", err)
+	}
+	return token, nil
+}
+
+func (m *Manager) parse(token, tokenType string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return m.key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	)
+	if err != nil || claims.Type != tokenType || claims.SessionID == "" {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// reserveAttempt counts a login from client before its password is checked.
+// Logins still being checked count as failures, so concurrent attempts
+// cannot all pass the lockout check before the first failure is recorded.
+func (m *Manager) reserveAttempt(client string, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f := m.failures[client]
+	if f == nil || (f.pending == 0 && now.Sub(f.last) > lockout) {
+		f = &failure{}
+		m.failures[client] = f
+	}
+	if now.Before(f.until) || f.count+f.pending >= maxFailures {
+		return ErrTooManyAttempts
+	}
+	f.pending++
+	return nil
+}
+
+// finishAttempt releases a login reserved by reserveAttempt with the result
+// of its password check: a wrong password counts as a failure, a success
+// clears the client's failures, and other errors count as neither.
+func (m *Manager) finishAttempt(client string, now time.Time, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f := m.failures[client]
+	if f == nil {
+		f = &failure{}
+		m.failures[client] = f
+	}
+	if f.pending > 0 {
+		f.pending--
+	}
+	switch {
+	case err == nil:
+		f.count, f.until = 0, time.Time{}
+	case errors.Is(err, ErrInvalidCredentials):
+		f.count++
+		f.last = now
+		if f.count >= maxFailures {
+			f.until = now.Add(lockout)
+			logger.Warning("Auth: %d failed logins from %s; locked out for %s", f.count, client, lockout)
+		}
+	}
+	if f.count == 0 && f.pending == 0 {
+		delete(m.failures, client)
+	}
+}
+
+// pruneLocked drops expired sessions and, beyond maxSessions, the oldest.
+func (m *Manager) pruneLocked(now time.Time) {
+	for sid, sess := range m.sessions {
+		if now.After(sess.expires) {
+			delete(m.sessions, sid)
+		}
+	}
+	for len(m.sessions) >= maxSessions {
+		oldest := ""
+		for sid, sess := range m.sessions {
+			if oldest == "" || sess.created.Before(m.sessions[oldest].created) {
+				oldest = sid
+			}
+		}
+		delete(m.sessions, oldest)
+	}
+	for client, f := range m.failures {
+		if now.Sub(f.last) > lockout && now.After(f.until) {
+			delete(m.failures, client)
+		}
+	}
+}
+
+// newID returns a random 128-bit identifier.
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
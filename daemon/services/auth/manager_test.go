@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+)
+
+func newTestManager(t *testing.T) (*Manager, *time.Time) {
+	t.Helper()
+	m, err := NewManager(domain.AuthConfig{}, writeShadow(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func TestLoginRefreshLogout(t *testing.T) {
+	m, now := newTestManager(t)
+
+	tokens, err := m.Login("10.0.0.2", "root", "hunter2")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if tokens.TokenType != "Bearer" || tokens.ExpiresIn != 900 || tokens.Username != "root" {
+		t.Errorf("tokens = %+v", tokens)
+	}
+	claims, err := m.Authenticate(tokens.AccessToken)
+	if err != nil || claims.Subject != "root" {
+		t.Fatalf("Authenticate: %v, %v", claims, err)
+	}
+	if _, err := m.Authenticate(tokens.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("refresh token accepted as access token: %v", err)
+	}
+
+	*now = now.Add(16 * time.Minute)
+	if _, err := m.Authenticate(tokens.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired access token: %v", err)
+	}
+
+	refreshed, err := m.Refresh(tokens.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if _, err := m.Authenticate(refreshed.AccessToken); err != nil {
+		t.Errorf("refreshed access token: %v", err)
+	}
+
+	m.Logout(claims.SessionID)
+	if _, err := m.Authenticate(refreshed.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("access token after logout: %v", err)
+	}
+	if _, err := m.Refresh(refreshed.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("refresh after logout: %v", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesSession(t *testing.T) {
+	m, _ := newTestManager(t)
+	tokens, err := m.Login("10.0.0.2", "root", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, err := m.Refresh(tokens.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Refresh(tokens.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("reused refresh token: %v", err)
+	}
+	if _, err := m.Authenticate(refreshed.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("session still live after refresh token reuse: %v", err)
+	}
+}
+
+func TestSessionExpiry(t *testing.T) {
+	m, now := newTestManager(t)
+	tokens, err := m.Login("10.0.0.2", "root", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(DefaultRefreshTTL*time.Hour + time.Minute)
+	if _, err := m.Refresh(tokens.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired refresh token: %v", err)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	m, now := newTestManager(t)
+
+	if _, err := m.Login("10.0.0.3", "admin", "hunter2"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("non-root login: %v", err)
+	}
+	for range maxFailures - 1 {
+		if _, err := m.Login("10.0.0.3", "root", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("wrong password: %v", err)
+		}
+	}
+	if _, err := m.Login("10.0.0.3", "root", "hunter2"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Errorf("login while locked out: %v", err)
+	}
+	if _, err := m.Login("10.0.0.4", "root", "hunter2"); err != nil {
+		t.Errorf("other client locked out: %v", err)
+	}
+
+	*now = now.Add(lockout + time.Second)
+	if _, err := m.Login("10.0.0.3", "root", "hunter2"); err != nil {
+		t.Errorf("login after lockout: %v", err)
+	}
+}
+
+func TestLoginLockoutConcurrent(t *testing.T) {
+	m, _ := newTestManager(t)
+	verify := m.verify
+	m.verify = func(shadowPath, username, password string) error {
+		time.Sleep(20 * time.Millisecond) // keep the checks overlapping
+		return verify(shadowPath, username, password)
+	}
+
+	// Attempts running at the same time count against the limit before
+	// their passwords have been checked
+	const attempts = 4 * maxFailures
+	var (
+		wg              sync.WaitGroup
+		mu              sync.Mutex
+		checked, locked int
+	)
+	start := make(chan struct{})
+	for range attempts {
+		wg.Go(func() {
+			<-start
+			_, err := m.Login("10.0.0.5", "root", "wrong")
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, ErrInvalidCredentials):
+				checked++
+			case errors.Is(err, ErrTooManyAttempts):
+				locked++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+	close(start)
+	wg.Wait()
+
+	if checked > maxFailures || checked+locked != attempts {
+		t.Errorf("%d passwords checked and %d attempts refused, want at most %d checked", checked, locked, maxFailures)
+	}
+	if _, err := m.Login("10.0.0.5", "root", "hunter2"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Errorf("login after concurrent failures: %v", err)
+	}
+}
+
+func TestTokensFromAnotherManagerRejected(t *testing.T) {
+	m, _ := newTestManager(t)
+	other, _ := newTestManager(t)
+	tokens, err := other.Login("10.0.0.2", "root", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Authenticate(tokens.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign token accepted: %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidCredentials is returned for an unknown user, a wrong
+	// password, or an account that is locked or has no password.
+	ErrInvalidCredentials = errors.New("invalid username or password")
+
+	// ErrUnsupportedHash is returned when the account's password hash uses a
+	// scheme other than SHA-256-crypt ($5$) or SHA-512-crypt ($6$).
+	ErrUnsupportedHash = errors.New("unsupported password hash scheme")
+)
+
+// shaCrypt rounds, as defined by the SHA-crypt specification.
+const (
+	shaCryptDefaultRounds = 5000
+	shaCryptMinRounds     = 1000
+	shaCryptMaxRounds     = 999999999
+	shaCryptMaxSalt       = 16
+)
+
+// VerifyShadowPassword checks password against user's hash in the shadow
+// file at path.
+func VerifyShadowPassword(path, user, password string) error {
+	stored, err := shadowHash(path, user)
+	if err != nil {
+		return err
+	}
+	// Locked ("!", "*") and empty hashes never match.
+	if !strings.HasPrefix(stored, "$") {
+		return ErrInvalidCredentials
+	}
+	computed, err := shaCrypt(password, stored)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(computed), []byte(stored)) != 1 {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// shadowHash returns the password field of user's entry in the shadow file.
+func shadowHash(path, user string) (string, error) {
+	f, err := os.Open(path) // #nosec G304 -- fixed system path, overridable in tests
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) >= 2 && fields[0] == user {
+			return fields[1], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return "", ErrInvalidCredentials
+}
+
+// shaCrypt hashes password with the SHA-crypt scheme, rounds and salt of
+// setting ("$5$[rounds=N$]salt[$hash]" or "$6$..."), returning the full
+// crypt(3) string.
+func shaCrypt(password, setting string) (string, error) {
+	var newHash func() hash.Hash
+	var order [][3]int
+	var id string
+	switch {
+	case strings.HasPrefix(setting, "$5$"):
+		newHash, order, id = sha256.New, sha256CryptOrder, "$5$"
+	case strings.HasPrefix(setting, "$6$"):
+		newHash, order, id = sha512.New, sha512CryptOrder, "$6$"
+	default:
+		return "", ErrUnsupportedHash
+	}
+
+	rest := setting[len(id):]
+	rounds, customRounds := shaCryptDefaultRounds, false
+	if r, ok := strings.CutPrefix(rest, "rounds="); ok {
+		n, after, found := strings.Cut(r, "$")
+		value, err := strconv.ParseUint(n, 10, 32)
+		if !found || err != nil {
+			return "", ErrUnsupportedHash
+		}
+		rounds = min(max(int(value), shaCryptMinRounds), shaCryptMaxRounds)
+		customRounds, rest = true, after
+	}
+	salt, _, _ := strings.Cut(rest, "$")
+	if len(salt) > shaCryptMaxSalt {
+		salt = salt[:shaCryptMaxSalt]
+	}
+	pw, sb := []byte(password), []byte(salt)
+
+	h := newHash()
+	h.Write(pw)
+	h.Write(sb)
+	h.Write(pw)
+	b := h.Sum(nil)
+
+	h.Reset()
+	h.Write(pw)
+	h.Write(sb)
+	h.Write(repeatTo(b, len(pw)))
+	for n := len(pw); n > 0; n >>= 1 {
+		if n&1 != 0 {
+			h.Write(b)
+		} else {
+			h.Write(pw)
+		}
+	}
+	a := h.Sum(nil)
+
+	h.Reset()
+	for range len(pw) {
+		h.Write(pw)
+	}
+	p := repeatTo(h.Sum(nil), len(pw))
+
+	h.Reset()
+	for range 16 + int(a[0]) {
+		h.Write(sb)
+	}
+	s := repeatTo(h.Sum(nil), len(sb))
+
+	c := a
+	for i := range rounds {
+		h.Reset()
+		if i%2 != 0 {
+			h.Write(p)
+		} else {
+			h.Write(c)
+		}
+		if i%3 != 0 {
+			h.Write(s)
+		}
+		if i%7 != 0 {
+			h.Write(p)
+		}
+		if i%2 != 0 {
+			h.Write(c)
+		} else {
+			h.Write(p)
+		}
+		c = h.Sum(nil)
+	}
+
+	var out strings.Builder
+	out.WriteString(id)
+	if customRounds {
+		fmt.Fprintf(&out, "rounds=%d$", rounds)
+	}
+	out.WriteString(salt)
+	out.WriteByte('$')
+	for i, g := range order {
+		n := 4
+		if i == len(order)-1 {
+			n = 2
+			if len(c) == sha256.Size {
+				n = 3
+			}
+		}
+		encode24(&out, byteAt(c, g[0]), byteAt(c, g[1]), byteAt(c, g[2]), n)
+	}
+	return out.String(), nil
+}
+
+// Byte orders of the final encoding; -1 stands for a zero byte.
+var (
+	sha256CryptOrder = [][3]int{
+		{0, 10, 20}, {21, 1, 11}, {12, 22, 2}, {3, 13, 23}, {24, 4, 14},
+		{15, 25, 5}, {6, 16, 26}, {27, 7, 17}, {18, 28, 8}, {9, 19, 29},
+		{-1, 31, 30},
+	}
+	sha512CryptOrder = [][3]int{
+		{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4},
+		{47, 5, 26}, {6, 27, 48}, {28, 49, 7}, {50, 8, 29}, {9, 30, 51},
+		{31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13}, {56, 14, 35},
+		{15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19},
+		{62, 20, 41}, {-1, -1, 63},
+	}
+)
+
+const cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+func encode24(out *strings.Builder, b2, b1, b0 byte, n int) {
+	w := uint(b2)<<16 | uint(b1)<<8 | uint(b0)
+	for range n {
+		out.WriteByte(cryptAlphabet[w&0x3f])
+		w >>= 6
+	}
+}
+
+func byteAt(b []byte, i int) byte {
+	if i < 0 {
+		return 0
+	}
+	return b[i]
+}
+
+// repeatTo returns b repeated to exactly n bytes.
+func repeatTo(b []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		out = append(out, b[:min(len(b), n-len(out))]...)
+	}
+	return out
+}
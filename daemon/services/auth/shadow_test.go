@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShaCrypt(t *testing.T) {
+	tests := []struct {
+		password, setting, want string
+	}{
+		{"Hello world!", "$5$saltstring", "$5$saltstring$5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5"},
+		{"Hello world!", "$5$rounds=10000$saltstringsaltstring", "$5$rounds=10000$saltstringsaltst$3xv.VbSHBb41AL9AvLeujZkZRBAwqFMz2.opqey6IcA"},
+		{"Hello world!", "$6$saltstring", "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"},
+		{"Hello world!", "$6$rounds=10000$saltstringsaltstring", "$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v."},
+		{"", "$6$xyz", "$6$xyz$jdHJneX6eYV7DP95OgtnE1J5bHq1yUumSdLXo09t/VEMjhG5QzsLETLqiWjslhYH9CihD0nXyST9wmd80QDa9/"},
+	}
+	for _, tt := range tests {
+		got, err := shaCrypt(tt.password, tt.setting)
+		if err != nil || got != tt.want {
+			t.Errorf("shaCrypt(%q, %q) = %q, %v; want %q", tt.password, tt.setting, got, err, tt.want)
+		}
+	}
+
+	for _, setting := range []string{"$y$j9T$salt$hash", "$1$salt$hash", "$6$rounds=x$salt"} {
+		if _, err := shaCrypt("pw", setting); !errors.Is(err, ErrUnsupportedHash) {
+			t.Errorf("shaCrypt(%q) error = %v, want ErrUnsupportedHash", setting, err)
+		}
+	}
+}
+
+// writeShadow writes a shadow file where root's password is "hunter2".
+func writeShadow(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "shadow")
+	content := "root:$6$testsalt$ehrqOiQRn2f7nCvA/LgwTY1odMW9hjQ/GS8KC7ztGNzzC8hmzy8/g/pV7Ryg5gmQx7Wa1u13rOGLJIS5QQGcQ/:19000:0:99999:7:::\n" +
+		"nobody:!:19000:0:99999:7:::\n" +
+		"empty::19000:0:99999:7:::\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerifyShadowPassword(t *testing.T) {
+	path := writeShadow(t)
+	tests := []struct {
+		user, password string
+		want           error
+	}{
+		{"root", "hunter2", nil},
+		{"root", "hunter3", ErrInvalidCredentials},
+		{"nobody", "", ErrInvalidCredentials},
+		{"empty", "", ErrInvalidCredentials},
+		{"missing", "hunter2", ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		if err := VerifyShadowPassword(path, tt.user, tt.password); !errors.Is(err, tt.want) {
+			t.Errorf("VerifyShadowPassword(%q, %q) = %v, want %v", tt.user, tt.password, err, tt.want)
+		}
+	}
+	if err := VerifyShadowPassword(filepath.Join(t.TempDir(), "none"), "root", "x"); err == nil {
+		t.Error("missing shadow file: want error")
+	}
+}
@@ -0,0 +1,156 @@
+package bootseq
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxKeySize caps a keyfile read from disk or fetched over HTTPS; LUKS
+	// keyfiles are at most 8 MiB.
+	maxKeySize = 8 << 20
+
+	// dialTimeout bounds each connection attempt to the network host.
+	dialTimeout = 3 * time.Second
+
+	// keyFetchTimeout bounds fetching the key from the key URL.
+	keyFetchTimeout = 30 * time.Second
+)
+
+// procNetRoute lists the IPv4 routes.
+var procNetRoute = "/proc/net/route"
+
+// checkNetwork returns an error until an IPv4 default route exists and, when
+// host is set, host accepts a TCP connection.
+func checkNetwork(ctx context.Context, host string) error {
+	ok, err := hasDefaultRoute(procNetRoute)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("no default route")
+	}
+	if host == "" {
+		return nil
+	}
+	dialer := net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// hasDefaultRoute reports whether the route table at path has an up default
+// route.
+func hasDefaultRoute(path string) (bool, error) {
+	file, err := os.Open(path) //nolint:gosec // G304: path is the /proc route table
+	if err != nil {
+		return false, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		// Iface Destination Gateway Flags ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[1] != "00000000" {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err == nil && flags&0x1 != 0 { // RTF_UP
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// splitHostPort splits a host:port address and parses the port.
+func splitHostPort(addr string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 || host == "" {
+		return "", 0, fmt.Errorf("invalid address %q", addr)
+	}
+	return host, port, nil
+}
+
+// validateKeyURL checks that the key URL is an absolute HTTPS URL, so the
+// key never crosses the network in the clear.
+func validateKeyURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("key_url must be an https:// URL")
+	}
+	return nil
+}
+
+// fetchKey downloads the encryption key from an HTTPS URL.
+func fetchKey(ctx context.Context, rawURL string) ([]byte, error) {
+	if err := validateKeyURL(rawURL); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, keyFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req) //nolint:gosec // G107: URL is validated as HTTPS
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("key URL returned HTTP %d", resp.StatusCode)
+	}
+	key, err := io.ReadAll(io.LimitReader(resp.Body, maxKeySize+1))
+	if err != nil {
+		return nil, err
+	}
+	return checkKey(key)
+}
+
+// readKeyFile reads the encryption key from a file, e.g. on a USB stick
+// that is only plugged in while booting.
+func readKeyFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxKeySize {
+		return nil, fmt.Errorf("keyfile is larger than %d bytes", maxKeySize)
+	}
+	key, err := os.ReadFile(path) //nolint:gosec // G304: path is the configured keyfile
+	if err != nil {
+		return nil, err
+	}
+	return checkKey(key)
+}
+
+// checkKey rejects empty and oversized keys.
+func checkKey(key []byte) ([]byte, error) {
+	switch {
+	case len(key) == 0:
+		return nil, errors.New("the key is empty")
+	case len(key) > maxKeySize:
+		return nil, fmt.Errorf("the key is larger than %d bytes", maxKeySize)
+	}
+	return key, nil
+}
@@ -0,0 +1,530 @@
+// Package bootseq runs the boot sequence: after the server boots it waits
+// for the network and UPS, unlocks and starts the array, and then starts the
+// selected containers and VMs in order.
+package bootseq
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+const (
+	// DefaultConfigDir is the default directory for the configuration.
+	DefaultConfigDir = "/boot/config/plugins/unraid-management-agent"
+
+	// ConfigFile is the filename of the persisted configuration.
+	ConfigFile = "boot_sequence.json"
+
+	// MaxBootAge is how long after boot the agent may start and still run
+	// the sequence; a later start is an agent restart, not a boot.
+	MaxBootAge = 15 * time.Minute
+
+	// DefaultStepTimeout bounds each waiting step when none is configured.
+	DefaultStepTimeout = 5 * time.Minute
+
+	// maxStepTimeout caps the configured step timeout.
+	maxStepTimeout = time.Hour
+
+	// maxStartDelay caps the pause between guest starts.
+	maxStartDelay = 10 * time.Minute
+
+	// TriggerBoot and TriggerManual record why the sequence ran.
+	TriggerBoot   = "boot"
+	TriggerManual = "manual"
+)
+
+// ErrRunning is returned when the sequence is started while it is running.
+var ErrRunning = errors.New("the boot sequence is already running")
+
+// pollInterval is how often waiting steps re-check their condition.
+var pollInterval = 2 * time.Second
+
+// Runner holds the boot sequence configuration and runs the sequence. The
+// function fields reach the system and are replaced in tests.
+type Runner struct {
+	mu       sync.Mutex
+	filePath string
+	config   dto.BootSequenceConfig
+	status   dto.BootSequenceStatus
+	running  bool
+
+	ups            func() *dto.UPSStatus
+	uptime         func() (time.Duration, error)
+	networkReady   func(ctx context.Context, host string) error
+	arrayState     func() string
+	arrayEncrypted func() bool
+	startArray     func() error
+	unlockArray    func(key []byte) error
+	fetchKey       func(ctx context.Context, rawURL string) ([]byte, error)
+	startContainer func(name string) error
+	startVM        func(name string) error
+	sleep          func(ctx context.Context, d time.Duration)
+}
+
+// NewRunner creates a runner that persists its configuration in configDir
+// (DefaultConfigDir if empty). ups returns the latest UPS status, or nil
+// before the UPS collector has reported one.
+func NewRunner(ctx *domain.Context, configDir string, ups func() *dto.UPSStatus) *Runner {
+	if configDir == "" {
+		configDir = DefaultConfigDir
+	}
+	array := controllers.NewArrayController(ctx)
+	return &Runner{
+		filePath:       filepath.Join(configDir, ConfigFile),
+		ups:            ups,
+		uptime:         readUptime,
+		networkReady:   checkNetwork,
+		arrayState:     controllers.ArrayState,
+		arrayEncrypted: controllers.ArrayEncrypted,
+		startArray:     array.StartArray,
+		unlockArray:    func(key []byte) error { return array.UnlockAndStartArray(key, false) },
+		fetchKey:       fetchKey,
+		startContainer: startContainer,
+		startVM:        func(name string) error { return controllers.NewVMController().Start(name) },
+		sleep:          sleepContext,
+	}
+}
+
+// Load reads the persisted configuration. A missing file leaves the
+// sequence disabled.
+func (r *Runner) Load() error {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading boot sequence config: %w", err)
+	}
+	var cfg dto.BootSequenceConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("parsing boot sequence config: %w", err)
+	}
+	r.mu.Lock()
+	r.config = cfg
+	r.mu.Unlock()
+	return nil
+}
+
+// SetConfig validates, stores and persists cfg. It applies from the next run.
+func (r *Runner) SetConfig(cfg dto.BootSequenceConfig) (dto.BootSequenceStatus, error) {
+	if err := Validate(cfg); err != nil {
+		return dto.BootSequenceStatus{}, err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return dto.BootSequenceStatus{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		return dto.BootSequenceStatus{}, fmt.Errorf("saving boot sequence config: %w", err)
+	}
+	// The file may name a key source, so keep it private.
+	if err := os.WriteFile(r.filePath, data, 0o600); err != nil {
+		return dto.BootSequenceStatus{}, fmt.Errorf("saving boot sequence config: %w", err)
+	}
+
+	r.mu.Lock()
+	r.config = cfg
+	r.mu.Unlock()
+	logger.Info("Boot sequence: Configuration updated (enabled: %v)", cfg.Enabled)
+	return r.Status(), nil
+}
+
+// Status returns the configuration and the progress of the last run.
+func (r *Runner) Status() dto.BootSequenceStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := r.status
+	status.Config = r.config
+	status.Steps = append([]dto.BootSequenceStep{}, r.status.Steps...)
+	status.Timestamp = time.Now()
+	return status
+}
+
+// RunAtBoot runs the sequence when it is enabled and the agent started
+// within MaxBootAge of the server booting. It blocks until the sequence
+// finishes or ctx is cancelled.
+func (r *Runner) RunAtBoot(ctx context.Context) {
+	r.mu.Lock()
+	cfg := r.config
+	r.mu.Unlock()
+	if !cfg.Enabled {
+		return
+	}
+
+	uptime, err := r.uptime()
+	if err != nil || uptime > MaxBootAge {
+		msg := fmt.Sprintf("agent started %d minutes after boot", int(uptime.Minutes()))
+		if err != nil {
+			msg = fmt.Sprintf("uptime unknown: %v", err)
+		}
+		logger.Info("Boot sequence: Skipped, %s", msg)
+		r.mu.Lock()
+		now := time.Now()
+		r.status = dto.BootSequenceStatus{State: dto.BootStateSkipped, Message: msg, Trigger: TriggerBoot, FinishedAt: &now}
+		r.mu.Unlock()
+		return
+	}
+
+	if err := r.begin(cfg, TriggerBoot); err != nil {
+		return
+	}
+	r.run(ctx, cfg)
+}
+
+// Start runs the sequence now in the background, whether or not it is
+// enabled, as after a boot.
+func (r *Runner) Start(ctx context.Context) (dto.BootSequenceStatus, error) {
+	r.mu.Lock()
+	cfg := r.config
+	r.mu.Unlock()
+	if err := r.begin(cfg, TriggerManual); err != nil {
+		return dto.BootSequenceStatus{}, err
+	}
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.LogPanicWithStack("Boot sequence", rec)
+			}
+		}()
+		r.run(ctx, cfg)
+	}()
+	return r.Status(), nil
+}
+
+// begin marks the sequence running and resets the step progress.
+func (r *Runner) begin(cfg dto.BootSequenceConfig, trigger string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		return ErrRunning
+	}
+	r.running = true
+
+	now := time.Now()
+	steps := make([]dto.BootSequenceStep, 0, 5)
+	for _, s := range []struct {
+		name       string
+		configured bool
+	}{
+		{dto.BootStepNetwork, cfg.WaitNetwork},
+		{dto.BootStepUPS, cfg.WaitUPS},
+		{dto.BootStepArray, cfg.StartArray},
+		{dto.BootStepContainers, len(cfg.Containers) > 0},
+		{dto.BootStepVMs, len(cfg.VMs) > 0},
+	} {
+		state := dto.BootStatePending
+		if !s.configured {
+			state = dto.BootStateSkipped
+		}
+		steps = append(steps, dto.BootSequenceStep{Name: s.name, State: state})
+	}
+	r.status = dto.BootSequenceStatus{State: dto.BootStateRunning, Trigger: trigger, StartedAt: &now, Steps: steps}
+	return nil
+}
+
+// run runs the configured steps in order. A failing precondition or array
+// start stops the sequence; guests that fail to start are reported as a
+// warning and the sequence continues.
+func (r *Runner) run(ctx context.Context, cfg dto.BootSequenceConfig) {
+	logger.Info("Boot sequence: Started")
+	timeout := stepTimeout(cfg)
+	steps := []struct {
+		name     string
+		run      func(ctx context.Context, report func(string)) (string, error)
+		critical bool
+	}{
+		{dto.BootStepNetwork, func(ctx context.Context, report func(string)) (string, error) {
+			return "network ready", r.waitFor(ctx, timeout, func() error { return r.networkReady(ctx, cfg.NetworkHost) })
+		}, true},
+		{dto.BootStepUPS, func(ctx context.Context, report func(string)) (string, error) {
+			return "UPS on line power", r.waitFor(ctx, timeout, func() error { return upsReady(r.ups(), cfg.MinBatteryPercent) })
+		}, true},
+		{dto.BootStepArray, func(ctx context.Context, report func(string)) (string, error) {
+			return r.runArray(ctx, cfg, timeout, report)
+		}, true},
+		{dto.BootStepContainers, func(ctx context.Context, report func(string)) (string, error) {
+			return r.startGuests(ctx, "container", cfg.Containers, r.startContainer, cfg, timeout, report)
+		}, false},
+		{dto.BootStepVMs, func(ctx context.Context, report func(string)) (string, error) {
+			return r.startGuests(ctx, "VM", cfg.VMs, r.startVM, cfg, timeout, report)
+		}, false},
+	}
+
+	state, message := dto.BootStateDone, "boot sequence completed"
+	for i, step := range steps {
+		if r.stepState(i) == dto.BootStateSkipped {
+			continue
+		}
+		report := func(msg string) {
+			logger.Info("Boot sequence: %s: %s", step.name, msg)
+			r.updateStep(i, dto.BootStateRunning, msg)
+		}
+		report("started")
+
+		msg, err := step.run(ctx, report)
+		switch {
+		case err == nil:
+			r.updateStep(i, dto.BootStateDone, msg)
+			continue
+		case step.critical:
+			logger.Error("Boot sequence: %s failed: %v", step.name, err)
+			r.updateStep(i, dto.BootStateFailed, err.Error())
+			state, message = dto.BootStateFailed, fmt.Sprintf("%s: %v", step.name, err)
+		default:
+			logger.Warning("Boot sequence: %s: %v", step.name, err)
+			r.updateStep(i, dto.BootStateWarning, err.Error())
+			state, message = dto.BootStateWarning, "boot sequence completed with warnings"
+			continue
+		}
+		break
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	r.status.State = state
+	r.status.Message = message
+	r.status.FinishedAt = &now
+	r.running = false
+	r.mu.Unlock()
+
+	logger.Info("Boot sequence: %s", message)
+	if state == dto.BootStateFailed && ctx.Err() == nil {
+		if err := controllers.CreateNotification(i18n.T("Boot sequence failed"), i18n.T("Boot sequence failed"),
+			i18n.T("The array and guests were not started: %s", message), "alert", ""); err != nil {
+			logger.Warning("Boot sequence: failed to send notification: %v", err)
+		}
+	}
+}
+
+// runArray starts the array unless it is already started. An encrypted
+// array is unlocked with the configured key, or the step waits for it to be
+// unlocked through the API when no key source is configured.
+func (r *Runner) runArray(ctx context.Context, cfg dto.BootSequenceConfig, timeout time.Duration, report func(string)) (string, error) {
+	if r.arrayState() == "STARTED" {
+		return "array already started", nil
+	}
+
+	if r.arrayEncrypted() {
+		if cfg.KeyFile == "" && cfg.KeyURL == "" {
+			report("waiting for the array to be unlocked with POST /array/unlock")
+			return "array unlocked", r.waitFor(ctx, timeout, r.arrayStarted)
+		}
+		report("reading the encryption key")
+		var key []byte
+		err := r.waitFor(ctx, timeout, func() error {
+			var err error
+			key, err = r.loadKey(ctx, cfg)
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("encryption key: %w", err)
+		}
+		report("unlocking encrypted devices and starting the array")
+		if err := r.unlockArray(key); err != nil {
+			return "", err
+		}
+		return "array unlocked and started", nil
+	}
+
+	report("starting the array")
+	if err := r.startArray(); err != nil {
+		return "", err
+	}
+	return "array started", r.waitFor(ctx, timeout, r.arrayStarted)
+}
+
+// arrayStarted returns an error until the array reports STARTED.
+func (r *Runner) arrayStarted() error {
+	if state := r.arrayState(); state != "STARTED" {
+		return fmt.Errorf("array state is %q", state)
+	}
+	return nil
+}
+
+// loadKey reads the key from the configured keyfile or key URL.
+func (r *Runner) loadKey(ctx context.Context, cfg dto.BootSequenceConfig) ([]byte, error) {
+	if cfg.KeyURL != "" {
+		return r.fetchKey(ctx, cfg.KeyURL)
+	}
+	return readKeyFile(cfg.KeyFile)
+}
+
+// startGuests starts each named container or VM in order, pausing
+// StartDelaySeconds between them. Docker and libvirt only come up once the
+// array has started, so each start is retried until the step timeout.
+func (r *Runner) startGuests(ctx context.Context, kind string, names []string, start func(string) error,
+	cfg dto.BootSequenceConfig, timeout time.Duration, report func(string)) (string, error) {
+	deadline := time.Now().Add(timeout)
+	var errs []error
+	for i, name := range names {
+		if i > 0 && cfg.StartDelaySeconds > 0 {
+			r.sleep(ctx, time.Duration(cfg.StartDelaySeconds)*time.Second)
+		}
+		report(fmt.Sprintf("starting %s %s (%d/%d)", kind, name, i+1, len(names)))
+		if err := r.waitFor(ctx, time.Until(deadline), func() error { return start(name) }); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", kind, name, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("started %d %s(s)", len(names), kind), nil
+}
+
+// waitFor calls check until it succeeds, timeout passes or ctx is
+// cancelled, and returns check's last error in the latter cases.
+func (r *Runner) waitFor(ctx context.Context, timeout time.Duration, check func() error) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := check()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("timed out after %s: %w", timeout.Round(time.Second), err)
+		}
+		r.sleep(ctx, pollInterval)
+	}
+}
+
+// stepState returns the state of step i.
+func (r *Runner) stepState(i int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status.Steps[i].State
+}
+
+// updateStep records the state of step i, stamping its start and end.
+func (r *Runner) updateStep(i int, state, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	step := &r.status.Steps[i]
+	if step.StartedAt == nil {
+		step.StartedAt = &now
+	}
+	if state != dto.BootStateRunning {
+		step.FinishedAt = &now
+	}
+	step.State = state
+	step.Message = msg
+}
+
+// upsReady returns an error until the UPS is on line power with at least
+// minBattery percent charge.
+func upsReady(ups *dto.UPSStatus, minBattery int) error {
+	if ups == nil || !ups.Connected {
+		return errors.New("no UPS status yet")
+	}
+	if !strings.Contains(ups.Status, "OL") && ups.Status != "ONLINE" {
+		return fmt.Errorf("UPS status is %s", ups.Status)
+	}
+	if ups.BatteryCharge < float64(minBattery) {
+		return fmt.Errorf("battery at %.0f%%, waiting for %d%%", ups.BatteryCharge, minBattery)
+	}
+	return nil
+}
+
+// stepTimeout returns the configured step timeout or the default.
+func stepTimeout(cfg dto.BootSequenceConfig) time.Duration {
+	if cfg.StepTimeoutSeconds > 0 {
+		return time.Duration(cfg.StepTimeoutSeconds) * time.Second
+	}
+	return DefaultStepTimeout
+}
+
+// Validate checks a boot sequence configuration.
+func Validate(cfg dto.BootSequenceConfig) error {
+	if cfg.StepTimeoutSeconds < 0 || time.Duration(cfg.StepTimeoutSeconds)*time.Second > maxStepTimeout {
+		return fmt.Errorf("step_timeout_seconds must be between 0 and %d", int(maxStepTimeout.Seconds()))
+	}
+	if cfg.StartDelaySeconds < 0 || time.Duration(cfg.StartDelaySeconds)*time.Second > maxStartDelay {
+		return fmt.Errorf("start_delay_seconds must be between 0 and %d", int(maxStartDelay.Seconds()))
+	}
+	if cfg.MinBatteryPercent < 0 || cfg.MinBatteryPercent > 100 {
+		return errors.New("min_battery_percent must be between 0 and 100")
+	}
+	if cfg.NetworkHost != "" {
+		if _, port, err := splitHostPort(cfg.NetworkHost); err != nil || port == 0 {
+			return errors.New("network_host must be host:port")
+		}
+	}
+	if cfg.KeyFile != "" && cfg.KeyURL != "" {
+		return errors.New("set at most one of key_file and key_url")
+	}
+	if cfg.KeyFile != "" && (!filepath.IsAbs(cfg.KeyFile) || filepath.Clean(cfg.KeyFile) != cfg.KeyFile) {
+		return errors.New("key_file must be a clean absolute path")
+	}
+	if cfg.KeyURL != "" {
+		if err := validateKeyURL(cfg.KeyURL); err != nil {
+			return err
+		}
+	}
+	for _, list := range [][]string{cfg.Containers, cfg.VMs} {
+		for _, name := range list {
+			if strings.TrimSpace(name) == "" || name != strings.TrimSpace(name) {
+				return fmt.Errorf("invalid container or VM name %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// readUptime returns the time since boot from /proc/uptime.
+func readUptime() (time.Duration, error) {
+	file, err := os.Open(constants.ProcUptime)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		return 0, errors.New("empty /proc/uptime")
+	}
+	fields := strings.Fields(scanner.Text())
+	if len(fields) == 0 {
+		return 0, errors.New("empty /proc/uptime")
+	}
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// startContainer starts a container with a short-lived Docker client.
+func startContainer(name string) error {
+	dc := controllers.NewDockerController()
+	defer dc.Close() //nolint:errcheck
+	return dc.Start(name)
+}
+
+// sleepContext sleeps for d or until ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,234 @@
+package bootseq
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// newTestRunner returns a runner whose system hooks succeed immediately and
+// record the guests started.
+func newTestRunner(t *testing.T, started *[]string) *Runner {
+	t.Helper()
+	state := "STOPPED"
+	return &Runner{
+		filePath:       filepath.Join(t.TempDir(), ConfigFile),
+		ups:            func() *dto.UPSStatus { return &dto.UPSStatus{Connected: true, Status: "OL", BatteryCharge: 100} },
+		uptime:         func() (time.Duration, error) { return time.Minute, nil },
+		networkReady:   func(context.Context, string) error { return nil },
+		arrayState:     func() string { return state },
+		arrayEncrypted: func() bool { return false },
+		startArray:     func() error { state = "STARTED"; return nil },
+		unlockArray:    func([]byte) error { state = "STARTED"; return nil },
+		fetchKey:       func(context.Context, string) ([]byte, error) { return nil, errors.New("unreachable") },
+		startContainer: func(name string) error { *started = append(*started, "ct:"+name); return nil },
+		startVM:        func(name string) error { *started = append(*started, "vm:"+name); return nil },
+		sleep:          func(context.Context, time.Duration) {},
+	}
+}
+
+func TestRunAtBoot(t *testing.T) {
+	var started []string
+	r := newTestRunner(t, &started)
+	cfg := dto.BootSequenceConfig{
+		Enabled: true, WaitNetwork: true, StartArray: true,
+		Containers: []string{"mariadb", "nextcloud"}, VMs: []string{"HomeAssistant"},
+	}
+	if _, err := r.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	r.RunAtBoot(context.Background())
+
+	status := r.Status()
+	if status.State != dto.BootStateDone || status.Trigger != TriggerBoot {
+		t.Fatalf("status = %+v", status)
+	}
+	want := []string{dto.BootStateDone, dto.BootStateSkipped, dto.BootStateDone, dto.BootStateDone, dto.BootStateDone}
+	for i, step := range status.Steps {
+		if step.State != want[i] {
+			t.Errorf("step %s = %s, want %s", step.Name, step.State, want[i])
+		}
+	}
+	if !slices.Equal(started, []string{"ct:mariadb", "ct:nextcloud", "vm:HomeAssistant"}) {
+		t.Errorf("started = %v", started)
+	}
+
+	// The configuration survives a restart.
+	reloaded := newTestRunner(t, &started)
+	reloaded.filePath = r.filePath
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Status().Config; !got.Enabled || len(got.Containers) != 2 {
+		t.Errorf("reloaded config = %+v", got)
+	}
+}
+
+func TestRunAtBootSkipsAfterAgentRestart(t *testing.T) {
+	var started []string
+	r := newTestRunner(t, &started)
+	r.uptime = func() (time.Duration, error) { return 2 * time.Hour, nil }
+	r.config = dto.BootSequenceConfig{Enabled: true, StartArray: true}
+
+	r.RunAtBoot(context.Background())
+	if status := r.Status(); status.State != dto.BootStateSkipped || r.arrayState() != "STOPPED" {
+		t.Errorf("status = %+v, array %s", status, r.arrayState())
+	}
+
+	r.config.Enabled = false
+	r.status = dto.BootSequenceStatus{}
+	r.RunAtBoot(context.Background())
+	if status := r.Status(); status.State != "" {
+		t.Errorf("disabled sequence ran: %+v", status)
+	}
+}
+
+func TestRunPreconditionFailureStopsSequence(t *testing.T) {
+	oldPoll := pollInterval
+	pollInterval = time.Millisecond
+	t.Cleanup(func() { pollInterval = oldPoll })
+
+	var started []string
+	r := newTestRunner(t, &started)
+	r.ups = func() *dto.UPSStatus { return &dto.UPSStatus{Connected: true, Status: "OB DISCHRG", BatteryCharge: 40} }
+	cfg := dto.BootSequenceConfig{WaitUPS: true, StartArray: true, Containers: []string{"plex"}, StepTimeoutSeconds: 1}
+
+	if err := r.begin(cfg, TriggerManual); err != nil {
+		t.Fatal(err)
+	}
+	r.run(context.Background(), cfg)
+
+	status := r.Status()
+	if status.State != dto.BootStateFailed || status.Steps[1].State != dto.BootStateFailed {
+		t.Fatalf("status = %+v", status)
+	}
+	if status.Steps[2].State != dto.BootStatePending || r.arrayState() != "STOPPED" || len(started) != 0 {
+		t.Errorf("steps after the failure ran: %+v, started %v", status.Steps, started)
+	}
+}
+
+func TestRunUnlocksEncryptedArray(t *testing.T) {
+	var started []string
+	r := newTestRunner(t, &started)
+	r.arrayEncrypted = func() bool { return true }
+	var gotKey []byte
+	unlock := r.unlockArray
+	r.unlockArray = func(key []byte) error { gotKey = key; return unlock(key) }
+
+	keyFile := filepath.Join(t.TempDir(), "keyfile")
+	if err := os.WriteFile(keyFile, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := dto.BootSequenceConfig{StartArray: true, KeyFile: keyFile}
+	if err := r.begin(cfg, TriggerManual); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.begin(cfg, TriggerManual); !errors.Is(err, ErrRunning) {
+		t.Errorf("second begin = %v, want ErrRunning", err)
+	}
+	r.run(context.Background(), cfg)
+
+	if status := r.Status(); status.State != dto.BootStateDone || string(gotKey) != "secret" {
+		t.Errorf("status = %+v, key %q", status, gotKey)
+	}
+}
+
+func TestRunGuestFailureIsWarning(t *testing.T) {
+	oldPoll := pollInterval
+	pollInterval = time.Millisecond
+	t.Cleanup(func() { pollInterval = oldPoll })
+
+	var started []string
+	r := newTestRunner(t, &started)
+	r.startContainer = func(name string) error {
+		if name == "broken" {
+			return errors.New("no such container")
+		}
+		started = append(started, name)
+		return nil
+	}
+	cfg := dto.BootSequenceConfig{Containers: []string{"broken", "plex"}, VMs: []string{"win11"}, StepTimeoutSeconds: 1}
+	if err := r.begin(cfg, TriggerManual); err != nil {
+		t.Fatal(err)
+	}
+	r.run(context.Background(), cfg)
+
+	status := r.Status()
+	if status.State != dto.BootStateWarning || status.Steps[3].State != dto.BootStateWarning || status.Steps[4].State != dto.BootStateDone {
+		t.Errorf("status = %+v", status)
+	}
+	if !slices.Equal(started, []string{"plex", "vm:win11"}) {
+		t.Errorf("started = %v", started)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := dto.BootSequenceConfig{
+		Enabled: true, NetworkHost: "192.168.1.1:53", MinBatteryPercent: 50,
+		KeyURL: "https://keys.example.com/tower", Containers: []string{"plex"},
+	}
+	if err := Validate(valid); err != nil {
+		t.Fatalf("valid config: %v", err)
+	}
+
+	for name, mutate := range map[string]func(*dto.BootSequenceConfig){
+		"negative timeout":   func(c *dto.BootSequenceConfig) { c.StepTimeoutSeconds = -1 },
+		"long delay":         func(c *dto.BootSequenceConfig) { c.StartDelaySeconds = 3600 },
+		"battery":            func(c *dto.BootSequenceConfig) { c.MinBatteryPercent = 101 },
+		"host without port":  func(c *dto.BootSequenceConfig) { c.NetworkHost = "192.168.1.1" },
+		"plain http key url": func(c *dto.BootSequenceConfig) { c.KeyURL = "http://keys.example.com/tower" },
+		"both key sources":   func(c *dto.BootSequenceConfig) { c.KeyFile = "/mnt/disks/key/keyfile" },
+		"relative key file":  func(c *dto.BootSequenceConfig) { c.KeyURL, c.KeyFile = "", "keyfile" },
+		"blank container":    func(c *dto.BootSequenceConfig) { c.Containers = []string{" "} },
+	} {
+		cfg := valid
+		mutate(&cfg)
+		if err := Validate(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestUPSReady(t *testing.T) {
+	for _, tt := range []struct {
+		ups  *dto.UPSStatus
+		ok   bool
+		name string
+	}{
+		{nil, false, "no status"},
+		{&dto.UPSStatus{Connected: true, Status: "OB DISCHRG", BatteryCharge: 90}, false, "on battery"},
+		{&dto.UPSStatus{Connected: true, Status: "OL CHRG", BatteryCharge: 30}, false, "charging below minimum"},
+		{&dto.UPSStatus{Connected: true, Status: "ONLINE", BatteryCharge: 60}, true, "apcupsd online"},
+	} {
+		if err := upsReady(tt.ups, 50); (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+	}
+}
+
+func TestHasDefaultRoute(t *testing.T) {
+	dir := t.TempDir()
+	header := "Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\t\tMTU\tWindow\tIRTT\n"
+	withDefault := filepath.Join(dir, "default")
+	if err := os.WriteFile(withDefault, []byte(header+"br0\t00000000\t0101A8C0\t0003\t0\t0\t0\t00000000\t0\t0\t0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	linkOnly := filepath.Join(dir, "link")
+	if err := os.WriteFile(linkOnly, []byte(header+"br0\t0001A8C0\t00000000\t0001\t0\t0\t0\t00FFFFFF\t0\t0\t0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := hasDefaultRoute(withDefault); !ok || err != nil {
+		t.Errorf("default route: %v, %v", ok, err)
+	}
+	if ok, err := hasDefaultRoute(linkOnly); ok || err != nil {
+		t.Errorf("link route only: %v, %v", ok, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -12,9 +13,12 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/mock"
 )
 
 // Collector is the interface that all collectors must implement for runtime management
@@ -22,6 +26,12 @@ type Collector interface {
 	Start(ctx context.Context, interval time.Duration)
 }
 
+// Refresher is implemented by collectors that can collect immediately on
+// request, outside their regular interval.
+type Refresher interface {
+	RequestRefresh()
+}
+
 // CollectorFactory is a function that creates a new collector instance
 type CollectorFactory func(ctx *domain.Context) Collector
 
@@ -38,6 +48,8 @@ type ManagedCollector struct {
 	// Runtime management
 	ctx       context.Context
 	cancel    context.CancelFunc
+	collector Collector     // running instance, nil when stopped
+	done      chan struct{} // closed when the running instance's Start returns
 	factory   CollectorFactory
 	domainCtx *domain.Context
 	wg        *sync.WaitGroup
@@ -47,8 +59,11 @@ type ManagedCollector struct {
 type CollectorManager struct {
 	mu         sync.RWMutex
 	collectors map[string]*ManagedCollector
+	order      []string // collector names in registration order
+	startup    startupState
 	domainCtx  *domain.Context
 	wg         *sync.WaitGroup
+	throttle   int // interval multiplier during maintenance; 0 or 1 = none
 }
 
 func (cm *CollectorManager) stopCollectorLocked(mc *ManagedCollector) {
@@ -57,6 +72,8 @@ func (cm *CollectorManager) stopCollectorLocked(mc *ManagedCollector) {
 		mc.cancel = nil
 	}
 	mc.ctx = nil
+	mc.collector = nil
+	mc.done = nil
 }
 
 // NewCollectorManager creates a new collector manager
@@ -81,6 +98,9 @@ func (cm *CollectorManager) Register(name string, factory CollectorFactory, inte
 		status = "disabled"
 	}
 
+	if _, exists := cm.collectors[name]; !exists {
+		cm.order = append(cm.order, name)
+	}
 	cm.collectors[name] = &ManagedCollector{
 		Name:      name,
 		Enabled:   enabled,
@@ -95,20 +115,44 @@ func (cm *CollectorManager) Register(name string, factory CollectorFactory, inte
 	logger.Debug("Registered collector: %s (interval: %ds, required: %v)", name, interval, required)
 }
 
-// StartAll starts all enabled collectors
+// StartAll starts all enabled collectors in dependency order (see
+// collectorDependencies): collectors without dependencies start right away,
+// the others in the background once their dependencies have published.
+// When every collector has run once the agent reports ready (Readiness).
+// It returns the number of collectors being started.
 func (cm *CollectorManager) StartAll() int {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	enabledCount := 0
-	for name, mc := range cm.collectors {
-		if mc.Enabled && mc.Interval > 0 {
+	var enabled []string
+	for _, name := range cm.order {
+		if mc := cm.collectors[name]; mc.Enabled && mc.Interval > 0 && mc.Status != "running" {
+			enabled = append(enabled, name)
+		}
+	}
+	levels := startupLevels(enabled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cm.startup = startupState{
+		startedAt: time.Now(),
+		firstRun:  make(map[string]<-chan struct{}, len(enabled)),
+		cancel:    cancel,
+	}
+	for _, name := range enabled {
+		cm.startup.firstRun[name] = cm.watchFirstRun(ctx, name)
+	}
+	if len(levels) > 0 {
+		for _, name := range levels[0] {
 			cm.startCollectorLocked(name)
-			enabledCount++
 		}
 	}
 
-	return enabledCount
+	cm.wg.Go(func() {
+		defer cancel()
+		cm.runStartup(ctx, levels)
+	})
+
+	return len(enabled)
 }
 
 // startCollectorLocked starts a collector (must hold lock)
@@ -121,15 +165,21 @@ func (cm *CollectorManager) startCollectorLocked(name string) {
 	// Create new context for this collector
 	// #nosec G118 -- cancel is stored on ManagedCollector and released by DisableCollector, UpdateInterval, StopAll, and collector exit.
 	ctx, cancel := context.WithCancel(context.Background())
+	// Tag the collector's log entries so they can be filtered per collector
+	ctx = lib.ContextWithCorrelationID(ctx, "collector:"+name)
 	mc.ctx = ctx
 	mc.cancel = cancel
 
 	// Create collector instance
 	collector := mc.factory(mc.domainCtx)
-	interval := time.Duration(mc.Interval) * time.Second
+	mc.collector = collector
+	interval := time.Duration(min(mc.Interval*max(cm.throttle, 1), maxThrottledInterval)) * time.Second
 
 	// Start the collector goroutine
+	done := make(chan struct{})
+	mc.done = done
 	mc.wg.Go(func() {
+		defer close(done)
 		defer cancel()
 		collector.Start(ctx, interval)
 	})
@@ -219,14 +269,15 @@ func (cm *CollectorManager) DisableCollector(name string) error {
 // UpdateInterval updates the collection interval for a collector
 func (cm *CollectorManager) UpdateInterval(name string, intervalSeconds int) error {
 	cm.mu.Lock()
-	defer cm.mu.Unlock()
 
 	mc, exists := cm.collectors[name]
 	if !exists {
+		cm.mu.Unlock()
 		return fmt.Errorf("unknown collector: %s", name)
 	}
 
 	if intervalSeconds < 5 || intervalSeconds > 86400 {
+		cm.mu.Unlock()
 		return errors.New("invalid interval: must be between 5 and 86400 seconds")
 	}
 
@@ -250,9 +301,71 @@ func (cm *CollectorManager) UpdateInterval(name string, intervalSeconds int) err
 
 	logger.Info("Updated collector %s interval to %d seconds", name, intervalSeconds)
 
+	event := cm.buildStateEvent(name, mc.Enabled)
+	cm.mu.Unlock()
+
+	// Broadcast outside the lock, as in EnableCollector.
+	domain.Publish(cm.domainCtx.Hub, constants.TopicCollectorStateChange, event)
+
 	return nil
 }
 
+// maxThrottledInterval caps throttled collector intervals, in seconds.
+const maxThrottledInterval = 86400
+
+// SetThrottle multiplies every collector interval by factor and restarts the
+// running collectors with the slower interval; a factor of 1 or less restores
+// the configured intervals. Maintenance windows use it to reduce load during
+// planned work. Reported intervals stay the configured ones.
+func (cm *CollectorManager) SetThrottle(factor int) {
+	factor = max(factor, 1)
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if factor == max(cm.throttle, 1) {
+		return
+	}
+	cm.throttle = factor
+
+	var running []string
+	for name, mc := range cm.collectors {
+		if mc.Status == "running" {
+			cm.stopCollectorLocked(mc)
+			mc.Status = "stopped"
+			running = append(running, name)
+		}
+	}
+	if len(running) > 0 {
+		// Give time for graceful stop
+		time.Sleep(100 * time.Millisecond)
+	}
+	for _, name := range running {
+		cm.startCollectorLocked(name)
+	}
+
+	logger.Info("Collector intervals throttled by a factor of %d (%d collectors restarted)", factor, len(running))
+}
+
+// RequestRefresh asks a running collector for an immediate collection. It
+// reports whether the request was queued: stopped collectors and collectors
+// that do not implement Refresher are skipped.
+func (cm *CollectorManager) RequestRefresh(name string) bool {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	mc, exists := cm.collectors[name]
+	if !exists || mc.Status != "running" {
+		return false
+	}
+	r, ok := mc.collector.(Refresher)
+	if !ok {
+		return false
+	}
+	r.RequestRefresh()
+	return true
+}
+
 // GetStatus returns the status of a specific collector
 func (cm *CollectorManager) GetStatus(name string) (*dto.CollectorStatus, error) {
 	cm.mu.RLock()
@@ -289,7 +402,8 @@ func (cm *CollectorManager) GetAllStatus() dto.CollectorsStatusResponse {
 		"ups", "nut", "gpu", "shares", "network",
 		"hardware", "zfs", "notification", "registration", "unassigned",
 		"fancontrol", "tuning", "docker_update", "docker_networks", "plugin_update",
-		"os_update", "mover",
+		"os_update", "mover", "dns", "wan", "speedtest", "pools", "btrfs",
+		"recycle_bin", "ipmi", "connect", "guest_traffic", "remote_mounts",
 	}
 
 	for _, name := range collectorOrder {
@@ -336,16 +450,56 @@ func (cm *CollectorManager) GetCollectorNames() []string {
 	return names
 }
 
-// StopAll stops all running collectors
+// collectorStopTimeout bounds how long StopAll waits for the collectors to
+// return, well within the 30 seconds the plugin's stop script allows before
+// killing the agent. A variable so tests can shorten it.
+var collectorStopTimeout = 20 * time.Second
+
+// StopAll cancels every running collector at once and waits until all of
+// them have returned or collectorStopTimeout has passed, so no collector
+// publishes after shutdown has moved on and one slow collector cannot hold
+// up the others.
 func (cm *CollectorManager) StopAll() {
+	type stopping struct {
+		name string
+		done chan struct{}
+	}
+
 	cm.mu.Lock()
-	defer cm.mu.Unlock()
+	if cm.startup.cancel != nil {
+		cm.startup.cancel() // start no more dependents
+	}
+	var pending []stopping
+	for _, name := range slices.Backward(cm.order) {
+		mc := cm.collectors[name]
+		if mc == nil || mc.cancel == nil {
+			continue
+		}
+		if mc.done != nil {
+			pending = append(pending, stopping{name, mc.done})
+		}
+		cm.stopCollectorLocked(mc)
+		mc.Status = "stopped"
+	}
+	cm.mu.Unlock()
 
-	for name, mc := range cm.collectors {
-		if mc.cancel != nil {
-			cm.stopCollectorLocked(mc)
-			mc.Status = "stopped"
-			logger.Debug("Stopped collector: %s", name)
+	deadline := time.NewTimer(collectorStopTimeout)
+	defer deadline.Stop()
+	for i, p := range pending {
+		select {
+		case <-p.done:
+			logger.Debug("Stopped collector: %s", p.name)
+		case <-deadline.C:
+			var late []string
+			for _, q := range pending[i:] {
+				select {
+				case <-q.done:
+				default:
+					late = append(late, q.name)
+				}
+			}
+			logger.Warning("Collectors did not stop within %s: %s", collectorStopTimeout, strings.Join(late, ", "))
+			return
 		}
 	}
 }
@@ -387,6 +541,16 @@ func (cm *CollectorManager) getDefaultInterval(name string) int {
 		"plugin_update":   constants.IntervalPluginUpdate,
 		"os_update":       constants.IntervalOSUpdate,
 		"mover":           constants.IntervalMover,
+		"dns":             constants.IntervalDNS,
+		"wan":             constants.IntervalWAN,
+		"speedtest":       constants.IntervalSpeedtest,
+		"pools":           constants.IntervalPools,
+		"btrfs":           constants.IntervalBtrfs,
+		"recycle_bin":     constants.IntervalRecycleBin,
+		"ipmi":            constants.IntervalIPMI,
+		"connect":         constants.IntervalConnect,
+		"guest_traffic":   constants.IntervalGuestTraffic,
+		"remote_mounts":   constants.IntervalRemoteMounts,
 	}
 
 	if interval, ok := defaults[name]; ok {
@@ -397,6 +561,11 @@ func (cm *CollectorManager) getDefaultInterval(name string) int {
 
 // RegisterAllCollectors registers all collectors with the manager
 func (cm *CollectorManager) RegisterAllCollectors() {
+	if cm.domainCtx.Mock {
+		cm.registerMockCollectors()
+		return
+	}
+
 	intervals := cm.domainCtx.Intervals
 
 	// System collector is required
@@ -503,9 +672,9 @@ func (cm *CollectorManager) RegisterAllCollectors() {
 		}
 		c.NotifyFn = func(names []string) {
 			if err := controllers.CreateNotification(
-				"Container updates available",
+				i18n.T("Container updates available"),
 				"Docker",
-				fmt.Sprintf("Updates available for: %s", strings.Join(names, ", ")),
+				i18n.T("Updates available for: %s", strings.Join(names, ", ")),
 				"info",
 				"",
 			); err != nil {
@@ -547,9 +716,9 @@ func (cm *CollectorManager) RegisterAllCollectors() {
 		}
 		c.NotifyFn = func(names []string) {
 			if err := controllers.CreateNotification(
-				"Plugin updates available",
-				"Plugins",
-				fmt.Sprintf("Updates available for: %s", strings.Join(names, ", ")),
+				i18n.T("Plugin updates available"),
+				i18n.T("Plugins"),
+				i18n.T("Updates available for: %s", strings.Join(names, ", ")),
 				"info",
 				"",
 			); err != nil {
@@ -566,9 +735,9 @@ func (cm *CollectorManager) RegisterAllCollectors() {
 		c := collectors.NewOSUpdateCollector(ctx)
 		c.NotifyFn = func(latest string) {
 			if err := controllers.CreateNotification(
-				"Unraid OS update available",
-				"System",
-				fmt.Sprintf("A new Unraid OS version is available: %s", latest),
+				i18n.T("Unraid OS update available"),
+				i18n.T("System"),
+				i18n.T("A new Unraid OS version is available: %s", latest),
 				"info",
 				"",
 			); err != nil {
@@ -582,4 +751,91 @@ func (cm *CollectorManager) RegisterAllCollectors() {
 	cm.Register("mover", func(ctx *domain.Context) Collector {
 		return collectors.NewMoverCollector(ctx)
 	}, intervals.Mover, false)
+
+	// DNS collector — verifies resolution from the host and from each Docker bridge gateway address.
+	// NetworksFn is injected here (not in the collector) to avoid a
+	// collectors→controllers import cycle.
+	cm.Register("dns", func(ctx *domain.Context) Collector {
+		c := collectors.NewDNSCollector(ctx)
+		c.NetworksFn = func() ([]dto.DockerNetworkInfo, error) {
+			dc := controllers.NewDockerController()
+			defer func() { _ = dc.Close() }()
+			return dc.ListNetworks()
+		}
+		return c
+	}, intervals.DNS, false)
+
+	// WAN collector — public IP change detection plus latency/packet loss to probe targets.
+	cm.Register("wan", func(ctx *domain.Context) Collector {
+		return collectors.NewWANCollector(ctx)
+	}, intervals.WAN, false)
+
+	// Speedtest collector — scheduled download/upload/ping bandwidth tests.
+	cm.Register("speedtest", func(ctx *domain.Context) Collector {
+		return collectors.NewSpeedtestCollector(ctx)
+	}, intervals.Speedtest, false)
+
+	// Pools collector — per-pool device membership, btrfs allocation, balance/scrub state.
+	cm.Register("pools", func(ctx *domain.Context) Collector {
+		return collectors.NewPoolsCollector(ctx)
+	}, intervals.Pools, false)
+
+	// Btrfs collector — per-device error counters and scrub results.
+	cm.Register("btrfs", func(ctx *domain.Context) Collector {
+		return collectors.NewBtrfsCollector(ctx)
+	}, intervals.Btrfs, false)
+
+	// Recycle bin collector — per-share recycle bin size and age (Recycle Bin plugin).
+	cm.Register("recycle_bin", func(ctx *domain.Context) Collector {
+		return collectors.NewRecycleBinCollector(ctx)
+	}, intervals.RecycleBin, false)
+
+	// IPMI collector — BMC sensors (fans, voltages, temperatures, PSUs) and chassis status.
+	cm.Register("ipmi", func(ctx *domain.Context) Collector {
+		return collectors.NewIPMICollector(ctx)
+	}, intervals.IPMI, false)
+
+	// Connect collector — Unraid Connect cloud connection, flash backup and remote access.
+	cm.Register("connect", func(ctx *domain.Context) Collector {
+		return collectors.NewConnectCollector(ctx)
+	}, intervals.Connect, false)
+
+	// Guest traffic collector — attributes host vnet/veth traffic to VMs and containers.
+	cm.Register("guest_traffic", func(ctx *domain.Context) Collector {
+		return collectors.NewGuestTrafficCollector(ctx)
+	}, intervals.GuestTraffic, false)
+
+	// Remote mount collector — probes NFS/SMB client mounts for staleness.
+	cm.Register("remote_mounts", func(ctx *domain.Context) Collector {
+		return collectors.NewRemoteMountCollector(ctx)
+	}, intervals.RemoteMounts, false)
+}
+
+// registerMockCollectors registers the synthetic-data collectors of mock
+// mode in place of the real ones. Collectors without synthetic data are
+// not registered.
+func (cm *CollectorManager) registerMockCollectors() {
+	intervals := cm.domainCtx.Intervals
+	configured := map[string]int{
+		"system":       intervals.System,
+		"array":        intervals.Array,
+		"disk":         intervals.Disk,
+		"docker":       intervals.Docker,
+		"vm":           intervals.VM,
+		"ups":          intervals.UPS,
+		"gpu":          intervals.GPU,
+		"shares":       intervals.Shares,
+		"network":      intervals.Network,
+		"notification": intervals.Notification,
+	}
+	for _, name := range mock.Names() {
+		interval, ok := configured[name]
+		if !ok {
+			interval = cm.getDefaultInterval(name)
+		}
+		cm.Register(name, func(ctx *domain.Context) Collector {
+			return mock.NewCollector(ctx, name)
+		}, interval, name == "system")
+	}
+	logger.Warning("Mock mode: registered synthetic collectors %v", mock.Names())
 }
@@ -124,7 +124,9 @@ func TestCollectorManager_RegisterAllCollectors(t *testing.T) {
 		"system", "array", "disk", "docker", "vm", "ups", "nut",
 		"gpu", "shares", "network", "hardware", "zfs", "notification",
 		"registration", "unassigned", "fancontrol", "tuning", "docker_update",
-		"docker_networks", "plugin_update", "os_update", "mover",
+		"docker_networks", "plugin_update", "os_update", "mover", "dns", "wan",
+		"speedtest", "pools", "btrfs", "recycle_bin", "ipmi", "connect", "guest_traffic",
+		"remote_mounts",
 	}
 
 	if len(names) != len(expectedNames) {
@@ -200,3 +202,31 @@ func TestCollectorManager_UpdateIntervalRestartsRunning(t *testing.T) {
 
 	cm.StopAll()
 }
+
+func TestCollectorManager_UpdateIntervalPublishesState(t *testing.T) {
+	ctx := createTestContext()
+	var wg sync.WaitGroup
+	cm := NewCollectorManager(ctx, &wg)
+	cm.Register("interval-event", func(dctx *domain.Context) Collector {
+		return &mockCollector{}
+	}, 10, false)
+
+	ch := ctx.Hub.Sub("collector_state_change")
+	if err := cm.UpdateInterval("interval-event", 45); err != nil {
+		t.Fatalf("UpdateInterval failed: %v", err)
+	}
+
+	select {
+	case msg := <-ch:
+		event, ok := msg.(dto.CollectorStateEvent)
+		if !ok || event.Collector != "interval-event" || event.Interval != 45 {
+			t.Errorf("event = %+v, want interval-event at 45s", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for collector_state_change event")
+	}
+
+	if err := cm.UpdateInterval("interval-event", 1); err == nil {
+		t.Error("UpdateInterval(1) should be rejected")
+	}
+}
@@ -285,6 +285,52 @@ func TestCollectorManager_UpdateIntervalBounds(t *testing.T) {
 	}
 }
 
+func TestCollectorManager_SetThrottle(t *testing.T) {
+	ctx := createTestContext()
+	var wg sync.WaitGroup
+
+	cm := NewCollectorManager(ctx, &wg)
+	started := make(chan *mockCollector, 4)
+	cm.Register("test", func(ctx *domain.Context) Collector {
+		mc := &mockCollector{}
+		started <- mc
+		return mc
+	}, 30, false)
+	cm.StartAll()
+	<-started
+
+	intervalOf := func(mc *mockCollector) time.Duration {
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			mc.mu.Lock()
+			ok, interval := mc.started, mc.interval
+			mc.mu.Unlock()
+			if ok {
+				return interval
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatal("collector did not start")
+		return 0
+	}
+
+	cm.SetThrottle(4)
+	if got := intervalOf(<-started); got != 120*time.Second {
+		t.Errorf("throttled interval = %s, want 2m0s", got)
+	}
+	if status, _ := cm.GetStatus("test"); status.Interval != 30 {
+		t.Errorf("reported interval = %d, want configured 30", status.Interval)
+	}
+
+	cm.SetThrottle(0)
+	if got := intervalOf(<-started); got != 30*time.Second {
+		t.Errorf("restored interval = %s, want 30s", got)
+	}
+
+	cm.StopAll()
+	wg.Wait()
+}
+
 func TestCollectorManager_GetAllStatus(t *testing.T) {
 	ctx := createTestContext()
 	var wg sync.WaitGroup
@@ -407,6 +453,65 @@ func TestCollectorManager_StopAll(t *testing.T) {
 	}
 }
 
+// slowStopper takes delay to return once its context is cancelled, and
+// hangs until hold is closed when it is set.
+type slowStopper struct {
+	delay time.Duration
+	hold  <-chan struct{}
+}
+
+func (c *slowStopper) Start(ctx context.Context, _ time.Duration) {
+	<-ctx.Done()
+	if c.hold != nil {
+		<-c.hold
+	}
+	time.Sleep(c.delay) // a collector finishing its last cycle
+}
+
+func TestCollectorManager_StopAllParallel(t *testing.T) {
+	var wg sync.WaitGroup
+	cm := NewCollectorManager(createTestContext(), &wg)
+	for _, name := range []string{"system", "array", "disk", "docker"} {
+		cm.Register(name, func(*domain.Context) Collector {
+			return &slowStopper{delay: 200 * time.Millisecond}
+		}, 10, false)
+	}
+	cm.StartAll()
+
+	start := time.Now()
+	cm.StopAll()
+	// Stopped one after another the four would take 800ms
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > 600*time.Millisecond {
+		t.Errorf("StopAll took %s, want about 200ms", elapsed)
+	}
+}
+
+func TestCollectorManager_StopAllDeadline(t *testing.T) {
+	saved := collectorStopTimeout
+	collectorStopTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { collectorStopTimeout = saved })
+
+	hold := make(chan struct{})
+	t.Cleanup(func() { close(hold) })
+
+	var wg sync.WaitGroup
+	cm := NewCollectorManager(createTestContext(), &wg)
+	cm.Register("system", func(*domain.Context) Collector { return &slowStopper{} }, 10, false)
+	cm.Register("docker", func(*domain.Context) Collector { return &slowStopper{hold: hold} }, 10, false)
+	cm.StartAll()
+
+	start := time.Now()
+	cm.StopAll()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("StopAll took %s with a hung collector, want the 100ms deadline", elapsed)
+	}
+	for _, name := range []string{"system", "docker"} {
+		if status, _ := cm.GetStatus(name); status.Status != "stopped" {
+			t.Errorf("%s status = %q, want stopped", name, status.Status)
+		}
+	}
+}
+
 func TestCollectorManager_GetCollectorNames(t *testing.T) {
 	ctx := createTestContext()
 	var wg sync.WaitGroup
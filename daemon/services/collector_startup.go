@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// collectorFirstRunTimeout bounds the startup phase. Collectors that have
+// not published by then (e.g. a disk collector stuck on a slow drive) no
+// longer hold back their dependents or readiness.
+const collectorFirstRunTimeout = 60 * time.Second
+
+// collectorDependencies lists, per collector, the collectors whose data its
+// own is read together with: shares are shown with the disks they live on,
+// pools and unassigned devices with the disk list, and the Docker update,
+// network and guest traffic views with the containers and VMs they refer
+// to. At startup a collector only starts once its dependencies have
+// published their first result.
+var collectorDependencies = map[string][]string{
+	"shares":          {"disk"},
+	"pools":           {"disk"},
+	"unassigned":      {"disk"},
+	"docker_update":   {"docker"},
+	"docker_networks": {"docker"},
+	"guest_traffic":   {"docker", "vm"},
+}
+
+// startupState tracks the startup phase for Readiness. Guarded by the
+// manager's mu.
+type startupState struct {
+	startedAt time.Time
+	readyAt   time.Time
+	firstRun  map[string]<-chan struct{} // closed on the collector's first publish
+	timedOut  []string
+	cancel    context.CancelFunc
+}
+
+// published reports whether the collector has published its first result.
+func (st *startupState) published(name string) bool {
+	select {
+	case <-st.firstRun[name]:
+		return true
+	default:
+		return false
+	}
+}
+
+// startupLevels groups names into the order they start in: a collector is
+// one level after the deepest of its dependencies that is also starting.
+// Names keep their relative order within a level.
+func startupLevels(names []string) [][]string {
+	starting := make(map[string]bool, len(names))
+	for _, name := range names {
+		starting[name] = true
+	}
+
+	level := make(map[string]int, len(names))
+	var depth func(name string, seen map[string]bool) int
+	depth = func(name string, seen map[string]bool) int {
+		if l, ok := level[name]; ok {
+			return l
+		}
+		if seen[name] {
+			return 0 // a dependency cycle; start the collector with the first level
+		}
+		seen[name] = true
+		l := 0
+		for _, dep := range collectorDependencies[name] {
+			if starting[dep] {
+				l = max(l, depth(dep, seen)+1)
+			}
+		}
+		level[name] = l
+		return l
+	}
+
+	var levels [][]string
+	for _, name := range names {
+		l := depth(name, map[string]bool{})
+		for len(levels) <= l {
+			levels = append(levels, nil)
+		}
+		levels[l] = append(levels[l], name)
+	}
+	return levels
+}
+
+// watchFirstRun returns a channel closed when the collector publishes on
+// any of its topics for the first time, or when ctx ends. A collector
+// without known topics counts as done right away.
+func (cm *CollectorManager) watchFirstRun(ctx context.Context, name string) <-chan struct{} {
+	done := make(chan struct{})
+	var topics []string
+	for topic, collector := range constants.TopicCollectors {
+		if collector == name {
+			topics = append(topics, topic)
+		}
+	}
+	if len(topics) == 0 || cm.domainCtx.Hub == nil {
+		close(done)
+		return done
+	}
+
+	ch := cm.domainCtx.Hub.Sub(topics...)
+	go func() {
+		defer close(done)
+		defer cm.domainCtx.Hub.Unsub(ch)
+		select {
+		case <-ch:
+		case <-ctx.Done():
+		}
+	}()
+	return done
+}
+
+// runStartup starts the levels one after another, waiting for every
+// collector of a level to publish before starting the next, then marks the
+// agent ready. Once collectorFirstRunTimeout has passed, the remaining
+// levels start without waiting.
+func (cm *CollectorManager) runStartup(ctx context.Context, levels [][]string) {
+	deadline := time.NewTimer(collectorFirstRunTimeout)
+	defer deadline.Stop()
+	expired := false
+
+	for i, level := range levels {
+		cm.mu.Lock()
+		if ctx.Err() != nil {
+			cm.mu.Unlock()
+			return
+		}
+		if i > 0 {
+			for _, name := range level {
+				if mc := cm.collectors[name]; mc != nil && mc.Enabled && mc.Interval > 0 {
+					cm.startCollectorLocked(name)
+				}
+			}
+		}
+		firstRun := cm.startup.firstRun
+		cm.mu.Unlock()
+
+		for _, name := range level {
+			if expired {
+				break
+			}
+			select {
+			case <-firstRun[name]:
+			case <-deadline.C:
+				expired = true
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+
+	cm.mu.Lock()
+	st := &cm.startup
+	st.readyAt = time.Now()
+	for _, level := range levels {
+		for _, name := range level {
+			if !st.published(name) {
+				st.timedOut = append(st.timedOut, name)
+			}
+		}
+	}
+	timedOut := slices.Clone(st.timedOut)
+	elapsed := st.readyAt.Sub(st.startedAt)
+	cm.mu.Unlock()
+
+	if len(timedOut) > 0 {
+		logger.Warning("Startup: ready after %s; no first result yet from %v", elapsed.Round(time.Millisecond), timedOut)
+	} else {
+		logger.Success("Startup: all collectors ran once in %s, agent ready", elapsed.Round(time.Millisecond))
+	}
+}
+
+// Readiness reports whether the startup phase has finished.
+func (cm *CollectorManager) Readiness() dto.Readiness {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	st := cm.startup
+	r := dto.Readiness{
+		Ready:     !st.readyAt.IsZero(),
+		StartedAt: st.startedAt,
+		Timestamp: time.Now(),
+	}
+	if r.Ready {
+		readyAt := st.readyAt
+		r.ReadyAt = &readyAt
+		r.TimedOut = slices.Clone(st.timedOut)
+		return r
+	}
+	for _, name := range cm.order {
+		if _, starting := st.firstRun[name]; starting && !st.published(name) {
+			r.Pending = append(r.Pending, name)
+		}
+	}
+	return r
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestStartupLevels(t *testing.T) {
+	got := startupLevels([]string{"system", "shares", "disk", "docker_update", "docker", "guest_traffic"})
+	want := [][]string{
+		{"system", "disk", "docker"},
+		{"shares", "docker_update", "guest_traffic"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("levels = %v, want %v", got, want)
+	}
+
+	// A dependency that is not starting does not hold a collector back
+	got = startupLevels([]string{"shares", "system"})
+	if want := [][]string{{"shares", "system"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("levels without disk = %v, want %v", got, want)
+	}
+}
+
+// publishingCollector publishes once after delay, then waits for cancellation.
+type publishingCollector struct {
+	ctx     *domain.Context
+	publish func(*domain.Context)
+	delay   time.Duration
+	started func()
+}
+
+func (p *publishingCollector) Start(ctx context.Context, _ time.Duration) {
+	if p.started != nil {
+		p.started()
+	}
+	select {
+	case <-time.After(p.delay):
+		p.publish(p.ctx)
+	case <-ctx.Done():
+		return
+	}
+	<-ctx.Done()
+}
+
+func TestCollectorManager_StartAllDependencyOrder(t *testing.T) {
+	var wg sync.WaitGroup
+	cm := NewCollectorManager(createTestContext(), &wg)
+	defer func() {
+		cm.StopAll()
+		wg.Wait()
+	}()
+
+	var mu sync.Mutex
+	var started []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			started = append(started, name)
+			mu.Unlock()
+		}
+	}
+
+	cm.Register("shares", func(ctx *domain.Context) Collector {
+		return &publishingCollector{ctx: ctx, started: record("shares"), publish: func(ctx *domain.Context) {
+			domain.Publish(ctx.Hub, constants.TopicShareListUpdate, []dto.ShareInfo{})
+		}}
+	}, 60, false)
+	cm.Register("disk", func(ctx *domain.Context) Collector {
+		return &publishingCollector{ctx: ctx, started: record("disk"), delay: 50 * time.Millisecond, publish: func(ctx *domain.Context) {
+			domain.Publish(ctx.Hub, constants.TopicDiskListUpdate, []dto.DiskInfo{})
+		}}
+	}, 30, false)
+
+	if n := cm.StartAll(); n != 2 {
+		t.Fatalf("StartAll started %d collectors, want 2", n)
+	}
+	if r := cm.Readiness(); r.Ready || !reflect.DeepEqual(r.Pending, []string{"shares", "disk"}) {
+		t.Errorf("readiness during startup = %+v, want pending shares and disk", r)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cm.Readiness().Ready && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	r := cm.Readiness()
+	if !r.Ready || r.ReadyAt == nil || len(r.TimedOut) != 0 {
+		t.Fatalf("readiness after startup = %+v, want ready", r)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(started, []string{"disk", "shares"}) {
+		t.Errorf("start order = %v, want disk before shares", started)
+	}
+}
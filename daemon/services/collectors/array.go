@@ -26,37 +26,48 @@ var watchedArrayFiles = []string{constants.VarIni, constants.DisksIni}
 // ArrayCollector collects Unraid array status information including state, parity status, and disk assignments.
 // It publishes array status updates to the event bus at regular intervals.
 type ArrayCollector struct {
-	ctx *domain.Context
+	ctx      *domain.Context
+	refresh  refreshTrigger
+	progress parityProgress
+	forecast fillForecast
 }
 
 // NewArrayCollector creates a new array status collector with the given context.
 func NewArrayCollector(ctx *domain.Context) *ArrayCollector {
-	return &ArrayCollector{ctx: ctx}
+	return &ArrayCollector{ctx: ctx, refresh: newRefreshTrigger()}
+}
+
+// RequestRefresh asks the running collector to collect immediately, e.g. after
+// an array or parity check action.
+func (c *ArrayCollector) RequestRefresh() {
+	c.refresh.request()
 }
 
 // Start begins the array collector's periodic data collection.
 // It runs in a goroutine and publishes array status updates at the specified interval until the context is cancelled.
 func (c *ArrayCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting array collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting array collector (interval: %v)", interval)
 
-	// Run once immediately with panic recovery
-	func() {
+	collect := func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Array collector", r)
+				logger.LogPanicWithStackContext(ctx, "Array collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "Array", interval, c.Collect)
-	}()
+	}
+
+	// Run once immediately with panic recovery
+	collect()
 
 	// Set up fsnotify watcher for instant state updates on INI file changes
 	fw, err := NewFileWatcher(500 * time.Millisecond)
 	if err != nil {
-		logger.Warning("Array collector: failed to create file watcher, using ticker only: %v", err)
+		logger.WarningContext(ctx, "Array collector: failed to create file watcher, using ticker only: %v", err)
 	} else {
 		for _, f := range watchedArrayFiles {
 			if watchErr := fw.WatchFile(f); watchErr != nil {
-				logger.Warning("Array collector: failed to watch %s: %v", f, watchErr)
+				logger.WarningContext(ctx, "Array collector: failed to watch %s: %v", f, watchErr)
 			}
 		}
 		// Run watcher in background goroutine — triggers Collect on file changes
@@ -67,15 +78,15 @@ func (c *ArrayCollector) Start(ctx context.Context, interval time.Duration) {
 				func() {
 					defer func() {
 						if r := recover(); r != nil {
-							logger.LogPanicWithStack("Array collector (fsnotify)", r)
+							logger.LogPanicWithStackContext(ctx, "Array collector (fsnotify)", r)
 						}
 					}()
-					logger.Debug("Array collector: INI file changed, collecting immediately")
+					logger.DebugContext(ctx, "Array collector: INI file changed, collecting immediately")
 					collectWithWatchdog(ctx, "Array", interval, c.Collect)
 				}()
 			})
 		}()
-		logger.Info("Array collector: fsnotify watching %v for instant updates", watchedArrayFiles)
+		logger.InfoContext(ctx, "Array collector: fsnotify watching %v for instant updates", watchedArrayFiles)
 	}
 
 	ticker := time.NewTicker(interval)
@@ -84,17 +95,13 @@ func (c *ArrayCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Array collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "Array collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Array collector", r)
-					}
-				}()
-				collectWithWatchdog(ctx, "Array", interval, c.Collect)
-			}()
+			collect()
+		case <-c.refresh:
+			logger.DebugContext(ctx, "Array collector: refresh requested, collecting immediately")
+			collect()
 		}
 	}
 }
@@ -141,6 +148,11 @@ func (c *ArrayCollector) Collect() {
 		arrayStatus.SourceStatus = c.ctx.Platform.StatusFor("array")
 	}
 
+	if arrayStatus.TotalBytes > 0 {
+		arrayStatus.FillRateBytesPerDay, arrayStatus.DaysUntilFull = c.forecast.observe(
+			"array", arrayStatus.TotalBytes-arrayStatus.FreeBytes, arrayStatus.TotalBytes, time.Now())
+	}
+
 	logger.Debug("Array: Successfully collected, publishing event")
 	// Publish event
 	domain.Publish(c.ctx.Hub, constants.TopicArrayStatusUpdate, arrayStatus)
@@ -273,29 +285,8 @@ func (c *ArrayCollector) collectArrayStatus() (status *dto.ArrayStatus, err erro
 	// - mdResyncDt: Delta time (0 = paused, >0 = running)
 	// - mdResyncSize: Total size for calculating progress
 	// - sbSyncAction: Type of parity operation (e.g., "check P", "check NOCORRECT")
-	var mdResyncPos, mdResyncSize uint64
-	var mdResyncDt int64
-
-	if section.HasKey("mdResyncPos") {
-		posStr := strings.Trim(section.Key("mdResyncPos").String(), `"`)
-		if pos, err := strconv.ParseUint(posStr, 10, 64); err == nil {
-			mdResyncPos = pos
-		}
-	}
-
-	if section.HasKey("mdResyncSize") {
-		sizeStr := strings.Trim(section.Key("mdResyncSize").String(), `"`)
-		if size, err := strconv.ParseUint(sizeStr, 10, 64); err == nil {
-			mdResyncSize = size
-		}
-	}
-
-	if section.HasKey("mdResyncDt") {
-		dtStr := strings.Trim(section.Key("mdResyncDt").String(), `"`)
-		if dt, err := strconv.ParseInt(dtStr, 10, 64); err == nil {
-			mdResyncDt = dt
-		}
-	}
+	resync := readMdResync(section)
+	mdResyncPos, mdResyncSize, mdResyncDt := resync.pos, resync.size, resync.dt
 
 	// Determine parity check status based on mdResyncPos and mdResyncDt
 	// - mdResyncPos > 0 AND mdResyncDt = 0 → PAUSED
@@ -342,6 +333,7 @@ func (c *ArrayCollector) collectArrayStatus() (status *dto.ArrayStatus, err erro
 		status.ParityCheckStatus = ""
 		status.ParityCheckProgress = 0
 	}
+	c.progress.apply(status, resync, status.Timestamp)
 
 	// Get array size information from /mnt/user filesystem
 	// /mnt/user is the shfs (Unraid user share filesystem) that represents the entire array
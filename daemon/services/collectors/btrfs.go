@@ -0,0 +1,240 @@
+package collectors
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// btrfsMountsPath is the mount table the btrfs collector scans. It is a
+// package-level variable (not a constant) so tests can use a fixture file.
+var btrfsMountsPath = "/proc/mounts"
+
+// BtrfsCollector collects per-device error counters (`btrfs device stats`)
+// and scrub results (`btrfs scrub status`) for every mounted btrfs
+// filesystem, so silent corruption on cache pools is noticed early.
+type BtrfsCollector struct {
+	ctx *domain.Context
+}
+
+// NewBtrfsCollector creates a new btrfs collector.
+func NewBtrfsCollector(ctx *domain.Context) *BtrfsCollector {
+	return &BtrfsCollector{ctx: ctx}
+}
+
+// Start begins the btrfs collection loop.
+func (c *BtrfsCollector) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.InfoContext(ctx, "Btrfs collector started (interval: %v)", interval)
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "Btrfs collector", r)
+			}
+		}()
+		collectWithWatchdog(ctx, "Btrfs", interval, c.Collect)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Btrfs collector stopped")
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						logger.LogPanicWithStackContext(ctx, "Btrfs collector", r)
+					}
+				}()
+				collectWithWatchdog(ctx, "Btrfs", interval, c.Collect)
+			}()
+		}
+	}
+}
+
+// Collect queries every mounted btrfs filesystem and publishes the result.
+func (c *BtrfsCollector) Collect() {
+	f, err := os.Open(btrfsMountsPath)
+	if err != nil {
+		logger.Debug("Btrfs: failed to open %s: %v", btrfsMountsPath, err)
+		return
+	}
+	mounts := parseBtrfsMounts(f)
+	_ = f.Close()
+
+	filesystems := make([]dto.BtrfsFilesystem, 0, len(mounts))
+	for _, mountPoint := range mounts {
+		filesystems = append(filesystems, collectBtrfsFilesystem(mountPoint))
+	}
+
+	domain.Publish(c.ctx.Hub, constants.TopicBtrfsUpdate, filesystems)
+	logger.Debug("Btrfs: published %d filesystem(s)", len(filesystems))
+}
+
+// collectBtrfsFilesystem gathers device stats and scrub status for one mount.
+func collectBtrfsFilesystem(mountPoint string) dto.BtrfsFilesystem {
+	fs := dto.BtrfsFilesystem{
+		Name:       filepath.Base(mountPoint),
+		MountPoint: mountPoint,
+		Devices:    []dto.BtrfsDeviceStats{},
+		Timestamp:  time.Now(),
+	}
+
+	// btrfs device stats exits non-zero on some versions when a counter is
+	// non-zero, so the output is parsed regardless of the exit status.
+	output, err := lib.ExecCommandOutput(constants.BtrfsBin, "device", "stats", mountPoint)
+	fs.Devices = parseBtrfsDeviceStats(output)
+	if err != nil && len(fs.Devices) == 0 {
+		logger.Debug("Btrfs: device stats failed for %s: %v", mountPoint, err)
+	}
+	for _, d := range fs.Devices {
+		fs.TotalErrors += d.TotalErrors()
+	}
+
+	if output, err := lib.ExecCommandOutput(constants.BtrfsBin, "scrub", "status", mountPoint); err == nil {
+		scrub := parseBtrfsScrubStatus(output)
+		fs.ScrubStatus = scrub.status
+		fs.ScrubStarted = scrub.started
+		fs.ScrubErrors = scrub.errorSummary
+		fs.ScrubCorrected = scrub.corrected
+		fs.ScrubUncorrectable = scrub.uncorrectable
+	} else {
+		logger.Debug("Btrfs: scrub status failed for %s: %v", mountPoint, err)
+	}
+
+	return fs
+}
+
+// parseBtrfsMounts returns the mount points of btrfs filesystems from a
+// /proc/mounts style table, one per filesystem (the first mount of each
+// source device), sorted by mount point.
+func parseBtrfsMounts(r io.Reader) []string {
+	seen := make(map[string]bool)
+	var mounts []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[2] != "btrfs" || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		// /proc/mounts escapes spaces in paths as \040.
+		mounts = append(mounts, strings.ReplaceAll(fields[1], `\040`, " "))
+	}
+	sort.Strings(mounts)
+	return mounts
+}
+
+// parseBtrfsDeviceStats parses `btrfs device stats` output, e.g.
+//
+//	[/dev/nvme0n1p1].write_io_errs    0
+//	[/dev/nvme0n1p1].read_io_errs     0
+//	[/dev/nvme0n1p1].flush_io_errs    0
+//	[/dev/nvme0n1p1].corruption_errs  0
+//	[/dev/nvme0n1p1].generation_errs  0
+func parseBtrfsDeviceStats(output string) []dto.BtrfsDeviceStats {
+	var devices []dto.BtrfsDeviceStats
+	index := make(map[string]int)
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[0], "[") {
+			continue
+		}
+		device, counter, ok := strings.Cut(strings.TrimPrefix(fields[0], "["), "].")
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		i, ok := index[device]
+		if !ok {
+			i = len(devices)
+			index[device] = i
+			devices = append(devices, dto.BtrfsDeviceStats{Device: device})
+		}
+		switch counter {
+		case "write_io_errs":
+			devices[i].WriteIOErrors = value
+		case "read_io_errs":
+			devices[i].ReadIOErrors = value
+		case "flush_io_errs":
+			devices[i].FlushIOErrors = value
+		case "corruption_errs":
+			devices[i].CorruptionErrors = value
+		case "generation_errs":
+			devices[i].GenerationErrors = value
+		}
+	}
+	if devices == nil {
+		return []dto.BtrfsDeviceStats{}
+	}
+	return devices
+}
+
+// btrfsScrub is the latest scrub result reported by `btrfs scrub status`.
+type btrfsScrub struct {
+	status        string
+	started       string
+	errorSummary  string
+	corrected     uint64
+	uncorrectable uint64
+}
+
+// parseBtrfsScrubStatus parses `btrfs scrub status` output, e.g.
+//
+//	UUID:             2d5f0c52-...
+//	Scrub started:    Sun Oct 12 03:00:01 2026
+//	Status:           finished
+//	Duration:         0:05:12
+//	Error summary:    csum=3
+//	  Corrected:      3
+//	  Uncorrectable:  0
+//
+// A filesystem that was never scrubbed reports "no stats available".
+func parseBtrfsScrubStatus(output string) btrfsScrub {
+	var scrub btrfsScrub
+	for line := range strings.SplitSeq(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "no stats available") {
+			scrub.status = "never"
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Status":
+			scrub.status = value
+		case "Scrub started":
+			scrub.started = value
+		case "Error summary":
+			scrub.errorSummary = value
+		case "Corrected":
+			scrub.corrected, _ = strconv.ParseUint(value, 10, 64)
+		case "Uncorrectable":
+			scrub.uncorrectable, _ = strconv.ParseUint(value, 10, 64)
+		}
+	}
+	return scrub
+}
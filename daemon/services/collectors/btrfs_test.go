@@ -0,0 +1,72 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBtrfsMounts(t *testing.T) {
+	mounts := parseBtrfsMounts(strings.NewReader(`rootfs / rootfs rw 0 0
+/dev/md1p1 /mnt/disk1 xfs rw,noatime 0 0
+/dev/nvme0n1p1 /mnt/cache btrfs rw,noatime,ssd,space_cache=v2 0 0
+/dev/loop2 /var/lib/docker btrfs rw,noatime 0 0
+/dev/nvme0n1p1 /mnt/cache/appdata btrfs rw,noatime 0 0
+/dev/sdc1 /mnt/fast\040pool btrfs rw 0 0
+`))
+	want := []string{"/mnt/cache", "/mnt/fast pool", "/var/lib/docker"}
+	if strings.Join(mounts, ",") != strings.Join(want, ",") {
+		t.Errorf("mounts = %q, want %q", mounts, want)
+	}
+}
+
+func TestParseBtrfsDeviceStats(t *testing.T) {
+	devices := parseBtrfsDeviceStats(`[/dev/nvme0n1p1].write_io_errs    0
+[/dev/nvme0n1p1].read_io_errs     2
+[/dev/nvme0n1p1].flush_io_errs    0
+[/dev/nvme0n1p1].corruption_errs  5
+[/dev/nvme0n1p1].generation_errs  0
+[/dev/nvme1n1p1].write_io_errs    0
+[/dev/nvme1n1p1].read_io_errs     0
+[/dev/nvme1n1p1].flush_io_errs    0
+[/dev/nvme1n1p1].corruption_errs  0
+[/dev/nvme1n1p1].generation_errs  1
+`)
+	if len(devices) != 2 || devices[0].Device != "/dev/nvme0n1p1" || devices[1].Device != "/dev/nvme1n1p1" {
+		t.Fatalf("devices = %+v", devices)
+	}
+	if devices[0].ReadIOErrors != 2 || devices[0].CorruptionErrors != 5 || devices[0].TotalErrors() != 7 {
+		t.Errorf("device 0 = %+v", devices[0])
+	}
+	if devices[1].GenerationErrors != 1 || devices[1].TotalErrors() != 1 {
+		t.Errorf("device 1 = %+v", devices[1])
+	}
+
+	if devices := parseBtrfsDeviceStats("ERROR: not a btrfs filesystem: /mnt/disk1\n"); devices == nil || len(devices) != 0 {
+		t.Errorf("error output devices = %#v, want empty slice", devices)
+	}
+}
+
+func TestParseBtrfsScrubStatus(t *testing.T) {
+	scrub := parseBtrfsScrubStatus(`UUID:             2d5f0c52-0a8e-4c1e-9a3f-7e1d2b3c4d5e
+Scrub started:    Sun Oct 12 03:00:01 2026
+Status:           finished
+Duration:         0:05:12
+Total to scrub:   380.00GiB
+Rate:             1.22GiB/s
+Error summary:    csum=3
+  Corrected:      2
+  Uncorrectable:  1
+  Unverified:     0
+`)
+	if scrub.status != "finished" || scrub.started != "Sun Oct 12 03:00:01 2026" || scrub.errorSummary != "csum=3" {
+		t.Errorf("scrub = %+v", scrub)
+	}
+	if scrub.corrected != 2 || scrub.uncorrectable != 1 {
+		t.Errorf("corrected/uncorrectable = %d/%d", scrub.corrected, scrub.uncorrectable)
+	}
+
+	scrub = parseBtrfsScrubStatus("UUID:             2d5f0c52\n\tno stats available\n")
+	if scrub.status != "never" {
+		t.Errorf("never-scrubbed status = %q", scrub.status)
+	}
+}
@@ -13,6 +13,14 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 )
 
+// Share config locations, replaceable for testing.
+var (
+	shareCfgDir = "/boot/config/shares"
+	// moverTuningDir is the Mover Tuning plugin's config directory; per-share
+	// overrides live in its shareOverrideConfig subdirectory.
+	moverTuningDir = "/boot/config/plugins/ca.mover.tuning"
+)
+
 // ConfigCollector collects configuration data
 type ConfigCollector struct{}
 
@@ -28,14 +36,14 @@ func (c *ConfigCollector) GetShareConfig(shareName string) (*dto.ShareConfig, er
 		return nil, err
 	}
 
-	configPath := fmt.Sprintf("/boot/config/shares/%s.cfg", shareName)
+	configPath := filepath.Join(shareCfgDir, shareName+".cfg")
 	logger.Debug("Config: Reading share config from %s", configPath)
 
 	// #nosec G304 - Path is validated by validateShareName() to prevent path traversal
 	file, err := os.Open(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("share config not found: %s", shareName)
+			return nil, fmt.Errorf("share config not found: %s: %w", shareName, os.ErrNotExist)
 		}
 		return nil, fmt.Errorf("failed to open share config: %w", err)
 	}
@@ -84,6 +92,8 @@ func (c *ConfigCollector) GetShareConfig(shareName string) (*dto.ShareConfig, er
 			}
 		case "shareUseCache":
 			config.UseCache = value
+		case "shareCachePool":
+			config.CachePool = value
 		case "shareExport":
 			config.Export = value
 		case "shareSecurity":
@@ -95,6 +105,7 @@ func (c *ConfigCollector) GetShareConfig(shareName string) (*dto.ShareConfig, er
 		return nil, fmt.Errorf("error reading share config: %w", err)
 	}
 
+	config.MoverTuning = readShareMoverTuning(shareName)
 	return config, nil
 }
 
@@ -318,10 +329,12 @@ func (c *ConfigCollector) GetVMSettings() (*dto.VMSettings, error) {
 	file, err := os.Open(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return &dto.VMSettings{
+			settings := &dto.VMSettings{
 				Enabled:   false,
 				Timestamp: time.Now(),
-			}, nil
+			}
+			c.addHostDevices(settings)
+			return settings, nil
 		}
 		return nil, fmt.Errorf("failed to open VM config: %w", err)
 	}
@@ -372,87 +385,179 @@ func (c *ConfigCollector) GetVMSettings() (*dto.VMSettings, error) {
 		return nil, fmt.Errorf("error reading VM config: %w", err)
 	}
 
+	c.addHostDevices(settings)
 	return settings, nil
 }
 
-// UpdateShareConfig writes share configuration to /boot/config/shares/{name}.cfg
+// UpdateShareConfig merges share configuration into /boot/config/shares/{name}.cfg.
+// Only the fields that are set are written; every other key in the file,
+// including settings this API does not model, is kept. The previous file is
+// saved as {name}.cfg.bak. When MoverTuning is set, the share's Mover Tuning
+// override is updated as well.
 func (c *ConfigCollector) UpdateShareConfig(config *dto.ShareConfig) error {
 	// Validate share name to prevent path traversal
 	if err := validateShareName(config.Name); err != nil {
 		return err
 	}
+	if config.MoverTuning != nil && !MoverTuningInstalled() {
+		return fmt.Errorf("mover tuning plugin is not installed")
+	}
 
-	configPath := fmt.Sprintf("/boot/config/shares/%s.cfg", config.Name)
+	configPath := filepath.Join(shareCfgDir, config.Name+".cfg")
 	logger.Info("Config: Writing share config to %s", configPath)
 
-	// Create backup
-	backupPath := configPath + ".bak"
-	if _, err := os.Stat(configPath); err == nil {
-		if err := os.Rename(configPath, backupPath); err != nil {
-			logger.Error("Config: Failed to create backup: %v", err)
-		}
+	if err := mergeCfgFile(configPath, shareConfigValues(config), true); err != nil {
+		return fmt.Errorf("failed to write share config: %w", err)
 	}
 
-	// #nosec G304 - Path is validated by validateShareName() to prevent path traversal
-	file, err := os.Create(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to create share config: %w", err)
-	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			logger.Debug("Error closing share config file: %v", err)
+	if config.MoverTuning != nil {
+		overridePath := filepath.Join(moverTuningDir, "shareOverrideConfig", config.Name+".cfg")
+		if err := mergeCfgFile(overridePath, moverTuningValues(config.MoverTuning), false); err != nil {
+			return fmt.Errorf("failed to write mover tuning override: %w", err)
 		}
-	}()
+	}
 
-	// Write configuration
-	if config.Comment != "" {
-		if _, err := fmt.Fprintf(file, "shareComment=\"%s\"\n", config.Comment); err != nil {
-			return fmt.Errorf("failed to write shareComment: %w", err)
-		}
+	logger.Info("Config: Share config written successfully")
+	return nil
+}
+
+// cfgValue is one key="value" line of an Unraid .cfg file.
+type cfgValue struct {
+	key, value string
+}
+
+// shareConfigValues returns the share .cfg keys for the fields set in config.
+func shareConfigValues(config *dto.ShareConfig) []cfgValue {
+	var values []cfgValue
+	add := func(key, value string) {
+		if value != "" {
+			values = append(values, cfgValue{key, value})
+		}
+	}
+	add("shareComment", config.Comment)
+	add("shareAllocator", config.Allocator)
+	add("shareFloor", config.Floor)
+	add("shareSplitLevel", config.SplitLevel)
+	add("shareInclude", strings.Join(config.IncludeDisks, ","))
+	add("shareExclude", strings.Join(config.ExcludeDisks, ","))
+	add("shareUseCache", config.UseCache)
+	add("shareCachePool", config.CachePool)
+	add("shareExport", config.Export)
+	add("shareSecurity", config.Security)
+	return values
+}
+
+// moverTuningValues returns the Mover Tuning override keys for tuning. Unset
+// thresholds are left as they are; an age or size of 0 turns that filter off.
+func moverTuningValues(tuning *dto.ShareMoverTuning) []cfgValue {
+	values := []cfgValue{{"moverOverride", yesNo(tuning.Override)}}
+	if tuning.ThresholdPercent != nil {
+		values = append(values, cfgValue{"threshold", strconv.Itoa(*tuning.ThresholdPercent)})
 	}
-	if config.Allocator != "" {
-		if _, err := fmt.Fprintf(file, "shareAllocator=\"%s\"\n", config.Allocator); err != nil {
-			return fmt.Errorf("failed to write shareAllocator: %w", err)
+	if tuning.AgeDays != nil {
+		values = append(values, cfgValue{"age", yesNo(*tuning.AgeDays > 0)})
+		if *tuning.AgeDays > 0 {
+			values = append(values, cfgValue{"daysold", strconv.Itoa(*tuning.AgeDays)})
 		}
 	}
-	if config.Floor != "" {
-		if _, err := fmt.Fprintf(file, "shareFloor=\"%s\"\n", config.Floor); err != nil {
-			return fmt.Errorf("failed to write shareFloor: %w", err)
+	if tuning.MinSizeMB != nil {
+		values = append(values, cfgValue{"sizef", yesNo(*tuning.MinSizeMB > 0)})
+		if *tuning.MinSizeMB > 0 {
+			values = append(values, cfgValue{"sizeinM", strconv.Itoa(*tuning.MinSizeMB)})
 		}
 	}
-	if config.SplitLevel != "" {
-		if _, err := fmt.Fprintf(file, "shareSplitLevel=\"%s\"\n", config.SplitLevel); err != nil {
-			return fmt.Errorf("failed to write shareSplitLevel: %w", err)
-		}
+	return values
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
 	}
-	if len(config.IncludeDisks) > 0 {
-		if _, err := fmt.Fprintf(file, "shareInclude=\"%s\"\n", strings.Join(config.IncludeDisks, ",")); err != nil {
-			return fmt.Errorf("failed to write shareInclude: %w", err)
-		}
+	return "no"
+}
+
+// mergeCfgFile sets values in the .cfg file at path, replacing existing keys
+// in place and appending new ones, and writes it through a temporary file so
+// readers never see a partial file. A missing file is created. With backup
+// set, the previous contents are kept in path+".bak".
+func mergeCfgFile(path string, values []cfgValue, backup bool) error {
+	// #nosec G304 - path is built from a validated share name
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
 	}
-	if len(config.ExcludeDisks) > 0 {
-		if _, err := fmt.Fprintf(file, "shareExclude=\"%s\"\n", strings.Join(config.ExcludeDisks, ",")); err != nil {
-			return fmt.Errorf("failed to write shareExclude: %w", err)
+
+	pending := make(map[string]string, len(values))
+	for _, v := range values {
+		pending[v.key] = v.value
+	}
+
+	var out []string
+	if len(data) > 0 {
+		for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+			key, _, found := strings.Cut(line, "=")
+			key = strings.TrimSpace(key)
+			if value, ok := pending[key]; found && ok {
+				line = fmt.Sprintf("%s=%q", key, value)
+				delete(pending, key)
+			}
+			out = append(out, line)
 		}
 	}
-	if config.UseCache != "" {
-		if _, err := fmt.Fprintf(file, "shareUseCache=\"%s\"\n", config.UseCache); err != nil {
-			return fmt.Errorf("failed to write shareUseCache: %w", err)
+	for _, v := range values {
+		if _, ok := pending[v.key]; ok {
+			out = append(out, fmt.Sprintf("%s=%q", v.key, v.value))
 		}
 	}
-	if config.Export != "" {
-		if _, err := fmt.Fprintf(file, "shareExport=\"%s\"\n", config.Export); err != nil {
-			return fmt.Errorf("failed to write shareExport: %w", err)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { // #nosec G301 - flash config directories are world-readable
+		return err
+	}
+	if backup && len(data) > 0 {
+		if err := os.WriteFile(path+".bak", data, 0o644); err != nil { // #nosec G306 - share configs are world-readable
+			logger.Error("Config: Failed to create backup: %v", err)
 		}
 	}
-	if config.Security != "" {
-		if _, err := fmt.Fprintf(file, "shareSecurity=\"%s\"\n", config.Security); err != nil {
-			return fmt.Errorf("failed to write shareSecurity: %w", err)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(out, "\n")+"\n"), 0o644); err != nil { // #nosec G306 - share configs are world-readable
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// MoverTuningInstalled reports whether the Mover Tuning plugin is installed.
+func MoverTuningInstalled() bool {
+	info, err := os.Stat(moverTuningDir)
+	return err == nil && info.IsDir()
+}
+
+// readShareMoverTuning reads the share's Mover Tuning override, returning nil
+// when the plugin is not installed or the share has none.
+func readShareMoverTuning(shareName string) *dto.ShareMoverTuning {
+	// #nosec G304 - shareName is validated by the caller
+	data, err := os.ReadFile(filepath.Join(moverTuningDir, "shareOverrideConfig", shareName+".cfg"))
+	if err != nil {
+		return nil
+	}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		if key, value, found := strings.Cut(line, "="); found {
+			values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
 		}
 	}
 
-	logger.Info("Config: Share config written successfully")
-	return nil
+	tuning := &dto.ShareMoverTuning{Override: values["moverOverride"] == "yes"}
+	if v, err := strconv.Atoi(values["threshold"]); err == nil {
+		tuning.ThresholdPercent = &v
+	}
+	if v, err := strconv.Atoi(values["daysold"]); err == nil && values["age"] == "yes" {
+		tuning.AgeDays = &v
+	}
+	if v, err := strconv.Atoi(values["sizeinM"]); err == nil && values["sizef"] == "yes" {
+		tuning.MinSizeMB = &v
+	}
+	return tuning
 }
 
 // UpdateSystemSettings writes system settings to /boot/config/ident.cfg
@@ -608,9 +713,8 @@ func validateShareName(name string) error {
 	}
 
 	// Additional security: ensure the resolved path stays within the shares directory
-	const sharesDir = "/boot/config/shares"
-	cleanPath := filepath.Clean(filepath.Join(sharesDir, name+".cfg"))
-	if !strings.HasPrefix(cleanPath, sharesDir) {
+	cleanPath := filepath.Clean(filepath.Join(shareCfgDir, name+".cfg"))
+	if !strings.HasPrefix(cleanPath, shareCfgDir) {
 		return fmt.Errorf("invalid share name: path escapes shares directory")
 	}
 
@@ -0,0 +1,195 @@
+package collectors
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"gopkg.in/ini.v1"
+)
+
+// Package-level variables (not constants) so tests can use fixture files.
+var (
+	connectPluginDir = constants.ConnectPluginDir
+	connectCfg       = constants.ConnectCfg
+	connectStateCfg  = constants.ConnectStateCfg
+	flashBackupIni   = constants.FlashBackupIni
+	flashGitDir      = constants.FlashGitDir
+)
+
+// ConnectCollector reads the Unraid Connect plugin state: account sign-in,
+// the cloud connection, flash backup and remote access. Secrets in the
+// plugin configuration (API keys, tokens, email) are never read into the
+// result.
+type ConnectCollector struct {
+	ctx *domain.Context
+}
+
+// NewConnectCollector creates a new Unraid Connect collector.
+func NewConnectCollector(ctx *domain.Context) *ConnectCollector {
+	return &ConnectCollector{ctx: ctx}
+}
+
+// Start begins the Unraid Connect collection loop.
+func (c *ConnectCollector) Start(ctx context.Context, interval time.Duration) {
+	logger.InfoContext(ctx, "Connect collector started (interval: %v)", interval)
+
+	collect := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "Connect collector", r)
+			}
+		}()
+		collectWithWatchdog(ctx, "Connect", interval, c.Collect)
+	}
+	collect()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Connect collector stopped")
+			return
+		case <-ticker.C:
+			collect()
+		}
+	}
+}
+
+// Collect reads the Unraid Connect state and publishes it.
+func (c *ConnectCollector) Collect() {
+	status := collectConnectStatus(time.Now())
+	domain.Publish(c.ctx.Hub, constants.TopicConnectUpdate, status)
+	logger.Debug("Connect: published installed=%v signed_in=%v cloud=%s", status.Installed, status.SignedIn, status.CloudStatus)
+}
+
+// collectConnectStatus reads the plugin configuration and the runtime state
+// files. Only the installed flag is set when the plugin is absent.
+func collectConnectStatus(now time.Time) *dto.ConnectStatus {
+	status := &dto.ConnectStatus{Timestamp: now}
+	status.RemoteAccess.Type = "disabled"
+	if info, err := os.Stat(connectPluginDir); err != nil || !info.IsDir() {
+		return status
+	}
+	status.Installed = true
+
+	if cfg, err := ini.Load(connectCfg); err == nil {
+		remote := cfg.Section("remote")
+		status.Username = iniValue(remote, "username")
+		status.SignedIn = status.Username != "" || iniValue(remote, "apikey") != ""
+		status.RemoteAccess = parseRemoteAccess(remote)
+	} else {
+		logger.Debug("Connect: failed to load %s: %v", connectCfg, err)
+	}
+
+	if cfg, err := ini.Load(connectStateCfg); err == nil {
+		conn := cfg.Section("connectionStatus")
+		status.CloudStatus = iniValue(conn, "minigraph")
+		status.CloudConnected = strings.EqualFold(status.CloudStatus, "CONNECTED")
+		status.RemoteAccess.UPnPStatus = iniValue(conn, "upnpStatus")
+	}
+
+	status.FlashBackup = collectFlashBackup()
+	return status
+}
+
+// parseRemoteAccess maps the [remote] settings of myservers.cfg onto the
+// remote access mode.
+func parseRemoteAccess(remote *ini.Section) dto.ConnectRemoteAccess {
+	access := dto.ConnectRemoteAccess{
+		Type:        "disabled",
+		UPnPEnabled: iniValue(remote, "upnpEnabled") == "yes",
+	}
+	if port, err := strconv.Atoi(iniValue(remote, "wanport")); err == nil {
+		access.WANPort = port
+	}
+	switch dynamic := strings.ToUpper(iniValue(remote, "dynamicRemoteAccessType")); dynamic {
+	case "STATIC", "UPNP":
+		access.Enabled = true
+		access.Type = "dynamic_" + strings.ToLower(dynamic)
+	default:
+		if iniValue(remote, "wanaccess") == "yes" {
+			access.Enabled = true
+			access.Type = "static"
+			if access.UPnPEnabled {
+				access.Type = "upnp"
+			}
+		}
+	}
+	return access
+}
+
+// collectFlashBackup reads the flash backup status. Backup is activated
+// when the plugin reports it or the flash drive holds the backup repository.
+func collectFlashBackup() dto.ConnectFlashBackup {
+	var backup dto.ConnectFlashBackup
+	if cfg, err := ini.Load(flashBackupIni); err == nil {
+		section := cfg.Section("")
+		backup.Activated = iniValue(section, "activated") == "yes"
+		if v := iniValue(section, "uptodate"); v != "" {
+			upToDate := v == "yes"
+			backup.UpToDate = &upToDate
+		}
+		backup.Error = iniValue(section, "error")
+		if backup.Error == "" {
+			backup.Error = iniValue(section, "remoteerror")
+		}
+	}
+	if info, err := os.Stat(flashGitDir); err == nil && info.IsDir() {
+		backup.Activated = true
+		backup.LastSync = lastFlashBackupPush()
+	}
+	return backup
+}
+
+// lastFlashBackupPush returns the time of the last push recorded in the
+// reflog of the backup repository's remote branch, or nil.
+func lastFlashBackupPush() *time.Time {
+	matches, _ := filepath.Glob(filepath.Join(flashGitDir, "logs", "refs", "remotes", "origin", "*"))
+	var latest *time.Time
+	for _, path := range matches {
+		if t := lastReflogTime(path); t != nil && (latest == nil || t.After(*latest)) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// lastReflogTime parses the timestamp of the last entry of a git reflog,
+// whose lines read "<old> <new> <name> <email> <unix-time> <tz>\t<message>".
+func lastReflogTime(path string) *time.Time {
+	file, err := os.Open(path) //nolint:gosec // G304: path from a glob of the flash backup reflogs
+	if err != nil {
+		return nil
+	}
+	defer file.Close() //nolint:errcheck
+
+	var last string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			last = line
+		}
+	}
+	header, _, _ := strings.Cut(last, "\t")
+	fields := strings.Fields(header)
+	if len(fields) < 2 {
+		return nil
+	}
+	sec, err := strconv.ParseInt(fields[len(fields)-2], 10, 64)
+	if err != nil {
+		return nil
+	}
+	t := time.Unix(sec, 0)
+	return &t
+}
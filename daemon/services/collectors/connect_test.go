@@ -0,0 +1,102 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/ini.v1"
+)
+
+func TestCollectConnectStatus(t *testing.T) {
+	root := t.TempDir()
+	origPlugin, origCfg, origState := connectPluginDir, connectCfg, connectStateCfg
+	origBackup, origGit := flashBackupIni, flashGitDir
+	t.Cleanup(func() {
+		connectPluginDir, connectCfg, connectStateCfg = origPlugin, origCfg, origState
+		flashBackupIni, flashGitDir = origBackup, origGit
+	})
+	connectPluginDir = filepath.Join(root, "plugin")
+	connectCfg = filepath.Join(root, "myservers.cfg")
+	connectStateCfg = filepath.Join(root, "state.cfg")
+	flashBackupIni = filepath.Join(root, "flashbackup.ini")
+	flashGitDir = filepath.Join(root, ".git")
+
+	now := time.Date(2026, 5, 20, 12, 0, 0, 0, time.UTC)
+	if status := collectConnectStatus(now); status.Installed || status.RemoteAccess.Type != "disabled" {
+		t.Fatalf("plugin not installed: status = %+v", status)
+	}
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(connectPluginDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(connectCfg, `[api]
+version="4.1.3"
+[remote]
+wanaccess="yes"
+wanport="33443"
+upnpEnabled="no"
+apikey="unraid_secret"
+username="tower-admin"
+email="admin@example.com"
+`)
+	writeFile(connectStateCfg, `[connectionStatus]
+minigraph="CONNECTED"
+upnpStatus=""
+`)
+	writeFile(flashBackupIni, `activated="yes"
+uptodate="no"
+error=""
+`)
+	writeFile(filepath.Join(flashGitDir, "logs", "refs", "remotes", "origin", "main"),
+		"0000 1111 root <gitbot@unraid.net> 1747735200 +0000\tupdate by push\n"+
+			"1111 2222 root <gitbot@unraid.net> 1747742400 +0000\tupdate by push\n")
+
+	status := collectConnectStatus(now)
+	if !status.Installed || !status.SignedIn || status.Username != "tower-admin" || !status.CloudConnected {
+		t.Fatalf("status = %+v", status)
+	}
+	if ra := status.RemoteAccess; !ra.Enabled || ra.Type != "static" || ra.WANPort != 33443 {
+		t.Errorf("remote access = %+v", ra)
+	}
+	fb := status.FlashBackup
+	if !fb.Activated || fb.UpToDate == nil || *fb.UpToDate || fb.LastSync == nil || fb.LastSync.Unix() != 1747742400 {
+		t.Errorf("flash backup = %+v", fb)
+	}
+}
+
+func TestParseRemoteAccess(t *testing.T) {
+	tests := []struct {
+		name, cfg string
+		want      string
+		enabled   bool
+	}{
+		{"disabled", `wanaccess="no"`, "disabled", false},
+		{"static", `wanaccess="yes"`, "static", true},
+		{"upnp", "wanaccess=\"yes\"\nupnpEnabled=\"yes\"", "upnp", true},
+		{"dynamic upnp", `dynamicRemoteAccessType="UPNP"`, "dynamic_upnp", true},
+		{"dynamic disabled", `dynamicRemoteAccessType="DISABLED"`, "disabled", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ini.Load([]byte("[remote]\n" + tt.cfg + "\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := parseRemoteAccess(cfg.Section("remote"))
+			if got.Type != tt.want || got.Enabled != tt.enabled {
+				t.Errorf("parseRemoteAccess = %+v, want type %s enabled %v", got, tt.want, tt.enabled)
+			}
+		})
+	}
+}
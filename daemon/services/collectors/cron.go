@@ -0,0 +1,112 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchDays bounds the search for the next run of a cron spec. Schedules
+// such as "0 0 29 2 *" only fire in leap years.
+const cronSearchDays = 5 * 366
+
+// nextCronTime returns the first time after from at which a five-field cron
+// spec ("minute hour day-of-month month day-of-week") fires, in from's
+// location. Fields accept *, numbers, ranges, lists and steps. As in cron,
+// when both day fields are restricted a day matching either one fires.
+func nextCronTime(spec string, from time.Time) (time.Time, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return time.Time{}, fmt.Errorf("cron spec %q: want 5 fields, got %d", spec, len(fields))
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var sets [5]map[int]bool
+	for i, f := range fields {
+		set, err := parseCronField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cron spec %q: %w", spec, err)
+		}
+		sets[i] = set
+	}
+	minutes, hours, doms, months, dows := sets[0], sets[1], sets[2], sets[3], sets[4]
+	if dows[7] {
+		dows[0] = true // 7 is Sunday too
+	}
+	domAny, dowAny := fields[2] == "*", fields[4] == "*"
+
+	start := from.Truncate(time.Minute).Add(time.Minute)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for range cronSearchDays {
+		domMatch, dowMatch := doms[day.Day()], dows[int(day.Weekday())]
+		var dayMatch bool
+		switch {
+		case domAny:
+			dayMatch = dowMatch
+		case dowAny:
+			dayMatch = domMatch
+		default:
+			dayMatch = domMatch || dowMatch
+		}
+		if months[int(day.Month())] && dayMatch {
+			for h := range 24 {
+				if !hours[h] {
+					continue
+				}
+				for m := range 60 {
+					if !minutes[m] {
+						continue
+					}
+					t := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+					if !t.Before(start) {
+						return t, nil
+					}
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, fmt.Errorf("cron spec %q never fires", spec)
+}
+
+// parseCronField expands one cron field into the set of values it matches.
+func parseCronField(field string, lo, hi int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for part := range strings.SplitSeq(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+		from, to := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if from, err = strconv.Atoi(a); err != nil {
+				return nil, fmt.Errorf("invalid value in %q", part)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(b); err != nil {
+					return nil, fmt.Errorf("invalid range in %q", part)
+				}
+			} else if hasStep {
+				to = hi
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return nil, fmt.Errorf("%q out of range %d-%d", part, lo, hi)
+		}
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	if len(set) == 0 {
+		return nil, errors.New("empty field")
+	}
+	return set, nil
+}
@@ -0,0 +1,51 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextCronTime(t *testing.T) {
+	// Wednesday 2026-10-14 10:30 UTC
+	from := time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"0 0 1 1 *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},         // yearly
+		{"0 3 * * 0", time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)},       // weekly on Sunday
+		{"0 3 * * 7", time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)},       // 7 is Sunday too
+		{"30 10 * * *", time.Date(2026, 10, 15, 10, 30, 0, 0, time.UTC)},   // strictly after from
+		{"*/15 * * * *", time.Date(2026, 10, 14, 10, 45, 0, 0, time.UTC)},  // steps
+		{"0 2 1 */3 *", time.Date(2027, 1, 1, 2, 0, 0, 0, time.UTC)},       // quarterly
+		{"0 0 20 * 1-5", time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},    // either day field matches
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},       // leap day
+		{"0 22,23 * 10 3", time.Date(2026, 10, 14, 22, 0, 0, 0, time.UTC)}, // lists
+		{"5 4 1-7 * *", time.Date(2026, 11, 1, 4, 5, 0, 0, time.UTC)},      // ranges
+		{"0 0 5/10 11 *", time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC)},    // value with step
+		{"0 0 13 10 *", time.Date(2027, 10, 13, 0, 0, 0, 0, time.UTC)},     // next year
+		{"0 0 31 11 *", time.Time{}},                                       // never fires
+		{"0 0 1 1", time.Time{}},                                           // too few fields
+		{"61 0 * * *", time.Time{}},                                        // out of range
+		{"0 0 * * mon", time.Time{}},                                       // names unsupported
+		{"*/0 * * * *", time.Time{}},                                       // zero step
+	}
+
+	for _, tt := range tests {
+		got, err := nextCronTime(tt.spec, from)
+		if tt.want.IsZero() {
+			if err == nil {
+				t.Errorf("nextCronTime(%q) = %v, want error", tt.spec, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("nextCronTime(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("nextCronTime(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
@@ -17,13 +17,31 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 )
 
+// Devices are enriched concurrently so one slow or hung drive does not hold
+// up the others on servers with many drives.
+const (
+	// diskCollectConcurrency bounds the devices enriched at once.
+	diskCollectConcurrency = 4
+	// diskDeviceTimeout is how long one device may take before it is
+	// published with its disks.ini data only.
+	diskDeviceTimeout = 20 * time.Second
+)
+
 // DiskCollector collects detailed information about all disks in the Unraid system.
-// It gathers disk metrics, SMART data, temperature, and usage statistics for array and cache disks.
+// It gathers disk metrics, SMART data, temperature, and usage statistics for array,
+// pool and unassigned disks.
 type DiskCollector struct {
-	ctx             *domain.Context
-	mu              sync.Mutex
+	ctx           *domain.Context
+	mu            sync.Mutex
+	deviceTimeout time.Duration
+
+	// stateMu guards the fields below, which device goroutines still use
+	// after a timed-out cycle has moved on.
+	stateMu         sync.Mutex
 	prevIOTicks     map[string]uint64
 	prevCollectTime time.Time
+	busy            map[string]bool // devices whose collection is running
+	smart           map[string]*smartCacheEntry
 }
 
 type zfsPoolUsage struct {
@@ -36,21 +54,24 @@ type zfsPoolUsage struct {
 // NewDiskCollector creates a new disk information collector with the given context.
 func NewDiskCollector(ctx *domain.Context) *DiskCollector {
 	return &DiskCollector{
-		ctx:         ctx,
-		prevIOTicks: make(map[string]uint64),
+		ctx:           ctx,
+		deviceTimeout: diskDeviceTimeout,
+		prevIOTicks:   make(map[string]uint64),
+		busy:          make(map[string]bool),
+		smart:         make(map[string]*smartCacheEntry),
 	}
 }
 
 // Start begins the disk collector's periodic data collection.
 // It runs in a goroutine and publishes disk information updates at the specified interval until the context is cancelled.
 func (c *DiskCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting disk collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting disk collector (interval: %v)", interval)
 
 	// Run once immediately with panic recovery
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Disk collector", r)
+				logger.LogPanicWithStackContext(ctx, "Disk collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "Disk", interval, c.Collect)
@@ -60,11 +81,11 @@ func (c *DiskCollector) Start(ctx context.Context, interval time.Duration) {
 	watchedFiles := []string{constants.DisksIni}
 	fw, err := NewFileWatcher(500 * time.Millisecond)
 	if err != nil {
-		logger.Warning("Disk collector: failed to create file watcher, using ticker only: %v", err)
+		logger.WarningContext(ctx, "Disk collector: failed to create file watcher, using ticker only: %v", err)
 	} else {
 		for _, f := range watchedFiles {
 			if watchErr := fw.WatchFile(f); watchErr != nil {
-				logger.Warning("Disk collector: failed to watch %s: %v", f, watchErr)
+				logger.WarningContext(ctx, "Disk collector: failed to watch %s: %v", f, watchErr)
 			}
 		}
 		// Close is deferred inside the goroutine to avoid racing with fw.Run()
@@ -74,15 +95,15 @@ func (c *DiskCollector) Start(ctx context.Context, interval time.Duration) {
 				func() {
 					defer func() {
 						if r := recover(); r != nil {
-							logger.LogPanicWithStack("Disk collector (fsnotify)", r)
+							logger.LogPanicWithStackContext(ctx, "Disk collector (fsnotify)", r)
 						}
 					}()
-					logger.Debug("Disk collector: disks.ini changed, collecting immediately")
+					logger.DebugContext(ctx, "Disk collector: disks.ini changed, collecting immediately")
 					collectWithWatchdog(ctx, "Disk", interval, c.Collect)
 				}()
 			})
 		}()
-		logger.Info("Disk collector: fsnotify watching %v for instant updates", watchedFiles)
+		logger.InfoContext(ctx, "Disk collector: fsnotify watching %v for instant updates", watchedFiles)
 	}
 
 	ticker := time.NewTicker(interval)
@@ -91,13 +112,13 @@ func (c *DiskCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Disk collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "Disk collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Disk collector", r)
+						logger.LogPanicWithStackContext(ctx, "Disk collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "Disk", interval, c.Collect)
@@ -158,16 +179,19 @@ func (c *DiskCollector) collectDisks() ([]dto.DiskInfo, error) {
 	logger.Debug("Disk: Starting collection from %s", constants.DisksIni)
 
 	// Parse disks.ini
-	disks, err := c.parseDisksINI()
+	disks, err := c.parseDisksINI(constants.DisksIni)
 	if err != nil {
 		return nil, err
 	}
+	disks = append(disks, c.collectUnassignedDisks()...)
 
 	// Enhance each disk with additional stats
 	c.enrichDisks(disks)
 
 	// Record the collection timestamp for delta-based IO utilization
+	c.stateMu.Lock()
 	c.prevCollectTime = time.Now()
+	c.stateMu.Unlock()
 
 	logger.Debug("Disk: Parsed %d disks successfully", len(disks))
 
@@ -186,9 +210,11 @@ func (c *DiskCollector) collectDisks() ([]dto.DiskInfo, error) {
 	return disks, nil
 }
 
-// parseDisksINI parses the disks.ini file and returns a slice of DiskInfo
-func (c *DiskCollector) parseDisksINI() ([]dto.DiskInfo, error) {
-	file, err := os.Open(constants.DisksIni)
+// parseDisksINI parses disks.ini, or devs.ini which has the same layout,
+// and returns a slice of DiskInfo
+func (c *DiskCollector) parseDisksINI(path string) ([]dto.DiskInfo, error) {
+	// #nosec G304 -- path is one of the emhttp state files.
+	file, err := os.Open(path)
 	if err != nil {
 		logger.Error("Disk: Failed to open file: %v", err)
 		return nil, err
@@ -203,6 +229,7 @@ func (c *DiskCollector) parseDisksINI() ([]dto.DiskInfo, error) {
 	var disks []dto.DiskInfo
 	scanner := bufio.NewScanner(file)
 	var currentDisk *dto.DiskInfo
+	var currentType string
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -211,6 +238,7 @@ func (c *DiskCollector) parseDisksINI() ([]dto.DiskInfo, error) {
 		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
 			// Save previous disk if exists
 			if currentDisk != nil {
+				applyDiskType(currentDisk, currentType)
 				disks = append(disks, *currentDisk)
 			}
 
@@ -218,17 +246,22 @@ func (c *DiskCollector) parseDisksINI() ([]dto.DiskInfo, error) {
 			currentDisk = &dto.DiskInfo{
 				Timestamp: time.Now(),
 			}
+			currentType = ""
 			continue
 		}
 
 		// Parse key=value pairs
 		if currentDisk != nil && strings.Contains(line, "=") {
+			if value, ok := strings.CutPrefix(line, "type="); ok {
+				currentType = strings.Trim(value, `"`)
+			}
 			c.parseDiskKeyValue(currentDisk, line)
 		}
 	}
 
 	// Save last disk
 	if currentDisk != nil {
+		applyDiskType(currentDisk, currentType)
 		disks = append(disks, *currentDisk)
 	}
 
@@ -259,6 +292,10 @@ func (c *DiskCollector) parseDiskKeyValue(disk *dto.DiskInfo, line string) {
 		disk.ID = value
 	case "status":
 		disk.Status = value
+	case "idx":
+		if slot, err := strconv.Atoi(value); err == nil {
+			disk.Slot = &slot
+		}
 	case "size":
 		if size, err := strconv.ParseUint(value, 10, 64); err == nil {
 			disk.Size = size * 1024 // Unraid disks.ini stores size in KiB (1024-byte blocks)
@@ -285,6 +322,16 @@ func (c *DiskCollector) parseDiskKeyValue(disk *dto.DiskInfo, line string) {
 		}
 	case "format":
 		disk.FileSystem = value
+	case "fsType":
+		// Encrypted filesystems are reported as "luks:xfs", "luks:btrfs", ...
+		if strings.HasPrefix(value, "luks:") {
+			disk.Encrypted = true
+		}
+	case "luksState":
+		if state := luksStateName(value); state != "" {
+			disk.Encrypted = true
+			disk.EncryptionState = state
+		}
 	// Per-disk temperature threshold overrides (Issue #46)
 	case "warning":
 		// Per-disk warning temperature override
@@ -303,36 +350,143 @@ func (c *DiskCollector) parseDiskKeyValue(disk *dto.DiskInfo, line string) {
 	}
 }
 
-// enrichDisks enhances each disk with additional statistics
+// luksStateName maps an Unraid luksState value to an encryption state:
+// 0 = not encrypted (or not opened yet), 1 = unlocked, 2 = key missing,
+// 3 = wrong key.
+func luksStateName(value string) string {
+	switch value {
+	case "", "0":
+		return ""
+	case "1":
+		return dto.EncryptionUnlocked
+	case "3":
+		return dto.EncryptionWrongKey
+	default:
+		return dto.EncryptionLocked
+	}
+}
+
+// enrichDisks enhances each disk with additional statistics. Devices are
+// enriched concurrently; a device that does not finish within the device
+// timeout is marked Partial and keeps its disks.ini data.
 func (c *DiskCollector) enrichDisks(disks []dto.DiskInfo) {
 	zfsPoolUsages := c.getZFSPoolUsages()
+	trims := lastTrims()
 
+	sem := make(chan struct{}, diskCollectConcurrency)
+	var wg sync.WaitGroup
 	for i := range disks {
-		// Get model and serial number
-		c.enrichWithModelAndSerial(&disks[i])
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
-		// Get I/O statistics
-		c.enrichWithIOStats(&disks[i])
+			key := disks[i].Device
+			if key == "" {
+				key = disks[i].Name
+			}
+			// The copy is only read back once its goroutine has finished
+			disk := disks[i]
+			if c.collectDevice(key, func() { c.enrichDisk(&disk, zfsPoolUsages, trims) }) {
+				disks[i] = disk
+			} else {
+				disks[i].Partial = true
+			}
+		})
+	}
+	wg.Wait()
+}
 
-		// Get SMART attributes (if device is available)
-		if disks[i].Device != "" {
-			c.enrichWithSMARTData(&disks[i])
-		}
+// collectDevice runs collect for the device key and reports whether it
+// finished within the device timeout. A collect that times out keeps
+// running in the background, and the device is skipped (false) until it
+// returns, so a hung drive never piles up smartctl processes.
+func (c *DiskCollector) collectDevice(key string, collect func()) bool {
+	c.stateMu.Lock()
+	if c.busy == nil {
+		c.busy = make(map[string]bool)
+	}
+	if c.busy[key] {
+		c.stateMu.Unlock()
+		logger.Warning("Disk: %s is still being collected from an earlier cycle, publishing disks.ini data only", key)
+		return false
+	}
+	c.busy[key] = true
+	c.stateMu.Unlock()
 
-		// Get mount information
-		c.enrichWithMountInfo(&disks[i])
+	done := make(chan bool, 1)
+	go func() {
+		defer func() {
+			c.stateMu.Lock()
+			delete(c.busy, key)
+			c.stateMu.Unlock()
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("Disk collector ("+key+")", r)
+				done <- false
+			}
+		}()
+		collect()
+		done <- true
+	}()
 
-		// Get disk role
-		c.enrichWithRole(&disks[i])
+	timeout := c.timeout()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case ok := <-done:
+		return ok
+	case <-timer.C:
+		logger.Warning("Disk: %s did not respond within %v, publishing disks.ini data only", key, timeout)
+		return false
+	}
+}
 
-		// For ZFS cache/pool disks, override statfs values with pool-level usage
-		// so mirrored pools and child datasets report the same numbers as Unraid.
-		c.enrichWithZFSPoolUsage(&disks[i], zfsPoolUsages)
+// timeout returns how long one device may take.
+func (c *DiskCollector) timeout() time.Duration {
+	if c.deviceTimeout <= 0 {
+		return diskDeviceTimeout
+	}
+	return c.deviceTimeout
+}
 
-		// Get spin state
-		if disks[i].Device != "" {
-			c.enrichWithSpinState(&disks[i])
-		}
+// smartTimeout bounds one smartctl call, so it is killed before the device
+// timeout gives up on the disk.
+func (c *DiskCollector) smartTimeout() time.Duration {
+	return c.timeout() * 3 / 4
+}
+
+// enrichDisk adds model, I/O, SMART, mount, role, controller and spin state
+// data to one disk.
+func (c *DiskCollector) enrichDisk(disk *dto.DiskInfo, zfsPoolUsages map[string]zfsPoolUsage, trims map[string]time.Time) {
+	// Get model and serial number
+	c.enrichWithModelAndSerial(disk)
+
+	// Get I/O statistics
+	c.enrichWithIOStats(disk)
+
+	// Get SMART attributes (if device is available)
+	if disk.Device != "" {
+		c.enrichWithSMARTData(disk)
+	}
+
+	// Get mount information
+	c.enrichWithMountInfo(disk)
+	disk.LastTrim = lastTrimAt(trims, disk.MountPoint)
+
+	// Get disk role
+	c.enrichWithRole(disk)
+
+	// Get controller and port
+	c.enrichWithBlockDevice(disk)
+
+	// For ZFS cache/pool disks, override statfs values with pool-level usage
+	// so mirrored pools and child datasets report the same numbers as Unraid.
+	c.enrichWithZFSPoolUsage(disk, zfsPoolUsages)
+
+	// Get spin state
+	if disk.Device != "" {
+		c.enrichWithSpinState(disk)
 	}
 }
 
@@ -456,11 +610,14 @@ func (c *DiskCollector) enrichWithIOStats(disk *dto.DiskInfo) {
 	if ioTicks, err := strconv.ParseUint(fields[9], 10, 64); err == nil {
 		// io_ticks is cumulative milliseconds spent doing I/O since boot.
 		// Compute utilization as delta(io_ticks) / delta(wall_time) * 100.
+		c.stateMu.Lock()
 		prev, hasPrev := c.prevIOTicks[disk.Device]
 		c.prevIOTicks[disk.Device] = ioTicks
+		prevCollectTime := c.prevCollectTime
+		c.stateMu.Unlock()
 
-		if hasPrev && !c.prevCollectTime.IsZero() {
-			elapsedMs := time.Since(c.prevCollectTime).Milliseconds()
+		if hasPrev && !prevCollectTime.IsZero() {
+			elapsedMs := time.Since(prevCollectTime).Milliseconds()
 			if elapsedMs > 0 && ioTicks >= prev {
 				util := float64(ioTicks-prev) / float64(elapsedMs) * 100.0
 				if util > 100.0 {
@@ -548,11 +705,19 @@ func (c *DiskCollector) isNVMeDevice(device string) bool {
 	return isNVMe
 }
 
+// isSSDDevice reports whether the kernel marks a device as non-rotational.
+func (c *DiskCollector) isSSDDevice(device string) bool {
+	data, err := os.ReadFile("/sys/block/" + device + "/queue/rotational")
+	return err == nil && strings.TrimSpace(string(data)) == "0"
+}
+
 // enrichWithSMARTData adds SMART health status and attributes using smartctl.
-// For SATA/SAS drives it combines -H and -A in a single invocation with -n standby
-// so spun-down disks are never woken up. The attribute table is parsed into
-// SMARTAttributes and the PowerOnHours / PowerCycleCount convenience fields are
-// populated from their well-known attribute IDs.
+// Readings are cached per device so drives are not polled every cycle:
+// spinning disks are re-read every smartActiveRefresh, and disks Unraid
+// reports as spun down (no temperature) only every smartStandbyRefresh, with
+// -n standby so they are never woken. A disk that spins up is re-read on the
+// next cycle. When a disk without an Unraid temperature turns out to be
+// spinning, its temperature is taken from the fresh SMART reading.
 func (c *DiskCollector) enrichWithSMARTData(disk *dto.DiskInfo) {
 	devicePath := "/dev/" + disk.Device
 
@@ -578,15 +743,43 @@ func (c *DiskCollector) enrichWithSMARTData(disk *dto.DiskInfo) {
 
 	// Detect device type for optimized SMART collection
 	isNVMe := c.isNVMeDevice(disk.Device)
+	// Unraid reports no temperature for a spun-down disk; NVMe drives have
+	// no standby mode
+	standby := !isNVMe && disk.Temperature == 0
 
+	now := time.Now()
+	if cached, ok := c.cachedSMART(disk.Device, standby, now); ok {
+		cached.apply(disk)
+		return
+	}
+
+	reading, err := c.readSMART(disk.Device, devicePath, isNVMe)
+	last := c.storeSMART(disk.Device, reading, standby, now)
+	if err != nil {
+		// Disk may be in standby mode (exit code 2) or otherwise inaccessible;
+		// keep the last reading, if any.
+		logger.Debug("Disk: Skipping SMART check for %s (disk may be in standby mode): %v", disk.Device, err)
+		last.apply(disk)
+		return
+	}
+	reading.apply(disk)
+	if disk.Temperature == 0 && reading.temperature > 0 {
+		disk.Temperature = reading.temperature
+	}
+}
+
+// readSMART runs smartctl on the device and parses its health status,
+// attribute table, endurance and temperature.
+func (c *DiskCollector) readSMART(device, devicePath string, isNVMe bool) (*smartReading, error) {
 	var lines []string
 	var err error
 
 	if isNVMe {
 		// NVMe drives don't support standby mode, so we skip the -n standby flag.
-		// -H only — NVMe attribute format differs from ATA; we parse health status only.
-		logger.Debug("Disk: Collecting SMART data for NVMe device %s (no standby check)", disk.Device)
-		lines, err = lib.ExecCommand("smartctl", "-H", devicePath)
+		// -A prints the NVMe health log, which has no ATA attribute table; only
+		// wear and data written are taken from it.
+		logger.Debug("Disk: Collecting SMART data for NVMe device %s (no standby check)", device)
+		lines, err = lib.ExecCommandWithTimeout(c.smartTimeout(), "smartctl", "-H", "-A", devicePath)
 	} else {
 		// SATA/SAS drives: combine health check (-H) and attribute table (-A) in one
 		// call while respecting standby mode (-n standby). Adding -A does not change
@@ -596,18 +789,34 @@ func (c *DiskCollector) enrichWithSMARTData(disk *dto.DiskInfo) {
 		//   0 = Success, disk is active, SMART data retrieved
 		//   2 = Disk is in standby/sleep mode, check skipped (disk NOT woken up)
 		//   Other = Error accessing disk
-		logger.Debug("Disk: Collecting SMART data for SATA/SAS device %s (with standby check)", disk.Device)
-		lines, err = lib.ExecCommand("smartctl", "-n", "standby", "-H", "-A", devicePath)
+		logger.Debug("Disk: Collecting SMART data for SATA/SAS device %s (with standby check)", device)
+		lines, err = lib.ExecCommandWithTimeout(c.smartTimeout(), "smartctl", "-n", "standby", "-H", "-A", devicePath)
 	}
-
 	if err != nil {
-		// Disk may be in standby mode (exit code 2) or otherwise inaccessible.
-		// The SMART status will remain as the last known value or UNKNOWN.
-		logger.Debug("Disk: Skipping SMART check for %s (disk may be in standby mode): %v", disk.Device, err)
-		return
+		return nil, err
 	}
 
-	logger.Debug("Disk: Successfully retrieved SMART health for %s", disk.Device)
+	logger.Debug("Disk: Successfully retrieved SMART health for %s", device)
+	disk := &dto.DiskInfo{Device: device, SMARTStatus: "UNKNOWN"}
+	c.parseSMARTOutput(disk, lines, isNVMe)
+	return &smartReading{
+		status:            disk.SMARTStatus,
+		attributes:        disk.SMARTAttributes,
+		powerOnHours:      disk.PowerOnHours,
+		powerCycleCount:   disk.PowerCycleCount,
+		wearPercent:       disk.WearPercent,
+		totalBytesWritten: disk.TotalBytesWritten,
+		temperature:       parseSMARTTemperature(lines, disk.SMARTAttributes),
+		collectedAt:       time.Now(),
+	}, nil
+}
+
+// parseSMARTOutput fills the disk's SMART fields from smartctl -H -A output.
+// The attribute table is parsed into SMARTAttributes and the PowerOnHours /
+// PowerCycleCount convenience fields are populated from their well-known
+// attribute IDs. SSD wear and total writes come from the NVMe health log or
+// the ATA attribute table.
+func (c *DiskCollector) parseSMARTOutput(disk *dto.DiskInfo, lines []string, isNVMe bool) {
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 
@@ -639,6 +848,10 @@ func (c *DiskCollector) enrichWithSMARTData(disk *dto.DiskInfo) {
 		}
 	}
 
+	if isNVMe {
+		parseNVMeEndurance(disk, lines)
+	}
+
 	// Parse the ATA attribute table (only present for SATA/SAS with -A).
 	if !isNVMe {
 		attrs := parseSMARTAttributes(lines)
@@ -655,6 +868,74 @@ func (c *DiskCollector) enrichWithSMARTData(disk *dto.DiskInfo) {
 					disk.PowerCycleCount = v
 				}
 			}
+			if c.isSSDDevice(disk.Device) {
+				applyATAEndurance(disk, attrs)
+			}
+		}
+	}
+}
+
+// nvmeDataUnitBytes is the size of an NVMe "data unit": 1000 512-byte blocks.
+const nvmeDataUnitBytes = 512000
+
+// parseNVMeEndurance reads wear and total writes from the NVMe health log
+// printed by smartctl -A:
+//
+//	Percentage Used:                    3%
+//	Data Units Written:                 12,345,678 [6.32 TB]
+func parseNVMeEndurance(disk *dto.DiskInfo, lines []string) {
+	for _, line := range lines {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Percentage Used":
+			if v, err := strconv.Atoi(strings.TrimSuffix(value, "%")); err == nil {
+				disk.WearPercent = &v
+			}
+		case "Data Units Written":
+			units, _, _ := strings.Cut(value, " ")
+			if v, err := strconv.ParseUint(strings.ReplaceAll(units, ",", ""), 10, 64); err == nil {
+				disk.TotalBytesWritten = v * nvmeDataUnitBytes
+			}
+		}
+	}
+}
+
+// ataWearAttributes are ATA attributes whose normalized value counts down
+// from 100 as an SSD's rated endurance is used, in order of preference:
+// SSD_Life_Left, Media_Wearout_Indicator, Wear_Leveling_Count and
+// Percent_Lifetime_Remain. HDDs reuse some of these IDs, so callers only
+// apply them to SSDs.
+var ataWearAttributes = []string{"231", "233", "177", "202"}
+
+// ataWriteUnits maps the names vendors give attribute 241 to its unit size.
+var ataWriteUnits = map[string]uint64{
+	"Total_LBAs_Written": 512,
+	"Host_Writes_32MiB":  32 << 20,
+	"Host_Writes_GiB":    1 << 30,
+	"Total_Writes_GiB":   1 << 30,
+}
+
+// applyATAEndurance sets WearPercent and TotalBytesWritten from an SSD's ATA
+// attribute table.
+func applyATAEndurance(disk *dto.DiskInfo, attrs map[string]dto.SMARTAttribute) {
+	for _, id := range ataWearAttributes {
+		if a, ok := attrs[id]; ok && a.Value >= 0 && a.Value <= 100 {
+			wear := 100 - a.Value
+			disk.WearPercent = &wear
+			break
+		}
+	}
+	if a, ok := attrs["241"]; ok {
+		unit, known := ataWriteUnits[a.Name]
+		fields := strings.Fields(a.RawValue)
+		if known && len(fields) > 0 {
+			if v, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+				disk.TotalBytesWritten = v * unit
+			}
 		}
 	}
 }
@@ -798,7 +1079,12 @@ func (c *DiskCollector) enrichWithMountInfo(disk *dto.DiskInfo) {
 }
 
 // enrichWithRole determines the disk role (parity, parity2, data, cache, pool)
+// of a disk whose disks.ini type did not set one
 func (c *DiskCollector) enrichWithRole(disk *dto.DiskInfo) {
+	if disk.Role != "" {
+		return
+	}
+
 	// Determine role based on disk name/ID
 	name := strings.ToLower(disk.Name)
 	id := strings.ToLower(disk.ID)
@@ -0,0 +1,115 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Package-level variables (not constants) so tests can use fixture trees.
+var (
+	diskDevsIni     = constants.DevsIni
+	diskSysBlockDir = "/sys/block"
+)
+
+var (
+	pciAddressPattern  = regexp.MustCompile(`^[0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-7]$`)
+	diskPortPattern    = regexp.MustCompile(`^(ata\d+|port-\d+:\d+(:\d+)?|nvme\d+|\d+-\d+(\.\d+)*)$`)
+	scsiAddressPattern = regexp.MustCompile(`^\d+:\d+:\d+:\d+$`)
+)
+
+// applyDiskType sets the role of a disks.ini slot from its type, and the
+// pool of pool devices. Unraid names the first device of a pool after the
+// pool and the others with a number appended (cache, cache2, ...), which is
+// why pool names cannot end in a digit. Slots without a known type keep no
+// role and are classified by name.
+func applyDiskType(disk *dto.DiskInfo, diskType string) {
+	switch strings.ToLower(diskType) {
+	case "parity":
+		disk.Role = "parity"
+		if strings.EqualFold(disk.Name, "parity2") {
+			disk.Role = "parity2"
+		}
+	case "data":
+		disk.Role = "data"
+	case "cache":
+		disk.Pool = strings.TrimRight(disk.Name, "0123456789")
+		disk.Role = "pool"
+		if strings.EqualFold(disk.Pool, "cache") {
+			disk.Role = "cache"
+		}
+	case "flash":
+		disk.Role = "flash"
+	}
+}
+
+// collectUnassignedDisks lists the devices in devs.ini, the disks that are
+// neither in the array nor in a pool.
+func (c *DiskCollector) collectUnassignedDisks() []dto.DiskInfo {
+	if _, err := os.Stat(diskDevsIni); err != nil {
+		return nil
+	}
+	disks, err := c.parseDisksINI(diskDevsIni)
+	if err != nil {
+		logger.Debug("Disk: Failed to parse %s: %v", diskDevsIni, err)
+		return nil
+	}
+	for i := range disks {
+		disks[i].Role = "unassigned"
+		disks[i].Slot = nil
+	}
+	return disks
+}
+
+// enrichWithBlockDevice adds the controller and port of the disk from sysfs,
+// and its size when disks.ini has none, as for unassigned devices.
+func (c *DiskCollector) enrichWithBlockDevice(disk *dto.DiskInfo) {
+	if disk.Device == "" {
+		return
+	}
+	disk.Controller, disk.Port = diskControllerPort(disk.Device)
+
+	if disk.Size == 0 {
+		// #nosec G304 -- the path is built from /sys/block and a device name from emhttp.
+		if data, err := os.ReadFile(filepath.Join(diskSysBlockDir, disk.Device, "size")); err == nil {
+			// sysfs counts 512-byte sectors whatever the device's sector size
+			if sectors, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err == nil {
+				disk.Size = sectors * 512
+			}
+		}
+	}
+}
+
+// diskControllerPort resolves /sys/block/<device> to its device path, such
+// as /sys/devices/pci0000:00/0000:00:17.0/ata3/host2/target2:0:0/2:0:0:0/
+// block/sda, and returns the last PCI device on it (the controller) and the
+// port below it. Without an ATA, SAS, NVMe or USB port the SCSI address is
+// used.
+func diskControllerPort(device string) (controller, port string) {
+	target, err := filepath.EvalSymlinks(filepath.Join(diskSysBlockDir, device))
+	if err != nil {
+		return "", ""
+	}
+	var scsi string
+	for part := range strings.SplitSeq(target, string(filepath.Separator)) {
+		switch {
+		case pciAddressPattern.MatchString(part):
+			// A controller behind a PCIe switch or bridge replaces it
+			controller, port, scsi = part, "", ""
+		case diskPortPattern.MatchString(part):
+			port = part
+		case scsiAddressPattern.MatchString(part) && scsi == "":
+			scsi = part
+		}
+	}
+	if port == "" {
+		port = scsi
+	}
+	return controller, port
+}
@@ -0,0 +1,156 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParseDisksINILayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disks.ini")
+	ini := `["parity"]
+idx="0"
+name="parity"
+device="sdb"
+type="Parity"
+["disk1"]
+idx="1"
+name="disk1"
+device="sdc"
+type="Data"
+["parity2"]
+idx="29"
+name="parity2"
+device="sdd"
+type="Parity"
+["cache"]
+idx="30"
+name="cache"
+device="nvme0n1"
+type="Cache"
+["fast2"]
+idx="32"
+name="fast2"
+device="nvme1n1"
+type="Cache"
+["flash"]
+idx="54"
+name="flash"
+device="sda"
+type="Flash"
+`
+	if err := os.WriteFile(path, []byte(ini), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	disks, err := (&DiskCollector{}).parseDisksINI(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		role, pool string
+		slot       int
+	}{
+		{"parity", "", 0},
+		{"data", "", 1},
+		{"parity2", "", 29},
+		{"cache", "cache", 30},
+		{"pool", "fast", 32},
+		{"flash", "", 54},
+	}
+	if len(disks) != len(want) {
+		t.Fatalf("parsed %d disks, want %d", len(disks), len(want))
+	}
+	for i, w := range want {
+		d := disks[i]
+		if d.Role != w.role || d.Pool != w.pool || d.Slot == nil || *d.Slot != w.slot {
+			t.Errorf("%s: role=%q pool=%q slot=%v, want %q %q %d", d.Name, d.Role, d.Pool, d.Slot, w.role, w.pool, w.slot)
+		}
+	}
+
+	// A role from the type is kept; the name is only a fallback
+	c := &DiskCollector{}
+	c.enrichWithRole(&disks[4])
+	if disks[4].Role != "pool" {
+		t.Errorf("enrichWithRole replaced the disks.ini role with %q", disks[4].Role)
+	}
+}
+
+func TestCollectUnassignedDisks(t *testing.T) {
+	orig := diskDevsIni
+	t.Cleanup(func() { diskDevsIni = orig })
+	diskDevsIni = filepath.Join(t.TempDir(), "devs.ini")
+
+	c := &DiskCollector{}
+	if disks := c.collectUnassignedDisks(); disks != nil {
+		t.Errorf("no devs.ini: got %+v", disks)
+	}
+
+	ini := `["dev1"]
+name="dev1"
+id="ST8000VN004-3CP101_WWZ1A2B3"
+device="sdg"
+temp="31"
+`
+	if err := os.WriteFile(diskDevsIni, []byte(ini), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	disks := c.collectUnassignedDisks()
+	if len(disks) != 1 || disks[0].Role != "unassigned" || disks[0].Device != "sdg" || disks[0].Slot != nil || disks[0].Temperature != 31 {
+		t.Errorf("unassigned disks = %+v", disks)
+	}
+}
+
+func TestDiskControllerPort(t *testing.T) {
+	root := t.TempDir()
+	orig := diskSysBlockDir
+	t.Cleanup(func() { diskSysBlockDir = orig })
+	diskSysBlockDir = filepath.Join(root, "sys", "block")
+	if err := os.MkdirAll(diskSysBlockDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	device := func(name, devicePath string) {
+		t.Helper()
+		dir := filepath.Join(root, "sys", "devices", devicePath, name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "size"), []byte("15628053168\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(dir, filepath.Join(diskSysBlockDir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	device("sda", "pci0000:00/0000:00:17.0/ata3/host2/target2:0:0/2:0:0:0/block")
+	device("nvme0n1", "pci0000:00/0000:00:01.1/0000:01:00.0/nvme/nvme0")
+	device("sdb", "pci0000:00/0000:00:14.0/usb1/1-4/1-4.2/1-4.2:1.0/host6/target6:0:0/6:0:0:0/block")
+	device("sdc", "pci0000:00/0000:00:01.0/0000:02:00.0/host0/port-0:3/end_device-0:3/target0:0:3/0:0:3:0/block")
+	device("sdd", "pci0000:00/0000:00:01.0/0000:03:00.0/host1/target1:0:5/1:0:5:0/block")
+
+	tests := []struct {
+		device, controller, port string
+	}{
+		{"sda", "0000:00:17.0", "ata3"},
+		{"nvme0n1", "0000:01:00.0", "nvme0"},
+		{"sdb", "0000:00:14.0", "1-4.2"},
+		{"sdc", "0000:02:00.0", "port-0:3"},
+		{"sdd", "0000:03:00.0", "1:0:5:0"},
+		{"sdz", "", ""},
+	}
+	for _, tt := range tests {
+		controller, port := diskControllerPort(tt.device)
+		if controller != tt.controller || port != tt.port {
+			t.Errorf("%s: controller=%q port=%q, want %q %q", tt.device, controller, port, tt.controller, tt.port)
+		}
+	}
+
+	disk := &dto.DiskInfo{Device: "sda", Role: "unassigned"}
+	(&DiskCollector{}).enrichWithBlockDevice(disk)
+	if disk.Size != 15628053168*512 || disk.Port != "ata3" {
+		t.Errorf("enriched unassigned disk = %+v", disk)
+	}
+}
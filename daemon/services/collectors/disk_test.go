@@ -3,6 +3,7 @@ package collectors
 import (
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
@@ -371,6 +372,24 @@ func TestParseDiskKeyValue(t *testing.T) {
 			checkField: "format",
 			expected:   "xfs",
 		},
+		{
+			name:       "parse encrypted fsType",
+			line:       `fsType="luks:xfs"`,
+			checkField: "encryption",
+			expected:   "",
+		},
+		{
+			name:       "parse luksState unlocked",
+			line:       "luksState=1",
+			checkField: "encryption",
+			expected:   dto.EncryptionUnlocked,
+		},
+		{
+			name:       "parse luksState wrong key",
+			line:       "luksState=3",
+			checkField: "encryption",
+			expected:   dto.EncryptionWrongKey,
+		},
 		{
 			name:       "invalid line (no equals)",
 			line:       "invalid line",
@@ -421,6 +440,10 @@ func TestParseDiskKeyValue(t *testing.T) {
 				if disk.FileSystem != tt.expected.(string) {
 					t.Errorf("FileSystem = %q, want %q", disk.FileSystem, tt.expected)
 				}
+			case "encryption":
+				if !disk.Encrypted || disk.EncryptionState != tt.expected.(string) {
+					t.Errorf("Encrypted = %v, EncryptionState = %q, want true, %q", disk.Encrypted, disk.EncryptionState, tt.expected)
+				}
 			case "none":
 				// Line should be ignored
 			}
@@ -909,6 +932,85 @@ func TestParseSMARTAttributes(t *testing.T) {
 	}
 }
 
+func TestParseNVMeEndurance(t *testing.T) {
+	lines := []string{
+		"=== START OF SMART DATA SECTION ===",
+		"SMART overall-health self-assessment test result: PASSED",
+		"Available Spare:                    100%",
+		"Percentage Used:                    3%",
+		"Data Units Read:                    8,123,456 [4.15 TB]",
+		"Data Units Written:                 12,345,678 [6.32 TB]",
+	}
+	var disk dto.DiskInfo
+	parseNVMeEndurance(&disk, lines)
+	if disk.WearPercent == nil || *disk.WearPercent != 3 {
+		t.Errorf("WearPercent = %v, want 3", disk.WearPercent)
+	}
+	if want := uint64(12345678) * 512000; disk.TotalBytesWritten != want {
+		t.Errorf("TotalBytesWritten = %d, want %d", disk.TotalBytesWritten, want)
+	}
+}
+
+func TestApplyATAEndurance(t *testing.T) {
+	tests := []struct {
+		name      string
+		attrs     map[string]dto.SMARTAttribute
+		wantWear  int // -1 when no wear is reported
+		wantBytes uint64
+	}{
+		{
+			name: "SSD_Life_Left and LBAs written",
+			attrs: map[string]dto.SMARTAttribute{
+				"231": {ID: 231, Name: "SSD_Life_Left", Value: 92},
+				"177": {ID: 177, Name: "Wear_Leveling_Count", Value: 50},
+				"241": {ID: 241, Name: "Total_LBAs_Written", RawValue: "1000000"},
+			},
+			wantWear:  8,
+			wantBytes: 512000000,
+		},
+		{
+			name: "Samsung wear leveling count",
+			attrs: map[string]dto.SMARTAttribute{
+				"177": {ID: 177, Name: "Wear_Leveling_Count", Value: 97},
+			},
+			wantWear: 3,
+		},
+		{
+			name: "Intel writes in 32MiB units",
+			attrs: map[string]dto.SMARTAttribute{
+				"233": {ID: 233, Name: "Media_Wearout_Indicator", Value: 100},
+				"241": {ID: 241, Name: "Host_Writes_32MiB", RawValue: "10"},
+			},
+			wantWear:  0,
+			wantBytes: 10 * 32 << 20,
+		},
+		{
+			name: "unknown write unit and out of range value",
+			attrs: map[string]dto.SMARTAttribute{
+				"231": {ID: 231, Name: "Temperature_Celsius", Value: 140},
+				"241": {ID: 241, Name: "Lifetime_Writes_GiB", RawValue: "10"},
+			},
+			wantWear: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var disk dto.DiskInfo
+			applyATAEndurance(&disk, tt.attrs)
+			wear := -1
+			if disk.WearPercent != nil {
+				wear = *disk.WearPercent
+			}
+			if wear != tt.wantWear {
+				t.Errorf("WearPercent = %d, want %d", wear, tt.wantWear)
+			}
+			if disk.TotalBytesWritten != tt.wantBytes {
+				t.Errorf("TotalBytesWritten = %d, want %d", disk.TotalBytesWritten, tt.wantBytes)
+			}
+		})
+	}
+}
+
 // TestEnrichWithModelAndSerialEmptyID tests enrichment when ID is empty
 func TestEnrichWithModelAndSerialEmptyID(t *testing.T) {
 	hub := domain.NewEventBus(10)
@@ -929,3 +1031,34 @@ func TestEnrichWithModelAndSerialEmptyID(t *testing.T) {
 		t.Logf("Sysfs read succeeded: Model=%q, Serial=%q", disk.Model, disk.SerialNumber)
 	}
 }
+
+func TestCollectDeviceTimeout(t *testing.T) {
+	collector := NewDiskCollector(&domain.Context{Hub: domain.NewEventBus(10)})
+	collector.deviceTimeout = 20 * time.Millisecond
+
+	if !collector.collectDevice("sda", func() {}) {
+		t.Error("fast device reported as timed out")
+	}
+
+	release := make(chan struct{})
+	if collector.collectDevice("sdb", func() { <-release }) {
+		t.Error("hung device not reported as timed out")
+	}
+	// A later cycle skips the device while the hung collection still runs
+	ran := false
+	if collector.collectDevice("sdb", func() { ran = true }) || ran {
+		t.Error("busy device collected again")
+	}
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for !collector.collectDevice("sdb", func() {}) {
+		if time.Now().After(deadline) {
+			t.Fatal("device still busy after its collection returned")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if collector.collectDevice("sdc", func() { panic("boom") }) {
+		t.Error("panicking device reported as collected")
+	}
+}
@@ -0,0 +1,438 @@
+package collectors
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// resolvConfPath is the path to the host resolver configuration. It is a
+// package-level variable (not a constant) so that tests can override it.
+var resolvConfPath = "/etc/resolv.conf"
+
+// dockerFallbackResolvers are the public resolvers Docker's embedded DNS
+// forwards to when the host resolv.conf lists only loopback nameservers.
+var dockerFallbackResolvers = []string{"8.8.8.8", "8.8.4.4"}
+
+const (
+	// dnsStartupStagger delays the first collection so it does not pile onto boot.
+	dnsStartupStagger = 20 * time.Second
+
+	// dnsProbeName is resolved through every resolver. It is a DNSSEC-signed
+	// zone so the AD flag reveals whether the resolver validates.
+	dnsProbeName = "cloudflare.com"
+
+	// dnsQueryTimeout bounds a single query so a dead resolver cannot stall the run.
+	dnsQueryTimeout = 3 * time.Second
+
+	// dnsUDPPayloadSize is the EDNS0 buffer size advertised with each query.
+	dnsUDPPayloadSize = 1232
+)
+
+// DNSCollector periodically verifies DNS resolution from the host. For each
+// external Docker bridge network it additionally queries the upstream resolvers
+// Docker's embedded DNS forwards to with the source bound to the network's
+// gateway address, which catches host routing and firewall rules that reject
+// that address. These queries still originate in the host's network namespace:
+// they do not go through a container's resolv.conf, Docker's embedded resolver
+// (127.0.0.11), or the container-side NAT path. macvlan/ipvlan networks bypass
+// the host bridge entirely and are reported as unchecked.
+type DNSCollector struct {
+	appCtx *domain.Context
+	// NetworksFn lists Docker networks; the collector factory in package services
+	// injects the controller-backed implementation to avoid a
+	// collectors→controllers import cycle. When nil, only host checks run.
+	NetworksFn func() ([]dto.DockerNetworkInfo, error)
+	// QueryFn resolves name through server, optionally binding the local source
+	// address. The constructor installs a real UDP implementation; tests may
+	// replace it.
+	QueryFn func(ctx context.Context, server, localIP, name string) dto.DNSResolverCheck
+}
+
+// NewDNSCollector creates a new DNS collector with the default QueryFn installed.
+func NewDNSCollector(ctx *domain.Context) *DNSCollector {
+	return &DNSCollector{
+		appCtx:  ctx,
+		QueryFn: queryDNS,
+	}
+}
+
+// Start begins the periodic DNS health collection after a startup stagger.
+func (c *DNSCollector) Start(ctx context.Context, interval time.Duration) {
+	logger.InfoContext(ctx, "Starting dns collector (interval: %v)", interval)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(dnsStartupStagger):
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "DNS collector", r)
+			}
+		}()
+		c.Collect(ctx)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "DNS collector stopping due to context cancellation")
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						logger.LogPanicWithStackContext(ctx, "DNS collector", r)
+					}
+				}()
+				c.Collect(ctx)
+			}()
+		}
+	}
+}
+
+// Collect runs one round of DNS checks and publishes the result. Response
+// times change on every run, so the result is always published.
+func (c *DNSCollector) Collect(ctx context.Context) {
+	health := c.check(ctx)
+	domain.Publish(c.appCtx.Hub, constants.TopicDNSHealthUpdate, health)
+
+	if health.Healthy {
+		logger.Debug("DNS: healthy (%d resolvers, %d checks, avg %.1fms)",
+			len(health.Resolvers), len(health.Checks), health.AvgResponseTimeMs)
+	} else {
+		logger.Warning("DNS: unhealthy: %s", strings.Join(health.Issues, "; "))
+	}
+}
+
+// check performs the host and Docker network queries and assembles the result.
+func (c *DNSCollector) check(ctx context.Context) *dto.DNSHealth {
+	health := &dto.DNSHealth{
+		Resolvers: []string{},
+		Checks:    []dto.DNSResolverCheck{},
+		Timestamp: time.Now(),
+	}
+
+	nameservers, search, err := readResolvConf(resolvConfPath)
+	if err != nil {
+		health.Issues = append(health.Issues, fmt.Sprintf("cannot read %s: %v", resolvConfPath, err))
+		return health
+	}
+	health.Resolvers = nameservers
+	health.SearchDomains = search
+	if len(nameservers) == 0 {
+		health.Issues = append(health.Issues, fmt.Sprintf("no nameservers configured in %s", resolvConfPath))
+		return health
+	}
+
+	// Host checks
+	successes := 0
+	var totalMs float64
+	for _, ns := range nameservers {
+		result := c.QueryFn(ctx, net.JoinHostPort(ns, "53"), "", dnsProbeName)
+		result.Scope = dto.DNSScopeHost
+		health.Checks = append(health.Checks, result)
+		if result.Success {
+			successes++
+			totalMs += result.ResponseTimeMs
+			if result.DNSSECValidated {
+				health.DNSSECValidating = true
+			}
+		} else {
+			health.Issues = append(health.Issues, fmt.Sprintf("host resolver %s failed: %s", ns, result.Error))
+		}
+	}
+	hostOK := successes > 0
+	if hostOK {
+		health.AvgResponseTimeMs = totalMs / float64(successes)
+	} else {
+		health.Issues = append(health.Issues, "no host resolver is answering")
+	}
+
+	networksOK := c.checkDockerNetworks(ctx, nameservers, health)
+
+	health.Healthy = hostOK && networksOK
+	return health
+}
+
+// checkDockerNetworks queries the upstream resolvers from the gateway address of
+// every external bridge network and records networks it cannot test. Returns
+// false if any checked gateway could not reach an upstream resolver.
+func (c *DNSCollector) checkDockerNetworks(ctx context.Context, nameservers []string, health *dto.DNSHealth) bool {
+	if c.NetworksFn == nil {
+		return true
+	}
+	networks, err := c.NetworksFn()
+	if err != nil {
+		// Docker may be stopped; that is not a DNS problem.
+		logger.Debug("DNS: skipping Docker network checks: %v", err)
+		return true
+	}
+
+	upstreams := dockerUpstreams(nameservers)
+	if len(upstreams) == 0 {
+		upstreams = dockerFallbackResolvers
+		health.Issues = append(health.Issues, fmt.Sprintf(
+			"host resolv.conf lists only loopback nameservers; containers on bridge networks fall back to %s",
+			strings.Join(dockerFallbackResolvers, ", ")))
+	}
+
+	allOK := true
+	for _, n := range networks {
+		// Internal networks have no external access by design, and host/null
+		// networks have no separate path to test.
+		if n.Internal || n.Driver == "host" || n.Driver == "null" {
+			continue
+		}
+		// Only bridge gateways live on the host; the upstream list is IPv4 in
+		// practice, so IPv6-only gateways are not tested either.
+		gw := net.ParseIP(n.Gateway)
+		if n.Driver != "bridge" || gw == nil || gw.To4() == nil {
+			health.UncheckedNetworks = append(health.UncheckedNetworks, n.Name)
+			continue
+		}
+
+		networkOK := false
+		for _, up := range upstreams {
+			if ip := net.ParseIP(up); ip == nil || ip.To4() == nil {
+				continue
+			}
+			result := c.QueryFn(ctx, net.JoinHostPort(up, "53"), n.Gateway, dnsProbeName)
+			result.Scope = dto.DNSScopeDockerGateway
+			result.Network = n.Name
+			health.Checks = append(health.Checks, result)
+			if result.Success {
+				networkOK = true
+			}
+		}
+		if !networkOK {
+			allOK = false
+			health.Issues = append(health.Issues, fmt.Sprintf("no upstream resolver answers from Docker network %s gateway %s", n.Name, n.Gateway))
+		}
+	}
+	return allOK
+}
+
+// dockerUpstreams returns the nameservers Docker's embedded DNS forwards to:
+// the host's non-loopback resolvers.
+func dockerUpstreams(nameservers []string) []string {
+	var result []string
+	for _, ns := range nameservers {
+		if ip := net.ParseIP(ns); ip != nil && !ip.IsLoopback() {
+			result = append(result, ns)
+		}
+	}
+	return result
+}
+
+// readResolvConf reads nameserver and search entries from a resolv.conf file.
+func readResolvConf(path string) (nameservers, search []string, err error) {
+	// #nosec G304 -- path is the fixed resolv.conf location (overridable only in tests).
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = f.Close() }()
+	nameservers, search = parseResolvConf(f)
+	return nameservers, search, nil
+}
+
+// parseResolvConf extracts nameserver and search/domain entries. Comments,
+// unknown options, and unparseable addresses are ignored.
+func parseResolvConf(r io.Reader) (nameservers, search []string) {
+	nameservers = []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			addr, _, _ := strings.Cut(fields[1], "%") // drop IPv6 zone
+			if net.ParseIP(addr) != nil {
+				nameservers = append(nameservers, addr)
+			}
+		case "search", "domain":
+			// The last search/domain line wins, matching glibc.
+			search = fields[1:]
+		}
+	}
+	return nameservers, search
+}
+
+// queryDNS sends a single A query with the DNSSEC OK bit set to server over
+// UDP. When localIP is non-empty the query is sourced from that address.
+func queryDNS(ctx context.Context, server, localIP, name string) dto.DNSResolverCheck {
+	result := dto.DNSResolverCheck{Server: server, Query: name}
+
+	var idBuf [2]byte
+	if _, err := rand.Read(idBuf[:]); err != nil {
+		result.Error = fmt.Sprintf("generating query ID: %v", err)
+		return result
+	}
+	id := binary.BigEndian.Uint16(idBuf[:])
+
+	query, err := buildDNSQuery(id, name)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsQueryTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	if localIP != "" {
+		dialer.LocalAddr = &net.UDPAddr{IP: net.ParseIP(localIP)}
+	}
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		result.Error = fmt.Sprintf("dial: %v", err)
+		return result
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	start := time.Now()
+	if _, err := conn.Write(query); err != nil {
+		result.Error = fmt.Sprintf("send: %v", err)
+		return result
+	}
+
+	buf := make([]byte, dnsUDPPayloadSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			result.Error = fmt.Sprintf("no response: %v", err)
+			return result
+		}
+		var p dnsmessage.Parser
+		header, err := p.Start(buf[:n])
+		if err != nil || header.ID != id || !header.Response {
+			continue // stray or malformed packet; keep waiting until the deadline
+		}
+		result.ResponseTimeMs = float64(time.Since(start).Microseconds()) / 1000
+		parseDNSResponse(&p, header, &result)
+		return result
+	}
+}
+
+// buildDNSQuery encodes a recursive A query for name with an EDNS0 OPT record
+// that sets the DNSSEC OK bit, so validating resolvers report the AD flag.
+func buildDNSQuery(id uint16, name string) ([]byte, error) {
+	qname, err := dnsmessage.NewName(strings.TrimSuffix(name, ".") + ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid query name %q: %w", name, err)
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: qname, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	if err := b.StartAdditionals(); err != nil {
+		return nil, err
+	}
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(dnsUDPPayloadSize, dnsmessage.RCodeSuccess, true); err != nil {
+		return nil, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// parseDNSResponse fills the outcome fields of result from a parsed response.
+func parseDNSResponse(p *dnsmessage.Parser, header dnsmessage.Header, result *dto.DNSResolverCheck) {
+	result.Rcode = rcodeName(header.RCode)
+	result.DNSSECValidated = header.AuthenticData
+
+	if err := p.SkipAllQuestions(); err != nil {
+		result.Error = fmt.Sprintf("malformed response: %v", err)
+		return
+	}
+	for {
+		ah, err := p.AnswerHeader()
+		if errors.Is(err, dnsmessage.ErrSectionDone) {
+			break
+		}
+		if err != nil {
+			result.Error = fmt.Sprintf("malformed response: %v", err)
+			return
+		}
+		if ah.Type != dnsmessage.TypeA {
+			if err := p.SkipAnswer(); err != nil {
+				result.Error = fmt.Sprintf("malformed response: %v", err)
+				return
+			}
+			continue
+		}
+		a, err := p.AResource()
+		if err != nil {
+			result.Error = fmt.Sprintf("malformed response: %v", err)
+			return
+		}
+		result.Addresses = append(result.Addresses, net.IP(a.A[:]).String())
+	}
+
+	switch {
+	case header.RCode != dnsmessage.RCodeSuccess:
+		result.Error = "resolver returned " + result.Rcode
+	case len(result.Addresses) == 0:
+		result.Error = "no A records in answer"
+	default:
+		result.Success = true
+	}
+}
+
+// rcodeName returns the conventional mnemonic for a DNS response code.
+func rcodeName(rc dnsmessage.RCode) string {
+	switch rc {
+	case dnsmessage.RCodeSuccess:
+		return "NOERROR"
+	case dnsmessage.RCodeFormatError:
+		return "FORMERR"
+	case dnsmessage.RCodeServerFailure:
+		return "SERVFAIL"
+	case dnsmessage.RCodeNameError:
+		return "NXDOMAIN"
+	case dnsmessage.RCodeNotImplemented:
+		return "NOTIMP"
+	case dnsmessage.RCodeRefused:
+		return "REFUSED"
+	default:
+		return fmt.Sprintf("RCODE%d", rc)
+	}
+}
@@ -0,0 +1,278 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParseResolvConf(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		wantNameservers []string
+		wantSearch      []string
+	}{
+		{
+			name:            "typical",
+			input:           "# generated\nsearch lan home\nnameserver 192.168.1.1\nnameserver 1.1.1.1\n",
+			wantNameservers: []string{"192.168.1.1", "1.1.1.1"},
+			wantSearch:      []string{"lan", "home"},
+		},
+		{
+			name:            "ipv6 zone and junk",
+			input:           "nameserver fe80::1%br0\nnameserver not-an-ip\noptions ndots:2\n; comment\n",
+			wantNameservers: []string{"fe80::1"},
+		},
+		{
+			name:            "last domain line wins",
+			input:           "search a\ndomain b\n",
+			wantNameservers: []string{},
+			wantSearch:      []string{"b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns, search := parseResolvConf(strings.NewReader(tt.input))
+			if strings.Join(ns, ",") != strings.Join(tt.wantNameservers, ",") {
+				t.Errorf("nameservers = %v, want %v", ns, tt.wantNameservers)
+			}
+			if strings.Join(search, ",") != strings.Join(tt.wantSearch, ",") {
+				t.Errorf("search = %v, want %v", search, tt.wantSearch)
+			}
+		})
+	}
+}
+
+// startFakeDNSServer answers every A query with 192.0.2.1 and the given rcode/AD flag.
+func startFakeDNSServer(t *testing.T, rcode dnsmessage.RCode, authenticated bool) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var p dnsmessage.Parser
+			h, err := p.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			q, err := p.Question()
+			if err != nil {
+				continue
+			}
+			b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+				ID: h.ID, Response: true, RecursionAvailable: true,
+				RCode: rcode, AuthenticData: authenticated,
+			})
+			_ = b.StartQuestions()
+			_ = b.Question(q)
+			_ = b.StartAnswers()
+			if rcode == dnsmessage.RCodeSuccess {
+				_ = b.AResource(dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 60},
+					dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}})
+			}
+			resp, err := b.Finish()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestQueryDNS(t *testing.T) {
+	t.Run("success with DNSSEC", func(t *testing.T) {
+		server := startFakeDNSServer(t, dnsmessage.RCodeSuccess, true)
+		got := queryDNS(context.Background(), server, "", dnsProbeName)
+		if !got.Success || got.Rcode != "NOERROR" || !got.DNSSECValidated {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+		if len(got.Addresses) != 1 || got.Addresses[0] != "192.0.2.1" {
+			t.Errorf("addresses = %v", got.Addresses)
+		}
+		if got.ResponseTimeMs <= 0 {
+			t.Errorf("response time = %v, want > 0", got.ResponseTimeMs)
+		}
+	})
+
+	t.Run("servfail", func(t *testing.T) {
+		server := startFakeDNSServer(t, dnsmessage.RCodeServerFailure, false)
+		got := queryDNS(context.Background(), server, "", dnsProbeName)
+		if got.Success || got.Rcode != "SERVFAIL" || got.Error == "" {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		// A bound but silent socket makes the query time out quickly via ctx.
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen: %v", err)
+		}
+		defer func() { _ = conn.Close() }()
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		got := queryDNS(ctx, conn.LocalAddr().String(), "", dnsProbeName)
+		if got.Success || !strings.Contains(got.Error, "no response") {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+}
+
+func writeResolvConf(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "resolv.conf")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write resolv.conf: %v", err)
+	}
+	orig := resolvConfPath
+	resolvConfPath = path
+	t.Cleanup(func() { resolvConfPath = orig })
+}
+
+func TestDNSCollectorCheck(t *testing.T) {
+	networks := []dto.DockerNetworkInfo{
+		{Name: "bridge", Driver: "bridge", Gateway: "172.17.0.1"},
+		{Name: "proxynet", Driver: "bridge", Gateway: "172.18.0.1"},
+		{Name: "isolated", Driver: "bridge", Gateway: "172.19.0.1", Internal: true},
+		{Name: "host", Driver: "host"},
+		{Name: "br0", Driver: "macvlan", Gateway: "192.168.1.1"},
+	}
+
+	t.Run("healthy host and networks", func(t *testing.T) {
+		writeResolvConf(t, "nameserver 192.168.1.1\n")
+		c := NewDNSCollector(&domain.Context{Hub: domain.NewEventBus(4)})
+		c.NetworksFn = func() ([]dto.DockerNetworkInfo, error) { return networks, nil }
+		var sources []string
+		c.QueryFn = func(_ context.Context, server, localIP, name string) dto.DNSResolverCheck {
+			sources = append(sources, localIP)
+			return dto.DNSResolverCheck{Server: server, Query: name, Success: true, ResponseTimeMs: 10, DNSSECValidated: true}
+		}
+
+		got := c.check(context.Background())
+		if !got.Healthy || !got.DNSSECValidating || len(got.Issues) != 0 {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+		// One host query plus one per external bridge gateway (internal, host and macvlan skipped).
+		if len(got.Checks) != 3 {
+			t.Fatalf("checks = %d, want 3", len(got.Checks))
+		}
+		if strings.Join(sources, ",") != ",172.17.0.1,172.18.0.1" {
+			t.Errorf("query sources = %v", sources)
+		}
+		if got.Checks[2].Scope != dto.DNSScopeDockerGateway || got.Checks[2].Network != "proxynet" {
+			t.Errorf("unexpected network check: %+v", got.Checks[2])
+		}
+		if len(got.UncheckedNetworks) != 1 || got.UncheckedNetworks[0] != "br0" {
+			t.Errorf("unchecked networks = %v, want [br0]", got.UncheckedNetworks)
+		}
+		if got.AvgResponseTimeMs != 10 {
+			t.Errorf("avg response = %v, want 10", got.AvgResponseTimeMs)
+		}
+	})
+
+	t.Run("network cannot reach upstream", func(t *testing.T) {
+		writeResolvConf(t, "nameserver 192.168.1.1\n")
+		c := NewDNSCollector(&domain.Context{Hub: domain.NewEventBus(4)})
+		c.NetworksFn = func() ([]dto.DockerNetworkInfo, error) { return networks, nil }
+		c.QueryFn = func(_ context.Context, server, localIP, _ string) dto.DNSResolverCheck {
+			if localIP == "172.18.0.1" {
+				return dto.DNSResolverCheck{Server: server, Error: "no response: i/o timeout"}
+			}
+			return dto.DNSResolverCheck{Server: server, Success: true}
+		}
+
+		got := c.check(context.Background())
+		if got.Healthy {
+			t.Fatal("expected unhealthy when a network cannot resolve")
+		}
+		if len(got.Issues) != 1 || !strings.Contains(got.Issues[0], "proxynet") {
+			t.Errorf("issues = %v", got.Issues)
+		}
+	})
+
+	t.Run("loopback only resolvers fall back for docker", func(t *testing.T) {
+		writeResolvConf(t, "nameserver 127.0.0.1\n")
+		c := NewDNSCollector(&domain.Context{Hub: domain.NewEventBus(4)})
+		c.NetworksFn = func() ([]dto.DockerNetworkInfo, error) { return networks[:1], nil }
+		var servers []string
+		c.QueryFn = func(_ context.Context, server, _, _ string) dto.DNSResolverCheck {
+			servers = append(servers, server)
+			return dto.DNSResolverCheck{Server: server, Success: true}
+		}
+
+		got := c.check(context.Background())
+		if !got.Healthy {
+			t.Fatalf("expected healthy, issues: %v", got.Issues)
+		}
+		if len(got.Issues) != 1 || !strings.Contains(got.Issues[0], "loopback") {
+			t.Errorf("issues = %v", got.Issues)
+		}
+		if strings.Join(servers, ",") != "127.0.0.1:53,8.8.8.8:53,8.8.4.4:53" {
+			t.Errorf("servers = %v", servers)
+		}
+	})
+
+	t.Run("docker unavailable is not a dns failure", func(t *testing.T) {
+		writeResolvConf(t, "nameserver 192.168.1.1\n")
+		c := NewDNSCollector(&domain.Context{Hub: domain.NewEventBus(4)})
+		c.NetworksFn = func() ([]dto.DockerNetworkInfo, error) { return nil, errors.New("docker not running") }
+		c.QueryFn = func(_ context.Context, server, _, _ string) dto.DNSResolverCheck {
+			return dto.DNSResolverCheck{Server: server, Success: true}
+		}
+		if got := c.check(context.Background()); !got.Healthy || len(got.Checks) != 1 {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("no nameservers", func(t *testing.T) {
+		writeResolvConf(t, "# empty\n")
+		c := NewDNSCollector(&domain.Context{Hub: domain.NewEventBus(4)})
+		got := c.check(context.Background())
+		if got.Healthy || len(got.Issues) != 1 {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+}
+
+func TestDNSCollectorCollectPublishes(t *testing.T) {
+	writeResolvConf(t, "nameserver 192.168.1.1\n")
+	hub := domain.NewEventBus(4)
+	ch := hub.SubTopics(constants.TopicDNSHealthUpdate)
+	c := NewDNSCollector(&domain.Context{Hub: hub})
+	c.QueryFn = func(_ context.Context, server, _, _ string) dto.DNSResolverCheck {
+		return dto.DNSResolverCheck{Server: server, Success: true}
+	}
+
+	c.Collect(context.Background())
+
+	select {
+	case msg := <-ch:
+		if health, ok := msg.(*dto.DNSHealth); !ok || !health.Healthy {
+			t.Fatalf("unexpected message: %#v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no DNS health published")
+	}
+}
@@ -40,9 +40,17 @@ type DockerCollector struct {
 	appCtx       *domain.Context
 	dockerClient *client.Client
 	initialized  bool
-	mu           sync.Mutex             // protects prevCPU and prevNet
+	clientMu     sync.Mutex             // protects dockerClient initialization
+	mu           sync.Mutex             // protects prevCPU, prevNet, details, detailsGen and eventsLive
 	prevCPU      map[string]cpuSnapshot // keyed by full container ID
 	prevNet      map[string]netSnapshot // keyed by full container ID
+	// details caches inspect data of running containers, keyed by full
+	// container ID, while the Docker event stream is connected (eventsLive).
+	// detailsGen counts invalidations.
+	details    map[string]containerDetails
+	detailsGen uint64
+	eventsLive bool
+	refresh    refreshTrigger
 }
 
 // NewDockerCollector creates a new Docker SDK-based collector
@@ -52,11 +60,21 @@ func NewDockerCollector(ctx *domain.Context) *DockerCollector {
 		initialized: false,
 		prevCPU:     make(map[string]cpuSnapshot),
 		prevNet:     make(map[string]netSnapshot),
+		details:     make(map[string]containerDetails),
+		refresh:     newRefreshTrigger(),
 	}
 }
 
+// RequestRefresh asks the running collector to collect immediately, e.g. after
+// a container was started or stopped.
+func (c *DockerCollector) RequestRefresh() {
+	c.refresh.request()
+}
+
 // initClient initializes the Docker client if not already done
 func (c *DockerCollector) initClient() error {
+	c.clientMu.Lock()
+	defer c.clientMu.Unlock()
 	if c.dockerClient != nil {
 		return nil
 	}
@@ -88,24 +106,35 @@ func (c *DockerCollector) reportDockerSourceFailure(reason string, err error) {
 
 // Start begins the Docker collector's periodic data collection
 func (c *DockerCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting docker collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting docker collector (interval: %v)", interval)
 
-	// Run once immediately with panic recovery
-	func() {
+	collect := func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Docker collector", r)
+				logger.LogPanicWithStackContext(ctx, "Docker collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "Docker", interval, c.Collect)
-	}()
+	}
+
+	// Follow the event stream so container details are only re-inspected
+	// when they change, and state changes are published without waiting
+	// for the next tick
+	eventsCtx, stopEvents := context.WithCancel(ctx)
+	var watcher sync.WaitGroup
+	watcher.Go(func() { c.watchEvents(eventsCtx) })
+
+	// Run once immediately with panic recovery
+	collect()
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	defer func() {
+		stopEvents()
+		watcher.Wait()
 		if c.dockerClient != nil {
 			if err := c.dockerClient.Close(); err != nil {
-				logger.Debug("Docker: Error closing client: %v", err)
+				logger.DebugContext(ctx, "Docker: Error closing client: %v", err)
 			}
 		}
 	}()
@@ -113,17 +142,13 @@ func (c *DockerCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Docker collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "Docker collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Docker collector", r)
-					}
-				}()
-				collectWithWatchdog(ctx, "Docker", interval, c.Collect)
-			}()
+			collect()
+		case <-c.refresh:
+			logger.DebugContext(ctx, "Docker collector: refresh requested, collecting immediately")
+			collect()
 		}
 	}
 }
@@ -138,7 +163,7 @@ func (c *DockerCollector) Collect() {
 		logger.Debug("Failed to initialize Docker client: %v (Docker may not be running)", err)
 		c.reportDockerSourceFailure("Docker client initialization failed", err)
 		// Publish empty list
-		domain.Publish(c.appCtx.Hub, constants.TopicContainerListUpdate, []*dto.ContainerInfo{})
+		c.publish([]*dto.ContainerInfo{})
 		return
 	}
 
@@ -150,7 +175,7 @@ func (c *DockerCollector) Collect() {
 	if err != nil {
 		logger.Debug("Failed to list containers via SDK: %v", err)
 		c.reportDockerSourceFailure("Docker daemon unreachable (ContainerList failed)", err)
-		domain.Publish(c.appCtx.Hub, constants.TopicContainerListUpdate, []*dto.ContainerInfo{})
+		c.publish([]*dto.ContainerInfo{})
 		return
 	}
 	apiContainers := result.Items
@@ -171,9 +196,17 @@ func (c *DockerCollector) Collect() {
 			State:     state,
 			Status:    apiContainer.Status,
 			Ports:     c.convertPorts(apiContainer.Ports),
+			Health:    dto.ContainerHealthNone,
+			Group:     dockerGroup(apiContainer.Labels),
 			Timestamp: time.Now(),
 		}
 
+		// Docker HEALTHCHECK status (only reported for containers whose image defines one)
+		if apiContainer.Health != nil && apiContainer.Health.Status != "" {
+			cont.Health = string(apiContainer.Health.Status)
+			cont.HealthFailingStreak = apiContainer.Health.FailingStreak
+		}
+
 		// Extract version from image tag
 		imageParts := strings.Split(apiContainer.Image, ":")
 		if len(imageParts) > 1 {
@@ -199,82 +232,14 @@ func (c *DockerCollector) Collect() {
 
 		for _, apiContainer := range runningContainers {
 			shortID := apiContainer.ID[:12]
-			inspectResult, err := c.dockerClient.ContainerInspect(ctx, apiContainer.ID, client.ContainerInspectOptions{})
+			details, err := c.containerDetails(ctx, apiContainer.ID)
 			if err != nil {
 				logger.Debug("Docker SDK: Failed to inspect container %s: %v", shortID, err)
 				continue
 			}
 
-			inspectData := inspectResult.Container
-
 			if cont, ok := containerMap[shortID]; ok {
-				// Network mode
-				if inspectData.HostConfig != nil {
-					cont.NetworkMode = string(inspectData.HostConfig.NetworkMode)
-				}
-
-				// IP Address and MAC (get first available)
-				if inspectData.NetworkSettings != nil {
-					for _, network := range inspectData.NetworkSettings.Networks {
-						if network.IPAddress.IsValid() {
-							cont.IPAddress = network.IPAddress.String()
-							if mac := network.MacAddress.String(); mac != "" {
-								cont.MACAddress = mac
-							}
-							break
-						}
-					}
-					// Fall back to any network that exposes a MAC even without a valid IP.
-					if cont.MACAddress == "" {
-						for _, network := range inspectData.NetworkSettings.Networks {
-							if mac := network.MacAddress.String(); mac != "" {
-								cont.MACAddress = mac
-								break
-							}
-						}
-					}
-				}
-
-				// Port mappings
-				if inspectData.HostConfig != nil {
-					portMappings := []string{}
-					for containerPort, bindings := range inspectData.HostConfig.PortBindings {
-						for _, binding := range bindings {
-							if binding.HostPort != "" {
-								portMappings = append(portMappings, fmt.Sprintf("%s:%s", binding.HostPort, containerPort))
-							}
-						}
-					}
-					cont.PortMappings = portMappings
-
-					// Restart policy
-					cont.RestartPolicy = string(inspectData.HostConfig.RestartPolicy.Name)
-					if cont.RestartPolicy == "" {
-						cont.RestartPolicy = "no"
-					}
-				}
-
-				// Volume mappings
-				volumeMappings := []dto.VolumeMapping{}
-				for _, mount := range inspectData.Mounts {
-					volumeMappings = append(volumeMappings, dto.VolumeMapping{
-						HostPath:      mount.Source,
-						ContainerPath: mount.Destination,
-						Mode:          mount.Mode,
-					})
-				}
-				cont.VolumeMappings = volumeMappings
-
-				// Uptime
-				if inspectData.State != nil && inspectData.State.StartedAt != "" {
-					startTime, err := time.Parse(time.RFC3339Nano, inspectData.State.StartedAt)
-					if err == nil {
-						cont.Uptime = dockerFormatUptime(time.Since(startTime))
-					}
-				}
-
-				// RestartCount
-				cont.RestartCount = inspectData.RestartCount
+				details.apply(cont, startInspect)
 
 				// Memory stats from cgroups (much faster than ContainerStats API)
 				c.getMemoryFromCgroups(apiContainer.ID, cont)
@@ -283,12 +248,10 @@ func (c *DockerCollector) Collect() {
 				c.getCPUFromCgroups(apiContainer.ID, cont)
 
 				// Network I/O from /proc/<pid>/net/dev
-				if inspectData.State != nil {
-					c.getNetworkFromProc(inspectData.State.Pid, apiContainer.ID, cont)
-				}
+				c.getNetworkFromProc(details.pid, apiContainer.ID, cont)
 			}
 		}
-		logger.Debug("Docker SDK: Inspect + cgroup stats took %v for %d containers", time.Since(startInspect), len(runningContainers))
+		logger.Debug("Docker SDK: Details + cgroup stats took %v for %d containers", time.Since(startInspect), len(runningContainers))
 	}
 
 	// Prune stale CPU snapshots for containers that no longer exist
@@ -306,10 +269,16 @@ func (c *DockerCollector) Collect() {
 	}
 
 	// Publish event
-	domain.Publish(c.appCtx.Hub, constants.TopicContainerListUpdate, containers)
+	c.publish(containers)
 	logger.Debug("Docker SDK: Total collection took %v, published %d containers", time.Since(startTotal), len(containers))
 }
 
+// publish sends the container list and its stats summary to the event bus.
+func (c *DockerCollector) publish(containers []*dto.ContainerInfo) {
+	domain.Publish(c.appCtx.Hub, constants.TopicContainerListUpdate, containers)
+	domain.Publish(c.appCtx.Hub, constants.TopicDockerStatsUpdate, SummarizeDockerStats(containers, time.Now()))
+}
+
 // getMemoryFromCgroups reads memory stats directly from cgroup v2 filesystem
 // This is much faster than using Docker's ContainerStats API
 func (c *DockerCollector) getMemoryFromCgroups(fullID string, cont *dto.ContainerInfo) {
@@ -360,6 +329,21 @@ func (c *DockerCollector) convertPorts(apiPorts []container.PortSummary) []dto.P
 	return ports
 }
 
+// dockerGroupLabels are the container labels naming a container's group, in
+// order of precedence: the folder set by the FolderView plugins, then the
+// Docker Compose project.
+var dockerGroupLabels = []string{"folder.view2", "folder.view", "com.docker.compose.project"}
+
+// dockerGroup returns the group named by a container's labels, or "".
+func dockerGroup(labels map[string]string) string {
+	for _, label := range dockerGroupLabels {
+		if group := strings.TrimSpace(labels[label]); group != "" {
+			return group
+		}
+	}
+	return ""
+}
+
 // dockerFormatUptime formats a duration as human-readable uptime string
 func dockerFormatUptime(d time.Duration) string {
 	days := int(d.Hours() / 24)
@@ -466,7 +450,8 @@ func (c *DockerCollector) getCPUFromCgroups(fullID string, cont *dto.ContainerIn
 	cont.CPUPercent = (float64(deltaUsec) / float64(elapsedUsec)) / numCPU * 100
 }
 
-// pruneStaleSnapshots removes CPU and network snapshots for containers that are no longer running.
+// pruneStaleSnapshots removes CPU and network snapshots and cached details
+// for containers that are no longer running.
 func (c *DockerCollector) pruneStaleSnapshots(running []container.Summary) {
 	active := make(map[string]struct{}, len(running))
 	for _, rc := range running {
@@ -483,6 +468,11 @@ func (c *DockerCollector) pruneStaleSnapshots(running []container.Summary) {
 			delete(c.prevNet, id)
 		}
 	}
+	for id := range c.details {
+		if _, ok := active[id]; !ok {
+			delete(c.details, id)
+		}
+	}
 	c.mu.Unlock()
 }
 
@@ -0,0 +1,236 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/client"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// dockerEventsRetry is the delay before re-opening the Docker event stream
+// after it failed or the daemon could not be reached.
+const dockerEventsRetry = 10 * time.Second
+
+// containerDetails is the part of a container's inspect data the collector
+// publishes. It only changes when the container is recreated, restarted,
+// updated or (dis)connected from a network, all of which Docker reports on
+// its event stream, so it is cached between collections instead of
+// inspecting every running container on every cycle.
+type containerDetails struct {
+	networkMode    string
+	ipAddress      string
+	macAddress     string
+	portMappings   []string
+	restartPolicy  string
+	volumeMappings []dto.VolumeMapping
+	startedAt      time.Time
+	restartCount   int
+	pid            int
+}
+
+// newContainerDetails extracts the published fields from an inspect response.
+func newContainerDetails(inspect container.InspectResponse) containerDetails {
+	var d containerDetails
+
+	if inspect.HostConfig != nil {
+		d.networkMode = string(inspect.HostConfig.NetworkMode)
+
+		d.portMappings = []string{}
+		for containerPort, bindings := range inspect.HostConfig.PortBindings {
+			for _, binding := range bindings {
+				if binding.HostPort != "" {
+					d.portMappings = append(d.portMappings, fmt.Sprintf("%s:%s", binding.HostPort, containerPort))
+				}
+			}
+		}
+
+		d.restartPolicy = string(inspect.HostConfig.RestartPolicy.Name)
+		if d.restartPolicy == "" {
+			d.restartPolicy = "no"
+		}
+	}
+
+	// IP Address and MAC (get first available)
+	if inspect.NetworkSettings != nil {
+		for _, network := range inspect.NetworkSettings.Networks {
+			if network.IPAddress.IsValid() {
+				d.ipAddress = network.IPAddress.String()
+				if mac := network.MacAddress.String(); mac != "" {
+					d.macAddress = mac
+				}
+				break
+			}
+		}
+		// Fall back to any network that exposes a MAC even without a valid IP.
+		if d.macAddress == "" {
+			for _, network := range inspect.NetworkSettings.Networks {
+				if mac := network.MacAddress.String(); mac != "" {
+					d.macAddress = mac
+					break
+				}
+			}
+		}
+	}
+
+	d.volumeMappings = []dto.VolumeMapping{}
+	for _, mount := range inspect.Mounts {
+		d.volumeMappings = append(d.volumeMappings, dto.VolumeMapping{
+			HostPath:      mount.Source,
+			ContainerPath: mount.Destination,
+			Mode:          mount.Mode,
+		})
+	}
+
+	if inspect.State != nil {
+		if inspect.State.StartedAt != "" {
+			if t, err := time.Parse(time.RFC3339Nano, inspect.State.StartedAt); err == nil {
+				d.startedAt = t
+			}
+		}
+		d.pid = inspect.State.Pid
+	}
+	d.restartCount = inspect.RestartCount
+
+	return d
+}
+
+// apply copies the details onto cont, computing the uptime as of now.
+func (d containerDetails) apply(cont *dto.ContainerInfo, now time.Time) {
+	cont.NetworkMode = d.networkMode
+	cont.IPAddress = d.ipAddress
+	cont.MACAddress = d.macAddress
+	cont.PortMappings = d.portMappings
+	cont.RestartPolicy = d.restartPolicy
+	cont.VolumeMappings = d.volumeMappings
+	cont.RestartCount = d.restartCount
+	if !d.startedAt.IsZero() {
+		cont.Uptime = dockerFormatUptime(now.Sub(d.startedAt))
+	}
+}
+
+// containerDetails returns the details of a running container, inspecting it
+// only when no cached copy exists. Results are cached only while the event
+// stream is connected, as otherwise changes would go unnoticed, and only if
+// no event arrived during the inspect, as the result may predate it.
+func (c *DockerCollector) containerDetails(ctx context.Context, id string) (containerDetails, error) {
+	c.mu.Lock()
+	d, ok := c.details[id]
+	gen := c.detailsGen
+	c.mu.Unlock()
+	if ok {
+		return d, nil
+	}
+
+	result, err := c.dockerClient.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+	if err != nil {
+		return containerDetails{}, err
+	}
+	d = newContainerDetails(result.Container)
+
+	c.mu.Lock()
+	if c.eventsLive && c.detailsGen == gen {
+		c.details[id] = d
+	}
+	c.mu.Unlock()
+	return d, nil
+}
+
+// setEventsLive records whether the event stream is connected. The detail
+// cache is dropped on every transition, since events may have been missed
+// while the stream was down.
+func (c *DockerCollector) setEventsLive(live bool) {
+	c.mu.Lock()
+	c.eventsLive = live
+	c.detailsGen++
+	clear(c.details)
+	c.mu.Unlock()
+}
+
+// dockerRefreshActions are the container events that change the published
+// container list, and so trigger an immediate collection.
+var dockerRefreshActions = map[events.Action]bool{
+	events.ActionCreate:  true,
+	events.ActionStart:   true,
+	events.ActionRestart: true,
+	events.ActionDie:     true,
+	events.ActionPause:   true,
+	events.ActionUnPause: true,
+	events.ActionRename:  true,
+	events.ActionUpdate:  true,
+	events.ActionDestroy: true,
+}
+
+// handleEvent invalidates the cached details of the container an event is
+// about, and reports whether the event warrants an immediate collection.
+func (c *DockerCollector) handleEvent(msg events.Message) bool {
+	id := msg.Actor.ID
+	if msg.Type == events.NetworkEventType {
+		// Network events name the network; the container is an attribute.
+		id = msg.Actor.Attributes["container"]
+	}
+	if id == "" {
+		return false
+	}
+
+	c.mu.Lock()
+	c.detailsGen++
+	delete(c.details, id)
+	c.mu.Unlock()
+
+	if msg.Type == events.NetworkEventType {
+		return msg.Action == events.ActionConnect || msg.Action == events.ActionDisconnect
+	}
+	return dockerRefreshActions[msg.Action] || strings.HasPrefix(string(msg.Action), string(events.ActionHealthStatus))
+}
+
+// watchEvents follows Docker's event stream for the collector's lifetime,
+// keeping the detail cache current and requesting a collection whenever a
+// container changes state. The stream is re-opened after failures.
+func (c *DockerCollector) watchEvents(ctx context.Context) {
+	for {
+		if err := c.initClient(); err == nil {
+			c.streamEvents(ctx)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dockerEventsRetry):
+		}
+	}
+}
+
+// streamEvents consumes one event stream until it fails or ctx is cancelled.
+func (c *DockerCollector) streamEvents(ctx context.Context) {
+	filters := make(client.Filters).
+		Add("type", string(events.ContainerEventType), string(events.NetworkEventType))
+	stream := c.dockerClient.Events(ctx, client.EventsListOptions{Filters: filters})
+
+	c.setEventsLive(true)
+	defer c.setEventsLive(false)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-stream.Messages:
+			if c.handleEvent(msg) {
+				c.RequestRefresh()
+			}
+		case err := <-stream.Err:
+			if ctx.Err() == nil {
+				logger.Debug("Docker: event stream closed: %v", err)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/api/types/network"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestNewContainerDetails(t *testing.T) {
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	inspect := container.InspectResponse{
+		HostConfig: &container.HostConfig{
+			NetworkMode: "bridge",
+			PortBindings: network.PortMap{
+				network.MustParsePort("80/tcp"): {{HostPort: "8080"}},
+			},
+		},
+		Mounts: []container.MountPoint{{Source: "/mnt/user/appdata/app", Destination: "/config", Mode: "rw"}},
+		State:  &container.State{StartedAt: started.Format(time.RFC3339Nano), Pid: 4242},
+	}
+	inspect.RestartCount = 3
+
+	d := newContainerDetails(inspect)
+	if d.networkMode != "bridge" || d.restartPolicy != "no" || d.pid != 4242 || d.restartCount != 3 {
+		t.Fatalf("unexpected details: %+v", d)
+	}
+	if len(d.portMappings) != 1 || d.portMappings[0] != "8080:80/tcp" {
+		t.Errorf("portMappings = %v, want [8080:80/tcp]", d.portMappings)
+	}
+
+	cont := &dto.ContainerInfo{}
+	d.apply(cont, started.Add(26*time.Hour))
+	if cont.Uptime != "1d 2h 0m" {
+		t.Errorf("Uptime = %q, want %q", cont.Uptime, "1d 2h 0m")
+	}
+	if len(cont.VolumeMappings) != 1 || cont.VolumeMappings[0].ContainerPath != "/config" {
+		t.Errorf("VolumeMappings = %+v", cont.VolumeMappings)
+	}
+}
+
+func TestDockerHandleEventInvalidatesDetails(t *testing.T) {
+	c := NewDockerCollector(&domain.Context{Hub: domain.NewEventBus(10)})
+	c.setEventsLive(true)
+	c.details["abc"] = containerDetails{pid: 1}
+	c.details["def"] = containerDetails{pid: 2}
+
+	tests := []struct {
+		name    string
+		msg     events.Message
+		id      string
+		refresh bool
+	}{
+		{"exec does not refresh", events.Message{Type: events.ContainerEventType, Action: events.ActionExecStart, Actor: events.Actor{ID: "abc"}}, "abc", false},
+		{"restart refreshes", events.Message{Type: events.ContainerEventType, Action: events.ActionRestart, Actor: events.Actor{ID: "abc"}}, "abc", true},
+		{"health status refreshes", events.Message{Type: events.ContainerEventType, Action: events.ActionHealthStatusHealthy, Actor: events.Actor{ID: "abc"}}, "abc", true},
+		{"network connect names container", events.Message{Type: events.NetworkEventType, Action: events.ActionConnect, Actor: events.Actor{ID: "net1", Attributes: map[string]string{"container": "def"}}}, "def", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.details[tt.id] = containerDetails{}
+			if got := c.handleEvent(tt.msg); got != tt.refresh {
+				t.Errorf("handleEvent() = %v, want %v", got, tt.refresh)
+			}
+			if _, ok := c.details[tt.id]; ok {
+				t.Errorf("details for %s not invalidated", tt.id)
+			}
+		})
+	}
+}
+
+func TestDockerContainerDetailsUsesCache(t *testing.T) {
+	c := NewDockerCollector(&domain.Context{Hub: domain.NewEventBus(10)})
+	c.setEventsLive(true)
+	c.details["abc"] = containerDetails{pid: 7}
+
+	// No Docker client is set, so a cache miss would panic
+	d, err := c.containerDetails(context.Background(), "abc")
+	if err != nil || d.pid != 7 {
+		t.Fatalf("containerDetails() = %+v, %v; want cached pid 7", d, err)
+	}
+
+	c.setEventsLive(false)
+	if len(c.details) != 0 {
+		t.Errorf("details not dropped when the event stream disconnects: %v", c.details)
+	}
+}
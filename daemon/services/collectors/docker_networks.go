@@ -39,7 +39,7 @@ func NewDockerNetworksCollector(ctx *domain.Context) *DockerNetworksCollector {
 
 // Start begins the periodic network listing after a startup stagger.
 func (c *DockerNetworksCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting docker_networks collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting docker_networks collector (interval: %v)", interval)
 
 	select {
 	case <-ctx.Done():
@@ -50,7 +50,7 @@ func (c *DockerNetworksCollector) Start(ctx context.Context, interval time.Durat
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("DockerNetworks collector", r)
+				logger.LogPanicWithStackContext(ctx, "DockerNetworks collector", r)
 			}
 		}()
 		c.Collect()
@@ -62,13 +62,13 @@ func (c *DockerNetworksCollector) Start(ctx context.Context, interval time.Durat
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("DockerNetworks collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "DockerNetworks collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("DockerNetworks collector", r)
+						logger.LogPanicWithStackContext(ctx, "DockerNetworks collector", r)
 					}
 				}()
 				c.Collect()
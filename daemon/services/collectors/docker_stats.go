@@ -0,0 +1,78 @@
+package collectors
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// DockerStatsTopN is the number of containers ranked by CPU and by memory in
+// the Docker stats summary.
+const DockerStatsTopN = 10
+
+// SummarizeDockerStats adds up the CPU and memory usage of the running
+// containers and ranks the DockerStatsTopN largest consumers of each. Ties
+// are ordered by name so the rankings are stable between collections.
+func SummarizeDockerStats(containers []*dto.ContainerInfo, now time.Time) *dto.DockerStatsSummary {
+	summary := &dto.DockerStatsSummary{
+		DockerAggregateStats: dto.DockerAggregateStats{Timestamp: now},
+		TopCPU:               []dto.ContainerUsage{},
+		TopMemory:            []dto.ContainerUsage{},
+	}
+	stats := &summary.DockerAggregateStats
+
+	var running []dto.ContainerUsage
+	for _, c := range containers {
+		if c == nil {
+			continue
+		}
+		stats.TotalContainers++
+		if c.State != "running" {
+			continue
+		}
+		stats.RunningContainers++
+		stats.TotalCPUPercent += c.CPUPercent
+		stats.TotalMemoryUsage += c.MemoryUsage
+		stats.TotalMemoryUsageMB += c.MemoryUsageMB
+		stats.TotalMemoryLimit += c.MemoryLimit
+		running = append(running, dto.ContainerUsage{
+			ID:            c.ID,
+			Name:          c.Name,
+			CPUPercent:    c.CPUPercent,
+			MemoryUsage:   c.MemoryUsage,
+			MemoryUsageMB: c.MemoryUsageMB,
+		})
+	}
+	if stats.TotalMemoryLimit > 0 {
+		stats.MemoryUsagePercent = float64(stats.TotalMemoryUsage) / float64(stats.TotalMemoryLimit) * 100
+	}
+
+	summary.TopCPU = topContainers(running, func(a, b dto.ContainerUsage) int {
+		return cmp.Compare(b.CPUPercent, a.CPUPercent)
+	})
+	summary.TopMemory = topContainers(running, func(a, b dto.ContainerUsage) int {
+		return cmp.Compare(b.MemoryUsage, a.MemoryUsage)
+	})
+	return summary
+}
+
+// topContainers returns the first DockerStatsTopN of usage ordered by
+// compare, then by name.
+func topContainers(usage []dto.ContainerUsage, compare func(a, b dto.ContainerUsage) int) []dto.ContainerUsage {
+	ranked := slices.Clone(usage)
+	slices.SortFunc(ranked, func(a, b dto.ContainerUsage) int {
+		if c := compare(a, b); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	if len(ranked) > DockerStatsTopN {
+		ranked = ranked[:DockerStatsTopN]
+	}
+	if ranked == nil {
+		return []dto.ContainerUsage{}
+	}
+	return ranked
+}
@@ -0,0 +1,56 @@
+package collectors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestSummarizeDockerStats(t *testing.T) {
+	containers := []*dto.ContainerInfo{
+		{ID: "a", Name: "plex", State: "running", CPUPercent: 12, MemoryUsage: 400, MemoryUsageMB: 0.4, MemoryLimit: 1000},
+		{ID: "b", Name: "sonarr", State: "running", CPUPercent: 3, MemoryUsage: 600, MemoryUsageMB: 0.6, MemoryLimit: 1000},
+		{ID: "c", Name: "backup", State: "exited", CPUPercent: 0, MemoryUsage: 0},
+		nil,
+	}
+	now := time.Now()
+	s := SummarizeDockerStats(containers, now)
+
+	if s.TotalContainers != 3 || s.RunningContainers != 2 {
+		t.Errorf("containers = %d running of %d, want 2 of 3", s.RunningContainers, s.TotalContainers)
+	}
+	if s.TotalCPUPercent != 15 || s.TotalMemoryUsage != 1000 || s.MemoryUsagePercent != 50 {
+		t.Errorf("totals = %+v", s.DockerAggregateStats)
+	}
+	if !s.Timestamp.Equal(now) {
+		t.Errorf("timestamp = %v, want %v", s.Timestamp, now)
+	}
+	if len(s.TopCPU) != 2 || s.TopCPU[0].Name != "plex" {
+		t.Errorf("top_cpu = %+v, want plex first and no stopped containers", s.TopCPU)
+	}
+	if len(s.TopMemory) != 2 || s.TopMemory[0].Name != "sonarr" {
+		t.Errorf("top_memory = %+v, want sonarr first", s.TopMemory)
+	}
+}
+
+func TestSummarizeDockerStatsTopN(t *testing.T) {
+	var containers []*dto.ContainerInfo
+	for i := range DockerStatsTopN + 5 {
+		// Equal usage: the ranking falls back to the name
+		containers = append(containers, &dto.ContainerInfo{Name: fmt.Sprintf("app%02d", DockerStatsTopN+5-i), State: "running", CPUPercent: 1})
+	}
+	s := SummarizeDockerStats(containers, time.Now())
+	if len(s.TopCPU) != DockerStatsTopN || len(s.TopMemory) != DockerStatsTopN {
+		t.Fatalf("rankings hold %d and %d, want %d", len(s.TopCPU), len(s.TopMemory), DockerStatsTopN)
+	}
+	if s.TopCPU[0].Name != "app01" || s.TopCPU[DockerStatsTopN-1].Name != fmt.Sprintf("app%02d", DockerStatsTopN) {
+		t.Errorf("ties not ordered by name: %+v", s.TopCPU)
+	}
+
+	empty := SummarizeDockerStats(nil, time.Now())
+	if empty.TopCPU == nil || empty.TopMemory == nil {
+		t.Error("empty rankings should be empty lists, not null")
+	}
+}
@@ -165,3 +165,24 @@ func TestDockerFormatMemoryDisplay(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerGroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{"no labels", nil, ""},
+		{"compose project", map[string]string{"com.docker.compose.project": "immich"}, "immich"},
+		{"folder overrides compose", map[string]string{"com.docker.compose.project": "immich", "folder.view2": "Photos"}, "Photos"},
+		{"legacy folder label", map[string]string{"folder.view": " Media "}, "Media"},
+		{"blank label ignored", map[string]string{"folder.view2": " ", "folder.view": "Media"}, "Media"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dockerGroup(tt.labels); got != tt.want {
+				t.Errorf("dockerGroup(%v) = %q, want %q", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
@@ -55,7 +55,7 @@ func NewDockerUpdateCollector(ctx *domain.Context) *DockerUpdateCollector {
 
 // Start begins the periodic update check after a startup stagger.
 func (c *DockerUpdateCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting docker_update collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting docker_update collector (interval: %v)", interval)
 
 	select {
 	case <-ctx.Done():
@@ -66,7 +66,7 @@ func (c *DockerUpdateCollector) Start(ctx context.Context, interval time.Duratio
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("DockerUpdate collector", r)
+				logger.LogPanicWithStackContext(ctx, "DockerUpdate collector", r)
 			}
 		}()
 		c.Collect(ctx)
@@ -78,13 +78,13 @@ func (c *DockerUpdateCollector) Start(ctx context.Context, interval time.Duratio
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("DockerUpdate collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "DockerUpdate collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("DockerUpdate collector", r)
+						logger.LogPanicWithStackContext(ctx, "DockerUpdate collector", r)
 					}
 				}()
 				c.Collect(ctx)
@@ -27,7 +27,7 @@ func (c *FanControlCollector) Start(ctx context.Context, interval time.Duration)
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Fan control collector", r)
+				logger.LogPanicWithStackContext(ctx, "Fan control collector", r)
 			}
 		}()
 		c.Collect()
@@ -44,7 +44,7 @@ func (c *FanControlCollector) Start(ctx context.Context, interval time.Duration)
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Fan control collector", r)
+						logger.LogPanicWithStackContext(ctx, "Fan control collector", r)
 					}
 				}()
 				c.Collect()
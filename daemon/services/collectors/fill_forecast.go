@@ -0,0 +1,112 @@
+package collectors
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Fill forecasting parameters. Samples are kept at most every
+// fillSampleInterval over fillWindow; a forecast needs fillMinSpan of history
+// so that a single large copy does not dominate it.
+const (
+	fillSampleInterval = 15 * time.Minute
+	fillWindow         = 7 * 24 * time.Hour
+	fillMinSpan        = 6 * time.Hour
+	fillMinSamples     = 3
+	// fillMaxDays caps "days until full"; beyond roughly ten years the
+	// projection is meaningless and is reported as not filling.
+	fillMaxDays = 3650
+)
+
+// fillSample is the used capacity of a pool at one point in time.
+type fillSample struct {
+	at   time.Time
+	used float64
+}
+
+// fillForecast projects when a pool or the array runs out of space from the
+// trend of its used capacity. It is keyed by pool name so one value serves a
+// collector that reports several pools. The zero value is ready to use.
+type fillForecast struct {
+	mu      sync.Mutex
+	samples map[string][]fillSample
+}
+
+// observe records the used and total capacity of key at time now and returns
+// the fill rate in bytes per day and the days until full. Both are nil until
+// enough history has been collected; days is also nil when the pool is not
+// filling.
+func (f *fillForecast) observe(key string, used, total uint64, now time.Time) (rate, days *float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.samples == nil {
+		f.samples = map[string][]fillSample{}
+	}
+	samples := f.samples[key]
+
+	// Drop samples outside the window, and everything if the clock went back.
+	cutoff := now.Add(-fillWindow)
+	start := 0
+	for start < len(samples) && (samples[start].at.Before(cutoff) || samples[start].at.After(now)) {
+		start++
+	}
+	samples = samples[start:]
+
+	if n := len(samples); n == 0 || now.Sub(samples[n-1].at) >= fillSampleInterval {
+		samples = append(samples, fillSample{at: now, used: float64(used)})
+	}
+	f.samples[key] = samples
+
+	if len(samples) < fillMinSamples || samples[len(samples)-1].at.Sub(samples[0].at) < fillMinSpan {
+		return nil, nil
+	}
+
+	perDay := math.Round(fillSlope(samples) * 24 * 60 * 60)
+	rate = &perDay
+	if total > 0 && used >= total {
+		full := 0.0
+		return rate, &full
+	}
+	if perDay <= 0 {
+		return rate, nil
+	}
+	d := float64(total-used) / perDay
+	if d > fillMaxDays {
+		return rate, nil
+	}
+	d = math.Round(d*10) / 10
+	return rate, &d
+}
+
+// prune forgets pools not in keep, e.g. after a pool is removed.
+func (f *fillForecast) prune(keep map[string]bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for key := range f.samples {
+		if !keep[key] {
+			delete(f.samples, key)
+		}
+	}
+}
+
+// fillSlope returns the least-squares slope of used bytes over time, in bytes
+// per second.
+func fillSlope(samples []fillSample) float64 {
+	t0 := samples[0].at
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.at.Sub(t0).Seconds()
+		sumX += x
+		sumY += s.used
+		sumXY += x * s.used
+		sumXX += x * x
+	}
+	n := float64(len(samples))
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
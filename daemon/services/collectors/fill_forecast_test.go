@@ -0,0 +1,80 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+const gib = 1 << 30
+
+func TestFillForecastObserve(t *testing.T) {
+	var f fillForecast
+	start := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	total := uint64(1000 * gib)
+
+	// 10 GiB per day, sampled hourly; samples closer than the interval are skipped.
+	var rate, days *float64
+	for h := 0; h <= 12; h++ {
+		now := start.Add(time.Duration(h) * time.Hour)
+		used := uint64(500*gib) + uint64(h)*10*gib/24
+		rate, days = f.observe("cache", used, total, now)
+		if h < 6 && (rate != nil || days != nil) {
+			t.Fatalf("hour %d: forecast before minimum span: rate=%v days=%v", h, rate, days)
+		}
+		f.observe("cache", used, total, now.Add(time.Minute))
+	}
+	if got := len(f.samples["cache"]); got != 13 {
+		t.Errorf("samples = %d, want 13", got)
+	}
+	if rate == nil || days == nil {
+		t.Fatal("no forecast after 12 hours")
+	}
+	if diff := *rate - 10*gib; diff < -gib/100 || diff > gib/100 {
+		t.Errorf("rate = %v, want ~%v", *rate, 10*gib)
+	}
+	// 495 GiB free at 10 GiB per day.
+	if *days < 49 || *days > 50 {
+		t.Errorf("days = %v, want ~49.5", *days)
+	}
+}
+
+func TestFillForecastNotFilling(t *testing.T) {
+	var f fillForecast
+	start := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	var rate, days *float64
+	for h := 0; h <= 8; h++ {
+		rate, days = f.observe("tank", uint64(600-h)*gib, 1000*gib, start.Add(time.Duration(h)*time.Hour))
+	}
+	if rate == nil || *rate >= 0 {
+		t.Errorf("rate = %v, want negative", rate)
+	}
+	if days != nil {
+		t.Errorf("days = %v, want nil while shrinking", *days)
+	}
+
+	// A full pool reports zero days regardless of trend.
+	_, days = f.observe("tank", 1000*gib, 1000*gib, start.Add(9*time.Hour))
+	if days == nil || *days != 0 {
+		t.Errorf("days for full pool = %v, want 0", days)
+	}
+}
+
+func TestFillForecastWindowAndPrune(t *testing.T) {
+	var f fillForecast
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for d := 0; d <= 10; d++ {
+		f.observe("array", uint64(d)*gib, 100*gib, start.Add(time.Duration(d)*24*time.Hour))
+	}
+	if got := len(f.samples["array"]); got != 8 {
+		t.Errorf("samples = %d, want 8 within the 7-day window", got)
+	}
+
+	f.observe("cache", gib, 100*gib, start)
+	f.prune(map[string]bool{"array": true})
+	if _, ok := f.samples["cache"]; ok {
+		t.Error("pruned pool still tracked")
+	}
+	if _, ok := f.samples["array"]; !ok {
+		t.Error("kept pool was pruned")
+	}
+}
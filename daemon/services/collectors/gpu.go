@@ -2,7 +2,6 @@ package collectors
 
 import (
 	"context"
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -18,27 +17,80 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 )
 
+// gpuReprobeInterval is how often an auto-detected backend that has not
+// found a GPU yet is probed again, e.g. for a GPU whose driver loads late.
+const gpuReprobeInterval = 10 * time.Minute
+
 // GPUCollector collects GPU metrics from NVIDIA, AMD, and Intel GPUs.
 // It gathers temperature, utilization, memory usage, and power consumption data.
 type GPUCollector struct {
 	ctx *domain.Context
+	// backends are the vendors probed, in collection order. When auto is set
+	// they were not configured explicitly, and a backend that never found a
+	// GPU is only probed every gpuReprobeInterval (idleUntil).
+	backends  []string
+	auto      bool
+	found     map[string]bool
+	idleUntil map[string]time.Time
 }
 
 // NewGPUCollector creates a new GPU metrics collector with the given context.
+// It probes the backends configured in ctx.GPUBackends.
 func NewGPUCollector(ctx *domain.Context) *GPUCollector {
-	return &GPUCollector{ctx: ctx}
+	backends, auto := resolveGPUBackends(ctx.GPUBackends)
+	return &GPUCollector{
+		ctx:       ctx,
+		backends:  backends,
+		auto:      auto,
+		found:     make(map[string]bool),
+		idleUntil: make(map[string]time.Time),
+	}
+}
+
+// gpuBackendOrder is the collection order of the GPU backends, which also
+// decides the global GPU indices.
+var gpuBackendOrder = []string{dto.GPUBackendIntel, dto.GPUBackendNvidia, dto.GPUBackendAMD}
+
+// resolveGPUBackends returns the backends to probe for the configured list,
+// and whether they are auto-detected. An empty list or "auto" selects every
+// backend; "none" selects none. Unknown names are ignored.
+func resolveGPUBackends(configured []string) ([]string, bool) {
+	selected := make(map[string]bool, len(configured))
+	for _, name := range configured {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "", dto.GPUBackendAuto:
+			return gpuBackendOrder, true
+		case dto.GPUBackendNone:
+			return nil, false
+		case dto.GPUBackendIntel, dto.GPUBackendNvidia, dto.GPUBackendAMD:
+			selected[name] = true
+		default:
+			logger.Warning("GPU: ignoring unknown backend %q (expected auto, none, intel, nvidia or amd)", name)
+		}
+	}
+	if len(selected) == 0 {
+		return gpuBackendOrder, true
+	}
+	backends := make([]string, 0, len(selected))
+	for _, name := range gpuBackendOrder {
+		if selected[name] {
+			backends = append(backends, name)
+		}
+	}
+	return backends, false
 }
 
 // Start begins the GPU collector's periodic data collection.
 // It runs in a goroutine and publishes GPU metrics updates at the specified interval until the context is cancelled.
 func (c *GPUCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting gpu collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting gpu collector (interval: %v)", interval)
 
 	// Run once immediately with panic recovery
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("GPU collector", r)
+				logger.LogPanicWithStackContext(ctx, "GPU collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "GPU", interval, c.Collect)
@@ -50,13 +102,13 @@ func (c *GPUCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("GPU collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "GPU collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("GPU collector", r)
+						logger.LogPanicWithStackContext(ctx, "GPU collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "GPU", interval, c.Collect)
@@ -70,33 +122,23 @@ func (c *GPUCollector) Start(ctx context.Context, interval time.Duration) {
 func (c *GPUCollector) Collect() {
 	logger.Debug("Collecting gpu data...")
 
-	// Collect GPU metrics from all available GPU types
+	// Collect GPU metrics from the selected backends
 	var gpuMetrics []*dto.GPUMetrics
-
-	// Try Intel iGPU
-	logger.Debug("Attempting Intel GPU collection...")
-	intelGPUs, err := c.collectIntelGPU()
-	logger.Debug("Intel GPU collection returned: gpus=%d, err=%v", len(intelGPUs), err)
-	if err == nil && len(intelGPUs) > 0 {
-		gpuMetrics = append(gpuMetrics, intelGPUs...)
-		logger.Debug("Collected %d Intel GPU(s)", len(intelGPUs))
-	} else if err != nil {
-		logger.Debug("Intel GPU collection failed: %v", err)
-	}
-
-	// Try NVIDIA GPU
-	if lib.CommandExists("nvidia-smi") {
-		if nvidiaGPUs, err := c.collectNvidiaGPU(); err == nil && len(nvidiaGPUs) > 0 {
-			gpuMetrics = append(gpuMetrics, nvidiaGPUs...)
-			logger.Debug("Collected %d NVIDIA GPU(s)", len(nvidiaGPUs))
+	now := time.Now()
+	for _, backend := range c.backends {
+		if now.Before(c.idleUntil[backend]) {
+			continue
 		}
-	}
-
-	// Try AMD GPU (radeontop or rocm-smi)
-	if lib.CommandExists("radeontop") || lib.CommandExists("rocm-smi") {
-		if amdGPUs, err := c.collectAMDGPU(); err == nil && len(amdGPUs) > 0 {
-			gpuMetrics = append(gpuMetrics, amdGPUs...)
-			logger.Debug("Collected %d AMD GPU(s)", len(amdGPUs))
+		gpus, err := c.collectBackend(backend)
+		if err != nil {
+			logger.Debug("GPU: %s collection failed: %v", backend, err)
+		}
+		if len(gpus) > 0 {
+			c.found[backend] = true
+			gpuMetrics = append(gpuMetrics, gpus...)
+			logger.Debug("Collected %d %s GPU(s)", len(gpus), backend)
+		} else if c.auto && !c.found[backend] {
+			c.idleUntil[backend] = now.Add(gpuReprobeInterval)
 		}
 	}
 
@@ -114,6 +156,26 @@ func (c *GPUCollector) Collect() {
 	logger.Debug("Published %s event for %d total GPU(s)", constants.TopicGPUMetricsUpdate.Name, len(gpuMetrics))
 }
 
+// collectBackend collects the GPUs of one backend. Backends whose tools are
+// not installed report no GPUs.
+func (c *GPUCollector) collectBackend(backend string) ([]*dto.GPUMetrics, error) {
+	switch backend {
+	case dto.GPUBackendIntel:
+		return c.collectIntelGPU()
+	case dto.GPUBackendNvidia:
+		if !lib.CommandExists("nvidia-smi") {
+			return nil, nil
+		}
+		return c.collectNvidiaGPU()
+	case dto.GPUBackendAMD:
+		if !lib.CommandExists("radeontop") && !lib.CommandExists("rocm-smi") {
+			return nil, nil
+		}
+		return c.collectAMDGPU()
+	}
+	return nil, fmt.Errorf("unknown GPU backend %q", backend)
+}
+
 // assignGlobalGPUIndices reassigns GPU indices sequentially (0, 1, 2, ...)
 // to ensure global uniqueness across all vendors.
 func assignGlobalGPUIndices(gpus []*dto.GPUMetrics) {
@@ -415,110 +477,6 @@ func (c *GPUCollector) getIntelDriverVersion() (string, error) {
 	return "", fmt.Errorf("failed to parse driver version from modinfo")
 }
 
-// NVIDIA GPU collection using nvidia-smi
-func (c *GPUCollector) collectNvidiaGPU() ([]*dto.GPUMetrics, error) {
-	// Query nvidia-smi with CSV output for easy parsing
-	// Added: pci.bus_id, uuid, fan.speed
-	output, err := lib.ExecCommandOutput(
-		"nvidia-smi",
-		"--query-gpu=index,pci.bus_id,uuid,name,temperature.gpu,utilization.gpu,memory.used,memory.total,power.draw,fan.speed",
-		"--format=csv,noheader,nounits",
-	)
-	if err != nil {
-		return nil, fmt.Errorf("nvidia-smi query failed: %w", err)
-	}
-
-	// Parse CSV output
-	reader := csv.NewReader(strings.NewReader(output))
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse CSV output: %w", err)
-	}
-
-	gpus := make([]*dto.GPUMetrics, 0, len(records))
-
-	for _, record := range records {
-		if len(record) < 10 {
-			continue
-		}
-
-		gpu := &dto.GPUMetrics{
-			Available: true,
-			Vendor:    "nvidia",
-			Timestamp: time.Now(),
-		}
-
-		// Index
-		if idx, err := strconv.Atoi(strings.TrimSpace(record[0])); err == nil {
-			gpu.Index = idx
-		}
-
-		// PCI Bus ID
-		gpu.PCIID = strings.TrimSpace(record[1])
-
-		// UUID
-		gpu.UUID = strings.TrimSpace(record[2])
-
-		// Name
-		gpu.Name = strings.TrimSpace(record[3])
-
-		// Temperature (°C)
-		if temp, err := strconv.ParseFloat(strings.TrimSpace(record[4]), 64); err == nil {
-			gpu.Temperature = temp
-		}
-
-		// Utilization (%)
-		if util, err := strconv.ParseFloat(strings.TrimSpace(record[5]), 64); err == nil {
-			gpu.UtilizationGPU = util
-		}
-
-		// Memory Used (MiB)
-		if memUsed, err := strconv.ParseFloat(strings.TrimSpace(record[6]), 64); err == nil {
-			gpu.MemoryUsed = uint64(memUsed * 1024 * 1024) // Convert MiB to bytes
-		}
-
-		// Memory Total (MiB)
-		if memTotal, err := strconv.ParseFloat(strings.TrimSpace(record[7]), 64); err == nil {
-			gpu.MemoryTotal = uint64(memTotal * 1024 * 1024) // Convert MiB to bytes
-			if gpu.MemoryTotal > 0 {
-				gpu.UtilizationMemory = float64(gpu.MemoryUsed) / float64(gpu.MemoryTotal) * 100
-			}
-		}
-
-		// Power Draw (W)
-		if power, err := strconv.ParseFloat(strings.TrimSpace(record[8]), 64); err == nil {
-			gpu.PowerDraw = power
-		}
-
-		// Fan Speed (%)
-		if fanSpeed, err := strconv.ParseFloat(strings.TrimSpace(record[9]), 64); err == nil {
-			gpu.FanSpeed = fanSpeed
-		}
-
-		// Get driver version (same for all GPUs, only query once)
-		if len(gpus) == 0 {
-			if driverVersion, err := c.getNvidiaDriverVersion(); err == nil {
-				gpu.DriverVersion = driverVersion
-			}
-		} else {
-			gpu.DriverVersion = gpus[0].DriverVersion
-		}
-
-		gpus = append(gpus, gpu)
-	}
-
-	return gpus, nil
-}
-
-// getNvidiaDriverVersion gets NVIDIA driver version
-func (c *GPUCollector) getNvidiaDriverVersion() (string, error) {
-	output, err := lib.ExecCommandOutput("nvidia-smi", "--query-gpu=driver_version", "--format=csv,noheader")
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(output), nil
-}
-
 // AMD GPU collection using radeontop (broader AMD GPU compatibility)
 func (c *GPUCollector) collectAMDGPU() ([]*dto.GPUMetrics, error) {
 	// Try radeontop first (supports consumer Radeon GPUs)
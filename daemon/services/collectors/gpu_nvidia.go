@@ -0,0 +1,128 @@
+package collectors
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+)
+
+// nvidiaSMILog is the subset of `nvidia-smi -q -x` output the collector
+// publishes. A single invocation reports every GPU and the driver version.
+type nvidiaSMILog struct {
+	DriverVersion string      `xml:"driver_version"`
+	GPUs          []nvidiaGPU `xml:"gpu"`
+}
+
+type nvidiaGPU struct {
+	BusID       string `xml:"id,attr"`
+	ProductName string `xml:"product_name"`
+	UUID        string `xml:"uuid"`
+	FanSpeed    string `xml:"fan_speed"`
+	Memory      struct {
+		Total string `xml:"total"`
+		Used  string `xml:"used"`
+	} `xml:"fb_memory_usage"`
+	Utilization struct {
+		GPU string `xml:"gpu_util"`
+	} `xml:"utilization"`
+	Temperature struct {
+		GPU string `xml:"gpu_temp"`
+	} `xml:"temperature"`
+	// Drivers before R530 report power under power_readings; newer ones
+	// under gpu_power_readings, where power_draw may be N/A in favour of
+	// instant_power_draw.
+	PowerReadings    nvidiaPowerReadings `xml:"power_readings"`
+	GPUPowerReadings nvidiaPowerReadings `xml:"gpu_power_readings"`
+}
+
+type nvidiaPowerReadings struct {
+	PowerDraw        string `xml:"power_draw"`
+	InstantPowerDraw string `xml:"instant_power_draw"`
+	AveragePowerDraw string `xml:"average_power_draw"`
+}
+
+// nvidiaValue parses the number in an nvidia-smi reading such as "55 C",
+// "2048 MiB" or "120.50 W". It reports false for "N/A" and similar.
+func nvidiaValue(s string) (float64, bool) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(fields[0], 64)
+	return v, err == nil
+}
+
+// powerDraw returns the first power reading the driver reports.
+func (g nvidiaGPU) powerDraw() (float64, bool) {
+	for _, r := range []nvidiaPowerReadings{g.GPUPowerReadings, g.PowerReadings} {
+		for _, s := range []string{r.PowerDraw, r.InstantPowerDraw, r.AveragePowerDraw} {
+			if v, ok := nvidiaValue(s); ok {
+				return v, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// parseNvidiaSMIXML converts `nvidia-smi -q -x` output into GPU metrics, in
+// the order nvidia-smi enumerates the GPUs.
+func parseNvidiaSMIXML(data []byte) ([]*dto.GPUMetrics, error) {
+	var log nvidiaSMILog
+	if err := xml.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("failed to parse nvidia-smi XML: %w", err)
+	}
+
+	now := time.Now()
+	gpus := make([]*dto.GPUMetrics, 0, len(log.GPUs))
+	for i, g := range log.GPUs {
+		gpu := &dto.GPUMetrics{
+			Available:     true,
+			Index:         i,
+			PCIID:         strings.TrimSpace(g.BusID),
+			Vendor:        "nvidia",
+			UUID:          strings.TrimSpace(g.UUID),
+			Name:          strings.TrimSpace(g.ProductName),
+			DriverVersion: strings.TrimSpace(log.DriverVersion),
+			Timestamp:     now,
+		}
+
+		if v, ok := nvidiaValue(g.Temperature.GPU); ok {
+			gpu.Temperature = v
+		}
+		if v, ok := nvidiaValue(g.Utilization.GPU); ok {
+			gpu.UtilizationGPU = v
+		}
+		if v, ok := nvidiaValue(g.Memory.Used); ok {
+			gpu.MemoryUsed = uint64(v * 1024 * 1024) // Convert MiB to bytes
+		}
+		if v, ok := nvidiaValue(g.Memory.Total); ok {
+			gpu.MemoryTotal = uint64(v * 1024 * 1024) // Convert MiB to bytes
+			if gpu.MemoryTotal > 0 {
+				gpu.UtilizationMemory = float64(gpu.MemoryUsed) / float64(gpu.MemoryTotal) * 100
+			}
+		}
+		if v, ok := g.powerDraw(); ok {
+			gpu.PowerDraw = v
+		}
+		if v, ok := nvidiaValue(g.FanSpeed); ok {
+			gpu.FanSpeed = v
+		}
+
+		gpus = append(gpus, gpu)
+	}
+	return gpus, nil
+}
+
+// collectNvidiaGPU reads every NVIDIA GPU from one nvidia-smi XML query.
+func (c *GPUCollector) collectNvidiaGPU() ([]*dto.GPUMetrics, error) {
+	output, err := lib.ExecCommandOutput("nvidia-smi", "-q", "-x")
+	if err != nil {
+		return nil, fmt.Errorf("nvidia-smi query failed: %w", err)
+	}
+	return parseNvidiaSMIXML([]byte(output))
+}
@@ -3,6 +3,7 @@ package collectors
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
@@ -256,3 +257,109 @@ func Test_assignGlobalGPUIndices(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNvidiaSMIXML(t *testing.T) {
+	output := `<?xml version="1.0" ?>
+<!DOCTYPE nvidia_smi_log SYSTEM "nvsmi_device_v12.dtd">
+<nvidia_smi_log>
+	<driver_version>550.127.05</driver_version>
+	<attached_gpus>2</attached_gpus>
+	<gpu id="00000000:01:00.0">
+		<product_name>NVIDIA GeForce RTX 3080</product_name>
+		<uuid>GPU-12345678-1234-1234-1234-123456789abc</uuid>
+		<fan_speed>45 %</fan_speed>
+		<fb_memory_usage>
+			<total>10240 MiB</total>
+			<used>2048 MiB</used>
+		</fb_memory_usage>
+		<utilization>
+			<gpu_util>25 %</gpu_util>
+		</utilization>
+		<temperature>
+			<gpu_temp>55 C</gpu_temp>
+		</temperature>
+		<gpu_power_readings>
+			<power_draw>N/A</power_draw>
+			<instant_power_draw>120.50 W</instant_power_draw>
+		</gpu_power_readings>
+	</gpu>
+	<gpu id="00000000:02:00.0">
+		<product_name>Tesla P4</product_name>
+		<uuid>GPU-abc</uuid>
+		<fan_speed>N/A</fan_speed>
+		<power_readings>
+			<power_draw>22.10 W</power_draw>
+		</power_readings>
+	</gpu>
+</nvidia_smi_log>`
+
+	gpus, err := parseNvidiaSMIXML([]byte(output))
+	if err != nil {
+		t.Fatalf("parseNvidiaSMIXML() error = %v", err)
+	}
+	if len(gpus) != 2 {
+		t.Fatalf("parsed %d GPUs, want 2", len(gpus))
+	}
+
+	g := gpus[0]
+	if g.Name != "NVIDIA GeForce RTX 3080" || g.PCIID != "00000000:01:00.0" || g.DriverVersion != "550.127.05" {
+		t.Errorf("unexpected identity: %+v", g)
+	}
+	if g.Temperature != 55 || g.UtilizationGPU != 25 || g.FanSpeed != 45 || g.PowerDraw != 120.5 {
+		t.Errorf("unexpected readings: temp=%v util=%v fan=%v power=%v", g.Temperature, g.UtilizationGPU, g.FanSpeed, g.PowerDraw)
+	}
+	if g.MemoryUsed != 2048*1024*1024 || g.UtilizationMemory != 20 {
+		t.Errorf("unexpected memory: used=%d util=%v", g.MemoryUsed, g.UtilizationMemory)
+	}
+
+	if gpus[1].Index != 1 || gpus[1].PowerDraw != 22.1 || gpus[1].FanSpeed != 0 {
+		t.Errorf("unexpected second GPU: %+v", gpus[1])
+	}
+
+	if _, err := parseNvidiaSMIXML([]byte("not xml")); err == nil {
+		t.Error("expected an error for malformed output")
+	}
+}
+
+func TestResolveGPUBackends(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured []string
+		want       []string
+		auto       bool
+	}{
+		{"empty probes all", nil, []string{"intel", "nvidia", "amd"}, true},
+		{"auto probes all", []string{"auto"}, []string{"intel", "nvidia", "amd"}, true},
+		{"none probes nothing", []string{"none"}, nil, false},
+		{"explicit list keeps collection order", []string{"AMD", "nvidia"}, []string{"nvidia", "amd"}, false},
+		{"unknown only falls back to auto", []string{"matrox"}, []string{"intel", "nvidia", "amd"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, auto := resolveGPUBackends(tt.configured)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || auto != tt.auto {
+				t.Errorf("resolveGPUBackends(%v) = %v, %v; want %v, %v", tt.configured, got, auto, tt.want, tt.auto)
+			}
+		})
+	}
+}
+
+func TestGPUCollectorSkipsIdleBackends(t *testing.T) {
+	c := NewGPUCollector(&domain.Context{Hub: domain.NewEventBus(10), GPUBackends: []string{"none"}})
+	if len(c.backends) != 0 {
+		t.Fatalf("backends = %v, want none", c.backends)
+	}
+
+	c = NewGPUCollector(&domain.Context{Hub: domain.NewEventBus(10)})
+	for _, backend := range c.backends {
+		c.idleUntil[backend] = time.Now().Add(time.Hour)
+	}
+	sub := c.ctx.Hub.Sub("gpu_metrics_update")
+	defer c.ctx.Hub.Unsub(sub)
+	c.Collect()
+	select {
+	case <-sub:
+		t.Error("idle backends were probed")
+	default:
+	}
+}
@@ -0,0 +1,194 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Package-level variables (not constants) so tests can use fixture trees.
+var (
+	guestTrafficNetDir     = "/sys/class/net"
+	guestTrafficProcDir    = "/proc"
+	guestTrafficLibvirtDir = constants.LibvirtQemuStateDir
+	guestTrafficCgroupDir  = constants.DockerCgroupDir
+)
+
+// GuestTrafficCollector attributes the traffic of host virtual interfaces to
+// their owners: vnetX taps to the running VM whose live libvirt XML names
+// them, and veth interfaces to the container holding the other end of the
+// pair. These interfaces are left out of per-interface network views, so
+// this is the only place their traffic is accounted for.
+type GuestTrafficCollector struct {
+	ctx  *domain.Context
+	mu   sync.Mutex             // protects prev
+	prev map[string]netSnapshot // keyed by host interface name
+}
+
+// NewGuestTrafficCollector creates a new guest traffic collector.
+func NewGuestTrafficCollector(ctx *domain.Context) *GuestTrafficCollector {
+	return &GuestTrafficCollector{ctx: ctx, prev: make(map[string]netSnapshot)}
+}
+
+// Start begins the guest traffic collection loop.
+func (c *GuestTrafficCollector) Start(ctx context.Context, interval time.Duration) {
+	logger.InfoContext(ctx, "Guest traffic collector started (interval: %v)", interval)
+
+	collect := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "Guest traffic collector", r)
+			}
+		}()
+		collectWithWatchdog(ctx, "Guest traffic", interval, c.Collect)
+	}
+	collect()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Guest traffic collector stopped")
+			return
+		case <-ticker.C:
+			collect()
+		}
+	}
+}
+
+// Collect maps the host virtual interfaces to their owners and publishes
+// their traffic.
+func (c *GuestTrafficCollector) Collect() {
+	traffic := c.collectGuestTraffic(time.Now())
+	domain.Publish(c.ctx.Hub, constants.TopicGuestTrafficUpdate, traffic)
+	logger.Debug("Guest traffic: published %d interfaces", len(traffic.Interfaces))
+}
+
+// collectGuestTraffic reads the counters of every attributed interface and
+// computes rates against the previous collection.
+func (c *GuestTrafficCollector) collectGuestTraffic(now time.Time) *dto.GuestTraffic {
+	owners := vmTapOwners()
+	for iface, owner := range containerVethOwners() {
+		owners[iface] = owner
+	}
+
+	traffic := &dto.GuestTraffic{
+		Interfaces: make([]dto.GuestInterfaceTraffic, 0, len(owners)),
+		Timestamp:  now,
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	seen := make(map[string]bool, len(owners))
+	for iface, owner := range owners {
+		dir := filepath.Join(guestTrafficNetDir, iface)
+		hostRX, errRX := strconv.ParseUint(readSysfsValue(dir, "statistics/rx_bytes"), 10, 64)
+		hostTX, errTX := strconv.ParseUint(readSysfsValue(dir, "statistics/tx_bytes"), 10, 64)
+		if errRX != nil || errTX != nil {
+			continue
+		}
+		seen[iface] = true
+
+		// The host side of a tap or veth transmits what the guest receives.
+		owner.Interface = iface
+		owner.RXBytes, owner.TXBytes = hostTX, hostRX
+		if master, err := os.Readlink(filepath.Join(dir, "master")); err == nil {
+			owner.Bridge = filepath.Base(master)
+		}
+		if prev, ok := c.prev[iface]; ok {
+			if dt := now.Sub(prev.readAt).Seconds(); dt > 0 {
+				if owner.RXBytes >= prev.rx {
+					owner.RXBytesPerSec = float64(owner.RXBytes-prev.rx) / dt
+				}
+				if owner.TXBytes >= prev.tx {
+					owner.TXBytesPerSec = float64(owner.TXBytes-prev.tx) / dt
+				}
+			}
+		}
+		c.prev[iface] = netSnapshot{rx: owner.RXBytes, tx: owner.TXBytes, readAt: now}
+		traffic.Interfaces = append(traffic.Interfaces, owner)
+	}
+	for iface := range c.prev {
+		if !seen[iface] {
+			delete(c.prev, iface)
+		}
+	}
+
+	slices.SortFunc(traffic.Interfaces, func(a, b dto.GuestInterfaceTraffic) int {
+		return strings.Compare(a.Interface, b.Interface)
+	})
+	return traffic
+}
+
+// vmTapOwners maps the tap interfaces named in the live XML of each running
+// VM (<name>.xml in the libvirt state directory) to that VM.
+func vmTapOwners() map[string]dto.GuestInterfaceTraffic {
+	owners := map[string]dto.GuestInterfaceTraffic{}
+	matches, _ := filepath.Glob(filepath.Join(guestTrafficLibvirtDir, "*.xml"))
+	for _, path := range matches {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path from a glob of the libvirt state directory
+		if err != nil {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(path), ".xml")
+		for _, iface := range extractInterfaceTargets(string(data)) {
+			owners[iface] = dto.GuestInterfaceTraffic{OwnerType: "vm", Owner: name}
+		}
+	}
+	return owners
+}
+
+// containerVethOwners maps host veth interfaces to the container holding the
+// peer end. A container's own interfaces are read through the sysfs of its
+// first process; the iflink of each is the host ifindex of its veth peer.
+func containerVethOwners() map[string]dto.GuestInterfaceTraffic {
+	owners := map[string]dto.GuestInterfaceTraffic{}
+	hostByIndex := map[string]string{}
+	entries, _ := os.ReadDir(guestTrafficNetDir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "veth") {
+			if idx := readSysfsValue(filepath.Join(guestTrafficNetDir, e.Name()), "ifindex"); idx != "" {
+				hostByIndex[idx] = e.Name()
+			}
+		}
+	}
+	if len(hostByIndex) == 0 {
+		return owners
+	}
+
+	containers, _ := os.ReadDir(guestTrafficCgroupDir)
+	for _, e := range containers {
+		id := e.Name()
+		if !e.IsDir() || len(id) < 12 {
+			continue
+		}
+		pid, _, _ := strings.Cut(readSysfsValue(filepath.Join(guestTrafficCgroupDir, id), "cgroup.procs"), "\n")
+		if pid == "" {
+			continue
+		}
+		netDir := filepath.Join(guestTrafficProcDir, pid, "root", "sys", "class", "net")
+		ifaces, _ := os.ReadDir(netDir)
+		for _, iface := range ifaces {
+			dir := filepath.Join(netDir, iface.Name())
+			iflink := readSysfsValue(dir, "iflink")
+			if iflink == "" || iflink == readSysfsValue(dir, "ifindex") {
+				continue
+			}
+			if host, ok := hostByIndex[iflink]; ok {
+				owners[host] = dto.GuestInterfaceTraffic{OwnerType: "container", Owner: id[:12]}
+			}
+		}
+	}
+	return owners
+}
@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCollectGuestTraffic(t *testing.T) {
+	root := t.TempDir()
+	origNet, origProc, origLibvirt, origCgroup := guestTrafficNetDir, guestTrafficProcDir, guestTrafficLibvirtDir, guestTrafficCgroupDir
+	t.Cleanup(func() {
+		guestTrafficNetDir, guestTrafficProcDir, guestTrafficLibvirtDir, guestTrafficCgroupDir = origNet, origProc, origLibvirt, origCgroup
+	})
+	guestTrafficNetDir = filepath.Join(root, "sys", "class", "net")
+	guestTrafficProcDir = filepath.Join(root, "proc")
+	guestTrafficLibvirtDir = filepath.Join(root, "libvirt")
+	guestTrafficCgroupDir = filepath.Join(root, "cgroup")
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hostIface := func(name, ifindex string, rx, tx int) {
+		dir := filepath.Join(guestTrafficNetDir, name)
+		writeFile(filepath.Join(dir, "ifindex"), ifindex+"\n")
+		writeFile(filepath.Join(dir, "statistics", "rx_bytes"), strconv.Itoa(rx))
+		writeFile(filepath.Join(dir, "statistics", "tx_bytes"), strconv.Itoa(tx))
+	}
+
+	// VM "Windows 11" owns vnet0, attached to br0.
+	writeFile(filepath.Join(guestTrafficLibvirtDir, "Windows 11.xml"),
+		`<domstatus><domain type='kvm'><name>Windows 11</name><devices><interface type='bridge'><source bridge='br0'/><target dev='vnet0'/></interface></devices></domain></domstatus>`)
+	hostIface("vnet0", "12", 1000, 5000)
+	if err := os.MkdirAll(filepath.Join(root, "bridges", "br0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "bridges", "br0"), filepath.Join(guestTrafficNetDir, "vnet0", "master")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Container abc123def456… has eth0 whose peer is host veth1a2b (ifindex 20).
+	const fullID = "abc123def4567890abc123def4567890abc123def4567890abc123def4567890"
+	writeFile(filepath.Join(guestTrafficCgroupDir, fullID, "cgroup.procs"), "4242\n4250\n")
+	containerNet := filepath.Join(guestTrafficProcDir, "4242", "root", "sys", "class", "net")
+	writeFile(filepath.Join(containerNet, "eth0", "ifindex"), "2\n")
+	writeFile(filepath.Join(containerNet, "eth0", "iflink"), "20\n")
+	writeFile(filepath.Join(containerNet, "lo", "ifindex"), "1\n")
+	writeFile(filepath.Join(containerNet, "lo", "iflink"), "1\n")
+	hostIface("veth1a2b", "20", 300, 700)
+	hostIface("veth9999", "21", 1, 1) // peer of no known container
+
+	c := NewGuestTrafficCollector(nil)
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	traffic := c.collectGuestTraffic(now)
+	if len(traffic.Interfaces) != 2 {
+		t.Fatalf("interfaces = %+v", traffic.Interfaces)
+	}
+	veth, vnet := traffic.Interfaces[0], traffic.Interfaces[1]
+	if veth.Interface != "veth1a2b" || veth.OwnerType != "container" || veth.Owner != "abc123def456" || veth.RXBytes != 700 || veth.TXBytes != 300 {
+		t.Errorf("veth = %+v", veth)
+	}
+	if vnet.Interface != "vnet0" || vnet.OwnerType != "vm" || vnet.Owner != "Windows 11" || vnet.Bridge != "br0" || vnet.RXBytes != 5000 || vnet.TXBytes != 1000 {
+		t.Errorf("vnet = %+v", vnet)
+	}
+	if vnet.RXBytesPerSec != 0 {
+		t.Errorf("first collection should have no rate: %+v", vnet)
+	}
+
+	hostIface("vnet0", "12", 2000, 15000)
+	traffic = c.collectGuestTraffic(now.Add(10 * time.Second))
+	if vnet := traffic.Interfaces[1]; vnet.RXBytesPerSec != 1000 || vnet.TXBytesPerSec != 100 {
+		t.Errorf("vnet rates = %+v", vnet)
+	}
+}
@@ -25,13 +25,13 @@ func NewHardwareCollector(ctx *domain.Context) *HardwareCollector {
 // Start begins the hardware collector's periodic data collection.
 // It runs in a goroutine and publishes hardware information updates at the specified interval until the context is cancelled.
 func (c *HardwareCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting hardware collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting hardware collector (interval: %v)", interval)
 
 	// Run once immediately with panic recovery
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Hardware collector", r)
+				logger.LogPanicWithStackContext(ctx, "Hardware collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "Hardware", interval, c.Collect)
@@ -43,13 +43,13 @@ func (c *HardwareCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Hardware collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "Hardware collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Hardware collector", r)
+						logger.LogPanicWithStackContext(ctx, "Hardware collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "Hardware", interval, c.Collect)
@@ -0,0 +1,258 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/platform"
+)
+
+// ipmiCommandTimeout bounds one ipmitool call; a BMC that stopped answering
+// otherwise blocks until ipmitool's own retries give up.
+const ipmiCommandTimeout = 30 * time.Second
+
+// IPMICollector reads the chassis status and the fan, voltage, temperature,
+// power and power supply sensors of the server's BMC with ipmitool. Boards
+// without a BMC (or without ipmitool) publish available=false.
+type IPMICollector struct {
+	ctx *domain.Context
+}
+
+// NewIPMICollector creates a new IPMI collector.
+func NewIPMICollector(ctx *domain.Context) *IPMICollector {
+	return &IPMICollector{ctx: ctx}
+}
+
+// Start begins the IPMI collection loop.
+func (c *IPMICollector) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.InfoContext(ctx, "IPMI collector started (interval: %v)", interval)
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "IPMI collector", r)
+			}
+		}()
+		collectWithWatchdog(ctx, "IPMI", interval, c.Collect)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "IPMI collector stopped")
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						logger.LogPanicWithStackContext(ctx, "IPMI collector", r)
+					}
+				}()
+				collectWithWatchdog(ctx, "IPMI", interval, c.Collect)
+			}()
+		}
+	}
+}
+
+// Collect reads the BMC and publishes the result.
+func (c *IPMICollector) Collect() {
+	status := collectIPMI()
+	domain.Publish(c.ctx.Hub, constants.TopicIPMIUpdate, status)
+	logger.Debug("IPMI: published %d sensor(s), available=%v", len(status.Sensors), status.Available)
+}
+
+func collectIPMI() *dto.IPMIStatus {
+	status := &dto.IPMIStatus{Sensors: []dto.IPMISensor{}, Timestamp: time.Now()}
+	if !platform.BinaryExists(constants.IpmitoolBin) {
+		return status
+	}
+
+	// `ipmitool sensor` lists threshold sensors with their thresholds; it
+	// fails when there is no BMC or the ipmi_devintf module is not loaded.
+	lines, err := lib.ExecCommandWithTimeout(ipmiCommandTimeout, constants.IpmitoolBin, "sensor")
+	if err != nil {
+		logger.Debug("IPMI: sensor read failed: %v", err)
+		return status
+	}
+	status.Available = true
+	status.Sensors = parseIPMISensors(lines)
+
+	if lines, err := lib.ExecCommandWithTimeout(ipmiCommandTimeout, constants.IpmitoolBin, "sdr", "type", "Power Supply"); err == nil {
+		status.Sensors = append(status.Sensors, parseIPMIPowerSupplies(lines)...)
+	} else {
+		logger.Debug("IPMI: power supply read failed: %v", err)
+	}
+
+	if lines, err := lib.ExecCommandWithTimeout(ipmiCommandTimeout, constants.IpmitoolBin, "chassis", "status"); err == nil {
+		status.Chassis = parseIPMIChassisStatus(lines)
+	} else {
+		logger.Debug("IPMI: chassis status failed: %v", err)
+	}
+
+	for _, s := range status.Sensors {
+		if ipmiSensorProblem(s) {
+			status.ProblemCount++
+		}
+	}
+	return status
+}
+
+// ipmiSensorProblem reports whether a sensor is past a threshold or a power
+// supply reports a fault. Sensors without a reading (empty fan headers) are
+// not problems.
+func ipmiSensorProblem(s dto.IPMISensor) bool {
+	switch s.Status {
+	case "nc", "cr", "nr":
+		return true
+	}
+	return s.Type == dto.IPMISensorPowerSupply && ipmiPowerSupplyFault(s.Reading)
+}
+
+// parseIPMISensors parses `ipmitool sensor` output:
+//
+//	FAN1       | 1400.000   | RPM        | ok    | 300.000 | 500.000 | 700.000 | 25300.000 | 25400.000 | 25500.000
+//
+// The columns are name, value, unit, status and the lower non-recoverable,
+// lower critical, lower non-critical, upper non-critical, upper critical and
+// upper non-recoverable thresholds. Discrete sensors are skipped; power
+// supplies are read separately with their event text.
+func parseIPMISensors(lines []string) []dto.IPMISensor {
+	sensors := []dto.IPMISensor{}
+	for _, line := range lines {
+		fields := strings.Split(line, "|")
+		if len(fields) < 4 {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		unit := fields[2]
+		if fields[0] == "" || unit == "discrete" {
+			continue
+		}
+
+		sensor := dto.IPMISensor{
+			Name:   fields[0],
+			Type:   ipmiSensorType(unit),
+			Unit:   unit,
+			Value:  parseIPMIFloat(fields[1]),
+			Status: fields[3],
+		}
+		if sensor.Value == nil || sensor.Status == "na" {
+			sensor.Status = "ns"
+		}
+		if len(fields) >= 10 {
+			sensor.LowerCritical = parseIPMIFloat(fields[5])
+			sensor.UpperCritical = parseIPMIFloat(fields[8])
+		}
+		sensors = append(sensors, sensor)
+	}
+	return sensors
+}
+
+// parseIPMIPowerSupplies parses `ipmitool sdr type "Power Supply"` output:
+//
+//	PS1 Status       | C8h | ok  | 10.1 | Presence detected
+//	PS2 Status       | C9h | ok  | 10.2 | Presence detected, Power Supply AC lost
+func parseIPMIPowerSupplies(lines []string) []dto.IPMISensor {
+	var supplies []dto.IPMISensor
+	for _, line := range lines {
+		fields := strings.Split(line, "|")
+		if len(fields) < 5 {
+			continue
+		}
+		name := strings.TrimSpace(fields[0])
+		if name == "" {
+			continue
+		}
+		reading := strings.TrimSpace(fields[4])
+		status := strings.TrimSpace(fields[2])
+		if ipmiPowerSupplyFault(reading) {
+			status = "cr"
+		}
+		supplies = append(supplies, dto.IPMISensor{
+			Name:    name,
+			Type:    dto.IPMISensorPowerSupply,
+			Status:  status,
+			Reading: reading,
+		})
+	}
+	return supplies
+}
+
+// ipmiPowerSupplyFault reports whether a power supply event text names a
+// failure, a predictive failure, or lost input power.
+func ipmiPowerSupplyFault(reading string) bool {
+	r := strings.ToLower(reading)
+	return strings.Contains(r, "fail") || strings.Contains(r, "lost") || strings.Contains(r, "out-of-range")
+}
+
+// parseIPMIChassisStatus parses `ipmitool chassis status` output of
+// "Key : value" lines.
+func parseIPMIChassisStatus(lines []string) *dto.IPMIChassis {
+	chassis := &dto.IPMIChassis{}
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "System Power":
+			chassis.PowerOn = value == "on"
+		case "Power Overload":
+			chassis.PowerOverload = value == "true"
+		case "Main Power Fault":
+			chassis.MainPowerFault = value == "true"
+		case "Power Control Fault":
+			chassis.PowerControlFault = value == "true"
+		case "Drive Fault":
+			chassis.DriveFault = value == "true"
+		case "Cooling/Fan Fault":
+			chassis.CoolingFault = value == "true"
+		case "Chassis Intrusion":
+			chassis.Intrusion = value == "active"
+		case "Power Restore Policy":
+			chassis.PowerRestorePolicy = value
+		case "Last Power Event":
+			chassis.LastPowerEvent = value
+		}
+	}
+	return chassis
+}
+
+func ipmiSensorType(unit string) string {
+	switch strings.ToLower(unit) {
+	case "rpm", "percent":
+		return dto.IPMISensorFan
+	case "volts":
+		return dto.IPMISensorVoltage
+	case "degrees c", "degrees f":
+		return dto.IPMISensorTemperature
+	case "watts":
+		return dto.IPMISensorPower
+	case "amps":
+		return dto.IPMISensorCurrent
+	default:
+		return dto.IPMISensorOther
+	}
+}
+
+// parseIPMIFloat parses an ipmitool number; "na" and empty fields are nil.
+func parseIPMIFloat(s string) *float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,76 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParseIPMISensors(t *testing.T) {
+	sensors := parseIPMISensors(strings.Split(`CPU Temp         | 36.000     | degrees C  | ok    | 0.000     | 0.000     | 0.000     | 90.000    | 95.000    | 95.000
+FAN1             | 1400.000   | RPM        | ok    | 300.000   | 500.000   | 700.000   | 25300.000 | 25400.000 | 25500.000
+FAN3             | na         | RPM        | na    | na        | na        | na        | na        | na        | na
+12V              | 10.100     | Volts      | cr    | 10.144    | 10.272    | 10.784    | 12.960    | 13.280    | 13.408
+PS1 Status       | 0x1        | discrete   | 0x0100| na        | na        | na        | na        | na        | na
+`, "\n"))
+
+	if len(sensors) != 4 {
+		t.Fatalf("sensors = %+v, want 4 threshold sensors", sensors)
+	}
+	temp, fan, empty, volt := sensors[0], sensors[1], sensors[2], sensors[3]
+	if temp.Type != dto.IPMISensorTemperature || temp.Value == nil || *temp.Value != 36 || *temp.UpperCritical != 95 {
+		t.Errorf("temperature = %+v", temp)
+	}
+	if fan.Type != dto.IPMISensorFan || *fan.Value != 1400 || *fan.LowerCritical != 500 || fan.Status != "ok" {
+		t.Errorf("fan = %+v", fan)
+	}
+	if empty.Value != nil || empty.Status != "ns" || ipmiSensorProblem(empty) {
+		t.Errorf("empty fan header = %+v", empty)
+	}
+	if volt.Type != dto.IPMISensorVoltage || !ipmiSensorProblem(volt) {
+		t.Errorf("voltage = %+v, want critical", volt)
+	}
+}
+
+func TestParseIPMIPowerSupplies(t *testing.T) {
+	supplies := parseIPMIPowerSupplies(strings.Split(`PS1 Status       | C8h | ok  | 10.1 | Presence detected
+PS2 Status       | C9h | ok  | 10.2 | Presence detected, Power Supply AC lost
+`, "\n"))
+
+	if len(supplies) != 2 {
+		t.Fatalf("supplies = %+v", supplies)
+	}
+	if supplies[0].Status != "ok" || supplies[0].Reading != "Presence detected" || ipmiSensorProblem(supplies[0]) {
+		t.Errorf("PS1 = %+v", supplies[0])
+	}
+	if supplies[1].Status != "cr" || !ipmiSensorProblem(supplies[1]) {
+		t.Errorf("PS2 = %+v, want AC lost fault", supplies[1])
+	}
+}
+
+func TestParseIPMIChassisStatus(t *testing.T) {
+	chassis := parseIPMIChassisStatus(strings.Split(`System Power         : on
+Power Overload       : false
+Power Interlock      : inactive
+Main Power Fault     : false
+Power Control Fault  : false
+Power Restore Policy : always-on
+Last Power Event     : command
+Chassis Intrusion    : active
+Front-Panel Lockout  : inactive
+Drive Fault          : false
+Cooling/Fan Fault    : true
+`, "\n"))
+
+	want := dto.IPMIChassis{
+		PowerOn:            true,
+		CoolingFault:       true,
+		Intrusion:          true,
+		PowerRestorePolicy: "always-on",
+		LastPowerEvent:     "command",
+	}
+	if *chassis != want {
+		t.Errorf("chassis = %+v, want %+v", *chassis, want)
+	}
+}
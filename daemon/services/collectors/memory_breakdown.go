@@ -0,0 +1,148 @@
+package collectors
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// tmpfsMagic is the statfs type of a tmpfs filesystem.
+const tmpfsMagic = 0x01021994
+
+// Package-level variables so tests can use fixture files.
+var (
+	memBreakdownMeminfo   = "/proc/meminfo"
+	memBreakdownProcDir   = "/proc"
+	memBreakdownDockerDir = constants.DockerCgroupDir
+	memBreakdownTmpfs     = []string{"/dev/shm", "/var/log"}
+	memBreakdownStatfs    = syscall.Statfs
+)
+
+// getMemoryBreakdown splits memory use into huge pages, tmpfs, slab and the
+// share of Docker, VMs and the host. memUsed is the used RAM from
+// getMemoryInfo, which the attribution divides up.
+func (c *SystemCollector) getMemoryBreakdown(memUsed uint64) *dto.MemoryBreakdown {
+	meminfo, err := readMeminfo(memBreakdownMeminfo)
+	if err != nil {
+		logger.Debug("Failed to read memory breakdown: %v", err)
+		return nil
+	}
+
+	// HugePages_* are page counts; the other fields are in kB.
+	b := &dto.MemoryBreakdown{
+		HugePagesTotal:    meminfo["HugePages_Total"],
+		HugePagesFree:     meminfo["HugePages_Free"],
+		HugePagesReserved: meminfo["HugePages_Rsvd"],
+		HugePageSize:      meminfo["Hugepagesize"] * 1024,
+		Slab:              meminfo["Slab"] * 1024,
+		SlabReclaimable:   meminfo["SReclaimable"] * 1024,
+		SlabUnreclaim:     meminfo["SUnreclaim"] * 1024,
+		Shmem:             meminfo["Shmem"] * 1024,
+		Tmpfs:             readTmpfsUsage(),
+	}
+	b.HugePagesBytes = b.HugePagesTotal * b.HugePageSize
+	if b.HugePagesTotal >= b.HugePagesFree {
+		b.HugePagesUsed = (b.HugePagesTotal - b.HugePagesFree) * b.HugePageSize
+	}
+
+	b.DockerBytes = dockerCgroupMemory(memBreakdownDockerDir)
+	b.VMBytes = qemuResidentMemory(memBreakdownProcDir) + b.HugePagesUsed
+	if guests := b.DockerBytes + b.VMBytes; memUsed > guests {
+		b.HostBytes = memUsed - guests
+	}
+	return b
+}
+
+// readMeminfo returns the values of /proc/meminfo by key, without units.
+func readMeminfo(path string) (map[string]uint64, error) {
+	file, err := os.Open(path) //nolint:gosec // G304: fixed procfs path
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[strings.TrimSuffix(fields[0], ":")] = v
+		}
+	}
+	return values, scanner.Err()
+}
+
+// readTmpfsUsage returns the usage of the RAM-backed tmpfs mounts. Paths
+// that are not tmpfs (e.g. /var/log on a disk) are skipped.
+func readTmpfsUsage() []dto.TmpfsUsage {
+	var usage []dto.TmpfsUsage
+	for _, mount := range memBreakdownTmpfs {
+		var st syscall.Statfs_t
+		if err := memBreakdownStatfs(mount, &st); err != nil || st.Type != tmpfsMagic {
+			continue
+		}
+		bsize := uint64(st.Bsize) //nolint:gosec // G115: block size is positive
+		u := dto.TmpfsUsage{
+			Mount:     mount,
+			SizeBytes: st.Blocks * bsize,
+			UsedBytes: (st.Blocks - st.Bfree) * bsize,
+		}
+		if u.SizeBytes > 0 {
+			u.Usage = math.Round(float64(u.UsedBytes)/float64(u.SizeBytes)*1000) / 10
+		}
+		usage = append(usage, u)
+	}
+	return usage
+}
+
+// dockerCgroupMemory returns the anonymous and kernel memory of all Docker
+// containers from the memory.stat of their parent cgroup. Page cache is left
+// out: it is already part of the cached RAM and freed under pressure.
+func dockerCgroupMemory(dir string) uint64 {
+	stat, err := readMeminfo(filepath.Join(dir, "memory.stat"))
+	if err != nil {
+		return 0
+	}
+	kernel, ok := stat["kernel"]
+	if !ok {
+		// Kernels before 5.18 report the kernel memory in parts.
+		kernel = stat["kernel_stack"] + stat["pagetables"] + stat["percpu"] + stat["sock"] + stat["slab"]
+	}
+	return stat["anon"] + kernel
+}
+
+// qemuResidentMemory sums the resident memory of QEMU processes. Huge pages
+// backing guest RAM are not included in VmRSS.
+func qemuResidentMemory(procDir string) uint64 {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return 0
+	}
+	var total uint64
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err != nil {
+			continue
+		}
+		dir := filepath.Join(procDir, e.Name())
+		if !strings.HasPrefix(readSysfsValue(dir, "comm"), "qemu-system") {
+			continue
+		}
+		status, err := readMeminfo(filepath.Join(dir, "status"))
+		if err != nil {
+			continue
+		}
+		total += status["VmRSS"] * 1024
+	}
+	return total
+}
@@ -0,0 +1,97 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func writeMemFixture(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetMemoryBreakdown(t *testing.T) {
+	dir := t.TempDir()
+	oldMeminfo, oldProc, oldDocker, oldTmpfs, oldStatfs := memBreakdownMeminfo, memBreakdownProcDir, memBreakdownDockerDir, memBreakdownTmpfs, memBreakdownStatfs
+	t.Cleanup(func() {
+		memBreakdownMeminfo, memBreakdownProcDir, memBreakdownDockerDir, memBreakdownTmpfs, memBreakdownStatfs = oldMeminfo, oldProc, oldDocker, oldTmpfs, oldStatfs
+	})
+	memBreakdownMeminfo = filepath.Join(dir, "meminfo")
+	memBreakdownProcDir = filepath.Join(dir, "proc")
+	memBreakdownDockerDir = filepath.Join(dir, "docker")
+	memBreakdownTmpfs = []string{"/dev/shm", "/var/log", "/mnt/cache"}
+	memBreakdownStatfs = func(path string, st *syscall.Statfs_t) error {
+		st.Type, st.Bsize = tmpfsMagic, 4096
+		switch path {
+		case "/var/log":
+			st.Blocks, st.Bfree = 32768, 29696 // 128 MiB, 12 MiB used
+		case "/dev/shm":
+			st.Blocks, st.Bfree = 1024, 1024
+		default:
+			st.Type = 0x9123683e // btrfs
+		}
+		return nil
+	}
+
+	writeMemFixture(t, memBreakdownMeminfo, `MemTotal:       32768000 kB
+Shmem:            524288 kB
+Slab:            1048576 kB
+SReclaimable:     786432 kB
+SUnreclaim:       262144 kB
+HugePages_Total:    4096
+HugePages_Free:     1024
+HugePages_Rsvd:        0
+HugePages_Surp:        0
+Hugepagesize:       2048 kB
+`)
+	writeMemFixture(t, filepath.Join(memBreakdownDockerDir, "memory.stat"), "anon 3000000000\nfile 900000000\nkernel 100000000\n")
+	writeMemFixture(t, filepath.Join(memBreakdownProcDir, "1234", "comm"), "qemu-system-x86\n")
+	writeMemFixture(t, filepath.Join(memBreakdownProcDir, "1234", "status"), "Name:\tqemu-system-x86\nVmRSS:\t  1048576 kB\n")
+	writeMemFixture(t, filepath.Join(memBreakdownProcDir, "99", "comm"), "nginx\n")
+	writeMemFixture(t, filepath.Join(memBreakdownProcDir, "99", "status"), "VmRSS:\t  2048 kB\n")
+
+	const gib = 1 << 30
+	b := (&SystemCollector{}).getMemoryBreakdown(20 * gib)
+	if b == nil {
+		t.Fatal("breakdown is nil")
+	}
+	if b.HugePageSize != 2<<20 || b.HugePagesBytes != 8*gib || b.HugePagesUsed != 6*gib {
+		t.Errorf("huge pages: size %d, pool %d, used %d", b.HugePageSize, b.HugePagesBytes, b.HugePagesUsed)
+	}
+	if b.Slab != gib || b.SlabReclaimable != 768<<20 || b.SlabUnreclaim != 256<<20 || b.Shmem != 512<<20 {
+		t.Errorf("slab/shmem = %+v", b)
+	}
+	if b.DockerBytes != 3100000000 {
+		t.Errorf("DockerBytes = %d, want anon+kernel", b.DockerBytes)
+	}
+	if b.VMBytes != 7*gib {
+		t.Errorf("VMBytes = %d, want QEMU RSS plus used huge pages", b.VMBytes)
+	}
+	if b.HostBytes != 20*gib-b.DockerBytes-b.VMBytes {
+		t.Errorf("HostBytes = %d", b.HostBytes)
+	}
+	if len(b.Tmpfs) != 2 {
+		t.Fatalf("tmpfs = %+v, want /dev/shm and /var/log only", b.Tmpfs)
+	}
+	if lg := b.Tmpfs[1]; lg.Mount != "/var/log" || lg.SizeBytes != 128<<20 || lg.UsedBytes != 12<<20 || lg.Usage != 9.4 {
+		t.Errorf("/var/log = %+v", lg)
+	}
+}
+
+func TestDockerCgroupMemoryLegacyKernel(t *testing.T) {
+	dir := t.TempDir()
+	writeMemFixture(t, filepath.Join(dir, "memory.stat"), "anon 1000\nfile 5000\nkernel_stack 10\npagetables 20\npercpu 30\nsock 40\nslab 100\n")
+	if got := dockerCgroupMemory(dir); got != 1200 {
+		t.Errorf("dockerCgroupMemory = %d, want 1200", got)
+	}
+	if got := dockerCgroupMemory(filepath.Join(dir, "missing")); got != 0 {
+		t.Errorf("missing cgroup = %d", got)
+	}
+}
@@ -44,7 +44,7 @@ func NewMoverCollector(ctx *domain.Context) *MoverCollector {
 
 // Start begins the periodic mover status collection after a startup stagger.
 func (c *MoverCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting mover collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting mover collector (interval: %v)", interval)
 
 	select {
 	case <-ctx.Done():
@@ -55,7 +55,7 @@ func (c *MoverCollector) Start(ctx context.Context, interval time.Duration) {
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Mover collector", r)
+				logger.LogPanicWithStackContext(ctx, "Mover collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "Mover", interval, c.Collect)
@@ -67,13 +67,13 @@ func (c *MoverCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Mover collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "Mover collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Mover collector", r)
+						logger.LogPanicWithStackContext(ctx, "Mover collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "Mover", interval, c.Collect)
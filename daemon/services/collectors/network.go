@@ -41,13 +41,13 @@ func NewNetworkCollector(ctx *domain.Context) *NetworkCollector {
 // Start begins the network collector's periodic data collection.
 // It runs in a goroutine and publishes network interface updates at the specified interval until the context is cancelled.
 func (c *NetworkCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting network collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting network collector (interval: %v)", interval)
 
 	// Run once immediately with panic recovery
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Network collector", r)
+				logger.LogPanicWithStackContext(ctx, "Network collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "Network", interval, c.Collect)
@@ -59,13 +59,13 @@ func (c *NetworkCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Network collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "Network collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Network collector", r)
+						logger.LogPanicWithStackContext(ctx, "Network collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "Network", interval, c.Collect)
@@ -2,6 +2,7 @@ package collectors
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -186,63 +187,90 @@ func getWireGuardAccessURLs() []dto.AccessURL {
 	return urls
 }
 
-// getWANAccessURL returns the public WAN IP if accessible
-func getWANAccessURL() *dto.AccessURL {
-	// Try multiple services to get public IP
-	services := []string{
-		"https://api.ipify.org",
-		"https://ifconfig.me/ip",
-		"https://icanhazip.com",
-	}
+// publicIPServices are queried in order to discover the public IPv4 address.
+// Some of them answer with IPv6 on dual-stack hosts, so lookups are forced over
+// IPv4 (see newPublicIPClient) to keep the result from flipping between families.
+var publicIPServices = []string{
+	"https://api.ipify.org",
+	"https://ifconfig.me/ip",
+	"https://icanhazip.com",
+}
 
+// newPublicIPClient returns an HTTP client that only connects over IPv4.
+func newPublicIPClient() *http.Client {
+	dialer := &net.Dialer{}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "tcp4", addr)
+	}
 	// 2s per service keeps worst-case discovery under ~6s on networks without
 	// outbound internet access (issue #123); a reachable IP service answers in
-	// well under a second. The first successful response wins.
-	client := &http.Client{
-		Timeout: 2 * time.Second,
+	// well under a second.
+	return &http.Client{
+		Timeout:   2 * time.Second,
+		Transport: transport,
 	}
+}
 
-	for _, service := range services {
-		//nolint:gosec // G107: URL is from a trusted constant list of IP services
-		resp, err := client.Get(service)
-		if err != nil {
-			continue
+// lookupPublicIP returns the public IPv4 address as reported by the first
+// public IP service that answers, or "" when none is reachable.
+func lookupPublicIP() string {
+	client := newPublicIPClient()
+	for _, service := range publicIPServices {
+		if ip := fetchPublicIP(client, service); ip != "" {
+			return ip
 		}
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				logger.Debug("Error closing response body: %v", err)
-			}
-		}()
+	}
+	return ""
+}
 
-		if resp.StatusCode != http.StatusOK {
-			continue
+// fetchPublicIP queries a single public IP service and returns the IPv4
+// address it reports, or "" on any failure or a non-IPv4 answer.
+func fetchPublicIP(client *http.Client, service string) string {
+	//nolint:gosec // G107: URL is from a trusted constant list of IP services
+	resp, err := client.Get(service)
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Debug("Error closing response body: %v", err)
 		}
+	}()
 
-		body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
-		if err != nil {
-			continue
-		}
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
 
-		ip := strings.TrimSpace(string(body))
-		if net.ParseIP(ip) != nil {
-			return &dto.AccessURL{
-				Type: dto.URLTypeWAN,
-				Name: "Remote Access (WAN)",
-				IPv4: fmt.Sprintf("http://%s", ip),
-			}
-		}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return ""
 	}
 
-	// Try getting WAN IP from Unraid's network.ini if available
-	if wanIP := getWANIPFromUnraid(); wanIP != "" {
-		return &dto.AccessURL{
-			Type: dto.URLTypeWAN,
-			Name: "Remote Access (WAN)",
-			IPv4: fmt.Sprintf("http://%s", wanIP),
-		}
+	ip := strings.TrimSpace(string(body))
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.To4() == nil {
+		return ""
 	}
+	return parsed.To4().String()
+}
 
-	return nil
+// getWANAccessURL returns the public WAN IP if accessible
+func getWANAccessURL() *dto.AccessURL {
+	wanIP := lookupPublicIP()
+	if wanIP == "" {
+		// Try getting WAN IP from Unraid's network.ini if available
+		wanIP = getWANIPFromUnraid()
+	}
+	if wanIP == "" {
+		return nil
+	}
+
+	return &dto.AccessURL{
+		Type: dto.URLTypeWAN,
+		Name: "Remote Access (WAN)",
+		IPv4: fmt.Sprintf("http://%s", wanIP),
+	}
 }
 
 // getWANIPFromUnraid tries to get the WAN IP from Unraid's network configuration
@@ -1,7 +1,10 @@
 package collectors
 
 import (
+	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -252,3 +255,65 @@ func TestAccessURLStructure(t *testing.T) {
 		t.Errorf("IPv6 mismatch")
 	}
 }
+
+func TestFetchPublicIP(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"ipv4 with newline", http.StatusOK, "203.0.113.45\n", "203.0.113.45"},
+		{"ipv6 is rejected", http.StatusOK, "2001:db8::1", ""},
+		{"ipv4-mapped ipv6", http.StatusOK, "::ffff:203.0.113.45", "203.0.113.45"},
+		{"not an ip", http.StatusOK, "<html>blocked</html>", ""},
+		{"server error", http.StatusServiceUnavailable, "203.0.113.45", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			if got := fetchPublicIP(srv.Client(), srv.URL); got != tt.want {
+				t.Errorf("fetchPublicIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPublicIPClientStaysOnIPv4 covers a dual-stack host where an IP service
+// answers with whichever family the connection used: the lookup must never
+// switch to the IPv6 answer.
+func TestPublicIPClientStaysOnIPv4(t *testing.T) {
+	serve := func(network, addr, body string) string {
+		t.Helper()
+		ln, err := net.Listen(network, addr)
+		if err != nil {
+			t.Skipf("cannot listen on %s: %v", addr, err)
+		}
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = fmt.Fprint(w, body)
+		}))
+		srv.Listener = ln
+		srv.Start()
+		t.Cleanup(srv.Close)
+		_, port, _ := net.SplitHostPort(ln.Addr().String())
+		return port
+	}
+
+	client := newPublicIPClient()
+
+	// Reachable only over IPv6: the IPv4-only client must not use it.
+	v6Port := serve("tcp6", "[::1]:0", "2001:db8::1")
+	if got := fetchPublicIP(client, "http://[::1]:"+v6Port); got != "" {
+		t.Errorf("IPv6-only service answered %q, want no result", got)
+	}
+
+	v4Port := serve("tcp4", "127.0.0.1:0", "203.0.113.45")
+	if got := fetchPublicIP(client, "http://localhost:"+v4Port); got != "203.0.113.45" {
+		t.Errorf("IPv4 service = %q, want 203.0.113.45", got)
+	}
+}
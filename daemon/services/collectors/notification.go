@@ -75,7 +75,7 @@ func (c *NotificationCollector) Start(ctx context.Context, interval time.Duratio
 	// Top-level safety net for startup preamble panics
 	defer func() {
 		if r := recover(); r != nil {
-			logger.LogPanicWithStack("Notification collector (top-level)", r)
+			logger.LogPanicWithStackContext(ctx, "Notification collector (top-level)", r)
 		}
 	}()
 
@@ -89,31 +89,31 @@ func (c *NotificationCollector) Start(ctx context.Context, interval time.Duratio
 	var err error
 	c.watcher, err = fsnotify.NewWatcher()
 	if err != nil {
-		logger.Error("Failed to create file watcher: %v", err)
+		logger.ErrorContext(ctx, "Failed to create file watcher: %v", err)
 		return
 	}
 	defer func() {
 		if err := c.watcher.Close(); err != nil {
-			logger.Error("Failed to close file watcher: %v", err)
+			logger.ErrorContext(ctx, "Failed to close file watcher: %v", err)
 		}
 	}()
 
 	// Ensure directories exist
 	// #nosec G301 - Unraid standard permissions (0755 for directories)
 	if err := os.MkdirAll(notificationsDir, 0755); err != nil {
-		logger.Warning("Failed to create notifications directory: %v", err)
+		logger.WarningContext(ctx, "Failed to create notifications directory: %v", err)
 	}
 	// #nosec G301 - Unraid standard permissions (0755 for directories)
 	if err := os.MkdirAll(notificationsArchiveDir, 0755); err != nil {
-		logger.Warning("Failed to create notifications archive directory: %v", err)
+		logger.WarningContext(ctx, "Failed to create notifications archive directory: %v", err)
 	}
 
 	// Watch notification directories
 	if err := c.watcher.Add(notificationsDir); err != nil {
-		logger.Warning("Failed to watch notifications directory: %v", err)
+		logger.WarningContext(ctx, "Failed to watch notifications directory: %v", err)
 	}
 	if err := c.watcher.Add(notificationsArchiveDir); err != nil {
-		logger.Warning("Failed to watch notifications archive directory: %v", err)
+		logger.WarningContext(ctx, "Failed to watch notifications archive directory: %v", err)
 	}
 
 	ticker := time.NewTicker(interval)
@@ -123,7 +123,7 @@ func (c *NotificationCollector) Start(ctx context.Context, interval time.Duratio
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Notification collector", r)
+				logger.LogPanicWithStackContext(ctx, "Notification collector", r)
 			}
 		}()
 		c.collect()
@@ -132,13 +132,13 @@ func (c *NotificationCollector) Start(ctx context.Context, interval time.Duratio
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Notification collector stopped")
+			logger.InfoContext(ctx, "Notification collector stopped")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Notification collector", r)
+						logger.LogPanicWithStackContext(ctx, "Notification collector", r)
 					}
 				}()
 				c.collect()
@@ -147,19 +147,19 @@ func (c *NotificationCollector) Start(ctx context.Context, interval time.Duratio
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Notification collector (watcher)", r)
+						logger.LogPanicWithStackContext(ctx, "Notification collector (watcher)", r)
 					}
 				}()
 				// Trigger immediate collection on file changes
 				if event.Op&fsnotify.Create == fsnotify.Create ||
 					event.Op&fsnotify.Remove == fsnotify.Remove ||
 					event.Op&fsnotify.Write == fsnotify.Write {
-					logger.Debug("Notification file change detected: %s", event.Name)
+					logger.DebugContext(ctx, "Notification file change detected: %s", event.Name)
 					c.collect()
 				}
 			}()
 		case err := <-c.watcher.Errors:
-			logger.Error("File watcher error: %v", err)
+			logger.ErrorContext(ctx, "File watcher error: %v", err)
 		}
 	}
 }
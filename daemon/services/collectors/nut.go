@@ -29,13 +29,13 @@ func NewNUTCollector(ctx *domain.Context) *NUTCollector {
 
 // Start begins the NUT collector's periodic data collection.
 func (c *NUTCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting NUT collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting NUT collector (interval: %v)", interval)
 
 	// Run once immediately
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("NUT collector", r)
+				logger.LogPanicWithStackContext(ctx, "NUT collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "NUT", interval, c.Collect)
@@ -47,13 +47,13 @@ func (c *NUTCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("NUT collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "NUT collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("NUT collector", r)
+						logger.LogPanicWithStackContext(ctx, "NUT collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "NUT", interval, c.Collect)
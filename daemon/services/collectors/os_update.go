@@ -66,7 +66,7 @@ func NewOSUpdateCollector(ctx *domain.Context) *OSUpdateCollector {
 
 // Start begins the periodic OS update check after a startup stagger.
 func (c *OSUpdateCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting os_update collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting os_update collector (interval: %v)", interval)
 
 	select {
 	case <-ctx.Done():
@@ -77,7 +77,7 @@ func (c *OSUpdateCollector) Start(ctx context.Context, interval time.Duration) {
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("OSUpdate collector", r)
+				logger.LogPanicWithStackContext(ctx, "OSUpdate collector", r)
 			}
 		}()
 		c.Collect()
@@ -89,13 +89,13 @@ func (c *OSUpdateCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("OSUpdate collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "OSUpdate collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("OSUpdate collector", r)
+						logger.LogPanicWithStackContext(ctx, "OSUpdate collector", r)
 					}
 				}()
 				c.Collect()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -20,17 +21,24 @@ type ParityCollector struct {
 	// logPath is the path to the parity-checks.log file. Defaults to parityLogPath
 	// but can be overridden (primarily for testing).
 	logPath string
+
+	// annotationsPath is the JSON file holding notes and trigger sources for
+	// history records.
+	annotationsPath string
 }
 
 // NewParityCollector creates a new parity collector using the default log path.
 func NewParityCollector() *ParityCollector {
-	return &ParityCollector{logPath: parityLogPath}
+	return &ParityCollector{logPath: parityLogPath, annotationsPath: parityAnnotationsPath}
 }
 
 // NewParityCollectorWithPath creates a parity collector that reads from a custom
-// log path. Primarily intended for tests.
+// log path, keeping annotations in the same directory. Primarily intended for tests.
 func NewParityCollectorWithPath(path string) *ParityCollector {
-	return &ParityCollector{logPath: path}
+	return &ParityCollector{
+		logPath:         path,
+		annotationsPath: filepath.Join(filepath.Dir(path), filepath.Base(parityAnnotationsPath)),
+	}
 }
 
 // GetParityHistory reads and parses the parity-checks.log file
@@ -82,6 +90,7 @@ func (c *ParityCollector) GetParityHistory() (*dto.ParityCheckHistory, error) {
 	}
 
 	logger.Debug("Parity: Found %d parity check records", len(records))
+	c.annotate(records, time.Now())
 
 	return &dto.ParityCheckHistory{
 		Records:   records,
@@ -0,0 +1,238 @@
+package collectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// parityAnnotationsPath holds notes and trigger sources for parity history
+	// records. Unraid owns parity-checks.log, so annotations are kept beside
+	// the agent's other config files instead of being written into it.
+	parityAnnotationsPath = "/boot/config/plugins/unraid-management-agent/parity_annotations.json"
+
+	// MaxParityNoteLength is the longest note accepted for a history record.
+	MaxParityNoteLength = 500
+
+	// parityStartMatchSlack allows for the delay between the agent starting a
+	// check and Unraid beginning it when matching a start to its log record.
+	parityStartMatchSlack = 5 * time.Minute
+
+	// parityStartExpiry drops recorded starts that never produced a log
+	// record (e.g. the array was stopped mid-check).
+	parityStartExpiry = 7 * 24 * time.Hour
+)
+
+// ErrParityRecordNotFound is returned when no history record has the given ID.
+var ErrParityRecordNotFound = errors.New("parity check record not found")
+
+// parityAnnotationsMu serializes read-modify-write cycles of the annotations file.
+var parityAnnotationsMu sync.Mutex
+
+// parityAnnotationsFile is the on-disk JSON schema.
+type parityAnnotationsFile struct {
+	Records map[string]parityAnnotation `json:"records"`
+	Pending []parityStart               `json:"pending,omitempty"`
+}
+
+// parityAnnotation is the agent-side data for one history record.
+type parityAnnotation struct {
+	Note       string `json:"note,omitempty"`
+	Trigger    string `json:"trigger,omitempty"`
+	Correcting *bool  `json:"correcting,omitempty"`
+}
+
+// parityStart is a check started through the agent whose log record has not
+// been written yet.
+type parityStart struct {
+	StartedAt  time.Time `json:"started_at"`
+	Trigger    string    `json:"trigger"`
+	Correcting bool      `json:"correcting"`
+}
+
+// parityRecordID returns the stable ID of a history record.
+func parityRecordID(r dto.ParityCheckRecord) string {
+	return strconv.FormatInt(r.Date.Unix(), 10)
+}
+
+// RecordStart remembers that a parity check was started through the agent,
+// so the history record Unraid writes when it finishes carries the trigger.
+func (c *ParityCollector) RecordStart(trigger string, correcting bool, now time.Time) error {
+	parityAnnotationsMu.Lock()
+	defer parityAnnotationsMu.Unlock()
+
+	data, err := c.loadAnnotations()
+	if err != nil {
+		return err
+	}
+	data.Pending = append(data.Pending, parityStart{StartedAt: now, Trigger: trigger, Correcting: correcting})
+	return c.saveAnnotations(data)
+}
+
+// SetNote sets the note on the history record with id; an empty note clears
+// it. It returns the annotated record.
+func (c *ParityCollector) SetNote(id, note string) (dto.ParityCheckRecord, error) {
+	if len(note) > MaxParityNoteLength {
+		return dto.ParityCheckRecord{}, fmt.Errorf("note exceeds %d characters", MaxParityNoteLength)
+	}
+	history, err := c.GetParityHistory()
+	if err != nil {
+		return dto.ParityCheckRecord{}, err
+	}
+	idx := -1
+	for i, r := range history.Records {
+		if r.ID == id {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return dto.ParityCheckRecord{}, ErrParityRecordNotFound
+	}
+
+	parityAnnotationsMu.Lock()
+	defer parityAnnotationsMu.Unlock()
+
+	data, err := c.loadAnnotations()
+	if err != nil {
+		return dto.ParityCheckRecord{}, err
+	}
+	a := data.Records[id]
+	a.Note = note
+	if a == (parityAnnotation{}) {
+		delete(data.Records, id)
+	} else {
+		data.Records[id] = a
+	}
+	if err := c.saveAnnotations(data); err != nil {
+		return dto.ParityCheckRecord{}, err
+	}
+
+	record := history.Records[idx]
+	record.Note = note
+	return record, nil
+}
+
+// annotate assigns IDs to records, attributes recorded agent starts to the
+// records they produced, and applies stored notes and triggers. Annotation
+// failures are logged; the history itself is still returned.
+func (c *ParityCollector) annotate(records []dto.ParityCheckRecord, now time.Time) {
+	for i := range records {
+		records[i].ID = parityRecordID(records[i])
+	}
+
+	parityAnnotationsMu.Lock()
+	defer parityAnnotationsMu.Unlock()
+
+	data, err := c.loadAnnotations()
+	if err != nil {
+		logger.Debug("Parity: Failed to load annotations: %v", err)
+		return
+	}
+	if matchParityStarts(data, records, now) {
+		if err := c.saveAnnotations(data); err != nil {
+			logger.Warning("Parity: Failed to save annotations: %v", err)
+		}
+	}
+
+	for i := range records {
+		if a, ok := data.Records[records[i].ID]; ok {
+			records[i].Note = a.Note
+			records[i].Trigger = a.Trigger
+			records[i].Correcting = a.Correcting
+		}
+	}
+}
+
+// matchParityStarts moves each pending start onto the first record that
+// finished after it and began no earlier than it (allowing for
+// parityStartMatchSlack), and drops expired starts. It reports whether data
+// changed.
+func matchParityStarts(data *parityAnnotationsFile, records []dto.ParityCheckRecord, now time.Time) bool {
+	if len(data.Pending) == 0 {
+		return false
+	}
+	sorted := make([]dto.ParityCheckRecord, len(records))
+	copy(sorted, records)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	changed := false
+	kept := data.Pending[:0]
+	for _, p := range data.Pending {
+		matched := false
+		for _, r := range sorted {
+			began := r.Date.Add(-time.Duration(r.Duration) * time.Second)
+			if !r.Date.After(p.StartedAt) || began.Before(p.StartedAt.Add(-parityStartMatchSlack)) {
+				continue
+			}
+			id := parityRecordID(r)
+			a := data.Records[id]
+			if a.Trigger != "" {
+				continue
+			}
+			correcting := p.Correcting
+			a.Trigger, a.Correcting = p.Trigger, &correcting
+			data.Records[id] = a
+			matched = true
+			break
+		}
+		switch {
+		case matched:
+			changed = true
+		case now.Sub(p.StartedAt) > parityStartExpiry:
+			changed = true
+		default:
+			kept = append(kept, p)
+		}
+	}
+	data.Pending = kept
+	return changed
+}
+
+// loadAnnotations reads the annotations file; a missing file is empty.
+func (c *ParityCollector) loadAnnotations() (*parityAnnotationsFile, error) {
+	data := &parityAnnotationsFile{Records: map[string]parityAnnotation{}}
+	raw, err := os.ReadFile(c.annotationsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, nil
+		}
+		return nil, fmt.Errorf("read parity annotations: %w", err)
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("parse parity annotations: %w", err)
+	}
+	if data.Records == nil {
+		data.Records = map[string]parityAnnotation{}
+	}
+	return data, nil
+}
+
+// saveAnnotations writes the annotations file atomically.
+func (c *ParityCollector) saveAnnotations(data *parityAnnotationsFile) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal parity annotations: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.annotationsPath), 0o755); err != nil {
+		return fmt.Errorf("create parity annotations directory: %w", err)
+	}
+	tmp := c.annotationsPath + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("write parity annotations: %w", err)
+	}
+	if err := os.Rename(tmp, c.annotationsPath); err != nil {
+		return fmt.Errorf("rename parity annotations: %w", err)
+	}
+	return nil
+}
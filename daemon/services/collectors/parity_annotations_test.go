@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// Two checks: one finishing 2025-01-02 06:25:17 after 63380 s (started
+// 2025-01-01 12:49:57 UTC) and one finishing 2025-02-01 08:00:00 after 3600 s.
+const annotatedParityLog = `2025 Jan  2 06:25:17|63380|157791595|0|0|check P|9766436812
+2025 Feb  1 08:00:00|3600|157791595|0|0|check P|9766436812
+`
+
+func newAnnotatedParityCollector(t *testing.T) *ParityCollector {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "parity-checks.log")
+	if err := os.WriteFile(path, []byte(annotatedParityLog), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return NewParityCollectorWithPath(path)
+}
+
+func TestParityHistoryRecordIDs(t *testing.T) {
+	c := newAnnotatedParityCollector(t)
+	history, err := c.GetParityHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strconv.FormatInt(time.Date(2025, 1, 2, 6, 25, 17, 0, time.UTC).Unix(), 10)
+	if history.Records[0].ID != want {
+		t.Errorf("ID = %q, want %q", history.Records[0].ID, want)
+	}
+	if _, err := os.Stat(c.annotationsPath); !os.IsNotExist(err) {
+		t.Error("reading history without annotations should not create the annotations file")
+	}
+}
+
+func TestParitySetNote(t *testing.T) {
+	c := newAnnotatedParityCollector(t)
+	history, _ := c.GetParityHistory()
+	id := history.Records[1].ID
+
+	record, err := c.SetNote(id, "after disk swap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Note != "after disk swap" || record.ID != id {
+		t.Errorf("SetNote returned %+v", record)
+	}
+
+	history, _ = c.GetParityHistory()
+	if history.Records[1].Note != "after disk swap" || history.Records[0].Note != "" {
+		t.Errorf("notes = %q, %q", history.Records[0].Note, history.Records[1].Note)
+	}
+
+	if _, err := c.SetNote(id, ""); err != nil {
+		t.Fatal(err)
+	}
+	history, _ = c.GetParityHistory()
+	if history.Records[1].Note != "" {
+		t.Errorf("note not cleared: %q", history.Records[1].Note)
+	}
+
+	if _, err := c.SetNote("12345", "x"); !errors.Is(err, ErrParityRecordNotFound) {
+		t.Errorf("unknown ID error = %v", err)
+	}
+	if _, err := c.SetNote(id, strings.Repeat("x", MaxParityNoteLength+1)); err == nil {
+		t.Error("overlong note accepted")
+	}
+}
+
+func TestParityRecordStartAttributesTrigger(t *testing.T) {
+	c := newAnnotatedParityCollector(t)
+
+	// Started just before the first check began.
+	if err := c.RecordStart(dto.ParityTriggerMCP, true, time.Date(2025, 1, 1, 12, 48, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	// Started recently, after both checks finished; stays pending.
+	if err := c.RecordStart(dto.ParityTriggerAPI, false, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := c.GetParityHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := history.Records[0], history.Records[1]
+	if first.Trigger != dto.ParityTriggerMCP || first.Correcting == nil || !*first.Correcting {
+		t.Errorf("first record trigger = %q, correcting = %v", first.Trigger, first.Correcting)
+	}
+	if second.Trigger != "" {
+		t.Errorf("second record trigger = %q, want none", second.Trigger)
+	}
+
+	data, err := c.loadAnnotations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Pending) != 1 || data.Pending[0].Trigger != dto.ParityTriggerAPI {
+		t.Errorf("pending = %+v, want only the unmatched API start", data.Pending)
+	}
+}
+
+func TestMatchParityStartsSkipsEarlierChecks(t *testing.T) {
+	// A scheduled check that began well before the agent start must not be
+	// attributed to it, and expired starts are dropped.
+	records := []dto.ParityCheckRecord{
+		{Date: time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC), Duration: 86400},
+	}
+	now := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	data := &parityAnnotationsFile{
+		Records: map[string]parityAnnotation{},
+		Pending: []parityStart{
+			{StartedAt: time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC), Trigger: dto.ParityTriggerAPI},
+		},
+	}
+	if !matchParityStarts(data, records, now) {
+		t.Fatal("expired start should change data")
+	}
+	if len(data.Records) != 0 || len(data.Pending) != 0 {
+		t.Errorf("records = %+v, pending = %+v", data.Records, data.Pending)
+	}
+}
@@ -0,0 +1,140 @@
+package collectors
+
+import (
+	"bufio"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/ini.v1"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// mdstatPath is the Unraid md driver status file. Variable for testing.
+var mdstatPath = constants.ProcMdstat
+
+// paritySpeedSmoothing is the weight of the newest sample in the smoothed
+// parity operation speed.
+const paritySpeedSmoothing = 0.3
+
+// mdResyncKeys are the var.ini and /proc/mdstat keys read into mdResync.
+var mdResyncKeys = []string{"mdResyncPos", "mdResyncSize", "mdResyncDb", "mdResyncDt"}
+
+// mdResync holds the md driver's parity operation counters. Positions and
+// sizes are in 1 KiB blocks.
+type mdResync struct {
+	pos  uint64 // mdResyncPos: blocks processed
+	size uint64 // mdResyncSize: blocks in the operation
+	db   uint64 // mdResyncDb: blocks processed during the last dt seconds
+	dt   int64  // mdResyncDt: sample window in seconds; 0 while paused
+}
+
+// readMdResync returns the parity operation counters from var.ini, replaced
+// by the live values in /proc/mdstat when it is readable (var.ini is only
+// rewritten when emhttpd refreshes it).
+func readMdResync(section *ini.Section) mdResync {
+	values := map[string]string{}
+	for _, key := range mdResyncKeys {
+		if section.HasKey(key) {
+			values[key] = strings.Trim(section.Key(key).String(), `"`)
+		}
+	}
+
+	// #nosec G304 -- mdstatPath is the fixed /proc/mdstat constant (or a test override).
+	if f, err := os.Open(mdstatPath); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+			if ok && slices.Contains(mdResyncKeys, key) {
+				values[key] = strings.Trim(value, `"`)
+			}
+		}
+		_ = f.Close()
+	}
+
+	var r mdResync
+	r.pos, _ = strconv.ParseUint(values["mdResyncPos"], 10, 64)
+	r.size, _ = strconv.ParseUint(values["mdResyncSize"], 10, 64)
+	r.db, _ = strconv.ParseUint(values["mdResyncDb"], 10, 64)
+	r.dt, _ = strconv.ParseInt(values["mdResyncDt"], 10, 64)
+	return r
+}
+
+// parityProgress derives the speed and ETA of a parity operation from the
+// change in position between collections.
+type parityProgress struct {
+	mu sync.Mutex
+	parityProgressState
+}
+
+// parityProgressState is the tracked state of the current parity operation.
+type parityProgressState struct {
+	size       uint64
+	lastPos    uint64
+	lastAt     time.Time
+	speed      float64 // smoothed bytes per second
+	doneBytes  float64 // bytes processed while observed running
+	activeSecs float64 // seconds observed running
+}
+
+// apply fills the position, speed and ETA fields of status for the operation
+// described by r at time now.
+func (p *parityProgress) apply(status *dto.ArrayStatus, r mdResync, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if r.pos == 0 || r.size == 0 {
+		p.parityProgressState = parityProgressState{}
+		return
+	}
+	running := r.dt > 0
+
+	if p.lastAt.IsZero() || r.size != p.size || r.pos < p.lastPos {
+		// New operation (or a restart of one): begin tracking from here,
+		// seeding the speed from the driver's own last sample window.
+		p.parityProgressState = parityProgressState{size: r.size, lastPos: r.pos, lastAt: now}
+		if running && r.db > 0 {
+			p.speed = float64(r.db) * 1024 / float64(r.dt)
+		}
+	} else if elapsed := now.Sub(p.lastAt).Seconds(); elapsed > 0 {
+		if running {
+			delta := float64(r.pos-p.lastPos) * 1024
+			instant := delta / elapsed
+			if p.speed == 0 {
+				p.speed = instant
+			} else {
+				p.speed = paritySpeedSmoothing*instant + (1-paritySpeedSmoothing)*p.speed
+			}
+			p.doneBytes += delta
+			p.activeSecs += elapsed
+		}
+		p.lastPos, p.lastAt = r.pos, now
+	}
+
+	status.ParityCheckPositionBytes = r.pos * 1024
+	status.ParityCheckSizeBytes = r.size * 1024
+	if p.activeSecs > 0 {
+		status.ParityCheckAvgSpeed = p.doneBytes / p.activeSecs
+	}
+	if !running {
+		// Paused: no current speed or finish time.
+		return
+	}
+	status.ParityCheckSpeed = p.speed
+
+	speed := p.speed
+	if speed <= 0 {
+		speed = status.ParityCheckAvgSpeed
+	}
+	if speed > 0 && r.size > r.pos {
+		eta := time.Duration(float64((r.size-r.pos)*1024) / speed * float64(time.Second)).Round(time.Second)
+		finish := now.Add(eta)
+		status.ParityCheckETASeconds = int64(eta / time.Second)
+		status.ParityCheckFinishAt = &finish
+	}
+}
@@ -0,0 +1,80 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/ini.v1"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestReadMdResyncPrefersMdstat(t *testing.T) {
+	cfg, err := ini.Load([]byte("mdResyncPos=\"100\"\nmdResyncSize=\"1000\"\nmdResyncDt=\"0\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orig := mdstatPath
+	defer func() { mdstatPath = orig }()
+
+	mdstatPath = filepath.Join(t.TempDir(), "missing")
+	if r := readMdResync(cfg.Section("")); r.pos != 100 || r.size != 1000 || r.dt != 0 {
+		t.Errorf("var.ini fallback = %+v", r)
+	}
+
+	mdstatPath = filepath.Join(t.TempDir(), "mdstat")
+	mdstat := "sbName=/boot/config/super.dat\nmdResyncPos=250\nmdResyncDb=3000\nmdResyncDt=30\n"
+	if err := os.WriteFile(mdstatPath, []byte(mdstat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r := readMdResync(cfg.Section(""))
+	if r.pos != 250 || r.size != 1000 || r.db != 3000 || r.dt != 30 {
+		t.Errorf("mdstat override = %+v", r)
+	}
+}
+
+func TestParityProgressETA(t *testing.T) {
+	var p parityProgress
+	start := time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC)
+	const size = 10_000_000 // blocks (~9.5 GiB)
+
+	// First sample seeds the speed from the driver's window: 300000 KiB in 30 s.
+	status := &dto.ArrayStatus{}
+	p.apply(status, mdResync{pos: 1_000_000, size: size, db: 300_000, dt: 30}, start)
+	if status.ParityCheckSpeed != 10_240_000 || status.ParityCheckPositionBytes != 1_024_000_000 {
+		t.Fatalf("seeded status = %+v", status)
+	}
+	if status.ParityCheckETASeconds != 900 || !status.ParityCheckFinishAt.Equal(start.Add(900*time.Second)) {
+		t.Errorf("ETA = %d, finish = %v", status.ParityCheckETASeconds, status.ParityCheckFinishAt)
+	}
+
+	// 600000 blocks in 60 s is the same 10000 KiB/s.
+	status = &dto.ArrayStatus{}
+	p.apply(status, mdResync{pos: 1_600_000, size: size, dt: 30}, start.Add(time.Minute))
+	if status.ParityCheckSpeed != 10_240_000 || status.ParityCheckAvgSpeed != 10_240_000 {
+		t.Errorf("speed = %v, avg = %v", status.ParityCheckSpeed, status.ParityCheckAvgSpeed)
+	}
+	if status.ParityCheckETASeconds != 840 {
+		t.Errorf("ETA = %d, want 840", status.ParityCheckETASeconds)
+	}
+
+	// Paused: position and average stay, no current speed or ETA, and the
+	// paused time does not lower the average.
+	status = &dto.ArrayStatus{}
+	p.apply(status, mdResync{pos: 1_600_000, size: size, dt: 0}, start.Add(time.Hour))
+	if status.ParityCheckETASeconds != 0 || status.ParityCheckFinishAt != nil || status.ParityCheckSpeed != 0 {
+		t.Errorf("paused status = %+v", status)
+	}
+	if status.ParityCheckAvgSpeed != 10_240_000 {
+		t.Errorf("paused avg = %v", status.ParityCheckAvgSpeed)
+	}
+
+	// Idle resets the tracker.
+	p.apply(&dto.ArrayStatus{}, mdResync{}, start.Add(2*time.Hour))
+	if !p.lastAt.IsZero() {
+		t.Error("tracker not reset when idle")
+	}
+}
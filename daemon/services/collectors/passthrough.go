@@ -0,0 +1,299 @@
+package collectors
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/go-libvirt"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// sysfs roots for the passthrough inventory. Package-level variables so tests
+// can point them at a fake tree.
+var (
+	pciDevicesPath = "/sys/bus/pci/devices"
+	usbDevicesPath = "/sys/bus/usb/devices"
+)
+
+// usbClassHub is the bDeviceClass of USB hubs, which cannot be passed through
+// usefully and are left out of the inventory.
+const usbClassHub = "09"
+
+// lspciQuoted matches the quoted fields of an `lspci -Dmm` line.
+var lspciQuoted = regexp.MustCompile(`"([^"]*)"`)
+
+// addHostDevices fills in the host PCI/USB device inventory of settings and
+// marks the devices that VM definitions pass through. Every source is best
+// effort: a missing sysfs tree, lspci or libvirt just leaves fields empty.
+func (c *ConfigCollector) addHostDevices(settings *dto.VMSettings) {
+	settings.HostPCIDevices = collectPCIDevices(pciDevicesPath)
+	settings.HostUSBDevices = collectUSBDevices(usbDevicesPath)
+
+	if len(settings.HostPCIDevices) > 0 {
+		if output, err := lib.ExecCommandOutput("lspci", "-Dmm"); err == nil {
+			applyLspciNames(settings.HostPCIDevices, output)
+		} else {
+			logger.Debug("Config: lspci unavailable for PCI device names: %v", err)
+		}
+	}
+
+	pciAssigned, usbAssigned := vmHostdevAssignments()
+	for i := range settings.HostPCIDevices {
+		d := &settings.HostPCIDevices[i]
+		d.AssignedTo = pciAssigned[d.Address]
+	}
+	for i := range settings.HostUSBDevices {
+		d := &settings.HostUSBDevices[i]
+		d.AssignedTo = mergeVMNames(usbAssigned[d.ID], usbAssigned[usbBusDeviceKey(d.Bus, d.Device)])
+	}
+}
+
+// collectPCIDevices lists the PCI devices under root (a /sys/bus/pci/devices
+// tree), sorted by address.
+func collectPCIDevices(root string) []dto.HostPCIDevice {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		logger.Debug("Config: cannot read PCI devices from %s: %v", root, err)
+		return nil
+	}
+
+	devices := make([]dto.HostPCIDevice, 0, len(entries))
+	for _, e := range entries {
+		dir := filepath.Join(root, e.Name())
+		dev := dto.HostPCIDevice{
+			Address:  e.Name(),
+			VendorID: strings.TrimPrefix(readSysfsValue(dir, "vendor"), "0x"),
+			DeviceID: strings.TrimPrefix(readSysfsValue(dir, "device"), "0x"),
+			ClassID:  strings.TrimPrefix(readSysfsValue(dir, "class"), "0x"),
+		}
+		if target, err := os.Readlink(filepath.Join(dir, "iommu_group")); err == nil {
+			if group, err := strconv.Atoi(filepath.Base(target)); err == nil {
+				dev.IOMMUGroup = &group
+			}
+		}
+		if target, err := os.Readlink(filepath.Join(dir, "driver")); err == nil {
+			dev.Driver = filepath.Base(target)
+		}
+		devices = append(devices, dev)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Address < devices[j].Address })
+	return devices
+}
+
+// collectUSBDevices lists the USB devices under root (a /sys/bus/usb/devices
+// tree), skipping root hubs, hubs and interface entries.
+func collectUSBDevices(root string) []dto.HostUSBDevice {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		logger.Debug("Config: cannot read USB devices from %s: %v", root, err)
+		return nil
+	}
+
+	var devices []dto.HostUSBDevice
+	for _, e := range entries {
+		name := e.Name()
+		// "usbN" are root hubs; "1-1:1.0" style entries are interfaces.
+		if strings.HasPrefix(name, "usb") || strings.Contains(name, ":") {
+			continue
+		}
+		dir := filepath.Join(root, name)
+		vendor, product := readSysfsValue(dir, "idVendor"), readSysfsValue(dir, "idProduct")
+		if vendor == "" || product == "" || readSysfsValue(dir, "bDeviceClass") == usbClassHub {
+			continue
+		}
+		bus, _ := strconv.Atoi(readSysfsValue(dir, "busnum"))
+		device, _ := strconv.Atoi(readSysfsValue(dir, "devnum"))
+		devices = append(devices, dto.HostUSBDevice{
+			ID:           vendor + ":" + product,
+			VendorID:     vendor,
+			ProductID:    product,
+			Manufacturer: readSysfsValue(dir, "manufacturer"),
+			Product:      readSysfsValue(dir, "product"),
+			Bus:          bus,
+			Device:       device,
+		})
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		if devices[i].Bus != devices[j].Bus {
+			return devices[i].Bus < devices[j].Bus
+		}
+		return devices[i].Device < devices[j].Device
+	})
+	return devices
+}
+
+// readSysfsValue returns the trimmed content of a sysfs attribute, or "" when
+// it cannot be read.
+func readSysfsValue(dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name)) //nolint:gosec // G304: path is built from sysfs directory entries
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// applyLspciNames sets the class, vendor and device names of devices from
+// `lspci -Dmm` output, e.g.:
+//
+//	0000:01:00.0 "VGA compatible controller" "NVIDIA Corporation" "GP104 [GeForce GTX 1080]" -ra1 -p00 "eVga.com. Corp." "Device 6180"
+func applyLspciNames(devices []dto.HostPCIDevice, output string) {
+	byAddress := make(map[string]int, len(devices))
+	for i, d := range devices {
+		byAddress[d.Address] = i
+	}
+	for line := range strings.SplitSeq(output, "\n") {
+		address, _, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		i, found := byAddress[address]
+		if !found {
+			continue
+		}
+		fields := lspciQuoted.FindAllStringSubmatch(line, 3)
+		if len(fields) < 3 {
+			continue
+		}
+		devices[i].Class = fields[0][1]
+		devices[i].Vendor = fields[1][1]
+		devices[i].Name = fields[2][1]
+	}
+}
+
+// domainHostdevXML is the subset of a libvirt domain definition describing
+// passed-through host devices.
+type domainHostdevXML struct {
+	Hostdevs []struct {
+		Type   string `xml:"type,attr"`
+		Source struct {
+			Vendor struct {
+				ID string `xml:"id,attr"`
+			} `xml:"vendor"`
+			Product struct {
+				ID string `xml:"id,attr"`
+			} `xml:"product"`
+			Address struct {
+				Domain   string `xml:"domain,attr"`
+				Bus      string `xml:"bus,attr"`
+				Slot     string `xml:"slot,attr"`
+				Function string `xml:"function,attr"`
+				Device   string `xml:"device,attr"`
+			} `xml:"address"`
+		} `xml:"source"`
+	} `xml:"devices>hostdev"`
+}
+
+// parseDomainHostdevs returns the PCI addresses ("0000:01:00.0") and USB
+// device keys passed through by a libvirt domain definition. USB devices are
+// keyed by "vendor:product" or, when the definition pins a bus address, by
+// usbBusDeviceKey.
+func parseDomainHostdevs(domainXML string) (pci, usb []string, err error) {
+	var def domainHostdevXML
+	if err := xml.Unmarshal([]byte(domainXML), &def); err != nil {
+		return nil, nil, fmt.Errorf("parse domain XML: %w", err)
+	}
+	for _, h := range def.Hostdevs {
+		src := h.Source
+		switch h.Type {
+		case "pci":
+			domainNum, err1 := parseXMLNumber(src.Address.Domain)
+			bus, err2 := parseXMLNumber(src.Address.Bus)
+			slot, err3 := parseXMLNumber(src.Address.Slot)
+			function, err4 := parseXMLNumber(src.Address.Function)
+			if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+				continue
+			}
+			pci = append(pci, fmt.Sprintf("%04x:%02x:%02x.%x", domainNum, bus, slot, function))
+		case "usb":
+			if src.Vendor.ID != "" && src.Product.ID != "" {
+				usb = append(usb, fmt.Sprintf("%s:%s",
+					strings.ToLower(strings.TrimPrefix(src.Vendor.ID, "0x")),
+					strings.ToLower(strings.TrimPrefix(src.Product.ID, "0x"))))
+				continue
+			}
+			bus, err1 := parseXMLNumber(src.Address.Bus)
+			device, err2 := parseXMLNumber(src.Address.Device)
+			if err1 == nil && err2 == nil {
+				usb = append(usb, usbBusDeviceKey(int(bus), int(device))) //nolint:gosec // G115: parsed with bitSize 32
+			}
+		}
+	}
+	return pci, usb, nil
+}
+
+// parseXMLNumber parses a libvirt address attribute, which is hex with a 0x
+// prefix ("0x01") or decimal without one ("3").
+func parseXMLNumber(s string) (uint64, error) {
+	if hex, ok := strings.CutPrefix(s, "0x"); ok {
+		return strconv.ParseUint(hex, 16, 32)
+	}
+	return strconv.ParseUint(s, 10, 32)
+}
+
+// usbBusDeviceKey keys a USB device by its current bus address.
+func usbBusDeviceKey(bus, device int) string {
+	return fmt.Sprintf("bus%d-dev%d", bus, device)
+}
+
+// vmHostdevAssignments maps PCI addresses and USB device keys to the VMs that
+// pass them through. Running VMs are read from their live definition, so
+// hot-plugged USB devices are included.
+func vmHostdevAssignments() (pci, usb map[string][]string) {
+	pci, usb = make(map[string][]string), make(map[string][]string)
+
+	uri, _ := url.Parse(string(libvirt.QEMUSystem))
+	l, err := libvirt.ConnectToURI(uri)
+	if err != nil {
+		logger.Debug("Config: libvirt unavailable for passthrough assignments: %v", err)
+		return pci, usb
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	flags := libvirt.ConnectListDomainsActive | libvirt.ConnectListDomainsInactive
+	domains, _, err := l.ConnectListAllDomains(1, flags)
+	if err != nil {
+		logger.Debug("Config: failed to list VMs for passthrough assignments: %v", err)
+		return pci, usb
+	}
+
+	for _, d := range domains {
+		domainXML, err := l.DomainGetXMLDesc(d, 0)
+		if err != nil {
+			continue
+		}
+		pciAddrs, usbKeys, err := parseDomainHostdevs(domainXML)
+		if err != nil {
+			logger.Debug("Config: VM %s: %v", d.Name, err)
+			continue
+		}
+		for _, a := range pciAddrs {
+			pci[a] = mergeVMNames(pci[a], []string{d.Name})
+		}
+		for _, k := range usbKeys {
+			usb[k] = mergeVMNames(usb[k], []string{d.Name})
+		}
+	}
+	return pci, usb
+}
+
+// mergeVMNames returns the sorted union of two VM name lists, or nil when
+// both are empty.
+func mergeVMNames(a, b []string) []string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	merged := slices.Concat(a, b)
+	slices.Sort(merged)
+	return slices.Compact(merged)
+}
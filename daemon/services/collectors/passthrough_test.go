@@ -0,0 +1,128 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// writeSysfs creates dir/name with content, creating dir as needed.
+func writeSysfs(t *testing.T, dir string, attrs map[string]string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range attrs {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCollectPCIDevices(t *testing.T) {
+	root := t.TempDir()
+	gpu := filepath.Join(root, "0000:01:00.0")
+	writeSysfs(t, gpu, map[string]string{"vendor": "0x10de", "device": "0x1b80", "class": "0x030000"})
+	if err := os.Symlink("../../../kernel/iommu_groups/14", filepath.Join(gpu, "iommu_group")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../../../bus/pci/drivers/vfio-pci", filepath.Join(gpu, "driver")); err != nil {
+		t.Fatal(err)
+	}
+	writeSysfs(t, filepath.Join(root, "0000:00:00.0"), map[string]string{"vendor": "0x8086", "device": "0x3e30", "class": "0x060000"})
+
+	got := collectPCIDevices(root)
+	if len(got) != 2 {
+		t.Fatalf("got %d devices, want 2", len(got))
+	}
+	if got[0].Address != "0000:00:00.0" || got[0].IOMMUGroup != nil || got[0].Driver != "" {
+		t.Errorf("host bridge = %+v", got[0])
+	}
+	g := got[1]
+	if g.VendorID != "10de" || g.DeviceID != "1b80" || g.ClassID != "030000" || g.Driver != "vfio-pci" {
+		t.Errorf("gpu = %+v", g)
+	}
+	if g.IOMMUGroup == nil || *g.IOMMUGroup != 14 {
+		t.Errorf("gpu iommu group = %v, want 14", g.IOMMUGroup)
+	}
+
+	applyLspciNames(got, `0000:00:00.0 "Host bridge" "Intel Corporation" "8th Gen Core Processor Host Bridge/DRAM Registers" -r0a "ASRock Incorporation" "Device 3e30"
+0000:01:00.0 "VGA compatible controller" "NVIDIA Corporation" "GP104 [GeForce GTX 1080]" -ra1 -p00 "eVga.com. Corp." "Device 6180"
+`)
+	if got[1].Class != "VGA compatible controller" || got[1].Vendor != "NVIDIA Corporation" || got[1].Name != "GP104 [GeForce GTX 1080]" {
+		t.Errorf("lspci names not applied: %+v", got[1])
+	}
+}
+
+func TestCollectUSBDevices(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, filepath.Join(root, "usb1"), map[string]string{"idVendor": "1d6b", "idProduct": "0002", "bDeviceClass": "09"})
+	writeSysfs(t, filepath.Join(root, "1-2"), map[string]string{"idVendor": "05e3", "idProduct": "0610", "bDeviceClass": "09"})
+	writeSysfs(t, filepath.Join(root, "1-2.1"), map[string]string{
+		"idVendor": "046d", "idProduct": "c52b", "bDeviceClass": "00",
+		"manufacturer": "Logitech", "product": "USB Receiver", "busnum": "1", "devnum": "4",
+	})
+	writeSysfs(t, filepath.Join(root, "1-2.1:1.0"), map[string]string{"bInterfaceClass": "03"})
+
+	got := collectUSBDevices(root)
+	want := []dto.HostUSBDevice{{
+		ID: "046d:c52b", VendorID: "046d", ProductID: "c52b",
+		Manufacturer: "Logitech", Product: "USB Receiver", Bus: 1, Device: 4,
+	}}
+	if len(got) != 1 || got[0].ID != want[0].ID || got[0].Product != want[0].Product || got[0].Bus != 1 || got[0].Device != 4 {
+		t.Errorf("collectUSBDevices() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseDomainHostdevs(t *testing.T) {
+	const domainXML = `<domain type='kvm'>
+  <name>Windows 11</name>
+  <devices>
+    <hostdev mode='subsystem' type='pci' managed='yes'>
+      <driver name='vfio'/>
+      <source>
+        <address domain='0x0000' bus='0x01' slot='0x00' function='0x0'/>
+      </source>
+      <address type='pci' domain='0x0000' bus='0x04' slot='0x00' function='0x0'/>
+    </hostdev>
+    <hostdev mode='subsystem' type='usb' managed='no'>
+      <source startupPolicy='optional'>
+        <vendor id='0x046D'/>
+        <product id='0xc52b'/>
+      </source>
+    </hostdev>
+    <hostdev mode='subsystem' type='usb' managed='no'>
+      <source>
+        <address bus='3' device='7'/>
+      </source>
+    </hostdev>
+  </devices>
+</domain>`
+
+	pci, usb, err := parseDomainHostdevs(domainXML)
+	if err != nil {
+		t.Fatalf("parseDomainHostdevs() error: %v", err)
+	}
+	if !slices.Equal(pci, []string{"0000:01:00.0"}) {
+		t.Errorf("pci = %v", pci)
+	}
+	if !slices.Equal(usb, []string{"046d:c52b", usbBusDeviceKey(3, 7)}) {
+		t.Errorf("usb = %v", usb)
+	}
+
+	if _, _, err := parseDomainHostdevs("<domain"); err == nil {
+		t.Error("expected error for malformed XML")
+	}
+}
+
+func TestMergeVMNames(t *testing.T) {
+	if got := mergeVMNames(nil, nil); got != nil {
+		t.Errorf("mergeVMNames(nil, nil) = %v, want nil", got)
+	}
+	if got := mergeVMNames([]string{"win11", "arch"}, []string{"win11"}); !slices.Equal(got, []string{"arch", "win11"}) {
+		t.Errorf("mergeVMNames() = %v", got)
+	}
+}
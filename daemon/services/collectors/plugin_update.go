@@ -55,7 +55,7 @@ func NewPluginUpdateCollector(ctx *domain.Context) *PluginUpdateCollector {
 
 // Start begins the periodic plugin update check after a startup stagger.
 func (c *PluginUpdateCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting plugin_update collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting plugin_update collector (interval: %v)", interval)
 
 	select {
 	case <-ctx.Done():
@@ -66,7 +66,7 @@ func (c *PluginUpdateCollector) Start(ctx context.Context, interval time.Duratio
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("PluginUpdate collector", r)
+				logger.LogPanicWithStackContext(ctx, "PluginUpdate collector", r)
 			}
 		}()
 		c.Collect(ctx)
@@ -78,13 +78,13 @@ func (c *PluginUpdateCollector) Start(ctx context.Context, interval time.Duratio
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("PluginUpdate collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "PluginUpdate collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("PluginUpdate collector", r)
+						logger.LogPanicWithStackContext(ctx, "PluginUpdate collector", r)
 					}
 				}()
 				c.Collect(ctx)
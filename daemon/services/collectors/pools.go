@@ -0,0 +1,417 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/ini.v1"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// poolsDisksIniPath and poolsConfigDir are package-level variables (not
+// constants) so tests can point the pools collector at fixture files.
+var (
+	poolsDisksIniPath = constants.DisksIni
+	poolsConfigDir    = constants.PoolsConfigDir
+)
+
+// PoolsCollector reports every Unraid pool with its member devices, capacity,
+// and filesystem-specific state: btrfs RAID profile, chunk allocation, and
+// balance/scrub status, or ZFS health, vdev layout, and scrub status.
+type PoolsCollector struct {
+	ctx      *domain.Context
+	forecast fillForecast
+}
+
+// NewPoolsCollector creates a new pools collector.
+func NewPoolsCollector(ctx *domain.Context) *PoolsCollector {
+	return &PoolsCollector{ctx: ctx}
+}
+
+// Start begins the pools collection loop.
+func (c *PoolsCollector) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.InfoContext(ctx, "Pools collector started (interval: %v)", interval)
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "Pools collector", r)
+			}
+		}()
+		collectWithWatchdog(ctx, "Pools", interval, c.Collect)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Pools collector stopped")
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						logger.LogPanicWithStackContext(ctx, "Pools collector", r)
+					}
+				}()
+				collectWithWatchdog(ctx, "Pools", interval, c.Collect)
+			}()
+		}
+	}
+}
+
+// Collect reads the pool layout from disks.ini, enriches each pool with
+// filesystem details, and publishes the result.
+func (c *PoolsCollector) Collect() {
+	data, err := os.ReadFile(poolsDisksIniPath)
+	if err != nil {
+		logger.Debug("Pools: failed to read %s: %v", poolsDisksIniPath, err)
+		return
+	}
+
+	pools, err := parsePools(data, readPoolNames(poolsConfigDir))
+	if err != nil {
+		logger.Warning("Pools: failed to parse %s: %v", poolsDisksIniPath, err)
+		return
+	}
+
+	now := time.Now()
+	trims := lastTrims()
+	seen := make(map[string]bool, len(pools))
+	for i := range pools {
+		pools[i].LastTrim = lastTrimAt(trims, pools[i].MountPoint)
+		if pools[i].TotalBytes > 0 {
+			pools[i].FillRateBytesPerDay, pools[i].DaysUntilFull = c.forecast.observe(
+				pools[i].Name, pools[i].UsedBytes, pools[i].TotalBytes, now)
+			seen[pools[i].Name] = true
+		}
+		switch pools[i].FileSystem {
+		case "btrfs":
+			enrichBtrfsPool(&pools[i])
+		case "zfs":
+			c.enrichZFSPool(&pools[i])
+		}
+	}
+
+	c.forecast.prune(seen)
+
+	domain.Publish(c.ctx.Hub, constants.TopicPoolsUpdate, pools)
+	logger.Debug("Pools: published %d pool(s)", len(pools))
+}
+
+// readPoolNames lists the pools configured in dir (one <name>.cfg per pool).
+// It returns nil when the directory does not exist.
+func readPoolNames(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".cfg"); ok && !e.IsDir() && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// poolSlot is a disks.ini section of type "Cache" (a pool device slot).
+type poolSlot struct {
+	name     string
+	device   string
+	id       string
+	status   string
+	sizeKiB  uint64
+	fsType   string
+	fsStatus string
+	fsSize   uint64
+	fsUsed   uint64
+	fsFree   uint64
+}
+
+// parsePools groups the pool device slots in disks.ini into pools. A pool's
+// first slot is named after the pool and carries the filesystem fields; further
+// slots are named <pool>2, <pool>3, and so on. poolNames are the configured
+// pool names; when empty they are inferred from the slot names.
+func parsePools(disksIni []byte, poolNames []string) ([]dto.PoolInfo, error) {
+	cfg, err := ini.Load(disksIni)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []poolSlot
+	for _, section := range cfg.Sections() {
+		if iniValue(section, "type") != "Cache" {
+			continue
+		}
+		slot := poolSlot{
+			name:     iniValue(section, "name"),
+			device:   iniValue(section, "device"),
+			id:       iniValue(section, "id"),
+			status:   iniValue(section, "status"),
+			fsType:   iniValue(section, "fsType"),
+			fsStatus: iniValue(section, "fsStatus"),
+		}
+		if slot.name == "" {
+			slot.name = strings.Trim(section.Name(), `"`)
+		}
+		slot.sizeKiB, _ = strconv.ParseUint(iniValue(section, "size"), 10, 64)
+		slot.fsSize, _ = strconv.ParseUint(iniValue(section, "fsSize"), 10, 64)
+		slot.fsUsed, _ = strconv.ParseUint(iniValue(section, "fsUsed"), 10, 64)
+		slot.fsFree, _ = strconv.ParseUint(iniValue(section, "fsFree"), 10, 64)
+		slots = append(slots, slot)
+	}
+
+	if len(poolNames) == 0 {
+		poolNames = inferPoolNames(slots)
+	}
+	isPool := make(map[string]bool, len(poolNames))
+	for _, name := range poolNames {
+		isPool[name] = true
+	}
+
+	now := time.Now()
+	pools := make([]dto.PoolInfo, 0, len(poolNames))
+	for _, name := range poolNames {
+		pool := dto.PoolInfo{
+			Name:       name,
+			MountPoint: "/mnt/" + name,
+			Devices:    []dto.PoolDevice{},
+			Timestamp:  now,
+		}
+		for _, slot := range slots {
+			if slot.name != name && (isPool[slot.name] || !isMemberSlot(slot.name, name)) {
+				continue
+			}
+			if slot.name == name {
+				pool.FileSystem = slot.fsType
+				pool.Status = slot.fsStatus
+				pool.TotalBytes = slot.fsSize * 1024
+				pool.UsedBytes = slot.fsUsed * 1024
+				pool.FreeBytes = slot.fsFree * 1024
+			}
+			if slot.device == "" {
+				continue // unassigned slot
+			}
+			pool.Devices = append(pool.Devices, dto.PoolDevice{
+				Slot:      slot.name,
+				Device:    slot.device,
+				ID:        slot.id,
+				Status:    slot.status,
+				SizeBytes: slot.sizeKiB * 1024,
+			})
+		}
+		if pool.TotalBytes > 0 {
+			pool.UsagePercent = float64(pool.UsedBytes) / float64(pool.TotalBytes) * 100
+		}
+		pools = append(pools, pool)
+	}
+
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+	return pools, nil
+}
+
+// inferPoolNames treats every pool slot that is not <other slot><digits> as a
+// pool of its own.
+func inferPoolNames(slots []poolSlot) []string {
+	var names []string
+	for _, slot := range slots {
+		member := false
+		for _, other := range slots {
+			if other.name != slot.name && isMemberSlot(slot.name, other.name) {
+				member = true
+				break
+			}
+		}
+		if !member && slot.name != "" {
+			names = append(names, slot.name)
+		}
+	}
+	return names
+}
+
+// isMemberSlot reports whether slot is an additional device slot of pool,
+// i.e. the pool name followed by a device number.
+func isMemberSlot(slot, pool string) bool {
+	suffix, ok := strings.CutPrefix(slot, pool)
+	if !ok || suffix == "" {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
+
+// iniValue returns a disks.ini key value with Unraid's surrounding quotes removed.
+func iniValue(section *ini.Section, key string) string {
+	return strings.Trim(section.Key(key).String(), `"`)
+}
+
+// enrichBtrfsPool adds RAID profile, chunk allocation, and balance/scrub state
+// for a mounted btrfs pool.
+func enrichBtrfsPool(pool *dto.PoolInfo) {
+	if pool.Status != "" && pool.Status != "Mounted" {
+		return
+	}
+
+	if output, err := lib.ExecCommandOutput(constants.BtrfsBin, "filesystem", "df", "-b", pool.MountPoint); err == nil {
+		parseBtrfsFilesystemDF(pool, output)
+	} else {
+		logger.Debug("Pools: btrfs filesystem df failed for %s: %v", pool.Name, err)
+	}
+
+	// btrfs balance status exits 1 while a balance is running, so the output
+	// is parsed regardless of the exit status.
+	output, _ := lib.ExecCommandOutput(constants.BtrfsBin, "balance", "status", pool.MountPoint)
+	parseBtrfsBalanceStatus(pool, output)
+
+	if output, err := lib.ExecCommandOutput(constants.BtrfsBin, "scrub", "status", pool.MountPoint); err == nil {
+		scrub := parseBtrfsScrubStatus(output)
+		pool.ScrubStatus = scrub.status
+		pool.ScrubStarted = scrub.started
+		pool.ScrubErrors = scrub.errorSummary
+	} else {
+		logger.Debug("Pools: btrfs scrub status failed for %s: %v", pool.Name, err)
+	}
+}
+
+// parseBtrfsFilesystemDF parses `btrfs filesystem df -b` output, e.g.
+//
+//	Data, RAID1: total=429496729600, used=408021893120
+//	System, RAID1: total=33554432, used=81920
+//	Metadata, RAID1: total=4294967296, used=2147483648
+//	GlobalReserve, single: total=536870912, used=0
+func parseBtrfsFilesystemDF(pool *dto.PoolInfo, output string) {
+	for line := range strings.SplitSeq(output, "\n") {
+		head, values, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		kind, profile, ok := strings.Cut(head, ",")
+		if !ok {
+			continue
+		}
+		profile = strings.ToLower(strings.TrimSpace(profile))
+
+		var total, used uint64
+		for field := range strings.SplitSeq(values, ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "total":
+				total = n
+			case "used":
+				used = n
+			}
+		}
+
+		switch strings.TrimSpace(kind) {
+		case "Data":
+			pool.Profile = profile
+			pool.DataAllocatedBytes = total
+			pool.DataUsedBytes = used
+		case "Metadata":
+			pool.MetadataProfile = profile
+			pool.MetadataAllocatedBytes = total
+			pool.MetadataUsedBytes = used
+		case "System":
+			pool.SystemAllocatedBytes = total
+		}
+	}
+}
+
+// parseBtrfsBalanceStatus parses `btrfs balance status` output, e.g.
+//
+//	No balance found on '/mnt/cache'
+//
+// or
+//
+//	Balance on '/mnt/cache' is running
+//	2 out of about 10 chunks balanced (3 considered),  80% left
+func parseBtrfsBalanceStatus(pool *dto.PoolInfo, output string) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	first := strings.TrimSpace(lines[0])
+	switch {
+	case strings.HasPrefix(first, "No balance found"):
+		pool.BalanceStatus = "idle"
+	case strings.HasSuffix(first, "is paused"):
+		pool.BalanceStatus = "paused"
+	case strings.Contains(first, "is running"):
+		pool.BalanceStatus = "running"
+		pool.BalanceRunning = true
+	default:
+		return
+	}
+	if len(lines) > 1 && pool.BalanceStatus != "idle" {
+		pool.BalanceProgress = strings.TrimSpace(lines[1])
+	}
+}
+
+// enrichZFSPool adds health, vdev layout, scrub state, and pool-level capacity
+// for a ZFS pool from the same zpool queries as the zfs collector.
+func (c *PoolsCollector) enrichZFSPool(pool *dto.PoolInfo) {
+	zpool, err := NewZFSCollector(c.ctx).collectPoolDetails(pool.Name)
+	if err != nil {
+		logger.Debug("Pools: zpool query failed for %s: %v", pool.Name, err)
+		return
+	}
+	applyZFSPool(pool, zpool)
+}
+
+// applyZFSPool copies the pool-level fields of a ZFS pool onto pool.
+func applyZFSPool(pool *dto.PoolInfo, zpool dto.ZFSPool) {
+	pool.Health = zpool.Health
+	if zpool.SizeBytes > 0 {
+		pool.TotalBytes = zpool.SizeBytes
+		pool.UsedBytes = zpool.AllocatedBytes
+		pool.FreeBytes = zpool.FreeBytes
+		pool.UsagePercent = float64(zpool.AllocatedBytes) / float64(zpool.SizeBytes) * 100
+	}
+
+	// The profile is the type of the data vdevs; plain disks are a stripe
+	// (or a single device).
+	var dataVdevs []dto.ZFSVdev
+	for _, vdev := range zpool.VDEVs {
+		switch vdev.Type {
+		case "spare", "cache", "log":
+			continue
+		}
+		dataVdevs = append(dataVdevs, vdev)
+	}
+	switch {
+	case len(dataVdevs) == 0:
+	case dataVdevs[0].Type != "disk":
+		pool.Profile = dataVdevs[0].Type
+	case len(dataVdevs) == 1:
+		pool.Profile = "single"
+	default:
+		pool.Profile = "stripe"
+	}
+
+	switch zpool.ScanState {
+	case "scanning":
+		pool.ScrubStatus = "running"
+	case "finished":
+		pool.ScrubStatus = "finished"
+		pool.ScrubErrors = fmt.Sprintf("%d errors", zpool.ScanErrors)
+	case "canceled":
+		pool.ScrubStatus = "aborted"
+	}
+	if !zpool.ScanStartTime.IsZero() {
+		pool.ScrubStarted = zpool.ScanStartTime.Format(time.ANSIC)
+	}
+}
@@ -0,0 +1,223 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+const poolsDisksINI = `["disk1"]
+name="disk1"
+type="Data"
+device="sdb"
+fsType="xfs"
+
+["cache"]
+name="cache"
+type="Cache"
+device="nvme0n1"
+id="Samsung_SSD_980_PRO_1TB_S5GX"
+status="DISK_OK"
+size="976762552"
+fsType="btrfs"
+fsStatus="Mounted"
+fsSize="976762552"
+fsUsed="402653184"
+fsFree="574109368"
+
+["cache2"]
+name="cache2"
+type="Cache"
+device="nvme1n1"
+id="Samsung_SSD_980_PRO_1TB_S5GY"
+status="DISK_OK"
+size="976762552"
+
+["cache3"]
+name="cache3"
+type="Cache"
+device=""
+
+["fast"]
+name="fast"
+type="Cache"
+device="sdc"
+status="DISK_OK"
+fsType="zfs"
+fsStatus="Mounted"
+fsSize="1000"
+fsUsed="250"
+fsFree="750"
+`
+
+func TestParsePools(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		poolNames []string
+	}{
+		{"configured pool names", []string{"fast", "cache"}},
+		{"inferred pool names", nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pools, err := parsePools([]byte(poolsDisksINI), tt.poolNames)
+			if err != nil {
+				t.Fatalf("parsePools: %v", err)
+			}
+			if len(pools) != 2 || pools[0].Name != "cache" || pools[1].Name != "fast" {
+				t.Fatalf("pools = %+v, want cache and fast", pools)
+			}
+
+			cache := pools[0]
+			if cache.FileSystem != "btrfs" || cache.Status != "Mounted" || cache.MountPoint != "/mnt/cache" {
+				t.Errorf("cache = %+v", cache)
+			}
+			if len(cache.Devices) != 2 || cache.Devices[1].Slot != "cache2" || cache.Devices[1].Device != "nvme1n1" {
+				t.Errorf("cache devices = %+v, want cache and cache2 (empty cache3 skipped)", cache.Devices)
+			}
+			if cache.TotalBytes != 976762552*1024 || cache.UsedBytes != 402653184*1024 {
+				t.Errorf("cache capacity = %d/%d", cache.UsedBytes, cache.TotalBytes)
+			}
+			if cache.UsagePercent < 41 || cache.UsagePercent > 42 {
+				t.Errorf("cache usage = %.2f%%", cache.UsagePercent)
+			}
+			if pools[1].UsagePercent != 25 || len(pools[1].Devices) != 1 {
+				t.Errorf("fast = %+v", pools[1])
+			}
+		})
+	}
+}
+
+func TestParsePoolsNumberedPoolName(t *testing.T) {
+	// A pool literally named "cache2" is its own pool, not a member of "cache".
+	pools, err := parsePools([]byte(poolsDisksINI), []string{"cache", "cache2", "fast"})
+	if err != nil {
+		t.Fatalf("parsePools: %v", err)
+	}
+	if len(pools) != 3 || len(pools[0].Devices) != 1 || pools[1].Name != "cache2" || len(pools[1].Devices) != 1 {
+		t.Errorf("pools = %+v", pools)
+	}
+}
+
+func TestReadPoolNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"cache.cfg", "fast.cfg", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names := readPoolNames(dir)
+	if len(names) != 2 || names[0] != "cache" || names[1] != "fast" {
+		t.Errorf("names = %v, want [cache fast]", names)
+	}
+	if names := readPoolNames(filepath.Join(dir, "missing")); names != nil {
+		t.Errorf("missing dir names = %v, want nil", names)
+	}
+}
+
+func TestParseBtrfsFilesystemDF(t *testing.T) {
+	var pool dto.PoolInfo
+	parseBtrfsFilesystemDF(&pool, `Data, RAID1: total=429496729600, used=408021893120
+System, RAID1: total=33554432, used=81920
+Metadata, RAID1C3: total=4294967296, used=2147483648
+GlobalReserve, single: total=536870912, used=0
+`)
+	if pool.Profile != "raid1" || pool.MetadataProfile != "raid1c3" {
+		t.Errorf("profiles = %q/%q", pool.Profile, pool.MetadataProfile)
+	}
+	if pool.DataAllocatedBytes != 429496729600 || pool.DataUsedBytes != 408021893120 {
+		t.Errorf("data = %d/%d", pool.DataUsedBytes, pool.DataAllocatedBytes)
+	}
+	if pool.MetadataAllocatedBytes != 4294967296 || pool.MetadataUsedBytes != 2147483648 || pool.SystemAllocatedBytes != 33554432 {
+		t.Errorf("metadata/system = %+v", pool)
+	}
+}
+
+func TestParseBtrfsBalanceStatus(t *testing.T) {
+	tests := []struct {
+		output       string
+		wantStatus   string
+		wantRunning  bool
+		wantProgress string
+	}{
+		{"No balance found on '/mnt/cache'\n", "idle", false, ""},
+		{"Balance on '/mnt/cache' is running\n2 out of about 10 chunks balanced (3 considered),  80% left\n",
+			"running", true, "2 out of about 10 chunks balanced (3 considered),  80% left"},
+		{"Balance on '/mnt/cache' is paused\n4 out of about 10 chunks balanced (5 considered),  60% left\n",
+			"paused", false, "4 out of about 10 chunks balanced (5 considered),  60% left"},
+		{"ERROR: cannot access '/mnt/cache': No such file or directory\n", "", false, ""},
+	}
+	for _, tt := range tests {
+		var pool dto.PoolInfo
+		parseBtrfsBalanceStatus(&pool, tt.output)
+		if pool.BalanceStatus != tt.wantStatus || pool.BalanceRunning != tt.wantRunning || pool.BalanceProgress != tt.wantProgress {
+			t.Errorf("parseBtrfsBalanceStatus(%q) = %q/%v/%q", tt.output, pool.BalanceStatus, pool.BalanceRunning, pool.BalanceProgress)
+		}
+	}
+}
+
+func TestApplyZFSPool(t *testing.T) {
+	started := time.Date(2026, 10, 12, 3, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		vdevs       []dto.ZFSVdev
+		wantProfile string
+	}{
+		{"mirror", []dto.ZFSVdev{{Type: "mirror"}, {Type: "log"}}, "mirror"},
+		{"single disk", []dto.ZFSVdev{{Type: "disk"}}, "single"},
+		{"striped disks", []dto.ZFSVdev{{Type: "disk"}, {Type: "disk"}, {Type: "cache"}}, "stripe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := dto.PoolInfo{Name: "fast"}
+			applyZFSPool(&pool, dto.ZFSPool{
+				Health: "ONLINE", SizeBytes: 1000, AllocatedBytes: 400, FreeBytes: 600,
+				VDEVs: tt.vdevs, ScanState: "finished", ScanErrors: 0, ScanStartTime: started,
+			})
+			if pool.Profile != tt.wantProfile {
+				t.Errorf("profile = %q, want %q", pool.Profile, tt.wantProfile)
+			}
+			if pool.Health != "ONLINE" || pool.UsagePercent != 40 || pool.ScrubStatus != "finished" || pool.ScrubErrors != "0 errors" {
+				t.Errorf("pool = %+v", pool)
+			}
+		})
+	}
+}
+
+func TestPoolsCollectorCollect(t *testing.T) {
+	dir := t.TempDir()
+	iniPath := filepath.Join(dir, "disks.ini")
+	if err := os.WriteFile(iniPath, []byte(`["cache"]
+name="cache"
+type="Cache"
+device="sdb"
+fsType="xfs"
+fsStatus="Mounted"
+fsSize="100"
+fsUsed="10"
+fsFree="90"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	origIni, origDir := poolsDisksIniPath, poolsConfigDir
+	poolsDisksIniPath, poolsConfigDir = iniPath, filepath.Join(dir, "pools")
+	t.Cleanup(func() { poolsDisksIniPath, poolsConfigDir = origIni, origDir })
+
+	hub := domain.NewEventBus(4)
+	ch := hub.SubTopics(constants.TopicPoolsUpdate)
+	NewPoolsCollector(&domain.Context{Hub: hub}).Collect()
+
+	select {
+	case msg := <-ch:
+		pools, ok := msg.([]dto.PoolInfo)
+		if !ok || len(pools) != 1 || pools[0].Name != "cache" || pools[0].FileSystem != "xfs" {
+			t.Fatalf("unexpected pools: %#v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no pools update published")
+	}
+}
@@ -0,0 +1,139 @@
+package collectors
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Package-level variables (not constants) so tests can use a fixture tree.
+var (
+	recycleBinPluginDir = constants.RecycleBinPluginDir
+	recycleBinSharesDir = constants.UserSharesDir
+)
+
+// RecycleBinCollector measures the recycle bin of every user share when the
+// Recycle Bin plugin is installed. Files deleted over SMB are kept in
+// .Recycle.Bin at the share's root until the plugin's retention removes them.
+type RecycleBinCollector struct {
+	ctx     *domain.Context
+	refresh refreshTrigger
+}
+
+// NewRecycleBinCollector creates a new recycle bin collector.
+func NewRecycleBinCollector(ctx *domain.Context) *RecycleBinCollector {
+	return &RecycleBinCollector{ctx: ctx, refresh: newRefreshTrigger()}
+}
+
+// RequestRefresh asks the running collector to collect immediately, e.g.
+// after a recycle bin was emptied.
+func (c *RecycleBinCollector) RequestRefresh() {
+	c.refresh.request()
+}
+
+// Start begins the recycle bin collection loop.
+func (c *RecycleBinCollector) Start(ctx context.Context, interval time.Duration) {
+	logger.InfoContext(ctx, "Recycle bin collector started (interval: %v)", interval)
+
+	collect := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "Recycle bin collector", r)
+			}
+		}()
+		collectWithWatchdog(ctx, "RecycleBin", interval, c.Collect)
+	}
+	collect()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Recycle bin collector stopped")
+			return
+		case <-ticker.C:
+			collect()
+		case <-c.refresh:
+			logger.DebugContext(ctx, "Recycle bin collector: refresh requested, collecting immediately")
+			collect()
+		}
+	}
+}
+
+// Collect measures the recycle bins and publishes the result.
+func (c *RecycleBinCollector) Collect() {
+	status := collectRecycleBins(time.Now())
+	domain.Publish(c.ctx.Hub, constants.TopicRecycleBinUpdate, status)
+	logger.Debug("RecycleBin: published %d share(s), %d bytes", len(status.Shares), status.TotalBytes)
+}
+
+// collectRecycleBins measures the recycle bin of every share that has one.
+// Nothing is walked when the plugin is not installed.
+func collectRecycleBins(now time.Time) *dto.RecycleBinStatus {
+	status := &dto.RecycleBinStatus{Shares: []dto.RecycleBinShare{}, Timestamp: now}
+	if info, err := os.Stat(recycleBinPluginDir); err != nil || !info.IsDir() {
+		return status
+	}
+	status.Installed = true
+
+	entries, err := os.ReadDir(recycleBinSharesDir)
+	if err != nil {
+		logger.Debug("RecycleBin: failed to read %s: %v", recycleBinSharesDir, err)
+		return status
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(recycleBinSharesDir, entry.Name(), constants.RecycleBinDirName)
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			continue
+		}
+		share := measureRecycleBin(entry.Name(), path, now)
+		status.TotalBytes += share.SizeBytes
+		status.TotalFiles += share.Files
+		status.Shares = append(status.Shares, share)
+	}
+	sort.Slice(status.Shares, func(i, j int) bool { return status.Shares[i].Share < status.Shares[j].Share })
+	return status
+}
+
+// measureRecycleBin returns the size, file count and file ages of the
+// recycle bin at path. Unreadable entries are skipped.
+func measureRecycleBin(share, path string, now time.Time) dto.RecycleBinShare {
+	bin := dto.RecycleBinShare{Share: share, Path: path}
+	var oldest, newest time.Time
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		bin.SizeBytes += uint64(max(info.Size(), 0)) // #nosec G115 -- clamped to non-negative
+		bin.Files++
+		if mt := info.ModTime(); oldest.IsZero() || mt.Before(oldest) {
+			oldest = mt
+		}
+		if mt := info.ModTime(); mt.After(newest) {
+			newest = mt
+		}
+		return nil
+	})
+	if bin.Files > 0 {
+		bin.OldestAt, bin.NewestAt = &oldest, &newest
+		bin.OldestAgeDays = float64(int(now.Sub(oldest).Hours()/24*10)) / 10
+	}
+	return bin
+}
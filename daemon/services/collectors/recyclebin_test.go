@@ -0,0 +1,62 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCollectRecycleBins(t *testing.T) {
+	root := t.TempDir()
+	origPlugin, origShares := recycleBinPluginDir, recycleBinSharesDir
+	t.Cleanup(func() { recycleBinPluginDir, recycleBinSharesDir = origPlugin, origShares })
+	recycleBinPluginDir = filepath.Join(root, "plugin")
+	recycleBinSharesDir = filepath.Join(root, "user")
+
+	now := time.Date(2026, 5, 20, 12, 0, 0, 0, time.UTC)
+	bin := filepath.Join(recycleBinSharesDir, "Media", ".Recycle.Bin")
+	if err := os.MkdirAll(filepath.Join(bin, "Movies"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(recycleBinSharesDir, "appdata"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]time.Time{
+		filepath.Join(bin, "old.mkv"):           now.Add(-10 * 24 * time.Hour),
+		filepath.Join(bin, "Movies", "new.mkv"): now.Add(-2 * time.Hour),
+	}
+	for path, mtime := range files {
+		if err := os.WriteFile(path, make([]byte, 1000), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if status := collectRecycleBins(now); status.Installed || len(status.Shares) != 0 {
+		t.Fatalf("plugin not installed: status = %+v", status)
+	}
+
+	if err := os.MkdirAll(recycleBinPluginDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	status := collectRecycleBins(now)
+	if !status.Installed || status.TotalBytes != 2000 || status.TotalFiles != 2 || len(status.Shares) != 1 {
+		t.Fatalf("status = %+v", status)
+	}
+	share := status.Shares[0]
+	if share.Share != "Media" || share.Path != bin {
+		t.Errorf("share = %+v", share)
+	}
+	if share.OldestAt == nil || !share.OldestAt.Equal(files[filepath.Join(bin, "old.mkv")]) {
+		t.Errorf("OldestAt = %v", share.OldestAt)
+	}
+	if share.NewestAt == nil || !share.NewestAt.Equal(files[filepath.Join(bin, "Movies", "new.mkv")]) {
+		t.Errorf("NewestAt = %v", share.NewestAt)
+	}
+	if share.OldestAgeDays != 10 {
+		t.Errorf("OldestAgeDays = %v, want 10", share.OldestAgeDays)
+	}
+}
@@ -0,0 +1,19 @@
+package collectors
+
+// refreshTrigger asks a running collector for an immediate collection outside
+// its regular interval. The collector's own loop performs the collection, so it
+// never runs concurrently with a scheduled one, and requests made while one is
+// already pending coalesce into a single run. A nil trigger ignores requests.
+type refreshTrigger chan struct{}
+
+func newRefreshTrigger() refreshTrigger {
+	return make(refreshTrigger, 1)
+}
+
+// request queues a refresh unless one is already pending.
+func (t refreshTrigger) request() {
+	select {
+	case t <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,19 @@
+package collectors
+
+import "testing"
+
+func TestRefreshTriggerCoalesces(t *testing.T) {
+	trigger := newRefreshTrigger()
+	trigger.request()
+	trigger.request()
+
+	<-trigger
+	select {
+	case <-trigger:
+		t.Fatal("second request while one was pending was not coalesced")
+	default:
+	}
+
+	var nilTrigger refreshTrigger
+	nilTrigger.request() // must not block
+}
@@ -15,27 +15,36 @@ import (
 
 // RegistrationCollector collects Unraid registration/license information
 type RegistrationCollector struct {
-	ctx *domain.Context
+	ctx     *domain.Context
+	refresh refreshTrigger
 }
 
 // NewRegistrationCollector creates a new registration collector
 func NewRegistrationCollector(ctx *domain.Context) *RegistrationCollector {
-	return &RegistrationCollector{ctx: ctx}
+	return &RegistrationCollector{ctx: ctx, refresh: newRefreshTrigger()}
+}
+
+// RequestRefresh asks the running collector to collect immediately, e.g.
+// after a new key file was installed.
+func (c *RegistrationCollector) RequestRefresh() {
+	c.refresh.request()
 }
 
 // Start begins collecting registration information at the specified interval
 func (c *RegistrationCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting registration collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting registration collector (interval: %v)", interval)
 
-	// Run once immediately with panic recovery
-	func() {
+	collect := func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Registration collector", r)
+				logger.LogPanicWithStackContext(ctx, "Registration collector", r)
 			}
 		}()
 		c.Collect()
-	}()
+	}
+
+	// Run once immediately with panic recovery
+	collect()
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -43,25 +52,31 @@ func (c *RegistrationCollector) Start(ctx context.Context, interval time.Duratio
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Registration collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "Registration collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Registration collector", r)
-					}
-				}()
-				c.Collect()
-			}()
+			collect()
+		case <-c.refresh:
+			logger.DebugContext(ctx, "Registration collector: refresh requested, collecting immediately")
+			collect()
 		}
 	}
 }
 
-// Collect gathers registration information
+// Collect gathers registration information. It also publishes the parity
+// check schedule and flash drive health, which come from the same boot
+// configuration and change just as rarely.
 func (c *RegistrationCollector) Collect() {
 	logger.Debug("Collecting registration data...")
 
+	settings := NewSettingsCollector()
+	if schedule, err := settings.GetParitySchedule(); err == nil {
+		domain.Publish(c.ctx.Hub, constants.TopicParityScheduleUpdate, schedule)
+	}
+	if flash, err := settings.GetFlashDriveHealth(); err == nil {
+		domain.Publish(c.ctx.Hub, constants.TopicFlashHealthUpdate, flash)
+	}
+
 	registration, err := c.collectRegistration()
 	if err != nil {
 		logger.Error("Registration: Failed to collect registration info: %v", err)
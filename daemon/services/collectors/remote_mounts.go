@@ -0,0 +1,340 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Package-level variables (not constants) so tests can use fixture files.
+var (
+	remoteMountsProcPath = "/proc/mounts"
+	remoteMountsFstab    = "/etc/fstab"
+)
+
+const (
+	// remoteMountStatfsTimeout is how long statfs may take before a mount is
+	// reported stale. Healthy NFS/SMB mounts answer in milliseconds.
+	remoteMountStatfsTimeout = 5 * time.Second
+
+	// remoteMountDialTimeout bounds the TCP probe of a mount's server.
+	remoteMountDialTimeout = 3 * time.Second
+)
+
+// errMountStalled is returned when statfs on a mount did not return in time.
+var errMountStalled = errors.New("statfs did not return (hung mount?)")
+
+// remoteMount is a remote mount found in /proc/mounts, /etc/fstab or the
+// Unassigned Devices configuration.
+type remoteMount struct {
+	source, mountPoint, fsType, origin string
+	mounted                            bool
+}
+
+// RemoteMountCollector probes NFS and SMB client mounts for staleness. Each
+// mounted share gets a bounded statfs; a hung NFS server or a disconnected
+// SMB session makes it hang or fail. The server's NFS or SMB port is probed
+// with a TCP connect for reachability and latency. A probe that hangs in the
+// kernel cannot be cancelled, so a mount is not probed again until its
+// previous statfs has returned.
+type RemoteMountCollector struct {
+	ctx *domain.Context
+
+	// DialFn connects to a server port; StatfsFn stats a mount point. Tests
+	// may replace them.
+	DialFn   func(network, address string, timeout time.Duration) (net.Conn, error)
+	StatfsFn func(path string) error
+
+	mu        sync.Mutex
+	probing   map[string]bool      // mount point -> statfs still running
+	staleFrom map[string]time.Time // mount point -> first stale probe
+	healthyAt map[string]time.Time // mount point -> last healthy probe
+}
+
+// NewRemoteMountCollector creates a remote mount health collector.
+func NewRemoteMountCollector(ctx *domain.Context) *RemoteMountCollector {
+	return &RemoteMountCollector{
+		ctx:    ctx,
+		DialFn: net.DialTimeout,
+		StatfsFn: func(path string) error {
+			_, _, _, _, err := getFilesystemUsage(path)
+			return err
+		},
+		probing:   make(map[string]bool),
+		staleFrom: make(map[string]time.Time),
+		healthyAt: make(map[string]time.Time),
+	}
+}
+
+// Start begins the remote mount health loop.
+func (c *RemoteMountCollector) Start(ctx context.Context, interval time.Duration) {
+	logger.InfoContext(ctx, "Remote mount collector started (interval: %v)", interval)
+
+	collect := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "Remote mount collector", r)
+			}
+		}()
+		collectWithWatchdog(ctx, "Remote mounts", interval, c.Collect)
+	}
+	collect()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Remote mount collector stopped")
+			return
+		case <-ticker.C:
+			collect()
+		}
+	}
+}
+
+// Collect probes every remote mount and publishes the result.
+func (c *RemoteMountCollector) Collect() {
+	procMounts, err := os.ReadFile(remoteMountsProcPath)
+	if err != nil {
+		logger.Debug("Remote mounts: failed to read %s: %v", remoteMountsProcPath, err)
+		return
+	}
+	fstab, _ := os.ReadFile(remoteMountsFstab)
+	mounts := listRemoteMounts(string(procMounts), string(fstab), readSambaConfig())
+
+	health := c.check(mounts, time.Now())
+	domain.Publish(c.ctx.Hub, constants.TopicRemoteMountsUpdate, health)
+	logger.Debug("Remote mounts: published %d mounts, %d stale", len(health.Mounts), health.StaleCount)
+}
+
+// check probes mounts concurrently and tracks when each went stale.
+func (c *RemoteMountCollector) check(mounts []remoteMount, now time.Time) *dto.RemoteMountHealth {
+	health := &dto.RemoteMountHealth{
+		Mounts:    make([]dto.RemoteMountStatus, len(mounts)),
+		Timestamp: now,
+	}
+
+	var wg sync.WaitGroup
+	for i, m := range mounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health.Mounts[i] = c.probe(m)
+		}()
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	seen := make(map[string]bool, len(mounts))
+	for i := range health.Mounts {
+		st := &health.Mounts[i]
+		seen[st.MountPoint] = true
+		if st.Stale {
+			health.StaleCount++
+			since, ok := c.staleFrom[st.MountPoint]
+			if !ok {
+				since = now
+				c.staleFrom[st.MountPoint] = now
+				logger.Warning("Remote mounts: %s (%s) is stale: %s", st.MountPoint, st.Source, st.Error)
+			}
+			st.StaleSince = &since
+		} else if st.Mounted {
+			if _, ok := c.staleFrom[st.MountPoint]; ok {
+				logger.Info("Remote mounts: %s (%s) recovered", st.MountPoint, st.Source)
+				delete(c.staleFrom, st.MountPoint)
+			}
+			c.healthyAt[st.MountPoint] = now
+		}
+		if at, ok := c.healthyAt[st.MountPoint]; ok {
+			st.LastHealthyAt = &at
+		}
+	}
+	for mountPoint := range c.staleFrom {
+		if !seen[mountPoint] {
+			delete(c.staleFrom, mountPoint)
+		}
+	}
+	for mountPoint := range c.healthyAt {
+		if !seen[mountPoint] {
+			delete(c.healthyAt, mountPoint)
+		}
+	}
+	return health
+}
+
+// probe checks the server port and, for a mounted share, statfs.
+func (c *RemoteMountCollector) probe(m remoteMount) dto.RemoteMountStatus {
+	st := dto.RemoteMountStatus{
+		MountPoint: m.mountPoint,
+		Source:     m.source,
+		Type:       remoteMountType(m.fsType),
+		Origin:     m.origin,
+		Mounted:    m.mounted,
+	}
+
+	var port string
+	if st.Type == "nfs" {
+		st.Server, _ = parseNFSSource(m.source)
+		port = "2049"
+	} else {
+		st.Server, _ = parseSMBSource(m.source)
+		port = "445"
+	}
+	var serverErr error
+	if host := strings.Trim(st.Server, "[]"); host != "" {
+		start := time.Now()
+		conn, err := c.DialFn("tcp", net.JoinHostPort(host, port), remoteMountDialTimeout)
+		if err == nil {
+			st.ServerReachable = true
+			st.ServerLatencyMs = float64(time.Since(start).Microseconds()) / 1000
+			_ = conn.Close()
+		} else {
+			serverErr = fmt.Errorf("server %s port %s unreachable: %w", host, port, err)
+		}
+	}
+
+	if m.mounted {
+		took, err := c.statfs(m.mountPoint)
+		st.StatfsLatencyMs = float64(took.Microseconds()) / 1000
+		if err != nil {
+			st.Stale = true
+			st.Error = err.Error()
+		}
+	}
+	if st.Error == "" && serverErr != nil {
+		st.Error = serverErr.Error()
+	}
+	return st
+}
+
+// statfs runs StatfsFn on mountPoint with a timeout. A hung call keeps its
+// goroutine until the kernel returns; until then the mount is not probed
+// again, and the shared statfsProbeSlots cap bounds all such goroutines.
+func (c *RemoteMountCollector) statfs(mountPoint string) (time.Duration, error) {
+	c.mu.Lock()
+	if c.probing[mountPoint] {
+		c.mu.Unlock()
+		return 0, fmt.Errorf("previous probe still blocked: %w", errMountStalled)
+	}
+	select {
+	case statfsProbeSlots <- struct{}{}:
+	default:
+		c.mu.Unlock()
+		return 0, fmt.Errorf("%d statfs probes already blocked: %w", maxConcurrentStatfsProbes, errMountStalled)
+	}
+	c.probing[mountPoint] = true
+	c.mu.Unlock()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			<-statfsProbeSlots
+			c.mu.Lock()
+			delete(c.probing, mountPoint)
+			c.mu.Unlock()
+		}()
+		done <- c.StatfsFn(mountPoint)
+	}()
+
+	select {
+	case err := <-done:
+		return time.Since(start), err
+	case <-time.After(remoteMountStatfsTimeout):
+		return time.Since(start), fmt.Errorf("no answer after %v: %w", remoteMountStatfsTimeout, errMountStalled)
+	}
+}
+
+// listRemoteMounts returns the NFS and SMB mounts in procMounts plus the
+// fstab entries and Unassigned Devices remote shares that are not mounted.
+// Mounts are ordered by mount point.
+func listRemoteMounts(procMounts, fstab, udConfig string) []remoteMount {
+	configured := make(map[string]remoteMount)
+	for _, m := range parseRemoteMountTable(fstab) {
+		m.origin = "fstab"
+		configured[m.mountPoint] = m
+	}
+	for _, share := range parseConfiguredRemoteShares(udConfig, time.Time{}) {
+		if share.MountPoint == "" {
+			continue
+		}
+		fsType := "cifs"
+		if share.Type == "nfs" {
+			fsType = "nfs"
+		}
+		configured[share.MountPoint] = remoteMount{source: share.Source, mountPoint: share.MountPoint, fsType: fsType, origin: "unassigned"}
+	}
+
+	var mounts []remoteMount
+	seen := make(map[string]bool)
+	for _, m := range parseRemoteMountTable(procMounts) {
+		if seen[m.mountPoint] {
+			continue
+		}
+		seen[m.mountPoint] = true
+		m.mounted = true
+		switch cfg, ok := configured[m.mountPoint]; {
+		case ok:
+			m.origin = cfg.origin
+		case isUnassignedRemoteMount(m.mountPoint):
+			m.origin = "unassigned"
+		default:
+			m.origin = "manual"
+		}
+		mounts = append(mounts, m)
+	}
+	for mountPoint, m := range configured {
+		if !seen[mountPoint] {
+			mounts = append(mounts, m)
+		}
+	}
+	slices.SortFunc(mounts, func(a, b remoteMount) int { return strings.Compare(a.mountPoint, b.mountPoint) })
+	return mounts
+}
+
+// parseRemoteMountTable parses NFS and SMB entries from a mount table in
+// /proc/mounts or /etc/fstab format.
+func parseRemoteMountTable(table string) []remoteMount {
+	var mounts []remoteMount
+	for line := range strings.SplitSeq(table, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 || remoteMountType(fields[2]) == "" {
+			continue
+		}
+		mounts = append(mounts, remoteMount{
+			source:     unescapeMountField(fields[0]),
+			mountPoint: unescapeMountField(fields[1]),
+			fsType:     fields[2],
+		})
+	}
+	return mounts
+}
+
+// remoteMountType maps a filesystem type to "nfs" or "smb", or "" for a
+// local filesystem.
+func remoteMountType(fsType string) string {
+	switch fsType {
+	case "nfs", "nfs4":
+		return "nfs"
+	case "cifs", "smb3", "smbfs":
+		return "smb"
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package collectors
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestListRemoteMounts(t *testing.T) {
+	procMounts := `/dev/md1p1 /mnt/disk1 xfs rw,noatime 0 0
+//nas/media /mnt/remotes/nas_media cifs rw,vers=3.0 0 0
+nas:/export/backup /mnt/backup nfs4 rw,relatime 0 0
+//nas/media /mnt/remotes/nas_media cifs rw,vers=3.0 0 0
+192.168.1.20:/tv /mnt/remotes/tv nfs rw 0 0
+`
+	fstab := `# comment
+/dev/sda1 /boot vfat defaults 0 0
+nas:/export/backup /mnt/backup nfs4 defaults 0 0
+//nas/photos /mnt/photos cifs credentials=/root/.smb 0 0
+`
+
+	mounts := listRemoteMounts(procMounts, fstab, "")
+	want := []remoteMount{
+		{source: "nas:/export/backup", mountPoint: "/mnt/backup", fsType: "nfs4", origin: "fstab", mounted: true},
+		{source: "//nas/photos", mountPoint: "/mnt/photos", fsType: "cifs", origin: "fstab"},
+		{source: "//nas/media", mountPoint: "/mnt/remotes/nas_media", fsType: "cifs", origin: "unassigned", mounted: true},
+		{source: "192.168.1.20:/tv", mountPoint: "/mnt/remotes/tv", fsType: "nfs", origin: "unassigned", mounted: true},
+	}
+	if len(mounts) != len(want) {
+		t.Fatalf("mounts = %+v", mounts)
+	}
+	for i := range want {
+		if mounts[i] != want[i] {
+			t.Errorf("mounts[%d] = %+v, want %+v", i, mounts[i], want[i])
+		}
+	}
+}
+
+type nopConn struct{ net.Conn }
+
+func (nopConn) Close() error { return nil }
+
+func TestRemoteMountCollector_Check(t *testing.T) {
+	c := NewRemoteMountCollector(nil)
+	c.DialFn = func(_, address string, _ time.Duration) (net.Conn, error) {
+		if address == "nas:2049" {
+			return nil, errors.New("connection refused")
+		}
+		return nopConn{}, nil
+	}
+	failing := map[string]bool{"/mnt/backup": true}
+	c.StatfsFn = func(path string) error {
+		if failing[path] {
+			return errors.New("stale file handle")
+		}
+		return nil
+	}
+
+	mounts := []remoteMount{
+		{source: "nas:/export/backup", mountPoint: "/mnt/backup", fsType: "nfs4", origin: "fstab", mounted: true},
+		{source: "//nas/photos", mountPoint: "/mnt/photos", fsType: "cifs", origin: "fstab"},
+		{source: "//nas/media", mountPoint: "/mnt/remotes/nas_media", fsType: "cifs", origin: "unassigned", mounted: true},
+	}
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	health := c.check(mounts, now)
+
+	if health.StaleCount != 1 || len(health.Mounts) != 3 {
+		t.Fatalf("health = %+v", health)
+	}
+	backup, photos, media := health.Mounts[0], health.Mounts[1], health.Mounts[2]
+	if !backup.Stale || backup.Type != "nfs" || backup.Server != "nas" || backup.ServerReachable || backup.Error != "stale file handle" {
+		t.Errorf("backup = %+v", backup)
+	}
+	if backup.StaleSince == nil || !backup.StaleSince.Equal(now) || backup.LastHealthyAt != nil {
+		t.Errorf("backup timestamps = %+v", backup)
+	}
+	if photos.Mounted || photos.Stale || photos.Type != "smb" || !photos.ServerReachable {
+		t.Errorf("photos = %+v", photos)
+	}
+	if media.Stale || !media.ServerReachable || media.LastHealthyAt == nil {
+		t.Errorf("media = %+v", media)
+	}
+
+	// Still stale a minute later: StaleSince is kept.
+	later := now.Add(time.Minute)
+	health = c.check(mounts, later)
+	if since := health.Mounts[0].StaleSince; since == nil || !since.Equal(now) {
+		t.Errorf("StaleSince = %v, want %v", since, now)
+	}
+
+	// Recovered.
+	delete(failing, "/mnt/backup")
+	health = c.check(mounts, later.Add(time.Minute))
+	if backup := health.Mounts[0]; backup.Stale || backup.StaleSince != nil || backup.LastHealthyAt == nil || health.StaleCount != 0 {
+		t.Errorf("recovered backup = %+v", backup)
+	}
+}
+
+func TestRemoteMountCollector_SkipsBlockedProbe(t *testing.T) {
+	c := NewRemoteMountCollector(nil)
+	c.mu.Lock()
+	c.probing["/mnt/hung"] = true
+	c.mu.Unlock()
+
+	if _, err := c.statfs("/mnt/hung"); !errors.Is(err, errMountStalled) {
+		t.Errorf("statfs on a mount with a blocked probe = %v, want errMountStalled", err)
+	}
+}
@@ -50,15 +50,15 @@ func isCfgTruthy(value string) bool {
 }
 
 // dockerServiceDisabled reports whether Docker is explicitly turned off in
-// docker.cfg. See dockerServiceDisabledAt for the decision rules.
-func dockerServiceDisabled() bool { return dockerServiceDisabledAt(constants.DockerCfg) }
+// docker.cfg. See DockerServiceDisabledAt for the decision rules.
+func dockerServiceDisabled() bool { return DockerServiceDisabledAt(constants.DockerCfg) }
 
-// dockerServiceDisabledAt reports whether Docker is explicitly turned off in the
+// DockerServiceDisabledAt reports whether Docker is explicitly turned off in the
 // given docker.cfg (DOCKER_ENABLED present and not truthy). It returns false
 // when the config is unreadable or the key is absent, so a genuinely
 // broken-but-enabled Docker is still reported as unavailable rather than
 // silently treated as disabled.
-func dockerServiceDisabledAt(path string) bool {
+func DockerServiceDisabledAt(path string) bool {
 	cfg, err := readFlatCfg(path)
 	if err != nil {
 		return false
@@ -71,15 +71,15 @@ func dockerServiceDisabledAt(path string) bool {
 }
 
 // vmServiceDisabled reports whether the Unraid VM manager is explicitly turned
-// off in domain.cfg. See vmServiceDisabledAt for the decision rules.
-func vmServiceDisabled() bool { return vmServiceDisabledAt(constants.DomainCfg) }
+// off in domain.cfg. See VMServiceDisabledAt for the decision rules.
+func vmServiceDisabled() bool { return VMServiceDisabledAt(constants.DomainCfg) }
 
-// vmServiceDisabledAt reports whether the Unraid VM manager is explicitly turned
+// VMServiceDisabledAt reports whether the Unraid VM manager is explicitly turned
 // off in the given domain.cfg. An explicit DISABLE flag wins; otherwise a
 // SERVICE value that is present but not enable-like means disabled. It returns
 // false when the config is unreadable or neither key is present, mirroring
-// dockerServiceDisabledAt's conservative default.
-func vmServiceDisabledAt(path string) bool {
+// DockerServiceDisabledAt's conservative default.
+func VMServiceDisabledAt(path string) bool {
 	cfg, err := readFlatCfg(path)
 	if err != nil {
 		return false
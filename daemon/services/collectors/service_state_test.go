@@ -63,15 +63,15 @@ func TestDockerServiceDisabledAt(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := dockerServiceDisabledAt(writeTempCfg(t, tt.body)); got != tt.want {
-				t.Errorf("dockerServiceDisabledAt = %v, want %v", got, tt.want)
+			if got := DockerServiceDisabledAt(writeTempCfg(t, tt.body)); got != tt.want {
+				t.Errorf("DockerServiceDisabledAt = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
 func TestDockerServiceDisabledAtMissingFile(t *testing.T) {
-	if dockerServiceDisabledAt(filepath.Join(t.TempDir(), "nope.cfg")) {
+	if DockerServiceDisabledAt(filepath.Join(t.TempDir(), "nope.cfg")) {
 		t.Error("missing config must be treated as not-disabled (conservative)")
 	}
 }
@@ -89,15 +89,15 @@ func TestVMServiceDisabledAt(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := vmServiceDisabledAt(writeTempCfg(t, tt.body)); got != tt.want {
-				t.Errorf("vmServiceDisabledAt = %v, want %v", got, tt.want)
+			if got := VMServiceDisabledAt(writeTempCfg(t, tt.body)); got != tt.want {
+				t.Errorf("VMServiceDisabledAt = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
 func TestVMServiceDisabledAtMissingFile(t *testing.T) {
-	if vmServiceDisabledAt(filepath.Join(t.TempDir(), "nope.cfg")) {
+	if VMServiceDisabledAt(filepath.Join(t.TempDir(), "nope.cfg")) {
 		t.Error("missing config must be treated as not-disabled (conservative)")
 	}
 }
@@ -341,6 +341,15 @@ func (c *SettingsCollector) GetParitySchedule() (*dto.ParitySchedule, error) {
 		logger.Debug("Settings: Could not read parity-check.cron: %v", err)
 	}
 
+	if schedule.CheckCron != "" {
+		next, err := nextCronTime(schedule.CheckCron, time.Now())
+		if err != nil {
+			logger.Debug("Settings: Could not compute next parity check: %v", err)
+		} else {
+			schedule.NextCheck = &next
+		}
+	}
+
 	return schedule, nil
 }
 
@@ -16,26 +16,28 @@ import (
 )
 
 // ShareCollector collects information about Unraid user shares.
-// It gathers share configuration, usage statistics, and disk allocation details.
+// It gathers share configuration, usage statistics, disk allocation details and
+// SMB/NFS activity.
 type ShareCollector struct {
-	ctx *domain.Context
+	ctx      *domain.Context
+	activity *shareActivity
 }
 
 // NewShareCollector creates a new user share collector with the given context.
 func NewShareCollector(ctx *domain.Context) *ShareCollector {
-	return &ShareCollector{ctx: ctx}
+	return &ShareCollector{ctx: ctx, activity: newShareActivity()}
 }
 
 // Start begins the share collector's periodic data collection.
 // It runs in a goroutine and publishes share information updates at the specified interval until the context is cancelled.
 func (c *ShareCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting share collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting share collector (interval: %v)", interval)
 
 	// Run once immediately with panic recovery
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Share collector", r)
+				logger.LogPanicWithStackContext(ctx, "Share collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "Share", interval, func() { c.Collect(ctx) })
@@ -45,11 +47,11 @@ func (c *ShareCollector) Start(ctx context.Context, interval time.Duration) {
 	watchedFiles := []string{constants.SharesIni}
 	fw, err := NewFileWatcher(500 * time.Millisecond)
 	if err != nil {
-		logger.Warning("Share collector: failed to create file watcher, using ticker only: %v", err)
+		logger.WarningContext(ctx, "Share collector: failed to create file watcher, using ticker only: %v", err)
 	} else {
 		for _, f := range watchedFiles {
 			if watchErr := fw.WatchFile(f); watchErr != nil {
-				logger.Warning("Share collector: failed to watch %s: %v", f, watchErr)
+				logger.WarningContext(ctx, "Share collector: failed to watch %s: %v", f, watchErr)
 			}
 		}
 		// Close is deferred inside the goroutine to avoid racing with fw.Run()
@@ -59,15 +61,15 @@ func (c *ShareCollector) Start(ctx context.Context, interval time.Duration) {
 				func() {
 					defer func() {
 						if r := recover(); r != nil {
-							logger.LogPanicWithStack("Share collector (fsnotify)", r)
+							logger.LogPanicWithStackContext(ctx, "Share collector (fsnotify)", r)
 						}
 					}()
-					logger.Debug("Share collector: shares.ini changed, collecting immediately")
+					logger.DebugContext(ctx, "Share collector: shares.ini changed, collecting immediately")
 					collectWithWatchdog(ctx, "Share", interval, func() { c.Collect(ctx) })
 				}()
 			})
 		}()
-		logger.Info("Share collector: fsnotify watching %v for instant updates", watchedFiles)
+		logger.InfoContext(ctx, "Share collector: fsnotify watching %v for instant updates", watchedFiles)
 	}
 
 	ticker := time.NewTicker(interval)
@@ -76,13 +78,13 @@ func (c *ShareCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Share collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "Share collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Share collector", r)
+						logger.LogPanicWithStackContext(ctx, "Share collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "Share", interval, func() { c.Collect(ctx) })
@@ -116,6 +118,8 @@ func (c *ShareCollector) Collect(ctx context.Context) {
 		return
 	}
 
+	c.activity.apply(ctx, shares, time.Now())
+
 	// Report source health and attach inline flag when not healthy.
 	if c.ctx.Platform != nil {
 		if len(shares) == 0 {
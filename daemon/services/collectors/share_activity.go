@@ -0,0 +1,329 @@
+package collectors
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Package-level variables (not constants) so tests can use fixtures.
+var (
+	shareActivityProcDir = "/proc"
+	// nfsdExportStats lists each export with its I/O counters (Linux 6.2+).
+	nfsdExportStats = "/proc/fs/nfsd/export_stats"
+	// smbTreeConnects returns the output of "smbstatus -S --json".
+	smbTreeConnects = func(ctx context.Context) (string, error) {
+		cmdCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		return lib.ExecCommandStdoutWithContext(cmdCtx, "smbstatus", "-S", "--json")
+	}
+)
+
+// procIO is the part of /proc/<pid>/io attributed to shares.
+type procIO struct {
+	readBytes, writeBytes, readOps, writeOps uint64
+}
+
+// sub returns the counters gained since prev. A counter that went down
+// belongs to a new process with a reused PID and is taken whole.
+func (p procIO) sub(prev procIO) procIO {
+	d := func(cur, old uint64) uint64 {
+		if cur < old {
+			return cur
+		}
+		return cur - old
+	}
+	return procIO{
+		readBytes:  d(p.readBytes, prev.readBytes),
+		writeBytes: d(p.writeBytes, prev.writeBytes),
+		readOps:    d(p.readOps, prev.readOps),
+		writeOps:   d(p.writeOps, prev.writeOps),
+	}
+}
+
+// shareActivity accumulates the SMB and NFS I/O of each user share across
+// collections. Samba keeps no per-share counters, so SMB I/O is read from
+// the smbd processes serving each share: Samba forks one per client
+// connection, and a process with several shares open has its I/O split
+// evenly between them. NFS I/O comes from the kernel's per-export counters.
+type shareActivity struct {
+	mu    sync.Mutex
+	since time.Time // zero until the first collection has seeded the counters
+	last  time.Time
+
+	smbProcs   map[int]procIO    // last counters of each smbd, by PID
+	nfsExports map[string]procIO // last counters of each export, by path
+
+	smb map[string]*dto.ShareProtocolIO // totals by share name
+	nfs map[string]*dto.ShareProtocolIO
+}
+
+func newShareActivity() *shareActivity {
+	return &shareActivity{
+		smbProcs:   map[int]procIO{},
+		nfsExports: map[string]procIO{},
+		smb:        map[string]*dto.ShareProtocolIO{},
+		nfs:        map[string]*dto.ShareProtocolIO{},
+	}
+}
+
+// apply reads the current counters and sets the activity of every share
+// exported over a protocol whose counters could be read.
+func (a *shareActivity) apply(ctx context.Context, shares []dto.ShareInfo, now time.Time) {
+	if a == nil {
+		return
+	}
+	smbProcs, smbOK := readSMBProcs(ctx)
+	nfsExports, nfsOK := readNFSExportStats(nfsdExportStats)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	seeded := !a.since.IsZero()
+	elapsed := now.Sub(a.last).Seconds()
+	if !seeded {
+		a.since = now
+	}
+	a.last = now
+
+	// Rates are recomputed on every collection; shares without I/O since the
+	// last one drop to zero.
+	for _, totals := range []map[string]*dto.ShareProtocolIO{a.smb, a.nfs} {
+		for _, io := range totals {
+			io.ReadBytesPerSec, io.WriteBytesPerSec = 0, 0
+		}
+	}
+	add := func(totals map[string]*dto.ShareProtocolIO, share string, d procIO, parts uint64) {
+		io := totals[share]
+		if io == nil {
+			io = &dto.ShareProtocolIO{}
+			totals[share] = io
+		}
+		io.ReadBytes += d.readBytes / parts
+		io.WriteBytes += d.writeBytes / parts
+		io.ReadOps += d.readOps / parts
+		io.WriteOps += d.writeOps / parts
+		if elapsed > 0 {
+			io.ReadBytesPerSec += float64(d.readBytes/parts) / elapsed
+			io.WriteBytesPerSec += float64(d.writeBytes/parts) / elapsed
+		}
+	}
+
+	if smbOK {
+		current := make(map[int]procIO, len(smbProcs))
+		for pid, proc := range smbProcs {
+			current[pid] = proc.io
+			// Counters of processes already running at the first collection
+			// cover I/O from before the agent counted; later processes are
+			// new connections and count from zero.
+			prev, known := a.smbProcs[pid]
+			if !known && !seeded {
+				continue
+			}
+			d := proc.io.sub(prev)
+			for _, share := range proc.shares {
+				add(a.smb, share, d, uint64(len(proc.shares)))
+			}
+		}
+		a.smbProcs = current
+	}
+	if nfsOK {
+		for path, cur := range nfsExports {
+			prev, known := a.nfsExports[path]
+			if !known && !seeded {
+				continue
+			}
+			if share, ok := userShareOfPath(path); ok {
+				add(a.nfs, share, cur.sub(prev), 1)
+			}
+		}
+		a.nfsExports = nfsExports
+	}
+
+	for i := range shares {
+		s := &shares[i]
+		activity := &dto.ShareActivity{Since: a.since}
+		if smbOK && s.SMBExport {
+			activity.SMB = protocolIO(a.smb[s.Name])
+		}
+		if nfsOK && s.NFSExport {
+			activity.NFS = protocolIO(a.nfs[s.Name])
+		}
+		if activity.SMB != nil || activity.NFS != nil {
+			s.Activity = activity
+		}
+	}
+}
+
+// protocolIO returns a copy of io, or zero counters for a share without I/O.
+func protocolIO(io *dto.ShareProtocolIO) *dto.ShareProtocolIO {
+	if io == nil {
+		return &dto.ShareProtocolIO{}
+	}
+	c := *io
+	return &c
+}
+
+// userShareOfPath returns the user share an export path such as
+// /mnt/user/Media belongs to.
+func userShareOfPath(path string) (string, bool) {
+	dir, name := filepath.Split(filepath.Clean(path))
+	if filepath.Clean(dir) != "/mnt/user" || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// smbProc is one smbd process with the user shares it has connected.
+type smbProc struct {
+	shares []string
+	io     procIO
+}
+
+// smbStatusJSON is the part of "smbstatus -S --json" used here.
+type smbStatusJSON struct {
+	Tcons map[string]struct {
+		Service  string `json:"service"`
+		ServerID struct {
+			PID json.RawMessage `json:"pid"`
+		} `json:"server_id"`
+	} `json:"tcons"`
+}
+
+// readSMBProcs maps the smbd processes to the shares they serve and reads
+// their I/O counters. It reports false when smbstatus is unavailable, as
+// when SMB is disabled.
+func readSMBProcs(ctx context.Context) (map[int]smbProc, bool) {
+	out, err := smbTreeConnects(ctx)
+	if err != nil {
+		logger.Debug("Share: smbstatus failed: %v", err)
+		return nil, false
+	}
+	var status smbStatusJSON
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		logger.Debug("Share: failed to parse smbstatus output: %v", err)
+		return nil, false
+	}
+
+	procs := make(map[int]smbProc)
+	for _, tcon := range status.Tcons {
+		pid, err := strconv.Atoi(strings.Trim(string(tcon.ServerID.PID), `"`))
+		// IPC$ and print$ are Samba's own services, not user shares
+		if err != nil || tcon.Service == "" || strings.HasSuffix(tcon.Service, "$") {
+			continue
+		}
+		p := procs[pid]
+		if !slices.Contains(p.shares, tcon.Service) {
+			p.shares = append(p.shares, tcon.Service)
+		}
+		procs[pid] = p
+	}
+	for pid, p := range procs {
+		io, ok := readProcIO(filepath.Join(shareActivityProcDir, strconv.Itoa(pid), "io"))
+		if !ok {
+			// The client disconnected since smbstatus ran
+			delete(procs, pid)
+			continue
+		}
+		p.io = io
+		procs[pid] = p
+	}
+	return procs, true
+}
+
+// readProcIO parses /proc/<pid>/io. Bytes are those read from and written
+// to storage, so reads served from the page cache are not counted; ops are
+// the process's read and write syscalls.
+func readProcIO(path string) (procIO, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return procIO{}, false
+	}
+	defer func() { _ = f.Close() }()
+
+	var io procIO
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "read_bytes":
+			io.readBytes = n
+		case "write_bytes":
+			io.writeBytes = n
+		case "syscr":
+			io.readOps = n
+		case "syscw":
+			io.writeOps = n
+		}
+	}
+	return io, true
+}
+
+// readNFSExportStats parses the kernel's export_stats file. Each export line
+// (path, then client and options) is followed by indented "name: value"
+// counters; io_read and io_write are summed per path over its clients. The
+// kernel counts no operations per export. It reports false when the file
+// cannot be read, as when NFS is stopped or the kernel predates 6.2.
+func readNFSExportStats(path string) (map[string]procIO, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer func() { _ = f.Close() }()
+
+	exports := make(map[string]procIO)
+	current := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			fields := strings.Fields(line)
+			current = unescapeMountField(fields[0])
+			if _, ok := exports[current]; !ok {
+				exports[current] = procIO{}
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		io := exports[current]
+		switch key {
+		case "io_read":
+			io.readBytes += n
+		case "io_write":
+			io.writeBytes += n
+		}
+		exports[current] = io
+	}
+	return exports, true
+}
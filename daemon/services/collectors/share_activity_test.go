@@ -0,0 +1,127 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestShareActivity(t *testing.T) {
+	root := t.TempDir()
+	origProc, origNFS, origSMB := shareActivityProcDir, nfsdExportStats, smbTreeConnects
+	t.Cleanup(func() { shareActivityProcDir, nfsdExportStats, smbTreeConnects = origProc, origNFS, origSMB })
+	shareActivityProcDir = filepath.Join(root, "proc")
+	nfsdExportStats = filepath.Join(root, "export_stats")
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	procIO := func(pid int, read, write, syscr, syscw uint64) {
+		writeFile(filepath.Join(shareActivityProcDir, fmt.Sprint(pid), "io"), fmt.Sprintf(
+			"rchar: 1\nwchar: 1\nsyscr: %d\nsyscw: %d\nread_bytes: %d\nwrite_bytes: %d\ncancelled_write_bytes: 0\n",
+			syscr, syscw, read, write))
+	}
+	exportStats := func(mediaRead, mediaWrite, backupsWrite uint64) {
+		writeFile(nfsdExportStats, fmt.Sprintf("# Version 1.1\n# Path Client Start-time\n#\tStats\n"+
+			"/mnt/user/Media\t192.168.1.0/24(ro,sync,wdelay)\n\tfh_stale: 0\n\tio_read: %d\n\tio_write: %d\n"+
+			"/mnt/user/Media\t10.0.0.5(rw,sync,wdelay)\n\tfh_stale: 0\n\tio_read: 100\n\tio_write: 0\n"+
+			"/mnt/user/My\\040Backups\t*(rw,sync)\n\tfh_stale: 0\n\tio_read: 0\n\tio_write: %d\n",
+			mediaRead, mediaWrite, backupsWrite))
+	}
+	// pid 100 serves Media, pid 200 has Media and appdata open; IPC$ is ignored
+	smbTreeConnects = func(context.Context) (string, error) {
+		return `{"tcons": {
+			"1": {"service": "Media", "server_id": {"pid": "100"}},
+			"2": {"service": "IPC$", "server_id": {"pid": "100"}},
+			"3": {"service": "Media", "server_id": {"pid": "200"}},
+			"4": {"service": "appdata", "server_id": {"pid": 200}}
+		}}`, nil
+	}
+	shares := func() []dto.ShareInfo {
+		return []dto.ShareInfo{
+			{Name: "Media", SMBExport: true, NFSExport: true},
+			{Name: "appdata", SMBExport: true},
+			{Name: "My Backups", NFSExport: true},
+			{Name: "system"},
+		}
+	}
+
+	a := newShareActivity()
+	start := time.Now()
+
+	// The first collection only seeds the counters
+	procIO(100, 5000, 5000, 50, 50)
+	procIO(200, 0, 0, 0, 0)
+	exportStats(1000, 1000, 1000)
+	first := shares()
+	a.apply(context.Background(), first, start)
+	if first[0].Activity == nil || *first[0].Activity.SMB != (dto.ShareProtocolIO{}) || *first[0].Activity.NFS != (dto.ShareProtocolIO{}) {
+		t.Fatalf("seeded Media activity = %+v, want zero counters", first[0].Activity)
+	}
+	if first[3].Activity != nil {
+		t.Errorf("unexported share has activity %+v", first[3].Activity)
+	}
+
+	procIO(100, 7000, 5000, 60, 50)
+	procIO(200, 1000, 400, 10, 4)
+	exportStats(3000, 1500, 1500)
+	second := shares()
+	a.apply(context.Background(), second, start.Add(10*time.Second))
+
+	media := second[0].Activity
+	// 2000 from pid 100 plus half of pid 200's 1000
+	if media.SMB.ReadBytes != 2500 || media.SMB.WriteBytes != 200 || media.SMB.ReadOps != 15 {
+		t.Errorf("Media SMB = %+v", media.SMB)
+	}
+	if media.SMB.ReadBytesPerSec != 250 {
+		t.Errorf("Media SMB read rate = %v, want 250", media.SMB.ReadBytesPerSec)
+	}
+	if media.NFS.ReadBytes != 2000 || media.NFS.WriteBytes != 500 || media.NFS.ReadOps != 0 {
+		t.Errorf("Media NFS = %+v", media.NFS)
+	}
+	if !media.Since.Equal(start) {
+		t.Errorf("since = %v, want %v", media.Since, start)
+	}
+	if appdata := second[1].Activity; appdata.SMB.ReadBytes != 500 || appdata.NFS != nil {
+		t.Errorf("appdata = %+v", appdata)
+	}
+	if backups := second[2].Activity; backups.NFS.WriteBytes != 500 || backups.SMB != nil {
+		t.Errorf("My Backups = %+v", backups)
+	}
+
+	// A new connection counts from zero; rates drop once the I/O stops
+	smbTreeConnects = func(context.Context) (string, error) {
+		return `{"tcons": {"1": {"service": "appdata", "server_id": {"pid": "300"}}}}`, nil
+	}
+	procIO(300, 100, 0, 1, 0)
+	third := shares()
+	a.apply(context.Background(), third, start.Add(20*time.Second))
+	if appdata := third[1].Activity.SMB; appdata.ReadBytes != 600 || appdata.ReadBytesPerSec != 10 {
+		t.Errorf("appdata after reconnect = %+v", appdata)
+	}
+	if media := third[0].Activity.SMB; media.ReadBytes != 2500 || media.ReadBytesPerSec != 0 {
+		t.Errorf("idle Media = %+v", media)
+	}
+
+	// Without smbstatus or export stats there is nothing to report
+	smbTreeConnects = func(context.Context) (string, error) { return "", os.ErrNotExist }
+	nfsdExportStats = filepath.Join(root, "missing")
+	fourth := shares()
+	a.apply(context.Background(), fourth, start.Add(30*time.Second))
+	for _, s := range fourth {
+		if s.Activity != nil {
+			t.Errorf("%s has activity %+v without sources", s.Name, s.Activity)
+		}
+	}
+}
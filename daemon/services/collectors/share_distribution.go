@@ -0,0 +1,198 @@
+package collectors
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// shareScanMaxAge forces a rescan of a share on a disk even when the disk's
+// used space has not changed, catching same-size rewrites and moves.
+const shareScanMaxAge = 24 * time.Hour
+
+// shareScan is the cached result of walking one share directory on one disk.
+type shareScan struct {
+	bytes     uint64
+	files     uint64
+	diskUsed  uint64 // disk used bytes when scanned
+	scannedAt time.Time
+}
+
+// ShareDistributionCollector computes how much of a user share lives on each
+// array disk and pool. Walking a share is expensive, so results are cached
+// per disk and a disk is only rescanned when its used space has changed or
+// its scan is older than shareScanMaxAge.
+type ShareDistributionCollector struct {
+	mu    sync.Mutex                      // serializes computations and guards scans
+	scans map[string]map[string]shareScan // share -> mount point -> scan
+
+	// walkFn and usedFn are replaceable for testing.
+	walkFn func(ctx context.Context, dir string) (bytes, files uint64, err error)
+	usedFn func(mount string) (uint64, error)
+}
+
+// NewShareDistributionCollector creates a share distribution collector.
+func NewShareDistributionCollector() *ShareDistributionCollector {
+	return &ShareDistributionCollector{
+		scans:  make(map[string]map[string]shareScan),
+		walkFn: walkShareDir,
+		usedFn: diskUsedBytes,
+	}
+}
+
+// Distribution returns the per-disk usage of share across disks. Disks in
+// standby are not spun up unless spinUp is set; their last scan is reported
+// instead, and the result is marked incomplete when there is none.
+func (c *ShareDistributionCollector) Distribution(ctx context.Context, share string, disks []dto.DiskInfo, spinUp bool, now time.Time) (*dto.ShareDistribution, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev := c.scans[share]
+	next := make(map[string]shareScan)
+	result := &dto.ShareDistribution{Share: share, Disks: []dto.ShareDiskUsage{}, Timestamp: now}
+
+	type job struct {
+		usage dto.ShareDiskUsage
+		used  uint64
+		scan  shareScan
+		err   error
+	}
+	var jobs []*job
+	for _, disk := range disks {
+		if disk.MountPoint == "" || disk.Role == "parity" || disk.Role == "parity2" {
+			continue
+		}
+		usage := dto.ShareDiskUsage{Disk: disk.ID, Role: disk.Role, MountPoint: disk.MountPoint}
+		last, hasLast := prev[disk.MountPoint]
+
+		if disk.SpinState == "standby" && !spinUp {
+			if hasLast {
+				next[disk.MountPoint] = last
+				usage.Bytes, usage.Files, usage.ScannedAt = last.bytes, last.files, last.scannedAt
+				usage.Standby = true
+				result.Disks = append(result.Disks, usage)
+			} else {
+				result.Incomplete = true
+			}
+			continue
+		}
+
+		if info, err := os.Stat(filepath.Join(disk.MountPoint, share)); err != nil || !info.IsDir() {
+			continue
+		}
+		used, err := c.usedFn(disk.MountPoint)
+		if err == nil && hasLast && last.diskUsed == used && now.Sub(last.scannedAt) < shareScanMaxAge {
+			next[disk.MountPoint] = last
+			usage.Bytes, usage.Files, usage.ScannedAt = last.bytes, last.files, last.scannedAt
+			usage.Cached = true
+			result.Disks = append(result.Disks, usage)
+			continue
+		}
+		jobs = append(jobs, &job{usage: usage, used: used})
+	}
+
+	// Disks are independent devices, so walk them concurrently.
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		wg.Go(func() {
+			dir := filepath.Join(j.usage.MountPoint, share)
+			j.scan.bytes, j.scan.files, j.err = c.walkFn(ctx, dir)
+			j.scan.diskUsed, j.scan.scannedAt = j.used, now
+		})
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, j := range jobs {
+		if j.err != nil {
+			return nil, j.err
+		}
+		next[j.usage.MountPoint] = j.scan
+		j.usage.Bytes, j.usage.Files, j.usage.ScannedAt = j.scan.bytes, j.scan.files, j.scan.scannedAt
+		result.Disks = append(result.Disks, j.usage)
+	}
+	c.scans[share] = next
+
+	for _, d := range result.Disks {
+		result.TotalBytes += d.Bytes
+		result.TotalFiles += d.Files
+	}
+	for i := range result.Disks {
+		if result.TotalBytes > 0 {
+			result.Disks[i].Percent = float64(result.Disks[i].Bytes) / float64(result.TotalBytes) * 100
+		}
+	}
+	sortShareDisks(result.Disks, disks)
+	return result, nil
+}
+
+// sortShareDisks orders usage entries to match the order of disks.
+func sortShareDisks(usage []dto.ShareDiskUsage, disks []dto.DiskInfo) {
+	order := make(map[string]int, len(disks))
+	for i, d := range disks {
+		order[d.MountPoint] = i
+	}
+	slices.SortStableFunc(usage, func(a, b dto.ShareDiskUsage) int {
+		return cmp.Compare(order[a.MountPoint], order[b.MountPoint])
+	})
+}
+
+// walkShareDir returns the allocated bytes and file count under dir. Hard
+// links are counted once. Unreadable entries are skipped.
+func walkShareDir(ctx context.Context, dir string) (bytes, files uint64, err error) {
+	seen := make(map[uint64]struct{})
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, walkErr error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if walkErr != nil {
+			if path == dir {
+				return walkErr
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if st, ok := info.Sys().(*syscall.Stat_t); ok {
+			if !d.IsDir() && st.Nlink > 1 {
+				if _, dup := seen[st.Ino]; dup {
+					return nil
+				}
+				seen[st.Ino] = struct{}{}
+			}
+			bytes += uint64(st.Blocks) * 512 // #nosec G115 -- block counts are non-negative
+		} else {
+			bytes += uint64(max(info.Size(), 0)) // #nosec G115 -- clamped to non-negative
+		}
+		if !d.IsDir() {
+			files++
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, 0, nil
+	}
+	return bytes, files, err
+}
+
+// diskUsedBytes returns the used bytes of the filesystem mounted at mount.
+func diskUsedBytes(mount string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(mount, &st); err != nil {
+		return 0, err
+	}
+	return (st.Blocks - st.Bfree) * uint64(st.Bsize), nil // #nosec G115 -- Bsize is positive
+}
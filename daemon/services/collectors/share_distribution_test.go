@@ -0,0 +1,143 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func writeShareFile(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWalkShareDirCountsHardLinksOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeShareFile(t, filepath.Join(dir, "a", "one.bin"), 64*1024)
+	if err := os.Link(filepath.Join(dir, "a", "one.bin"), filepath.Join(dir, "link.bin")); err != nil {
+		t.Skipf("hard links unsupported: %v", err)
+	}
+
+	bytes, files, err := walkShareDir(context.Background(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files != 1 {
+		t.Errorf("files = %d, want 1", files)
+	}
+	if bytes < 64*1024 {
+		t.Errorf("bytes = %d, want at least the file's size", bytes)
+	}
+}
+
+func TestShareDistributionCachesUnchangedDisks(t *testing.T) {
+	root := t.TempDir()
+	disk1, disk2, cache := filepath.Join(root, "disk1"), filepath.Join(root, "disk2"), filepath.Join(root, "cache")
+	writeShareFile(t, filepath.Join(disk1, "Media", "a.mkv"), 1)
+	writeShareFile(t, filepath.Join(cache, "Media", "b.mkv"), 1)
+	if err := os.MkdirAll(disk2, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewShareDistributionCollector()
+	walks := map[string]int{}
+	c.walkFn = func(_ context.Context, dir string) (uint64, uint64, error) {
+		walks[dir]++
+		if filepath.Dir(dir) == disk1 {
+			return 300, 3, nil
+		}
+		return 100, 1, nil
+	}
+	used := map[string]uint64{disk1: 1000, cache: 500}
+	c.usedFn = func(mount string) (uint64, error) { return used[mount], nil }
+
+	disks := []dto.DiskInfo{
+		{ID: "parity", Role: "parity"},
+		{ID: "disk1", Role: "data", MountPoint: disk1},
+		{ID: "disk2", Role: "data", MountPoint: disk2},
+		{ID: "cache", Role: "cache", MountPoint: cache},
+	}
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	dist, err := c.Distribution(context.Background(), "Media", disks, false, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dist.TotalBytes != 400 || dist.TotalFiles != 4 || len(dist.Disks) != 2 {
+		t.Fatalf("distribution = %+v", dist)
+	}
+	if dist.Disks[0].Disk != "disk1" || dist.Disks[0].Percent != 75 || dist.Disks[1].Disk != "cache" {
+		t.Errorf("disks = %+v", dist.Disks)
+	}
+
+	// Only the disk whose usage changed is walked again.
+	used[cache] = 600
+	dist, err = c.Distribution(context.Background(), "Media", disks, false, now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if walks[filepath.Join(disk1, "Media")] != 1 || walks[filepath.Join(cache, "Media")] != 2 {
+		t.Errorf("walks = %v", walks)
+	}
+	if !dist.Disks[0].Cached || dist.Disks[1].Cached {
+		t.Errorf("cached flags = %v, %v", dist.Disks[0].Cached, dist.Disks[1].Cached)
+	}
+
+	// Old scans are refreshed even when usage is unchanged.
+	if _, err := c.Distribution(context.Background(), "Media", disks, false, now.Add(shareScanMaxAge+time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if walks[filepath.Join(disk1, "Media")] != 2 {
+		t.Errorf("stale scan not refreshed: %v", walks)
+	}
+}
+
+func TestShareDistributionSkipsStandbyDisks(t *testing.T) {
+	root := t.TempDir()
+	disk1, disk2 := filepath.Join(root, "disk1"), filepath.Join(root, "disk2")
+	writeShareFile(t, filepath.Join(disk1, "Backups", "a"), 1)
+	writeShareFile(t, filepath.Join(disk2, "Backups", "b"), 1)
+
+	c := NewShareDistributionCollector()
+	var walked []string
+	c.walkFn = func(_ context.Context, dir string) (uint64, uint64, error) {
+		walked = append(walked, dir)
+		return 10, 1, nil
+	}
+	c.usedFn = func(string) (uint64, error) { return 1, nil }
+
+	disks := []dto.DiskInfo{
+		{ID: "disk1", Role: "data", MountPoint: disk1},
+		{ID: "disk2", Role: "data", MountPoint: disk2, SpinState: "standby"},
+	}
+	now := time.Now()
+
+	dist, err := c.Distribution(context.Background(), "Backups", disks, false, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dist.Incomplete || len(dist.Disks) != 1 || len(walked) != 1 {
+		t.Fatalf("distribution = %+v, walked = %v", dist, walked)
+	}
+
+	// Spinning up scans the standby disk; later standby requests reuse that scan.
+	if _, err := c.Distribution(context.Background(), "Backups", disks, true, now); err != nil {
+		t.Fatal(err)
+	}
+	dist, err = c.Distribution(context.Background(), "Backups", disks, false, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dist.Incomplete || len(dist.Disks) != 2 || !dist.Disks[1].Standby || dist.Disks[1].Bytes != 10 {
+		t.Errorf("distribution = %+v", dist)
+	}
+}
@@ -0,0 +1,320 @@
+package collectors
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+)
+
+// Mover actions reported by a share config dry run.
+const (
+	moverToArray  = "move_to_array"
+	moverToPool   = "move_to_pool"
+	moverStay     = "stay"
+	moverStranded = "stranded"
+)
+
+// maxDryRunFiles caps the affected files listed in a share config dry run.
+const maxDryRunFiles = 500
+
+// defaultCachePool is the pool a share uses when shareCachePool is not set.
+const defaultCachePool = "cache"
+
+// MergeShareConfig returns current with the fields set in update applied, as
+// UpdateShareConfig would leave it.
+func MergeShareConfig(current, update *dto.ShareConfig) *dto.ShareConfig {
+	merged := *current
+	set := func(dst *string, value string) {
+		if value != "" {
+			*dst = value
+		}
+	}
+	set(&merged.Comment, update.Comment)
+	set(&merged.Allocator, update.Allocator)
+	set(&merged.Floor, update.Floor)
+	set(&merged.SplitLevel, update.SplitLevel)
+	set(&merged.UseCache, update.UseCache)
+	set(&merged.CachePool, update.CachePool)
+	set(&merged.Export, update.Export)
+	set(&merged.Security, update.Security)
+	if len(update.IncludeDisks) > 0 {
+		merged.IncludeDisks = update.IncludeDisks
+	}
+	if len(update.ExcludeDisks) > 0 {
+		merged.ExcludeDisks = update.ExcludeDisks
+	}
+	if t := update.MoverTuning; t != nil {
+		tuning := dto.ShareMoverTuning{Override: t.Override}
+		if current.MoverTuning != nil {
+			tuning = *current.MoverTuning
+			tuning.Override = t.Override
+		}
+		if t.ThresholdPercent != nil {
+			tuning.ThresholdPercent = t.ThresholdPercent
+		}
+		if t.AgeDays != nil {
+			tuning.AgeDays = nil
+			if *t.AgeDays > 0 {
+				tuning.AgeDays = t.AgeDays
+			}
+		}
+		if t.MinSizeMB != nil {
+			tuning.MinSizeMB = nil
+			if *t.MinSizeMB > 0 {
+				tuning.MinSizeMB = t.MinSizeMB
+			}
+		}
+		merged.MoverTuning = &tuning
+	}
+	return &merged
+}
+
+// ShareConfigDryRun reports what applying update to the share configured as
+// current would do without changing anything: the settings that change, the
+// files whose handling by the mover changes and the disks with less free space
+// than a new minimum free space. The share is walked on every data disk and
+// pool in disks; disks in standby are skipped unless spinUp is set.
+func ShareConfigDryRun(ctx context.Context, current, update *dto.ShareConfig, disks []dto.DiskInfo, spinUp bool, now time.Time) (*dto.ShareConfigDryRun, error) {
+	next := MergeShareConfig(current, update)
+	result := &dto.ShareConfigDryRun{
+		Share:     current.Name,
+		Changes:   shareConfigChanges(current, next),
+		Moves:     []dto.ShareMoveSummary{},
+		Files:     []dto.ShareAffectedFile{},
+		Timestamp: now,
+	}
+
+	totals := make(map[string]*dto.ShareMoveSummary)
+	var affected uint64
+	seen := make(map[string]bool)
+	for _, disk := range disks {
+		if disk.MountPoint == "" || seen[disk.MountPoint] || (disk.Role != "data" && !isPoolRole(disk.Role)) {
+			continue
+		}
+		seen[disk.MountPoint] = true
+		if disk.SpinState == "standby" && !spinUp {
+			result.SkippedDisks = append(result.SkippedDisks, disk.ID)
+			continue
+		}
+
+		pool := ""
+		if isPoolRole(disk.Role) {
+			pool = filepath.Base(disk.MountPoint)
+		}
+		dir := filepath.Join(disk.MountPoint, current.Name)
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, walkErr error) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if walkErr != nil {
+				if path == dir {
+					return walkErr
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			action := moverAction(next, pool, info, now)
+			if action == moverAction(current, pool, info, now) {
+				return nil
+			}
+
+			size := uint64(max(info.Size(), 0)) // #nosec G115 -- clamped to non-negative
+			total := totals[action]
+			if total == nil {
+				total = &dto.ShareMoveSummary{Action: action}
+				totals[action] = total
+			}
+			total.Files++
+			total.Bytes += size
+			affected++
+
+			result.Files = append(result.Files, dto.ShareAffectedFile{
+				Path:       path,
+				SizeBytes:  size,
+				ModifiedAt: info.ModTime(),
+				Action:     action,
+			})
+			if len(result.Files) >= 2*maxDryRunFiles {
+				result.Files = largestFiles(result.Files)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("scan %s: %w", dir, err)
+		}
+	}
+
+	result.Files = largestFiles(result.Files)
+	result.FilesTruncated = affected > uint64(len(result.Files))
+	for _, action := range []string{moverToArray, moverToPool, moverStay, moverStranded} {
+		if total := totals[action]; total != nil {
+			result.Moves = append(result.Moves, *total)
+		}
+	}
+
+	if next.Floor != current.Floor && next.Floor != "" {
+		floor, err := lib.ParseShareFloor(next.Floor)
+		if err != nil {
+			return nil, err
+		}
+		for _, disk := range disks {
+			if disk.MountPoint != "" && shareMayUseDisk(next, disk) && disk.Free < floor {
+				result.DisksBelowFloor = append(result.DisksBelowFloor, disk.ID)
+			}
+		}
+	}
+	result.Warnings = shareConfigWarnings(next, disks, totals[moverToArray] != nil)
+	return result, nil
+}
+
+// moverAction returns what the mover does with a file of a share configured
+// as cfg. pool is the pool the file is on, or empty for an array disk.
+func moverAction(cfg *dto.ShareConfig, pool string, info fs.FileInfo, now time.Time) string {
+	if pool == "" {
+		switch cfg.UseCache {
+		case "prefer":
+			return moverToPool
+		case "only":
+			return moverStranded
+		}
+		return moverStay
+	}
+
+	if pool != cmp.Or(cfg.CachePool, defaultCachePool) {
+		return moverStranded
+	}
+	switch cfg.UseCache {
+	case "yes":
+		if !moverTuningSelects(cfg.MoverTuning, info, now) {
+			return moverStay
+		}
+		return moverToArray
+	case "no", "":
+		return moverStranded
+	}
+	return moverStay
+}
+
+// moverTuningSelects reports whether a Mover Tuning override lets the mover
+// move a file off the pool. Files younger or smaller than its filters stay.
+func moverTuningSelects(tuning *dto.ShareMoverTuning, info fs.FileInfo, now time.Time) bool {
+	if tuning == nil || !tuning.Override {
+		return true
+	}
+	if tuning.AgeDays != nil && now.Sub(info.ModTime()) < time.Duration(*tuning.AgeDays)*24*time.Hour {
+		return false
+	}
+	if tuning.MinSizeMB != nil && info.Size() < int64(*tuning.MinSizeMB)<<20 {
+		return false
+	}
+	return true
+}
+
+// largestFiles sorts files largest first and keeps at most maxDryRunFiles.
+func largestFiles(files []dto.ShareAffectedFile) []dto.ShareAffectedFile {
+	slices.SortFunc(files, func(a, b dto.ShareAffectedFile) int {
+		return cmp.Or(cmp.Compare(b.SizeBytes, a.SizeBytes), cmp.Compare(a.Path, b.Path))
+	})
+	if len(files) > maxDryRunFiles {
+		files = files[:maxDryRunFiles]
+	}
+	return files
+}
+
+// shareMayUseDisk reports whether new files of a share configured as cfg can
+// be written to disk: its cache pool, or an included, non-excluded data disk.
+func shareMayUseDisk(cfg *dto.ShareConfig, disk dto.DiskInfo) bool {
+	name := filepath.Base(disk.MountPoint)
+	if isPoolRole(disk.Role) {
+		return cfg.UseCache != "no" && name == cmp.Or(cfg.CachePool, defaultCachePool)
+	}
+	if disk.Role != "data" || cfg.UseCache == "only" || slices.Contains(cfg.ExcludeDisks, name) {
+		return false
+	}
+	return len(cfg.IncludeDisks) == 0 || slices.Contains(cfg.IncludeDisks, name)
+}
+
+// shareConfigWarnings flags settings that will not behave as expected: a
+// cache pool that does not exist, or a Mover Tuning threshold the pool has
+// not reached, which holds back the files the dry run expects to move.
+func shareConfigWarnings(cfg *dto.ShareConfig, disks []dto.DiskInfo, movesToArray bool) []string {
+	if cfg.UseCache == "no" || cfg.UseCache == "" {
+		return nil
+	}
+	poolName := cmp.Or(cfg.CachePool, defaultCachePool)
+	idx := slices.IndexFunc(disks, func(d dto.DiskInfo) bool {
+		return isPoolRole(d.Role) && d.MountPoint != "" && filepath.Base(d.MountPoint) == poolName
+	})
+	if idx < 0 {
+		return []string{fmt.Sprintf("pool %q is not mounted", poolName)}
+	}
+
+	var warnings []string
+	if t := cfg.MoverTuning; movesToArray && t != nil && t.Override && t.ThresholdPercent != nil &&
+		disks[idx].UsagePercent < float64(*t.ThresholdPercent) {
+		warnings = append(warnings, fmt.Sprintf(
+			"pool %s is %.0f%% used, below the mover tuning threshold of %d%%; files are not moved until it is reached",
+			poolName, disks[idx].UsagePercent, *t.ThresholdPercent))
+	}
+	return warnings
+}
+
+// shareConfigChanges lists the settings that differ between from and to.
+func shareConfigChanges(from, to *dto.ShareConfig) []dto.ShareConfigChange {
+	changes := []dto.ShareConfigChange{}
+	add := func(field, a, b string) {
+		if a != b {
+			changes = append(changes, dto.ShareConfigChange{Field: field, From: a, To: b})
+		}
+	}
+	add("comment", from.Comment, to.Comment)
+	add("allocator", from.Allocator, to.Allocator)
+	add("floor", from.Floor, to.Floor)
+	add("split_level", from.SplitLevel, to.SplitLevel)
+	add("include_disks", strings.Join(from.IncludeDisks, ","), strings.Join(to.IncludeDisks, ","))
+	add("exclude_disks", strings.Join(from.ExcludeDisks, ","), strings.Join(to.ExcludeDisks, ","))
+	add("use_cache", from.UseCache, to.UseCache)
+	add("cache_pool", from.CachePool, to.CachePool)
+	add("export", from.Export, to.Export)
+	add("security", from.Security, to.Security)
+
+	var a, b dto.ShareMoverTuning
+	if from.MoverTuning != nil {
+		a = *from.MoverTuning
+	}
+	if to.MoverTuning != nil {
+		b = *to.MoverTuning
+	}
+	add("mover_tuning.override", strconv.FormatBool(a.Override), strconv.FormatBool(b.Override))
+	add("mover_tuning.threshold_percent", optInt(a.ThresholdPercent), optInt(b.ThresholdPercent))
+	add("mover_tuning.age_days", optInt(a.AgeDays), optInt(b.AgeDays))
+	add("mover_tuning.min_size_mb", optInt(a.MinSizeMB), optInt(b.MinSizeMB))
+	return changes
+}
+
+func optInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func isPoolRole(role string) bool {
+	return role == "cache" || role == "pool"
+}
@@ -0,0 +1,173 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestShareConfigDryRunPreferMovesArrayFilesToPool(t *testing.T) {
+	root := t.TempDir()
+	disk1, disk2, cache := filepath.Join(root, "disk1"), filepath.Join(root, "disk2"), filepath.Join(root, "cache")
+	writeShareFile(t, filepath.Join(disk1, "appdata", "big.db"), 4096)
+	writeShareFile(t, filepath.Join(disk1, "appdata", "small.db"), 16)
+	writeShareFile(t, filepath.Join(disk2, "appdata", "cold.db"), 32)
+	writeShareFile(t, filepath.Join(cache, "appdata", "hot.db"), 64)
+	disks := []dto.DiskInfo{
+		{ID: "disk1", Role: "data", MountPoint: disk1, SpinState: "active"},
+		{ID: "disk2", Role: "data", MountPoint: disk2, SpinState: "standby"},
+		{ID: "cache", Role: "cache", MountPoint: cache, SpinState: "active"},
+		{ID: "parity", Role: "parity", SpinState: "active"},
+	}
+	current := &dto.ShareConfig{Name: "appdata", UseCache: "yes", CachePool: "cache"}
+
+	got, err := ShareConfigDryRun(context.Background(), current, &dto.ShareConfig{UseCache: "prefer"}, disks, false, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Changes) != 1 || got.Changes[0] != (dto.ShareConfigChange{Field: "use_cache", From: "yes", To: "prefer"}) {
+		t.Errorf("Changes = %+v, want only use_cache yes -> prefer", got.Changes)
+	}
+	want := []dto.ShareMoveSummary{
+		{Action: moverToPool, Files: 2, Bytes: 4096 + 16},
+		{Action: moverStay, Files: 1, Bytes: 64},
+	}
+	if !slices.Equal(got.Moves, want) {
+		t.Errorf("Moves = %+v, want %+v", got.Moves, want)
+	}
+	if len(got.Files) != 3 || filepath.Base(got.Files[0].Path) != "big.db" || got.Files[0].Action != moverToPool {
+		t.Errorf("Files = %+v, want big.db first, moving to the pool", got.Files)
+	}
+	if !slices.Equal(got.SkippedDisks, []string{"disk2"}) {
+		t.Errorf("SkippedDisks = %v, want [disk2]", got.SkippedDisks)
+	}
+}
+
+func TestShareConfigDryRunMoverTuningFilters(t *testing.T) {
+	root := t.TempDir()
+	cache := filepath.Join(root, "cache")
+	now := time.Now()
+	writeShareFile(t, filepath.Join(cache, "media", "new.mkv"), 2<<20)
+	writeShareFile(t, filepath.Join(cache, "media", "old.mkv"), 2<<20)
+	writeShareFile(t, filepath.Join(cache, "media", "old.nfo"), 10)
+	old := now.Add(-60 * 24 * time.Hour)
+	for _, name := range []string{"old.mkv", "old.nfo"} {
+		if err := os.Chtimes(filepath.Join(cache, "media", name), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	disks := []dto.DiskInfo{{ID: "cache", Role: "cache", MountPoint: cache, UsagePercent: 40}}
+	current := &dto.ShareConfig{Name: "media", UseCache: "prefer"}
+	age, size, threshold := 30, 1, 75
+	update := &dto.ShareConfig{
+		UseCache:    "yes",
+		MoverTuning: &dto.ShareMoverTuning{Override: true, ThresholdPercent: &threshold, AgeDays: &age, MinSizeMB: &size},
+	}
+
+	got, err := ShareConfigDryRun(context.Background(), current, update, disks, false, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Files) != 1 || filepath.Base(got.Files[0].Path) != "old.mkv" || got.Files[0].Action != moverToArray {
+		t.Errorf("Files = %+v, want only old.mkv moving to the array", got.Files)
+	}
+	if len(got.Warnings) != 1 {
+		t.Errorf("Warnings = %v, want the pool below the mover tuning threshold", got.Warnings)
+	}
+}
+
+func TestShareConfigDryRunFloorAndStrandedFiles(t *testing.T) {
+	root := t.TempDir()
+	disk1, cache, nvme := filepath.Join(root, "disk1"), filepath.Join(root, "cache"), filepath.Join(root, "nvme")
+	writeShareFile(t, filepath.Join(cache, "isos", "a.iso"), 128)
+	if err := os.MkdirAll(nvme, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	disks := []dto.DiskInfo{
+		{ID: "disk1", Role: "data", MountPoint: disk1, Free: 10 << 30},
+		{ID: "cache", Role: "cache", MountPoint: cache, Free: 1 << 30},
+		{ID: "nvme", Role: "pool", MountPoint: nvme, Free: 100 << 30},
+	}
+	current := &dto.ShareConfig{Name: "isos", UseCache: "only", CachePool: "cache", Floor: "0"}
+
+	got, err := ShareConfigDryRun(context.Background(), current, &dto.ShareConfig{CachePool: "nvme", Floor: "20G"}, disks, false, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Moves) != 1 || got.Moves[0].Action != moverStranded || got.Moves[0].Files != 1 {
+		t.Errorf("Moves = %+v, want one file stranded on the old pool", got.Moves)
+	}
+	// Only the new pool takes new files of a cache-only share.
+	if len(got.DisksBelowFloor) != 0 {
+		t.Errorf("DisksBelowFloor = %v, want none", got.DisksBelowFloor)
+	}
+
+	got, err = ShareConfigDryRun(context.Background(), current, &dto.ShareConfig{UseCache: "yes", Floor: "5G"}, disks, false, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.DisksBelowFloor, []string{"cache"}) {
+		t.Errorf("DisksBelowFloor = %v, want [cache]", got.DisksBelowFloor)
+	}
+}
+
+func TestUpdateShareConfigMergesExistingKeys(t *testing.T) {
+	dir := t.TempDir()
+	oldShareDir, oldTuningDir := shareCfgDir, moverTuningDir
+	shareCfgDir, moverTuningDir = filepath.Join(dir, "shares"), filepath.Join(dir, "ca.mover.tuning")
+	t.Cleanup(func() { shareCfgDir, moverTuningDir = oldShareDir, oldTuningDir })
+
+	if err := os.MkdirAll(shareCfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	original := "shareComment=\"Movies\"\nshareUseCache=\"yes\"\nshareCOW=\"auto\"\n"
+	if err := os.WriteFile(filepath.Join(shareCfgDir, "media.cfg"), []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewConfigCollector()
+
+	threshold := 80
+	update := &dto.ShareConfig{Name: "media", UseCache: "prefer", CachePool: "nvme",
+		MoverTuning: &dto.ShareMoverTuning{Override: true, ThresholdPercent: &threshold}}
+	if err := c.UpdateShareConfig(update); err == nil {
+		t.Fatal("expected an error when the mover tuning plugin is not installed")
+	}
+
+	if err := os.MkdirAll(moverTuningDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateShareConfig(update); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(shareCfgDir, "media.cfg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "shareComment=\"Movies\"\nshareUseCache=\"prefer\"\nshareCOW=\"auto\"\nshareCachePool=\"nvme\"\n"
+	if string(data) != want {
+		t.Errorf("media.cfg = %q, want %q", data, want)
+	}
+	if backup, _ := os.ReadFile(filepath.Join(shareCfgDir, "media.cfg.bak")); string(backup) != original {
+		t.Errorf("media.cfg.bak = %q, want the original file", backup)
+	}
+
+	got, err := c.GetShareConfig("media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.UseCache != "prefer" || got.CachePool != "nvme" {
+		t.Errorf("GetShareConfig() = %+v, want use_cache prefer on nvme", got)
+	}
+	if got.MoverTuning == nil || !got.MoverTuning.Override || got.MoverTuning.ThresholdPercent == nil || *got.MoverTuning.ThresholdPercent != 80 {
+		t.Errorf("MoverTuning = %+v, want override with threshold 80", got.MoverTuning)
+	}
+}
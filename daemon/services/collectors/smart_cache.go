@@ -0,0 +1,126 @@
+package collectors
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// How often smartctl is run per device. SMART health changes slowly, so
+// spinning disks are not polled on every disk collection, and disks that
+// Unraid reports as spun down are only checked (without waking them) on a
+// much longer schedule.
+const (
+	smartActiveRefresh  = 5 * time.Minute
+	smartStandbyRefresh = 30 * time.Minute
+)
+
+// smartReading is the SMART data of one successful smartctl run.
+type smartReading struct {
+	status            string
+	attributes        map[string]dto.SMARTAttribute
+	powerOnHours      uint64
+	powerCycleCount   uint64
+	wearPercent       *int
+	totalBytesWritten uint64
+	temperature       float64 // 0 when smartctl reported none
+	collectedAt       time.Time
+}
+
+// apply copies the reading onto disk. A nil reading leaves disk unchanged.
+func (r *smartReading) apply(disk *dto.DiskInfo) {
+	if r == nil {
+		return
+	}
+	disk.SMARTStatus = r.status
+	disk.SMARTAttributes = r.attributes
+	disk.PowerOnHours = r.powerOnHours
+	disk.PowerCycleCount = r.powerCycleCount
+	disk.WearPercent = r.wearPercent
+	disk.TotalBytesWritten = r.totalBytesWritten
+	collectedAt := r.collectedAt
+	disk.SMARTCollectedAt = &collectedAt
+}
+
+// smartCacheEntry tracks the SMART data of one device between collections.
+type smartCacheEntry struct {
+	reading   *smartReading // last successful reading; nil if none yet
+	checkedAt time.Time     // last smartctl run, successful or not
+	standby   bool          // whether the disk looked spun down at checkedAt
+}
+
+// due reports whether smartctl should run again for a disk that now looks
+// spun down (standby) or spinning.
+func (e *smartCacheEntry) due(standby bool, now time.Time) bool {
+	if e.standby && !standby {
+		return true // spun up since the last check
+	}
+	refresh := smartActiveRefresh
+	if standby {
+		refresh = smartStandbyRefresh
+	}
+	return now.Sub(e.checkedAt) >= refresh
+}
+
+// cachedSMART returns the device's last reading (possibly nil) and true
+// when smartctl does not need to run this cycle.
+func (c *DiskCollector) cachedSMART(device string, standby bool, now time.Time) (*smartReading, bool) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	entry, ok := c.smart[device]
+	if !ok || entry.due(standby, now) {
+		return nil, false
+	}
+	return entry.reading, true
+}
+
+// storeSMART records a smartctl run for the device and returns the latest
+// successful reading, which is the previous one when reading is nil.
+func (c *DiskCollector) storeSMART(device string, reading *smartReading, standby bool, now time.Time) *smartReading {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	if c.smart == nil {
+		c.smart = make(map[string]*smartCacheEntry)
+	}
+	entry, ok := c.smart[device]
+	if !ok {
+		entry = &smartCacheEntry{}
+		c.smart[device] = entry
+	}
+	entry.checkedAt = now
+	entry.standby = standby
+	if reading != nil {
+		entry.reading = reading
+	}
+	return entry.reading
+}
+
+// parseSMARTTemperature returns the drive temperature from smartctl -A
+// output: ATA attribute 194 (or 190), or the NVMe "Temperature:" line.
+// It returns 0 when none is present.
+func parseSMARTTemperature(lines []string, attrs map[string]dto.SMARTAttribute) float64 {
+	for _, id := range []string{"194", "190"} {
+		if a, ok := attrs[id]; ok {
+			if fields := strings.Fields(a.RawValue); len(fields) > 0 {
+				if t, err := strconv.ParseFloat(fields[0], 64); err == nil && t > 0 {
+					return t
+				}
+			}
+		}
+	}
+	for _, line := range lines {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok || key != "Temperature" {
+			continue
+		}
+		// Temperature:                        38 Celsius
+		if fields := strings.Fields(value); len(fields) > 0 {
+			if t, err := strconv.ParseFloat(fields[0], 64); err == nil && t > 0 {
+				return t
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestSMARTCacheSchedule(t *testing.T) {
+	c := &DiskCollector{}
+	now := time.Now()
+
+	if _, ok := c.cachedSMART("sdb", false, now); ok {
+		t.Fatal("uncached device should be read")
+	}
+	first := &smartReading{status: "PASSED", collectedAt: now}
+	c.storeSMART("sdb", first, false, now)
+
+	if r, ok := c.cachedSMART("sdb", false, now.Add(time.Minute)); !ok || r != first {
+		t.Error("spinning disk re-read before smartActiveRefresh")
+	}
+	if _, ok := c.cachedSMART("sdb", false, now.Add(smartActiveRefresh)); ok {
+		t.Error("spinning disk not re-read after smartActiveRefresh")
+	}
+	if _, ok := c.cachedSMART("sdb", true, now.Add(smartActiveRefresh)); !ok {
+		t.Error("spun-down disk re-read before smartStandbyRefresh")
+	}
+
+	// A failed run in standby keeps the last reading
+	if got := c.storeSMART("sdb", nil, true, now); got != first {
+		t.Errorf("storeSMART(nil) = %v, want previous reading", got)
+	}
+	if _, ok := c.cachedSMART("sdb", true, now.Add(smartStandbyRefresh)); ok {
+		t.Error("spun-down disk not re-checked after smartStandbyRefresh")
+	}
+	// Spinning up forces a fresh reading
+	if _, ok := c.cachedSMART("sdb", false, now.Add(time.Second)); ok {
+		t.Error("disk that spun up not re-read")
+	}
+}
+
+func TestSMARTReadingApply(t *testing.T) {
+	collected := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	disk := dto.DiskInfo{SMARTStatus: "UNKNOWN"}
+	(*smartReading)(nil).apply(&disk)
+	if disk.SMARTStatus != "UNKNOWN" || disk.SMARTCollectedAt != nil {
+		t.Errorf("nil reading changed disk: %+v", disk)
+	}
+	(&smartReading{status: "PASSED", powerOnHours: 100, collectedAt: collected}).apply(&disk)
+	if disk.SMARTStatus != "PASSED" || disk.PowerOnHours != 100 || !disk.SMARTCollectedAt.Equal(collected) {
+		t.Errorf("apply: %+v", disk)
+	}
+}
+
+func TestParseSMARTTemperature(t *testing.T) {
+	attrs := map[string]dto.SMARTAttribute{
+		"190": {RawValue: "36"},
+		"194": {RawValue: "35 (Min/Max 20/45)"},
+	}
+	if got := parseSMARTTemperature(nil, attrs); got != 35 {
+		t.Errorf("ATA: %v, want 35", got)
+	}
+	lines := []string{"Critical Warning:                   0x00", "Temperature:                        38 Celsius"}
+	if got := parseSMARTTemperature(lines, nil); got != 38 {
+		t.Errorf("NVMe: %v, want 38", got)
+	}
+	if got := parseSMARTTemperature([]string{"Temperature Sensor 1:               40 Celsius"}, nil); got != 0 {
+		t.Errorf("sensor line: %v, want 0", got)
+	}
+}
@@ -0,0 +1,371 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// speedtestStartupStagger delays the first test well past boot, when the
+	// array, containers, and VMs are still starting and would skew the result.
+	speedtestStartupStagger = 5 * time.Minute
+
+	// speedtestTimeout bounds a whole test run (download, upload, and ping).
+	speedtestTimeout = 3 * time.Minute
+
+	// iperf3Seconds is the transmit time of each iperf3 direction.
+	iperf3Seconds = 10
+
+	// speedtestHistorySize is the number of results kept; at the default
+	// 6-hour interval this covers 12 days.
+	speedtestHistorySize = 48
+
+	// speedtestHistoryFile holds the persisted result history. Results are
+	// written once per test, so flash wear is negligible.
+	speedtestHistoryFile = "/boot/config/plugins/unraid-management-agent/speedtest_history.json"
+)
+
+// SpeedtestCollector runs scheduled bandwidth tests with speedtest-cli (against
+// Speedtest.net servers) or iperf3 (against a configured server) and keeps a
+// history of the results.
+type SpeedtestCollector struct {
+	appCtx *domain.Context
+	tool   string
+	server string
+
+	// RunFn performs one test. Tests may replace it.
+	RunFn func(ctx context.Context) dto.SpeedtestResult
+	// HistoryPath is where the result history is persisted; empty disables
+	// persistence.
+	HistoryPath string
+
+	mu      sync.Mutex
+	history []dto.SpeedtestResult
+	loaded  bool
+}
+
+// NewSpeedtestCollector creates a new speedtest collector. The tool and server
+// come from the context (SPEEDTEST_TOOL, SPEEDTEST_SERVER); speedtest-cli is used
+// when no tool is configured.
+func NewSpeedtestCollector(ctx *domain.Context) *SpeedtestCollector {
+	tool := ctx.SpeedtestTool
+	if tool == "" {
+		tool = dto.SpeedtestToolSpeedtestCLI
+	}
+	c := &SpeedtestCollector{
+		appCtx:      ctx,
+		tool:        tool,
+		server:      ctx.SpeedtestServer,
+		HistoryPath: speedtestHistoryFile,
+	}
+	c.RunFn = c.runTest
+	return c
+}
+
+// Start begins the scheduled bandwidth tests after a startup stagger.
+func (c *SpeedtestCollector) Start(ctx context.Context, interval time.Duration) {
+	logger.InfoContext(ctx, "Starting speedtest collector (interval: %v, tool: %s)", interval, c.tool)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(speedtestStartupStagger):
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "Speedtest collector", r)
+			}
+		}()
+		c.Collect(ctx)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Speedtest collector stopping due to context cancellation")
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						logger.LogPanicWithStackContext(ctx, "Speedtest collector", r)
+					}
+				}()
+				c.Collect(ctx)
+			}()
+		}
+	}
+}
+
+// Collect runs one bandwidth test, appends it to the history, and publishes
+// the updated status.
+func (c *SpeedtestCollector) Collect(ctx context.Context) {
+	c.loadHistory()
+
+	result := c.RunFn(ctx)
+	if result.Success {
+		logger.Info("Speedtest: %.1f Mbps down, %.1f Mbps up, %.1f ms ping (%s)",
+			result.DownloadMbps, result.UploadMbps, result.PingMs, result.Server)
+	} else {
+		logger.Warning("Speedtest: %s test failed: %s", result.Tool, result.Error)
+	}
+
+	status := c.record(result)
+	domain.Publish(c.appCtx.Hub, constants.TopicSpeedtestUpdate, status)
+	c.saveHistory(status.History)
+}
+
+// record appends result to the bounded history and returns the new status.
+func (c *SpeedtestCollector) record(result dto.SpeedtestResult) *dto.SpeedtestStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.history = append(c.history, result)
+	if len(c.history) > speedtestHistorySize {
+		c.history = c.history[len(c.history)-speedtestHistorySize:]
+	}
+
+	history := make([]dto.SpeedtestResult, len(c.history))
+	copy(history, c.history)
+	latest := history[len(history)-1]
+	return &dto.SpeedtestStatus{
+		Latest:    &latest,
+		History:   history,
+		Timestamp: time.Now(),
+	}
+}
+
+// loadHistory reads the persisted history once, before the first test.
+func (c *SpeedtestCollector) loadHistory() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded || c.HistoryPath == "" {
+		return
+	}
+	c.loaded = true
+
+	data, err := os.ReadFile(c.HistoryPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warning("Speedtest: failed to read history: %v", err)
+		}
+		return
+	}
+	var history []dto.SpeedtestResult
+	if err := json.Unmarshal(data, &history); err != nil {
+		logger.Warning("Speedtest: ignoring corrupt history file %s: %v", c.HistoryPath, err)
+		return
+	}
+	if len(history) > speedtestHistorySize {
+		history = history[len(history)-speedtestHistorySize:]
+	}
+	c.history = history
+}
+
+// saveHistory persists history via a temp file and rename.
+func (c *SpeedtestCollector) saveHistory(history []dto.SpeedtestResult) {
+	if c.HistoryPath == "" {
+		return
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		logger.Warning("Speedtest: failed to encode history: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.HistoryPath), 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		logger.Warning("Speedtest: failed to create history directory: %v", err)
+		return
+	}
+	tmp := c.HistoryPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		logger.Warning("Speedtest: failed to write history: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, c.HistoryPath); err != nil {
+		logger.Warning("Speedtest: failed to save history: %v", err)
+	}
+}
+
+// runTest runs the configured tool and returns its result. Failures are
+// reported in the result rather than as an error so they appear in the history.
+func (c *SpeedtestCollector) runTest(ctx context.Context) dto.SpeedtestResult {
+	ctx, cancel := context.WithTimeout(ctx, speedtestTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var result dto.SpeedtestResult
+	var err error
+	switch c.tool {
+	case dto.SpeedtestToolSpeedtestCLI:
+		result, err = runSpeedtestCLI(ctx, c.server)
+	case dto.SpeedtestToolIperf3:
+		result, err = runIperf3(ctx, c.server)
+	default:
+		err = fmt.Errorf("unknown speedtest tool %q (use %s or %s)",
+			c.tool, dto.SpeedtestToolSpeedtestCLI, dto.SpeedtestToolIperf3)
+	}
+
+	result.Tool = c.tool
+	result.Timestamp = start
+	result.DurationSeconds = time.Since(start).Seconds()
+	result.Success = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// runSpeedtestCLI runs speedtest-cli against server (a Speedtest.net server
+// ID), or the closest server when server is empty.
+func runSpeedtestCLI(ctx context.Context, server string) (dto.SpeedtestResult, error) {
+	if !lib.CommandExists(dto.SpeedtestToolSpeedtestCLI) {
+		return dto.SpeedtestResult{}, errors.New("speedtest-cli is not installed")
+	}
+	args := []string{"--json", "--secure"}
+	if server != "" {
+		if id, err := strconv.Atoi(server); err != nil || id <= 0 {
+			return dto.SpeedtestResult{}, fmt.Errorf("invalid speedtest-cli server ID %q", server)
+		}
+		args = append(args, "--server", server)
+	}
+
+	output, err := lib.ExecCommandStdoutWithContext(ctx, dto.SpeedtestToolSpeedtestCLI, args...)
+	if err != nil {
+		return dto.SpeedtestResult{}, commandError(err)
+	}
+	return parseSpeedtestCLIOutput(output)
+}
+
+// speedtestCLIOutput is the subset of `speedtest-cli --json` output we use.
+// Throughput is reported in bits per second.
+type speedtestCLIOutput struct {
+	Download float64 `json:"download"`
+	Upload   float64 `json:"upload"`
+	Ping     float64 `json:"ping"`
+	Server   struct {
+		Name    string `json:"name"`
+		Sponsor string `json:"sponsor"`
+	} `json:"server"`
+}
+
+// parseSpeedtestCLIOutput converts speedtest-cli JSON output to a result.
+func parseSpeedtestCLIOutput(output string) (dto.SpeedtestResult, error) {
+	var out speedtestCLIOutput
+	if err := json.Unmarshal([]byte(output), &out); err != nil {
+		return dto.SpeedtestResult{}, fmt.Errorf("unable to parse speedtest-cli output: %w", err)
+	}
+	result := dto.SpeedtestResult{
+		DownloadMbps: out.Download / 1e6,
+		UploadMbps:   out.Upload / 1e6,
+		PingMs:       out.Ping,
+	}
+	switch {
+	case out.Server.Sponsor != "" && out.Server.Name != "":
+		result.Server = fmt.Sprintf("%s (%s)", out.Server.Sponsor, out.Server.Name)
+	case out.Server.Sponsor != "":
+		result.Server = out.Server.Sponsor
+	default:
+		result.Server = out.Server.Name
+	}
+	return result, nil
+}
+
+// runIperf3 measures download (reverse mode) and upload throughput to the
+// iperf3 server at host, plus ping latency to it.
+func runIperf3(ctx context.Context, host string) (dto.SpeedtestResult, error) {
+	if host == "" {
+		return dto.SpeedtestResult{}, errors.New("iperf3 requires SPEEDTEST_SERVER to name an iperf3 server")
+	}
+	if err := lib.ValidateHostOrIP(host); err != nil {
+		return dto.SpeedtestResult{}, err
+	}
+	if !lib.CommandExists(dto.SpeedtestToolIperf3) {
+		return dto.SpeedtestResult{}, errors.New("iperf3 is not installed")
+	}
+
+	result := dto.SpeedtestResult{Server: host}
+	var err error
+	if result.DownloadMbps, err = iperf3Throughput(ctx, host, true); err != nil {
+		return result, fmt.Errorf("download: %w", err)
+	}
+	if result.UploadMbps, err = iperf3Throughput(ctx, host, false); err != nil {
+		return result, fmt.Errorf("upload: %w", err)
+	}
+	// iperf3 does not measure latency; reuse the wan collector's ping probe.
+	result.PingMs = pingTarget(ctx, host).LatencyMs
+	return result, nil
+}
+
+// iperf3Throughput runs one iperf3 direction and returns the receiver-side
+// throughput in Mbps. reverse makes the server send, measuring download.
+func iperf3Throughput(ctx context.Context, host string, reverse bool) (float64, error) {
+	args := []string{"-c", host, "-J", "-t", strconv.Itoa(iperf3Seconds)}
+	if reverse {
+		args = append(args, "-R")
+	}
+	// iperf3 -J reports failures in the JSON "error" field and exits non-zero,
+	// so parse the output before looking at the exit status.
+	output, err := lib.ExecCommandStdoutWithContext(ctx, dto.SpeedtestToolIperf3, args...)
+	mbps, parseErr := parseIperf3Output(output)
+	if parseErr != nil && err != nil {
+		return 0, commandError(err)
+	}
+	return mbps, parseErr
+}
+
+// iperf3Output is the subset of `iperf3 -J` output we use.
+type iperf3Output struct {
+	End struct {
+		SumReceived struct {
+			BitsPerSecond float64 `json:"bits_per_second"`
+		} `json:"sum_received"`
+	} `json:"end"`
+	Error string `json:"error"`
+}
+
+// parseIperf3Output returns the receiver-side throughput in Mbps from iperf3
+// JSON output.
+func parseIperf3Output(output string) (float64, error) {
+	var out iperf3Output
+	if err := json.Unmarshal([]byte(output), &out); err != nil {
+		return 0, fmt.Errorf("unable to parse iperf3 output: %w", err)
+	}
+	if out.Error != "" {
+		return 0, errors.New(out.Error)
+	}
+	return out.End.SumReceived.BitsPerSecond / 1e6, nil
+}
+
+// commandError prefers the last line a failed command wrote to stderr over
+// the bare exit status.
+func commandError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		lines := strings.Split(strings.TrimSpace(string(exitErr.Stderr)), "\n")
+		if msg := strings.TrimSpace(lines[len(lines)-1]); msg != "" {
+			return errors.New(msg)
+		}
+	}
+	return err
+}
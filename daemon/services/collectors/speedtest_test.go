@@ -0,0 +1,187 @@
+package collectors
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParseSpeedtestCLIOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		wantErr    bool
+		wantDown   float64
+		wantUp     float64
+		wantPing   float64
+		wantServer string
+	}{
+		{
+			name: "full result",
+			output: `{"download": 938400000.5, "upload": 47900000.0, "ping": 8.2,
+				"server": {"name": "Amsterdam", "sponsor": "Example ISP", "id": "12345"},
+				"bytes_sent": 60817408, "bytes_received": 1174552216}`,
+			wantDown: 938.4000005, wantUp: 47.9, wantPing: 8.2, wantServer: "Example ISP (Amsterdam)",
+		},
+		{
+			name:     "server without sponsor",
+			output:   `{"download": 1e8, "upload": 2e7, "ping": 15, "server": {"name": "Cape Town"}}`,
+			wantDown: 100, wantUp: 20, wantPing: 15, wantServer: "Cape Town",
+		},
+		{
+			name:    "not json",
+			output:  "Cannot retrieve speedtest configuration",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSpeedtestCLIOutput(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.DownloadMbps != tt.wantDown || got.UploadMbps != tt.wantUp || got.PingMs != tt.wantPing {
+				t.Errorf("got %.7f/%.7f/%.1f, want %.7f/%.7f/%.1f",
+					got.DownloadMbps, got.UploadMbps, got.PingMs, tt.wantDown, tt.wantUp, tt.wantPing)
+			}
+			if got.Server != tt.wantServer {
+				t.Errorf("server = %q, want %q", got.Server, tt.wantServer)
+			}
+		})
+	}
+}
+
+func TestParseIperf3Output(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		wantMbps float64
+		wantErr  string
+	}{
+		{
+			name:     "success",
+			output:   `{"start": {}, "end": {"sum_sent": {"bits_per_second": 9.5e8}, "sum_received": {"bits_per_second": 9.4e8}}}`,
+			wantMbps: 940,
+		},
+		{
+			name:    "server unreachable",
+			output:  `{"start": {}, "intervals": [], "end": {}, "error": "unable to connect to server: Connection refused"}`,
+			wantErr: "Connection refused",
+		},
+		{
+			name:    "empty output",
+			output:  "",
+			wantErr: "unable to parse iperf3 output",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIperf3Output(tt.output)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.wantMbps {
+				t.Errorf("mbps = %v, want %v", got, tt.wantMbps)
+			}
+		})
+	}
+}
+
+func TestSpeedtestRunTestReportsConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    string
+		server  string
+		wantErr string
+	}{
+		{"unknown tool", "fast-cli", "", "unknown speedtest tool"},
+		{"iperf3 without server", dto.SpeedtestToolIperf3, "", "requires SPEEDTEST_SERVER"},
+		{"iperf3 flag injection", dto.SpeedtestToolIperf3, "-R", "must not start with a hyphen"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSpeedtestCollector(&domain.Context{
+				Hub: domain.NewEventBus(4), SpeedtestTool: tt.tool, SpeedtestServer: tt.server,
+			})
+			got := c.runTest(context.Background())
+			if got.Success || !strings.Contains(got.Error, tt.wantErr) {
+				t.Errorf("got success=%v error=%q, want failure containing %q", got.Success, got.Error, tt.wantErr)
+			}
+			if got.Tool != tt.tool || got.Timestamp.IsZero() {
+				t.Errorf("tool = %q, timestamp = %v", got.Tool, got.Timestamp)
+			}
+		})
+	}
+}
+
+func TestNewSpeedtestCollectorDefaultTool(t *testing.T) {
+	c := NewSpeedtestCollector(&domain.Context{Hub: domain.NewEventBus(4)})
+	if c.tool != dto.SpeedtestToolSpeedtestCLI {
+		t.Errorf("tool = %q, want %q", c.tool, dto.SpeedtestToolSpeedtestCLI)
+	}
+}
+
+func TestSpeedtestCollectorHistory(t *testing.T) {
+	hub := domain.NewEventBus(4)
+	ch := hub.SubTopics(constants.TopicSpeedtestUpdate)
+	path := filepath.Join(t.TempDir(), "speedtest_history.json")
+
+	run := 0
+	newCollector := func() *SpeedtestCollector {
+		c := NewSpeedtestCollector(&domain.Context{Hub: hub})
+		c.HistoryPath = path
+		c.RunFn = func(context.Context) dto.SpeedtestResult {
+			run++
+			return dto.SpeedtestResult{
+				Tool: dto.SpeedtestToolSpeedtestCLI, Success: true,
+				DownloadMbps: float64(run), Timestamp: time.Now(),
+			}
+		}
+		return c
+	}
+
+	c := newCollector()
+	c.Collect(context.Background())
+	select {
+	case msg := <-ch:
+		status, ok := msg.(*dto.SpeedtestStatus)
+		if !ok || status.Latest == nil || status.Latest.DownloadMbps != 1 || len(status.History) != 1 {
+			t.Fatalf("unexpected status: %#v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no speedtest status published")
+	}
+
+	// A new collector (agent restart) continues the persisted history, which
+	// stays bounded.
+	c = newCollector()
+	for range speedtestHistorySize + 5 {
+		c.Collect(context.Background())
+		<-ch
+	}
+	status := c.record(dto.SpeedtestResult{Error: "timeout"})
+	if len(status.History) != speedtestHistorySize {
+		t.Fatalf("history length = %d, want %d", len(status.History), speedtestHistorySize)
+	}
+	if status.Latest.Success || status.Latest.Error != "timeout" {
+		t.Errorf("latest = %+v, want the failed run", status.Latest)
+	}
+	if first := status.History[0].DownloadMbps; first != float64(run-speedtestHistorySize+2) {
+		t.Errorf("oldest retained run = %v, want %v", first, run-speedtestHistorySize+2)
+	}
+}
@@ -33,13 +33,13 @@ func NewSystemCollector(ctx *domain.Context) *SystemCollector {
 // Start begins the system collector's periodic data collection.
 // It runs in a goroutine and publishes system information updates at the specified interval until the context is cancelled.
 func (c *SystemCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting system collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting system collector (interval: %v)", interval)
 
 	// Run once immediately with panic recovery
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("System collector", r)
+				logger.LogPanicWithStackContext(ctx, "System collector", r)
 			}
 		}()
 		c.Collect()
@@ -51,13 +51,13 @@ func (c *SystemCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("System collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "System collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("System collector", r)
+						logger.LogPanicWithStackContext(ctx, "System collector", r)
 					}
 				}()
 				c.Collect()
@@ -148,6 +148,10 @@ func (c *SystemCollector) collectSystemInfo() (*dto.SystemInfo, error) {
 		info.CPUPerCore = perCoreUsage
 	}
 
+	// Get per-core temperatures and current frequencies
+	info.CPUCoreTemps = lib.ReadCPUCoreTemps()
+	info.CPUCoreFrequencies = lib.ReadCPUCoreFrequencies()
+
 	// Get memory info
 	memUsed, memTotal, memFree, memBuffers, memCached, err := c.getMemoryInfo()
 	if err != nil {
@@ -163,6 +167,7 @@ func (c *SystemCollector) collectSystemInfo() (*dto.SystemInfo, error) {
 		}
 		info.RAMUsedMB = float64(memUsed) / (1024 * 1024)
 		info.RAMTotalMB = float64(memTotal) / (1024 * 1024)
+		info.MemoryBreakdown = c.getMemoryBreakdown(memUsed)
 	}
 
 	// Get swap info
@@ -250,9 +255,10 @@ func (c *SystemCollector) collectSystemInfo() (*dto.SystemInfo, error) {
 	info.ParityCheckSpeed = c.getParityCheckSpeed()
 
 	// Get CPU power consumption from Intel RAPL
-	cpuPower, dramPower := c.getCPUPower()
+	cpuPower, dramPower, packagePower := c.getCPUPower()
 	info.CPUPowerWatts = cpuPower
 	info.DRAMPowerWatts = dramPower
+	info.CPUPackagePowerWatts = packagePower
 
 	// Get CPU power state (scaling governor)
 	info.CPUPowerState = c.getCPUPowerState()
@@ -1023,12 +1029,12 @@ func (c *SystemCollector) getParityCheckSpeed() string {
 
 // getCPUPower reads CPU power consumption from Intel RAPL (Running Average Power Limit).
 // It requires two consecutive readings to calculate power in watts.
-// Returns nil pointers if RAPL is not available or on the first collection cycle.
-func (c *SystemCollector) getCPUPower() (cpuPower *float64, dramPower *float64) {
+// Returns nil if RAPL is not available or on the first collection cycle.
+func (c *SystemCollector) getCPUPower() (cpuPower *float64, dramPower *float64, packagePower []float64) {
 	currRAPL := lib.ReadRAPLEnergy()
 	if currRAPL == nil {
 		c.prevRAPL = nil
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// Calculate power from delta between previous and current readings
@@ -1040,7 +1046,7 @@ func (c *SystemCollector) getCPUPower() (cpuPower *float64, dramPower *float64)
 	if power == nil {
 		// First reading — no delta available yet
 		logger.Debug("RAPL: first reading captured, power will be available on next collection")
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	cpu := power.PackageWatts
@@ -1054,7 +1060,7 @@ func (c *SystemCollector) getCPUPower() (cpuPower *float64, dramPower *float64)
 		dram = &d
 	}
 
-	return &cpu, dram
+	return &cpu, dram, power.PerPackageWatts
 }
 
 // getCPUPowerState reads the current CPU scaling governor configuration.
@@ -0,0 +1,89 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// trimHistoryPath records when each filesystem was last trimmed through the
+// agent, keyed by mount point. It is a variable so tests can redirect it.
+var trimHistoryPath = "/boot/config/plugins/unraid-management-agent/trim_history.json"
+
+// trimHistoryMu serializes read-modify-write cycles of the trim history file.
+var trimHistoryMu sync.Mutex
+
+// RecordTrim remembers that the filesystem mounted at mountPoint was trimmed
+// at the given time.
+func RecordTrim(mountPoint string, at time.Time) error {
+	trimHistoryMu.Lock()
+	defer trimHistoryMu.Unlock()
+
+	history, err := readTrimHistory(trimHistoryPath)
+	if err != nil {
+		return err
+	}
+	history[mountPoint] = at.UTC()
+
+	raw, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal trim history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(trimHistoryPath), 0o755); err != nil {
+		return fmt.Errorf("create trim history directory: %w", err)
+	}
+	tmp := trimHistoryPath + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("write trim history: %w", err)
+	}
+	if err := os.Rename(tmp, trimHistoryPath); err != nil {
+		return fmt.Errorf("rename trim history: %w", err)
+	}
+	return nil
+}
+
+// lastTrims returns the last trim time per mount point. Read failures are
+// logged and yield an empty map.
+func lastTrims() map[string]time.Time {
+	trimHistoryMu.Lock()
+	defer trimHistoryMu.Unlock()
+
+	history, err := readTrimHistory(trimHistoryPath)
+	if err != nil {
+		logger.Debug("Trim: %v", err)
+		return map[string]time.Time{}
+	}
+	return history
+}
+
+// readTrimHistory reads the trim history file; a missing file is empty.
+func readTrimHistory(path string) (map[string]time.Time, error) {
+	history := map[string]time.Time{}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, fmt.Errorf("read trim history: %w", err)
+	}
+	if err := json.Unmarshal(raw, &history); err != nil {
+		return nil, fmt.Errorf("parse trim history: %w", err)
+	}
+	return history, nil
+}
+
+// lastTrimAt returns the last trim of mountPoint from history, or nil.
+func lastTrimAt(history map[string]time.Time, mountPoint string) *time.Time {
+	if mountPoint == "" {
+		return nil
+	}
+	if t, ok := history[mountPoint]; ok {
+		return &t
+	}
+	return nil
+}
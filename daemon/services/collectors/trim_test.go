@@ -0,0 +1,42 @@
+package collectors
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordTrim(t *testing.T) {
+	orig := trimHistoryPath
+	trimHistoryPath = filepath.Join(t.TempDir(), "plugin", "trim_history.json")
+	t.Cleanup(func() { trimHistoryPath = orig })
+
+	if got := lastTrims(); len(got) != 0 {
+		t.Fatalf("missing file: lastTrims() = %v, want empty", got)
+	}
+
+	first := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	for _, rec := range []struct {
+		mount string
+		at    time.Time
+	}{{"/mnt/cache", first}, {"/mnt/fast", first}, {"/mnt/cache", second}} {
+		if err := RecordTrim(rec.mount, rec.at); err != nil {
+			t.Fatalf("RecordTrim(%s): %v", rec.mount, err)
+		}
+	}
+
+	history := lastTrims()
+	if got := lastTrimAt(history, "/mnt/cache"); got == nil || !got.Equal(second) {
+		t.Errorf("/mnt/cache last trim = %v, want %v", got, second)
+	}
+	if got := lastTrimAt(history, "/mnt/fast"); got == nil || !got.Equal(first) {
+		t.Errorf("/mnt/fast last trim = %v, want %v", got, first)
+	}
+	if got := lastTrimAt(history, "/mnt/disk1"); got != nil {
+		t.Errorf("/mnt/disk1 last trim = %v, want nil", got)
+	}
+	if got := lastTrimAt(history, ""); got != nil {
+		t.Errorf("empty mount point last trim = %v, want nil", got)
+	}
+}
@@ -25,12 +25,12 @@ func NewTuningCollector(ctx *domain.Context) *TuningCollector {
 
 // Start begins the tuning collector's periodic data collection.
 func (c *TuningCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting tuning collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting tuning collector (interval: %v)", interval)
 
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Tuning collector", r)
+				logger.LogPanicWithStackContext(ctx, "Tuning collector", r)
 			}
 		}()
 		c.Collect()
@@ -47,7 +47,7 @@ func (c *TuningCollector) Start(ctx context.Context, interval time.Duration) {
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Tuning collector", r)
+						logger.LogPanicWithStackContext(ctx, "Tuning collector", r)
 					}
 				}()
 				c.Collect()
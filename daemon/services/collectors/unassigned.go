@@ -32,17 +32,17 @@ func (c *UnassignedCollector) Start(ctx context.Context, interval time.Duration)
 	// Top-level safety net for startup preamble panics
 	defer func() {
 		if r := recover(); r != nil {
-			logger.LogPanicWithStack("Unassigned collector (top-level)", r)
+			logger.LogPanicWithStackContext(ctx, "Unassigned collector (top-level)", r)
 		}
 	}()
 
-	logger.Info("Starting unassigned devices collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting unassigned devices collector (interval: %v)", interval)
 
 	// Initial collection with panic recovery
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Unassigned collector", r)
+				logger.LogPanicWithStackContext(ctx, "Unassigned collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "Unassigned", interval, c.collect)
@@ -54,13 +54,13 @@ func (c *UnassignedCollector) Start(ctx context.Context, interval time.Duration)
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Stopping unassigned devices collector")
+			logger.InfoContext(ctx, "Stopping unassigned devices collector")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("Unassigned collector", r)
+						logger.LogPanicWithStackContext(ctx, "Unassigned collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "Unassigned", interval, c.collect)
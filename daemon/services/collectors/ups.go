@@ -28,12 +28,12 @@ func NewUPSCollector(ctx *domain.Context) *UPSCollector {
 // Start begins the UPS collector's periodic data collection.
 // It runs in a goroutine and publishes UPS status updates at the specified interval until the context is cancelled.
 func (c *UPSCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting ups collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting ups collector (interval: %v)", interval)
 
 	runCollectSafely := func(phase string) {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("UPS collector ("+phase+")", r)
+				logger.LogPanicWithStackContext(ctx, "UPS collector ("+phase+")", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "UPS", interval, c.Collect)
@@ -47,7 +47,7 @@ func (c *UPSCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("UPS collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "UPS collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
 			runCollectSafely("periodic collection")
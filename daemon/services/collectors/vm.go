@@ -29,6 +29,7 @@ type VMCollector struct {
 	appCtx        *domain.Context
 	cpuStatsMutex sync.RWMutex
 	previousStats map[string]*vmCPUStats // vmName -> previous CPU stats
+	refresh       refreshTrigger
 }
 
 // NewVMCollector creates a new libvirt-based VM collector
@@ -36,22 +37,31 @@ func NewVMCollector(ctx *domain.Context) *VMCollector {
 	return &VMCollector{
 		appCtx:        ctx,
 		previousStats: make(map[string]*vmCPUStats),
+		refresh:       newRefreshTrigger(),
 	}
 }
 
+// RequestRefresh asks the running collector to collect immediately, e.g. after
+// a VM was started or stopped.
+func (c *VMCollector) RequestRefresh() {
+	c.refresh.request()
+}
+
 // Start begins the VM collector's periodic data collection
 func (c *VMCollector) Start(ctx context.Context, interval time.Duration) {
-	logger.Info("Starting VM collector (interval: %v)", interval)
+	logger.InfoContext(ctx, "Starting VM collector (interval: %v)", interval)
 
-	// Run once immediately with panic recovery
-	func() {
+	collect := func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("VM collector", r)
+				logger.LogPanicWithStackContext(ctx, "VM collector", r)
 			}
 		}()
 		c.Collect()
-	}()
+	}
+
+	// Run once immediately with panic recovery
+	collect()
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -59,17 +69,13 @@ func (c *VMCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("VM collector stopping due to context cancellation")
+			logger.InfoContext(ctx, "VM collector stopping due to context cancellation")
 			return
 		case <-ticker.C:
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						logger.LogPanicWithStack("VM collector", r)
-					}
-				}()
-				c.Collect()
-			}()
+			collect()
+		case <-c.refresh:
+			logger.DebugContext(ctx, "VM collector: refresh requested, collecting immediately")
+			collect()
 		}
 	}
 }
@@ -0,0 +1,250 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// wanStartupStagger delays the first collection so it does not pile onto boot.
+	wanStartupStagger = 15 * time.Second
+
+	// wanPingCount is the number of echo requests sent to each probe target.
+	wanPingCount = 4
+
+	// wanPingTimeout bounds the ping run for a single target.
+	wanPingTimeout = 10 * time.Second
+)
+
+var (
+	pingLossRegex = regexp.MustCompile(`([\d.]+)% packet loss`)
+	// Matches both iputils ("rtt min/avg/max/mdev = ...") and busybox
+	// ("round-trip min/avg/max = ...") summaries; the second field is the average.
+	pingRTTRegex = regexp.MustCompile(`= [\d.]+/([\d.]+)/`)
+)
+
+// WANCollector monitors internet connectivity: the public IP address (with
+// change detection for dynamic DNS users) and latency/packet loss to a set of
+// configurable probe targets.
+type WANCollector struct {
+	appCtx *domain.Context
+	probes []string
+
+	// PublicIPFn returns the current public IP, or "" when it cannot be
+	// determined. Tests may replace it.
+	PublicIPFn func() string
+	// PingFn measures latency and packet loss to a single target. Tests may
+	// replace it.
+	PingFn func(ctx context.Context, target string) dto.WANProbeResult
+
+	mu         sync.Mutex
+	lastIP     string
+	previousIP string
+	changedAt  *time.Time
+}
+
+// NewWANCollector creates a new WAN collector. Probe targets come from the
+// context (WAN_PROBES); constants.DefaultWANProbes is used when none are configured.
+func NewWANCollector(ctx *domain.Context) *WANCollector {
+	probes := ctx.WANProbes
+	if len(probes) == 0 {
+		probes = constants.DefaultWANProbes
+	}
+	return &WANCollector{
+		appCtx:     ctx,
+		probes:     probes,
+		PublicIPFn: lookupPublicIP,
+		PingFn:     pingTarget,
+	}
+}
+
+// Start begins the periodic WAN monitoring after a startup stagger.
+func (c *WANCollector) Start(ctx context.Context, interval time.Duration) {
+	logger.InfoContext(ctx, "Starting wan collector (interval: %v, probes: %v)", interval, c.probes)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(wanStartupStagger):
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStackContext(ctx, "WAN collector", r)
+			}
+		}()
+		c.Collect(ctx)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "WAN collector stopping due to context cancellation")
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						logger.LogPanicWithStackContext(ctx, "WAN collector", r)
+					}
+				}()
+				c.Collect(ctx)
+			}()
+		}
+	}
+}
+
+// Collect runs one round of WAN checks and publishes the result. A public IP
+// change is additionally published as a dto.WANIPChange event.
+func (c *WANCollector) Collect(ctx context.Context) {
+	status, change := c.check(ctx)
+	domain.Publish(c.appCtx.Hub, constants.TopicWANStatusUpdate, status)
+
+	if change != nil {
+		logger.Info("WAN: public IP changed from %s to %s", change.OldIP, change.NewIP)
+		domain.Publish(c.appCtx.Hub, constants.TopicWANIPChanged, change)
+	}
+
+	if status.Online {
+		logger.Debug("WAN: online (ip %s, avg %.1fms, loss %.0f%%)",
+			status.PublicIP, status.AvgLatencyMs, status.PacketLossPct)
+	} else {
+		logger.Warning("WAN: offline (no probe target reachable and public IP lookup failed)")
+	}
+}
+
+// check probes every target concurrently, looks up the public IP, and returns
+// the status plus an IP change event when the public IP differs from the last
+// known one.
+func (c *WANCollector) check(ctx context.Context) (*dto.WANStatus, *dto.WANIPChange) {
+	status := &dto.WANStatus{
+		Probes:    make([]dto.WANProbeResult, len(c.probes)),
+		Timestamp: time.Now(),
+	}
+
+	var wg sync.WaitGroup
+	for i, target := range c.probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := lib.ValidateHostOrIP(target); err != nil {
+				status.Probes[i] = dto.WANProbeResult{Target: target, PacketLossPct: 100, Error: err.Error()}
+				return
+			}
+			result := c.PingFn(ctx, target)
+			result.Target = target
+			status.Probes[i] = result
+		}()
+	}
+	publicIP := c.PublicIPFn()
+	wg.Wait()
+
+	reachable := 0
+	var totalLatency, totalLoss float64
+	for _, p := range status.Probes {
+		totalLoss += p.PacketLossPct
+		if p.Reachable {
+			reachable++
+			totalLatency += p.LatencyMs
+		}
+	}
+	if reachable > 0 {
+		status.AvgLatencyMs = totalLatency / float64(reachable)
+	}
+	if len(status.Probes) > 0 {
+		status.PacketLossPct = totalLoss / float64(len(status.Probes))
+	}
+	status.PublicIP = publicIP
+	status.Online = reachable > 0 || publicIP != ""
+
+	change := c.trackPublicIP(publicIP, status.Timestamp)
+
+	c.mu.Lock()
+	status.PreviousPublicIP = c.previousIP
+	status.PublicIPChangedAt = c.changedAt
+	c.mu.Unlock()
+
+	return status, change
+}
+
+// trackPublicIP records ip as the current public IP and returns a change event
+// when it differs from the previously seen address. Failed lookups ("") never
+// count as a change, so a brief outage does not produce spurious events.
+func (c *WANCollector) trackPublicIP(ip string, now time.Time) *dto.WANIPChange {
+	if ip == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	old := c.lastIP
+	c.lastIP = ip
+	if old == "" || old == ip {
+		return nil
+	}
+	c.previousIP = old
+	c.changedAt = &now
+	return &dto.WANIPChange{OldIP: old, NewIP: ip, Timestamp: now}
+}
+
+// pingTarget sends wanPingCount echo requests to target via the ping binary
+// and parses the summary.
+func pingTarget(ctx context.Context, target string) dto.WANProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, wanPingTimeout)
+	defer cancel()
+
+	// ping exits non-zero when no replies arrive, but still prints the summary.
+	output, err := lib.ExecCommandOutputWithContext(ctx, "ping", "-q", "-n",
+		"-c", strconv.Itoa(wanPingCount), "-W", "2", target)
+	result, ok := parsePingOutput(output)
+	if !ok {
+		result = dto.WANProbeResult{PacketLossPct: 100}
+		if err != nil {
+			result.Error = fmt.Sprintf("ping failed: %v", err)
+		} else {
+			result.Error = "unable to parse ping output"
+		}
+	}
+	result.Target = target
+	return result
+}
+
+// parsePingOutput extracts packet loss and average RTT from ping's summary.
+// ok is false when no packet loss line is present.
+func parsePingOutput(output string) (result dto.WANProbeResult, ok bool) {
+	m := pingLossRegex.FindStringSubmatch(output)
+	if m == nil {
+		return result, false
+	}
+	loss, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return result, false
+	}
+	result.PacketLossPct = loss
+	result.Reachable = loss < 100
+
+	if rtt := pingRTTRegex.FindStringSubmatch(output); rtt != nil {
+		if avg, err := strconv.ParseFloat(rtt[1], 64); err == nil {
+			result.LatencyMs = avg
+		}
+	}
+	if !result.Reachable {
+		result.Error = "no echo replies received"
+	}
+	return result, true
+}
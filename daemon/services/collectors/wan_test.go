@@ -0,0 +1,185 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParsePingOutput(t *testing.T) {
+	tests := []struct {
+		name          string
+		output        string
+		wantOK        bool
+		wantReachable bool
+		wantLoss      float64
+		wantLatency   float64
+	}{
+		{
+			name: "iputils all replies",
+			output: "PING 1.1.1.1 (1.1.1.1) 56(84) bytes of data.\n\n--- 1.1.1.1 ping statistics ---\n" +
+				"4 packets transmitted, 4 received, 0% packet loss, time 3004ms\n" +
+				"rtt min/avg/max/mdev = 11.120/11.843/12.501/0.512 ms\n",
+			wantOK: true, wantReachable: true, wantLoss: 0, wantLatency: 11.843,
+		},
+		{
+			name: "iputils partial loss with errors",
+			output: "4 packets transmitted, 3 received, +1 errors, 25% packet loss, time 3005ms\n" +
+				"rtt min/avg/max/mdev = 20.0/25.5/31.0/4.1 ms\n",
+			wantOK: true, wantReachable: true, wantLoss: 25, wantLatency: 25.5,
+		},
+		{
+			name: "busybox",
+			output: "4 packets transmitted, 4 packets received, 0% packet loss\n" +
+				"round-trip min/avg/max = 8.1/9.2/10.3 ms\n",
+			wantOK: true, wantReachable: true, wantLoss: 0, wantLatency: 9.2,
+		},
+		{
+			name:   "no replies",
+			output: "4 packets transmitted, 0 received, 100% packet loss, time 3062ms\n",
+			wantOK: true, wantReachable: false, wantLoss: 100,
+		},
+		{
+			name:   "unknown host",
+			output: "ping: unknown.invalid: Name or service not known\n",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parsePingOutput(tt.output)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Reachable != tt.wantReachable || got.PacketLossPct != tt.wantLoss || got.LatencyMs != tt.wantLatency {
+				t.Errorf("got %+v, want reachable=%v loss=%v latency=%v", got, tt.wantReachable, tt.wantLoss, tt.wantLatency)
+			}
+			if !got.Reachable && got.Error == "" {
+				t.Error("expected error for unreachable target")
+			}
+		})
+	}
+}
+
+func newTestWANCollector(hub *domain.EventBus, probes []string, ip *string) *WANCollector {
+	c := NewWANCollector(&domain.Context{Hub: hub, WANProbes: probes})
+	c.PublicIPFn = func() string { return *ip }
+	c.PingFn = func(_ context.Context, target string) dto.WANProbeResult {
+		switch target {
+		case "1.1.1.1":
+			return dto.WANProbeResult{Reachable: true, LatencyMs: 10}
+		case "8.8.8.8":
+			return dto.WANProbeResult{Reachable: true, LatencyMs: 20, PacketLossPct: 50}
+		default:
+			return dto.WANProbeResult{PacketLossPct: 100, Error: "no echo replies received"}
+		}
+	}
+	return c
+}
+
+func TestNewWANCollectorDefaultProbes(t *testing.T) {
+	c := NewWANCollector(&domain.Context{Hub: domain.NewEventBus(4)})
+	if len(c.probes) != len(constants.DefaultWANProbes) {
+		t.Errorf("probes = %v, want defaults %v", c.probes, constants.DefaultWANProbes)
+	}
+}
+
+func TestWANCollectorCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		probes      []string
+		ip          string
+		wantOnline  bool
+		wantLatency float64
+		wantLoss    float64
+	}{
+		{"all reachable", []string{"1.1.1.1", "8.8.8.8"}, "203.0.113.45", true, 15, 25},
+		{"one target down", []string{"1.1.1.1", "192.0.2.1"}, "203.0.113.45", true, 10, 50},
+		{"probes down but ip lookup works", []string{"192.0.2.1"}, "203.0.113.45", true, 0, 100},
+		{"fully offline", []string{"192.0.2.1"}, "", false, 0, 100},
+		{"invalid target is not pinged", []string{"-c1"}, "", false, 0, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := tt.ip
+			c := newTestWANCollector(domain.NewEventBus(4), tt.probes, &ip)
+			got, change := c.check(context.Background())
+			if change != nil {
+				t.Errorf("unexpected IP change on first run: %+v", change)
+			}
+			if got.Online != tt.wantOnline || got.AvgLatencyMs != tt.wantLatency || got.PacketLossPct != tt.wantLoss {
+				t.Errorf("got online=%v latency=%v loss=%v, want %v/%v/%v",
+					got.Online, got.AvgLatencyMs, got.PacketLossPct, tt.wantOnline, tt.wantLatency, tt.wantLoss)
+			}
+			if got.PublicIP != tt.ip {
+				t.Errorf("public ip = %q, want %q", got.PublicIP, tt.ip)
+			}
+			for i, p := range got.Probes {
+				if p.Target != tt.probes[i] {
+					t.Errorf("probe %d target = %q, want %q", i, p.Target, tt.probes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWANCollectorDetectsIPChange(t *testing.T) {
+	hub := domain.NewEventBus(4)
+	changes := hub.SubTopics(constants.TopicWANIPChanged)
+	ip := "203.0.113.12"
+	c := newTestWANCollector(hub, []string{"1.1.1.1"}, &ip)
+
+	c.Collect(context.Background())
+
+	// A failed lookup must not count as a change.
+	ip = ""
+	if status, change := c.check(context.Background()); change != nil || status.PreviousPublicIP != "" {
+		t.Fatalf("lookup failure treated as change: %+v", change)
+	}
+
+	ip = "203.0.113.45"
+	c.Collect(context.Background())
+
+	select {
+	case msg := <-changes:
+		change, ok := msg.(*dto.WANIPChange)
+		if !ok || change.OldIP != "203.0.113.12" || change.NewIP != "203.0.113.45" {
+			t.Fatalf("unexpected change event: %#v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no IP change event published")
+	}
+
+	status, change := c.check(context.Background())
+	if change != nil {
+		t.Errorf("unchanged IP reported as change: %+v", change)
+	}
+	if status.PreviousPublicIP != "203.0.113.12" || status.PublicIPChangedAt == nil {
+		t.Errorf("previous ip = %q, changed at = %v", status.PreviousPublicIP, status.PublicIPChangedAt)
+	}
+}
+
+func TestWANCollectorCollectPublishesStatus(t *testing.T) {
+	hub := domain.NewEventBus(4)
+	ch := hub.SubTopics(constants.TopicWANStatusUpdate)
+	ip := "203.0.113.45"
+	c := newTestWANCollector(hub, []string{"1.1.1.1"}, &ip)
+
+	c.Collect(context.Background())
+
+	select {
+	case msg := <-ch:
+		if status, ok := msg.(*dto.WANStatus); !ok || !status.Online {
+			t.Fatalf("unexpected message: %#v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no WAN status published")
+	}
+}
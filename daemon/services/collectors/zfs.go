@@ -18,7 +18,8 @@ import (
 
 // ZFSCollector collects ZFS pool, dataset, and ARC statistics
 type ZFSCollector struct {
-	ctx *domain.Context
+	ctx      *domain.Context
+	forecast fillForecast
 }
 
 // NewZFSCollector creates a new ZFS collector
@@ -31,13 +32,13 @@ func (c *ZFSCollector) Start(ctx context.Context, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	logger.Info("ZFS collector started (interval: %v)", interval)
+	logger.InfoContext(ctx, "ZFS collector started (interval: %v)", interval)
 
 	// Collect immediately on start with panic recovery
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("ZFS collector", r)
+				logger.LogPanicWithStackContext(ctx, "ZFS collector", r)
 			}
 		}()
 		collectWithWatchdog(ctx, "ZFS", interval, c.collect)
@@ -46,13 +47,13 @@ func (c *ZFSCollector) Start(ctx context.Context, interval time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("ZFS collector stopped")
+			logger.InfoContext(ctx, "ZFS collector stopped")
 			return
 		case <-ticker.C:
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						logger.LogPanicWithStack("ZFS collector", r)
+						logger.LogPanicWithStackContext(ctx, "ZFS collector", r)
 					}
 				}()
 				collectWithWatchdog(ctx, "ZFS", interval, c.collect)
@@ -80,6 +81,7 @@ func (c *ZFSCollector) collect() {
 	if err != nil {
 		logger.Warning("Failed to collect ZFS pools: %v", err)
 	} else if len(pools) > 0 {
+		c.applyForecast(pools, time.Now())
 		domain.Publish(c.ctx.Hub, constants.TopicZFSPoolsUpdate, pools)
 		logger.Debug("Published ZFS pools update (count: %d)", len(pools))
 	}
@@ -124,6 +126,20 @@ func (c *ZFSCollector) isZFSAvailable() bool {
 	return err == nil
 }
 
+// applyForecast fills the fill rate and days-until-full of each pool.
+func (c *ZFSCollector) applyForecast(pools []dto.ZFSPool, now time.Time) {
+	seen := make(map[string]bool, len(pools))
+	for i := range pools {
+		if pools[i].SizeBytes == 0 {
+			continue
+		}
+		pools[i].FillRateBytesPerDay, pools[i].DaysUntilFull = c.forecast.observe(
+			pools[i].Name, pools[i].AllocatedBytes, pools[i].SizeBytes, now)
+		seen[pools[i].Name] = true
+	}
+	c.forecast.prune(seen)
+}
+
 // collectPools collects information about all ZFS pools
 func (c *ZFSCollector) collectPools() ([]dto.ZFSPool, error) {
 	// Get list of pool names
@@ -0,0 +1,412 @@
+// Package configreload applies changes to the agent's YAML config file at
+// runtime. Reloads are triggered by SIGHUP, by edits to the file, or through
+// the REST API.
+package configreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
+)
+
+// ErrInvalidConfig wraps validation failures so callers can tell bad input
+// apart from I/O errors.
+var ErrInvalidConfig = errors.New("invalid config")
+
+// watchDebounce coalesces the burst of events an editor produces on save.
+const watchDebounce = time.Second
+
+// maxInterval mirrors the upper bound enforced by the collector manager.
+const maxInterval = 86400
+
+// CollectorController is the subset of the collector manager needed to apply
+// interval changes.
+type CollectorController interface {
+	EnableCollector(name string) error
+	DisableCollector(name string) error
+	UpdateInterval(name string, intervalSeconds int) error
+}
+
+// Reloader re-reads the config file and applies the settings that can change
+// without a restart: the log level and collector intervals. Changes to any
+// other setting are recorded as requiring a restart.
+type Reloader struct {
+	path       string
+	lowPower   bool
+	collectors CollectorController
+
+	mu         sync.Mutex
+	current    *domain.FileConfig
+	lastReload time.Time
+	lastError  string
+	restart    map[string]bool
+}
+
+// NewReloader creates a reloader for the config file at path. The file as it
+// is now becomes the baseline, so only later edits are applied.
+func NewReloader(path string, ctx *domain.Context, collectorController CollectorController) *Reloader {
+	cfg, err := domain.LoadConfigFile(path)
+	if err != nil {
+		logger.Warning("Config: %v", err)
+	}
+	if cfg == nil {
+		cfg = &domain.FileConfig{}
+	}
+	return &Reloader{
+		path:       path,
+		lowPower:   ctx.LowPowerMode,
+		collectors: collectorController,
+		current:    cfg,
+		restart:    make(map[string]bool),
+	}
+}
+
+// Path returns the config file location.
+func (r *Reloader) Path() string {
+	return r.path
+}
+
+// Start reloads the config on SIGHUP and whenever the file changes. It blocks
+// until ctx is cancelled.
+func (r *Reloader) Start(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	fw, err := collectors.NewFileWatcher(watchDebounce)
+	if err != nil {
+		logger.Warning("Config: file watcher unavailable (%v); reload with SIGHUP instead", err)
+	} else if err := fw.WatchFile(r.path); err != nil {
+		logger.Debug("Config: not watching %s: %v", r.path, err)
+		_ = fw.Close()
+	} else {
+		defer func() { _ = fw.Close() }()
+		go fw.Run(ctx, []string{r.path}, func() { r.reloadAndLog("file change") })
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reloadAndLog("SIGHUP")
+		}
+	}
+}
+
+// reloadAndLog runs a reload from a background trigger and logs the outcome.
+func (r *Reloader) reloadAndLog(trigger string) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.LogPanicWithStack("Config reload", rec)
+		}
+	}()
+
+	result, err := r.Reload()
+	if err != nil {
+		logger.Error("Config: reload after %s failed: %v", trigger, err)
+		return
+	}
+	if len(result.Applied) > 0 {
+		logger.Info("Config: reloaded after %s, applied %s", trigger, strings.Join(result.Applied, ", "))
+	}
+	for _, e := range result.Errors {
+		logger.Warning("Config: %s", e)
+	}
+	if len(result.RestartRequired) > 0 {
+		logger.Info("Config: restart required for %s", strings.Join(result.RestartRequired, ", "))
+	}
+}
+
+// Reload re-reads the config file and applies what changed since the last
+// load. An invalid file is rejected as a whole and the running settings are
+// kept.
+func (r *Reloader) Reload() (*dto.ConfigReloadResult, error) {
+	cfg, err := domain.LoadConfigFile(r.path)
+	if err == nil && cfg != nil {
+		err = validate(cfg)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastReload = time.Now()
+	if err != nil {
+		r.lastError = err.Error()
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = &domain.FileConfig{}
+	}
+
+	result := r.applyLocked(cfg)
+	r.lastError = strings.Join(result.Errors, "; ")
+	return result, nil
+}
+
+// Update validates cfg, writes it to the config file, and reloads. Secrets
+// sent back as dto.RedactedSecret keep their stored value.
+func (r *Reloader) Update(cfg *domain.FileConfig) (*dto.ConfigReloadResult, error) {
+	if err := validate(cfg); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	keepSecrets(r.current, cfg)
+	r.mu.Unlock()
+
+	if err := domain.SaveConfigFile(r.path, cfg); err != nil {
+		return nil, err
+	}
+	return r.Reload()
+}
+
+// Status returns the loaded config with secrets redacted and the reload state.
+func (r *Reloader) Status() dto.AgentConfigStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, statErr := os.Stat(r.path)
+	status := dto.AgentConfigStatus{
+		Path:            r.path,
+		Exists:          statErr == nil,
+		Config:          redact(r.current),
+		LastError:       r.lastError,
+		RestartRequired: r.restartListLocked(),
+		Timestamp:       time.Now(),
+	}
+	if !r.lastReload.IsZero() {
+		t := r.lastReload
+		status.LastReload = &t
+	}
+	return status
+}
+
+// applyLocked applies the hot-reloadable differences between the current and
+// next config and records everything else as pending a restart.
+func (r *Reloader) applyLocked(next *domain.FileConfig) *dto.ConfigReloadResult {
+	prev := r.current
+	result := &dto.ConfigReloadResult{
+		Applied:   []string{},
+		Timestamp: time.Now(),
+	}
+
+	switch {
+	case next.LogLevel == nil:
+		if prev.LogLevel != nil {
+			r.restart["log_level"] = true
+		}
+	case prev.LogLevel == nil || *prev.LogLevel != *next.LogLevel:
+		level, _ := domain.ParseLogLevel(*next.LogLevel)
+		logger.SetLevel(level)
+		result.Applied = append(result.Applied, "log_level")
+	}
+
+	prevIntervals, nextIntervals := intervalsOf(prev), intervalsOf(next)
+	names := make([]string, 0, len(nextIntervals))
+	for name := range nextIntervals {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		key := "intervals." + name
+		old, v := prevIntervals[name], nextIntervals[name]
+		if v == nil {
+			// Removing an override has no runtime value to fall back to
+			if old != nil {
+				r.restart[key] = true
+			}
+			continue
+		}
+		if old != nil && *old == *v {
+			continue
+		}
+		if err := r.applyInterval(name, *v); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		result.Applied = append(result.Applied, key)
+	}
+
+	for _, key := range changedKeys("", reflect.ValueOf(*prev), reflect.ValueOf(*next)) {
+		r.restart[key] = true
+	}
+
+	r.current = next
+	result.RestartRequired = r.restartListLocked()
+	return result
+}
+
+// applyInterval sets a collector interval; zero disables the collector.
+func (r *Reloader) applyInterval(name string, seconds int) error {
+	if seconds == 0 {
+		return r.collectors.DisableCollector(name)
+	}
+	if r.lowPower {
+		seconds = min(seconds*4, maxInterval)
+	}
+	if err := r.collectors.UpdateInterval(name, seconds); err != nil {
+		return err
+	}
+	return r.collectors.EnableCollector(name)
+}
+
+func (r *Reloader) restartListLocked() []string {
+	keys := make([]string, 0, len(r.restart))
+	for key := range r.restart {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// validate checks a config before it is applied or saved.
+func validate(cfg *domain.FileConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if cfg.BindAddress != nil && *cfg.BindAddress != "" {
+		if err := lib.ValidateBindAddress(*cfg.BindAddress); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+	return nil
+}
+
+func intervalsOf(cfg *domain.FileConfig) map[string]*int {
+	if cfg.Intervals == nil {
+		return (&domain.FileConfigIntervals{}).ByCollector()
+	}
+	return cfg.Intervals.ByCollector()
+}
+
+// changedKeys lists the yaml keys that differ between a and b, descending
+// into nested sections. The hot-reloadable keys are skipped.
+func changedKeys(prefix string, a, b reflect.Value) []string {
+	var keys []string
+	t := a.Type()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		key := prefix + name
+		if key == "log_level" || key == "intervals" {
+			continue
+		}
+
+		fa, fb := a.Field(i), b.Field(i)
+		if elem := t.Field(i).Type.Elem(); elem.Kind() == reflect.Struct {
+			keys = append(keys, changedKeys(key+".", derefOrZero(fa, elem), derefOrZero(fb, elem))...)
+			continue
+		}
+		if !reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func derefOrZero(v reflect.Value, t reflect.Type) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(t)
+	}
+	return v.Elem()
+}
+
+// keepSecrets restores secrets that a client echoed back in redacted form.
+func keepSecrets(current, next *domain.FileConfig) {
+	if next.MQTT != nil && next.MQTT.Password != nil && *next.MQTT.Password == dto.RedactedSecret {
+		next.MQTT.Password = nil
+		if current.MQTT != nil {
+			next.MQTT.Password = current.MQTT.Password
+		}
+	}
+	if next.InfluxDB != nil && next.InfluxDB.Token != nil && *next.InfluxDB.Token == dto.RedactedSecret {
+		next.InfluxDB.Token = nil
+		if current.InfluxDB != nil {
+			next.InfluxDB.Token = current.InfluxDB.Token
+		}
+	}
+	if next.InfluxDB != nil && next.InfluxDB.Password != nil && *next.InfluxDB.Password == dto.RedactedSecret {
+		next.InfluxDB.Password = nil
+		if current.InfluxDB != nil {
+			next.InfluxDB.Password = current.InfluxDB.Password
+		}
+	}
+	if next.Heartbeat != nil && next.Heartbeat.URL != nil && *next.Heartbeat.URL == dto.RedactedSecret {
+		next.Heartbeat.URL = nil
+		if current.Heartbeat != nil {
+			next.Heartbeat.URL = current.Heartbeat.URL
+		}
+	}
+	if next.MCP != nil && next.MCP.APIKey != nil && *next.MCP.APIKey == dto.RedactedSecret {
+		next.MCP.APIKey = nil
+		if current.MCP != nil {
+			next.MCP.APIKey = current.MCP.APIKey
+		}
+	}
+	if next.ACME != nil && next.ACME.DNSToken != nil && *next.ACME.DNSToken == dto.RedactedSecret {
+		next.ACME.DNSToken = nil
+		if current.ACME != nil {
+			next.ACME.DNSToken = current.ACME.DNSToken
+		}
+	}
+}
+
+// redact returns a copy of cfg with secret values masked.
+func redact(cfg *domain.FileConfig) *domain.FileConfig {
+	out := *cfg
+	secret := dto.RedactedSecret
+	if cfg.MQTT != nil {
+		mqtt := *cfg.MQTT
+		if mqtt.Password != nil && *mqtt.Password != "" {
+			mqtt.Password = &secret
+		}
+		out.MQTT = &mqtt
+	}
+	if cfg.InfluxDB != nil {
+		influx := *cfg.InfluxDB
+		if influx.Token != nil && *influx.Token != "" {
+			influx.Token = &secret
+		}
+		if influx.Password != nil && *influx.Password != "" {
+			influx.Password = &secret
+		}
+		out.InfluxDB = &influx
+	}
+	if cfg.Heartbeat != nil {
+		hb := *cfg.Heartbeat
+		// The push URL embeds the check's token.
+		if hb.URL != nil && *hb.URL != "" {
+			hb.URL = &secret
+		}
+		out.Heartbeat = &hb
+	}
+	if cfg.MCP != nil {
+		mcp := *cfg.MCP
+		if mcp.APIKey != nil && *mcp.APIKey != "" {
+			mcp.APIKey = &secret
+		}
+		out.MCP = &mcp
+	}
+	if cfg.ACME != nil {
+		acme := *cfg.ACME
+		if acme.DNSToken != nil && *acme.DNSToken != "" {
+			acme.DNSToken = &secret
+		}
+		out.ACME = &acme
+	}
+	return &out
+}
@@ -0,0 +1,223 @@
+package configreload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+type fakeCollectors struct {
+	intervals map[string]int
+	disabled  []string
+	enabled   []string
+}
+
+func (f *fakeCollectors) EnableCollector(name string) error {
+	f.enabled = append(f.enabled, name)
+	return nil
+}
+
+func (f *fakeCollectors) DisableCollector(name string) error {
+	if name == "system" {
+		return errors.New("cannot disable system collector (always required)")
+	}
+	f.disabled = append(f.disabled, name)
+	return nil
+}
+
+func (f *fakeCollectors) UpdateInterval(name string, seconds int) error {
+	f.intervals[name] = seconds
+	return nil
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+func TestReloadAppliesChanges(t *testing.T) {
+	defer logger.SetLevel(logger.GetLevel())
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path, "port: 8043\nintervals:\n  docker: 30\n  vm: 60\n")
+
+	fc := &fakeCollectors{intervals: map[string]int{}}
+	r := NewReloader(path, &domain.Context{}, fc)
+
+	writeConfig(t, path, "port: 9000\nlog_level: debug\nintervals:\n  docker: 15\n  vm: 60\n  zfs: 0\n  system: 0\n")
+	result, err := r.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if want := []string{"log_level", "intervals.docker", "intervals.zfs"}; !slices.Equal(result.Applied, want) {
+		t.Errorf("Applied = %v, want %v", result.Applied, want)
+	}
+	if fc.intervals["docker"] != 15 || len(fc.intervals) != 1 {
+		t.Errorf("intervals = %v, want only docker=15", fc.intervals)
+	}
+	if !slices.Equal(fc.disabled, []string{"zfs"}) {
+		t.Errorf("disabled = %v, want [zfs]", fc.disabled)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("expected an error for disabling system, got %v", result.Errors)
+	}
+	if !slices.Equal(result.RestartRequired, []string{"port"}) {
+		t.Errorf("RestartRequired = %v, want [port]", result.RestartRequired)
+	}
+	if logger.GetLevel() != logger.LevelDebug {
+		t.Errorf("log level not applied")
+	}
+
+	// Reloading an unchanged file applies nothing
+	result, err = r.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if len(result.Applied) != 0 {
+		t.Errorf("Applied = %v, want none", result.Applied)
+	}
+}
+
+func TestReloadLowPowerScalesIntervals(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	fc := &fakeCollectors{intervals: map[string]int{}}
+	r := NewReloader(path, &domain.Context{Config: domain.Config{LowPowerMode: true}}, fc)
+
+	writeConfig(t, path, "intervals:\n  docker: 30\n  hardware: 86400\n")
+	if _, err := r.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if fc.intervals["docker"] != 120 || fc.intervals["hardware"] != maxInterval {
+		t.Errorf("intervals = %v", fc.intervals)
+	}
+}
+
+func TestReloadRejectsInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path, "intervals:\n  docker: 30\n")
+	fc := &fakeCollectors{intervals: map[string]int{}}
+	r := NewReloader(path, &domain.Context{}, fc)
+
+	for _, content := range []string{"intervals:\n  docker: 2\n", "port: [\n", "log_level: loud\n"} {
+		writeConfig(t, path, content)
+		if _, err := r.Reload(); err == nil {
+			t.Errorf("expected error for %q", content)
+		}
+	}
+	if len(fc.intervals) != 0 {
+		t.Errorf("invalid config was applied: %v", fc.intervals)
+	}
+	if r.Status().LastError == "" {
+		t.Error("expected last_error to be set")
+	}
+}
+
+func TestUpdateKeepsRedactedSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path, "mqtt:\n  broker: tcp://broker\n  password: hunter2\n")
+	r := NewReloader(path, &domain.Context{}, &fakeCollectors{intervals: map[string]int{}})
+
+	status := r.Status()
+	cfg := status.Config.(*domain.FileConfig)
+	if *cfg.MQTT.Password != dto.RedactedSecret {
+		t.Fatalf("password not redacted: %q", *cfg.MQTT.Password)
+	}
+
+	broker := "tcp://other"
+	cfg.MQTT.Broker = &broker
+	result, err := r.Update(cfg)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !slices.Equal(result.RestartRequired, []string{"mqtt.broker"}) {
+		t.Errorf("RestartRequired = %v", result.RestartRequired)
+	}
+
+	saved, err := domain.LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+	if *saved.MQTT.Password != "hunter2" || *saved.MQTT.Broker != broker {
+		t.Errorf("saved mqtt = %+v", saved.MQTT)
+	}
+
+	port := 70000
+	if _, err := r.Update(&domain.FileConfig{Port: &port}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestStatusRedactsInfluxDBSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path, "influxdb:\n  url: http://influx:8086\n  token: tok\n  password: pw\n")
+	r := NewReloader(path, &domain.Context{}, &fakeCollectors{intervals: map[string]int{}})
+
+	cfg := r.Status().Config.(*domain.FileConfig)
+	if *cfg.InfluxDB.Token != dto.RedactedSecret || *cfg.InfluxDB.Password != dto.RedactedSecret {
+		t.Fatalf("secrets not redacted: %+v", cfg.InfluxDB)
+	}
+
+	if _, err := r.Update(cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	saved, err := domain.LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+	if *saved.InfluxDB.Token != "tok" || *saved.InfluxDB.Password != "pw" {
+		t.Errorf("saved influxdb = %+v", saved.InfluxDB)
+	}
+}
+
+func TestStatusRedactsHeartbeatURL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path, "heartbeat:\n  enabled: true\n  url: https://hc-ping.com/uuid\n")
+	r := NewReloader(path, &domain.Context{}, &fakeCollectors{intervals: map[string]int{}})
+
+	cfg := r.Status().Config.(*domain.FileConfig)
+	if *cfg.Heartbeat.URL != dto.RedactedSecret {
+		t.Fatalf("heartbeat url not redacted: %q", *cfg.Heartbeat.URL)
+	}
+
+	if _, err := r.Update(cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	saved, err := domain.LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+	if *saved.Heartbeat.URL != "https://hc-ping.com/uuid" {
+		t.Errorf("saved heartbeat url = %q", *saved.Heartbeat.URL)
+	}
+}
+
+func TestStatusRedactsACMEToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path, "acme:\n  enabled: true\n  domains: tower.example.com\n  dns_token: cf-secret\n")
+	r := NewReloader(path, &domain.Context{}, &fakeCollectors{intervals: map[string]int{}})
+
+	cfg := r.Status().Config.(*domain.FileConfig)
+	if *cfg.ACME.DNSToken != dto.RedactedSecret {
+		t.Fatalf("acme dns token not redacted: %q", *cfg.ACME.DNSToken)
+	}
+
+	if _, err := r.Update(cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	saved, err := domain.LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+	if *saved.ACME.DNSToken != "cf-secret" {
+		t.Errorf("saved acme dns token = %q", *saved.ACME.DNSToken)
+	}
+}
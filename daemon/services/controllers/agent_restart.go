@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// agentRestartLog receives the output of the plugin scripts during a restart.
+var agentRestartLog = filepath.Join("/var/log", agentPluginName+"-restart.log")
+
+// RestartAgent restarts the agent through the plugin's stop and start
+// scripts, run detached so they outlive the process they stop. The stop
+// script sends SIGTERM, so the agent shuts down gracefully: MQTT reports it
+// offline, WebSocket clients get a going-away frame and the caches are
+// saved for the new process. It returns the log file of the restart.
+func RestartAgent(ctx context.Context) (string, error) {
+	// The short delay lets the HTTP response reach the client before the
+	// agent stops. All arguments are constants.
+	stop := filepath.Join(constants.AgentScriptsDir, "stop")
+	start := filepath.Join(constants.AgentScriptsDir, "start")
+	script := fmt.Sprintf("exec >%s 2>&1; sleep 2; %s && %s", agentRestartLog, stop, start)
+	if err := startDetached(script); err != nil {
+		return "", fmt.Errorf("start agent restart: %w", err)
+	}
+	logger.InfoContext(ctx, "Agent restart requested, output in %s", agentRestartLog)
+	return agentRestartLog, nil
+}
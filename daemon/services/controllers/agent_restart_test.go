@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRestartAgent(t *testing.T) {
+	oldStart := startDetached
+	t.Cleanup(func() { startDetached = oldStart })
+
+	var scripts []string
+	startDetached = func(script string) error {
+		scripts = append(scripts, script)
+		return nil
+	}
+
+	logFile, err := RestartAgent(context.Background())
+	if err != nil {
+		t.Fatalf("RestartAgent: %v", err)
+	}
+	if logFile != agentRestartLog {
+		t.Errorf("log file = %q, want %q", logFile, agentRestartLog)
+	}
+	if len(scripts) != 1 {
+		t.Fatalf("started %d scripts, want 1", len(scripts))
+	}
+	stop := strings.Index(scripts[0], "scripts/stop")
+	start := strings.Index(scripts[0], "scripts/start")
+	if stop < 0 || start < stop {
+		t.Errorf("script %q does not run stop before start", scripts[0])
+	}
+
+	startDetached = func(string) error { return errors.New("setsid missing") }
+	if _, err := RestartAgent(context.Background()); err == nil {
+		t.Error("expected an error when the restart cannot be started")
+	}
+}
@@ -0,0 +1,331 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	agentPluginName = "unraid-management-agent"
+	// maxAgentBundleSize caps the release bundle download.
+	maxAgentBundleSize = 200 << 20
+	// maxChecksumFileSize caps checksum asset downloads.
+	maxChecksumFileSize = 4 << 10
+)
+
+// agentReleasesURL, agentBundleDir, and startDetached are package-level
+// variables so tests can point the updater at a fake release server and a
+// temporary directory without launching the plugin installer.
+var (
+	agentReleasesURL = "https://api.github.com/repos/ruaan-deysel/unraid-management-agent/releases/latest"
+	agentBundleDir   = filepath.Join(constants.PluginsConfigDir, agentPluginName)
+	startDetached    = func(script string) error {
+		_, err := lib.ExecCommand(constants.SetsidBin, "-f", "/bin/sh", "-c", script)
+		return err
+	}
+)
+
+// githubRelease is the subset of the GitHub release API response the updater uses.
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	HTMLURL     string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	Assets      []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+		Size int64  `json:"size"`
+	} `json:"assets"`
+}
+
+// AgentUpdater checks GitHub releases for a newer agent and installs it
+// through the Unraid plugin system.
+type AgentUpdater struct {
+	currentVersion string
+	client         *http.Client
+}
+
+// NewAgentUpdater creates an updater for the running agent version.
+func NewAgentUpdater(currentVersion string) *AgentUpdater {
+	return &AgentUpdater{
+		currentVersion: currentVersion,
+		client:         &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Check reports whether a newer release than the running agent is published.
+func (u *AgentUpdater) Check(ctx context.Context) (*dto.AgentUpdateStatus, error) {
+	status, _, err := u.check(ctx)
+	return status, err
+}
+
+// Update downloads the latest release bundle and verifies its checksum. When
+// no newer release exists it returns the check result unchanged. In
+// dry-run mode it stops there; otherwise it stores the bundle where the
+// plugin installer expects it and runs `plugin update` detached, which stops
+// the agent, extracts the new files, and starts it again through the rc
+// script.
+func (u *AgentUpdater) Update(ctx context.Context, dryRun bool) (*dto.AgentUpdateStatus, error) {
+	status, release, err := u.check(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status.DryRun = dryRun
+	if !status.UpdateAvailable {
+		return status, nil
+	}
+
+	bundleName := fmt.Sprintf("%s-%s.tgz", agentPluginName, status.LatestVersion)
+	bundleURL, want, err := u.releaseChecksum(ctx, release, bundleName)
+	if err != nil {
+		return nil, err
+	}
+	status.BundleURL = bundleURL
+	status.Checksum = "sha256:" + want
+
+	if err := os.MkdirAll(agentBundleDir, 0o750); err != nil {
+		return nil, fmt.Errorf("create bundle directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(agentBundleDir, ".download-*")
+	if err != nil {
+		return nil, fmt.Errorf("create download file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	got, err := u.download(ctx, bundleURL, tmp, true)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("download bundle: %w", err)
+	}
+	if !strings.EqualFold(got, want) {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", bundleName, want, got)
+	}
+	status.ChecksumVerified = true
+
+	if dryRun {
+		status.Status = dto.AgentUpdateVerified
+		status.Message = fmt.Sprintf("Release %s downloaded and SHA-256 checksum verified; nothing installed", status.LatestVersion)
+		return status, nil
+	}
+
+	bundlePath := filepath.Join(agentBundleDir, bundleName)
+	if err := os.Rename(tmpPath, bundlePath); err != nil {
+		return nil, fmt.Errorf("store bundle: %w", err)
+	}
+
+	// The short delay lets the HTTP response reach the client before the
+	// installer stops the agent. All arguments are constants.
+	pluginFile := filepath.Join(constants.PluginsConfigDir, agentPluginName+".plg")
+	logFile := filepath.Join("/var/log", agentPluginName+"-update.log")
+	script := fmt.Sprintf("exec >%s 2>&1; sleep 2; %s update %s", logFile, constants.PluginBin, pluginFile)
+	if err := startDetached(script); err != nil {
+		return nil, fmt.Errorf("start plugin update: %w", err)
+	}
+
+	logger.InfoContext(ctx, "Agent update: installing %s (current %s), output in %s", status.LatestVersion, status.CurrentVersion, logFile)
+	status.Status = dto.AgentUpdateInstalling
+	status.Message = fmt.Sprintf("Installing %s; the agent will restart shortly (log: %s)", status.LatestVersion, logFile)
+	return status, nil
+}
+
+// check fetches the latest release and compares it to the running version.
+func (u *AgentUpdater) check(ctx context.Context) (*dto.AgentUpdateStatus, *githubRelease, error) {
+	var release githubRelease
+	if err := u.getJSON(ctx, agentReleasesURL, &release); err != nil {
+		return nil, nil, fmt.Errorf("fetch latest release: %w", err)
+	}
+	if release.TagName == "" || release.Draft || release.Prerelease {
+		return nil, nil, errors.New("no published release found")
+	}
+
+	latest := strings.TrimPrefix(release.TagName, "v")
+	status := &dto.AgentUpdateStatus{
+		CurrentVersion: u.currentVersion,
+		LatestVersion:  latest,
+		ReleaseURL:     release.HTMLURL,
+		Status:         dto.AgentUpdateUpToDate,
+		Timestamp:      time.Now(),
+	}
+	if !release.PublishedAt.IsZero() {
+		published := release.PublishedAt
+		status.PublishedAt = &published
+	}
+
+	cmp, ok := compareVersions(latest, u.currentVersion)
+	switch {
+	case !ok:
+		status.Message = fmt.Sprintf("Running version %q cannot be compared with release %s", u.currentVersion, latest)
+	case cmp > 0:
+		status.UpdateAvailable = true
+		status.Status = dto.AgentUpdateAvailable
+		status.Message = fmt.Sprintf("Version %s is available", latest)
+	default:
+		status.Message = "Agent is up to date"
+	}
+	return status, &release, nil
+}
+
+// releaseChecksum finds the bundle asset and its SHA-256 checksum in the
+// release's SHA256SUM asset. Releases without one, such as those that only
+// published an MD5SUM, are refused. Releases are not signed: the checksum
+// comes from the same release as the bundle, so it catches a corrupted or
+// truncated download but not a tampered release.
+func (u *AgentUpdater) releaseChecksum(ctx context.Context, release *githubRelease, bundleName string) (bundleURL, sum string, err error) {
+	assets := make(map[string]string, len(release.Assets))
+	for _, a := range release.Assets {
+		assets[a.Name] = a.URL
+	}
+
+	bundleURL = assets[bundleName]
+	if bundleURL == "" {
+		return "", "", fmt.Errorf("release %s has no %s asset", release.TagName, bundleName)
+	}
+
+	url := assets["SHA256SUM"]
+	if url == "" {
+		return "", "", fmt.Errorf("release %s publishes no SHA256SUM; refusing to install", release.TagName)
+	}
+	var sb strings.Builder
+	if _, err := u.download(ctx, url, &sb, false); err != nil {
+		return "", "", fmt.Errorf("download SHA256SUM: %w", err)
+	}
+	if sum = parseChecksumFile(sb.String(), bundleName); sum == "" {
+		return "", "", fmt.Errorf("SHA256SUM does not list %s", bundleName)
+	}
+	return bundleURL, sum, nil
+}
+
+// download streams url into w, returning the hex SHA-256 digest when bundle
+// is set. Bundle downloads are capped at maxAgentBundleSize and checksum
+// files at maxChecksumFileSize.
+func (u *AgentUpdater) download(ctx context.Context, url string, w io.Writer, bundle bool) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", agentPluginName)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	limit := int64(maxChecksumFileSize)
+	h := sha256.New()
+	if bundle {
+		limit = maxAgentBundleSize
+		w = io.MultiWriter(w, h)
+	}
+
+	n, err := io.Copy(w, io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return "", err
+	}
+	if n > limit {
+		return "", fmt.Errorf("download exceeds %d bytes", limit)
+	}
+	if !bundle {
+		return "", nil
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (u *AgentUpdater) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", agentPluginName)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// parseChecksumFile returns the checksum for name from a checksum file. It
+// accepts both `sha256sum`-style "<hash>  <file>" lines and a bare hash.
+func parseChecksumFile(content, name string) string {
+	for line := range strings.SplitSeq(strings.TrimSpace(content), "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1:
+			return fields[0]
+		case len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == name:
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// compareVersions compares dotted numeric versions such as "2026.07.00". It
+// reports false when either version is not numeric (e.g. a "dev" build).
+func compareVersions(a, b string) (int, bool) {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := range max(len(pa), len(pb)) {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x > y {
+				return 1, true
+			}
+			return -1, true
+		}
+	}
+	return 0, true
+}
+
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if v == "" {
+		return nil, false
+	}
+	var parts []int
+	for p := range strings.SplitSeq(v, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
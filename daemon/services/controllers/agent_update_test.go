@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// fakeReleaseServer serves a latest-release document with a bundle and a
+// SHA256SUM asset; sum overrides the published checksum when non-empty.
+func fakeReleaseServer(t *testing.T, tag, sum string) *httptest.Server {
+	t.Helper()
+	bundle := []byte("bundle contents")
+	digest := sha256.Sum256(bundle)
+	if sum == "" {
+		sum = hex.EncodeToString(digest[:])
+	}
+	version := strings.TrimPrefix(tag, "v")
+	name := fmt.Sprintf("unraid-management-agent-%s.tgz", version)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	mux.HandleFunc("/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"tag_name":%q,"html_url":"https://example/release","assets":[
+			{"name":%q,"browser_download_url":"%s/bundle"},
+			{"name":"SHA256SUM","browser_download_url":"%s/sha256"}]}`, tag, name, srv.URL, srv.URL)
+	})
+	mux.HandleFunc("/bundle", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write(bundle) })
+	mux.HandleFunc("/sha256", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s  %s\n", sum, name)
+	})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func setupAgentUpdate(t *testing.T, srv *httptest.Server) (*[]string, string) {
+	t.Helper()
+	oldURL, oldDir, oldStart := agentReleasesURL, agentBundleDir, startDetached
+	t.Cleanup(func() { agentReleasesURL, agentBundleDir, startDetached = oldURL, oldDir, oldStart })
+
+	agentReleasesURL = srv.URL + "/latest"
+	agentBundleDir = t.TempDir()
+	var scripts []string
+	startDetached = func(script string) error {
+		scripts = append(scripts, script)
+		return nil
+	}
+	return &scripts, agentBundleDir
+}
+
+func TestAgentUpdater(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("up to date", func(t *testing.T) {
+		scripts, _ := setupAgentUpdate(t, fakeReleaseServer(t, "v2026.07.00", ""))
+		status, err := NewAgentUpdater("2026.07.00").Update(ctx, false)
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if status.UpdateAvailable || status.Status != dto.AgentUpdateUpToDate || len(*scripts) != 0 {
+			t.Errorf("unexpected status %+v (scripts %v)", status, *scripts)
+		}
+	})
+
+	t.Run("dry run verifies without installing", func(t *testing.T) {
+		scripts, dir := setupAgentUpdate(t, fakeReleaseServer(t, "v2026.08.00", ""))
+		status, err := NewAgentUpdater("2026.07.00").Update(ctx, true)
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if status.Status != dto.AgentUpdateVerified || !status.ChecksumVerified || !strings.HasPrefix(status.Checksum, "sha256:") {
+			t.Errorf("unexpected status %+v", status)
+		}
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 0 || len(*scripts) != 0 {
+			t.Errorf("dry run left files %v or ran %v", entries, *scripts)
+		}
+	})
+
+	t.Run("install stores bundle and runs plugin update", func(t *testing.T) {
+		scripts, dir := setupAgentUpdate(t, fakeReleaseServer(t, "v2026.08.00", ""))
+		status, err := NewAgentUpdater("2026.07.00").Update(ctx, false)
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if status.Status != dto.AgentUpdateInstalling {
+			t.Errorf("status = %q", status.Status)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "unraid-management-agent-2026.08.00.tgz")); err != nil {
+			t.Errorf("bundle not stored: %v", err)
+		}
+		if len(*scripts) != 1 || !strings.Contains((*scripts)[0], "plugin update") {
+			t.Errorf("scripts = %v", *scripts)
+		}
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		scripts, dir := setupAgentUpdate(t, fakeReleaseServer(t, "v2026.08.00", strings.Repeat("0", 64)))
+		if _, err := NewAgentUpdater("2026.07.00").Update(ctx, false); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Fatalf("expected checksum mismatch, got %v", err)
+		}
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 0 || len(*scripts) != 0 {
+			t.Errorf("failed update left files %v or ran %v", entries, *scripts)
+		}
+	})
+
+	t.Run("release without SHA256SUM is refused", func(t *testing.T) {
+		mux := http.NewServeMux()
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+		mux.HandleFunc("/latest", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"tag_name":"v2026.08.00","assets":[
+				{"name":"unraid-management-agent-2026.08.00.tgz","browser_download_url":"%s/bundle"},
+				{"name":"MD5SUM","browser_download_url":"%s/md5"}]}`, srv.URL, srv.URL)
+		})
+		scripts, _ := setupAgentUpdate(t, srv)
+		if _, err := NewAgentUpdater("2026.07.00").Update(ctx, true); err == nil || !strings.Contains(err.Error(), "no SHA256SUM") {
+			t.Fatalf("expected refusal, got %v", err)
+		}
+		if len(*scripts) != 0 {
+			t.Errorf("ran %v", *scripts)
+		}
+	})
+
+	t.Run("dev build is not compared", func(t *testing.T) {
+		setupAgentUpdate(t, fakeReleaseServer(t, "v2026.08.00", ""))
+		status, err := NewAgentUpdater("dev").Check(ctx)
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		if status.UpdateAvailable {
+			t.Error("dev build should not report an update")
+		}
+	})
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"2026.08.00", "2026.07.00", 1, true},
+		{"v2026.07.00", "2026.07.00", 0, true},
+		{"2026.07", "2026.07.01", -1, true},
+		{"2026.10.00", "2026.9.00", 1, true},
+		{"2026.08.00", "dev", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := compareVersions(tt.a, tt.b)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("compareVersions(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseChecksumFile(t *testing.T) {
+	if got := parseChecksumFile("abc123\n", "x.tgz"); got != "abc123" {
+		t.Errorf("bare hash: got %q", got)
+	}
+	if got := parseChecksumFile("aaa  other.tgz\nbbb *x.tgz\n", "x.tgz"); got != "bbb" {
+		t.Errorf("named hash: got %q", got)
+	}
+	if got := parseChecksumFile("aaa  other.tgz\n", "x.tgz"); got != "" {
+		t.Errorf("missing entry: got %q", got)
+	}
+}
@@ -3,11 +3,13 @@ package controllers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
 )
 
 // mdcmdExec writes a command to /proc/mdcmd directly for zero shell overhead.
@@ -65,9 +67,11 @@ func (c *ArrayController) StopArray() error {
 	return nil
 }
 
-// StartParityCheck starts a parity check.
+// StartParityCheck starts a parity check. trigger records where the request
+// came from (dto.ParityTrigger*) on the history record Unraid writes when the
+// check finishes.
 // Uses direct /proc/mdcmd write for zero shell overhead with fallback to mdcmd binary.
-func (c *ArrayController) StartParityCheck(correcting bool) error {
+func (c *ArrayController) StartParityCheck(correcting bool, trigger string) error {
 	logger.Info("Array: Starting parity check (correcting: %v)...", correcting)
 
 	var err error
@@ -82,6 +86,9 @@ func (c *ArrayController) StartParityCheck(correcting bool) error {
 	}
 
 	logger.Info("Array: Parity check started successfully")
+	if err := collectors.NewParityCollector().RecordStart(trigger, correcting, time.Now()); err != nil {
+		logger.Warning("Array: Failed to record parity check trigger: %v", err)
+	}
 	return nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 )
 
 func TestNewArrayController(t *testing.T) {
@@ -74,7 +75,7 @@ func TestArrayControllerParityCheckModes(t *testing.T) {
 	// These will fail without mdcmd but test the logic paths
 
 	t.Run("StartParityCheck with correcting=true", func(t *testing.T) {
-		err := ac.StartParityCheck(true)
+		err := ac.StartParityCheck(true, dto.ParityTriggerAPI)
 		// Will fail without mdcmd, but tests the code path
 		if err == nil {
 			t.Log("Note: No error - mdcmd might be available")
@@ -82,7 +83,7 @@ func TestArrayControllerParityCheckModes(t *testing.T) {
 	})
 
 	t.Run("StartParityCheck with correcting=false", func(t *testing.T) {
-		err := ac.StartParityCheck(false)
+		err := ac.StartParityCheck(false, dto.ParityTriggerAPI)
 		// Will fail without mdcmd, but tests the code path
 		if err == nil {
 			t.Log("Note: No error - mdcmd might be available")
@@ -0,0 +1,552 @@
+package controllers
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/go-libvirt"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// ErrInvalidCPUPinning is returned for a pinning request that is unconfirmed,
+// malformed or names CPUs or vCPUs that do not exist.
+var ErrInvalidCPUPinning = errors.New("invalid CPU pinning request")
+
+// Package-level variables so tests can use fixture files.
+var (
+	cpuSysDir          = "/sys/devices/system/cpu"
+	cpuKernelCmdline   = "/proc/cmdline"
+	dockerTemplatesDir = "/boot/config/plugins/dockerMan/templates-user"
+)
+
+// templateCPUSet matches the CPUset element of a dockerMan template.
+var templateCPUSet = regexp.MustCompile(`<CPUset\s*/>|<CPUset>[^<]*</CPUset>`)
+
+// GetCPUTopology returns the host's logical CPUs with their isolation and
+// the CPU pinning of every VM and container. VMs or containers that cannot
+// be read (libvirt or Docker not running) are reported as warnings.
+func GetCPUTopology() (*dto.CPUTopology, error) {
+	topo, err := readCPUTopology()
+	if err != nil {
+		return nil, err
+	}
+
+	vms, err := NewVMController().CPUPinnings()
+	if err != nil {
+		topo.Warnings = append(topo.Warnings, fmt.Sprintf("VMs not read: %v", err))
+	}
+	topo.VMs = vms
+
+	dc := NewDockerController()
+	defer dc.Close() //nolint:errcheck
+	containers, err := dc.ContainerCPUSets()
+	if err != nil {
+		topo.Warnings = append(topo.Warnings, fmt.Sprintf("containers not read: %v", err))
+	}
+	topo.Containers = containers
+
+	assignCPUGuests(topo)
+	return topo, nil
+}
+
+// readCPUTopology reads the online CPUs, their core and package, and the
+// isolated CPUs from sysfs.
+func readCPUTopology() (*dto.CPUTopology, error) {
+	online, err := os.ReadFile(filepath.Join(cpuSysDir, "online")) //nolint:gosec // G304: fixed sysfs path
+	if err != nil {
+		return nil, fmt.Errorf("read online CPUs: %w", err)
+	}
+	cpus, err := parseCPUList(string(online))
+	if err != nil {
+		return nil, fmt.Errorf("parse online CPUs: %w", err)
+	}
+	isolated, _ := parseCPUList(readCPUSysfs("isolated"))
+
+	topo := &dto.CPUTopology{
+		CPUs:       make([]dto.CPUThread, 0, len(cpus)),
+		Isolated:   isolated,
+		VMs:        []dto.VMCPUPinning{},
+		Containers: []dto.ContainerCPUSet{},
+		Timestamp:  time.Now(),
+	}
+	if topo.Isolated == nil {
+		topo.Isolated = []int{}
+	}
+	if cmdline, err := os.ReadFile(cpuKernelCmdline); err == nil {
+		for field := range strings.FieldsSeq(string(cmdline)) {
+			if v, ok := strings.CutPrefix(field, "isolcpus="); ok {
+				topo.IsolcpusParam = v
+			}
+		}
+	}
+	for _, cpu := range cpus {
+		dir := fmt.Sprintf("cpu%d/topology/", cpu)
+		thread := dto.CPUThread{CPU: cpu, Isolated: slices.Contains(isolated, cpu)}
+		thread.Core, _ = strconv.Atoi(readCPUSysfs(dir + "core_id"))
+		thread.Package, _ = strconv.Atoi(readCPUSysfs(dir + "physical_package_id"))
+		thread.Siblings, _ = parseCPUList(readCPUSysfs(dir + "thread_siblings_list"))
+		if thread.Siblings == nil {
+			thread.Siblings = []int{cpu}
+		}
+		topo.CPUs = append(topo.CPUs, thread)
+	}
+	return topo, nil
+}
+
+func readCPUSysfs(name string) string {
+	data, err := os.ReadFile(filepath.Join(cpuSysDir, name)) //nolint:gosec // G304: path under the CPU sysfs directory
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// assignCPUGuests lists on each CPU the VMs and containers pinned to it.
+func assignCPUGuests(topo *dto.CPUTopology) {
+	index := make(map[int]int, len(topo.CPUs))
+	for i, c := range topo.CPUs {
+		index[c.CPU] = i
+	}
+	add := func(cpus []int, fn func(*dto.CPUThread)) {
+		for _, cpu := range cpus {
+			if i, ok := index[cpu]; ok {
+				fn(&topo.CPUs[i])
+			}
+		}
+	}
+	for _, vm := range topo.VMs {
+		for _, pin := range vm.Pins {
+			add(pin.CPUs, func(t *dto.CPUThread) {
+				if !slices.Contains(t.VMs, vm.Name) {
+					t.VMs = append(t.VMs, vm.Name)
+				}
+			})
+		}
+	}
+	for _, c := range topo.Containers {
+		add(c.CPUs, func(t *dto.CPUThread) { t.Containers = append(t.Containers, c.Name) })
+	}
+}
+
+// parseCPUList parses a kernel CPU list such as "0-3,8,10-11". An empty list
+// returns nil.
+func parseCPUList(list string) ([]int, error) {
+	list = strings.TrimSpace(list)
+	if list == "" {
+		return nil, nil
+	}
+	var cpus []int
+	for part := range strings.SplitSeq(list, ",") {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, err := strconv.Atoi(lo)
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid CPU %q in %q", lo, list)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(hi); err != nil || last < first {
+				return nil, fmt.Errorf("invalid CPU range %q in %q", part, list)
+			}
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			if !slices.Contains(cpus, cpu) {
+				cpus = append(cpus, cpu)
+			}
+		}
+	}
+	slices.Sort(cpus)
+	return cpus, nil
+}
+
+// validateCPUSet parses a CPU list from a request and checks that every CPU
+// is online.
+func validateCPUSet(list string, online []int) ([]int, error) {
+	cpus, err := parseCPUList(list)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCPUPinning, err)
+	}
+	for _, cpu := range cpus {
+		if !slices.Contains(online, cpu) {
+			return nil, fmt.Errorf("%w: CPU %d is not online", ErrInvalidCPUPinning, cpu)
+		}
+	}
+	return cpus, nil
+}
+
+// onlineCPUs returns the online CPUs and those isolated with isolcpus.
+func onlineCPUs() (online, isolated []int, err error) {
+	topo, err := readCPUTopology()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, c := range topo.CPUs {
+		online = append(online, c.CPU)
+	}
+	return online, topo.Isolated, nil
+}
+
+// isolationWarnings notes when a container is pinned to isolated CPUs, where
+// the scheduler does not balance its threads, or a VM to CPUs that are not.
+func isolationWarnings(cpus, isolated []int, vm bool) []string {
+	var shared, iso []int
+	for _, cpu := range cpus {
+		if slices.Contains(isolated, cpu) {
+			iso = append(iso, cpu)
+		} else {
+			shared = append(shared, cpu)
+		}
+	}
+	switch {
+	case vm && len(isolated) > 0 && len(shared) > 0:
+		return []string{fmt.Sprintf("CPUs %s are not isolated; host processes and containers may also run on them", formatCPUList(shared))}
+	case !vm && len(iso) > 0:
+		return []string{fmt.Sprintf("CPUs %s are isolated; the scheduler does not balance the container's threads across them", formatCPUList(iso))}
+	}
+	return nil
+}
+
+// formatCPUList formats sorted CPUs as a kernel CPU list, e.g. "0-3,8".
+func formatCPUList(cpus []int) string {
+	var parts []string
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(cpus[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// cpuMap builds a libvirt CPU bitmap covering every online CPU.
+func cpuMap(cpus, online []int) []byte {
+	maxCPU := 0
+	if len(online) > 0 {
+		maxCPU = slices.Max(online)
+	}
+	m := make([]byte, maxCPU/8+1)
+	for _, cpu := range cpus {
+		m[cpu/8] |= 1 << (cpu % 8)
+	}
+	return m
+}
+
+// domainCPUTuneXML is the subset of a libvirt domain definition describing
+// vCPUs and their pinning.
+type domainCPUTuneXML struct {
+	VCPU    int `xml:"vcpu"`
+	CPUTune struct {
+		VCPUPins []struct {
+			VCPU   int    `xml:"vcpu,attr"`
+			CPUSet string `xml:"cpuset,attr"`
+		} `xml:"vcpupin"`
+		EmulatorPin struct {
+			CPUSet string `xml:"cpuset,attr"`
+		} `xml:"emulatorpin"`
+	} `xml:"cputune"`
+}
+
+// parseDomainCPUTune returns the vCPU count and pinning of a libvirt domain
+// definition.
+func parseDomainCPUTune(domainXML string) (dto.VMCPUPinning, error) {
+	var def domainCPUTuneXML
+	if err := xml.Unmarshal([]byte(domainXML), &def); err != nil {
+		return dto.VMCPUPinning{}, fmt.Errorf("parse domain XML: %w", err)
+	}
+	pinning := dto.VMCPUPinning{
+		VCPUs:          def.VCPU,
+		Pins:           make([]dto.VCPUPin, 0, len(def.CPUTune.VCPUPins)),
+		EmulatorCPUSet: def.CPUTune.EmulatorPin.CPUSet,
+	}
+	for _, p := range def.CPUTune.VCPUPins {
+		cpus, _ := parseCPUList(p.CPUSet)
+		pinning.Pins = append(pinning.Pins, dto.VCPUPin{VCPU: p.VCPU, CPUSet: p.CPUSet, CPUs: cpus})
+	}
+	slices.SortFunc(pinning.Pins, func(a, b dto.VCPUPin) int { return a.VCPU - b.VCPU })
+	return pinning, nil
+}
+
+// CPUPinnings returns the vCPU pinning of every VM, read from the persistent
+// definition so shut-off VMs are included.
+func (vc *VMController) CPUPinnings() ([]dto.VMCPUPinning, error) {
+	uri, _ := url.Parse(string(libvirt.QEMUSystem))
+	l, err := libvirt.ConnectToURI(uri)
+	if err != nil {
+		return []dto.VMCPUPinning{}, fmt.Errorf("failed to connect to libvirt: %w", err)
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	domains, _, err := l.ConnectListAllDomains(1, 0)
+	if err != nil {
+		return []dto.VMCPUPinning{}, fmt.Errorf("failed to list VMs: %w", err)
+	}
+	pinnings := make([]dto.VMCPUPinning, 0, len(domains))
+	for _, d := range domains {
+		domainXML, err := l.DomainGetXMLDesc(d, libvirt.DomainXMLInactive)
+		if err != nil {
+			logger.Debug("VM: failed to get definition of %s: %v", d.Name, err)
+			continue
+		}
+		pinning, err := parseDomainCPUTune(domainXML)
+		if err != nil {
+			logger.Debug("VM: %s: %v", d.Name, err)
+			continue
+		}
+		pinning.Name = d.Name
+		if state, _, err := l.DomainGetState(d, 0); err == nil {
+			pinning.State = vmStateName(libvirt.DomainState(state))
+		}
+		pinnings = append(pinnings, pinning)
+	}
+	slices.SortFunc(pinnings, func(a, b dto.VMCPUPinning) int { return strings.Compare(a.Name, b.Name) })
+	return pinnings, nil
+}
+
+// vmStateName returns the state name used in VMInfo.State.
+func vmStateName(state libvirt.DomainState) string {
+	switch state {
+	case libvirt.DomainRunning:
+		return "running"
+	case libvirt.DomainPaused:
+		return "paused"
+	case libvirt.DomainShutdown:
+		return "shutdown"
+	case libvirt.DomainShutoff:
+		return "shut off"
+	case libvirt.DomainCrashed:
+		return "crashed"
+	case libvirt.DomainPmsuspended:
+		return "pmsuspended"
+	default:
+		return "unknown"
+	}
+}
+
+// SetCPUPinning replaces the vCPU pinning of a VM. The VM definition is
+// updated, and a running VM is re-pinned immediately. vCPUs that are not in
+// req.Pins are unpinned (allowed on every online CPU).
+func (vc *VMController) SetCPUPinning(vmName string, req dto.VMCPUPinningRequest) (*dto.CPUPinningResult, error) {
+	if !req.Confirm {
+		return nil, fmt.Errorf("%w: set confirm to true to change the VM's CPU pinning", ErrInvalidCPUPinning)
+	}
+	online, isolated, err := onlineCPUs()
+	if err != nil {
+		return nil, err
+	}
+
+	l, domain, err := vc.connect(vmName)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	domainXML, err := l.DomainGetXMLDesc(domain, libvirt.DomainXMLInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get definition of VM %s: %w", vmName, err)
+	}
+	current, err := parseDomainCPUTune(domainXML)
+	if err != nil {
+		return nil, err
+	}
+
+	pins := make(map[int][]int, current.VCPUs)
+	var used []int
+	for _, p := range req.Pins {
+		if p.VCPU < 0 || p.VCPU >= current.VCPUs {
+			return nil, fmt.Errorf("%w: VM %s has %d vCPUs; vCPU %d does not exist", ErrInvalidCPUPinning, vmName, current.VCPUs, p.VCPU)
+		}
+		cpus, err := validateCPUSet(p.CPUSet, online)
+		if err != nil {
+			return nil, err
+		}
+		if len(cpus) == 0 {
+			return nil, fmt.Errorf("%w: cpuset of vCPU %d is empty", ErrInvalidCPUPinning, p.VCPU)
+		}
+		pins[p.VCPU] = cpus
+		used = append(used, cpus...)
+	}
+	var emulator []int
+	if req.EmulatorCPUSet != "" {
+		if emulator, err = validateCPUSet(req.EmulatorCPUSet, online); err != nil {
+			return nil, err
+		}
+	}
+
+	state, _, err := l.DomainGetState(domain, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VM state: %w", err)
+	}
+	live := libvirt.DomainState(state) == libvirt.DomainRunning || libvirt.DomainState(state) == libvirt.DomainPaused
+	flags := libvirt.DomainAffectConfig
+	if live {
+		flags |= libvirt.DomainAffectLive
+	}
+
+	logger.Info("VM: Setting CPU pinning of %s (%d pinned vCPUs, live=%v)", vmName, len(pins), live)
+	for vcpu := range current.VCPUs {
+		cpus, ok := pins[vcpu]
+		if !ok {
+			cpus = online
+		}
+		if err := l.DomainPinVcpuFlags(domain, uint32(vcpu), cpuMap(cpus, online), uint32(flags)); err != nil { //nolint:gosec // G115: vcpu < VCPUs
+			return nil, fmt.Errorf("failed to pin vCPU %d of VM %s: %w", vcpu, vmName, err)
+		}
+	}
+	if emulator != nil {
+		if err := l.DomainPinEmulator(domain, cpuMap(emulator, online), flags); err != nil {
+			return nil, fmt.Errorf("failed to pin emulator threads of VM %s: %w", vmName, err)
+		}
+	}
+
+	slices.Sort(used)
+	return &dto.CPUPinningResult{
+		Name:      vmName,
+		Live:      live,
+		Persisted: true,
+		Message:   "CPU pinning updated",
+		Warnings:  isolationWarnings(slices.Compact(used), isolated, true),
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// ContainerCPUSets returns the cpuset of every container.
+func (dc *DockerController) ContainerCPUSets() ([]dto.ContainerCPUSet, error) {
+	if err := dc.initClient(); err != nil {
+		return []dto.ContainerCPUSet{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	listResult, err := dc.client.ContainerList(ctx, client.ContainerListOptions{All: true})
+	if err != nil {
+		return []dto.ContainerCPUSet{}, fmt.Errorf("failed to list containers: %w", err)
+	}
+	sets := make([]dto.ContainerCPUSet, 0, len(listResult.Items))
+	for _, c := range listResult.Items {
+		set := dto.ContainerCPUSet{ID: shortID(c.ID), State: string(c.State)}
+		if len(c.Names) > 0 {
+			set.Name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		inspect, err := dc.client.ContainerInspect(ctx, c.ID, client.ContainerInspectOptions{})
+		if err != nil {
+			logger.Debug("Docker: failed to inspect %s: %v", set.Name, err)
+			continue
+		}
+		if hc := inspect.Container.HostConfig; hc != nil {
+			set.CPUSet = hc.CpusetCpus
+			set.CPUs, _ = parseCPUList(hc.CpusetCpus)
+		}
+		sets = append(sets, set)
+	}
+	slices.SortFunc(sets, func(a, b dto.ContainerCPUSet) int { return strings.Compare(a.Name, b.Name) })
+	return sets, nil
+}
+
+// SetCPUSet changes the cpuset of a container in place (docker update
+// --cpuset-cpus) and saves it in the container's Unraid template, when it has
+// one, so the pinning survives the container being recreated.
+func (dc *DockerController) SetCPUSet(containerRef string, req dto.ContainerCPUSetRequest) (*dto.CPUPinningResult, error) {
+	if !req.Confirm {
+		return nil, fmt.Errorf("%w: set confirm to true to change the container's cpuset", ErrInvalidCPUPinning)
+	}
+	online, isolated, err := onlineCPUs()
+	if err != nil {
+		return nil, err
+	}
+	cpus, err := validateCPUSet(req.CPUSet, online)
+	if err != nil {
+		return nil, err
+	}
+	cpuset := formatCPUList(cpus)
+	if cpuset == "" {
+		// Docker keeps the current cpuset for an empty value; every online
+		// CPU removes the restriction.
+		cpuset = formatCPUList(online)
+	}
+
+	if err := dc.initClient(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	inspect, err := dc.client.ContainerInspect(ctx, containerRef, client.ContainerInspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container %s: %w", containerRef, err)
+	}
+	name := strings.TrimPrefix(inspect.Container.Name, "/")
+
+	logger.Info("Docker: Setting cpuset of %s to %q", name, cpuset)
+	result, err := dc.client.ContainerUpdate(ctx, containerRef, client.ContainerUpdateOptions{
+		Resources: &container.Resources{CpusetCpus: cpuset},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cpuset of container %s: %w", name, err)
+	}
+
+	res := &dto.CPUPinningResult{
+		Name:      name,
+		Live:      true,
+		Message:   "Container cpuset updated",
+		Warnings:  append(result.Warnings, isolationWarnings(cpus, isolated, false)...),
+		Timestamp: time.Now(),
+	}
+	persisted, err := saveTemplateCPUSet(name, formatCPUList(cpus))
+	switch {
+	case err != nil:
+		res.Warnings = append(res.Warnings, fmt.Sprintf("template not updated: %v", err))
+	case !persisted:
+		res.Warnings = append(res.Warnings, "container has no Unraid template; the cpuset is lost when it is recreated")
+	}
+	res.Persisted = persisted
+	return res, nil
+}
+
+// saveTemplateCPUSet writes cpuset to the CPUset element of a container's
+// dockerMan user template. It reports false when the container has no
+// template.
+func saveTemplateCPUSet(name, cpuset string) (bool, error) {
+	file := "my-" + name + ".xml"
+	path := filepath.Join(dockerTemplatesDir, file)
+	data, err := os.ReadFile(path) //nolint:gosec // G304: container name from Docker, under the template directory
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	element := "<CPUset>" + cpuset + "</CPUset>"
+	content := string(data)
+	switch {
+	case templateCPUSet.MatchString(content):
+		content = templateCPUSet.ReplaceAllLiteralString(content, element)
+	case strings.Contains(content, "</Container>"):
+		content = strings.Replace(content, "</Container>", "  "+element+"\n</Container>", 1)
+	default:
+		return false, fmt.Errorf("%s is not a dockerMan template", file)
+	}
+	if err := writeFileAtomic(dockerTemplatesDir, file, []byte(content)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParseCPUList(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    []int
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"0-3,8", []int{0, 1, 2, 3, 8}, false},
+		{" 10-11, 4 ,4\n", []int{4, 10, 11}, false},
+		{"3-1", nil, true},
+		{"a", nil, true},
+		{"-1", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCPUList(tt.list)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCPUList(%q) error = %v, wantErr %v", tt.list, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseCPUList(%q) = %v, want %v", tt.list, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCPUList(t *testing.T) {
+	if got := formatCPUList([]int{0, 1, 2, 3, 8, 10, 11}); got != "0-3,8,10-11" {
+		t.Errorf("formatCPUList = %q", got)
+	}
+	if got := formatCPUList(nil); got != "" {
+		t.Errorf("formatCPUList(nil) = %q", got)
+	}
+}
+
+func TestCPUMap(t *testing.T) {
+	got := cpuMap([]int{0, 9}, []int{0, 1, 2, 3, 8, 9, 10, 11})
+	if !slices.Equal(got, []byte{0x01, 0x02}) {
+		t.Errorf("cpuMap = %v", got)
+	}
+}
+
+func writeCPUFixture(t *testing.T, dir, name, value string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(value+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadCPUTopology(t *testing.T) {
+	dir := t.TempDir()
+	oldDir, oldCmdline := cpuSysDir, cpuKernelCmdline
+	cpuSysDir, cpuKernelCmdline = dir, filepath.Join(dir, "cmdline")
+	t.Cleanup(func() { cpuSysDir, cpuKernelCmdline = oldDir, oldCmdline })
+
+	writeCPUFixture(t, dir, "online", "0-3")
+	writeCPUFixture(t, dir, "isolated", "2-3")
+	writeCPUFixture(t, dir, "cmdline", "BOOT_IMAGE=/bzimage isolcpus=2-3 initrd=/bzroot")
+	for cpu, core := range []string{"0", "1", "0", "1"} {
+		base := "cpu" + strconv.Itoa(cpu) + "/topology/"
+		writeCPUFixture(t, dir, base+"core_id", core)
+		writeCPUFixture(t, dir, base+"physical_package_id", "0")
+		writeCPUFixture(t, dir, base+"thread_siblings_list", map[string]string{"0": "0,2", "1": "1,3"}[core])
+	}
+
+	topo, err := readCPUTopology()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if topo.IsolcpusParam != "2-3" || !slices.Equal(topo.Isolated, []int{2, 3}) {
+		t.Errorf("isolation = %q %v", topo.IsolcpusParam, topo.Isolated)
+	}
+	if len(topo.CPUs) != 4 {
+		t.Fatalf("got %d CPUs, want 4", len(topo.CPUs))
+	}
+	if c := topo.CPUs[3]; c.Core != 1 || !c.Isolated || !slices.Equal(c.Siblings, []int{1, 3}) {
+		t.Errorf("cpu3 = %+v", c)
+	}
+
+	topo.VMs = []dto.VMCPUPinning{{Name: "Windows 11", Pins: []dto.VCPUPin{{VCPU: 0, CPUs: []int{2}}, {VCPU: 1, CPUs: []int{2, 3}}}}}
+	topo.Containers = []dto.ContainerCPUSet{{Name: "plex", CPUs: []int{0, 1}}}
+	assignCPUGuests(topo)
+	if !slices.Equal(topo.CPUs[2].VMs, []string{"Windows 11"}) || !slices.Equal(topo.CPUs[0].Containers, []string{"plex"}) {
+		t.Errorf("guests not assigned: %+v", topo.CPUs)
+	}
+}
+
+func TestParseDomainCPUTune(t *testing.T) {
+	pinning, err := parseDomainCPUTune(`<domain type='kvm'>
+  <name>Windows 11</name>
+  <vcpu placement='static'>2</vcpu>
+  <cputune>
+    <vcpupin vcpu='1' cpuset='3'/>
+    <vcpupin vcpu='0' cpuset='2'/>
+    <emulatorpin cpuset='0'/>
+  </cputune>
+</domain>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pinning.VCPUs != 2 || pinning.EmulatorCPUSet != "0" || len(pinning.Pins) != 2 {
+		t.Fatalf("pinning = %+v", pinning)
+	}
+	if pinning.Pins[0].VCPU != 0 || !slices.Equal(pinning.Pins[0].CPUs, []int{2}) {
+		t.Errorf("pins not sorted by vCPU: %+v", pinning.Pins)
+	}
+}
+
+func TestValidateCPUSet(t *testing.T) {
+	if _, err := validateCPUSet("0-4", []int{0, 1, 2, 3}); !errors.Is(err, ErrInvalidCPUPinning) {
+		t.Errorf("offline CPU: err = %v", err)
+	}
+	if _, err := validateCPUSet("x", []int{0}); !errors.Is(err, ErrInvalidCPUPinning) {
+		t.Errorf("malformed list: err = %v", err)
+	}
+	if cpus, err := validateCPUSet("1,3", []int{0, 1, 2, 3}); err != nil || !slices.Equal(cpus, []int{1, 3}) {
+		t.Errorf("validateCPUSet = %v, %v", cpus, err)
+	}
+}
+
+func TestSetCPUPinningRequiresConfirm(t *testing.T) {
+	if _, err := NewVMController().SetCPUPinning("win11", dto.VMCPUPinningRequest{}); !errors.Is(err, ErrInvalidCPUPinning) {
+		t.Errorf("VM: err = %v", err)
+	}
+	if _, err := NewDockerController().SetCPUSet("plex", dto.ContainerCPUSetRequest{}); !errors.Is(err, ErrInvalidCPUPinning) {
+		t.Errorf("container: err = %v", err)
+	}
+}
+
+func TestSaveTemplateCPUSet(t *testing.T) {
+	dir := t.TempDir()
+	old := dockerTemplatesDir
+	dockerTemplatesDir = dir
+	t.Cleanup(func() { dockerTemplatesDir = old })
+
+	if ok, err := saveTemplateCPUSet("missing", "2"); ok || err != nil {
+		t.Errorf("missing template: %v, %v", ok, err)
+	}
+
+	tests := map[string]string{
+		"plex":   "<?xml version=\"1.0\"?>\n<Container version=\"2\">\n  <Name>plex</Name>\n  <CPUset/>\n</Container>\n",
+		"sonarr": "<?xml version=\"1.0\"?>\n<Container version=\"2\">\n  <Name>sonarr</Name>\n  <CPUset>0-1</CPUset>\n</Container>\n",
+		"radarr": "<?xml version=\"1.0\"?>\n<Container version=\"2\">\n  <Name>radarr</Name>\n</Container>\n",
+	}
+	for name, content := range tests {
+		writeCPUFixture(t, dir, "my-"+name+".xml", content)
+		ok, err := saveTemplateCPUSet(name, "2-3")
+		if !ok || err != nil {
+			t.Fatalf("%s: %v, %v", name, ok, err)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "my-"+name+".xml"))
+		if strings.Count(string(data), "<CPUset>2-3</CPUset>") != 1 || strings.Contains(string(data), "0-1") {
+			t.Errorf("%s template:\n%s", name, data)
+		}
+	}
+}
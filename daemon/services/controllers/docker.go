@@ -2,19 +2,25 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/netip"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/client"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 )
 
@@ -94,6 +100,28 @@ func (dc *DockerController) Stop(containerID string) error {
 	return nil
 }
 
+// RunningContainerNames returns the names of the running containers.
+func (dc *DockerController) RunningContainerNames() ([]string, error) {
+	if err := dc.initClient(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	listResult, err := dc.client.ContainerList(ctx, client.ContainerListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	names := make([]string, 0, len(listResult.Items))
+	for _, c := range listResult.Items {
+		if len(c.Names) > 0 {
+			names = append(names, strings.TrimPrefix(c.Names[0], "/"))
+		}
+	}
+	return names, nil
+}
+
 // Restart restarts a Docker container by ID or name using the Docker SDK.
 func (dc *DockerController) Restart(containerID string) error {
 	logger.Info("Restarting Docker container: %s", containerID)
@@ -194,10 +222,13 @@ func (dc *DockerController) Remove(containerID string, removeImage bool) error {
 // variable so tests can point it at a temp file without touching the real path.
 //
 // VERIFIED 2026-06-07 on Unraid 7.x (192.168.20.21):
-//   - /var/lib/docker/unraid-autostart contains one container NAME per line (no quotes,
-//     no extra fields), in the order Unraid starts them at boot. The file is the
-//     canonical source of truth for which containers auto-start — the WebUI reads/writes
-//     this file directly. Empty lines are ignored by Unraid.
+//   - /var/lib/docker/unraid-autostart contains one container NAME per line (no quotes),
+//     in the order Unraid starts them at boot. The file is the canonical source of truth
+//     for which containers auto-start — the WebUI reads/writes this file directly. Empty
+//     lines are ignored by Unraid.
+//   - A line may carry a second, space-separated field: the number of seconds Unraid
+//     waits after starting that container before starting the next one (the WebUI
+//     "wait" column). Lines without it have no wait.
 //   - /boot/config/plugins/dockerMan/userprefs.cfg holds the UI ordering (indexed
 //     key=value pairs) and is NOT the runtime autostart gate; do not write to it.
 var dockerAutostartFile = "/var/lib/docker/unraid-autostart"
@@ -221,6 +252,63 @@ func (dc *DockerController) SetAutostart(containerID string, enabled bool) error
 	return modifyAutostartFile(dockerAutostartFile, name, enabled)
 }
 
+// GetAutostart returns the autostart configuration: the containers in the
+// autostart file in start order, followed by the remaining containers in
+// containerNames (sorted by name) with autostart disabled. Names in the file
+// that are not in containerNames are still listed, since Unraid keeps entries
+// for containers that are recreated later.
+func (dc *DockerController) GetAutostart(containerNames []string) (*dto.DockerAutostart, error) {
+	entries, err := readAutostartFile(dockerAutostartFile)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]bool, len(entries))
+	result := &dto.DockerAutostart{
+		Containers: make([]dto.ContainerAutostartEntry, 0, len(entries)+len(containerNames)),
+		Timestamp:  time.Now(),
+	}
+	for i, e := range entries {
+		listed[e.Name] = true
+		result.Containers = append(result.Containers, dto.ContainerAutostartEntry{
+			Name:    e.Name,
+			Enabled: true,
+			Order:   i + 1,
+			Wait:    e.Wait,
+		})
+	}
+
+	others := make([]string, 0, len(containerNames))
+	for _, name := range containerNames {
+		if name != "" && !listed[name] {
+			listed[name] = true
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		result.Containers = append(result.Containers, dto.ContainerAutostartEntry{Name: name})
+	}
+	return result, nil
+}
+
+// SetAutostartOrder replaces the Unraid autostart list. Containers are started
+// at boot in the order given, waiting each entry's Wait seconds before starting
+// the next; containers not listed are not autostarted.
+func (dc *DockerController) SetAutostartOrder(entries []dto.ContainerAutostartRequestEntry) error {
+	logger.Info("Docker: SetAutostartOrder(%d containers)", len(entries))
+
+	list := make([]autostartLine, 0, len(entries))
+	for _, e := range entries {
+		list = append(list, autostartLine{Name: e.Name, Wait: e.Wait})
+	}
+	if err := writeAutostartFile(dockerAutostartFile, list); err != nil {
+		return err
+	}
+	logger.Info("Docker: autostart list replaced (%d containers)", len(list))
+	return nil
+}
+
 // resolveContainerName returns the plain container name for a given ID or name.
 // If the Docker daemon is unreachable (no client), the input is returned unchanged so
 // that callers that already have a plain name (e.g. from the cache) still work.
@@ -243,41 +331,75 @@ func (dc *DockerController) resolveContainerName(containerID string) (string, er
 	return name, nil
 }
 
+// autostartLine is one entry of the Unraid autostart file.
+type autostartLine struct {
+	Name string
+	Wait int
+}
+
+// readAutostartFile parses the autostart file at path. A missing file is an
+// empty list. Duplicate names keep their first position.
+func readAutostartFile(path string) ([]autostartLine, error) {
+	// path is the package-level dockerAutostartFile constant (or a test temp path) — not user input.
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a controlled constant, not user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read autostart file %s: %w", path, err)
+	}
+	return parseAutostart(string(data)), nil
+}
+
+// parseAutostart parses autostart file content: one "name [wait]" entry per line.
+func parseAutostart(content string) []autostartLine {
+	var entries []autostartLine
+	seen := make(map[string]bool)
+	for line := range strings.SplitSeq(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		entry := autostartLine{Name: fields[0]}
+		if len(fields) > 1 {
+			if wait, err := strconv.Atoi(fields[1]); err == nil && wait > 0 {
+				entry.Wait = wait
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
 // modifyAutostartFile is the pure, testable file-manipulation helper.
 // It reads the autostart file at path, adds or removes containerName, and writes the
-// result atomically. Order of existing entries is preserved; appends at end when adding.
+// result atomically. Order and wait times of existing entries are preserved; appends
+// at end when adding.
 func modifyAutostartFile(path, containerName string, enabled bool) error {
 	// Read existing entries (file may not exist yet — treat as empty).
-	// path is the package-level dockerAutostartFile constant (or a test temp path) — not user input.
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a controlled constant, not user input
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to read autostart file %s: %w", path, err)
+	entries, err := readAutostartFile(path)
+	if err != nil {
+		return err
 	}
 
-	// Parse: split on newlines, keep non-empty, non-duplicate names.
-	lines := strings.Split(string(data), "\n")
-	entries := make([]string, 0, len(lines))
 	found := false
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" {
-			continue
-		}
-		if trimmed == containerName {
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Name == containerName {
 			found = true
-			if enabled {
-				// Already present — keep it.
-				entries = append(entries, trimmed)
+			if !enabled {
+				// Skip (i.e. remove) this entry.
+				continue
 			}
-			// If !enabled: skip (i.e. remove) this entry.
-		} else {
-			entries = append(entries, trimmed)
 		}
+		kept = append(kept, e)
 	}
+	entries = kept
 
 	if enabled && !found {
 		// Container not in the list — append it.
-		entries = append(entries, containerName)
+		entries = append(entries, autostartLine{Name: containerName})
 	}
 
 	if !enabled && !found {
@@ -285,13 +407,30 @@ func modifyAutostartFile(path, containerName string, enabled bool) error {
 		logger.Info("Docker: autostart: %s was not in the list (no change)", containerName)
 	}
 
-	// Build file content: one name per line, trailing newline.
-	content := strings.Join(entries, "\n")
-	if len(entries) > 0 {
-		content += "\n"
+	if err := writeAutostartFile(path, entries); err != nil {
+		return err
+	}
+
+	action := "added to"
+	if !enabled {
+		action = "removed from"
+	}
+	logger.Info("Docker: %s autostart list (%s)", containerName, action)
+	return nil
+}
+
+// writeAutostartFile writes entries to path atomically (write to a temp file in
+// the same directory, then rename), one "name" or "name wait" per line.
+func writeAutostartFile(path string, entries []autostartLine) error {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(e.Name)
+		if e.Wait > 0 {
+			b.WriteString(" " + strconv.Itoa(e.Wait))
+		}
+		b.WriteString("\n")
 	}
 
-	// Atomic write: write to a temp file in the same directory, then rename.
 	dir := "."
 	if idx := strings.LastIndex(path, "/"); idx >= 0 {
 		dir = path[:idx]
@@ -302,7 +441,7 @@ func modifyAutostartFile(path, containerName string, enabled bool) error {
 	}
 	tmpName := tmp.Name()
 
-	if _, err := tmp.WriteString(content); err != nil {
+	if _, err := tmp.WriteString(b.String()); err != nil {
 		_ = tmp.Close()
 		_ = os.Remove(tmpName)
 		return fmt.Errorf("failed to write autostart temp file: %w", err)
@@ -315,12 +454,6 @@ func modifyAutostartFile(path, containerName string, enabled bool) error {
 		_ = os.Remove(tmpName)
 		return fmt.Errorf("failed to rename autostart temp file to %s: %w", path, err)
 	}
-
-	action := "added to"
-	if !enabled {
-		action = "removed from"
-	}
-	logger.Info("Docker: %s autostart list (%s)", containerName, action)
 	return nil
 }
 
@@ -646,6 +779,76 @@ func (dc *DockerController) GetContainerSize(containerRef string) (*dto.Containe
 	}, nil
 }
 
+// UpdateLimits changes the CPU and memory limits and restart policy of a
+// container in place (the equivalent of `docker update`) and returns the
+// resulting settings. Fields not set in req are left unchanged.
+func (dc *DockerController) UpdateLimits(containerRef string, req dto.ContainerLimitsRequest) (*dto.ContainerLimits, error) {
+	logger.Info("Updating resource limits for Docker container: %s", containerRef)
+
+	if err := lib.ValidateContainerLimits(req); err != nil {
+		return nil, err
+	}
+	if err := dc.initClient(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	opts := client.ContainerUpdateOptions{Resources: &container.Resources{}}
+	if req.CPUShares != nil {
+		opts.Resources.CPUShares = *req.CPUShares
+	}
+	if req.CPUQuota != nil {
+		opts.Resources.CPUQuota = *req.CPUQuota
+	}
+	if req.CPUPeriod != nil {
+		opts.Resources.CPUPeriod = *req.CPUPeriod
+	}
+	if req.MemoryBytes != nil {
+		opts.Resources.Memory = *req.MemoryBytes
+	}
+	if req.MemorySwapBytes != nil {
+		opts.Resources.MemorySwap = *req.MemorySwapBytes
+	}
+	if req.RestartPolicy != nil {
+		opts.RestartPolicy = &container.RestartPolicy{Name: container.RestartPolicyMode(*req.RestartPolicy)}
+		if req.RestartMaxRetries != nil {
+			opts.RestartPolicy.MaximumRetryCount = *req.RestartMaxRetries
+		}
+	}
+
+	result, err := dc.client.ContainerUpdate(ctx, containerRef, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update limits for container %s: %w", containerRef, err)
+	}
+
+	inspectResult, err := dc.client.ContainerInspect(ctx, containerRef, client.ContainerInspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container %s: %w", containerRef, err)
+	}
+
+	info := inspectResult.Container
+	limits := &dto.ContainerLimits{
+		ContainerID:   shortID(info.ID),
+		ContainerName: strings.TrimPrefix(info.Name, "/"),
+		Warnings:      result.Warnings,
+		Timestamp:     time.Now(),
+	}
+	if hc := info.HostConfig; hc != nil {
+		limits.CPUShares = hc.CPUShares
+		limits.CPUQuota = hc.CPUQuota
+		limits.CPUPeriod = hc.CPUPeriod
+		limits.MemoryBytes = hc.Memory
+		limits.MemorySwapBytes = hc.MemorySwap
+		limits.RestartPolicy = string(hc.RestartPolicy.Name)
+		limits.RestartMaxRetries = hc.RestartPolicy.MaximumRetryCount
+	}
+
+	logger.Info("Successfully updated resource limits for Docker container: %s", containerRef)
+	return limits, nil
+}
+
 // UpdateContainer updates a specific container by pulling the latest image and recreating it.
 func (dc *DockerController) UpdateContainer(containerRef string, force bool) (*dto.ContainerUpdateResult, error) {
 	logger.Info("Updating container: %s (force=%v)", containerRef, force)
@@ -881,6 +1084,114 @@ func (dc *DockerController) ListNetworks() ([]dto.DockerNetworkInfo, error) {
 	return result, nil
 }
 
+// ErrDockerNetworkInUse is returned when removing a network that still has
+// containers attached.
+var ErrDockerNetworkInUse = errors.New("docker network has containers attached")
+
+// ErrDockerNetworkNotFound is returned for an unknown network name or ID.
+var ErrDockerNetworkNotFound = errors.New("docker network not found")
+
+// CreateNetwork creates a custom Docker network. req must have passed
+// lib.ValidateDockerNetworkCreate. For macvlan/ipvlan networks the parent is
+// passed as the "parent" driver option, so Docker creates a dotted VLAN
+// sub-interface (br0.20) on demand, as Unraid's own custom networks do.
+func (dc *DockerController) CreateNetwork(req dto.DockerNetworkCreateRequest) (*dto.DockerNetworkCreateResult, error) {
+	logger.Info("Creating Docker network %s (%s)", req.Name, req.Driver)
+
+	if err := lib.ValidateDockerNetworkCreate(req); err != nil {
+		return nil, err
+	}
+	if err := dc.initClient(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	opts := client.NetworkCreateOptions{
+		Driver:     req.Driver,
+		Internal:   req.Internal,
+		Attachable: req.Attachable,
+		Options:    map[string]string{},
+	}
+	if req.Parent != "" {
+		opts.Options["parent"] = req.Parent
+	}
+	if req.IPVlanMode != "" {
+		opts.Options["ipvlan_mode"] = req.IPVlanMode
+	}
+	if req.Subnet != "" {
+		// Already validated, so parsing cannot fail.
+		ipam := network.IPAMConfig{Subnet: netip.MustParsePrefix(req.Subnet)}
+		if req.Gateway != "" {
+			ipam.Gateway = netip.MustParseAddr(req.Gateway)
+		}
+		if req.IPRange != "" {
+			ipam.IPRange = netip.MustParsePrefix(req.IPRange).Masked()
+		}
+		opts.IPAM = &network.IPAM{Driver: "default", Config: []network.IPAMConfig{ipam}}
+	}
+
+	result, err := dc.client.NetworkCreate(ctx, req.Name, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker network %s: %w", req.Name, err)
+	}
+
+	logger.Info("Created Docker network %s (%s)", req.Name, shortID(result.ID))
+	return &dto.DockerNetworkCreateResult{
+		ID:        result.ID,
+		Name:      req.Name,
+		Warnings:  result.Warning,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// RemoveNetwork removes a custom Docker network by name or ID. Networks with
+// containers attached are refused with ErrDockerNetworkInUse rather than
+// disconnecting the containers.
+func (dc *DockerController) RemoveNetwork(ref string) error {
+	logger.Info("Removing Docker network %s", ref)
+
+	if err := lib.ValidateDockerNetworkRef(ref); err != nil {
+		return err
+	}
+	if err := dc.initClient(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	inspect, err := dc.client.NetworkInspect(ctx, ref, client.NetworkInspectOptions{})
+	if err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrDockerNetworkNotFound, ref)
+		}
+		return fmt.Errorf("failed to inspect docker network %s: %w", ref, err)
+	}
+	if n := len(inspect.Network.Containers); n > 0 {
+		names := make([]string, 0, n)
+		for _, ep := range inspect.Network.Containers {
+			names = append(names, ep.Name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("%w: %s", ErrDockerNetworkInUse, strings.Join(names, ", "))
+	}
+	if slices.Contains([]string{"bridge", "host", "none"}, inspect.Network.Name) {
+		return fmt.Errorf("network %q is predefined by Docker", inspect.Network.Name)
+	}
+
+	if _, err := dc.client.NetworkRemove(ctx, inspect.Network.ID, client.NetworkRemoveOptions{}); err != nil {
+		if cerrdefs.IsNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrDockerNetworkNotFound, ref)
+		}
+		return fmt.Errorf("failed to remove docker network %s: %w", ref, err)
+	}
+
+	logger.Info("Removed Docker network %s", inspect.Network.Name)
+	return nil
+}
+
 // detectPortConflicts groups container names by (host port, protocol) and
 // returns entries where more than one container binds the same host port.
 // Input is keyed "<hostPort>/<proto>" → container names.
@@ -3,9 +3,12 @@ package controllers
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 )
 
 func TestNewDockerController(t *testing.T) {
@@ -115,6 +118,25 @@ func TestDockerControllerRestart(t *testing.T) {
 	})
 }
 
+func TestDockerControllerUpdateLimits(t *testing.T) {
+	dc := NewDockerController()
+	defer dc.Close()
+
+	t.Run("UpdateLimits rejects empty request", func(t *testing.T) {
+		if _, err := dc.UpdateLimits("plex", dto.ContainerLimitsRequest{}); err == nil {
+			t.Error("expected validation error for empty limits request")
+		}
+	})
+
+	t.Run("UpdateLimits with nonexistent container", func(t *testing.T) {
+		shares := int64(512)
+		_, err := dc.UpdateLimits("nonexistent-container-67890", dto.ContainerLimitsRequest{CPUShares: &shares})
+		if err == nil {
+			t.Log("Note: No error returned - Docker socket might not be available")
+		}
+	})
+}
+
 func TestDockerControllerClose(t *testing.T) {
 	dc := NewDockerController()
 
@@ -421,4 +443,59 @@ func TestSetAutostartFile(t *testing.T) {
 			t.Errorf("expected 'mycontainer' in temp file, got: %q", string(data))
 		}
 	})
+
+	t.Run("wait times are preserved", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "unraid-autostart")
+		if err := os.WriteFile(path, []byte("mariadb 30\nplex\nnextcloud 10\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := modifyAutostartFile(path, "plex", false); err != nil {
+			t.Fatalf("modifyAutostartFile(disable) error: %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		if got, want := string(data), "mariadb 30\nnextcloud 10\n"; got != want {
+			t.Errorf("file content = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestAutostartOrder(t *testing.T) {
+	orig := dockerAutostartFile
+	defer func() { dockerAutostartFile = orig }()
+	dockerAutostartFile = filepath.Join(t.TempDir(), "unraid-autostart")
+
+	dc := &DockerController{}
+	err := dc.SetAutostartOrder([]dto.ContainerAutostartRequestEntry{
+		{Name: "mariadb", Wait: 30},
+		{Name: "nextcloud"},
+	})
+	if err != nil {
+		t.Fatalf("SetAutostartOrder() error: %v", err)
+	}
+	data, _ := os.ReadFile(dockerAutostartFile)
+	if got, want := string(data), "mariadb 30\nnextcloud\n"; got != want {
+		t.Errorf("file content = %q, want %q", got, want)
+	}
+
+	got, err := dc.GetAutostart([]string{"plex", "nextcloud", "adminer", "mariadb"})
+	if err != nil {
+		t.Fatalf("GetAutostart() error: %v", err)
+	}
+	want := []dto.ContainerAutostartEntry{
+		{Name: "mariadb", Enabled: true, Order: 1, Wait: 30},
+		{Name: "nextcloud", Enabled: true, Order: 2},
+		{Name: "adminer"},
+		{Name: "plex"},
+	}
+	if !slices.Equal(got.Containers, want) {
+		t.Errorf("GetAutostart() = %+v, want %+v", got.Containers, want)
+	}
+
+	// An empty list disables autostart for everything.
+	if err := dc.SetAutostartOrder(nil); err != nil {
+		t.Fatalf("SetAutostartOrder(nil) error: %v", err)
+	}
+	if data, _ := os.ReadFile(dockerAutostartFile); len(data) != 0 {
+		t.Errorf("expected empty file, got %q", data)
+	}
 }
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// defaultLUKSKeyfile is where Unraid reads the array encryption key when
+// var.ini does not name a keyfile.
+const defaultLUKSKeyfile = "/root/keyfile"
+
+// unlockStartTimeout bounds waiting for the array to start after the key has
+// been supplied. Opening every encrypted device can take a while.
+var unlockStartTimeout = 3 * time.Minute
+
+// ErrWrongEncryptionKey is returned when the supplied key did not open every
+// encrypted device.
+var ErrWrongEncryptionKey = errors.New("the encryption key did not unlock all encrypted devices")
+
+// LUKSKeyfilePath returns the keyfile Unraid opens encrypted devices with.
+func LUKSKeyfilePath() string {
+	return luksKeyfilePath(constants.VarIni)
+}
+
+// luksKeyfilePath reads luksKeyfile from var.ini at path.
+func luksKeyfilePath(path string) string {
+	if value := readVarIniValue(path, "luksKeyfile"); value != "" {
+		return value
+	}
+	return defaultLUKSKeyfile
+}
+
+// readVarIniValue returns the unquoted value of key in an emhttp ini file,
+// or "" when it cannot be read.
+func readVarIniValue(path, key string) string {
+	file, err := os.Open(path) //nolint:gosec // G304: path is an emhttp state file
+	if err != nil {
+		return ""
+	}
+	defer file.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), key+"="); ok {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// UnlockAndStartArray starts an encrypted array with key, like entering the
+// passphrase or choosing a keyfile on the Main page: the key is written to
+// Unraid's keyfile and emhttpd starts the array, opening each encrypted
+// device. Unless keepKeyfile is set the keyfile is deleted again afterwards,
+// so the key does not stay in RAM.
+func (c *ArrayController) UnlockAndStartArray(key []byte, keepKeyfile bool) error {
+	if len(key) == 0 {
+		return errors.New("encryption key is empty")
+	}
+	if !lib.IsEmhttpdAvailable() {
+		return fmt.Errorf("array control unavailable: emhttpd socket not found at %s", lib.EmhttpdSocket)
+	}
+
+	keyfile := LUKSKeyfilePath()
+	if err := os.WriteFile(keyfile, key, 0o600); err != nil {
+		return fmt.Errorf("write keyfile: %w", err)
+	}
+	if !keepKeyfile {
+		defer func() {
+			if err := os.Remove(keyfile); err != nil && !os.IsNotExist(err) {
+				logger.Warning("Array: Failed to delete keyfile %s: %v", keyfile, err)
+			}
+		}()
+	}
+
+	logger.Info("Array: Starting encrypted array...")
+	if err := lib.EmhttpdRequest(map[string]string{"cmdStart": "Start"}); err != nil {
+		return fmt.Errorf("failed to start array: %w", err)
+	}
+
+	// Devices report a missing key (2) while the array is stopped, so only
+	// a wrong key (3) ends the wait early.
+	deadline := time.Now().Add(unlockStartTimeout)
+	for readArrayState(constants.VarIni) != "STARTED" {
+		if wrong := devicesInLUKSState(constants.DisksIni, "3"); len(wrong) > 0 {
+			return fmt.Errorf("%w: %s", ErrWrongEncryptionKey, strings.Join(wrong, ", "))
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("array did not start within %s", unlockStartTimeout)
+		}
+		time.Sleep(arrayPollInterval)
+	}
+	if locked := devicesInLUKSState(constants.DisksIni, "2", "3"); len(locked) > 0 {
+		return fmt.Errorf("%w: %s", ErrWrongEncryptionKey, strings.Join(locked, ", "))
+	}
+
+	logger.Info("Array: Encrypted array started successfully")
+	return nil
+}
+
+// ArrayEncrypted reports whether any array or pool device is LUKS-encrypted.
+func ArrayEncrypted() bool {
+	return hasEncryptedDevices(constants.DisksIni)
+}
+
+// hasEncryptedDevices reports whether disks.ini at path has a device with a
+// LUKS filesystem or a LUKS state set.
+func hasEncryptedDevices(path string) bool {
+	file, err := os.Open(path) //nolint:gosec // G304: path is the constant disks.ini location
+	if err != nil {
+		return false
+	}
+	defer file.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if value, ok := strings.CutPrefix(line, "fsType="); ok && strings.HasPrefix(strings.Trim(value, `"`), "luks:") {
+			return true
+		}
+		if value, ok := strings.CutPrefix(line, "luksState="); ok && !slices.Contains([]string{"", "0"}, strings.Trim(value, `"`)) {
+			return true
+		}
+	}
+	return false
+}
+
+// devicesInLUKSState lists the disks.ini slots whose luksState is one of
+// states (2 = key missing, 3 = wrong key).
+func devicesInLUKSState(path string, states ...string) []string {
+	file, err := os.Open(path) //nolint:gosec // G304: path is the constant disks.ini location
+	if err != nil {
+		return nil
+	}
+	defer file.Close() //nolint:errcheck
+
+	var locked []string
+	var slot string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			slot = strings.Trim(line, `[]"`)
+			continue
+		}
+		if value, ok := strings.CutPrefix(line, "luksState="); ok {
+			if slices.Contains(states, strings.Trim(value, `"`)) {
+				locked = append(locked, slot)
+			}
+		}
+	}
+	return locked
+}
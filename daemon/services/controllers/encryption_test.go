@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+)
+
+func TestLUKSKeyfilePath(t *testing.T) {
+	dir := t.TempDir()
+	varIni := filepath.Join(dir, "var.ini")
+
+	if got := luksKeyfilePath(varIni); got != defaultLUKSKeyfile {
+		t.Errorf("missing var.ini: got %q, want %q", got, defaultLUKSKeyfile)
+	}
+
+	if err := os.WriteFile(varIni, []byte("mdState=\"STOPPED\"\nluksKeyfile=\"/root/custom-keyfile\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := luksKeyfilePath(varIni); got != "/root/custom-keyfile" {
+		t.Errorf("got %q, want /root/custom-keyfile", got)
+	}
+}
+
+func TestDevicesInLUKSState(t *testing.T) {
+	disksIni := filepath.Join(t.TempDir(), "disks.ini")
+	content := `["parity"]
+name="parity"
+luksState="0"
+["disk1"]
+name="disk1"
+luksState="1"
+["disk2"]
+name="disk2"
+luksState="3"
+["cache"]
+name="cache"
+luksState="2"
+`
+	if err := os.WriteFile(disksIni, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := devicesInLUKSState(disksIni, "3"); !slices.Equal(got, []string{"disk2"}) {
+		t.Errorf("wrong key = %v, want [disk2]", got)
+	}
+	if got := devicesInLUKSState(disksIni, "2", "3"); !slices.Equal(got, []string{"disk2", "cache"}) {
+		t.Errorf("locked = %v, want [disk2 cache]", got)
+	}
+	if got := devicesInLUKSState(filepath.Join(t.TempDir(), "missing.ini"), "3"); got != nil {
+		t.Errorf("missing file = %v, want nil", got)
+	}
+}
+
+func TestHasEncryptedDevices(t *testing.T) {
+	dir := t.TempDir()
+	for name, tt := range map[string]struct {
+		content string
+		want    bool
+	}{
+		"plain":         {"[\"disk1\"]\nfsType=\"xfs\"\nluksState=\"0\"\n", false},
+		"luks fsType":   {"[\"disk1\"]\nfsType=\"luks:btrfs\"\nluksState=\"0\"\n", true},
+		"luks unlocked": {"[\"cache\"]\nfsType=\"\"\nluksState=\"1\"\n", true},
+	} {
+		path := filepath.Join(dir, name+".ini")
+		if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if got := hasEncryptedDevices(path); got != tt.want {
+			t.Errorf("%s: got %v, want %v", name, got, tt.want)
+		}
+	}
+	if hasEncryptedDevices(filepath.Join(dir, "missing.ini")) {
+		t.Error("missing file reported encrypted devices")
+	}
+}
+
+func TestUnlockAndStartArrayEmptyKey(t *testing.T) {
+	c := NewArrayController(&domain.Context{})
+	if err := c.UnlockAndStartArray(nil, false); err == nil {
+		t.Fatal("expected error for empty key")
+	}
+}
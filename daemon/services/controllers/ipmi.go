@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// ErrInvalidIPMIAction is returned for an unknown chassis action or an
+// out-of-range identify duration.
+var ErrInvalidIPMIAction = errors.New("invalid IPMI chassis action")
+
+// IPMIChassisActionIdentify blinks the chassis identify LED. Every other
+// action in ipmiPowerActions changes the power state of the server.
+const IPMIChassisActionIdentify = "identify"
+
+// ipmiPowerActions are the `ipmitool chassis power` commands the API allows.
+// "on" is left out: a BMC is only reachable in-band while the server runs.
+var ipmiPowerActions = []string{"soft", "off", "cycle", "reset"}
+
+// ipmiExec runs ipmitool; a package-level variable so tests can substitute it.
+var ipmiExec = func(args ...string) error {
+	_, err := lib.ExecCommandWithTimeout(30*time.Second, constants.IpmitoolBin, args...)
+	return err
+}
+
+// IsIPMIPowerAction reports whether action changes the server's power state
+// (and therefore needs confirmation).
+func IsIPMIPowerAction(action string) bool {
+	return slices.Contains(ipmiPowerActions, action)
+}
+
+// IPMIChassisAction sends a chassis command to the BMC: identify (blink the
+// identify LED for identifySeconds, default 15) or a power action (soft, off,
+// cycle, reset). Power actions bypass the OS, so the array is not stopped
+// cleanly; callers must confirm them.
+func IPMIChassisAction(action string, identifySeconds int) error {
+	var args []string
+	switch {
+	case action == IPMIChassisActionIdentify:
+		if identifySeconds == 0 {
+			identifySeconds = 15
+		}
+		if identifySeconds < 1 || identifySeconds > 255 {
+			return fmt.Errorf("%w: identify_seconds must be between 1 and 255", ErrInvalidIPMIAction)
+		}
+		args = []string{"chassis", "identify", strconv.Itoa(identifySeconds)}
+	case IsIPMIPowerAction(action):
+		args = []string{"chassis", "power", action}
+	default:
+		return fmt.Errorf("%w %q: must be identify, soft, off, cycle or reset", ErrInvalidIPMIAction, action)
+	}
+
+	if err := requireBinary("IPMI", constants.IpmitoolBin); err != nil {
+		return err
+	}
+
+	logger.Warning("IPMI: Sending chassis command: %v", args[1:])
+	if err := ipmiExec(args...); err != nil {
+		return fmt.Errorf("ipmitool chassis %s: %w", action, err)
+	}
+	return nil
+}
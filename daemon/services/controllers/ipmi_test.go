@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIPMIChassisActionValidation(t *testing.T) {
+	var calls [][]string
+	orig := ipmiExec
+	ipmiExec = func(args ...string) error {
+		calls = append(calls, args)
+		return nil
+	}
+	defer func() { ipmiExec = orig }()
+
+	tests := []struct {
+		name    string
+		action  string
+		seconds int
+	}{
+		{"unknown action", "explode", 0},
+		{"power on is not allowed", "on", 0},
+		{"identify too long", "identify", 300},
+		{"identify negative", "identify", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := IPMIChassisAction(tt.action, tt.seconds); !errors.Is(err, ErrInvalidIPMIAction) {
+				t.Errorf("IPMIChassisAction(%q, %d) = %v, want ErrInvalidIPMIAction", tt.action, tt.seconds, err)
+			}
+		})
+	}
+	if len(calls) != 0 {
+		t.Errorf("ipmitool called for invalid actions: %v", calls)
+	}
+}
+
+func TestIsIPMIPowerAction(t *testing.T) {
+	for _, action := range []string{"soft", "off", "cycle", "reset"} {
+		if !IsIPMIPowerAction(action) {
+			t.Errorf("IsIPMIPowerAction(%q) = false", action)
+		}
+	}
+	for _, action := range []string{"identify", "on", ""} {
+		if IsIPMIPowerAction(action) {
+			t.Errorf("IsIPMIPowerAction(%q) = true", action)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// ErrInvalidMDTuning is returned for md tuning values out of range.
+var ErrInvalidMDTuning = errors.New("invalid md tuning request")
+
+const (
+	// Sysctls holding the md resync speed limits in KB/s.
+	sysctlSyncSpeedMin = "dev.raid.speed_limit_min"
+	sysctlSyncSpeedMax = "dev.raid.speed_limit_max"
+
+	maxSyncSpeedKB = 10_000_000
+	minNrRequests  = 4
+	maxNrRequests  = 8192
+)
+
+// Package-level variables so tests can use fixture files.
+var (
+	mdstatFile    = constants.ProcMdstat
+	mdSysBlockDir = "/sys/block"
+)
+
+// GetMDStat returns /proc/mdstat as text and parsed, with the resync speed
+// limits and the queue depth of each array device.
+func GetMDStat() (*dto.MDStat, error) {
+	data, err := os.ReadFile(mdstatFile) //nolint:gosec // G304: fixed /proc/mdstat path
+	if err != nil {
+		return nil, fmt.Errorf("read mdstat: %w", err)
+	}
+	stat := parseMDStat(string(data))
+	for i := range stat.Devices {
+		stat.Devices[i].NrRequests = readNrRequests(stat.Devices[i].Device)
+	}
+	stat.Tuning.SyncSpeedMinKB, _ = lib.ReadSysctlInt(sysctlSyncSpeedMin)
+	stat.Tuning.SyncSpeedMaxKB, _ = lib.ReadSysctlInt(sysctlSyncSpeedMax)
+	stat.Timestamp = time.Now()
+	return stat, nil
+}
+
+// parseMDStat parses the key=value lines of /proc/mdstat. Keys with a
+// ".<slot>" suffix describe array devices; the others are global.
+func parseMDStat(raw string) *dto.MDStat {
+	stat := &dto.MDStat{Raw: raw, Global: map[string]string{}, Devices: []dto.MDDevice{}}
+	slots := map[int]map[string]string{}
+	for line := range strings.SplitSeq(raw, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || key == "" {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		if name, idx, found := strings.Cut(key, "."); found {
+			if slot, err := strconv.Atoi(idx); err == nil {
+				if slots[slot] == nil {
+					slots[slot] = map[string]string{}
+				}
+				slots[slot][name] = value
+				continue
+			}
+		}
+		stat.Global[key] = value
+	}
+
+	g := stat.Global
+	stat.State = g["mdState"]
+	stat.NumDisks = atoiOrZero(g["mdNumDisks"])
+	stat.NumDisabled = atoiOrZero(g["mdNumDisabled"])
+	stat.NumInvalid = atoiOrZero(g["mdNumInvalid"])
+	stat.NumMissing = atoiOrZero(g["mdNumMissing"])
+	stat.SyncErrors = parseUintOrZero(g["sbSyncErrs"])
+	stat.LastSyncExit = atoiOrZero(g["sbSyncExit"])
+
+	// Positions and sizes are in 1 KiB blocks.
+	pos, size := parseUintOrZero(g["mdResyncPos"]), parseUintOrZero(g["mdResyncSize"])
+	if pos > 0 {
+		dt, db := parseUintOrZero(g["mdResyncDt"]), parseUintOrZero(g["mdResyncDb"])
+		stat.Resync = dto.MDResyncStatus{
+			Active:        true,
+			Paused:        dt == 0,
+			Action:        g["mdResyncAction"],
+			Correcting:    g["mdResyncCorr"] == "1",
+			PositionBytes: pos * 1024,
+			SizeBytes:     size * 1024,
+		}
+		if size > 0 {
+			stat.Resync.PercentComplete = float64(pos) / float64(size) * 100
+		}
+		if dt > 0 {
+			stat.Resync.SpeedBytes = db * 1024 / dt
+		}
+	}
+
+	order := make([]int, 0, len(slots))
+	for slot := range slots {
+		order = append(order, slot)
+	}
+	sort.Ints(order)
+	for _, slot := range order {
+		f := slots[slot]
+		// Unassigned slots are listed as not present.
+		if f["rdevStatus"] == "DISK_NP" && f["rdevName"] == "" {
+			continue
+		}
+		stat.Devices = append(stat.Devices, dto.MDDevice{
+			Slot:      slot,
+			Name:      f["diskName"],
+			Device:    f["rdevName"],
+			Status:    f["rdevStatus"],
+			ID:        f["rdevId"],
+			SizeBytes: parseUintOrZero(f["rdevSize"]) * 1024,
+			Reads:     parseUintOrZero(f["rdevReads"]),
+			Writes:    parseUintOrZero(f["rdevWrites"]),
+			Errors:    parseUintOrZero(f["rdevNumErrors"]),
+		})
+	}
+	return stat
+}
+
+// SetMDTuning applies the requested resync speed limits and queue depth.
+// Changes last until reboot.
+func SetMDTuning(req dto.MDTuningRequest) error {
+	if req.SyncSpeedMinKB == nil && req.SyncSpeedMaxKB == nil && req.NrRequests == nil {
+		return fmt.Errorf("%w: nothing to change", ErrInvalidMDTuning)
+	}
+
+	var minKB, maxKB int
+	if req.SyncSpeedMinKB != nil || req.SyncSpeedMaxKB != nil {
+		// The limit left unchanged is validated against the new one.
+		var err error
+		if minKB, err = resolveSyncSpeed(req.SyncSpeedMinKB, sysctlSyncSpeedMin); err != nil {
+			return err
+		}
+		if maxKB, err = resolveSyncSpeed(req.SyncSpeedMaxKB, sysctlSyncSpeedMax); err != nil {
+			return err
+		}
+		if err := validateSyncSpeeds(minKB, maxKB); err != nil {
+			return err
+		}
+	}
+	if req.NrRequests != nil && (*req.NrRequests < minNrRequests || *req.NrRequests > maxNrRequests) {
+		return fmt.Errorf("%w: nr_requests must be between %d and %d", ErrInvalidMDTuning, minNrRequests, maxNrRequests)
+	}
+
+	var devices []string
+	if req.NrRequests != nil {
+		data, err := os.ReadFile(mdstatFile) //nolint:gosec // G304: fixed /proc/mdstat path
+		if err != nil {
+			return fmt.Errorf("read mdstat: %w", err)
+		}
+		for _, d := range parseMDStat(string(data)).Devices {
+			if d.Device != "" {
+				devices = append(devices, d.Device)
+			}
+		}
+		if len(devices) == 0 {
+			return fmt.Errorf("%w: no array devices are assigned", ErrInvalidMDTuning)
+		}
+	}
+
+	if req.SyncSpeedMaxKB != nil {
+		if err := lib.WriteSysctl(sysctlSyncSpeedMax, strconv.Itoa(maxKB)); err != nil {
+			return err
+		}
+	}
+	if req.SyncSpeedMinKB != nil {
+		if err := lib.WriteSysctl(sysctlSyncSpeedMin, strconv.Itoa(minKB)); err != nil {
+			return err
+		}
+	}
+	var errs []error
+	for _, dev := range devices {
+		path := filepath.Join(mdSysBlockDir, dev, "queue", "nr_requests")
+		if err := os.WriteFile(path, []byte(strconv.Itoa(*req.NrRequests)), 0o644); err != nil { //nolint:gosec // G306: sysfs attribute
+			errs = append(errs, fmt.Errorf("set nr_requests on %s: %w", dev, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	logger.Info("Array: md tuning set (sync_speed_min=%s sync_speed_max=%s nr_requests=%s)",
+		formatOptionalInt(req.SyncSpeedMinKB), formatOptionalInt(req.SyncSpeedMaxKB), formatOptionalInt(req.NrRequests))
+	return nil
+}
+
+// resolveSyncSpeed returns the requested speed limit, or the current value
+// of key when none is requested.
+func resolveSyncSpeed(requested *int, key string) (int, error) {
+	if requested != nil {
+		return *requested, nil
+	}
+	return lib.ReadSysctlInt(key)
+}
+
+// validateSyncSpeeds checks the resulting resync speed limits.
+func validateSyncSpeeds(minKB, maxKB int) error {
+	if minKB < 1 || minKB > maxSyncSpeedKB || maxKB < 1 || maxKB > maxSyncSpeedKB {
+		return fmt.Errorf("%w: sync speed limits must be between 1 and %d KB/s", ErrInvalidMDTuning, maxSyncSpeedKB)
+	}
+	if minKB > maxKB {
+		return fmt.Errorf("%w: sync_speed_min (%d) must not exceed sync_speed_max (%d)", ErrInvalidMDTuning, minKB, maxKB)
+	}
+	return nil
+}
+
+// readNrRequests returns the block queue depth of device, or 0 if unknown.
+func readNrRequests(device string) int {
+	if device == "" || strings.ContainsAny(device, "/.") {
+		return 0
+	}
+	data, err := os.ReadFile(filepath.Join(mdSysBlockDir, device, "queue", "nr_requests")) //nolint:gosec // G304: device name from mdstat
+	if err != nil {
+		return 0
+	}
+	return atoiOrZero(strings.TrimSpace(string(data)))
+}
+
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func parseUintOrZero(s string) uint64 {
+	n, _ := strconv.ParseUint(s, 10, 64)
+	return n
+}
+
+func formatOptionalInt(n *int) string {
+	if n == nil {
+		return "unchanged"
+	}
+	return strconv.Itoa(*n)
+}
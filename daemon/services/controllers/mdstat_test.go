@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+const testMdstat = `sbName=/boot/config/super.dat
+sbSyncErrs=3
+sbSyncExit=0
+mdState=STARTED
+mdNumDisks=3
+mdNumDisabled=0
+mdNumInvalid=0
+mdNumMissing=0
+mdResyncAction=check P
+mdResyncSize=1000000
+mdResyncCorr=1
+mdResync=1000000
+mdResyncPos=250000
+mdResyncDt=10
+mdResyncDb=2000000
+diskName.0=
+rdevStatus.0=DISK_OK
+rdevName.0=sdb
+rdevId.0=WDC_WD120EFBX_PARITY
+rdevSize.0=1000000
+rdevReads.0=100
+rdevWrites.0=200
+rdevNumErrors.0=0
+diskName.1=md1
+rdevStatus.1=DISK_OK
+rdevName.1=sdc
+rdevId.1=WDC_WD120EFBX_DATA
+rdevSize.1=1000000
+rdevReads.1=300
+rdevWrites.1=400
+rdevNumErrors.1=2
+diskName.2=
+rdevStatus.2=DISK_NP
+rdevName.2=
+`
+
+// setupMdstatFixture writes testMdstat and a /sys/block tree for its devices.
+func setupMdstatFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mdstat")
+	if err := os.WriteFile(path, []byte(testMdstat), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, dev := range []string{"sdb", "sdc"} {
+		queue := filepath.Join(dir, "block", dev, "queue")
+		if err := os.MkdirAll(queue, 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(queue, "nr_requests"), []byte("128\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldFile, oldBlock := mdstatFile, mdSysBlockDir
+	mdstatFile, mdSysBlockDir = path, filepath.Join(dir, "block")
+	t.Cleanup(func() { mdstatFile, mdSysBlockDir = oldFile, oldBlock })
+	return dir
+}
+
+func TestGetMDStat(t *testing.T) {
+	setupMdstatFixture(t)
+
+	stat, err := GetMDStat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Raw != testMdstat || stat.Global["sbName"] != "/boot/config/super.dat" {
+		t.Error("raw text or global keys not preserved")
+	}
+	if stat.State != "STARTED" || stat.NumDisks != 3 || stat.SyncErrors != 3 {
+		t.Errorf("array fields = %+v", stat)
+	}
+
+	want := dto.MDResyncStatus{
+		Active: true, Action: "check P", Correcting: true,
+		PositionBytes: 250000 * 1024, SizeBytes: 1000000 * 1024,
+		PercentComplete: 25, SpeedBytes: 200000 * 1024,
+	}
+	if stat.Resync != want {
+		t.Errorf("resync = %+v, want %+v", stat.Resync, want)
+	}
+
+	if len(stat.Devices) != 2 {
+		t.Fatalf("devices = %+v, want the unassigned slot skipped", stat.Devices)
+	}
+	disk1 := stat.Devices[1]
+	if disk1.Slot != 1 || disk1.Name != "md1" || disk1.Device != "sdc" || disk1.Errors != 2 ||
+		disk1.SizeBytes != 1000000*1024 || disk1.NrRequests != 128 {
+		t.Errorf("disk1 = %+v", disk1)
+	}
+}
+
+func TestParseMDStatPausedAndIdle(t *testing.T) {
+	paused := parseMDStat("mdResyncPos=100\nmdResyncSize=400\nmdResyncDt=0\nmdResyncDb=0\n")
+	if !paused.Resync.Active || !paused.Resync.Paused || paused.Resync.SpeedBytes != 0 {
+		t.Errorf("paused resync = %+v", paused.Resync)
+	}
+	idle := parseMDStat("mdResyncPos=0\nmdResyncSize=400\n")
+	if idle.Resync.Active {
+		t.Errorf("idle resync = %+v", idle.Resync)
+	}
+}
+
+func TestSetMDTuningNrRequests(t *testing.T) {
+	dir := setupMdstatFixture(t)
+
+	n := 256
+	if err := SetMDTuning(dto.MDTuningRequest{NrRequests: &n}); err != nil {
+		t.Fatal(err)
+	}
+	for _, dev := range []string{"sdb", "sdc"} {
+		data, err := os.ReadFile(filepath.Join(dir, "block", dev, "queue", "nr_requests"))
+		if err != nil || string(data) != "256" {
+			t.Errorf("%s nr_requests = %q, %v", dev, data, err)
+		}
+	}
+}
+
+func TestSetMDTuningValidation(t *testing.T) {
+	setupMdstatFixture(t)
+	ptr := func(n int) *int { return &n }
+
+	for name, req := range map[string]dto.MDTuningRequest{
+		"empty":             {},
+		"min above max":     {SyncSpeedMinKB: ptr(200000), SyncSpeedMaxKB: ptr(1000)},
+		"zero speed":        {SyncSpeedMinKB: ptr(0), SyncSpeedMaxKB: ptr(1000)},
+		"speed too high":    {SyncSpeedMinKB: ptr(1000), SyncSpeedMaxKB: ptr(maxSyncSpeedKB + 1)},
+		"nr_requests small": {NrRequests: ptr(2)},
+		"nr_requests large": {NrRequests: ptr(maxNrRequests + 1)},
+	} {
+		if err := SetMDTuning(req); !errors.Is(err, ErrInvalidMDTuning) {
+			t.Errorf("%s: err = %v, want ErrInvalidMDTuning", name, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// RunMover starts the mover and waits until it has finished moving files,
+// like the Move button on the Main page. Cancelling ctx kills the mover
+// script; use StopMover to stop it cleanly.
+func RunMover(ctx context.Context) (string, error) {
+	logger.InfoContext(ctx, "Mover: Starting mover")
+	out, err := lib.ExecCommandOutputWithContext(ctx, constants.MoverBin, "start")
+	out = strings.TrimSpace(out)
+	if err != nil {
+		return out, fmt.Errorf("mover failed: %w", err)
+	}
+	logger.InfoContext(ctx, "Mover: Finished")
+	return out, nil
+}
+
+// StopMover asks a running mover to stop after the file it is moving.
+func StopMover() error {
+	logger.Info("Mover: Stopping mover")
+	if out, err := lib.ExecCommandOutput(constants.MoverBin, "stop"); err != nil {
+		return fmt.Errorf("stop mover: %s: %w", strings.TrimSpace(out), err)
+	}
+	return nil
+}
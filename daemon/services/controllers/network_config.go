@@ -0,0 +1,730 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+var (
+	// ErrInvalidNetworkConfig is returned for an invalid network update.
+	ErrInvalidNetworkConfig = errors.New("invalid network configuration")
+
+	// ErrNetworkChangePending is returned when a change is applied while the
+	// previous one still waits for confirmation.
+	ErrNetworkChangePending = errors.New("a network change is waiting for confirmation")
+
+	// ErrNoNetworkChangePending is returned when confirming or rolling back
+	// without a pending change.
+	ErrNoNetworkChangePending = errors.New("no network change is waiting for confirmation")
+)
+
+// Network change states reported in dto.NetworkChange.
+const (
+	NetworkChangePending    = "pending"
+	NetworkChangeConfirmed  = "confirmed"
+	NetworkChangeRolledBack = "rolled_back"
+)
+
+const (
+	defaultNetworkRollback = 120 * time.Second
+	minNetworkRollback     = 30 * time.Second
+	maxNetworkRollback     = 600 * time.Second
+)
+
+// Package-level variables so tests can substitute the file and the restart.
+var (
+	networkCfgPath = constants.NetworkCfg
+	networkRestart = func() error {
+		_, err := lib.ExecCommandWithTimeout(2*time.Minute, constants.RcInet1Bin, "restart")
+		return err
+	}
+	// networkApplyDelay lets the API response reach the client before the
+	// interfaces go down.
+	networkApplyDelay = 2 * time.Second
+	// networkStateDir holds the pending change record on the flash drive.
+	networkStateDir = "/boot/config/plugins/unraid-management-agent"
+)
+
+// networkChangeFile records a change waiting for confirmation, so it is
+// still rolled back when the agent restarts or the server reboots first.
+const networkChangeFile = "network_change.json"
+
+// networkChangeRecord is the persisted form of a pending change.
+type networkChangeRecord struct {
+	Backup     string    `json:"backup"`
+	AppliedAt  time.Time `json:"applied_at"`
+	RollbackAt time.Time `json:"rollback_at"`
+}
+
+// networkChange is the change applied by UpdateNetworkSettings. backup holds
+// the previous network.cfg until the change is confirmed or rolled back.
+var networkChange struct {
+	sync.Mutex
+	state  *dto.NetworkChange
+	backup []byte
+	timer  *time.Timer
+}
+
+// networkRestartMu serializes rc.inet1 restarts of an apply and a rollback.
+var networkRestartMu sync.Mutex
+
+var (
+	netCfgIndexedKey   = regexp.MustCompile(`^([A-Z0-9_]+)\[(\d+)(?:,(\d+))?\]$`)
+	netCfgPortPattern  = regexp.MustCompile(`^eth(\d+)$`)
+	netCfgTextPattern  = regexp.MustCompile(`^[^"\\$` + "`" + `\r\n]{0,64}$`)
+	validBondingModes  = []string{"0", "1", "2", "3", "4", "5", "6"}
+	maxStaticDNSServer = 3
+)
+
+// netCfgLine is a network.cfg line: a KEY="value" assignment, or a comment
+// or blank line kept verbatim in raw.
+type netCfgLine struct {
+	key, value, raw string
+}
+
+// netCfg is network.cfg in file order, so rewriting it keeps keys the API
+// does not manage (IPv6, metrics, ...) and the generated-settings comment.
+type netCfg struct {
+	lines []netCfgLine
+}
+
+func parseNetCfg(data []byte) *netCfg {
+	c := &netCfg{}
+	for line := range strings.SplitSeq(strings.TrimRight(string(data), "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		key, value, ok := strings.Cut(trimmed, "=")
+		if !ok || strings.HasPrefix(trimmed, "#") {
+			c.lines = append(c.lines, netCfgLine{raw: line})
+			continue
+		}
+		c.lines = append(c.lines, netCfgLine{key: strings.TrimSpace(key), value: strings.Trim(strings.TrimSpace(value), `"`)})
+	}
+	return c
+}
+
+func (c *netCfg) get(key string) string {
+	for _, l := range c.lines {
+		if l.key == key {
+			return l.value
+		}
+	}
+	return ""
+}
+
+// set updates key in place, or appends it.
+func (c *netCfg) set(key, value string) {
+	for i := range c.lines {
+		if c.lines[i].key == key {
+			c.lines[i].value = value
+			return
+		}
+	}
+	c.lines = append(c.lines, netCfgLine{key: key, value: value})
+}
+
+func (c *netCfg) del(keys ...string) {
+	c.lines = slices.DeleteFunc(c.lines, func(l netCfgLine) bool {
+		return l.key != "" && slices.Contains(keys, l.key)
+	})
+}
+
+func (c *netCfg) bytes() []byte {
+	var b bytes.Buffer
+	for _, l := range c.lines {
+		if l.key == "" {
+			b.WriteString(l.raw)
+		} else {
+			fmt.Fprintf(&b, "%s=\"%s\"", l.key, l.value)
+		}
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// ports returns the port indexes (N of IPADDR[N]) in the file, sorted.
+func (c *netCfg) ports() []int {
+	var ports []int
+	for _, l := range c.lines {
+		if m := netCfgIndexedKey.FindStringSubmatch(l.key); m != nil {
+			if n, err := strconv.Atoi(m[2]); err == nil && !slices.Contains(ports, n) {
+				ports = append(ports, n)
+			}
+		}
+	}
+	slices.Sort(ports)
+	return ports
+}
+
+// vlanIndexes returns the VLAN indexes (i of VLANID[N,i]) of port n, sorted.
+func (c *netCfg) vlanIndexes(n int) []int {
+	var idx []int
+	for _, l := range c.lines {
+		if m := netCfgIndexedKey.FindStringSubmatch(l.key); m != nil && m[1] == "VLANID" && m[3] != "" && m[2] == strconv.Itoa(n) {
+			if i, err := strconv.Atoi(m[3]); err == nil {
+				idx = append(idx, i)
+			}
+		}
+	}
+	slices.Sort(idx)
+	return idx
+}
+
+func portKey(name string, n int) string {
+	return fmt.Sprintf("%s[%d]", name, n)
+}
+
+func vlanKey(name string, n, i int) string {
+	return fmt.Sprintf("%s[%d,%d]", name, n, i)
+}
+
+// splitNICs splits a BONDNICS/BRNICS list, which Unraid versions write
+// comma- or space-separated.
+func splitNICs(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+func networkSettingsFromCfg(c *netCfg) *dto.NetworkSettings {
+	settings := &dto.NetworkSettings{
+		Interfaces: []dto.NetworkInterfaceSettings{},
+		DNSServers: []string{},
+		Timestamp:  time.Now(),
+	}
+	for n := range maxStaticDNSServer {
+		if dns := c.get(fmt.Sprintf("DNS_SERVER%d", n+1)); dns != "" {
+			settings.DNSServers = append(settings.DNSServers, dns)
+		}
+	}
+
+	for _, n := range c.ports() {
+		iface := dto.NetworkInterfaceSettings{
+			Port:        fmt.Sprintf("eth%d", n),
+			Name:        c.get(portKey("IFNAME", n)),
+			Description: c.get(portKey("DESCRIPTION", n)),
+			Bonding:     c.get(portKey("BONDNAME", n)) != "",
+			BondMode:    c.get(portKey("BONDING_MODE", n)),
+			BondNICs:    splitNICs(c.get(portKey("BONDNICS", n))),
+			Bridging:    c.get(portKey("BRNAME", n)) != "",
+			BridgeNICs:  splitNICs(c.get(portKey("BRNICS", n))),
+			BridgeSTP:   c.get(portKey("BRSTP", n)) == "yes",
+			UseDHCP:     c.get(portKey("USE_DHCP", n)) == "yes",
+			IPAddress:   c.get(portKey("IPADDR", n)),
+			Netmask:     c.get(portKey("NETMASK", n)),
+			Gateway:     c.get(portKey("GATEWAY", n)),
+			VLANs:       []dto.NetworkVLANSettings{},
+		}
+		if iface.Name == "" {
+			iface.Name = iface.Port
+		}
+		iface.MTU, _ = strconv.Atoi(c.get(portKey("MTU", n)))
+		for _, i := range c.vlanIndexes(n) {
+			id, _ := strconv.Atoi(c.get(vlanKey("VLANID", n, i)))
+			iface.VLANs = append(iface.VLANs, dto.NetworkVLANSettings{
+				ID:          id,
+				Description: c.get(vlanKey("DESCRIPTION", n, i)),
+				UseDHCP:     c.get(vlanKey("USE_DHCP", n, i)) == "yes",
+				IPAddress:   c.get(vlanKey("IPADDR", n, i)),
+				Netmask:     c.get(vlanKey("NETMASK", n, i)),
+				Gateway:     c.get(vlanKey("GATEWAY", n, i)),
+			})
+		}
+		settings.Interfaces = append(settings.Interfaces, iface)
+	}
+	return settings
+}
+
+// GetNetworkSettings returns the interface configuration of network.cfg and
+// the state of the most recent change applied through the API.
+func GetNetworkSettings() (*dto.NetworkSettings, error) {
+	data, err := os.ReadFile(networkCfgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network config: %w", err)
+	}
+	settings := networkSettingsFromCfg(parseNetCfg(data))
+
+	networkChange.Lock()
+	if networkChange.state != nil {
+		change := *networkChange.state
+		settings.Change = &change
+	}
+	networkChange.Unlock()
+	return settings, nil
+}
+
+// UpdateNetworkSettings writes the update to network.cfg and restarts
+// networking. The previous configuration is restored unless
+// ConfirmNetworkChange is called within the rollback window, so a change
+// that makes the server unreachable reverts itself.
+func UpdateNetworkSettings(update dto.NetworkSettingsUpdate) (*dto.NetworkChange, error) {
+	rollback := defaultNetworkRollback
+	if update.RollbackSeconds != 0 {
+		rollback = time.Duration(update.RollbackSeconds) * time.Second
+		if rollback < minNetworkRollback || rollback > maxNetworkRollback {
+			return nil, fmt.Errorf("%w: rollback_seconds must be between %d and %d", ErrInvalidNetworkConfig,
+				int(minNetworkRollback.Seconds()), int(maxNetworkRollback.Seconds()))
+		}
+	}
+	if len(update.Interfaces) == 0 && update.DNSServers == nil {
+		return nil, fmt.Errorf("%w: nothing to change", ErrInvalidNetworkConfig)
+	}
+
+	networkChange.Lock()
+	defer networkChange.Unlock()
+	if networkChange.state != nil && networkChange.state.State == NetworkChangePending {
+		return nil, ErrNetworkChangePending
+	}
+
+	data, err := os.ReadFile(networkCfgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network config: %w", err)
+	}
+	cfg := parseNetCfg(data)
+	if err := applyNetworkUpdate(cfg, update); err != nil {
+		return nil, err
+	}
+	updated := cfg.bytes()
+	if bytes.Equal(updated, data) {
+		return nil, fmt.Errorf("%w: the update does not change the configuration", ErrInvalidNetworkConfig)
+	}
+
+	now := time.Now()
+	record := networkChangeRecord{Backup: networkCfgPath + ".bak", AppliedAt: now, RollbackAt: now.Add(rollback)}
+	if err := os.WriteFile(record.Backup, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to back up network config: %w", err)
+	}
+	if err := saveNetworkChangeRecord(record); err != nil {
+		return nil, fmt.Errorf("failed to record network change: %w", err)
+	}
+	if err := os.WriteFile(networkCfgPath, updated, 0o600); err != nil {
+		removeNetworkChangeRecord()
+		return nil, fmt.Errorf("failed to write network config: %w", err)
+	}
+
+	networkChange.state = &dto.NetworkChange{
+		State:      NetworkChangePending,
+		AppliedAt:  record.AppliedAt,
+		RollbackAt: record.RollbackAt,
+	}
+	networkChange.backup = data
+	networkChange.timer = time.AfterFunc(rollback, func() {
+		_ = rollbackNetworkChange(fmt.Sprintf("not confirmed within %s; previous configuration restored", rollback))
+	})
+	logger.Warning("Network: Configuration changed; reverting at %s unless confirmed", networkChange.state.RollbackAt.Format(time.RFC3339))
+
+	go func() {
+		time.Sleep(networkApplyDelay)
+		if err := restartNetwork(); err != nil {
+			_ = rollbackNetworkChange(fmt.Sprintf("network restart failed: %v; previous configuration restored", err))
+		}
+	}()
+
+	change := *networkChange.state
+	return &change, nil
+}
+
+// ConfirmNetworkChange keeps the pending network change.
+func ConfirmNetworkChange() (*dto.NetworkChange, error) {
+	networkChange.Lock()
+	defer networkChange.Unlock()
+	if networkChange.state == nil || networkChange.state.State != NetworkChangePending {
+		return nil, ErrNoNetworkChangePending
+	}
+	if networkChange.timer != nil {
+		networkChange.timer.Stop()
+	}
+	now := time.Now()
+	networkChange.state.State = NetworkChangeConfirmed
+	networkChange.state.ResolvedAt = &now
+	networkChange.backup = nil
+	removeNetworkChangeRecord()
+
+	logger.Info("Network: Configuration change confirmed")
+	change := *networkChange.state
+	return &change, nil
+}
+
+// RollbackNetworkChange restores the configuration from before the pending
+// change right away.
+func RollbackNetworkChange() (*dto.NetworkChange, error) {
+	if err := rollbackNetworkChange("rolled back on request"); err != nil {
+		return nil, err
+	}
+	networkChange.Lock()
+	defer networkChange.Unlock()
+	change := *networkChange.state
+	return &change, nil
+}
+
+func rollbackNetworkChange(reason string) error {
+	networkChange.Lock()
+	if networkChange.state == nil || networkChange.state.State != NetworkChangePending {
+		networkChange.Unlock()
+		return ErrNoNetworkChangePending
+	}
+	if networkChange.timer != nil {
+		networkChange.timer.Stop()
+	}
+	err := os.WriteFile(networkCfgPath, networkChange.backup, 0o600)
+	now := time.Now()
+	networkChange.state.State = NetworkChangeRolledBack
+	networkChange.state.ResolvedAt = &now
+	networkChange.state.Message = reason
+	if err != nil {
+		networkChange.state.Message = fmt.Sprintf("%s; restoring network.cfg failed: %v", reason, err)
+	}
+	networkChange.backup = nil
+	networkChange.Unlock()
+
+	if err != nil {
+		logger.Error("Network: Failed to restore network config: %v", err)
+		return fmt.Errorf("failed to restore network config: %w", err)
+	}
+	removeNetworkChangeRecord()
+	logger.Warning("Network: Configuration change rolled back: %s", reason)
+	if err := restartNetwork(); err != nil {
+		logger.Error("Network: Restart after rollback failed: %v", err)
+	}
+	return nil
+}
+
+// RestorePendingNetworkChange picks up a change that was still waiting for
+// confirmation when the agent stopped. It is rolled back right away when its
+// deadline has passed, and the rollback timer is re-armed otherwise.
+func RestorePendingNetworkChange() {
+	record, err := loadNetworkChangeRecord()
+	if err != nil || record == nil {
+		if err != nil {
+			logger.Warning("Network: Failed to read pending change: %v", err)
+		}
+		return
+	}
+	backup, err := os.ReadFile(record.Backup)
+	if err != nil {
+		logger.Error("Network: Pending change cannot be rolled back: %v", err)
+		removeNetworkChangeRecord()
+		return
+	}
+
+	networkChange.Lock()
+	networkChange.state = &dto.NetworkChange{
+		State:      NetworkChangePending,
+		AppliedAt:  record.AppliedAt,
+		RollbackAt: record.RollbackAt,
+	}
+	networkChange.backup = backup
+	remaining := time.Until(record.RollbackAt)
+	if remaining <= 0 {
+		networkChange.Unlock()
+		_ = rollbackNetworkChange("deadline passed while the agent was stopped; previous configuration restored")
+		return
+	}
+	networkChange.timer = time.AfterFunc(remaining, func() {
+		_ = rollbackNetworkChange(fmt.Sprintf("not confirmed by %s; previous configuration restored", record.RollbackAt.Format(time.RFC3339)))
+	})
+	networkChange.Unlock()
+	logger.Warning("Network: Configuration change still pending; reverting at %s unless confirmed", record.RollbackAt.Format(time.RFC3339))
+}
+
+func loadNetworkChangeRecord() (*networkChangeRecord, error) {
+	data, err := os.ReadFile(filepath.Join(networkStateDir, networkChangeFile)) //nolint:gosec // G304: fixed state path
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record networkChangeRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", networkChangeFile, err)
+	}
+	return &record, nil
+}
+
+func saveNetworkChangeRecord(record networkChangeRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(networkStateDir, 0o755); err != nil { //nolint:gosec // G301: plugin config directory
+		return err
+	}
+	return writeFileAtomic(networkStateDir, networkChangeFile, data)
+}
+
+func removeNetworkChangeRecord() {
+	if err := os.Remove(filepath.Join(networkStateDir, networkChangeFile)); err != nil && !os.IsNotExist(err) {
+		logger.Warning("Network: Failed to remove pending change record: %v", err)
+	}
+}
+
+func restartNetwork() error {
+	networkRestartMu.Lock()
+	defer networkRestartMu.Unlock()
+	logger.Info("Network: Restarting networking (%s restart)", constants.RcInet1Bin)
+	return networkRestart()
+}
+
+// applyNetworkUpdate validates update against the current configuration and
+// writes it into cfg.
+func applyNetworkUpdate(cfg *netCfg, update dto.NetworkSettingsUpdate) error {
+	current := networkSettingsFromCfg(cfg)
+	var seen []string
+	for _, u := range update.Interfaces {
+		if slices.Contains(seen, u.Port) {
+			return fmt.Errorf("%w: port %s listed twice", ErrInvalidNetworkConfig, u.Port)
+		}
+		seen = append(seen, u.Port)
+
+		idx := slices.IndexFunc(current.Interfaces, func(i dto.NetworkInterfaceSettings) bool { return i.Port == u.Port })
+		if idx < 0 {
+			return fmt.Errorf("%w: unknown port %q", ErrInvalidNetworkConfig, u.Port)
+		}
+		iface := mergeInterfaceUpdate(current.Interfaces[idx], u)
+		if err := validateInterfaceSettings(iface); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidNetworkConfig, u.Port, err)
+		}
+		n, _ := strconv.Atoi(netCfgPortPattern.FindStringSubmatch(u.Port)[1])
+		writeInterfaceSettings(cfg, n, iface)
+	}
+
+	if update.DNSServers != nil {
+		servers := *update.DNSServers
+		if len(servers) > maxStaticDNSServer {
+			return fmt.Errorf("%w: at most %d DNS servers", ErrInvalidNetworkConfig, maxStaticDNSServer)
+		}
+		for i := range maxStaticDNSServer {
+			key := fmt.Sprintf("DNS_SERVER%d", i+1)
+			if i >= len(servers) {
+				cfg.del(key)
+				continue
+			}
+			addr, err := netip.ParseAddr(servers[i])
+			if err != nil {
+				return fmt.Errorf("%w: invalid DNS server %q", ErrInvalidNetworkConfig, servers[i])
+			}
+			cfg.set(key, addr.String())
+		}
+		// With DHCP, keep the static servers instead of the ones it offers.
+		keep := "no"
+		if len(servers) > 0 {
+			keep = "yes"
+		}
+		cfg.set("DHCP_KEEPRESOLV", keep)
+	}
+	return nil
+}
+
+func mergeInterfaceUpdate(iface dto.NetworkInterfaceSettings, u dto.NetworkInterfaceUpdate) dto.NetworkInterfaceSettings {
+	if u.Description != nil {
+		iface.Description = *u.Description
+	}
+	if u.Bonding != nil {
+		iface.Bonding = *u.Bonding
+	}
+	if u.BondMode != nil {
+		iface.BondMode = *u.BondMode
+	}
+	if u.BondNICs != nil {
+		iface.BondNICs = *u.BondNICs
+	}
+	if iface.Bonding && len(iface.BondNICs) == 0 {
+		iface.BondNICs = []string{iface.Port}
+	}
+	if iface.Bonding && iface.BondMode == "" {
+		iface.BondMode = "1"
+	}
+	if u.Bridging != nil {
+		iface.Bridging = *u.Bridging
+	}
+	if u.BridgeSTP != nil {
+		iface.BridgeSTP = *u.BridgeSTP
+	}
+	if u.UseDHCP != nil {
+		iface.UseDHCP = *u.UseDHCP
+	}
+	if u.IPAddress != nil {
+		iface.IPAddress = *u.IPAddress
+	}
+	if u.Netmask != nil {
+		iface.Netmask = *u.Netmask
+	}
+	if u.Gateway != nil {
+		iface.Gateway = *u.Gateway
+	}
+	if u.MTU != nil {
+		iface.MTU = *u.MTU
+	}
+	if u.VLANs != nil {
+		iface.VLANs = *u.VLANs
+	}
+	return iface
+}
+
+func validateInterfaceSettings(iface dto.NetworkInterfaceSettings) error {
+	if !netCfgTextPattern.MatchString(iface.Description) {
+		return errors.New("description must be at most 64 characters without quotes, backslashes or $")
+	}
+	if iface.Bonding {
+		if !slices.Contains(validBondingModes, iface.BondMode) {
+			return fmt.Errorf("bond_mode must be 0-6, got %q", iface.BondMode)
+		}
+		if !slices.Contains(iface.BondNICs, iface.Port) {
+			return fmt.Errorf("bond_nics must include %s", iface.Port)
+		}
+		for i, nic := range iface.BondNICs {
+			if !netCfgPortPattern.MatchString(nic) || slices.Contains(iface.BondNICs[:i], nic) {
+				return fmt.Errorf("invalid or duplicate bond member %q", nic)
+			}
+		}
+	}
+	if iface.MTU != 0 && (iface.MTU < 68 || iface.MTU > 9216) {
+		return fmt.Errorf("mtu must be between 68 and 9216, got %d", iface.MTU)
+	}
+	if !iface.UseDHCP {
+		if err := validateStaticIPv4(iface.IPAddress, iface.Netmask, iface.Gateway); err != nil {
+			return err
+		}
+	}
+
+	var ids []int
+	for _, v := range iface.VLANs {
+		if v.ID < 1 || v.ID > 4094 || slices.Contains(ids, v.ID) {
+			return fmt.Errorf("invalid or duplicate VLAN ID %d (1-4094)", v.ID)
+		}
+		ids = append(ids, v.ID)
+		if !netCfgTextPattern.MatchString(v.Description) {
+			return fmt.Errorf("VLAN %d: description must be at most 64 characters without quotes, backslashes or $", v.ID)
+		}
+		if !v.UseDHCP {
+			if err := validateStaticIPv4(v.IPAddress, v.Netmask, v.Gateway); err != nil {
+				return fmt.Errorf("VLAN %d: %w", v.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateStaticIPv4 checks a static address, its dotted netmask and an
+// optional gateway inside the resulting subnet.
+func validateStaticIPv4(ip, netmask, gateway string) error {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || !addr.Is4() {
+		return fmt.Errorf("static configuration needs an IPv4 ip_address, got %q", ip)
+	}
+	mask, err := netip.ParseAddr(netmask)
+	if err != nil || !mask.Is4() {
+		return fmt.Errorf("static configuration needs a netmask such as 255.255.255.0, got %q", netmask)
+	}
+	bits, _ := net.IPMask(mask.AsSlice()).Size()
+	if bits == 0 {
+		return fmt.Errorf("invalid netmask %q", netmask)
+	}
+	if gateway == "" {
+		return nil
+	}
+	gw, err := netip.ParseAddr(gateway)
+	if err != nil || !gw.Is4() {
+		return fmt.Errorf("invalid gateway %q", gateway)
+	}
+	if !netip.PrefixFrom(addr, bits).Masked().Contains(gw) {
+		return fmt.Errorf("gateway %s is outside %s/%d", gw, addr, bits)
+	}
+	return nil
+}
+
+// writeInterfaceSettings writes iface into the keys of port n the way the
+// Network Settings page does: the port joins bondN when bonding, the bond
+// (or port) joins brN when bridging, and IFNAME names the top interface.
+func writeInterfaceSettings(cfg *netCfg, n int, iface dto.NetworkInterfaceSettings) {
+	name := iface.Port
+	if iface.Bonding {
+		name = fmt.Sprintf("bond%d", n)
+		cfg.set(portKey("BONDNAME", n), name)
+		cfg.set(portKey("BONDING_MODE", n), iface.BondMode)
+		cfg.set(portKey("BONDNICS", n), strings.Join(iface.BondNICs, " "))
+	} else {
+		cfg.del(portKey("BONDNAME", n), portKey("BONDNICS", n))
+	}
+	if iface.Bridging {
+		cfg.set(portKey("BRNAME", n), fmt.Sprintf("br%d", n))
+		cfg.set(portKey("BRNICS", n), name)
+		name = fmt.Sprintf("br%d", n)
+		cfg.set(portKey("BRSTP", n), yesNo(iface.BridgeSTP))
+	} else {
+		cfg.del(portKey("BRNAME", n), portKey("BRNICS", n))
+	}
+	cfg.set(portKey("IFNAME", n), name)
+	cfg.set(portKey("DESCRIPTION", n), iface.Description)
+	cfg.set(portKey("USE_DHCP", n), yesNo(iface.UseDHCP))
+	if iface.UseDHCP {
+		cfg.del(portKey("IPADDR", n), portKey("NETMASK", n), portKey("GATEWAY", n))
+	} else {
+		cfg.set(portKey("IPADDR", n), iface.IPAddress)
+		cfg.set(portKey("NETMASK", n), iface.Netmask)
+		setOrDelete(cfg, portKey("GATEWAY", n), iface.Gateway)
+	}
+	mtu := ""
+	if iface.MTU != 0 {
+		mtu = strconv.Itoa(iface.MTU)
+	}
+	cfg.set(portKey("MTU", n), mtu)
+
+	// Rewrite the VLAN list: drop every [n,i] key, then number VLANs from 1.
+	var vlanKeys []string
+	for _, l := range cfg.lines {
+		if m := netCfgIndexedKey.FindStringSubmatch(l.key); m != nil && m[2] == strconv.Itoa(n) && m[3] != "" {
+			vlanKeys = append(vlanKeys, l.key)
+		}
+	}
+	cfg.del(vlanKeys...)
+	if len(iface.VLANs) == 0 {
+		cfg.del(portKey("VLANS", n))
+		cfg.set(portKey("TYPE", n), "access")
+		return
+	}
+	cfg.set(portKey("TYPE", n), "trunk")
+	for i, v := range iface.VLANs {
+		i++
+		cfg.set(vlanKey("VLANID", n, i), strconv.Itoa(v.ID))
+		cfg.set(vlanKey("DESCRIPTION", n, i), v.Description)
+		cfg.set(vlanKey("PROTOCOL", n, i), "ipv4")
+		cfg.set(vlanKey("USE_DHCP", n, i), yesNo(v.UseDHCP))
+		if !v.UseDHCP {
+			cfg.set(vlanKey("IPADDR", n, i), v.IPAddress)
+			cfg.set(vlanKey("NETMASK", n, i), v.Netmask)
+			setOrDelete(cfg, vlanKey("GATEWAY", n, i), v.Gateway)
+		}
+	}
+	cfg.set(portKey("VLANS", n), strconv.Itoa(len(iface.VLANs)+1))
+}
+
+func setOrDelete(cfg *netCfg, key, value string) {
+	if value == "" {
+		cfg.del(key)
+		return
+	}
+	cfg.set(key, value)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,350 @@
+package controllers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+const testNetworkCfg = `# Generated settings:
+IFNAME[0]="br0"
+BONDNAME[0]="bond0"
+BONDING_MODE[0]="1"
+BONDNICS[0]="eth0,eth1"
+BRNAME[0]="br0"
+BRSTP[0]="no"
+BRFD[0]="0"
+BRNICS[0]="bond0"
+DESCRIPTION[0]=""
+PROTOCOL[0]="ipv4"
+USE_DHCP[0]="no"
+IPADDR[0]="192.168.1.10"
+NETMASK[0]="255.255.255.0"
+GATEWAY[0]="192.168.1.1"
+DNS_SERVER1="192.168.1.1"
+USE_DHCP6[0]="yes"
+MTU[0]=""
+TYPE[0]="trunk"
+VLANID[0,1]="20"
+DESCRIPTION[0,1]="IoT"
+PROTOCOL[0,1]="ipv4"
+USE_DHCP[0,1]="yes"
+VLANS[0]="2"
+IFNAME[2]="eth2"
+USE_DHCP[2]="yes"
+SYSNICS="3"
+`
+
+// setupNetworkConfig points the controller at a temporary network.cfg and
+// counts restarts instead of running rc.inet1.
+func setupNetworkConfig(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "network.cfg")
+	if err := os.WriteFile(path, []byte(testNetworkCfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var restarts atomic.Int32
+	origPath, origRestart, origDelay, origStateDir := networkCfgPath, networkRestart, networkApplyDelay, networkStateDir
+	networkCfgPath, networkApplyDelay, networkStateDir = path, 0, filepath.Join(t.TempDir(), "state")
+	networkRestart = func() error {
+		restarts.Add(1)
+		return nil
+	}
+	t.Cleanup(func() {
+		forgetNetworkChange()
+		networkCfgPath, networkRestart, networkApplyDelay, networkStateDir = origPath, origRestart, origDelay, origStateDir
+	})
+	return path, &restarts
+}
+
+// forgetNetworkChange drops the in-memory change, as an agent restart does.
+func forgetNetworkChange() {
+	networkChange.Lock()
+	defer networkChange.Unlock()
+	if networkChange.timer != nil {
+		networkChange.timer.Stop()
+	}
+	networkChange.state, networkChange.backup, networkChange.timer = nil, nil, nil
+}
+
+func TestGetNetworkSettings(t *testing.T) {
+	setupNetworkConfig(t)
+
+	settings, err := GetNetworkSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(settings.Interfaces) != 2 {
+		t.Fatalf("got %d interfaces, want 2: %+v", len(settings.Interfaces), settings.Interfaces)
+	}
+	eth0 := settings.Interfaces[0]
+	if eth0.Port != "eth0" || eth0.Name != "br0" || !eth0.Bonding || !eth0.Bridging || eth0.BondMode != "1" {
+		t.Errorf("eth0 = %+v", eth0)
+	}
+	if strings.Join(eth0.BondNICs, " ") != "eth0 eth1" || eth0.UseDHCP || eth0.IPAddress != "192.168.1.10" {
+		t.Errorf("eth0 bond/address = %+v", eth0)
+	}
+	if len(eth0.VLANs) != 1 || eth0.VLANs[0].ID != 20 || eth0.VLANs[0].Description != "IoT" || !eth0.VLANs[0].UseDHCP {
+		t.Errorf("eth0 VLANs = %+v", eth0.VLANs)
+	}
+	if eth2 := settings.Interfaces[1]; eth2.Port != "eth2" || eth2.Bonding || !eth2.UseDHCP {
+		t.Errorf("eth2 = %+v", eth2)
+	}
+	if len(settings.DNSServers) != 1 || settings.DNSServers[0] != "192.168.1.1" {
+		t.Errorf("DNS = %v", settings.DNSServers)
+	}
+}
+
+func TestApplyNetworkUpdate(t *testing.T) {
+	cfg := parseNetCfg([]byte(testNetworkCfg))
+	bonding, dhcp := false, false
+	ip, mask := "192.168.1.20", "255.255.255.0"
+	vlans := []dto.NetworkVLANSettings{
+		{ID: 30, Description: "Cameras", IPAddress: "192.168.30.2", Netmask: "255.255.255.0", Gateway: "192.168.30.1"},
+		{ID: 40, UseDHCP: true},
+	}
+	dns := []string{"1.1.1.1", "9.9.9.9"}
+
+	err := applyNetworkUpdate(cfg, dto.NetworkSettingsUpdate{
+		Interfaces: []dto.NetworkInterfaceUpdate{
+			{Port: "eth0", Bonding: &bonding, VLANs: &vlans},
+			{Port: "eth2", UseDHCP: &dhcp, IPAddress: &ip, Netmask: &mask},
+		},
+		DNSServers: &dns,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := string(cfg.bytes())
+	for _, want := range []string{
+		`IFNAME[0]="br0"`, `BRNICS[0]="eth0"`, `VLANID[0,1]="30"`, `GATEWAY[0,1]="192.168.30.1"`,
+		`VLANID[0,2]="40"`, `USE_DHCP[0,2]="yes"`, `VLANS[0]="3"`, `TYPE[0]="trunk"`,
+		`USE_DHCP[2]="no"`, `IPADDR[2]="192.168.1.20"`, `DNS_SERVER2="9.9.9.9"`, `DHCP_KEEPRESOLV="yes"`,
+		"# Generated settings:", `USE_DHCP6[0]="yes"`, `SYSNICS="3"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+	for _, gone := range []string{"BONDNAME[0]", "BONDNICS[0]", `DESCRIPTION[0,1]="IoT"`} {
+		if strings.Contains(out, gone) {
+			t.Errorf("%s should be removed:\n%s", gone, out)
+		}
+	}
+}
+
+func TestApplyNetworkUpdateValidation(t *testing.T) {
+	str := func(s string) *string { return &s }
+	boolp := func(b bool) *bool { return &b }
+	intp := func(i int) *int { return &i }
+	vlans := func(v ...dto.NetworkVLANSettings) *[]dto.NetworkVLANSettings { return &v }
+
+	tests := []struct {
+		name   string
+		update dto.NetworkInterfaceUpdate
+	}{
+		{"unknown port", dto.NetworkInterfaceUpdate{Port: "eth9", MTU: intp(1500)}},
+		{"bad ip", dto.NetworkInterfaceUpdate{Port: "eth0", IPAddress: str("192.168.1.300")}},
+		{"non-contiguous netmask", dto.NetworkInterfaceUpdate{Port: "eth0", Netmask: str("255.0.255.0")}},
+		{"gateway outside subnet", dto.NetworkInterfaceUpdate{Port: "eth0", Gateway: str("10.0.0.1")}},
+		{"static without address", dto.NetworkInterfaceUpdate{Port: "eth2", UseDHCP: boolp(false)}},
+		{"bad bond mode", dto.NetworkInterfaceUpdate{Port: "eth0", BondMode: str("7")}},
+		{"bond without own port", dto.NetworkInterfaceUpdate{Port: "eth0", BondNICs: &[]string{"eth1"}}},
+		{"mtu too large", dto.NetworkInterfaceUpdate{Port: "eth0", MTU: intp(65000)}},
+		{"vlan id out of range", dto.NetworkInterfaceUpdate{Port: "eth0", VLANs: vlans(dto.NetworkVLANSettings{ID: 4095, UseDHCP: true})}},
+		{"duplicate vlan", dto.NetworkInterfaceUpdate{Port: "eth0", VLANs: vlans(dto.NetworkVLANSettings{ID: 5, UseDHCP: true}, dto.NetworkVLANSettings{ID: 5, UseDHCP: true})}},
+		{"description injection", dto.NetworkInterfaceUpdate{Port: "eth0", Description: str("x\"\nIPADDR[0]=\"1.2.3.4")}},
+	}
+	for _, tt := range tests {
+		cfg := parseNetCfg([]byte(testNetworkCfg))
+		err := applyNetworkUpdate(cfg, dto.NetworkSettingsUpdate{Interfaces: []dto.NetworkInterfaceUpdate{tt.update}})
+		if !errors.Is(err, ErrInvalidNetworkConfig) {
+			t.Errorf("%s: err = %v, want ErrInvalidNetworkConfig", tt.name, err)
+		}
+	}
+
+	cfg := parseNetCfg([]byte(testNetworkCfg))
+	if err := applyNetworkUpdate(cfg, dto.NetworkSettingsUpdate{DNSServers: &[]string{"dns.example"}}); !errors.Is(err, ErrInvalidNetworkConfig) {
+		t.Errorf("invalid DNS: err = %v", err)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestUpdateNetworkSettingsConfirm(t *testing.T) {
+	path, restarts := setupNetworkConfig(t)
+	mtu := 9000
+
+	change, err := UpdateNetworkSettings(dto.NetworkSettingsUpdate{
+		Interfaces: []dto.NetworkInterfaceUpdate{{Port: "eth0", MTU: &mtu}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change.State != NetworkChangePending || change.RollbackAt.Sub(change.AppliedAt) != defaultNetworkRollback {
+		t.Errorf("change = %+v", change)
+	}
+	waitFor(t, func() bool { return restarts.Load() == 1 })
+
+	if _, err := UpdateNetworkSettings(dto.NetworkSettingsUpdate{DNSServers: &[]string{}}); !errors.Is(err, ErrNetworkChangePending) {
+		t.Errorf("second change: err = %v, want ErrNetworkChangePending", err)
+	}
+
+	if change, err = ConfirmNetworkChange(); err != nil || change.State != NetworkChangeConfirmed {
+		t.Fatalf("confirm: change %+v, err %v", change, err)
+	}
+	if _, err := ConfirmNetworkChange(); !errors.Is(err, ErrNoNetworkChangePending) {
+		t.Errorf("second confirm: err = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `MTU[0]="9000"`) {
+		t.Errorf("confirmed change not kept:\n%s", data)
+	}
+}
+
+func TestUpdateNetworkSettingsRollback(t *testing.T) {
+	path, restarts := setupNetworkConfig(t)
+	mtu := 9000
+
+	if _, err := UpdateNetworkSettings(dto.NetworkSettingsUpdate{
+		Interfaces: []dto.NetworkInterfaceUpdate{{Port: "eth0", MTU: &mtu}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return restarts.Load() == 1 })
+
+	change, err := RollbackNetworkChange()
+	if err != nil || change.State != NetworkChangeRolledBack {
+		t.Fatalf("rollback: change %+v, err %v", change, err)
+	}
+	if restarts.Load() != 2 {
+		t.Errorf("restarts = %d, want 2", restarts.Load())
+	}
+	if data, _ := os.ReadFile(path); string(data) != testNetworkCfg {
+		t.Errorf("network.cfg not restored:\n%s", data)
+	}
+
+	settings, err := GetNetworkSettings()
+	if err != nil || settings.Change == nil || settings.Change.State != NetworkChangeRolledBack {
+		t.Errorf("settings.Change = %+v, err %v", settings.Change, err)
+	}
+}
+
+func TestUpdateNetworkSettingsRollsBackFailedRestart(t *testing.T) {
+	path, _ := setupNetworkConfig(t)
+	var calls atomic.Int32
+	networkRestart = func() error {
+		if calls.Add(1) == 1 {
+			return errors.New("bond0: no such device")
+		}
+		return nil
+	}
+	dhcp := true
+
+	if _, err := UpdateNetworkSettings(dto.NetworkSettingsUpdate{
+		Interfaces: []dto.NetworkInterfaceUpdate{{Port: "eth0", UseDHCP: &dhcp}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return calls.Load() == 2 })
+
+	settings, _ := GetNetworkSettings()
+	if settings.Change == nil || settings.Change.State != NetworkChangeRolledBack || !strings.Contains(settings.Change.Message, "no such device") {
+		t.Errorf("change = %+v", settings.Change)
+	}
+	if data, _ := os.ReadFile(path); string(data) != testNetworkCfg {
+		t.Errorf("network.cfg not restored:\n%s", data)
+	}
+}
+
+func TestUpdateNetworkSettingsRejectsRollbackWindow(t *testing.T) {
+	setupNetworkConfig(t)
+	if _, err := UpdateNetworkSettings(dto.NetworkSettingsUpdate{DNSServers: &[]string{}, RollbackSeconds: 5}); !errors.Is(err, ErrInvalidNetworkConfig) {
+		t.Errorf("err = %v, want ErrInvalidNetworkConfig", err)
+	}
+}
+
+func TestPendingNetworkChangeSurvivesRestart(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		deadline time.Duration
+	}{
+		{"deadline passed", -time.Second},
+		{"timer re-armed", 100 * time.Millisecond},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path, restarts := setupNetworkConfig(t)
+			mtu := 9000
+			if _, err := UpdateNetworkSettings(dto.NetworkSettingsUpdate{
+				Interfaces: []dto.NetworkInterfaceUpdate{{Port: "eth0", MTU: &mtu}},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			waitFor(t, func() bool { return restarts.Load() == 1 })
+
+			record, err := loadNetworkChangeRecord()
+			if err != nil || record == nil {
+				t.Fatalf("pending change not recorded: %+v, %v", record, err)
+			}
+			forgetNetworkChange()
+			record.RollbackAt = time.Now().Add(tc.deadline)
+			if err := saveNetworkChangeRecord(*record); err != nil {
+				t.Fatal(err)
+			}
+
+			RestorePendingNetworkChange()
+			if tc.deadline > 0 {
+				if settings, _ := GetNetworkSettings(); settings.Change == nil || settings.Change.State != NetworkChangePending {
+					t.Fatalf("after restart: change = %+v, want pending", settings.Change)
+				}
+			}
+			waitFor(t, func() bool { return restarts.Load() == 2 })
+
+			settings, _ := GetNetworkSettings()
+			if settings.Change == nil || settings.Change.State != NetworkChangeRolledBack {
+				t.Errorf("change = %+v, want rolled back", settings.Change)
+			}
+			if data, _ := os.ReadFile(path); string(data) != testNetworkCfg {
+				t.Errorf("network.cfg not restored:\n%s", data)
+			}
+			if record, err := loadNetworkChangeRecord(); record != nil || err != nil {
+				t.Errorf("record left behind: %+v, %v", record, err)
+			}
+		})
+	}
+}
+
+func TestConfirmNetworkChangeRemovesRecord(t *testing.T) {
+	_, restarts := setupNetworkConfig(t)
+	mtu := 9000
+	if _, err := UpdateNetworkSettings(dto.NetworkSettingsUpdate{
+		Interfaces: []dto.NetworkInterfaceUpdate{{Port: "eth0", MTU: &mtu}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return restarts.Load() == 1 })
+	if _, err := ConfirmNetworkChange(); err != nil {
+		t.Fatal(err)
+	}
+	forgetNetworkChange()
+	RestorePendingNetworkChange()
+	if settings, _ := GetNetworkSettings(); settings.Change != nil {
+		t.Errorf("confirmed change restored after restart: %+v", settings.Change)
+	}
+}
@@ -70,6 +70,110 @@ func (pc *ProcessController) ListProcesses(sortBy string, limit int) (*dto.Proce
 	return result, nil
 }
 
+// Limits for TopProcesses.
+const (
+	DefaultTopProcesses = 10
+	MaxTopProcesses     = 100
+)
+
+// TopProcesses returns the top limit processes ranked by sortBy ("cpu" or
+// "memory"), each annotated with its name, cgroup and, for processes running
+// inside a Docker container, the container from containers it belongs to.
+func (pc *ProcessController) TopProcesses(sortBy string, limit int, containers []dto.ContainerInfo) (*dto.ProcessList, error) {
+	if sortBy != "cpu" && sortBy != "memory" {
+		return nil, fmt.Errorf("invalid sort %q: must be cpu or memory", sortBy)
+	}
+	if limit <= 0 || limit > MaxTopProcesses {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", MaxTopProcesses, limit)
+	}
+
+	result, err := pc.ListProcesses(sortBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(containers))
+	for _, c := range containers {
+		names[c.ID] = c.Name
+	}
+	for i := range result.Processes {
+		proc := &result.Processes[i]
+		proc.Name = readProcComm(proc.PID)
+		proc.Cgroup = readProcCgroup(proc.PID)
+		if id := containerIDFromCgroup(proc.Cgroup); id != "" {
+			proc.ContainerID = id
+			proc.ContainerName = containerName(names, id)
+		}
+	}
+	return result, nil
+}
+
+// readProcCgroup returns the cgroup v2 path of a process from /proc/<pid>/cgroup.
+func readProcCgroup(pid int) string {
+	// #nosec G304 -- path is /proc/<numeric-pid>/cgroup, bounded to the proc fs.
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/cgroup")
+	if err != nil {
+		return ""
+	}
+	return parseProcCgroup(string(data))
+}
+
+// parseProcCgroup extracts the unified (v2) hierarchy path from the contents
+// of /proc/<pid>/cgroup, falling back to the first v1 hierarchy path.
+func parseProcCgroup(data string) string {
+	fallback := ""
+	for line := range strings.SplitSeq(strings.TrimSpace(data), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			return parts[2]
+		}
+		if fallback == "" {
+			fallback = parts[2]
+		}
+	}
+	return fallback
+}
+
+// containerIDFromCgroup returns the Docker container ID encoded in a cgroup
+// path, for both the cgroupfs ("/docker/<id>") and systemd
+// ("/system.slice/docker-<id>.scope") drivers, or "" for host processes.
+func containerIDFromCgroup(cgroup string) string {
+	for segment := range strings.SplitSeq(cgroup, "/") {
+		id := strings.TrimSuffix(strings.TrimPrefix(segment, "docker-"), ".scope")
+		if len(id) == 64 && isHex(id) {
+			return id
+		}
+	}
+	return ""
+}
+
+// containerName finds the name of the container with the given full ID; the
+// container cache may hold either full or abbreviated IDs.
+func containerName(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	for cid, name := range names {
+		if cid != "" && strings.HasPrefix(id, cid) {
+			return name
+		}
+	}
+	return ""
+}
+
+// isHex reports whether s consists only of lowercase hexadecimal digits.
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
 // procIOSampleInterval is the delay between the two /proc/<pid>/io reads used
 // to derive a per-process I/O rate.
 const procIOSampleInterval = 500 * time.Millisecond
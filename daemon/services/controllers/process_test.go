@@ -29,6 +29,68 @@ func TestSortProcessesByIO(t *testing.T) {
 	}
 }
 
+func TestParseProcCgroup(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"cgroup v2", "0::/docker/abc\n", "/docker/abc"},
+		{"hybrid prefers unified", "12:cpu,cpuacct:/docker/v1\n0::/docker/v2\n", "/docker/v2"},
+		{"cgroup v1 only", "12:cpu,cpuacct:/docker/v1\n11:memory:/docker/v1\n", "/docker/v1"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseProcCgroup(tt.data); got != tt.want {
+				t.Errorf("parseProcCgroup() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerIDFromCgroup(t *testing.T) {
+	id := "3f4e2a1b9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f"
+	tests := []struct {
+		cgroup string
+		want   string
+	}{
+		{"/docker/" + id, id},
+		{"/system.slice/docker-" + id + ".scope", id},
+		{"/", ""},
+		{"/system.slice/sshd.service", ""},
+		{"/machine/qemu-1-win11.libvirt-qemu", ""},
+	}
+	for _, tt := range tests {
+		if got := containerIDFromCgroup(tt.cgroup); got != tt.want {
+			t.Errorf("containerIDFromCgroup(%q) = %q, want %q", tt.cgroup, got, tt.want)
+		}
+	}
+}
+
+func TestContainerName(t *testing.T) {
+	id := "3f4e2a1b9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f"
+	if got := containerName(map[string]string{id: "plex"}, id); got != "plex" {
+		t.Errorf("full ID: got %q, want plex", got)
+	}
+	if got := containerName(map[string]string{"3f4e2a1b9c8d": "plex"}, id); got != "plex" {
+		t.Errorf("short ID: got %q, want plex", got)
+	}
+	if got := containerName(map[string]string{"aaaa": "other"}, id); got != "" {
+		t.Errorf("unknown ID: got %q, want empty", got)
+	}
+}
+
+func TestTopProcessesRejectsInvalidArgs(t *testing.T) {
+	pc := NewProcessController()
+	if _, err := pc.TopProcesses("pid", 10, nil); err == nil {
+		t.Error("expected error for sort=pid")
+	}
+	if _, err := pc.TopProcesses("cpu", MaxTopProcesses+1, nil); err == nil {
+		t.Error("expected error for limit above maximum")
+	}
+}
+
 func TestListProcessIONoPanic(t *testing.T) {
 	// Smoke test: must not panic and must return a non-nil result on any platform.
 	pc := NewProcessController()
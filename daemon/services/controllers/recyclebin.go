@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// ErrRecycleBinNotFound is returned when a share has no recycle bin.
+var ErrRecycleBinNotFound = errors.New("recycle bin not found")
+
+// Package-level variables (not constants) so tests can use a fixture tree.
+var (
+	recycleBinPluginDir = constants.RecycleBinPluginDir
+	recycleBinSharesDir = constants.UserSharesDir
+)
+
+// EmptyRecycleBin permanently deletes everything in a share's recycle bin,
+// keeping the .Recycle.Bin directory itself so Samba can keep using it.
+func EmptyRecycleBin(share string) (*dto.RecycleBinEmptyResult, error) {
+	if err := lib.ValidateShareName(share); err != nil {
+		return nil, fmt.Errorf("validate share name: %w", err)
+	}
+	if info, err := os.Stat(recycleBinPluginDir); err != nil || !info.IsDir() {
+		return nil, errors.New("recycle bin plugin is not installed")
+	}
+
+	bin := filepath.Join(recycleBinSharesDir, share, constants.RecycleBinDirName)
+	info, err := os.Lstat(bin)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%w for share %q", ErrRecycleBinNotFound, share)
+	}
+
+	entries, err := os.ReadDir(bin)
+	if err != nil {
+		return nil, fmt.Errorf("read recycle bin: %w", err)
+	}
+
+	result := &dto.RecycleBinEmptyResult{Share: share}
+	var errs []error
+	for _, entry := range entries {
+		path := filepath.Join(bin, entry.Name())
+		files, bytes := recycleBinUsage(path)
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.FilesRemoved += files
+		result.BytesFreed += bytes
+	}
+	result.Timestamp = time.Now()
+
+	logger.Info("RecycleBin: emptied recycle bin of share %q (%d files, %d bytes)", share, result.FilesRemoved, result.BytesFreed)
+	if len(errs) > 0 {
+		return result, fmt.Errorf("failed to remove some recycle bin entries: %w", errors.Join(errs...))
+	}
+	return result, nil
+}
+
+// recycleBinUsage counts the regular files and bytes below path.
+func recycleBinUsage(path string) (files, bytes uint64) {
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			files++
+			bytes += uint64(max(info.Size(), 0)) // #nosec G115 -- clamped to non-negative
+		}
+		return nil
+	})
+	return files, bytes
+}
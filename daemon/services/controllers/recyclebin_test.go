@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmptyRecycleBin(t *testing.T) {
+	root := t.TempDir()
+	origPlugin, origShares := recycleBinPluginDir, recycleBinSharesDir
+	t.Cleanup(func() { recycleBinPluginDir, recycleBinSharesDir = origPlugin, origShares })
+	recycleBinPluginDir = filepath.Join(root, "plugin")
+	recycleBinSharesDir = filepath.Join(root, "user")
+
+	bin := filepath.Join(recycleBinSharesDir, "Media", ".Recycle.Bin")
+	if err := os.MkdirAll(filepath.Join(bin, "Movies"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(bin, "a.mkv"), filepath.Join(bin, "Movies", "b.mkv")} {
+		if err := os.WriteFile(path, make([]byte, 512), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := EmptyRecycleBin("Media"); err == nil {
+		t.Fatal("expected error when the plugin is not installed")
+	}
+	if err := os.MkdirAll(recycleBinPluginDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := EmptyRecycleBin("../etc"); err == nil {
+		t.Error("expected error for invalid share name")
+	}
+	if _, err := EmptyRecycleBin("appdata"); !errors.Is(err, ErrRecycleBinNotFound) {
+		t.Errorf("missing recycle bin: err = %v, want ErrRecycleBinNotFound", err)
+	}
+
+	result, err := EmptyRecycleBin("Media")
+	if err != nil {
+		t.Fatalf("EmptyRecycleBin: %v", err)
+	}
+	if result.Share != "Media" || result.FilesRemoved != 2 || result.BytesFreed != 1024 {
+		t.Errorf("result = %+v", result)
+	}
+	entries, err := os.ReadDir(bin)
+	if err != nil {
+		t.Fatalf("recycle bin directory should be kept: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("recycle bin still has %d entries", len(entries))
+	}
+}
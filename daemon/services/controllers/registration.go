@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// ErrInvalidRegistrationKey is returned for a key request that is malformed
+// or names a file that is not a key file.
+var ErrInvalidRegistrationKey = errors.New("invalid registration key request")
+
+// maxRegistrationKeyRedirects caps the redirects followed by a key download.
+const maxRegistrationKeyRedirects = 5
+
+// maxRegistrationKeySize caps uploaded and downloaded key files; real keys
+// are a few hundred bytes.
+const maxRegistrationKeySize = 16 << 10
+
+// Package-level variables so tests can install into a temporary directory
+// and fetch from a local TLS server.
+var (
+	registrationKeyDir    = "/boot/config"
+	registrationVarIni    = constants.VarIni
+	registrationKeyClient = &http.Client{Timeout: 30 * time.Second, CheckRedirect: checkRegistrationKeyRedirect}
+	// registrationKeyHosts are the hosts key files may be fetched from,
+	// together with their subdomains.
+	registrationKeyHosts = []string{"lime-technology.com", "unraid.net"}
+)
+
+// registrationKeyName matches Unraid key file names such as Pro.key or
+// Unleashed.key.
+var registrationKeyName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}\.key$`)
+
+// InstallRegistrationKey installs a registration key file on the flash drive,
+// either uploaded in the request or downloaded from a Lime Technology key
+// server. Other key files in /boot/config are renamed with a .bak suffix
+// first, since Unraid refuses to start the array with more than one key.
+func InstallRegistrationKey(ctx context.Context, req dto.RegistrationKeyRequest) (*dto.RegistrationKeyResult, error) {
+	if !req.Confirm {
+		return nil, fmt.Errorf("%w: set confirm to true to replace the installed key file", ErrInvalidRegistrationKey)
+	}
+
+	var (
+		name string
+		data []byte
+		err  error
+	)
+	switch {
+	case req.URL != "" && req.KeyData != "":
+		return nil, fmt.Errorf("%w: set either url or key_data, not both", ErrInvalidRegistrationKey)
+	case req.URL != "":
+		name, data, err = fetchRegistrationKey(ctx, req.URL, req.FileName)
+	case req.KeyData != "":
+		name = req.FileName
+		data, err = base64.StdEncoding.DecodeString(req.KeyData)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key_data is not valid base64: %w", ErrInvalidRegistrationKey, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: url or key_data is required", ErrInvalidRegistrationKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRegistrationKey(name, data); err != nil {
+		return nil, err
+	}
+
+	replaced, err := backupRegistrationKeys(name)
+	if err != nil {
+		return nil, err
+	}
+	target := filepath.Join(registrationKeyDir, name)
+	if err := writeFileAtomic(registrationKeyDir, name, data); err != nil {
+		return nil, fmt.Errorf("write key file: %w", err)
+	}
+	logger.InfoContext(ctx, "Registration: Installed key file %s (%d bytes), replaced %v", target, len(data), replaced)
+
+	result := &dto.RegistrationKeyResult{
+		FileName:     name,
+		Path:         target,
+		Size:         len(data),
+		Replaced:     replaced,
+		ArrayStarted: readArrayState(registrationVarIni) == "STARTED",
+		Message:      "Key file installed; registration is being re-read",
+		Timestamp:    time.Now(),
+	}
+	if result.ArrayStarted {
+		result.Message = "Key file installed; Unraid applies it the next time the array is started"
+	}
+	return result, nil
+}
+
+// fetchRegistrationKey downloads a key file over https from an allowed key
+// server and returns its file name and content. Redirects must stay on https
+// and on an allowed host.
+func fetchRegistrationKey(ctx context.Context, rawURL, fileName string) (string, []byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "", nil, fmt.Errorf("%w: url must be an https link", ErrInvalidRegistrationKey)
+	}
+	if !registrationKeyHostAllowed(u.Hostname()) {
+		return "", nil, fmt.Errorf("%w: key files can only be fetched from %s", ErrInvalidRegistrationKey, strings.Join(registrationKeyHosts, ", "))
+	}
+	if fileName == "" {
+		fileName = path.Base(u.Path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("create request: %w", err)
+	}
+	resp, err := registrationKeyClient.Do(req) // #nosec G107 -- host and redirects checked against registrationKeyHosts
+	if err != nil {
+		return "", nil, fmt.Errorf("download key file: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("download key file: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRegistrationKeySize+1))
+	if err != nil {
+		return "", nil, fmt.Errorf("download key file: %w", err)
+	}
+	return fileName, data, nil
+}
+
+// checkRegistrationKeyRedirect applies the checks of fetchRegistrationKey to
+// every redirect, so a key server cannot send the download to another host
+// or over plain http.
+func checkRegistrationKeyRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRegistrationKeyRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRegistrationKeyRedirects)
+	}
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("%w: redirected to a non-https url", ErrInvalidRegistrationKey)
+	}
+	if !registrationKeyHostAllowed(req.URL.Hostname()) {
+		return fmt.Errorf("%w: redirected to %s, which is not a key server", ErrInvalidRegistrationKey, req.URL.Hostname())
+	}
+	return nil
+}
+
+// registrationKeyHostAllowed reports whether host is a key server or one of
+// its subdomains.
+func registrationKeyHostAllowed(host string) bool {
+	host = strings.ToLower(host)
+	return slices.ContainsFunc(registrationKeyHosts, func(allowed string) bool {
+		return host == allowed || strings.HasSuffix(host, "."+allowed)
+	})
+}
+
+// validateRegistrationKey checks the file name and rejects content that
+// cannot be a key file, such as an HTML error page.
+func validateRegistrationKey(name string, data []byte) error {
+	if !registrationKeyName.MatchString(name) {
+		return fmt.Errorf("%w: %q is not a key file name (expected e.g. Pro.key)", ErrInvalidRegistrationKey, name)
+	}
+	switch {
+	case len(data) == 0:
+		return fmt.Errorf("%w: key file is empty", ErrInvalidRegistrationKey)
+	case len(data) > maxRegistrationKeySize:
+		return fmt.Errorf("%w: key file exceeds %d bytes", ErrInvalidRegistrationKey, maxRegistrationKeySize)
+	case bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")):
+		return fmt.Errorf("%w: content is an HTML or XML document, not a key file", ErrInvalidRegistrationKey)
+	}
+	return nil
+}
+
+// backupRegistrationKeys saves every installed key file as <name>.bak,
+// renaming the others and copying keep, which is about to be overwritten. It
+// returns the names of the backed-up files.
+func backupRegistrationKeys(keep string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(registrationKeyDir, "*.key"))
+	if err != nil {
+		return nil, err
+	}
+	var replaced []string
+	for _, match := range matches {
+		base := filepath.Base(match)
+		if base == keep {
+			if data, err := os.ReadFile(match); err == nil { //nolint:gosec // G304: path from glob of the key directory
+				if err := writeFileAtomic(registrationKeyDir, base+".bak", data); err != nil {
+					return nil, fmt.Errorf("back up %s: %w", base, err)
+				}
+				replaced = append(replaced, base)
+			}
+			continue
+		}
+		if err := os.Rename(match, match+".bak"); err != nil {
+			return nil, fmt.Errorf("back up %s: %w", base, err)
+		}
+		replaced = append(replaced, base)
+	}
+	return replaced, nil
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// useRegistrationKeyDir points the key installer at a temporary flash
+// config directory and var.ini for the duration of a test.
+func useRegistrationKeyDir(t *testing.T, arrayState string) string {
+	t.Helper()
+	dir := t.TempDir()
+	varIni := filepath.Join(dir, "var.ini")
+	if err := os.WriteFile(varIni, []byte("mdState=\""+arrayState+"\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	oldDir, oldVar := registrationKeyDir, registrationVarIni
+	registrationKeyDir, registrationVarIni = dir, varIni
+	t.Cleanup(func() { registrationKeyDir, registrationVarIni = oldDir, oldVar })
+	return dir
+}
+
+func TestInstallRegistrationKeyUpload(t *testing.T) {
+	dir := useRegistrationKeyDir(t, "STOPPED")
+	for name, content := range map[string]string{"Basic.key": "old-basic", "Plus.key": "old-plus"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := InstallRegistrationKey(context.Background(), dto.RegistrationKeyRequest{
+		KeyData:  base64.StdEncoding.EncodeToString([]byte("new-plus-key")),
+		FileName: "Plus.key",
+		Confirm:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.FileName != "Plus.key" || result.Size != 12 || result.ArrayStarted {
+		t.Errorf("result = %+v", result)
+	}
+	if !slices.Equal(result.Replaced, []string{"Basic.key", "Plus.key"}) {
+		t.Errorf("replaced = %v", result.Replaced)
+	}
+
+	keys, _ := filepath.Glob(filepath.Join(dir, "*.key"))
+	if len(keys) != 1 || filepath.Base(keys[0]) != "Plus.key" {
+		t.Fatalf("key files = %v, want only Plus.key", keys)
+	}
+	for name, want := range map[string]string{"Plus.key": "new-plus-key", "Plus.key.bak": "old-plus", "Basic.key.bak": "old-basic"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(got) != want {
+			t.Errorf("%s = %q (%v), want %q", name, got, err, want)
+		}
+	}
+}
+
+func TestInstallRegistrationKeyURL(t *testing.T) {
+	dir := useRegistrationKeyDir(t, "STARTED")
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/unraid/abc/Pro.key":
+			_, _ = w.Write([]byte{0x01, 0x02, 0x03})
+		case "/moved/Pro.key":
+			http.Redirect(w, r, "/unraid/abc/Pro.key", http.StatusFound)
+		case "/plain/Pro.key":
+			http.Redirect(w, r, "http://"+r.Host+"/unraid/abc/Pro.key", http.StatusFound)
+		case "/foreign/Pro.key":
+			http.Redirect(w, r, "https://example.com/Pro.key", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := srv.Client()
+	client.CheckRedirect = checkRegistrationKeyRedirect
+	oldClient, oldHosts := registrationKeyClient, registrationKeyHosts
+	registrationKeyClient, registrationKeyHosts = client, []string{"127.0.0.1"}
+	defer func() { registrationKeyClient, registrationKeyHosts = oldClient, oldHosts }()
+
+	result, err := InstallRegistrationKey(context.Background(), dto.RegistrationKeyRequest{
+		URL:     srv.URL + "/unraid/abc/Pro.key",
+		Confirm: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.FileName != "Pro.key" || !result.ArrayStarted || len(result.Replaced) != 0 {
+		t.Errorf("result = %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Pro.key")); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := InstallRegistrationKey(context.Background(), dto.RegistrationKeyRequest{
+		URL:     srv.URL + "/missing/Pro.key",
+		Confirm: true,
+	}); err == nil || errors.Is(err, ErrInvalidRegistrationKey) {
+		t.Errorf("missing key: err = %v, want download error", err)
+	}
+
+	// Redirects are followed only on https and to allowed hosts
+	if _, err := InstallRegistrationKey(context.Background(), dto.RegistrationKeyRequest{
+		URL:     srv.URL + "/moved/Pro.key",
+		Confirm: true,
+	}); err != nil {
+		t.Errorf("allowed redirect: %v", err)
+	}
+	for _, p := range []string{"/plain/Pro.key", "/foreign/Pro.key"} {
+		if _, err := InstallRegistrationKey(context.Background(), dto.RegistrationKeyRequest{
+			URL:     srv.URL + p,
+			Confirm: true,
+		}); !errors.Is(err, ErrInvalidRegistrationKey) {
+			t.Errorf("%s: err = %v, want ErrInvalidRegistrationKey", p, err)
+		}
+	}
+}
+
+func TestInstallRegistrationKeyRejects(t *testing.T) {
+	dir := useRegistrationKeyDir(t, "STOPPED")
+	data := base64.StdEncoding.EncodeToString([]byte("key"))
+	tests := []struct {
+		name string
+		req  dto.RegistrationKeyRequest
+	}{
+		{"no confirm", dto.RegistrationKeyRequest{KeyData: data, FileName: "Pro.key"}},
+		{"no source", dto.RegistrationKeyRequest{Confirm: true}},
+		{"both sources", dto.RegistrationKeyRequest{URL: "https://keys.lime-technology.com/Pro.key", KeyData: data, FileName: "Pro.key", Confirm: true}},
+		{"plain http", dto.RegistrationKeyRequest{URL: "http://keys.lime-technology.com/Pro.key", Confirm: true}},
+		{"foreign host", dto.RegistrationKeyRequest{URL: "https://example.com/Pro.key", Confirm: true}},
+		{"lookalike host", dto.RegistrationKeyRequest{URL: "https://evil-unraid.net/Pro.key", Confirm: true}},
+		{"bad base64", dto.RegistrationKeyRequest{KeyData: "not base64!", FileName: "Pro.key", Confirm: true}},
+		{"bad name", dto.RegistrationKeyRequest{KeyData: data, FileName: "../go", Confirm: true}},
+		{"not a key", dto.RegistrationKeyRequest{KeyData: data, FileName: "config.cfg", Confirm: true}},
+		{"html", dto.RegistrationKeyRequest{KeyData: base64.StdEncoding.EncodeToString([]byte("<html>")), FileName: "Pro.key", Confirm: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := InstallRegistrationKey(context.Background(), tt.req); !errors.Is(err, ErrInvalidRegistrationKey) {
+				t.Errorf("err = %v, want ErrInvalidRegistrationKey", err)
+			}
+		})
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("rejected requests wrote files: %v", entries)
+	}
+}
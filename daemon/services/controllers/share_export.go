@@ -0,0 +1,326 @@
+package controllers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+var (
+	// ErrShareNotFound is returned for a share without a configuration file.
+	ErrShareNotFound = errors.New("share not found")
+
+	// ErrInvalidShareExport is returned for an invalid export update.
+	ErrInvalidShareExport = errors.New("invalid share export settings")
+)
+
+// Package-level variables so tests can substitute the paths and emhttpd.
+var (
+	shareConfigDir   = "/boot/config/shares"
+	emhttpUsersIni   = "/var/local/emhttp/users.ini"
+	emhttpdAvailable = lib.IsEmhttpdAvailable
+	emhttpdRequest   = lib.EmhttpdRequest
+)
+
+// smbExportCodes maps SMB export modes to the shareExport values emhttpd uses.
+var smbExportCodes = map[string]string{
+	"no":                  "-",
+	"yes":                 "e",
+	"hidden":              "eh",
+	"time_machine":        "et",
+	"time_machine_hidden": "eth",
+}
+
+// nfsExportCodes maps NFS export modes to shareExportNFS values.
+var nfsExportCodes = map[string]string{
+	"no":  "-",
+	"yes": "e",
+}
+
+var shareSecurityModes = []string{"public", "secure", "private"}
+
+// nfsRulesPattern allows exports(5) host rules such as
+// "192.168.1.0/24(rw,sec=sys) *.lan(ro)".
+var nfsRulesPattern = regexp.MustCompile(`^[A-Za-z0-9.:/*_\-()=, @\[\]]*$`)
+
+// readShareCfg reads the key/value pairs of /boot/config/shares/<name>.cfg.
+func readShareCfg(name string) (map[string]string, error) {
+	if err := lib.ValidateShareName(name); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidShareExport, err)
+	}
+	// #nosec G304 -- name is validated by lib.ValidateShareName
+	data, err := os.ReadFile(filepath.Join(shareConfigDir, name+".cfg"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrShareNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to read share config: %w", err)
+	}
+	cfg := make(map[string]string)
+	for line := range strings.SplitSeq(string(data), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+		cfg[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return cfg, nil
+}
+
+// splitUserList splits a comma-separated user list.
+func splitUserList(s string) []string {
+	users := make([]string, 0)
+	for u := range strings.SplitSeq(s, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			users = append(users, u)
+		}
+	}
+	return users
+}
+
+// exportMode returns the mode name for an emhttpd export code.
+func exportMode(codes map[string]string, code string) string {
+	for mode, c := range codes {
+		if c == code {
+			return mode
+		}
+	}
+	return "no"
+}
+
+// GetShareExport returns how a user share is exported over SMB and NFS.
+func GetShareExport(name string) (*dto.ShareExport, error) {
+	cfg, err := readShareCfg(name)
+	if err != nil {
+		return nil, err
+	}
+	return shareExportFromCfg(name, cfg), nil
+}
+
+func shareExportFromCfg(name string, cfg map[string]string) *dto.ShareExport {
+	export := &dto.ShareExport{
+		Name:          name,
+		SMBExport:     exportMode(smbExportCodes, cfg["shareExport"]),
+		SMBSecurity:   cfg["shareSecurity"],
+		SMBReadUsers:  splitUserList(cfg["shareReadList"]),
+		SMBWriteUsers: splitUserList(cfg["shareWriteList"]),
+		NFSExport:     exportMode(nfsExportCodes, cfg["shareExportNFS"]),
+		NFSSecurity:   cfg["shareSecurityNFS"],
+		NFSRules:      cfg["shareHostListNFS"],
+		Timestamp:     time.Now(),
+	}
+	if export.SMBSecurity == "" {
+		export.SMBSecurity = "public"
+	}
+	if export.NFSSecurity == "" {
+		export.NFSSecurity = "public"
+	}
+	return export
+}
+
+// emhttpUser is an Unraid user account as listed in users.ini.
+type emhttpUser struct {
+	name, idx string
+}
+
+// readEmhttpUsers returns the user accounts emhttpd manages share access for.
+// root has no share access rules and is skipped.
+func readEmhttpUsers() ([]emhttpUser, error) {
+	f, err := os.Open(emhttpUsersIni)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	var users []emhttpUser
+	var current *emhttpUser
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			users = append(users, emhttpUser{name: strings.Trim(line, `[]"`)})
+			current = &users[len(users)-1]
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok && current != nil {
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+			switch strings.TrimSpace(key) {
+			case "name":
+				current.name = value
+			case "idx":
+				current.idx = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read users: %w", err)
+	}
+	return slices.DeleteFunc(users, func(u emhttpUser) bool { return u.name == "root" || u.idx == "" }), nil
+}
+
+// UpdateShareExport changes how a user share is exported over SMB and NFS.
+// The changes are submitted to emhttpd like the share settings page does, so
+// Unraid rewrites the share configuration and reloads Samba and NFS.
+func UpdateShareExport(name string, update dto.ShareExportUpdate) (*dto.ShareExport, error) {
+	logger.Info("Share: Updating export settings of %s", name)
+
+	cfg, err := readShareCfg(name)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := shareExportRequests(name, cfg, update)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return shareExportFromCfg(name, cfg), nil
+	}
+	if !emhttpdAvailable() {
+		return nil, fmt.Errorf("share export control unavailable: emhttpd socket not found at %s", lib.EmhttpdSocket)
+	}
+	for _, params := range requests {
+		if err := emhttpdRequest(params); err != nil {
+			return nil, fmt.Errorf("failed to update export settings of share %s: %w", name, err)
+		}
+	}
+
+	logger.Info("Share: Export settings of %s updated", name)
+	return GetShareExport(name)
+}
+
+// shareExportRequests validates update and builds the emhttpd requests that
+// apply it: SMB security, SMB user access, NFS security and NFS host rules.
+func shareExportRequests(name string, cfg map[string]string, update dto.ShareExportUpdate) ([]map[string]string, error) {
+	var requests []map[string]string
+
+	if update.SMBExport != nil || update.SMBSecurity != nil {
+		export, security := cfg["shareExport"], cfg["shareSecurity"]
+		if update.SMBExport != nil {
+			code, ok := smbExportCodes[*update.SMBExport]
+			if !ok {
+				return nil, fmt.Errorf("%w: smb_export must be no, yes, hidden, time_machine or time_machine_hidden", ErrInvalidShareExport)
+			}
+			export = code
+		}
+		if update.SMBSecurity != nil {
+			if !slices.Contains(shareSecurityModes, *update.SMBSecurity) {
+				return nil, fmt.Errorf("%w: smb_security must be public, secure or private", ErrInvalidShareExport)
+			}
+			security = *update.SMBSecurity
+		}
+		params := map[string]string{
+			"shareName":           name,
+			"shareExport":         export,
+			"shareSecurity":       security,
+			"changeShareSecurity": "Apply",
+		}
+		// The settings page submits these with the security form; keep them.
+		for _, key := range []string{"shareFruit", "shareCaseSensitive", "shareVolsizelimit"} {
+			if v, ok := cfg[key]; ok {
+				params[key] = v
+			}
+		}
+		requests = append(requests, params)
+	}
+
+	if update.SMBReadUsers != nil || update.SMBWriteUsers != nil {
+		params, err := shareAccessParams(name, cfg, update)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, params)
+	}
+
+	if update.NFSExport != nil || update.NFSSecurity != nil {
+		export, security := cfg["shareExportNFS"], cfg["shareSecurityNFS"]
+		if update.NFSExport != nil {
+			code, ok := nfsExportCodes[*update.NFSExport]
+			if !ok {
+				return nil, fmt.Errorf("%w: nfs_export must be no or yes", ErrInvalidShareExport)
+			}
+			export = code
+		}
+		if update.NFSSecurity != nil {
+			if !slices.Contains(shareSecurityModes, *update.NFSSecurity) {
+				return nil, fmt.Errorf("%w: nfs_security must be public, secure or private", ErrInvalidShareExport)
+			}
+			security = *update.NFSSecurity
+		}
+		requests = append(requests, map[string]string{
+			"shareName":              name,
+			"shareExportNFS":         export,
+			"shareSecurityNFS":       security,
+			"changeShareSecurityNFS": "Apply",
+		})
+	}
+
+	if update.NFSRules != nil {
+		rules := strings.TrimSpace(*update.NFSRules)
+		if len(rules) > 1024 || !nfsRulesPattern.MatchString(rules) {
+			return nil, fmt.Errorf("%w: nfs_rules must be exports(5) host rules such as 192.168.1.0/24(rw,sec=sys)", ErrInvalidShareExport)
+		}
+		requests = append(requests, map[string]string{
+			"shareName":            name,
+			"shareHostListNFS":     rules,
+			"changeShareAccessNFS": "Apply",
+		})
+	}
+
+	return requests, nil
+}
+
+// shareAccessParams builds the emhttpd per-user access request. Every user is
+// submitted: listed users get read-only or read-write access, everyone else
+// no access.
+func shareAccessParams(name string, cfg map[string]string, update dto.ShareExportUpdate) (map[string]string, error) {
+	readUsers, writeUsers := splitUserList(cfg["shareReadList"]), splitUserList(cfg["shareWriteList"])
+	if update.SMBReadUsers != nil {
+		readUsers = *update.SMBReadUsers
+	}
+	if update.SMBWriteUsers != nil {
+		writeUsers = *update.SMBWriteUsers
+	}
+
+	users, err := readEmhttpUsers()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]string, len(users))
+	for _, u := range users {
+		known[u.name] = u.idx
+	}
+	for _, u := range slices.Concat(readUsers, writeUsers) {
+		if _, ok := known[u]; !ok {
+			return nil, fmt.Errorf("%w: unknown user %q", ErrInvalidShareExport, u)
+		}
+		if slices.Contains(readUsers, u) && slices.Contains(writeUsers, u) {
+			return nil, fmt.Errorf("%w: user %q is in both smb_read_users and smb_write_users", ErrInvalidShareExport, u)
+		}
+	}
+
+	params := map[string]string{
+		"shareName":         name,
+		"changeShareAccess": "Apply",
+	}
+	for _, u := range users {
+		access := "no-access"
+		switch {
+		case slices.Contains(writeUsers, u.name):
+			access = "read-write"
+		case slices.Contains(readUsers, u.name):
+			access = "read-only"
+		}
+		params["userAccess."+u.idx] = access
+	}
+	return params, nil
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+const testUsersIni = `["root"]
+name="root"
+idx="0"
+["alice"]
+name="alice"
+idx="1"
+["bob"]
+name="bob"
+idx="2"
+`
+
+func setupShareExport(t *testing.T, cfg string) *[]map[string]string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "media.cfg"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	usersIni := filepath.Join(dir, "users.ini")
+	if err := os.WriteFile(usersIni, []byte(testUsersIni), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent []map[string]string
+	origDir, origUsers, origAvail, origReq := shareConfigDir, emhttpUsersIni, emhttpdAvailable, emhttpdRequest
+	shareConfigDir, emhttpUsersIni = dir, usersIni
+	emhttpdAvailable = func() bool { return true }
+	emhttpdRequest = func(params map[string]string) error {
+		sent = append(sent, params)
+		return nil
+	}
+	t.Cleanup(func() {
+		shareConfigDir, emhttpUsersIni, emhttpdAvailable, emhttpdRequest = origDir, origUsers, origAvail, origReq
+	})
+	return &sent
+}
+
+func TestGetShareExport(t *testing.T) {
+	setupShareExport(t, `shareExport="eh"
+shareSecurity="private"
+shareReadList="alice"
+shareWriteList="bob"
+shareExportNFS="e"
+shareHostListNFS="192.168.1.0/24(rw)"
+`)
+
+	export, err := GetShareExport("media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export.SMBExport != "hidden" || export.SMBSecurity != "private" || export.NFSExport != "yes" || export.NFSSecurity != "public" {
+		t.Errorf("export = %+v", export)
+	}
+	if len(export.SMBReadUsers) != 1 || export.SMBReadUsers[0] != "alice" || len(export.SMBWriteUsers) != 1 || export.SMBWriteUsers[0] != "bob" {
+		t.Errorf("users = %v / %v", export.SMBReadUsers, export.SMBWriteUsers)
+	}
+	if export.NFSRules != "192.168.1.0/24(rw)" {
+		t.Errorf("NFSRules = %q", export.NFSRules)
+	}
+
+	if _, err := GetShareExport("missing"); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("missing share: err = %v, want ErrShareNotFound", err)
+	}
+	if _, err := GetShareExport("../etc"); !errors.Is(err, ErrInvalidShareExport) {
+		t.Errorf("invalid name: err = %v, want ErrInvalidShareExport", err)
+	}
+}
+
+func TestUpdateShareExport(t *testing.T) {
+	sent := setupShareExport(t, `shareExport="-"
+shareSecurity="public"
+shareFruit="no"
+shareReadList="alice"
+shareExportNFS="-"
+`)
+	smbExport, security := "yes", "secure"
+	writeUsers := []string{"bob"}
+	nfsRules := "*(ro,sec=sys)"
+
+	if _, err := UpdateShareExport("media", dto.ShareExportUpdate{
+		SMBExport:     &smbExport,
+		SMBSecurity:   &security,
+		SMBWriteUsers: &writeUsers,
+		NFSRules:      &nfsRules,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*sent) != 3 {
+		t.Fatalf("sent %d requests, want 3: %v", len(*sent), *sent)
+	}
+	smb := (*sent)[0]
+	if smb["changeShareSecurity"] != "Apply" || smb["shareExport"] != "e" || smb["shareSecurity"] != "secure" || smb["shareFruit"] != "no" {
+		t.Errorf("security request = %v", smb)
+	}
+	access := (*sent)[1]
+	if access["userAccess.1"] != "read-only" || access["userAccess.2"] != "read-write" {
+		t.Errorf("access request = %v (alice keeps read-only, bob gets read-write)", access)
+	}
+	if _, ok := access["userAccess.0"]; ok {
+		t.Error("root must not be submitted")
+	}
+	if nfs := (*sent)[2]; nfs["changeShareAccessNFS"] != "Apply" || nfs["shareHostListNFS"] != nfsRules {
+		t.Errorf("NFS rules request = %v", nfs)
+	}
+}
+
+func TestUpdateShareExportValidation(t *testing.T) {
+	sent := setupShareExport(t, "shareExport=\"e\"\n")
+	str := func(s string) *string { return &s }
+	list := func(s ...string) *[]string { return &s }
+
+	tests := []struct {
+		name   string
+		update dto.ShareExportUpdate
+	}{
+		{"bad smb export", dto.ShareExportUpdate{SMBExport: str("maybe")}},
+		{"bad security", dto.ShareExportUpdate{SMBSecurity: str("open")}},
+		{"bad nfs export", dto.ShareExportUpdate{NFSExport: str("hidden")}},
+		{"unknown user", dto.ShareExportUpdate{SMBReadUsers: list("mallory")}},
+		{"root user", dto.ShareExportUpdate{SMBWriteUsers: list("root")}},
+		{"user in both lists", dto.ShareExportUpdate{SMBReadUsers: list("alice"), SMBWriteUsers: list("alice")}},
+		{"nfs rules injection", dto.ShareExportUpdate{NFSRules: str("*(rw)\n/etc *(rw)")}},
+	}
+	for _, tt := range tests {
+		if _, err := UpdateShareExport("media", tt.update); !errors.Is(err, ErrInvalidShareExport) {
+			t.Errorf("%s: err = %v, want ErrInvalidShareExport", tt.name, err)
+		}
+	}
+	if len(*sent) != 0 {
+		t.Errorf("invalid updates sent %d requests", len(*sent))
+	}
+
+	emhttpdAvailable = func() bool { return false }
+	if _, err := UpdateShareExport("media", dto.ShareExportUpdate{SMBExport: str("no")}); err == nil {
+		t.Error("expected error without emhttpd")
+	}
+}
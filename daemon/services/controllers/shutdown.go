@@ -0,0 +1,280 @@
+package controllers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// shutdownVMTimeout bounds the graceful shutdown of each VM before it is
+	// forced off.
+	shutdownVMTimeout = 3 * time.Minute
+
+	// shutdownArrayTimeout bounds waiting for the array to report STOPPED.
+	shutdownArrayTimeout = 5 * time.Minute
+
+	// shutdownSyncTimeout bounds flushing dirty pages to disk.
+	shutdownSyncTimeout = 5 * time.Minute
+
+	// arrayPollInterval is how often the array state is checked while stopping.
+	arrayPollInterval = 2 * time.Second
+)
+
+// ErrShutdownInProgress is returned when an orchestrated shutdown is requested
+// while another one is running.
+var ErrShutdownInProgress = errors.New("an orchestrated shutdown is already in progress")
+
+// shutdownInProgress guards against concurrent orchestrated shutdowns.
+var shutdownInProgress atomic.Bool
+
+// shutdownStep is one stage of an orchestrated shutdown. run reports
+// intermediate progress through report.
+type shutdownStep struct {
+	name string
+	run  func(report func(string)) error
+	// critical steps abort the sequence when they fail, so the server is not
+	// powered off with the array still started.
+	critical bool
+}
+
+// StartOrchestratedShutdown starts a safe shutdown in the background: running
+// VMs are shut down, containers are stopped in reverse autostart order, the
+// array is stopped, disks are synced, and the server is powered off. Progress
+// is published on constants.TopicShutdownProgress. If the array cannot be
+// stopped the sequence is aborted and the server keeps running.
+func (c *SystemController) StartOrchestratedShutdown() error {
+	if !shutdownInProgress.CompareAndSwap(false, true) {
+		return ErrShutdownInProgress
+	}
+
+	go func() {
+		defer shutdownInProgress.Store(false)
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("Orchestrated shutdown", r)
+			}
+		}()
+		if err := c.runShutdown(c.shutdownSteps()); err != nil {
+			logger.Error("System: orchestrated shutdown aborted: %v", err)
+			if nerr := CreateNotification(i18n.T("Shutdown aborted"), i18n.T("Orchestrated shutdown aborted"),
+				i18n.T("The server was not powered off: %v", err), "alert", ""); nerr != nil {
+				logger.Warning("System: failed to send shutdown notification: %v", nerr)
+			}
+		}
+	}()
+	return nil
+}
+
+// runShutdown runs steps in order, publishing a running event when each step
+// starts and a done, warning or failed event when it ends. A failing critical
+// step stops the sequence and its error is returned.
+func (c *SystemController) runShutdown(steps []shutdownStep) error {
+	logger.Info("System: orchestrated shutdown started")
+	for _, step := range steps {
+		report := func(msg string) {
+			logger.Info("System: shutdown %s: %s", step.name, msg)
+			c.publishShutdownProgress(step.name, dto.ShutdownStateRunning, msg)
+		}
+		report("started")
+
+		err := step.run(report)
+		switch {
+		case err == nil:
+			c.publishShutdownProgress(step.name, dto.ShutdownStateDone, "completed")
+		case step.critical:
+			c.publishShutdownProgress(step.name, dto.ShutdownStateFailed, err.Error())
+			return fmt.Errorf("%s: %w", step.name, err)
+		default:
+			logger.Warning("System: shutdown %s: %v", step.name, err)
+			c.publishShutdownProgress(step.name, dto.ShutdownStateWarning, err.Error())
+		}
+	}
+	return nil
+}
+
+// publishShutdownProgress publishes one progress event when an event bus is
+// available.
+func (c *SystemController) publishShutdownProgress(step, state, msg string) {
+	if c.ctx == nil || c.ctx.Hub == nil {
+		return
+	}
+	domain.Publish(c.ctx.Hub, constants.TopicShutdownProgress, dto.ShutdownProgress{
+		Step:      step,
+		State:     state,
+		Message:   msg,
+		Timestamp: time.Now(),
+	})
+}
+
+// shutdownSteps returns the real shutdown sequence.
+func (c *SystemController) shutdownSteps() []shutdownStep {
+	return []shutdownStep{
+		{name: dto.ShutdownStepVMs, run: shutdownVMs},
+		{name: dto.ShutdownStepContainers, run: shutdownContainers},
+		{name: dto.ShutdownStepArray, run: c.shutdownArray, critical: true},
+		{name: dto.ShutdownStepSync, run: shutdownSync, critical: true},
+		{name: dto.ShutdownStepPowerOff, run: func(func(string)) error { return c.Shutdown() }, critical: true},
+	}
+}
+
+// shutdownVMs gracefully shuts down all running VMs in parallel. Libvirt has
+// no VM start order, so there is no order to reverse.
+func shutdownVMs(report func(string)) error {
+	vc := NewVMController()
+	names, err := vc.RunningVMs()
+	if err != nil {
+		report(fmt.Sprintf("skipped: %v", err))
+		return nil
+	}
+	if len(names) == 0 {
+		report("no running VMs")
+		return nil
+	}
+
+	report(fmt.Sprintf("shutting down %d VM(s): %s", len(names), strings.Join(names, ", ")))
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, name := range names {
+		wg.Go(func() {
+			if err := vc.StopAndWait(name, shutdownVMTimeout); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				return
+			}
+			report(fmt.Sprintf("VM %s shut down", name))
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// shutdownContainers stops running containers: those not in the autostart
+// list first, then autostarted containers in reverse start order.
+func shutdownContainers(report func(string)) error {
+	dc := NewDockerController()
+	defer dc.Close() //nolint:errcheck
+
+	running, err := dc.RunningContainerNames()
+	if err != nil {
+		report(fmt.Sprintf("skipped: %v", err))
+		return nil
+	}
+	autostart, err := readAutostartFile(dockerAutostartFile)
+	if err != nil {
+		logger.Warning("System: shutdown: %v; stopping containers in list order", err)
+	}
+
+	order := containerStopOrder(running, autostart)
+	if len(order) == 0 {
+		report("no running containers")
+		return nil
+	}
+
+	var errs []error
+	for i, name := range order {
+		report(fmt.Sprintf("stopping container %s (%d/%d)", name, i+1, len(order)))
+		if err := dc.Stop(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// containerStopOrder orders running containers for shutdown: containers that
+// are not autostarted come first (sorted by name), followed by autostarted
+// containers in reverse autostart order, so dependencies such as databases
+// that start first are stopped last.
+func containerStopOrder(running []string, autostart []autostartLine) []string {
+	isRunning := make(map[string]bool, len(running))
+	for _, name := range running {
+		isRunning[name] = true
+	}
+
+	var ordered []string
+	for _, e := range slices.Backward(autostart) {
+		if isRunning[e.Name] {
+			ordered = append(ordered, e.Name)
+			delete(isRunning, e.Name)
+		}
+	}
+
+	others := make([]string, 0, len(isRunning))
+	for name := range isRunning {
+		others = append(others, name)
+	}
+	slices.Sort(others)
+	return append(others, ordered...)
+}
+
+// shutdownArray stops the array and waits until it reports STOPPED.
+func (c *SystemController) shutdownArray(report func(string)) error {
+	if state := readArrayState(constants.VarIni); state == "STOPPED" {
+		report("array already stopped")
+		return nil
+	}
+
+	if err := NewArrayController(c.ctx).StopArray(); err != nil {
+		return err
+	}
+	report("waiting for the array to stop")
+
+	deadline := time.Now().Add(shutdownArrayTimeout)
+	for time.Now().Before(deadline) {
+		if readArrayState(constants.VarIni) == "STOPPED" {
+			return nil
+		}
+		time.Sleep(arrayPollInterval)
+	}
+	return fmt.Errorf("array did not stop within %s", shutdownArrayTimeout)
+}
+
+// ArrayState returns the array state from var.ini (e.g. "STARTED",
+// "STOPPED"), or "" when it cannot be read.
+func ArrayState() string {
+	return readArrayState(constants.VarIni)
+}
+
+// readArrayState returns the mdState value from var.ini (e.g. "STARTED",
+// "STOPPED"), or "" when it cannot be read.
+func readArrayState(path string) string {
+	file, err := os.Open(path) //nolint:gosec // G304: path is the constant var.ini location
+	if err != nil {
+		return ""
+	}
+	defer file.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "mdState="); ok {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// shutdownSync flushes dirty pages to all disks.
+func shutdownSync(report func(string)) error {
+	report("flushing disk caches")
+	if _, err := lib.ExecCommandWithTimeout(shutdownSyncTimeout, "sync"); err != nil {
+		return fmt.Errorf("sync failed: %w", err)
+	}
+	return nil
+}
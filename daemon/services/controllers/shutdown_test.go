@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestContainerStopOrder(t *testing.T) {
+	running := []string{"plex", "mariadb", "nextcloud", "adhoc", "zz-tool"}
+	autostart := []autostartLine{{Name: "mariadb"}, {Name: "stopped"}, {Name: "nextcloud", Wait: 10}, {Name: "plex"}}
+
+	got := containerStopOrder(running, autostart)
+	want := []string{"adhoc", "zz-tool", "plex", "nextcloud", "mariadb"}
+	if !slices.Equal(got, want) {
+		t.Errorf("containerStopOrder() = %v, want %v", got, want)
+	}
+
+	if got := containerStopOrder(nil, autostart); len(got) != 0 {
+		t.Errorf("containerStopOrder(nil) = %v, want empty", got)
+	}
+}
+
+func TestRunShutdown(t *testing.T) {
+	noop := func(func(string)) error { return nil }
+
+	t.Run("non-critical failure continues", func(t *testing.T) {
+		var ran []string
+		step := func(name string, err error) shutdownStep {
+			return shutdownStep{name: name, run: func(report func(string)) error {
+				ran = append(ran, name)
+				report("working")
+				return err
+			}}
+		}
+		hub := domain.NewEventBus(32)
+		ch := hub.SubTopics(constants.TopicShutdownProgress)
+		defer hub.Unsub(ch)
+
+		c := NewSystemController(&domain.Context{Hub: hub})
+		err := c.runShutdown([]shutdownStep{step("a", errors.New("boom")), step("b", nil)})
+		if err != nil {
+			t.Fatalf("runShutdown() error = %v", err)
+		}
+		if !slices.Equal(ran, []string{"a", "b"}) {
+			t.Errorf("ran = %v, want [a b]", ran)
+		}
+
+		var states []string
+		for range 6 {
+			p := (<-ch).(dto.ShutdownProgress)
+			states = append(states, p.Step+":"+p.State)
+		}
+		want := []string{
+			"a:" + dto.ShutdownStateRunning, "a:" + dto.ShutdownStateRunning, "a:" + dto.ShutdownStateWarning,
+			"b:" + dto.ShutdownStateRunning, "b:" + dto.ShutdownStateRunning, "b:" + dto.ShutdownStateDone,
+		}
+		if !slices.Equal(states, want) {
+			t.Errorf("progress = %v, want %v", states, want)
+		}
+	})
+
+	t.Run("critical failure aborts", func(t *testing.T) {
+		poweredOff := false
+		c := NewSystemController(&domain.Context{})
+		err := c.runShutdown([]shutdownStep{
+			{name: dto.ShutdownStepContainers, run: noop},
+			{name: dto.ShutdownStepArray, critical: true, run: func(func(string)) error { return errors.New("array busy") }},
+			{name: dto.ShutdownStepPowerOff, critical: true, run: func(func(string)) error { poweredOff = true; return nil }},
+		})
+		if err == nil {
+			t.Fatal("expected error when a critical step fails")
+		}
+		if poweredOff {
+			t.Error("power off ran after a critical failure")
+		}
+	})
+}
+
+func TestStartOrchestratedShutdownInProgress(t *testing.T) {
+	shutdownInProgress.Store(true)
+	defer shutdownInProgress.Store(false)
+
+	if err := NewSystemController(&domain.Context{}).StartOrchestratedShutdown(); !errors.Is(err, ErrShutdownInProgress) {
+		t.Errorf("StartOrchestratedShutdown() error = %v, want ErrShutdownInProgress", err)
+	}
+}
+
+func TestReadArrayState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "var.ini")
+	if err := os.WriteFile(path, []byte("NAME=\"Tower\"\nmdState=\"STOPPED\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readArrayState(path); got != "STOPPED" {
+		t.Errorf("readArrayState() = %q, want STOPPED", got)
+	}
+	if got := readArrayState(filepath.Join(t.TempDir(), "missing.ini")); got != "" {
+		t.Errorf("readArrayState(missing) = %q, want empty", got)
+	}
+}
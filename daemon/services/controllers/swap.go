@@ -0,0 +1,366 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+var (
+	// ErrInvalidSwapRequest is returned for an unconfirmed or invalid swap
+	// file request, or a pool whose filesystem cannot hold a swap file.
+	ErrInvalidSwapRequest = errors.New("invalid swap file request")
+	// ErrSwapFileExists is returned when a swap file is already configured
+	// or the target path is taken.
+	ErrSwapFileExists = errors.New("swap file already exists")
+	// ErrNoSwapFile is returned when disabling without a configured swap file.
+	ErrNoSwapFile = errors.New("no swap file configured")
+)
+
+const (
+	// swapFileName is the name of the swap file in the pool's root.
+	swapFileName = "swapfile"
+	// swapConfigFile stores the configured swap file on the flash drive.
+	swapConfigFile = "swapfile.json"
+
+	minSwapFileBytes = 256 << 20
+	maxSwapFileBytes = 256 << 30
+	// swapFreeMargin is the space left free on the pool after allocation.
+	swapFreeMargin = 1 << 30
+	// swapoffTimeout bounds swapoff, which pages everything back into RAM.
+	swapoffTimeout = 10 * time.Minute
+
+	btrfsMagic = 0x9123683e
+	zfsMagic   = 0x2fc12fc1
+	tmpfsMagic = 0x01021994
+)
+
+// Package-level variables so tests can use fixture files.
+var (
+	procSwapsPath  = "/proc/swaps"
+	zramSysDir     = "/sys/block"
+	swapPoolsDir   = constants.PoolsConfigDir
+	swapMountDir   = "/mnt"
+	swapConfigDir  = "/boot/config/plugins/unraid-management-agent"
+	swapStatfs     = syscall.Statfs
+	swapRunCommand = lib.ExecCommandWithTimeout
+)
+
+// GetSwapStatus returns the active swap devices with their usage, zram
+// compression, swappiness and the swap file configured through the API.
+func GetSwapStatus() (*dto.SwapStatus, error) {
+	devices, err := readProcSwaps()
+	if err != nil {
+		return nil, fmt.Errorf("read swap devices: %w", err)
+	}
+	status := &dto.SwapStatus{Devices: devices, Swappiness: -1, Timestamp: time.Now()}
+	if v, err := lib.ReadSysctlInt("vm.swappiness"); err == nil {
+		status.Swappiness = v
+	}
+	for _, d := range devices {
+		status.TotalBytes += d.SizeBytes
+		status.UsedBytes += d.UsedBytes
+	}
+	status.Usage = percentOf(status.UsedBytes, status.TotalBytes)
+
+	cfg, err := loadSwapFileConfig()
+	if err != nil {
+		logger.Warning("Swap: failed to read swap file config: %v", err)
+	}
+	if cfg != nil {
+		cfg.Active = swapActive(devices, cfg.Path)
+		status.SwapFile = cfg
+	}
+	return status, nil
+}
+
+// readProcSwaps parses /proc/swaps. Sizes there are in KiB.
+func readProcSwaps() ([]dto.SwapDevice, error) {
+	file, err := os.Open(procSwapsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	devices := []dto.SwapDevice{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] == "Filename" {
+			continue
+		}
+		size, _ := strconv.ParseUint(fields[2], 10, 64)
+		used, _ := strconv.ParseUint(fields[3], 10, 64)
+		prio, _ := strconv.Atoi(fields[4])
+		// The kernel escapes spaces in paths as \040.
+		path := strings.ReplaceAll(fields[0], `\040`, " ")
+		d := dto.SwapDevice{
+			Path:      path,
+			Type:      fields[1],
+			SizeBytes: size * 1024,
+			UsedBytes: used * 1024,
+			Priority:  prio,
+		}
+		d.Usage = percentOf(d.UsedBytes, d.SizeBytes)
+		if name := filepath.Base(path); strings.HasPrefix(name, "zram") && strings.HasPrefix(path, "/dev/") {
+			d.Type = "zram"
+			d.Zram = readZramStats(name)
+		}
+		devices = append(devices, d)
+	}
+	return devices, scanner.Err()
+}
+
+// readZramStats reads the compression algorithm and mm_stat of a zram device.
+func readZramStats(name string) *dto.ZramStats {
+	dir := filepath.Join(zramSysDir, name)
+	data, err := os.ReadFile(filepath.Join(dir, "mm_stat")) //nolint:gosec // G304: zram device name from /proc/swaps
+	if err != nil {
+		return nil
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return nil
+	}
+	stats := &dto.ZramStats{}
+	stats.OriginalBytes, _ = strconv.ParseUint(fields[0], 10, 64)
+	stats.CompressedBytes, _ = strconv.ParseUint(fields[1], 10, 64)
+	stats.MemUsedBytes, _ = strconv.ParseUint(fields[2], 10, 64)
+	if stats.CompressedBytes > 0 {
+		stats.CompressionRatio = math.Round(float64(stats.OriginalBytes)/float64(stats.CompressedBytes)*100) / 100
+	}
+	// comp_algorithm lists the available algorithms with the active one in
+	// brackets, e.g. "lzo lz4 [zstd]".
+	if algos, err := os.ReadFile(filepath.Join(dir, "comp_algorithm")); err == nil { //nolint:gosec // G304: see above
+		for a := range strings.FieldsSeq(string(algos)) {
+			if strings.HasPrefix(a, "[") {
+				stats.Algorithm = strings.Trim(a, "[]")
+			}
+		}
+	}
+	return stats
+}
+
+func percentOf(part, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
+
+func swapActive(devices []dto.SwapDevice, path string) bool {
+	for _, d := range devices {
+		if d.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// EnableSwapFile creates a swap file in the root of a pool and enables it.
+// On btrfs the file is created with copy-on-write disabled, which swap
+// requires; ZFS pools cannot hold a swap file. The file is re-enabled each
+// time the agent starts.
+func EnableSwapFile(req dto.SwapFileRequest) (*dto.SwapFileConfig, error) {
+	if !req.Confirm {
+		return nil, fmt.Errorf("%w: set confirm to true to create the swap file", ErrInvalidSwapRequest)
+	}
+	if err := lib.ValidateDiskName(req.Pool); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSwapRequest, err)
+	}
+	if req.SizeBytes < minSwapFileBytes || req.SizeBytes > maxSwapFileBytes {
+		return nil, fmt.Errorf("%w: size_bytes must be between 256 MiB and 256 GiB", ErrInvalidSwapRequest)
+	}
+	if _, err := os.Stat(filepath.Join(swapPoolsDir, req.Pool+".cfg")); err != nil {
+		return nil, fmt.Errorf("%w: %s is not a pool", ErrInvalidSwapRequest, req.Pool)
+	}
+	if cfg, err := loadSwapFileConfig(); err != nil {
+		return nil, err
+	} else if cfg != nil {
+		return nil, fmt.Errorf("%w: %s is configured; disable it first", ErrSwapFileExists, cfg.Path)
+	}
+
+	mount := filepath.Join(swapMountDir, req.Pool)
+	var st syscall.Statfs_t
+	if err := swapStatfs(mount, &st); err != nil {
+		return nil, fmt.Errorf("%w: pool %s is not mounted", ErrInvalidSwapRequest, req.Pool)
+	}
+	switch st.Type {
+	case zfsMagic:
+		return nil, fmt.Errorf("%w: swap files are not supported on ZFS pools", ErrInvalidSwapRequest)
+	case tmpfsMagic:
+		return nil, fmt.Errorf("%w: pool %s is not mounted", ErrInvalidSwapRequest, req.Pool)
+	}
+	if free := st.Bavail * uint64(st.Bsize); free < req.SizeBytes+swapFreeMargin { //nolint:gosec // G115: block size is positive
+		return nil, fmt.Errorf("%w: pool %s has %d bytes free, not enough for the swap file and a 1 GiB margin", ErrInvalidSwapRequest, req.Pool, free)
+	}
+
+	path := filepath.Join(mount, swapFileName)
+	if _, err := os.Lstat(path); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSwapFileExists, path)
+	}
+
+	logger.Info("Swap: creating %d byte swap file %s", req.SizeBytes, path)
+	if err := createSwapFile(path, req.SizeBytes, st.Type == btrfsMagic); err != nil {
+		if rmErr := os.Remove(path); rmErr != nil && !os.IsNotExist(rmErr) {
+			logger.Warning("Swap: failed to remove %s: %v", path, rmErr)
+		}
+		return nil, err
+	}
+
+	cfg := &dto.SwapFileConfig{Pool: req.Pool, Path: path, SizeBytes: req.SizeBytes, Active: true}
+	if err := saveSwapFileConfig(cfg); err != nil {
+		return cfg, fmt.Errorf("swap file enabled but not saved for the next start: %w", err)
+	}
+	return cfg, nil
+}
+
+// createSwapFile allocates, formats and enables a swap file.
+func createSwapFile(path string, size uint64, btrfs bool) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600) //nolint:gosec // G304: path under the pool mount
+	if err != nil {
+		return fmt.Errorf("create swap file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("create swap file: %w", err)
+	}
+	// btrfs only allows swap on an empty file marked No_COW before any
+	// data is written.
+	if btrfs {
+		if _, err := swapRunCommand(30*time.Second, "chattr", "+C", path); err != nil {
+			return fmt.Errorf("disable copy-on-write: %w", err)
+		}
+	}
+	steps := [][]string{
+		{"fallocate", "-l", strconv.FormatUint(size, 10), path},
+		{"mkswap", path},
+		{"swapon", path},
+	}
+	for _, step := range steps {
+		if _, err := swapRunCommand(5*time.Minute, step[0], step[1:]...); err != nil {
+			return fmt.Errorf("%s: %w", step[0], err)
+		}
+	}
+	return nil
+}
+
+// DisableSwapFile turns off the configured swap file and forgets it. With
+// remove the file is deleted from the pool as well.
+func DisableSwapFile(remove bool) (*dto.SwapFileConfig, error) {
+	cfg, err := loadSwapFileConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, ErrNoSwapFile
+	}
+	if err := swapOff(cfg.Path); err != nil {
+		return nil, err
+	}
+	cfg.Active = false
+	if err := os.Remove(filepath.Join(swapConfigDir, swapConfigFile)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("remove swap file config: %w", err)
+	}
+	if remove {
+		logger.Info("Swap: deleting %s", cfg.Path)
+		if err := os.Remove(cfg.Path); err != nil && !os.IsNotExist(err) {
+			return cfg, fmt.Errorf("delete swap file: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// swapOff disables a swap area if it is active.
+func swapOff(path string) error {
+	devices, err := readProcSwaps()
+	if err != nil {
+		return fmt.Errorf("read swap devices: %w", err)
+	}
+	if !swapActive(devices, path) {
+		return nil
+	}
+	logger.Info("Swap: disabling %s", path)
+	if _, err := swapRunCommand(swapoffTimeout, "swapoff", path); err != nil {
+		return fmt.Errorf("swapoff: %w", err)
+	}
+	return nil
+}
+
+// RestoreSwapFile enables the configured swap file at startup. The agent
+// starts with the array, so the pool is mounted by then.
+func RestoreSwapFile() {
+	cfg, err := loadSwapFileConfig()
+	if err != nil || cfg == nil {
+		if err != nil {
+			logger.Warning("Swap: failed to read swap file config: %v", err)
+		}
+		return
+	}
+	devices, err := readProcSwaps()
+	if err == nil && swapActive(devices, cfg.Path) {
+		return
+	}
+	if _, err := os.Stat(cfg.Path); err != nil {
+		logger.Warning("Swap: configured swap file %s is missing", cfg.Path)
+		return
+	}
+	if _, err := swapRunCommand(time.Minute, "swapon", cfg.Path); err != nil {
+		logger.Warning("Swap: failed to enable %s: %v", cfg.Path, err)
+		return
+	}
+	logger.Info("Swap: enabled swap file %s", cfg.Path)
+}
+
+// ReleaseSwapFile disables the configured swap file at shutdown, keeping it
+// configured, so the array can stop and unmount the pool.
+func ReleaseSwapFile() {
+	cfg, err := loadSwapFileConfig()
+	if err != nil || cfg == nil {
+		return
+	}
+	if err := swapOff(cfg.Path); err != nil {
+		logger.Warning("Swap: failed to disable %s: %v", cfg.Path, err)
+	}
+}
+
+// loadSwapFileConfig returns the configured swap file, or nil when none is.
+func loadSwapFileConfig() (*dto.SwapFileConfig, error) {
+	data, err := os.ReadFile(filepath.Join(swapConfigDir, swapConfigFile)) //nolint:gosec // G304: fixed config path
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read swap file config: %w", err)
+	}
+	var cfg dto.SwapFileConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse swap file config: %w", err)
+	}
+	return &cfg, nil
+}
+
+func saveSwapFileConfig(cfg *dto.SwapFileConfig) error {
+	stored := *cfg
+	stored.Active = false
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(swapConfigDir, 0o755); err != nil { //nolint:gosec // G301: plugin config directory
+		return err
+	}
+	return writeFileAtomic(swapConfigDir, swapConfigFile, data)
+}
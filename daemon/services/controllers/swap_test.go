@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// setupSwapFixture points the swap controller at a temporary tree with a
+// "cache" pool and records the commands it runs instead of executing them.
+func setupSwapFixture(t *testing.T, fsType int64) (dir string, commands *[]string) {
+	t.Helper()
+	dir = t.TempDir()
+	oldSwaps, oldZram, oldPools, oldMnt, oldCfg, oldStatfs, oldRun := procSwapsPath, zramSysDir, swapPoolsDir, swapMountDir, swapConfigDir, swapStatfs, swapRunCommand
+	t.Cleanup(func() {
+		procSwapsPath, zramSysDir, swapPoolsDir, swapMountDir, swapConfigDir, swapStatfs, swapRunCommand = oldSwaps, oldZram, oldPools, oldMnt, oldCfg, oldStatfs, oldRun
+	})
+	procSwapsPath = filepath.Join(dir, "swaps")
+	zramSysDir = filepath.Join(dir, "block")
+	swapPoolsDir = filepath.Join(dir, "pools")
+	swapMountDir = filepath.Join(dir, "mnt")
+	swapConfigDir = filepath.Join(dir, "config")
+	swapStatfs = func(_ string, st *syscall.Statfs_t) error {
+		st.Type, st.Bsize, st.Bavail = fsType, 4096, 4<<20 // 16 GiB free
+		return nil
+	}
+	commands = &[]string{}
+	swapRunCommand = func(_ time.Duration, command string, args ...string) ([]string, error) {
+		*commands = append(*commands, command+" "+strings.Join(args, " "))
+		return nil, nil
+	}
+
+	writeSwapFixture(t, filepath.Join(swapPoolsDir, "cache.cfg"), "diskFsType=\"btrfs\"\n")
+	if err := os.MkdirAll(filepath.Join(swapMountDir, "cache"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeSwapFixture(t, procSwapsPath, "Filename\t\t\t\tType\t\tSize\t\tUsed\t\tPriority\n")
+	return dir, commands
+}
+
+func writeSwapFixture(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetSwapStatus(t *testing.T) {
+	_, _ = setupSwapFixture(t, btrfsMagic)
+	writeSwapFixture(t, procSwapsPath, `Filename				Type		Size		Used		Priority
+/dev/zram0                              partition	4194300		1048576		100
+/mnt/cache/swap\040file                 file		4194300		0		-2
+`)
+	writeSwapFixture(t, filepath.Join(zramSysDir, "zram0", "mm_stat"), "1073741824 268435456 283115520 0 283115520 1024 0 0 0\n")
+	writeSwapFixture(t, filepath.Join(zramSysDir, "zram0", "comp_algorithm"), "lzo lzo-rle lz4 [zstd]\n")
+
+	status, err := GetSwapStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Devices) != 2 {
+		t.Fatalf("devices = %+v", status.Devices)
+	}
+	zram := status.Devices[0]
+	if zram.Type != "zram" || zram.Priority != 100 || zram.UsedBytes != 1<<30 || zram.Usage != 25 {
+		t.Errorf("zram device = %+v", zram)
+	}
+	if zram.Zram == nil || zram.Zram.Algorithm != "zstd" || zram.Zram.CompressionRatio != 4 {
+		t.Errorf("zram stats = %+v", zram.Zram)
+	}
+	if file := status.Devices[1]; file.Path != "/mnt/cache/swap file" || file.Type != "file" || file.Zram != nil {
+		t.Errorf("file device = %+v", file)
+	}
+	if status.TotalBytes != 2*4194300*1024 || status.UsedBytes != 1<<30 || status.SwapFile != nil {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestEnableSwapFileValidation(t *testing.T) {
+	valid := dto.SwapFileRequest{Pool: "cache", SizeBytes: 4 << 30, Confirm: true}
+	tests := []struct {
+		name   string
+		fsType int64
+		modify func(*dto.SwapFileRequest)
+		want   error
+	}{
+		{"not confirmed", btrfsMagic, func(r *dto.SwapFileRequest) { r.Confirm = false }, ErrInvalidSwapRequest},
+		{"invalid pool name", btrfsMagic, func(r *dto.SwapFileRequest) { r.Pool = "../boot" }, ErrInvalidSwapRequest},
+		{"unknown pool", btrfsMagic, func(r *dto.SwapFileRequest) { r.Pool = "nvme" }, ErrInvalidSwapRequest},
+		{"too small", btrfsMagic, func(r *dto.SwapFileRequest) { r.SizeBytes = 1 << 20 }, ErrInvalidSwapRequest},
+		{"not enough space", btrfsMagic, func(r *dto.SwapFileRequest) { r.SizeBytes = 16 << 30 }, ErrInvalidSwapRequest},
+		{"zfs pool", zfsMagic, func(*dto.SwapFileRequest) {}, ErrInvalidSwapRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, commands := setupSwapFixture(t, tt.fsType)
+			req := valid
+			tt.modify(&req)
+			if _, err := EnableSwapFile(req); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+			if len(*commands) != 0 {
+				t.Errorf("commands run for a rejected request: %v", *commands)
+			}
+		})
+	}
+}
+
+func TestEnableAndDisableSwapFile(t *testing.T) {
+	_, commands := setupSwapFixture(t, btrfsMagic)
+	path := filepath.Join(swapMountDir, "cache", "swapfile")
+
+	cfg, err := EnableSwapFile(dto.SwapFileRequest{Pool: "cache", SizeBytes: 4 << 30, Confirm: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Path != path || !cfg.Active {
+		t.Errorf("config = %+v", cfg)
+	}
+	want := []string{"chattr +C " + path, "fallocate -l 4294967296 " + path, "mkswap " + path, "swapon " + path}
+	if strings.Join(*commands, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commands:\n%s\nwant:\n%s", strings.Join(*commands, "\n"), strings.Join(want, "\n"))
+	}
+	if _, err := EnableSwapFile(dto.SwapFileRequest{Pool: "cache", SizeBytes: 4 << 30, Confirm: true}); !errors.Is(err, ErrSwapFileExists) {
+		t.Errorf("second enable: err = %v", err)
+	}
+
+	writeSwapFixture(t, procSwapsPath, "Filename Type Size Used Priority\n"+path+" file 4194300 0 -2\n")
+	*commands = nil
+	if _, err := DisableSwapFile(true); err != nil {
+		t.Fatal(err)
+	}
+	if len(*commands) != 1 || (*commands)[0] != "swapoff "+path {
+		t.Errorf("commands = %v", *commands)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("swap file not deleted: %v", err)
+	}
+	if _, err := DisableSwapFile(false); !errors.Is(err, ErrNoSwapFile) {
+		t.Errorf("disable without config: err = %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
+)
+
+// fstrimBytesRe matches the byte count in fstrim -v output, e.g.
+// "/mnt/cache: 39.2 GiB (42088275968 bytes) trimmed".
+var fstrimBytesRe = regexp.MustCompile(`\((\d+) bytes\) trimmed`)
+
+// TrimPool discards unused blocks on a mounted pool and waits until the
+// device has finished. Btrfs and XFS pools are trimmed with fstrim, ZFS pools
+// with zpool trim. A successful run is recorded as the pool's last trim.
+func TrimPool(ctx context.Context, pool dto.PoolInfo) (dto.TrimResult, error) {
+	result := dto.TrimResult{Pool: pool.Name, MountPoint: pool.MountPoint, FileSystem: pool.FileSystem}
+	start := time.Now()
+
+	logger.InfoContext(ctx, "Trim: Trimming pool %s", pool.Name)
+	switch pool.FileSystem {
+	case "zfs":
+		if err := requireBinary("Trim", constants.ZpoolBin); err != nil {
+			return result, err
+		}
+		// -w waits for the trim to finish instead of returning at once.
+		if out, err := lib.ExecCommandOutputWithContext(ctx, constants.ZpoolBin, "trim", "-w", pool.Name); err != nil {
+			return result, fmt.Errorf("zpool trim %s: %s: %w", pool.Name, strings.TrimSpace(out), err)
+		}
+	case "btrfs", "xfs":
+		if err := requireBinary("Trim", constants.FstrimBin); err != nil {
+			return result, err
+		}
+		out, err := lib.ExecCommandOutputWithContext(ctx, constants.FstrimBin, "-v", pool.MountPoint)
+		if err != nil {
+			return result, fmt.Errorf("fstrim %s: %s: %w", pool.MountPoint, strings.TrimSpace(out), err)
+		}
+		result.TrimmedBytes = parseFstrimBytes(out)
+	default:
+		return result, fmt.Errorf("pool %s: trim is not supported on %q filesystems", pool.Name, pool.FileSystem)
+	}
+
+	result.FinishedAt = time.Now()
+	result.DurationSeconds = result.FinishedAt.Sub(start).Seconds()
+	if err := collectors.RecordTrim(pool.MountPoint, result.FinishedAt); err != nil {
+		logger.Warning("Trim: Failed to record trim of %s: %v", pool.Name, err)
+	}
+	logger.InfoContext(ctx, "Trim: Pool %s trimmed (%d bytes)", pool.Name, result.TrimmedBytes)
+	return result, nil
+}
+
+// parseFstrimBytes returns the number of bytes fstrim -v reports as trimmed,
+// or 0 when the output has no count.
+func parseFstrimBytes(out string) uint64 {
+	m := fstrimBytesRe.FindStringSubmatch(out)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.ParseUint(m[1], 10, 64)
+	return n
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParseFstrimBytes(t *testing.T) {
+	tests := map[string]uint64{
+		"/mnt/cache: 39.2 GiB (42088275968 bytes) trimmed\n":           42088275968,
+		"/mnt/cache: 0 B (0 bytes) trimmed on /dev/nvme0n1p1\n":        0,
+		"/mnt/fast: 1.5 MiB (1572864 bytes) trimmed on /dev/sdb1\n":    1572864,
+		"fstrim: /mnt/disk1: the discard operation is not supported\n": 0,
+		"": 0,
+	}
+	for out, want := range tests {
+		if got := parseFstrimBytes(out); got != want {
+			t.Errorf("parseFstrimBytes(%q) = %d, want %d", out, got, want)
+		}
+	}
+}
+
+func TestTrimPoolUnsupportedFilesystem(t *testing.T) {
+	_, err := TrimPool(context.Background(), dto.PoolInfo{Name: "usb", FileSystem: "vfat", MountPoint: "/mnt/usb"})
+	if err == nil {
+		t.Fatal("expected error for vfat pool")
+	}
+}
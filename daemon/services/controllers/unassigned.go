@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// unassignedMountsPath and unassignedPartitionWait are package-level variables
+// so tests can point the mounted-device guard at a fixture and skip the wait.
+var (
+	unassignedMountsPath    = "/proc/mounts"
+	unassignedPartitionWait = 10 * time.Second
+)
+
+// unassignedFormatters maps the filesystems the format action supports to the
+// mkfs binary, its arguments, and the GPT partition type code for the single
+// partition that is created.
+var unassignedFormatters = map[string]struct {
+	bin      string
+	args     []string
+	typeCode string
+}{
+	"xfs":   {constants.MkfsXFSBin, []string{"-f"}, "8300"},
+	"btrfs": {constants.MkfsBtrfsBin, []string{"-f"}, "8300"},
+	"exfat": {constants.MkfsExfatBin, nil, "0700"},
+}
+
+// UnassignedDeviceController provides mount, unmount, and format operations
+// for disks managed by the Unassigned Devices plugin. Mount and unmount are
+// delegated to the plugin's rc.unassigned control script so mount points,
+// options, and share settings match the Unraid web UI.
+type UnassignedDeviceController struct{}
+
+// NewUnassignedDeviceController creates a new unassigned device controller.
+func NewUnassignedDeviceController() *UnassignedDeviceController {
+	return &UnassignedDeviceController{}
+}
+
+// Mount mounts an unassigned disk or partition (e.g. "sdc" or "sdc1").
+func (uc *UnassignedDeviceController) Mount(device string) error {
+	return uc.run("mount", device)
+}
+
+// Unmount unmounts an unassigned disk or partition.
+func (uc *UnassignedDeviceController) Unmount(device string) error {
+	return uc.run("umount", device)
+}
+
+// run validates the device name and invokes the rc.unassigned control script.
+func (uc *UnassignedDeviceController) run(action, device string) error {
+	if err := lib.ValidateDiskID(device); err != nil {
+		return fmt.Errorf("validate device: %w", err)
+	}
+	if err := requireBinary("unassigned devices", constants.RcUnassignedBin); err != nil {
+		return err
+	}
+
+	logger.Info("Unassigned: %s device %s", action, device)
+
+	output, err := lib.ExecCommandWithTimeout(60*time.Second, constants.RcUnassignedBin, action, "/dev/"+device)
+	if err != nil {
+		return fmt.Errorf("failed to %s device %s: %w (output: %s)",
+			action, device, err, strings.TrimSpace(strings.Join(output, "\n")))
+	}
+
+	logger.Info("Unassigned: %s of %s completed", action, device)
+	return nil
+}
+
+// Format erases an unassigned disk and creates a single partition with the
+// given filesystem ("xfs", "btrfs", or "exfat"). The device must come from the
+// unassigned devices list, so array and pool disks can never be targeted, and
+// is refused while it or any of its partitions is mounted or passed through.
+func (uc *UnassignedDeviceController) Format(device dto.UnassignedDevice, filesystem string) error {
+	if err := lib.ValidateDiskID(device.Device); err != nil {
+		return fmt.Errorf("validate device: %w", err)
+	}
+	formatter, ok := unassignedFormatters[filesystem]
+	if !ok {
+		return fmt.Errorf("unsupported filesystem %q: must be xfs, btrfs, or exfat", filesystem)
+	}
+	if err := checkUnassignedFormattable(device); err != nil {
+		return err
+	}
+	if mounted, err := deviceMounted(device.Device); err != nil {
+		return fmt.Errorf("check mounts: %w", err)
+	} else if mounted {
+		return fmt.Errorf("device %s has a mounted filesystem", device.Device)
+	}
+	for _, bin := range []string{constants.SgdiskBin, formatter.bin} {
+		if err := requireBinary("disk format", bin); err != nil {
+			return err
+		}
+	}
+
+	disk := "/dev/" + device.Device
+	partition := "/dev/" + partitionName(device.Device, 1)
+	logger.Warning("Unassigned: formatting %s as %s", disk, filesystem)
+
+	steps := [][]string{
+		{constants.SgdiskBin, "-Z", disk},
+		{constants.SgdiskBin, "-o", "-a", "8", "-n", "1:32K:0", "-t", "1:" + formatter.typeCode, disk},
+	}
+	for _, step := range steps {
+		if output, err := lib.ExecCommandWithTimeout(60*time.Second, step[0], step[1:]...); err != nil {
+			return fmt.Errorf("failed to partition %s: %w (output: %s)",
+				disk, err, strings.TrimSpace(strings.Join(output, "\n")))
+		}
+	}
+
+	if err := waitForDeviceNode(partition, unassignedPartitionWait); err != nil {
+		return err
+	}
+
+	args := append(append([]string{}, formatter.args...), partition)
+	if output, err := lib.ExecCommandWithTimeout(10*time.Minute, formatter.bin, args...); err != nil {
+		return fmt.Errorf("failed to create %s filesystem on %s: %w (output: %s)",
+			filesystem, partition, err, strings.TrimSpace(strings.Join(output, "\n")))
+	}
+
+	logger.Info("Unassigned: formatted %s as %s", disk, filesystem)
+	return nil
+}
+
+// checkUnassignedFormattable refuses to format a device the Unassigned
+// Devices plugin reports as mounted or passed through to a VM.
+func checkUnassignedFormattable(device dto.UnassignedDevice) error {
+	if device.PassThrough {
+		return fmt.Errorf("device %s is set to pass through", device.Device)
+	}
+	if device.Status == "mounted" {
+		return fmt.Errorf("device %s is mounted", device.Device)
+	}
+	for _, p := range device.Partitions {
+		if p.MountPoint != "" || p.Status == "mounted" {
+			return fmt.Errorf("partition %d of device %s is mounted", p.PartitionNumber, device.Device)
+		}
+	}
+	return nil
+}
+
+// deviceMounted reports whether the disk or any of its partitions appears as a
+// mount source in the live mount table, so a stale collector cache can never
+// allow a mounted disk to be formatted.
+func deviceMounted(device string) (bool, error) {
+	f, err := os.Open(unassignedMountsPath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	disk := "/dev/" + device
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], disk) {
+			continue
+		}
+		// Match the disk itself or one of its partitions ("sdc1", "nvme0n1p1"),
+		// not a longer disk name that shares the prefix ("sdca").
+		rest := strings.TrimPrefix(strings.TrimPrefix(fields[0], disk), "p")
+		if rest == "" || strings.Trim(rest, "0123456789") == "" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// partitionName returns the kernel name of partition n of a disk: disks whose
+// name ends in a digit (nvme0n1) use a "p" separator.
+func partitionName(device string, n int) string {
+	if last := device[len(device)-1]; last >= '0' && last <= '9' {
+		return fmt.Sprintf("%sp%d", device, n)
+	}
+	return fmt.Sprintf("%s%d", device, n)
+}
+
+// waitForDeviceNode waits for udev to create a freshly partitioned device node.
+func waitForDeviceNode(path string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("partition %s did not appear after partitioning", path)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
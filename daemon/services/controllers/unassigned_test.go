@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestUnassignedDeviceControllerRejectsInvalidDevice(t *testing.T) {
+	uc := NewUnassignedDeviceController()
+
+	for _, device := range []string{"", "../sda", "-rf", "sda; reboot", "/dev/sdc"} {
+		if err := uc.Mount(device); err == nil || !strings.Contains(err.Error(), "validate device") {
+			t.Errorf("Mount(%q) = %v, want validation error", device, err)
+		}
+		if err := uc.Unmount(device); err == nil || !strings.Contains(err.Error(), "validate device") {
+			t.Errorf("Unmount(%q) = %v, want validation error", device, err)
+		}
+	}
+}
+
+func TestUnassignedDeviceControllerFormatGuards(t *testing.T) {
+	mounts := filepath.Join(t.TempDir(), "mounts")
+	if err := os.WriteFile(mounts, []byte("/dev/sdd1 /mnt/disks/backup xfs rw 0 0\n/dev/sdca1 /mnt/disks/other xfs rw 0 0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	orig := unassignedMountsPath
+	unassignedMountsPath = mounts
+	t.Cleanup(func() { unassignedMountsPath = orig })
+
+	uc := NewUnassignedDeviceController()
+	tests := []struct {
+		name       string
+		device     dto.UnassignedDevice
+		filesystem string
+		wantErr    string
+	}{
+		{"invalid device", dto.UnassignedDevice{Device: "../sda"}, "xfs", "validate device"},
+		{"unsupported filesystem", dto.UnassignedDevice{Device: "sdc"}, "ntfs", "unsupported filesystem"},
+		{"pass through", dto.UnassignedDevice{Device: "sdc", PassThrough: true}, "xfs", "pass through"},
+		{"device mounted", dto.UnassignedDevice{Device: "sdc", Status: "mounted"}, "xfs", "is mounted"},
+		{"partition mounted", dto.UnassignedDevice{Device: "sdc", Partitions: []dto.UnassignedPartition{
+			{PartitionNumber: 1, MountPoint: "/mnt/disks/data"},
+		}}, "btrfs", "partition 1"},
+		{"mounted per live mount table", dto.UnassignedDevice{Device: "sdd"}, "xfs", "mounted filesystem"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.Format(tt.device, tt.filesystem)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Format() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeviceMounted(t *testing.T) {
+	mounts := filepath.Join(t.TempDir(), "mounts")
+	if err := os.WriteFile(mounts, []byte(`/dev/sdc1 /mnt/disks/a xfs rw 0 0
+/dev/nvme1n1p2 /mnt/disks/b btrfs rw 0 0
+/dev/sdea1 /mnt/disks/c xfs rw 0 0
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	orig := unassignedMountsPath
+	unassignedMountsPath = mounts
+	t.Cleanup(func() { unassignedMountsPath = orig })
+
+	for device, want := range map[string]bool{"sdc": true, "nvme1n1": true, "sde": false, "sdb": false} {
+		got, err := deviceMounted(device)
+		if err != nil {
+			t.Fatalf("deviceMounted(%q): %v", device, err)
+		}
+		if got != want {
+			t.Errorf("deviceMounted(%q) = %v, want %v", device, got, want)
+		}
+	}
+}
+
+func TestPartitionName(t *testing.T) {
+	for device, want := range map[string]string{"sdc": "sdc1", "nvme0n1": "nvme0n1p1", "sdaa": "sdaa1"} {
+		if got := partitionName(device, 1); got != want {
+			t.Errorf("partitionName(%q) = %q, want %q", device, got, want)
+		}
+	}
+}
@@ -15,6 +15,9 @@ import (
 const (
 	// UserScriptsBasePath is the base directory for user scripts
 	UserScriptsBasePath = "/boot/config/plugins/user.scripts/scripts"
+
+	// defaultScriptTimeout is how long a script run with wait may take.
+	defaultScriptTimeout = 60 * time.Second
 )
 
 // ListUserScripts returns a list of all available user scripts
@@ -95,24 +98,27 @@ func ExecuteUserScript(scriptName string, background bool, wait bool) (*dto.User
 		}, fmt.Errorf("script not found: %s", scriptName)
 	}
 
-	// Execute script based on options
-	if background && !wait {
-		// Background execution - don't wait for completion
-		return executeScriptBackground(scriptPath, scriptName)
-	}
+	// Wait for completion and return output when asked, even if background is
+	// also set; otherwise run in the background (the default)
+	return RunScript(scriptPath, scriptName, nil, nil, wait, defaultScriptTimeout)
+}
+
+// RunScript runs a bash script file through the user script execution path.
+// Arguments are passed to the script without shell interpolation and env
+// holds extra KEY=VALUE variables. With wait the script's output is returned
+// and it is killed after timeout; otherwise it runs in the background.
+func RunScript(scriptPath, scriptName string, args, env []string, wait bool, timeout time.Duration) (*dto.UserScriptExecuteResponse, error) {
 	if wait {
-		// Wait for completion and return output
-		return executeScriptWait(scriptPath, scriptName)
+		return executeScriptWait(scriptPath, scriptName, args, env, timeout)
 	}
-	// Default: background execution
-	return executeScriptBackground(scriptPath, scriptName)
+	return executeScriptBackground(scriptPath, scriptName, args, env)
 }
 
 // executeScriptBackground executes a script in the background without waiting
 // for completion. It uses os.StartProcess with direct arguments to avoid shell
 // interpolation (CWE-78 prevention) and redirects stdio to /dev/null so the
 // API request is not blocked.
-func executeScriptBackground(scriptPath string, scriptName string) (*dto.UserScriptExecuteResponse, error) {
+func executeScriptBackground(scriptPath, scriptName string, args, env []string) (*dto.UserScriptExecuteResponse, error) {
 	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
 	if err != nil {
 		err = fmt.Errorf("open %s: %w", os.DevNull, err)
@@ -131,8 +137,12 @@ func executeScriptBackground(scriptPath string, scriptName string) (*dto.UserScr
 	procAttr := &os.ProcAttr{
 		Files: []*os.File{devNull, devNull, devNull},
 	}
+	if len(env) > 0 {
+		procAttr.Env = append(os.Environ(), env...)
+	}
 
-	proc, err := os.StartProcess("/bin/bash", []string{"bash", scriptPath}, procAttr)
+	argv := append([]string{"bash", scriptPath}, args...)
+	proc, err := os.StartProcess("/bin/bash", argv, procAttr)
 	if err != nil {
 		err = fmt.Errorf("start background script %s: %w", scriptName, err)
 		logger.Error("Failed to execute user script %s in background: %v", scriptName, err)
@@ -157,10 +167,10 @@ func executeScriptBackground(scriptPath string, scriptName string) (*dto.UserScr
 }
 
 // executeScriptWait executes a script and waits for completion
-func executeScriptWait(scriptPath string, scriptName string) (*dto.UserScriptExecuteResponse, error) {
+func executeScriptWait(scriptPath, scriptName string, args, env []string, timeout time.Duration) (*dto.UserScriptExecuteResponse, error) {
 	// Execute script and wait for completion
 	startTime := time.Now()
-	lines, err := lib.ExecCommand("bash", scriptPath)
+	lines, err := lib.ExecCommandWithEnv(timeout, env, "bash", append([]string{scriptPath}, args...)...)
 	duration := time.Since(startTime)
 
 	// Join output lines
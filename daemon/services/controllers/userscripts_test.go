@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestUserScriptsBasePath(t *testing.T) {
@@ -231,3 +232,18 @@ func TestUserScriptFilePermissions(t *testing.T) {
 		}
 	})
 }
+
+func TestRunScriptArgsAndEnv(t *testing.T) {
+	scriptPath := filepath.Join(t.TempDir(), "script")
+	if err := os.WriteFile(scriptPath, []byte("echo \"$1 $2 $GREETING\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := RunScript(scriptPath, "greet", []string{"a b", "$HOME"}, []string{"GREETING=hi"}, true, 5*time.Second)
+	if err != nil {
+		t.Fatalf("RunScript() error = %v", err)
+	}
+	if !resp.Success || resp.Output != "a b $HOME hi" {
+		t.Errorf("RunScript() = %+v, want arguments passed verbatim", resp)
+	}
+}
@@ -98,6 +98,59 @@ func (vc *VMController) Stop(vmName string) error {
 	return nil
 }
 
+// RunningVMs returns the names of the running virtual machines.
+func (vc *VMController) RunningVMs() ([]string, error) {
+	uri, _ := url.Parse(string(libvirt.QEMUSystem))
+	l, err := libvirt.ConnectToURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to libvirt: %w", err)
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	domains, _, err := l.ConnectListAllDomains(1, libvirt.ConnectListDomainsActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running VMs: %w", err)
+	}
+	names := make([]string, 0, len(domains))
+	for _, d := range domains {
+		names = append(names, d.Name)
+	}
+	return names, nil
+}
+
+// StopAndWait gracefully shuts down a VM and waits up to timeout for it to
+// power off. A VM that is still running after timeout is force-stopped and
+// an error is returned so the caller can report it.
+func (vc *VMController) StopAndWait(vmName string, timeout time.Duration) error {
+	if err := vc.Stop(vmName); err != nil {
+		return err
+	}
+
+	l, domain, err := vc.connect(vmName)
+	if err != nil {
+		return err
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		state, _, err := l.DomainGetState(domain, 0)
+		if err != nil {
+			return fmt.Errorf("failed to get state of VM %s: %w", vmName, err)
+		}
+		if libvirt.DomainState(state) == libvirt.DomainShutoff {
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	logger.Warning("VM: %s did not shut down within %s; forcing it off", vmName, timeout)
+	if err := l.DomainDestroy(domain); err != nil {
+		return fmt.Errorf("VM %s did not shut down within %s and could not be forced off: %w", vmName, timeout, err)
+	}
+	return fmt.Errorf("VM %s did not shut down within %s and was forced off", vmName, timeout)
+}
+
 // Restart reboots a virtual machine by name using the libvirt API.
 func (vc *VMController) Restart(vmName string) error {
 	logger.Info("Restarting VM: %s", vmName)
@@ -225,6 +278,66 @@ func (vc *VMController) ForceStop(vmName string) error {
 	return nil
 }
 
+// usbHostdevXML builds the libvirt hostdev definition for a USB device given
+// as "vendor:product". deviceID must already be validated.
+func usbHostdevXML(deviceID string) string {
+	vendor, product, _ := strings.Cut(strings.ToLower(deviceID), ":")
+	return fmt.Sprintf(`<hostdev mode='subsystem' type='usb' managed='no'><source><vendor id='0x%s'/><product id='0x%s'/></source></hostdev>`, vendor, product)
+}
+
+// AttachUSB hot-plugs the host USB device deviceID ("vendor:product") into a
+// running VM. The change affects the live VM only; the device is detached
+// again when the VM shuts down.
+func (vc *VMController) AttachUSB(vmName, deviceID string) error {
+	return vc.hotplugUSB(vmName, deviceID, true)
+}
+
+// DetachUSB removes a hot-plugged (or permanently assigned) USB device from a
+// running VM without changing its saved definition.
+func (vc *VMController) DetachUSB(vmName, deviceID string) error {
+	return vc.hotplugUSB(vmName, deviceID, false)
+}
+
+// hotplugUSB attaches or detaches a USB device on the live VM.
+func (vc *VMController) hotplugUSB(vmName, deviceID string, attach bool) error {
+	if err := lib.ValidateUSBDeviceID(deviceID); err != nil {
+		return err
+	}
+	action := "detach"
+	if attach {
+		action = "attach"
+	}
+	logger.Info("VM: USB %s %s for %s", action, deviceID, vmName)
+
+	l, domain, err := vc.connect(vmName)
+	if err != nil {
+		return err
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	state, _, err := l.DomainGetState(domain, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get VM state: %w", err)
+	}
+	if libvirt.DomainState(state) != libvirt.DomainRunning {
+		return fmt.Errorf("cannot %s USB device on VM %q: not running", action, vmName)
+	}
+
+	hostdev := usbHostdevXML(deviceID)
+	flags := libvirt.DomainDeviceModifyLive
+	if attach {
+		err = l.DomainAttachDeviceFlags(domain, hostdev, uint32(flags))
+	} else {
+		err = l.DomainDetachDeviceFlags(domain, hostdev, uint32(flags))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s USB device %s on VM %q: %w", action, deviceID, vmName, err)
+	}
+
+	logger.Info("VM: USB device %s %sed on %s", deviceID, action, vmName)
+	return nil
+}
+
 // CreateSnapshot creates a snapshot of a virtual machine using the libvirt API.
 func (vc *VMController) CreateSnapshot(vmName, snapshotName, description string) error {
 	logger.Info("Creating snapshot '%s' for VM: %s", snapshotName, vmName)
@@ -0,0 +1,441 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/go-libvirt"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+var (
+	// ErrVMNotShutOff is returned when a disk image is changed while its VM is not shut off.
+	ErrVMNotShutOff = errors.New("VM must be shut off")
+
+	// ErrVMDiskNotFound is returned for a target that is not a file-backed disk of the VM.
+	ErrVMDiskNotFound = errors.New("VM disk not found")
+
+	// ErrInvalidDiskRequest is returned for a resize or conversion that cannot be performed.
+	ErrInvalidDiskRequest = errors.New("invalid disk image request")
+
+	// ErrDiskConversionRunning is returned while a conversion of the disk is in progress.
+	ErrDiskConversionRunning = errors.New("disk image conversion already running")
+)
+
+// diskImageExtensions are the formats disk images can be converted to, with
+// the file extension given to the converted image.
+var diskImageExtensions = map[string]string{
+	"qcow2": ".qcow2",
+	"raw":   ".img",
+}
+
+// diskConvertTimeout bounds a single qemu-img convert run.
+const diskConvertTimeout = 6 * time.Hour
+
+// Disk conversions run in the background; the latest per disk is kept so
+// ListDiskImages can report progress and failures.
+var (
+	diskConversionsMu sync.Mutex
+	diskConversions   = make(map[string]*dto.VMDiskConversion) // by diskKey
+)
+
+func diskKey(vmName, target string) string { return vmName + "/" + target }
+
+// domainDiskXML is the subset of a libvirt domain definition describing disks.
+type domainDiskXML struct {
+	Disks []struct {
+		Type   string `xml:"type,attr"`
+		Device string `xml:"device,attr"`
+		Source struct {
+			File string `xml:"file,attr"`
+		} `xml:"source"`
+		Target struct {
+			Dev string `xml:"dev,attr"`
+			Bus string `xml:"bus,attr"`
+		} `xml:"target"`
+	} `xml:"devices>disk"`
+}
+
+// parseDomainDisks returns the file-backed disks of a libvirt domain
+// definition, skipping CD-ROMs, floppies and block devices.
+func parseDomainDisks(domainXML string) ([]dto.VMDiskImage, error) {
+	var def domainDiskXML
+	if err := xml.Unmarshal([]byte(domainXML), &def); err != nil {
+		return nil, fmt.Errorf("parse domain XML: %w", err)
+	}
+	disks := make([]dto.VMDiskImage, 0, len(def.Disks))
+	for _, d := range def.Disks {
+		if d.Type != "file" || d.Device != "disk" || d.Source.File == "" {
+			continue
+		}
+		disks = append(disks, dto.VMDiskImage{Target: d.Target.Dev, Bus: d.Target.Bus, Path: d.Source.File})
+	}
+	return disks, nil
+}
+
+// qemuImgInfo is the subset of `qemu-img info --output=json` used here.
+type qemuImgInfo struct {
+	Format          string `json:"format"`
+	VirtualSize     uint64 `json:"virtual-size"`
+	ActualSize      uint64 `json:"actual-size"`
+	BackingFilename string `json:"backing-filename"`
+}
+
+// readDiskImageInfo fills in the format and sizes of disk from qemu-img.
+// -U lets qemu-img read images that a running VM holds locked.
+func readDiskImageInfo(disk *dto.VMDiskImage) error {
+	out, err := lib.ExecCommandStdout(constants.QemuImgBin, "info", "--output=json", "-U", disk.Path)
+	if err != nil {
+		return fmt.Errorf("qemu-img info failed for %s: %w", disk.Path, err)
+	}
+	return parseQemuImgInfo(out, disk)
+}
+
+// parseQemuImgInfo applies `qemu-img info --output=json` output to disk.
+func parseQemuImgInfo(out string, disk *dto.VMDiskImage) error {
+	var info qemuImgInfo
+	if err := json.Unmarshal([]byte(out), &info); err != nil {
+		return fmt.Errorf("parse qemu-img info output: %w", err)
+	}
+	disk.Format = info.Format
+	disk.VirtualSizeBytes = info.VirtualSize
+	disk.ActualSizeBytes = info.ActualSize
+	disk.BackingFile = info.BackingFilename
+	return nil
+}
+
+// domainShutOff reports whether domain is shut off.
+func domainShutOff(l *libvirt.Libvirt, domain libvirt.Domain) (bool, error) {
+	state, _, err := l.DomainGetState(domain, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to get state of VM %s: %w", domain.Name, err)
+	}
+	return libvirt.DomainState(state) == libvirt.DomainShutoff, nil
+}
+
+// domainDisks returns the file-backed disks of domain's persistent definition.
+func domainDisks(l *libvirt.Libvirt, domain libvirt.Domain) ([]dto.VMDiskImage, error) {
+	domainXML, err := l.DomainGetXMLDesc(domain, libvirt.DomainXMLInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get definition of VM %s: %w", domain.Name, err)
+	}
+	return parseDomainDisks(domainXML)
+}
+
+// diskConversion returns a copy of the latest conversion of a disk, or nil.
+func diskConversion(vmName, target string) *dto.VMDiskConversion {
+	diskConversionsMu.Lock()
+	defer diskConversionsMu.Unlock()
+	c, ok := diskConversions[diskKey(vmName, target)]
+	if !ok {
+		return nil
+	}
+	conv := *c
+	return &conv
+}
+
+// ListDiskImages lists the file-backed disks of a VM with their format and
+// virtual and actual sizes.
+func (vc *VMController) ListDiskImages(vmName string) (*dto.VMDiskImageList, error) {
+	if err := requireBinary("vm", constants.QemuImgBin); err != nil {
+		return nil, err
+	}
+
+	l, domain, err := vc.connect(vmName)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	shutOff, err := domainShutOff(l, domain)
+	if err != nil {
+		return nil, err
+	}
+	disks, err := domainDisks(l, domain)
+	if err != nil {
+		return nil, err
+	}
+	for i := range disks {
+		if err := readDiskImageInfo(&disks[i]); err != nil {
+			disks[i].Error = err.Error()
+		}
+		disks[i].Conversion = diskConversion(vmName, disks[i].Target)
+	}
+
+	return &dto.VMDiskImageList{
+		VMName:    vmName,
+		ShutOff:   shutOff,
+		Disks:     disks,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// shutOffDisk returns disk target of a shut-off VM with its qemu-img details,
+// refusing disks that are being converted.
+func shutOffDisk(l *libvirt.Libvirt, domain libvirt.Domain, target string) (*dto.VMDiskImage, error) {
+	shutOff, err := domainShutOff(l, domain)
+	if err != nil {
+		return nil, err
+	}
+	if !shutOff {
+		return nil, fmt.Errorf("%w: %s", ErrVMNotShutOff, domain.Name)
+	}
+	if c := diskConversion(domain.Name, target); c != nil && c.State == "running" {
+		return nil, fmt.Errorf("%w: %s %s", ErrDiskConversionRunning, domain.Name, target)
+	}
+
+	disks, err := domainDisks(l, domain)
+	if err != nil {
+		return nil, err
+	}
+	for i := range disks {
+		if disks[i].Target == target {
+			if err := readDiskImageInfo(&disks[i]); err != nil {
+				return nil, err
+			}
+			return &disks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s on VM %s", ErrVMDiskNotFound, target, domain.Name)
+}
+
+// ResizeDiskImage grows the virtual size of a VM disk image to sizeBytes with
+// qemu-img resize. Shrinking is refused because it destroys guest data; the
+// guest partition and filesystem still have to be extended from inside the VM.
+func (vc *VMController) ResizeDiskImage(vmName, target string, sizeBytes uint64) (*dto.VMDiskImage, error) {
+	logger.Info("Resizing disk %s of VM %s to %d bytes", target, vmName, sizeBytes)
+
+	if err := requireBinary("vm", constants.QemuImgBin); err != nil {
+		return nil, err
+	}
+
+	l, domain, err := vc.connect(vmName)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	disk, err := shutOffDisk(l, domain, target)
+	if err != nil {
+		return nil, err
+	}
+	if sizeBytes <= disk.VirtualSizeBytes {
+		return nil, fmt.Errorf("%w: new size %d must be larger than the current size %d (shrinking is not supported)",
+			ErrInvalidDiskRequest, sizeBytes, disk.VirtualSizeBytes)
+	}
+
+	output, err := lib.ExecCommandOutput(constants.QemuImgBin, "resize", "-f", disk.Format, disk.Path, strconv.FormatUint(sizeBytes, 10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resize %s: %w (output: %s)", disk.Path, err, strings.TrimSpace(output))
+	}
+	if err := readDiskImageInfo(disk); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Successfully resized disk %s of VM %s", target, vmName)
+	return disk, nil
+}
+
+// convertedImagePath returns the path of the converted copy of image src.
+func convertedImagePath(src, format string) string {
+	base := strings.TrimSuffix(src, filepath.Ext(src))
+	dest := base + diskImageExtensions[format]
+	if dest == src {
+		dest = base + "." + format
+	}
+	return dest
+}
+
+// ConvertDiskImage starts converting a VM disk image to format ("qcow2" or
+// "raw") in the background. qemu-img writes a new image next to the original;
+// once it completes, the VM definition is switched to the new image and the
+// original is kept so it can be removed after the VM has been checked. The
+// conversion stops when ctx is cancelled. Progress is reported by
+// ListDiskImages.
+func (vc *VMController) ConvertDiskImage(ctx context.Context, vmName, target, format string) (*dto.VMDiskConversion, error) {
+	logger.InfoContext(ctx, "Converting disk %s of VM %s to %s", target, vmName, format)
+
+	if _, ok := diskImageExtensions[format]; !ok {
+		return nil, fmt.Errorf("%w: format must be qcow2 or raw", ErrInvalidDiskRequest)
+	}
+	if err := requireBinary("vm", constants.QemuImgBin); err != nil {
+		return nil, err
+	}
+
+	l, domain, err := vc.connect(vmName)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	disk, err := shutOffDisk(l, domain, target)
+	if err != nil {
+		return nil, err
+	}
+	if disk.Format == format {
+		return nil, fmt.Errorf("%w: %s is already %s", ErrInvalidDiskRequest, disk.Path, format)
+	}
+	if disk.BackingFile != "" {
+		return nil, fmt.Errorf("%w: images with a backing file are not supported", ErrInvalidDiskRequest)
+	}
+	// Snapshots reference the current image and would be lost.
+	if n, err := l.DomainSnapshotNum(domain, 0); err == nil && n > 0 {
+		return nil, fmt.Errorf("%w: VM %s has %d snapshots; delete them before converting", ErrInvalidDiskRequest, vmName, n)
+	}
+	dest := convertedImagePath(disk.Path, format)
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%w: %s already exists", ErrInvalidDiskRequest, dest)
+	}
+
+	conv := &dto.VMDiskConversion{
+		VMName:       vmName,
+		Target:       target,
+		SourcePath:   disk.Path,
+		Destination:  dest,
+		TargetFormat: format,
+		State:        "running",
+		StartedAt:    time.Now(),
+	}
+	diskConversionsMu.Lock()
+	if c, ok := diskConversions[diskKey(vmName, target)]; ok && c.State == "running" {
+		diskConversionsMu.Unlock()
+		return nil, fmt.Errorf("%w: %s %s", ErrDiskConversionRunning, vmName, target)
+	}
+	diskConversions[diskKey(vmName, target)] = conv
+	result := *conv
+	diskConversionsMu.Unlock()
+
+	go vc.runDiskConversion(ctx, conv, disk.Format)
+	return &result, nil
+}
+
+// runDiskConversion runs qemu-img convert for conv and switches the VM to the
+// converted image.
+func (vc *VMController) runDiskConversion(ctx context.Context, conv *dto.VMDiskConversion, srcFormat string) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LogPanicWithStackContext(ctx, "VM disk conversion", r)
+		}
+	}()
+
+	err := vc.convertAndRedefine(ctx, conv, srcFormat)
+
+	now := time.Now()
+	diskConversionsMu.Lock()
+	conv.FinishedAt = &now
+	if err != nil {
+		conv.State = "failed"
+		conv.Error = err.Error()
+	} else {
+		conv.State = "succeeded"
+	}
+	diskConversionsMu.Unlock()
+
+	if err != nil {
+		logger.ErrorContext(ctx, "VM: Converting disk %s of %s failed: %v", conv.Target, conv.VMName, err)
+		return
+	}
+	logger.InfoContext(ctx, "VM: Converted disk %s of %s to %s (%s); original kept at %s",
+		conv.Target, conv.VMName, conv.TargetFormat, conv.Destination, conv.SourcePath)
+}
+
+// convertAndRedefine writes the converted image and points the VM definition
+// at it. The converted image is removed on failure.
+func (vc *VMController) convertAndRedefine(ctx context.Context, conv *dto.VMDiskConversion, srcFormat string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, diskConvertTimeout)
+	defer cancel()
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(conv.Destination)
+		}
+	}()
+
+	output, err := lib.ExecCommandOutputWithContext(ctx, constants.QemuImgBin,
+		"convert", "-f", srcFormat, "-O", conv.TargetFormat, conv.SourcePath, conv.Destination)
+	if err != nil {
+		return fmt.Errorf("qemu-img convert failed: %w (output: %s)", err, strings.TrimSpace(output))
+	}
+
+	l, domain, err := vc.connect(conv.VMName)
+	if err != nil {
+		return err
+	}
+	defer l.Disconnect() //nolint:errcheck
+
+	// Writes made by the VM after the copy started would be lost.
+	shutOff, err := domainShutOff(l, domain)
+	if err != nil {
+		return err
+	}
+	if !shutOff {
+		return fmt.Errorf("%w: VM was started during the conversion; converted image discarded", ErrVMNotShutOff)
+	}
+
+	domainXML, err := l.DomainGetXMLDesc(domain, libvirt.DomainXMLInactive|libvirt.DomainXMLSecure)
+	if err != nil {
+		return fmt.Errorf("failed to get definition of VM %s: %w", conv.VMName, err)
+	}
+	updated, err := retargetDiskXML(domainXML, conv.Target, conv.Destination, conv.TargetFormat)
+	if err != nil {
+		return err
+	}
+	if _, err := l.DomainDefineXML(updated); err != nil {
+		return fmt.Errorf("failed to update definition of VM %s: %w", conv.VMName, err)
+	}
+	return nil
+}
+
+var (
+	diskElementPattern = regexp.MustCompile(`(?s)<disk\b[^>]*>.*?</disk>`)
+	sourceFilePattern  = regexp.MustCompile(`(<source\b[^>]*?\sfile=)(?:'[^']*'|"[^"]*")`)
+	driverTypePattern  = regexp.MustCompile(`(<driver\b[^>]*?\stype=)(?:'[^']*'|"[^"]*")`)
+)
+
+// retargetDiskXML points disk target of a domain definition at image path
+// with the given driver format, leaving the rest of the definition untouched.
+func retargetDiskXML(domainXML, target, path, format string) (string, error) {
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(path)); err != nil {
+		return "", err
+	}
+
+	found := false
+	updated := diskElementPattern.ReplaceAllStringFunc(domainXML, func(disk string) string {
+		disks, err := parseDomainDisks("<domain><devices>" + disk + "</devices></domain>")
+		if err != nil || len(disks) != 1 || disks[0].Target != target {
+			return disk
+		}
+		found = true
+		disk = replaceXMLAttr(sourceFilePattern, disk, escaped.String())
+		return replaceXMLAttr(driverTypePattern, disk, format)
+	})
+	if !found {
+		return "", fmt.Errorf("%w: %s", ErrVMDiskNotFound, target)
+	}
+	return updated, nil
+}
+
+// replaceXMLAttr sets the attribute matched by pattern to the already
+// escaped value.
+func replaceXMLAttr(pattern *regexp.Regexp, s, value string) string {
+	return pattern.ReplaceAllStringFunc(s, func(m string) string {
+		prefix := pattern.FindStringSubmatch(m)[1]
+		return prefix + "'" + value + "'"
+	})
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+const testDomainXML = `<domain type='kvm'>
+  <name>Windows 11</name>
+  <devices>
+    <disk type='file' device='disk'>
+      <driver name='qemu' type='raw' cache='writeback'/>
+      <source file='/mnt/user/domains/Windows 11/vdisk1.img'/>
+      <target dev='hdc' bus='virtio'/>
+      <boot order='1'/>
+    </disk>
+    <disk type='file' device='cdrom'>
+      <driver name='qemu' type='raw'/>
+      <source file='/mnt/user/isos/Win11.iso'/>
+      <target dev='hda' bus='sata'/>
+      <readonly/>
+    </disk>
+    <disk type='block' device='disk'>
+      <driver name='qemu' type='raw'/>
+      <source dev='/dev/disk/by-id/ata-WDC_WD40EFRX'/>
+      <target dev='hdd' bus='sata'/>
+    </disk>
+    <disk type='file' device='disk'>
+      <driver name='qemu' type='qcow2' cache='writeback'/>
+      <source file='/mnt/user/domains/Windows 11/vdisk2.img'/>
+      <target dev='hde' bus='virtio'/>
+    </disk>
+  </devices>
+</domain>`
+
+func TestParseDomainDisks(t *testing.T) {
+	disks, err := parseDomainDisks(testDomainXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disks) != 2 {
+		t.Fatalf("got %d disks, want 2 (CD-ROM and block device skipped): %+v", len(disks), disks)
+	}
+	if disks[0].Target != "hdc" || disks[0].Bus != "virtio" || disks[0].Path != "/mnt/user/domains/Windows 11/vdisk1.img" {
+		t.Errorf("disks[0] = %+v", disks[0])
+	}
+	if disks[1].Target != "hde" {
+		t.Errorf("disks[1] = %+v", disks[1])
+	}
+
+	if _, err := parseDomainDisks("not xml"); err == nil {
+		t.Error("expected error for invalid XML")
+	}
+}
+
+func TestParseQemuImgInfo(t *testing.T) {
+	out := `{
+    "virtual-size": 107374182400,
+    "filename": "/mnt/user/domains/Windows 11/vdisk2.img",
+    "cluster-size": 65536,
+    "format": "qcow2",
+    "actual-size": 32212254720,
+    "backing-filename": "base.qcow2",
+    "dirty-flag": false
+}`
+	var disk dto.VMDiskImage
+	if err := parseQemuImgInfo(out, &disk); err != nil {
+		t.Fatal(err)
+	}
+	if disk.Format != "qcow2" || disk.VirtualSizeBytes != 107374182400 || disk.ActualSizeBytes != 32212254720 || disk.BackingFile != "base.qcow2" {
+		t.Errorf("disk = %+v", disk)
+	}
+	if err := parseQemuImgInfo("qemu-img: Could not open", &disk); err == nil {
+		t.Error("expected error for non-JSON output")
+	}
+}
+
+func TestConvertedImagePath(t *testing.T) {
+	tests := []struct {
+		src, format, want string
+	}{
+		{"/mnt/user/domains/vm/vdisk1.img", "qcow2", "/mnt/user/domains/vm/vdisk1.qcow2"},
+		{"/mnt/user/domains/vm/vdisk1.qcow2", "raw", "/mnt/user/domains/vm/vdisk1.img"},
+		{"/mnt/user/domains/vm/vdisk1.img", "raw", "/mnt/user/domains/vm/vdisk1.raw"},
+		{"/mnt/user/domains/vm/disk", "qcow2", "/mnt/user/domains/vm/disk.qcow2"},
+	}
+	for _, tt := range tests {
+		if got := convertedImagePath(tt.src, tt.format); got != tt.want {
+			t.Errorf("convertedImagePath(%q, %q) = %q, want %q", tt.src, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestRetargetDiskXML(t *testing.T) {
+	updated, err := retargetDiskXML(testDomainXML, "hdc", "/mnt/user/domains/Windows 11/vdisk1 & co.qcow2", "qcow2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(updated, `<driver name='qemu' type='qcow2' cache='writeback'/>
+      <source file='/mnt/user/domains/Windows 11/vdisk1 &amp; co.qcow2'/>
+      <target dev='hdc' bus='virtio'/>`) {
+		t.Errorf("disk hdc not retargeted:\n%s", updated)
+	}
+	// Other disks are untouched.
+	if !strings.Contains(updated, `<source file='/mnt/user/isos/Win11.iso'/>`) ||
+		!strings.Contains(updated, `<driver name='qemu' type='qcow2' cache='writeback'/>
+      <source file='/mnt/user/domains/Windows 11/vdisk2.img'/>`) {
+		t.Errorf("other disks changed:\n%s", updated)
+	}
+	disks, err := parseDomainDisks(updated)
+	if err != nil || disks[0].Path != "/mnt/user/domains/Windows 11/vdisk1 & co.qcow2" {
+		t.Errorf("parsed retargeted disks = %+v, err %v", disks, err)
+	}
+
+	if _, err := retargetDiskXML(testDomainXML, "hdz", "/x.qcow2", "qcow2"); !errors.Is(err, ErrVMDiskNotFound) {
+		t.Errorf("unknown target: err = %v, want ErrVMDiskNotFound", err)
+	}
+}
+
+func TestConvertDiskImageRejectsFormat(t *testing.T) {
+	_, err := NewVMController().ConvertDiskImage(t.Context(), "vm", "hdc", "vmdk")
+	if !errors.Is(err, ErrInvalidDiskRequest) {
+		t.Errorf("err = %v, want ErrInvalidDiskRequest", err)
+	}
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -77,6 +78,25 @@ func TestVMReset(t *testing.T) {
 	}
 }
 
+func TestUSBHostdevXML(t *testing.T) {
+	got := usbHostdevXML("046D:C52B")
+	want := `<hostdev mode='subsystem' type='usb' managed='no'><source><vendor id='0x046d'/><product id='0xc52b'/></source></hostdev>`
+	if got != want {
+		t.Errorf("usbHostdevXML() = %s, want %s", got, want)
+	}
+}
+
+func TestVMHotplugUSBRejectsInvalidID(t *testing.T) {
+	vc := NewVMController()
+	// Validation runs before libvirt is contacted.
+	if err := vc.AttachUSB("win11", "046d:c52b'/><disk"); err == nil || !strings.Contains(err.Error(), "invalid USB device ID") {
+		t.Errorf("AttachUSB() error = %v, want invalid USB device ID", err)
+	}
+	if err := vc.DetachUSB("win11", "usb"); err == nil || !strings.Contains(err.Error(), "invalid USB device ID") {
+		t.Errorf("DetachUSB() error = %v, want invalid USB device ID", err)
+	}
+}
+
 func TestVMControllerWithInvalidVM(t *testing.T) {
 	// Skip if not in integration test mode
 	if testing.Short() {
@@ -0,0 +1,342 @@
+package diagnostics
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+)
+
+const (
+	// DefaultPingCount is the number of echo requests sent when none is given.
+	DefaultPingCount = 4
+	// MaxPingCount caps the echo requests of a single ping.
+	MaxPingCount = 20
+
+	dnsLookupTimeout = 10 * time.Second
+	httpCheckTimeout = 15 * time.Second
+	httpMaxBodyBytes = 1 << 20
+	httpMaxRedirects = 5
+)
+
+// ErrTargetNotAllowed is returned when a diagnostic target does not match the
+// configured allow list (DIAGNOSTICS_TARGETS).
+var ErrTargetNotAllowed = errors.New("target is not in the diagnostics allow list")
+
+var (
+	// pingStatsRegex matches "4 packets transmitted, 4 received, 0% packet loss".
+	pingStatsRegex = regexp.MustCompile(`(\d+) packets transmitted, (\d+) (?:packets )?received.*?([\d.]+)% packet loss`)
+	// pingRTTStatsRegex matches "rtt min/avg/max/mdev = 11.2/12.4/14.1/0.9 ms".
+	pingRTTStatsRegex = regexp.MustCompile(`= ([\d.]+)/([\d.]+)/([\d.]+)/`)
+
+	dnsRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS"}
+)
+
+// NetworkDiagnostics runs ping, DNS, and HTTP checks from the server's point of
+// view, restricted to an allow list of targets so the agent cannot be used to
+// probe arbitrary hosts.
+type NetworkDiagnostics struct {
+	allowed []string
+}
+
+// NewNetworkDiagnostics creates network diagnostics restricted to the given
+// allow list. Entries are hostnames ("github.com"), subdomain wildcards
+// ("*.docker.io"), IP addresses, CIDR ranges ("192.168.1.0/24"), or "*" to
+// allow any target. An empty list allows nothing.
+func NewNetworkDiagnostics(allowed []string) *NetworkDiagnostics {
+	return &NetworkDiagnostics{allowed: allowed}
+}
+
+// Allowed reports whether host matches the allow list.
+func (d *NetworkDiagnostics) Allowed(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	ip := net.ParseIP(host)
+	for _, entry := range d.allowed {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "*":
+			return true
+		case strings.HasPrefix(entry, "*."):
+			if ip == nil && strings.HasSuffix(host, entry[1:]) {
+				return true
+			}
+		case strings.Contains(entry, "/"):
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && ip != nil && cidr.Contains(ip) {
+				return true
+			}
+		default:
+			if entryIP := net.ParseIP(entry); entryIP != nil {
+				if ip != nil && entryIP.Equal(ip) {
+					return true
+				}
+			} else if host == entry {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkTarget validates host and enforces the allow list.
+func (d *NetworkDiagnostics) checkTarget(host string) error {
+	if err := lib.ValidateHostOrIP(host); err != nil {
+		return err
+	}
+	if !d.Allowed(host) {
+		return fmt.Errorf("%w: %s", ErrTargetNotAllowed, host)
+	}
+	return nil
+}
+
+// Ping sends count ICMP echo requests to host. Validation and allow-list
+// failures are returned as errors; an unreachable host is reported in the
+// result.
+func (d *NetworkDiagnostics) Ping(ctx context.Context, host string, count int) (*dto.PingResult, error) {
+	if count == 0 {
+		count = DefaultPingCount
+	}
+	if count < 1 || count > MaxPingCount {
+		return nil, fmt.Errorf("invalid count %d: must be between 1 and %d", count, MaxPingCount)
+	}
+	if err := d.checkTarget(host); err != nil {
+		return nil, err
+	}
+
+	// One second per echo plus the final reply wait.
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(count+3)*time.Second)
+	defer cancel()
+
+	// ping exits non-zero when no replies arrive, but still prints the summary.
+	output, err := lib.ExecCommandOutputWithContext(ctx, "ping", "-n",
+		"-c", strconv.Itoa(count), "-W", "2", host)
+	result := parsePingSummary(output)
+	result.Host = host
+	result.Timestamp = time.Now()
+	if result.Transmitted == 0 {
+		result.PacketLossPct = 100
+		if err != nil {
+			result.Error = fmt.Sprintf("ping failed: %s", firstLine(output, err))
+		} else {
+			result.Error = "unable to parse ping output"
+		}
+	} else if !result.Reachable {
+		result.Error = "no echo replies received"
+	}
+	return result, nil
+}
+
+// parsePingSummary extracts the packet counts and round-trip statistics from
+// ping's summary lines.
+func parsePingSummary(output string) *dto.PingResult {
+	result := &dto.PingResult{}
+	if m := pingStatsRegex.FindStringSubmatch(output); m != nil {
+		result.Transmitted, _ = strconv.Atoi(m[1])
+		result.Received, _ = strconv.Atoi(m[2])
+		result.PacketLossPct, _ = strconv.ParseFloat(m[3], 64)
+		result.Reachable = result.Received > 0
+	}
+	if m := pingRTTStatsRegex.FindStringSubmatch(output); m != nil {
+		result.MinMs, _ = strconv.ParseFloat(m[1], 64)
+		result.AvgMs, _ = strconv.ParseFloat(m[2], 64)
+		result.MaxMs, _ = strconv.ParseFloat(m[3], 64)
+	}
+	return result
+}
+
+// LookupDNS resolves host with the server's resolver. recordType defaults to
+// "A".
+func (d *NetworkDiagnostics) LookupDNS(ctx context.Context, host, recordType string) (*dto.DNSLookupResult, error) {
+	recordType = strings.ToUpper(strings.TrimSpace(recordType))
+	if recordType == "" {
+		recordType = "A"
+	}
+	if !slices.Contains(dnsRecordTypes, recordType) {
+		return nil, fmt.Errorf("invalid record type %q: must be one of %s", recordType, strings.Join(dnsRecordTypes, ", "))
+	}
+	if err := d.checkTarget(host); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsLookupTimeout)
+	defer cancel()
+
+	result := &dto.DNSLookupResult{Host: host, RecordType: recordType, Records: []string{}}
+	start := time.Now()
+	records, err := lookupRecords(ctx, net.DefaultResolver, host, recordType)
+	result.DurationMs = msSince(start)
+	result.Timestamp = time.Now()
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Records = records
+	return result, nil
+}
+
+// lookupRecords queries a single record type.
+func lookupRecords(ctx context.Context, r *net.Resolver, host, recordType string) ([]string, error) {
+	var records []string
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := r.LookupIP(ctx, network, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			records = append(records, ip.String())
+		}
+	case "CNAME":
+		cname, err := r.LookupCNAME(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, cname)
+	case "MX":
+		mxs, err := r.LookupMX(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			records = append(records, fmt.Sprintf("%d %s", mx.Pref, mx.Host))
+		}
+	case "TXT":
+		txts, err := r.LookupTXT(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, txts...)
+	case "NS":
+		nss, err := r.LookupNS(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			records = append(records, ns.Host)
+		}
+	}
+	return records, nil
+}
+
+// CheckHTTP requests rawURL (GET or HEAD) and reports the status and a timing
+// breakdown. Redirects are followed only to allow-listed hosts. The response
+// body is read (up to 1 MiB) and discarded.
+func (d *NetworkDiagnostics) CheckHTTP(ctx context.Context, rawURL, method string) (*dto.HTTPCheckResult, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	if method != http.MethodGet && method != http.MethodHead {
+		return nil, fmt.Errorf("invalid method %q: must be GET or HEAD", method)
+	}
+	if len(rawURL) > 2048 {
+		return nil, errors.New("invalid url: exceeds 2048 characters")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, errors.New("invalid url: must be an absolute http:// or https:// URL")
+	}
+	if u.User != nil {
+		return nil, errors.New("invalid url: credentials are not allowed")
+	}
+	if err := d.checkTarget(u.Hostname()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, httpCheckTimeout)
+	defer cancel()
+
+	result := &dto.HTTPCheckResult{URL: rawURL, Method: method}
+	var dnsStart, connectStart, tlsStart time.Time
+	start := time.Now()
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:           func(httptrace.DNSDoneInfo) { result.DNSMs = msSince(dnsStart) },
+		ConnectStart:      func(string, string) { connectStart = time.Now() },
+		ConnectDone:       func(string, string, error) { result.ConnectMs = msSince(connectStart) },
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { result.TLSMs = msSince(tlsStart) },
+		GotFirstResponseByte: func() {
+			result.TTFBMs = msSince(start)
+		},
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), method, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	req.Header.Set("User-Agent", "unraid-management-agent-diagnostics")
+
+	resp, err := d.httpClient().Do(req)
+	if err != nil {
+		result.DurationMs = msSince(start)
+		result.Error = err.Error()
+		result.Timestamp = time.Now()
+		return result, nil
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	result.BodyBytes, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, httpMaxBodyBytes))
+	result.DurationMs = msSince(start)
+	result.StatusCode = resp.StatusCode
+	result.Status = resp.Status
+	result.FinalURL = resp.Request.URL.String()
+	if resp.TLS != nil {
+		result.TLSVersion = tls.VersionName(resp.TLS.Version)
+		if len(resp.TLS.PeerCertificates) > 0 {
+			expires := resp.TLS.PeerCertificates[0].NotAfter
+			result.CertExpiresAt = &expires
+		}
+	}
+	result.Timestamp = time.Now()
+	return result, nil
+}
+
+// httpClient returns a client that refuses redirects to hosts outside the
+// allow list.
+func (d *NetworkDiagnostics) httpClient() *http.Client {
+	return &http.Client{
+		// A fresh transport per check so timings always include DNS, connect,
+		// and TLS rather than reusing a pooled connection.
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, DisableKeepAlives: true},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= httpMaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", httpMaxRedirects)
+			}
+			if !d.Allowed(req.URL.Hostname()) {
+				return fmt.Errorf("redirect to %s: %w", req.URL.Hostname(), ErrTargetNotAllowed)
+			}
+			return nil
+		},
+	}
+}
+
+// msSince returns the milliseconds elapsed since t.
+func msSince(t time.Time) float64 {
+	return float64(time.Since(t).Microseconds()) / 1000
+}
+
+// firstLine returns the first line of output, falling back to err when the
+// command printed nothing (e.g. "unknown host" goes to stderr on some pings).
+func firstLine(output string, err error) string {
+	if line, _, _ := strings.Cut(strings.TrimSpace(output), "\n"); line != "" {
+		return line
+	}
+	return err.Error()
+}
@@ -5,8 +5,11 @@ package discovery
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/grandcat/zeroconf"
@@ -29,8 +32,16 @@ type Service struct {
 	bindAddress string
 	tlsEnabled  bool
 
+	// avahiDir is where the _unraid-agent._tcp service file is written when
+	// avahi-daemon is installed; tests point it at a temp dir.
+	avahiDir string
+
 	mu     sync.Mutex
 	server *zeroconf.Server
+	// agentServer advertises _unraid-agent._tcp when avahi is not available;
+	// avahiFile is the service file written when it is.
+	agentServer *zeroconf.Server
+	avahiFile   string
 }
 
 // NewService creates a discovery service that will advertise the given metadata.
@@ -48,6 +59,7 @@ func NewService(config domain.DiscoveryConfig, hostname string, port int, versio
 		version:     version,
 		bindAddress: bindAddress,
 		tlsEnabled:  tlsEnabled,
+		avahiDir:    constants.AvahiServicesDir,
 	}
 }
 
@@ -76,6 +88,22 @@ func (s *Service) txtRecords() []string {
 	}
 }
 
+// agentTxtRecords returns the TXT records of the _unraid-agent._tcp service:
+// the server name, agent version and the REST API and MCP endpoint paths.
+func (s *Service) agentTxtRecords() []string {
+	scheme := "http"
+	if s.tlsEnabled {
+		scheme = "https"
+	}
+	return []string{
+		"server=" + s.hostname,
+		"version=" + s.version,
+		"api=/api/v1",
+		"mcp=/mcp",
+		"scheme=" + scheme,
+	}
+}
+
 // Start registers the mDNS service. Registration failures are logged and
 // returned, but callers should treat discovery as optional and continue.
 //
@@ -97,7 +125,7 @@ func (s *Service) Start(_ context.Context) error {
 		return nil
 	}
 
-	server, advertised, err := s.register()
+	server, advertised, err := s.register(constants.DiscoveryServiceType, s.txtRecords())
 	if err != nil {
 		return fmt.Errorf("registering mDNS service: %w", err)
 	}
@@ -107,26 +135,92 @@ func (s *Service) Start(_ context.Context) error {
 		"Discovery: advertising %q as %s.%s on port %d (%s)",
 		s.instanceName(), constants.DiscoveryServiceType, constants.DiscoveryDomain, s.port, advertised,
 	)
+
+	if err := s.advertiseAgent(); err != nil {
+		logger.Warning("Discovery: advertising %s failed: %v", constants.AgentServiceType, err)
+	}
+	return nil
+}
+
+// advertiseAgent publishes the _unraid-agent._tcp service. When avahi-daemon
+// is installed (as on Unraid) the service is handed to it as a static service
+// file, so the system responder owns the records and resolves name conflicts;
+// otherwise it is registered with zeroconf like the integration service.
+func (s *Service) advertiseAgent() error {
+	if info, err := os.Stat(s.avahiDir); err == nil && info.IsDir() {
+		path := filepath.Join(s.avahiDir, constants.AvahiServiceFile)
+		if err := writeAvahiService(path, s.instanceName(), s.port, s.agentTxtRecords()); err != nil {
+			return err
+		}
+		s.avahiFile = path
+		logger.Success("Discovery: advertising %s via avahi (%s)", constants.AgentServiceType, path)
+		return nil
+	}
+
+	server, advertised, err := s.register(constants.AgentServiceType, s.agentTxtRecords())
+	if err != nil {
+		return err
+	}
+	s.agentServer = server
+	logger.Success("Discovery: advertising %s on port %d (%s)", constants.AgentServiceType, s.port, advertised)
+	return nil
+}
+
+// avahiServiceGroup is the avahi.service(5) file format.
+type avahiServiceGroup struct {
+	XMLName xml.Name `xml:"service-group"`
+	Name    string   `xml:"name"`
+	Service struct {
+		Type       string   `xml:"type"`
+		Port       int      `xml:"port"`
+		TXTRecords []string `xml:"txt-record"`
+	} `xml:"service"`
+}
+
+// writeAvahiService writes an avahi service file atomically; avahi-daemon
+// watches its services directory and publishes the change on its own.
+func writeAvahiService(path, name string, port int, txt []string) error {
+	group := avahiServiceGroup{Name: name}
+	group.Service.Type = constants.AgentServiceType
+	group.Service.Port = port
+	group.Service.TXTRecords = txt
+
+	body, err := xml.MarshalIndent(group, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding avahi service: %w", err)
+	}
+	data := []byte(xml.Header + `<!DOCTYPE service-group SYSTEM "avahi-service.dtd">` + "\n")
+	data = append(data, body...)
+	data = append(data, '\n')
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil { //nolint:gosec // avahi-daemon must read it
+		return fmt.Errorf("writing avahi service: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("writing avahi service: %w", err)
+	}
 	return nil
 }
 
-// register registers the service with zeroconf. When an advertise address is
+// register registers serviceType with zeroconf. When an advertise address is
 // known (the configured bind address, or the detected primary LAN IPv4) it is
 // advertised explicitly via RegisterProxy, so a single, reachable address is
 // published regardless of how many (docker/virtual) interfaces the host has.
 // Otherwise it falls back to Register, which derives addresses from the
 // interface a query arrives on. The returned string describes the advertised
 // address(es) for logging.
-func (s *Service) register() (*zeroconf.Server, string, error) {
+func (s *Service) register(serviceType string, txt []string) (*zeroconf.Server, string, error) {
 	if ip := s.advertiseIP(); ip != nil {
 		server, err := zeroconf.RegisterProxy(
 			s.instanceName(),
-			constants.DiscoveryServiceType,
+			serviceType,
 			constants.DiscoveryDomain,
 			s.port,
 			s.hostname, // host whose A record points at the LAN IP
 			[]string{ip.String()},
-			s.txtRecords(),
+			txt,
 			nil, // respond on all interfaces; the explicit IP is always returned
 		)
 		if err != nil {
@@ -137,10 +231,10 @@ func (s *Service) register() (*zeroconf.Server, string, error) {
 
 	server, err := zeroconf.Register(
 		s.instanceName(),
-		constants.DiscoveryServiceType,
+		serviceType,
 		constants.DiscoveryDomain,
 		s.port,
-		s.txtRecords(),
+		txt,
 		nil, // nil interfaces => advertise on all suitable interfaces
 	)
 	if err != nil {
@@ -186,12 +280,22 @@ func primaryIPv4() net.IP {
 	return ip
 }
 
-// Shutdown stops advertising the service, sending mDNS goodbye packets so
-// clients can remove the entry promptly.
+// Shutdown stops advertising the services, sending mDNS goodbye packets (or
+// removing the avahi service file) so clients can remove the entries promptly.
 func (s *Service) Shutdown() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.agentServer != nil {
+		s.agentServer.Shutdown()
+		s.agentServer = nil
+	}
+	if s.avahiFile != "" {
+		if err := os.Remove(s.avahiFile); err != nil && !os.IsNotExist(err) {
+			logger.Warning("Discovery: removing %s: %v", s.avahiFile, err)
+		}
+		s.avahiFile = ""
+	}
 	if s.server == nil {
 		return
 	}
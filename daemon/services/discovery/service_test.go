@@ -2,6 +2,8 @@ package discovery
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
@@ -172,3 +174,45 @@ func TestStartSkipsAdvertisementOnLoopbackBind(t *testing.T) {
 		})
 	}
 }
+
+func TestAgentTxtRecords(t *testing.T) {
+	s := NewService(domain.DiscoveryConfig{Enabled: true, ServiceName: "Main Unraid"}, "tower", 8043, "2026.06.01", "", true)
+	got := s.agentTxtRecords()
+	want := []string{"server=tower", "version=2026.06.01", "api=/api/v1", "mcp=/mcp", "scheme=https"}
+	if !slices.Equal(got, want) {
+		t.Errorf("agentTxtRecords() = %v, want %v", got, want)
+	}
+}
+
+func TestAdvertiseAgentWritesAvahiService(t *testing.T) {
+	s := NewService(domain.DiscoveryConfig{Enabled: true, ServiceName: "Main <Unraid>"}, "tower", 8043, "2026.06.01", "", false)
+	s.avahiDir = t.TempDir()
+
+	if err := s.advertiseAgent(); err != nil {
+		t.Fatalf("advertiseAgent() error: %v", err)
+	}
+	if s.agentServer != nil {
+		t.Error("advertiseAgent() registered with zeroconf although avahi is available")
+	}
+	data, err := os.ReadFile(filepath.Join(s.avahiDir, "unraid-agent.service"))
+	if err != nil {
+		t.Fatalf("reading service file: %v", err)
+	}
+	for _, want := range []string{
+		`<!DOCTYPE service-group SYSTEM "avahi-service.dtd">`,
+		"<name>Main &lt;Unraid&gt;</name>",
+		"<type>_unraid-agent._tcp</type>",
+		"<port>8043</port>",
+		"<txt-record>mcp=/mcp</txt-record>",
+		"<txt-record>server=tower</txt-record>",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("service file missing %q:\n%s", want, data)
+		}
+	}
+
+	s.Shutdown()
+	if _, err := os.Stat(filepath.Join(s.avahiDir, "unraid-agent.service")); !os.IsNotExist(err) {
+		t.Errorf("service file not removed on Shutdown: %v", err)
+	}
+}
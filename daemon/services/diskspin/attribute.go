@@ -0,0 +1,144 @@
+package diskspin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/platform"
+)
+
+// fatraceSeconds is how long fatrace watches for file accesses after a
+// spin-up. The access that woke the disk has already happened by the time
+// the spin-up is seen, but the process behind it usually keeps reading.
+const fatraceSeconds = 5
+
+// User share roots. Files opened through a user share show up under these
+// paths in /proc rather than under the disk's mount point.
+var userShareRoots = []string{"/mnt/user/", "/mnt/user0/"}
+
+// procPath is the proc filesystem root. Variable for testing.
+var procPath = "/proc"
+
+// fatraceLine matches one fatrace event: "comm(pid): TYPES path".
+var fatraceLine = regexp.MustCompile(`^(.+)\((\d+)\): [A-Z+<>]+ (/.*)$`)
+
+// attribute returns the processes accessing the disk mounted at mountPoint:
+// open files first, then fatrace accesses when fatrace is installed.
+func attribute(ctx context.Context, mountPoint string) []dto.DiskSpinUpCause {
+	causes := openFileCauses(procPath, mountPoint)
+	if !platform.BinaryExists(constants.FatraceBin) {
+		return causes
+	}
+	ctx, cancel := context.WithTimeout(ctx, (fatraceSeconds+10)*time.Second)
+	defer cancel()
+	out, err := lib.ExecCommandStdoutWithContext(ctx, constants.FatraceBin, "--seconds", strconv.Itoa(fatraceSeconds))
+	if err != nil && out == "" {
+		return causes
+	}
+	return mergeCauses(causes, parseFatrace(out, mountPoint))
+}
+
+// openFileCauses lists the processes under proc holding a file open on the
+// disk mounted at mountPoint, directly or through a user share. The shfs
+// (user share FUSE) process is left out: its open files mirror those of the
+// processes reading through /mnt/user.
+func openFileCauses(proc, mountPoint string) []dto.DiskSpinUpCause {
+	entries, err := os.ReadDir(proc)
+	if err != nil {
+		return nil
+	}
+	var causes []dto.DiskSpinUpCause
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		pidDir := filepath.Join(proc, entry.Name())
+		comm := readComm(pidDir)
+		if comm == "shfs" {
+			continue
+		}
+		fds, err := os.ReadDir(filepath.Join(pidDir, "fd"))
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			target, err := os.Readlink(filepath.Join(pidDir, "fd", fd.Name()))
+			if err != nil || !onDisk(target, mountPoint) {
+				continue
+			}
+			causes = mergeCauses(causes, []dto.DiskSpinUpCause{{
+				Process: comm, PID: pid, Path: target, Source: dto.SpinUpCauseOpenFile,
+			}})
+		}
+	}
+	return causes
+}
+
+// parseFatrace returns the fatrace events in out that touched the disk
+// mounted at mountPoint, one per process and path.
+func parseFatrace(out, mountPoint string) []dto.DiskSpinUpCause {
+	var causes []dto.DiskSpinUpCause
+	for line := range strings.SplitSeq(out, "\n") {
+		m := fatraceLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil || m[1] == "shfs" || !onDisk(m[3], mountPoint) {
+			continue
+		}
+		pid, _ := strconv.Atoi(m[2])
+		causes = mergeCauses(causes, []dto.DiskSpinUpCause{{
+			Process: m[1], PID: pid, Path: m[3], Source: dto.SpinUpCauseFatrace,
+		}})
+	}
+	return causes
+}
+
+// onDisk reports whether path is stored on the disk mounted at mountPoint:
+// either a path below the mount point, or a user share path whose file exists
+// on that disk.
+func onDisk(path, mountPoint string) bool {
+	if strings.HasPrefix(path, mountPoint+"/") {
+		return true
+	}
+	for _, root := range userShareRoots {
+		if rel, ok := strings.CutPrefix(path, root); ok {
+			_, err := os.Lstat(filepath.Join(mountPoint, rel))
+			return err == nil
+		}
+	}
+	return false
+}
+
+// readComm returns the command name of the process at pidDir.
+func readComm(pidDir string) string {
+	data, err := os.ReadFile(filepath.Join(pidDir, "comm"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// mergeCauses appends the causes in add that are not already in causes (same
+// process and path).
+func mergeCauses(causes, add []dto.DiskSpinUpCause) []dto.DiskSpinUpCause {
+	for _, c := range add {
+		dup := false
+		for _, existing := range causes {
+			if existing.PID == c.PID && existing.Path == c.Path {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			causes = append(causes, c)
+		}
+	}
+	return causes
+}
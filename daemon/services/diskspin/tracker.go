@@ -0,0 +1,233 @@
+// Package diskspin records the spin-up and spin-down transitions of array and
+// pool disks from the spin state the disk collector publishes, and makes a
+// best-effort attempt to name the processes that woke a disk: files held open
+// on it when the spin-up was seen, and file accesses fatrace reports in the
+// seconds after.
+package diskspin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// Window is the period covered by the per-disk spin-up and spin-down
+	// counts.
+	Window = 24 * time.Hour
+
+	// maxEvents is the number of transitions kept in memory across all
+	// disks.
+	maxEvents = 500
+
+	// maxCauses is the number of processes recorded for one spin-up.
+	maxCauses = 20
+)
+
+// diskState is the last known spin state of one disk.
+type diskState struct {
+	name   string
+	device string
+	state  string
+	since  time.Time
+}
+
+// Tracker keeps the spin state history of every disk and publishes a
+// dto.DiskSpinEvent per transition and a fresh dto.DiskSpinHistory after
+// every change.
+type Tracker struct {
+	hub *domain.EventBus
+
+	mu     sync.RWMutex
+	states map[string]*diskState
+	order  []string // disk IDs in the order the disk collector lists them
+	events []dto.DiskSpinEvent
+
+	// attributeFn is replaced in tests.
+	attributeFn func(ctx context.Context, mountPoint string) []dto.DiskSpinUpCause
+}
+
+// NewTracker creates a tracker fed by disk list updates on hub.
+func NewTracker(hub *domain.EventBus) *Tracker {
+	return &Tracker{
+		hub:         hub,
+		states:      map[string]*diskState{},
+		attributeFn: attribute,
+	}
+}
+
+// Start consumes disk list updates until ctx is cancelled.
+func (t *Tracker) Start(ctx context.Context) {
+	ch := t.hub.SubTopics(constants.TopicDiskListUpdate)
+	defer t.hub.Unsub(ch, constants.TopicDiskListUpdate.Name)
+	logger.Success("Disk spin tracker started")
+
+	published := false
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Disk spin tracker stopped")
+			return
+		case msg := <-ch:
+			disks, ok := msg.([]dto.DiskInfo)
+			if !ok {
+				continue
+			}
+			events := t.Update(disks, time.Now())
+			// Publish the initial states once, then only on changes.
+			if len(events) == 0 && published {
+				continue
+			}
+			published = true
+			domain.Publish(t.hub, constants.TopicDiskSpinHistoryUpdate, t.History(time.Now()))
+			for _, event := range events {
+				if event.State != "active" {
+					domain.Publish(t.hub, constants.TopicDiskSpinEvent, event)
+					continue
+				}
+				mountPoint := mountPointOf(disks, event.Disk)
+				go t.attributeSpinUp(ctx, event, mountPoint)
+			}
+		}
+	}
+}
+
+// attributeSpinUp looks for the processes that woke a disk, stores them on
+// the recorded event and publishes it.
+func (t *Tracker) attributeSpinUp(ctx context.Context, event dto.DiskSpinEvent, mountPoint string) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LogPanicWithStack("Disk spin attribution", r)
+		}
+	}()
+
+	if mountPoint != "" {
+		event.Causes = t.attributeFn(ctx, mountPoint)
+		if len(event.Causes) > maxCauses {
+			event.Causes = event.Causes[:maxCauses]
+		}
+	}
+	if len(event.Causes) > 0 {
+		t.mu.Lock()
+		for i := range t.events {
+			if t.events[i].Disk == event.Disk && t.events[i].Timestamp.Equal(event.Timestamp) {
+				t.events[i].Causes = event.Causes
+				break
+			}
+		}
+		t.mu.Unlock()
+		logger.Info("Disk spin tracker: %s spun up; accessed by %s (pid %d): %s",
+			event.Disk, event.Causes[0].Process, event.Causes[0].PID, event.Causes[0].Path)
+		domain.Publish(t.hub, constants.TopicDiskSpinHistoryUpdate, t.History(time.Now()))
+	}
+	domain.Publish(t.hub, constants.TopicDiskSpinEvent, event)
+}
+
+// Update records the spin state of disks and returns the transitions since
+// the previous update. Disks in an unknown state keep their last state, and
+// a disk seen for the first time produces no transition.
+func (t *Tracker) Update(disks []dto.DiskInfo, now time.Time) []dto.DiskSpinEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var events []dto.DiskSpinEvent
+	present := make(map[string]bool, len(disks))
+	t.order = t.order[:0]
+	for _, d := range disks {
+		if d.ID == "" {
+			continue
+		}
+		present[d.ID] = true
+		t.order = append(t.order, d.ID)
+		if d.SpinState != "active" && d.SpinState != "standby" {
+			continue
+		}
+		st := t.states[d.ID]
+		if st == nil {
+			t.states[d.ID] = &diskState{name: d.Name, device: d.Device, state: d.SpinState, since: now}
+			continue
+		}
+		st.name, st.device = d.Name, d.Device
+		if st.state == d.SpinState {
+			continue
+		}
+		events = append(events, dto.DiskSpinEvent{
+			Disk:            d.ID,
+			Name:            d.Name,
+			Device:          d.Device,
+			State:           d.SpinState,
+			PreviousState:   st.state,
+			PreviousSeconds: int64(now.Sub(st.since).Seconds()),
+			Timestamp:       now,
+		})
+		st.state, st.since = d.SpinState, now
+	}
+	for id := range t.states {
+		if !present[id] {
+			delete(t.states, id)
+		}
+	}
+
+	t.events = append(t.events, events...)
+	if len(t.events) > maxEvents {
+		t.events = t.events[len(t.events)-maxEvents:]
+	}
+	return events
+}
+
+// History returns the spin statistics of every tracked disk over Window and
+// the recent transitions.
+func (t *Tracker) History(now time.Time) *dto.DiskSpinHistory {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	history := &dto.DiskSpinHistory{
+		WindowSeconds: int64(Window.Seconds()),
+		Disks:         make([]dto.DiskSpinStats, 0, len(t.states)),
+		Events:        make([]dto.DiskSpinEvent, len(t.events)),
+		Timestamp:     now,
+	}
+	copy(history.Events, t.events)
+
+	for _, id := range t.order {
+		st := t.states[id]
+		if st == nil {
+			continue
+		}
+		stats := dto.DiskSpinStats{Disk: id, Name: st.name, Device: st.device, State: st.state, Since: st.since}
+		for _, e := range t.events {
+			if e.Disk != id {
+				continue
+			}
+			if e.State == "active" {
+				ts := e.Timestamp
+				stats.LastSpinUp = &ts
+			}
+			if now.Sub(e.Timestamp) > Window {
+				continue
+			}
+			if e.State == "active" {
+				stats.SpinUps++
+			} else {
+				stats.SpinDowns++
+			}
+		}
+		history.Disks = append(history.Disks, stats)
+	}
+	return history
+}
+
+// mountPointOf returns the mount point of the disk with the given ID.
+func mountPointOf(disks []dto.DiskInfo, id string) string {
+	for _, d := range disks {
+		if d.ID == id {
+			return d.MountPoint
+		}
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+package diskspin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func disk(id, state string) dto.DiskInfo {
+	return dto.DiskInfo{ID: id, Name: id, Device: "sd" + id[len(id)-1:], SpinState: state}
+}
+
+func TestUpdateRecordsTransitions(t *testing.T) {
+	tr := NewTracker(nil)
+	start := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+
+	if events := tr.Update([]dto.DiskInfo{disk("disk1", "standby"), disk("disk2", "active")}, start); len(events) != 0 {
+		t.Fatalf("first update produced %d events, want 0", len(events))
+	}
+
+	// disk1 spins up, disk2 reports unknown and keeps its state.
+	events := tr.Update([]dto.DiskInfo{disk("disk1", "active"), disk("disk2", "unknown")}, start.Add(90*time.Minute))
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1: %+v", len(events), events)
+	}
+	e := events[0]
+	if e.Disk != "disk1" || e.State != "active" || e.PreviousState != "standby" || e.PreviousSeconds != 5400 {
+		t.Errorf("event = %+v", e)
+	}
+
+	events = tr.Update([]dto.DiskInfo{disk("disk1", "standby"), disk("disk2", "active")}, start.Add(2*time.Hour))
+	if len(events) != 1 || events[0].State != "standby" || events[0].PreviousSeconds != 1800 {
+		t.Fatalf("spin-down events = %+v", events)
+	}
+
+	history := tr.History(start.Add(2 * time.Hour))
+	if len(history.Disks) != 2 || history.Disks[0].Disk != "disk1" {
+		t.Fatalf("disks = %+v", history.Disks)
+	}
+	d1 := history.Disks[0]
+	if d1.State != "standby" || d1.SpinUps != 1 || d1.SpinDowns != 1 || d1.LastSpinUp == nil {
+		t.Errorf("disk1 stats = %+v", d1)
+	}
+	if d2 := history.Disks[1]; d2.State != "active" || d2.SpinUps != 0 || !d2.Since.Equal(start) {
+		t.Errorf("disk2 stats = %+v", d2)
+	}
+	if len(history.Events) != 2 {
+		t.Errorf("events = %d, want 2", len(history.Events))
+	}
+
+	// Transitions older than the window are kept as events but not counted.
+	if d1 := tr.History(start.Add(30 * time.Hour)).Disks[0]; d1.SpinUps != 0 || d1.LastSpinUp == nil {
+		t.Errorf("disk1 stats after window = %+v", d1)
+	}
+
+	// A removed disk is dropped.
+	tr.Update([]dto.DiskInfo{disk("disk2", "active")}, start.Add(3*time.Hour))
+	if got := tr.History(start.Add(3 * time.Hour)).Disks; len(got) != 1 || got[0].Disk != "disk2" {
+		t.Errorf("disks after removal = %+v", got)
+	}
+}
+
+func TestAttributeSpinUpStoresCauses(t *testing.T) {
+	hub := domain.NewEventBus(8)
+	ch := hub.SubTopics(constants.TopicDiskSpinEvent)
+	defer hub.Unsub(ch, constants.TopicDiskSpinEvent.Name)
+
+	tr := NewTracker(hub)
+	tr.attributeFn = func(_ context.Context, mountPoint string) []dto.DiskSpinUpCause {
+		return []dto.DiskSpinUpCause{{Process: "Plex", PID: 42, Path: mountPoint + "/Movies/a.mkv", Source: dto.SpinUpCauseOpenFile}}
+	}
+	now := time.Now()
+	tr.Update([]dto.DiskInfo{disk("disk3", "standby")}, now)
+	events := tr.Update([]dto.DiskInfo{disk("disk3", "active")}, now.Add(time.Minute))
+	if len(events) != 1 {
+		t.Fatalf("got %d events", len(events))
+	}
+
+	tr.attributeSpinUp(context.Background(), events[0], "/mnt/disk3")
+
+	got := tr.History(now).Events[0].Causes
+	if len(got) != 1 || got[0].Path != "/mnt/disk3/Movies/a.mkv" {
+		t.Errorf("causes = %+v", got)
+	}
+	select {
+	case msg := <-ch:
+		if e, ok := msg.(dto.DiskSpinEvent); !ok || e.Disk != "disk3" || len(e.Causes) != 1 {
+			t.Errorf("published %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Error("spin-up event not published")
+	}
+}
+
+func TestOpenFileCauses(t *testing.T) {
+	root := t.TempDir()
+	mount := filepath.Join(root, "disk3")
+	userRoot := filepath.Join(root, "user") + "/"
+	if err := os.MkdirAll(filepath.Join(mount, "Media"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mount, "Media", "a.mkv"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	orig := userShareRoots
+	userShareRoots = []string{userRoot}
+	defer func() { userShareRoots = orig }()
+
+	proc := filepath.Join(root, "proc")
+	addProc := func(pid, comm string, targets ...string) {
+		fdDir := filepath.Join(proc, pid, "fd")
+		if err := os.MkdirAll(fdDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(proc, pid, "comm"), []byte(comm+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		for i, target := range targets {
+			if err := os.Symlink(target, filepath.Join(fdDir, string(rune('3'+i)))); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	addProc("100", "Plex Media Serv", userRoot+"Media/a.mkv", "/dev/null")
+	addProc("200", "shfs", mount+"/Media/a.mkv")
+	addProc("300", "rsync", mount+"/backup.tar")
+	addProc("400", "smbd", userRoot+"Media/other-disk.mkv")
+	addProc("self", "ignored", mount+"/x")
+
+	causes := openFileCauses(proc, mount)
+	if len(causes) != 2 {
+		t.Fatalf("causes = %+v, want Plex and rsync", causes)
+	}
+	if causes[0].Process != "Plex Media Serv" || causes[0].PID != 100 || causes[0].Source != dto.SpinUpCauseOpenFile {
+		t.Errorf("causes[0] = %+v", causes[0])
+	}
+	if causes[1].Process != "rsync" || causes[1].Path != mount+"/backup.tar" {
+		t.Errorf("causes[1] = %+v", causes[1])
+	}
+}
+
+func TestParseFatrace(t *testing.T) {
+	out := `smbd(2211): R /mnt/disk3/Photos/2024/IMG_0001.jpg
+smbd(2211): R /mnt/disk3/Photos/2024/IMG_0001.jpg
+shfs(1801): RO /mnt/disk3/Photos/2024/IMG_0001.jpg
+find(9120): O /mnt/disk30/data
+mover(7003): CW /mnt/disk3/appdata/db.sqlite
+garbage line
+`
+	causes := parseFatrace(out, "/mnt/disk3")
+	if len(causes) != 2 {
+		t.Fatalf("causes = %+v, want smbd and mover", causes)
+	}
+	if causes[0].Process != "smbd" || causes[0].PID != 2211 || causes[0].Source != dto.SpinUpCauseFatrace {
+		t.Errorf("causes[0] = %+v", causes[0])
+	}
+	if causes[1].Process != "mover" || causes[1].Path != "/mnt/disk3/appdata/db.sqlite" {
+		t.Errorf("causes[1] = %+v", causes[1])
+	}
+}
@@ -0,0 +1,331 @@
+package filesystem
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DefaultAnalyzeDepth and MaxAnalyzeDepth bound the levels of children
+	// reported below the analyzed directory.
+	DefaultAnalyzeDepth = 1
+	MaxAnalyzeDepth     = 3
+
+	// DefaultAnalyzeLimit and MaxAnalyzeLimit bound the largest children kept
+	// per directory.
+	DefaultAnalyzeLimit = 20
+	MaxAnalyzeLimit     = 100
+
+	// maxRunningJobs limits concurrent analyses; each one walks a whole tree.
+	maxRunningJobs = 2
+
+	// analyzeTimeout cancels analyses that run too long.
+	analyzeTimeout = 30 * time.Minute
+
+	// jobRetention is how long finished jobs stay available, and maxKeptJobs
+	// caps how many are kept.
+	jobRetention = time.Hour
+	maxKeptJobs  = 20
+)
+
+var (
+	// ErrTooManyJobs is returned when maxRunningJobs analyses are running.
+	ErrTooManyJobs = fmt.Errorf("at most %d directory analyses can run at once", maxRunningJobs)
+
+	// ErrJobNotFound is returned for unknown or expired job IDs.
+	ErrJobNotFound = errors.New("analysis job not found")
+)
+
+// Analyzer runs directory size analyses in the background and tracks them
+// as jobs that clients poll.
+type Analyzer struct {
+	mu   sync.Mutex
+	jobs map[string]*analyzeJob
+}
+
+// analyzeJob is a tracked analysis. status is guarded by Analyzer.mu; the
+// progress counters are updated by the walking goroutine.
+type analyzeJob struct {
+	status dto.FilesystemAnalyzeJob
+	cancel context.CancelFunc
+
+	files   atomic.Uint64
+	bytes   atomic.Uint64
+	skipped atomic.Uint64
+}
+
+// NewAnalyzer creates a directory analyzer.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{jobs: make(map[string]*analyzeJob)}
+}
+
+// Start begins analyzing path, which must already be resolved with
+// ResolvePath, and returns the new job. A depth or limit of 0 selects the
+// default. The job is cancelled when parent is.
+func (a *Analyzer) Start(parent context.Context, path string, depth, limit int) (dto.FilesystemAnalyzeJob, error) {
+	if depth == 0 {
+		depth = DefaultAnalyzeDepth
+	}
+	if limit == 0 {
+		limit = DefaultAnalyzeLimit
+	}
+	if depth < 1 || depth > MaxAnalyzeDepth {
+		return dto.FilesystemAnalyzeJob{}, fmt.Errorf("depth must be between 1 and %d", MaxAnalyzeDepth)
+	}
+	if limit < 1 || limit > MaxAnalyzeLimit {
+		return dto.FilesystemAnalyzeJob{}, fmt.Errorf("limit must be between 1 and %d", MaxAnalyzeLimit)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return dto.FilesystemAnalyzeJob{}, ErrPathNotFound
+	}
+	if !info.IsDir() {
+		return dto.FilesystemAnalyzeJob{}, fmt.Errorf("not a directory: %s", path)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	a.prune(now)
+	running := 0
+	for _, j := range a.jobs {
+		if j.status.State == dto.AnalyzeJobRunning {
+			running++
+		}
+	}
+	if running >= maxRunningJobs {
+		return dto.FilesystemAnalyzeJob{}, ErrTooManyJobs
+	}
+
+	ctx, cancel := context.WithTimeout(parent, analyzeTimeout)
+	job := &analyzeJob{
+		status: dto.FilesystemAnalyzeJob{
+			ID:        newJobID(),
+			Path:      path,
+			Depth:     depth,
+			Limit:     limit,
+			State:     dto.AnalyzeJobRunning,
+			StartedAt: now,
+		},
+		cancel: cancel,
+	}
+	a.jobs[job.status.ID] = job
+	logger.Info("Filesystem: Analyzing %s (job %s, depth %d)", path, job.status.ID, depth)
+
+	go a.run(ctx, job)
+	return job.snapshot(), nil
+}
+
+// Get returns the job with id.
+func (a *Analyzer) Get(id string) (dto.FilesystemAnalyzeJob, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	job, ok := a.jobs[id]
+	if !ok {
+		return dto.FilesystemAnalyzeJob{}, ErrJobNotFound
+	}
+	return job.snapshot(), nil
+}
+
+// List returns all tracked jobs, newest first, without their results.
+func (a *Analyzer) List() []dto.FilesystemAnalyzeJob {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.prune(time.Now())
+	jobs := make([]dto.FilesystemAnalyzeJob, 0, len(a.jobs))
+	for _, j := range a.jobs {
+		status := j.snapshot()
+		status.Result = nil
+		jobs = append(jobs, status)
+	}
+	slices.SortFunc(jobs, func(x, y dto.FilesystemAnalyzeJob) int { return y.StartedAt.Compare(x.StartedAt) })
+	return jobs
+}
+
+// Cancel stops a running job and returns its state. Cancelling a finished
+// job has no effect.
+func (a *Analyzer) Cancel(id string) (dto.FilesystemAnalyzeJob, error) {
+	a.mu.Lock()
+	job, ok := a.jobs[id]
+	a.mu.Unlock()
+	if !ok {
+		return dto.FilesystemAnalyzeJob{}, ErrJobNotFound
+	}
+	job.cancel()
+	return a.Get(id)
+}
+
+// run walks the job's directory and records the outcome.
+func (a *Analyzer) run(ctx context.Context, job *analyzeJob) {
+	defer job.cancel()
+	w := &walker{ctx: ctx, job: job, root: job.status.Path, limit: job.status.Limit, seen: make(map[uint64]struct{})}
+	result, err := w.dir(job.status.Path, filepath.Base(job.status.Path), job.status.Depth)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	finished := time.Now()
+	job.status.FinishedAt = &finished
+	switch {
+	case errors.Is(err, context.Canceled):
+		job.status.State = dto.AnalyzeJobCancelled
+	case err != nil:
+		job.status.State = dto.AnalyzeJobFailed
+		job.status.Error = err.Error()
+		logger.Warning("Filesystem: Analysis of %s failed: %v", job.status.Path, err)
+	default:
+		job.status.State = dto.AnalyzeJobCompleted
+		job.status.Result = &result
+	}
+}
+
+// prune drops expired finished jobs, then the oldest finished jobs beyond
+// maxKeptJobs. Must be called with a.mu held.
+func (a *Analyzer) prune(now time.Time) {
+	var finished []*analyzeJob
+	for id, j := range a.jobs {
+		if j.status.FinishedAt == nil {
+			continue
+		}
+		if now.Sub(*j.status.FinishedAt) > jobRetention {
+			delete(a.jobs, id)
+			continue
+		}
+		finished = append(finished, j)
+	}
+	if excess := len(a.jobs) - maxKeptJobs; excess > 0 {
+		slices.SortFunc(finished, func(x, y *analyzeJob) int { return x.status.FinishedAt.Compare(*y.status.FinishedAt) })
+		for _, j := range finished[:min(excess, len(finished))] {
+			delete(a.jobs, j.status.ID)
+		}
+	}
+}
+
+// snapshot returns the job state with current progress. Must be called with
+// Analyzer.mu held.
+func (j *analyzeJob) snapshot() dto.FilesystemAnalyzeJob {
+	status := j.status
+	status.ScannedFiles = j.files.Load()
+	status.ScannedBytes = j.bytes.Load()
+	status.SkippedDirs = j.skipped.Load()
+	return status
+}
+
+// walker computes directory sizes for one job. Hard links are counted once
+// and symlinks are not followed.
+type walker struct {
+	ctx   context.Context
+	job   *analyzeJob
+	root  string
+	limit int
+	seen  map[uint64]struct{}
+}
+
+// dir returns the size of the directory at path, keeping its largest
+// children down to depth further levels.
+func (w *walker) dir(path, name string, depth int) (dto.DirectorySize, error) {
+	node := dto.DirectorySize{Name: name, Path: path, Type: "dir"}
+	if err := w.ctx.Err(); err != nil {
+		return node, err
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if path == w.root {
+			return node, err
+		}
+		w.job.skipped.Add(1)
+		return node, nil
+	}
+
+	var children []dto.DirectorySize
+	for _, entry := range entries {
+		childPath := filepath.Join(path, entry.Name())
+		if entry.IsDir() {
+			child, err := w.dir(childPath, entry.Name(), depth-1)
+			if err != nil {
+				return node, err
+			}
+			node.Bytes += child.Bytes
+			node.Files += child.Files
+			node.Dirs += child.Dirs + 1
+			if depth > 0 {
+				children = append(children, child)
+			}
+			continue
+		}
+		size, ok := w.fileSize(entry)
+		if !ok {
+			continue
+		}
+		node.Bytes += size
+		node.Files++
+		if depth > 0 {
+			children = append(children, dto.DirectorySize{Name: entry.Name(), Path: childPath, Type: "file", Bytes: size, Files: 1})
+		}
+	}
+	if info, err := os.Lstat(path); err == nil {
+		node.Bytes += allocated(info)
+	}
+
+	if depth > 0 {
+		slices.SortFunc(children, func(x, y dto.DirectorySize) int { return cmp.Compare(y.Bytes, x.Bytes) })
+		if len(children) > w.limit {
+			for _, c := range children[w.limit:] {
+				node.OmittedBytes += c.Bytes
+			}
+			node.OmittedChildren = len(children) - w.limit
+			children = children[:w.limit]
+		}
+		node.Children = children
+	}
+	return node, nil
+}
+
+// fileSize returns the allocated size of a non-directory entry, or false
+// when it is a hard link already counted or cannot be read.
+func (w *walker) fileSize(entry os.DirEntry) (uint64, bool) {
+	info, err := entry.Info()
+	if err != nil {
+		return 0, false
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok && st.Nlink > 1 {
+		if _, dup := w.seen[st.Ino]; dup {
+			return 0, false
+		}
+		w.seen[st.Ino] = struct{}{}
+	}
+	size := allocated(info)
+	w.job.files.Add(1)
+	w.job.bytes.Add(size)
+	return size, true
+}
+
+// allocated returns the disk space used by a file, falling back to its
+// apparent size when block counts are unavailable.
+func allocated(info os.FileInfo) uint64 {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Blocks) * 512 // #nosec G115 -- block counts are non-negative
+	}
+	return uint64(max(info.Size(), 0)) // #nosec G115 -- clamped to non-negative
+}
+
+// newJobID returns a random job identifier.
+func newJobID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
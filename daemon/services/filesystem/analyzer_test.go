@@ -0,0 +1,125 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func writeFile(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitForJob polls until the job leaves the running state.
+func waitForJob(t *testing.T, a *Analyzer, id string) dto.FilesystemAnalyzeJob {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := a.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.State != dto.AnalyzeJobRunning {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("job did not finish")
+	return dto.FilesystemAnalyzeJob{}
+}
+
+func TestAnalyzerReportsLargestChildren(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "plex", "db", "library.db"), 256*1024)
+	writeFile(t, filepath.Join(root, "plex", "cache.bin"), 64*1024)
+	writeFile(t, filepath.Join(root, "sonarr", "config.xml"), 4*1024)
+	writeFile(t, filepath.Join(root, "small.txt"), 10)
+
+	a := NewAnalyzer()
+	started, err := a.Start(context.Background(), root, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started.State != dto.AnalyzeJobRunning || started.Depth != 2 || started.Limit != 2 {
+		t.Errorf("started job = %+v", started)
+	}
+
+	job := waitForJob(t, a, started.ID)
+	if job.State != dto.AnalyzeJobCompleted || job.Result == nil {
+		t.Fatalf("job = %+v", job)
+	}
+	result := job.Result
+	if result.Files != 4 || result.Dirs != 3 || job.ScannedFiles != 4 {
+		t.Errorf("files = %d, dirs = %d, scanned = %d", result.Files, result.Dirs, job.ScannedFiles)
+	}
+	if len(result.Children) != 2 || result.OmittedChildren != 1 || result.OmittedBytes == 0 {
+		t.Fatalf("children = %+v, omitted = %d", result.Children, result.OmittedChildren)
+	}
+	plex := result.Children[0]
+	if plex.Name != "plex" || plex.Type != "dir" || plex.Bytes < 320*1024 {
+		t.Errorf("largest child = %+v", plex)
+	}
+	if len(plex.Children) != 2 || plex.Children[0].Name != "db" || plex.Children[0].Children != nil {
+		t.Errorf("plex children = %+v", plex.Children)
+	}
+
+	if list := a.List(); len(list) != 1 || list[0].Result != nil {
+		t.Errorf("list = %+v", list)
+	}
+}
+
+func TestAnalyzerValidatesAndLimitsJobs(t *testing.T) {
+	root := t.TempDir()
+	a := NewAnalyzer()
+
+	if _, err := a.Start(context.Background(), root, MaxAnalyzeDepth+1, 0); err == nil {
+		t.Error("depth above maximum accepted")
+	}
+	if _, err := a.Start(context.Background(), filepath.Join(root, "missing"), 0, 0); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("missing path error = %v", err)
+	}
+
+	// Fill the running slots so the next start is rejected.
+	a.mu.Lock()
+	for i := range maxRunningJobs {
+		a.jobs[string(rune('a'+i))] = &analyzeJob{
+			status: dto.FilesystemAnalyzeJob{ID: string(rune('a' + i)), State: dto.AnalyzeJobRunning},
+			cancel: func() {},
+		}
+	}
+	a.mu.Unlock()
+	if _, err := a.Start(context.Background(), root, 0, 0); !errors.Is(err, ErrTooManyJobs) {
+		t.Errorf("error with %d running jobs = %v", maxRunningJobs, err)
+	}
+
+	if _, err := a.Get("unknown"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("unknown job error = %v", err)
+	}
+}
+
+func TestAnalyzerCancel(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a"), 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	a := NewAnalyzer()
+	job, err := a.Start(ctx, root, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job = waitForJob(t, a, job.ID); job.State != dto.AnalyzeJobCancelled || job.FinishedAt == nil {
+		t.Errorf("job = %+v", job)
+	}
+}
@@ -0,0 +1,139 @@
+package filesystem
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// DefaultListLimit and MaxListLimit bound the entries returned per page of
+// a directory listing.
+const (
+	DefaultListLimit = 1000
+	MaxListLimit     = 5000
+)
+
+var (
+	// ErrNotDirectory is returned when listing a path that is not a directory.
+	ErrNotDirectory = errors.New("not a directory")
+
+	// ErrNotRegularFile is returned when opening a path that is not a regular file.
+	ErrNotRegularFile = errors.New("not a regular file")
+)
+
+// ListDir returns one page of the entries of the directory at path, which
+// must already be resolved with ResolvePath. A limit of 0 selects
+// DefaultListLimit.
+func ListDir(path string, offset, limit int) (*dto.DirectoryListing, error) {
+	if limit == 0 {
+		limit = DefaultListLimit
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	if limit < 1 || limit > MaxListLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", MaxListLimit)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, statError(err)
+	}
+	if !info.IsDir() {
+		return nil, ErrNotDirectory
+	}
+
+	dirEntries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+	entries := make([]dto.FileEntry, 0, len(dirEntries))
+	for _, d := range dirEntries {
+		info, err := d.Info()
+		if err != nil {
+			// Removed since the directory was read.
+			continue
+		}
+		entries = append(entries, fileEntry(filepath.Join(path, d.Name()), info))
+	}
+	slices.SortFunc(entries, func(a, b dto.FileEntry) int {
+		if aDir, bDir := a.Type == "dir", b.Type == "dir"; aDir != bDir {
+			if aDir {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	listing := &dto.DirectoryListing{Path: path, Total: len(entries), Offset: offset, Limit: limit}
+	start := min(offset, len(entries))
+	listing.Entries = entries[start:min(start+limit, len(entries))]
+	return listing, nil
+}
+
+// Stat describes the file or directory at path, which must already be
+// resolved with ResolvePath.
+func Stat(path string) (*dto.FileEntry, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, statError(err)
+	}
+	entry := fileEntry(path, info)
+	return &entry, nil
+}
+
+// OpenFile opens the regular file at path, which must already be resolved
+// with ResolvePath, for reading. The caller closes the file.
+func OpenFile(path string) (*os.File, os.FileInfo, error) {
+	// #nosec G304 -- path is resolved and checked against the share roots by ResolvePath.
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, statError(err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, err
+	}
+	if !info.Mode().IsRegular() {
+		_ = f.Close()
+		return nil, nil, ErrNotRegularFile
+	}
+	return f, info, nil
+}
+
+// fileEntry converts Lstat information into a FileEntry.
+func fileEntry(path string, info os.FileInfo) dto.FileEntry {
+	entry := dto.FileEntry{
+		Name:      info.Name(),
+		Path:      path,
+		SizeBytes: info.Size(),
+		Mode:      info.Mode().String(),
+		ModTime:   info.ModTime(),
+	}
+	switch mode := info.Mode(); {
+	case mode.IsDir():
+		entry.Type = "dir"
+	case mode.IsRegular():
+		entry.Type = "file"
+	case mode&fs.ModeSymlink != 0:
+		entry.Type = "symlink"
+	default:
+		entry.Type = "other"
+	}
+	return entry
+}
+
+// statError maps a missing path to ErrPathNotFound.
+func statError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrPathNotFound
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package filesystem
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "b.txt"), 3)
+	writeFile(t, filepath.Join(dir, "a.txt"), 5)
+	writeFile(t, filepath.Join(dir, "zdir", "x"), 1)
+	if err := os.Symlink("/etc/passwd", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	listing, err := ListDir(dir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listing.Total != 4 || listing.Limit != DefaultListLimit {
+		t.Fatalf("listing = %+v", listing)
+	}
+	want := []struct{ name, typ string }{{"zdir", "dir"}, {"a.txt", "file"}, {"b.txt", "file"}, {"link", "symlink"}}
+	for i, w := range want {
+		if e := listing.Entries[i]; e.Name != w.name || e.Type != w.typ {
+			t.Errorf("entries[%d] = %s (%s), want %s (%s)", i, e.Name, e.Type, w.name, w.typ)
+		}
+	}
+	if listing.Entries[1].SizeBytes != 5 || listing.Entries[1].Path != filepath.Join(dir, "a.txt") {
+		t.Errorf("a.txt entry = %+v", listing.Entries[1])
+	}
+
+	page, err := ListDir(dir, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Entries) != 1 || page.Entries[0].Name != "link" || page.Total != 4 {
+		t.Errorf("page = %+v", page)
+	}
+	if page, _ := ListDir(dir, 10, 2); len(page.Entries) != 0 {
+		t.Errorf("offset past end = %+v", page.Entries)
+	}
+
+	if _, err := ListDir(filepath.Join(dir, "a.txt"), 0, 0); !errors.Is(err, ErrNotDirectory) {
+		t.Errorf("listing a file error = %v", err)
+	}
+	if _, err := ListDir(filepath.Join(dir, "missing"), 0, 0); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("listing a missing path error = %v", err)
+	}
+	if _, err := ListDir(dir, 0, MaxListLimit+1); err == nil {
+		t.Error("limit above maximum accepted")
+	}
+}
+
+func TestStatAndOpenFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backup.tar")
+	if err := os.WriteFile(path, []byte("archive"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Type != "file" || entry.SizeBytes != 7 || entry.Mode != "-rw-------" {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	f, info, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	data, _ := io.ReadAll(f)
+	if string(data) != "archive" || info.Size() != 7 {
+		t.Errorf("read %q, size %d", data, info.Size())
+	}
+
+	if _, _, err := OpenFile(dir); !errors.Is(err, ErrNotRegularFile) {
+		t.Errorf("opening a directory error = %v", err)
+	}
+	if _, err := Stat(filepath.Join(dir, "missing")); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("stat missing error = %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Package filesystem provides guarded, read-only access to user share data:
+// resolving client-supplied paths against the allowed share roots, browsing
+// and downloading files, and analyzing directory sizes.
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+var (
+	// ErrPathNotAllowed is returned for paths outside every share root.
+	ErrPathNotAllowed = errors.New("path is outside the allowed share paths")
+
+	// ErrPathNotFound is returned for paths that do not exist.
+	ErrPathNotFound = errors.New("path not found")
+)
+
+// ShareRoots returns the directories clients may access: each share's user
+// share path (/mnt/user/<share>) and its directory on every mounted array
+// disk and pool (e.g. /mnt/disk1/<share>, /mnt/cache/<share>). Parity disks
+// are never mounted and so never contribute a root.
+func ShareRoots(shares []dto.ShareInfo, disks []dto.DiskInfo) []string {
+	var roots []string
+	for _, share := range shares {
+		if share.Name == "" {
+			continue
+		}
+		if share.Path != "" {
+			roots = append(roots, filepath.Clean(share.Path))
+		}
+		for _, disk := range disks {
+			if disk.MountPoint != "" {
+				roots = append(roots, filepath.Join(disk.MountPoint, share.Name))
+			}
+		}
+	}
+	return roots
+}
+
+// ResolvePath returns the canonical form of path, with symlinks resolved,
+// when it lies within one of roots. Resolving symlinks first stops a link
+// inside a share from reaching the rest of the filesystem.
+func ResolvePath(path string, roots []string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("path must be absolute: %q", path)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", ErrPathNotFound
+		}
+		return "", fmt.Errorf("resolve path: %w", err)
+	}
+	for _, root := range roots {
+		if r, err := filepath.EvalSymlinks(root); err == nil {
+			root = r
+		}
+		if within(resolved, root) {
+			return resolved, nil
+		}
+	}
+	return "", ErrPathNotAllowed
+}
+
+// within reports whether path is root or lies below it.
+func within(path, root string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
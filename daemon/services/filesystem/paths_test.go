@@ -0,0 +1,46 @@
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestResolvePath(t *testing.T) {
+	mnt := t.TempDir()
+	share := filepath.Join(mnt, "user", "appdata")
+	writeFile(t, filepath.Join(share, "plex", "x"), 1)
+	writeFile(t, filepath.Join(mnt, "boot", "config", "secret"), 1)
+	if err := os.Symlink(filepath.Join(mnt, "boot"), filepath.Join(share, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	roots := ShareRoots(
+		[]dto.ShareInfo{{Name: "appdata", Path: share}},
+		[]dto.DiskInfo{{MountPoint: filepath.Join(mnt, "cache")}},
+	)
+	if len(roots) != 2 || roots[1] != filepath.Join(mnt, "cache", "appdata") {
+		t.Fatalf("roots = %v", roots)
+	}
+
+	if got, err := ResolvePath(filepath.Join(share, "plex", "..", "plex"), roots); err != nil || got != filepath.Join(share, "plex") {
+		t.Errorf("ResolvePath(plex) = %q, %v", got, err)
+	}
+	for _, path := range []string{
+		filepath.Join(share, ".."),
+		filepath.Join(share, "escape", "config"),
+		filepath.Join(mnt, "user", "appdata2"),
+	} {
+		if _, err := ResolvePath(path, roots); err == nil {
+			t.Errorf("ResolvePath(%q) allowed", path)
+		}
+	}
+	if _, err := ResolvePath(filepath.Join(share, "missing"), roots); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("missing path error = %v", err)
+	}
+	if _, err := ResolvePath("appdata", roots); err == nil {
+		t.Error("relative path allowed")
+	}
+}
@@ -0,0 +1,223 @@
+package fleet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// peerTimeout bounds each status request made for the fleet overview.
+	peerTimeout = 5 * time.Second
+
+	// proxyTimeout bounds proxied requests, which may be slow control actions.
+	proxyTimeout = 2 * time.Minute
+
+	// maxPeerResponseBytes caps the status payload read from a peer.
+	maxPeerResponseBytes = 4 << 20
+)
+
+// Client aggregates status from peers and proxies API requests to them.
+type Client struct {
+	store      *Store
+	relay      *SSHRelay
+	httpClient *http.Client
+	transport  http.RoundTripper
+}
+
+// NewClient creates a fleet client backed by the given peer store. The SSH
+// relay keeps its key next to the peer list.
+func NewClient(store *Store) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = proxyTimeout
+	return &Client{
+		store:      store,
+		relay:      NewSSHRelay(filepath.Dir(store.filePath)),
+		httpClient: &http.Client{Timeout: peerTimeout, Transport: transport},
+		transport:  transport,
+	}
+}
+
+// Store returns the peer store.
+func (c *Client) Store() *Store {
+	return c.store
+}
+
+// Relay returns the SSH relay for peers.
+func (c *Client) Relay() *SSHRelay {
+	return c.relay
+}
+
+// RunRelay runs an allow-listed relay command on the named peer.
+func (c *Client) RunRelay(ctx context.Context, name, command string) (*dto.FleetRelayResult, error) {
+	peer, err := c.store.GetPeer(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.relay.Run(ctx, *peer, command)
+}
+
+// Overview returns the local member followed by every registered peer. Peers
+// are queried concurrently; unreachable peers are reported, not omitted.
+func (c *Client) Overview(ctx context.Context, local dto.FleetMember) *dto.FleetOverview {
+	peers := c.store.GetPeers()
+	members := make([]dto.FleetMember, len(peers)+1)
+	local.Local = true
+	local.Reachable = true
+	members[0] = local
+
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Fleet peer status", r)
+					members[i+1] = dto.FleetMember{Name: peer.Name, URL: peer.URL, Error: "internal error"}
+				}
+			}()
+			members[i+1] = c.peerStatus(ctx, peer)
+		})
+	}
+	wg.Wait()
+
+	overview := &dto.FleetOverview{
+		Members:   members,
+		Total:     len(members),
+		Timestamp: time.Now(),
+	}
+	for _, m := range members {
+		if m.Reachable {
+			overview.Reachable++
+		}
+	}
+	return overview
+}
+
+// peerStatus fetches system and array status from one peer, over SSH when
+// the peer does not run the agent.
+func (c *Client) peerStatus(ctx context.Context, peer dto.FleetPeer) dto.FleetMember {
+	if peer.URL == "" {
+		return c.relay.Status(ctx, peer)
+	}
+	member := dto.FleetMember{Name: peer.Name, URL: peer.URL, Via: "agent"}
+	start := time.Now()
+
+	var system dto.SystemInfo
+	if err := c.getJSON(ctx, peer, "system", &system); err != nil {
+		member.Error = err.Error()
+		return member
+	}
+	member.Reachable = true
+	member.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	member.Hostname = system.Hostname
+	member.UnraidVer = system.Version
+	member.AgentVersion = system.AgentVersion
+	member.Uptime = system.Uptime
+	member.CPUUsage = &system.CPUUsage
+	member.RAMUsage = &system.RAMUsage
+
+	// Array status is best effort; a peer with a stopped array still counts
+	// as reachable.
+	var array dto.ArrayStatus
+	if err := c.getJSON(ctx, peer, "array", &array); err == nil {
+		member.ArrayState = array.State
+		member.ArrayUsed = &array.UsedPercent
+	}
+	return member
+}
+
+// getJSON performs a GET against the peer's /api/v1/{path} and decodes the body.
+func (c *Client) getJSON(ctx context.Context, peer dto.FleetPeer, path string, out any) error {
+	endpoint, err := url.JoinPath(peer.URL, "api/v1", path)
+	if err != nil {
+		return fmt.Errorf("invalid peer url: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if peer.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+peer.APIKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("peer returned HTTP %d for /%s", resp.StatusCode, path)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxPeerResponseBytes)).Decode(out); err != nil {
+		return fmt.Errorf("decoding /%s: %w", path, err)
+	}
+	return nil
+}
+
+// Proxy forwards r to /api/v1/{path} on the named peer and streams the
+// response back to w. The caller's credentials are never forwarded; the
+// peer's own API key is used instead.
+func (c *Client) Proxy(w http.ResponseWriter, r *http.Request, name, path string) error {
+	peer, err := c.store.GetPeer(name)
+	if err != nil {
+		return err
+	}
+	if peer.URL == "" {
+		return fmt.Errorf("peer %s does not run the agent; only relay commands are available", name)
+	}
+	path = strings.TrimPrefix(path, "/")
+	if path == "" || strings.Contains(path, "..") {
+		return fmt.Errorf("invalid proxy path %q", path)
+	}
+	// Keep the path prefix of a peer behind a reverse proxy
+	endpoint, err := url.JoinPath(peer.URL, "api/v1", path)
+	if err != nil {
+		return fmt.Errorf("invalid peer url: %w", err)
+	}
+	target, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid peer url: %w", err)
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Scheme = target.Scheme
+			pr.Out.URL.Host = target.Host
+			pr.Out.URL.Path = target.Path
+			pr.Out.URL.RawPath = target.RawPath
+			pr.Out.URL.RawQuery = r.URL.RawQuery
+			pr.Out.Host = target.Host
+			pr.Out.Header.Del("Authorization")
+			pr.Out.Header.Del("X-API-Key")
+			pr.Out.Header.Del("Cookie")
+			if peer.APIKey != "" {
+				pr.Out.Header.Set("Authorization", "Bearer "+peer.APIKey)
+			}
+		},
+		Transport: c.transport,
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			logger.Warning("Fleet: proxy to peer '%s' failed: %v", peer.Name, err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+			_ = json.NewEncoder(w).Encode(dto.Response{
+				Success:   false,
+				Message:   fmt.Sprintf("peer %s unreachable", peer.Name),
+				Timestamp: time.Now(),
+			})
+		},
+	}
+	proxy.ServeHTTP(w, r)
+	return nil
+}
@@ -0,0 +1,152 @@
+package fleet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// newPeerServer fakes a peer agent that records the last request it saw.
+func newPeerServer(t *testing.T, last **http.Request) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = r
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/system":
+			_ = json.NewEncoder(w).Encode(dto.SystemInfo{Hostname: "nas2", AgentVersion: "2026.1.0", CPUUsage: 12.5})
+		case "/api/v1/array":
+			_ = json.NewEncoder(w).Encode(dto.ArrayStatus{State: "Started", UsedPercent: 40})
+		case "/api/v1/docker/plex/restart":
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"success":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOverviewReportsPeers(t *testing.T) {
+	var last *http.Request
+	peer := newPeerServer(t, &last)
+
+	store := NewStore(t.TempDir())
+	if err := store.AddPeer(dto.FleetPeer{Name: "nas2", URL: peer.URL, APIKey: "k2"}); err != nil {
+		t.Fatalf("AddPeer: %v", err)
+	}
+	if err := store.AddPeer(dto.FleetPeer{Name: "down", URL: "http://127.0.0.1:1"}); err != nil {
+		t.Fatalf("AddPeer: %v", err)
+	}
+
+	overview := NewClient(store).Overview(context.Background(), dto.FleetMember{Name: "local", Hostname: "tower"})
+	if overview.Total != 3 || overview.Reachable != 2 {
+		t.Fatalf("total/reachable = %d/%d, want 3/2", overview.Total, overview.Reachable)
+	}
+	if !overview.Members[0].Local || overview.Members[0].Hostname != "tower" {
+		t.Errorf("first member = %+v, want local", overview.Members[0])
+	}
+
+	nas2 := overview.Members[1]
+	if !nas2.Reachable || nas2.Hostname != "nas2" || nas2.ArrayState != "Started" || *nas2.CPUUsage != 12.5 {
+		t.Errorf("nas2 = %+v", nas2)
+	}
+	if got := last.Header.Get("Authorization"); got != "Bearer k2" {
+		t.Errorf("Authorization = %q, want peer key", got)
+	}
+
+	down := overview.Members[2]
+	if down.Reachable || down.Error == "" {
+		t.Errorf("down = %+v, want unreachable with error", down)
+	}
+}
+
+func TestProxyForwardsRequest(t *testing.T) {
+	var last *http.Request
+	peer := newPeerServer(t, &last)
+
+	store := NewStore(t.TempDir())
+	if err := store.AddPeer(dto.FleetPeer{Name: "nas2", URL: peer.URL, APIKey: "k2"}); err != nil {
+		t.Fatalf("AddPeer: %v", err)
+	}
+	client := NewClient(store)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/fleet/nas2/docker/plex/restart?force=1", strings.NewReader("{}"))
+	req.Header.Set("Authorization", "Bearer caller")
+	rec := httptest.NewRecorder()
+	if err := client.Proxy(rec, req, "nas2", "docker/plex/restart"); err != nil {
+		t.Fatalf("Proxy: %v", err)
+	}
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want 202", rec.Code)
+	}
+	if last.Method != http.MethodPost || last.URL.RawQuery != "force=1" {
+		t.Errorf("peer saw %s ?%s", last.Method, last.URL.RawQuery)
+	}
+	if got := last.Header.Get("Authorization"); got != "Bearer k2" {
+		t.Errorf("Authorization = %q, caller credentials must not be forwarded", got)
+	}
+
+	if err := client.Proxy(httptest.NewRecorder(), req, "missing", "docker"); err == nil {
+		t.Error("expected unknown peer to fail")
+	}
+	if err := client.Proxy(httptest.NewRecorder(), req, "nas2", "../../etc"); err == nil {
+		t.Error("expected traversal path to be rejected")
+	}
+}
+
+func TestProxyKeepsPeerPathPrefix(t *testing.T) {
+	var last *http.Request
+	peer := httptest.NewServer(http.StripPrefix("/uma", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = r
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v1/system" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(dto.SystemInfo{Hostname: "nas2"})
+	})))
+	t.Cleanup(peer.Close)
+
+	store := NewStore(t.TempDir())
+	if err := store.AddPeer(dto.FleetPeer{Name: "nas2", URL: peer.URL + "/uma/"}); err != nil {
+		t.Fatalf("AddPeer: %v", err)
+	}
+	client := NewClient(store)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/fleet/nas2/system", nil)
+	if err := client.Proxy(rec, req, "nas2", "system"); err != nil {
+		t.Fatalf("Proxy: %v", err)
+	}
+	if rec.Code != http.StatusOK || last == nil {
+		t.Fatalf("status = %d, want 200 from /uma/api/v1/system", rec.Code)
+	}
+
+	p, _ := store.GetPeer("nas2")
+	if member := client.peerStatus(context.Background(), *p); !member.Reachable || member.Hostname != "nas2" {
+		t.Errorf("member = %+v, want system info through the prefix", member)
+	}
+}
+
+func TestProxyUnreachablePeer(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.AddPeer(dto.FleetPeer{Name: "down", URL: "http://127.0.0.1:1"}); err != nil {
+		t.Fatalf("AddPeer: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/fleet/down/system", nil)
+	if err := NewClient(store).Proxy(rec, req, "down", "system"); err != nil {
+		t.Fatalf("Proxy: %v", err)
+	}
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", rec.Code)
+	}
+}
@@ -0,0 +1,412 @@
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// SSHKeyFile is the filename of the private key used to log in to peers.
+	SSHKeyFile = "fleet_ssh_key"
+
+	// KnownHostsFile records the host key of each peer on first connection.
+	KnownHostsFile = "fleet_known_hosts"
+
+	// sshTimeout bounds connecting to a peer and running a relay command.
+	sshTimeout = 15 * time.Second
+
+	// maxRelayOutputBytes caps the command output returned to clients.
+	maxRelayOutputBytes = 64 << 10
+)
+
+var (
+	// ErrNoSSHKey is returned when no fleet SSH key has been created.
+	ErrNoSSHKey = errors.New("no fleet ssh key configured")
+
+	// ErrUnknownCommand is returned for commands outside the relay allow list.
+	ErrUnknownCommand = errors.New("unknown relay command")
+)
+
+// relayCommands is the allow list of shell commands run on peers over SSH.
+// "wake" is not run over SSH: it sends a Wake-on-LAN packet to the peer.
+var relayCommands = map[string]string{
+	"status": `echo "hostname=$(hostname)"; cat /etc/unraid-version; ` +
+		`echo "uptime=$(cut -d. -f1 /proc/uptime)"; ` +
+		`grep -m1 '^mdState=' /var/local/emhttp/var.ini`,
+	"reboot":   "/sbin/reboot",
+	"shutdown": "/sbin/poweroff",
+}
+
+// destructiveRelayCommands must be confirmed by the caller.
+var destructiveRelayCommands = map[string]bool{"reboot": true, "shutdown": true}
+
+// IsDestructiveRelayCommand reports whether command needs confirmation.
+func IsDestructiveRelayCommand(command string) bool {
+	return destructiveRelayCommands[command]
+}
+
+// SSHRelay runs the allow-listed relay commands on peers over SSH with a
+// key owned by the agent. Host keys are trusted on first use and pinned in
+// KnownHostsFile.
+type SSHRelay struct {
+	mu        sync.Mutex
+	keyPath   string
+	hostsPath string
+
+	// dial connects to a peer's SSH port; tests may replace it.
+	dial func(ctx context.Context, network, address string) (net.Conn, error)
+	// sendWake sends a Wake-on-LAN packet; tests may replace it.
+	sendWake func(mac net.HardwareAddr) error
+}
+
+// NewSSHRelay creates a relay that keeps its key and known hosts in configDir.
+// If configDir is empty, DefaultConfigDir is used.
+func NewSSHRelay(configDir string) *SSHRelay {
+	if configDir == "" {
+		configDir = DefaultConfigDir
+	}
+	return &SSHRelay{
+		keyPath:   filepath.Join(configDir, SSHKeyFile),
+		hostsPath: filepath.Join(configDir, KnownHostsFile),
+		dial:      (&net.Dialer{}).DialContext,
+		sendWake:  func(mac net.HardwareAddr) error { return lib.SendWakeOnLAN(mac, "") },
+	}
+}
+
+// PublicKey returns the public half of the fleet SSH key.
+func (r *SSHRelay) PublicKey() (*dto.FleetSSHKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	signer, err := r.loadSigner()
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(r.keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading fleet ssh key: %w", err)
+	}
+	return describeKey(signer.PublicKey(), info.ModTime()), nil
+}
+
+// SetKey imports privateKey as the fleet SSH key, or generates a new ed25519
+// key when privateKey is empty. An existing key is replaced.
+func (r *SSHRelay) SetKey(privateKey string) (*dto.FleetSSHKey, error) {
+	var data []byte
+	if strings.TrimSpace(privateKey) == "" {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generating ssh key: %w", err)
+		}
+		hostname, _ := os.Hostname()
+		block, err := ssh.MarshalPrivateKey(priv, "unraid-management-agent@"+hostname)
+		if err != nil {
+			return nil, fmt.Errorf("encoding ssh key: %w", err)
+		}
+		data = pem.EncodeToMemory(block)
+	} else {
+		data = []byte(strings.TrimSpace(privateKey) + "\n")
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return nil, errors.New("invalid private key: passphrase-protected keys are not supported")
+		}
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(r.keyPath), 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		return nil, fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(r.keyPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("writing fleet ssh key: %w", err)
+	}
+
+	key := describeKey(signer.PublicKey(), time.Now())
+	logger.Info("Fleet SSH key set (%s)", key.Fingerprint)
+	return key, nil
+}
+
+// DeleteKey removes the fleet SSH key.
+func (r *SSHRelay) DeleteKey() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.Remove(r.keyPath); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNoSSHKey
+		}
+		return fmt.Errorf("removing fleet ssh key: %w", err)
+	}
+	logger.Info("Fleet SSH key removed")
+	return nil
+}
+
+// Run executes an allow-listed relay command on peer. Failures of the remote
+// command are reported in the result; the error is only set when the command
+// cannot be attempted at all.
+func (r *SSHRelay) Run(ctx context.Context, peer dto.FleetPeer, command string) (*dto.FleetRelayResult, error) {
+	result := &dto.FleetRelayResult{Peer: peer.Name, Command: command}
+	start := time.Now()
+	defer func() {
+		result.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+		result.Timestamp = time.Now()
+	}()
+
+	if command == "wake" {
+		if peer.MACAddress == "" {
+			return nil, fmt.Errorf("peer %s has no mac_address", peer.Name)
+		}
+		mac, err := net.ParseMAC(peer.MACAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mac_address: %w", err)
+		}
+		if err := r.sendWake(mac); err != nil {
+			result.Error = err.Error()
+			return result, nil
+		}
+		result.Success = true
+		result.Output = "magic packet sent to " + mac.String()
+		return result, nil
+	}
+
+	script, ok := relayCommands[command]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownCommand, command)
+	}
+	if peer.SSH == nil {
+		return nil, fmt.Errorf("peer %s has no ssh access configured", peer.Name)
+	}
+
+	output, exitCode, err := r.exec(ctx, peer.SSH, script)
+	result.Output = output
+	result.ExitCode = exitCode
+	if err != nil {
+		if errors.Is(err, ErrNoSSHKey) {
+			return nil, err
+		}
+		result.Error = err.Error()
+		logger.Warning("Fleet: relay %q to peer '%s' failed: %v", command, peer.Name, err)
+		return result, nil
+	}
+	result.Success = true
+	if command == "status" {
+		status := parseRelayStatus(output)
+		status.Name = peer.Name
+		result.Status = &status
+	}
+	return result, nil
+}
+
+// Status fetches the status of a peer that is only reachable over SSH.
+func (r *SSHRelay) Status(ctx context.Context, peer dto.FleetPeer) dto.FleetMember {
+	member := dto.FleetMember{Name: peer.Name, Via: "ssh"}
+	result, err := r.Run(ctx, peer, "status")
+	switch {
+	case err != nil:
+		member.Error = err.Error()
+	case !result.Success:
+		member.Error = result.Error
+	default:
+		member = *result.Status
+		member.Via = "ssh"
+		member.Reachable = true
+		member.LatencyMs = result.DurationMs
+	}
+	return member
+}
+
+// exec runs script on the peer and returns its combined output and exit code.
+func (r *SSHRelay) exec(ctx context.Context, target *dto.FleetPeerSSH, script string) (string, int, error) {
+	r.mu.Lock()
+	signer, err := r.loadSigner()
+	r.mu.Unlock()
+	if err != nil {
+		return "", 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sshTimeout)
+	defer cancel()
+
+	address := net.JoinHostPort(target.Host, strconv.Itoa(target.Port))
+	conn, err := r.dial(ctx, "tcp", address)
+	if err != nil {
+		return "", 0, fmt.Errorf("connecting to %s: %w", address, err)
+	}
+	// Close the connection when ctx ends so a stalled handshake or command
+	// cannot outlive the timeout.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, &ssh.ClientConfig{
+		User:            target.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: r.trustOnFirstUse,
+		Timeout:         sshTimeout,
+	})
+	if err != nil {
+		_ = conn.Close()
+		return "", 0, fmt.Errorf("ssh login to %s: %w", address, err)
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer func() { _ = client.Close() }()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", 0, fmt.Errorf("opening ssh session: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	var out limitedBuffer
+	session.Stdout = &out
+	session.Stderr = &out
+	err = session.Run(script)
+	output := strings.TrimSpace(out.String())
+
+	var exitErr *ssh.ExitError
+	var missing *ssh.ExitMissingError
+	switch {
+	case err == nil:
+		return output, 0, nil
+	case errors.As(err, &exitErr):
+		return output, exitErr.ExitStatus(), fmt.Errorf("command exited with status %d", exitErr.ExitStatus())
+	case errors.As(err, &missing):
+		// reboot and poweroff may drop the connection before reporting.
+		return output, 0, nil
+	case ctx.Err() != nil:
+		return output, 0, fmt.Errorf("command timed out after %v", sshTimeout)
+	}
+	return output, 0, fmt.Errorf("running command: %w", err)
+}
+
+// trustOnFirstUse checks a peer's host key against KnownHostsFile and pins
+// it there when the host has not been seen before. A changed key is refused.
+func (r *SSHRelay) trustOnFirstUse(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := os.OpenFile(r.hostsPath, os.O_CREATE|os.O_RDONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening known hosts: %w", err)
+	}
+	_ = f.Close()
+
+	check, err := knownhosts.New(r.hostsPath)
+	if err != nil {
+		return fmt.Errorf("reading known hosts: %w", err)
+	}
+	err = check(hostname, remote, key)
+	var keyErr *knownhosts.KeyError
+	if !errors.As(err, &keyErr) {
+		return err
+	}
+	if len(keyErr.Want) > 0 {
+		return fmt.Errorf("host key of %s changed; remove its line from %s if this is expected", hostname, r.hostsPath)
+	}
+
+	line := knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key) + "\n"
+	f, err = os.OpenFile(r.hostsPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening known hosts: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteString(line); err != nil {
+		return fmt.Errorf("writing known hosts: %w", err)
+	}
+	logger.Info("Fleet: pinned host key of %s (%s)", hostname, ssh.FingerprintSHA256(key))
+	return nil
+}
+
+// loadSigner reads the fleet SSH key. Caller must hold r.mu.
+func (r *SSHRelay) loadSigner() (ssh.Signer, error) {
+	data, err := os.ReadFile(r.keyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoSSHKey
+		}
+		return nil, fmt.Errorf("reading fleet ssh key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing fleet ssh key: %w", err)
+	}
+	return signer, nil
+}
+
+// describeKey formats a public key for the API.
+func describeKey(key ssh.PublicKey, createdAt time.Time) *dto.FleetSSHKey {
+	hostname, _ := os.Hostname()
+	return &dto.FleetSSHKey{
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))) + " unraid-management-agent@" + hostname,
+		Fingerprint: ssh.FingerprintSHA256(key),
+		CreatedAt:   createdAt,
+	}
+}
+
+// parseRelayStatus parses the key=value output of the "status" command.
+func parseRelayStatus(output string) dto.FleetMember {
+	var member dto.FleetMember
+	for line := range strings.SplitSeq(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "hostname":
+			member.Hostname = value
+		case "version":
+			member.UnraidVer = value
+		case "uptime":
+			member.Uptime, _ = strconv.ParseInt(value, 10, 64)
+		case "mdState":
+			member.ArrayState = value
+		}
+	}
+	return member
+}
+
+// limitedBuffer keeps the first maxRelayOutputBytes written to it.
+type limitedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if room := maxRelayOutputBytes - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
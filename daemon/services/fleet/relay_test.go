@@ -0,0 +1,229 @@
+package fleet
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// startSSHServer serves one exec request per connection, answering with the
+// output returned by handle.
+func startSSHServer(t *testing.T, authorized ssh.PublicKey, handle func(cmd string) (string, uint32)) string {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorized.Marshal()) {
+				return nil, errors.New("unauthorized")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, config, handle)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func serveSSH(conn net.Conn, config *ssh.ServerConfig, handle func(cmd string) (string, uint32)) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		ch, requests, err := newChan.Accept()
+		if err != nil {
+			return
+		}
+		for req := range requests {
+			if req.Type != "exec" {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			_ = req.Reply(true, nil)
+			cmd := string(req.Payload[4:])
+			out, status := handle(cmd)
+			_, _ = ch.Write([]byte(out))
+			payload := make([]byte, 4)
+			binary.BigEndian.PutUint32(payload, status)
+			_, _ = ch.SendRequest("exit-status", false, payload)
+			_ = ch.Close()
+		}
+	}
+}
+
+func peerFor(t *testing.T, addr string) dto.FleetPeer {
+	t.Helper()
+	host, portStr, _ := net.SplitHostPort(addr)
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer := dto.FleetPeer{Name: "old-tower", SSH: &dto.FleetPeerSSH{Host: host, Port: port}}
+	if err := normalizePeerSSH(peer.SSH); err != nil {
+		t.Fatal(err)
+	}
+	return peer
+}
+
+func TestSSHRelayStatusAndHostKeyPinning(t *testing.T) {
+	dir := t.TempDir()
+	relay := NewSSHRelay(dir)
+	key, err := relay.SetKey("")
+	if err != nil {
+		t.Fatalf("SetKey: %v", err)
+	}
+	if !strings.HasPrefix(key.PublicKey, "ssh-ed25519 ") || !strings.HasPrefix(key.Fingerprint, "SHA256:") {
+		t.Errorf("key = %+v", key)
+	}
+	info, err := os.Stat(filepath.Join(dir, SSHKeyFile))
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("key file: %v, %v", info, err)
+	}
+	authorized, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ran := make(chan string, 4)
+	addr := startSSHServer(t, authorized, func(cmd string) (string, uint32) {
+		ran <- cmd
+		return "hostname=OldTower\nversion=\"6.12.13\"\nuptime=3600\nmdState=STARTED\n", 0
+	})
+	peer := peerFor(t, addr)
+
+	result, err := relay.Run(context.Background(), peer, "status")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !result.Success || result.Status == nil {
+		t.Fatalf("result = %+v", result)
+	}
+	if s := result.Status; s.Hostname != "OldTower" || s.UnraidVer != "6.12.13" || s.Uptime != 3600 || s.ArrayState != "STARTED" {
+		t.Errorf("status = %+v", s)
+	}
+	if cmd := <-ran; cmd != relayCommands["status"] {
+		t.Errorf("ran %q", cmd)
+	}
+
+	hosts, err := os.ReadFile(filepath.Join(dir, KnownHostsFile))
+	if err != nil || !strings.Contains(string(hosts), "ssh-ed25519") {
+		t.Fatalf("known hosts = %q, %v", hosts, err)
+	}
+
+	// A different host key on the same address is refused.
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ssh.NewPublicKey(otherPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned := knownhosts.Line([]string{knownhosts.Normalize(addr)}, other) + "\n"
+	if err := os.WriteFile(filepath.Join(dir, KnownHostsFile), []byte(pinned), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result, err = relay.Run(context.Background(), peer, "status")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Success || !strings.Contains(result.Error, "host key") {
+		t.Errorf("changed host key result = %+v", result)
+	}
+}
+
+func TestSSHRelayRejectsUnknownCommandsAndMissingKey(t *testing.T) {
+	relay := NewSSHRelay(t.TempDir())
+	peer := dto.FleetPeer{Name: "old-tower", SSH: &dto.FleetPeerSSH{Host: "192.168.1.30", Port: 22, User: "root"}}
+
+	if _, err := relay.Run(context.Background(), peer, "rm -rf /"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("unknown command err = %v", err)
+	}
+	if _, err := relay.Run(context.Background(), peer, "status"); !errors.Is(err, ErrNoSSHKey) {
+		t.Errorf("missing key err = %v", err)
+	}
+	if _, err := relay.PublicKey(); !errors.Is(err, ErrNoSSHKey) {
+		t.Errorf("PublicKey err = %v", err)
+	}
+	if _, err := relay.SetKey("not a key"); err == nil {
+		t.Error("expected invalid private key to be rejected")
+	}
+}
+
+func TestSSHRelayWake(t *testing.T) {
+	relay := NewSSHRelay(t.TempDir())
+	var sent net.HardwareAddr
+	relay.sendWake = func(mac net.HardwareAddr) error {
+		sent = mac
+		return nil
+	}
+
+	if _, err := relay.Run(context.Background(), dto.FleetPeer{Name: "old-tower"}, "wake"); err == nil {
+		t.Error("expected wake without mac_address to fail")
+	}
+	result, err := relay.Run(context.Background(), dto.FleetPeer{Name: "old-tower", MACAddress: "a8:a1:59:12:34:56"}, "wake")
+	if err != nil || !result.Success {
+		t.Fatalf("wake = %+v, %v", result, err)
+	}
+	if sent.String() != "a8:a1:59:12:34:56" {
+		t.Errorf("sent to %v", sent)
+	}
+}
+
+func TestNormalizePeerSSHOnly(t *testing.T) {
+	peer := dto.FleetPeer{Name: "old-tower", SSH: &dto.FleetPeerSSH{Host: "192.168.1.30"}, MACAddress: "A8-A1-59-12-34-56"}
+	if err := normalizePeer(&peer); err != nil {
+		t.Fatalf("normalizePeer: %v", err)
+	}
+	if peer.SSH.Port != 22 || peer.SSH.User != "root" || peer.MACAddress != "a8:a1:59:12:34:56" {
+		t.Errorf("peer = %+v %+v", peer, peer.SSH)
+	}
+
+	for _, bad := range []dto.FleetPeer{
+		{Name: "no-access"},
+		{Name: "bad-user", SSH: &dto.FleetPeerSSH{Host: "nas", User: "root;reboot"}},
+		{Name: "bad-port", SSH: &dto.FleetPeerSSH{Host: "nas", Port: 70000}},
+		{Name: "bad-mac", SSH: &dto.FleetPeerSSH{Host: "nas"}, MACAddress: "zz"},
+		{Name: "ssh-key", SSH: &dto.FleetPeerSSH{Host: "nas"}},
+	} {
+		if err := normalizePeer(&bad); err == nil {
+			t.Errorf("normalizePeer(%s) accepted", bad.Name)
+		}
+	}
+}
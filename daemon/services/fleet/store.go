@@ -0,0 +1,274 @@
+// Package fleet lets one agent register peer agents on other Unraid servers,
+// aggregate their status, and proxy API requests to them. Peers that do not
+// run the agent can be reached over SSH for a fixed set of relay commands.
+package fleet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DefaultConfigDir is the default directory for the peer list.
+	DefaultConfigDir = "/boot/config/plugins/unraid-management-agent"
+
+	// PeersConfigFile is the filename of the peer list.
+	PeersConfigFile = "fleet_peers.json"
+
+	// MaxPeers is the maximum number of peers that can be registered.
+	MaxPeers = 32
+)
+
+// ErrPeerNotFound is returned when a peer name is not registered.
+var ErrPeerNotFound = errors.New("peer not found")
+
+// peerNameRegex keeps peer names safe to use as a URL path segment.
+var peerNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// sshUserRegex matches a POSIX login name.
+var sshUserRegex = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
+// reservedNames collide with fixed /fleet routes.
+var reservedNames = map[string]bool{"peers": true, "local": true, "ssh-key": true}
+
+// Store manages persistent storage of fleet peers in a JSON file.
+type Store struct {
+	mu       sync.RWMutex
+	peers    []dto.FleetPeer
+	filePath string
+}
+
+// NewStore creates a new peer store. If configDir is empty, DefaultConfigDir is used.
+func NewStore(configDir string) *Store {
+	if configDir == "" {
+		configDir = DefaultConfigDir
+	}
+	return &Store{
+		filePath: filepath.Join(configDir, PeersConfigFile),
+		peers:    make([]dto.FleetPeer, 0),
+	}
+}
+
+// Load reads the peer list from disk. A missing file starts an empty fleet.
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading fleet peers: %w", err)
+	}
+
+	var config dto.FleetPeersConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("parsing fleet peers: %w", err)
+	}
+
+	s.peers = config.Peers
+	if s.peers == nil {
+		s.peers = make([]dto.FleetPeer, 0)
+	}
+
+	logger.Info("Loaded %d fleet peers from %s", len(s.peers), s.filePath)
+	return nil
+}
+
+// save writes the peer list to disk. Caller must hold the write lock.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(dto.FleetPeersConfig{Peers: s.peers}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling fleet peers: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	// 0600: peers may carry API keys.
+	if err := os.WriteFile(s.filePath, data, 0o600); err != nil {
+		return fmt.Errorf("writing fleet peers: %w", err)
+	}
+	return nil
+}
+
+// GetPeers returns a copy of all peers.
+func (s *Store) GetPeers() []dto.FleetPeer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]dto.FleetPeer, len(s.peers))
+	copy(result, s.peers)
+	return result
+}
+
+// GetPeer returns a peer by name.
+func (s *Store) GetPeer(name string) (*dto.FleetPeer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := range s.peers {
+		if s.peers[i].Name == name {
+			peer := s.peers[i]
+			return &peer, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPeerNotFound, name)
+}
+
+// AddPeer validates and registers a peer, replacing one with the same name.
+func (s *Store) AddPeer(peer dto.FleetPeer) error {
+	if err := normalizePeer(&peer); err != nil {
+		return err
+	}
+	peer.AddedAt = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.peers
+	replaced := false
+	next := make([]dto.FleetPeer, 0, len(s.peers)+1)
+	for _, existing := range s.peers {
+		if existing.Name == peer.Name {
+			next = append(next, peer)
+			replaced = true
+			continue
+		}
+		next = append(next, existing)
+	}
+	if !replaced {
+		if len(s.peers) >= MaxPeers {
+			return fmt.Errorf("maximum of %d fleet peers reached", MaxPeers)
+		}
+		next = append(next, peer)
+	}
+
+	s.peers = next
+	if err := s.save(); err != nil {
+		s.peers = old
+		return fmt.Errorf("saving after add: %w", err)
+	}
+
+	logger.Info("Registered fleet peer '%s' (%s)", peer.Name, peer.URL)
+	return nil
+}
+
+// DeletePeer removes a peer by name.
+func (s *Store) DeletePeer(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.peers {
+		if s.peers[i].Name == name {
+			old := s.peers
+			s.peers = append(append([]dto.FleetPeer{}, s.peers[:i]...), s.peers[i+1:]...)
+			if err := s.save(); err != nil {
+				s.peers = old
+				return fmt.Errorf("saving after delete: %w", err)
+			}
+			logger.Info("Removed fleet peer '%s'", name)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrPeerNotFound, name)
+}
+
+// normalizePeer validates a peer and reduces its URL to scheme://host[:port].
+// A peer needs an agent URL, SSH access, or both.
+func normalizePeer(peer *dto.FleetPeer) error {
+	peer.Name = strings.ToLower(strings.TrimSpace(peer.Name))
+	if !peerNameRegex.MatchString(peer.Name) {
+		return fmt.Errorf("invalid peer name %q: use 1-32 lowercase letters, digits, or hyphens", peer.Name)
+	}
+	if reservedNames[peer.Name] {
+		return fmt.Errorf("peer name %q is reserved", peer.Name)
+	}
+
+	peer.URL = strings.TrimSpace(peer.URL)
+	if peer.URL == "" && peer.SSH == nil {
+		return errors.New("peer needs a url, ssh access, or both")
+	}
+	if peer.URL != "" {
+		if err := normalizePeerURL(peer); err != nil {
+			return err
+		}
+	}
+	if peer.SSH != nil {
+		if err := normalizePeerSSH(peer.SSH); err != nil {
+			return err
+		}
+	}
+	if peer.MACAddress != "" {
+		mac, err := net.ParseMAC(strings.TrimSpace(peer.MACAddress))
+		if err != nil || len(mac) != 6 {
+			return fmt.Errorf("invalid mac_address %q", peer.MACAddress)
+		}
+		peer.MACAddress = mac.String()
+	}
+
+	return lib.ValidateMaxLength(peer.APIKey, "api_key", 256)
+}
+
+// normalizePeerURL validates the agent URL of a peer. A path is kept as the
+// prefix of an agent behind a reverse proxy.
+func normalizePeerURL(peer *dto.FleetPeer) error {
+	u, err := url.Parse(peer.URL)
+	if err != nil {
+		return fmt.Errorf("invalid peer url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("invalid peer url: scheme must be http or https")
+	}
+	if u.User != nil {
+		return errors.New("invalid peer url: credentials are not allowed, use api_key")
+	}
+	if err := lib.ValidateHostOrIP(u.Hostname()); err != nil {
+		return fmt.Errorf("invalid peer url: %w", err)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return errors.New("invalid peer url: query and fragment are not allowed")
+	}
+	prefix := strings.TrimRight(u.EscapedPath(), "/")
+	if strings.HasSuffix(prefix, "/api/v1") {
+		return errors.New("invalid peer url: give the agent's base URL without /api/v1")
+	}
+	peer.URL = u.Scheme + "://" + u.Host + prefix
+	return nil
+}
+
+// normalizePeerSSH validates SSH access to a peer and fills in the defaults.
+func normalizePeerSSH(ssh *dto.FleetPeerSSH) error {
+	ssh.Host = strings.TrimSpace(ssh.Host)
+	if err := lib.ValidateHostOrIP(ssh.Host); err != nil {
+		return fmt.Errorf("invalid ssh host: %w", err)
+	}
+	if ssh.Port == 0 {
+		ssh.Port = 22
+	}
+	if ssh.Port < 1 || ssh.Port > 65535 {
+		return fmt.Errorf("invalid ssh port %d", ssh.Port)
+	}
+	if ssh.User == "" {
+		ssh.User = "root"
+	}
+	if !sshUserRegex.MatchString(ssh.User) {
+		return fmt.Errorf("invalid ssh user %q", ssh.User)
+	}
+	return nil
+}
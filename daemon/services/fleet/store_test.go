@@ -0,0 +1,96 @@
+package fleet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestStoreAddDeletePersists(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	peer := dto.FleetPeer{Name: " Backup-NAS ", URL: "http://192.168.1.20:8043/", APIKey: "secret"}
+	if err := store.AddPeer(peer); err != nil {
+		t.Fatalf("AddPeer: %v", err)
+	}
+
+	got, err := store.GetPeer("backup-nas")
+	if err != nil {
+		t.Fatalf("GetPeer: %v", err)
+	}
+	if got.URL != "http://192.168.1.20:8043" {
+		t.Errorf("url = %q, want trailing slash trimmed", got.URL)
+	}
+	if got.AddedAt.IsZero() {
+		t.Error("expected added_at to be set")
+	}
+
+	// Re-adding the same name replaces rather than duplicates.
+	if err := store.AddPeer(dto.FleetPeer{Name: "backup-nas", URL: "https://nas.local:8443"}); err != nil {
+		t.Fatalf("AddPeer replace: %v", err)
+	}
+	if n := len(store.GetPeers()); n != 1 {
+		t.Fatalf("peers = %d, want 1", n)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, PeersConfigFile))
+	if err != nil {
+		t.Fatalf("config not written: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("config mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	reloaded := NewStore(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p, err := reloaded.GetPeer("backup-nas"); err != nil || p.URL != "https://nas.local:8443" {
+		t.Errorf("reloaded peer = %+v, %v", p, err)
+	}
+
+	if err := reloaded.DeletePeer("backup-nas"); err != nil {
+		t.Fatalf("DeletePeer: %v", err)
+	}
+	if err := reloaded.DeletePeer("backup-nas"); !errors.Is(err, ErrPeerNotFound) {
+		t.Errorf("second delete err = %v, want ErrPeerNotFound", err)
+	}
+}
+
+func TestStoreRejectsInvalidPeers(t *testing.T) {
+	store := NewStore(t.TempDir())
+	cases := map[string]dto.FleetPeer{
+		"empty name":     {Name: "", URL: "http://10.0.0.2:8043"},
+		"bad name":       {Name: "nas/../x", URL: "http://10.0.0.2:8043"},
+		"reserved name":  {Name: "peers", URL: "http://10.0.0.2:8043"},
+		"bad scheme":     {Name: "nas", URL: "ftp://10.0.0.2"},
+		"credentials":    {Name: "nas", URL: "http://user:pw@10.0.0.2:8043"},
+		"api path":       {Name: "nas", URL: "http://10.0.0.2:8043/api/v1"},
+		"query":          {Name: "nas", URL: "http://10.0.0.2:8043/?x=1"},
+		"invalid host":   {Name: "nas", URL: "http://bad_host!:8043"},
+		"missing scheme": {Name: "nas", URL: "10.0.0.2:8043"},
+	}
+	for name, peer := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := store.AddPeer(peer); err == nil {
+				t.Errorf("expected %+v to be rejected", peer)
+			}
+		})
+	}
+	if n := len(store.GetPeers()); n != 0 {
+		t.Errorf("peers = %d, want 0", n)
+	}
+}
+
+func TestStoreKeepsPathPrefix(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.AddPeer(dto.FleetPeer{Name: "nas", URL: "https://proxy.local/uma/"}); err != nil {
+		t.Fatalf("AddPeer: %v", err)
+	}
+	if p, _ := store.GetPeer("nas"); p.URL != "https://proxy.local/uma" {
+		t.Errorf("url = %q, want the prefix kept without the trailing slash", p.URL)
+	}
+}
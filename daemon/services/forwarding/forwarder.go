@@ -0,0 +1,213 @@
+package forwarding
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nicholas-fedor/shoutrrr"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// agentAlertEvent is the event name of Unraid notifications created by alert
+// rules with the "unraid" channel. They are forwarded as alert events instead,
+// so targets subscribed to both sources don't receive them twice.
+const agentAlertEvent = "Management Agent Alert"
+
+// Maintenance reports whether a maintenance window is in effect.
+type Maintenance interface {
+	Active() bool
+}
+
+// Forwarder delivers new Unraid notifications and alert rule events to the
+// notification targets in its store.
+type Forwarder struct {
+	store *Store
+	hub   *domain.EventBus
+	maint Maintenance
+
+	// send delivers a message to a shoutrrr URL; replaced in tests.
+	send func(url, message string) error
+
+	mu     sync.Mutex
+	seen   map[string]bool
+	seeded bool
+}
+
+// NewForwarder creates a forwarder for the targets in store, fed by hub.
+func NewForwarder(store *Store, hub *domain.EventBus) *Forwarder {
+	return &Forwarder{
+		store: store,
+		hub:   hub,
+		send:  func(url, message string) error { return shoutrrr.Send(url, message) },
+		seen:  make(map[string]bool),
+	}
+}
+
+// Start loads the targets, subscribes to notification and alert events and
+// runs until ctx is cancelled.
+func (f *Forwarder) Start(ctx context.Context) {
+	if err := f.store.Load(); err != nil {
+		logger.Error("Notification forwarding: failed to load targets: %v", err)
+	}
+	if f.hub == nil {
+		logger.Info("Notification forwarding not started (no event bus)")
+		return
+	}
+	ch := f.hub.SubTopics(constants.TopicNotificationsUpdate, constants.TopicAlertEvent)
+	defer f.hub.Unsub(ch, constants.TopicNotificationsUpdate.Name, constants.TopicAlertEvent.Name)
+	logger.Success("Notification forwarding listening for notifications and alerts")
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Notification forwarding stopped")
+			return
+		case msg := <-ch:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						logger.LogPanicWithStack("Notification forwarder", r)
+					}
+				}()
+				switch m := msg.(type) {
+				case *dto.NotificationList:
+					f.handleNotifications(m)
+				case dto.AlertEvent:
+					f.handleAlert(m)
+				}
+			}()
+		}
+	}
+}
+
+// handleNotifications forwards unread notifications not seen in an earlier
+// list. The first list only seeds the seen set, so a restart does not replay
+// every unread notification.
+func (f *Forwarder) handleNotifications(list *dto.NotificationList) {
+	if list == nil {
+		return
+	}
+
+	f.mu.Lock()
+	current := make(map[string]bool, len(list.Notifications))
+	var fresh []dto.Notification
+	for _, n := range list.Notifications {
+		if n.Type != "unread" {
+			continue
+		}
+		current[n.ID] = true
+		if f.seeded && !f.seen[n.ID] {
+			fresh = append(fresh, n)
+		}
+	}
+	// Keep only IDs still unread so the set doesn't grow without bound.
+	f.seen = current
+	f.seeded = true
+	f.mu.Unlock()
+
+	for _, n := range fresh {
+		if n.Title == agentAlertEvent {
+			continue
+		}
+		f.forward(dto.NotificationSourceUnraid, normalizeLevel(n.Importance), formatNotification(n))
+	}
+}
+
+// handleAlert forwards an alert rule firing or resolved event.
+func (f *Forwarder) handleAlert(event dto.AlertEvent) {
+	f.forward(dto.NotificationSourceAlerts, severityLevel(event.Severity), formatAlert(event))
+}
+
+// forward sends message to every enabled target subscribed to source and
+// level. Deliveries run in their own goroutines so a slow service never
+// blocks the event loop.
+func (f *Forwarder) forward(source, level, message string) {
+	if f.maint != nil && f.maint.Active() {
+		logger.Debug("Notification forwarding: suppressed %s message during maintenance", source)
+		return
+	}
+	for _, target := range f.store.GetTargets() {
+		if !target.Enabled || !matches(target.Sources, source) || !matches(target.Levels, level) {
+			continue
+		}
+		go func(target dto.NotificationTarget) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Notification forwarding delivery", r)
+				}
+			}()
+			if err := f.send(target.URL, message); err != nil {
+				logger.Warning("Notification forwarding: delivery to '%s' failed: %v", target.ID, err)
+			}
+		}(target)
+	}
+}
+
+// SetMaintenance drops messages instead of forwarding them while m reports a
+// maintenance window. They are not delivered after the window ends. Call
+// before Start.
+func (f *Forwarder) SetMaintenance(m Maintenance) { f.maint = m }
+
+// SendTest synchronously sends a test message to the target with the given
+// ID, regardless of whether it is enabled.
+func (f *Forwarder) SendTest(id string) error {
+	target, err := f.store.GetTarget(id)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("Test notification from Unraid Management Agent (target '%s')", target.ID)
+	if err := f.send(target.URL, message); err != nil {
+		return fmt.Errorf("sending test notification: %w", err)
+	}
+	return nil
+}
+
+// matches reports whether value is selected by filter; an empty filter selects everything.
+func matches(filter []string, value string) bool {
+	return len(filter) == 0 || slices.Contains(filter, value)
+}
+
+// severityLevel maps an alert rule severity to a notification importance level.
+func severityLevel(severity string) string {
+	switch severity {
+	case "critical":
+		return dto.NotificationLevelAlert
+	case "warning":
+		return dto.NotificationLevelWarning
+	default:
+		return dto.NotificationLevelNormal
+	}
+}
+
+// formatNotification renders an Unraid notification as a plain-text message.
+func formatNotification(n dto.Notification) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", strings.ToUpper(normalizeLevel(n.Importance)), n.Title)
+	if n.Subject != "" {
+		fmt.Fprintf(&b, ": %s", n.Subject)
+	}
+	if n.Description != "" {
+		fmt.Fprintf(&b, "\n%s", n.Description)
+	}
+	return b.String()
+}
+
+// formatAlert renders an alert rule event as a plain-text message.
+func formatAlert(event dto.AlertEvent) string {
+	state := "FIRING"
+	if event.State == "resolved" {
+		state = "RESOLVED"
+	}
+	message := fmt.Sprintf("[%s] %s (%s)", state, event.RuleName, event.Severity)
+	if event.Message != "" {
+		message += "\n" + event.Message
+	}
+	return message
+}
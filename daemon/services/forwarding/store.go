@@ -0,0 +1,267 @@
+// Package forwarding mirrors Unraid notifications and alert rule events to
+// external services (Telegram, Discord, Pushover).
+package forwarding
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DefaultConfigDir is the default directory for notification target configuration.
+	DefaultConfigDir = "/boot/config/plugins/unraid-management-agent"
+
+	// TargetsConfigFile is the filename for notification target configuration.
+	TargetsConfigFile = "notification_targets.json"
+
+	// MaxTargets is the maximum number of notification targets allowed.
+	MaxTargets = 20
+
+	// maxIDLength caps target IDs and names.
+	maxIDLength = 64
+)
+
+var (
+	validLevels  = []string{dto.NotificationLevelAlert, dto.NotificationLevelWarning, dto.NotificationLevelNormal}
+	validSources = []string{dto.NotificationSourceUnraid, dto.NotificationSourceAlerts}
+)
+
+// Store manages persistent storage of notification targets in a JSON file.
+type Store struct {
+	mu       sync.RWMutex
+	targets  []dto.NotificationTarget
+	filePath string
+}
+
+// NewStore creates a new notification target store. If configDir is empty, DefaultConfigDir is used.
+func NewStore(configDir string) *Store {
+	if configDir == "" {
+		configDir = DefaultConfigDir
+	}
+	return &Store{
+		filePath: filepath.Join(configDir, TargetsConfigFile),
+		targets:  make([]dto.NotificationTarget, 0),
+	}
+}
+
+// Load reads notification targets from the JSON config file.
+// If the file doesn't exist, starts with an empty set.
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Info("Notification target config not found, starting with empty set")
+			return nil
+		}
+		return fmt.Errorf("reading notification target config: %w", err)
+	}
+
+	var config dto.NotificationTargetsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("parsing notification target config: %w", err)
+	}
+
+	s.targets = config.Targets
+	if s.targets == nil {
+		s.targets = make([]dto.NotificationTarget, 0)
+	}
+
+	logger.Info("Loaded %d notification targets from %s", len(s.targets), s.filePath)
+	return nil
+}
+
+// save writes the current targets to the JSON config file. Caller must hold the write lock.
+func (s *Store) save() error {
+	config := dto.NotificationTargetsConfig{Targets: s.targets}
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling notification target config: %w", err)
+	}
+
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	// 0600: target URLs embed bot tokens and webhook secrets.
+	if err := os.WriteFile(s.filePath, data, 0o600); err != nil {
+		return fmt.Errorf("writing notification target config: %w", err)
+	}
+
+	return nil
+}
+
+// GetTargets returns a copy of all notification targets.
+func (s *Store) GetTargets() []dto.NotificationTarget {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]dto.NotificationTarget, len(s.targets))
+	copy(result, s.targets)
+	return result
+}
+
+// GetTarget returns a notification target by ID.
+func (s *Store) GetTarget(id string) (*dto.NotificationTarget, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := range s.targets {
+		if s.targets[i].ID == id {
+			target := s.targets[i]
+			return &target, nil
+		}
+	}
+	return nil, fmt.Errorf("notification target '%s' not found", id)
+}
+
+// CreateTarget validates and adds a new notification target and persists to disk.
+func (s *Store) CreateTarget(target dto.NotificationTarget) error {
+	if err := normalizeTarget(&target); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.targets) >= MaxTargets {
+		return fmt.Errorf("maximum of %d notification targets reached", MaxTargets)
+	}
+
+	for _, existing := range s.targets {
+		if existing.ID == target.ID {
+			return fmt.Errorf("notification target with ID '%s' already exists", target.ID)
+		}
+	}
+
+	s.targets = append(s.targets, target)
+
+	if err := s.save(); err != nil {
+		// Rollback
+		s.targets = s.targets[:len(s.targets)-1]
+		return fmt.Errorf("saving after create: %w", err)
+	}
+
+	logger.Info("Created notification target '%s'", target.ID)
+	return nil
+}
+
+// UpdateTarget validates and replaces an existing notification target and
+// persists to disk. An empty or redacted URL keeps the stored one, so clients
+// can round-trip a target read from the API without knowing its secret.
+func (s *Store) UpdateTarget(target dto.NotificationTarget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.targets {
+		if s.targets[i].ID == target.ID {
+			old := s.targets[i]
+			if target.URL == "" || strings.Contains(target.URL, "[REDACTED]") {
+				target.URL = old.URL
+			}
+			if err := normalizeTarget(&target); err != nil {
+				return err
+			}
+			s.targets[i] = target
+
+			if err := s.save(); err != nil {
+				// Rollback
+				s.targets[i] = old
+				return fmt.Errorf("saving after update: %w", err)
+			}
+
+			logger.Info("Updated notification target '%s'", target.ID)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("notification target '%s' not found", target.ID)
+}
+
+// DeleteTarget removes a notification target by ID and persists to disk.
+func (s *Store) DeleteTarget(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.targets {
+		if s.targets[i].ID == id {
+			old := s.targets[i]
+			oldIdx := i
+			s.targets = append(s.targets[:i], s.targets[i+1:]...)
+
+			if err := s.save(); err != nil {
+				// Rollback: re-insert at old position
+				s.targets = append(s.targets[:oldIdx], append([]dto.NotificationTarget{old}, s.targets[oldIdx:]...)...)
+				return fmt.Errorf("saving after delete: %w", err)
+			}
+
+			logger.Info("Deleted notification target '%s'", id)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("notification target '%s' not found", id)
+}
+
+// normalizeTarget validates a target and canonicalizes its level and source
+// lists ("info" is accepted as an alias for "normal").
+func normalizeTarget(target *dto.NotificationTarget) error {
+	if err := lib.ValidateNonEmpty(target.ID, "id"); err != nil {
+		return err
+	}
+	if err := lib.ValidateMaxLength(target.ID, "id", maxIDLength); err != nil {
+		return err
+	}
+	if err := lib.ValidateMaxLength(target.Name, "name", maxIDLength); err != nil {
+		return err
+	}
+	if err := lib.ValidateNotificationTargetURL(target.URL); err != nil {
+		return err
+	}
+
+	levels := make([]string, 0, len(target.Levels))
+	for _, level := range target.Levels {
+		level = normalizeLevel(level)
+		if !slices.Contains(validLevels, level) {
+			return fmt.Errorf("invalid level %q: must be one of %s", level, strings.Join(validLevels, ", "))
+		}
+		if !slices.Contains(levels, level) {
+			levels = append(levels, level)
+		}
+	}
+	target.Levels = levels
+
+	sources := make([]string, 0, len(target.Sources))
+	for _, source := range target.Sources {
+		source = strings.ToLower(strings.TrimSpace(source))
+		if !slices.Contains(validSources, source) {
+			return fmt.Errorf("invalid source %q: must be one of %s", source, strings.Join(validSources, ", "))
+		}
+		if !slices.Contains(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+	target.Sources = sources
+	return nil
+}
+
+// normalizeLevel lowercases an importance level and maps "info" to "normal".
+func normalizeLevel(level string) string {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if level == "info" {
+		return dto.NotificationLevelNormal
+	}
+	return level
+}
@@ -0,0 +1,326 @@
+// Package graphite pushes the agent's metrics to Graphite (plaintext protocol
+// over TCP) or StatsD (gauges over UDP), for monitoring stacks that predate
+// Prometheus.
+package graphite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	prommodel "github.com/prometheus/client_model/go"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// ProtocolGraphite sends "path value timestamp" lines over TCP.
+	ProtocolGraphite = "graphite"
+	// ProtocolStatsD sends "path:value|g" gauges over UDP.
+	ProtocolStatsD = "statsd"
+
+	defaultGraphitePort = "2003"
+	defaultStatsDPort   = "8125"
+
+	// defaultInterval is used when no push interval is configured.
+	defaultInterval = 60 * time.Second
+
+	// minInterval matches the lower bound accepted in the config file.
+	minInterval = 5 * time.Second
+
+	// dialTimeout bounds connecting and writing one batch.
+	dialTimeout = 10 * time.Second
+
+	// metricPrefix selects the collector metrics; Go runtime and process
+	// metrics from the Prometheus registry are not exported.
+	metricPrefix = "unraid_"
+
+	// maxDatagramBytes keeps StatsD packets below a typical Ethernet MTU so
+	// they are not fragmented.
+	maxDatagramBytes = 1432
+)
+
+// Gatherer returns the current metric snapshot. It is satisfied by the API
+// server, which fills the Prometheus registry from its caches.
+type Gatherer interface {
+	GatherMetrics() ([]*prommodel.MetricFamily, error)
+}
+
+// Sample is one metric value flattened to a dotted metric path.
+type Sample struct {
+	Path  string
+	Value float64
+}
+
+// Exporter periodically sends every collector metric to Graphite or StatsD.
+type Exporter struct {
+	protocol string
+	address  string
+	prefix   string
+	hostname string
+	interval time.Duration
+	gatherer Gatherer
+
+	// failing suppresses repeated warnings while the server is unreachable.
+	failing bool
+}
+
+// NewExporter validates cfg and creates an exporter whose metric paths start
+// with the configured prefix followed by hostname.
+func NewExporter(cfg domain.GraphiteConfig, hostname string, gatherer Gatherer) (*Exporter, error) {
+	protocol := strings.ToLower(strings.TrimSpace(cfg.Protocol))
+	if protocol == "" {
+		protocol = ProtocolGraphite
+	}
+
+	var defaultPort string
+	switch protocol {
+	case ProtocolGraphite:
+		defaultPort = defaultGraphitePort
+	case ProtocolStatsD:
+		defaultPort = defaultStatsDPort
+	default:
+		return nil, fmt.Errorf("graphite protocol must be %s or %s, got %q", ProtocolGraphite, ProtocolStatsD, cfg.Protocol)
+	}
+
+	address, err := normalizeAddress(cfg.Address, defaultPort)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := time.Duration(cfg.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	interval = max(interval, minInterval)
+
+	return &Exporter{
+		protocol: protocol,
+		address:  address,
+		prefix:   strings.Trim(strings.TrimSpace(cfg.Prefix), "."),
+		hostname: hostname,
+		interval: interval,
+		gatherer: gatherer,
+	}, nil
+}
+
+// normalizeAddress appends defaultPort when addr has no port.
+func normalizeAddress(addr, defaultPort string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return "", fmt.Errorf("graphite address is required")
+	}
+	if _, port, err := net.SplitHostPort(addr); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", fmt.Errorf("graphite address has invalid port %q", port)
+		}
+		return addr, nil
+	}
+	return net.JoinHostPort(strings.Trim(addr, "[]"), defaultPort), nil
+}
+
+// Start pushes the metrics every interval until ctx is cancelled.
+func (e *Exporter) Start(ctx context.Context) {
+	logger.Success("Graphite exporter pushing to %s (%s) every %s", e.address, e.protocol, e.interval)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Graphite exporter stopped")
+			return
+		case <-ticker.C:
+			e.pushAndLog(ctx)
+		}
+	}
+}
+
+// pushAndLog pushes once, logging the first failure and the recovery rather
+// than every failed attempt.
+func (e *Exporter) pushAndLog(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LogPanicWithStack("Graphite push", r)
+		}
+	}()
+
+	err := e.Push(ctx)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return
+	case err != nil && !e.failing:
+		logger.Warning("Graphite: push failed: %v", err)
+		e.failing = true
+	case err != nil:
+		logger.Debug("Graphite: push failed: %v", err)
+	case e.failing:
+		logger.Info("Graphite: push succeeded again")
+		e.failing = false
+	}
+}
+
+// Push gathers the current metrics and sends them in the configured protocol.
+func (e *Exporter) Push(ctx context.Context) error {
+	families, err := e.gatherer.GatherMetrics()
+	if err != nil {
+		return fmt.Errorf("gather metrics: %w", err)
+	}
+	samples := Flatten(families, e.prefix, e.hostname)
+	if len(samples) == 0 {
+		return nil
+	}
+
+	if e.protocol == ProtocolStatsD {
+		return e.sendStatsD(ctx, samples)
+	}
+	return e.sendGraphite(ctx, samples, time.Now())
+}
+
+// sendGraphite writes all samples over one TCP connection.
+func (e *Exporter) sendGraphite(ctx context.Context, samples []Sample, ts time.Time) error {
+	dialer := net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.address)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	_ = conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+	_, err = conn.Write(EncodeGraphite(samples, ts))
+	return err
+}
+
+// sendStatsD writes the samples as gauges, packing as many lines into each
+// datagram as fit.
+func (e *Exporter) sendStatsD(ctx context.Context, samples []Sample) error {
+	dialer := net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "udp", e.address)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	for _, packet := range EncodeStatsD(samples, maxDatagramBytes) {
+		if _, err := conn.Write(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flatten converts the collector metric families to dotted metric paths of
+// the form prefix.hostname.metric.labelvalue..., with the "unraid_" metric
+// prefix dropped and label values ordered by label name.
+func Flatten(families []*prommodel.MetricFamily, prefix, hostname string) []Sample {
+	var base []string
+	if prefix != "" {
+		base = append(base, prefix)
+	}
+	if hostname != "" {
+		base = append(base, sanitize(hostname))
+	}
+
+	var samples []Sample
+	for _, mf := range families {
+		name := mf.GetName()
+		if !strings.HasPrefix(name, metricPrefix) {
+			continue
+		}
+		name = sanitize(strings.TrimPrefix(name, metricPrefix))
+
+		for _, m := range mf.GetMetric() {
+			value, ok := metricValue(mf.GetType(), m)
+			if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+
+			labels := slices.Clone(m.GetLabel())
+			slices.SortFunc(labels, func(a, b *prommodel.LabelPair) int {
+				return strings.Compare(a.GetName(), b.GetName())
+			})
+
+			parts := append(slices.Clone(base), name)
+			for _, l := range labels {
+				if l.GetValue() != "" {
+					parts = append(parts, sanitize(l.GetValue()))
+				}
+			}
+			samples = append(samples, Sample{Path: strings.Join(parts, "."), Value: value})
+		}
+	}
+	return samples
+}
+
+// EncodeGraphite renders samples in the Graphite plaintext protocol.
+func EncodeGraphite(samples []Sample, ts time.Time) []byte {
+	var buf bytes.Buffer
+	stamp := strconv.FormatInt(ts.Unix(), 10)
+	for _, s := range samples {
+		buf.WriteString(s.Path)
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.FormatFloat(s.Value, 'f', -1, 64))
+		buf.WriteByte(' ')
+		buf.WriteString(stamp)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// EncodeStatsD renders samples as StatsD gauges, split into newline-separated
+// packets of at most maxBytes each. A single line longer than maxBytes is sent
+// in a packet of its own.
+func EncodeStatsD(samples []Sample, maxBytes int) [][]byte {
+	var packets [][]byte
+	var buf bytes.Buffer
+	for _, s := range samples {
+		line := s.Path + ":" + strconv.FormatFloat(s.Value, 'f', -1, 64) + "|g"
+		if buf.Len() > 0 && buf.Len()+1+len(line) > maxBytes {
+			packets = append(packets, slices.Clone(buf.Bytes()))
+			buf.Reset()
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(line)
+	}
+	if buf.Len() > 0 {
+		packets = append(packets, buf.Bytes())
+	}
+	return packets
+}
+
+// metricValue extracts the sample value of a gauge, counter or untyped metric.
+func metricValue(t prommodel.MetricType, m *prommodel.Metric) (float64, bool) {
+	switch t {
+	case prommodel.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), m.GetGauge() != nil
+	case prommodel.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), m.GetCounter() != nil
+	case prommodel.MetricType_UNTYPED:
+		return m.GetUntyped().GetValue(), m.GetUntyped() != nil
+	default:
+		return 0, false
+	}
+}
+
+// sanitize makes s safe as one Graphite path node: anything other than
+// letters, digits, '-' and '_' becomes '_'.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
@@ -0,0 +1,155 @@
+package graphite
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prommodel "github.com/prometheus/client_model/go"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+)
+
+type fakeGatherer struct {
+	families []*prommodel.MetricFamily
+}
+
+func (g fakeGatherer) GatherMetrics() ([]*prommodel.MetricFamily, error) {
+	return g.families, nil
+}
+
+// testFamilies builds a registry with one collector gauge vector and one
+// non-collector metric that must be skipped.
+func testFamilies(t *testing.T) []*prommodel.MetricFamily {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	temp := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "unraid_disk_temperature_celsius", Help: "h"},
+		[]string{"disk", "device", "type"})
+	temp.WithLabelValues("disk 1", "sdb", "").Set(34)
+	other := prometheus.NewGauge(prometheus.GaugeOpts{Name: "go_goroutines_test", Help: "h"})
+	other.Set(5)
+	reg.MustRegister(temp, other)
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	return families
+}
+
+func TestFlatten(t *testing.T) {
+	got := Flatten(testFamilies(t), "unraid", "tower.lan")
+	want := []Sample{{Path: "unraid.tower_lan.disk_temperature_celsius.sdb.disk_1", Value: 34}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Flatten() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEncodeGraphite(t *testing.T) {
+	got := string(EncodeGraphite([]Sample{{Path: "a.b", Value: 1.5}}, time.Unix(1700000000, 0)))
+	if want := "a.b 1.5 1700000000\n"; got != want {
+		t.Errorf("EncodeGraphite() = %q, want %q", got, want)
+	}
+}
+
+func TestEncodeStatsDSplitsPackets(t *testing.T) {
+	samples := []Sample{{Path: "a", Value: 1}, {Path: "b", Value: 2}, {Path: "c", Value: 3}}
+	got := EncodeStatsD(samples, len("a:1|g\nb:2|g"))
+	if len(got) != 2 || string(got[0]) != "a:1|g\nb:2|g" || string(got[1]) != "c:3|g" {
+		t.Errorf("EncodeStatsD() = %q", got)
+	}
+}
+
+func TestNewExporterValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      domain.GraphiteConfig
+		wantAddr string
+		wantErr  bool
+	}{
+		{name: "graphite default port", cfg: domain.GraphiteConfig{Address: "graphite"}, wantAddr: "graphite:2003"},
+		{name: "statsd default port", cfg: domain.GraphiteConfig{Protocol: "statsd", Address: "10.0.0.2"}, wantAddr: "10.0.0.2:8125"},
+		{name: "explicit port", cfg: domain.GraphiteConfig{Address: "graphite:2013"}, wantAddr: "graphite:2013"},
+		{name: "missing address", cfg: domain.GraphiteConfig{}, wantErr: true},
+		{name: "bad port", cfg: domain.GraphiteConfig{Address: "graphite:99999"}, wantErr: true},
+		{name: "bad protocol", cfg: domain.GraphiteConfig{Protocol: "collectd", Address: "x"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewExporter(tt.cfg, "tower", fakeGatherer{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && e.address != tt.wantAddr {
+				t.Errorf("address = %q, want %q", e.address, tt.wantAddr)
+			}
+		})
+	}
+}
+
+func TestPushGraphite(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	lines := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		lines <- line
+	}()
+
+	cfg := domain.GraphiteConfig{Address: ln.Addr().String(), Prefix: "unraid"}
+	e, err := NewExporter(cfg, "tower", fakeGatherer{families: testFamilies(t)})
+	if err != nil {
+		t.Fatalf("NewExporter: %v", err)
+	}
+	if err := e.Push(context.Background()); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	select {
+	case line := <-lines:
+		if !strings.HasPrefix(line, "unraid.tower.disk_temperature_celsius.sdb.disk_1 34 ") {
+			t.Errorf("line = %q", line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no data received")
+	}
+}
+
+func TestPushStatsD(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket: %v", err)
+	}
+	defer func() { _ = pc.Close() }()
+
+	cfg := domain.GraphiteConfig{Protocol: ProtocolStatsD, Address: pc.LocalAddr().String(), Prefix: "unraid"}
+	e, err := NewExporter(cfg, "tower", fakeGatherer{families: testFamilies(t)})
+	if err != nil {
+		t.Fatalf("NewExporter: %v", err)
+	}
+	if err := e.Push(context.Background()); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	_ = pc.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, maxDatagramBytes)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if got, want := string(buf[:n]), "unraid.tower.disk_temperature_celsius.sdb.disk_1:34|g"; got != want {
+		t.Errorf("packet = %q, want %q", got, want)
+	}
+}
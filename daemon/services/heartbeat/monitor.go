@@ -0,0 +1,267 @@
+// Package heartbeat sends periodic heartbeat pings to a healthchecks.io or
+// Uptime Kuma push URL, giving dead-man's-switch monitoring of the server.
+// A missing ping means the agent (or the server) is down; a failure ping
+// means the agent is running but a critical data source is failing or the
+// array is degraded.
+package heartbeat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/platform"
+)
+
+// Supported push providers.
+const (
+	ProviderHealthchecks = "healthchecks"
+	ProviderUptimeKuma   = "uptime_kuma"
+)
+
+const (
+	// defaultInterval is used when no ping interval is configured.
+	defaultInterval = 60 * time.Second
+
+	// minInterval matches the lower bound accepted in the config file.
+	minInterval = 10 * time.Second
+
+	// pingTimeout bounds a single ping request.
+	pingTimeout = 10 * time.Second
+
+	// maxMessageLen caps the failure reason sent to the provider.
+	maxMessageLen = 1000
+)
+
+// degradedDiskStates are array disk states that mean the array is running
+// without full redundancy.
+var degradedDiskStates = []string{"DISK_DSBL", "DISK_INVALID", "DISK_EMULATED", "DISK_WRONG"}
+
+// Monitor pings the configured push URL every interval, reporting success or
+// the current list of problems.
+type Monitor struct {
+	pushURL  *url.URL
+	provider string
+	interval time.Duration
+	critical []string
+
+	hub        *domain.EventBus
+	registry   *platform.Registry
+	httpClient *http.Client
+
+	mu    sync.RWMutex
+	array *dto.ArrayStatus
+	disks []dto.DiskInfo
+
+	// failing suppresses repeated warnings while the push URL is unreachable.
+	failing bool
+}
+
+// NewMonitor validates cfg and creates a monitor that reads data source
+// health from registry and array state from hub. Either may be nil.
+func NewMonitor(cfg domain.HeartbeatConfig, hub *domain.EventBus, registry *platform.Registry) (*Monitor, error) {
+	pushURL, err := url.Parse(strings.TrimSpace(cfg.URL))
+	if err != nil || (pushURL.Scheme != "http" && pushURL.Scheme != "https") || pushURL.Host == "" {
+		return nil, fmt.Errorf("heartbeat url must be an http(s) URL, got %q", cfg.URL)
+	}
+
+	provider := strings.ToLower(strings.TrimSpace(cfg.Provider))
+	switch provider {
+	case "", "auto":
+		provider = detectProvider(pushURL)
+	case ProviderHealthchecks, ProviderUptimeKuma:
+	default:
+		return nil, fmt.Errorf("heartbeat provider must be auto, %s or %s, got %q", ProviderHealthchecks, ProviderUptimeKuma, cfg.Provider)
+	}
+
+	interval := time.Duration(cfg.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	interval = max(interval, minInterval)
+
+	return &Monitor{
+		pushURL:    pushURL,
+		provider:   provider,
+		interval:   interval,
+		critical:   cfg.CriticalSources,
+		hub:        hub,
+		registry:   registry,
+		httpClient: &http.Client{Timeout: pingTimeout},
+	}, nil
+}
+
+// detectProvider recognizes Uptime Kuma push URLs (/api/push/<token>); any
+// other URL is treated as a healthchecks.io-style ping URL.
+func detectProvider(u *url.URL) string {
+	if strings.Contains(u.Path, "/api/push/") {
+		return ProviderUptimeKuma
+	}
+	return ProviderHealthchecks
+}
+
+// Start tracks array and disk updates and pings every interval until ctx is
+// cancelled. The first ping is sent one interval after start so collectors
+// have reported in.
+func (m *Monitor) Start(ctx context.Context) {
+	var ch chan any
+	if m.hub != nil {
+		ch = m.hub.SubTopics(constants.TopicArrayStatusUpdate, constants.TopicDiskListUpdate)
+		defer m.hub.Unsub(ch, constants.TopicArrayStatusUpdate.Name, constants.TopicDiskListUpdate.Name)
+	}
+	logger.Success("Heartbeat pinging %s (%s) every %s", m.pushURL.Host, m.provider, m.interval)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Heartbeat stopped")
+			return
+		case msg := <-ch:
+			m.mu.Lock()
+			switch v := msg.(type) {
+			case *dto.ArrayStatus:
+				m.array = v
+			case []dto.DiskInfo:
+				m.disks = v
+			}
+			m.mu.Unlock()
+		case <-ticker.C:
+			m.pingAndLog(ctx)
+		}
+	}
+}
+
+// pingAndLog sends one ping, logging the first failure and the recovery
+// rather than every failed attempt.
+func (m *Monitor) pingAndLog(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LogPanicWithStack("Heartbeat ping", r)
+		}
+	}()
+
+	problems := m.Problems()
+	if len(problems) > 0 {
+		logger.Debug("Heartbeat: reporting failure: %s", strings.Join(problems, "; "))
+	}
+
+	err := m.Ping(ctx, problems)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return
+	case err != nil && !m.failing:
+		logger.Warning("Heartbeat: ping failed: %v", err)
+		m.failing = true
+	case err != nil:
+		logger.Debug("Heartbeat: ping failed: %v", err)
+	case m.failing:
+		logger.Info("Heartbeat: ping succeeded again")
+		m.failing = false
+	}
+}
+
+// Problems returns the reasons the server should be reported as failing: a
+// critical data source that is degraded or unavailable, an array that is not
+// started, or array disks that are disabled, emulated or invalid.
+func (m *Monitor) Problems() []string {
+	var problems []string
+
+	if m.registry != nil {
+		for _, s := range m.registry.Snapshot() {
+			if s.State.Severity() == 0 || !slices.Contains(m.critical, s.Subsystem) {
+				continue
+			}
+			p := fmt.Sprintf("%s source %s", s.Subsystem, s.State)
+			if s.Reason != "" {
+				p += ": " + s.Reason
+			}
+			problems = append(problems, p)
+		}
+	}
+
+	m.mu.RLock()
+	array, disks := m.array, m.disks
+	m.mu.RUnlock()
+
+	if array != nil && array.State != "" && array.State != "Started" {
+		problems = append(problems, fmt.Sprintf("array is %s", array.State))
+	}
+	for _, d := range disks {
+		if slices.Contains(degradedDiskStates, d.Status) {
+			name := d.Name
+			if name == "" {
+				name = d.ID
+			}
+			problems = append(problems, fmt.Sprintf("disk %s is %s", name, d.Status))
+		}
+	}
+	return problems
+}
+
+// Ping reports success when problems is empty and failure otherwise.
+func (m *Monitor) Ping(ctx context.Context, problems []string) error {
+	msg := "OK"
+	if len(problems) > 0 {
+		msg = strings.Join(problems, "; ")
+		if len(msg) > maxMessageLen {
+			msg = msg[:maxMessageLen]
+		}
+	}
+
+	req, err := m.buildRequest(ctx, len(problems) == 0, msg)
+	if err != nil {
+		return err
+	}
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("push URL returned %s", resp.Status)
+	}
+	return nil
+}
+
+// buildRequest builds the provider-specific ping. healthchecks.io takes a
+// POST to the ping URL (or its /fail endpoint) with the message as the body;
+// Uptime Kuma takes a GET with status and msg query parameters.
+func (m *Monitor) buildRequest(ctx context.Context, ok bool, msg string) (*http.Request, error) {
+	u := *m.pushURL
+
+	if m.provider == ProviderUptimeKuma {
+		q := u.Query()
+		q.Set("status", "up")
+		if !ok {
+			q.Set("status", "down")
+		}
+		q.Set("msg", msg)
+		u.RawQuery = q.Encode()
+		return http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	}
+
+	if !ok {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/fail"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	return req, nil
+}
@@ -0,0 +1,113 @@
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/platform"
+)
+
+type recordedPing struct {
+	method, path, query, body string
+}
+
+func pingServer(t *testing.T) (*httptest.Server, *[]recordedPing) {
+	t.Helper()
+	var pings []recordedPing
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		pings = append(pings, recordedPing{r.Method, r.URL.Path, r.URL.RawQuery, string(b)})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &pings
+}
+
+func TestNewMonitorDetectsProvider(t *testing.T) {
+	tests := []struct {
+		cfg     domain.HeartbeatConfig
+		want    string
+		wantErr bool
+	}{
+		{cfg: domain.HeartbeatConfig{URL: "https://hc-ping.com/5f1c"}, want: ProviderHealthchecks},
+		{cfg: domain.HeartbeatConfig{URL: "http://kuma:3001/api/push/abc"}, want: ProviderUptimeKuma},
+		{cfg: domain.HeartbeatConfig{URL: "http://kuma/api/push/abc", Provider: "healthchecks"}, want: ProviderHealthchecks},
+		{cfg: domain.HeartbeatConfig{URL: "ftp://hc"}, wantErr: true},
+		{cfg: domain.HeartbeatConfig{URL: "https://hc", Provider: "pagerduty"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		m, err := NewMonitor(tt.cfg, nil, nil)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewMonitor(%+v) err = %v, wantErr %v", tt.cfg, err, tt.wantErr)
+			continue
+		}
+		if err == nil && m.provider != tt.want {
+			t.Errorf("NewMonitor(%+v) provider = %q, want %q", tt.cfg, m.provider, tt.want)
+		}
+	}
+}
+
+func TestProblems(t *testing.T) {
+	registry := platform.NewRegistry()
+	registry.Report("array", dto.SourceUnavailable, "mdcmd missing", errors.New("not found"))
+	registry.Report("docker", dto.SourceDegraded, "socket timeout", nil)
+
+	m, err := NewMonitor(domain.HeartbeatConfig{URL: "https://hc-ping.com/x", CriticalSources: []string{"array", "disk"}}, nil, registry)
+	if err != nil {
+		t.Fatalf("NewMonitor: %v", err)
+	}
+	m.array = &dto.ArrayStatus{State: "Stopped"}
+	m.disks = []dto.DiskInfo{{ID: "disk1", Name: "disk1", Status: "DISK_OK"}, {ID: "disk2", Name: "disk2", Status: "DISK_DSBL"}}
+
+	got := strings.Join(m.Problems(), "; ")
+	want := "array source unavailable: mdcmd missing; array is Stopped; disk disk2 is DISK_DSBL"
+	if got != want {
+		t.Errorf("Problems() = %q, want %q", got, want)
+	}
+}
+
+func TestPingHealthchecks(t *testing.T) {
+	srv, pings := pingServer(t)
+	m, err := NewMonitor(domain.HeartbeatConfig{URL: srv.URL + "/uuid"}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewMonitor: %v", err)
+	}
+
+	if err := m.Ping(context.Background(), nil); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if err := m.Ping(context.Background(), []string{"array is Stopped"}); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+
+	want := []recordedPing{
+		{method: http.MethodPost, path: "/uuid", body: "OK"},
+		{method: http.MethodPost, path: "/uuid/fail", body: "array is Stopped"},
+	}
+	if len(*pings) != 2 || (*pings)[0] != want[0] || (*pings)[1] != want[1] {
+		t.Errorf("pings = %+v, want %+v", *pings, want)
+	}
+}
+
+func TestPingUptimeKuma(t *testing.T) {
+	srv, pings := pingServer(t)
+	m, err := NewMonitor(domain.HeartbeatConfig{URL: srv.URL + "/api/push/token?ping="}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewMonitor: %v", err)
+	}
+
+	if err := m.Ping(context.Background(), []string{"disk disk2 is DISK_DSBL"}); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	got := (*pings)[0]
+	if got.method != http.MethodGet || got.path != "/api/push/token" ||
+		got.query != "msg=disk+disk2+is+DISK_DSBL&ping=&status=down" {
+		t.Errorf("ping = %+v", got)
+	}
+}
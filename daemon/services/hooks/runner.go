@@ -0,0 +1,116 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/scripts"
+)
+
+// Runner executes the actions of triggered webhooks.
+type Runner struct {
+	store    *Store
+	scripts  *scripts.Store
+	auditLog *audit.Log
+
+	// execute runs an action; tests replace it.
+	execute func(hook dto.InboundHook, env map[string]string) error
+}
+
+// NewRunner creates a runner that records results in store and runs script
+// actions from scriptStore.
+func NewRunner(store *Store, scriptStore *scripts.Store) *Runner {
+	r := &Runner{store: store, scripts: scriptStore}
+	r.execute = r.run
+	return r
+}
+
+// SetAuditLog sets the audit log every webhook action is recorded in, which
+// also refreshes the collectors of the containers and VMs it changed.
+func (r *Runner) SetAuditLog(l *audit.Log) {
+	r.auditLog = l
+}
+
+// Trigger runs hook's action in the background, so slow actions such as a
+// container restart do not time out the sender, and records the result.
+// Script actions get WEBHOOK_NAME, WEBHOOK_EVENT and WEBHOOK_DELIVERY in
+// their environment.
+func (r *Runner) Trigger(hook dto.InboundHook, event, delivery string) {
+	env := map[string]string{"WEBHOOK_NAME": hook.Name}
+	if event != "" {
+		env["WEBHOOK_EVENT"] = event
+	}
+	if delivery != "" {
+		env["WEBHOOK_DELIVERY"] = delivery
+	}
+
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.LogPanicWithStack("Inbound webhook "+hook.Name, rec)
+			}
+		}()
+		err := r.execute(hook, env)
+		r.store.record(hook.Name, time.Now(), err)
+		r.audit(hook, err)
+		if err != nil {
+			logger.Warning("Hooks: '%s' failed to %s %s: %v", hook.Name, hook.Action, hook.Target, err)
+			return
+		}
+		logger.Info("Hooks: '%s' ran %s %s", hook.Name, hook.Action, hook.Target)
+	}()
+}
+
+// audit records the outcome of hook's action in the audit log.
+func (r *Runner) audit(hook dto.InboundHook, err error) {
+	entry := dto.AuditEntry{
+		Source: dto.AuditSourceWebhook,
+		Client: hook.Name,
+		Action: hook.Action,
+		Target: hook.Target,
+		Result: dto.AuditResultSuccess,
+	}
+	if err != nil {
+		entry.Result = dto.AuditResultFailure
+		entry.Detail = err.Error()
+	}
+	r.auditLog.Record(entry)
+}
+
+// run executes an action synchronously.
+func (r *Runner) run(hook dto.InboundHook, env map[string]string) error {
+	switch hook.Action {
+	case dto.HookActionScript:
+		if r.scripts == nil {
+			return errors.New("script registry not initialized")
+		}
+		req := dto.ScriptExecuteRequest{Env: env}
+		if len(hook.Args) > 0 {
+			req.Args = hook.Args
+		}
+		_, err := r.scripts.Execute(hook.Target, req)
+		return err
+	case dto.HookActionContainerStart, dto.HookActionContainerStop, dto.HookActionContainerRestart:
+		dc := controllers.NewDockerController()
+		defer func() { _ = dc.Close() }()
+		switch hook.Action {
+		case dto.HookActionContainerStart:
+			return dc.Start(hook.Target)
+		case dto.HookActionContainerStop:
+			return dc.Stop(hook.Target)
+		default:
+			return dc.Restart(hook.Target)
+		}
+	case dto.HookActionVMStart:
+		return controllers.NewVMController().Start(hook.Target)
+	case dto.HookActionVMStop:
+		return controllers.NewVMController().Stop(hook.Target)
+	default:
+		return fmt.Errorf("unknown action %q", hook.Action)
+	}
+}
@@ -0,0 +1,351 @@
+// Package hooks receives inbound webhooks at /api/v1/hooks/{name} and maps
+// each one to a configured server action, such as running a registered script
+// or restarting a container. Callers prove knowledge of the hook's secret
+// instead of holding full API access.
+package hooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DefaultConfigDir is the default directory for the webhook list.
+	DefaultConfigDir = "/boot/config/plugins/unraid-management-agent"
+
+	// HooksConfigFile is the filename of the webhook list.
+	HooksConfigFile = "inbound_hooks.json"
+
+	// MaxHooks is the maximum number of inbound webhooks.
+	MaxHooks = 50
+
+	// MinSecretLength and maxSecretLength bound user-supplied secrets.
+	MinSecretLength = 16
+	maxSecretLength = 256
+
+	maxDescriptionLength = 500
+	maxEvents            = 16
+	maxEventLength       = 64
+	maxArgs              = 32
+	maxArgLength         = 1024
+)
+
+var (
+	// ErrNotFound is returned for unknown webhook names.
+	ErrNotFound = errors.New("webhook not found")
+
+	// ErrExists is returned when creating a webhook whose name is taken.
+	ErrExists = errors.New("webhook already exists")
+
+	nameRegex   = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	scriptRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+	validActions = []string{
+		dto.HookActionScript,
+		dto.HookActionContainerStart,
+		dto.HookActionContainerStop,
+		dto.HookActionContainerRestart,
+		dto.HookActionVMStart,
+		dto.HookActionVMStop,
+	}
+)
+
+// stats records deliveries since the agent started. They are kept in memory
+// so a busy hook does not rewrite the flash drive.
+type stats struct {
+	count  int
+	last   time.Time
+	result string
+}
+
+// Store manages persistent storage of inbound webhooks in a JSON file.
+type Store struct {
+	mu       sync.RWMutex
+	hooks    []dto.InboundHook
+	stats    map[string]stats
+	filePath string
+}
+
+// NewStore creates a new webhook store. If configDir is empty, DefaultConfigDir is used.
+func NewStore(configDir string) *Store {
+	if configDir == "" {
+		configDir = DefaultConfigDir
+	}
+	return &Store{
+		filePath: filepath.Join(configDir, HooksConfigFile),
+		hooks:    make([]dto.InboundHook, 0),
+		stats:    make(map[string]stats),
+	}
+}
+
+// Load reads the webhook list from disk. A missing file starts an empty list.
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading inbound webhooks: %w", err)
+	}
+
+	var config dto.InboundHooksConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("parsing inbound webhooks: %w", err)
+	}
+
+	s.hooks = config.Hooks
+	if s.hooks == nil {
+		s.hooks = make([]dto.InboundHook, 0)
+	}
+
+	logger.Info("Loaded %d inbound webhooks from %s", len(s.hooks), s.filePath)
+	return nil
+}
+
+// save writes the webhook list to disk. Caller must hold the write lock.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(dto.InboundHooksConfig{Hooks: s.hooks}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling inbound webhooks: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	// 0600: the file holds the webhook secrets.
+	if err := os.WriteFile(s.filePath, data, 0o600); err != nil {
+		return fmt.Errorf("writing inbound webhooks: %w", err)
+	}
+	return nil
+}
+
+// List returns all webhooks with their delivery statistics. Secrets are
+// included; callers must redact them before returning them to clients.
+func (s *Store) List() []dto.InboundHookInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]dto.InboundHookInfo, len(s.hooks))
+	for i, hook := range s.hooks {
+		result[i] = s.info(hook)
+	}
+	return result
+}
+
+// Get returns a webhook by name, including its secret.
+func (s *Store) Get(name string) (*dto.InboundHookInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, hook := range s.hooks {
+		if hook.Name == name {
+			info := s.info(hook)
+			return &info, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// info adds the statistics to hook. Caller must hold the lock.
+func (s *Store) info(hook dto.InboundHook) dto.InboundHookInfo {
+	hook.Args = slices.Clone(hook.Args)
+	hook.Events = slices.Clone(hook.Events)
+	info := dto.InboundHookInfo{InboundHook: hook}
+	if st, ok := s.stats[hook.Name]; ok {
+		info.TriggerCount = st.count
+		last := st.last
+		info.LastTriggeredAt = &last
+		info.LastResult = st.result
+	}
+	return info
+}
+
+// Create validates and adds a webhook and returns its secret, which is
+// generated when hook.Secret is empty.
+func (s *Store) Create(hook dto.InboundHook) (string, error) {
+	if hook.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return "", err
+		}
+		hook.Secret = secret
+	}
+	if err := normalizeHook(&hook); err != nil {
+		return "", err
+	}
+	hook.CreatedAt = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.hooks {
+		if existing.Name == hook.Name {
+			return "", fmt.Errorf("%w: %s", ErrExists, hook.Name)
+		}
+	}
+	if len(s.hooks) >= MaxHooks {
+		return "", fmt.Errorf("maximum of %d inbound webhooks reached", MaxHooks)
+	}
+
+	s.hooks = append(s.hooks, hook)
+	if err := s.save(); err != nil {
+		s.hooks = s.hooks[:len(s.hooks)-1]
+		return "", fmt.Errorf("saving after create: %w", err)
+	}
+
+	logger.Info("Created inbound webhook '%s' (%s %s)", hook.Name, hook.Action, hook.Target)
+	return hook.Secret, nil
+}
+
+// Update validates and replaces a webhook. An empty or redacted secret keeps
+// the stored one, so clients can round-trip a webhook read from the API.
+func (s *Store) Update(hook dto.InboundHook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.hooks {
+		if s.hooks[i].Name != hook.Name {
+			continue
+		}
+		old := s.hooks[i]
+		if hook.Secret == "" || hook.Secret == dto.RedactedSecret {
+			hook.Secret = old.Secret
+		}
+		if err := normalizeHook(&hook); err != nil {
+			return err
+		}
+		hook.CreatedAt = old.CreatedAt
+		s.hooks[i] = hook
+
+		if err := s.save(); err != nil {
+			s.hooks[i] = old
+			return fmt.Errorf("saving after update: %w", err)
+		}
+
+		logger.Info("Updated inbound webhook '%s'", hook.Name)
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrNotFound, hook.Name)
+}
+
+// Delete removes a webhook by name.
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.hooks {
+		if s.hooks[i].Name == name {
+			old := s.hooks
+			s.hooks = append(append([]dto.InboundHook{}, s.hooks[:i]...), s.hooks[i+1:]...)
+			if err := s.save(); err != nil {
+				s.hooks = old
+				return fmt.Errorf("saving after delete: %w", err)
+			}
+			delete(s.stats, name)
+			logger.Info("Deleted inbound webhook '%s'", name)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// record stores the outcome of a delivery.
+func (s *Store) record(name string, at time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.stats[name]
+	st.count++
+	st.last = at
+	st.result = "ok"
+	if err != nil {
+		st.result = err.Error()
+	}
+	s.stats[name] = st
+}
+
+// normalizeHook validates a webhook and trims its fields.
+func normalizeHook(hook *dto.InboundHook) error {
+	hook.Name = strings.ToLower(strings.TrimSpace(hook.Name))
+	if !nameRegex.MatchString(hook.Name) {
+		return fmt.Errorf("invalid webhook name %q: use 1-64 lowercase letters, digits, '_' or '-'", hook.Name)
+	}
+	if len(hook.Secret) < MinSecretLength || len(hook.Secret) > maxSecretLength {
+		return fmt.Errorf("secret must be %d-%d characters", MinSecretLength, maxSecretLength)
+	}
+	if err := lib.ValidateMaxLength(hook.Description, "description", maxDescriptionLength); err != nil {
+		return err
+	}
+
+	hook.Action = strings.TrimSpace(hook.Action)
+	hook.Target = strings.TrimSpace(hook.Target)
+	switch hook.Action {
+	case dto.HookActionScript:
+		if !scriptRegex.MatchString(hook.Target) {
+			return fmt.Errorf("invalid script name %q", hook.Target)
+		}
+	case dto.HookActionContainerStart, dto.HookActionContainerStop, dto.HookActionContainerRestart:
+		if err := lib.ValidateContainerRef(hook.Target); err != nil {
+			return err
+		}
+	case dto.HookActionVMStart, dto.HookActionVMStop:
+		if err := lib.ValidateVMName(hook.Target); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid action %q: must be one of %s", hook.Action, strings.Join(validActions, ", "))
+	}
+
+	if len(hook.Args) > 0 && hook.Action != dto.HookActionScript {
+		return errors.New("args are only supported for the script action")
+	}
+	if len(hook.Args) > maxArgs {
+		return fmt.Errorf("at most %d arguments are allowed", maxArgs)
+	}
+	for _, arg := range hook.Args {
+		if len(arg) > maxArgLength || strings.ContainsRune(arg, 0) {
+			return fmt.Errorf("arguments must be at most %d characters without null bytes", maxArgLength)
+		}
+	}
+
+	if len(hook.Events) > maxEvents {
+		return fmt.Errorf("at most %d events are allowed", maxEvents)
+	}
+	events := make([]string, 0, len(hook.Events))
+	for _, event := range hook.Events {
+		event = strings.TrimSpace(event)
+		if event == "" || len(event) > maxEventLength {
+			return fmt.Errorf("events must be 1-%d characters", maxEventLength)
+		}
+		events = append(events, event)
+	}
+	hook.Events = events
+	return nil
+}
+
+// generateSecret returns a random 256-bit secret as hex.
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
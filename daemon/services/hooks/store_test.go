@@ -0,0 +1,141 @@
+package hooks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+)
+
+func TestStoreCreateUpdateDeletePersists(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	secret, err := store.Create(dto.InboundHook{Name: " Deploy ", Action: dto.HookActionContainerRestart, Target: "myapp", Enabled: true})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(secret) != 64 {
+		t.Errorf("generated secret = %q, want 64 hex characters", secret)
+	}
+	if _, err := store.Create(dto.InboundHook{Name: "deploy", Action: dto.HookActionVMStart, Target: "win11", Secret: secret}); !errors.Is(err, ErrExists) {
+		t.Errorf("duplicate create err = %v, want ErrExists", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, HooksConfigFile))
+	if err != nil {
+		t.Fatalf("config not written: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("config mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A redacted secret keeps the stored one.
+	update := dto.InboundHook{Name: "deploy", Action: dto.HookActionScript, Target: "pull-image", Args: []string{"--force"}, Secret: dto.RedactedSecret}
+	if err := store.Update(update); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	reloaded := NewStore(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got, err := reloaded.Get("deploy")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Secret != secret || got.Action != dto.HookActionScript || got.CreatedAt.IsZero() {
+		t.Errorf("reloaded hook = %+v", got)
+	}
+
+	if err := reloaded.Delete("deploy"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := reloaded.Delete("deploy"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second delete err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestNormalizeHookRejectsInvalid(t *testing.T) {
+	valid := dto.InboundHook{Name: "ok", Secret: "0123456789abcdef", Action: dto.HookActionContainerStart, Target: "plex"}
+	tests := []struct {
+		name   string
+		mutate func(*dto.InboundHook)
+	}{
+		{"bad name", func(h *dto.InboundHook) { h.Name = "a/b" }},
+		{"short secret", func(h *dto.InboundHook) { h.Secret = "short" }},
+		{"unknown action", func(h *dto.InboundHook) { h.Action = "array_stop" }},
+		{"bad container", func(h *dto.InboundHook) { h.Target = "../etc" }},
+		{"bad script", func(h *dto.InboundHook) { h.Action, h.Target = dto.HookActionScript, "a b" }},
+		{"args on container", func(h *dto.InboundHook) { h.Args = []string{"x"} }},
+		{"empty event", func(h *dto.InboundHook) { h.Events = []string{" "} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := valid
+			tt.mutate(&hook)
+			if err := normalizeHook(&hook); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+	if err := normalizeHook(&valid); err != nil {
+		t.Errorf("valid hook: %v", err)
+	}
+}
+
+func TestRunnerRecordsResult(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if _, err := store.Create(dto.InboundHook{Name: "sync", Action: dto.HookActionScript, Target: "sync", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	runner := NewRunner(store, nil)
+	gotEnv := make(chan map[string]string, 1)
+	runner.execute = func(_ dto.InboundHook, env map[string]string) error {
+		gotEnv <- env
+		return errors.New("boom")
+	}
+
+	hook, _ := store.Get("sync")
+	runner.Trigger(hook.InboundHook, "push", "abc-123")
+	env := <-gotEnv
+	if env["WEBHOOK_NAME"] != "sync" || env["WEBHOOK_EVENT"] != "push" || env["WEBHOOK_DELIVERY"] != "abc-123" {
+		t.Errorf("env = %v", env)
+	}
+
+	// The result is recorded after execute returns.
+	for range 100 {
+		if got, _ := store.Get("sync"); got.TriggerCount == 1 {
+			if got.LastResult != "boom" || got.LastTriggeredAt == nil {
+				t.Errorf("stats = %+v", got)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("delivery was not recorded")
+}
+
+func TestRunnerAuditsAction(t *testing.T) {
+	store := NewStore(t.TempDir())
+	runner := NewRunner(store, nil)
+	auditLog := audit.NewLog("")
+	runner.SetAuditLog(auditLog)
+	recorded := make(chan dto.AuditEntry, 1)
+	auditLog.OnRecord(func(e dto.AuditEntry) { recorded <- e })
+	runner.execute = func(dto.InboundHook, map[string]string) error { return nil }
+
+	runner.Trigger(dto.InboundHook{Name: "redeploy", Action: dto.HookActionContainerRestart, Target: "plex"}, "", "")
+	select {
+	case e := <-recorded:
+		if e.Source != dto.AuditSourceWebhook || e.Client != "redeploy" || e.Action != "container_restart" ||
+			e.Target != "plex" || e.Result != dto.AuditResultSuccess {
+			t.Errorf("audit entry = %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("action was not audited")
+	}
+}
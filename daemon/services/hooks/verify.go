@@ -0,0 +1,84 @@
+package hooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const (
+	// SignatureHeader carries a GitHub-style HMAC-SHA256 of the body,
+	// "sha256=<hex>", keyed with the hook secret.
+	SignatureHeader = "X-Hub-Signature-256"
+
+	// SecretHeader carries the hook secret itself, for senders that can add
+	// a custom header but cannot sign.
+	SecretHeader = "X-Webhook-Secret"
+)
+
+// Verify reports whether a delivery proves knowledge of secret, by any of:
+// a valid X-Hub-Signature-256 body signature (GitHub, Gitea), the secret in
+// X-Webhook-Secret, or the secret as the HTTP basic auth password (Sonarr,
+// Radarr and other *arr webhook connections).
+func Verify(secret string, r *http.Request, body []byte) bool {
+	if secret == "" {
+		return false
+	}
+	if sig := r.Header.Get(SignatureHeader); sig != "" {
+		got, err := hex.DecodeString(strings.TrimPrefix(sig, "sha256="))
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return hmac.Equal(got, mac.Sum(nil))
+	}
+	if got := r.Header.Get(SecretHeader); got != "" {
+		return subtle.ConstantTimeCompare([]byte(got), []byte(secret)) == 1
+	}
+	if _, password, ok := r.BasicAuth(); ok {
+		return subtle.ConstantTimeCompare([]byte(password), []byte(secret)) == 1
+	}
+	return false
+}
+
+// Event returns the sender's event name: the X-GitHub-Event, X-Gitea-Event
+// or X-Gitlab-Event header, or the eventType field of an *arr payload.
+func Event(r *http.Request, body []byte) string {
+	for _, h := range []string{"X-GitHub-Event", "X-Gitea-Event", "X-Gitlab-Event"} {
+		if v := r.Header.Get(h); v != "" {
+			return v
+		}
+	}
+	var payload struct {
+		EventType string `json:"eventType"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		return payload.EventType
+	}
+	return ""
+}
+
+// IsTest reports whether event is a connectivity test that should be
+// acknowledged without running the action: GitHub's ping when a webhook is
+// added, or the *arr "Test" button.
+func IsTest(event string) bool {
+	return strings.EqualFold(event, "ping") || strings.EqualFold(event, "Test")
+}
+
+// Accepts reports whether hook runs for event.
+func Accepts(events []string, event string) bool {
+	if len(events) == 0 {
+		return true
+	}
+	for _, e := range events {
+		if strings.EqualFold(e, event) {
+			return true
+		}
+	}
+	return false
+}
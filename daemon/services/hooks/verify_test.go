@@ -0,0 +1,73 @@
+package hooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testSecret = "0123456789abcdef0123"
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"action":"completed"}`)
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name  string
+		setup func(h map[string]string)
+		basic string
+		want  bool
+	}{
+		{"github signature", func(h map[string]string) { h[SignatureHeader] = signature }, "", true},
+		{"wrong signature", func(h map[string]string) { h[SignatureHeader] = "sha256=00" }, "", false},
+		{"signature wins over secret header", func(h map[string]string) {
+			h[SignatureHeader] = "sha256=zz"
+			h[SecretHeader] = testSecret
+		}, "", false},
+		{"secret header", func(h map[string]string) { h[SecretHeader] = testSecret }, "", true},
+		{"wrong secret header", func(h map[string]string) { h[SecretHeader] = "nope" }, "", false},
+		{"basic auth password", func(map[string]string) {}, testSecret, true},
+		{"wrong basic auth", func(map[string]string) {}, "nope", false},
+		{"no credentials", func(map[string]string) {}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/v1/hooks/x", strings.NewReader(string(body)))
+			headers := map[string]string{}
+			tt.setup(headers)
+			for k, v := range headers {
+				r.Header.Set(k, v)
+			}
+			if tt.basic != "" {
+				r.SetBasicAuth("sonarr", tt.basic)
+			}
+			if got := Verify(testSecret, r, body); got != tt.want {
+				t.Errorf("Verify = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvent(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("X-GitHub-Event", "workflow_run")
+	if got := Event(r, nil); got != "workflow_run" {
+		t.Errorf("github event = %q", got)
+	}
+
+	r = httptest.NewRequest("POST", "/", nil)
+	if got := Event(r, []byte(`{"eventType":"Download","series":{}}`)); got != "Download" {
+		t.Errorf("sonarr event = %q", got)
+	}
+	if !IsTest("Test") || !IsTest("ping") || IsTest("Download") {
+		t.Error("IsTest mismatch")
+	}
+	if !Accepts(nil, "anything") || !Accepts([]string{"download"}, "Download") || Accepts([]string{"Grab"}, "Download") {
+		t.Error("Accepts mismatch")
+	}
+}
@@ -0,0 +1,281 @@
+// Package influxdb pushes the agent's metrics to InfluxDB in line protocol,
+// as an alternative to MQTT for users with an existing TIG stack.
+package influxdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	prommodel "github.com/prometheus/client_model/go"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// defaultInterval is used when no batch interval is configured.
+	defaultInterval = 30 * time.Second
+
+	// minInterval matches the lower bound accepted in the config file.
+	minInterval = 5 * time.Second
+
+	// writeTimeout bounds a single batch write.
+	writeTimeout = 10 * time.Second
+
+	// metricPrefix selects the collector metrics; Go runtime and process
+	// metrics from the Prometheus registry are not exported.
+	metricPrefix = "unraid_"
+
+	// maxErrorBodyBytes caps how much of an error response is reported.
+	maxErrorBodyBytes = 512
+)
+
+// Gatherer returns the current metric snapshot. It is satisfied by the API
+// server, which fills the Prometheus registry from its caches.
+type Gatherer interface {
+	GatherMetrics() ([]*prommodel.MetricFamily, error)
+}
+
+// Exporter periodically writes every collector metric to InfluxDB as one
+// line-protocol batch.
+type Exporter struct {
+	cfg        domain.InfluxDBConfig
+	hostname   string
+	gatherer   Gatherer
+	httpClient *http.Client
+	writeURL   string
+	interval   time.Duration
+
+	// failing suppresses repeated warnings while InfluxDB is unreachable.
+	failing bool
+}
+
+// NewExporter validates cfg and creates an exporter that tags every point
+// with hostname.
+func NewExporter(cfg domain.InfluxDBConfig, hostname string, gatherer Gatherer) (*Exporter, error) {
+	writeURL, err := buildWriteURL(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := time.Duration(cfg.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	interval = max(interval, minInterval)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // G402: opt-in, warned at startup
+	}
+
+	return &Exporter{
+		cfg:        cfg,
+		hostname:   hostname,
+		gatherer:   gatherer,
+		httpClient: &http.Client{Timeout: writeTimeout, Transport: transport},
+		writeURL:   writeURL,
+		interval:   interval,
+	}, nil
+}
+
+// buildWriteURL returns the write endpoint for the configured API version.
+func buildWriteURL(cfg domain.InfluxDBConfig) (string, error) {
+	base, err := url.Parse(strings.TrimSpace(cfg.URL))
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return "", fmt.Errorf("influxdb url must be an http(s) URL, got %q", cfg.URL)
+	}
+
+	q := url.Values{}
+	q.Set("precision", "s")
+	switch cfg.Version {
+	case 1:
+		if cfg.Database == "" {
+			return "", errors.New("influxdb database is required for version 1")
+		}
+		base = base.JoinPath("write")
+		q.Set("db", cfg.Database)
+		if cfg.RetentionPolicy != "" {
+			q.Set("rp", cfg.RetentionPolicy)
+		}
+	case 2:
+		if cfg.Org == "" || cfg.Bucket == "" {
+			return "", errors.New("influxdb org and bucket are required for version 2")
+		}
+		base = base.JoinPath("api", "v2", "write")
+		q.Set("org", cfg.Org)
+		q.Set("bucket", cfg.Bucket)
+	default:
+		return "", fmt.Errorf("influxdb version must be 1 or 2, got %d", cfg.Version)
+	}
+	base.RawQuery = q.Encode()
+	return base.String(), nil
+}
+
+// Start pushes a batch every interval until ctx is cancelled.
+func (e *Exporter) Start(ctx context.Context) {
+	logger.Success("InfluxDB exporter pushing to %s every %s", e.cfg.URL, e.interval)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("InfluxDB exporter stopped")
+			return
+		case <-ticker.C:
+			e.pushAndLog(ctx)
+		}
+	}
+}
+
+// pushAndLog pushes one batch, logging the first failure and the recovery
+// rather than every failed attempt.
+func (e *Exporter) pushAndLog(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LogPanicWithStack("InfluxDB push", r)
+		}
+	}()
+
+	err := e.Push(ctx)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return
+	case err != nil && !e.failing:
+		logger.Warning("InfluxDB: push failed: %v", err)
+		e.failing = true
+	case err != nil:
+		logger.Debug("InfluxDB: push failed: %v", err)
+	case e.failing:
+		logger.Info("InfluxDB: push succeeded again")
+		e.failing = false
+	}
+}
+
+// Push gathers the current metrics and writes them as a single batch.
+func (e *Exporter) Push(ctx context.Context) error {
+	families, err := e.gatherer.GatherMetrics()
+	if err != nil {
+		return fmt.Errorf("gather metrics: %w", err)
+	}
+	body := Encode(families, e.hostname, time.Now())
+	if len(body) == 0 {
+		return nil
+	}
+	return e.write(ctx, body)
+}
+
+// write sends a line-protocol payload to the write endpoint.
+func (e *Exporter) write(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.writeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	switch {
+	case e.cfg.Version == 2 && e.cfg.Token != "":
+		req.Header.Set("Authorization", "Token "+e.cfg.Token)
+	case e.cfg.Version == 1 && e.cfg.Username != "":
+		req.SetBasicAuth(e.cfg.Username, e.cfg.Password)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("influxdb returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// Encode renders the collector metric families as line protocol. Each metric
+// becomes a point named after the metric with its labels (plus host) as tags
+// and a single "value" field, all stamped with ts.
+func Encode(families []*prommodel.MetricFamily, hostname string, ts time.Time) []byte {
+	var buf bytes.Buffer
+	stamp := strconv.FormatInt(ts.Unix(), 10)
+
+	for _, mf := range families {
+		name := mf.GetName()
+		if !strings.HasPrefix(name, metricPrefix) {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			value, ok := metricValue(mf.GetType(), m)
+			if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+
+			tags := make([][2]string, 0, len(m.GetLabel())+1)
+			if hostname != "" {
+				tags = append(tags, [2]string{"host", hostname})
+			}
+			for _, l := range m.GetLabel() {
+				if l.GetValue() != "" && l.GetName() != "host" {
+					tags = append(tags, [2]string{l.GetName(), l.GetValue()})
+				}
+			}
+			// InfluxDB performs best with tags sorted by key
+			slices.SortFunc(tags, func(a, b [2]string) int { return strings.Compare(a[0], b[0]) })
+
+			buf.WriteString(escapeMeasurement(name))
+			for _, t := range tags {
+				buf.WriteByte(',')
+				buf.WriteString(escapeTag(t[0]))
+				buf.WriteByte('=')
+				buf.WriteString(escapeTag(t[1]))
+			}
+			buf.WriteString(" value=")
+			buf.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+			buf.WriteByte(' ')
+			buf.WriteString(stamp)
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
+
+// metricValue extracts the sample value of a gauge, counter or untyped metric.
+func metricValue(t prommodel.MetricType, m *prommodel.Metric) (float64, bool) {
+	switch t {
+	case prommodel.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), m.GetGauge() != nil
+	case prommodel.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), m.GetCounter() != nil
+	case prommodel.MetricType_UNTYPED:
+		return m.GetUntyped().GetValue(), m.GetUntyped() != nil
+	default:
+		return 0, false
+	}
+}
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\n`)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+)
+
+func escapeMeasurement(s string) string {
+	return measurementEscaper.Replace(s)
+}
+
+func escapeTag(s string) string {
+	return tagEscaper.Replace(strings.TrimSuffix(s, `\`))
+}
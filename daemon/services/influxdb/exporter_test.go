@@ -0,0 +1,134 @@
+package influxdb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prommodel "github.com/prometheus/client_model/go"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+)
+
+type fakeGatherer struct {
+	families []*prommodel.MetricFamily
+}
+
+func (g fakeGatherer) GatherMetrics() ([]*prommodel.MetricFamily, error) {
+	return g.families, nil
+}
+
+// testFamilies builds a registry with one collector gauge vector and one
+// non-collector metric that must be skipped.
+func testFamilies(t *testing.T) []*prommodel.MetricFamily {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	temp := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "unraid_disk_temperature_celsius", Help: "h"},
+		[]string{"disk", "device", "type"})
+	temp.WithLabelValues("disk 1", "sdb", "").Set(34)
+	other := prometheus.NewGauge(prometheus.GaugeOpts{Name: "go_goroutines_test", Help: "h"})
+	other.Set(5)
+	reg.MustRegister(temp, other)
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	return families
+}
+
+func TestEncode(t *testing.T) {
+	got := string(Encode(testFamilies(t), "tower", time.Unix(1700000000, 0)))
+	want := "unraid_disk_temperature_celsius,device=sdb,disk=disk\\ 1,host=tower value=34 1700000000\n"
+	if got != want {
+		t.Errorf("Encode() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildWriteURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     domain.InfluxDBConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "v2",
+			cfg:  domain.InfluxDBConfig{URL: "http://influx:8086", Version: 2, Org: "home", Bucket: "unraid"},
+			want: "http://influx:8086/api/v2/write?bucket=unraid&org=home&precision=s",
+		},
+		{
+			name: "v1 with retention policy",
+			cfg:  domain.InfluxDBConfig{URL: "https://influx/", Version: 1, Database: "telegraf", RetentionPolicy: "30d"},
+			want: "https://influx/write?db=telegraf&precision=s&rp=30d",
+		},
+		{name: "v2 missing bucket", cfg: domain.InfluxDBConfig{URL: "http://influx", Version: 2, Org: "home"}, wantErr: true},
+		{name: "v1 missing database", cfg: domain.InfluxDBConfig{URL: "http://influx", Version: 1}, wantErr: true},
+		{name: "bad scheme", cfg: domain.InfluxDBConfig{URL: "udp://influx", Version: 1, Database: "db"}, wantErr: true},
+		{name: "bad version", cfg: domain.InfluxDBConfig{URL: "http://influx", Version: 3}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildWriteURL(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPushWritesBatch(t *testing.T) {
+	var auth, path, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	cfg := domain.InfluxDBConfig{URL: srv.URL, Version: 2, Org: "home", Bucket: "unraid", Token: "secret"}
+	e, err := NewExporter(cfg, "tower", fakeGatherer{families: testFamilies(t)})
+	if err != nil {
+		t.Fatalf("NewExporter: %v", err)
+	}
+	if err := e.Push(context.Background()); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if path != "/api/v2/write" || auth != "Token secret" {
+		t.Errorf("path/auth = %q/%q", path, auth)
+	}
+	if !strings.HasPrefix(body, "unraid_disk_temperature_celsius,") {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestPushReportsServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "admin" || pass != "pw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, `{"error":"database not found"}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	cfg := domain.InfluxDBConfig{URL: srv.URL, Version: 1, Database: "missing", Username: "admin", Password: "pw"}
+	e, err := NewExporter(cfg, "tower", fakeGatherer{families: testFamilies(t)})
+	if err != nil {
+		t.Fatalf("NewExporter: %v", err)
+	}
+	err = e.Push(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "database not found") {
+		t.Errorf("Push() error = %v", err)
+	}
+}
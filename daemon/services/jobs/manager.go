@@ -0,0 +1,275 @@
+// Package jobs runs long operations in the background and tracks them as
+// jobs that clients poll, cancel and follow over WebSocket and MQTT.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// maxRunningJobs limits how many jobs run at once; later jobs queue.
+	maxRunningJobs = 4
+
+	// maxLogLines caps the log lines kept per job.
+	maxLogLines = 100
+
+	// jobRetention is how long finished jobs stay available, and maxKeptJobs
+	// caps how many are kept.
+	jobRetention = time.Hour
+	maxKeptJobs  = 50
+)
+
+var (
+	// ErrJobNotFound is returned for unknown or expired job IDs.
+	ErrJobNotFound = errors.New("job not found")
+
+	// ErrCancelled is the cancellation cause of a job cancelled through
+	// Cancel. Jobs check it with context.Cause to tell a user cancellation,
+	// which should stop the underlying operation, from the agent stopping,
+	// which should leave it running.
+	ErrCancelled = errors.New("job cancelled")
+)
+
+// Func is the work of a job. It reports progress and log lines through p
+// and returns the job result.
+type Func func(ctx context.Context, p *Progress) (any, error)
+
+// Manager runs jobs with limited concurrency and keeps their state.
+type Manager struct {
+	mu    sync.Mutex
+	jobs  map[string]*job
+	slots chan struct{}
+	hub   *domain.EventBus
+}
+
+// job is a tracked job. status is guarded by Manager.mu.
+type job struct {
+	status dto.Job
+	cancel context.CancelCauseFunc
+}
+
+// NewManager creates a job manager that publishes job updates on hub's
+// constants.TopicJobUpdate. hub may be nil.
+func NewManager(hub *domain.EventBus) *Manager {
+	return &Manager{
+		jobs:  make(map[string]*job),
+		slots: make(chan struct{}, maxRunningJobs),
+		hub:   hub,
+	}
+}
+
+// Submit queues fn as a job of the given kind and returns it immediately.
+// The job is cancelled when parent is. Jobs keep the correlation ID of parent
+// or use their job ID as one.
+func (m *Manager) Submit(parent context.Context, kind, target string, fn Func) dto.Job {
+	ctx, cancel := context.WithCancelCause(parent)
+	now := time.Now()
+	j := &job{
+		status: dto.Job{
+			ID:        newJobID(),
+			Kind:      kind,
+			Target:    target,
+			State:     dto.JobQueued,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		cancel: cancel,
+	}
+
+	m.mu.Lock()
+	m.prune(now)
+	m.jobs[j.status.ID] = j
+	status := j.snapshot()
+	m.mu.Unlock()
+
+	if lib.CorrelationIDFromContext(ctx) == "" {
+		ctx = lib.ContextWithCorrelationID(ctx, status.ID)
+	}
+	logger.InfoContext(ctx, "Jobs: Queued %s job %s (target %q)", kind, status.ID, target)
+	m.publish(status)
+	go m.run(ctx, j, fn)
+	return status
+}
+
+// Get returns the job with id.
+func (m *Manager) Get(id string) (dto.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return dto.Job{}, ErrJobNotFound
+	}
+	return j.snapshot(), nil
+}
+
+// List returns all tracked jobs, newest first, without their logs and
+// results.
+func (m *Manager) List() []dto.Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prune(time.Now())
+	jobs := make([]dto.Job, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		status := j.status
+		status.Logs = nil
+		status.Result = nil
+		jobs = append(jobs, status)
+	}
+	slices.SortFunc(jobs, func(x, y dto.Job) int { return y.CreatedAt.Compare(x.CreatedAt) })
+	return jobs
+}
+
+// Cancel cancels a queued or running job and returns its state. Cancelling
+// a finished job has no effect.
+func (m *Manager) Cancel(id string) (dto.Job, error) {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	m.mu.Unlock()
+	if !ok {
+		return dto.Job{}, ErrJobNotFound
+	}
+	j.cancel(ErrCancelled)
+	return m.Get(id)
+}
+
+// run waits for a slot, runs fn and records the outcome.
+func (m *Manager) run(ctx context.Context, j *job, fn Func) {
+	defer j.cancel(nil)
+
+	select {
+	case m.slots <- struct{}{}:
+		defer func() { <-m.slots }()
+	case <-ctx.Done():
+		m.finish(ctx, j, nil, context.Cause(ctx))
+		return
+	}
+
+	m.update(j, func(s *dto.Job) {
+		started := time.Now()
+		s.State = dto.JobRunning
+		s.StartedAt = &started
+	})
+
+	result, err := fn(ctx, &Progress{m: m, j: j})
+	if err == nil && ctx.Err() != nil {
+		err = context.Cause(ctx)
+	}
+	m.finish(ctx, j, result, err)
+}
+
+// finish records the final state of j.
+func (m *Manager) finish(ctx context.Context, j *job, result any, err error) {
+	m.update(j, func(s *dto.Job) {
+		finished := time.Now()
+		s.FinishedAt = &finished
+		s.Result = result
+		switch {
+		case errors.Is(err, ErrCancelled) || errors.Is(err, context.Canceled):
+			s.State = dto.JobCancelled
+			s.Error = err.Error()
+		case err != nil:
+			s.State = dto.JobFailed
+			s.Error = err.Error()
+		default:
+			s.State = dto.JobSucceeded
+			s.Progress = 100
+		}
+	})
+	if err != nil && !errors.Is(err, ErrCancelled) && !errors.Is(err, context.Canceled) {
+		logger.WarningContext(ctx, "Jobs: %s job %s failed: %v", j.status.Kind, j.status.ID, err)
+	}
+}
+
+// update applies fn to the job state and publishes the result.
+func (m *Manager) update(j *job, fn func(*dto.Job)) {
+	m.mu.Lock()
+	fn(&j.status)
+	j.status.UpdatedAt = time.Now()
+	status := j.snapshot()
+	m.mu.Unlock()
+	m.publish(status)
+}
+
+// publish broadcasts a job update when an event bus is available.
+func (m *Manager) publish(status dto.Job) {
+	if m.hub == nil {
+		return
+	}
+	domain.Publish(m.hub, constants.TopicJobUpdate, status)
+}
+
+// prune drops expired finished jobs, then the oldest finished jobs beyond
+// maxKeptJobs. Must be called with m.mu held.
+func (m *Manager) prune(now time.Time) {
+	var finished []*job
+	for id, j := range m.jobs {
+		if j.status.FinishedAt == nil {
+			continue
+		}
+		if now.Sub(*j.status.FinishedAt) > jobRetention {
+			delete(m.jobs, id)
+			continue
+		}
+		finished = append(finished, j)
+	}
+	if excess := len(m.jobs) - maxKeptJobs; excess > 0 {
+		slices.SortFunc(finished, func(x, y *job) int { return x.status.FinishedAt.Compare(*y.status.FinishedAt) })
+		for _, j := range finished[:min(excess, len(finished))] {
+			delete(m.jobs, j.status.ID)
+		}
+	}
+}
+
+// snapshot returns a copy of the job state that is safe to hand out. Must be
+// called with Manager.mu held.
+func (j *job) snapshot() dto.Job {
+	status := j.status
+	status.Logs = slices.Clone(j.status.Logs)
+	return status
+}
+
+// Progress reports the progress of a running job.
+type Progress struct {
+	m *Manager
+	j *job
+}
+
+// Set records the percent complete and a short status message.
+func (p *Progress) Set(percent float64, message string) {
+	p.m.update(p.j, func(s *dto.Job) {
+		s.Progress = min(max(percent, 0), 100)
+		s.Message = message
+	})
+}
+
+// Log appends a line to the job log, dropping the oldest lines beyond
+// maxLogLines, and makes it the current status message.
+func (p *Progress) Log(format string, args ...any) {
+	line := fmt.Sprintf(format, args...)
+	p.m.update(p.j, func(s *dto.Job) {
+		s.Logs = append(s.Logs, time.Now().Format(time.TimeOnly)+" "+line)
+		if len(s.Logs) > maxLogLines {
+			s.Logs = s.Logs[len(s.Logs)-maxLogLines:]
+		}
+		s.Message = line
+	})
+}
+
+func newJobID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// waitFinished polls until the job reaches a final state.
+func waitFinished(t *testing.T, m *Manager, id string) dto.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		j, err := m.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if j.Finished() {
+			return j
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return dto.Job{}
+}
+
+func TestManagerSubmit(t *testing.T) {
+	hub := domain.NewEventBus(16)
+	ch := hub.SubTopics(constants.TopicJobUpdate)
+	defer hub.Unsub(ch)
+	m := NewManager(hub)
+
+	job := m.Submit(context.Background(), dto.JobKindVMHibernate, "win11", func(_ context.Context, p *Progress) (any, error) {
+		p.Set(50, "halfway")
+		p.Log("hibernating %s", "win11")
+		return "done", nil
+	})
+	if job.ID == "" || job.State != dto.JobQueued || job.Kind != dto.JobKindVMHibernate {
+		t.Fatalf("submitted job = %+v", job)
+	}
+
+	got := waitFinished(t, m, job.ID)
+	if got.State != dto.JobSucceeded || got.Progress != 100 || got.Result != "done" || got.StartedAt == nil {
+		t.Errorf("finished job = %+v", got)
+	}
+	if len(got.Logs) != 1 || got.Message != "hibernating win11" {
+		t.Errorf("logs = %v, message = %q", got.Logs, got.Message)
+	}
+
+	// queued, running, progress, log, succeeded
+	states := map[dto.JobState]bool{}
+	for range 5 {
+		select {
+		case msg := <-ch:
+			states[msg.(dto.Job).State] = true
+		case <-time.After(time.Second):
+			t.Fatal("missing job update event")
+		}
+	}
+	for _, s := range []dto.JobState{dto.JobQueued, dto.JobRunning, dto.JobSucceeded} {
+		if !states[s] {
+			t.Errorf("no %s event published", s)
+		}
+	}
+
+	list := m.List()
+	if len(list) != 1 || list[0].Logs != nil || list[0].Result != nil {
+		t.Errorf("List() = %+v", list)
+	}
+}
+
+func TestManagerFailureAndCancel(t *testing.T) {
+	m := NewManager(nil)
+
+	failed := m.Submit(context.Background(), dto.JobKindMover, "", func(context.Context, *Progress) (any, error) {
+		return nil, errors.New("mover not installed")
+	})
+	if got := waitFinished(t, m, failed.ID); got.State != dto.JobFailed || got.Error != "mover not installed" {
+		t.Errorf("failed job = %+v", got)
+	}
+
+	started := make(chan struct{})
+	cause := make(chan error, 1)
+	running := m.Submit(context.Background(), dto.JobKindParityCheck, "", func(ctx context.Context, _ *Progress) (any, error) {
+		close(started)
+		<-ctx.Done()
+		cause <- context.Cause(ctx)
+		return nil, ctx.Err()
+	})
+	<-started
+	if _, err := m.Cancel(running.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := waitFinished(t, m, running.ID); got.State != dto.JobCancelled {
+		t.Errorf("cancelled job = %+v", got)
+	}
+	if err := <-cause; !errors.Is(err, ErrCancelled) {
+		t.Errorf("cancel cause = %v, want ErrCancelled", err)
+	}
+
+	if _, err := m.Get("nope"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Get unknown = %v", err)
+	}
+	if _, err := m.Cancel("nope"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Cancel unknown = %v", err)
+	}
+}
+
+func TestManagerQueuesBeyondLimit(t *testing.T) {
+	m := NewManager(nil)
+	release := make(chan struct{})
+	block := func(ctx context.Context, _ *Progress) (any, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil, nil
+	}
+
+	var ids []string
+	for range maxRunningJobs + 1 {
+		ids = append(ids, m.Submit(context.Background(), dto.JobKindContainerUpdate, "", block).ID)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		running, queued := 0, 0
+		for _, j := range m.List() {
+			switch j.State {
+			case dto.JobRunning:
+				running++
+			case dto.JobQueued:
+				queued++
+			}
+		}
+		if running == maxRunningJobs && queued == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("running=%d queued=%d, want %d and 1", running, queued, maxRunningJobs)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	close(release)
+	for _, id := range ids {
+		if got := waitFinished(t, m, id); got.State != dto.JobSucceeded {
+			t.Errorf("job %s = %s", id, got.State)
+		}
+	}
+}
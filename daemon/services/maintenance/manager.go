@@ -0,0 +1,262 @@
+// Package maintenance tracks the maintenance window during which alerts,
+// remediation, notification forwarding and Home Assistant problem sensors
+// are suppressed so planned work doesn't spam notifications.
+package maintenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DefaultConfigDir is the default directory for the persisted window.
+	DefaultConfigDir = "/boot/config/plugins/unraid-management-agent"
+
+	// StateFile is the filename of the persisted window, so a window
+	// survives the reboots that planned work often involves.
+	StateFile = "maintenance.json"
+
+	// MaxDuration is the longest maintenance window.
+	MaxDuration = 72 * time.Hour
+
+	// MaxThrottleFactor is the largest collector interval multiplier.
+	MaxThrottleFactor = 10
+
+	// maxReasonLength caps the window reason.
+	maxReasonLength = 200
+)
+
+// window is the persisted form of an active maintenance window.
+type window struct {
+	Reason         string    `json:"reason,omitempty"`
+	StartedAt      time.Time `json:"started_at"`
+	EndsAt         time.Time `json:"ends_at"`
+	ThrottleFactor int       `json:"throttle_factor,omitempty"`
+}
+
+// Manager holds the maintenance window, ends it when it expires and
+// publishes every change on constants.TopicMaintenanceUpdate.
+type Manager struct {
+	mu       sync.Mutex
+	filePath string
+	hub      *domain.EventBus
+	current  *window
+	timer    *time.Timer
+	onChange []func(dto.MaintenanceStatus)
+}
+
+// NewManager creates a maintenance manager that persists the window in
+// configDir (DefaultConfigDir if empty) and publishes changes on hub, which
+// may be nil.
+func NewManager(configDir string, hub *domain.EventBus) *Manager {
+	if configDir == "" {
+		configDir = DefaultConfigDir
+	}
+	return &Manager{
+		filePath: filepath.Join(configDir, StateFile),
+		hub:      hub,
+	}
+}
+
+// OnChange registers fn to be called with the new status whenever the
+// window starts, changes or ends. Register callbacks before Load.
+func (m *Manager) OnChange(fn func(dto.MaintenanceStatus)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = append(m.onChange, fn)
+}
+
+// Load restores a persisted window that has not expired yet.
+func (m *Manager) Load() error {
+	data, err := os.ReadFile(m.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading maintenance state: %w", err)
+	}
+	var w window
+	if err := json.Unmarshal(data, &w); err != nil {
+		return fmt.Errorf("parsing maintenance state: %w", err)
+	}
+	if !w.EndsAt.After(time.Now()) {
+		m.removeState()
+		return nil
+	}
+
+	m.mu.Lock()
+	m.setLocked(&w)
+	status := m.statusLocked(time.Now())
+	m.mu.Unlock()
+
+	logger.Info("Maintenance: Restored maintenance window until %s", w.EndsAt.Format(time.RFC3339))
+	m.notify(status)
+	return nil
+}
+
+// Begin starts a maintenance window of the requested length, or extends
+// the active one from now, replacing its reason and throttle factor.
+func (m *Manager) Begin(req dto.MaintenanceRequest) (dto.MaintenanceStatus, error) {
+	if err := Validate(req); err != nil {
+		return dto.MaintenanceStatus{}, err
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	w := &window{
+		Reason:         req.Reason,
+		StartedAt:      now,
+		EndsAt:         now.Add(time.Duration(req.DurationMinutes) * time.Minute),
+		ThrottleFactor: req.ThrottleFactor,
+	}
+	if m.current != nil {
+		w.StartedAt = m.current.StartedAt
+	}
+	m.setLocked(w)
+	status := m.statusLocked(now)
+	m.mu.Unlock()
+
+	m.saveState(w)
+	logger.Info("Maintenance: Window active until %s (reason: %q, throttle: %d)",
+		w.EndsAt.Format(time.RFC3339), w.Reason, w.ThrottleFactor)
+	m.notify(status)
+	return status, nil
+}
+
+// End ends the active maintenance window early. Ending when no window is
+// active has no effect.
+func (m *Manager) End() dto.MaintenanceStatus {
+	m.mu.Lock()
+	if m.current == nil {
+		status := m.statusLocked(time.Now())
+		m.mu.Unlock()
+		return status
+	}
+	m.setLocked(nil)
+	status := m.statusLocked(time.Now())
+	m.mu.Unlock()
+
+	m.removeState()
+	logger.Info("Maintenance: Window ended")
+	m.notify(status)
+	return status
+}
+
+// Status returns the current maintenance window state.
+func (m *Manager) Status() dto.MaintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.statusLocked(time.Now())
+}
+
+// Active reports whether a maintenance window is in effect.
+func (m *Manager) Active() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current != nil && time.Now().Before(m.current.EndsAt)
+}
+
+// Validate checks a maintenance request.
+func Validate(req dto.MaintenanceRequest) error {
+	if req.DurationMinutes < 1 || time.Duration(req.DurationMinutes)*time.Minute > MaxDuration {
+		return fmt.Errorf("duration_minutes must be between 1 and %d", int(MaxDuration.Minutes()))
+	}
+	if req.ThrottleFactor < 0 || req.ThrottleFactor > MaxThrottleFactor {
+		return fmt.Errorf("throttle_factor must be between 0 and %d", MaxThrottleFactor)
+	}
+	if len(req.Reason) > maxReasonLength {
+		return fmt.Errorf("reason must be at most %d characters", maxReasonLength)
+	}
+	return nil
+}
+
+// setLocked replaces the window and re-arms the expiry timer. Must be
+// called with m.mu held.
+func (m *Manager) setLocked(w *window) {
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	m.current = w
+	if w != nil {
+		m.timer = time.AfterFunc(time.Until(w.EndsAt), func() { m.expire(w) })
+	}
+}
+
+// expire ends w when its timer fires, unless it was replaced meanwhile.
+func (m *Manager) expire(w *window) {
+	m.mu.Lock()
+	if m.current != w {
+		m.mu.Unlock()
+		return
+	}
+	m.current = nil
+	m.timer = nil
+	status := m.statusLocked(time.Now())
+	m.mu.Unlock()
+
+	m.removeState()
+	logger.Info("Maintenance: Window expired")
+	m.notify(status)
+}
+
+// statusLocked builds the status at now. Must be called with m.mu held.
+func (m *Manager) statusLocked(now time.Time) dto.MaintenanceStatus {
+	status := dto.MaintenanceStatus{Timestamp: now}
+	w := m.current
+	if w == nil || !now.Before(w.EndsAt) {
+		return status
+	}
+	started, ends := w.StartedAt, w.EndsAt
+	status.Active = true
+	status.Reason = w.Reason
+	status.StartedAt = &started
+	status.EndsAt = &ends
+	status.RemainingSeconds = int64(w.EndsAt.Sub(now).Seconds())
+	status.ThrottleFactor = w.ThrottleFactor
+	return status
+}
+
+// notify runs the change callbacks and publishes the status.
+func (m *Manager) notify(status dto.MaintenanceStatus) {
+	m.mu.Lock()
+	callbacks := m.onChange
+	m.mu.Unlock()
+	for _, fn := range callbacks {
+		fn(status)
+	}
+	if m.hub != nil {
+		domain.Publish(m.hub, constants.TopicMaintenanceUpdate, status)
+	}
+}
+
+// saveState persists w, logging failures: the window still applies until
+// the agent restarts.
+func (m *Manager) saveState(w *window) {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(m.filePath), 0o750) //nolint:gosec // G301: Plugin config directory
+	}
+	if err == nil {
+		err = os.WriteFile(m.filePath, data, 0o600)
+	}
+	if err != nil {
+		logger.Warning("Maintenance: Failed to save maintenance state: %v", err)
+	}
+}
+
+// removeState deletes the persisted window.
+func (m *Manager) removeState() {
+	if err := os.Remove(m.filePath); err != nil && !os.IsNotExist(err) {
+		logger.Warning("Maintenance: Failed to remove maintenance state: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package maintenance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestManagerBeginAndEnd(t *testing.T) {
+	dir := t.TempDir()
+	hub := domain.NewEventBus(8)
+	ch := hub.SubTopics(constants.TopicMaintenanceUpdate)
+	defer hub.Unsub(ch)
+
+	m := NewManager(dir, hub)
+	var changes []dto.MaintenanceStatus
+	m.OnChange(func(s dto.MaintenanceStatus) { changes = append(changes, s) })
+
+	if m.Active() {
+		t.Fatal("new manager is active")
+	}
+	status, err := m.Begin(dto.MaintenanceRequest{DurationMinutes: 30, Reason: "disk swap", ThrottleFactor: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Active || status.Reason != "disk swap" || status.ThrottleFactor != 3 || status.RemainingSeconds < 1790 {
+		t.Errorf("Begin() = %+v", status)
+	}
+	if !m.Active() {
+		t.Error("Active() = false during window")
+	}
+	if _, err := os.Stat(filepath.Join(dir, StateFile)); err != nil {
+		t.Errorf("window not persisted: %v", err)
+	}
+	select {
+	case msg := <-ch:
+		if !msg.(dto.MaintenanceStatus).Active {
+			t.Errorf("published %+v, want active", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no maintenance update published")
+	}
+
+	// Extending keeps the original start time.
+	extended, err := m.Begin(dto.MaintenanceRequest{DurationMinutes: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !extended.StartedAt.Equal(*status.StartedAt) || !extended.EndsAt.After(*status.EndsAt) {
+		t.Errorf("extended = %+v, started %v", extended, status.StartedAt)
+	}
+
+	if ended := m.End(); ended.Active {
+		t.Errorf("End() = %+v", ended)
+	}
+	if m.Active() {
+		t.Error("Active() = true after End")
+	}
+	if _, err := os.Stat(filepath.Join(dir, StateFile)); !os.IsNotExist(err) {
+		t.Errorf("state file not removed: %v", err)
+	}
+	if len(changes) != 3 || changes[2].Active {
+		t.Errorf("changes = %+v", changes)
+	}
+}
+
+func TestManagerLoadRestoresWindow(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewManager(dir, nil).Begin(dto.MaintenanceRequest{DurationMinutes: 10, ThrottleFactor: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(dir, nil)
+	var restored dto.MaintenanceStatus
+	m.OnChange(func(s dto.MaintenanceStatus) { restored = s })
+	if err := m.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !m.Active() || !restored.Active || restored.ThrottleFactor != 2 {
+		t.Errorf("restored = %+v", restored)
+	}
+}
+
+func TestManagerExpires(t *testing.T) {
+	m := NewManager(t.TempDir(), nil)
+	ended := make(chan dto.MaintenanceStatus, 2)
+	m.OnChange(func(s dto.MaintenanceStatus) { ended <- s })
+
+	m.mu.Lock()
+	m.setLocked(&window{StartedAt: time.Now(), EndsAt: time.Now().Add(20 * time.Millisecond)})
+	m.mu.Unlock()
+
+	select {
+	case s := <-ended:
+		if s.Active {
+			t.Errorf("expiry status = %+v", s)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("window did not expire")
+	}
+	if m.Active() {
+		t.Error("Active() = true after expiry")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.MaintenanceRequest
+		wantErr bool
+	}{
+		{"valid", dto.MaintenanceRequest{DurationMinutes: 60, ThrottleFactor: 4}, false},
+		{"zero duration", dto.MaintenanceRequest{}, true},
+		{"too long", dto.MaintenanceRequest{DurationMinutes: 72*60 + 1}, true},
+		{"throttle too high", dto.MaintenanceRequest{DurationMinutes: 5, ThrottleFactor: 11}, true},
+		{"negative throttle", dto.MaintenanceRequest{DurationMinutes: 5, ThrottleFactor: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// approvalSchema is the elicitation form shown to the user: a single checkbox
+// that must be ticked to approve the action.
+var approvalSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"approve": map[string]any{
+			"type":        "boolean",
+			"title":       "Approve",
+			"description": "Allow the AI agent to perform this action",
+		},
+	},
+	"required": []string{"approve"},
+}
+
+// supportsElicitation reports whether the client that sent req can show
+// elicitation prompts to its user.
+func supportsElicitation(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// requireApproval gates a destructive tool call on human approval. When the
+// client supports elicitation the user is asked to approve message directly
+// and the answer is recorded in the audit log; the confirm argument is ignored
+// so the model cannot approve its own action. Other clients fall back to the
+// confirm argument and get notConfirmed when it is false.
+//
+// It returns nil when the action may proceed, or the result to send back.
+func (s *Server) requireApproval(ctx context.Context, req *mcp.CallToolRequest, confirm bool, message, notConfirmed string) *mcp.CallToolResult {
+	if !supportsElicitation(req) {
+		if confirm {
+			return nil
+		}
+		return textResult(notConfirmed)
+	}
+
+	tool := req.Params.Name
+	res, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+		Message:         message,
+		RequestedSchema: approvalSchema,
+	})
+	if err != nil {
+		logger.Warning("MCP: approval prompt for '%s' failed: %v", tool, err)
+		s.recordToolAudit(tool, req, req.Params.Arguments, dto.AuditResultDenied, "approval prompt failed: "+err.Error())
+		return textResult(fmt.Sprintf("Could not get user approval (%v). The action was not performed.", err))
+	}
+	if res.Action != "accept" || res.Content["approve"] != true {
+		logger.Info("MCP: user did not approve '%s' (%s)", tool, res.Action)
+		s.recordToolAudit(tool, req, req.Params.Arguments, dto.AuditResultDenied, "not approved by user: "+res.Action)
+		return textResult("The user did not approve this action. It was not performed.")
+	}
+
+	logger.Info("MCP: user approved '%s'", tool)
+	s.recordToolAudit(tool, req, req.Params.Arguments, dto.AuditResultApproved, "approved by user")
+	return nil
+}
@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+)
+
+// connectElicitingClient connects a client whose user answers every approval
+// prompt with the given result.
+func connectElicitingClient(t *testing.T, server *Server, answer *mcp.ElicitResult, prompts *[]string) *mcp.ClientSession {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	ct, st := mcp.NewInMemoryTransports()
+	if _, err := server.mcpServer.Connect(ctx, st, nil); err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0"}, &mcp.ClientOptions{
+		ElicitationHandler: func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			*prompts = append(*prompts, req.Params.Message)
+			return answer, nil
+		},
+	})
+	cs, err := client.Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cs.Close() })
+	return cs
+}
+
+func TestDestructiveToolApproval(t *testing.T) {
+	// update_plugin with no plugin name fails validation right after the
+	// approval gate, so the test never touches the system.
+	const notApproved = "The user did not approve this action"
+	tests := []struct {
+		name       string
+		answer     *mcp.ElicitResult
+		confirm    bool
+		wantText   string
+		wantResult string
+	}{
+		{"approved", &mcp.ElicitResult{Action: "accept", Content: map[string]any{"approve": true}}, false, "plugin_name is required", dto.AuditResultApproved},
+		{"box left unticked", &mcp.ElicitResult{Action: "accept", Content: map[string]any{"approve": false}}, true, notApproved, dto.AuditResultDenied},
+		{"declined despite confirm=true", &mcp.ElicitResult{Action: "decline"}, true, notApproved, dto.AuditResultDenied},
+		{"cancelled", &mcp.ElicitResult{Action: "cancel"}, false, notApproved, dto.AuditResultDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := setupInitializedServer(t)
+			auditLog := audit.NewLog("")
+			server.SetAuditLog(auditLog)
+			var prompts []string
+			cs := connectElicitingClient(t, server, tt.answer, &prompts)
+
+			_, text := callToolJSON(t, cs, "update_plugin", map[string]any{"confirm": tt.confirm})
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("result = %q, want it to contain %q", text, tt.wantText)
+			}
+			if len(prompts) != 1 || !strings.Contains(prompts[0], "Update plugin") {
+				t.Errorf("prompts = %q, want one update prompt", prompts)
+			}
+
+			entries := auditLog.Query(dto.AuditFilter{Result: tt.wantResult})
+			if len(entries) != 1 || entries[0].Action != "update_plugin" {
+				t.Errorf("audit entries with result %q = %+v, want one update_plugin entry", tt.wantResult, entries)
+			}
+		})
+	}
+}
+
+func TestDestructiveToolWithoutElicitationUsesConfirm(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	_, text := callToolJSON(t, cs, "update_plugin", map[string]any{"confirm": false})
+	if !strings.Contains(text, "requires confirm=true") {
+		t.Errorf("without confirm: %q", text)
+	}
+	_, text = callToolJSON(t, cs, "update_plugin", map[string]any{"confirm": true})
+	if !strings.Contains(text, "plugin_name is required") {
+		t.Errorf("with confirm: %q", text)
+	}
+}
@@ -73,6 +73,19 @@ func TestToolListVMSnapshots(t *testing.T) {
 	}
 }
 
+func TestToolListVMDisks(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	_, text := callToolJSON(t, cs, "list_vm_disks", map[string]any{
+		"vm_name": "test-vm",
+	})
+	if text == "" {
+		t.Error("Expected non-empty response")
+	}
+}
+
 func TestToolGetServiceStatus(t *testing.T) {
 	server, _ := setupInitializedServer(t)
 	cs, cleanup := connectClientToServer(t, server)
@@ -240,6 +253,32 @@ func TestToolArrayAction_UnknownAction(t *testing.T) {
 	}
 }
 
+func TestToolUnassignedDeviceAction(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"unknown action", map[string]any{"device": "sdd", "action": "wipe"}, "Unknown action"},
+		{"format unconfirmed", map[string]any{"device": "sdd", "action": "format", "filesystem": "xfs"}, "requires confirm=true"},
+		{"format unknown device", map[string]any{"device": "sdz", "action": "format", "filesystem": "xfs", "confirm": true}, "not found"},
+		{"format unsupported filesystem", map[string]any{"device": "sdd", "action": "format", "filesystem": "ntfs", "confirm": true}, "unsupported filesystem"},
+		{"mount invalid device", map[string]any{"device": "../sda", "action": "mount"}, "Failed to mount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, text := callToolJSON(t, cs, "unassigned_device_action", tt.args)
+			if !strings.Contains(text, tt.want) {
+				t.Errorf("expected %q, got: %s", tt.want, text)
+			}
+		})
+	}
+}
+
 func TestToolParityCheckAction(t *testing.T) {
 	server, _ := setupInitializedServer(t)
 	cs, cleanup := connectClientToServer(t, server)
@@ -896,3 +935,40 @@ func TestToolRestoreVMSnapshot_Confirmed(t *testing.T) {
 		t.Error("Expected non-empty response")
 	}
 }
+
+func TestToolBatchActions_Unconfirmed(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	_, text := callToolJSON(t, cs, "batch_actions", map[string]any{
+		"actions": []any{map[string]any{"action": "stop_container", "target": "a1b2c3d4e5f6"}},
+		"confirm": false,
+	})
+	if !strings.Contains(text, "confirm=true") {
+		t.Errorf("Expected confirmation message, got: %s", text)
+	}
+}
+
+func TestToolBatchActions_InvalidItems(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	_, text := callToolJSON(t, cs, "batch_actions", map[string]any{"actions": []any{}, "confirm": true})
+	if !strings.Contains(text, "Invalid batch") {
+		t.Errorf("Expected 'Invalid batch' message, got: %s", text)
+	}
+
+	// Invalid targets fail per item without reaching a controller.
+	_, text = callToolJSON(t, cs, "batch_actions", map[string]any{
+		"actions": []any{
+			map[string]any{"action": "frobnicate", "target": "x"},
+			map[string]any{"action": "spin_down_disk", "target": "../disk1"},
+		},
+		"confirm": true,
+	})
+	if !strings.Contains(text, `"failed": 2`) {
+		t.Errorf("Expected two failed results, got: %s", text)
+	}
+}
@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+)
+
+// toolCacheTopics maps the tools that answer from collector caches to the
+// cache topics they read. Their results carry the data's freshness.
+var toolCacheTopics = map[string][]string{
+	"get_system_info":            {constants.TopicSystemUpdate.Name},
+	"get_temperatures":           {constants.TopicSystemUpdate.Name},
+	"get_array_status":           {constants.TopicArrayStatusUpdate.Name},
+	"list_disks":                 {constants.TopicDiskListUpdate.Name},
+	"get_disk_info":              {constants.TopicDiskListUpdate.Name},
+	"get_disk_spin_history":      {constants.TopicDiskSpinHistoryUpdate.Name},
+	"list_shares":                {constants.TopicShareListUpdate.Name},
+	"list_containers":            {constants.TopicContainerListUpdate.Name},
+	"get_container_info":         {constants.TopicContainerListUpdate.Name},
+	"search_containers":          {constants.TopicContainerListUpdate.Name},
+	"get_container_autostart":    {constants.TopicContainerListUpdate.Name},
+	"get_docker_stats":           {constants.TopicDockerStatsUpdate.Name},
+	"list_docker_networks":       {constants.TopicDockerNetworksUpdate.Name},
+	"list_vms":                   {constants.TopicVMListUpdate.Name},
+	"get_vm_info":                {constants.TopicVMListUpdate.Name},
+	"search_vms":                 {constants.TopicVMListUpdate.Name},
+	"get_ups_status":             {constants.TopicUPSStatusUpdate.Name},
+	"get_nut_status":             {constants.TopicNUTStatusUpdate.Name},
+	"get_gpu_metrics":            {constants.TopicGPUMetricsUpdate.Name},
+	"get_network_info":           {constants.TopicNetworkListUpdate.Name},
+	"get_hardware_info":          {constants.TopicHardwareUpdate.Name},
+	"get_registration":           {constants.TopicRegistrationUpdate.Name},
+	"get_notifications":          {constants.TopicNotificationsUpdate.Name},
+	"get_notifications_overview": {constants.TopicNotificationsUpdate.Name},
+	"get_pools":                  {constants.TopicPoolsUpdate.Name},
+	"get_btrfs_stats":            {constants.TopicBtrfsUpdate.Name},
+	"get_recycle_bin":            {constants.TopicRecycleBinUpdate.Name},
+	"get_ipmi_sensors":           {constants.TopicIPMIUpdate.Name},
+	"get_zfs_pools":              {constants.TopicZFSPoolsUpdate.Name},
+	"get_zfs_datasets":           {constants.TopicZFSDatasetsUpdate.Name},
+	"get_zfs_snapshots":          {constants.TopicZFSSnapshotsUpdate.Name},
+	"get_zfs_arc_stats":          {constants.TopicZFSARCStatsUpdate.Name},
+	"get_unassigned_devices":     {constants.TopicUnassignedDevicesUpdate.Name},
+	"get_remote_shares":          {constants.TopicUnassignedDevicesUpdate.Name},
+	"get_os_update":              {constants.TopicOSUpdateUpdate.Name},
+	"check_plugin_updates":       {constants.TopicPluginUpdatesUpdate.Name},
+	"get_mover_status":           {constants.TopicMoverUpdate.Name},
+	"get_dns_health":             {constants.TopicDNSHealthUpdate.Name},
+	"get_wan_status":             {constants.TopicWANStatusUpdate.Name},
+	"get_remote_mounts":          {constants.TopicRemoteMountsUpdate.Name},
+	"get_speedtest_results":      {constants.TopicSpeedtestUpdate.Name},
+	"get_power_estimate":         {constants.TopicPowerUpdate.Name},
+	"get_fan_status":             {constants.TopicFanControlUpdate.Name},
+	"get_tuning_status":          {constants.TopicTuningUpdate.Name},
+	"get_array_stop_impact": {
+		constants.TopicArrayStatusUpdate.Name, constants.TopicContainerListUpdate.Name,
+		constants.TopicVMListUpdate.Name, constants.TopicShareListUpdate.Name,
+	},
+	"get_diagnostic_summary": {
+		constants.TopicSystemUpdate.Name, constants.TopicArrayStatusUpdate.Name,
+		constants.TopicDiskListUpdate.Name, constants.TopicContainerListUpdate.Name,
+		constants.TopicDockerStatsUpdate.Name, constants.TopicNotificationsUpdate.Name,
+	},
+	"find_root_cause": {
+		constants.TopicSystemUpdate.Name, constants.TopicArrayStatusUpdate.Name,
+		constants.TopicDiskListUpdate.Name, constants.TopicContainerListUpdate.Name,
+	},
+	"system_health_report": {
+		constants.TopicArrayStatusUpdate.Name, constants.TopicDiskListUpdate.Name,
+		constants.TopicContainerListUpdate.Name,
+	},
+}
+
+// freshnessMiddleware adds collected_at and stale to the results of cache
+// backed tools, both in _meta and as a trailing JSON text block, so agents
+// can tell fresh data from data collected before a collector hung.
+func (s *Server) freshnessMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		if err != nil || method != "tools/call" {
+			return result, err
+		}
+		call, ok := req.(*mcp.CallToolRequest)
+		if !ok || call.Params == nil {
+			return result, err
+		}
+		res, ok := result.(*mcp.CallToolResult)
+		topics := toolCacheTopics[call.Params.Name]
+		if !ok || res == nil || res.IsError || len(topics) == 0 {
+			return result, err
+		}
+		fresh := s.cacheProvider.CacheFreshness(topics...)
+		if fresh.CollectedAt.IsZero() {
+			return result, err
+		}
+		if res.Meta == nil {
+			res.Meta = mcp.Meta{}
+		}
+		res.Meta["collected_at"] = fresh.CollectedAt.UTC().Format(time.RFC3339)
+		res.Meta["stale"] = fresh.Stale
+		if data, jerr := json.Marshal(fresh); jerr == nil {
+			res.Content = append(res.Content, &mcp.TextContent{Text: string(data)})
+		}
+		return res, nil
+	}
+}
@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestToolResultsCarryFreshness(t *testing.T) {
+	server, cache := setupInitializedServer(t)
+	cache.freshness = dto.CacheFreshness{CollectedAt: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC), Stale: true}
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	result, _ := callToolJSON(t, cs, "get_system_info", nil)
+	if result.Meta["collected_at"] != "2026-10-17T09:00:00Z" || result.Meta["stale"] != true {
+		t.Errorf("_meta = %v", result.Meta)
+	}
+	last, ok := result.Content[len(result.Content)-1].(*mcp.TextContent)
+	if !ok || last.Text != `{"collected_at":"2026-10-17T09:00:00Z","stale":true}` {
+		t.Errorf("last content = %#v", result.Content[len(result.Content)-1])
+	}
+
+	// Tools that do not read collector caches are left alone
+	result, _ = callToolJSON(t, cs, "run_self_test", nil)
+	if _, ok := result.Meta["stale"]; ok {
+		t.Errorf("run_self_test got freshness: %v", result.Meta)
+	}
+}
@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Tool policies select which MCP tools a transport exposes (MCP_HTTP_TOOLS,
+// MCP_STDIO_TOOLS). Any other value is a comma-separated allow-list of tool
+// names.
+const (
+	// ToolPolicyAll exposes every tool.
+	ToolPolicyAll = "all"
+	// ToolPolicyReadOnly exposes only tools annotated with readOnlyHint.
+	ToolPolicyReadOnly = "read-only"
+	// ToolPolicyAuto resolves to ToolPolicyAll when an MCP API key is
+	// configured and to ToolPolicyReadOnly otherwise, so an unauthenticated
+	// network endpoint never offers control tools.
+	ToolPolicyAuto = "auto"
+)
+
+// ResolveToolPolicy turns ToolPolicyAuto (or an empty policy) into a concrete
+// policy given whether an API key protects the transport.
+func ResolveToolPolicy(policy string, hasAPIKey bool) string {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy != "" && policy != ToolPolicyAuto {
+		return policy
+	}
+	if hasAPIKey {
+		return ToolPolicyAll
+	}
+	return ToolPolicyReadOnly
+}
+
+// RestrictTools removes every tool the policy does not allow, so it is neither
+// listed nor callable. It must be called after Initialize and before the
+// server is exposed on a transport. An allow-list naming an unknown tool is
+// rejected without changing the server, so typos do not silently hide tools.
+func (s *Server) RestrictTools(policy string) error {
+	if s.mcpServer == nil {
+		return fmt.Errorf("MCP server not initialized")
+	}
+	policy = strings.TrimSpace(policy)
+	if policy == "" || strings.EqualFold(policy, ToolPolicyAll) {
+		return nil
+	}
+
+	tools, err := s.listTools(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list MCP tools: %w", err)
+	}
+
+	var keep func(*mcp.Tool) bool
+	if strings.EqualFold(policy, ToolPolicyReadOnly) {
+		keep = func(t *mcp.Tool) bool { return t.Annotations != nil && t.Annotations.ReadOnlyHint }
+	} else {
+		allowed := make(map[string]bool)
+		for name := range strings.SplitSeq(policy, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				allowed[name] = true
+			}
+		}
+		for name := range allowed {
+			if !slices.ContainsFunc(tools, func(t *mcp.Tool) bool { return t.Name == name }) {
+				return fmt.Errorf("unknown MCP tool %q in tool policy", name)
+			}
+		}
+		keep = func(t *mcp.Tool) bool { return allowed[t.Name] }
+	}
+
+	var removed []string
+	for _, t := range tools {
+		if !keep(t) {
+			removed = append(removed, t.Name)
+		}
+	}
+	s.mcpServer.RemoveTools(removed...)
+	logger.Info("MCP: tool policy %q exposes %d of %d tools", policy, len(tools)-len(removed), len(tools))
+	return nil
+}
+
+// listTools returns every registered tool by listing them over an in-memory
+// session, the only way the SDK exposes its tool registry.
+func (s *Server) listTools(ctx context.Context) ([]*mcp.Tool, error) {
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	ss, err := s.mcpServer.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = ss.Close() }()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "tool-policy", Version: s.ctx.Version}, nil)
+	cs, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cs.Close() }()
+
+	var tools []*mcp.Tool
+	for tool, err := range cs.Tools(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, tool)
+	}
+	return tools, nil
+}
+
+// requireAPIKey rejects requests that do not present key as a bearer token or
+// X-API-Key header. CORS preflight requests carry no credentials and pass
+// through.
+func requireAPIKey(key string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+		presented := r.Header.Get("X-API-Key")
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			presented = strings.TrimSpace(token)
+		}
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(key)) != 1 {
+			logger.Warning("MCP: rejected unauthenticated request from %s", r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+			http.Error(w, "missing or invalid MCP API key", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
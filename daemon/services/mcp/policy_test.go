@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestResolveToolPolicy(t *testing.T) {
+	tests := []struct {
+		policy    string
+		hasAPIKey bool
+		want      string
+	}{
+		{"auto", false, ToolPolicyReadOnly},
+		{"auto", true, ToolPolicyAll},
+		{"", false, ToolPolicyReadOnly},
+		{" AUTO ", true, ToolPolicyAll},
+		{"all", false, ToolPolicyAll},
+		{"read-only", true, ToolPolicyReadOnly},
+		{"get_system_info,system_reboot", false, "get_system_info,system_reboot"},
+	}
+	for _, tt := range tests {
+		if got := ResolveToolPolicy(tt.policy, tt.hasAPIKey); got != tt.want {
+			t.Errorf("ResolveToolPolicy(%q, %v) = %q, want %q", tt.policy, tt.hasAPIKey, got, tt.want)
+		}
+	}
+}
+
+// toolNames lists the tools a client sees.
+func toolNames(t *testing.T, server *Server) map[string]bool {
+	t.Helper()
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+	names := make(map[string]bool)
+	for tool, err := range cs.Tools(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("list tools: %v", err)
+		}
+		names[tool.Name] = true
+	}
+	return names
+}
+
+func TestRestrictTools(t *testing.T) {
+	t.Run("read-only hides control tools", func(t *testing.T) {
+		server, _ := setupInitializedServer(t)
+		all := toolNames(t, server)
+		if err := server.RestrictTools(ToolPolicyReadOnly); err != nil {
+			t.Fatalf("RestrictTools: %v", err)
+		}
+		names := toolNames(t, server)
+		if !names["get_system_info"] || names["system_shutdown"] || names["container_action"] {
+			t.Errorf("unexpected tool set after read-only policy: shutdown=%v container_action=%v system_info=%v",
+				names["system_shutdown"], names["container_action"], names["get_system_info"])
+		}
+		if len(names) == 0 || len(names) >= len(all) {
+			t.Errorf("read-only policy kept %d of %d tools", len(names), len(all))
+		}
+
+		cs, cleanup := connectClientToServer(t, server)
+		defer cleanup()
+		_, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+			Name: "system_shutdown", Arguments: map[string]any{"confirm": true},
+		})
+		if err == nil || !strings.Contains(err.Error(), "unknown tool") {
+			t.Errorf("system_shutdown call error = %v, want unknown tool", err)
+		}
+	})
+
+	t.Run("allow-list", func(t *testing.T) {
+		server, _ := setupInitializedServer(t)
+		if err := server.RestrictTools("get_system_info, container_action"); err != nil {
+			t.Fatalf("RestrictTools: %v", err)
+		}
+		names := toolNames(t, server)
+		if len(names) != 2 || !names["get_system_info"] || !names["container_action"] {
+			t.Errorf("tools = %v, want exactly get_system_info and container_action", names)
+		}
+	})
+
+	t.Run("unknown tool is rejected without changes", func(t *testing.T) {
+		server, _ := setupInitializedServer(t)
+		before := len(toolNames(t, server))
+		err := server.RestrictTools("get_system_info,system_rebot")
+		if err == nil || !strings.Contains(err.Error(), "system_rebot") {
+			t.Fatalf("err = %v, want unknown tool error", err)
+		}
+		if after := len(toolNames(t, server)); after != before {
+			t.Errorf("tool count changed from %d to %d on invalid policy", before, after)
+		}
+	})
+
+	t.Run("all keeps everything", func(t *testing.T) {
+		server, _ := setupInitializedServer(t)
+		before := len(toolNames(t, server))
+		if err := server.RestrictTools(ToolPolicyAll); err != nil {
+			t.Fatalf("RestrictTools: %v", err)
+		}
+		if after := len(toolNames(t, server)); after != before {
+			t.Errorf("tool count changed from %d to %d", before, after)
+		}
+	})
+}
+
+func TestHTTPHandlerRequiresAPIKey(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	server.ctx.MCPAPIKey = "s3cret"
+	handler := server.GetHTTPHandler()
+
+	tests := []struct {
+		name         string
+		method       string
+		header       string
+		value        string
+		wantRejected bool
+	}{
+		{"no credentials", http.MethodPost, "", "", true},
+		{"wrong bearer token", http.MethodPost, "Authorization", "Bearer nope", true},
+		{"bearer token", http.MethodPost, "Authorization", "Bearer s3cret", false},
+		{"x-api-key header", http.MethodPost, "X-API-Key", "s3cret", false},
+		{"cors preflight", http.MethodOptions, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/mcp", strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", "application/json, text/event-stream")
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if got := rec.Code == http.StatusUnauthorized; got != tt.wantRejected {
+				t.Errorf("status = %d, want rejected=%v", rec.Code, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestHTTPHandlerWithoutAPIKeyIsOpen(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	server.GetHTTPHandler().ServeHTTP(rec, req)
+	if rec.Code == http.StatusUnauthorized {
+		t.Error("handler without an API key must not require authentication")
+	}
+}
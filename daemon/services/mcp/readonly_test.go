@@ -41,13 +41,23 @@ func TestReadOnlyModeBlocksWriteTools(t *testing.T) {
 		// registerControlTools
 		{"container_action", map[string]any{"container_id": "abc", "action": "stop"}},
 		{"set_container_autostart", map[string]any{"container_id": "abc", "enabled": true}},
+		{"set_container_autostart_order", map[string]any{"containers": []any{map[string]any{"name": "plex"}}}},
+		{"set_container_limits", map[string]any{"container_id": "abc", "memory_bytes": 1 << 30}},
+		{"create_docker_network", map[string]any{"name": "iot", "driver": "bridge"}},
+		{"remove_docker_network", map[string]any{"network": "iot", "confirm": true}},
 		{"vm_action", map[string]any{"vm_name": "vm1", "action": "stop"}},
+		{"vm_usb_hotplug", map[string]any{"vm_name": "vm1", "action": "attach", "device_id": "046d:c52b"}},
 		{"array_action", map[string]any{"action": "stop", "confirm": true}},
 		{"system_reboot", map[string]any{"confirm": true}},
 		{"system_shutdown", map[string]any{"confirm": true}},
+		{"system_orchestrated_shutdown", map[string]any{"confirm": true}},
+		{"ipmi_chassis_action", map[string]any{"action": "identify"}},
 		{"parity_check_action", map[string]any{}},
 		{"disk_spin_down", map[string]any{"disk_id": "disk1"}},
+		{"empty_recycle_bin", map[string]any{"share": "Media", "confirm": true}},
 		{"execute_user_script", map[string]any{"script_name": "test", "confirm": true}},
+		// registerRemediationTools
+		{"batch_actions", map[string]any{"actions": []any{map[string]any{"action": "stop_container", "target": "abc"}}, "confirm": true}},
 		// registerNewControlTools
 		{"update_container", map[string]any{"container_id": "abc", "confirm": true}},
 		{"create_vm_snapshot", map[string]any{"vm_name": "vm1", "snapshot_name": "snap1"}},
@@ -62,6 +72,10 @@ func TestReadOnlyModeBlocksWriteTools(t *testing.T) {
 		// registerWatchdogTools
 		{"create_health_check", map[string]any{"id": "hc1", "name": "hc 1", "type": "http", "target": "http://localhost"}},
 		{"run_health_check", map[string]any{"check_id": "hc1"}},
+		// registerTransferTools
+		{"transfer_job_action", map[string]any{"job_id": "backup", "action": "run", "confirm": true}},
+		// registerScriptTools
+		{"execute_script", map[string]any{"script_name": "test", "confirm": true}},
 		// registerFanControlTools / CPU / tuning
 		{"set_fan_mode", map[string]any{"fan_id": "hwmon0_fan1", "mode": "automatic"}},
 		{"set_cpu_governor", map[string]any{"governor": "performance", "confirm": true}},
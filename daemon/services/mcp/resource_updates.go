@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// auditResourceURI is updated from the audit log rather than the event hub.
+const auditResourceURI = "unraid://audit"
+
+// resourceUpdateDelay coalesces bursts of hub events into one notification per
+// resource, and gives the API cache that resource handlers read from time to
+// store the new value before clients re-read it.
+const resourceUpdateDelay = 250 * time.Millisecond
+
+// resourceWatch connects a hub topic to the resource whose content it changes.
+type resourceWatch struct {
+	uri     string
+	topic   string
+	msgType reflect.Type
+}
+
+// watchResource creates a type-safe resourceWatch for topic.
+func watchResource[T any](uri string, topic domain.Topic[T]) resourceWatch {
+	return resourceWatch{uri: uri, topic: topic.Name, msgType: reflect.TypeFor[T]()}
+}
+
+// resourceWatches returns the hub topics behind every subscribable resource.
+func resourceWatches() []resourceWatch {
+	return []resourceWatch{
+		watchResource("unraid://system", constants.TopicSystemUpdate),
+		watchResource("unraid://array", constants.TopicArrayStatusUpdate),
+		watchResource("unraid://containers", constants.TopicContainerListUpdate),
+		watchResource("unraid://vms", constants.TopicVMListUpdate),
+		watchResource("unraid://disks", constants.TopicDiskListUpdate),
+		watchResource("unraid://shares", constants.TopicShareListUpdate),
+		watchResource("unraid://network", constants.TopicNetworkListUpdate),
+		watchResource("unraid://ups", constants.TopicUPSStatusUpdate),
+		watchResource("unraid://gpu", constants.TopicGPUMetricsUpdate),
+		watchResource("unraid://pools", constants.TopicPoolsUpdate),
+		watchResource("unraid://btrfs", constants.TopicBtrfsUpdate),
+		watchResource("unraid://zfs/pools", constants.TopicZFSPoolsUpdate),
+		watchResource("unraid://zfs/datasets", constants.TopicZFSDatasetsUpdate),
+		watchResource("unraid://notifications", constants.TopicNotificationsUpdate),
+		watchResource("unraid://collectors", constants.TopicCollectorStateChange),
+	}
+}
+
+// subscribableResource reports whether clients can subscribe to uri.
+func subscribableResource(uri string) bool {
+	if uri == auditResourceURI {
+		return true
+	}
+	for _, w := range resourceWatches() {
+		if w.uri == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSubscribe accepts subscriptions to resources that send update
+// notifications. The SDK tracks the subscribed sessions itself.
+func handleSubscribe(_ context.Context, req *mcp.SubscribeRequest) error {
+	if !subscribableResource(req.Params.URI) {
+		return fmt.Errorf("resource %q does not support subscriptions", req.Params.URI)
+	}
+	logger.Debug("MCP: session %s subscribed to %s", req.Session.ID(), req.Params.URI)
+	return nil
+}
+
+// handleUnsubscribe is required alongside handleSubscribe by the SDK.
+func handleUnsubscribe(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	logger.Debug("MCP: session %s unsubscribed from %s", req.Session.ID(), req.Params.URI)
+	return nil
+}
+
+// notifyResourceUpdated tells subscribed clients that uri has new content.
+func (s *Server) notifyResourceUpdated(ctx context.Context, uri string) {
+	if s.mcpServer == nil {
+		return
+	}
+	if err := s.mcpServer.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+		logger.Debug("MCP: resource update notification for %s failed: %v", uri, err)
+	}
+}
+
+// WatchResources sends resources/updated notifications to subscribed clients
+// whenever the collectors publish new data for a resource, so clients do not
+// have to poll. It blocks until ctx is cancelled.
+func (s *Server) WatchResources(ctx context.Context) {
+	watches := resourceWatches()
+	topics := make([]string, len(watches))
+	uriByType := make(map[reflect.Type]string, len(watches))
+	for i, w := range watches {
+		topics[i] = w.topic
+		uriByType[w.msgType] = w.uri
+	}
+	ch := s.ctx.Hub.Sub(topics...)
+	defer s.ctx.Hub.Unsub(ch)
+
+	pending := make(map[string]bool)
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-ch:
+			uri, ok := uriByType[reflect.TypeOf(msg)]
+			if !ok {
+				continue
+			}
+			pending[uri] = true
+			if flush == nil {
+				flush = time.After(resourceUpdateDelay)
+			}
+		case <-flush:
+			for uri := range pending {
+				s.notifyResourceUpdated(ctx, uri)
+			}
+			clear(pending)
+			flush = nil
+		}
+	}
+}
+
+// auditResourceListener notifies unraid://audit subscribers of new entries.
+// It runs on the recording goroutine, so the notification is sent async.
+func (s *Server) auditResourceListener(dto.AuditEntry) {
+	go s.notifyResourceUpdated(context.Background(), auditResourceURI)
+}
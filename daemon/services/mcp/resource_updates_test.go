@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+)
+
+// connectSubscriber connects a client that forwards resources/updated
+// notifications to the returned channel.
+func connectSubscriber(t *testing.T, server *Server) (*mcp.ClientSession, <-chan string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	updates := make(chan string, 16)
+	ct, st := mcp.NewInMemoryTransports()
+	if _, err := server.mcpServer.Connect(ctx, st, nil); err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updates <- req.Params.URI
+		},
+	})
+	cs, err := client.Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cs.Close() })
+	return cs, updates
+}
+
+func waitForUpdate(t *testing.T, updates <-chan string, want string) {
+	t.Helper()
+	select {
+	case uri := <-updates:
+		if uri != want {
+			t.Fatalf("update for %q, want %q", uri, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("no update notification for %q", want)
+	}
+}
+
+func TestResourceSubscriptionNotifiesOnHubEvents(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	server.ctx.Hub = domain.NewEventBus(16)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.WatchResources(ctx)
+
+	cs, updates := connectSubscriber(t, server)
+	if err := cs.Subscribe(ctx, &mcp.SubscribeParams{URI: "unraid://containers"}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	// Give WatchResources time to subscribe to the hub.
+	time.Sleep(50 * time.Millisecond)
+
+	// A burst of events coalesces into one notification; unsubscribed
+	// resources send none.
+	domain.Publish(server.ctx.Hub, constants.TopicContainerListUpdate, []*dto.ContainerInfo{})
+	domain.Publish(server.ctx.Hub, constants.TopicContainerListUpdate, []*dto.ContainerInfo{})
+	domain.Publish(server.ctx.Hub, constants.TopicSystemUpdate, &dto.SystemInfo{})
+	waitForUpdate(t, updates, "unraid://containers")
+	select {
+	case uri := <-updates:
+		t.Errorf("unexpected extra notification for %q", uri)
+	case <-time.After(3 * resourceUpdateDelay):
+	}
+}
+
+func TestResourceSubscriptionAudit(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	auditLog := audit.NewLog("")
+	server.SetAuditLog(auditLog)
+
+	cs, updates := connectSubscriber(t, server)
+	if err := cs.Subscribe(context.Background(), &mcp.SubscribeParams{URI: auditResourceURI}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	auditLog.Record(dto.AuditEntry{Source: dto.AuditSourceREST, Action: "POST /api/v1/array/start", Result: dto.AuditResultSuccess})
+	waitForUpdate(t, updates, auditResourceURI)
+}
+
+func TestResourceSubscriptionRejectsUnknownURI(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, _ := connectSubscriber(t, server)
+	if err := cs.Subscribe(context.Background(), &mcp.SubscribeParams{URI: "unraid://nope"}); err == nil {
+		t.Error("expected subscribing to an unknown resource to fail")
+	}
+}
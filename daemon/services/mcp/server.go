@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 	"time"
 
@@ -23,10 +24,16 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/agent"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/agentlog"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/alerting"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/api"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/diagnostics"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/mock"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/remediation"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/scripts"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/transfer"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/watchdog"
 )
 
@@ -54,12 +61,30 @@ type CacheProvider interface {
 	GetFanControlCache() *dto.FanControlStatus
 	GetTuningCache() *dto.TuningInfo
 	GetDockerNetworksCache() *dto.DockerNetworkList
+	GetDockerStatsSummaryCache() *dto.DockerStatsSummary
 	GetPluginUpdatesCache() *dto.PluginList
 	GetOSUpdateCache() *dto.OSUpdateStatus
 	GetMoverCache() *dto.MoverStatus
+	GetDNSHealthCache() *dto.DNSHealth
+	GetWANStatusCache() *dto.WANStatus
+	GetRemoteMountsCache() *dto.RemoteMountHealth
+	GetSpeedtestCache() *dto.SpeedtestStatus
+	GetPowerCache() *dto.PowerEstimate
+	GetPoolsCache() []dto.PoolInfo
+	GetBtrfsCache() []dto.BtrfsFilesystem
+	GetRecycleBinCache() *dto.RecycleBinStatus
+	GetIPMICache() *dto.IPMIStatus
+	GetConnectCache() *dto.ConnectStatus
+	GetDiskSpinHistoryCache() *dto.DiskSpinHistory
+	// CacheFreshness reports when the caches fed by topics were last updated
+	// and whether they are stale.
+	CacheFreshness(topics ...string) dto.CacheFreshness
 	// Logs
 	ListLogFiles() []dto.LogFile
 	GetLogContent(path, lines, start string) (*dto.LogFileContent, error)
+	SearchLogs(query dto.LogSearchQuery) (*dto.LogSearchResult, error)
+	// Fleet
+	GetFleetOverview(ctx context.Context) (*dto.FleetOverview, error)
 	// Collectors
 	GetCollectorsStatus() dto.CollectorsStatusResponse
 	GetCollectorStatus(name string) (*dto.CollectorStatus, error)
@@ -90,10 +115,13 @@ type Server struct {
 	alertStore       *alerting.Store
 	watchdogRunner   *watchdog.Runner
 	watchdogStore    *watchdog.Store
+	transferRunner   *transfer.Runner
+	scriptStore      *scripts.Store
 	fanController    *controllers.FanController
 	cpuController    *controllers.CPUController
 	tuningController *controllers.TuningController
 	agentSvc         *agent.Service
+	auditLog         *audit.Log
 }
 
 // NewServer creates a new MCP server instance.
@@ -114,8 +142,11 @@ func (s *Server) Initialize() error {
 		&mcp.ServerOptions{
 			Instructions: "Unraid server management agent providing system monitoring, Docker container control, " +
 				"VM management, array operations, and comprehensive diagnostics via MCP tools.",
+			SubscribeHandler:   handleSubscribe,
+			UnsubscribeHandler: handleUnsubscribe,
 		},
 	)
+	s.mcpServer.AddReceivingMiddleware(s.freshnessMiddleware)
 
 	// Register all tools, resources, and prompts
 	s.registerMonitoringTools()
@@ -127,6 +158,8 @@ func (s *Server) Initialize() error {
 	s.registerPrompts()
 	s.registerAlertingTools()
 	s.registerWatchdogTools()
+	s.registerTransferTools()
+	s.registerScriptTools()
 	s.registerAgentTools()
 	s.registerFanControlTools()
 	s.registerCPUControlTools()
@@ -154,6 +187,16 @@ func (s *Server) SetWatchdog(runner *watchdog.Runner, store *watchdog.Store) {
 	s.watchdogStore = store
 }
 
+// SetTransfers sets the transfer runner for MCP transfer job tools.
+func (s *Server) SetTransfers(runner *transfer.Runner) {
+	s.transferRunner = runner
+}
+
+// SetScripts sets the script store for MCP registered script tools.
+func (s *Server) SetScripts(store *scripts.Store) {
+	s.scriptStore = store
+}
+
 // SetFanController sets the fan controller for MCP fan control tools.
 func (s *Server) SetFanController(fc *controllers.FanController) {
 	s.fanController = fc
@@ -172,15 +215,25 @@ func (s *Server) SetTuningController(tc *controllers.TuningController) {
 	s.tuningController = tc
 }
 
+// SetAuditLog sets the audit log that records every MCP write tool invocation.
+func (s *Server) SetAuditLog(l *audit.Log) {
+	s.auditLog = l
+	l.OnRecord(s.auditResourceListener)
+}
+
 // GetHTTPHandler returns the Streamable HTTP handler for the MCP endpoint.
 // This single handler supports POST, GET, DELETE, and OPTIONS on the MCP endpoint,
 // conforming to the MCP 2025-06-18 Streamable HTTP transport specification.
+// When an MCP API key is configured, requests without it are rejected with 401.
 func (s *Server) GetHTTPHandler() http.Handler {
 	if s.httpHandler == nil {
 		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			http.Error(w, "MCP server not initialized", http.StatusInternalServerError)
 		})
 	}
+	if s.ctx.MCPAPIKey != "" {
+		return requireAPIKey(s.ctx.MCPAPIKey, s.httpHandler)
+	}
 	return s.httpHandler
 }
 
@@ -202,6 +255,10 @@ func (s *Server) RunSTDIO(ctx context.Context) error {
 	return s.mcpServer.Run(ctx, &mcp.StdioTransport{})
 }
 
+// diagnosticTopContainers is how many of the largest CPU and memory
+// consumers the diagnostic summary lists.
+const diagnosticTopContainers = 3
+
 // registerMonitoringTools registers all read-only monitoring tools.
 func (s *Server) registerMonitoringTools() {
 	// Self-test / OS-resilience tool
@@ -470,6 +527,111 @@ func (s *Server) registerMonitoringTools() {
 		return jsonResult(notifications)
 	})
 
+	// Pools tool
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_pools",
+		Description: "Get every Unraid pool (cache, cache2, named btrfs/ZFS/XFS pools) with member devices, capacity, btrfs RAID profile and data/metadata allocation vs usage, and balance/scrub state",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPPoolArgs) (*mcp.CallToolResult, any, error) {
+		pools := s.cacheProvider.GetPoolsCache()
+		if len(pools) == 0 {
+			return textResult("No pools configured or pool information not available"), nil, nil
+		}
+
+		if args.PoolName != "" {
+			for _, pool := range pools {
+				if pool.Name == args.PoolName {
+					return jsonResult(pool)
+				}
+			}
+			return textResult(fmt.Sprintf("Pool '%s' not found", args.PoolName)), nil, nil
+		}
+		return jsonResult(pools)
+	})
+
+	// Btrfs health tool
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_btrfs_stats",
+		Description: "Get btrfs health for every mounted btrfs filesystem: per-device write/read/flush I/O, corruption and generation error counters, and the latest scrub result with corrected/uncorrectable error counts",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		filesystems := s.cacheProvider.GetBtrfsCache()
+		if len(filesystems) == 0 {
+			return textResult("No btrfs filesystems mounted or btrfs statistics not available"), nil, nil
+		}
+		return jsonResult(filesystems)
+	})
+
+	// Recycle bin tool
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_recycle_bin",
+		Description: "Get the size, file count and oldest/newest file age of each user share's recycle bin (files deleted over SMB, kept by the Recycle Bin plugin)",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		status := s.cacheProvider.GetRecycleBinCache()
+		if status == nil {
+			return textResult("Recycle bin statistics not available"), nil, nil
+		}
+		if !status.Installed {
+			return textResult("Recycle Bin plugin is not installed"), nil, nil
+		}
+		return jsonResult(status)
+	})
+
+	// Array stop impact tool
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_array_stop_impact",
+		Description: "Answer 'what breaks if I stop the array': lists the running containers (with the shares, pools and disks their volumes use), running VMs and exported shares that stop or go offline when the array is stopped. Use before array_action stop or a reboot.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		array := s.cacheProvider.GetArrayCache()
+		if array == nil {
+			return textResult("Array status not available"), nil, nil
+		}
+		return jsonResult(api.BuildArrayStopImpact(
+			array,
+			s.cacheProvider.GetDockerCache(),
+			s.cacheProvider.GetSharesCache(),
+			s.cacheProvider.GetVMsCache(),
+			time.Now(),
+		))
+	})
+
+	// IPMI sensors tool
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_ipmi_sensors",
+		Description: "Get the BMC (IPMI) sensors: fan speeds, voltages, temperatures, power and current with critical thresholds and status (ok, nc, cr, nr), power supply states, and the chassis status (power, power/cooling/drive faults, intrusion, last power event)",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		status := s.cacheProvider.GetIPMICache()
+		if status == nil {
+			return textResult("IPMI status not available"), nil, nil
+		}
+		if !status.Available {
+			return textResult("No BMC found: ipmitool is not installed or the board has no IPMI interface"), nil, nil
+		}
+		return jsonResult(status)
+	})
+
+	// Disk spin history tool
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_disk_spin_history",
+		Description: "Get disk spin-up and spin-down history: the current spin state of each array/pool disk, spin-ups and spin-downs in the last 24 hours, and recent transitions. Spin-ups list the processes that were accessing the disk (open files, and fatrace accesses when installed), to answer what keeps waking a disk. Attribution is best effort.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPDiskSpinHistoryArgs) (*mcp.CallToolResult, any, error) {
+		history := s.cacheProvider.GetDiskSpinHistoryCache()
+		if history == nil {
+			return textResult("Disk spin history not available"), nil, nil
+		}
+		if args.Disk != "" {
+			history = history.ForDisk(args.Disk)
+			if len(history.Disks) == 0 {
+				return textResult(fmt.Sprintf("Disk '%s' not found in spin history", args.Disk)), nil, nil
+			}
+		}
+		return jsonResult(history)
+	})
+
 	// ZFS pools tool
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_zfs_pools",
@@ -660,6 +822,29 @@ func (s *Server) registerMonitoringTools() {
 		return jsonResult(content)
 	})
 
+	// Search logs tool
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "search_logs",
+		Description: "Search approved log files server-side for matching lines, optionally limited to a recent time window (e.g. since=2h). Returns capped matches with file and line number - prefer this over paging through full logs.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPLogSearchArgs) (*mcp.CallToolResult, any, error) {
+		if args.Query == "" {
+			return textResult("query is required"), nil, nil
+		}
+
+		result, err := s.cacheProvider.SearchLogs(dto.LogSearchQuery{
+			Query:      args.Query,
+			Regex:      args.Regex,
+			Files:      args.Files,
+			Since:      args.Since,
+			MaxResults: args.MaxResults,
+		})
+		if err != nil {
+			return textResult(fmt.Sprintf("Failed to search logs: %v", err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
 	// Get Docker log tool (convenience wrapper)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_docker_log",
@@ -737,7 +922,7 @@ func (s *Server) registerMonitoringTools() {
 	// Get VM settings tool
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_vm_settings",
-		Description: "Get VM Manager configuration settings including enabled state, PCI/USB passthrough devices",
+		Description: "Get VM Manager configuration settings including enabled state, PCI/USB passthrough devices, and the host PCI devices (with IOMMU groups and bound driver) and USB devices with the VMs each is passed through to",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
 		settings := s.cacheProvider.GetVMSettings()
@@ -789,10 +974,23 @@ func (s *Server) registerMonitoringTools() {
 		return jsonResult(urls)
 	})
 
+	// Network interface configuration tool
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_network_config",
+		Description: "Get the network interface configuration from network.cfg: per port bonding, bridging, VLAN sub-interfaces, DHCP or static IPv4 addresses, MTU and static DNS servers, plus whether a change applied through the REST API is waiting for confirmation or was rolled back",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		settings, err := controllers.GetNetworkSettings()
+		if err != nil {
+			return textResult(fmt.Sprintf("Failed to read network configuration: %v", err)), nil, nil
+		}
+		return jsonResult(settings)
+	})
+
 	// Get health status tool
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_health_status",
-		Description: "Get a quick health check summary of the Unraid server including API status, uptime, and basic connectivity",
+		Description: "Get a quick health check summary of the Unraid server: overall status, the state of the Docker and libvirt sockets, MQTT, the collectors and the log disk, plus uptime and basic counts",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
 		health := s.cacheProvider.GetHealthStatus()
@@ -940,6 +1138,30 @@ func (s *Server) registerMonitoringTools() {
 		summary["stopped_containers"] = stoppedContainers
 		summary["stopped_containers_count"] = len(stoppedContainers)
 
+		// Docker resource usage and its largest consumers
+		if stats := s.cacheProvider.GetDockerStatsSummaryCache(); stats != nil {
+			summary["docker"] = map[string]any{
+				"running_containers": stats.RunningContainers,
+				"total_cpu_percent":  stats.TotalCPUPercent,
+				"total_memory_mb":    stats.TotalMemoryUsageMB,
+				"top_cpu":            stats.TopCPU[:min(diagnosticTopContainers, len(stats.TopCPU))],
+				"top_memory":         stats.TopMemory[:min(diagnosticTopContainers, len(stats.TopMemory))],
+			}
+		}
+
+		// Unraid Connect (only when the plugin is installed)
+		if connect := s.cacheProvider.GetConnectCache(); connect != nil && connect.Installed {
+			summary["connect"] = map[string]any{
+				"signed_in":           connect.SignedIn,
+				"cloud_status":        connect.CloudStatus,
+				"cloud_connected":     connect.CloudConnected,
+				"flash_backup":        connect.FlashBackup.Activated,
+				"flash_backup_error":  connect.FlashBackup.Error,
+				"flash_backup_synced": connect.FlashBackup.LastSync,
+				"remote_access":       connect.RemoteAccess.Type,
+			}
+		}
+
 		return jsonResult(summary)
 	})
 
@@ -1016,6 +1238,27 @@ func (s *Server) registerNewMonitoringTools() {
 		return jsonResult(result)
 	})
 
+	// Get container autostart order
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_container_autostart",
+		Description: "Get the Unraid container autostart configuration: autostarted containers in boot start order with the wait (seconds) after each, followed by the containers that do not autostart.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: Getting container autostart configuration")
+		containers := s.cacheProvider.GetDockerCache()
+		names := make([]string, 0, len(containers))
+		for _, c := range containers {
+			names = append(names, c.Name)
+		}
+		dockerCtrl := controllers.NewDockerController()
+		defer dockerCtrl.Close() //nolint:errcheck
+		result, err := dockerCtrl.GetAutostart(names)
+		if err != nil {
+			return textResult(fmt.Sprintf("Failed to read autostart configuration: %v", err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
 	// Get OS update status (local-file only, no network calls)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_os_update",
@@ -1045,6 +1288,137 @@ func (s *Server) registerNewMonitoringTools() {
 		return jsonResult(&dto.MoverStatus{Timestamp: time.Now()})
 	})
 
+	// Get DNS health (resolver diagnostics from the host and Docker bridge gateways)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_dns_health",
+		Description: "Return the cached DNS health: configured resolvers, response times, DNSSEC validation, and whether the upstream resolvers answer queries sourced from each Docker bridge gateway address (run from the host, not inside containers; macvlan/ipvlan networks such as br0 are listed as unchecked). Use this when containers cannot resolve hostnames or fail to pull updates.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: Getting cached DNS health")
+		if cached := s.cacheProvider.GetDNSHealthCache(); cached != nil {
+			return jsonResult(cached)
+		}
+		return textResult("DNS health not available yet (dns collector has not run)"), nil, nil
+	})
+
+	// Get WAN status (internet connectivity, public IP, probe latency)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_wan_status",
+		Description: "Return the cached internet connectivity status: whether the WAN is online, the current public IP (plus the previous IP and when it changed), and latency/packet loss to each probe target. Use this to diagnose ISP outages, flaky connections, or dynamic DNS problems.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: Getting cached WAN status")
+		if cached := s.cacheProvider.GetWANStatusCache(); cached != nil {
+			return jsonResult(cached)
+		}
+		return textResult("WAN status not available yet (wan collector has not run)"), nil, nil
+	})
+
+	// Get NFS/SMB client mount health
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_remote_mounts",
+		Description: "Return the cached health of remote NFS and SMB client mounts (Unassigned Devices remote shares, /etc/fstab entries and other network mounts): whether each is mounted, stale (statfs hangs or fails, e.g. a hung NFS server or disconnected SMB session) and since when, and whether the server's NFS/SMB port is reachable with its latency. Use this when a remote share or a container using one stops responding.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: Getting cached remote mount health")
+		if cached := s.cacheProvider.GetRemoteMountsCache(); cached != nil {
+			return jsonResult(cached)
+		}
+		return textResult("Remote mount health not available yet (remote_mounts collector has not run)"), nil, nil
+	})
+
+	// Get scheduled bandwidth test results
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_speedtest_results",
+		Description: "Return the latest scheduled bandwidth test (download/upload Mbps and ping ms from speedtest-cli or iperf3) and the history of previous runs, oldest first. Use this to spot ISP throughput degradation over time. Results are cached; this does not start a new test.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: Getting cached speedtest results")
+		if cached := s.cacheProvider.GetSpeedtestCache(); cached != nil {
+			return jsonResult(cached)
+		}
+		return textResult("No bandwidth test results yet (the speedtest collector is disabled by default; enable it with INTERVAL_SPEEDTEST)"), nil, nil
+	})
+
+	// Get estimated power draw and energy use
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_power_estimate",
+		Description: "Return the estimated power draw of the server in watts and the projected kWh per day, with the breakdown: CPU and DRAM (Intel RAPL), GPU power draw, UPS load, and disks estimated from spin state. The total is the UPS load when a UPS reports it, otherwise the component sum. Use this to answer questions about electricity use or cost.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: Getting power estimate")
+		if cached := s.cacheProvider.GetPowerCache(); cached != nil {
+			return jsonResult(cached)
+		}
+		return textResult("Power estimate not available yet (the system collector has not run)"), nil, nil
+	})
+
+	// Network diagnostics: ping, DNS, and HTTP from the server's perspective,
+	// restricted to the DIAGNOSTICS_TARGETS allow list.
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "ping_host",
+		Description: "Ping a host from the Unraid server and report packet loss and min/avg/max round-trip times. Use this to answer 'can the server reach X'. The host must be in the DIAGNOSTICS_TARGETS allow list.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, OpenWorldHint: ptr(true)},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args dto.MCPPingArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: Pinging %s", args.Host)
+		result, err := diagnostics.NewNetworkDiagnostics(s.ctx.DiagnosticsTargets).Ping(ctx, args.Host, args.Count)
+		if err != nil {
+			return textResult(fmt.Sprintf("Ping rejected: %v", err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "dns_lookup",
+		Description: "Resolve a hostname (A, AAAA, CNAME, MX, TXT, or NS records) with the Unraid server's own resolver and report the records and lookup time. The host must be in the DIAGNOSTICS_TARGETS allow list.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, OpenWorldHint: ptr(true)},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args dto.MCPDNSLookupArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: DNS lookup %s %s", args.RecordType, args.Host)
+		result, err := diagnostics.NewNetworkDiagnostics(s.ctx.DiagnosticsTargets).LookupDNS(ctx, args.Host, args.RecordType)
+		if err != nil {
+			return textResult(fmt.Sprintf("DNS lookup rejected: %v", err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "http_check",
+		Description: "Request an http(s) URL from the Unraid server (like curl) and report the status code, TLS version, certificate expiry, and a DNS/connect/TLS/first-byte timing breakdown. The host must be in the DIAGNOSTICS_TARGETS allow list; redirects are only followed to allow-listed hosts.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, OpenWorldHint: ptr(true)},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args dto.MCPHTTPCheckArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: HTTP check %s", args.URL)
+		result, err := diagnostics.NewNetworkDiagnostics(s.ctx.DiagnosticsTargets).CheckHTTP(ctx, args.URL, args.Method)
+		if err != nil {
+			return textResult(fmt.Sprintf("HTTP check rejected: %v", err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
+	// Wake-on-LAN: a magic packet sent from the server to a configured device
+	// or MAC address on the local network.
+	addWriteTool(s, &mcp.Tool{
+		Name:        "wake_on_lan",
+		Description: "Send a Wake-on-LAN magic packet from the Unraid server to wake a machine on the local network. Give a device name from WOL_DEVICES (listed in the tool error if unknown) or a MAC address. Success means the packet was sent, not that the machine woke up.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(false),
+			IdempotentHint:  true,
+		},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPWakeOnLANArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: wake_on_lan(device=%s, mac=%s)", args.Device, args.MAC)
+		result, err := lib.Wake(s.ctx.WOLDevices, dto.WOLRequest{Device: args.Device, MAC: args.MAC, Broadcast: args.Broadcast})
+		if err != nil {
+			names := []string{"none"}
+			if len(s.ctx.WOLDevices) > 0 {
+				names = names[:0]
+				for _, d := range s.ctx.WOLDevices {
+					names = append(names, d.Name)
+				}
+			}
+			return textResult(fmt.Sprintf("Wake-on-LAN failed: %v (configured devices: %s)", err, strings.Join(names, ", "))), nil, nil
+		}
+		return jsonResult(result)
+	})
+
 	// Check plugin updates (returns cached result)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "check_plugin_updates",
@@ -1098,6 +1472,22 @@ func (s *Server) registerNewMonitoringTools() {
 		return jsonResult(result)
 	})
 
+	// List VM disk images
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "list_vm_disks",
+		Description: "List the file-backed disk images of a virtual machine with their format (qcow2/raw), virtual size, actual size on disk, and any running or recent format conversion.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPVMArgs) (*mcp.CallToolResult, any, error) {
+		if err := lib.ValidateVMName(args.VMName); err != nil {
+			return textResult(err.Error()), nil, nil
+		}
+		result, err := controllers.NewVMController().ListDiskImages(args.VMName)
+		if err != nil {
+			return textResult(fmt.Sprintf("Failed to list VM disk images: %v", err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
 	// Get service status
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_service_status",
@@ -1162,6 +1552,32 @@ func (s *Server) registerNewMonitoringTools() {
 		return jsonResult(result)
 	})
 
+	// Top resource consumers with container attribution
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_top_processes",
+		Description: "Get the top processes by CPU or memory usage, with process name, PID, user, cgroup and the Docker container each process runs in. Useful for finding what is loading the server.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPTopProcessesArgs) (*mcp.CallToolResult, any, error) {
+		sortBy := args.SortBy
+		if sortBy == "" {
+			sortBy = "cpu"
+		}
+		limit := args.Limit
+		if limit <= 0 {
+			limit = controllers.DefaultTopProcesses
+		}
+		if limit > controllers.MaxTopProcesses {
+			limit = controllers.MaxTopProcesses
+		}
+		logger.Info("MCP: Getting top processes (sort=%s, limit=%d)", sortBy, limit)
+		processCtrl := controllers.NewProcessController()
+		result, err := processCtrl.TopProcesses(sortBy, limit, s.cacheProvider.GetDockerCache())
+		if err != nil {
+			return textResult(fmt.Sprintf("Failed to get top processes: %v", err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
 	// Top processes by disk I/O (native /proc/<pid>/io sampling)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "list_process_io",
@@ -1207,7 +1623,20 @@ func (s *Server) registerNewMonitoringTools() {
 		return jsonResult(result)
 	})
 
-	logger.Debug("MCP new monitoring tools registered (8 additional read-only tools)")
+	// Fleet overview across this server and its registered peer agents
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_fleet_status",
+		Description: "Get an aggregated status of this Unraid server and every registered peer agent (hostname, versions, uptime, CPU/RAM usage, array state, reachability). Other servers can then be queried through the REST proxy at /api/v1/fleet/{server}/...",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		overview, err := s.cacheProvider.GetFleetOverview(ctx)
+		if err != nil {
+			return textResult(fmt.Sprintf("Failed to get fleet status: %v", err)), nil, nil
+		}
+		return jsonResult(overview)
+	})
+
+	logger.Debug("MCP new monitoring tools registered (9 additional read-only tools)")
 }
 
 // registerControlTools registers tools that can modify system state.
@@ -1220,12 +1649,16 @@ func (s *Server) registerControlTools() {
 			DestructiveHint: ptr(true),
 			IdempotentHint:  true,
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPContainerActionArgs) (*mcp.CallToolResult, any, error) {
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPContainerActionArgs) (*mcp.CallToolResult, any, error) {
 		logger.Info("MCP: Container action '%s' requested for '%s'", args.Action, args.ContainerID)
 
 		// The remove action requires explicit confirmation.
-		if args.Action == "remove" && !args.Confirm {
-			return textResult("Action 'remove' requires confirm=true. Set confirm to true to execute this destructive action."), nil, nil
+		if args.Action == "remove" {
+			if denied := s.requireApproval(ctx, req, args.Confirm,
+				fmt.Sprintf("Remove Docker container '%s'?", args.ContainerID),
+				"Action 'remove' requires confirm=true. Set confirm to true to execute this destructive action."); denied != nil {
+				return denied, nil, nil
+			}
 		}
 
 		dockerCtrl := controllers.NewDockerController()
@@ -1282,6 +1715,125 @@ func (s *Server) registerControlTools() {
 		return textResult(fmt.Sprintf("Autostart %s for container %q", action, args.ContainerID)), nil, nil
 	})
 
+	// Container autostart order tool
+	addWriteTool(s, &mcp.Tool{
+		Name:        "set_container_autostart_order",
+		Description: "Replace the Unraid container autostart list. Listed containers are started at boot in the given order, waiting each entry's wait seconds before starting the next; every other container has autostart disabled. Use get_container_autostart first to see the current list.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(false),
+			IdempotentHint:  true,
+		},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPSetAutostartOrderArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: set_container_autostart_order(%d containers)", len(args.Containers))
+
+		req := dto.DockerAutostartRequest{Containers: args.Containers}
+		if req.Containers == nil {
+			req.Containers = []dto.ContainerAutostartRequestEntry{}
+		}
+		if err := lib.ValidateAutostartOrder(req); err != nil {
+			return textResult(fmt.Sprintf("Invalid autostart order: %v", err)), nil, nil
+		}
+
+		dockerCtrl := controllers.NewDockerController()
+		defer dockerCtrl.Close() //nolint:errcheck
+
+		if err := dockerCtrl.SetAutostartOrder(req.Containers); err != nil {
+			logger.Error("MCP: set_container_autostart_order failed: %v", err)
+			return textResult(fmt.Sprintf("Failed to set autostart order: %v", err)), nil, nil
+		}
+		return textResult(fmt.Sprintf("Autostart order set for %d containers", len(req.Containers))), nil, nil
+	})
+
+	// Container resource limits tool
+	addWriteTool(s, &mcp.Tool{
+		Name:        "set_container_limits",
+		Description: "Change the CPU shares, CPU quota/period, memory limit and restart policy of a Docker container in place (docker update), for example to throttle a runaway container. Only the fields provided are changed. Changes are lost when Unraid recreates the container from its template.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(false),
+			IdempotentHint:  true,
+		},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPSetContainerLimitsArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: set_container_limits(%s)", args.ContainerID)
+
+		if err := lib.ValidateContainerRef(args.ContainerID); err != nil {
+			return textResult(fmt.Sprintf("Invalid container reference: %v", err)), nil, nil
+		}
+
+		dockerCtrl := controllers.NewDockerController()
+		defer dockerCtrl.Close() //nolint:errcheck
+
+		limits, err := dockerCtrl.UpdateLimits(args.ContainerID, dto.ContainerLimitsRequest{
+			CPUShares:         args.CPUShares,
+			CPUQuota:          args.CPUQuota,
+			CPUPeriod:         args.CPUPeriod,
+			MemoryBytes:       args.MemoryBytes,
+			MemorySwapBytes:   args.MemorySwapBytes,
+			RestartPolicy:     args.RestartPolicy,
+			RestartMaxRetries: args.RestartMaxRetries,
+		})
+		if err != nil {
+			logger.Error("MCP: set_container_limits failed: %v", err)
+			return textResult(fmt.Sprintf("Failed to update limits for container %q: %v", args.ContainerID, err)), nil, nil
+		}
+		return jsonResult(limits)
+	})
+
+	// Docker network tools
+	addWriteTool(s, &mcp.Tool{
+		Name:        "create_docker_network",
+		Description: "Create a custom Docker network: bridge, or macvlan/ipvlan on a parent interface such as br0 or a VLAN sub-interface like br0.20, so containers get their own address on that LAN or VLAN (e.g. to segregate IoT containers). macvlan and ipvlan need a parent and a subnet.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(false),
+		},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPCreateDockerNetworkArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: create_docker_network(%s, %s)", args.Name, args.Driver)
+
+		dockerCtrl := controllers.NewDockerController()
+		defer dockerCtrl.Close() //nolint:errcheck
+
+		result, err := dockerCtrl.CreateNetwork(dto.DockerNetworkCreateRequest{
+			Name:       args.Name,
+			Driver:     args.Driver,
+			Parent:     args.Parent,
+			Subnet:     args.Subnet,
+			Gateway:    args.Gateway,
+			IPRange:    args.IPRange,
+			IPVlanMode: args.IPVlanMode,
+			Internal:   args.Internal,
+		})
+		if err != nil {
+			logger.Error("MCP: create_docker_network failed: %v", err)
+			return textResult(fmt.Sprintf("Failed to create Docker network %q: %v", args.Name, err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
+	addWriteTool(s, &mcp.Tool{
+		Name:        "remove_docker_network",
+		Description: "Remove a custom Docker network by name or ID. Refused while containers are attached. Requires confirm=true.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(true),
+			IdempotentHint:  true,
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPRemoveDockerNetworkArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: remove_docker_network(%s)", args.Network)
+
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			fmt.Sprintf("Remove Docker network '%s'?", args.Network),
+			"Removing a Docker network requires confirm=true."); denied != nil {
+			return denied, nil, nil
+		}
+
+		dockerCtrl := controllers.NewDockerController()
+		defer dockerCtrl.Close() //nolint:errcheck
+
+		if err := dockerCtrl.RemoveNetwork(args.Network); err != nil {
+			logger.Error("MCP: remove_docker_network failed: %v", err)
+			return textResult(fmt.Sprintf("Failed to remove Docker network %q: %v", args.Network, err)), nil, nil
+		}
+		return textResult(fmt.Sprintf("Docker network %q removed", args.Network)), nil, nil
+	})
+
 	// Port-conflict detection tool (read-only)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_port_conflicts",
@@ -1309,12 +1861,16 @@ func (s *Server) registerControlTools() {
 			DestructiveHint: ptr(true),
 			IdempotentHint:  true,
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPVMActionArgs) (*mcp.CallToolResult, any, error) {
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPVMActionArgs) (*mcp.CallToolResult, any, error) {
 		logger.Info("MCP: VM action '%s' requested for '%s'", args.Action, args.VMName)
 
 		// The reset action requires explicit confirmation.
-		if args.Action == "reset" && !args.Confirm {
-			return textResult("Action 'reset' requires confirm=true. Set confirm to true to execute this destructive action."), nil, nil
+		if args.Action == "reset" {
+			if denied := s.requireApproval(ctx, req, args.Confirm,
+				fmt.Sprintf("Hard-reset virtual machine '%s'? Unsaved guest data will be lost.", args.VMName),
+				"Action 'reset' requires confirm=true. Set confirm to true to execute this destructive action."); denied != nil {
+				return denied, nil, nil
+			}
 		}
 
 		vmCtrl := controllers.NewVMController()
@@ -1349,6 +1905,38 @@ func (s *Server) registerControlTools() {
 		return textResult(fmt.Sprintf("Successfully executed '%s' on VM '%s'", args.Action, args.VMName)), nil, nil
 	})
 
+	// VM USB hotplug tool
+	addWriteTool(s, &mcp.Tool{
+		Name:        "vm_usb_hotplug",
+		Description: "Attach a host USB device to, or detach it from, a running VM without restarting it. The device is given as vendor:product (see host_usb_devices from get_vm_settings). Applies to the live VM only.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(false),
+			IdempotentHint:  true,
+		},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPVMUSBHotplugArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: vm_usb_hotplug(%s, %s, %s)", args.VMName, args.Action, args.DeviceID)
+
+		if err := lib.ValidateVMName(args.VMName); err != nil {
+			return textResult(fmt.Sprintf("Invalid VM name: %v", err)), nil, nil
+		}
+
+		vmCtrl := controllers.NewVMController()
+		var err error
+		switch args.Action {
+		case "attach":
+			err = vmCtrl.AttachUSB(args.VMName, args.DeviceID)
+		case "detach":
+			err = vmCtrl.DetachUSB(args.VMName, args.DeviceID)
+		default:
+			return textResult(fmt.Sprintf("Unknown action: %s (use attach or detach)", args.Action)), nil, nil
+		}
+		if err != nil {
+			logger.Error("MCP: vm_usb_hotplug failed: %v", err)
+			return textResult(fmt.Sprintf("Failed to %s USB device: %v", args.Action, err)), nil, nil
+		}
+		return textResult(fmt.Sprintf("USB device %s %sed on VM '%s'", args.DeviceID, args.Action, args.VMName)), nil, nil
+	})
+
 	// Array control tool
 	addWriteTool(s, &mcp.Tool{
 		Name:        "array_action",
@@ -1357,9 +1945,11 @@ func (s *Server) registerControlTools() {
 			DestructiveHint: ptr(true),
 			IdempotentHint:  true,
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPArrayActionArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Action not confirmed. Set 'confirm' to true to execute this action."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPArrayActionArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			fmt.Sprintf("Run array action '%s'? Stopping the array makes all data inaccessible.", args.Action),
+			"Action not confirmed. Set 'confirm' to true to execute this action."); denied != nil {
+			return denied, nil, nil
 		}
 
 		logger.Info("MCP: Array action '%s' requested (confirmed)", args.Action)
@@ -1414,6 +2004,74 @@ func (s *Server) registerControlTools() {
 		return textResult(fmt.Sprintf("Successfully initiated '%s' on remote share '%s'", args.Action, args.Source)), nil, nil
 	})
 
+	// Empty recycle bin tool
+	addWriteTool(s, &mcp.Tool{
+		Name:        "empty_recycle_bin",
+		Description: "Permanently delete every file in a user share's recycle bin (share as reported by get_recycle_bin). Requires confirm=true.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(true),
+			IdempotentHint:  true,
+		},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPRecycleBinEmptyArgs) (*mcp.CallToolResult, any, error) {
+		if !args.Confirm {
+			return textResult("Emptying a recycle bin requires confirm=true. Deleted files cannot be recovered."), nil, nil
+		}
+		logger.Info("MCP: Empty recycle bin requested for share '%s'", args.Share)
+
+		result, err := controllers.EmptyRecycleBin(args.Share)
+		if err != nil {
+			logger.Error("MCP: Empty recycle bin failed: %v", err)
+			return textResult(fmt.Sprintf("Failed to empty recycle bin of share '%s': %v", args.Share, err)), nil, nil
+		}
+		return jsonResult(result)
+	})
+
+	// Unassigned device mount/unmount/format tool
+	addWriteTool(s, &mcp.Tool{
+		Name:        "unassigned_device_action",
+		Description: "Mount, unmount, or format an Unassigned Devices disk (device as reported by get_unassigned_devices). Format erases the whole disk and creates one xfs, btrfs, or exfat partition; it requires confirm=true and is refused while the disk is mounted or passed through.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(true),
+		},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPUnassignedDeviceActionArgs) (*mcp.CallToolResult, any, error) {
+		logger.Info("MCP: Unassigned device action '%s' requested for '%s'", args.Action, args.Device)
+
+		ctrl := controllers.NewUnassignedDeviceController()
+		var err error
+		switch strings.ToLower(strings.TrimSpace(args.Action)) {
+		case "mount":
+			err = ctrl.Mount(args.Device)
+		case "unmount":
+			err = ctrl.Unmount(args.Device)
+		case "format":
+			if !args.Confirm {
+				return textResult("Format requires confirm=true. This erases all data on the device."), nil, nil
+			}
+			var target *dto.UnassignedDevice
+			if list := s.cacheProvider.GetUnassignedCache(); list != nil {
+				for i := range list.Devices {
+					if list.Devices[i].Device == args.Device {
+						target = &list.Devices[i]
+						break
+					}
+				}
+			}
+			if target == nil {
+				return textResult(fmt.Sprintf("Unassigned device '%s' not found", args.Device)), nil, nil
+			}
+			err = ctrl.Format(*target, args.Filesystem)
+		default:
+			return textResult(fmt.Sprintf("Unknown action: %s (expected mount, unmount, or format)", args.Action)), nil, nil
+		}
+
+		if err != nil {
+			logger.Error("MCP: Unassigned device action failed: %v", err)
+			return textResult(fmt.Sprintf("Failed to %s device: %v", args.Action, err)), nil, nil
+		}
+
+		return textResult(fmt.Sprintf("Successfully executed '%s' on device '%s'", args.Action, args.Device)), nil, nil
+	})
+
 	// Parity check tool
 	addWriteTool(s, &mcp.Tool{
 		Name:        "parity_check_action",
@@ -1426,7 +2084,7 @@ func (s *Server) registerControlTools() {
 		logger.Info("MCP: Parity check action requested (correcting=%v)", args.Correcting)
 
 		arrayCtrl := controllers.NewArrayController(s.ctx)
-		err := arrayCtrl.StartParityCheck(args.Correcting)
+		err := arrayCtrl.StartParityCheck(args.Correcting, dto.ParityTriggerMCP)
 
 		if err != nil {
 			logger.Error("MCP: Parity check action failed: %v", err)
@@ -1447,9 +2105,11 @@ func (s *Server) registerControlTools() {
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr(true),
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPSystemActionArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Reboot not confirmed. Set 'confirm' to true to execute this action."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPSystemActionArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			"Reboot the Unraid server?",
+			"Reboot not confirmed. Set 'confirm' to true to execute this action."); denied != nil {
+			return denied, nil, nil
 		}
 
 		logger.Info("MCP: System reboot requested (confirmed)")
@@ -1472,9 +2132,11 @@ func (s *Server) registerControlTools() {
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr(true),
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPSystemActionArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Shutdown not confirmed. Set 'confirm' to true to execute this action."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPSystemActionArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			"Shut down the Unraid server?",
+			"Shutdown not confirmed. Set 'confirm' to true to execute this action."); denied != nil {
+			return denied, nil, nil
 		}
 
 		logger.Info("MCP: System shutdown requested (confirmed)")
@@ -1490,6 +2152,53 @@ func (s *Server) registerControlTools() {
 		return textResult("System shutdown initiated. The server will power off shortly."), nil, nil
 	})
 
+	// Orchestrated shutdown tool
+	addWriteTool(s, &mcp.Tool{
+		Name:        "system_orchestrated_shutdown",
+		Description: "Safely shut down the Unraid server: shut down running VMs, stop containers in reverse autostart order, stop the array, sync disks, then power off. Runs in the background with progress on the shutdown_progress WebSocket topic; aborts without powering off if the array cannot be stopped. CAUTION: Requires confirmation.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(true),
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPSystemActionArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			"Stop all VMs, containers and the array, then shut down the Unraid server?",
+			"Shutdown not confirmed. Set 'confirm' to true to execute this action."); denied != nil {
+			return denied, nil, nil
+		}
+
+		logger.Info("MCP: Orchestrated shutdown requested (confirmed)")
+
+		systemCtrl := controllers.NewSystemController(s.ctx)
+		if err := systemCtrl.StartOrchestratedShutdown(); err != nil {
+			return textResult(fmt.Sprintf("Failed to start orchestrated shutdown: %v", err)), nil, nil
+		}
+
+		return textResult("Orchestrated shutdown started. VMs, containers and the array will be stopped before the server powers off."), nil, nil
+	})
+
+	// IPMI chassis command tool
+	addWriteTool(s, &mcp.Tool{
+		Name:        "ipmi_chassis_action",
+		Description: "Send a chassis command to the BMC: identify (blink the chassis identify LED) or a power action: soft (ACPI shutdown), off (hard power off), cycle or reset. CAUTION: power actions bypass Unraid and do not stop the array; prefer system_orchestrated_shutdown unless the OS is unresponsive. Power actions require confirmation.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(true),
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPIPMIChassisActionArgs) (*mcp.CallToolResult, any, error) {
+		if controllers.IsIPMIPowerAction(args.Action) {
+			if denied := s.requireApproval(ctx, req, args.Confirm,
+				fmt.Sprintf("Send chassis power %s to the BMC? The array is not stopped first.", args.Action),
+				"Chassis power action not confirmed. Set 'confirm' to true to execute this action."); denied != nil {
+				return denied, nil, nil
+			}
+		}
+
+		logger.Info("MCP: IPMI chassis %s requested", args.Action)
+		if err := controllers.IPMIChassisAction(args.Action, args.IdentifySeconds); err != nil {
+			return textResult(fmt.Sprintf("Failed to send chassis %s: %v", args.Action, err)), nil, nil
+		}
+		return textResult(fmt.Sprintf("Chassis %s command sent to the BMC", args.Action)), nil, nil
+	})
+
 	// Parity check stop tool
 	addWriteTool(s, &mcp.Tool{
 		Name:        "parity_check_stop",
@@ -1635,9 +2344,11 @@ func (s *Server) registerControlTools() {
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr(true),
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPUserScriptArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Script execution not confirmed. Set 'confirm' to true to execute."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPUserScriptArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			fmt.Sprintf("Run user script '%s'?", args.ScriptName),
+			"Script execution not confirmed. Set 'confirm' to true to execute."); denied != nil {
+			return denied, nil, nil
 		}
 
 		if args.ScriptName == "" {
@@ -1739,9 +2450,11 @@ func (s *Server) registerNewControlTools() {
 			DestructiveHint: ptr(true),
 			IdempotentHint:  true,
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPContainerUpdateArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Container update requires confirm=true. This will stop, remove, and recreate the container with the latest image."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPContainerUpdateArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			fmt.Sprintf("Update container '%s'? It will be stopped, removed, and recreated with the latest image.", args.ContainerID),
+			"Container update requires confirm=true. This will stop, remove, and recreate the container with the latest image."); denied != nil {
+			return denied, nil, nil
 		}
 		logger.Info("MCP: Updating container '%s'", args.ContainerID)
 		dockerCtrl := controllers.NewDockerController()
@@ -1760,9 +2473,11 @@ func (s *Server) registerNewControlTools() {
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr(true),
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPContainerUpdateArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Bulk container update requires confirm=true. This will update ALL containers with available updates."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPContainerUpdateArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			"Update ALL containers that have updates available?",
+			"Bulk container update requires confirm=true. This will update ALL containers with available updates."); denied != nil {
+			return denied, nil, nil
 		}
 		logger.Info("MCP: Updating all containers")
 		dockerCtrl := controllers.NewDockerController()
@@ -1782,9 +2497,11 @@ func (s *Server) registerNewControlTools() {
 			DestructiveHint: ptr(true),
 			IdempotentHint:  true,
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPPluginUpdateArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Plugin update requires confirm=true."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPPluginUpdateArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			fmt.Sprintf("Update plugin '%s'?", args.PluginName),
+			"Plugin update requires confirm=true."); denied != nil {
+			return denied, nil, nil
 		}
 		if args.PluginName == "" {
 			return textResult("plugin_name is required for single plugin updates"), nil, nil
@@ -1805,9 +2522,11 @@ func (s *Server) registerNewControlTools() {
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr(true),
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPPluginUpdateArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Bulk plugin update requires confirm=true."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPPluginUpdateArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			"Update ALL plugins that have updates available?",
+			"Bulk plugin update requires confirm=true."); denied != nil {
+			return denied, nil, nil
 		}
 		logger.Info("MCP: Updating all plugins")
 		pluginCtrl := controllers.NewPluginController()
@@ -1867,9 +2586,11 @@ func (s *Server) registerNewControlTools() {
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr(true),
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPVMSnapshotRestoreArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Snapshot restore requires confirm=true. WARNING: This will revert the VM to the snapshot state — the current state will be lost."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPVMSnapshotRestoreArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			fmt.Sprintf("Restore VM '%s' to snapshot '%s'? Its current state will be lost.", args.VMName, args.SnapshotName),
+			"Snapshot restore requires confirm=true. WARNING: This will revert the VM to the snapshot state — the current state will be lost."); denied != nil {
+			return denied, nil, nil
 		}
 		if args.SnapshotName == "" {
 			return textResult("snapshot_name is required"), nil, nil
@@ -1914,9 +2635,11 @@ func (s *Server) registerNewControlTools() {
 			DestructiveHint: ptr(true),
 			IdempotentHint:  true,
 		},
-	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPServiceActionArgs) (*mcp.CallToolResult, any, error) {
-		if !args.Confirm {
-			return textResult("Service action requires confirm=true. This will modify the running state of a system service."), nil, nil
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPServiceActionArgs) (*mcp.CallToolResult, any, error) {
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			fmt.Sprintf("Run '%s' on system service '%s'?", args.Action, args.ServiceName),
+			"Service action requires confirm=true. This will modify the running state of a system service."); denied != nil {
+			return denied, nil, nil
 		}
 		logger.Info("MCP: Service action '%s' on '%s'", args.Action, args.ServiceName)
 		serviceCtrl := controllers.NewServiceController()
@@ -1991,10 +2714,8 @@ func (s *Server) registerRemediationTools() {
 		}
 
 		// Execute path — construct executor from live controllers.
-		dockerCtrl := controllers.NewDockerController()
-		defer func() { _ = dockerCtrl.Close() }()
-		vmCtrl := controllers.NewVMController()
-		exec := remediation.NewExecutor(dockerCtrl, vmCtrl)
+		exec, release := s.newRemediationExecutor()
+		defer release()
 
 		results := make([]dto.ActionResult, 0, len(args.Actions))
 		for _, a := range args.Actions {
@@ -2020,6 +2741,44 @@ func (s *Server) registerRemediationTools() {
 		})
 	})
 
+	// batch_actions — executes many container, VM and disk actions in one call.
+	addWriteTool(s, &mcp.Tool{
+		Name: "batch_actions",
+		Description: "Execute up to 100 container, VM and disk actions in one call (e.g. stop five containers and " +
+			"spin down three disks) instead of calling a tool per item. Actions run with limited concurrency " +
+			"(default 4, max 10); actions on the same target run in the given order and a failing action does not " +
+			"stop the others. Supported actions: start/stop/restart_container, start/stop/restart/force_stop_vm, " +
+			"spin_down_disk, spin_up_disk. Returns per-action results. Requires confirm=true.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(true),
+			IdempotentHint:  false,
+		},
+	}, func(ctx context.Context, toolReq *mcp.CallToolRequest, args dto.MCPBatchArgs) (*mcp.CallToolResult, any, error) {
+		if !args.Confirm {
+			return textResult("Batch execution requires confirm=true. Review the actions before running them."), nil, nil
+		}
+		req := dto.BatchRequest{Actions: args.Actions, Concurrency: args.Concurrency}
+		if err := remediation.ValidateBatch(req); err != nil {
+			return textResult(fmt.Sprintf("Invalid batch: %v", err)), nil, nil
+		}
+
+		exec, release := s.newRemediationExecutor()
+		defer release()
+
+		logger.Info("MCP batch_actions: executing %d actions (concurrency %d)", len(req.Actions), req.Concurrency)
+		result := remediation.RunBatch(ctx, exec, req)
+		// One entry per action, so the log shows what the batch changed and
+		// the affected collectors are refreshed
+		for _, ar := range result.Results {
+			status, detail := dto.AuditResultSuccess, ""
+			if !ar.Succeeded {
+				status, detail = dto.AuditResultFailure, ar.Error
+			}
+			s.recordToolAudit("batch_actions "+ar.Action, toolReq, map[string]string{"target": ar.Target}, status, detail)
+		}
+		return jsonResult(result)
+	})
+
 	// list_runbooks — read-only; returns the static catalogue of reviewed runbooks.
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "list_runbooks",
@@ -2061,10 +2820,8 @@ func (s *Server) registerRemediationTools() {
 			confirm = false
 		}
 
-		dockerCtrl := controllers.NewDockerController()
-		defer func() { _ = dockerCtrl.Close() }()
-		vmCtrl := controllers.NewVMController()
-		exec := remediation.NewExecutor(dockerCtrl, vmCtrl)
+		exec, release := s.newRemediationExecutor()
+		defer release()
 		results, steps, err := remediation.RunRunbook(ctx, exec, args.Name, confirm, targets)
 		if err != nil {
 			return textResult(fmt.Sprintf("run_runbook error: %v", err)), nil, nil
@@ -2173,7 +2930,7 @@ func (s *Server) registerRemediationTools() {
 		})
 	})
 
-	logger.Debug("MCP remediation tools registered (4 tools)")
+	logger.Debug("MCP remediation tools registered (5 tools)")
 }
 
 // registerAlertingTools registers MCP tools for alert rule management and monitoring.
@@ -2368,7 +3125,7 @@ func (s *Server) registerAlertingTools() {
 	// Query metric history
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "query_metric_history",
-		Description: "Query the in-memory ring-buffer history for a named metric series. Returns all buffered samples plus summary statistics (slope per second, min, max, average, last value). Global metrics (no entity): cpu_temp, array_used_pct. Per-entity metrics (provide entity id): disk_temp, disk_used_pct, disk_errors, reallocated, pending, restart_count.",
+		Description: "Query the history for a named metric series (the last hour at full resolution, the last 7 days as 5-minute averages), optionally limited to a time range (start/end as RFC 3339, or lookback such as 30m). Returns the samples plus summary statistics (slope per second, min, max, average, last value) and retention_seconds, how far back history reaches. Global metrics (no entity): cpu_usage, cpu_temp, motherboard_temp, ram_used_pct, array_used_pct. Per-entity metrics (provide entity id): disk_temp, disk_used_pct, disk_errors, reallocated, pending, restart_count, gpu_temp (entity is the GPU index).",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPMetricHistoryArgs) (*mcp.CallToolResult, any, error) {
 		if s.alertEngine == nil {
@@ -2377,10 +3134,53 @@ func (s *Server) registerAlertingTools() {
 		if args.Metric == "" {
 			return textResult("metric is required"), nil, nil
 		}
-		return jsonResult(s.alertEngine.QueryHistory(args.Metric, args.Entity))
+		from, to, err := alerting.ParseTimeRange(args.Start, args.End, args.Lookback, time.Now())
+		if err != nil {
+			return textResult(err.Error()), nil, nil
+		}
+		return jsonResult(s.alertEngine.QueryHistoryRange(args.Metric, args.Entity, from, to))
 	})
 
-	logger.Debug("MCP alerting tools registered (10 tools)")
+	// Compare a metric across two time periods
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "compare_periods",
+		Description: "Compare a metric series across two time periods (e.g. last night against the night before). Returns count/min/max/average for each period plus avg_delta, avg_delta_pct, and max_delta (period B minus period A). Uses the same metrics as query_metric_history; periods must fall within retention_seconds.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPComparePeriodsArgs) (*mcp.CallToolResult, any, error) {
+		if s.alertEngine == nil {
+			return textResult("Alerting engine not initialized"), nil, nil
+		}
+		if args.Metric == "" {
+			return textResult("metric is required"), nil, nil
+		}
+		if args.PeriodAStart == "" || args.PeriodAEnd == "" || args.PeriodBStart == "" || args.PeriodBEnd == "" {
+			return textResult("period_a_start, period_a_end, period_b_start, and period_b_end are required"), nil, nil
+		}
+		now := time.Now()
+		aFrom, aTo, err := alerting.ParseTimeRange(args.PeriodAStart, args.PeriodAEnd, "", now)
+		if err != nil {
+			return textResult("period A: " + err.Error()), nil, nil
+		}
+		bFrom, bTo, err := alerting.ParseTimeRange(args.PeriodBStart, args.PeriodBEnd, "", now)
+		if err != nil {
+			return textResult("period B: " + err.Error()), nil, nil
+		}
+		return jsonResult(s.alertEngine.ComparePeriods(args.Metric, args.Entity, aFrom, aTo, bFrom, bTo))
+	})
+
+	// Temperature history and thermal events
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_thermal_summary",
+		Description: "Get the current, maximum and average temperature over the last 24 hours for the CPU, motherboard, every disk and every GPU, with each sensor's warning/critical thresholds and sustained level, plus recent thermal events. A thermal event is raised (and an Unraid notification created) only when a sensor stays at or above a threshold for 5 minutes, so momentary spikes are ignored. Disk thresholds come from the Unraid disk settings (HDD or SSD) or per-disk overrides.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		if s.alertEngine == nil {
+			return textResult("Alerting engine not initialized"), nil, nil
+		}
+		return jsonResult(s.alertEngine.ThermalSummary(time.Now()))
+	})
+
+	logger.Debug("MCP alerting tools registered (12 tools)")
 }
 
 // registerWatchdogTools registers MCP tools for health check management and monitoring.
@@ -2420,7 +3220,7 @@ func (s *Server) registerWatchdogTools() {
 	// Create health check
 	addWriteTool(s, &mcp.Tool{
 		Name:        "create_health_check",
-		Description: "Create a new health check probe (HTTP, TCP, or container state). Probes run at configurable intervals with optional remediation actions on failure (notify, restart container, or webhook).",
+		Description: "Create a new health check probe (HTTP, TCP, or container state). Probes run at configurable intervals with optional remediation actions on failure (notify, restart container, or webhook). Set container to link an HTTP/TCP probe to a container so its results appear on that container.",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr(false),
 			IdempotentHint:  true,
@@ -2436,6 +3236,11 @@ func (s *Server) registerWatchdogTools() {
 		if checkType != dto.HealthCheckHTTP && checkType != dto.HealthCheckTCP && checkType != dto.HealthCheckContainer {
 			return textResult("type must be http, tcp, or container"), nil, nil
 		}
+		if args.Container != "" {
+			if err := lib.ValidateContainerRef(args.Container); err != nil {
+				return textResult(err.Error()), nil, nil
+			}
+		}
 		enabled := args.Enabled
 		if args.ID != "" && !args.Enabled {
 			enabled = true // Default to enabled when creating
@@ -2450,6 +3255,7 @@ func (s *Server) registerWatchdogTools() {
 			SuccessCode:     args.SuccessCode,
 			OnFail:          args.OnFail,
 			Enabled:         enabled,
+			Container:       args.Container,
 		}
 		if err := s.watchdogStore.CreateCheck(check); err != nil {
 			return textResult(fmt.Sprintf("Failed to create health check: %v", err)), nil, nil
@@ -2538,6 +3344,138 @@ func (s *Server) registerWatchdogTools() {
 	logger.Debug("MCP watchdog tools registered (7 tools)")
 }
 
+// registerTransferTools registers MCP tools for rsync/rclone transfer jobs.
+func (s *Server) registerTransferTools() {
+	// List transfer jobs
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "list_transfer_jobs",
+		Description: "List the configured rsync and rclone transfer jobs with their source, destination, flags and schedule",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		if s.transferRunner == nil {
+			return textResult("Transfer runner not initialized"), nil, nil
+		}
+		jobs := s.transferRunner.Store().GetJobs()
+		if len(jobs) == 0 {
+			return textResult("No transfer jobs configured"), nil, nil
+		}
+		return jsonResult(jobs)
+	})
+
+	// Transfer runs with live progress
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_transfer_runs",
+		Description: "Get running transfers with live progress (bytes, percent, speed, ETA) followed by the last 50 finished runs and their outcome. Optionally filter by job_id.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, args dto.MCPTransferRunsArgs) (*mcp.CallToolResult, any, error) {
+		if s.transferRunner == nil {
+			return textResult("Transfer runner not initialized"), nil, nil
+		}
+		runs := make([]dto.TransferRun, 0)
+		for _, run := range s.transferRunner.Runs() {
+			if args.JobID == "" || run.JobID == args.JobID {
+				runs = append(runs, run)
+			}
+		}
+		if len(runs) == 0 {
+			return textResult("No transfer runs recorded"), nil, nil
+		}
+		return jsonResult(runs)
+	})
+
+	// Run or cancel a transfer job
+	addWriteTool(s, &mcp.Tool{
+		Name:        "transfer_job_action",
+		Description: "Run or cancel a transfer job (job_id as reported by list_transfer_jobs). Running a job copies, syncs or moves files as configured, which may overwrite or delete files at the destination; it requires confirm=true. Follow progress with get_transfer_runs.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(true),
+		},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args dto.MCPTransferJobActionArgs) (*mcp.CallToolResult, any, error) {
+		if s.transferRunner == nil {
+			return textResult("Transfer runner not initialized"), nil, nil
+		}
+		logger.Info("MCP: Transfer job action '%s' requested for '%s'", args.Action, args.JobID)
+
+		switch strings.ToLower(strings.TrimSpace(args.Action)) {
+		case "run":
+			if !args.Confirm {
+				return textResult("Running a transfer job requires confirm=true. Files at the destination may be overwritten or deleted."), nil, nil
+			}
+			run, err := s.transferRunner.Run(context.WithoutCancel(ctx), args.JobID, transfer.TriggerManual)
+			if err != nil {
+				return textResult(fmt.Sprintf("Failed to run transfer job '%s': %v", args.JobID, err)), nil, nil
+			}
+			return jsonResult(run)
+		case "cancel":
+			if err := s.transferRunner.Cancel(args.JobID); err != nil {
+				return textResult(fmt.Sprintf("Failed to cancel transfer job '%s': %v", args.JobID, err)), nil, nil
+			}
+			return textResult(fmt.Sprintf("Cancelled transfer job '%s'", args.JobID)), nil, nil
+		default:
+			return textResult("action must be run or cancel"), nil, nil
+		}
+	})
+
+	logger.Debug("MCP transfer tools registered (3 tools)")
+}
+
+// registerScriptTools registers MCP tools for scripts registered with the agent.
+func (s *Server) registerScriptTools() {
+	// List registered scripts
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "list_scripts",
+		Description: "List scripts registered with the agent (independent of the User Scripts plugin) with their body, default arguments and environment",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
+		if s.scriptStore == nil {
+			return textResult("Script registry not initialized"), nil, nil
+		}
+		list, err := s.scriptStore.List()
+		if err != nil {
+			return textResult(fmt.Sprintf("Failed to list scripts: %v", err)), nil, nil
+		}
+		if len(list) == 0 {
+			return textResult("No scripts registered"), nil, nil
+		}
+		return jsonResult(list)
+	})
+
+	// Execute a registered script
+	addWriteTool(s, &mcp.Tool{
+		Name:        "execute_script",
+		Description: "Run a script registered with the agent (script_name as reported by list_scripts) and wait for its output. args replace the script's default arguments and env is merged over its environment. Requires confirmation for safety.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr(true),
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args dto.MCPScriptExecuteArgs) (*mcp.CallToolResult, any, error) {
+		if s.scriptStore == nil {
+			return textResult("Script registry not initialized"), nil, nil
+		}
+		if denied := s.requireApproval(ctx, req, args.Confirm,
+			fmt.Sprintf("Run script '%s'?", args.ScriptName),
+			"Script execution not confirmed. Set 'confirm' to true to execute."); denied != nil {
+			return denied, nil, nil
+		}
+		if args.ScriptName == "" {
+			return textResult("script_name is required"), nil, nil
+		}
+
+		logger.Info("MCP: Script execution requested for '%s' (confirmed)", args.ScriptName)
+
+		response, err := s.scriptStore.Execute(args.ScriptName, dto.ScriptExecuteRequest{
+			Args: args.Args,
+			Env:  args.Env,
+			Wait: true,
+		})
+		if err != nil {
+			return textResult(fmt.Sprintf("Failed to execute script: %v", err)), nil, nil
+		}
+		return jsonResult(response)
+	})
+
+	logger.Debug("MCP script tools registered (2 tools)")
+}
+
 // registerAgentTools registers tools that drive the embedded autonomous agent.
 func (s *Server) registerAgentTools() {
 	type startArgs struct {
@@ -2737,7 +3675,121 @@ func (s *Server) registerResources() {
 		return resourceResult("unraid://disks", string(data))
 	})
 
-	logger.Debug("MCP resources registered (5 resources)")
+	// Audit log resource
+	s.mcpServer.AddResource(&mcp.Resource{
+		URI:         "unraid://audit",
+		Name:        "audit-log",
+		Description: "Most recent state-changing operations received over REST, MCP, and MQTT",
+		MIMEType:    "application/json",
+	}, func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		if s.auditLog == nil {
+			return resourceResult("unraid://audit", `{"error": "Audit log not available"}`)
+		}
+		entries := s.auditLog.Query(dto.AuditFilter{})
+		data, _ := json.Marshal(dto.AuditLogResponse{Entries: entries, Total: len(entries)})
+		return resourceResult("unraid://audit", string(data))
+	})
+
+	// Agent log resource
+	s.mcpServer.AddResource(&mcp.Resource{
+		URI:         "unraid://agent/logs",
+		Name:        "agent-log",
+		Description: "Most recent entries of the agent's own log, for debugging the agent without shell access",
+		MIMEType:    "application/json",
+	}, func(_ context.Context, _ *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		result, err := agentlog.Read(s.ctx.LogsDir, agentlog.Query{MinLevel: logger.LevelDebug})
+		if err != nil {
+			return resourceResult("unraid://agent/logs", fmt.Sprintf(`{"error": %q}`, err.Error()))
+		}
+		data, _ := json.Marshal(result)
+		return resourceResult("unraid://agent/logs", string(data))
+	})
+
+	// Cache-backed resources for the remaining collected data, so clients
+	// can browse everything without calling tools.
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://shares",
+		Name:        "share-list",
+		Description: "User shares with usage and configuration",
+		MIMEType:    "application/json",
+	}, "Share information", s.cacheProvider.GetSharesCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://network",
+		Name:        "network-interfaces",
+		Description: "Network interfaces with addresses and traffic statistics",
+		MIMEType:    "application/json",
+	}, "Network information", s.cacheProvider.GetNetworkCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://ups",
+		Name:        "ups-status",
+		Description: "UPS status, battery charge, load, and runtime",
+		MIMEType:    "application/json",
+	}, "UPS status", s.cacheProvider.GetUPSCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://gpu",
+		Name:        "gpu-metrics",
+		Description: "GPU utilization, memory, temperature, and power metrics",
+		MIMEType:    "application/json",
+	}, "GPU metrics", s.cacheProvider.GetGPUCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://pools",
+		Name:        "pools",
+		Description: "Unraid pools with devices, btrfs allocation, and balance/scrub state",
+		MIMEType:    "application/json",
+	}, "Pool information", s.cacheProvider.GetPoolsCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://btrfs",
+		Name:        "btrfs",
+		Description: "Btrfs per-device error counters and scrub results",
+		MIMEType:    "application/json",
+	}, "Btrfs statistics", s.cacheProvider.GetBtrfsCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://zfs/pools",
+		Name:        "zfs-pools",
+		Description: "ZFS pools with health, capacity, and vdev layout",
+		MIMEType:    "application/json",
+	}, "ZFS pool information", s.cacheProvider.GetZFSPoolsCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://zfs/datasets",
+		Name:        "zfs-datasets",
+		Description: "ZFS datasets with usage and properties",
+		MIMEType:    "application/json",
+	}, "ZFS dataset information", s.cacheProvider.GetZFSDatasetsCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://notifications",
+		Name:        "notifications",
+		Description: "Unraid notifications (unread and archived)",
+		MIMEType:    "application/json",
+	}, "Notifications", s.cacheProvider.GetNotificationsCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://parity-history",
+		Name:        "parity-history",
+		Description: "Parity check history with duration, speed, and errors",
+		MIMEType:    "application/json",
+	}, "Parity check history", s.cacheProvider.GetParityHistoryCache)
+	addCacheResource(s, &mcp.Resource{
+		URI:         "unraid://collectors",
+		Name:        "collectors-status",
+		Description: "Status and intervals of all data collectors",
+		MIMEType:    "application/json",
+	}, "Collector status", s.cacheProvider.GetCollectorsStatus)
+
+	logger.Debug("MCP resources registered (15 resources)")
+}
+
+// addCacheResource registers a JSON resource served from the cache. A nil
+// value (nothing collected yet) is reported as an error object naming what is
+// not available, like the hand-written resources above.
+func addCacheResource[T any](s *Server, resource *mcp.Resource, what string, get func() T) {
+	s.mcpServer.AddResource(resource, func(context.Context, *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		value := get()
+		if rv := reflect.ValueOf(value); !rv.IsValid() ||
+			(rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Slice) && rv.IsNil() {
+			return resourceResult(resource.URI, fmt.Sprintf(`{"error": "%s not available"}`, what))
+		}
+		data, _ := json.Marshal(value)
+		return resourceResult(resource.URI, string(data))
+	})
 }
 
 // registerPrompts registers MCP prompts for guided interactions.
@@ -2995,22 +4047,107 @@ Please describe your issue and I'll gather the relevant system information to he
 // agent runs in read-only mode.
 const readOnlyBlockedMessage = "This operation is blocked: the agent is running in read-only mode"
 
+// mockRunsTool lists the write tools whose handlers still run in mock mode:
+// they only change the agent's own configuration, or act through
+// newRemediationExecutor, which simulates the actions.
+var mockRunsTool = map[string]bool{
+	"batch_actions":             true,
+	"collector_action":          true,
+	"update_collector_interval": true,
+	"create_alert_rule":         true,
+	"delete_alert_rule":         true,
+	"enable_alert_template":     true,
+	"create_health_check":       true,
+	"delete_health_check":       true,
+}
+
+// newRemediationExecutor returns the executor for container, VM and disk
+// actions and a function releasing its controllers. In mock mode the
+// actions are simulated.
+func (s *Server) newRemediationExecutor() (*remediation.Executor, func()) {
+	if s.ctx.Mock {
+		return remediation.NewExecutor(mock.Actor{}, mock.Actor{}).WithDisks(mock.Actor{}), func() {}
+	}
+	dockerCtrl := controllers.NewDockerController()
+	exec := remediation.NewExecutor(dockerCtrl, controllers.NewVMController()).
+		WithDisks(controllers.NewArrayController(s.ctx))
+	return exec, func() { _ = dockerCtrl.Close() }
+}
+
 // addWriteTool registers a state-changing MCP tool with a read-only mode
 // guard. The tool stays visible in listings (less confusing for clients),
 // but every invocation is rejected before reaching the handler while the
 // agent runs in read-only mode. Read-only tools keep using mcp.AddTool
 // directly. Tools with both a read and an execute path (system_health_report,
 // run_runbook) instead guard only their execute path inline.
+//
+// Every invocation, including blocked ones, is recorded to the audit log.
 func addWriteTool[In any](s *Server, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, any]) {
 	mcp.AddTool(s.mcpServer, tool, func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
 		if s.ctx.ReadOnly {
 			logger.Warning("MCP: blocked write tool '%s': agent is in read-only mode", tool.Name)
+			s.recordToolAudit(tool.Name, req, args, dto.AuditResultDenied, readOnlyBlockedMessage)
 			return textResult(readOnlyBlockedMessage), nil, nil
 		}
-		return handler(ctx, req, args)
+		if s.ctx.Mock && !mockRunsTool[tool.Name] {
+			if err := mock.SimulateAction(ctx); err != nil {
+				return nil, nil, err
+			}
+			logger.Info("MCP: mock mode, simulated write tool '%s'", tool.Name)
+			s.recordToolAudit(tool.Name, req, args, dto.AuditResultSuccess, "simulated (mock mode)")
+			return textResult(fmt.Sprintf("Mock mode: %s simulated, nothing was changed", tool.Name)), nil, nil
+		}
+		result, out, err := handler(ctx, req, args)
+		outcome, detail := toolAuditOutcome(result, err)
+		s.recordToolAudit(tool.Name, req, args, outcome, detail)
+		return result, out, err
 	})
 }
 
+// recordToolAudit writes an audit entry for an MCP write tool call. The tool
+// arguments are serialized as the target so the entry shows exactly what was
+// requested.
+func (s *Server) recordToolAudit(toolName string, req *mcp.CallToolRequest, args any, result, detail string) {
+	if s.auditLog == nil {
+		return
+	}
+	entry := dto.AuditEntry{
+		Source: dto.AuditSourceMCP,
+		Action: toolName,
+		Result: result,
+		Detail: detail,
+	}
+	if req != nil && req.Session != nil {
+		entry.Client = req.Session.ID()
+	}
+	if data, err := json.Marshal(args); err == nil && string(data) != "{}" && string(data) != "null" {
+		entry.Target = string(data)
+	}
+	s.auditLog.Record(entry)
+}
+
+// toolAuditOutcome derives the audit result from a tool handler's return
+// values. Handlers report most failures as plain text, so the first text
+// block is kept as the detail.
+func toolAuditOutcome(result *mcp.CallToolResult, err error) (string, string) {
+	if err != nil {
+		return dto.AuditResultFailure, err.Error()
+	}
+	detail := ""
+	if result != nil {
+		for _, c := range result.Content {
+			if tc, ok := c.(*mcp.TextContent); ok {
+				detail = tc.Text
+				break
+			}
+		}
+		if result.IsError {
+			return dto.AuditResultFailure, detail
+		}
+	}
+	return dto.AuditResultSuccess, detail
+}
+
 // textResult creates a tool result with text content.
 func textResult(text string) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
@@ -3219,31 +4356,14 @@ func (s *Server) registerCPUControlTools() {
 	// Get Docker aggregate stats (monitoring)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_docker_stats",
-		Description: "Get aggregate CPU and memory statistics across all running Docker containers, including total CPU%, total memory usage (bytes and MB), and per-container breakdown",
+		Description: "Get aggregate CPU and memory statistics across all running Docker containers, including total CPU%, total memory usage (bytes and MB), and the top 10 containers by CPU and by memory",
 		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
 	}, func(_ context.Context, _ *mcp.CallToolRequest, _ dto.MCPEmptyArgs) (*mcp.CallToolResult, any, error) {
-		containers := s.cacheProvider.GetDockerCache()
-		if containers == nil {
+		summary := s.cacheProvider.GetDockerStatsSummaryCache()
+		if summary == nil {
 			return textResult("Docker information not available yet"), nil, nil
 		}
-
-		var stats dto.DockerAggregateStats
-		for i := range containers {
-			c := &containers[i]
-			stats.TotalContainers++
-			if c.State == "running" {
-				stats.RunningContainers++
-				stats.TotalCPUPercent += c.CPUPercent
-				stats.TotalMemoryUsage += c.MemoryUsage
-				stats.TotalMemoryUsageMB += c.MemoryUsageMB
-				stats.TotalMemoryLimit += c.MemoryLimit
-			}
-		}
-		if stats.TotalMemoryLimit > 0 {
-			stats.MemoryUsagePercent = float64(stats.TotalMemoryUsage) / float64(stats.TotalMemoryLimit) * 100
-		}
-		stats.Timestamp = time.Now()
-		return jsonResult(stats)
+		return jsonResult(summary)
 	})
 
 	// Get all temperature sensors (monitoring)
@@ -35,9 +35,14 @@ type MockCacheProvider struct {
 	zfsDatasets   []dto.ZFSDataset
 	zfsSnapshots  []dto.ZFSSnapshot
 	zfsARCStats   *dto.ZFSARCStats
+	pools         []dto.PoolInfo
+	btrfs         []dto.BtrfsFilesystem
 	unassigned    *dto.UnassignedDeviceList
 	nutResponse   *dto.NUTResponse
 	parityHistory *dto.ParityCheckHistory
+	connect       *dto.ConnectStatus
+	dockerStats   *dto.DockerStatsSummary
+	freshness     dto.CacheFreshness
 	// Log and collector mock data
 	logFiles           []dto.LogFile
 	collectorsStatus   dto.CollectorsStatusResponse
@@ -68,9 +73,24 @@ func (m *MockCacheProvider) GetParityHistoryCache() *dto.ParityCheckHistory { re
 func (m *MockCacheProvider) GetFanControlCache() *dto.FanControlStatus      { return nil }
 func (m *MockCacheProvider) GetTuningCache() *dto.TuningInfo                { return nil }
 func (m *MockCacheProvider) GetDockerNetworksCache() *dto.DockerNetworkList { return nil }
-func (m *MockCacheProvider) GetPluginUpdatesCache() *dto.PluginList         { return nil }
-func (m *MockCacheProvider) GetOSUpdateCache() *dto.OSUpdateStatus          { return nil }
-func (m *MockCacheProvider) GetMoverCache() *dto.MoverStatus                { return nil }
+func (m *MockCacheProvider) GetDockerStatsSummaryCache() *dto.DockerStatsSummary {
+	return m.dockerStats
+}
+func (m *MockCacheProvider) GetPluginUpdatesCache() *dto.PluginList        { return nil }
+func (m *MockCacheProvider) GetOSUpdateCache() *dto.OSUpdateStatus         { return nil }
+func (m *MockCacheProvider) GetMoverCache() *dto.MoverStatus               { return nil }
+func (m *MockCacheProvider) GetDNSHealthCache() *dto.DNSHealth             { return nil }
+func (m *MockCacheProvider) GetWANStatusCache() *dto.WANStatus             { return nil }
+func (m *MockCacheProvider) GetRemoteMountsCache() *dto.RemoteMountHealth  { return nil }
+func (m *MockCacheProvider) GetSpeedtestCache() *dto.SpeedtestStatus       { return nil }
+func (m *MockCacheProvider) GetPowerCache() *dto.PowerEstimate             { return nil }
+func (m *MockCacheProvider) GetPoolsCache() []dto.PoolInfo                 { return m.pools }
+func (m *MockCacheProvider) GetBtrfsCache() []dto.BtrfsFilesystem          { return m.btrfs }
+func (m *MockCacheProvider) GetRecycleBinCache() *dto.RecycleBinStatus     { return nil }
+func (m *MockCacheProvider) GetIPMICache() *dto.IPMIStatus                 { return nil }
+func (m *MockCacheProvider) GetConnectCache() *dto.ConnectStatus           { return m.connect }
+func (m *MockCacheProvider) GetDiskSpinHistoryCache() *dto.DiskSpinHistory { return nil }
+func (m *MockCacheProvider) CacheFreshness(...string) dto.CacheFreshness   { return m.freshness }
 
 // Log methods
 func (m *MockCacheProvider) ListLogFiles() []dto.LogFile { return m.logFiles }
@@ -85,6 +105,21 @@ func (m *MockCacheProvider) GetLogContent(path, lines, start string) (*dto.LogFi
 		EndLine:       3,
 	}, nil
 }
+func (m *MockCacheProvider) SearchLogs(query dto.LogSearchQuery) (*dto.LogSearchResult, error) {
+	return &dto.LogSearchResult{
+		Query:        query.Query,
+		Files:        []string{"/var/log/syslog"},
+		Matches:      []dto.LogSearchMatch{{File: "/var/log/syslog", Line: 2, Text: "Test log content line 2"}},
+		TotalMatches: 1,
+	}, nil
+}
+func (m *MockCacheProvider) GetFleetOverview(_ context.Context) (*dto.FleetOverview, error) {
+	return &dto.FleetOverview{
+		Members:   []dto.FleetMember{{Name: "local", Local: true, Reachable: true, Hostname: "test-server"}},
+		Total:     1,
+		Reachable: 1,
+	}, nil
+}
 
 // Collector methods
 func (m *MockCacheProvider) GetCollectorsStatus() dto.CollectorsStatusResponse {
@@ -214,6 +249,19 @@ func newMockCacheProvider() *MockCacheProvider {
 			State: "valid",
 			Type:  "Pro",
 		},
+		dockerStats: &dto.DockerStatsSummary{
+			DockerAggregateStats: dto.DockerAggregateStats{TotalCPUPercent: 7.5, TotalMemoryUsageMB: 1536, RunningContainers: 2, TotalContainers: 3},
+			TopCPU:               []dto.ContainerUsage{{ID: "abc123", Name: "plex", CPUPercent: 5}, {ID: "def456", Name: "sonarr", CPUPercent: 2.5}},
+			TopMemory:            []dto.ContainerUsage{{ID: "abc123", Name: "plex", MemoryUsageMB: 1024}, {ID: "def456", Name: "sonarr", MemoryUsageMB: 512}},
+		},
+		connect: &dto.ConnectStatus{
+			Installed:      true,
+			SignedIn:       true,
+			CloudStatus:    "CONNECTED",
+			CloudConnected: true,
+			FlashBackup:    dto.ConnectFlashBackup{Activated: true},
+			RemoteAccess:   dto.ConnectRemoteAccess{Type: "disabled"},
+		},
 		notifications: &dto.NotificationList{
 			Notifications: []dto.Notification{
 				{ID: "1", Subject: "Test Alert", Description: "This is a test notification", Importance: "alert"},
@@ -222,6 +270,12 @@ func newMockCacheProvider() *MockCacheProvider {
 		zfsPools: []dto.ZFSPool{
 			{Name: "tank", Health: "ONLINE", SizeBytes: 8 * 1024 * 1024 * 1024 * 1024},
 		},
+		pools: []dto.PoolInfo{
+			{Name: "cache", FileSystem: "btrfs", Profile: "raid1", Devices: []dto.PoolDevice{{Slot: "cache", Device: "nvme0n1"}}},
+		},
+		btrfs: []dto.BtrfsFilesystem{
+			{Name: "cache", MountPoint: "/mnt/cache", Devices: []dto.BtrfsDeviceStats{{Device: "/dev/nvme0n1p1", CorruptionErrors: 2}}, TotalErrors: 2},
+		},
 		zfsDatasets: []dto.ZFSDataset{
 			{Name: "tank/data", UsedBytes: 1024 * 1024 * 1024 * 1024},
 		},
@@ -3,6 +3,8 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -362,6 +364,63 @@ func TestToolGetZFSPools(t *testing.T) {
 	})
 }
 
+func TestToolGetPools(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	_, text := callToolJSON(t, cs, "get_pools", nil)
+	if !strings.Contains(text, "raid1") {
+		t.Errorf("expected pool profile, got %s", text)
+	}
+
+	_, text = callToolJSON(t, cs, "get_pools", map[string]any{"pool_name": "cache"})
+	if !strings.Contains(text, "nvme0n1") {
+		t.Errorf("expected specific pool, got %s", text)
+	}
+
+	_, text = callToolJSON(t, cs, "get_pools", map[string]any{"pool_name": "nonexist"})
+	if !strings.Contains(text, "not found") {
+		t.Errorf("expected not found, got %s", text)
+	}
+}
+
+func TestToolGetBtrfsStats(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	_, text := callToolJSON(t, cs, "get_btrfs_stats", nil)
+	if !strings.Contains(text, "corruption_errs") || !strings.Contains(text, "/dev/nvme0n1p1") {
+		t.Errorf("expected device stats, got %s", text)
+	}
+}
+
+func TestToolNetworkDiagnosticsRejected(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	// The test context has no DIAGNOSTICS_TARGETS, so every target is refused.
+	tests := []struct {
+		tool string
+		args map[string]any
+		want string
+	}{
+		{"ping_host", map[string]any{"host": "1.1.1.1"}, "Ping rejected"},
+		{"dns_lookup", map[string]any{"host": "github.com"}, "DNS lookup rejected"},
+		{"http_check", map[string]any{"url": "https://github.com/"}, "HTTP check rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			_, text := callToolJSON(t, cs, tt.tool, tt.args)
+			if !strings.Contains(text, tt.want) || !strings.Contains(text, "allow list") {
+				t.Errorf("expected %q, got %s", tt.want, text)
+			}
+		})
+	}
+}
+
 func TestToolGetZFSDatasets(t *testing.T) {
 	server, _ := setupInitializedServer(t)
 	cs, cleanup := connectClientToServer(t, server)
@@ -470,6 +529,37 @@ func TestToolGetSyslog(t *testing.T) {
 	}
 }
 
+func TestToolSearchLogs(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	t.Run("with query", func(t *testing.T) {
+		_, text := callToolJSON(t, cs, "search_logs", map[string]any{"query": "line 2", "since": "1h"})
+		if !strings.Contains(text, "Test log content line 2") {
+			t.Errorf("expected matching line, got %s", text)
+		}
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		_, text := callToolJSON(t, cs, "search_logs", nil)
+		if !strings.Contains(text, "required") {
+			t.Errorf("expected 'required' error")
+		}
+	})
+}
+
+func TestToolGetFleetStatus(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	_, text := callToolJSON(t, cs, "get_fleet_status", nil)
+	if !strings.Contains(text, "test-server") {
+		t.Errorf("expected local member, got %s", text)
+	}
+}
+
 func TestToolGetDockerLog(t *testing.T) {
 	server, _ := setupInitializedServer(t)
 	cs, cleanup := connectClientToServer(t, server)
@@ -705,6 +795,16 @@ func TestToolGetDiagnosticSummary(t *testing.T) {
 	if _, ok := summary["stopped_containers"]; !ok {
 		t.Error("expected 'stopped_containers' in diagnostic summary")
 	}
+	if connect, ok := summary["connect"].(map[string]any); !ok || connect["cloud_connected"] != true {
+		t.Errorf("expected Unraid Connect status in diagnostic summary, got %v", summary["connect"])
+	}
+	docker, ok := summary["docker"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected 'docker' in diagnostic summary, got %v", summary["docker"])
+	}
+	if topCPU, _ := docker["top_cpu"].([]any); len(topCPU) != 2 || topCPU[0].(map[string]any)["name"] != "plex" {
+		t.Errorf("expected plex first in top_cpu, got %v", docker["top_cpu"])
+	}
 	// backup container is exited, should appear in stopped_containers
 	stoppedList, ok := summary["stopped_containers"].([]any)
 	if !ok {
@@ -745,6 +845,12 @@ func TestToolsNilCaches(t *testing.T) {
 		{"get_hardware_info", "not available"},
 		{"get_registration", "not available"},
 		{"get_notifications", "not available"},
+		{"get_pools", "No pools"},
+		{"get_btrfs_stats", "No btrfs"},
+		{"get_recycle_bin", "not available"},
+		{"get_ipmi_sensors", "not available"},
+		{"get_array_stop_impact", "not available"},
+		{"get_disk_spin_history", "not available"},
 		{"get_zfs_pools", "No ZFS pools"},
 		{"get_zfs_datasets", "No ZFS datasets"},
 		{"get_zfs_snapshots", "No ZFS snapshots"},
@@ -1233,3 +1339,54 @@ func TestResourceReadDisksNilCache(t *testing.T) {
 		t.Errorf("expected not available message")
 	}
 }
+
+func TestResourceReadAgentLog(t *testing.T) {
+	server, _ := setupInitializedServer(t)
+	server.ctx.LogsDir = t.TempDir()
+	content := "2026/10/17 09:00:00 DEBUG: MQTT: publishing discovery\n"
+	if err := os.WriteFile(filepath.Join(server.ctx.LogsDir, "unraid-management-agent.log"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	result, err := cs.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "unraid://agent/logs"})
+	if err != nil {
+		t.Fatalf("ReadResource error: %v", err)
+	}
+	if text := result.Contents[0].Text; !strings.Contains(text, "MQTT: publishing discovery") || !strings.Contains(text, `"level":"debug"`) {
+		t.Errorf("content = %s", text)
+	}
+}
+
+func TestResourceReadCacheResources(t *testing.T) {
+	server, mock := setupInitializedServer(t)
+	mock.ups = nil
+	cs, cleanup := connectClientToServer(t, server)
+	defer cleanup()
+
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"unraid://shares", "appdata"},
+		{"unraid://network", "eth0"},
+		{"unraid://gpu", "RTX 3080"},
+		{"unraid://collectors", "collectors"},
+		{"unraid://ups", `"error": "UPS status not available"`},
+		{"unraid://zfs/pools", "tank"},
+		{"unraid://pools", "raid1"},
+		{"unraid://btrfs", "corruption_errs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			result, err := cs.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: tt.uri})
+			if err != nil {
+				t.Fatalf("ReadResource error: %v", err)
+			}
+			if text := result.Contents[0].Text; !strings.Contains(text, tt.want) {
+				t.Errorf("content = %s, want it to contain %q", text[:min(len(text), 100)], tt.want)
+			}
+		})
+	}
+}
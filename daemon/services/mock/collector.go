@@ -0,0 +1,107 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/collectors"
+)
+
+// publishers maps each collector with synthetic data to the function that
+// publishes one reading of it.
+var publishers = map[string]func(*domain.Context, time.Time){
+	"system": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicSystemUpdate, systemInfo(ctx, now))
+	},
+	"array": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicArrayStatusUpdate, arrayStatus(now))
+	},
+	"disk": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicDiskListUpdate, disks(now))
+	},
+	"docker": func(ctx *domain.Context, now time.Time) {
+		list := containers(now)
+		domain.Publish(ctx.Hub, constants.TopicContainerListUpdate, list)
+		domain.Publish(ctx.Hub, constants.TopicDockerStatsUpdate, collectors.SummarizeDockerStats(list, now))
+	},
+	"vm": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicVMListUpdate, vms(now))
+	},
+	"ups": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicUPSStatusUpdate, upsStatus(now))
+	},
+	"gpu": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicGPUMetricsUpdate, gpus(now))
+	},
+	"shares": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicShareListUpdate, shares(now))
+	},
+	"network": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicNetworkListUpdate, networkInterfaces(now))
+	},
+	"notification": func(ctx *domain.Context, now time.Time) {
+		domain.Publish(ctx.Hub, constants.TopicNotificationsUpdate, notifications(now))
+	},
+}
+
+// Names returns the collectors that have synthetic data, sorted.
+func Names() []string {
+	names := make([]string, 0, len(publishers))
+	for name := range publishers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Collector publishes synthetic data for one collector name.
+type Collector struct {
+	ctx     *domain.Context
+	name    string
+	publish func(*domain.Context, time.Time)
+	refresh chan struct{}
+}
+
+// NewCollector returns the mock collector for name, or nil if there is no
+// synthetic data for it.
+func NewCollector(ctx *domain.Context, name string) *Collector {
+	publish, ok := publishers[name]
+	if !ok {
+		return nil
+	}
+	return &Collector{ctx: ctx, name: name, publish: publish, refresh: make(chan struct{}, 1)}
+}
+
+// RequestRefresh publishes a new reading right away.
+func (c *Collector) RequestRefresh() {
+	select {
+	case c.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// Start publishes a reading now and then every interval until ctx ends.
+func (c *Collector) Start(ctx context.Context, interval time.Duration) {
+	logger.InfoContext(ctx, "Starting mock %s collector (interval: %v)", c.name, interval)
+
+	c.publish(c.ctx, time.Now())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "Mock %s collector stopping due to context cancellation", c.name)
+			return
+		case <-ticker.C:
+			c.publish(c.ctx, time.Now())
+		case <-c.refresh:
+			c.publish(c.ctx, time.Now())
+		}
+	}
+}
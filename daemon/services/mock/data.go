@@ -0,0 +1,464 @@
+package mock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+const (
+	gib = uint64(1) << 30
+	tb  = uint64(1_000_000_000_000)
+)
+
+// The synthetic server: a 16-thread Ryzen with 64 GiB of RAM, one parity
+// and four data disks, an NVMe cache pool, six containers and two VMs.
+const (
+	mockHostname = "Tower"
+	mockVersion  = "7.1.4"
+	mockRAM      = 64 * gib
+)
+
+// mockDisk describes one synthetic disk. usedBytes grows by growthPerSec.
+// slot is the Unraid slot index, or -1 for an unassigned device.
+type mockDisk struct {
+	name, device, role, model, serial, fs, port string
+	slot                                        int
+	size, usedBytes                             uint64
+	growthPerSec                                float64
+	temp                                        float64 // 0 = spun down
+	ssd                                         bool
+}
+
+var mockDisks = []mockDisk{
+	{name: "parity", device: "sdb", role: "parity", model: "WDC WD161KFGX-68AFPN0", serial: "2BG4ZK8N", port: "ata1", slot: 0, size: 16 * tb, temp: 34},
+	{name: "disk1", device: "sdc", role: "data", model: "ST12000VN0008-2YS101", serial: "ZRT0A1B2", fs: "xfs", port: "ata2", slot: 1, size: 12 * tb, usedBytes: 9_140 * gib, growthPerSec: 2_000_000, temp: 36},
+	{name: "disk2", device: "sdd", role: "data", model: "ST12000VN0008-2YS101", serial: "ZRT0C3D4", fs: "xfs", port: "ata3", slot: 2, size: 12 * tb, usedBytes: 7_380 * gib, growthPerSec: 500_000, temp: 35},
+	{name: "disk3", device: "sde", role: "data", model: "WDC WD80EFZZ-68BTXN0", serial: "WD-CA1B2C3D", fs: "xfs", port: "ata4", slot: 3, size: 8 * tb, usedBytes: 2_210 * gib},
+	{name: "disk4", device: "sdf", role: "data", model: "WDC WD80EFZZ-68BTXN0", serial: "WD-CA5E6F7G", fs: "xfs", port: "ata5", slot: 4, size: 8 * tb, usedBytes: 640 * gib, temp: 33},
+	{name: "cache", device: "nvme0n1", role: "cache", model: "Samsung SSD 980 PRO 1TB", serial: "S5GXNF0R123456A", fs: "btrfs", port: "nvme0", slot: 30, size: 1 * tb, usedBytes: 318 * gib, growthPerSec: 50_000, temp: 42, ssd: true},
+	{name: "dev1", device: "sdg", role: "unassigned", model: "ST8000VN004-3CP101", serial: "WWZ1A2B3", port: "ata6", slot: -1, size: 8 * tb},
+}
+
+func (d mockDisk) used(now time.Time) uint64 {
+	return min(d.usedBytes+uint64(elapsed(now)*d.growthPerSec), d.size)
+}
+
+func systemInfo(ctx *domain.Context, now time.Time) *dto.SystemInfo {
+	cpu := round1(wave(now, 18, 9, 5*time.Minute, 0))
+	perCore := make(map[string]float64, 16)
+	for i := range 16 {
+		perCore[fmt.Sprintf("cpu%d", i)] = round1(max(0, wave(now, cpu, 12, 90*time.Second, float64(i))))
+	}
+	ramUsed := uint64(wave(now, 0.42, 0.03, 10*time.Minute, 0) * float64(mockRAM))
+	cached := 18 * gib
+	cpuTemp := round1(wave(now, 48, 4, 5*time.Minute, 0.5))
+
+	return &dto.SystemInfo{
+		Hostname:        mockHostname,
+		Version:         mockVersion,
+		AgentVersion:    ctx.Version,
+		Uptime:          int64(elapsed(now)) + 12*86400,
+		CPUUsage:        cpu,
+		CPUModel:        "AMD Ryzen 7 5700G with Radeon Graphics",
+		CPUCores:        8,
+		CPUThreads:      16,
+		CPUMHz:          round1(wave(now, 3800, 400, time.Minute, 0)),
+		CPUPerCore:      perCore,
+		CPUTemp:         cpuTemp,
+		RAMUsage:        round1(float64(ramUsed) / float64(mockRAM) * 100),
+		RAMTotal:        mockRAM,
+		RAMUsed:         ramUsed,
+		RAMFree:         mockRAM - ramUsed - cached,
+		RAMBuffers:      gib,
+		RAMCached:       cached,
+		Swappiness:      60,
+		ServerModel:     "ASRock B550M Steel Legend",
+		BIOSVersion:     "P3.20",
+		BIOSDate:        "03/08/2024",
+		MotherboardTemp: round1(wave(now, 36, 2, 10*time.Minute, 1)),
+		HVMEnabled:      true,
+		IOMMUEnabled:    true,
+		KernelVersion:   "6.12.24-Unraid",
+		Temperatures: []dto.TemperatureReading{
+			{Name: "k10temp_Tctl", Value: cpuTemp, SensorType: "cpu", Source: "k10temp"},
+			{Name: "nct6798_SYSTIN", Value: round1(wave(now, 36, 2, 10*time.Minute, 1)), SensorType: "motherboard", Source: "nct6798"},
+		},
+		RAMUsedMB:  float64(ramUsed / (1 << 20)),
+		RAMTotalMB: float64(mockRAM / (1 << 20)),
+		Fans: []dto.FanInfo{
+			{Name: "CPU Fan", RPM: int(wave(now, 1100, 150, 5*time.Minute, 0.5))},
+			{Name: "Chassis Fan 1", RPM: int(wave(now, 850, 50, 10*time.Minute, 2))},
+		},
+		Timestamp: now,
+	}
+}
+
+func arrayStatus(now time.Time) *dto.ArrayStatus {
+	var total, used uint64
+	numDisks, numData, numParity := 0, 0, 0
+	for _, d := range mockDisks {
+		switch d.role {
+		case "parity":
+			numDisks++
+			numParity++
+		case "data":
+			numDisks++
+			numData++
+			total += d.size
+			used += d.used(now)
+		}
+	}
+	return &dto.ArrayStatus{
+		State:             "Started",
+		UsedPercent:       round1(float64(used) / float64(total) * 100),
+		FreeBytes:         total - used,
+		TotalBytes:        total,
+		ParityValid:       true,
+		ParityCheckStatus: "idle",
+		NumDisks:          numDisks,
+		NumDataDisks:      numData,
+		NumParityDisks:    numParity,
+		Timestamp:         now,
+	}
+}
+
+func disks(now time.Time) []dto.DiskInfo {
+	out := make([]dto.DiskInfo, 0, len(mockDisks))
+	for i, d := range mockDisks {
+		disk := dto.DiskInfo{
+			ID:            d.model + "_" + d.serial,
+			Device:        d.device,
+			Name:          d.name,
+			Status:        "DISK_OK",
+			Size:          d.size,
+			SMARTStatus:   "PASSED",
+			SpindownDelay: 30,
+			FileSystem:    d.fs,
+			SerialNumber:  d.serial,
+			Model:         d.model,
+			Role:          d.role,
+			Controller:    "0000:00:17.0",
+			Port:          d.port,
+			SpinState:     "standby",
+			PowerOnHours:  uint64(21_000 + i*3_100),
+			Timestamp:     now,
+		}
+		if d.slot >= 0 {
+			disk.Slot = &d.slot
+		}
+		if d.role == "cache" {
+			disk.Pool = d.name
+		}
+		if d.ssd {
+			disk.Controller = "0000:01:00.0"
+		}
+		if d.temp > 0 {
+			disk.SpinState = "active"
+			disk.Temperature = round1(wave(now, d.temp, 1, 15*time.Minute, float64(i)))
+			disk.IOUtilization = round1(max(0, wave(now, 4, 4, 2*time.Minute, float64(i))))
+		}
+		if d.fs != "" {
+			disk.Used = d.used(now)
+			disk.Free = d.size - disk.Used
+			disk.UsagePercent = round1(float64(disk.Used) / float64(d.size) * 100)
+			disk.MountPoint = "/mnt/" + d.name
+		}
+		if d.ssd {
+			wear := 3
+			disk.WearPercent = &wear
+			disk.TotalBytesWritten = 41 * tb
+		}
+		out = append(out, disk)
+	}
+	return out
+}
+
+// mockContainer describes one synthetic container.
+type mockContainer struct {
+	id, name, image, version string
+	running                  bool
+	cpu                      float64
+	memory                   uint64
+	port                     int
+}
+
+var mockContainers = []mockContainer{
+	{id: "3f2a9c1b7d4e", name: "plex", image: "plexinc/pms-docker:latest", version: "1.41.3", running: true, cpu: 6, memory: 1_400 << 20, port: 32400},
+	{id: "8b1c4e2f9a0d", name: "sonarr", image: "lscr.io/linuxserver/sonarr:latest", version: "4.0.14", running: true, cpu: 1.2, memory: 310 << 20, port: 8989},
+	{id: "a7d3e9f1c2b4", name: "radarr", image: "lscr.io/linuxserver/radarr:latest", version: "5.21.1", running: true, cpu: 1.0, memory: 290 << 20, port: 7878},
+	{id: "c4e8b2a6f1d9", name: "homeassistant", image: "ghcr.io/home-assistant/home-assistant:stable", version: "2025.5.1", running: true, cpu: 2.5, memory: 620 << 20, port: 8123},
+	{id: "e1f7a3d8c5b2", name: "nginx-proxy-manager", image: "jc21/nginx-proxy-manager:latest", version: "2.12.3", running: true, cpu: 0.3, memory: 140 << 20, port: 81},
+	{id: "f9b2c7e4a1d6", name: "duplicati", image: "lscr.io/linuxserver/duplicati:latest", version: "2.1.0", port: 8200},
+}
+
+func containers(now time.Time) []*dto.ContainerInfo {
+	out := make([]*dto.ContainerInfo, 0, len(mockContainers))
+	for i, c := range mockContainers {
+		info := &dto.ContainerInfo{
+			ID:            c.id,
+			Name:          c.name,
+			Image:         c.image,
+			Version:       c.version,
+			State:         "exited",
+			Status:        "Exited (0) 3 days ago",
+			NetworkMode:   "bridge",
+			Ports:         []dto.PortMapping{{PrivatePort: c.port, PublicPort: c.port, Type: "tcp"}},
+			PortMappings:  []string{fmt.Sprintf("%d:%d/tcp", c.port, c.port)},
+			RestartPolicy: "unless-stopped",
+			UpdateStatus:  dto.UpdateStatusUpToDate,
+			Timestamp:     now,
+		}
+		if c.running {
+			mem := uint64(wave(now, float64(c.memory), float64(c.memory)/10, 10*time.Minute, float64(i)))
+			limit := 4 * gib
+			info.State = "running"
+			info.Status = "Up 12 days"
+			info.Uptime = "12 days"
+			info.Health = "healthy"
+			info.IPAddress = fmt.Sprintf("172.17.0.%d", i+2)
+			info.CPUPercent = round1(max(0, wave(now, c.cpu, c.cpu/2, 3*time.Minute, float64(i))))
+			info.MemoryUsage = mem
+			info.MemoryUsageMB = float64(mem >> 20)
+			info.MemoryLimit = limit
+			info.MemoryPercent = round1(float64(mem) / float64(limit) * 100)
+			info.MemoryDisplay = fmt.Sprintf("%d MiB / 4 GiB", mem>>20)
+			info.NetworkRX = uint64(elapsed(now)*20_000) + uint64(i)*gib
+			info.NetworkTX = uint64(elapsed(now)*8_000) + uint64(i)*gib/2
+			info.NetworkRXBytesPerSec = 20_000
+			info.NetworkTXBytesPerSec = 8_000
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+func vms(now time.Time) []*dto.VMInfo {
+	winMem := 16 * gib
+	return []*dto.VMInfo{
+		{
+			ID:                   "6e1c9b2f4a7d4e3f8b0c5d2a1e9f7b3c",
+			Name:                 "Windows 11",
+			State:                "running",
+			CPUCount:             4,
+			GuestCPUPercent:      round1(wave(now, 12, 8, 4*time.Minute, 0)),
+			HostCPUPercent:       round1(wave(now, 3, 2, 4*time.Minute, 0)),
+			MemoryAllocated:      winMem,
+			MemoryUsed:           9 * gib,
+			MemoryDisplay:        "9 GiB / 16 GiB",
+			DiskPath:             "/mnt/user/domains/Windows 11/vdisk1.img",
+			DiskSize:             128 * gib,
+			DiskReadBytes:        uint64(elapsed(now)*150_000) + 40*gib,
+			DiskWriteBytes:       uint64(elapsed(now)*60_000) + 18*gib,
+			NetworkRXBytes:       uint64(elapsed(now)*12_000) + 6*gib,
+			NetworkTXBytes:       uint64(elapsed(now)*3_000) + 2*gib,
+			NetworkRXBytesPerSec: 12_000,
+			NetworkTXBytesPerSec: 3_000,
+			Autostart:            true,
+			PersistentState:      true,
+			Timestamp:            now,
+		},
+		{
+			ID:              "b4d8f2a6c1e94f7a9d3b6e0c2f5a8d1e",
+			Name:            "Ubuntu Server",
+			State:           "shut off",
+			CPUCount:        2,
+			MemoryAllocated: 4 * gib,
+			MemoryDisplay:   "0 B / 4 GiB",
+			DiskPath:        "/mnt/user/domains/Ubuntu Server/vdisk1.img",
+			DiskSize:        40 * gib,
+			PersistentState: true,
+			Timestamp:       now,
+		},
+	}
+}
+
+func upsStatus(now time.Time) *dto.UPSStatus {
+	load := round1(wave(now, 22, 4, 5*time.Minute, 0))
+	return &dto.UPSStatus{
+		Connected:     true,
+		Status:        "ONLINE",
+		LoadPercent:   load,
+		BatteryCharge: 100,
+		RuntimeLeft:   int(3600 - load*60),
+		PowerWatts:    round1(load / 100 * 900),
+		NominalPower:  900,
+		Model:         "Back-UPS Pro 1500",
+		Timestamp:     now,
+	}
+}
+
+func gpus(now time.Time) []*dto.GPUMetrics {
+	util := round1(max(0, wave(now, 15, 15, 6*time.Minute, 0)))
+	return []*dto.GPUMetrics{{
+		Available:         true,
+		Index:             0,
+		PCIID:             "0000:01:00.0",
+		Vendor:            "nvidia",
+		UUID:              "GPU-5f3c2a1e-7b9d-4c8e-a6f0-1d2b3c4e5f60",
+		Name:              "NVIDIA GeForce RTX 3060",
+		DriverVersion:     "570.144",
+		Temperature:       round1(38 + util/4),
+		UtilizationGPU:    util,
+		UtilizationMemory: round1(util / 2),
+		MemoryTotal:       12 * gib,
+		MemoryUsed:        uint64(util/100*float64(6*gib)) + 300<<20,
+		PowerDraw:         round1(18 + util*1.5),
+		FanSpeed:          round1(30 + util/3),
+		Timestamp:         now,
+	}}
+}
+
+func shares(now time.Time) []dto.ShareInfo {
+	arr := arrayStatus(now)
+	list := []struct {
+		name, comment, useCache, pool string
+		used                          uint64
+		smbRead, smbWrite             float64 // average bytes per second
+	}{
+		{"appdata", "Container data", "only", "cache", 210 * gib, 20_000, 8_000},
+		{"backups", "Duplicati backups", "no", "", 1_900 * gib, 0, 350_000},
+		{"domains", "VM disks", "only", "cache", 168 * gib, 0, 0},
+		{"isos", "Installer images", "yes", "cache", 42 * gib, 5_000, 0},
+		{"media", "Movies and TV", "yes", "cache", arr.TotalBytes - arr.FreeBytes - 1_942*gib, 4_500_000, 60_000},
+		{"system", "Docker and libvirt images", "only", "cache", 60 * gib, 0, 0},
+	}
+	out := make([]dto.ShareInfo, 0, len(list))
+	for i, s := range list {
+		total := arr.TotalBytes
+		storage := "array"
+		switch s.useCache {
+		case "only":
+			total = 1 * tb
+			storage = "cache"
+		case "yes":
+			storage = "cache+array"
+		}
+		share := dto.ShareInfo{
+			Name:         s.name,
+			Path:         "/mnt/user/" + s.name,
+			Used:         s.used,
+			Free:         total - s.used,
+			Total:        total,
+			UsagePercent: round1(float64(s.used) / float64(total) * 100),
+			Comment:      s.comment,
+			SMBExport:    s.name != "system",
+			Storage:      storage,
+			UseCache:     s.useCache,
+			Security:     "private",
+			CachePool:    s.pool,
+			Timestamp:    now,
+		}
+		if s.useCache == "yes" {
+			share.MoverAction = "cache->array"
+		}
+		if share.SMBExport {
+			share.Activity = &dto.ShareActivity{
+				SMB: &dto.ShareProtocolIO{
+					ReadBytes:        uint64(elapsed(now) * s.smbRead),
+					WriteBytes:       uint64(elapsed(now) * s.smbWrite),
+					ReadOps:          uint64(elapsed(now) * s.smbRead / 65536),
+					WriteOps:         uint64(elapsed(now) * s.smbWrite / 65536),
+					ReadBytesPerSec:  round1(max(0, wave(now, s.smbRead, s.smbRead/2, 4*time.Minute, float64(i)))),
+					WriteBytesPerSec: round1(max(0, wave(now, s.smbWrite, s.smbWrite/2, 4*time.Minute, float64(i)+1))),
+				},
+				Since: started,
+			}
+		}
+		out = append(out, share)
+	}
+	return out
+}
+
+func networkInterfaces(now time.Time) []dto.NetworkInfo {
+	rx := round1(wave(now, 2_500_000, 1_500_000, 3*time.Minute, 0))
+	tx := round1(wave(now, 900_000, 500_000, 3*time.Minute, 1))
+	rxTotal := uint64(elapsed(now)*2_500_000) + 1_800*gib
+	txTotal := uint64(elapsed(now)*900_000) + 640*gib
+	return []dto.NetworkInfo{
+		{
+			Name:            "eth0",
+			MACAddress:      "a8:a1:59:3c:7e:21",
+			Speed:           2500,
+			State:           "up",
+			BytesReceived:   rxTotal,
+			BytesSent:       txTotal,
+			PacketsReceived: rxTotal / 1200,
+			PacketsSent:     txTotal / 900,
+			RxBytesPerSec:   rx,
+			TxBytesPerSec:   tx,
+			Duplex:          "Full",
+			LinkDetected:    true,
+			MTU:             1500,
+			Timestamp:       now,
+		},
+		{
+			Name:            "br0",
+			MACAddress:      "a8:a1:59:3c:7e:21",
+			IPAddress:       "192.168.1.50",
+			Speed:           2500,
+			State:           "up",
+			BytesReceived:   rxTotal,
+			BytesSent:       txTotal,
+			PacketsReceived: rxTotal / 1200,
+			PacketsSent:     txTotal / 900,
+			RxBytesPerSec:   rx,
+			TxBytesPerSec:   tx,
+			LinkDetected:    true,
+			MTU:             1500,
+			Timestamp:       now,
+		},
+	}
+}
+
+func notifications(now time.Time) *dto.NotificationList {
+	day := now.Truncate(24 * time.Hour)
+	list := []dto.Notification{
+		{
+			ID:          "mock_parity_check.notify",
+			Subject:     "Notice [TOWER] - Parity check finished (0 errors)",
+			Description: "Duration: 19 hours, 12 minutes, 3 seconds. Average speed: 231.4 MB/s",
+			Importance:  "normal",
+			Timestamp:   day.Add(-20 * time.Hour),
+			Type:        "unread",
+		},
+		{
+			ID:          "mock_disk_temp.notify",
+			Title:       "Unraid Disk 1 temperature",
+			Subject:     "Warning [TOWER] - Disk 1 is hot (46 C)",
+			Description: "ST12000VN0008-2YS101_ZRT0A1B2 (sdc)",
+			Importance:  "warning",
+			Timestamp:   day.Add(-30 * time.Hour),
+			Type:        "unread",
+		},
+		{
+			ID:          "mock_docker_update.notify",
+			Title:       "Container updates available",
+			Subject:     "Docker",
+			Description: "Updates available for: sonarr",
+			Importance:  "normal",
+			Timestamp:   day.Add(-3 * 24 * time.Hour),
+			Type:        "archive",
+		},
+	}
+	result := &dto.NotificationList{Notifications: list, Timestamp: now}
+	for i := range list {
+		list[i].FormattedTimestamp = list[i].Timestamp.Format("2006-01-02 15:04:05")
+		counts := &result.Overview.Unread
+		if list[i].Type == "archive" {
+			counts = &result.Overview.Archive
+		}
+		switch list[i].Importance {
+		case "warning":
+			counts.Warning++
+		case "alert":
+			counts.Alert++
+		default:
+			counts.Info++
+		}
+		counts.Total++
+	}
+	return result
+}
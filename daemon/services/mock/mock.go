@@ -0,0 +1,76 @@
+// Package mock provides the synthetic Unraid server behind the agent's mock
+// mode. Its collectors publish realistic, slowly changing data on the same
+// event bus topics as the real collectors, and SimulateAction stands in for
+// controller calls, so dashboards and integrations can be built against the
+// API without an Unraid box. Nothing in this package touches the host.
+package mock
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Simulated latency of a control action.
+const (
+	minActionLatency = 150 * time.Millisecond
+	maxActionLatency = 600 * time.Millisecond
+)
+
+// started anchors the synthetic clock: uptime and traffic counters grow
+// from here.
+var started = time.Now()
+
+// SimulateAction waits as long as a typical control action takes. It
+// returns the context error if ctx ends first.
+func SimulateAction(ctx context.Context) error {
+	delay := minActionLatency + rand.N(maxActionLatency-minActionLatency) //nolint:gosec // G404: simulated latency, not security sensitive
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// wave returns a value oscillating smoothly around base by up to ±spread,
+// repeating every period. phase shifts readings of similar things apart.
+func wave(now time.Time, base, spread float64, period time.Duration, phase float64) float64 {
+	t := now.Sub(started).Seconds() / period.Seconds()
+	return base + spread*math.Sin(2*math.Pi*t+phase)
+}
+
+// elapsed returns the seconds since the synthetic clock started.
+func elapsed(now time.Time) float64 {
+	return now.Sub(started).Seconds()
+}
+
+// round1 rounds v to one decimal place.
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// Actor stands in for the Docker, VM and array controllers behind
+// remediation actions: every action takes a simulated latency and succeeds.
+type Actor struct{}
+
+// Start simulates starting a container or VM.
+func (Actor) Start(string) error { return SimulateAction(context.Background()) }
+
+// Stop simulates stopping a container or VM.
+func (Actor) Stop(string) error { return SimulateAction(context.Background()) }
+
+// Restart simulates restarting a container or VM.
+func (Actor) Restart(string) error { return SimulateAction(context.Background()) }
+
+// ForceStop simulates forcing a VM off.
+func (Actor) ForceStop(string) error { return SimulateAction(context.Background()) }
+
+// SpinUpDisk simulates spinning up a disk.
+func (Actor) SpinUpDisk(string) error { return SimulateAction(context.Background()) }
+
+// SpinDownDisk simulates spinning down a disk.
+func (Actor) SpinDownDisk(string) error { return SimulateAction(context.Background()) }
@@ -0,0 +1,81 @@
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestCollectorPublishes(t *testing.T) {
+	ctx := &domain.Context{Config: domain.Config{Version: "test"}, Hub: domain.NewEventBus(16)}
+	ch := ctx.Hub.SubTopics(constants.TopicSystemUpdate)
+	defer ctx.Hub.Unsub(ch)
+
+	if NewCollector(ctx, "zfs") != nil {
+		t.Error("NewCollector(zfs) should be nil without synthetic data")
+	}
+	c := NewCollector(ctx, "system")
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Start(runCtx, time.Hour)
+
+	for range 2 {
+		select {
+		case msg := <-ch:
+			info, ok := msg.(*dto.SystemInfo)
+			if !ok || info.Hostname != mockHostname || info.AgentVersion != "test" {
+				t.Fatalf("unexpected system update: %#v", msg)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no system update published")
+		}
+		c.RequestRefresh()
+	}
+}
+
+func TestDataConsistency(t *testing.T) {
+	now := time.Now().Add(time.Hour)
+
+	arr := arrayStatus(now)
+	if arr.NumDisks != 5 || arr.NumDataDisks != 4 || arr.NumParityDisks != 1 {
+		t.Errorf("array disk counts = %d/%d/%d", arr.NumDisks, arr.NumDataDisks, arr.NumParityDisks)
+	}
+	var dataFree uint64
+	for _, d := range disks(now) {
+		if d.Used+d.Free != d.Size && d.FileSystem != "" {
+			t.Errorf("%s: used+free != size", d.Name)
+		}
+		if d.SpinState == "standby" && d.Temperature != 0 {
+			t.Errorf("%s: spun down disk reports a temperature", d.Name)
+		}
+		if d.Role == "data" {
+			dataFree += d.Free
+		}
+	}
+	if dataFree != arr.FreeBytes {
+		t.Errorf("array free %d, data disks free %d", arr.FreeBytes, dataFree)
+	}
+
+	for _, s := range shares(now) {
+		if s.Used > s.Total {
+			t.Errorf("share %s: used %d exceeds total %d", s.Name, s.Used, s.Total)
+		}
+	}
+
+	n := notifications(now)
+	if n.Overview.Unread.Total+n.Overview.Archive.Total != len(n.Notifications) {
+		t.Errorf("notification overview %+v does not match %d notifications", n.Overview, len(n.Notifications))
+	}
+}
+
+func TestSimulateActionCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := SimulateAction(ctx); err == nil {
+		t.Error("SimulateAction should return the context error")
+	}
+}
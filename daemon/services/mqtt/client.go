@@ -17,8 +17,18 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
 )
 
+// disconnectTimeout bounds the offline publish on disconnect, so an
+// unresponsive broker cannot hold up shutdown.
+const disconnectTimeout = 2 * time.Second
+
+// disconnectQuiesceMillis lets in-flight publishes finish before the
+// connection closes.
+const disconnectQuiesceMillis = 1000
+
 // Client represents an MQTT client that publishes Unraid metrics and events.
 type Client struct {
 	config       *dto.MQTTConfig
@@ -44,6 +54,13 @@ type Client struct {
 	seenNotifications map[string]bool
 	notifSeeded       bool
 
+	// triggers holds the previous readings used to fire HA device triggers.
+	triggers triggerState
+
+	// retracted holds discovery topics of disabled categories already
+	// cleared from the broker.
+	retracted sync.Map
+
 	// connectCancel cancels the context for goroutines spawned by handleConnect.
 	// Protected by mu.
 	connectCancel context.CancelFunc
@@ -54,6 +71,43 @@ type Client struct {
 	// unassigned discovery publish.
 	remoteShareMu      sync.RWMutex
 	remoteShareSources map[string]string
+
+	// recycleBinShares maps a sanitized share ID (used in the recycle bin
+	// empty button's command topic) back to the share name. Rebuilt on each
+	// recycle bin discovery publish.
+	recycleBinMu     sync.RWMutex
+	recycleBinShares map[string]string
+
+	// auditLog records every handled command (nil-safe).
+	auditLog *audit.Log
+
+	// fanController handles fan entity commands. Set after Connect, so it
+	// is read atomically by the command handler.
+	fanController atomic.Pointer[controllers.FanController]
+
+	// maintenanceStatus returns the maintenance window state published on
+	// connect (nil = no window). Set before Connect.
+	maintenanceStatus func() dto.MaintenanceStatus
+
+	// collectorControl backs the collector switch and interval entities
+	// (nil = not exposed). Set before Connect.
+	collectorControl CollectorControl
+}
+
+// SetAuditLog sets the audit log that records handled MQTT commands.
+func (c *Client) SetAuditLog(l *audit.Log) {
+	c.auditLog = l
+}
+
+// SetMaintenance sets the source of the maintenance window state published
+// on every connect. Call before Connect.
+func (c *Client) SetMaintenance(status func() dto.MaintenanceStatus) {
+	c.maintenanceStatus = status
+}
+
+// SetFanController sets the fan controller that handles HA fan entity commands.
+func (c *Client) SetFanController(fc *controllers.FanController) {
+	c.fanController.Store(fc)
 }
 
 // setRemoteShareSources atomically replaces the remote-share ID→source map.
@@ -71,6 +125,21 @@ func (c *Client) lookupRemoteShareSource(shareID string) string {
 	return c.remoteShareSources[shareID]
 }
 
+// setRecycleBinShares atomically replaces the recycle bin share ID→name map.
+func (c *Client) setRecycleBinShares(m map[string]string) {
+	c.recycleBinMu.Lock()
+	c.recycleBinShares = m
+	c.recycleBinMu.Unlock()
+}
+
+// lookupRecycleBinShare resolves a sanitized share ID to its share name,
+// returning an empty string if unknown.
+func (c *Client) lookupRecycleBinShare(shareID string) string {
+	c.recycleBinMu.RLock()
+	defer c.recycleBinMu.RUnlock()
+	return c.recycleBinShares[shareID]
+}
+
 func normalizeQoS(qos int) byte {
 	switch qos {
 	case 0, 1, 2:
@@ -82,6 +151,9 @@ func normalizeQoS(qos int) byte {
 
 // NewClient creates a new MQTT client with the given configuration.
 func NewClient(config *dto.MQTTConfig, hostname, agentVersion string, domainCtx *domain.Context) *Client {
+	if config != nil && !validTopicTemplate(config.TopicTemplate) {
+		logger.Warning("MQTT: Ignoring topic template %q: it must end with {topic}", config.TopicTemplate)
+	}
 	return &Client{
 		config:       config,
 		hostname:     hostname,
@@ -188,6 +260,14 @@ func (c *Client) handleConnect() {
 	availabilityTopic := c.buildTopic("availability")
 	_ = c.publish(availabilityTopic, "online", true)
 
+	// Home Assistant binary sensors stay unavailable until the maintenance
+	// state is known, so publish it right away.
+	status := dto.MaintenanceStatus{Timestamp: now}
+	if c.maintenanceStatus != nil {
+		status = c.maintenanceStatus()
+	}
+	_ = c.PublishMaintenanceStatus(status)
+
 	// Publish Home Assistant discovery if enabled
 	if c.config.HomeAssistantMode {
 		go func() {
@@ -206,6 +286,7 @@ func (c *Client) handleConnect() {
 				return
 			}
 			c.publishServiceStates()
+			_ = c.publishCollectorStates()
 			if ctx.Err() != nil {
 				return
 			}
@@ -248,11 +329,20 @@ func (c *Client) Disconnect() {
 	}
 
 	if c.client != nil && c.client.IsConnected() {
-		// Publish offline status
+		// Publish offline status explicitly: the broker only sends the
+		// will message after an unclean disconnect, so without it Home
+		// Assistant would keep showing the agent's entities as available.
 		availabilityTopic := c.buildTopic("availability")
-		_ = c.publish(availabilityTopic, "offline", true)
+		token := c.client.Publish(availabilityTopic, normalizeQoS(c.config.QoS), true, "offline")
+		if !token.WaitTimeout(disconnectTimeout) {
+			logger.Warning("MQTT: Timed out publishing offline availability")
+		} else if err := token.Error(); err != nil {
+			logger.Warning("MQTT: Failed to publish offline availability: %v", err)
+		} else {
+			c.msgSent.Add(1)
+		}
 
-		c.client.Disconnect(250)
+		c.client.Disconnect(disconnectQuiesceMillis)
 		c.connected.Store(false)
 		logger.Info("MQTT: Disconnected from broker")
 	}
@@ -270,7 +360,7 @@ func (c *Client) TestConnection() error {
 	}
 
 	// Publish a test message to verify the connection is working
-	testTopic := fmt.Sprintf("%s/test", c.config.TopicPrefix)
+	testTopic := c.buildTopic("test")
 	testPayload := map[string]any{
 		"test":      true,
 		"timestamp": time.Now().Unix(),
@@ -329,6 +419,11 @@ func (c *Client) GetConfig() *dto.MQTTConfig {
 		AutoReconnect:     c.config.AutoReconnect,
 		HomeAssistantMode: c.config.HomeAssistantMode,
 		HADiscoveryPrefix: c.config.HADiscoveryPrefix,
+
+		TopicTemplate:        c.config.TopicTemplate,
+		EntityNameTemplate:   c.config.EntityNameTemplate,
+		UniqueIDTemplate:     c.config.UniqueIDTemplate,
+		HADisabledCategories: c.config.HADisabledCategories,
 	}
 }
 
@@ -356,6 +451,22 @@ func (c *Client) GetTopics() *dto.MQTTTopics {
 		ZFSDatasets:       c.buildTopic("zfs/datasets"),
 		ZFSSnapshots:      c.buildTopic("zfs/snapshots"),
 		ZFSARC:            c.buildTopic("zfs/arc"),
+		WAN:               c.buildTopic("wan"),
+		WANIPChanged:      c.buildTopic("wan/ip_changed"),
+		Speedtest:         c.buildTopic("speedtest"),
+		Btrfs:             c.buildTopic("btrfs"),
+		Pools:             c.buildTopic("pools"),
+		Power:             c.buildTopic("power"),
+		RecycleBin:        c.buildTopic("recycle_bin"),
+		IPMI:              c.buildTopic("ipmi"),
+		Maintenance:       c.buildTopic("maintenance"),
+		ParitySchedule:    c.buildTopic("array/parity_schedule"),
+		Flash:             c.buildTopic("flash"),
+		Mover:             c.buildTopic("mover"),
+		OSUpdate:          c.buildTopic("updates/os"),
+		PluginUpdates:     c.buildTopic("updates/plugins"),
+		ContainerUpdates:  c.buildTopic("updates/docker"),
+		Collectors:        c.buildTopic("collectors"),
 	}
 }
 
@@ -379,7 +490,9 @@ func (c *Client) PublishArrayStatus(status *dto.ArrayStatus) error {
 	if !c.shouldPublish() {
 		return nil
 	}
-	return c.publishJSON(c.buildTopic("array"), status)
+	err := c.publishJSON(c.buildTopic("array"), status)
+	c.fireTriggers(c.triggers.parityTriggers(status))
+	return err
 }
 
 // PublishDisks publishes disk information to MQTT.
@@ -399,6 +512,7 @@ func (c *Client) PublishContainers(containers []dto.ContainerInfo) error {
 		return nil
 	}
 	err := c.publishJSON(c.buildTopic("docker/containers"), containers)
+	c.fireTriggers(c.triggers.containerTriggers(containers))
 	// Publish per-container topics and HA discovery
 	go c.publishContainerDiscovery(containers)
 	return err
@@ -420,7 +534,9 @@ func (c *Client) PublishUPSStatus(ups *dto.UPSStatus) error {
 	if !c.shouldPublish() {
 		return nil
 	}
-	return c.publishJSON(c.buildTopic("ups"), ups)
+	err := c.publishJSON(c.buildTopic("ups"), ups)
+	c.fireTriggers(c.triggers.upsTriggers(ups))
+	return err
 }
 
 // PublishGPUMetrics publishes GPU metrics to MQTT.
@@ -543,6 +659,86 @@ func (c *Client) PublishZFSPools(pools []dto.ZFSPool) error {
 	return err
 }
 
+// PublishPools publishes Unraid pool capacity and fill forecasts to MQTT.
+func (c *Client) PublishPools(pools []dto.PoolInfo) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	err := c.publishJSON(c.buildTopic("pools"), pools)
+	// Publish per-pool topics and HA discovery
+	go c.publishPoolDiscovery(pools)
+	return err
+}
+
+// PublishBtrfsStats publishes btrfs device error and scrub statistics to MQTT.
+func (c *Client) PublishBtrfsStats(filesystems []dto.BtrfsFilesystem) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	err := c.publishJSON(c.buildTopic("btrfs"), filesystems)
+	// Publish per-filesystem topics and HA discovery
+	go c.publishBtrfsDiscovery(filesystems)
+	return err
+}
+
+// PublishRecycleBin publishes per-share recycle bin statistics to MQTT.
+func (c *Client) PublishRecycleBin(status *dto.RecycleBinStatus) error {
+	if !c.shouldPublish() || status == nil {
+		return nil
+	}
+	err := c.publishJSON(c.buildTopic("recycle_bin"), status)
+	// Publish per-share topics and HA discovery
+	go c.publishRecycleBinDiscovery(status)
+	return err
+}
+
+// PublishIPMIStatus publishes BMC sensors and the chassis status to MQTT.
+func (c *Client) PublishIPMIStatus(status *dto.IPMIStatus) error {
+	if !c.shouldPublish() || status == nil || !status.Available {
+		return nil
+	}
+	err := c.publishJSON(c.buildTopic("ipmi"), status)
+	// Publish per-sensor topics and HA discovery
+	go c.publishIPMIDiscovery(status)
+	return err
+}
+
+// PublishTransferProgress publishes the progress of a transfer job run to
+// MQTT under transfers/<job_id>.
+func (c *Client) PublishTransferProgress(run dto.TransferRun) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("transfers/"+sanitizeID(run.JobID)), run)
+}
+
+// PublishJobUpdate publishes a background job update to MQTT under
+// jobs/<kind>. Job updates are events and are never retained.
+func (c *Client) PublishJobUpdate(job dto.Job) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return c.publish(c.buildTopic("jobs/"+sanitizeID(job.Kind)), string(data), false)
+}
+
+// PublishMaintenanceStatus publishes the maintenance window state to MQTT.
+// It is always retained: Home Assistant binary sensors use it for their
+// availability and are unavailable during maintenance.
+func (c *Client) PublishMaintenanceStatus(status dto.MaintenanceStatus) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return c.publish(c.buildTopic("maintenance"), string(data), true)
+}
+
 // PublishNUTStatus publishes NUT UPS status to MQTT.
 func (c *Client) PublishNUTStatus(data *dto.NUTResponse) error {
 	if !c.shouldPublish() {
@@ -603,6 +799,124 @@ func (c *Client) PublishZFSARCStats(stats dto.ZFSARCStats) error {
 	return c.publishJSON(c.buildTopic("zfs/arc"), stats)
 }
 
+// PublishHealthChecks publishes watchdog health check statuses to MQTT.
+func (c *Client) PublishHealthChecks(statuses []dto.HealthCheckStatus) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	err := c.publishJSON(c.buildTopic("healthchecks"), statuses)
+	// Publish per-check topics and HA discovery
+	go c.publishHealthCheckDiscovery(statuses)
+	return err
+}
+
+// PublishWANStatus publishes WAN connectivity status to MQTT.
+func (c *Client) PublishWANStatus(status *dto.WANStatus) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("wan"), status)
+}
+
+// PublishWANIPChange publishes a public IP change event to MQTT. The payload
+// carries an event_type so it doubles as a Home Assistant event entity.
+func (c *Client) PublishWANIPChange(change *dto.WANIPChange) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	data, err := json.Marshal(map[string]any{
+		"event_type": "ip_changed",
+		"old_ip":     change.OldIP,
+		"new_ip":     change.NewIP,
+		"timestamp":  change.Timestamp.Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	// Never retain event payloads — HA must not replay the last event on reconnect.
+	return c.publish(c.buildTopic("wan/ip_changed"), string(data), false)
+}
+
+// PublishSpeedtestStatus publishes the latest bandwidth test to MQTT. Failed
+// runs are not published, so Home Assistant sensors keep the last measured
+// values instead of dropping to zero when a test server is unreachable.
+func (c *Client) PublishSpeedtestStatus(status *dto.SpeedtestStatus) error {
+	if !c.shouldPublish() || status.Latest == nil || !status.Latest.Success {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("speedtest"), status.Latest)
+}
+
+// PublishPowerEstimate publishes the estimated power draw and energy total to
+// MQTT.
+func (c *Client) PublishPowerEstimate(est *dto.PowerEstimate) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("power"), est)
+}
+
+// PublishParitySchedule publishes the parity check schedule and its next run
+// to MQTT.
+func (c *Client) PublishParitySchedule(schedule *dto.ParitySchedule) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("array/parity_schedule"), schedule)
+}
+
+// PublishFlashHealth publishes flash boot drive usage and identity to MQTT.
+func (c *Client) PublishFlashHealth(flash *dto.FlashDriveHealth) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("flash"), flash)
+}
+
+// PublishMoverStatus publishes mover activity and last-run statistics to MQTT.
+func (c *Client) PublishMoverStatus(status *dto.MoverStatus) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("mover"), status)
+}
+
+// PublishOSUpdate publishes Unraid OS update availability to MQTT.
+func (c *Client) PublishOSUpdate(status *dto.OSUpdateStatus) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("updates/os"), status)
+}
+
+// PublishPluginUpdates publishes plugin update counts to MQTT.
+func (c *Client) PublishPluginUpdates(list *dto.PluginList) error {
+	if !c.shouldPublish() || list == nil {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("updates/plugins"), updateCounts{
+		Total: list.TotalCount, UpdatesAvailable: list.UpdatesAvailable, Timestamp: list.Timestamp,
+	})
+}
+
+// PublishContainerUpdates publishes container image update counts to MQTT.
+func (c *Client) PublishContainerUpdates(result *dto.ContainerUpdatesResult) error {
+	if !c.shouldPublish() || result == nil {
+		return nil
+	}
+	return c.publishJSON(c.buildTopic("updates/docker"), updateCounts{
+		Total: result.TotalCount, UpdatesAvailable: result.UpdatesAvailable, Timestamp: result.Timestamp,
+	})
+}
+
+// updateCounts is the payload of the plugin and container update topics.
+// Only the counts are published; the per-item lists are on the REST API.
+type updateCounts struct {
+	Total            int       `json:"total_count"`
+	UpdatesAvailable int       `json:"updates_available"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
 // PublishFanControlStatus publishes fan control status to MQTT.
 func (c *Client) PublishFanControlStatus(status *dto.FanControlStatus) error {
 	if !c.shouldPublish() {
@@ -662,10 +976,7 @@ func (c *Client) publishJSON(topic string, payload any) error {
 
 // buildTopic constructs a full topic path with the configured prefix.
 func (c *Client) buildTopic(suffix string) string {
-	if c.config.TopicPrefix == "" {
-		return suffix
-	}
-	return fmt.Sprintf("%s/%s", c.config.TopicPrefix, suffix)
+	return c.expandTopic(suffix)
 }
 
 // NOTE: publishHADiscovery, publishHAEntity, and all per-item discovery
@@ -201,6 +201,11 @@ func TestPerItemDiscovery_NotConnected(t *testing.T) {
 	client.publishShareDiscovery([]dto.ShareInfo{{Name: "Media"}})
 	// ZFS
 	client.publishZFSDiscovery([]dto.ZFSPool{{Name: "tank", Health: "ONLINE"}})
+	// Btrfs
+	client.publishBtrfsDiscovery([]dto.BtrfsFilesystem{{Name: "cache", MountPoint: "/mnt/cache"}})
+	// Pools
+	rate, days := 5e9, 109.5
+	client.publishPoolDiscovery([]dto.PoolInfo{{Name: "cache", TotalBytes: 1 << 40, FillRateBytesPerDay: &rate, DaysUntilFull: &days}})
 	// Unassigned
 	client.publishUnassignedDiscovery(&dto.UnassignedDeviceList{
 		Devices: []dto.UnassignedDevice{{Device: "sdc", Model: "WD Black",
@@ -208,6 +213,23 @@ func TestPerItemDiscovery_NotConnected(t *testing.T) {
 	})
 	// ZFS Datasets
 	client.publishZFSDatasetDiscovery([]dto.ZFSDataset{{Name: "tank/media"}})
+	// Recycle bin
+	client.publishRecycleBinDiscovery(&dto.RecycleBinStatus{Installed: true,
+		Shares: []dto.RecycleBinShare{{Share: "TV Shows", SizeBytes: 1024, Files: 1}}})
+	if got := client.lookupRecycleBinShare("tv_shows"); got != "TV Shows" {
+		t.Errorf("lookupRecycleBinShare(tv_shows) = %q, want %q", got, "TV Shows")
+	}
+	if err := client.execRecycleBinEmpty("unknown"); err == nil {
+		t.Error("expected error for unknown recycle bin share id")
+	}
+	// IPMI
+	rpm := 1400.0
+	client.publishIPMIDiscovery(&dto.IPMIStatus{Available: true, Chassis: &dto.IPMIChassis{PowerOn: true},
+		Sensors: []dto.IPMISensor{
+			{Name: "FAN1", Type: dto.IPMISensorFan, Value: &rpm, Unit: "RPM", Status: "ok"},
+			{Name: "FAN2", Type: dto.IPMISensorFan, Status: "ns"},
+			{Name: "PS1 Status", Type: dto.IPMISensorPowerSupply, Status: "ok", Reading: "Presence detected"},
+		}})
 
 	if client.IsConnected() {
 		t.Error("client should not be connected")
@@ -351,6 +373,8 @@ func TestPublishMethodsWithEnabledButNotConnected(t *testing.T) {
 		{"PublishShares", func() error { return client.PublishShares([]dto.ShareInfo{}) }},
 		{"PublishNotifications", func() error { return client.PublishNotifications(&dto.NotificationList{}) }},
 		{"PublishZFSPools", func() error { return client.PublishZFSPools([]dto.ZFSPool{}) }},
+		{"PublishBtrfsStats", func() error { return client.PublishBtrfsStats([]dto.BtrfsFilesystem{}) }},
+		{"PublishPools", func() error { return client.PublishPools([]dto.PoolInfo{}) }},
 		{"PublishNUTStatus", func() error { return client.PublishNUTStatus(&dto.NUTResponse{}) }},
 		{"PublishHardwareInfo", func() error { return client.PublishHardwareInfo(&dto.HardwareInfo{}) }},
 		{"PublishRegistration", func() error { return client.PublishRegistration(&dto.Registration{}) }},
@@ -359,7 +383,17 @@ func TestPublishMethodsWithEnabledButNotConnected(t *testing.T) {
 		}},
 		{"PublishZFSDatasets", func() error { return client.PublishZFSDatasets([]dto.ZFSDataset{}) }},
 		{"PublishZFSSnapshots", func() error { return client.PublishZFSSnapshots([]dto.ZFSSnapshot{}) }},
+		{"PublishIPMIStatus", func() error { return client.PublishIPMIStatus(&dto.IPMIStatus{Available: true}) }},
 		{"PublishZFSARCStats", func() error { return client.PublishZFSARCStats(dto.ZFSARCStats{}) }},
+		{"PublishSpeedtestStatus", func() error {
+			return client.PublishSpeedtestStatus(&dto.SpeedtestStatus{Latest: &dto.SpeedtestResult{Success: true}})
+		}},
+		{"PublishSpeedtestStatus without a run", func() error {
+			return client.PublishSpeedtestStatus(&dto.SpeedtestStatus{})
+		}},
+		{"PublishPowerEstimate", func() error { return client.PublishPowerEstimate(&dto.PowerEstimate{}) }},
+		{"PublishJobUpdate", func() error { return client.PublishJobUpdate(dto.Job{Kind: dto.JobKindMover}) }},
+		{"PublishMaintenanceStatus", func() error { return client.PublishMaintenanceStatus(dto.MaintenanceStatus{Active: true}) }},
 	}
 
 	for _, tt := range tests {
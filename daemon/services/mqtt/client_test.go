@@ -1,10 +1,12 @@
 package mqtt
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
 )
 
 func TestNormalizeQoS(t *testing.T) {
@@ -270,6 +272,7 @@ func TestClientGetTopics(t *testing.T) {
 		{"ZFSDatasets", topics.ZFSDatasets, "unraid/zfs/datasets"},
 		{"ZFSSnapshots", topics.ZFSSnapshots, "unraid/zfs/snapshots"},
 		{"ZFSARC", topics.ZFSARC, "unraid/zfs/arc"},
+		{"Maintenance", topics.Maintenance, "unraid/maintenance"},
 	}
 
 	for _, tc := range testCases {
@@ -714,3 +717,29 @@ func TestPublishSystemInfoNilSafe(t *testing.T) {
 		t.Errorf("PublishSystemInfo(nil) = %v, want nil", err)
 	}
 }
+
+func TestFanCommands(t *testing.T) {
+	client := NewClient(DefaultConfig(), "test-server", "1.0.0", nil)
+	if err := client.execFanSwitch("hwmon0_fan1", "ON"); err == nil || !strings.Contains(err.Error(), "not available") {
+		t.Errorf("switch without controller: err = %v, want not available", err)
+	}
+
+	client.SetFanController(controllers.NewFanController())
+	tests := []struct {
+		name    string
+		exec    func() error
+		wantErr string
+	}{
+		{"invalid switch payload", func() error { return client.execFanSwitch("hwmon0_fan1", "MAYBE") }, "expected ON/OFF"},
+		{"invalid percentage", func() error { return client.execFanPercentage("hwmon0_fan1", "fast") }, "expected 0-100"},
+		{"invalid fan id", func() error { return client.execFanSwitch("../pwm1", "ON") }, "invalid fan ID"},
+		{"control disabled", func() error { return client.execFanPercentage("hwmon0_fan1", "40") }, "not enabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.exec(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package mqtt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Collector interval bounds accepted by the collector manager, in seconds.
+const (
+	collectorIntervalMin = 5
+	collectorIntervalMax = 86400
+)
+
+// CollectorControl is the runtime collector control exposed to Home
+// Assistant. It is implemented by the collector manager.
+type CollectorControl interface {
+	EnableCollector(name string) error
+	DisableCollector(name string) error
+	UpdateInterval(name string, intervalSeconds int) error
+	GetAllStatus() dto.CollectorsStatusResponse
+}
+
+// collectorState is one collector's entry in the collectors state topic.
+type collectorState struct {
+	Enabled  bool `json:"enabled"`
+	Interval int  `json:"interval"`
+}
+
+// SetCollectorControl sets the collector manager behind the collector switch
+// and interval entities. Call before Connect.
+func (c *Client) SetCollectorControl(cc CollectorControl) {
+	c.collectorControl = cc
+}
+
+// PublishCollectorState republishes all collector states when one collector
+// is enabled, disabled or gets a new interval.
+func (c *Client) PublishCollectorState(_ dto.CollectorStateEvent) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	return c.publishCollectorStates()
+}
+
+// publishCollectorStates publishes every collector's enabled state and
+// interval, keyed by collector name.
+func (c *Client) publishCollectorStates() error {
+	if c.collectorControl == nil {
+		return nil
+	}
+	all := c.collectorControl.GetAllStatus()
+	states := make(map[string]collectorState, len(all.Collectors))
+	for _, s := range all.Collectors {
+		states[s.Name] = collectorState{Enabled: s.Enabled, Interval: s.Interval}
+	}
+	return c.publishJSON(c.buildTopic("collectors"), states)
+}
+
+// publishCollectorDiscovery publishes an enable switch and an interval number
+// for each collector. Required collectors cannot be disabled, so they only
+// get the interval. The entities are registered disabled to keep the device
+// page tidy; enable the ones you want to adjust.
+func (c *Client) publishCollectorDiscovery() {
+	if c.collectorControl == nil {
+		return
+	}
+	topic := c.buildTopic("collectors")
+
+	for _, s := range c.collectorControl.GetAllStatus().Collectors {
+		id := sanitizeID(s.Name)
+		display := collectorDisplayName(s.Name)
+
+		if !s.Required {
+			c.publishHAEntity(haEntityOpts{
+				entityType:   "switch",
+				stateTopic:   topic,
+				commandTopic: c.buildCommandTopic("collectors", s.Name, "set"),
+				id:           "collector_" + id, name: i18n.T("Collector: %s", display),
+				icon:              "mdi:database-sync",
+				template:          fmt.Sprintf("{{ 'ON' if value_json['%s'].enabled else 'OFF' }}", s.Name),
+				entityCategory:    "config",
+				disabledByDefault: true,
+			})
+		}
+		c.publishHAEntity(haEntityOpts{
+			entityType:   "number",
+			stateTopic:   topic,
+			commandTopic: c.buildCommandTopic("collectors", s.Name, "interval"),
+			id:           "collector_" + id + "_interval", name: i18n.T("Collector: %s Interval", display),
+			unit: "s", icon: "mdi:timer-cog-outline",
+			template:   fmt.Sprintf("{{ value_json['%s'].interval }}", s.Name),
+			numberMin:  collectorIntervalMin,
+			numberMax:  collectorIntervalMax,
+			numberStep: 1, numberMode: "box",
+			deviceClass:       "duration",
+			entityCategory:    "config",
+			disabledByDefault: true,
+		})
+	}
+}
+
+// collectorDisplayName turns a collector name such as "docker_update" into
+// "Docker Update".
+func collectorDisplayName(name string) string {
+	words := strings.Split(name, "_")
+	for i, w := range words {
+		switch w {
+		case "ups", "nut", "gpu", "vm", "zfs", "dns", "wan", "ipmi":
+			words[i] = strings.ToUpper(w)
+		default:
+			if w != "" {
+				words[i] = strings.ToUpper(w[:1]) + w[1:]
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// execCollectorSwitch enables or disables a collector.
+func (c *Client) execCollectorSwitch(name, payload string) error {
+	if c.collectorControl == nil {
+		return fmt.Errorf("collector control not available")
+	}
+	switch strings.ToUpper(payload) {
+	case "ON":
+		logger.Info("MQTT: Enabling collector %s", name)
+		return c.collectorControl.EnableCollector(name)
+	case "OFF":
+		logger.Info("MQTT: Disabling collector %s", name)
+		return c.collectorControl.DisableCollector(name)
+	default:
+		return fmt.Errorf("invalid collector payload: %s (expected ON or OFF)", payload)
+	}
+}
+
+// execCollectorInterval sets a collector's interval. Home Assistant number
+// entities send floats such as "30.0".
+func (c *Client) execCollectorInterval(name, payload string) error {
+	if c.collectorControl == nil {
+		return fmt.Errorf("collector control not available")
+	}
+	seconds, err := strconv.ParseFloat(payload, 64)
+	if err != nil {
+		return fmt.Errorf("invalid interval: %s", payload)
+	}
+	logger.Info("MQTT: Setting collector %s interval to %.0fs", name, seconds)
+	return c.collectorControl.UpdateInterval(name, int(seconds))
+}
@@ -0,0 +1,79 @@
+package mqtt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+type fakeCollectorControl struct {
+	enabled   map[string]bool
+	intervals map[string]int
+}
+
+func (f *fakeCollectorControl) EnableCollector(name string) error {
+	f.enabled[name] = true
+	return nil
+}
+
+func (f *fakeCollectorControl) DisableCollector(name string) error {
+	if name == "system" {
+		return errors.New("cannot disable system collector (always required)")
+	}
+	f.enabled[name] = false
+	return nil
+}
+
+func (f *fakeCollectorControl) UpdateInterval(name string, seconds int) error {
+	f.intervals[name] = seconds
+	return nil
+}
+
+func (f *fakeCollectorControl) GetAllStatus() dto.CollectorsStatusResponse {
+	return dto.CollectorsStatusResponse{}
+}
+
+func TestCollectorCommands(t *testing.T) {
+	client := NewClient(DefaultConfig(), "tower", "1.0.0", nil)
+	if err := client.execCollectorSwitch("docker", "ON"); err == nil {
+		t.Error("expected an error without collector control")
+	}
+
+	cc := &fakeCollectorControl{enabled: map[string]bool{}, intervals: map[string]int{}}
+	client.SetCollectorControl(cc)
+
+	if err := client.execCollectorSwitch("docker", "OFF"); err != nil || cc.enabled["docker"] {
+		t.Errorf("OFF: err=%v enabled=%v", err, cc.enabled["docker"])
+	}
+	if err := client.execCollectorSwitch("docker", "on"); err != nil || !cc.enabled["docker"] {
+		t.Errorf("on: err=%v enabled=%v", err, cc.enabled["docker"])
+	}
+	if err := client.execCollectorSwitch("docker", "TOGGLE"); err == nil {
+		t.Error("expected an error for an invalid payload")
+	}
+	if err := client.execCollectorSwitch("system", "OFF"); err == nil {
+		t.Error("expected the manager's error for a required collector")
+	}
+
+	// Home Assistant number entities send floats.
+	if err := client.execCollectorInterval("disk", "60.0"); err != nil || cc.intervals["disk"] != 60 {
+		t.Errorf("interval: err=%v interval=%d", err, cc.intervals["disk"])
+	}
+	if err := client.execCollectorInterval("disk", "soon"); err == nil {
+		t.Error("expected an error for a non-numeric interval")
+	}
+}
+
+func TestCollectorDisplayName(t *testing.T) {
+	for name, want := range map[string]string{
+		"docker_update": "Docker Update",
+		"zfs":           "ZFS",
+		"recycle_bin":   "Recycle Bin",
+		"nut":           "NUT",
+	} {
+		if got := collectorDisplayName(name); got != want {
+			t.Errorf("collectorDisplayName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
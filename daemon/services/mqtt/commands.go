@@ -2,14 +2,19 @@
 package mqtt
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	pahomqtt "github.com/eclipse/paho.mqtt.golang"
 
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/mock"
 )
 
 // subscribeCommandTopics subscribes to all command topics for switches and buttons.
@@ -62,6 +67,16 @@ func (c *Client) handleCommand(msg pahomqtt.Message) {
 
 	logger.Info("MQTT: Command received: %s → %s", relative, payload)
 
+	// Mock mode simulates every command except collector control, which
+	// only changes the agent.
+	if c.domainCtx != nil && c.domainCtx.Mock && parts[0] != "collectors" {
+		err := mock.SimulateAction(context.Background())
+		logger.Info("MQTT: Mock mode, simulated command %s", relative)
+		c.recordCommandAudit(parts, payload, err)
+		c.publishCommandResult(topic, err)
+		return
+	}
+
 	var err error
 
 	switch {
@@ -99,23 +114,78 @@ func (c *Client) handleCommand(msg pahomqtt.Message) {
 	case len(parts) == 4 && parts[0] == "unassigned" && parts[1] == "remote" && parts[3] == "set":
 		err = c.execRemoteShareSwitch(parts[2], payload)
 
+	// Recycle bin: recycle_bin/{share}/empty (button)
+	case len(parts) == 3 && parts[0] == "recycle_bin" && parts[2] == "empty":
+		err = c.execRecycleBinEmpty(parts[1])
+
+	// IPMI: ipmi/identify (button)
+	case len(parts) == 2 && parts[0] == "ipmi" && parts[1] == "identify":
+		logger.Info("MQTT: Blinking the chassis identify LED")
+		err = controllers.IPMIChassisAction(controllers.IPMIChassisActionIdentify, 0)
+
 	// System: system/reboot, system/shutdown (buttons)
 	case len(parts) == 2 && parts[0] == "system":
 		err = c.execSystemButton(parts[1])
 
+	// Fan: fan/{id}/set (on/off), fan/{id}/percentage (speed)
+	case len(parts) == 3 && parts[0] == "fan" && parts[2] == "set":
+		err = c.execFanSwitch(parts[1], payload)
+	case len(parts) == 3 && parts[0] == "fan" && parts[2] == "percentage":
+		err = c.execFanPercentage(parts[1], payload)
+
 	// Notifications: notifications/archive_all (button)
 	case len(parts) == 2 && parts[0] == "notifications" && parts[1] == "archive_all":
 		err = c.execArchiveAllNotifications()
 
+	// Collectors: collectors/{name}/set (switch), collectors/{name}/interval (number)
+	case len(parts) == 3 && parts[0] == "collectors" && parts[2] == "set":
+		err = c.execCollectorSwitch(parts[1], payload)
+	case len(parts) == 3 && parts[0] == "collectors" && parts[2] == "interval":
+		err = c.execCollectorInterval(parts[1], payload)
+
+	// Wake-on-LAN: wol/{device} (button)
+	case len(parts) == 2 && parts[0] == "wol":
+		err = c.execWakeOnLAN(parts[1])
+
 	default:
 		logger.Debug("MQTT: Unhandled command topic: %s", relative)
 		return
 	}
 
+	c.recordCommandAudit(parts, payload, err)
+
 	// Publish result
 	c.publishCommandResult(topic, err)
 }
 
+// recordCommandAudit writes an audit entry for a handled command. The entity
+// segment is split out of the command path so entries group by action, e.g.
+// "docker/plex/restart" is recorded as action "docker/restart", target "plex".
+func (c *Client) recordCommandAudit(parts []string, payload string, err error) {
+	action := strings.Join(parts, "/")
+	target := ""
+	switch {
+	case len(parts) == 4 && parts[0] == "unassigned" && parts[1] == "remote":
+		action, target = "unassigned/remote/"+parts[3], parts[2]
+	case len(parts) == 3 && parts[0] != "array":
+		action, target = parts[0]+"/"+parts[2], parts[1]
+	}
+
+	entry := dto.AuditEntry{
+		Source: dto.AuditSourceMQTT,
+		Client: c.config.Broker,
+		Action: action,
+		Target: target,
+		Result: dto.AuditResultSuccess,
+		Detail: payload,
+	}
+	if err != nil {
+		entry.Result = dto.AuditResultFailure
+		entry.Detail = err.Error()
+	}
+	c.auditLog.Record(entry)
+}
+
 // publishCommandResult publishes a success/error result on the command topic.
 func (c *Client) publishCommandResult(topic string, err error) {
 	result := map[string]any{"success": err == nil}
@@ -245,7 +315,7 @@ func (c *Client) execParityButton(action string) error {
 	switch action {
 	case "start":
 		logger.Info("MQTT: Starting parity check")
-		return ctrl.StartParityCheck(false)
+		return ctrl.StartParityCheck(false, dto.ParityTriggerMQTT)
 	case "stop":
 		logger.Info("MQTT: Stopping parity check")
 		return ctrl.StopParityCheck()
@@ -330,6 +400,21 @@ func (c *Client) execRemoteShareSwitch(shareID, payload string) error {
 	}
 }
 
+// --- Recycle Bin ---
+
+func (c *Client) execRecycleBinEmpty(shareID string) error {
+	share := c.lookupRecycleBinShare(shareID)
+	if share == "" {
+		return fmt.Errorf("unknown recycle bin share id: %s", shareID)
+	}
+
+	logger.Info("MQTT: Emptying recycle bin of share %s", share)
+	if _, err := controllers.EmptyRecycleBin(share); err != nil {
+		return fmt.Errorf("emptying recycle bin of share %s: %w", share, err)
+	}
+	return nil
+}
+
 // --- System ---
 
 func (c *Client) execSystemButton(action string) error {
@@ -350,9 +435,75 @@ func (c *Client) execSystemButton(action string) error {
 	}
 }
 
+// --- Fans ---
+
+// fanCtrl returns the fan controller, or an error when fan control is unavailable.
+func (c *Client) fanCtrl() (*controllers.FanController, error) {
+	fc := c.fanController.Load()
+	if fc == nil {
+		return nil, fmt.Errorf("fan controller not available")
+	}
+	return fc, nil
+}
+
+func (c *Client) execFanSwitch(fanID, payload string) error {
+	fc, err := c.fanCtrl()
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToUpper(payload) {
+	case "ON":
+		logger.Info("MQTT: Switching fan %s to manual control", fanID)
+		return fc.SetMode(fanID, string(dto.FanModeManual))
+	case "OFF":
+		logger.Info("MQTT: Returning fan %s to automatic control", fanID)
+		return fc.SetMode(fanID, string(dto.FanModeAutomatic))
+	default:
+		return fmt.Errorf("invalid fan switch payload: %s (expected ON/OFF)", payload)
+	}
+}
+
+func (c *Client) execFanPercentage(fanID, payload string) error {
+	fc, err := c.fanCtrl()
+	if err != nil {
+		return err
+	}
+	pct, err := strconv.Atoi(payload)
+	if err != nil {
+		return fmt.Errorf("invalid fan percentage payload: %s (expected 0-100)", payload)
+	}
+
+	// HA sends a percentage without switching the fan on first, so take
+	// manual control before setting the speed.
+	if err := fc.SetMode(fanID, string(dto.FanModeManual)); err != nil {
+		return err
+	}
+	logger.Info("MQTT: Setting fan %s speed to %d%%", fanID, pct)
+	return fc.SetSpeed(fanID, pct)
+}
+
 // --- Notifications ---
 
 func (c *Client) execArchiveAllNotifications() error {
 	logger.Info("MQTT: Archiving all notifications")
 	return controllers.ArchiveAllNotifications()
 }
+
+// --- Wake-on-LAN ---
+
+// execWakeOnLAN wakes the configured device whose sanitized name is id.
+func (c *Client) execWakeOnLAN(id string) error {
+	if c.domainCtx == nil {
+		return fmt.Errorf("domain context not available for wake-on-lan")
+	}
+	for _, device := range c.domainCtx.WOLDevices {
+		if sanitizeID(device.Name) != id {
+			continue
+		}
+		logger.Info("MQTT: Sending Wake-on-LAN packet to %s (%s)", device.Name, device.MAC)
+		_, err := lib.Wake(c.domainCtx.WOLDevices, dto.WOLRequest{Device: device.Name})
+		return err
+	}
+	return fmt.Errorf("unknown wake-on-lan device: %s", id)
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
 )
@@ -31,6 +32,22 @@ type haEntityOpts struct {
 	stateOff       string   // for switch (value that means OFF)
 	optimistic     bool     // for switch (no state feedback)
 	eventTypes     []string // for event entity type
+
+	// maintenanceExempt keeps a binary sensor available during maintenance
+	// windows; all other binary sensors report unavailable.
+	maintenanceExempt bool
+
+	percentageCommandTopic string // for fan
+	percentageTemplate     string // for fan
+
+	numberMin  float64 // for number
+	numberMax  float64 // for number
+	numberStep float64 // for number
+	numberMode string  // for number: auto, box or slider
+
+	// disabledByDefault registers the entity disabled, for settings most
+	// users never touch.
+	disabledByDefault bool
 }
 
 // discoveryTracker tracks published per-item HA discovery entities
@@ -70,18 +87,22 @@ func (t *discoveryTracker) update(category string, currentIDs []string) []string
 
 // publishHAEntity publishes a single Home Assistant discovery config.
 func (c *Client) publishHAEntity(opts haEntityOpts) {
-	hostID := strings.ReplaceAll(c.hostname, " ", "_")
-
 	discoveryTopic := fmt.Sprintf("%s/%s/%s/%s/config",
 		c.config.HADiscoveryPrefix,
 		opts.entityType,
-		hostID,
+		c.hostID(),
 		opts.id,
 	)
 
+	category := c.entityCategory(opts)
+	if c.discoveryDisabled(category) {
+		c.retractDiscovery(discoveryTopic)
+		return
+	}
+
 	config := map[string]any{
-		"name":                  opts.name,
-		"unique_id":             fmt.Sprintf("unraid_%s_%s", hostID, opts.id),
+		"name":                  c.entityName(opts, category),
+		"unique_id":             c.uniqueID(opts, category),
 		"availability_topic":    c.buildTopic("availability"),
 		"payload_available":     "online",
 		"payload_not_available": "offline",
@@ -89,13 +110,26 @@ func (c *Client) publishHAEntity(opts haEntityOpts) {
 		"device":                c.deviceInfo,
 	}
 
+	// Binary sensors also follow the maintenance window, so problem sensors
+	// don't trigger automations during planned work.
+	if opts.entityType == "binary_sensor" && !opts.maintenanceExempt {
+		delete(config, "availability_topic")
+		delete(config, "payload_available")
+		delete(config, "payload_not_available")
+		config["availability"] = []map[string]string{
+			{"topic": c.buildTopic("availability")},
+			{"topic": c.buildTopic("maintenance"), "value_template": "{{ 'offline' if value_json.active else 'online' }}"},
+		}
+		config["availability_mode"] = "all"
+	}
+
 	// state_topic is used by sensor, binary_sensor, and switch (not button)
 	if opts.entityType != "button" && opts.stateTopic != "" {
 		config["state_topic"] = opts.stateTopic
 	}
 
-	// value_template for sensors and binary sensors
-	if opts.template != "" && (opts.entityType == "sensor" || opts.entityType == "binary_sensor") {
+	// value_template for sensors, binary sensors and numbers
+	if opts.template != "" && (opts.entityType == "sensor" || opts.entityType == "binary_sensor" || opts.entityType == "number") {
 		config["value_template"] = opts.template
 	}
 
@@ -111,6 +145,9 @@ func (c *Client) publishHAEntity(opts haEntityOpts) {
 	if opts.entityCategory != "" {
 		config["entity_category"] = opts.entityCategory
 	}
+	if opts.disabledByDefault {
+		config["enabled_by_default"] = false
+	}
 
 	// binary_sensor payloads
 	if opts.entityType == "binary_sensor" {
@@ -154,6 +191,29 @@ func (c *Client) publishHAEntity(opts haEntityOpts) {
 		}
 	}
 
+	// fan-specific config: on/off plus a percentage speed control, both read
+	// from the shared state topic.
+	if opts.entityType == "fan" {
+		config["command_topic"] = opts.commandTopic
+		config["state_value_template"] = opts.template
+		config["payload_on"] = "ON"
+		config["payload_off"] = "OFF"
+		config["percentage_command_topic"] = opts.percentageCommandTopic
+		config["percentage_state_topic"] = opts.stateTopic
+		config["percentage_value_template"] = opts.percentageTemplate
+	}
+
+	// number-specific config
+	if opts.entityType == "number" {
+		config["command_topic"] = opts.commandTopic
+		config["min"] = opts.numberMin
+		config["max"] = opts.numberMax
+		config["step"] = opts.numberStep
+		if opts.numberMode != "" {
+			config["mode"] = opts.numberMode
+		}
+	}
+
 	// button-specific config
 	if opts.entityType == "button" {
 		config["command_topic"] = opts.commandTopic
@@ -177,11 +237,10 @@ func (c *Client) publishHAEntity(opts haEntityOpts) {
 
 // removeHAEntity removes a Home Assistant discovery entity by publishing empty payload.
 func (c *Client) removeHAEntity(entityType, id string) {
-	hostID := strings.ReplaceAll(c.hostname, " ", "_")
 	discoveryTopic := fmt.Sprintf("%s/%s/%s/%s/config",
 		c.config.HADiscoveryPrefix,
 		entityType,
-		hostID,
+		c.hostID(),
 		id,
 	)
 
@@ -192,7 +251,7 @@ func (c *Client) removeHAEntity(entityType, id string) {
 
 // removeHAEntities removes HA discovery entities across all possible entity types.
 func (c *Client) removeHAEntities(id string) {
-	for _, t := range []string{"sensor", "binary_sensor", "switch", "button"} {
+	for _, t := range []string{"sensor", "binary_sensor", "switch", "button", "fan", "number"} {
 		c.removeHAEntity(t, id)
 	}
 }
@@ -212,6 +271,17 @@ func (c *Client) publishHADiscovery() {
 	c.publishRegistrationDiscovery()
 	c.publishZFSSnapshotDiscovery()
 	c.publishZFSARCDiscovery()
+	c.publishWANDiscovery()
+	c.publishSpeedtestDiscovery()
+	c.publishPowerDiscovery()
+	c.publishMaintenanceDiscovery()
+	c.publishWOLDiscovery()
+	c.publishTriggerDiscovery()
+	c.publishParityScheduleDiscovery()
+	c.publishMoverDiscovery()
+	c.publishFlashDiscovery()
+	c.publishUpdatesDiscovery()
+	c.publishCollectorDiscovery()
 
 	logger.Success("MQTT: Home Assistant discovery published")
 }
@@ -227,31 +297,31 @@ func (c *Client) publishSystemDiscovery() {
 	// CPU sensors
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "cpu_usage", name: "System: CPU Usage", unit: "%",
+		id: "cpu_usage", name: i18n.T("System: CPU Usage"), unit: "%",
 		icon: "mdi:cpu-64-bit", template: "{{ value_json.cpu_usage_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "cpu_temp", name: "System: CPU Temperature", unit: "°C",
+		id: "cpu_temp", name: i18n.T("System: CPU Temperature"), unit: "°C",
 		icon: "mdi:thermometer", template: "{{ value_json.cpu_temp_celsius }}",
 		deviceClass: "temperature", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "cpu_mhz", name: "System: CPU Frequency", unit: "MHz",
+		id: "cpu_mhz", name: i18n.T("System: CPU Frequency"), unit: "MHz",
 		icon: "mdi:speedometer", template: "{{ value_json.cpu_mhz | round(0) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "cpu_power", name: "System: CPU Power", unit: "W",
+		id: "cpu_power", name: i18n.T("System: CPU Power"), unit: "W",
 		icon: "mdi:lightning-bolt", template: "{{ value_json.cpu_power_watts | default(0) | round(1) }}",
 		deviceClass: "power", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "dram_power", name: "System: DRAM Power", unit: "W",
+		id: "dram_power", name: i18n.T("System: DRAM Power"), unit: "W",
 		icon: "mdi:lightning-bolt", template: "{{ value_json.dram_power_watts | default(0) | round(1) }}",
 		deviceClass: "power", stateClass: "measurement",
 	})
@@ -259,25 +329,25 @@ func (c *Client) publishSystemDiscovery() {
 	// RAM sensors
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ram_usage", name: "System: RAM Usage", unit: "%",
+		id: "ram_usage", name: i18n.T("System: RAM Usage"), unit: "%",
 		icon: "mdi:memory", template: "{{ value_json.ram_usage_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ram_used", name: "System: RAM Used", unit: "B",
+		id: "ram_used", name: i18n.T("System: RAM Used"), unit: "B",
 		icon: "mdi:memory", template: "{{ value_json.ram_used_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ram_free", name: "System: RAM Free", unit: "B",
+		id: "ram_free", name: i18n.T("System: RAM Free"), unit: "B",
 		icon: "mdi:memory", template: "{{ value_json.ram_free_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ram_total", name: "System: RAM Total", unit: "B",
+		id: "ram_total", name: i18n.T("System: RAM Total"), unit: "B",
 		icon: "mdi:memory", template: "{{ value_json.ram_total_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement", entityCategory: "diagnostic",
 	})
@@ -285,31 +355,31 @@ func (c *Client) publishSystemDiscovery() {
 	// Swap sensors
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "swap_usage", name: "System: Swap Usage", unit: "%",
+		id: "swap_usage", name: i18n.T("System: Swap Usage"), unit: "%",
 		icon: "mdi:harddisk", template: "{{ value_json.swap_usage_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "swap_used", name: "System: Swap Used", unit: "B",
+		id: "swap_used", name: i18n.T("System: Swap Used"), unit: "B",
 		icon: "mdi:harddisk", template: "{{ value_json.swap_used_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "swap_free", name: "System: Swap Free", unit: "B",
+		id: "swap_free", name: i18n.T("System: Swap Free"), unit: "B",
 		icon: "mdi:harddisk", template: "{{ value_json.swap_free_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "swap_total", name: "System: Swap Total", unit: "B",
+		id: "swap_total", name: i18n.T("System: Swap Total"), unit: "B",
 		icon: "mdi:harddisk", template: "{{ value_json.swap_total_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement", entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "swappiness", name: "System: Swappiness",
+		id: "swappiness", name: i18n.T("System: Swappiness"),
 		icon: "mdi:tune-variant", template: "{{ value_json.swappiness }}",
 		stateClass: "measurement", entityCategory: "diagnostic",
 	})
@@ -317,7 +387,7 @@ func (c *Client) publishSystemDiscovery() {
 	// Motherboard temperature
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "motherboard_temp", name: "System: Motherboard Temperature", unit: "°C",
+		id: "motherboard_temp", name: i18n.T("System: Motherboard Temperature"), unit: "°C",
 		icon: "mdi:thermometer", template: "{{ value_json.motherboard_temp_celsius | default(0) }}",
 		deviceClass: "temperature", stateClass: "measurement",
 	})
@@ -325,7 +395,7 @@ func (c *Client) publishSystemDiscovery() {
 	// Uptime
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "uptime", name: "System: Uptime", unit: "s",
+		id: "uptime", name: i18n.T("System: Uptime"), unit: "s",
 		icon: "mdi:clock-outline", template: "{{ value_json.uptime_seconds }}",
 		deviceClass: "duration", stateClass: "measurement",
 	})
@@ -333,19 +403,19 @@ func (c *Client) publishSystemDiscovery() {
 	// Version info (diagnostic)
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "unraid_version", name: "System: Unraid Version",
+		id: "unraid_version", name: i18n.T("System: Unraid Version"),
 		icon: "mdi:information-outline", template: "{{ value_json.version }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "agent_version", name: "System: Agent Version",
+		id: "agent_version", name: i18n.T("System: Agent Version"),
 		icon: "mdi:information-outline", template: "{{ value_json.agent_version }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "kernel_version", name: "System: Kernel Version",
+		id: "kernel_version", name: i18n.T("System: Kernel Version"),
 		icon: "mdi:linux", template: "{{ value_json.kernel_version }}",
 		entityCategory: "diagnostic",
 	})
@@ -353,13 +423,13 @@ func (c *Client) publishSystemDiscovery() {
 	// CPU info (diagnostic)
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "cpu_model", name: "System: CPU Model",
+		id: "cpu_model", name: i18n.T("System: CPU Model"),
 		icon: "mdi:cpu-64-bit", template: "{{ value_json.cpu_model }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "cpu_cores", name: "System: CPU Cores",
+		id: "cpu_cores", name: i18n.T("System: CPU Cores"),
 		icon: "mdi:cpu-64-bit", template: "{{ value_json.cpu_cores }}",
 		entityCategory: "diagnostic",
 	})
@@ -367,13 +437,13 @@ func (c *Client) publishSystemDiscovery() {
 	// Binary sensors
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: "hvm_support", name: "System: HVM Support",
+		id: "hvm_support", name: i18n.T("System: HVM Support"),
 		icon: "mdi:chip", template: "{{ 'ON' if value_json.hvm_enabled else 'OFF' }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: "iommu_support", name: "System: IOMMU Support",
+		id: "iommu_support", name: i18n.T("System: IOMMU Support"),
 		icon: "mdi:chip", template: "{{ 'ON' if value_json.iommu_enabled else 'OFF' }}",
 		entityCategory: "diagnostic",
 	})
@@ -394,7 +464,7 @@ func (c *Client) publishFanDiscovery(fans []dto.FanInfo) {
 		fanID := "fan_" + sanitizeID(fan.Name)
 		c.publishHAEntity(haEntityOpts{
 			entityType: "sensor", stateTopic: topic,
-			id: fanID, name: fmt.Sprintf("System: %s", fan.Name), unit: "RPM",
+			id: fanID, name: i18n.T("System: %s", fan.Name), unit: "RPM",
 			icon:       "mdi:fan",
 			template:   fmt.Sprintf(`{{ (value_json.fans | selectattr('name', 'eq', '%s') | map(attribute='rpm') | first | default(0)) }}`, fan.Name),
 			stateClass: "measurement",
@@ -418,55 +488,81 @@ func (c *Client) publishArrayDiscovery() {
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "array_state", name: "Array: State",
+		id: "array_state", name: i18n.T("Array: State"),
 		icon: "mdi:server", template: "{{ value_json.state }}",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "array_usage", name: "Array: Usage", unit: "%",
+		id: "array_usage", name: i18n.T("Array: Usage"), unit: "%",
 		icon: "mdi:chart-pie", template: "{{ value_json.used_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "array_free", name: "Array: Free Space", unit: "B",
+		id: "array_free", name: i18n.T("Array: Free Space"), unit: "B",
 		icon: "mdi:harddisk", template: "{{ value_json.free_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "array_total", name: "Array: Total Space", unit: "B",
+		id: "array_total", name: i18n.T("Array: Total Space"), unit: "B",
 		icon: "mdi:harddisk", template: "{{ value_json.total_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement", entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "array_num_disks", name: "Array: Disk Count",
+		id: "array_fill_rate", name: i18n.T("Array: Fill Rate"), unit: fillRateUnit,
+		icon: "mdi:trending-up", template: fillRateTemplate,
+		stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "array_days_until_full", name: i18n.T("Array: Days Until Full"), unit: "d",
+		icon: "mdi:calendar-clock", template: daysUntilFullTemplate,
+		deviceClass: "duration", stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "array_num_disks", name: i18n.T("Array: Disk Count"),
 		icon: "mdi:harddisk", template: "{{ value_json.num_disks }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "parity_status", name: "Array: Parity Status",
+		id: "parity_status", name: i18n.T("Array: Parity Status"),
 		icon: "mdi:shield-check", template: "{{ value_json.parity_check_status | default('idle') }}",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "parity_progress", name: "Array: Parity Progress", unit: "%",
+		id: "parity_progress", name: i18n.T("Array: Parity Progress"), unit: "%",
 		icon: "mdi:progress-check", template: "{{ value_json.parity_check_progress | default(0) | round(1) }}",
 		stateClass: "measurement",
 	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "parity_speed", name: i18n.T("Array: Parity Speed"), unit: "MB/s",
+		icon:        "mdi:speedometer",
+		template:    "{{ ((value_json.parity_check_speed_bytes_per_sec | default(0)) / 1000000) | round(1) }}",
+		deviceClass: "data_rate", stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "parity_finish", name: i18n.T("Array: Parity Finishes At"),
+		icon:        "mdi:timer-sand",
+		template:    "{{ value_json.parity_check_finish_at | default(None) }}",
+		deviceClass: "timestamp",
+	})
 
 	// Binary sensors
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: "parity_valid", name: "Array: Parity Valid",
+		id: "parity_valid", name: i18n.T("Array: Parity Valid"),
 		icon: "mdi:shield-check", template: "{{ 'ON' if value_json.parity_valid else 'OFF' }}",
 		deviceClass: "safety",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: "array_started", name: "Array: Started",
+		id: "array_started", name: i18n.T("Array: Started"),
 		icon: "mdi:server", template: "{{ 'ON' if value_json.state == 'Started' else 'OFF' }}",
 		deviceClass: "running",
 	})
@@ -475,7 +571,7 @@ func (c *Client) publishArrayDiscovery() {
 	c.publishHAEntity(haEntityOpts{
 		entityType: "switch", stateTopic: topic,
 		commandTopic: c.buildCommandTopic("array", "set"),
-		id:           "array_switch", name: "Array: Power",
+		id:           "array_switch", name: i18n.T("Array: Power"),
 		icon: "mdi:server", template: "{{ value_json.state }}",
 		stateOn: "STARTED", stateOff: "STOPPED",
 	})
@@ -484,25 +580,25 @@ func (c *Client) publishArrayDiscovery() {
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("array", "parity", "start"),
-		id:           "parity_start", name: "Array: Start Parity Check",
+		id:           "parity_start", name: i18n.T("Array: Start Parity Check"),
 		icon: "mdi:shield-sync",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("array", "parity", "stop"),
-		id:           "parity_stop", name: "Array: Stop Parity Check",
+		id:           "parity_stop", name: i18n.T("Array: Stop Parity Check"),
 		icon: "mdi:shield-off",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("array", "parity", "pause"),
-		id:           "parity_pause", name: "Array: Pause Parity Check",
+		id:           "parity_pause", name: i18n.T("Array: Pause Parity Check"),
 		icon: "mdi:pause-circle",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("array", "parity", "resume"),
-		id:           "parity_resume", name: "Array: Resume Parity Check",
+		id:           "parity_resume", name: i18n.T("Array: Resume Parity Check"),
 		icon: "mdi:play-circle",
 	})
 }
@@ -517,42 +613,42 @@ func (c *Client) publishUPSDiscovery() {
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: "ups_connected", name: "UPS: Connected",
+		id: "ups_connected", name: i18n.T("UPS: Connected"),
 		icon: "mdi:battery-charging", template: "{{ 'ON' if value_json.connected else 'OFF' }}",
 		deviceClass: "connectivity",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ups_status", name: "UPS: Status",
+		id: "ups_status", name: i18n.T("UPS: Status"),
 		icon: "mdi:battery-charging", template: "{{ value_json.status }}",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ups_load", name: "UPS: Load", unit: "%",
+		id: "ups_load", name: i18n.T("UPS: Load"), unit: "%",
 		icon: "mdi:gauge", template: "{{ value_json.load_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ups_battery", name: "UPS: Battery Level", unit: "%",
+		id: "ups_battery", name: i18n.T("UPS: Battery Level"), unit: "%",
 		icon: "mdi:battery", template: "{{ value_json.battery_charge_percent | round(0) }}",
 		deviceClass: "battery", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ups_runtime", name: "UPS: Runtime Remaining", unit: "s",
+		id: "ups_runtime", name: i18n.T("UPS: Runtime Remaining"), unit: "s",
 		icon: "mdi:clock-outline", template: "{{ value_json.runtime_left_seconds }}",
 		deviceClass: "duration", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ups_power", name: "UPS: Power Draw", unit: "W",
+		id: "ups_power", name: i18n.T("UPS: Power Draw"), unit: "W",
 		icon: "mdi:lightning-bolt", template: "{{ value_json.power_watts | default(0) | round(0) }}",
 		deviceClass: "power", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "ups_model", name: "UPS: Model",
+		id: "ups_model", name: i18n.T("UPS: Model"),
 		icon: "mdi:battery-charging", template: "{{ value_json.model }}",
 		entityCategory: "diagnostic",
 	})
@@ -568,25 +664,25 @@ func (c *Client) publishNotificationDiscovery() {
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "notif_unread", name: "Notifications: Unread",
+		id: "notif_unread", name: i18n.T("Notifications: Unread"),
 		icon: "mdi:bell-badge", template: "{{ value_json.overview.unread.total | default(0) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "notif_alerts", name: "Notifications: Alerts",
+		id: "notif_alerts", name: i18n.T("Notifications: Alerts"),
 		icon: "mdi:alert-circle", template: "{{ value_json.overview.unread.alert | default(0) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "notif_warnings", name: "Notifications: Warnings",
+		id: "notif_warnings", name: i18n.T("Notifications: Warnings"),
 		icon: "mdi:alert", template: "{{ value_json.overview.unread.warning | default(0) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "notif_info", name: "Notifications: Info",
+		id: "notif_info", name: i18n.T("Notifications: Info"),
 		icon: "mdi:information", template: "{{ value_json.overview.unread.info | default(0) }}",
 		stateClass: "measurement",
 	})
@@ -595,7 +691,7 @@ func (c *Client) publishNotificationDiscovery() {
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("notifications", "archive_all"),
-		id:           "notif_archive_all", name: "Notifications: Archive All",
+		id:           "notif_archive_all", name: i18n.T("Notifications: Archive All"),
 		icon: "mdi:archive-arrow-down",
 	})
 
@@ -604,7 +700,7 @@ func (c *Client) publishNotificationDiscovery() {
 	// event attributes so automations can react to individual notifications.
 	c.publishHAEntity(haEntityOpts{
 		entityType: "event", stateTopic: c.buildTopic("notifications/event"),
-		id: "notif_event", name: "Notifications: Event",
+		id: "notif_event", name: i18n.T("Notifications: Event"),
 		icon:       "mdi:bell-ring",
 		eventTypes: []string{"alert", "warning", "info"},
 	})
@@ -628,7 +724,7 @@ func (c *Client) publishServiceDiscovery() {
 			stateTopic:   servicesTopic,
 			commandTopic: c.buildCommandTopic("service", svcID, "set"),
 			id:           fmt.Sprintf("service_%s_switch", svcID),
-			name:         fmt.Sprintf("Service: %s", displayName),
+			name:         i18n.T("Service: %s", displayName),
 			icon:         serviceIcon(svc),
 			template:     fmt.Sprintf("{{ 'ON' if value_json.%s else 'OFF' }}", svc),
 		})
@@ -708,14 +804,14 @@ func (c *Client) publishSystemControlDiscovery() {
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("system", "reboot"),
-		id:           "system_reboot", name: "System: Reboot",
+		id:           "system_reboot", name: i18n.T("System: Reboot"),
 		icon:        "mdi:restart",
 		deviceClass: "restart",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("system", "shutdown"),
-		id:           "system_shutdown", name: "System: Shutdown",
+		id:           "system_shutdown", name: i18n.T("System: Shutdown"),
 		icon: "mdi:power",
 	})
 }
@@ -779,59 +875,59 @@ func (c *Client) publishDiskEntities(topic, prefix, displayName, diskID string)
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_temp", name: fmt.Sprintf("Disk: %s Temperature", displayName), unit: "°C",
+		id: prefix + "_temp", name: i18n.T("Disk: %s Temperature", displayName), unit: "°C",
 		icon: "mdi:thermometer", template: "{{ value_json.temperature_celsius }}",
 		deviceClass: "temperature", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_status", name: fmt.Sprintf("Disk: %s Status", displayName),
+		id: prefix + "_status", name: i18n.T("Disk: %s Status", displayName),
 		icon: "mdi:harddisk", template: "{{ value_json.status }}",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_smart_status", name: fmt.Sprintf("Disk: %s SMART Status", displayName),
+		id: prefix + "_smart_status", name: i18n.T("Disk: %s SMART Status", displayName),
 		icon: "mdi:harddisk", template: "{{ value_json.smart_status }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_usage", name: fmt.Sprintf("Disk: %s Usage", displayName), unit: "%",
+		id: prefix + "_usage", name: i18n.T("Disk: %s Usage", displayName), unit: "%",
 		icon: "mdi:chart-pie", template: "{{ value_json.usage_percent | default(0) | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_used", name: fmt.Sprintf("Disk: %s Used", displayName), unit: "B",
+		id: prefix + "_used", name: i18n.T("Disk: %s Used", displayName), unit: "B",
 		icon: "mdi:harddisk", template: "{{ value_json.used_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_free", name: fmt.Sprintf("Disk: %s Free", displayName), unit: "B",
+		id: prefix + "_free", name: i18n.T("Disk: %s Free", displayName), unit: "B",
 		icon: "mdi:harddisk", template: "{{ value_json.free_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_spin_state", name: fmt.Sprintf("Disk: %s Spin State", displayName),
+		id: prefix + "_spin_state", name: i18n.T("Disk: %s Spin State", displayName),
 		icon: "mdi:rotate-3d-variant", template: "{{ value_json.spin_state | default('unknown') }}",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_power_hours", name: fmt.Sprintf("Disk: %s Power On Hours", displayName), unit: "h",
+		id: prefix + "_power_hours", name: i18n.T("Disk: %s Power On Hours", displayName), unit: "h",
 		icon: "mdi:clock-outline", template: "{{ value_json.power_on_hours | default(0) }}",
 		deviceClass: "duration", stateClass: "total_increasing",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_io_util", name: fmt.Sprintf("Disk: %s I/O Utilization", displayName), unit: "%",
+		id: prefix + "_io_util", name: i18n.T("Disk: %s I/O Utilization", displayName), unit: "%",
 		icon: "mdi:speedometer", template: "{{ value_json.io_utilization_percent | default(0) | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: prefix + "_healthy", name: fmt.Sprintf("Disk: %s Healthy", displayName),
+		id: prefix + "_healthy", name: i18n.T("Disk: %s Healthy", displayName),
 		icon: "mdi:check-circle", template: "{{ 'ON' if value_json.smart_status == 'PASSED' else 'OFF' }}",
 		deviceClass: "safety",
 	})
@@ -840,13 +936,13 @@ func (c *Client) publishDiskEntities(topic, prefix, displayName, diskID string)
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("disk", diskID, "spin_up"),
-		id:           prefix + "_spin_up", name: fmt.Sprintf("Disk: %s Spin Up", displayName),
+		id:           prefix + "_spin_up", name: i18n.T("Disk: %s Spin Up", displayName),
 		icon: "mdi:rotate-right",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("disk", diskID, "spin_down"),
-		id:           prefix + "_spin_down", name: fmt.Sprintf("Disk: %s Spin Down", displayName),
+		id:           prefix + "_spin_down", name: i18n.T("Disk: %s Spin Down", displayName),
 		icon: "mdi:stop-circle",
 	})
 
@@ -868,13 +964,13 @@ func (c *Client) publishContainerDiscovery(containers []dto.ContainerInfo) {
 	containersTopic := c.buildTopic("docker/containers")
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: containersTopic,
-		id: "docker_total", name: "Docker: Total Containers",
+		id: "docker_total", name: i18n.T("Docker: Total Containers"),
 		icon: "mdi:docker", template: "{{ value_json | length }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: containersTopic,
-		id: "docker_running", name: "Docker: Running Containers",
+		id: "docker_running", name: i18n.T("Docker: Running Containers"),
 		icon: "mdi:docker", template: "{{ value_json | selectattr('state', 'eq', 'running') | list | length }}",
 		stateClass: "measurement",
 	})
@@ -910,6 +1006,7 @@ func (c *Client) publishContainerEntities(topic, prefix, displayName, nameID str
 		prefix + "_net_rx",
 		prefix + "_net_tx",
 		prefix + "_mac",
+		prefix + "_health",
 		prefix + "_switch",
 		prefix + "_restart",
 		prefix + "_pause",
@@ -918,48 +1015,56 @@ func (c *Client) publishContainerEntities(topic, prefix, displayName, nameID str
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: prefix + "_state", name: fmt.Sprintf("Docker: %s Running", displayName),
+		id: prefix + "_state", name: i18n.T("Docker: %s Running", displayName),
 		icon: "mdi:docker", template: "{{ 'ON' if value_json.state == 'running' else 'OFF' }}",
 		deviceClass: "running",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_cpu", name: fmt.Sprintf("Docker: %s CPU", displayName), unit: "%",
+		id: prefix + "_cpu", name: i18n.T("Docker: %s CPU", displayName), unit: "%",
 		icon: "mdi:cpu-64-bit", template: "{{ value_json.cpu_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_memory", name: fmt.Sprintf("Docker: %s Memory", displayName), unit: "B",
+		id: prefix + "_memory", name: i18n.T("Docker: %s Memory", displayName), unit: "B",
 		icon: "mdi:memory", template: "{{ value_json.memory_usage_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_net_rx", name: fmt.Sprintf("Docker: %s Network RX", displayName), unit: "B",
+		id: prefix + "_net_rx", name: i18n.T("Docker: %s Network RX", displayName), unit: "B",
 		icon: "mdi:download", template: "{{ value_json.network_rx_bytes }}",
 		deviceClass: "data_size", stateClass: "total_increasing",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_net_tx", name: fmt.Sprintf("Docker: %s Network TX", displayName), unit: "B",
+		id: prefix + "_net_tx", name: i18n.T("Docker: %s Network TX", displayName), unit: "B",
 		icon: "mdi:upload", template: "{{ value_json.network_tx_bytes }}",
 		deviceClass: "data_size", stateClass: "total_increasing",
 	})
 	// MAC address (Docker 29 / Unraid 7.3 fixed-MAC support)
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_mac", name: fmt.Sprintf("Docker: %s MAC Address", displayName),
+		id: prefix + "_mac", name: i18n.T("Docker: %s MAC Address", displayName),
 		icon:           "mdi:ethernet",
 		template:       "{{ value_json.mac_address | default('') }}",
 		entityCategory: "diagnostic",
 	})
 
+	// Docker HEALTHCHECK status (healthy, unhealthy, starting, none)
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: prefix + "_health", name: i18n.T("Docker: %s Health", displayName),
+		icon:     "mdi:heart-pulse",
+		template: "{{ value_json.health | default('none') }}",
+	})
+
 	// Power switch (start/stop)
 	c.publishHAEntity(haEntityOpts{
 		entityType: "switch", stateTopic: topic,
 		commandTopic: c.buildCommandTopic("docker", nameID, "set"),
-		id:           prefix + "_switch", name: fmt.Sprintf("Docker: %s Power", displayName),
+		id:           prefix + "_switch", name: i18n.T("Docker: %s Power", displayName),
 		icon: "mdi:docker", template: "{{ value_json.state }}",
 		stateOn: "running", stateOff: "exited",
 	})
@@ -968,20 +1073,20 @@ func (c *Client) publishContainerEntities(topic, prefix, displayName, nameID str
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("docker", nameID, "restart"),
-		id:           prefix + "_restart", name: fmt.Sprintf("Docker: %s Restart", displayName),
+		id:           prefix + "_restart", name: i18n.T("Docker: %s Restart", displayName),
 		icon:        "mdi:restart",
 		deviceClass: "restart",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("docker", nameID, "pause"),
-		id:           prefix + "_pause", name: fmt.Sprintf("Docker: %s Pause", displayName),
+		id:           prefix + "_pause", name: i18n.T("Docker: %s Pause", displayName),
 		icon: "mdi:pause-circle",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("docker", nameID, "unpause"),
-		id:           prefix + "_unpause", name: fmt.Sprintf("Docker: %s Unpause", displayName),
+		id:           prefix + "_unpause", name: i18n.T("Docker: %s Unpause", displayName),
 		icon: "mdi:play-circle",
 	})
 
@@ -1003,13 +1108,13 @@ func (c *Client) publishVMDiscovery(vms []dto.VMInfo) {
 	vmsTopic := c.buildTopic("vm/list")
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: vmsTopic,
-		id: "vm_total", name: "VM: Total",
+		id: "vm_total", name: i18n.T("VM: Total"),
 		icon: "mdi:desktop-classic", template: "{{ value_json | length }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: vmsTopic,
-		id: "vm_running", name: "VM: Running",
+		id: "vm_running", name: i18n.T("VM: Running"),
 		icon: "mdi:desktop-classic", template: "{{ value_json | selectattr('state', 'eq', 'running') | list | length }}",
 		stateClass: "measurement",
 	})
@@ -1054,31 +1159,31 @@ func (c *Client) publishVMEntities(topic, prefix, displayName, nameID string) []
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: prefix + "_state", name: fmt.Sprintf("VM: %s Running", displayName),
+		id: prefix + "_state", name: i18n.T("VM: %s Running", displayName),
 		icon: "mdi:desktop-classic", template: "{{ 'ON' if value_json.state == 'running' else 'OFF' }}",
 		deviceClass: "running",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_guest_cpu", name: fmt.Sprintf("VM: %s Guest CPU", displayName), unit: "%",
+		id: prefix + "_guest_cpu", name: i18n.T("VM: %s Guest CPU", displayName), unit: "%",
 		icon: "mdi:cpu-64-bit", template: "{{ value_json.guest_cpu_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_host_cpu", name: fmt.Sprintf("VM: %s Host CPU", displayName), unit: "%",
+		id: prefix + "_host_cpu", name: i18n.T("VM: %s Host CPU", displayName), unit: "%",
 		icon: "mdi:cpu-64-bit", template: "{{ value_json.host_cpu_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_memory_used", name: fmt.Sprintf("VM: %s Memory Used", displayName), unit: "B",
+		id: prefix + "_memory_used", name: i18n.T("VM: %s Memory Used", displayName), unit: "B",
 		icon: "mdi:memory", template: "{{ value_json.memory_used_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_memory_allocated", name: fmt.Sprintf("VM: %s Memory Allocated", displayName), unit: "B",
+		id: prefix + "_memory_allocated", name: i18n.T("VM: %s Memory Allocated", displayName), unit: "B",
 		icon: "mdi:memory", template: "{{ value_json.memory_allocated_bytes }}",
 		deviceClass: "data_size", entityCategory: "diagnostic",
 	})
@@ -1087,7 +1192,7 @@ func (c *Client) publishVMEntities(topic, prefix, displayName, nameID string) []
 	c.publishHAEntity(haEntityOpts{
 		entityType: "switch", stateTopic: topic,
 		commandTopic: c.buildCommandTopic("vm", nameID, "set"),
-		id:           prefix + "_switch", name: fmt.Sprintf("VM: %s Power", displayName),
+		id:           prefix + "_switch", name: i18n.T("VM: %s Power", displayName),
 		icon: "mdi:desktop-classic", template: "{{ value_json.state }}",
 		stateOn: "running", stateOff: "shut off",
 	})
@@ -1096,32 +1201,32 @@ func (c *Client) publishVMEntities(topic, prefix, displayName, nameID string) []
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("vm", nameID, "restart"),
-		id:           prefix + "_restart", name: fmt.Sprintf("VM: %s Restart", displayName),
+		id:           prefix + "_restart", name: i18n.T("VM: %s Restart", displayName),
 		icon:        "mdi:restart",
 		deviceClass: "restart",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("vm", nameID, "pause"),
-		id:           prefix + "_pause", name: fmt.Sprintf("VM: %s Pause", displayName),
+		id:           prefix + "_pause", name: i18n.T("VM: %s Pause", displayName),
 		icon: "mdi:pause-circle",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("vm", nameID, "resume"),
-		id:           prefix + "_resume", name: fmt.Sprintf("VM: %s Resume", displayName),
+		id:           prefix + "_resume", name: i18n.T("VM: %s Resume", displayName),
 		icon: "mdi:play-circle",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("vm", nameID, "hibernate"),
-		id:           prefix + "_hibernate", name: fmt.Sprintf("VM: %s Hibernate", displayName),
+		id:           prefix + "_hibernate", name: i18n.T("VM: %s Hibernate", displayName),
 		icon: "mdi:power-sleep",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType:   "button",
 		commandTopic: c.buildCommandTopic("vm", nameID, "force_stop"),
-		id:           prefix + "_force_stop", name: fmt.Sprintf("VM: %s Force Stop", displayName),
+		id:           prefix + "_force_stop", name: i18n.T("VM: %s Force Stop", displayName),
 		icon: "mdi:power-off",
 	})
 
@@ -1155,7 +1260,7 @@ func (c *Client) publishGPUDiscovery(gpus []*dto.GPUMetrics) {
 		prefix := fmt.Sprintf("gpu_%s", gpuID)
 		displayName := gpu.Name
 		if displayName == "" {
-			displayName = fmt.Sprintf("GPU %d", gpu.Index)
+			displayName = i18n.T("GPU %d", gpu.Index)
 		}
 
 		ids := c.publishGPUEntities(gpuTopic, prefix, displayName)
@@ -1181,37 +1286,37 @@ func (c *Client) publishGPUEntities(topic, prefix, displayName string) []string
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_temp", name: fmt.Sprintf("GPU: %s Temperature", displayName), unit: "°C",
+		id: prefix + "_temp", name: i18n.T("GPU: %s Temperature", displayName), unit: "°C",
 		icon: "mdi:thermometer", template: "{{ value_json.temperature_celsius }}",
 		deviceClass: "temperature", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_util", name: fmt.Sprintf("GPU: %s Utilization", displayName), unit: "%",
+		id: prefix + "_util", name: i18n.T("GPU: %s Utilization", displayName), unit: "%",
 		icon: "mdi:expansion-card", template: "{{ value_json.utilization_gpu_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_mem_util", name: fmt.Sprintf("GPU: %s Memory Utilization", displayName), unit: "%",
+		id: prefix + "_mem_util", name: i18n.T("GPU: %s Memory Utilization", displayName), unit: "%",
 		icon: "mdi:expansion-card", template: "{{ value_json.utilization_memory_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_mem_used", name: fmt.Sprintf("GPU: %s Memory Used", displayName), unit: "B",
+		id: prefix + "_mem_used", name: i18n.T("GPU: %s Memory Used", displayName), unit: "B",
 		icon: "mdi:memory", template: "{{ value_json.memory_used_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_power", name: fmt.Sprintf("GPU: %s Power Draw", displayName), unit: "W",
+		id: prefix + "_power", name: i18n.T("GPU: %s Power Draw", displayName), unit: "W",
 		icon: "mdi:lightning-bolt", template: "{{ value_json.power_draw_watts | round(1) }}",
 		deviceClass: "power", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_fan", name: fmt.Sprintf("GPU: %s Fan Speed", displayName), unit: "%",
+		id: prefix + "_fan", name: i18n.T("GPU: %s Fan Speed", displayName), unit: "%",
 		icon: "mdi:fan", template: "{{ value_json.fan_speed_percent | default(0) | round(0) }}",
 		stateClass: "measurement",
 	})
@@ -1297,37 +1402,37 @@ func (c *Client) publishNetworkEntities(topic, prefix, displayName string) []str
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: prefix + "_state", name: fmt.Sprintf("Network: %s Link", displayName),
+		id: prefix + "_state", name: i18n.T("Network: %s Link", displayName),
 		icon: "mdi:ethernet", template: "{{ 'ON' if value_json.state == 'up' else 'OFF' }}",
 		deviceClass: "connectivity",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_speed", name: fmt.Sprintf("Network: %s Speed", displayName), unit: "Mbit/s",
+		id: prefix + "_speed", name: i18n.T("Network: %s Speed", displayName), unit: "Mbit/s",
 		icon: "mdi:speedometer", template: "{{ value_json.speed_mbps }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_rx", name: fmt.Sprintf("Network: %s Throughput In", displayName), unit: "B/s",
+		id: prefix + "_rx", name: i18n.T("Network: %s Throughput In", displayName), unit: "B/s",
 		icon: "mdi:download", template: "{{ value_json.rx_bytes_per_sec | round(1) }}",
 		deviceClass: "data_rate", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_tx", name: fmt.Sprintf("Network: %s Throughput Out", displayName), unit: "B/s",
+		id: prefix + "_tx", name: i18n.T("Network: %s Throughput Out", displayName), unit: "B/s",
 		icon: "mdi:upload", template: "{{ value_json.tx_bytes_per_sec | round(1) }}",
 		deviceClass: "data_rate", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_errors_rx", name: fmt.Sprintf("Network: %s RX Errors", displayName),
+		id: prefix + "_errors_rx", name: i18n.T("Network: %s RX Errors", displayName),
 		icon: "mdi:alert-circle", template: "{{ value_json.errors_received }}",
 		stateClass: "total_increasing", entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_errors_tx", name: fmt.Sprintf("Network: %s TX Errors", displayName),
+		id: prefix + "_errors_tx", name: i18n.T("Network: %s TX Errors", displayName),
 		icon: "mdi:alert-circle", template: "{{ value_json.errors_sent }}",
 		stateClass: "total_increasing", entityCategory: "diagnostic",
 	})
@@ -1379,19 +1484,19 @@ func (c *Client) publishShareEntities(topic, prefix, displayName string) []strin
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_usage", name: fmt.Sprintf("Share: %s Usage", displayName), unit: "%",
+		id: prefix + "_usage", name: i18n.T("Share: %s Usage", displayName), unit: "%",
 		icon: "mdi:folder", template: "{{ value_json.usage_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_used", name: fmt.Sprintf("Share: %s Used", displayName), unit: "B",
+		id: prefix + "_used", name: i18n.T("Share: %s Used", displayName), unit: "B",
 		icon: "mdi:folder", template: "{{ value_json.used_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_free", name: fmt.Sprintf("Share: %s Free", displayName), unit: "B",
+		id: prefix + "_free", name: i18n.T("Share: %s Free", displayName), unit: "B",
 		icon: "mdi:folder", template: "{{ value_json.free_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
@@ -1443,56 +1548,399 @@ func (c *Client) publishZFSEntities(topic, prefix, displayName string) []string
 		prefix + "_errors",
 		prefix + "_healthy",
 		prefix + "_corrupted_files",
+		prefix + "_fill_rate",
+		prefix + "_days_until_full",
 	}
 
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_health", name: fmt.Sprintf("ZFS: %s Health", displayName),
+		id: prefix + "_health", name: i18n.T("ZFS: %s Health", displayName),
 		icon: "mdi:database", template: "{{ value_json.health }}",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_capacity", name: fmt.Sprintf("ZFS: %s Usage", displayName), unit: "%",
+		id: prefix + "_capacity", name: i18n.T("ZFS: %s Usage", displayName), unit: "%",
 		icon: "mdi:database", template: "{{ value_json.capacity_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_free", name: fmt.Sprintf("ZFS: %s Free", displayName), unit: "B",
+		id: prefix + "_free", name: i18n.T("ZFS: %s Free", displayName), unit: "B",
 		icon: "mdi:database", template: "{{ value_json.free_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_fragmentation", name: fmt.Sprintf("ZFS: %s Fragmentation", displayName), unit: "%",
+		id: prefix + "_fragmentation", name: i18n.T("ZFS: %s Fragmentation", displayName), unit: "%",
 		icon: "mdi:chart-scatter-plot", template: "{{ value_json.fragmentation_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_errors", name: fmt.Sprintf("ZFS: %s Errors", displayName),
+		id: prefix + "_errors", name: i18n.T("ZFS: %s Errors", displayName),
 		icon:       "mdi:alert-circle",
 		template:   "{{ (value_json.read_errors | default(0)) + (value_json.write_errors | default(0)) + (value_json.checksum_errors | default(0)) }}",
 		stateClass: "total_increasing",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: prefix + "_healthy", name: fmt.Sprintf("ZFS: %s Healthy", displayName),
+		id: prefix + "_healthy", name: i18n.T("ZFS: %s Healthy", displayName),
 		icon: "mdi:check-circle", template: "{{ 'ON' if value_json.health == 'ONLINE' else 'OFF' }}",
 		deviceClass: "safety",
 	})
 	// Corrupted file count (Unraid 7.3 / ZFS 2.4.1 surfaces these without a scrub)
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_corrupted_files", name: fmt.Sprintf("ZFS: %s Corrupted Files", displayName),
+		id: prefix + "_corrupted_files", name: i18n.T("ZFS: %s Corrupted Files", displayName),
 		icon:       "mdi:file-alert",
 		template:   "{{ value_json.corrupted_files | default([]) | count }}",
 		stateClass: "measurement",
 	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: prefix + "_fill_rate", name: i18n.T("ZFS: %s Fill Rate", displayName), unit: fillRateUnit,
+		icon: "mdi:trending-up", template: fillRateTemplate,
+		stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: prefix + "_days_until_full", name: i18n.T("ZFS: %s Days Until Full", displayName), unit: "d",
+		icon: "mdi:calendar-clock", template: daysUntilFullTemplate,
+		deviceClass: "duration", stateClass: "measurement",
+	})
 
 	return ids
 }
 
+// ──────────────────────────────────────────────────────────────────────────────
+// Pools (per-pool)
+// ──────────────────────────────────────────────────────────────────────────────
+
+// Fill forecast sensors. The rate is shown in GB per day; both sensors are
+// unknown until the collector has enough usage history for a forecast, and
+// days until full stays unknown while usage is flat or shrinking.
+const (
+	fillRateUnit          = "GB/d"
+	fillRateTemplate      = "{{ ((value_json.fill_rate_bytes_per_day / 1000000000) | round(2)) if value_json.fill_rate_bytes_per_day is defined else None }}"
+	daysUntilFullTemplate = "{{ value_json.days_until_full | default(None) }}"
+)
+
+// publishPoolDiscovery publishes per-pool topics and HA discovery entities for
+// Unraid cache pools: usage, free space, fill rate and days until full.
+func (c *Client) publishPoolDiscovery(pools []dto.PoolInfo) {
+	if !c.config.HomeAssistantMode {
+		return
+	}
+
+	var currentIDs []string
+
+	for _, pool := range pools {
+		poolID := sanitizeID(pool.Name)
+		poolTopic := c.buildTopic(fmt.Sprintf("pools/%s", poolID))
+
+		if err := c.publishJSON(poolTopic, pool); err != nil {
+			logger.Debug("MQTT: Failed to publish pool %s: %v", poolID, err)
+			continue
+		}
+
+		prefix := fmt.Sprintf("pool_%s", poolID)
+		ids := []string{
+			prefix + "_usage",
+			prefix + "_free",
+			prefix + "_fill_rate",
+			prefix + "_days_until_full",
+		}
+
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: poolTopic,
+			id: prefix + "_usage", name: i18n.T("Pool: %s Usage", pool.Name), unit: "%",
+			icon: "mdi:chart-pie", template: "{{ value_json.usage_percent | round(1) }}",
+			stateClass: "measurement",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: poolTopic,
+			id: prefix + "_free", name: i18n.T("Pool: %s Free Space", pool.Name), unit: "B",
+			icon: "mdi:harddisk", template: "{{ value_json.free_bytes }}",
+			deviceClass: "data_size", stateClass: "measurement",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: poolTopic,
+			id: prefix + "_fill_rate", name: i18n.T("Pool: %s Fill Rate", pool.Name), unit: fillRateUnit,
+			icon: "mdi:trending-up", template: fillRateTemplate,
+			stateClass: "measurement",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: poolTopic,
+			id: prefix + "_days_until_full", name: i18n.T("Pool: %s Days Until Full", pool.Name), unit: "d",
+			icon: "mdi:calendar-clock", template: daysUntilFullTemplate,
+			deviceClass: "duration", stateClass: "measurement",
+		})
+
+		currentIDs = append(currentIDs, ids...)
+	}
+
+	removed := c.tracker.update("pools", currentIDs)
+	for _, id := range removed {
+		c.removeHAEntities(id)
+	}
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Btrfs (per-filesystem)
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishBtrfsDiscovery publishes per-filesystem HA discovery entities for
+// btrfs device error counters and scrub results.
+func (c *Client) publishBtrfsDiscovery(filesystems []dto.BtrfsFilesystem) {
+	if !c.config.HomeAssistantMode {
+		return
+	}
+
+	var currentIDs []string
+
+	for _, fs := range filesystems {
+		fsID := sanitizeID(fs.Name)
+		fsTopic := c.buildTopic(fmt.Sprintf("btrfs/%s", fsID))
+
+		if err := c.publishJSON(fsTopic, fs); err != nil {
+			logger.Debug("MQTT: Failed to publish btrfs filesystem %s: %v", fsID, err)
+			continue
+		}
+
+		prefix := fmt.Sprintf("btrfs_%s", fsID)
+		ids := []string{
+			prefix + "_device_errors",
+			prefix + "_corruption_errors",
+			prefix + "_scrub_status",
+			prefix + "_problem",
+		}
+
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: fsTopic,
+			id: prefix + "_device_errors", name: i18n.T("Btrfs: %s Device Errors", fs.Name),
+			icon: "mdi:alert-circle", template: "{{ value_json.total_errors }}",
+			stateClass: "total_increasing",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: fsTopic,
+			id: prefix + "_corruption_errors", name: i18n.T("Btrfs: %s Corruption Errors", fs.Name),
+			icon:       "mdi:file-alert",
+			template:   "{{ value_json.devices | map(attribute='corruption_errs') | sum }}",
+			stateClass: "total_increasing",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: fsTopic,
+			id: prefix + "_scrub_status", name: i18n.T("Btrfs: %s Scrub Status", fs.Name),
+			icon: "mdi:magnify-scan", template: "{{ value_json.scrub_status | default('unknown') }}",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "binary_sensor", stateTopic: fsTopic,
+			id: prefix + "_problem", name: i18n.T("Btrfs: %s Problem", fs.Name),
+			icon:        "mdi:harddisk-remove",
+			template:    "{{ 'ON' if value_json.total_errors > 0 or value_json.scrub_uncorrectable_errors > 0 else 'OFF' }}",
+			deviceClass: "problem",
+		})
+
+		currentIDs = append(currentIDs, ids...)
+	}
+
+	removed := c.tracker.update("btrfs", currentIDs)
+	for _, id := range removed {
+		c.removeHAEntities(id)
+	}
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Recycle Bin
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishRecycleBinDiscovery publishes per-share recycle bin topics and HA
+// discovery: size, file count and oldest file age sensors, and a button that
+// empties the share's recycle bin.
+func (c *Client) publishRecycleBinDiscovery(status *dto.RecycleBinStatus) {
+	shareNames := make(map[string]string, len(status.Shares))
+	var currentIDs []string
+
+	for _, share := range status.Shares {
+		shareID := sanitizeID(share.Share)
+		shareNames[shareID] = share.Share
+		shareTopic := c.buildTopic(fmt.Sprintf("recycle_bin/%s", shareID))
+
+		if err := c.publishJSON(shareTopic, share); err != nil {
+			logger.Debug("MQTT: Failed to publish recycle bin %s: %v", shareID, err)
+			continue
+		}
+		if !c.config.HomeAssistantMode {
+			continue
+		}
+
+		prefix := fmt.Sprintf("recycle_bin_%s", shareID)
+		ids := []string{
+			prefix + "_size",
+			prefix + "_files",
+			prefix + "_oldest_age",
+			prefix + "_empty",
+		}
+
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: shareTopic,
+			id: prefix + "_size", name: i18n.T("Recycle Bin: %s Size", share.Share),
+			unit: "B", icon: "mdi:delete-variant", template: "{{ value_json.size_bytes }}",
+			deviceClass: "data_size", stateClass: "measurement",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: shareTopic,
+			id: prefix + "_files", name: i18n.T("Recycle Bin: %s Files", share.Share),
+			icon: "mdi:file-multiple", template: "{{ value_json.files }}",
+			stateClass: "measurement",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: shareTopic,
+			id: prefix + "_oldest_age", name: i18n.T("Recycle Bin: %s Oldest File Age", share.Share),
+			unit: "d", icon: "mdi:clock-alert", template: "{{ value_json.oldest_age_days | default(0) }}",
+			deviceClass: "duration", stateClass: "measurement",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType:   "button",
+			commandTopic: c.buildCommandTopic("recycle_bin", shareID, "empty"),
+			id:           prefix + "_empty", name: i18n.T("Recycle Bin: %s Empty", share.Share),
+			icon: "mdi:delete-empty",
+		})
+
+		currentIDs = append(currentIDs, ids...)
+	}
+	c.setRecycleBinShares(shareNames)
+
+	if !c.config.HomeAssistantMode {
+		return
+	}
+	removed := c.tracker.update("recycle_bin", currentIDs)
+	for _, id := range removed {
+		c.removeHAEntities(id)
+	}
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// IPMI
+// ──────────────────────────────────────────────────────────────────────────────
+
+// ipmiUnits maps ipmitool sensor units to Home Assistant units and device
+// classes.
+var ipmiUnits = map[string]struct{ unit, deviceClass string }{
+	"RPM":       {"RPM", ""},
+	"percent":   {"%", ""},
+	"Volts":     {"V", "voltage"},
+	"degrees C": {"°C", "temperature"},
+	"degrees F": {"°F", "temperature"},
+	"Watts":     {"W", "power"},
+	"Amps":      {"A", "current"},
+}
+
+// publishIPMIDiscovery publishes per-sensor IPMI topics and HA discovery: a
+// sensor per threshold sensor with a reading, a problem binary sensor per
+// power supply, chassis power, intrusion and problem binary sensors, and a
+// button that blinks the chassis identify LED.
+func (c *Client) publishIPMIDiscovery(status *dto.IPMIStatus) {
+	if !c.config.HomeAssistantMode {
+		return
+	}
+
+	topic := c.buildTopic("ipmi")
+	currentIDs := []string{"ipmi_problem", "ipmi_identify"}
+	c.publishHAEntity(haEntityOpts{
+		entityType: "binary_sensor", stateTopic: topic,
+		id: "ipmi_problem", name: i18n.T("IPMI: Problem"),
+		icon:        "mdi:server-network-outline",
+		template:    "{{ 'ON' if value_json.problem_count > 0 else 'OFF' }}",
+		deviceClass: "problem",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType:   "button",
+		commandTopic: c.buildCommandTopic("ipmi", "identify"),
+		id:           "ipmi_identify", name: i18n.T("IPMI: Identify"),
+		icon: "mdi:lightbulb-on", deviceClass: "identify",
+	})
+	if status.Chassis != nil {
+		currentIDs = append(currentIDs, "ipmi_chassis_power", "ipmi_chassis_intrusion")
+		c.publishHAEntity(haEntityOpts{
+			entityType: "binary_sensor", stateTopic: topic,
+			id: "ipmi_chassis_power", name: i18n.T("IPMI: Chassis Power"),
+			icon:        "mdi:power",
+			template:    "{{ 'ON' if value_json.chassis.power_on else 'OFF' }}",
+			deviceClass: "power",
+		})
+		c.publishHAEntity(haEntityOpts{
+			entityType: "binary_sensor", stateTopic: topic,
+			id: "ipmi_chassis_intrusion", name: i18n.T("IPMI: Chassis Intrusion"),
+			icon:        "mdi:shield-alert",
+			template:    "{{ 'ON' if value_json.chassis.intrusion else 'OFF' }}",
+			deviceClass: "tamper",
+		})
+	}
+
+	for _, sensor := range status.Sensors {
+		sensorID := sanitizeID(sensor.Name)
+		sensorTopic := c.buildTopic(fmt.Sprintf("ipmi/%s", sensorID))
+		id := fmt.Sprintf("ipmi_%s", sensorID)
+
+		if sensor.Type == dto.IPMISensorPowerSupply {
+			if err := c.publishJSON(sensorTopic, sensor); err != nil {
+				logger.Debug("MQTT: Failed to publish IPMI sensor %s: %v", sensorID, err)
+				continue
+			}
+			c.publishHAEntity(haEntityOpts{
+				entityType: "binary_sensor", stateTopic: sensorTopic,
+				id: id, name: i18n.T("IPMI: %s", sensor.Name),
+				icon:        "mdi:power-plug",
+				template:    "{{ 'ON' if value_json.status == 'cr' else 'OFF' }}",
+				deviceClass: "problem",
+			})
+			currentIDs = append(currentIDs, id)
+			continue
+		}
+
+		// Empty fan headers and absent probes have no reading; skip them
+		// rather than creating entities that stay unknown.
+		if sensor.Value == nil {
+			continue
+		}
+		if err := c.publishJSON(sensorTopic, sensor); err != nil {
+			logger.Debug("MQTT: Failed to publish IPMI sensor %s: %v", sensorID, err)
+			continue
+		}
+		units := ipmiUnits[sensor.Unit]
+		c.publishHAEntity(haEntityOpts{
+			entityType: "sensor", stateTopic: sensorTopic,
+			id: id, name: i18n.T("IPMI: %s", sensor.Name),
+			unit: units.unit, icon: ipmiSensorIcon(sensor.Type), template: "{{ value_json.value }}",
+			deviceClass: units.deviceClass, stateClass: "measurement",
+		})
+		currentIDs = append(currentIDs, id)
+	}
+
+	removed := c.tracker.update("ipmi", currentIDs)
+	for _, id := range removed {
+		c.removeHAEntities(id)
+	}
+}
+
+func ipmiSensorIcon(sensorType string) string {
+	switch sensorType {
+	case dto.IPMISensorFan:
+		return "mdi:fan"
+	case dto.IPMISensorVoltage:
+		return "mdi:sine-wave"
+	case dto.IPMISensorTemperature:
+		return "mdi:thermometer"
+	case dto.IPMISensorPower:
+		return "mdi:flash"
+	case dto.IPMISensorCurrent:
+		return "mdi:current-ac"
+	default:
+		return "mdi:gauge"
+	}
+}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // NUT UPS
 // ──────────────────────────────────────────────────────────────────────────────
@@ -1503,62 +1951,62 @@ func (c *Client) publishNUTDiscovery() {
 	topic := c.buildTopic("nut/status")
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: "nut_connected", name: "NUT: UPS Connected",
+		id: "nut_connected", name: i18n.T("NUT: UPS Connected"),
 		icon:        "mdi:battery-charging",
 		template:    "{{ 'ON' if value_json.status.connected | default(false) else 'OFF' }}",
 		deviceClass: "connectivity",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "nut_status", name: "NUT: UPS Status",
+		id: "nut_status", name: i18n.T("NUT: UPS Status"),
 		icon:     "mdi:battery-charging",
 		template: "{{ value_json.status.status | default('unknown') }}",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "nut_battery_charge", name: "NUT: Battery Charge", unit: "%",
+		id: "nut_battery_charge", name: i18n.T("NUT: Battery Charge"), unit: "%",
 		icon:        "mdi:battery",
 		template:    "{{ value_json.status.battery_charge_percent | default(0) | round(0) }}",
 		deviceClass: "battery", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "nut_battery_runtime", name: "NUT: Battery Runtime", unit: "s",
+		id: "nut_battery_runtime", name: i18n.T("NUT: Battery Runtime"), unit: "s",
 		icon:        "mdi:clock-outline",
 		template:    "{{ value_json.status.battery_runtime_seconds | default(0) }}",
 		deviceClass: "duration", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "nut_load", name: "NUT: Load", unit: "%",
+		id: "nut_load", name: i18n.T("NUT: Load"), unit: "%",
 		icon:       "mdi:gauge",
 		template:   "{{ value_json.status.load_percent | default(0) | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "nut_realpower", name: "NUT: Real Power", unit: "W",
+		id: "nut_realpower", name: i18n.T("NUT: Real Power"), unit: "W",
 		icon:        "mdi:lightning-bolt",
 		template:    "{{ value_json.status.realpower_watts | default(0) | round(0) }}",
 		deviceClass: "power", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "nut_input_voltage", name: "NUT: Input Voltage", unit: "V",
+		id: "nut_input_voltage", name: i18n.T("NUT: Input Voltage"), unit: "V",
 		icon:        "mdi:sine-wave",
 		template:    "{{ value_json.status.input_voltage | default(0) | round(1) }}",
 		deviceClass: "voltage", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "nut_output_voltage", name: "NUT: Output Voltage", unit: "V",
+		id: "nut_output_voltage", name: i18n.T("NUT: Output Voltage"), unit: "V",
 		icon:        "mdi:sine-wave",
 		template:    "{{ value_json.status.output_voltage | default(0) | round(1) }}",
 		deviceClass: "voltage", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "nut_model", name: "NUT: UPS Model",
+		id: "nut_model", name: i18n.T("NUT: UPS Model"),
 		icon:           "mdi:battery-charging",
 		template:       "{{ value_json.status.model | default('') }}",
 		entityCategory: "diagnostic",
@@ -1574,42 +2022,42 @@ func (c *Client) publishHardwareDiscovery() {
 	topic := c.buildTopic("hardware")
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "bios_version", name: "Hardware: BIOS Version",
+		id: "bios_version", name: i18n.T("Hardware: BIOS Version"),
 		icon:           "mdi:chip",
 		template:       "{{ value_json.bios.version | default('') }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "bios_date", name: "Hardware: BIOS Release Date",
+		id: "bios_date", name: i18n.T("Hardware: BIOS Release Date"),
 		icon:           "mdi:calendar",
 		template:       "{{ value_json.bios.release_date | default('') }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "board_manufacturer", name: "Hardware: Board Manufacturer",
+		id: "board_manufacturer", name: i18n.T("Hardware: Board Manufacturer"),
 		icon:           "mdi:factory",
 		template:       "{{ value_json.baseboard.manufacturer | default('') }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "board_model", name: "Hardware: Board Model",
+		id: "board_model", name: i18n.T("Hardware: Board Model"),
 		icon:           "mdi:circuit-board",
 		template:       "{{ value_json.baseboard.product_name | default('') }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "cpu_max_speed", name: "Hardware: CPU Max Speed", unit: "MHz",
+		id: "cpu_max_speed", name: i18n.T("Hardware: CPU Max Speed"), unit: "MHz",
 		icon:           "mdi:cpu-64-bit",
 		template:       "{{ value_json.cpu.max_speed_mhz | default(0) }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "memory_slots_total", name: "Hardware: Memory Slots",
+		id: "memory_slots_total", name: i18n.T("Hardware: Memory Slots"),
 		icon:           "mdi:memory",
 		template:       "{{ value_json.memory_array.number_of_devices | default(0) }}",
 		entityCategory: "diagnostic",
@@ -1617,7 +2065,7 @@ func (c *Client) publishHardwareDiscovery() {
 	// Chassis serial (new in Unraid 7.3)
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "chassis_serial", name: "Hardware: Chassis Serial",
+		id: "chassis_serial", name: i18n.T("Hardware: Chassis Serial"),
 		icon:           "mdi:barcode",
 		template:       "{{ value_json.chassis.serial_number | default('') }}",
 		entityCategory: "diagnostic",
@@ -1625,7 +2073,7 @@ func (c *Client) publishHardwareDiscovery() {
 	// TPM presence (Unraid 7.3 TPM-based licensing)
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: "tpm_present", name: "Hardware: TPM Present",
+		id: "tpm_present", name: i18n.T("Hardware: TPM Present"),
 		icon:           "mdi:shield-key",
 		template:       "{{ 'ON' if value_json.tpm.present | default(false) else 'OFF' }}",
 		entityCategory: "diagnostic",
@@ -1633,7 +2081,7 @@ func (c *Client) publishHardwareDiscovery() {
 	// Boot device type (Unraid 7.3 internal boot)
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "boot_device_type", name: "Hardware: Boot Device Type",
+		id: "boot_device_type", name: i18n.T("Hardware: Boot Device Type"),
 		icon:           "mdi:usb-flash-drive",
 		template:       "{{ value_json.boot.device_type | default('unknown') }}",
 		entityCategory: "diagnostic",
@@ -1649,27 +2097,27 @@ func (c *Client) publishRegistrationDiscovery() {
 	topic := c.buildTopic("registration")
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "registration_state", name: "Registration: State",
+		id: "registration_state", name: i18n.T("Registration: State"),
 		icon:     "mdi:license",
 		template: "{{ value_json.state }}",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "registration_type", name: "Registration: Type",
+		id: "registration_type", name: i18n.T("Registration: Type"),
 		icon:           "mdi:tag",
 		template:       "{{ value_json.type }}",
 		entityCategory: "diagnostic",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: "registration_valid", name: "Registration: Valid",
+		id: "registration_valid", name: i18n.T("Registration: Valid"),
 		icon:        "mdi:check-decagram",
 		template:    "{{ 'ON' if value_json.state == 'valid' else 'OFF' }}",
 		deviceClass: "safety",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "registration_expiry", name: "Registration: Expiry",
+		id: "registration_expiry", name: i18n.T("Registration: Expiry"),
 		icon:        "mdi:calendar-clock",
 		template:    "{{ value_json.expiration }}",
 		deviceClass: "timestamp",
@@ -1747,7 +2195,7 @@ func (c *Client) publishRemoteShareEntities(topic, prefix, displayName, shareID,
 	ids := []string{prefix + "_mounted", prefix + "_usage", prefix + "_used", prefix + "_free"}
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: prefix + "_mounted", name: fmt.Sprintf("Remote Share: %s Mounted", displayName),
+		id: prefix + "_mounted", name: i18n.T("Remote Share: %s Mounted", displayName),
 		icon:        "mdi:nas",
 		template:    "{{ 'ON' if value_json.status == 'mounted' else 'OFF' }}",
 		deviceClass: "connectivity",
@@ -1758,7 +2206,7 @@ func (c *Client) publishRemoteShareEntities(topic, prefix, displayName, shareID,
 		c.publishHAEntity(haEntityOpts{
 			entityType: "switch", stateTopic: topic,
 			commandTopic: c.buildCommandTopic("unassigned", "remote", shareID, "set"),
-			id:           prefix + "_switch", name: fmt.Sprintf("Remote Share: %s Mount", displayName),
+			id:           prefix + "_switch", name: i18n.T("Remote Share: %s Mount", displayName),
 			icon:     "mdi:nas",
 			template: "{{ value_json.status }}",
 			stateOn:  "mounted", stateOff: "unmounted",
@@ -1766,21 +2214,21 @@ func (c *Client) publishRemoteShareEntities(topic, prefix, displayName, shareID,
 	}
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_usage", name: fmt.Sprintf("Remote Share: %s Usage", displayName), unit: "%",
+		id: prefix + "_usage", name: i18n.T("Remote Share: %s Usage", displayName), unit: "%",
 		icon:       "mdi:nas",
 		template:   "{{ value_json.usage_percent | default(0) | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_used", name: fmt.Sprintf("Remote Share: %s Used", displayName), unit: "B",
+		id: prefix + "_used", name: i18n.T("Remote Share: %s Used", displayName), unit: "B",
 		icon:        "mdi:nas",
 		template:    "{{ value_json.used_bytes | default(0) }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_free", name: fmt.Sprintf("Remote Share: %s Free", displayName), unit: "B",
+		id: prefix + "_free", name: i18n.T("Remote Share: %s Free", displayName), unit: "B",
 		icon:        "mdi:nas",
 		template:    "{{ value_json.free_bytes | default(0) }}",
 		deviceClass: "data_size", stateClass: "measurement",
@@ -1793,21 +2241,21 @@ func (c *Client) publishUnassignedEntities(topic, prefix, displayName string, de
 	ids := []string{prefix + "_connected", prefix + "_temp", prefix + "_spin_state"}
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: prefix + "_connected", name: fmt.Sprintf("Unassigned: %s Connected", displayName),
+		id: prefix + "_connected", name: i18n.T("Unassigned: %s Connected", displayName),
 		icon:        "mdi:harddisk",
 		template:    "{{ 'ON' if value_json.status != 'error' else 'OFF' }}",
 		deviceClass: "connectivity",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_temp", name: fmt.Sprintf("Unassigned: %s Temperature", displayName), unit: "°C",
+		id: prefix + "_temp", name: i18n.T("Unassigned: %s Temperature", displayName), unit: "°C",
 		icon:        "mdi:thermometer",
 		template:    "{{ value_json.temperature_celsius | default(0) }}",
 		deviceClass: "temperature", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_spin_state", name: fmt.Sprintf("Unassigned: %s Spin State", displayName),
+		id: prefix + "_spin_state", name: i18n.T("Unassigned: %s Spin State", displayName),
 		icon:     "mdi:rotate-3d-variant",
 		template: "{{ value_json.spin_state | default('unknown') }}",
 	})
@@ -1815,13 +2263,13 @@ func (c *Client) publishUnassignedEntities(topic, prefix, displayName string, de
 		partPrefix := fmt.Sprintf("%s_part%d", prefix, i+1)
 		partLabel := part.Label
 		if partLabel == "" {
-			partLabel = fmt.Sprintf("Part %d", part.PartitionNumber)
+			partLabel = i18n.T("Part %d", part.PartitionNumber)
 		}
 		ids = append(ids, partPrefix+"_usage", partPrefix+"_used", partPrefix+"_free")
 		c.publishHAEntity(haEntityOpts{
 			entityType: "sensor", stateTopic: topic,
 			id:   partPrefix + "_usage",
-			name: fmt.Sprintf("Unassigned: %s %s Usage", displayName, partLabel), unit: "%",
+			name: i18n.T("Unassigned: %s %s Usage", displayName, partLabel), unit: "%",
 			icon:       "mdi:harddisk",
 			template:   fmt.Sprintf("{{ value_json.partitions[%d].usage_percent | default(0) | round(1) }}", i),
 			stateClass: "measurement",
@@ -1829,7 +2277,7 @@ func (c *Client) publishUnassignedEntities(topic, prefix, displayName string, de
 		c.publishHAEntity(haEntityOpts{
 			entityType: "sensor", stateTopic: topic,
 			id:   partPrefix + "_used",
-			name: fmt.Sprintf("Unassigned: %s %s Used", displayName, partLabel), unit: "B",
+			name: i18n.T("Unassigned: %s %s Used", displayName, partLabel), unit: "B",
 			icon:        "mdi:harddisk",
 			template:    fmt.Sprintf("{{ value_json.partitions[%d].used_bytes | default(0) }}", i),
 			deviceClass: "data_size", stateClass: "measurement",
@@ -1837,7 +2285,7 @@ func (c *Client) publishUnassignedEntities(topic, prefix, displayName string, de
 		c.publishHAEntity(haEntityOpts{
 			entityType: "sensor", stateTopic: topic,
 			id:   partPrefix + "_free",
-			name: fmt.Sprintf("Unassigned: %s %s Free", displayName, partLabel), unit: "B",
+			name: i18n.T("Unassigned: %s %s Free", displayName, partLabel), unit: "B",
 			icon:        "mdi:harddisk",
 			template:    fmt.Sprintf("{{ value_json.partitions[%d].free_bytes | default(0) }}", i),
 			deviceClass: "data_size", stateClass: "measurement",
@@ -1880,28 +2328,28 @@ func (c *Client) publishZFSDatasetEntities(topic, prefix, displayName string) []
 	ids := []string{prefix + "_used", prefix + "_available", prefix + "_compress_ratio", prefix + "_readonly"}
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_used", name: fmt.Sprintf("ZFS Dataset: %s Used", displayName), unit: "B",
+		id: prefix + "_used", name: i18n.T("ZFS Dataset: %s Used", displayName), unit: "B",
 		icon:        "mdi:database",
 		template:    "{{ value_json.used_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_available", name: fmt.Sprintf("ZFS Dataset: %s Available", displayName), unit: "B",
+		id: prefix + "_available", name: i18n.T("ZFS Dataset: %s Available", displayName), unit: "B",
 		icon:        "mdi:database",
 		template:    "{{ value_json.available_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: prefix + "_compress_ratio", name: fmt.Sprintf("ZFS Dataset: %s Compression Ratio", displayName),
+		id: prefix + "_compress_ratio", name: i18n.T("ZFS Dataset: %s Compression Ratio", displayName),
 		icon:       "mdi:zip-box",
 		template:   "{{ value_json.compress_ratio | round(2) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: prefix + "_readonly", name: fmt.Sprintf("ZFS Dataset: %s Read-Only", displayName),
+		id: prefix + "_readonly", name: i18n.T("ZFS Dataset: %s Read-Only", displayName),
 		icon:     "mdi:lock",
 		template: "{{ 'ON' if value_json.readonly else 'OFF' }}",
 	})
@@ -1917,14 +2365,14 @@ func (c *Client) publishZFSSnapshotDiscovery() {
 	topic := c.buildTopic("zfs/snapshots")
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "zfs_snapshot_count", name: "ZFS: Snapshot Count",
+		id: "zfs_snapshot_count", name: i18n.T("ZFS: Snapshot Count"),
 		icon:       "mdi:camera",
 		template:   "{{ value_json | length }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "zfs_snapshot_total_size", name: "ZFS: Snapshot Total Size", unit: "B",
+		id: "zfs_snapshot_total_size", name: i18n.T("ZFS: Snapshot Total Size"), unit: "B",
 		icon:        "mdi:camera",
 		template:    "{{ value_json | sum(attribute='used_bytes') | default(0) }}",
 		deviceClass: "data_size", stateClass: "measurement",
@@ -1940,14 +2388,14 @@ func (c *Client) publishZFSARCDiscovery() {
 	topic := c.buildTopic("zfs/arc")
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "arc_size", name: "ZFS ARC: Size", unit: "B",
+		id: "arc_size", name: i18n.T("ZFS ARC: Size"), unit: "B",
 		icon:        "mdi:memory",
 		template:    "{{ value_json.size_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "arc_target_size", name: "ZFS ARC: Target Size", unit: "B",
+		id: "arc_target_size", name: i18n.T("ZFS ARC: Target Size"), unit: "B",
 		icon:        "mdi:memory",
 		template:    "{{ value_json.target_size_bytes }}",
 		deviceClass: "data_size", stateClass: "measurement",
@@ -1956,7 +2404,7 @@ func (c *Client) publishZFSARCDiscovery() {
 	// Configured zfs_arc_max (0 = auto) — Unraid 7.3 first-class tunable
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "arc_configured_max", name: "ZFS ARC: Configured Max", unit: "B",
+		id: "arc_configured_max", name: i18n.T("ZFS ARC: Configured Max"), unit: "B",
 		icon:        "mdi:memory",
 		template:    "{{ value_json.configured_max_bytes | default(0) }}",
 		deviceClass: "data_size", stateClass: "measurement",
@@ -1964,14 +2412,14 @@ func (c *Client) publishZFSARCDiscovery() {
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "arc_hit_ratio", name: "ZFS ARC: Hit Ratio", unit: "%",
+		id: "arc_hit_ratio", name: i18n.T("ZFS ARC: Hit Ratio"), unit: "%",
 		icon:       "mdi:chart-line",
 		template:   "{{ value_json.hit_ratio_percent | round(1) }}",
 		stateClass: "measurement",
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "arc_l2_size", name: "ZFS L2ARC: Size", unit: "B",
+		id: "arc_l2_size", name: i18n.T("ZFS L2ARC: Size"), unit: "B",
 		icon:        "mdi:memory",
 		template:    "{{ value_json.l2_size_bytes | default(0) }}",
 		deviceClass: "data_size", stateClass: "measurement",
@@ -1979,7 +2427,7 @@ func (c *Client) publishZFSARCDiscovery() {
 	})
 	c.publishHAEntity(haEntityOpts{
 		entityType: "sensor", stateTopic: topic,
-		id: "arc_l2_hit_ratio", name: "ZFS L2ARC: Hit Ratio", unit: "%",
+		id: "arc_l2_hit_ratio", name: i18n.T("ZFS L2ARC: Hit Ratio"), unit: "%",
 		icon:           "mdi:chart-line",
 		template:       "{{ ((value_json.l2_hits | default(0)) / ((value_json.l2_hits | default(0)) + (value_json.l2_misses | default(0))) * 100) | round(1) if ((value_json.l2_hits | default(0)) + (value_json.l2_misses | default(0))) > 0 else 0 }}",
 		stateClass:     "measurement",
@@ -2007,7 +2455,7 @@ func (c *Client) publishFanControlDiscovery(status *dto.FanControlStatus) {
 		rpmID := fanID + "_rpm"
 		c.publishHAEntity(haEntityOpts{
 			entityType: "sensor", stateTopic: topic,
-			id: rpmID, name: fmt.Sprintf("Fan Control: %s RPM", fan.Name), unit: "RPM",
+			id: rpmID, name: i18n.T("Fan Control: %s RPM", fan.Name), unit: "RPM",
 			icon:       "mdi:fan",
 			template:   fmt.Sprintf(`{{ (value_json.fans | selectattr('id', 'eq', '%s') | map(attribute='rpm') | first | default(0)) }}`, fan.ID),
 			stateClass: "measurement",
@@ -2018,7 +2466,7 @@ func (c *Client) publishFanControlDiscovery(status *dto.FanControlStatus) {
 		pwmID := fanID + "_pwm"
 		c.publishHAEntity(haEntityOpts{
 			entityType: "sensor", stateTopic: topic,
-			id: pwmID, name: fmt.Sprintf("Fan Control: %s PWM", fan.Name), unit: "%",
+			id: pwmID, name: i18n.T("Fan Control: %s PWM", fan.Name), unit: "%",
 			icon:       "mdi:fan",
 			template:   fmt.Sprintf(`{{ (value_json.fans | selectattr('id', 'eq', '%s') | map(attribute='pwm_percent') | first | default(0)) }}`, fan.ID),
 			stateClass: "measurement",
@@ -2029,18 +2477,36 @@ func (c *Client) publishFanControlDiscovery(status *dto.FanControlStatus) {
 		modeID := fanID + "_mode"
 		c.publishHAEntity(haEntityOpts{
 			entityType: "sensor", stateTopic: topic,
-			id: modeID, name: fmt.Sprintf("Fan Control: %s Mode", fan.Name),
+			id: modeID, name: i18n.T("Fan Control: %s Mode", fan.Name),
 			icon:     "mdi:fan-clock",
 			template: fmt.Sprintf(`{{ (value_json.fans | selectattr('id', 'eq', '%s') | map(attribute='mode') | first | default('unknown')) }}`, fan.ID),
 		})
 		currentIDs = append(currentIDs, modeID)
+
+		// Controllable fans get an HA fan entity: ON hands the fan to the
+		// agent (manual mode), OFF returns it to firmware control, and the
+		// percentage sets its speed. The controller clamps speeds to the
+		// configured safety minimum.
+		if fan.Controllable {
+			controlID := fanID + "_control"
+			c.publishHAEntity(haEntityOpts{
+				entityType: "fan", stateTopic: topic,
+				commandTopic: c.buildCommandTopic("fan", fan.ID, "set"),
+				id:           controlID, name: i18n.T("Fan Control: %s", fan.Name),
+				icon:                   "mdi:fan",
+				template:               fmt.Sprintf(`{{ 'ON' if (value_json.fans | selectattr('id', 'eq', '%s') | map(attribute='mode') | first | default('')) == 'manual' else 'OFF' }}`, fan.ID),
+				percentageCommandTopic: c.buildCommandTopic("fan", fan.ID, "percentage"),
+				percentageTemplate:     fmt.Sprintf(`{{ (value_json.fans | selectattr('id', 'eq', '%s') | map(attribute='pwm_percent') | first | default(0)) }}`, fan.ID),
+			})
+			currentIDs = append(currentIDs, controlID)
+		}
 	}
 
 	// Fan control enabled binary sensor
 	enabledID := "fanctrl_enabled"
 	c.publishHAEntity(haEntityOpts{
 		entityType: "binary_sensor", stateTopic: topic,
-		id: enabledID, name: "Fan Control: Enabled",
+		id: enabledID, name: i18n.T("Fan Control: Enabled"),
 		icon:       "mdi:fan-alert",
 		template:   `{{ value_json.config.control_enabled }}`,
 		payloadOn:  "true",
@@ -2054,6 +2520,314 @@ func (c *Client) publishFanControlDiscovery(status *dto.FanControlStatus) {
 	}
 }
 
+// ──────────────────────────────────────────────────────────────────────────────
+// Health Checks (per-item)
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishHealthCheckDiscovery publishes a per-check HA binary_sensor that turns
+// ON when the watchdog probe is failing.
+func (c *Client) publishHealthCheckDiscovery(statuses []dto.HealthCheckStatus) {
+	if !c.config.HomeAssistantMode {
+		return
+	}
+
+	var currentIDs []string
+
+	for _, status := range statuses {
+		checkID := sanitizeID(status.CheckID)
+		checkTopic := c.buildTopic(fmt.Sprintf("healthchecks/%s", checkID))
+
+		if err := c.publishJSON(checkTopic, status); err != nil {
+			logger.Debug("MQTT: Failed to publish health check %s: %v", checkID, err)
+			continue
+		}
+
+		displayName := status.CheckName
+		if status.Container != "" {
+			displayName = fmt.Sprintf("%s (%s)", status.CheckName, status.Container)
+		}
+
+		id := fmt.Sprintf("healthcheck_%s_problem", checkID)
+		c.publishHAEntity(haEntityOpts{
+			entityType: "binary_sensor", stateTopic: checkTopic,
+			id: id, name: i18n.T("Health Check: %s", displayName),
+			icon: "mdi:heart-pulse", template: "{{ 'OFF' if value_json.healthy else 'ON' }}",
+			deviceClass: "problem",
+		})
+		currentIDs = append(currentIDs, id)
+	}
+
+	removed := c.tracker.update("healthchecks", currentIDs)
+	for _, id := range removed {
+		c.removeHAEntities(id)
+	}
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// WAN
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishWANDiscovery publishes HA discovery for internet connectivity,
+// public IP, probe latency/packet loss, and the public IP change event.
+func (c *Client) publishWANDiscovery() {
+	topic := c.buildTopic("wan")
+
+	c.publishHAEntity(haEntityOpts{
+		entityType: "binary_sensor", stateTopic: topic,
+		id: "wan_online", name: i18n.T("WAN: Online"),
+		icon: "mdi:web", template: "{{ 'ON' if value_json.online else 'OFF' }}",
+		deviceClass: "connectivity",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "wan_public_ip", name: i18n.T("WAN: Public IP"),
+		icon: "mdi:ip-network", template: "{{ value_json.public_ip | default('unknown') }}",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "wan_latency", name: i18n.T("WAN: Latency"), unit: "ms",
+		icon: "mdi:timer-outline", template: "{{ value_json.avg_latency_ms | round(1) }}",
+		deviceClass: "duration", stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "wan_packet_loss", name: i18n.T("WAN: Packet Loss"), unit: "%",
+		icon: "mdi:lan-disconnect", template: "{{ value_json.packet_loss_percent | round(1) }}",
+		stateClass: "measurement",
+	})
+
+	// Public IP change event — lets DDNS automations react immediately.
+	c.publishHAEntity(haEntityOpts{
+		entityType: "event", stateTopic: c.buildTopic("wan/ip_changed"),
+		id: "wan_ip_changed", name: i18n.T("WAN: Public IP Changed"),
+		icon:       "mdi:ip-network-outline",
+		eventTypes: []string{"ip_changed"},
+	})
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Speedtest
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishSpeedtestDiscovery publishes HA discovery for the scheduled bandwidth
+// test results.
+func (c *Client) publishSpeedtestDiscovery() {
+	topic := c.buildTopic("speedtest")
+
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "speedtest_download", name: i18n.T("Speedtest: Download"), unit: "Mbit/s",
+		icon: "mdi:download-network", template: "{{ value_json.download_mbps | round(1) }}",
+		deviceClass: "data_rate", stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "speedtest_upload", name: i18n.T("Speedtest: Upload"), unit: "Mbit/s",
+		icon: "mdi:upload-network", template: "{{ value_json.upload_mbps | round(1) }}",
+		deviceClass: "data_rate", stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "speedtest_ping", name: i18n.T("Speedtest: Ping"), unit: "ms",
+		icon: "mdi:timer-outline", template: "{{ value_json.ping_ms | round(1) }}",
+		deviceClass: "duration", stateClass: "measurement",
+	})
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Power
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishPowerDiscovery publishes HA discovery for the power estimate. The
+// energy sensor is total_increasing in kWh so it can be added to the Home
+// Assistant energy dashboard; HA treats the reset on agent restart as a new
+// meter cycle.
+func (c *Client) publishPowerDiscovery() {
+	topic := c.buildTopic("power")
+
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "power_estimated", name: i18n.T("Power: Estimated Draw"), unit: "W",
+		icon: "mdi:flash", template: "{{ value_json.total_watts | round(1) }}",
+		deviceClass: "power", stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "power_energy", name: i18n.T("Power: Energy"), unit: "kWh",
+		icon: "mdi:lightning-bolt", template: "{{ value_json.energy_kwh | round(3) }}",
+		deviceClass: "energy", stateClass: "total_increasing",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "power_kwh_per_day", name: i18n.T("Power: Estimated Daily Energy"), unit: "kWh",
+		icon: "mdi:calendar-today", template: "{{ value_json.kwh_per_day | round(2) }}",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "power_source", name: i18n.T("Power: Estimate Source"),
+		icon: "mdi:information-outline", template: "{{ value_json.source }}",
+		entityCategory: "diagnostic",
+	})
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Maintenance
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishMaintenanceDiscovery publishes HA discovery for the maintenance
+// window. Its binary sensor is the only one that stays available during
+// maintenance.
+func (c *Client) publishMaintenanceDiscovery() {
+	topic := c.buildTopic("maintenance")
+
+	c.publishHAEntity(haEntityOpts{
+		entityType: "binary_sensor", stateTopic: topic,
+		id: "maintenance_mode", name: i18n.T("System: Maintenance Mode"),
+		icon: "mdi:wrench-clock", template: "{{ 'ON' if value_json.active else 'OFF' }}",
+		maintenanceExempt: true,
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "maintenance_ends", name: i18n.T("System: Maintenance Ends"),
+		icon: "mdi:clock-end", template: "{{ value_json.ends_at | default(None) }}",
+		deviceClass: "timestamp", entityCategory: "diagnostic",
+	})
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Parity Schedule, Mover, Flash and Updates
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishParityScheduleDiscovery publishes HA discovery for the next
+// scheduled parity check.
+func (c *Client) publishParityScheduleDiscovery() {
+	topic := c.buildTopic("array/parity_schedule")
+
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "parity_next_check", name: i18n.T("Array: Next Parity Check"),
+		icon: "mdi:calendar-clock", template: "{{ value_json.next_check | default(None) }}",
+		deviceClass: "timestamp",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "parity_schedule", name: i18n.T("Array: Parity Schedule"),
+		icon: "mdi:calendar-sync", template: "{{ value_json.mode }}",
+		entityCategory: "diagnostic",
+	})
+}
+
+// publishMoverDiscovery publishes HA discovery for mover activity and its
+// last run.
+func (c *Client) publishMoverDiscovery() {
+	topic := c.buildTopic("mover")
+
+	c.publishHAEntity(haEntityOpts{
+		entityType: "binary_sensor", stateTopic: topic,
+		id: "mover_active", name: i18n.T("Mover: Active"),
+		icon: "mdi:truck-fast", template: "{{ 'ON' if value_json.active else 'OFF' }}",
+		deviceClass: "running",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "mover_last_run", name: i18n.T("Mover: Last Run"),
+		icon: "mdi:truck-check", template: "{{ value_json.last_run_finish | default(None) }}",
+		deviceClass: "timestamp",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "mover_last_run_duration", name: i18n.T("Mover: Last Run Duration"), unit: "s",
+		icon: "mdi:timer-outline", template: "{{ value_json.last_run_duration_seconds }}",
+		deviceClass: "duration",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "mover_last_run_bytes", name: i18n.T("Mover: Last Run Data Moved"), unit: "B",
+		icon: "mdi:database-arrow-right", template: "{{ value_json.last_run_bytes_moved }}",
+		deviceClass: "data_size",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "mover_last_run_files", name: i18n.T("Mover: Last Run Files Moved"),
+		icon: "mdi:file-move", template: "{{ value_json.last_run_files_moved }}",
+	})
+}
+
+// publishFlashDiscovery publishes HA discovery for the flash boot drive.
+func (c *Client) publishFlashDiscovery() {
+	topic := c.buildTopic("flash")
+
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "flash_usage", name: i18n.T("Flash: Usage"), unit: "%",
+		icon: "mdi:usb-flash-drive", template: "{{ value_json.usage_percent | round(1) }}",
+		stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "flash_free", name: i18n.T("Flash: Free Space"), unit: "B",
+		icon: "mdi:usb-flash-drive-outline", template: "{{ value_json.free_bytes }}",
+		deviceClass: "data_size", stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: topic,
+		id: "flash_guid", name: i18n.T("Flash: GUID"),
+		icon: "mdi:identifier", template: "{{ value_json.guid }}",
+		entityCategory: "diagnostic",
+	})
+}
+
+// publishUpdatesDiscovery publishes HA discovery for OS, plugin and container
+// update availability.
+func (c *Client) publishUpdatesDiscovery() {
+	osTopic := c.buildTopic("updates/os")
+
+	c.publishHAEntity(haEntityOpts{
+		entityType: "binary_sensor", stateTopic: osTopic,
+		id: "os_update_available", name: i18n.T("Updates: Unraid OS"),
+		icon: "mdi:update", template: "{{ 'ON' if value_json.update_available else 'OFF' }}",
+		deviceClass: "update",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: osTopic,
+		id: "os_latest_version", name: i18n.T("Updates: Latest Unraid Version"),
+		icon: "mdi:package-up", template: "{{ value_json.latest_version | default(value_json.current_version) }}",
+		entityCategory: "diagnostic",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: c.buildTopic("updates/plugins"),
+		id: "plugin_updates", name: i18n.T("Updates: Plugins"),
+		icon: "mdi:puzzle", template: "{{ value_json.updates_available }}",
+		stateClass: "measurement",
+	})
+	c.publishHAEntity(haEntityOpts{
+		entityType: "sensor", stateTopic: c.buildTopic("updates/docker"),
+		id: "container_updates", name: i18n.T("Updates: Containers"),
+		icon: "mdi:docker", template: "{{ value_json.updates_available }}",
+		stateClass: "measurement",
+	})
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Wake-on-LAN
+// ──────────────────────────────────────────────────────────────────────────────
+
+// publishWOLDiscovery publishes a wake button for each device in WOL_DEVICES.
+func (c *Client) publishWOLDiscovery() {
+	if c.domainCtx == nil {
+		return
+	}
+	for _, device := range c.domainCtx.WOLDevices {
+		id := sanitizeID(device.Name)
+		c.publishHAEntity(haEntityOpts{
+			entityType:   "button",
+			commandTopic: c.buildCommandTopic("wol", id),
+			id:           "wol_" + id, name: i18n.T("Wake: %s", device.Name),
+			icon: "mdi:power",
+		})
+	}
+}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Helpers
 // ──────────────────────────────────────────────────────────────────────────────
@@ -0,0 +1,117 @@
+package mqtt
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// defaultTopicTemplate is the topic layout used when none is configured.
+	defaultTopicTemplate = "{prefix}/{topic}"
+
+	// defaultUniqueIDTemplate matches the unique IDs published before
+	// templates were configurable, so existing HA entities keep their history.
+	defaultUniqueIDTemplate = "unraid_{host}_{id}"
+)
+
+// hostID returns the hostname as used in topics, unique IDs and discovery
+// node IDs.
+func (c *Client) hostID() string {
+	return strings.ReplaceAll(c.hostname, " ", "_")
+}
+
+// validTopicTemplate reports whether tmpl can be used as a topic layout. It
+// must end with {topic} so that wildcard command subscriptions such as
+// "<prefix>/cmd/#" stay valid.
+func validTopicTemplate(tmpl string) bool {
+	return tmpl == "" || strings.HasSuffix(tmpl, "{topic}")
+}
+
+// expandTopic lays out suffix according to the configured topic template.
+// Empty placeholders never leave a leading or doubled slash behind.
+func (c *Client) expandTopic(suffix string) string {
+	tmpl := c.config.TopicTemplate
+	if tmpl == "" || !validTopicTemplate(tmpl) {
+		tmpl = defaultTopicTemplate
+	}
+	topic := strings.NewReplacer(
+		"{prefix}", c.config.TopicPrefix,
+		"{host}", c.hostID(),
+		"{topic}", suffix,
+	).Replace(tmpl)
+	for strings.Contains(topic, "//") {
+		topic = strings.ReplaceAll(topic, "//", "/")
+	}
+	return strings.TrimPrefix(topic, "/")
+}
+
+// topicCategory returns the first path segment of topic below the configured
+// layout, e.g. "docker" for "<prefix>/docker/plex". Command topics are
+// categorized by the segment after "cmd".
+func (c *Client) topicCategory(topic string) string {
+	rel, ok := strings.CutPrefix(topic, c.buildTopic(""))
+	if !ok || rel == "" {
+		return ""
+	}
+	category, rest, _ := strings.Cut(rel, "/")
+	if category == "cmd" {
+		category, _, _ = strings.Cut(rest, "/")
+	}
+	return category
+}
+
+// entityCategory returns the topic category an HA entity belongs to.
+func (c *Client) entityCategory(opts haEntityOpts) string {
+	if opts.stateTopic != "" {
+		return c.topicCategory(opts.stateTopic)
+	}
+	return c.topicCategory(opts.commandTopic)
+}
+
+// discoveryDisabled reports whether HA discovery is switched off for category.
+func (c *Client) discoveryDisabled(category string) bool {
+	return category != "" && slices.ContainsFunc(c.config.HADisabledCategories, func(d string) bool {
+		return strings.EqualFold(strings.TrimSpace(d), category)
+	})
+}
+
+// entityName applies the entity name template to an HA entity.
+func (c *Client) entityName(opts haEntityOpts, category string) string {
+	if c.config.EntityNameTemplate == "" {
+		return opts.name
+	}
+	return strings.TrimSpace(c.entityReplacer(opts, category, c.hostname).Replace(c.config.EntityNameTemplate))
+}
+
+// uniqueID applies the unique ID template to an HA entity.
+func (c *Client) uniqueID(opts haEntityOpts, category string) string {
+	tmpl := c.config.UniqueIDTemplate
+	if tmpl == "" {
+		tmpl = defaultUniqueIDTemplate
+	}
+	return c.entityReplacer(opts, category, c.hostID()).Replace(tmpl)
+}
+
+func (c *Client) entityReplacer(opts haEntityOpts, category, host string) *strings.Replacer {
+	return strings.NewReplacer(
+		"{host}", host,
+		"{category}", category,
+		"{id}", opts.id,
+		"{name}", opts.name,
+	)
+}
+
+// retractDiscovery clears a retained discovery config once, so entities of a
+// category disabled since the last run disappear from Home Assistant without
+// publishing an empty payload on every update.
+func (c *Client) retractDiscovery(topic string) {
+	if _, done := c.retracted.LoadOrStore(topic, true); done {
+		return
+	}
+	if err := c.publish(topic, "", true); err != nil {
+		logger.Debug("MQTT: Failed to retract HA discovery %s: %v", topic, err)
+		c.retracted.Delete(topic)
+	}
+}
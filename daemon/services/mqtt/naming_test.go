@@ -0,0 +1,93 @@
+package mqtt
+
+import "testing"
+
+func TestBuildTopicTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		template string
+		suffix   string
+		expected string
+	}{
+		{"default layout", "unraid", "", "system", "unraid/system"},
+		{"host level", "unraid", "{prefix}/{host}/{topic}", "docker/plex", "unraid/My_Tower/docker/plex"},
+		{"host first", "unraid", "{host}/{prefix}/{topic}", "array", "My_Tower/unraid/array"},
+		{"empty prefix", "", "{prefix}/{host}/{topic}", "system", "My_Tower/system"},
+		{"command base keeps trailing slash", "unraid", "{prefix}/{host}/{topic}", "cmd/", "unraid/My_Tower/cmd/"},
+		{"invalid template falls back", "unraid", "{topic}/{host}", "system", "unraid/system"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.TopicPrefix = tt.prefix
+			config.TopicTemplate = tt.template
+			client := NewClient(config, "My Tower", "1.0.0", nil)
+
+			if got := client.buildTopic(tt.suffix); got != tt.expected {
+				t.Errorf("buildTopic(%q) = %q, want %q", tt.suffix, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEntityCategory(t *testing.T) {
+	config := DefaultConfig()
+	config.TopicTemplate = "{prefix}/{host}/{topic}"
+	client := NewClient(config, "tower", "1.0.0", nil)
+
+	tests := []struct {
+		opts     haEntityOpts
+		expected string
+	}{
+		{haEntityOpts{stateTopic: client.buildTopic("system")}, "system"},
+		{haEntityOpts{stateTopic: client.buildTopic("docker/plex")}, "docker"},
+		{haEntityOpts{commandTopic: client.buildCommandTopic("array", "parity", "start")}, "array"},
+		{haEntityOpts{stateTopic: "elsewhere/system"}, ""},
+	}
+	for _, tt := range tests {
+		if got := client.entityCategory(tt.opts); got != tt.expected {
+			t.Errorf("entityCategory(%+v) = %q, want %q", tt.opts, got, tt.expected)
+		}
+	}
+}
+
+func TestDiscoveryDisabled(t *testing.T) {
+	config := DefaultConfig()
+	config.HADisabledCategories = []string{"docker", " VM "}
+	client := NewClient(config, "tower", "1.0.0", nil)
+
+	for category, want := range map[string]bool{"docker": true, "vm": true, "system": false, "": false} {
+		if got := client.discoveryDisabled(category); got != want {
+			t.Errorf("discoveryDisabled(%q) = %v, want %v", category, got, want)
+		}
+	}
+}
+
+func TestEntityNaming(t *testing.T) {
+	opts := haEntityOpts{id: "cpu_usage", name: "System: CPU Usage"}
+
+	t.Run("defaults", func(t *testing.T) {
+		client := NewClient(DefaultConfig(), "My Tower", "1.0.0", nil)
+		if got := client.entityName(opts, "system"); got != "System: CPU Usage" {
+			t.Errorf("entityName = %q", got)
+		}
+		if got := client.uniqueID(opts, "system"); got != "unraid_My_Tower_cpu_usage" {
+			t.Errorf("uniqueID = %q", got)
+		}
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		config := DefaultConfig()
+		config.EntityNameTemplate = "{host} {name}"
+		config.UniqueIDTemplate = "nas_{host}_{category}_{id}"
+		client := NewClient(config, "My Tower", "1.0.0", nil)
+		if got := client.entityName(opts, "system"); got != "My Tower System: CPU Usage" {
+			t.Errorf("entityName = %q", got)
+		}
+		if got := client.uniqueID(opts, "system"); got != "nas_My_Tower_system_cpu_usage" {
+			t.Errorf("uniqueID = %q", got)
+		}
+	})
+}
@@ -0,0 +1,297 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Home Assistant device trigger types. Each fires once per edge event, so
+// automations can react to it instead of polling a state sensor.
+const (
+	triggerParityCheckFinished = "parity_check_finished"
+	triggerDiskOverheated      = "disk_overheated"
+	triggerDiskUsage           = "disk_usage"
+	triggerUPSOnBattery        = "ups_on_battery"
+	triggerUPSPowerRestored    = "ups_power_restored"
+	triggerContainerCrashed    = "container_crashed"
+)
+
+// deviceTrigger is one type/subtype pair offered to Home Assistant.
+type deviceTrigger struct {
+	kind    string
+	subtype string
+}
+
+// deviceTriggers lists every trigger published for discovery.
+var deviceTriggers = []deviceTrigger{
+	{triggerParityCheckFinished, "array"},
+	{triggerDiskOverheated, dto.ThermalLevelWarning},
+	{triggerDiskOverheated, dto.ThermalLevelCritical},
+	{triggerDiskUsage, dto.ThermalLevelWarning},
+	{triggerDiskUsage, dto.ThermalLevelCritical},
+	{triggerDiskUsage, dto.ThermalLevelResolved},
+	{triggerUPSOnBattery, "ups"},
+	{triggerUPSPowerRestored, "ups"},
+	{triggerContainerCrashed, "docker"},
+}
+
+// exitCodeRegex extracts the exit code from a Docker status such as
+// "Exited (137) 5 seconds ago".
+var exitCodeRegex = regexp.MustCompile(`^Exited \((\d+)\)`)
+
+// containerSnapshot is the part of a container's state used to spot crashes.
+type containerSnapshot struct {
+	state        string
+	restartCount int
+}
+
+// triggerState remembers the previous readings needed to detect edges. Each
+// source is seeded by its first reading, so an agent restart never fires.
+type triggerState struct {
+	mu           sync.Mutex
+	parityStatus *string
+	upsOnBattery *bool
+	containers   map[string]containerSnapshot
+}
+
+// firedTrigger is a trigger with its payload.
+type firedTrigger struct {
+	deviceTrigger
+	payload map[string]any
+}
+
+// triggerTopic returns the topic a trigger's events are published on.
+func (c *Client) triggerTopic(t deviceTrigger) string {
+	return c.buildTopic(fmt.Sprintf("triggers/%s/%s", t.kind, t.subtype))
+}
+
+// publishTriggerDiscovery publishes an HA device_automation config for each
+// device trigger, unless the "triggers" category is disabled.
+func (c *Client) publishTriggerDiscovery() {
+	for _, t := range deviceTriggers {
+		topic := fmt.Sprintf("%s/device_automation/%s/%s_%s/config",
+			c.config.HADiscoveryPrefix, c.hostID(), t.kind, t.subtype)
+		if c.discoveryDisabled(c.topicCategory(c.triggerTopic(t))) {
+			c.retractDiscovery(topic)
+			continue
+		}
+		config := map[string]any{
+			"automation_type": "trigger",
+			"topic":           c.triggerTopic(t),
+			"type":            t.kind,
+			"subtype":         t.subtype,
+			"device":          c.deviceInfo,
+		}
+		if err := c.publishJSON(topic, config); err != nil {
+			logger.Warning("MQTT: Failed to publish HA device trigger %s/%s: %v", t.kind, t.subtype, err)
+		}
+	}
+}
+
+// fireTriggers publishes trigger events. They are never retained, so Home
+// Assistant does not replay them on reconnect, and are held back during a
+// maintenance window like the problem binary sensors.
+func (c *Client) fireTriggers(fired []firedTrigger) {
+	if len(fired) == 0 {
+		return
+	}
+	if c.maintenanceStatus != nil && c.maintenanceStatus().Active {
+		logger.Debug("MQTT: Suppressed %d device trigger(s) during maintenance", len(fired))
+		return
+	}
+	now := time.Now().Format(time.RFC3339)
+	for _, f := range fired {
+		f.payload["type"] = f.kind
+		f.payload["subtype"] = f.subtype
+		f.payload["timestamp"] = now
+		data, err := json.Marshal(f.payload)
+		if err != nil {
+			logger.Warning("MQTT: Failed to marshal device trigger %s: %v", f.kind, err)
+			continue
+		}
+		if err := c.publish(c.triggerTopic(f.deviceTrigger), string(data), false); err != nil {
+			logger.Warning("MQTT: Failed to publish device trigger %s: %v", f.kind, err)
+		}
+	}
+}
+
+// PublishThermalEvent fires the disk_overheated trigger when a disk stays
+// above its warning or critical temperature threshold.
+func (c *Client) PublishThermalEvent(event dto.ThermalEvent) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	c.fireTriggers(diskOverheatTriggers(event))
+	return nil
+}
+
+// diskOverheatTriggers maps a thermal event to the disk_overheated trigger.
+func diskOverheatTriggers(event dto.ThermalEvent) []firedTrigger {
+	if event.Sensor != dto.ThermalSensorDisk ||
+		(event.Level != dto.ThermalLevelWarning && event.Level != dto.ThermalLevelCritical) {
+		return nil
+	}
+	return []firedTrigger{{
+		deviceTrigger: deviceTrigger{triggerDiskOverheated, event.Level},
+		payload: map[string]any{
+			"disk":                event.Entity,
+			"name":                event.Name,
+			"temperature_celsius": event.TemperatureC,
+			"threshold_celsius":   event.ThresholdC,
+			"since":               event.Since.Format(time.RFC3339),
+		},
+	}}
+}
+
+// parityTriggers fires parity_check_finished when a running or paused parity
+// operation is no longer reported.
+func (s *triggerState) parityTriggers(status *dto.ArrayStatus) []firedTrigger {
+	if status == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.parityStatus
+	cur := status.ParityCheckStatus
+	s.parityStatus = &cur
+	if prev == nil || !isParityActive(*prev) || isParityActive(cur) {
+		return nil
+	}
+	return []firedTrigger{{
+		deviceTrigger: deviceTrigger{triggerParityCheckFinished, "array"},
+		payload: map[string]any{
+			"previous_status": *prev,
+			"status":          cur,
+			"parity_valid":    status.ParityValid,
+			"array_state":     status.State,
+		},
+	}}
+}
+
+func isParityActive(status string) bool {
+	return status == "running" || status == "paused"
+}
+
+// upsTriggers fires ups_on_battery and ups_power_restored when the UPS
+// switches between mains and battery.
+func (s *triggerState) upsTriggers(ups *dto.UPSStatus) []firedTrigger {
+	if ups == nil || !ups.Connected {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	onBattery := isOnBattery(ups.Status)
+	prev := s.upsOnBattery
+	s.upsOnBattery = &onBattery
+	if prev == nil || *prev == onBattery {
+		return nil
+	}
+	kind := triggerUPSPowerRestored
+	if onBattery {
+		kind = triggerUPSOnBattery
+	}
+	return []firedTrigger{{
+		deviceTrigger: deviceTrigger{kind, "ups"},
+		payload: map[string]any{
+			"status":                 ups.Status,
+			"battery_charge_percent": ups.BatteryCharge,
+			"runtime_left_seconds":   ups.RuntimeLeft,
+		},
+	}}
+}
+
+// isOnBattery reports whether an apcupsd ("ONBATT") or NUT ("OB DISCHRG")
+// status means the UPS runs on battery.
+func isOnBattery(status string) bool {
+	fields := strings.Fields(strings.ToUpper(status))
+	return slices.Contains(fields, "ONBATT") || slices.Contains(fields, "OB")
+}
+
+// containerTriggers fires container_crashed when a running container exits
+// with a non-zero code or restarts on its own. Exit codes 137 and 143 are
+// what docker stop produces, so they are not treated as crashes.
+func (s *triggerState) containerTriggers(containers []dto.ContainerInfo) []firedTrigger {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.containers
+	s.containers = make(map[string]containerSnapshot, len(containers))
+	for _, ct := range containers {
+		s.containers[ct.Name] = containerSnapshot{state: ct.State, restartCount: ct.RestartCount}
+	}
+	if prev == nil {
+		return nil
+	}
+
+	var fired []firedTrigger
+	for _, ct := range containers {
+		old, ok := prev[ct.Name]
+		if !ok {
+			continue
+		}
+		exitCode := -1
+		if m := exitCodeRegex.FindStringSubmatch(ct.Status); m != nil {
+			exitCode, _ = strconv.Atoi(m[1])
+		}
+		var reason string
+		switch {
+		case ct.RestartCount > old.restartCount:
+			reason = "restarted"
+		case old.state == "running" && ct.State == "exited" && exitCode > 0 && exitCode != 137 && exitCode != 143:
+			reason = "exited"
+		case old.state == "running" && ct.State == "dead":
+			reason = "dead"
+		default:
+			continue
+		}
+		payload := map[string]any{
+			"container":     ct.Name,
+			"image":         ct.Image,
+			"reason":        reason,
+			"state":         ct.State,
+			"status":        ct.Status,
+			"restart_count": ct.RestartCount,
+		}
+		if exitCode >= 0 {
+			payload["exit_code"] = exitCode
+		}
+		fired = append(fired, firedTrigger{deviceTrigger: deviceTrigger{triggerContainerCrashed, "docker"}, payload: payload})
+	}
+	return fired
+}
+
+// PublishUtilizationEvent fires the disk_usage trigger when an array disk or
+// pool changes utilization level.
+func (c *Client) PublishUtilizationEvent(event dto.UtilizationEvent) error {
+	if !c.shouldPublish() {
+		return nil
+	}
+	c.fireTriggers(diskUsageTriggers(event))
+	return nil
+}
+
+// diskUsageTriggers maps a utilization event to the disk_usage trigger.
+func diskUsageTriggers(event dto.UtilizationEvent) []firedTrigger {
+	return []firedTrigger{{
+		deviceTrigger: deviceTrigger{triggerDiskUsage, event.Level},
+		payload: map[string]any{
+			"kind":              event.Kind,
+			"entity":            event.Entity,
+			"name":              event.Name,
+			"previous_level":    event.PreviousLevel,
+			"used_percent":      event.UsedPercent,
+			"threshold_percent": event.ThresholdPercent,
+		},
+	}}
+}
@@ -0,0 +1,107 @@
+package mqtt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParityTriggers(t *testing.T) {
+	var s triggerState
+	steps := []struct {
+		status string
+		fire   bool
+	}{
+		{"running", false}, // seeds
+		{"paused", false},
+		{"running", false},
+		{"idle", true},
+		{"idle", false},
+	}
+	for i, step := range steps {
+		fired := s.parityTriggers(&dto.ArrayStatus{State: "Started", ParityCheckStatus: step.status, ParityValid: true})
+		if (len(fired) == 1) != step.fire {
+			t.Fatalf("step %d (%s): fired %v, want fire=%v", i, step.status, fired, step.fire)
+		}
+		if step.fire && (fired[0].kind != triggerParityCheckFinished || fired[0].payload["previous_status"] != "running") {
+			t.Errorf("fired = %+v", fired[0])
+		}
+	}
+}
+
+func TestUPSTriggers(t *testing.T) {
+	var s triggerState
+	if fired := s.upsTriggers(&dto.UPSStatus{Connected: true, Status: "ONLINE"}); len(fired) != 0 {
+		t.Fatalf("seed fired %v", fired)
+	}
+	fired := s.upsTriggers(&dto.UPSStatus{Connected: true, Status: "ONBATT", BatteryCharge: 97})
+	if len(fired) != 1 || fired[0].kind != triggerUPSOnBattery {
+		t.Fatalf("on battery: %v", fired)
+	}
+	if fired := s.upsTriggers(&dto.UPSStatus{Connected: true, Status: "OB DISCHRG"}); len(fired) != 0 {
+		t.Errorf("still on battery fired %v", fired)
+	}
+	if fired := s.upsTriggers(&dto.UPSStatus{Connected: false}); len(fired) != 0 {
+		t.Errorf("disconnected UPS fired %v", fired)
+	}
+	fired = s.upsTriggers(&dto.UPSStatus{Connected: true, Status: "OL CHRG"})
+	if len(fired) != 1 || fired[0].kind != triggerUPSPowerRestored {
+		t.Errorf("power restored: %v", fired)
+	}
+}
+
+func TestContainerTriggers(t *testing.T) {
+	var s triggerState
+	running := func(name string) dto.ContainerInfo {
+		return dto.ContainerInfo{Name: name, State: "running", Status: "Up 2 hours"}
+	}
+	s.containerTriggers([]dto.ContainerInfo{running("crash"), running("stopped"), running("killed"), running("looping"), running("fine")})
+
+	fired := s.containerTriggers([]dto.ContainerInfo{
+		{Name: "crash", State: "exited", Status: "Exited (1) 3 seconds ago"},
+		{Name: "stopped", State: "exited", Status: "Exited (0) 3 seconds ago"},
+		{Name: "killed", State: "exited", Status: "Exited (143) 3 seconds ago"},
+		{Name: "looping", State: "running", Status: "Up 1 second", RestartCount: 1},
+		running("fine"),
+		{Name: "new", State: "exited", Status: "Exited (1) 1 second ago"},
+	})
+	got := map[string]string{}
+	for _, f := range fired {
+		got[f.payload["container"].(string)] = f.payload["reason"].(string)
+	}
+	want := map[string]string{"crash": "exited", "looping": "restarted"}
+	if len(got) != len(want) {
+		t.Fatalf("crashed = %v, want %v", got, want)
+	}
+	for name, reason := range want {
+		if got[name] != reason {
+			t.Errorf("%s: reason %q, want %q", name, got[name], reason)
+		}
+	}
+}
+
+func TestDiskOverheatTriggers(t *testing.T) {
+	event := dto.ThermalEvent{Sensor: dto.ThermalSensorDisk, Entity: "disk3", Level: dto.ThermalLevelCritical, TemperatureC: 61, Since: time.Now()}
+	fired := diskOverheatTriggers(event)
+	if len(fired) != 1 || fired[0].subtype != dto.ThermalLevelCritical || fired[0].payload["disk"] != "disk3" {
+		t.Fatalf("fired = %v", fired)
+	}
+
+	event.Level = dto.ThermalLevelResolved
+	if fired := diskOverheatTriggers(event); len(fired) != 0 {
+		t.Errorf("resolved fired %v", fired)
+	}
+	event.Sensor, event.Level = dto.ThermalSensorCPU, dto.ThermalLevelWarning
+	if fired := diskOverheatTriggers(event); len(fired) != 0 {
+		t.Errorf("cpu fired %v", fired)
+	}
+}
+
+func TestDiskUsageTriggers(t *testing.T) {
+	event := dto.UtilizationEvent{Kind: dto.UtilizationKindPool, Entity: "cache", Level: dto.ThermalLevelResolved, UsedPercent: 60, ThresholdPercent: 70}
+	fired := diskUsageTriggers(event)
+	if len(fired) != 1 || fired[0].kind != triggerDiskUsage || fired[0].subtype != dto.ThermalLevelResolved || fired[0].payload["entity"] != "cache" {
+		t.Fatalf("fired = %v", fired)
+	}
+}
@@ -17,14 +17,31 @@ import (
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/platform"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/acme"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/agent"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/alerting"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/api"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/audit"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/auth"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/bootseq"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/configreload"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/discovery"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/diskspin"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/fleet"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/forwarding"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/graphite"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/heartbeat"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/hooks"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/influxdb"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/maintenance"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/mcp"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/mqtt"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/power"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/scripts"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/transfer"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/watchdog"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/zabbix"
 )
 
 // Orchestrator coordinates the lifecycle of all collectors, API server, and handles graceful shutdown.
@@ -90,6 +107,16 @@ func (o *Orchestrator) Run() error {
 	// Pass the collector manager for runtime control
 	apiServer := api.NewServerWithCollectorManager(o.ctx, o.collectorManager)
 
+	// Answer with the state saved by the previous shutdown until the
+	// collectors have run again
+	if !o.ctx.Mock {
+		if n, err := apiServer.RestoreCacheSnapshot(constants.CacheSnapshotFile); err != nil {
+			logger.Warning("Cache: failed to restore cache snapshot: %v", err)
+		} else if n > 0 {
+			logger.Info("Cache: restored %d caches from the previous run", n)
+		}
+	}
+
 	// Start API server subscriptions and WebSocket hub
 	apiServer.StartSubscriptions()
 
@@ -97,11 +124,101 @@ func (o *Orchestrator) Run() error {
 	<-apiServer.Ready()
 	logger.Success("API server subscriptions ready")
 
+	// Audit trail of every state-changing operation (REST, MCP, MQTT, webhooks)
+	auditLog := audit.NewLog(o.ctx.LogsDir)
+	if err := auditLog.Load(); err != nil {
+		logger.Warning("Audit: failed to load existing entries: %v", err)
+	}
+	// Successful control actions re-collect the affected category right away
+	auditLog.OnRecord(o.collectorManager.RefreshAfterAction)
+	apiServer.SetAuditLog(auditLog)
+
+	// Maintenance window: suppresses alerts, remediation, notification
+	// forwarding and HA binary sensors, and optionally throttles collectors.
+	// Loaded before MQTT connects so a restored window is published.
+	maintenanceMgr := maintenance.NewManager("", o.ctx.Hub)
+	maintenanceMgr.OnChange(func(s dto.MaintenanceStatus) {
+		o.collectorManager.SetThrottle(s.ThrottleFactor)
+	})
+	if err := maintenanceMgr.Load(); err != nil {
+		logger.Warning("Maintenance: failed to load maintenance state: %v", err)
+	}
+	apiServer.SetMaintenance(maintenanceMgr)
+
+	// Password login for web frontends; AUTH_REQUIRED makes it mandatory
+	authManager, err := auth.NewManager(o.ctx.Auth, "")
+	if err != nil {
+		logger.Error("Login disabled: %v", err)
+	} else {
+		apiServer.SetAuthManager(authManager)
+	}
+	if o.ctx.Auth.Required {
+		logger.Info("Authentication required on the REST API (login token or API key)")
+	}
+
+	// Obtain and renew the HTTPS certificate with ACME if enabled. Set up
+	// before discovery so a failed setup advertises plain HTTP.
+	if o.ctx.ACME.Enabled {
+		o.initializeACME(ctx, &wg, apiServer)
+	}
+
 	// Initialize MQTT client if enabled
 	if o.ctx.MQTTConfig.Enabled {
 		o.initializeMQTT(ctx, &wg, apiServer)
 	}
 
+	// Mock data is never pushed to external systems
+	if o.ctx.Mock {
+		logger.Info("Mock mode: InfluxDB, Graphite, Zabbix and heartbeat not started")
+	}
+
+	// Push metrics to InfluxDB if enabled
+	if o.ctx.InfluxDBConfig.Enabled && !o.ctx.Mock {
+		o.initializeInfluxDB(ctx, &wg, apiServer)
+	}
+
+	// Push metrics to Graphite/StatsD if enabled
+	if o.ctx.GraphiteConfig.Enabled && !o.ctx.Mock {
+		o.initializeGraphite(ctx, &wg, apiServer)
+	}
+
+	// Push collector data to Zabbix if enabled
+	if o.ctx.ZabbixConfig.Enabled && !o.ctx.Mock {
+		o.initializeZabbix(ctx, &wg)
+	}
+
+	// Dead-man's-switch heartbeat pings if enabled
+	if o.ctx.HeartbeatConfig.Enabled && !o.ctx.Mock {
+		o.initializeHeartbeat(ctx, &wg)
+	}
+
+	// Restart containers stuck unhealthy if enabled
+	if o.ctx.UnhealthyRestart.Enabled && !o.ctx.Mock {
+		o.initializeUnhealthyRestart(ctx, &wg)
+	}
+
+	// Estimate power draw and energy use from collector readings
+	powerEstimator := power.NewEstimator(o.ctx.Hub)
+	wg.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("Power estimator goroutine", r)
+			}
+		}()
+		powerEstimator.Start(ctx)
+	})
+
+	// Record disk spin-ups and spin-downs and what caused the spin-ups
+	spinTracker := diskspin.NewTracker(o.ctx.Hub)
+	wg.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("Disk spin tracker goroutine", r)
+			}
+		}()
+		spinTracker.Start(ctx)
+	})
+
 	// Advertise the agent on the local network via mDNS so integrations
 	// (e.g. Home Assistant) can auto-discover it. Best-effort and optional.
 	if o.ctx.DiscoveryConfig.Enabled {
@@ -111,9 +228,24 @@ func (o *Orchestrator) Run() error {
 	// Initialize MCP server with Streamable HTTP transport (MCP spec 2025-06-18)
 	// Uses the official MCP Go SDK for protocol compliance with Claude, ChatGPT, Cursor, Copilot, etc.
 	mcpServer := mcp.NewServer(o.ctx, apiServer)
+	mcpServer.SetAuditLog(auditLog)
 	if err := mcpServer.Initialize(); err != nil {
 		logger.Error("Failed to initialize MCP server: %v", err)
 	} else {
+		// Restrict the tools offered to network clients. An invalid policy
+		// fails closed to read-only rather than exposing control tools.
+		policy := mcp.ResolveToolPolicy(o.ctx.MCPHTTPTools, o.ctx.MCPAPIKey != "")
+		if err := mcpServer.RestrictTools(policy); err != nil {
+			logger.Error("MCP: invalid HTTP tool policy (%v); exposing read-only tools only", err)
+			_ = mcpServer.RestrictTools(mcp.ToolPolicyReadOnly)
+		}
+		if o.ctx.MCPAPIKey == "" {
+			logger.Warning("MCP: /mcp endpoint has no API key (set MCP_API_KEY); tool policy %q", policy)
+		}
+
+		// Push resources/updated notifications to subscribed clients
+		wg.Go(func() { mcpServer.WatchResources(ctx) })
+
 		// Mount as PathPrefix handler — the StreamableHTTPHandler manages all HTTP methods internally
 		apiServer.GetRouter().PathPrefix("/mcp").Handler(mcpServer.GetHTTPHandler())
 		logger.Success("MCP server initialized at /mcp endpoint (official SDK, protocol 2025-06-18)")
@@ -128,15 +260,20 @@ func (o *Orchestrator) Run() error {
 	// reads of the hub field don't race with a later SetEventBus write.
 	// Publishing to agent_wake with no subscriber (agent disabled) is a no-op.
 	alertEngine.SetEventBus(o.ctx.Hub)
-	wg.Go(func() {
-		defer func() {
-			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Alerting engine goroutine", r)
-			}
-		}()
-		alertEngine.Start(ctx)
-	})
-	logger.Success("Alerting engine started")
+	alertEngine.SetMaintenance(maintenanceMgr)
+	if o.ctx.Mock {
+		logger.Info("Mock mode: alerting engine not started")
+	} else {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Alerting engine goroutine", r)
+				}
+			}()
+			alertEngine.Start(ctx)
+		})
+		logger.Success("Alerting engine started")
+	}
 
 	// Initialize watchdog (health checks)
 	watchdogStore := watchdog.NewStore("")
@@ -147,15 +284,90 @@ func (o *Orchestrator) Run() error {
 	// Set the event bus before launching Start to avoid racing with a later
 	// SetEventBus write. No-op publish if the agent is disabled.
 	watchdogRunner.SetEventBus(o.ctx.Hub)
+	watchdogRunner.SetMaintenance(maintenanceMgr)
+	if o.ctx.Mock {
+		logger.Info("Mock mode: watchdog not started")
+	} else {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Watchdog goroutine", r)
+				}
+			}()
+			watchdogRunner.Start(ctx)
+		})
+		logger.Success("Watchdog started")
+	}
+
+	// Initialize the agent-managed script registry
+	scriptStore := scripts.NewStore("")
+	apiServer.SetScripts(scriptStore)
+	mcpServer.SetScripts(scriptStore)
+
+	// Initialize inbound webhooks
+	hookStore := hooks.NewStore("")
+	if err := hookStore.Load(); err != nil {
+		logger.Warning("Failed to load inbound webhooks: %v", err)
+	}
+	hookRunner := hooks.NewRunner(hookStore, scriptStore)
+	hookRunner.SetAuditLog(auditLog)
+	apiServer.SetHooks(hookRunner, hookStore)
+
+	// Initialize transfer jobs (rsync/rclone)
+	transferRunner := transfer.NewRunner(transfer.NewStore(""))
+	apiServer.SetTransfers(transferRunner)
+	mcpServer.SetTransfers(transferRunner)
+	transferRunner.SetEventBus(o.ctx.Hub)
+	if o.ctx.Mock {
+		logger.Info("Mock mode: transfer runner not started")
+	} else {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Transfer runner goroutine", r)
+				}
+			}()
+			transferRunner.Start(ctx)
+		})
+		logger.Success("Transfer runner started")
+	}
+
+	// Hot-reload the YAML config file on SIGHUP or when it changes on disk
+	configReloader := configreload.NewReloader(domain.DefaultConfigPath, o.ctx, o.collectorManager)
+	apiServer.SetConfigReloader(configReloader)
 	wg.Go(func() {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Watchdog goroutine", r)
+				logger.LogPanicWithStack("Config reloader goroutine", r)
 			}
 		}()
-		watchdogRunner.Start(ctx)
+		configReloader.Start(ctx)
 	})
-	logger.Success("Watchdog started")
+
+	// Initialize notification forwarding (Telegram, Discord, Pushover)
+	forwardingStore := forwarding.NewStore("")
+	forwarder := forwarding.NewForwarder(forwardingStore, o.ctx.Hub)
+	forwarder.SetMaintenance(maintenanceMgr)
+	apiServer.SetNotificationForwarding(forwarder, forwardingStore)
+	if o.ctx.Mock {
+		logger.Info("Mock mode: notification forwarding not started")
+	} else {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Notification forwarding goroutine", r)
+				}
+			}()
+			forwarder.Start(ctx)
+		})
+	}
+
+	// Initialize fleet mode (peer agents on other Unraid servers)
+	fleetStore := fleet.NewStore("")
+	if err := fleetStore.Load(); err != nil {
+		logger.Warning("Failed to load fleet peers: %v", err)
+	}
+	apiServer.SetFleet(fleet.NewClient(fleetStore))
 
 	// Initialize agent (disabled by default; opt-in via agent_config.json + UMA_AGENT_API_KEY)
 	agentCfg := agent.LoadConfig("")
@@ -182,37 +394,52 @@ func (o *Orchestrator) Run() error {
 		}
 	}
 
-	// Initialize fan controller (disabled by default, enabled via config)
-	fanCtrl := controllers.NewFanController()
-	if err := fanCtrl.Initialize(); err != nil {
-		logger.Warning("Fan controller initialization failed (fan control disabled): %v", err)
+	// Mock mode leaves fans, CPU governors, kernel tuning and swap alone
+	if o.ctx.Mock {
+		logger.Info("Mock mode: fan, CPU and tuning controllers not started")
 	} else {
-		o.fanController = fanCtrl
-		apiServer.SetFanController(fanCtrl)
-		mcpServer.SetFanController(fanCtrl)
-		logger.Success("Fan controller initialized")
-	}
+		// Initialize fan controller (disabled by default, enabled via config)
+		fanCtrl := controllers.NewFanController()
+		if err := fanCtrl.Initialize(); err != nil {
+			logger.Warning("Fan controller initialization failed (fan control disabled): %v", err)
+		} else {
+			o.fanController = fanCtrl
+			apiServer.SetFanController(fanCtrl)
+			mcpServer.SetFanController(fanCtrl)
+			if o.mqttClient != nil {
+				o.mqttClient.SetFanController(fanCtrl)
+			}
+			logger.Success("Fan controller initialized")
+		}
 
-	// Initialize CPU controller (for scaling governor management)
-	cpuCtrl := controllers.NewCPUController()
-	if err := cpuCtrl.Initialize(); err != nil {
-		logger.Warning("CPU controller initialization failed (cpufreq not available): %v", err)
-	} else {
-		o.cpuController = cpuCtrl
-		apiServer.SetCPUController(cpuCtrl)
-		mcpServer.SetCPUController(cpuCtrl)
-		logger.Success("CPU controller initialized")
-	}
+		// Initialize CPU controller (for scaling governor management)
+		cpuCtrl := controllers.NewCPUController()
+		if err := cpuCtrl.Initialize(); err != nil {
+			logger.Warning("CPU controller initialization failed (cpufreq not available): %v", err)
+		} else {
+			o.cpuController = cpuCtrl
+			apiServer.SetCPUController(cpuCtrl)
+			mcpServer.SetCPUController(cpuCtrl)
+			logger.Success("CPU controller initialized")
+		}
 
-	// Initialize tuning controller (for turbo boost, disk cache, inotify)
-	tuningCtrl := controllers.NewTuningController()
-	if err := tuningCtrl.Initialize(); err != nil {
-		logger.Warning("Tuning controller initialization failed: %v", err)
-	} else {
-		o.tuningController = tuningCtrl
-		apiServer.SetTuningController(tuningCtrl)
-		mcpServer.SetTuningController(tuningCtrl)
-		logger.Success("Tuning controller initialized")
+		// Initialize tuning controller (for turbo boost, disk cache, inotify)
+		tuningCtrl := controllers.NewTuningController()
+		if err := tuningCtrl.Initialize(); err != nil {
+			logger.Warning("Tuning controller initialization failed: %v", err)
+		} else {
+			o.tuningController = tuningCtrl
+			apiServer.SetTuningController(tuningCtrl)
+			mcpServer.SetTuningController(tuningCtrl)
+			logger.Success("Tuning controller initialized")
+		}
+
+		// Re-enable the swap file configured through the API
+		controllers.RestoreSwapFile()
+
+		// Roll back or re-arm a network change left unconfirmed by the
+		// previous run
+		controllers.RestorePendingNetworkChange()
 	}
 
 	// Start all enabled collectors
@@ -231,6 +458,24 @@ func (o *Orchestrator) Run() error {
 		logger.Info("Disabled collectors: %v", disabledNames)
 	}
 
+	// Boot sequence: after a boot, wait for network/UPS, unlock and start
+	// the array, then start the selected containers and VMs
+	bootSequence := bootseq.NewRunner(o.ctx, "", apiServer.GetUPSCache)
+	if err := bootSequence.Load(); err != nil {
+		logger.Warning("Boot sequence: failed to load configuration: %v", err)
+	}
+	apiServer.SetBootSequence(bootSequence)
+	if !o.ctx.Mock {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Boot sequence goroutine", r)
+				}
+			}()
+			bootSequence.RunAtBoot(ctx)
+		})
+	}
+
 	// Start HTTP server
 	wg.Go(func() {
 		defer func() {
@@ -282,7 +527,12 @@ func (o *Orchestrator) Run() error {
 		logger.Info("Tuning controller shut down")
 	}
 
-	// 4. Close the agent's Docker controller if it was started
+	// 4. Release the swap file so the array can unmount its pool
+	if !o.ctx.Mock {
+		controllers.ReleaseSwapFile()
+	}
+
+	// 5. Close the agent's Docker controller if it was started
 	if o.agentDocker != nil {
 		if err := o.agentDocker.Close(); err != nil {
 			logger.Warning("Agent Docker controller close failed: %v", err)
@@ -291,30 +541,45 @@ func (o *Orchestrator) Run() error {
 		logger.Info("Agent Docker controller closed")
 	}
 
-	// 5. Stop advertising via mDNS (sends goodbye packets) before other
-	// services wind down so discovery clients drop the entry promptly.
+	// 6. Save the caches for the next start first, so they are on disk even
+	// if a collector holds up the rest of the shutdown
+	if !o.ctx.Mock {
+		if err := apiServer.SaveCacheSnapshot(constants.CacheSnapshotFile); err != nil {
+			logger.Warning("Cache: failed to save cache snapshot: %v", err)
+		}
+	}
+
+	// 7. Stop the collectors together under one deadline, so nothing
+	// publishes after the MQTT offline message
+	o.collectorManager.StopAll()
+	logger.Info("Collectors stopped")
+
+	// 8. Stop advertising via mDNS (sends goodbye packets) so discovery
+	// clients drop the entry promptly.
 	if o.discoveryService != nil {
 		o.discoveryService.Shutdown()
 		o.discoveryService = nil
 		logger.Info("Discovery service stopped")
 	}
 
-	// 6. Stop MQTT client if running
+	// 9. Stop MQTT client if running (publishes offline availability)
 	if o.mqttClient != nil {
 		o.mqttClient.Disconnect()
 		logger.Info("MQTT client disconnected")
 	}
 
-	// 7. Stop all collectors via manager
-	o.collectorManager.StopAll()
-
-	// 8. Stop API server (which also cancels its internal goroutines)
+	// 10. Stop API server (sends WebSocket clients a going-away frame and
+	// cancels its internal goroutines)
 	apiServer.Stop()
 
-	// 9. Wait for all goroutines to complete
+	// 11. Wait for all goroutines to complete
 	logger.Info("Waiting for all goroutines to complete...")
 	wg.Wait()
 
+	if err := auditLog.Close(); err != nil {
+		logger.Warning("Audit log close failed: %v", err)
+	}
+
 	logger.Success("Shutdown complete")
 
 	return nil
@@ -363,8 +628,18 @@ func (o *Orchestrator) RunMCPStdio() error {
 	enabledCount := o.collectorManager.StartAll()
 	logger.Success("%d collectors started for MCP STDIO", enabledCount)
 
+	// Audit MCP write tools in STDIO mode too
+	auditLog := audit.NewLog(o.ctx.LogsDir)
+	if err := auditLog.Load(); err != nil {
+		logger.Warning("Audit: failed to load existing entries: %v", err)
+	}
+	defer func() { _ = auditLog.Close() }()
+	auditLog.OnRecord(o.collectorManager.RefreshAfterAction)
+	apiServer.SetAuditLog(auditLog)
+
 	// Initialize MCP server
 	mcpServer := mcp.NewServer(o.ctx, apiServer)
+	mcpServer.SetAuditLog(auditLog)
 	if err := mcpServer.Initialize(); err != nil {
 		cancel()
 		o.collectorManager.StopAll()
@@ -372,21 +647,33 @@ func (o *Orchestrator) RunMCPStdio() error {
 		wg.Wait()
 		return fmt.Errorf("failed to initialize MCP server: %w", err)
 	}
+	// STDIO is only reachable by local processes, so "auto" means all tools.
+	if err := mcpServer.RestrictTools(mcp.ResolveToolPolicy(o.ctx.MCPStdioTools, true)); err != nil {
+		cancel()
+		o.collectorManager.StopAll()
+		apiServer.Stop()
+		wg.Wait()
+		return fmt.Errorf("invalid MCP STDIO tool policy: %w", err)
+	}
 
 	// Initialize alerting engine for STDIO mode
 	alertStore := alerting.NewStore("")
 	alertEngine := alerting.NewEngine(alertStore, apiServer)
 	apiServer.SetAlertEngine(alertEngine, alertStore)
 	mcpServer.SetAlertEngine(alertEngine, alertStore)
-	wg.Go(func() {
-		defer func() {
-			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Alerting engine goroutine (STDIO)", r)
-			}
-		}()
-		alertEngine.Start(ctx)
-	})
-	logger.Success("Alerting engine started (STDIO mode)")
+	if o.ctx.Mock {
+		logger.Info("Mock mode: alerting engine not started")
+	} else {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Alerting engine goroutine (STDIO)", r)
+				}
+			}()
+			alertEngine.Start(ctx)
+		})
+		logger.Success("Alerting engine started (STDIO mode)")
+	}
 
 	// Initialize watchdog for STDIO mode
 	watchdogStore := watchdog.NewStore("")
@@ -394,53 +681,88 @@ func (o *Orchestrator) RunMCPStdio() error {
 	watchdog.SetDockerProvider(apiServer)
 	apiServer.SetWatchdog(watchdogRunner, watchdogStore)
 	mcpServer.SetWatchdog(watchdogRunner, watchdogStore)
-	wg.Go(func() {
-		defer func() {
-			if r := recover(); r != nil {
-				logger.LogPanicWithStack("Watchdog goroutine (STDIO)", r)
-			}
-		}()
-		watchdogRunner.Start(ctx)
-	})
-	logger.Success("Watchdog started (STDIO mode)")
-
-	// Initialize fan controller for STDIO mode
-	fanCtrl := controllers.NewFanController()
-	if err := fanCtrl.Initialize(); err != nil {
-		logger.Warning("Fan controller initialization failed (STDIO mode): %v", err)
+	if o.ctx.Mock {
+		logger.Info("Mock mode: watchdog not started")
 	} else {
-		o.fanController = fanCtrl
-		apiServer.SetFanController(fanCtrl)
-		mcpServer.SetFanController(fanCtrl)
-		logger.Success("Fan controller initialized (STDIO mode)")
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Watchdog goroutine (STDIO)", r)
+				}
+			}()
+			watchdogRunner.Start(ctx)
+		})
+		logger.Success("Watchdog started (STDIO mode)")
 	}
 
-	// Initialize CPU controller for STDIO mode
-	cpuCtrl := controllers.NewCPUController()
-	if err := cpuCtrl.Initialize(); err != nil {
-		logger.Warning("CPU controller initialization failed (STDIO mode): %v", err)
+	// Initialize the agent-managed script registry for STDIO mode
+	scriptStore := scripts.NewStore("")
+	apiServer.SetScripts(scriptStore)
+	mcpServer.SetScripts(scriptStore)
+
+	// Initialize transfer jobs for STDIO mode
+	transferRunner := transfer.NewRunner(transfer.NewStore(""))
+	apiServer.SetTransfers(transferRunner)
+	mcpServer.SetTransfers(transferRunner)
+	if o.ctx.Mock {
+		logger.Info("Mock mode: transfer runner not started")
 	} else {
-		o.cpuController = cpuCtrl
-		apiServer.SetCPUController(cpuCtrl)
-		mcpServer.SetCPUController(cpuCtrl)
-		logger.Success("CPU controller initialized (STDIO mode)")
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.LogPanicWithStack("Transfer runner goroutine (STDIO)", r)
+				}
+			}()
+			transferRunner.Start(ctx)
+		})
+		logger.Success("Transfer runner started (STDIO mode)")
 	}
 
-	// Initialize tuning controller for STDIO mode
-	tuningCtrl := controllers.NewTuningController()
-	if err := tuningCtrl.Initialize(); err != nil {
-		logger.Warning("Tuning controller initialization failed (STDIO mode): %v", err)
+	// Mock mode leaves fans, CPU governors and kernel tuning alone
+	if o.ctx.Mock {
+		logger.Info("Mock mode: fan, CPU and tuning controllers not started (STDIO mode)")
 	} else {
-		o.tuningController = tuningCtrl
-		apiServer.SetTuningController(tuningCtrl)
-		mcpServer.SetTuningController(tuningCtrl)
-		logger.Success("Tuning controller initialized (STDIO mode)")
+		// Initialize fan controller for STDIO mode
+		fanCtrl := controllers.NewFanController()
+		if err := fanCtrl.Initialize(); err != nil {
+			logger.Warning("Fan controller initialization failed (STDIO mode): %v", err)
+		} else {
+			o.fanController = fanCtrl
+			apiServer.SetFanController(fanCtrl)
+			mcpServer.SetFanController(fanCtrl)
+			logger.Success("Fan controller initialized (STDIO mode)")
+		}
+
+		// Initialize CPU controller for STDIO mode
+		cpuCtrl := controllers.NewCPUController()
+		if err := cpuCtrl.Initialize(); err != nil {
+			logger.Warning("CPU controller initialization failed (STDIO mode): %v", err)
+		} else {
+			o.cpuController = cpuCtrl
+			apiServer.SetCPUController(cpuCtrl)
+			mcpServer.SetCPUController(cpuCtrl)
+			logger.Success("CPU controller initialized (STDIO mode)")
+		}
+
+		// Initialize tuning controller for STDIO mode
+		tuningCtrl := controllers.NewTuningController()
+		if err := tuningCtrl.Initialize(); err != nil {
+			logger.Warning("Tuning controller initialization failed (STDIO mode): %v", err)
+		} else {
+			o.tuningController = tuningCtrl
+			apiServer.SetTuningController(tuningCtrl)
+			mcpServer.SetTuningController(tuningCtrl)
+			logger.Success("Tuning controller initialized (STDIO mode)")
+		}
 	}
 
 	// Cancel context on shutdown signals (SIGTERM, SIGINT)
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
+	// Push resources/updated notifications; stops with the deferred cancel
+	go mcpServer.WatchResources(ctx)
+
 	// Run MCP over STDIO (blocks until context cancelled or pipe closed)
 	logger.Info("MCP STDIO transport ready — waiting for client")
 	err := mcpServer.RunSTDIO(ctx)
@@ -475,6 +797,135 @@ func (o *Orchestrator) initializeDiscovery(ctx context.Context) {
 	o.discoveryService = svc
 }
 
+// initializeInfluxDB starts the InfluxDB line-protocol exporter. An invalid
+// configuration is logged and leaves the exporter disabled.
+func (o *Orchestrator) initializeInfluxDB(ctx context.Context, wg *sync.WaitGroup, apiServer *api.Server) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unraid"
+	}
+
+	exporter, err := influxdb.NewExporter(o.ctx.InfluxDBConfig, hostname, apiServer)
+	if err != nil {
+		logger.Error("InfluxDB exporter disabled: %v", err)
+		return
+	}
+
+	wg.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("InfluxDB exporter goroutine", r)
+			}
+		}()
+		exporter.Start(ctx)
+	})
+}
+
+// initializeGraphite starts the Graphite/StatsD exporter. An invalid
+// configuration is logged and leaves the exporter disabled.
+func (o *Orchestrator) initializeGraphite(ctx context.Context, wg *sync.WaitGroup, apiServer *api.Server) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unraid"
+	}
+
+	exporter, err := graphite.NewExporter(o.ctx.GraphiteConfig, hostname, apiServer)
+	if err != nil {
+		logger.Error("Graphite exporter disabled: %v", err)
+		return
+	}
+
+	wg.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("Graphite exporter goroutine", r)
+			}
+		}()
+		exporter.Start(ctx)
+	})
+}
+
+// initializeZabbix starts the Zabbix sender. An invalid configuration is
+// logged and leaves the integration disabled.
+func (o *Orchestrator) initializeZabbix(ctx context.Context, wg *sync.WaitGroup) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unraid"
+	}
+
+	publisher, err := zabbix.NewPublisher(o.ctx.ZabbixConfig, hostname, o.ctx.Hub)
+	if err != nil {
+		logger.Error("Zabbix sender disabled: %v", err)
+		return
+	}
+
+	wg.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("Zabbix sender goroutine", r)
+			}
+		}()
+		publisher.Start(ctx)
+	})
+}
+
+// initializeHeartbeat starts the heartbeat pinger. An invalid configuration
+// is logged and leaves heartbeats disabled.
+func (o *Orchestrator) initializeHeartbeat(ctx context.Context, wg *sync.WaitGroup) {
+	monitor, err := heartbeat.NewMonitor(o.ctx.HeartbeatConfig, o.ctx.Hub, o.ctx.Platform)
+	if err != nil {
+		logger.Error("Heartbeat disabled: %v", err)
+		return
+	}
+
+	wg.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("Heartbeat goroutine", r)
+			}
+		}()
+		monitor.Start(ctx)
+	})
+}
+
+// initializeACME creates the ACME certificate manager, hands it to the API
+// server for the HTTPS listener and starts background renewal. When the
+// manager cannot be created ACME is disabled and the server stays on plain
+// HTTP, unless certificate files are also configured.
+func (o *Orchestrator) initializeACME(ctx context.Context, wg *sync.WaitGroup, apiServer *api.Server) {
+	manager, err := acme.NewManager(o.ctx.ACME, "")
+	if err != nil {
+		logger.Error("ACME disabled: %v", err)
+		o.ctx.ACME.Enabled = false
+		return
+	}
+	apiServer.SetCertificateManager(manager)
+
+	wg.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("ACME renewal goroutine", r)
+			}
+		}()
+		manager.Start(ctx)
+	})
+}
+
+// initializeUnhealthyRestart starts the watchdog that restarts containers
+// whose Docker healthcheck stays unhealthy.
+func (o *Orchestrator) initializeUnhealthyRestart(ctx context.Context, wg *sync.WaitGroup) {
+	restarter := watchdog.NewUnhealthyRestarter(o.ctx.UnhealthyRestart, o.ctx.Hub)
+
+	wg.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogPanicWithStack("Unhealthy container watchdog goroutine", r)
+			}
+		}()
+		restarter.Start(ctx)
+	})
+}
+
 // initializeMQTT sets up the MQTT client and starts publishing events.
 func (o *Orchestrator) initializeMQTT(ctx context.Context, wg *sync.WaitGroup, apiServer *api.Server) {
 	// Get hostname for MQTT client
@@ -488,6 +939,11 @@ func (o *Orchestrator) initializeMQTT(ctx context.Context, wg *sync.WaitGroup, a
 
 	// Create MQTT client
 	o.mqttClient = mqtt.NewClient(mqttConfig, hostname, o.ctx.Version, o.ctx)
+	o.mqttClient.SetAuditLog(apiServer.GetAuditLog())
+	o.mqttClient.SetCollectorControl(o.collectorManager)
+	if m := apiServer.GetMaintenance(); m != nil {
+		o.mqttClient.SetMaintenance(m.Status)
+	}
 
 	// Connect to broker
 	if err := o.mqttClient.Connect(ctx); err != nil {
@@ -564,6 +1020,13 @@ func (o *Orchestrator) subscribeMQTTEvents(ctx context.Context, _ *api.Server) {
 		mqttBind(constants.TopicNetworkListUpdate, o.mqttClient.PublishNetworkInfo),
 		mqttBind(constants.TopicNotificationsUpdate, o.mqttClient.PublishNotifications),
 		mqttBind(constants.TopicZFSPoolsUpdate, o.mqttClient.PublishZFSPools),
+		mqttBind(constants.TopicBtrfsUpdate, o.mqttClient.PublishBtrfsStats),
+		mqttBind(constants.TopicPoolsUpdate, o.mqttClient.PublishPools),
+		mqttBind(constants.TopicRecycleBinUpdate, o.mqttClient.PublishRecycleBin),
+		mqttBind(constants.TopicIPMIUpdate, o.mqttClient.PublishIPMIStatus),
+		mqttBind(constants.TopicTransferProgress, o.mqttClient.PublishTransferProgress),
+		mqttBind(constants.TopicJobUpdate, o.mqttClient.PublishJobUpdate),
+		mqttBind(constants.TopicMaintenanceUpdate, o.mqttClient.PublishMaintenanceStatus),
 		mqttBind(constants.TopicNUTStatusUpdate, o.mqttClient.PublishNUTStatus),
 		mqttBind(constants.TopicHardwareUpdate, o.mqttClient.PublishHardwareInfo),
 		mqttBind(constants.TopicRegistrationUpdate, o.mqttClient.PublishRegistration),
@@ -572,6 +1035,20 @@ func (o *Orchestrator) subscribeMQTTEvents(ctx context.Context, _ *api.Server) {
 		mqttBind(constants.TopicZFSSnapshotsUpdate, o.mqttClient.PublishZFSSnapshots),
 		mqttBind(constants.TopicZFSARCStatsUpdate, o.mqttClient.PublishZFSARCStats),
 		mqttBind(constants.TopicFanControlUpdate, o.mqttClient.PublishFanControlStatus),
+		mqttBind(constants.TopicHealthCheckStatusUpdate, o.mqttClient.PublishHealthChecks),
+		mqttBind(constants.TopicWANStatusUpdate, o.mqttClient.PublishWANStatus),
+		mqttBind(constants.TopicWANIPChanged, o.mqttClient.PublishWANIPChange),
+		mqttBind(constants.TopicSpeedtestUpdate, o.mqttClient.PublishSpeedtestStatus),
+		mqttBind(constants.TopicPowerUpdate, o.mqttClient.PublishPowerEstimate),
+		mqttBind(constants.TopicThermalEvent, o.mqttClient.PublishThermalEvent),
+		mqttBind(constants.TopicUtilizationEvent, o.mqttClient.PublishUtilizationEvent),
+		mqttBind(constants.TopicParityScheduleUpdate, o.mqttClient.PublishParitySchedule),
+		mqttBind(constants.TopicFlashHealthUpdate, o.mqttClient.PublishFlashHealth),
+		mqttBind(constants.TopicMoverUpdate, o.mqttClient.PublishMoverStatus),
+		mqttBind(constants.TopicOSUpdateUpdate, o.mqttClient.PublishOSUpdate),
+		mqttBind(constants.TopicPluginUpdatesUpdate, o.mqttClient.PublishPluginUpdates),
+		mqttBind(constants.TopicDockerUpdatesUpdate, o.mqttClient.PublishContainerUpdates),
+		mqttBind(constants.TopicCollectorStateChange, o.mqttClient.PublishCollectorState),
 	}
 
 	topics := make([]string, len(bindings))
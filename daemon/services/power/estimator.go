@@ -0,0 +1,195 @@
+// Package power estimates the power draw of the server from the readings the
+// collectors already publish: CPU and DRAM power from Intel RAPL, GPU power
+// draw, UPS load, and per-disk spin state. It integrates the estimate into a
+// running energy total suitable for the Home Assistant energy dashboard.
+package power
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DiskActiveWatts is the assumed draw of a spinning 3.5" hard drive.
+	DiskActiveWatts = 7.0
+
+	// DiskStandbyWatts is the assumed draw of a spun-down hard drive.
+	DiskStandbyWatts = 1.0
+
+	// maxIntegrationGap is the longest interval between two estimates that is
+	// added to the energy total. Longer gaps (agent paused, clock jumps) are
+	// skipped rather than extrapolated.
+	maxIntegrationGap = 10 * time.Minute
+)
+
+// Estimator keeps the latest collector readings and publishes a fresh
+// dto.PowerEstimate on every system update.
+type Estimator struct {
+	hub *domain.EventBus
+
+	mu    sync.Mutex
+	gpus  []*dto.GPUMetrics
+	ups   *dto.UPSStatus
+	disks []dto.DiskInfo
+
+	energyKWh   float64
+	energySince time.Time
+	lastWatts   float64
+	lastAt      time.Time
+}
+
+// NewEstimator creates an estimator fed by collector updates on hub.
+func NewEstimator(hub *domain.EventBus) *Estimator {
+	return &Estimator{hub: hub}
+}
+
+// Start consumes collector updates until ctx is cancelled.
+func (e *Estimator) Start(ctx context.Context) {
+	topics := []string{
+		constants.TopicSystemUpdate.Name,
+		constants.TopicGPUMetricsUpdate.Name,
+		constants.TopicUPSStatusUpdate.Name,
+		constants.TopicDiskListUpdate.Name,
+	}
+	ch := e.hub.SubTopics(constants.TopicSystemUpdate, constants.TopicGPUMetricsUpdate,
+		constants.TopicUPSStatusUpdate, constants.TopicDiskListUpdate)
+	defer e.hub.Unsub(ch, topics...)
+	logger.Success("Power estimator started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Power estimator stopped")
+			return
+		case msg := <-ch:
+			switch v := msg.(type) {
+			case []*dto.GPUMetrics:
+				e.mu.Lock()
+				e.gpus = v
+				e.mu.Unlock()
+			case *dto.UPSStatus:
+				e.mu.Lock()
+				e.ups = v
+				e.mu.Unlock()
+			case []dto.DiskInfo:
+				e.mu.Lock()
+				e.disks = v
+				e.mu.Unlock()
+			case *dto.SystemInfo:
+				// The system collector runs on a steady interval, so it
+				// drives the estimate and the energy integration.
+				domain.Publish(e.hub, constants.TopicPowerUpdate, e.Update(v, time.Now()))
+			}
+		}
+	}
+}
+
+// Update computes the estimate for system and the latest GPU, UPS and disk
+// readings, and adds the energy used since the previous update to the total.
+func (e *Estimator) Update(system *dto.SystemInfo, now time.Time) *dto.PowerEstimate {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	est := Estimate(system, e.gpus, e.ups, e.disks)
+	est.Timestamp = now
+
+	if e.energySince.IsZero() {
+		e.energySince = now
+	} else if gap := now.Sub(e.lastAt); gap > 0 && gap <= maxIntegrationGap {
+		// Trapezoidal rule between the previous and current reading.
+		avg := (e.lastWatts + est.TotalWatts) / 2
+		e.energyKWh += avg * gap.Hours() / 1000
+	}
+	e.lastWatts, e.lastAt = est.TotalWatts, now
+
+	est.EnergyKWh = round(e.energyKWh, 3)
+	est.EnergySince = e.energySince
+	return est
+}
+
+// Estimate combines the component readings into a power estimate. The UPS
+// load is used as the total when available, as it measures the whole server
+// at the wall; otherwise the component sum is used. Any argument may be nil.
+func Estimate(system *dto.SystemInfo, gpus []*dto.GPUMetrics, ups *dto.UPSStatus, disks []dto.DiskInfo) *dto.PowerEstimate {
+	est := &dto.PowerEstimate{Source: dto.PowerSourceComponents}
+
+	if system != nil {
+		est.CPUWatts = roundPtr(system.CPUPowerWatts)
+		est.DRAMWatts = roundPtr(system.DRAMPowerWatts)
+	}
+
+	var gpuWatts float64
+	var gpuReported bool
+	for _, g := range gpus {
+		if g != nil && g.Available && g.PowerDraw > 0 {
+			gpuWatts += g.PowerDraw
+			gpuReported = true
+		}
+	}
+	if gpuReported {
+		w := round(gpuWatts, 1)
+		est.GPUWatts = &w
+	}
+
+	for _, d := range disks {
+		switch d.SpinState {
+		case "active":
+			est.DisksActive++
+		case "standby":
+			est.DisksStandby++
+		}
+	}
+	est.DiskWatts = float64(est.DisksActive)*DiskActiveWatts + float64(est.DisksStandby)*DiskStandbyWatts
+
+	est.ComponentWatts = round(deref(est.CPUWatts)+deref(est.DRAMWatts)+deref(est.GPUWatts)+est.DiskWatts, 1)
+	est.TotalWatts = est.ComponentWatts
+
+	if w := upsWatts(ups); w > 0 {
+		w = round(w, 1)
+		est.UPSWatts = &w
+		est.TotalWatts = w
+		est.Source = dto.PowerSourceUPS
+	}
+
+	est.KWhPerDay = round(est.TotalWatts*24/1000, 2)
+	return est
+}
+
+// upsWatts returns the UPS load in watts, derived from the load percentage
+// and nominal power when the UPS does not report watts directly.
+func upsWatts(ups *dto.UPSStatus) float64 {
+	if ups == nil || !ups.Connected {
+		return 0
+	}
+	if ups.PowerWatts > 0 {
+		return ups.PowerWatts
+	}
+	return ups.LoadPercent * ups.NominalPower / 100
+}
+
+func deref(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func roundPtr(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	r := round(*v, 1)
+	return &r
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow10(places)
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,134 @@
+package power
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func float(v float64) *float64 { return &v }
+
+func TestEstimateComponents(t *testing.T) {
+	system := &dto.SystemInfo{CPUPowerWatts: float(35.24), DRAMPowerWatts: float(4.1)}
+	gpus := []*dto.GPUMetrics{
+		{Available: true, PowerDraw: 12.5},
+		{Available: true, PowerDraw: 6.2},
+		{Available: false, PowerDraw: 99},
+	}
+	disks := []dto.DiskInfo{
+		{ID: "disk1", SpinState: "active"},
+		{ID: "disk2", SpinState: "standby"},
+		{ID: "disk3", SpinState: "standby"},
+		{ID: "cache", SpinState: "unknown"},
+	}
+
+	est := Estimate(system, gpus, nil, disks)
+	if est.Source != dto.PowerSourceComponents {
+		t.Errorf("Source = %q, want components", est.Source)
+	}
+	if est.CPUWatts == nil || *est.CPUWatts != 35.2 {
+		t.Errorf("CPUWatts = %v, want 35.2", est.CPUWatts)
+	}
+	if est.GPUWatts == nil || *est.GPUWatts != 18.7 {
+		t.Errorf("GPUWatts = %v, want 18.7", est.GPUWatts)
+	}
+	if est.DisksActive != 1 || est.DisksStandby != 2 || est.DiskWatts != DiskActiveWatts+2*DiskStandbyWatts {
+		t.Errorf("disks = %d active, %d standby, %.1f W", est.DisksActive, est.DisksStandby, est.DiskWatts)
+	}
+	want := 35.2 + 4.1 + 18.7 + DiskActiveWatts + 2*DiskStandbyWatts
+	if est.TotalWatts != want || est.ComponentWatts != want {
+		t.Errorf("TotalWatts = %.1f, ComponentWatts = %.1f, want %.1f", est.TotalWatts, est.ComponentWatts, want)
+	}
+	if est.KWhPerDay != math.Round(want*24/1000*100)/100 {
+		t.Errorf("KWhPerDay = %v", est.KWhPerDay)
+	}
+	if est.UPSWatts != nil {
+		t.Errorf("UPSWatts = %v, want nil", *est.UPSWatts)
+	}
+}
+
+func TestEstimatePrefersUPS(t *testing.T) {
+	tests := []struct {
+		name string
+		ups  *dto.UPSStatus
+		want float64
+	}{
+		{"reported watts", &dto.UPSStatus{Connected: true, PowerWatts: 140, LoadPercent: 50, NominalPower: 900}, 140},
+		{"derived from load", &dto.UPSStatus{Connected: true, LoadPercent: 15, NominalPower: 900}, 135},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est := Estimate(&dto.SystemInfo{CPUPowerWatts: float(20)}, nil, tt.ups, nil)
+			if est.Source != dto.PowerSourceUPS || est.TotalWatts != tt.want {
+				t.Errorf("Source = %q, TotalWatts = %v, want ups %v", est.Source, est.TotalWatts, tt.want)
+			}
+			if est.ComponentWatts != 20 {
+				t.Errorf("ComponentWatts = %v, want 20", est.ComponentWatts)
+			}
+		})
+	}
+
+	est := Estimate(nil, nil, &dto.UPSStatus{Connected: false, PowerWatts: 140}, nil)
+	if est.Source != dto.PowerSourceComponents || est.TotalWatts != 0 {
+		t.Errorf("disconnected UPS used: %+v", est)
+	}
+}
+
+func TestUpdateIntegratesEnergy(t *testing.T) {
+	e := NewEstimator(nil)
+	e.ups = &dto.UPSStatus{Connected: true, PowerWatts: 100}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := e.Update(nil, start)
+	if first.EnergyKWh != 0 || !first.EnergySince.Equal(start) {
+		t.Fatalf("first update = %+v", first)
+	}
+
+	if got := e.Update(nil, start.Add(30*time.Minute)); got.EnergyKWh != 0 {
+		t.Errorf("gap over maxIntegrationGap should be skipped, EnergyKWh = %v", got.EnergyKWh)
+	}
+	for i := 1; i <= 6; i++ {
+		e.Update(nil, start.Add(30*time.Minute+time.Duration(i)*5*time.Minute))
+	}
+	got := e.Update(nil, start.Add(time.Hour+5*time.Minute))
+	// 100 W for the 35 minutes after the skipped gap = 0.0583 kWh.
+	if got.EnergyKWh != 0.058 {
+		t.Errorf("EnergyKWh = %v, want 0.058", got.EnergyKWh)
+	}
+	if !got.EnergySince.Equal(start) {
+		t.Errorf("EnergySince = %v, want %v", got.EnergySince, start)
+	}
+}
+
+func TestStartPublishesOnSystemUpdate(t *testing.T) {
+	hub := domain.NewEventBus(8)
+	out := hub.SubTopics(constants.TopicPowerUpdate)
+	defer hub.Unsub(out, constants.TopicPowerUpdate.Name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e := NewEstimator(hub)
+	go e.Start(ctx)
+
+	// Retry until the estimator's subscription is in place.
+	deadline := time.After(2 * time.Second)
+	for {
+		domain.Publish(hub, constants.TopicSystemUpdate, &dto.SystemInfo{CPUPowerWatts: float(42)})
+		select {
+		case msg := <-out:
+			est, ok := msg.(*dto.PowerEstimate)
+			if !ok || est.TotalWatts != 42 {
+				t.Fatalf("published %+v, want 42 W estimate", msg)
+			}
+			return
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("no power estimate published")
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// actionRefreshCollectors maps words in an audited action to the collectors
+// whose data that action changes. Actions are REST routes
+// ("POST /api/v1/docker/{id}/start"), MCP tool names ("vm_action") or MQTT
+// command paths ("array/start"), so matching on words covers all three.
+var actionRefreshCollectors = map[string][]string{
+	"docker":       {"docker"},
+	"container":    {"docker"},
+	"containers":   {"docker"},
+	"vm":           {"vm"},
+	"vms":          {"vm"},
+	"array":        {"array"},
+	"parity":       {"array"},
+	"recycle":      {"recycle_bin"},
+	"recyclebin":   {"recycle_bin"},
+	"registration": {"registration"},
+}
+
+// collectorsForAction returns the collectors to refresh after action.
+func collectorsForAction(action string) []string {
+	var names []string
+	words := strings.FieldsFunc(strings.ToLower(action), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		for _, name := range actionRefreshCollectors[word] {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// RefreshAfterAction triggers an immediate collection of the categories a
+// successful control action affected, so the API, WebSocket and MQTT reflect
+// the new state without waiting for the next interval. It is registered as an
+// audit log listener, which sees every control action from REST, MCP and MQTT.
+func (cm *CollectorManager) RefreshAfterAction(entry dto.AuditEntry) {
+	if entry.Result != dto.AuditResultSuccess {
+		return
+	}
+	for _, name := range collectorsForAction(entry.Action) {
+		if cm.RequestRefresh(name) {
+			logger.Debug("Refreshing %s collector after %s %s", name, entry.Source, entry.Action)
+		}
+	}
+}
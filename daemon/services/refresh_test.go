@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestCollectorsForAction(t *testing.T) {
+	tests := []struct {
+		action string
+		want   []string
+	}{
+		{"POST /api/v1/docker/{id}/start", []string{"docker"}},
+		{"POST /api/v1/vm/{name}/force-stop", []string{"vm"}},
+		{"POST /api/v1/array/stop", []string{"array"}},
+		{"POST /api/v1/array/parity-check/start", []string{"array"}},
+		{"container_action", []string{"docker"}},
+		{"update_all_containers", []string{"docker"}},
+		{"vm_action", []string{"vm"}},
+		{"docker/restart", []string{"docker"}},
+		{"array/start", []string{"array"}},
+		{"POST /api/v1/registration/key", []string{"registration"}},
+		{"batch stop_container", []string{"docker"}},
+		{"batch_actions force_stop_vm", []string{"vm"}},
+		{"batch spin_down_disk", nil},
+		{"system_reboot", nil},
+		{"POST /api/v1/notifications/archive", nil},
+	}
+	for _, tt := range tests {
+		if got := collectorsForAction(tt.action); !slices.Equal(got, tt.want) {
+			t.Errorf("collectorsForAction(%q) = %v, want %v", tt.action, got, tt.want)
+		}
+	}
+}
+
+// refreshingCollector counts refresh requests while running.
+type refreshingCollector struct {
+	mu       sync.Mutex
+	requests int
+}
+
+func (r *refreshingCollector) Start(ctx context.Context, _ time.Duration) { <-ctx.Done() }
+
+func (r *refreshingCollector) RequestRefresh() {
+	r.mu.Lock()
+	r.requests++
+	r.mu.Unlock()
+}
+
+func (r *refreshingCollector) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+func TestRefreshAfterAction(t *testing.T) {
+	var wg sync.WaitGroup
+	cm := NewCollectorManager(&domain.Context{Hub: domain.NewEventBus(10)}, &wg)
+	docker := &refreshingCollector{}
+	vm := &refreshingCollector{}
+	cm.Register("docker", func(*domain.Context) Collector { return docker }, 10, false)
+	cm.Register("vm", func(*domain.Context) Collector { return vm }, 0, false)
+	cm.Register("array", func(*domain.Context) Collector { return &mockCollector{} }, 10, false)
+	cm.StartAll()
+	defer func() {
+		cm.StopAll()
+		wg.Wait()
+	}()
+
+	cm.RefreshAfterAction(dto.AuditEntry{Action: "container_action", Result: dto.AuditResultSuccess})
+	cm.RefreshAfterAction(dto.AuditEntry{Action: "docker/stop", Result: dto.AuditResultFailure})
+	if got := docker.count(); got != 1 {
+		t.Errorf("docker refresh requests = %d, want 1 (failed actions are ignored)", got)
+	}
+
+	// Stopped collectors and collectors without RequestRefresh are skipped.
+	cm.RefreshAfterAction(dto.AuditEntry{Action: "vm_action", Result: dto.AuditResultSuccess})
+	if vm.count() != 0 {
+		t.Error("disabled vm collector was asked to refresh")
+	}
+	if cm.RequestRefresh("array") {
+		t.Error("RequestRefresh reported success for a collector without refresh support")
+	}
+	if cm.RequestRefresh("unknown") {
+		t.Error("RequestRefresh reported success for an unknown collector")
+	}
+}
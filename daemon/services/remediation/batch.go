@@ -0,0 +1,99 @@
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+const (
+	// MaxBatchActions is the largest number of actions accepted in one batch.
+	MaxBatchActions = 100
+	// DefaultBatchConcurrency is used when a batch does not set a concurrency.
+	DefaultBatchConcurrency = 4
+	// MaxBatchConcurrency caps how many actions run at the same time.
+	MaxBatchConcurrency = 10
+)
+
+// ValidateBatch checks the size and concurrency of a batch request. Individual
+// actions are validated by the executor and fail on their own.
+func ValidateBatch(req dto.BatchRequest) error {
+	if len(req.Actions) == 0 {
+		return fmt.Errorf("actions must not be empty")
+	}
+	if len(req.Actions) > MaxBatchActions {
+		return fmt.Errorf("too many actions: %d (max %d)", len(req.Actions), MaxBatchActions)
+	}
+	if req.Concurrency < 0 || req.Concurrency > MaxBatchConcurrency {
+		return fmt.Errorf("concurrency must be between 0 (default) and %d", MaxBatchConcurrency)
+	}
+	return nil
+}
+
+// RunBatch executes the actions of req through exec with at most
+// req.Concurrency actions in flight (DefaultBatchConcurrency when unset).
+// Actions on the same target run one after another in request order, so a
+// stop followed by a start of one container behaves as expected. A failing
+// action does not stop the others. Results are returned in request order;
+// actions not started before ctx is cancelled report the context error.
+func RunBatch(ctx context.Context, exec *Executor, req dto.BatchRequest) dto.BatchResult {
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	// Group action indexes by target, keeping first-seen order.
+	var order []string
+	byTarget := make(map[string][]int)
+	for i, a := range req.Actions {
+		if _, ok := byTarget[a.Target]; !ok {
+			order = append(order, a.Target)
+		}
+		byTarget[a.Target] = append(byTarget[a.Target], i)
+	}
+
+	start := time.Now()
+	results := make([]dto.ActionResult, len(req.Actions))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, target := range order {
+		wg.Add(1)
+		go func(indexes []int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			for _, i := range indexes {
+				a := req.Actions[i]
+				ar := dto.ActionResult{Action: a.Action, Target: a.Target}
+				if err := ctx.Err(); err != nil {
+					ar.Error = err.Error()
+				} else {
+					ok, dur, err := exec.Execute(ctx, a.Action, a.Target)
+					ar.Succeeded, ar.DurationMs = ok, dur
+					if err != nil {
+						ar.Error = err.Error()
+					}
+				}
+				results[i] = ar
+			}
+		}(byTarget[target])
+	}
+	wg.Wait()
+
+	res := dto.BatchResult{
+		Results:    results,
+		DurationMs: time.Since(start).Milliseconds(),
+		Timestamp:  time.Now(),
+	}
+	for _, r := range results {
+		if r.Succeeded {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+	return res
+}
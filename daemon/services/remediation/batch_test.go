@@ -0,0 +1,120 @@
+package remediation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// slowDockerActor records call order per target and the peak number of
+// concurrent calls.
+type slowDockerActor struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	calls    map[string][]string
+	failOn   string
+}
+
+func (f *slowDockerActor) do(method, id string) error {
+	f.mu.Lock()
+	f.inFlight++
+	f.peak = max(f.peak, f.inFlight)
+	if f.calls == nil {
+		f.calls = map[string][]string{}
+	}
+	f.calls[id] = append(f.calls[id], method)
+	f.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+	if id == f.failOn {
+		return errors.New("boom")
+	}
+	return nil
+}
+
+func (f *slowDockerActor) Start(id string) error   { return f.do("Start", id) }
+func (f *slowDockerActor) Stop(id string) error    { return f.do("Stop", id) }
+func (f *slowDockerActor) Restart(id string) error { return f.do("Restart", id) }
+
+func TestRunBatch(t *testing.T) {
+	ids := []string{"aaaaaaaaaaa1", "aaaaaaaaaaa2", "aaaaaaaaaaa3", "aaaaaaaaaaa4", "aaaaaaaaaaa5"}
+	docker := &slowDockerActor{failOn: ids[2]}
+	exec := NewExecutor(docker, &fakeVMActor{})
+
+	req := dto.BatchRequest{Concurrency: 2}
+	for _, id := range ids {
+		req.Actions = append(req.Actions, dto.ActionRef{Action: "stop_container", Target: id})
+	}
+	req.Actions = append(req.Actions,
+		dto.ActionRef{Action: "start_container", Target: ids[0]},
+		dto.ActionRef{Action: "frobnicate", Target: ids[1]},
+	)
+
+	res := RunBatch(context.Background(), exec, req)
+
+	if len(res.Results) != len(req.Actions) {
+		t.Fatalf("got %d results, want %d", len(res.Results), len(req.Actions))
+	}
+	for i, r := range res.Results {
+		if r.Action != req.Actions[i].Action || r.Target != req.Actions[i].Target {
+			t.Errorf("result %d = %s %s, want request order", i, r.Action, r.Target)
+		}
+	}
+	if res.Succeeded != 5 || res.Failed != 2 {
+		t.Errorf("succeeded=%d failed=%d, want 5 and 2", res.Succeeded, res.Failed)
+	}
+	if res.Results[2].Succeeded || res.Results[2].Error == "" || res.Results[6].Error == "" {
+		t.Errorf("failures not reported: %+v", res.Results)
+	}
+	if docker.peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", docker.peak)
+	}
+	if got := docker.calls[ids[0]]; len(got) != 2 || got[0] != "Stop" || got[1] != "Start" {
+		t.Errorf("calls on %s = %v, want [Stop Start]", ids[0], got)
+	}
+}
+
+func TestRunBatchCancelled(t *testing.T) {
+	docker := &slowDockerActor{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := RunBatch(ctx, NewExecutor(docker, &fakeVMActor{}), dto.BatchRequest{
+		Actions: []dto.ActionRef{{Action: "stop_container", Target: validContainerID}},
+	})
+	if res.Failed != 1 || res.Results[0].Error == "" || len(docker.calls) != 0 {
+		t.Errorf("cancelled batch executed: %+v", res)
+	}
+}
+
+func TestValidateBatch(t *testing.T) {
+	one := []dto.ActionRef{{Action: "stop_container", Target: validContainerID}}
+	tests := []struct {
+		name    string
+		req     dto.BatchRequest
+		wantErr bool
+	}{
+		{"valid", dto.BatchRequest{Actions: one}, false},
+		{"max concurrency", dto.BatchRequest{Actions: one, Concurrency: MaxBatchConcurrency}, false},
+		{"empty", dto.BatchRequest{}, true},
+		{"too many", dto.BatchRequest{Actions: make([]dto.ActionRef, MaxBatchActions+1)}, true},
+		{"negative concurrency", dto.BatchRequest{Actions: one, Concurrency: -1}, true},
+		{"concurrency too high", dto.BatchRequest{Actions: one, Concurrency: MaxBatchConcurrency + 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateBatch(tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -23,10 +23,17 @@ type vmActor interface {
 	ForceStop(name string) error
 }
 
+// diskActor is the subset of ArrayController methods batch actions need.
+type diskActor interface {
+	SpinUpDisk(name string) error
+	SpinDownDisk(name string) error
+}
+
 // Executor maps action strings to controller operations with input validation.
 type Executor struct {
 	docker dockerActor
 	vm     vmActor
+	disk   diskActor
 }
 
 // NewExecutor creates an Executor backed by the provided docker and vm actors.
@@ -34,6 +41,13 @@ func NewExecutor(docker dockerActor, vm vmActor) *Executor {
 	return &Executor{docker: docker, vm: vm}
 }
 
+// WithDisks enables the disk spin actions, backed by the provided actor.
+// Without it those actions fail without touching any disk.
+func (e *Executor) WithDisks(disk diskActor) *Executor {
+	e.disk = disk
+	return e
+}
+
 // Execute validates the target and dispatches the action. It returns whether
 // the action succeeded, how long it took in milliseconds, and any error.
 // Unknown actions and invalid targets return an error WITHOUT calling a controller.
@@ -48,6 +62,13 @@ func (e *Executor) Execute(_ context.Context, action, target string) (ok bool, d
 		if verr := lib.ValidateVMName(target); verr != nil {
 			return false, 0, fmt.Errorf("invalid vm target %q: %w", target, verr)
 		}
+	case "spin_down_disk", "spin_up_disk":
+		if verr := lib.ValidateDiskName(target); verr != nil {
+			return false, 0, fmt.Errorf("invalid disk target %q: %w", target, verr)
+		}
+		if e.disk == nil {
+			return false, 0, fmt.Errorf("disk actions are not available")
+		}
 	default:
 		return false, 0, fmt.Errorf("unknown remediation action: %q", action)
 	}
@@ -69,6 +90,10 @@ func (e *Executor) Execute(_ context.Context, action, target string) (ok bool, d
 		err = e.vm.Start(target)
 	case "force_stop_vm":
 		err = e.vm.ForceStop(target)
+	case "spin_down_disk":
+		err = e.disk.SpinDownDisk(target)
+	case "spin_up_disk":
+		err = e.disk.SpinUpDisk(target)
 	}
 
 	durationMs = time.Since(start).Milliseconds()
@@ -85,5 +110,7 @@ func SupportedActions() []string {
 		"stop_vm",
 		"start_vm",
 		"force_stop_vm",
+		"spin_down_disk",
+		"spin_up_disk",
 	}
 }
@@ -255,6 +255,8 @@ func TestSupportedActions(t *testing.T) {
 		"stop_vm":           true,
 		"start_vm":          true,
 		"force_stop_vm":     true,
+		"spin_down_disk":    true,
+		"spin_up_disk":      true,
 	}
 	if len(actions) != len(want) {
 		t.Errorf("SupportedActions() returned %d actions, want %d", len(actions), len(want))
@@ -265,3 +267,51 @@ func TestSupportedActions(t *testing.T) {
 		}
 	}
 }
+
+// fakeDiskActor records the most recent (method, target) call.
+type fakeDiskActor struct {
+	lastMethod string
+	lastTarget string
+	callCount  int
+}
+
+func (f *fakeDiskActor) SpinUpDisk(name string) error {
+	f.lastMethod, f.lastTarget, f.callCount = "SpinUpDisk", name, f.callCount+1
+	return nil
+}
+
+func (f *fakeDiskActor) SpinDownDisk(name string) error {
+	f.lastMethod, f.lastTarget, f.callCount = "SpinDownDisk", name, f.callCount+1
+	return nil
+}
+
+func TestExecutor_DiskActions(t *testing.T) {
+	// Without WithDisks the disk actions are refused.
+	ok, _, err := NewExecutor(&fakeDockerActor{}, &fakeVMActor{}).Execute(context.Background(), "spin_down_disk", "disk1")
+	if err == nil || ok {
+		t.Fatalf("spin_down_disk without disk actor: ok=%v err=%v, want error", ok, err)
+	}
+
+	disk := &fakeDiskActor{}
+	exec := NewExecutor(&fakeDockerActor{}, &fakeVMActor{}).WithDisks(disk)
+
+	if ok, _, err := exec.Execute(context.Background(), "spin_down_disk", "disk1"); !ok || err != nil {
+		t.Fatalf("spin_down_disk: ok=%v err=%v", ok, err)
+	}
+	if disk.lastMethod != "SpinDownDisk" || disk.lastTarget != "disk1" {
+		t.Errorf("disk call: got %s(%q)", disk.lastMethod, disk.lastTarget)
+	}
+	if ok, _, err := exec.Execute(context.Background(), "spin_up_disk", "parity"); !ok || err != nil {
+		t.Fatalf("spin_up_disk: ok=%v err=%v", ok, err)
+	}
+	if disk.lastMethod != "SpinUpDisk" || disk.lastTarget != "parity" {
+		t.Errorf("disk call: got %s(%q)", disk.lastMethod, disk.lastTarget)
+	}
+
+	if _, _, err := exec.Execute(context.Background(), "spin_up_disk", "../disk1"); err == nil {
+		t.Error("expected error for invalid disk name")
+	}
+	if disk.callCount != 2 {
+		t.Errorf("disk called %d times, want 2", disk.callCount)
+	}
+}
@@ -0,0 +1,353 @@
+// Package scripts stores named scripts registered through the API, with their
+// default arguments and environment, and runs them through the user script
+// execution path. It works without the User Scripts plugin.
+package scripts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+const (
+	// DefaultDir is the default directory for registered scripts. Each script
+	// is a subdirectory holding the script body and its settings.
+	DefaultDir = "/boot/config/plugins/unraid-management-agent/scripts"
+
+	// MaxScripts is the maximum number of registered scripts.
+	MaxScripts = 100
+
+	// MaxContentBytes caps the size of a script body.
+	MaxContentBytes = 64 << 10
+
+	// MaxArgs and MaxEnv cap the arguments and environment variables of a
+	// script or run.
+	MaxArgs = 32
+	MaxEnv  = 32
+
+	// DefaultTimeout and MaxTimeout bound runs that wait for completion.
+	DefaultTimeout = 60 * time.Second
+	MaxTimeout     = time.Hour
+
+	maxArgLength         = 1024
+	maxDescriptionLength = 500
+
+	scriptFile   = "script"
+	settingsFile = "settings.json"
+)
+
+var (
+	// ErrNotFound is returned for unknown script names.
+	ErrNotFound = errors.New("script not found")
+
+	// ErrExists is returned when creating a script whose name is taken.
+	ErrExists = errors.New("script already exists")
+
+	nameRegex   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+	envKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+)
+
+// settings is the on-disk form of everything but the script body.
+type settings struct {
+	Description    string            `json:"description,omitempty"`
+	Args           []string          `json:"args,omitempty"`
+	Env            map[string]string `json:"env,omitempty"`
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// Store manages registered scripts on disk.
+type Store struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewStore creates a script store. If dir is empty, DefaultDir is used.
+func NewStore(dir string) *Store {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	return &Store{dir: dir}
+}
+
+// List returns all registered scripts sorted by name. A missing directory
+// means no scripts.
+func (s *Store) List() ([]dto.Script, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []dto.Script{}, nil
+		}
+		return nil, fmt.Errorf("reading scripts directory: %w", err)
+	}
+
+	scripts := make([]dto.Script, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() || !nameRegex.MatchString(entry.Name()) {
+			continue
+		}
+		script, err := s.read(entry.Name())
+		if err != nil {
+			logger.Debug("Scripts: skipping %s: %v", entry.Name(), err)
+			continue
+		}
+		scripts = append(scripts, *script)
+	}
+	sort.Slice(scripts, func(i, j int) bool { return scripts[i].Name < scripts[j].Name })
+	return scripts, nil
+}
+
+// Get returns the script with the given name.
+func (s *Store) Get(name string) (*dto.Script, error) {
+	if !nameRegex.MatchString(name) {
+		return nil, ErrNotFound
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.read(name)
+}
+
+// Create validates and stores a new script.
+func (s *Store) Create(script dto.Script) error {
+	if err := Validate(script); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := os.Stat(filepath.Join(s.dir, script.Name)); err == nil {
+		return ErrExists
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading scripts directory: %w", err)
+	}
+	if len(entries) >= MaxScripts {
+		return fmt.Errorf("maximum of %d scripts reached", MaxScripts)
+	}
+
+	if err := s.write(script); err != nil {
+		return err
+	}
+	logger.Info("Scripts: Registered script '%s'", script.Name)
+	return nil
+}
+
+// Update validates and replaces an existing script.
+func (s *Store) Update(script dto.Script) error {
+	if err := Validate(script); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := os.Stat(filepath.Join(s.dir, script.Name, scriptFile)); err != nil {
+		return ErrNotFound
+	}
+	if err := s.write(script); err != nil {
+		return err
+	}
+	logger.Info("Scripts: Updated script '%s'", script.Name)
+	return nil
+}
+
+// Delete removes a script.
+func (s *Store) Delete(name string) error {
+	if !nameRegex.MatchString(name) {
+		return ErrNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Join(s.dir, name)
+	if _, err := os.Stat(dir); err != nil {
+		return ErrNotFound
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("deleting script: %w", err)
+	}
+	logger.Info("Scripts: Deleted script '%s'", name)
+	return nil
+}
+
+// Execute runs a registered script. Arguments in req replace the script's
+// default arguments and req.Env is merged over its environment.
+func (s *Store) Execute(name string, req dto.ScriptExecuteRequest) (*dto.UserScriptExecuteResponse, error) {
+	script, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	args := script.Args
+	if req.Args != nil {
+		args = req.Args
+	}
+	env := make(map[string]string, len(script.Env)+len(req.Env))
+	for k, v := range script.Env {
+		env[k] = v
+	}
+	for k, v := range req.Env {
+		env[k] = v
+	}
+	if err := validateArgs(args); err != nil {
+		return nil, err
+	}
+	if err := validateEnv(env); err != nil {
+		return nil, err
+	}
+
+	timeout := DefaultTimeout
+	if script.TimeoutSeconds > 0 {
+		timeout = time.Duration(script.TimeoutSeconds) * time.Second
+	}
+
+	logger.Info("Scripts: Running script '%s' with %d arguments (wait: %v)", name, len(args), req.Wait)
+	return controllers.RunScript(filepath.Join(s.dir, name, scriptFile), name, args, envList(env), req.Wait, timeout)
+}
+
+// Validate checks a script definition.
+func Validate(script dto.Script) error {
+	if !nameRegex.MatchString(script.Name) {
+		return errors.New("name must be 1-64 letters, digits, '.', '_' or '-', starting with a letter or digit")
+	}
+	if strings.TrimSpace(script.Content) == "" {
+		return errors.New("content is required")
+	}
+	if len(script.Content) > MaxContentBytes {
+		return fmt.Errorf("content must be at most %d bytes", MaxContentBytes)
+	}
+	if strings.ContainsRune(script.Content, 0) {
+		return errors.New("content cannot contain null bytes")
+	}
+	if len(script.Description) > maxDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", maxDescriptionLength)
+	}
+	if script.TimeoutSeconds < 0 || time.Duration(script.TimeoutSeconds)*time.Second > MaxTimeout {
+		return fmt.Errorf("timeout_seconds must be between 0 and %d", int(MaxTimeout.Seconds()))
+	}
+	if err := validateArgs(script.Args); err != nil {
+		return err
+	}
+	return validateEnv(script.Env)
+}
+
+func validateArgs(args []string) error {
+	if len(args) > MaxArgs {
+		return fmt.Errorf("at most %d arguments are allowed", MaxArgs)
+	}
+	for _, arg := range args {
+		if len(arg) > maxArgLength {
+			return fmt.Errorf("arguments must be at most %d characters", maxArgLength)
+		}
+		if strings.ContainsRune(arg, 0) {
+			return errors.New("arguments cannot contain null bytes")
+		}
+	}
+	return nil
+}
+
+func validateEnv(env map[string]string) error {
+	if len(env) > MaxEnv {
+		return fmt.Errorf("at most %d environment variables are allowed", MaxEnv)
+	}
+	for k, v := range env {
+		if !envKeyRegex.MatchString(k) {
+			return fmt.Errorf("invalid environment variable name %q", k)
+		}
+		if len(v) > maxArgLength {
+			return fmt.Errorf("environment variable %s must be at most %d characters", k, maxArgLength)
+		}
+		if strings.ContainsRune(v, 0) {
+			return fmt.Errorf("environment variable %s cannot contain null bytes", k)
+		}
+	}
+	return nil
+}
+
+// envList converts env to sorted KEY=VALUE pairs.
+func envList(env map[string]string) []string {
+	list := make([]string, 0, len(env))
+	for k, v := range env {
+		list = append(list, k+"="+v)
+	}
+	slices.Sort(list)
+	return list
+}
+
+// read loads a script from disk. Caller must hold the lock.
+func (s *Store) read(name string) (*dto.Script, error) {
+	dir := filepath.Join(s.dir, name)
+	content, err := os.ReadFile(filepath.Join(dir, scriptFile)) // #nosec G304 -- name is validated against nameRegex
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("reading script: %w", err)
+	}
+
+	var meta settings
+	data, err := os.ReadFile(filepath.Join(dir, settingsFile)) // #nosec G304 -- name is validated against nameRegex
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("parsing script settings: %w", err)
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("reading script settings: %w", err)
+	}
+
+	return &dto.Script{
+		Name:           name,
+		Description:    meta.Description,
+		Content:        string(content),
+		Args:           meta.Args,
+		Env:            meta.Env,
+		TimeoutSeconds: meta.TimeoutSeconds,
+		UpdatedAt:      meta.UpdatedAt,
+	}, nil
+}
+
+// write stores a script body and its settings. Caller must hold the lock.
+func (s *Store) write(script dto.Script) error {
+	dir := filepath.Join(s.dir, script.Name)
+	if err := os.MkdirAll(dir, 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		return fmt.Errorf("creating script directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(settings{
+		Description:    script.Description,
+		Args:           script.Args,
+		Env:            script.Env,
+		TimeoutSeconds: script.TimeoutSeconds,
+		UpdatedAt:      time.Now(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling script settings: %w", err)
+	}
+	// 0600: the environment may hold credentials.
+	if err := os.WriteFile(filepath.Join(dir, settingsFile), data, 0o600); err != nil {
+		return fmt.Errorf("writing script settings: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, scriptFile), []byte(script.Content), 0o600); err != nil {
+		return fmt.Errorf("writing script: %w", err)
+	}
+	return nil
+}
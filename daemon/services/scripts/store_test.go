@@ -0,0 +1,123 @@
+package scripts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestStoreCRUD(t *testing.T) {
+	s := NewStore(t.TempDir())
+
+	list, err := s.List()
+	if err != nil || len(list) != 0 {
+		t.Fatalf("List() on empty store = %v, %v", list, err)
+	}
+
+	script := dto.Script{
+		Name:    "hello",
+		Content: "echo hello \"$@\"",
+		Args:    []string{"world"},
+		Env:     map[string]string{"GREETING": "hi"},
+	}
+	if err := s.Create(script); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Create(script); !errors.Is(err, ErrExists) {
+		t.Errorf("duplicate Create() error = %v, want ErrExists", err)
+	}
+
+	got, err := s.Get("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Content != script.Content || got.Env["GREETING"] != "hi" || len(got.Args) != 1 || got.UpdatedAt.IsZero() {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	script.Description = "Say hello"
+	if err := s.Update(script); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get("hello"); got.Description != "Say hello" {
+		t.Errorf("updated description = %q", got.Description)
+	}
+	if err := s.Update(dto.Script{Name: "missing", Content: "true"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update() of missing script error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.Delete("hello"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("hello"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete("hello"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStoreExecute(t *testing.T) {
+	s := NewStore(t.TempDir())
+	if err := s.Create(dto.Script{
+		Name:    "greet",
+		Content: "echo \"$GREETING $1\"",
+		Args:    []string{"world"},
+		Env:     map[string]string{"GREETING": "hello"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.Execute("greet", dto.ScriptExecuteRequest{Wait: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Success || strings.TrimSpace(resp.Output) != "hello world" {
+		t.Errorf("default run = %+v", resp)
+	}
+
+	resp, err = s.Execute("greet", dto.ScriptExecuteRequest{
+		Args: []string{"there"},
+		Env:  map[string]string{"GREETING": "hi"},
+		Wait: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(resp.Output) != "hi there" {
+		t.Errorf("override run output = %q", resp.Output)
+	}
+
+	if _, err := s.Execute("greet", dto.ScriptExecuteRequest{Env: map[string]string{"BAD-KEY": "x"}}); err == nil {
+		t.Error("Execute() accepted an invalid environment variable name")
+	}
+	if _, err := s.Execute("nope", dto.ScriptExecuteRequest{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Execute() of missing script error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  dto.Script
+		wantErr bool
+	}{
+		{"valid", dto.Script{Name: "backup.v2", Content: "true"}, false},
+		{"bad name", dto.Script{Name: "../etc", Content: "true"}, true},
+		{"leading dot", dto.Script{Name: ".hidden", Content: "true"}, true},
+		{"empty content", dto.Script{Name: "a", Content: "  "}, true},
+		{"content too large", dto.Script{Name: "a", Content: strings.Repeat("x", MaxContentBytes+1)}, true},
+		{"timeout too long", dto.Script{Name: "a", Content: "true", TimeoutSeconds: 3601}, true},
+		{"too many args", dto.Script{Name: "a", Content: "true", Args: make([]string, MaxArgs+1)}, true},
+		{"bad env key", dto.Script{Name: "a", Content: "true", Env: map[string]string{"1X": "y"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.script); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// progress is one parsed progress report from a tool.
+type progress struct {
+	bytes, total, files uint64
+	percent, speed      float64
+	eta                 int64
+}
+
+// commandArgs returns the arguments for running job, including the options
+// that make the tool report machine-readable progress.
+func commandArgs(job dto.TransferJob) []string {
+	var args []string
+	switch job.Tool {
+	case dto.TransferToolRclone:
+		args = append(args, job.Operation)
+		args = append(args, job.Flags...)
+		args = append(args, "--use-json-log", "--stats=1s", "--stats-log-level=NOTICE")
+	default:
+		args = append(args, job.Flags...)
+		args = append(args, "--info=progress2")
+	}
+	return append(args, job.Source, job.Destination)
+}
+
+// parseProgress parses a line of tool output, reporting false when the line
+// is not a progress report.
+func parseProgress(tool dto.TransferTool, line string) (progress, bool) {
+	if tool == dto.TransferToolRclone {
+		return parseRcloneStats(line)
+	}
+	return parseRsyncProgress(line)
+}
+
+// parseRsyncProgress parses an rsync --info=progress2 line such as
+//
+//	1,234,567  45%   12.34MB/s    0:01:23 (xfr#5, to-chk=10/20)
+func parseRsyncProgress(line string) (progress, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || !strings.HasSuffix(fields[1], "%") || !strings.HasSuffix(fields[2], "/s") {
+		return progress{}, false
+	}
+	b, err := strconv.ParseUint(strings.ReplaceAll(fields[0], ",", ""), 10, 64)
+	if err != nil {
+		return progress{}, false
+	}
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+	if err != nil {
+		return progress{}, false
+	}
+
+	p := progress{bytes: b, percent: pct, speed: parseRsyncRate(fields[2]), eta: parseClock(fields[3])}
+	if pct > 0 {
+		p.total = uint64(float64(b) * 100 / pct)
+	}
+	if len(fields) > 4 {
+		if xfr, ok := strings.CutPrefix(fields[4], "(xfr#"); ok {
+			p.files, _ = strconv.ParseUint(strings.TrimSuffix(xfr, ","), 10, 64)
+		}
+	}
+	return p, true
+}
+
+// parseRsyncRate converts an rsync rate such as "12.34MB/s" to bytes per
+// second. rsync uses binary multiples for kB, MB and GB.
+func parseRsyncRate(s string) float64 {
+	s = strings.TrimSuffix(s, "/s")
+	mult := 1.0
+	for _, unit := range []struct {
+		suffix string
+		mult   float64
+	}{{"kB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"TB", 1 << 40}, {"B", 1}} {
+		if v, ok := strings.CutSuffix(s, unit.suffix); ok {
+			s, mult = v, unit.mult
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v * mult
+}
+
+// parseClock converts "h:mm:ss" to seconds.
+func parseClock(s string) int64 {
+	var total int64
+	for part := range strings.SplitSeq(s, ":") {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return 0
+		}
+		total = total*60 + n
+	}
+	return total
+}
+
+// rcloneLogLine is the subset of an rclone --use-json-log line we use.
+type rcloneLogLine struct {
+	Stats *struct {
+		Bytes      uint64   `json:"bytes"`
+		TotalBytes uint64   `json:"totalBytes"`
+		Speed      float64  `json:"speed"`
+		ETA        *float64 `json:"eta"`
+		Transfers  uint64   `json:"transfers"`
+	} `json:"stats"`
+}
+
+// parseRcloneStats parses the stats of an rclone --use-json-log line.
+func parseRcloneStats(line string) (progress, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return progress{}, false
+	}
+	var entry rcloneLogLine
+	if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Stats == nil {
+		return progress{}, false
+	}
+	st := entry.Stats
+	p := progress{bytes: st.Bytes, total: st.TotalBytes, speed: st.Speed, files: st.Transfers}
+	if st.TotalBytes > 0 {
+		p.percent = float64(int(float64(st.Bytes)/float64(st.TotalBytes)*1000)) / 10
+	}
+	if st.ETA != nil {
+		p.eta = int64(*st.ETA)
+	}
+	return p, true
+}
+
+// rcloneMessage returns the msg field of an rclone JSON log line, or the line
+// itself when it is not JSON.
+func rcloneMessage(line string) string {
+	var entry struct {
+		Msg string `json:"msg"`
+	}
+	if err := json.Unmarshal([]byte(line), &entry); err == nil && entry.Msg != "" {
+		return entry.Msg
+	}
+	return line
+}
+
+// scanLinesOrCR is a bufio.SplitFunc that splits on "\n" and on the "\r"
+// rsync uses to redraw its progress line.
+func scanLinesOrCR(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,74 @@
+package transfer
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func TestParseRsyncProgress(t *testing.T) {
+	p, ok := parseRsyncProgress("  1,073,741,824  25%   12.50MB/s    0:01:23 (xfr#5, to-chk=10/20)")
+	if !ok {
+		t.Fatal("expected a progress line")
+	}
+	if p.bytes != 1<<30 || p.percent != 25 || p.total != 4<<30 || p.files != 5 || p.eta != 83 {
+		t.Errorf("progress = %+v", p)
+	}
+	if p.speed != 12.5*(1<<20) {
+		t.Errorf("speed = %v", p.speed)
+	}
+
+	for _, line := range []string{"sending incremental file list", "appdata/plex/db", "sent 1,234 bytes  received 56 bytes"} {
+		if _, ok := parseRsyncProgress(line); ok {
+			t.Errorf("%q parsed as progress", line)
+		}
+	}
+}
+
+func TestParseRcloneStats(t *testing.T) {
+	line := `{"level":"notice","msg":"1 GiB / 4 GiB, 25%","stats":{"bytes":1073741824,"totalBytes":4294967296,"speed":52428800,"eta":61,"transfers":3},"time":"2026-10-17T03:00:10+10:00"}`
+	p, ok := parseRcloneStats(line)
+	if !ok {
+		t.Fatal("expected stats")
+	}
+	if p.bytes != 1<<30 || p.total != 4<<30 || p.percent != 25 || p.speed != 52428800 || p.eta != 61 || p.files != 3 {
+		t.Errorf("progress = %+v", p)
+	}
+
+	msg := `{"level":"error","msg":"Failed to copy: permission denied","time":"2026-10-17T03:00:10+10:00"}`
+	if _, ok := parseRcloneStats(msg); ok {
+		t.Error("log line without stats parsed as progress")
+	}
+	if got := rcloneMessage(msg); got != "Failed to copy: permission denied" {
+		t.Errorf("rcloneMessage = %q", got)
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	job := testJob()
+	want := []string{"-a", "--delete", "--info=progress2", "/mnt/user/appdata/", "/mnt/user/backups/appdata/"}
+	if got := commandArgs(job); !slices.Equal(got, want) {
+		t.Errorf("rsync args = %q, want %q", got, want)
+	}
+
+	job.Tool, job.Operation, job.Flags, job.Destination = dto.TransferToolRclone, "sync", nil, "b2:backups"
+	want = []string{"sync", "--use-json-log", "--stats=1s", "--stats-log-level=NOTICE", "/mnt/user/appdata/", "b2:backups"}
+	if got := commandArgs(job); !slices.Equal(got, want) {
+		t.Errorf("rclone args = %q, want %q", got, want)
+	}
+}
+
+func TestScanLinesOrCR(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("a\r b\r\nc\nd"))
+	scanner.Split(scanLinesOrCR)
+	var got []string
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+	if want := []string{"a", " b", "", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("tokens = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,402 @@
+package transfer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// MaxHistoryRuns is the number of finished runs kept in memory.
+	MaxHistoryRuns = 50
+
+	// TickInterval is how often the runner checks for scheduled jobs.
+	TickInterval = 30 * time.Second
+
+	// progressPublishInterval throttles progress events per run.
+	progressPublishInterval = time.Second
+
+	// outputTailLines is the number of non-progress output lines kept per run.
+	outputTailLines = 20
+
+	// TriggerManual and TriggerSchedule record what started a run.
+	TriggerManual   = "manual"
+	TriggerSchedule = "schedule"
+)
+
+var (
+	// ErrJobRunning is returned when a job is started while it is running.
+	ErrJobRunning = errors.New("transfer job is already running")
+
+	// ErrJobNotRunning is returned when cancelling a job that is not running.
+	ErrJobNotRunning = errors.New("transfer job is not running")
+
+	// ErrToolNotInstalled is returned when rsync or rclone is not on PATH.
+	ErrToolNotInstalled = errors.New("transfer tool is not installed")
+)
+
+// Package-level variables so tests can substitute the tools.
+var (
+	execCommand   = exec.CommandContext
+	commandExists = lib.CommandExists
+)
+
+// activeRun is a running transfer and the means to cancel it.
+type activeRun struct {
+	run         dto.TransferRun
+	cancel      context.CancelFunc
+	lastPublish time.Time
+}
+
+// Runner runs transfer jobs, on demand and on their schedules, publishing
+// progress on constants.TopicTransferProgress.
+type Runner struct {
+	store *Store
+	hub   *domain.EventBus
+
+	mu            sync.Mutex
+	active        map[string]*activeRun // by job ID
+	history       []dto.TransferRun     // finished runs, oldest first
+	lastScheduled map[string]time.Time  // interval schedules, by job ID
+	lastTick      time.Time
+}
+
+// NewRunner creates a new transfer job runner.
+func NewRunner(store *Store) *Runner {
+	return &Runner{
+		store:         store,
+		active:        make(map[string]*activeRun),
+		lastScheduled: make(map[string]time.Time),
+	}
+}
+
+// SetEventBus sets the event bus progress is published on.
+func (r *Runner) SetEventBus(hub *domain.EventBus) { r.hub = hub }
+
+// Store returns the job store.
+func (r *Runner) Store() *Store { return r.store }
+
+// Start loads the jobs and runs scheduled jobs until ctx is cancelled.
+// Running transfers, however they were started, are stopped when ctx is
+// cancelled.
+func (r *Runner) Start(ctx context.Context) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.LogPanicWithStack("Transfer runner (top-level)", rec)
+		}
+	}()
+
+	if err := r.store.Load(); err != nil {
+		logger.Error("Transfers: Failed to load transfer jobs: %v", err)
+	}
+
+	r.mu.Lock()
+	r.lastTick = time.Now()
+	r.mu.Unlock()
+
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.cancelAll()
+			logger.Info("Transfer runner stopped")
+			return
+		case now := <-ticker.C:
+			func() {
+				defer func() {
+					if rec := recover(); rec != nil {
+						logger.LogPanicWithStack("Transfer runner", rec)
+					}
+				}()
+				r.tick(ctx, now)
+			}()
+		}
+	}
+}
+
+// tick starts the enabled jobs whose schedule is due.
+func (r *Runner) tick(ctx context.Context, now time.Time) {
+	for _, job := range r.store.GetJobs() {
+		if !job.Enabled || !r.due(job, now) {
+			continue
+		}
+		if _, err := r.Run(ctx, job.ID, TriggerSchedule); err != nil {
+			logger.Warning("Transfers: Scheduled run of '%s' not started: %v", job.ID, err)
+		}
+	}
+
+	r.mu.Lock()
+	r.lastTick = now
+	r.mu.Unlock()
+}
+
+// due reports whether job's schedule fires at now. Interval schedules count
+// from the previous scheduled start, or from when the runner first saw the
+// job; daily schedules fire when their time of day passed since the last tick.
+func (r *Runner) due(job dto.TransferJob, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case job.ScheduleIntervalMinutes > 0:
+		last, ok := r.lastScheduled[job.ID]
+		if !ok {
+			r.lastScheduled[job.ID] = now
+			return false
+		}
+		if now.Sub(last) < time.Duration(job.ScheduleIntervalMinutes)*time.Minute {
+			return false
+		}
+		r.lastScheduled[job.ID] = now
+		return true
+	case job.ScheduleDailyAt != "":
+		t, err := time.Parse("15:04", job.ScheduleDailyAt)
+		if err != nil || r.lastTick.IsZero() {
+			return false
+		}
+		at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		return r.lastTick.Before(at) && !now.Before(at)
+	}
+	return false
+}
+
+// Run starts job id in the background and returns its initial state. The run
+// stops when ctx is cancelled.
+func (r *Runner) Run(ctx context.Context, id, trigger string) (dto.TransferRun, error) {
+	job, err := r.store.GetJob(id)
+	if err != nil {
+		return dto.TransferRun{}, err
+	}
+	if !commandExists(string(job.Tool)) {
+		return dto.TransferRun{}, fmt.Errorf("%w: %s", ErrToolNotInstalled, job.Tool)
+	}
+
+	r.mu.Lock()
+	if _, running := r.active[job.ID]; running {
+		r.mu.Unlock()
+		return dto.TransferRun{}, fmt.Errorf("%w: %s", ErrJobRunning, job.ID)
+	}
+	now := time.Now()
+	runCtx, cancel := context.WithCancel(ctx)
+	ar := &activeRun{
+		run: dto.TransferRun{
+			ID:        fmt.Sprintf("%s-%d", job.ID, now.Unix()),
+			JobID:     job.ID,
+			JobName:   job.Name,
+			Tool:      job.Tool,
+			Trigger:   trigger,
+			State:     dto.TransferRunRunning,
+			StartedAt: now,
+			UpdatedAt: now,
+		},
+		cancel:      cancel,
+		lastPublish: now,
+	}
+	r.active[job.ID] = ar
+	run := ar.run
+	r.mu.Unlock()
+
+	logger.Info("Transfers: Starting %s job '%s' (%s)", job.Tool, job.ID, trigger)
+	r.publish(run)
+	go r.execute(runCtx, *job, ar)
+	return run, nil
+}
+
+// Cancel stops the running transfer of job id.
+func (r *Runner) Cancel(id string) error {
+	r.mu.Lock()
+	ar, ok := r.active[id]
+	r.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrJobNotRunning, id)
+	}
+	logger.Info("Transfers: Cancelling job '%s'", id)
+	ar.cancel()
+	return nil
+}
+
+// cancelAll stops every running transfer.
+func (r *Runner) cancelAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ar := range r.active {
+		ar.cancel()
+	}
+}
+
+// Running reports whether job id has a running transfer.
+func (r *Runner) Running(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.active[id]
+	return ok
+}
+
+// Runs returns the running transfers followed by finished runs, newest first.
+func (r *Runner) Runs() []dto.TransferRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	runs := make([]dto.TransferRun, 0, len(r.active)+len(r.history))
+	for _, ar := range r.active {
+		runs = append(runs, cloneRun(ar.run))
+	}
+	slices.SortFunc(runs, func(a, b dto.TransferRun) int { return b.StartedAt.Compare(a.StartedAt) })
+	for i := len(r.history) - 1; i >= 0; i-- {
+		runs = append(runs, cloneRun(r.history[i]))
+	}
+	return runs
+}
+
+// execute runs the tool for ar and records its progress and outcome.
+func (r *Runner) execute(ctx context.Context, job dto.TransferJob, ar *activeRun) {
+	defer ar.cancel()
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.LogPanicWithStack("Transfer job "+job.ID, rec)
+		}
+	}()
+
+	cmd := execCommand(ctx, string(job.Tool), commandArgs(job)...) // #nosec G204 -- tool is rsync or rclone; arguments are validated and passed without a shell
+	cmd.WaitDelay = 10 * time.Second
+	pr, pw := io.Pipe()
+	cmd.Stdout, cmd.Stderr = pw, pw
+
+	if err := cmd.Start(); err != nil {
+		_ = pw.Close()
+		r.finish(ctx, ar, err)
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.readOutput(job.Tool, ar, pr)
+	}()
+
+	err := cmd.Wait()
+	_ = pw.Close()
+	<-done
+	r.finish(ctx, ar, err)
+}
+
+// readOutput parses tool output into progress updates and an output tail.
+func (r *Runner) readOutput(tool dto.TransferTool, ar *activeRun, out io.Reader) {
+	scanner := bufio.NewScanner(out)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	scanner.Split(scanLinesOrCR)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if p, ok := parseProgress(tool, line); ok {
+			r.updateProgress(ar, p)
+			continue
+		}
+		if tool == dto.TransferToolRclone {
+			line = rcloneMessage(line)
+		}
+		r.mu.Lock()
+		ar.run.Output = append(ar.run.Output, line)
+		if len(ar.run.Output) > outputTailLines {
+			ar.run.Output = slices.Delete(ar.run.Output, 0, len(ar.run.Output)-outputTailLines)
+		}
+		r.mu.Unlock()
+	}
+	// Keep draining so the tool never blocks on a full pipe.
+	_, _ = io.Copy(io.Discard, out)
+}
+
+// updateProgress records p on ar, publishing at most once per second.
+func (r *Runner) updateProgress(ar *activeRun, p progress) {
+	now := time.Now()
+	r.mu.Lock()
+	run := &ar.run
+	run.BytesTransferred = p.bytes
+	run.TotalBytes = p.total
+	run.Percent = p.percent
+	run.SpeedBytesPerSec = p.speed
+	run.ETASeconds = p.eta
+	run.FilesTransferred = p.files
+	run.UpdatedAt = now
+	publish := now.Sub(ar.lastPublish) >= progressPublishInterval
+	if publish {
+		ar.lastPublish = now
+	}
+	snapshot := cloneRun(*run)
+	r.mu.Unlock()
+
+	if publish {
+		r.publish(snapshot)
+	}
+}
+
+// finish records the outcome of ar and moves it to the history.
+func (r *Runner) finish(ctx context.Context, ar *activeRun, err error) {
+	now := time.Now()
+	r.mu.Lock()
+	run := &ar.run
+	run.UpdatedAt = now
+	run.FinishedAt = &now
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		run.State = dto.TransferRunCancelled
+	case err != nil:
+		run.State = dto.TransferRunFailed
+		run.Error = err.Error()
+		if errors.As(err, &exitErr) {
+			run.ExitCode = exitErr.ExitCode()
+		}
+	default:
+		run.State = dto.TransferRunSucceeded
+		if run.TotalBytes > 0 || run.BytesTransferred > 0 {
+			run.Percent = 100
+		}
+		run.ETASeconds = 0
+	}
+	delete(r.active, run.JobID)
+	r.history = append(r.history, cloneRun(*run))
+	if len(r.history) > MaxHistoryRuns {
+		r.history = slices.Delete(r.history, 0, len(r.history)-MaxHistoryRuns)
+	}
+	snapshot := cloneRun(*run)
+	r.mu.Unlock()
+
+	if snapshot.State == dto.TransferRunFailed {
+		logger.Warning("Transfers: Job '%s' failed: %s", snapshot.JobID, snapshot.Error)
+	} else {
+		logger.Info("Transfers: Job '%s' %s (%d bytes)", snapshot.JobID, snapshot.State, snapshot.BytesTransferred)
+	}
+	r.publish(snapshot)
+}
+
+// publish emits run on the event bus (no-op without a hub).
+func (r *Runner) publish(run dto.TransferRun) {
+	if r.hub == nil {
+		return
+	}
+	domain.Publish(r.hub, constants.TopicTransferProgress, run)
+}
+
+// cloneRun copies run so callers cannot share its output slice.
+func cloneRun(run dto.TransferRun) dto.TransferRun {
+	run.Output = slices.Clone(run.Output)
+	return run
+}
@@ -0,0 +1,165 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// fakeTool makes the runner execute script with sh instead of the real tool.
+func fakeTool(t *testing.T, script string) {
+	t.Helper()
+	origExec, origExists := execCommand, commandExists
+	t.Cleanup(func() { execCommand, commandExists = origExec, origExists })
+	commandExists = func(string) bool { return true }
+	execCommand = func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "sh", "-c", script)
+	}
+}
+
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	store := NewStore(t.TempDir())
+	if err := store.CreateJob(testJob()); err != nil {
+		t.Fatal(err)
+	}
+	return NewRunner(store)
+}
+
+func waitFinished(t *testing.T, r *Runner) dto.TransferRun {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if runs := r.Runs(); len(runs) > 0 && runs[0].State != dto.TransferRunRunning {
+			return runs[0]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("transfer did not finish")
+	return dto.TransferRun{}
+}
+
+func TestRunnerRunSucceeded(t *testing.T) {
+	fakeTool(t, `printf 'sending incremental file list\n'; printf '  1,024  50%%  1.00kB/s  0:00:01 (xfr#1, to-chk=1/2)\r'; printf '  2,048 100%%  2.00kB/s  0:00:02 (xfr#2, to-chk=0/2)\n'`)
+	r := newTestRunner(t)
+
+	run, err := r.Run(t.Context(), "appdata-backup", TriggerManual)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if run.State != dto.TransferRunRunning || run.JobName != "Appdata backup" {
+		t.Errorf("initial run = %+v", run)
+	}
+
+	got := waitFinished(t, r)
+	if got.State != dto.TransferRunSucceeded || got.BytesTransferred != 2048 || got.FilesTransferred != 2 || got.Percent != 100 {
+		t.Errorf("finished run = %+v", got)
+	}
+	if len(got.Output) != 1 || got.Output[0] != "sending incremental file list" {
+		t.Errorf("Output = %q", got.Output)
+	}
+	if got.FinishedAt == nil {
+		t.Error("FinishedAt not set")
+	}
+}
+
+func TestRunnerRunFailed(t *testing.T) {
+	fakeTool(t, `echo 'rsync: change_dir "/missing" failed' >&2; exit 23`)
+	r := newTestRunner(t)
+
+	if _, err := r.Run(t.Context(), "appdata-backup", TriggerManual); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	got := waitFinished(t, r)
+	if got.State != dto.TransferRunFailed || got.ExitCode != 23 || len(got.Output) != 1 {
+		t.Errorf("finished run = %+v", got)
+	}
+}
+
+func TestRunnerCancel(t *testing.T) {
+	fakeTool(t, `exec sleep 30`)
+	r := newTestRunner(t)
+
+	if _, err := r.Run(t.Context(), "appdata-backup", TriggerManual); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if _, err := r.Run(t.Context(), "appdata-backup", TriggerManual); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("second Run: err = %v, want ErrJobRunning", err)
+	}
+	if err := r.Cancel("appdata-backup"); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if got := waitFinished(t, r); got.State != dto.TransferRunCancelled {
+		t.Errorf("state = %s, want cancelled", got.State)
+	}
+	if err := r.Cancel("appdata-backup"); !errors.Is(err, ErrJobNotRunning) {
+		t.Errorf("Cancel idle job: err = %v, want ErrJobNotRunning", err)
+	}
+}
+
+func TestRunnerStopCancelsRuns(t *testing.T) {
+	fakeTool(t, `exec sleep 30`)
+	r := newTestRunner(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		r.Start(ctx)
+		close(done)
+	}()
+	if _, err := r.Run(context.Background(), "appdata-backup", TriggerManual); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	cancel()
+	<-done
+	if got := waitFinished(t, r); got.State != dto.TransferRunCancelled {
+		t.Errorf("state = %s, want cancelled", got.State)
+	}
+}
+
+func TestRunnerRunErrors(t *testing.T) {
+	r := newTestRunner(t)
+	if _, err := r.Run(t.Context(), "missing", TriggerManual); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("unknown job: err = %v, want ErrJobNotFound", err)
+	}
+
+	origExists := commandExists
+	t.Cleanup(func() { commandExists = origExists })
+	commandExists = func(string) bool { return false }
+	if _, err := r.Run(t.Context(), "appdata-backup", TriggerManual); !errors.Is(err, ErrToolNotInstalled) {
+		t.Errorf("missing tool: err = %v, want ErrToolNotInstalled", err)
+	}
+}
+
+func TestRunnerDue(t *testing.T) {
+	r := NewRunner(NewStore(t.TempDir()))
+	start := time.Date(2026, 10, 17, 2, 59, 40, 0, time.Local)
+	r.lastTick = start
+
+	daily := dto.TransferJob{ID: "daily", ScheduleDailyAt: "03:00"}
+	if r.due(daily, start.Add(10*time.Second)) {
+		t.Error("daily job due before its time")
+	}
+	if !r.due(daily, start.Add(30*time.Second)) {
+		t.Error("daily job not due once its time passed")
+	}
+	r.lastTick = start.Add(30 * time.Second)
+	if r.due(daily, start.Add(60*time.Second)) {
+		t.Error("daily job due twice")
+	}
+
+	interval := dto.TransferJob{ID: "hourly", ScheduleIntervalMinutes: 60}
+	if r.due(interval, start) {
+		t.Error("interval job due when first seen")
+	}
+	if r.due(interval, start.Add(59*time.Minute)) {
+		t.Error("interval job due early")
+	}
+	if !r.due(interval, start.Add(60*time.Minute)) {
+		t.Error("interval job not due after its interval")
+	}
+}
@@ -0,0 +1,294 @@
+// Package transfer runs user-defined rsync and rclone jobs as supervised
+// processes, reporting live progress and running them on a schedule.
+package transfer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+const (
+	// DefaultConfigDir matches the watchdog/alert stores.
+	DefaultConfigDir = "/boot/config/plugins/unraid-management-agent"
+
+	// TransferJobsConfigFile is the filename for transfer job configuration.
+	TransferJobsConfigFile = "transfer_jobs.json"
+
+	// MaxJobs is the maximum number of transfer jobs allowed.
+	MaxJobs = 50
+
+	// MinScheduleIntervalMinutes is the shortest allowed schedule interval.
+	MinScheduleIntervalMinutes = 5
+)
+
+// ErrJobNotFound is returned for an unknown job ID.
+var ErrJobNotFound = errors.New("transfer job not found")
+
+var jobIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// blockedFlags are options that make the tool execute other programs or
+// that conflict with the progress reporting the runner adds itself.
+var blockedFlags = map[dto.TransferTool][]string{
+	dto.TransferToolRsync: {
+		"-e", "--rsh", "--rsync-path", "--daemon", "--config",
+		"--info", "--log-file", "--write-batch", "--only-write-batch", "--read-batch",
+	},
+	dto.TransferToolRclone: {
+		"--password-command", "--rc", "--rcd", "--log-file", "--use-json-log",
+		"--stats", "--stats-one-line", "--stats-log-level", "--progress", "-P",
+	},
+}
+
+// Store manages persistent storage of transfer jobs in a JSON file.
+type Store struct {
+	mu       sync.RWMutex
+	jobs     []dto.TransferJob
+	filePath string
+}
+
+// NewStore creates a new transfer job store. If configDir is empty,
+// DefaultConfigDir is used.
+func NewStore(configDir string) *Store {
+	if configDir == "" {
+		configDir = DefaultConfigDir
+	}
+	return &Store{
+		filePath: filepath.Join(configDir, TransferJobsConfigFile),
+		jobs:     make([]dto.TransferJob, 0),
+	}
+}
+
+// Load reads transfer jobs from the JSON config file. A missing file starts
+// with an empty set.
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Info("Transfer job config not found, starting with empty set")
+			return nil
+		}
+		return fmt.Errorf("reading transfer job config: %w", err)
+	}
+
+	var config dto.TransferJobsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("parsing transfer job config: %w", err)
+	}
+
+	s.jobs = config.Jobs
+	if s.jobs == nil {
+		s.jobs = make([]dto.TransferJob, 0)
+	}
+
+	logger.Info("Loaded %d transfer jobs from %s", len(s.jobs), s.filePath)
+	return nil
+}
+
+// save writes the current jobs to the JSON config file. Caller must hold the write lock.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(dto.TransferJobsConfig{Jobs: s.jobs}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling transfer job config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0o750); err != nil { //nolint:gosec // G301: Plugin config directory
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0o600); err != nil { //nolint:gosec // G306: Plugin config file
+		return fmt.Errorf("writing transfer job config: %w", err)
+	}
+	return nil
+}
+
+// GetJobs returns a copy of all transfer jobs.
+func (s *Store) GetJobs() []dto.TransferJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]dto.TransferJob, len(s.jobs))
+	copy(result, s.jobs)
+	return result
+}
+
+// GetJob returns a transfer job by ID.
+func (s *Store) GetJob(id string) (*dto.TransferJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := range s.jobs {
+		if s.jobs[i].ID == id {
+			job := s.jobs[i]
+			return &job, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+}
+
+// CreateJob validates and adds a new transfer job, persisting it to disk.
+func (s *Store) CreateJob(job dto.TransferJob) error {
+	job = normalizeJob(job)
+	if err := ValidateJob(job); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.jobs) >= MaxJobs {
+		return fmt.Errorf("maximum of %d transfer jobs reached", MaxJobs)
+	}
+	for _, existing := range s.jobs {
+		if existing.ID == job.ID {
+			return fmt.Errorf("transfer job with ID '%s' already exists", job.ID)
+		}
+	}
+
+	s.jobs = append(s.jobs, job)
+	if err := s.save(); err != nil {
+		s.jobs = s.jobs[:len(s.jobs)-1]
+		return fmt.Errorf("saving after create: %w", err)
+	}
+
+	logger.Info("Created transfer job '%s' (%s)", job.ID, job.Tool)
+	return nil
+}
+
+// UpdateJob validates and replaces an existing transfer job, persisting it to disk.
+func (s *Store) UpdateJob(job dto.TransferJob) error {
+	job = normalizeJob(job)
+	if err := ValidateJob(job); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.jobs {
+		if s.jobs[i].ID == job.ID {
+			old := s.jobs[i]
+			s.jobs[i] = job
+			if err := s.save(); err != nil {
+				s.jobs[i] = old
+				return fmt.Errorf("saving after update: %w", err)
+			}
+			logger.Info("Updated transfer job '%s'", job.ID)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrJobNotFound, job.ID)
+}
+
+// DeleteJob removes a transfer job by ID, persisting the change to disk.
+func (s *Store) DeleteJob(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.jobs {
+		if s.jobs[i].ID == id {
+			old := slices.Clone(s.jobs)
+			s.jobs = slices.Delete(s.jobs, i, i+1)
+			if err := s.save(); err != nil {
+				s.jobs = old
+				return fmt.Errorf("saving after delete: %w", err)
+			}
+			logger.Info("Deleted transfer job '%s'", id)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrJobNotFound, id)
+}
+
+// normalizeJob applies defaults.
+func normalizeJob(job dto.TransferJob) dto.TransferJob {
+	job.Operation = strings.ToLower(strings.TrimSpace(job.Operation))
+	if job.Tool == dto.TransferToolRclone && job.Operation == "" {
+		job.Operation = "copy"
+	}
+	if job.Tool == dto.TransferToolRsync {
+		job.Operation = ""
+	}
+	return job
+}
+
+// ValidateJob checks a transfer job definition. Source, destination and flags
+// are passed to the tool as separate arguments without a shell, so the checks
+// only guard against option injection and options that run other programs.
+func ValidateJob(job dto.TransferJob) error {
+	if !jobIDPattern.MatchString(job.ID) {
+		return fmt.Errorf("invalid id %q: use 1-64 lowercase letters, digits, '-' or '_'", job.ID)
+	}
+	if strings.TrimSpace(job.Name) == "" {
+		return errors.New("name is required")
+	}
+	switch job.Tool {
+	case dto.TransferToolRsync:
+	case dto.TransferToolRclone:
+		if !slices.Contains([]string{"copy", "sync", "move"}, job.Operation) {
+			return fmt.Errorf("invalid rclone operation %q: must be copy, sync or move", job.Operation)
+		}
+	default:
+		return fmt.Errorf("invalid tool %q: must be rsync or rclone", job.Tool)
+	}
+
+	for _, f := range []struct{ field, value string }{{"source", job.Source}, {"destination", job.Destination}} {
+		field, value := f.field, f.value
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("%s is required", field)
+		}
+		if strings.HasPrefix(value, "-") || strings.ContainsAny(value, "\x00\n\r") {
+			return fmt.Errorf("invalid %s %q", field, value)
+		}
+	}
+
+	for _, flag := range job.Flags {
+		if err := validateFlag(job.Tool, flag); err != nil {
+			return err
+		}
+	}
+
+	if job.ScheduleIntervalMinutes < 0 || (job.ScheduleIntervalMinutes > 0 && job.ScheduleIntervalMinutes < MinScheduleIntervalMinutes) {
+		return fmt.Errorf("schedule_interval_minutes must be 0 or at least %d", MinScheduleIntervalMinutes)
+	}
+	if job.ScheduleDailyAt != "" {
+		if job.ScheduleIntervalMinutes > 0 {
+			return errors.New("set either schedule_interval_minutes or schedule_daily_at, not both")
+		}
+		if _, err := time.Parse("15:04", job.ScheduleDailyAt); err != nil {
+			return fmt.Errorf("invalid schedule_daily_at %q: use HH:MM", job.ScheduleDailyAt)
+		}
+	}
+	return nil
+}
+
+// validateFlag rejects flags that are not options, and options that make the
+// tool run other programs or interfere with progress reporting.
+func validateFlag(tool dto.TransferTool, flag string) error {
+	if !strings.HasPrefix(flag, "-") || flag == "-" || flag == "--" || strings.ContainsAny(flag, "\x00\n\r") {
+		return fmt.Errorf("invalid flag %q: flags must be options starting with '-'", flag)
+	}
+	name, _, _ := strings.Cut(flag, "=")
+	if slices.Contains(blockedFlags[tool], name) {
+		return fmt.Errorf("flag %q is not allowed", name)
+	}
+	// rsync short option clusters such as -avze take the shell from the next
+	// argument.
+	if tool == dto.TransferToolRsync && !strings.HasPrefix(flag, "--") && strings.Contains(flag, "e") {
+		return fmt.Errorf("flag %q is not allowed: -e (remote shell) is not supported", flag)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package transfer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func testJob() dto.TransferJob {
+	return dto.TransferJob{
+		ID:          "appdata-backup",
+		Name:        "Appdata backup",
+		Tool:        dto.TransferToolRsync,
+		Source:      "/mnt/user/appdata/",
+		Destination: "/mnt/user/backups/appdata/",
+		Flags:       []string{"-a", "--delete"},
+	}
+}
+
+func TestStoreCRUD(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	if err := store.CreateJob(testJob()); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if err := store.CreateJob(testJob()); err == nil {
+		t.Error("expected error for duplicate ID")
+	}
+
+	job := testJob()
+	job.ScheduleDailyAt = "03:00"
+	job.Enabled = true
+	if err := store.UpdateJob(job); err != nil {
+		t.Fatalf("UpdateJob: %v", err)
+	}
+
+	reloaded := NewStore(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got, err := reloaded.GetJob("appdata-backup")
+	if err != nil || got.ScheduleDailyAt != "03:00" || !got.Enabled {
+		t.Fatalf("GetJob = %+v, %v", got, err)
+	}
+
+	if err := reloaded.DeleteJob("appdata-backup"); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	if _, err := reloaded.GetJob("appdata-backup"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("GetJob after delete: err = %v, want ErrJobNotFound", err)
+	}
+	if err := reloaded.DeleteJob("appdata-backup"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("DeleteJob missing: err = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestStoreRcloneDefaultOperation(t *testing.T) {
+	store := NewStore(t.TempDir())
+	job := testJob()
+	job.Tool = dto.TransferToolRclone
+	job.Destination = "b2:backups/appdata"
+	if err := store.CreateJob(job); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	got, _ := store.GetJob(job.ID)
+	if got.Operation != "copy" {
+		t.Errorf("Operation = %q, want copy", got.Operation)
+	}
+}
+
+func TestValidateJob(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*dto.TransferJob)
+		ok     bool
+	}{
+		{"valid", func(*dto.TransferJob) {}, true},
+		{"bad id", func(j *dto.TransferJob) { j.ID = "Bad ID" }, false},
+		{"missing name", func(j *dto.TransferJob) { j.Name = " " }, false},
+		{"bad tool", func(j *dto.TransferJob) { j.Tool = "scp" }, false},
+		{"bad rclone operation", func(j *dto.TransferJob) { j.Tool, j.Operation = dto.TransferToolRclone, "purge" }, false},
+		{"missing source", func(j *dto.TransferJob) { j.Source = "" }, false},
+		{"option as destination", func(j *dto.TransferJob) { j.Destination = "--delete" }, false},
+		{"flag not an option", func(j *dto.TransferJob) { j.Flags = []string{"/etc"} }, false},
+		{"remote shell", func(j *dto.TransferJob) { j.Flags = []string{"--rsh=sh -c id"} }, false},
+		{"remote shell in cluster", func(j *dto.TransferJob) { j.Flags = []string{"-avze"} }, false},
+		{"rclone password command", func(j *dto.TransferJob) {
+			j.Tool, j.Operation, j.Flags = dto.TransferToolRclone, "sync", []string{"--password-command=id"}
+		}, false},
+		{"short interval", func(j *dto.TransferJob) { j.ScheduleIntervalMinutes = 1 }, false},
+		{"interval", func(j *dto.TransferJob) { j.ScheduleIntervalMinutes = 60 }, true},
+		{"bad daily time", func(j *dto.TransferJob) { j.ScheduleDailyAt = "25:00" }, false},
+		{"both schedules", func(j *dto.TransferJob) { j.ScheduleIntervalMinutes, j.ScheduleDailyAt = 60, "03:00" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := testJob()
+			tt.modify(&job)
+			if err := ValidateJob(job); (err == nil) != tt.ok {
+				t.Errorf("ValidateJob() error = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
 )
@@ -45,10 +46,10 @@ func (r *Remediator) Execute(ctx context.Context, check dto.HealthCheck, result
 
 // notifyUnraid creates an Unraid system notification about the health check failure.
 func (r *Remediator) notifyUnraid(check dto.HealthCheck, result ProbeResult) error {
-	msg := fmt.Sprintf("Health check '%s' (%s) failed: %s", check.Name, check.Target, result.Error)
+	msg := i18n.T("Health check '%s' (%s) failed: %s", check.Name, check.Target, result.Error)
 	importance := "warning"
 
-	err := controllers.CreateNotification("Health Check", check.Name, msg, importance, "")
+	err := controllers.CreateNotification(i18n.T("Health Check"), check.Name, msg, importance, "")
 	if err != nil {
 		return fmt.Errorf("sending unraid notification: %w", err)
 	}
@@ -2,6 +2,7 @@ package watchdog
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -22,11 +23,17 @@ const (
 	RemediationCooldown = 5 * time.Minute
 )
 
+// Maintenance reports whether a maintenance window is in effect.
+type Maintenance interface {
+	Active() bool
+}
+
 // Runner orchestrates health check probes and remediation actions.
 type Runner struct {
 	store      *Store
 	remediator *Remediator
 	hub        *domain.EventBus
+	maint      Maintenance
 
 	mu          sync.RWMutex
 	statuses    map[string]*dto.HealthCheckStatus
@@ -97,6 +104,7 @@ func (r *Runner) Start(ctx context.Context) {
 func (r *Runner) tick(ctx context.Context) {
 	checks := r.store.GetEnabledChecks()
 	now := time.Now()
+	ran := r.pruneStatuses(checks)
 
 	for _, check := range checks {
 		interval := time.Duration(check.IntervalSeconds) * time.Second
@@ -110,9 +118,37 @@ func (r *Runner) tick(ctx context.Context) {
 		}
 
 		r.runCheck(ctx, check, now)
+		ran = true
+	}
+
+	if ran {
+		r.publishStatuses()
 	}
 }
 
+// pruneStatuses drops runner state for checks that are no longer enabled in the
+// store (disabled, or removed without CleanupCheck). Returns true if anything
+// was dropped.
+func (r *Runner) pruneStatuses(enabled []dto.HealthCheck) bool {
+	keep := make(map[string]bool, len(enabled))
+	for _, c := range enabled {
+		keep[c.ID] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pruned := false
+	for id := range r.statuses {
+		if !keep[id] {
+			delete(r.statuses, id)
+			delete(r.lastRun, id)
+			pruned = true
+		}
+	}
+	return pruned
+}
+
 // runCheck executes a single health check probe and handles the result.
 func (r *Runner) runCheck(ctx context.Context, check dto.HealthCheck, now time.Time) {
 	result := RunProbe(ctx, check)
@@ -127,6 +163,7 @@ func (r *Runner) runCheck(ctx context.Context, check dto.HealthCheck, now time.T
 			CheckName:         check.Name,
 			CheckType:         check.Type,
 			Target:            check.Target,
+			Container:         check.Container,
 			RemediationAction: check.OnFail,
 		}
 		r.statuses[check.ID] = status
@@ -137,6 +174,7 @@ func (r *Runner) runCheck(ctx context.Context, check dto.HealthCheck, now time.T
 	status.LastCheck = now
 	status.CheckName = check.Name
 	status.Target = check.Target
+	status.Container = check.Container
 	status.RemediationAction = check.OnFail
 
 	if result.Healthy {
@@ -154,6 +192,10 @@ func (r *Runner) runCheck(ctx context.Context, check dto.HealthCheck, now time.T
 	// Check remediation cooldown
 	canRemediate := status.LastRemediation == nil || now.Sub(*status.LastRemediation) >= RemediationCooldown
 	needsRemediation := !result.Healthy && transitionedToUnhealthy && canRemediate && check.OnFail != ""
+	inMaintenance := r.maint != nil && r.maint.Active()
+	if inMaintenance {
+		needsRemediation = false
+	}
 
 	if needsRemediation {
 		ts := now
@@ -180,12 +222,16 @@ func (r *Runner) runCheck(ctx context.Context, check dto.HealthCheck, now time.T
 			} else {
 				event.RemediationTaken = check.OnFail
 			}
+		} else if inMaintenance {
+			logger.Info("Watchdog: '%s' failed during maintenance, remediation suppressed: %s", check.Name, result.Error)
 		} else {
 			logger.Warning("Watchdog: '%s' failed: %s", check.Name, result.Error)
 		}
 
 		r.addHistory(event)
-		r.publishWake(check, result)
+		if !inMaintenance {
+			r.publishWake(check, result)
+		}
 	} else if transitionedToHealthy {
 		logger.Success("Watchdog: '%s' recovered", check.Name)
 		r.addHistory(dto.HealthCheckEvent{
@@ -201,6 +247,10 @@ func (r *Runner) runCheck(ctx context.Context, check dto.HealthCheck, now time.T
 // SetEventBus wires the pubsub hub so unhealthy transitions can wake the agent.
 func (r *Runner) SetEventBus(hub *domain.EventBus) { r.hub = hub }
 
+// SetMaintenance suppresses remediation actions and agent wakes while m
+// reports a maintenance window. Probes keep running. Call before Start.
+func (r *Runner) SetMaintenance(m Maintenance) { r.maint = m }
+
 // publishWake emits an AgentWakeEvent for an unhealthy check (no-op if no hub).
 func (r *Runner) publishWake(check dto.HealthCheck, result ProbeResult) {
 	if r.hub == nil {
@@ -216,6 +266,15 @@ func (r *Runner) publishWake(check dto.HealthCheck, result ProbeResult) {
 	})
 }
 
+// publishStatuses emits the current check statuses so MQTT and other
+// subscribers can track probe results (no-op if no hub).
+func (r *Runner) publishStatuses() {
+	if r.hub == nil {
+		return
+	}
+	domain.Publish(r.hub, constants.TopicHealthCheckStatusUpdate, r.GetStatuses())
+}
+
 // addHistory adds an event to the ring buffer.
 func (r *Runner) addHistory(event dto.HealthCheckEvent) {
 	r.mu.Lock()
@@ -275,6 +334,26 @@ func (r *Runner) GetStatus(id string) (*dto.HealthCheckStatus, error) {
 	return &result, nil
 }
 
+// GetContainerStatuses returns the statuses of probes linked to a container,
+// grouped by the container reference (ID or name) they were configured with.
+func (r *Runner) GetContainerStatuses() map[string][]dto.HealthCheckStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string][]dto.HealthCheckStatus)
+	for _, s := range r.statuses {
+		if s.Container != "" {
+			result[s.Container] = append(result[s.Container], *s)
+		}
+	}
+	for ref := range result {
+		sort.Slice(result[ref], func(i, j int) bool {
+			return result[ref][i].CheckID < result[ref][j].CheckID
+		})
+	}
+	return result
+}
+
 // GetUnhealthyChecks returns only checks that are currently unhealthy.
 func (r *Runner) GetUnhealthyChecks() []dto.HealthCheckStatus {
 	r.mu.RLock()
@@ -297,6 +376,7 @@ func (r *Runner) RunSingleCheck(ctx context.Context, id string) (*dto.HealthChec
 	}
 
 	r.runCheck(ctx, *check, time.Now())
+	r.publishStatuses()
 
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -309,11 +389,20 @@ func (r *Runner) RunSingleCheck(ctx context.Context, id string) (*dto.HealthChec
 	return &result, nil
 }
 
-// CleanupCheck removes status tracking for a deleted health check.
+// CleanupCheck removes status tracking for a deleted health check and
+// republishes the statuses so subscribers (e.g. MQTT discovery) drop it.
 func (r *Runner) CleanupCheck(id string) {
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
 	delete(r.statuses, id)
 	delete(r.lastRun, id)
+	r.mu.Unlock()
+
+	r.publishStatuses()
+}
+
+// ResetCheck discards status tracking for a health check whose configuration
+// changed. A disabled check stays absent; an enabled one is probed again with
+// its new settings on the next tick.
+func (r *Runner) ResetCheck(id string) {
+	r.CleanupCheck(id)
 }
@@ -423,3 +423,114 @@ func TestRunnerRecovery(t *testing.T) {
 		t.Errorf("consecutive fails should be 0 after recovery, got %d", status.ConsecutiveFails)
 	}
 }
+
+func TestRunnerContainerStatusesAndPublish(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	store := NewStore(t.TempDir())
+	for _, c := range []dto.HealthCheck{
+		{ID: "plex-web", Name: "Plex Web", Type: dto.HealthCheckHTTP, Target: srv.URL, Container: "plex", Enabled: true},
+		{ID: "router", Name: "Router", Type: dto.HealthCheckHTTP, Target: srv.URL, Enabled: true},
+	} {
+		if err := store.CreateCheck(c); err != nil {
+			t.Fatalf("CreateCheck(%s): %v", c.ID, err)
+		}
+	}
+
+	bus := domain.NewEventBus(8)
+	ch := bus.SubTopics(constants.TopicHealthCheckStatusUpdate)
+	r := NewRunner(store)
+	r.SetEventBus(bus)
+	r.tick(context.Background())
+
+	select {
+	case msg := <-ch:
+		if statuses := msg.([]dto.HealthCheckStatus); len(statuses) != 2 {
+			t.Errorf("published %d statuses, want 2", len(statuses))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no status update published")
+	}
+
+	byContainer := r.GetContainerStatuses()
+	if len(byContainer) != 1 {
+		t.Fatalf("expected 1 linked container, got %d", len(byContainer))
+	}
+	plex := byContainer["plex"]
+	if len(plex) != 1 || plex[0].CheckID != "plex-web" || !plex[0].Healthy || plex[0].Container != "plex" {
+		t.Errorf("unexpected plex statuses: %+v", plex)
+	}
+}
+
+func TestRunnerDropsDisabledAndDeletedChecks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	checks := []dto.HealthCheck{
+		{ID: "plex-web", Name: "Plex Web", Type: dto.HealthCheckHTTP, Target: srv.URL, Container: "plex", Enabled: true},
+		{ID: "sonarr-web", Name: "Sonarr Web", Type: dto.HealthCheckHTTP, Target: srv.URL, Container: "sonarr", Enabled: true},
+		{ID: "router", Name: "Router", Type: dto.HealthCheckHTTP, Target: srv.URL, Enabled: true},
+	}
+	store := NewStore(t.TempDir())
+	for _, c := range checks {
+		if err := store.CreateCheck(c); err != nil {
+			t.Fatalf("CreateCheck(%s): %v", c.ID, err)
+		}
+	}
+
+	bus := domain.NewEventBus(8)
+	ch := bus.SubTopics(constants.TopicHealthCheckStatusUpdate)
+	r := NewRunner(store)
+	r.SetEventBus(bus)
+
+	expectPublished := func(step string, want int) {
+		t.Helper()
+		select {
+		case msg := <-ch:
+			if statuses := msg.([]dto.HealthCheckStatus); len(statuses) != want {
+				t.Errorf("%s: published %d statuses, want %d", step, len(statuses), want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: no status update published", step)
+		}
+	}
+
+	r.tick(context.Background())
+	expectPublished("initial run", 3)
+
+	// Disabled through the API: the handler resets the runner state.
+	disabled := checks[0]
+	disabled.Enabled = false
+	if err := store.UpdateCheck(disabled); err != nil {
+		t.Fatalf("UpdateCheck: %v", err)
+	}
+	r.ResetCheck(disabled.ID)
+	expectPublished("reset", 2)
+	if _, ok := r.GetContainerStatuses()["plex"]; ok {
+		t.Error("disabled check still reported on its container")
+	}
+
+	// Disabled without a reset (e.g. config file edited): pruned on the next tick.
+	disabled = checks[1]
+	disabled.Enabled = false
+	if err := store.UpdateCheck(disabled); err != nil {
+		t.Fatalf("UpdateCheck: %v", err)
+	}
+	r.tick(context.Background())
+	expectPublished("prune", 1)
+	if len(r.GetContainerStatuses()) != 0 {
+		t.Errorf("container statuses = %v, want none", r.GetContainerStatuses())
+	}
+
+	// Deleted: CleanupCheck republishes so subscribers drop the entity.
+	if err := store.DeleteCheck("router"); err != nil {
+		t.Fatalf("DeleteCheck: %v", err)
+	}
+	r.CleanupCheck("router")
+	expectPublished("delete", 0)
+}
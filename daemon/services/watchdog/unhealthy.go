@@ -0,0 +1,200 @@
+package watchdog
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/controllers"
+)
+
+const (
+	// DefaultUnhealthyRestartAfter is how long a container must stay unhealthy
+	// before it is restarted when no duration is configured.
+	DefaultUnhealthyRestartAfter = 5 * time.Minute
+
+	// MaxUnhealthyRestarts caps automatic restarts of one container within
+	// unhealthyRestartWindow, so a container that never recovers is not
+	// restarted in a loop. Once reached, a single notification is sent and the
+	// container is left alone until the window has passed.
+	MaxUnhealthyRestarts = 3
+
+	unhealthyRestartWindow = 24 * time.Hour
+)
+
+// UnhealthyRestarter restarts containers whose Docker HEALTHCHECK has been
+// reporting unhealthy for longer than the configured duration and raises an
+// Unraid notification for every action it takes.
+type UnhealthyRestarter struct {
+	after   time.Duration
+	exclude []string
+	hub     *domain.EventBus
+
+	// restartFn and notifyFn are replaceable for tests.
+	restartFn func(name string) error
+	notifyFn  func(subject, description, importance string) error
+
+	mu sync.Mutex
+	// unhealthySince records when each container (by name) was first seen
+	// unhealthy in the current streak.
+	unhealthySince map[string]time.Time
+	// restarts holds the times of recent automatic restarts per container.
+	restarts map[string][]time.Time
+	// gaveUp marks containers that hit MaxUnhealthyRestarts and were notified.
+	gaveUp map[string]bool
+}
+
+// NewUnhealthyRestarter creates a restarter driven by container list updates
+// on hub. Containers named in exclude are never restarted.
+func NewUnhealthyRestarter(cfg domain.UnhealthyRestartConfig, hub *domain.EventBus) *UnhealthyRestarter {
+	after := time.Duration(cfg.AfterMinutes) * time.Minute
+	if after <= 0 {
+		after = DefaultUnhealthyRestartAfter
+	}
+	return &UnhealthyRestarter{
+		after:          after,
+		exclude:        cfg.Exclude,
+		hub:            hub,
+		restartFn:      restartContainerByName,
+		notifyFn:       notifyUnhealthyRestart,
+		unhealthySince: make(map[string]time.Time),
+		restarts:       make(map[string][]time.Time),
+		gaveUp:         make(map[string]bool),
+	}
+}
+
+// Start evaluates every container list update until ctx is cancelled.
+func (u *UnhealthyRestarter) Start(ctx context.Context) {
+	ch := u.hub.SubTopics(constants.TopicContainerListUpdate)
+	defer u.hub.Unsub(ch, constants.TopicContainerListUpdate.Name)
+	logger.Success("Unhealthy container watchdog started (restart after %s unhealthy)", u.after)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Unhealthy container watchdog stopped")
+			return
+		case msg := <-ch:
+			if containers, ok := msg.([]*dto.ContainerInfo); ok {
+				u.Evaluate(containers, time.Now())
+			}
+		}
+	}
+}
+
+// Evaluate updates the unhealthy streak of every container and restarts those
+// that have been unhealthy for longer than the configured duration. It
+// returns the names of the containers it restarted.
+func (u *UnhealthyRestarter) Evaluate(containers []*dto.ContainerInfo, now time.Time) []string {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LogPanicWithStack("Unhealthy container watchdog", r)
+		}
+	}()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	seen := make(map[string]bool, len(containers))
+	var restarted []string
+
+	for _, c := range containers {
+		if c == nil || c.Name == "" || slices.Contains(u.exclude, c.Name) {
+			continue
+		}
+		if c.State != "running" || c.Health != dto.ContainerHealthUnhealthy {
+			continue
+		}
+		seen[c.Name] = true
+
+		since, ok := u.unhealthySince[c.Name]
+		if !ok {
+			u.unhealthySince[c.Name] = now
+			logger.Info("Watchdog: container '%s' is unhealthy; restarting if still unhealthy after %s", c.Name, u.after)
+			continue
+		}
+		if now.Sub(since) < u.after {
+			continue
+		}
+
+		if u.restartLimitReached(c.Name, now) {
+			if !u.gaveUp[c.Name] {
+				u.gaveUp[c.Name] = true
+				logger.Warning("Watchdog: container '%s' is still unhealthy after %d automatic restarts in the last 24 hours; not restarting it again", c.Name, MaxUnhealthyRestarts)
+				u.notify(c.Name, i18n.T("Container '%s' is still unhealthy after %d automatic restarts in the last 24 hours; not restarting it again.", c.Name, MaxUnhealthyRestarts), "alert")
+			}
+			continue
+		}
+		delete(u.gaveUp, c.Name)
+
+		unhealthyFor := now.Sub(since).Round(time.Second)
+		if err := u.restartFn(c.Name); err != nil {
+			logger.Error("Watchdog: failed to restart unhealthy container '%s': %v", c.Name, err)
+			u.notify(c.Name, i18n.T("Container '%s' has been unhealthy for %s; restart failed: %v", c.Name, unhealthyFor, err), "alert")
+			// Retry after another full period rather than on every update.
+			u.unhealthySince[c.Name] = now
+			continue
+		}
+
+		u.restarts[c.Name] = append(u.restarts[c.Name], now)
+		delete(u.unhealthySince, c.Name)
+		restarted = append(restarted, c.Name)
+		logger.Warning("Watchdog: restarted container '%s' after %s unhealthy", c.Name, unhealthyFor)
+		u.notify(c.Name, i18n.T("Container '%s' was unhealthy for %s and has been restarted.", c.Name, unhealthyFor), "warning")
+	}
+
+	// Containers that recovered, stopped or disappeared start a new streak.
+	for name := range u.unhealthySince {
+		if !seen[name] {
+			delete(u.unhealthySince, name)
+		}
+	}
+	for name := range u.gaveUp {
+		if !seen[name] {
+			delete(u.gaveUp, name)
+		}
+	}
+	return restarted
+}
+
+// restartLimitReached prunes restarts older than the window and reports
+// whether the container already used up its automatic restarts.
+func (u *UnhealthyRestarter) restartLimitReached(name string, now time.Time) bool {
+	recent := slices.DeleteFunc(u.restarts[name], func(t time.Time) bool {
+		return now.Sub(t) >= unhealthyRestartWindow
+	})
+	if len(recent) == 0 {
+		delete(u.restarts, name)
+		return false
+	}
+	u.restarts[name] = recent
+	return len(recent) >= MaxUnhealthyRestarts
+}
+
+// notify sends a notification, logging rather than returning a failure.
+func (u *UnhealthyRestarter) notify(name, description, importance string) {
+	if u.notifyFn == nil {
+		return
+	}
+	if err := u.notifyFn(name, description, importance); err != nil {
+		logger.Warning("Watchdog: failed to send notification for '%s': %v", name, err)
+	}
+}
+
+// restartContainerByName restarts a container through the Docker controller.
+func restartContainerByName(name string) error {
+	dc := controllers.NewDockerController()
+	defer func() { _ = dc.Close() }()
+	return dc.Restart(name)
+}
+
+// notifyUnhealthyRestart raises an Unraid notification for an automatic restart.
+func notifyUnhealthyRestart(subject, description, importance string) error {
+	return controllers.CreateNotification(i18n.T("Unhealthy container"), subject, description, importance, "")
+}
@@ -0,0 +1,126 @@
+package watchdog
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+// newTestRestarter returns a restarter with recording restart/notify hooks.
+func newTestRestarter(cfg domain.UnhealthyRestartConfig) (*UnhealthyRestarter, *[]string, *[]string) {
+	var restarts, notes []string
+	u := NewUnhealthyRestarter(cfg, nil)
+	u.restartFn = func(name string) error {
+		restarts = append(restarts, name)
+		return nil
+	}
+	u.notifyFn = func(subject, _, importance string) error {
+		notes = append(notes, subject+":"+importance)
+		return nil
+	}
+	return u, &restarts, &notes
+}
+
+func unhealthy(name string) *dto.ContainerInfo {
+	return &dto.ContainerInfo{Name: name, State: "running", Health: dto.ContainerHealthUnhealthy}
+}
+
+func TestUnhealthyRestarterRestartsAfterThreshold(t *testing.T) {
+	u, restarts, notes := newTestRestarter(domain.UnhealthyRestartConfig{AfterMinutes: 5})
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	list := []*dto.ContainerInfo{
+		unhealthy("plex"),
+		{Name: "sonarr", State: "running", Health: dto.ContainerHealthHealthy},
+	}
+
+	if got := u.Evaluate(list, start); len(got) != 0 {
+		t.Fatalf("restarted %v on first sighting", got)
+	}
+	if got := u.Evaluate(list, start.Add(4*time.Minute)); len(got) != 0 {
+		t.Fatalf("restarted %v before threshold", got)
+	}
+	if got := u.Evaluate(list, start.Add(5*time.Minute)); !slices.Equal(got, []string{"plex"}) {
+		t.Fatalf("restarted %v, want [plex]", got)
+	}
+	if !slices.Equal(*restarts, []string{"plex"}) || !slices.Equal(*notes, []string{"plex:warning"}) {
+		t.Errorf("restarts = %v, notes = %v", *restarts, *notes)
+	}
+
+	// The streak starts over after a restart.
+	if got := u.Evaluate(list, start.Add(6*time.Minute)); len(got) != 0 {
+		t.Errorf("restarted %v again immediately", got)
+	}
+}
+
+func TestUnhealthyRestarterRecoveryResetsStreak(t *testing.T) {
+	u, restarts, _ := newTestRestarter(domain.UnhealthyRestartConfig{AfterMinutes: 5})
+	start := time.Now()
+
+	u.Evaluate([]*dto.ContainerInfo{unhealthy("plex")}, start)
+	u.Evaluate([]*dto.ContainerInfo{{Name: "plex", State: "running", Health: dto.ContainerHealthHealthy}}, start.Add(3*time.Minute))
+	u.Evaluate([]*dto.ContainerInfo{unhealthy("plex")}, start.Add(4*time.Minute))
+	if got := u.Evaluate([]*dto.ContainerInfo{unhealthy("plex")}, start.Add(6*time.Minute)); len(got) != 0 {
+		t.Errorf("restarted %v although the streak was interrupted", got)
+	}
+	if len(*restarts) != 0 {
+		t.Errorf("restarts = %v", *restarts)
+	}
+}
+
+func TestUnhealthyRestarterExclude(t *testing.T) {
+	u, restarts, _ := newTestRestarter(domain.UnhealthyRestartConfig{AfterMinutes: 1, Exclude: []string{"plex"}})
+	start := time.Now()
+
+	u.Evaluate([]*dto.ContainerInfo{unhealthy("plex")}, start)
+	u.Evaluate([]*dto.ContainerInfo{unhealthy("plex")}, start.Add(time.Hour))
+	if len(*restarts) != 0 {
+		t.Errorf("excluded container restarted: %v", *restarts)
+	}
+}
+
+func TestUnhealthyRestarterGivesUpAfterLimit(t *testing.T) {
+	u, restarts, notes := newTestRestarter(domain.UnhealthyRestartConfig{AfterMinutes: 1})
+	now := time.Now()
+	list := []*dto.ContainerInfo{unhealthy("plex")}
+
+	for range MaxUnhealthyRestarts + 2 {
+		u.Evaluate(list, now)
+		now = now.Add(time.Minute)
+		u.Evaluate(list, now)
+		now = now.Add(time.Minute)
+	}
+
+	if len(*restarts) != MaxUnhealthyRestarts {
+		t.Errorf("restarts = %d, want %d", len(*restarts), MaxUnhealthyRestarts)
+	}
+	if last := (*notes)[len(*notes)-1]; last != "plex:alert" {
+		t.Errorf("last notification = %q, want give-up alert", last)
+	}
+	alerts := 0
+	for _, n := range *notes {
+		if n == "plex:alert" {
+			alerts++
+		}
+	}
+	if alerts != 1 {
+		t.Errorf("give-up alerts = %d, want 1", alerts)
+	}
+}
+
+func TestUnhealthyRestarterRestartFailure(t *testing.T) {
+	u, _, notes := newTestRestarter(domain.UnhealthyRestartConfig{AfterMinutes: 1})
+	u.restartFn = func(string) error { return errors.New("daemon unreachable") }
+	start := time.Now()
+
+	u.Evaluate([]*dto.ContainerInfo{unhealthy("plex")}, start)
+	if got := u.Evaluate([]*dto.ContainerInfo{unhealthy("plex")}, start.Add(time.Minute)); len(got) != 0 {
+		t.Errorf("reported %v as restarted after failure", got)
+	}
+	if !slices.Equal(*notes, []string{"plex:alert"}) {
+		t.Errorf("notes = %v, want failure alert", *notes)
+	}
+}
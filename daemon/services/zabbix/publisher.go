@@ -0,0 +1,284 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
+)
+
+// Low-level discovery rule keys. Each is a trapper item whose value is the
+// discovery JSON for one entity type.
+const (
+	DiskDiscoveryKey      = "unraid.disk.discovery"
+	ContainerDiscoveryKey = "unraid.container.discovery"
+	VMDiscoveryKey        = "unraid.vm.discovery"
+)
+
+// discoveryRefresh is how often unchanged discovery data is re-sent, so a
+// Zabbix server that was restarted or had the host re-created catches up.
+const discoveryRefresh = time.Hour
+
+// Publisher sends collector updates to Zabbix as they are published on the
+// event bus: discovery data when the set of disks, containers or VMs changes,
+// and item values on every collection cycle.
+type Publisher struct {
+	sender *Sender
+	host   string
+	hub    *domain.EventBus
+
+	// discovery remembers the last discovery value sent per rule key.
+	discovery     map[string]string
+	discoverySent map[string]time.Time
+
+	// failing suppresses repeated warnings while the server is unreachable.
+	failing bool
+}
+
+// NewPublisher validates cfg and creates a publisher that reports as the
+// Zabbix host cfg.Host, falling back to hostname.
+func NewPublisher(cfg domain.ZabbixConfig, hostname string, hub *domain.EventBus) (*Publisher, error) {
+	sender, err := NewSender(cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+	host := strings.TrimSpace(cfg.Host)
+	if host == "" {
+		host = hostname
+	}
+	return &Publisher{
+		sender:        sender,
+		host:          host,
+		hub:           hub,
+		discovery:     make(map[string]string),
+		discoverySent: make(map[string]time.Time),
+	}, nil
+}
+
+// Start subscribes to collector updates and sends them until ctx is
+// cancelled.
+func (p *Publisher) Start(ctx context.Context) {
+	ch := p.hub.SubTopics(
+		constants.TopicSystemUpdate,
+		constants.TopicArrayStatusUpdate,
+		constants.TopicDiskListUpdate,
+		constants.TopicContainerListUpdate,
+		constants.TopicVMListUpdate,
+	)
+	defer p.hub.Unsub(ch,
+		constants.TopicSystemUpdate.Name,
+		constants.TopicArrayStatusUpdate.Name,
+		constants.TopicDiskListUpdate.Name,
+		constants.TopicContainerListUpdate.Name,
+		constants.TopicVMListUpdate.Name,
+	)
+	logger.Success("Zabbix sender publishing to %s as host %q", p.sender.Address(), p.host)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Zabbix sender stopped")
+			return
+		case msg := <-ch:
+			p.handle(ctx, msg)
+		}
+	}
+}
+
+// handle converts one collector update to items and sends them.
+func (p *Publisher) handle(ctx context.Context, msg any) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LogPanicWithStack("Zabbix sender", r)
+		}
+	}()
+
+	items := p.Items(msg, time.Now())
+	if len(items) == 0 {
+		return
+	}
+
+	resp, err := p.sender.Send(ctx, items)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return
+	case err != nil && !p.failing:
+		logger.Warning("Zabbix: send failed: %v", err)
+		p.failing = true
+	case err != nil:
+		logger.Debug("Zabbix: send failed: %v", err)
+	default:
+		if p.failing {
+			logger.Info("Zabbix: send succeeded again")
+			p.failing = false
+		}
+		if resp.Failed > 0 {
+			logger.Debug("Zabbix: %s", resp.Info)
+		}
+	}
+	if err != nil {
+		// Resend discovery on the next cycle in case it was lost.
+		clear(p.discovery)
+	}
+}
+
+// Items returns the items for one collector update. Discovery data is only
+// included when it differs from the last value sent or is due for a refresh.
+func (p *Publisher) Items(msg any, now time.Time) []Item {
+	b := itemBuilder{host: p.host, clock: now.Unix()}
+
+	switch m := msg.(type) {
+	case *dto.SystemInfo:
+		if m == nil {
+			return nil
+		}
+		b.add("unraid.cpu.usage", m.CPUUsage)
+		b.add("unraid.cpu.temp", m.CPUTemp)
+		b.add("unraid.memory.usage", m.RAMUsage)
+		b.add("unraid.memory.used", m.RAMUsed)
+		b.add("unraid.memory.total", m.RAMTotal)
+		b.add("unraid.uptime", m.Uptime)
+	case *dto.ArrayStatus:
+		if m == nil {
+			return nil
+		}
+		b.add("unraid.array.state", m.State)
+		b.add("unraid.array.used_percent", m.UsedPercent)
+		b.add("unraid.array.free", m.FreeBytes)
+		b.add("unraid.array.total", m.TotalBytes)
+		b.add("unraid.array.parity_valid", m.ParityValid)
+		b.add("unraid.array.parity_check_status", m.ParityCheckStatus)
+		b.add("unraid.array.parity_check_progress", m.ParityCheckProgress)
+	case []dto.DiskInfo:
+		rows := make([]map[string]string, 0, len(m))
+		for _, d := range m {
+			if d.ID == "" {
+				continue
+			}
+			rows = append(rows, map[string]string{
+				"{#DISK.ID}":     d.ID,
+				"{#DISK.NAME}":   d.Name,
+				"{#DISK.DEVICE}": d.Device,
+				"{#DISK.ROLE}":   d.Role,
+			})
+			b.add(Key("unraid.disk.status", d.ID), d.Status)
+			b.add(Key("unraid.disk.temp", d.ID), d.Temperature)
+			b.add(Key("unraid.disk.smart_status", d.ID), d.SMARTStatus)
+			b.add(Key("unraid.disk.smart_errors", d.ID), d.SMARTErrors)
+			b.add(Key("unraid.disk.spin_state", d.ID), d.SpinState)
+			b.add(Key("unraid.disk.used_percent", d.ID), d.UsagePercent)
+			b.add(Key("unraid.disk.free", d.ID), d.Free)
+		}
+		p.addDiscovery(&b, DiskDiscoveryKey, rows, now)
+	case []*dto.ContainerInfo:
+		rows := make([]map[string]string, 0, len(m))
+		for _, c := range m {
+			if c == nil || c.Name == "" {
+				continue
+			}
+			rows = append(rows, map[string]string{
+				"{#CONTAINER.NAME}":  c.Name,
+				"{#CONTAINER.IMAGE}": c.Image,
+			})
+			b.add(Key("unraid.container.state", c.Name), c.State)
+			b.add(Key("unraid.container.cpu", c.Name), c.CPUPercent)
+			b.add(Key("unraid.container.memory", c.Name), c.MemoryUsage)
+			b.add(Key("unraid.container.restarts", c.Name), c.RestartCount)
+		}
+		p.addDiscovery(&b, ContainerDiscoveryKey, rows, now)
+	case []*dto.VMInfo:
+		rows := make([]map[string]string, 0, len(m))
+		for _, vm := range m {
+			if vm == nil || vm.Name == "" {
+				continue
+			}
+			rows = append(rows, map[string]string{"{#VM.NAME}": vm.Name})
+			b.add(Key("unraid.vm.state", vm.Name), vm.State)
+			b.add(Key("unraid.vm.cpu", vm.Name), vm.GuestCPUPercent)
+			b.add(Key("unraid.vm.memory", vm.Name), vm.MemoryUsed)
+		}
+		p.addDiscovery(&b, VMDiscoveryKey, rows, now)
+	}
+	return b.items
+}
+
+// addDiscovery prepends the discovery item for key when rows changed since
+// the last send or the last send is older than discoveryRefresh. Discovery
+// goes first so the server can create the items before their values arrive
+// on the next cycle.
+func (p *Publisher) addDiscovery(b *itemBuilder, key string, rows []map[string]string, now time.Time) {
+	slices.SortFunc(rows, func(a, c map[string]string) int {
+		return strings.Compare(firstMacro(a), firstMacro(c))
+	})
+	data, err := json.Marshal(map[string]any{"data": rows})
+	if err != nil {
+		return
+	}
+	value := string(data)
+	if p.discovery[key] == value && now.Sub(p.discoverySent[key]) < discoveryRefresh {
+		return
+	}
+	p.discovery[key] = value
+	p.discoverySent[key] = now
+	b.items = append([]Item{{Host: b.host, Key: key, Value: value, Clock: b.clock}}, b.items...)
+}
+
+// firstMacro returns the value of the entity's identifying macro, used to
+// keep discovery output stable between cycles.
+func firstMacro(row map[string]string) string {
+	for _, k := range []string{"{#DISK.ID}", "{#CONTAINER.NAME}", "{#VM.NAME}"} {
+		if v, ok := row[k]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// Key builds an item key with one parameter, quoting the parameter when it
+// contains characters that are special in Zabbix key syntax.
+func Key(base, param string) string {
+	if strings.ContainsAny(param, `,[]" `) {
+		param = `"` + strings.ReplaceAll(param, `"`, `\"`) + `"`
+	}
+	return base + "[" + param + "]"
+}
+
+// itemBuilder accumulates items for one host and timestamp.
+type itemBuilder struct {
+	host  string
+	clock int64
+	items []Item
+}
+
+// add appends an item, formatting value as Zabbix expects: numbers in plain
+// decimal notation and booleans as 1/0.
+func (b *itemBuilder) add(key string, value any) {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case bool:
+		s = "0"
+		if v {
+			s = "1"
+		}
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		s = strconv.Itoa(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case uint64:
+		s = strconv.FormatUint(v, 10)
+	default:
+		return
+	}
+	b.items = append(b.items, Item{Host: b.host, Key: key, Value: s, Clock: b.clock})
+}
@@ -0,0 +1,79 @@
+package zabbix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/dto"
+)
+
+func newTestPublisher(t *testing.T) *Publisher {
+	t.Helper()
+	p, err := NewPublisher(domain.ZabbixConfig{Server: "zabbix"}, "tower", nil)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	return p
+}
+
+func TestKey(t *testing.T) {
+	tests := map[string]string{
+		"disk1":         "unraid.vm.state[disk1]",
+		"Windows 11":    `unraid.vm.state["Windows 11"]`,
+		`say "hi", [x]`: `unraid.vm.state["say \"hi\", [x]"]`,
+	}
+	for param, want := range tests {
+		if got := Key("unraid.vm.state", param); got != want {
+			t.Errorf("Key(%q) = %q, want %q", param, got, want)
+		}
+	}
+}
+
+func TestItemsDiskDiscovery(t *testing.T) {
+	p := newTestPublisher(t)
+	now := time.Unix(1700000000, 0)
+	disks := []dto.DiskInfo{{ID: "disk1", Name: "Disk 1", Device: "sdb", Role: "data", Temperature: 34, Status: "DISK_OK"}}
+
+	items := p.Items(disks, now)
+	if len(items) == 0 || items[0].Key != DiskDiscoveryKey {
+		t.Fatalf("first item = %+v, want discovery", items)
+	}
+	want := `{"data":[{"{#DISK.DEVICE}":"sdb","{#DISK.ID}":"disk1","{#DISK.NAME}":"Disk 1","{#DISK.ROLE}":"data"}]}`
+	if items[0].Value != want {
+		t.Errorf("discovery = %s, want %s", items[0].Value, want)
+	}
+
+	var temp string
+	for _, it := range items {
+		if it.Key == "unraid.disk.temp[disk1]" {
+			temp = it.Value
+		}
+		if it.Host != "tower" || it.Clock != now.Unix() {
+			t.Errorf("item %+v has wrong host/clock", it)
+		}
+	}
+	if temp != "34" {
+		t.Errorf("disk temp = %q, want 34", temp)
+	}
+
+	// Unchanged discovery is not resent until the refresh interval passes.
+	if again := p.Items(disks, now.Add(time.Minute)); again[0].Key == DiskDiscoveryKey {
+		t.Error("unchanged discovery was resent")
+	}
+	if later := p.Items(disks, now.Add(discoveryRefresh)); later[0].Key != DiskDiscoveryKey {
+		t.Error("discovery was not refreshed")
+	}
+}
+
+func TestItemsArrayStatus(t *testing.T) {
+	p := newTestPublisher(t)
+	items := p.Items(&dto.ArrayStatus{State: "Started", ParityValid: true}, time.Now())
+	values := make(map[string]string, len(items))
+	for _, it := range items {
+		values[it.Key] = it.Value
+	}
+	if values["unraid.array.state"] != "Started" || values["unraid.array.parity_valid"] != "1" {
+		t.Errorf("items = %v", values)
+	}
+}
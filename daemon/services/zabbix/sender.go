@@ -0,0 +1,170 @@
+// Package zabbix pushes the agent's collector data to a Zabbix server or
+// proxy using the zabbix_sender (trapper) protocol, including low-level
+// discovery data for disks, containers and VMs.
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPort is the Zabbix trapper port.
+	DefaultPort = "10051"
+
+	// sendTimeout bounds connecting, writing and reading one request.
+	sendTimeout = 10 * time.Second
+
+	// maxResponseBytes caps the size of a response accepted from the server.
+	maxResponseBytes = 1 << 20
+)
+
+// protocolHeader starts every zabbix_sender packet: "ZBXD" followed by the
+// protocol flags (0x01 = standard, no compression).
+var protocolHeader = []byte{'Z', 'B', 'X', 'D', 0x01}
+
+// Item is one trapper value for a host and item key.
+type Item struct {
+	Host  string `json:"host"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Clock int64  `json:"clock,omitempty"`
+}
+
+// Response is the outcome of a sender request as reported by the server.
+type Response struct {
+	Processed int
+	Failed    int
+	Total     int
+	Info      string
+}
+
+type senderRequest struct {
+	Request string `json:"request"`
+	Data    []Item `json:"data"`
+	Clock   int64  `json:"clock"`
+}
+
+type senderResponse struct {
+	Response string `json:"response"`
+	Info     string `json:"info"`
+}
+
+// infoPattern extracts the counters from a response "info" string such as
+// "processed: 3; failed: 1; total: 4; seconds spent: 0.000055".
+var infoPattern = regexp.MustCompile(`processed:\s*(\d+);\s*failed:\s*(\d+);\s*total:\s*(\d+)`)
+
+// Sender writes items to a Zabbix server or proxy.
+type Sender struct {
+	address string
+}
+
+// NewSender creates a sender for address (host[:port], default port 10051).
+func NewSender(address string) (*Sender, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return nil, errors.New("zabbix server address is required")
+	}
+	if _, port, err := net.SplitHostPort(address); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("zabbix server address has invalid port %q", port)
+		}
+	} else {
+		address = net.JoinHostPort(strings.Trim(address, "[]"), DefaultPort)
+	}
+	return &Sender{address: address}, nil
+}
+
+// Address returns the host:port the sender connects to.
+func (s *Sender) Address() string {
+	return s.address
+}
+
+// Send writes items in a single request and returns the server's counters.
+// Items rejected by the server (for example because the item does not exist
+// yet) are reported in Response.Failed, not as an error.
+func (s *Sender) Send(ctx context.Context, items []Item) (*Response, error) {
+	payload, err := json.Marshal(senderRequest{Request: "sender data", Data: items, Clock: time.Now().Unix()})
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := net.Dialer{Timeout: sendTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(sendTimeout))
+
+	if _, err := conn.Write(encodePacket(payload)); err != nil {
+		return nil, fmt.Errorf("write request: %w", err)
+	}
+
+	body, err := decodePacket(conn)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	var resp senderResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if resp.Response != "success" {
+		return nil, fmt.Errorf("zabbix server returned %q: %s", resp.Response, resp.Info)
+	}
+	return parseInfo(resp.Info), nil
+}
+
+// encodePacket frames payload with the protocol header and its length: a
+// 4-byte little-endian data length followed by 4 reserved bytes.
+func encodePacket(payload []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(protocolHeader) + 8 + len(payload))
+	buf.Write(protocolHeader)
+	var size [8]byte
+	binary.LittleEndian.PutUint32(size[:4], uint32(len(payload))) //nolint:gosec // G115: payload is far below 4 GiB
+	buf.Write(size[:])
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+// decodePacket reads one framed packet from r and returns its payload.
+func decodePacket(r io.Reader) ([]byte, error) {
+	header := make([]byte, len(protocolHeader)+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:4], protocolHeader[:4]) {
+		return nil, errors.New("invalid protocol header")
+	}
+	size := binary.LittleEndian.Uint32(header[len(protocolHeader):])
+	if size > maxResponseBytes {
+		return nil, fmt.Errorf("response too large (%d bytes)", size)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// parseInfo extracts the processed/failed/total counters from info.
+func parseInfo(info string) *Response {
+	resp := &Response{Info: info}
+	if m := infoPattern.FindStringSubmatch(info); m != nil {
+		resp.Processed, _ = strconv.Atoi(m[1])
+		resp.Failed, _ = strconv.Atoi(m[2])
+		resp.Total, _ = strconv.Atoi(m[3])
+	}
+	return resp
+}
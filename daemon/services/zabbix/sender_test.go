@@ -0,0 +1,82 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+)
+
+// fakeServer accepts one sender request, records it and replies with info.
+func fakeServer(t *testing.T, info string) (string, <-chan senderRequest) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	requests := make(chan senderRequest, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		body, err := decodePacket(conn)
+		if err != nil {
+			return
+		}
+		var req senderRequest
+		_ = json.Unmarshal(body, &req)
+		requests <- req
+		resp, _ := json.Marshal(senderResponse{Response: "success", Info: info})
+		_, _ = conn.Write(encodePacket(resp))
+	}()
+	return ln.Addr().String(), requests
+}
+
+func TestSenderSend(t *testing.T) {
+	addr, requests := fakeServer(t, "processed: 1; failed: 1; total: 2; seconds spent: 0.000055")
+	s, err := NewSender(addr)
+	if err != nil {
+		t.Fatalf("NewSender: %v", err)
+	}
+
+	items := []Item{{Host: "tower", Key: "unraid.cpu.usage", Value: "12.5"}, {Host: "tower", Key: "missing", Value: "1"}}
+	resp, err := s.Send(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.Processed != 1 || resp.Failed != 1 || resp.Total != 2 {
+		t.Errorf("response = %+v", resp)
+	}
+
+	req := <-requests
+	if req.Request != "sender data" || len(req.Data) != 2 || req.Data[0].Key != "unraid.cpu.usage" {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestNewSenderAddress(t *testing.T) {
+	tests := []struct {
+		addr    string
+		want    string
+		wantErr bool
+	}{
+		{addr: "zabbix", want: "zabbix:10051"},
+		{addr: "10.0.0.5:10052", want: "10.0.0.5:10052"},
+		{addr: "", wantErr: true},
+		{addr: "zabbix:0", wantErr: true},
+	}
+	for _, tt := range tests {
+		s, err := NewSender(tt.addr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewSender(%q) err = %v, wantErr %v", tt.addr, err, tt.wantErr)
+			continue
+		}
+		if err == nil && s.Address() != tt.want {
+			t.Errorf("NewSender(%q) address = %q, want %q", tt.addr, s.Address(), tt.want)
+		}
+	}
+}
@@ -15,10 +15,13 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/cmd"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/constants"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/domain"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/i18n"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/lib"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/logger"
 	"github.com/ruaan-deysel/unraid-management-agent/daemon/platform"
+	"github.com/ruaan-deysel/unraid-management-agent/daemon/services/acme"
 )
 
 // Version is the application version, set at build time via ldflags.
@@ -48,6 +51,16 @@ var validCollectorNames = map[string]bool{
 	"plugin_update":   true,
 	"os_update":       true,
 	"mover":           true,
+	"dns":             true,
+	"wan":             true,
+	"speedtest":       true,
+	"pools":           true,
+	"btrfs":           true,
+	"recycle_bin":     true,
+	"ipmi":            true,
+	"connect":         true,
+	"guest_traffic":   true,
+	"remote_mounts":   true,
 }
 
 var cli struct {
@@ -56,10 +69,26 @@ var cli struct {
 	BindAddress string `default:"" env:"BIND_ADDRESS" help:"IP address to bind the HTTP server to (empty = all interfaces)"`
 	Debug       bool   `default:"false" help:"enable debug mode with stdout logging"`
 	LogLevel    string `default:"info" help:"log level: debug, info, warning, error"`
+	LogFormat   string `default:"text" env:"LOG_FORMAT" help:"log format: text, or json for one structured entry per line with request correlation IDs"`
+	Pprof       bool   `default:"false" env:"PPROF_ENABLED" help:"serve Go runtime profiles at /debug/pprof for diagnosing performance issues"`
+	Mock        bool   `default:"false" env:"MOCK_MODE" help:"serve synthetic data and simulate control actions without touching the host, for dashboard and integration development"`
+	Language    string `default:"en" env:"UNRAID_LANGUAGE" help:"language of notifications, Home Assistant entity names and health report text: en, de, fr, es"`
+
+	ConfirmDestructive bool `default:"false" env:"CONFIRM_DESTRUCTIVE" help:"require a one-time confirmation token (valid 60s) for REST array stop, reboot, shutdown and format"`
+
+	// WebSocket slow consumers
+	WSQueueSize  int    `default:"256" env:"WS_QUEUE_SIZE" help:"events queued per WebSocket client before the slow-client policy applies (16-4096)"`
+	WSSlowClient string `default:"disconnect" env:"WS_SLOW_CLIENT" help:"what to do when a WebSocket client's queue is full: disconnect, or drop-oldest to discard its oldest queued events"`
+	EventReplay  int    `default:"1" env:"EVENT_REPLAY_SIZE" help:"events kept per topic and replayed to WebSocket clients when they connect or subscribe (0=off, max 32)"`
 
 	// Read-only mode - blocks all state-changing MCP tools (REST API unaffected)
 	ReadOnly bool `default:"false" env:"READ_ONLY" help:"block all state-changing MCP tools so AI agents can only consume data"`
 
+	// MCP access control
+	MCPAPIKey     string `default:"" env:"MCP_API_KEY" help:"API key required on the HTTP /mcp endpoint (Authorization: Bearer or X-API-Key); empty disables the check"`
+	MCPHTTPTools  string `default:"auto" env:"MCP_HTTP_TOOLS" help:"MCP tools exposed over HTTP: auto (all with an API key, read-only without), all, read-only, or a comma-separated list of tool names"`
+	MCPStdioTools string `default:"all" env:"MCP_STDIO_TOOLS" help:"MCP tools exposed over STDIO: all, read-only, or a comma-separated list of tool names"`
+
 	// CORS
 	CORSOrigin string `default:"*" env:"CORS_ORIGIN" help:"Access-Control-Allow-Origin value (default: *)"`
 
@@ -67,6 +96,21 @@ var cli struct {
 	TLSCertFile string `default:"" env:"TLS_CERT_FILE" help:"path to a PEM TLS certificate file (enables HTTPS when set with --tls-key-file)"`
 	TLSKeyFile  string `default:"" env:"TLS_KEY_FILE" help:"path to a PEM TLS private key file (enables HTTPS when set with --tls-cert-file)"`
 
+	// ACME: obtain and renew the HTTPS certificate with DNS-01 challenges
+	ACMEEnabled     bool   `default:"false" env:"ACME_ENABLED" help:"obtain and renew the HTTPS certificate from Let's Encrypt (takes precedence over --tls-cert-file)"`
+	ACMEDomains     string `default:"" env:"ACME_DOMAINS" help:"comma-separated certificate domain names; *.example.com requests a wildcard"`
+	ACMEEmail       string `default:"" env:"ACME_EMAIL" help:"contact email for the ACME account (optional)"`
+	ACMEDNSProvider string `default:"cloudflare" env:"ACME_DNS_PROVIDER" help:"DNS-01 provider: cloudflare, digitalocean, duckdns, or exec"`
+	ACMEDNSToken    string `default:"" env:"ACME_DNS_TOKEN" help:"API token for the DNS provider"`
+	ACMEDNSHook     string `default:"" env:"ACME_DNS_HOOK" help:"script run as '<hook> present|cleanup <fqdn> <value>' by the exec provider"`
+	ACMEDirectory   string `default:"" env:"ACME_DIRECTORY" help:"ACME directory URL (default: Let's Encrypt production)"`
+	ACMERenewDays   int    `default:"30" env:"ACME_RENEW_DAYS" help:"renew the certificate this many days before it expires (1-60)"`
+
+	// Login sessions: /api/v1/auth/login issues JWTs checked against the root password
+	AuthRequired   bool `default:"false" env:"AUTH_REQUIRED" help:"require a login access token or the API key (MCP_API_KEY) on the REST API"`
+	AuthAccessTTL  int  `default:"15" env:"AUTH_ACCESS_TTL" help:"access token lifetime in minutes (1-1440)"`
+	AuthRefreshTTL int  `default:"168" env:"AUTH_REFRESH_TTL" help:"refresh token (session) lifetime in hours (1-2160)"`
+
 	// Low power mode - multiplies all intervals for resource-constrained systems
 	LowPowerMode bool `default:"false" env:"UNRAID_LOW_POWER" help:"enable low power mode (4x longer intervals for old/slow hardware)"`
 
@@ -87,11 +131,67 @@ var cli struct {
 	MQTTRetain             bool   `default:"true" env:"MQTT_RETAIN" help:"retain MQTT messages"`
 	MQTTHomeAssistant      bool   `default:"false" env:"MQTT_HOME_ASSISTANT" help:"enable Home Assistant MQTT discovery"`
 	MQTTHAPrefix           string `default:"homeassistant" env:"MQTT_HA_PREFIX" help:"Home Assistant discovery prefix"`
+	MQTTTopicTemplate      string `default:"" env:"MQTT_TOPIC_TEMPLATE" help:"MQTT topic layout from {prefix}, {host} and {topic}; must end with {topic} (empty = {prefix}/{topic})"`
+	MQTTEntityNameTemplate string `default:"" env:"MQTT_ENTITY_NAME_TEMPLATE" help:"Home Assistant entity name template from {host}, {category}, {id} and {name} (empty = {name})"`
+	MQTTUniqueIDTemplate   string `default:"" env:"MQTT_UNIQUE_ID_TEMPLATE" help:"Home Assistant unique_id template from {host}, {category} and {id} (empty = unraid_{host}_{id})"`
+	MQTTHADisabled         string `default:"" env:"MQTT_HA_DISABLED_CATEGORIES" help:"comma-separated topic categories left out of Home Assistant discovery (e.g., docker,vm,triggers)"`
+
+	// InfluxDB Configuration
+	InfluxDBEnabled            bool   `default:"false" env:"INFLUXDB_ENABLED" help:"enable pushing metrics to InfluxDB"`
+	InfluxDBURL                string `default:"" env:"INFLUXDB_URL" help:"InfluxDB base URL, e.g. http://192.168.1.10:8086"`
+	InfluxDBVersion            int    `default:"2" env:"INFLUXDB_VERSION" help:"InfluxDB API version (1 or 2)"`
+	InfluxDBOrg                string `default:"" env:"INFLUXDB_ORG" help:"InfluxDB 2.x organization"`
+	InfluxDBBucket             string `default:"" env:"INFLUXDB_BUCKET" help:"InfluxDB 2.x bucket"`
+	InfluxDBToken              string `default:"" env:"INFLUXDB_TOKEN" help:"InfluxDB 2.x API token"`
+	InfluxDBDatabase           string `default:"" env:"INFLUXDB_DATABASE" help:"InfluxDB 1.x database"`
+	InfluxDBRetentionPolicy    string `default:"" env:"INFLUXDB_RETENTION_POLICY" help:"InfluxDB 1.x retention policy (empty=default)"`
+	InfluxDBUsername           string `default:"" env:"INFLUXDB_USERNAME" help:"InfluxDB 1.x username"`
+	InfluxDBPassword           string `default:"" env:"INFLUXDB_PASSWORD" help:"InfluxDB 1.x password"`
+	InfluxDBInterval           int    `default:"30" env:"INFLUXDB_INTERVAL" help:"InfluxDB batch push interval (seconds, 5-86400)"`
+	InfluxDBInsecureSkipVerify bool   `default:"false" env:"INFLUXDB_INSECURE_SKIP_VERIFY" help:"skip TLS certificate verification for InfluxDB"`
+
+	// Graphite / StatsD Configuration
+	GraphiteEnabled  bool   `default:"false" env:"GRAPHITE_ENABLED" help:"enable pushing metrics to Graphite or StatsD"`
+	GraphiteProtocol string `default:"graphite" env:"GRAPHITE_PROTOCOL" help:"export protocol: graphite (plaintext TCP) or statsd (UDP)"`
+	GraphiteAddress  string `default:"" env:"GRAPHITE_ADDRESS" help:"Graphite/StatsD host[:port] (default port 2003 or 8125)"`
+	GraphitePrefix   string `default:"unraid" env:"GRAPHITE_PREFIX" help:"metric path prefix"`
+	GraphiteInterval int    `default:"60" env:"GRAPHITE_INTERVAL" help:"Graphite/StatsD push interval (seconds, 5-86400)"`
+
+	// Zabbix Configuration
+	ZabbixEnabled bool   `default:"false" env:"ZABBIX_ENABLED" help:"enable pushing collector data to Zabbix (zabbix_sender protocol)"`
+	ZabbixServer  string `default:"" env:"ZABBIX_SERVER" help:"Zabbix server or proxy host[:port] (default port 10051)"`
+	ZabbixHost    string `default:"" env:"ZABBIX_HOST" help:"host name configured in Zabbix (default: system hostname)"`
+
+	// Heartbeat Configuration
+	HeartbeatEnabled         bool   `default:"false" env:"HEARTBEAT_ENABLED" help:"enable dead-man's-switch pings to a healthchecks.io or Uptime Kuma push URL"`
+	HeartbeatURL             string `default:"" env:"HEARTBEAT_URL" help:"healthchecks.io ping URL or Uptime Kuma push URL"`
+	HeartbeatProvider        string `default:"auto" env:"HEARTBEAT_PROVIDER" help:"push URL type: auto, healthchecks, or uptime_kuma"`
+	HeartbeatInterval        int    `default:"60" env:"HEARTBEAT_INTERVAL" help:"heartbeat ping interval (seconds, 10-86400)"`
+	HeartbeatCriticalSources string `default:"array,disk,system" env:"HEARTBEAT_CRITICAL_SOURCES" help:"comma-separated data sources whose failure sends a failure ping"`
+
+	// Unhealthy container restart
+	UnhealthyRestartEnabled bool   `default:"false" env:"UNHEALTHY_RESTART_ENABLED" help:"restart containers whose Docker healthcheck stays unhealthy"`
+	UnhealthyRestartAfter   int    `default:"5" env:"UNHEALTHY_RESTART_AFTER" help:"minutes a container must be unhealthy before it is restarted (1-1440)"`
+	UnhealthyRestartExclude string `default:"" env:"UNHEALTHY_RESTART_EXCLUDE" help:"comma-separated container names never restarted automatically"`
 
 	// Discovery (zeroconf/mDNS) Configuration
 	DiscoveryEnabled     bool   `default:"true" env:"DISCOVERY_ENABLED" help:"advertise the agent on the local network via mDNS for auto-discovery"`
 	DiscoveryServiceName string `default:"" env:"DISCOVERY_SERVICE_NAME" help:"override the advertised mDNS instance name (default: system hostname)"`
 
+	// WAN monitoring
+	WANProbes string `default:"1.1.1.1,8.8.8.8" env:"WAN_PROBES" help:"comma-separated hosts pinged for WAN latency and packet loss"`
+
+	// On-demand network diagnostics
+	DiagnosticsTargets string `default:"1.1.1.1,8.8.8.8,one.one.one.one,dns.google,github.com,*.github.com,*.docker.io,*.docker.com,*.unraid.net" env:"DIAGNOSTICS_TARGETS" help:"comma-separated allow list for ping/DNS/HTTP diagnostics: hostnames, *.domain wildcards, IPs, CIDRs, or * for any"`
+	WOLDevices         string `env:"WOL_DEVICES" help:"comma-separated Wake-on-LAN targets as name=MAC[@broadcast], offered by name over REST/MCP and as Home Assistant buttons"`
+
+	// GPU monitoring
+	GPUBackends string `default:"auto" env:"GPU_BACKENDS" help:"GPU vendors probed by the gpu collector: auto, none, or a comma-separated list of intel, nvidia, amd"`
+
+	// Scheduled bandwidth tests
+	SpeedtestTool   string `default:"speedtest-cli" env:"SPEEDTEST_TOOL" help:"bandwidth test program: speedtest-cli or iperf3"`
+	SpeedtestServer string `default:"" env:"SPEEDTEST_SERVER" help:"speedtest-cli server ID (empty=auto) or iperf3 server host (required for iperf3)"`
+
 	// Collection intervals (overridable via environment variables)
 	// Use 0 to disable a collector completely
 	// Maximum interval: 86400 seconds (24 hours)
@@ -117,6 +217,16 @@ var cli struct {
 	IntervalPluginUpdate   int  `default:"3600" env:"INTERVAL_PLUGIN_UPDATE" help:"plugin update check interval (seconds, 0=disabled, max 86400)"`
 	IntervalOSUpdate       int  `default:"86400" env:"INTERVAL_OS_UPDATE" help:"OS update availability check interval (seconds, 0=disabled, max 86400)"`
 	IntervalMover          int  `default:"30" env:"INTERVAL_MOVER" help:"mover status collection interval (seconds, 0=disabled, max 86400)"`
+	IntervalDNS            int  `default:"300" env:"INTERVAL_DNS" help:"DNS health check interval (seconds, 0=disabled, max 86400)"`
+	IntervalWAN            int  `default:"0" env:"INTERVAL_WAN" help:"WAN connectivity check interval (seconds, 0=disabled, max 86400); sends pings and public IP lookups to the internet"`
+	IntervalSpeedtest      int  `default:"0" env:"INTERVAL_SPEEDTEST" help:"scheduled bandwidth test interval (seconds, 0=disabled, max 86400); each test saturates the uplink"`
+	IntervalPools          int  `default:"60" env:"INTERVAL_POOLS" help:"pool layout, allocation and balance/scrub status interval (seconds, 0=disabled, max 86400)"`
+	IntervalBtrfs          int  `default:"300" env:"INTERVAL_BTRFS" help:"btrfs device error and scrub statistics interval (seconds, 0=disabled, max 86400)"`
+	IntervalRecycleBin     int  `default:"3600" env:"INTERVAL_RECYCLE_BIN" help:"per-share recycle bin size and age interval (seconds, 0=disabled, max 86400); only active with the Recycle Bin plugin"`
+	IntervalIPMI           int  `default:"60" env:"INTERVAL_IPMI" help:"IPMI (BMC) sensor and chassis status interval (seconds, 0=disabled, max 86400); only active when ipmitool can reach a BMC"`
+	IntervalConnect        int  `default:"60" env:"INTERVAL_CONNECT" help:"Unraid Connect cloud, flash backup and remote access status interval (seconds, 0=disabled, max 86400)"`
+	IntervalGuestTraffic   int  `default:"30" env:"INTERVAL_GUEST_TRAFFIC" help:"Per-VM and per-container traffic attribution from host vnet/veth interfaces interval (seconds, 0=disabled, max 86400)"`
+	IntervalRemoteMounts   int  `default:"60" env:"INTERVAL_REMOTE_MOUNTS" help:"NFS/SMB client mount staleness probe interval (seconds, 0=disabled, max 86400)"`
 	DockerUpdateNotify     bool `default:"false" env:"DOCKER_UPDATE_NOTIFY" help:"raise an Unraid notification when new container updates become available"`
 
 	Boot        cmd.Boot        `cmd:"" default:"1" help:"start the management agent"`
@@ -138,6 +248,18 @@ func cleanupOldLogs(logsDir, baseName string) {
 	}
 }
 
+// splitList splits a comma-separated list, trimming whitespace and dropping
+// empty entries.
+func splitList(s string) []string {
+	var out []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func main() {
 	ctx := kong.Parse(&cli)
 
@@ -151,19 +273,9 @@ func main() {
 	}
 	applyFileConfig(fileCfg)
 
-	// Set log level based on CLI flag
-	switch strings.ToLower(cli.LogLevel) {
-	case "debug":
-		logger.SetLevel(logger.LevelDebug)
-	case "info":
-		logger.SetLevel(logger.LevelInfo)
-	case "warning", "warn":
-		logger.SetLevel(logger.LevelWarning)
-	case "error":
-		logger.SetLevel(logger.LevelError)
-	default:
-		logger.SetLevel(logger.LevelInfo)
-	}
+	// Set log level based on CLI flag (unknown values fall back to info)
+	level, _ := domain.ParseLogLevel(cli.LogLevel)
+	logger.SetLevel(level)
 
 	// Set up logging
 	if isStdio {
@@ -204,7 +316,16 @@ func main() {
 		log.SetOutput(multiWriter)
 	}
 
-	log.Printf("Starting Unraid Management Agent v%s (log level: %s)", Version, cli.LogLevel)
+	// Switch to JSON after the output is set up so it also drops the debug
+	// mode file:line flags
+	format, _ := logger.ParseFormat(cli.LogFormat)
+	logger.SetFormat(format)
+
+	logger.Plain("Starting Unraid Management Agent v%s (log level: %s, log format: %s)", Version, cli.LogLevel, cli.LogFormat)
+
+	if err := i18n.SetLanguage(cli.Language); err != nil {
+		logger.Warning("%v; using English", err)
+	}
 
 	// Validate the bind address. Fall back to all interfaces rather than
 	// refusing to start, so a stale config value (e.g. after a VLAN change)
@@ -230,6 +351,58 @@ func main() {
 		logger.Info("HTTPS enabled (certificate: %s)", cli.TLSCertFile)
 	}
 
+	// Validate ACME configuration. Like a bad cert/key pair, invalid ACME
+	// settings disable ACME rather than refusing to start.
+	acmeConfig := domain.ACMEConfig{
+		Enabled:      cli.ACMEEnabled,
+		Domains:      splitList(cli.ACMEDomains),
+		Email:        cli.ACMEEmail,
+		DNSProvider:  cli.ACMEDNSProvider,
+		DNSToken:     cli.ACMEDNSToken,
+		DNSHook:      cli.ACMEDNSHook,
+		DirectoryURL: cli.ACMEDirectory,
+		RenewDays:    cli.ACMERenewDays,
+	}
+	if acmeConfig.Enabled {
+		if err := acme.ValidateConfig(acmeConfig); err != nil {
+			logger.Error("ACME is enabled but misconfigured (%v); ACME disabled", err)
+			acmeConfig.Enabled = false
+		} else {
+			if cli.TLSCertFile != "" {
+				logger.Warning("ACME is enabled; ignoring --tls-cert-file and --tls-key-file")
+			}
+			logger.Info("HTTPS enabled with ACME certificates for %s", strings.Join(acmeConfig.Domains, ", "))
+		}
+	}
+
+	if cli.WSQueueSize < 16 || cli.WSQueueSize > 4096 {
+		logger.Warning("WS_QUEUE_SIZE %d out of range (16-4096); using %d", cli.WSQueueSize, constants.WSBufferSize)
+		cli.WSQueueSize = constants.WSBufferSize
+	}
+	if cli.WSSlowClient != constants.WSSlowClientDisconnect && cli.WSSlowClient != constants.WSSlowClientDropOldest {
+		logger.Warning("WS_SLOW_CLIENT %q is not disconnect or drop-oldest; using disconnect", cli.WSSlowClient)
+		cli.WSSlowClient = constants.WSSlowClientDisconnect
+	}
+	if cli.EventReplay < 0 || cli.EventReplay > 32 {
+		logger.Warning("EVENT_REPLAY_SIZE %d out of range (0-32); using 1", cli.EventReplay)
+		cli.EventReplay = 1
+	}
+	if cli.AuthAccessTTL < 1 || cli.AuthAccessTTL > 1440 {
+		logger.Warning("AUTH_ACCESS_TTL %d out of range (1-1440); using 15", cli.AuthAccessTTL)
+		cli.AuthAccessTTL = 15
+	}
+	if cli.AuthRefreshTTL < 1 || cli.AuthRefreshTTL > 2160 {
+		logger.Warning("AUTH_REFRESH_TTL %d out of range (1-2160); using 168", cli.AuthRefreshTTL)
+		cli.AuthRefreshTTL = 168
+	}
+	if cli.AuthRequired && !acmeConfig.Enabled && (cli.TLSCertFile == "" || cli.TLSKeyFile == "") {
+		logger.Warning("AUTH_REQUIRED is set without HTTPS; passwords and tokens are sent in clear text")
+	}
+
+	if cli.Mock {
+		logger.Warning("Mock mode enabled: serving synthetic data; control actions are simulated")
+	}
+
 	if cli.ReadOnly {
 		logger.Info("Read-only mode enabled: all state-changing MCP tools are blocked")
 	}
@@ -243,15 +416,15 @@ func main() {
 				continue
 			}
 			if name == "system" {
-				log.Printf("WARNING: Cannot disable system collector (always required), ignoring")
+				logger.Warning("Cannot disable system collector (always required), ignoring")
 				continue
 			}
 			if !validCollectorNames[name] {
-				log.Printf("WARNING: Unknown collector name '%s' in disable list, ignoring", name)
+				logger.Warning("Unknown collector name '%s' in disable list, ignoring", name)
 				continue
 			}
 			disabledCollectors[name] = true
-			log.Printf("Collector '%s' disabled via UNRAID_DISABLE_COLLECTORS", name)
+			logger.Plain("Collector '%s' disabled via UNRAID_DISABLE_COLLECTORS", name)
 		}
 	}
 
@@ -268,7 +441,7 @@ func main() {
 	}
 
 	if cli.LowPowerMode {
-		log.Printf("Low power mode enabled - all intervals multiplied by 4x")
+		logger.Plain("Low power mode enabled - all intervals multiplied by 4x")
 	}
 
 	// Create diagnostic logger for structured JSON logging
@@ -278,15 +451,35 @@ func main() {
 	)
 
 	// Create application context with intervals from CLI/env
+	wolDevices, wolErrs := lib.ParseWOLDevices(splitList(cli.WOLDevices))
+	for _, err := range wolErrs {
+		logger.Warning("%v; ignoring", err)
+	}
+
 	appCtx := &domain.Context{
 		Config: domain.Config{
-			Version:     Version,
-			Port:        cli.Port,
-			BindAddress: cli.BindAddress,
-			CORSOrigin:  cli.CORSOrigin,
-			ReadOnly:    cli.ReadOnly,
-			TLSCertFile: cli.TLSCertFile,
-			TLSKeyFile:  cli.TLSKeyFile,
+			Version:       Version,
+			Port:          cli.Port,
+			BindAddress:   cli.BindAddress,
+			CORSOrigin:    cli.CORSOrigin,
+			ReadOnly:      cli.ReadOnly,
+			MCPAPIKey:     cli.MCPAPIKey,
+			MCPHTTPTools:  cli.MCPHTTPTools,
+			MCPStdioTools: cli.MCPStdioTools,
+			TLSCertFile:   cli.TLSCertFile,
+			TLSKeyFile:    cli.TLSKeyFile,
+			ACME:          acmeConfig,
+			LowPowerMode:  cli.LowPowerMode,
+			Pprof:         cli.Pprof,
+			Mock:          cli.Mock,
+			Auth: domain.AuthConfig{
+				Required:   cli.AuthRequired,
+				AccessTTL:  cli.AuthAccessTTL,
+				RefreshTTL: cli.AuthRefreshTTL,
+			},
+			ConfirmDestructive: cli.ConfirmDestructive,
+			WSQueueSize:        cli.WSQueueSize,
+			WSSlowClientPolicy: cli.WSSlowClient,
 		},
 		Hub:      domain.NewEventBus(1024), // Buffer size for event bus
 		Platform: platform.NewRegistry(),
@@ -305,6 +498,49 @@ func main() {
 			HomeAssistantMode:   cli.MQTTHomeAssistant,
 			HomeAssistantPrefix: cli.MQTTHAPrefix,
 			DiscoveryEnabled:    cli.MQTTHomeAssistant, // Enable discovery when HA mode is enabled
+
+			TopicTemplate:        cli.MQTTTopicTemplate,
+			EntityNameTemplate:   cli.MQTTEntityNameTemplate,
+			UniqueIDTemplate:     cli.MQTTUniqueIDTemplate,
+			HADisabledCategories: splitList(cli.MQTTHADisabled),
+		},
+		InfluxDBConfig: domain.InfluxDBConfig{
+			Enabled:            cli.InfluxDBEnabled,
+			URL:                cli.InfluxDBURL,
+			Version:            cli.InfluxDBVersion,
+			Org:                cli.InfluxDBOrg,
+			Bucket:             cli.InfluxDBBucket,
+			Token:              cli.InfluxDBToken,
+			Database:           cli.InfluxDBDatabase,
+			RetentionPolicy:    cli.InfluxDBRetentionPolicy,
+			Username:           cli.InfluxDBUsername,
+			Password:           cli.InfluxDBPassword,
+			Interval:           cli.InfluxDBInterval,
+			InsecureSkipVerify: cli.InfluxDBInsecureSkipVerify,
+		},
+		GraphiteConfig: domain.GraphiteConfig{
+			Enabled:  cli.GraphiteEnabled,
+			Protocol: cli.GraphiteProtocol,
+			Address:  cli.GraphiteAddress,
+			Prefix:   cli.GraphitePrefix,
+			Interval: cli.GraphiteInterval,
+		},
+		ZabbixConfig: domain.ZabbixConfig{
+			Enabled: cli.ZabbixEnabled,
+			Server:  cli.ZabbixServer,
+			Host:    cli.ZabbixHost,
+		},
+		HeartbeatConfig: domain.HeartbeatConfig{
+			Enabled:         cli.HeartbeatEnabled,
+			URL:             cli.HeartbeatURL,
+			Provider:        cli.HeartbeatProvider,
+			Interval:        cli.HeartbeatInterval,
+			CriticalSources: splitList(cli.HeartbeatCriticalSources),
+		},
+		UnhealthyRestart: domain.UnhealthyRestartConfig{
+			Enabled:      cli.UnhealthyRestartEnabled,
+			AfterMinutes: cli.UnhealthyRestartAfter,
+			Exclude:      splitList(cli.UnhealthyRestartExclude),
 		},
 		DiscoveryConfig: domain.DiscoveryConfig{
 			Enabled:     cli.DiscoveryEnabled,
@@ -313,6 +549,12 @@ func main() {
 		DiagnosticLogger:   diagLogger,
 		LogsDir:            cli.LogsDir,
 		DockerUpdateNotify: cli.DockerUpdateNotify,
+		WANProbes:          splitList(cli.WANProbes),
+		SpeedtestTool:      cli.SpeedtestTool,
+		SpeedtestServer:    cli.SpeedtestServer,
+		GPUBackends:        splitList(cli.GPUBackends),
+		DiagnosticsTargets: splitList(cli.DiagnosticsTargets),
+		WOLDevices:         wolDevices,
 		Intervals: domain.Intervals{
 			System:         getInterval("system", cli.IntervalSystem),
 			Array:          getInterval("array", cli.IntervalArray),
@@ -336,14 +578,30 @@ func main() {
 			PluginUpdate:   getInterval("plugin_update", cli.IntervalPluginUpdate),
 			OSUpdate:       getInterval("os_update", cli.IntervalOSUpdate),
 			Mover:          getInterval("mover", cli.IntervalMover),
+			DNS:            getInterval("dns", cli.IntervalDNS),
+			WAN:            getInterval("wan", cli.IntervalWAN),
+			Speedtest:      getInterval("speedtest", cli.IntervalSpeedtest),
+			Pools:          getInterval("pools", cli.IntervalPools),
+			Btrfs:          getInterval("btrfs", cli.IntervalBtrfs),
+			RecycleBin:     getInterval("recycle_bin", cli.IntervalRecycleBin),
+			IPMI:           getInterval("ipmi", cli.IntervalIPMI),
+			Connect:        getInterval("connect", cli.IntervalConnect),
+			GuestTraffic:   getInterval("guest_traffic", cli.IntervalGuestTraffic),
+			RemoteMounts:   getInterval("remote_mounts", cli.IntervalRemoteMounts),
 		},
 	}
 
+	appCtx.Hub.SetReplaySize(cli.EventReplay)
+
 	// Warn about insecure MQTT TLS configuration
 	if appCtx.MQTTConfig.InsecureSkipVerify && appCtx.MQTTConfig.Enabled {
 		logger.Warning("MQTT TLS certificate verification is disabled (InsecureSkipVerify). This is insecure and should only be used for testing.")
 	}
 
+	if appCtx.InfluxDBConfig.InsecureSkipVerify && appCtx.InfluxDBConfig.Enabled {
+		logger.Warning("InfluxDB TLS certificate verification is disabled (InsecureSkipVerify). This is insecure and should only be used for testing.")
+	}
+
 	// Run the boot command
 	err = ctx.Run(appCtx)
 	ctx.FatalIfErrorf(err)
@@ -380,14 +638,47 @@ func applyFileConfig(cfg *domain.FileConfig) {
 	setInt(&cli.Port, cfg.Port)
 	setStr(&cli.BindAddress, cfg.BindAddress)
 	setStr(&cli.LogLevel, cfg.LogLevel)
+	setStr(&cli.LogFormat, cfg.LogFormat)
+	setStr(&cli.Language, cfg.Language)
 	setStr(&cli.LogsDir, cfg.LogsDir)
 	setBool(&cli.Debug, cfg.Debug)
 	setBool(&cli.ReadOnly, cfg.ReadOnly)
+	setBool(&cli.Pprof, cfg.Pprof)
+	setBool(&cli.ConfirmDestructive, cfg.ConfirmDestructive)
+	setInt(&cli.WSQueueSize, cfg.WSQueueSize)
+	setStr(&cli.WSSlowClient, cfg.WSSlowClient)
+	setInt(&cli.EventReplay, cfg.EventReplaySize)
+	if mc := cfg.MCP; mc != nil {
+		setStr(&cli.MCPAPIKey, mc.APIKey)
+		setStr(&cli.MCPHTTPTools, mc.HTTPTools)
+		setStr(&cli.MCPStdioTools, mc.StdioTools)
+	}
 	setBool(&cli.LowPowerMode, cfg.LowPowerMode)
 	setStr(&cli.DisableCollectors, cfg.DisableCollectors)
 	setStr(&cli.CORSOrigin, cfg.CORSOrigin)
 	setStr(&cli.TLSCertFile, cfg.TLSCertFile)
 	setStr(&cli.TLSKeyFile, cfg.TLSKeyFile)
+	if a := cfg.ACME; a != nil {
+		setBool(&cli.ACMEEnabled, a.Enabled)
+		setStr(&cli.ACMEDomains, a.Domains)
+		setStr(&cli.ACMEEmail, a.Email)
+		setStr(&cli.ACMEDNSProvider, a.DNSProvider)
+		setStr(&cli.ACMEDNSToken, a.DNSToken)
+		setStr(&cli.ACMEDNSHook, a.DNSHook)
+		setStr(&cli.ACMEDirectory, a.Directory)
+		setInt(&cli.ACMERenewDays, a.RenewDays)
+	}
+	if a := cfg.Auth; a != nil {
+		setBool(&cli.AuthRequired, a.Required)
+		setInt(&cli.AuthAccessTTL, a.AccessTTL)
+		setInt(&cli.AuthRefreshTTL, a.RefreshTTL)
+	}
+	setStr(&cli.WANProbes, cfg.WANProbes)
+	setStr(&cli.GPUBackends, cfg.GPUBackends)
+	setStr(&cli.SpeedtestTool, cfg.SpeedtestTool)
+	setStr(&cli.SpeedtestServer, cfg.SpeedtestServer)
+	setStr(&cli.DiagnosticsTargets, cfg.DiagnosticsTargets)
+	setStr(&cli.WOLDevices, cfg.WOLDevices)
 
 	// MQTT
 	if m := cfg.MQTT; m != nil {
@@ -404,6 +695,58 @@ func applyFileConfig(cfg *domain.FileConfig) {
 		setBool(&cli.MQTTRetain, m.Retain)
 		setBool(&cli.MQTTHomeAssistant, m.HomeAssistant)
 		setStr(&cli.MQTTHAPrefix, m.HAPrefix)
+		setStr(&cli.MQTTTopicTemplate, m.TopicTemplate)
+		setStr(&cli.MQTTEntityNameTemplate, m.EntityNameTemplate)
+		setStr(&cli.MQTTUniqueIDTemplate, m.UniqueIDTemplate)
+		setStr(&cli.MQTTHADisabled, m.HADisabledCategories)
+	}
+
+	// InfluxDB
+	if i := cfg.InfluxDB; i != nil {
+		setBool(&cli.InfluxDBEnabled, i.Enabled)
+		setStr(&cli.InfluxDBURL, i.URL)
+		setInt(&cli.InfluxDBVersion, i.Version)
+		setStr(&cli.InfluxDBOrg, i.Org)
+		setStr(&cli.InfluxDBBucket, i.Bucket)
+		setStr(&cli.InfluxDBToken, i.Token)
+		setStr(&cli.InfluxDBDatabase, i.Database)
+		setStr(&cli.InfluxDBRetentionPolicy, i.RetentionPolicy)
+		setStr(&cli.InfluxDBUsername, i.Username)
+		setStr(&cli.InfluxDBPassword, i.Password)
+		setInt(&cli.InfluxDBInterval, i.Interval)
+		setBool(&cli.InfluxDBInsecureSkipVerify, i.InsecureSkipVerify)
+	}
+
+	// Graphite / StatsD
+	if g := cfg.Graphite; g != nil {
+		setBool(&cli.GraphiteEnabled, g.Enabled)
+		setStr(&cli.GraphiteProtocol, g.Protocol)
+		setStr(&cli.GraphiteAddress, g.Address)
+		setStr(&cli.GraphitePrefix, g.Prefix)
+		setInt(&cli.GraphiteInterval, g.Interval)
+	}
+
+	// Zabbix
+	if z := cfg.Zabbix; z != nil {
+		setBool(&cli.ZabbixEnabled, z.Enabled)
+		setStr(&cli.ZabbixServer, z.Server)
+		setStr(&cli.ZabbixHost, z.Host)
+	}
+
+	// Heartbeat
+	if h := cfg.Heartbeat; h != nil {
+		setBool(&cli.HeartbeatEnabled, h.Enabled)
+		setStr(&cli.HeartbeatURL, h.URL)
+		setStr(&cli.HeartbeatProvider, h.Provider)
+		setInt(&cli.HeartbeatInterval, h.Interval)
+		setStr(&cli.HeartbeatCriticalSources, h.CriticalSources)
+	}
+
+	// Unhealthy container restart
+	if u := cfg.UnhealthyRestart; u != nil {
+		setBool(&cli.UnhealthyRestartEnabled, u.Enabled)
+		setInt(&cli.UnhealthyRestartAfter, u.AfterMinutes)
+		setStr(&cli.UnhealthyRestartExclude, u.Exclude)
 	}
 
 	// Discovery (zeroconf/mDNS)
@@ -436,5 +779,15 @@ func applyFileConfig(cfg *domain.FileConfig) {
 		setInt(&cli.IntervalPluginUpdate, iv.PluginUpdate)
 		setInt(&cli.IntervalOSUpdate, iv.OSUpdate)
 		setInt(&cli.IntervalMover, iv.Mover)
+		setInt(&cli.IntervalDNS, iv.DNS)
+		setInt(&cli.IntervalWAN, iv.WAN)
+		setInt(&cli.IntervalSpeedtest, iv.Speedtest)
+		setInt(&cli.IntervalPools, iv.Pools)
+		setInt(&cli.IntervalBtrfs, iv.Btrfs)
+		setInt(&cli.IntervalRecycleBin, iv.RecycleBin)
+		setInt(&cli.IntervalIPMI, iv.IPMI)
+		setInt(&cli.IntervalConnect, iv.Connect)
+		setInt(&cli.IntervalGuestTraffic, iv.GuestTraffic)
+		setInt(&cli.IntervalRemoteMounts, iv.RemoteMounts)
 	}
 }